@@ -35,6 +35,9 @@ type LRUCache struct {
 
 	// How much we are limiting the cache to.
 	capacity int64
+
+	// evictions counts the items deleted to obey the capacity.
+	evictions int64
 }
 
 // Value is the interface values that go into LRUCache need to satisfy
@@ -194,6 +197,14 @@ func (lru *LRUCache) Capacity() int64 {
 	return lru.capacity
 }
 
+// Evictions returns how many items were evicted to obey the
+// capacity. The items removed by Delete or Clear are not counted.
+func (lru *LRUCache) Evictions() int64 {
+	lru.mu.Lock()
+	defer lru.mu.Unlock()
+	return lru.evictions
+}
+
 // Oldest returns the insertion time of the oldest element in the cache,
 // or a IsZero() time if cache is empty.
 func (lru *LRUCache) Oldest() (oldest time.Time) {
@@ -263,5 +274,6 @@ func (lru *LRUCache) checkCapacity() {
 		lru.list.Remove(delElem)
 		delete(lru.table, delValue.key)
 		lru.size -= delValue.size
+		lru.evictions++
 	}
 }
@@ -272,9 +272,18 @@ func TestLRUIsEvicted(t *testing.T) {
 	if _, ok := cache.Get("key3"); ok {
 		t.Error("Least recently used element was not evicted.")
 	}
+	if e := cache.Evictions(); e != 1 {
+		t.Errorf("cache.Evictions() = %v, expected 1", e)
+	}
 
 	// Check oldest
 	if o := cache.Oldest(); o.Before(beforeKey2) || o.After(afterKey2) {
 		t.Errorf("cache.Oldest returned an unexpected value: got %v, expected a value between %v and %v", o, beforeKey2, afterKey2)
 	}
+
+	cache.Delete("key0")
+	cache.Clear()
+	if e := cache.Evictions(); e != 1 {
+		t.Errorf("cache.Evictions() after Delete and Clear = %v, expected 1", e)
+	}
 }
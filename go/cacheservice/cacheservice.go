@@ -57,6 +57,10 @@ type CacheService interface {
 	Prepend(key string, flags uint16, timeout uint64, value []byte) (stored bool, err error)
 	// Cas stores the value only if no one else has updated the data since you read it last.
 	Cas(key string, flags uint16, timeout uint64, value []byte, cas uint64) (stored bool, err error)
+	// SetMulti sets the same value for all the keys, and returns the
+	// number of keys that were stored. The commands may be pipelined,
+	// so the callers should bound the number of keys.
+	SetMulti(keys []string, flags uint16, timeout uint64, value []byte) (stored int, err error)
 	// Delete delete the value for the specified cache key.
 	Delete(key string) (deleted bool, err error)
 	// FlushAll purges the entire cache.
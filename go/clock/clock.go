@@ -0,0 +1,116 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package clock abstracts the passing of time, so that the components
+// that wait for it can be tested with a fake clock instead of real
+// sleeps. See the fakeclock package.
+package clock
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// Clock tells the time, and waits for it.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// After returns a channel that receives the current time once d
+	// has elapsed.
+	After(d time.Duration) <-chan time.Time
+	// Sleep waits for d to elapse.
+	Sleep(d time.Duration)
+	// NewTicker returns a Ticker that ticks every d, that must be
+	// positive.
+	NewTicker(d time.Duration) Ticker
+}
+
+// Ticker is a time.Ticker of a Clock.
+type Ticker interface {
+	// Chan returns the channel that receives the ticks. Like for
+	// time.Ticker, ticks are dropped if the receiver is too slow.
+	Chan() <-chan time.Time
+	// Stop stops the ticks. It doesn't close the channel.
+	Stop()
+}
+
+// Real is the Clock of the time package.
+var Real Clock = realClock{}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func (realClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+func (realClock) NewTicker(d time.Duration) Ticker {
+	return realTicker{time.NewTicker(d)}
+}
+
+type realTicker struct {
+	*time.Ticker
+}
+
+func (t realTicker) Chan() <-chan time.Time {
+	return t.C
+}
+
+// WithTimeout is context.WithTimeout, with the timeout measured by c.
+// With another Clock than Real, the deadline of the context is the one
+// of c, and the deadline of the parent isn't enforced by c.
+func WithTimeout(parent context.Context, c Clock, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if c == Real {
+		return context.WithTimeout(parent, timeout)
+	}
+	ctx, cancel := context.WithCancel(parent)
+	tc := &timeoutCtx{
+		Context:  ctx,
+		deadline: c.Now().Add(timeout),
+	}
+	expired := c.After(timeout)
+	go func() {
+		select {
+		case <-expired:
+			tc.mu.Lock()
+			tc.expired = ctx.Err() == nil
+			tc.mu.Unlock()
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	return tc, cancel
+}
+
+// timeoutCtx is a context canceled when the timeout of WithTimeout
+// expires.
+type timeoutCtx struct {
+	context.Context
+	deadline time.Time
+
+	mu      sync.Mutex
+	expired bool
+}
+
+func (tc *timeoutCtx) Deadline() (time.Time, bool) {
+	return tc.deadline, true
+}
+
+func (tc *timeoutCtx) Err() error {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	if tc.expired {
+		return context.DeadlineExceeded
+	}
+	return tc.Context.Err()
+}
@@ -0,0 +1,61 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package clock_test
+
+import (
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/clock"
+	"github.com/youtube/vitess/go/clock/fakeclock"
+)
+
+func TestWithTimeout(t *testing.T) {
+	start := time.Unix(1000, 0)
+	fc := fakeclock.New(start)
+	ctx, cancel := clock.WithTimeout(context.Background(), fc, time.Minute)
+	defer cancel()
+	if deadline, ok := ctx.Deadline(); !ok || !deadline.Equal(start.Add(time.Minute)) {
+		t.Errorf("Deadline: %v, %v, want %v", deadline, ok, start.Add(time.Minute))
+	}
+
+	fc.BlockUntil(1)
+	fc.Advance(time.Minute - time.Second)
+	if err := ctx.Err(); err != nil {
+		t.Errorf("Err before the timeout: %v, want nil", err)
+	}
+	fc.Advance(time.Second)
+	<-ctx.Done()
+	if got, want := ctx.Err(), context.DeadlineExceeded; got != want {
+		t.Errorf("Err after the timeout: %v, want %v", got, want)
+	}
+}
+
+func TestWithTimeoutCanceled(t *testing.T) {
+	fc := fakeclock.New(time.Unix(1000, 0))
+	parent, cancelParent := context.WithCancel(context.Background())
+	ctx, cancel := clock.WithTimeout(parent, fc, time.Minute)
+	defer cancel()
+	cancelParent()
+	<-ctx.Done()
+	if got, want := ctx.Err(), context.Canceled; got != want {
+		t.Errorf("Err after the parent is canceled: %v, want %v", got, want)
+	}
+	fc.Advance(time.Hour)
+	if got, want := ctx.Err(), context.Canceled; got != want {
+		t.Errorf("Err after the timeout: %v, want %v", got, want)
+	}
+}
+
+func TestWithTimeoutReal(t *testing.T) {
+	ctx, cancel := clock.WithTimeout(context.Background(), clock.Real, time.Millisecond)
+	defer cancel()
+	<-ctx.Done()
+	if got, want := ctx.Err(), context.DeadlineExceeded; got != want {
+		t.Errorf("Err after the timeout: %v, want %v", got, want)
+	}
+}
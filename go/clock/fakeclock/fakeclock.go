@@ -0,0 +1,151 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package fakeclock provides a clock.Clock for tests, whose time only
+// passes when the test advances it.
+package fakeclock
+
+import (
+	"sync"
+	"time"
+
+	"github.com/youtube/vitess/go/clock"
+)
+
+// FakeClock is a clock.Clock that is advanced manually, with Advance.
+// It's safe for concurrent use.
+type FakeClock struct {
+	mu sync.Mutex
+	// changed is broadcast when waiters changes.
+	changed *sync.Cond
+	now     time.Time
+	waiters []*waiter
+}
+
+// waiter is a channel of After, or of a Ticker if period is set.
+type waiter struct {
+	deadline time.Time
+	period   time.Duration
+	c        chan time.Time
+}
+
+// New returns a FakeClock set to now.
+func New(now time.Time) *FakeClock {
+	fc := &FakeClock{now: now}
+	fc.changed = sync.NewCond(&fc.mu)
+	return fc
+}
+
+// Now is part of the clock.Clock interface.
+func (fc *FakeClock) Now() time.Time {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	return fc.now
+}
+
+// After is part of the clock.Clock interface.
+func (fc *FakeClock) After(d time.Duration) <-chan time.Time {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	c := make(chan time.Time, 1)
+	if d <= 0 {
+		c <- fc.now
+		return c
+	}
+	fc.addWaiter(&waiter{deadline: fc.now.Add(d), c: c})
+	return c
+}
+
+// Sleep is part of the clock.Clock interface. It returns when the
+// clock is advanced by d.
+func (fc *FakeClock) Sleep(d time.Duration) {
+	<-fc.After(d)
+}
+
+// NewTicker is part of the clock.Clock interface.
+func (fc *FakeClock) NewTicker(d time.Duration) clock.Ticker {
+	if d <= 0 {
+		panic("non-positive interval for NewTicker")
+	}
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	w := &waiter{deadline: fc.now.Add(d), period: d, c: make(chan time.Time, 1)}
+	fc.addWaiter(w)
+	return &ticker{fc: fc, w: w}
+}
+
+// Advance moves the clock forward by d. The channels of After and of
+// the tickers receive the time they expired at, in order.
+func (fc *FakeClock) Advance(d time.Duration) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	end := fc.now.Add(d)
+	for {
+		var next *waiter
+		for _, w := range fc.waiters {
+			if !w.deadline.After(end) && (next == nil || w.deadline.Before(next.deadline)) {
+				next = w
+			}
+		}
+		if next == nil {
+			break
+		}
+		fc.now = next.deadline
+		select {
+		case next.c <- fc.now:
+		default:
+			// A tick the receiver didn't read yet.
+		}
+		if next.period > 0 {
+			next.deadline = next.deadline.Add(next.period)
+		} else {
+			fc.removeWaiter(next)
+		}
+	}
+	fc.now = end
+}
+
+// BlockUntil waits until n channels of After and tickers are waiting
+// for the clock. Tests call it before Advance, to make sure the
+// goroutines that wait for the clock are ready.
+func (fc *FakeClock) BlockUntil(n int) {
+	fc.mu.Lock()
+	defer fc.mu.Unlock()
+	for len(fc.waiters) < n {
+		fc.changed.Wait()
+	}
+}
+
+// addWaiter must be called with mu held.
+func (fc *FakeClock) addWaiter(w *waiter) {
+	fc.waiters = append(fc.waiters, w)
+	fc.changed.Broadcast()
+}
+
+// removeWaiter must be called with mu held.
+func (fc *FakeClock) removeWaiter(w *waiter) {
+	for i, other := range fc.waiters {
+		if other == w {
+			fc.waiters = append(fc.waiters[:i], fc.waiters[i+1:]...)
+			fc.changed.Broadcast()
+			return
+		}
+	}
+}
+
+// ticker is the clock.Ticker of FakeClock.
+type ticker struct {
+	fc *FakeClock
+	w  *waiter
+}
+
+func (t *ticker) Chan() <-chan time.Time {
+	return t.w.c
+}
+
+func (t *ticker) Stop() {
+	t.fc.mu.Lock()
+	defer t.fc.mu.Unlock()
+	t.fc.removeWaiter(t.w)
+}
@@ -0,0 +1,86 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package fakeclock
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAfter(t *testing.T) {
+	start := time.Unix(1000, 0)
+	fc := New(start)
+	c1 := fc.After(time.Second)
+	c2 := fc.After(2 * time.Second)
+
+	fc.Advance(time.Second / 2)
+	select {
+	case got := <-c1:
+		t.Fatalf("After(1s) expired after 0.5s, at %v", got)
+	default:
+	}
+
+	fc.Advance(time.Second)
+	if got, want := <-c1, start.Add(time.Second); !got.Equal(want) {
+		t.Errorf("After(1s): %v, want %v", got, want)
+	}
+	select {
+	case got := <-c2:
+		t.Fatalf("After(2s) expired after 1.5s, at %v", got)
+	default:
+	}
+	if got, want := fc.Now(), start.Add(3*time.Second/2); !got.Equal(want) {
+		t.Errorf("Now: %v, want %v", got, want)
+	}
+
+	fc.Advance(time.Hour)
+	if got, want := <-c2, start.Add(2*time.Second); !got.Equal(want) {
+		t.Errorf("After(2s): %v, want %v", got, want)
+	}
+	if got, want := <-fc.After(0), fc.Now(); !got.Equal(want) {
+		t.Errorf("After(0): %v, want %v", got, want)
+	}
+}
+
+func TestSleep(t *testing.T) {
+	fc := New(time.Unix(1000, 0))
+	done := make(chan struct{})
+	go func() {
+		fc.Sleep(time.Minute)
+		close(done)
+	}()
+	fc.BlockUntil(1)
+	fc.Advance(time.Minute)
+	<-done
+}
+
+func TestTicker(t *testing.T) {
+	start := time.Unix(1000, 0)
+	fc := New(start)
+	ticker := fc.NewTicker(time.Second)
+
+	fc.Advance(time.Second)
+	if got, want := <-ticker.Chan(), start.Add(time.Second); !got.Equal(want) {
+		t.Errorf("first tick: %v, want %v", got, want)
+	}
+	// As with time.Ticker, the ticks are dropped if they're not read.
+	fc.Advance(3 * time.Second)
+	if got, want := <-ticker.Chan(), start.Add(2*time.Second); !got.Equal(want) {
+		t.Errorf("second tick: %v, want %v", got, want)
+	}
+	select {
+	case got := <-ticker.Chan():
+		t.Errorf("unread tick %v was not dropped", got)
+	default:
+	}
+
+	ticker.Stop()
+	fc.Advance(time.Hour)
+	select {
+	case got := <-ticker.Chan():
+		t.Errorf("tick %v after Stop", got)
+	default:
+	}
+}
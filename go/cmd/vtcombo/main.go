@@ -6,6 +6,8 @@
 // - a ZK topology server based on an in-memory map.
 // - one vtgate instance.
 // - many vttablet instaces.
+// With -schema_dir, it also applies the schema and the vschema of the
+// keyspaces from a directory, and applies them again when they change.
 package main
 
 import (
@@ -97,8 +99,20 @@ func main() {
 	// tablets configuration and init
 	initTabletMap(ts, *topology, mysqld, dbcfgs, formal, mycnf)
 
+	// schema and vschema of -schema_dir, applied again when they change
+	if *schemaDir != "" {
+		sdw := newSchemaDirWatcher(ts, mysqld, *schemaDir)
+		if err := sdw.apply(context.Background()); err != nil {
+			log.Errorf("cannot apply %v: %v", *schemaDir, err)
+			exit.Return(1)
+		}
+		sdw.Start()
+		servenv.OnClose(sdw.Stop)
+	}
+
 	// vtgate configuration and init
 	resilientSrvTopoServer := vtgate.NewResilientSrvTopoServer(ts, "ResilientSrvTopoServer")
+	servenv.OnClose(resilientSrvTopoServer.Close)
 	healthCheck := discovery.NewHealthCheck(30*time.Second /*connTimeoutTotal*/, 1*time.Millisecond /*retryDelay*/, 1*time.Minute /*healthCheckTimeout*/, "" /* statsSuffix */)
 	tabletTypesToWait := []topodatapb.TabletType{
 		topodatapb.TabletType_MASTER,
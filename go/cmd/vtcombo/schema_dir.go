@@ -0,0 +1,188 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	log "github.com/golang/glog"
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/timer"
+	"github.com/youtube/vitess/go/vt/mysqlctl"
+	"github.com/youtube/vitess/go/vt/topo"
+	"github.com/youtube/vitess/go/vt/vtgate/vindexes"
+)
+
+var (
+	schemaDir             = flag.String("schema_dir", "", "directory with one subdirectory per keyspace. The .sql files of a keyspace are applied, in name order, to the database of every shard of the keyspace, and its optional vschema.json replaces the keyspace of -vschema. The directory is watched: the new and changed .sql files are applied again in full, so their statements must be idempotent (e.g. create table if not exists), and the changed vschema.json files are saved in the topology, where the tablets and vtgate reload them.")
+	schemaDirPollInterval = flag.Duration("schema_dir_poll_interval", 2*time.Second, "how often -schema_dir is checked for changes")
+)
+
+// schemaDirWatcher applies the schema and the vschema of -schema_dir,
+// and applies them again when they change.
+type schemaDirWatcher struct {
+	ts     topo.Server
+	mysqld mysqlctl.MysqlDaemon
+	dir    string
+	// applied has the content of the files that were applied,
+	// by path.
+	applied map[string]string
+	timer   *timer.Timer
+}
+
+func newSchemaDirWatcher(ts topo.Server, mysqld mysqlctl.MysqlDaemon, dir string) *schemaDirWatcher {
+	return &schemaDirWatcher{
+		ts:      ts,
+		mysqld:  mysqld,
+		dir:     dir,
+		applied: make(map[string]string),
+		timer:   timer.NewTimer(*schemaDirPollInterval),
+	}
+}
+
+// Start checks the directory for changes in the background.
+func (sdw *schemaDirWatcher) Start() {
+	sdw.timer.Start(func() {
+		if err := sdw.apply(context.Background()); err != nil {
+			log.Errorf("cannot apply %v: %v", sdw.dir, err)
+		}
+	})
+}
+
+// Stop stops the background checks.
+func (sdw *schemaDirWatcher) Stop() {
+	sdw.timer.Stop()
+}
+
+// apply applies the new and changed files of the keyspaces of the
+// tablet map, and reloads the schema of the tablets of the keyspaces
+// that changed. A file that fails is not applied again until it
+// changes.
+func (sdw *schemaDirWatcher) apply(ctx context.Context) error {
+	dbNames := make(map[string]map[string]bool)
+	for _, t := range tabletMap {
+		if dbNames[t.keyspace] == nil {
+			dbNames[t.keyspace] = make(map[string]bool)
+		}
+		dbNames[t.keyspace][t.dbname] = true
+	}
+	var errs []string
+	for keyspace, dbs := range dbNames {
+		changed, err := sdw.applyKeyspace(ctx, keyspace, dbs)
+		if err != nil {
+			errs = append(errs, err.Error())
+		}
+		if !changed {
+			continue
+		}
+		for _, t := range tabletMap {
+			if t.keyspace == keyspace {
+				t.qsc.ReloadSchema()
+			}
+		}
+	}
+	if len(errs) != 0 {
+		return fmt.Errorf("%v", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// applyKeyspace applies the new and changed files of keyspace, and
+// returns if its schema changed.
+func (sdw *schemaDirWatcher) applyKeyspace(ctx context.Context, keyspace string, dbs map[string]bool) (bool, error) {
+	sqlFiles, err := filepath.Glob(path.Join(sdw.dir, keyspace, "*.sql"))
+	if err != nil {
+		return false, err
+	}
+	sort.Strings(sqlFiles)
+	changed := false
+	for _, file := range sqlFiles {
+		content, ok := sdw.read(file)
+		if !ok {
+			continue
+		}
+		changed = true
+		statements := splitSQLStatements(content)
+		for db := range dbs {
+			queries := append([]string{
+				fmt.Sprintf("create database if not exists `%v`", db),
+				fmt.Sprintf("use `%v`", db),
+			}, statements...)
+			if err := sdw.mysqld.ExecuteSuperQueryList(queries); err != nil {
+				return changed, fmt.Errorf("cannot apply %v to %v: %v", file, db, err)
+			}
+		}
+		log.Infof("Applied %v to the shards of %v", file, keyspace)
+	}
+
+	vschemaFile := path.Join(sdw.dir, keyspace, "vschema.json")
+	content, ok := sdw.read(vschemaFile)
+	if !ok {
+		return changed, nil
+	}
+	var kformal vindexes.KeyspaceFormal
+	if err := json.Unmarshal([]byte(content), &kformal); err != nil {
+		return changed, fmt.Errorf("cannot parse %v: %v", vschemaFile, err)
+	}
+	if _, err := vindexes.BuildVSchema(&vindexes.VSchemaFormal{Keyspaces: map[string]vindexes.KeyspaceFormal{keyspace: kformal}}); err != nil {
+		return changed, fmt.Errorf("invalid vschema in %v: %v", vschemaFile, err)
+	}
+	if err := sdw.ts.SaveVSchema(ctx, keyspace, content); err != nil {
+		return changed, fmt.Errorf("cannot save the vschema of %v: %v", keyspace, err)
+	}
+	log.Infof("Saved the vschema of %v from %v", keyspace, vschemaFile)
+	return changed, nil
+}
+
+// read returns the content of file, and true if it's new or it
+// changed since the last read. A file that can't be read is skipped.
+func (sdw *schemaDirWatcher) read(file string) (string, bool) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return "", false
+	}
+	content := string(data)
+	if applied, ok := sdw.applied[file]; ok && applied == content {
+		return "", false
+	}
+	sdw.applied[file] = content
+	return content, true
+}
+
+// splitSQLStatements splits the content of a .sql file in
+// statements. A statement ends with a ';' at the end of a line, the
+// empty lines and the lines starting with "--" are skipped.
+func splitSQLStatements(content string) []string {
+	var statements []string
+	var current []string
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+		if !strings.HasSuffix(trimmed, ";") {
+			current = append(current, line)
+			continue
+		}
+		current = append(current, strings.TrimSuffix(strings.TrimRight(line, " \t\r"), ";"))
+		if statement := strings.TrimSpace(strings.Join(current, "\n")); statement != "" {
+			statements = append(statements, statement)
+		}
+		current = nil
+	}
+	if len(current) != 0 {
+		statements = append(statements, strings.TrimSpace(strings.Join(current, "\n")))
+	}
+	return statements
+}
@@ -262,6 +262,97 @@ func (itc *internalTabletConn) ExecuteBatch(ctx context.Context, queries []query
 	return results, nil
 }
 
+// WaitForGTID is part of tabletconn.TabletConn
+func (itc *internalTabletConn) WaitForGTID(ctx context.Context, gtid string) error {
+	err := itc.tablet.qsc.QueryService().WaitForGTID(ctx, &querypb.Target{
+		Keyspace:   itc.tablet.keyspace,
+		Shard:      itc.tablet.shard,
+		TabletType: itc.tablet.tabletType,
+	}, gtid)
+	if err != nil {
+		return tabletconn.TabletErrorFromGRPC(tabletserver.ToGRPCError(err))
+	}
+	return nil
+}
+
+// XAStart is part of tabletconn.TabletConn
+func (itc *internalTabletConn) XAStart(ctx context.Context, xid string) (int64, error) {
+	transactionID, err := itc.tablet.qsc.QueryService().XAStart(ctx, &querypb.Target{
+		Keyspace:   itc.tablet.keyspace,
+		Shard:      itc.tablet.shard,
+		TabletType: itc.tablet.tabletType,
+	}, 0, xid)
+	if err != nil {
+		return 0, tabletconn.TabletErrorFromGRPC(tabletserver.ToGRPCError(err))
+	}
+	return transactionID, nil
+}
+
+// XAPrepare is part of tabletconn.TabletConn
+func (itc *internalTabletConn) XAPrepare(ctx context.Context, xid string) error {
+	err := itc.tablet.qsc.QueryService().XAPrepare(ctx, &querypb.Target{
+		Keyspace:   itc.tablet.keyspace,
+		Shard:      itc.tablet.shard,
+		TabletType: itc.tablet.tabletType,
+	}, 0, xid)
+	if err != nil {
+		return tabletconn.TabletErrorFromGRPC(tabletserver.ToGRPCError(err))
+	}
+	return nil
+}
+
+// XACommit is part of tabletconn.TabletConn
+func (itc *internalTabletConn) XACommit(ctx context.Context, xid string) error {
+	err := itc.tablet.qsc.QueryService().XACommit(ctx, &querypb.Target{
+		Keyspace:   itc.tablet.keyspace,
+		Shard:      itc.tablet.shard,
+		TabletType: itc.tablet.tabletType,
+	}, 0, xid)
+	if err != nil {
+		return tabletconn.TabletErrorFromGRPC(tabletserver.ToGRPCError(err))
+	}
+	return nil
+}
+
+// XARollback is part of tabletconn.TabletConn
+func (itc *internalTabletConn) XARollback(ctx context.Context, xid string) error {
+	err := itc.tablet.qsc.QueryService().XARollback(ctx, &querypb.Target{
+		Keyspace:   itc.tablet.keyspace,
+		Shard:      itc.tablet.shard,
+		TabletType: itc.tablet.tabletType,
+	}, 0, xid)
+	if err != nil {
+		return tabletconn.TabletErrorFromGRPC(tabletserver.ToGRPCError(err))
+	}
+	return nil
+}
+
+// XARecover is part of tabletconn.TabletConn
+func (itc *internalTabletConn) XARecover(ctx context.Context) ([]string, error) {
+	xids, err := itc.tablet.qsc.QueryService().XARecover(ctx, &querypb.Target{
+		Keyspace:   itc.tablet.keyspace,
+		Shard:      itc.tablet.shard,
+		TabletType: itc.tablet.tabletType,
+	}, 0)
+	if err != nil {
+		return nil, tabletconn.TabletErrorFromGRPC(tabletserver.ToGRPCError(err))
+	}
+	return xids, nil
+}
+
+// HotQueries is part of tabletconn.TabletConn
+func (itc *internalTabletConn) HotQueries(ctx context.Context) ([]querytypes.BoundQuery, error) {
+	queries, err := itc.tablet.qsc.QueryService().HotQueries(ctx, &querypb.Target{
+		Keyspace:   itc.tablet.keyspace,
+		Shard:      itc.tablet.shard,
+		TabletType: itc.tablet.tabletType,
+	})
+	if err != nil {
+		return nil, tabletconn.TabletErrorFromGRPC(tabletserver.ToGRPCError(err))
+	}
+	return queries, nil
+}
+
 type streamExecuteAdapter struct {
 	c   chan *sqltypes.Result
 	err *error
@@ -312,6 +403,61 @@ func (itc *internalTabletConn) StreamExecute(ctx context.Context, query string,
 	return &streamExecuteAdapter{result, &finalErr}, nil
 }
 
+type resumableResult struct {
+	result      *sqltypes.Result
+	resumeToken []byte
+}
+
+type resumableStreamExecuteAdapter struct {
+	c   chan resumableResult
+	err *error
+}
+
+func (a *resumableStreamExecuteAdapter) Recv() (*sqltypes.Result, []byte, error) {
+	r, ok := <-a.c
+	if !ok {
+		if *a.err == nil {
+			return nil, nil, io.EOF
+		}
+		return nil, nil, *a.err
+	}
+	return r.result, r.resumeToken, nil
+}
+
+// StreamExecuteResumable is part of tabletconn.TabletConn
+// We need to copy the bind variables as tablet server will change them.
+func (itc *internalTabletConn) StreamExecuteResumable(ctx context.Context, query string, bindVars map[string]interface{}, resumeToken []byte) (tabletconn.ResumableResultStream, error) {
+	bv, err := querytypes.BindVariablesToProto3(bindVars)
+	if err != nil {
+		return nil, err
+	}
+	bindVars, err = querytypes.Proto3ToBindVariables(bv)
+	if err != nil {
+		return nil, err
+	}
+	result := make(chan resumableResult, 10)
+	var finalErr error
+
+	go func() {
+		finalErr = itc.tablet.qsc.QueryService().StreamExecuteResumable(ctx, &querypb.Target{
+			Keyspace:   itc.tablet.keyspace,
+			Shard:      itc.tablet.shard,
+			TabletType: itc.tablet.tabletType,
+		}, query, bindVars, 0, resumeToken, func(reply *sqltypes.Result, resumeToken []byte) error {
+			// We need to deep-copy the reply before returning,
+			// because the underlying buffers are reused.
+			result <- resumableResult{reply.Copy(), resumeToken}
+			return nil
+		})
+
+		// the client will only access finalErr after the
+		// channel is closed, and then it's already set.
+		close(result)
+	}()
+
+	return &resumableStreamExecuteAdapter{result, &finalErr}, nil
+}
+
 // Begin is part of tabletconn.TabletConn
 func (itc *internalTabletConn) Begin(ctx context.Context) (int64, error) {
 	transactionID, err := itc.tablet.qsc.QueryService().Begin(ctx, &querypb.Target{
@@ -727,6 +873,10 @@ func (itmc *internalTabletManagerClient) Backup(ctx context.Context, tablet *top
 	return nil, fmt.Errorf("not implemented in vtcombo")
 }
 
+func (itmc *internalTabletManagerClient) WarmQueries(ctx context.Context, tablet *topo.TabletInfo) error {
+	return fmt.Errorf("not implemented in vtcombo")
+}
+
 func (itmc *internalTabletManagerClient) IsTimeoutError(err error) bool {
 	return false
 }
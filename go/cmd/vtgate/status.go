@@ -221,6 +221,7 @@ google.setOnLoadCallback(function() {
     <th>Query Error</th>
     <th>QPS (avg 1m)</th>
     <th>Latency (ms) (avg 1m)</th>
+    <th>Circuit Breaker</th>
   </tr>
   {{range $i, $status := .}}
   <tr>
@@ -232,9 +233,51 @@ google.setOnLoadCallback(function() {
     <td>{{$status.QueryError}}</td>
     <td>{{$status.QPS}}</td>
     <td>{{$status.AvgLatency}}</td>
+    <td>{{with $status.CircuitBreaker}}{{.State}}, {{.ConsecutiveErrors}} errors, {{.Trips}} trips{{if .LastError}}<br>{{.LastError}}{{end}}{{end}}</td>
   </tr>
   {{end}}
 </table>
+`
+
+	keyspaceLimiterTemplate = `
+<style>
+  table {
+    border-collapse: collapse;
+  }
+  td, th {
+    border: 1px solid #999;
+    padding: 0.2rem;
+  }
+</style>
+{{if .}}
+<table>
+  <tr>
+    <th>Keyspace</th>
+    <th>Concurrency Limit</th>
+    <th>In Flight</th>
+    <th>Rejections</th>
+  </tr>
+  {{range $i, $status := .}}
+  <tr>
+    <td>{{$status.Keyspace}}</td>
+    <td>{{if $status.Limit}}{{$status.Limit}}{{else}}none{{end}}</td>
+    <td>{{$status.InFlight}}</td>
+    <td>{{$status.Rejections}}</td>
+  </tr>
+  {{end}}
+</table>
+{{else}}
+No per-keyspace concurrency limit is set.
+{{end}}
+`
+
+	cellAliasTemplate = `
+{{if .}}
+Cell alias <b>{{.Name}}</b>: {{range $i, $cell := .Cells}}{{if $i}}, {{end}}{{github_com_youtube_vitess_vtctld_srv_cell $cell}}{{end}}.
+The replica and rdonly queries go to the first of these cells that has serving endpoints.
+{{else}}
+No cell alias is set.
+{{end}}
 `
 
 	healthCheckTemplate = `
@@ -281,6 +324,12 @@ func addStatusParts(vtgate *vtgate.VTGate) {
 	servenv.AddStatusPart("Gateway Status", gatewayStatusTemplate, func() interface{} {
 		return vtgate.GetGatewayCacheStatus()
 	})
+	servenv.AddStatusPart("Keyspace Concurrency", keyspaceLimiterTemplate, func() interface{} {
+		return vtgate.GetKeyspaceLimiterStatus()
+	})
+	servenv.AddStatusPart("Cell Alias", cellAliasTemplate, func() interface{} {
+		return vtgate.GetCellAlias()
+	})
 	servenv.AddStatusPart("Health Check Cache (NOT FOR QUERY ROUTING)", healthCheckTemplate, func() interface{} {
 		return healthCheck.CacheStatus()
 	})
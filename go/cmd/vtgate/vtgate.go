@@ -7,6 +7,7 @@ package main
 import (
 	"flag"
 	"math/rand"
+	"net/http"
 	"strings"
 	"time"
 
@@ -30,10 +31,13 @@ var (
 	retryCount            = flag.Int("retry-count", 2, "retry count")
 	connTimeoutTotal      = flag.Duration("conn-timeout-total", 3*time.Second, "vttablet connection timeout (total)")
 	connTimeoutPerConn    = flag.Duration("conn-timeout-per-conn", 1500*time.Millisecond, "vttablet connection timeout (per connection)")
-	connLife              = flag.Duration("conn-life", 365*24*time.Hour, "average life of vttablet connections")
+	connLife              = flag.Duration("conn-life", 365*24*time.Hour, "average life of the vtgate connections to vttablet. Each connection is closed after a random life between 0.5 and 1.5 times this, so that the connections created together don't all reconnect at the same time. The vttablet connections to MySQL have their own -pool_conn_life and -pool_conn_life_jitter.")
 	maxInFlight           = flag.Int("max-in-flight", 0, "maximum number of calls to allow simultaneously")
 	healthCheckRetryDelay = flag.Duration("healthcheck_retry_delay", 2*time.Millisecond, "health check retry delay")
+	healthCheckMaxDelay   = flag.Duration("healthcheck_retry_max_delay", 10*time.Second, "maximum health check retry delay, the delay is multiplied by healthcheck_retry_multiplier after each failure")
+	healthCheckMultiplier = flag.Float64("healthcheck_retry_multiplier", 2.0, "health check retry delay multiplier")
 	healthCheckTimeout    = flag.Duration("healthcheck_timeout", time.Minute, "the health check timeout period")
+	maxReplicationLag     = flag.Duration("max-replication-lag", 0, "the replication lag above which the replicas don't serve the reads, 0 means no limit")
 	tabletTypesToWait     = flag.String("tablet_types_to_wait", "", "wait till connected for specified tablet types during Gateway initialization")
 	testGateway           = flag.String("test_gateway", "", "additional gateway to test health check module")
 )
@@ -61,8 +65,13 @@ func main() {
 	defer topo.CloseServers()
 
 	resilientSrvTopoServer = vtgate.NewResilientSrvTopoServer(ts, "ResilientSrvTopoServer")
+	servenv.OnClose(resilientSrvTopoServer.Close)
 
-	healthCheck = discovery.NewHealthCheck(*connTimeoutTotal, *healthCheckRetryDelay, *healthCheckTimeout, "" /* statsSuffix */)
+	hc := discovery.NewHealthCheckWithBackoff(*connTimeoutTotal, *healthCheckRetryDelay, *healthCheckMaxDelay, *healthCheckMultiplier, *healthCheckTimeout, "" /* statsSuffix */)
+	hc.SetMaxReplicationLag(*maxReplicationLag)
+	http.Handle("/debug/healthcheck_backoff", hc)
+	http.HandleFunc("/debug/tablet_health_history", hc.ServeTabletHistory)
+	healthCheck = hc
 
 	tabletTypes := make([]topodatapb.TabletType, 0, 1)
 	if len(*tabletTypesToWait) != 0 {
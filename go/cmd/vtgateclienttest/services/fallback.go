@@ -55,6 +55,18 @@ func (c fallbackClient) ExecuteBatchKeyspaceIds(ctx context.Context, queries []*
 	return c.fallback.ExecuteBatchKeyspaceIds(ctx, queries, tabletType, asTransaction, session)
 }
 
+func (c fallbackClient) Prepare(ctx context.Context, sql string, keyspace string, tabletType topodatapb.TabletType) (int64, int64, []*querypb.Field, error) {
+	return c.fallback.Prepare(ctx, sql, keyspace, tabletType)
+}
+
+func (c fallbackClient) ExecutePrepared(ctx context.Context, id int64, bindVariables map[string]interface{}, session *vtgatepb.Session, notInTransaction bool) (*sqltypes.Result, error) {
+	return c.fallback.ExecutePrepared(ctx, id, bindVariables, session, notInTransaction)
+}
+
+func (c fallbackClient) ClosePrepared(ctx context.Context, ids []int64) error {
+	return c.fallback.ClosePrepared(ctx, ids)
+}
+
 func (c fallbackClient) StreamExecute(ctx context.Context, sql string, bindVariables map[string]interface{}, keyspace string, tabletType topodatapb.TabletType, sendReply func(*sqltypes.Result) error) error {
 	return c.fallback.StreamExecute(ctx, sql, bindVariables, keyspace, tabletType, sendReply)
 }
@@ -106,6 +118,10 @@ func (c fallbackClient) GetSrvKeyspace(ctx context.Context, keyspace string) (*t
 	return c.fallback.GetSrvKeyspace(ctx, keyspace)
 }
 
+func (c fallbackClient) GetKeyspaceSchema(ctx context.Context, keyspace string) (*vtgatepb.GetKeyspaceSchemaResponse, error) {
+	return c.fallback.GetKeyspaceSchema(ctx, keyspace)
+}
+
 func (c fallbackClient) GetSrvShard(ctx context.Context, keyspace, shard string) (*topodatapb.SrvShard, error) {
 	return c.fallback.GetSrvShard(ctx, keyspace, shard)
 }
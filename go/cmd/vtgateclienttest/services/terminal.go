@@ -60,6 +60,18 @@ func (c *terminalClient) ExecuteBatchKeyspaceIds(ctx context.Context, queries []
 	return nil, errTerminal
 }
 
+func (c *terminalClient) Prepare(ctx context.Context, sql string, keyspace string, tabletType topodatapb.TabletType) (int64, int64, []*querypb.Field, error) {
+	return 0, 0, nil, errTerminal
+}
+
+func (c *terminalClient) ExecutePrepared(ctx context.Context, id int64, bindVariables map[string]interface{}, session *vtgatepb.Session, notInTransaction bool) (*sqltypes.Result, error) {
+	return nil, errTerminal
+}
+
+func (c *terminalClient) ClosePrepared(ctx context.Context, ids []int64) error {
+	return errTerminal
+}
+
 func (c *terminalClient) StreamExecute(ctx context.Context, sql string, bindVariables map[string]interface{}, keyspace string, tabletType topodatapb.TabletType, sendReply func(*sqltypes.Result) error) error {
 	return errTerminal
 }
@@ -110,6 +122,10 @@ func (c *terminalClient) GetSrvKeyspace(ctx context.Context, keyspace string) (*
 	return nil, errTerminal
 }
 
+func (c *terminalClient) GetKeyspaceSchema(ctx context.Context, keyspace string) (*vtgatepb.GetKeyspaceSchemaResponse, error) {
+	return nil, errTerminal
+}
+
 func (c *terminalClient) GetSrvShard(ctx context.Context, keyspace, shard string) (*topodatapb.SrvShard, error) {
 	return nil, errTerminal
 }
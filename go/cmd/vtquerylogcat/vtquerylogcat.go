@@ -0,0 +1,79 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// vtquerylogcat prints the records of vttablet query logs written in
+// the binary format, e.g. with -querylog-file-format binary, in the
+// text or JSON format, so that they can be grepped. It reads the
+// files given as arguments, or the standard input if there are none.
+package main
+
+import (
+	"bufio"
+	"flag"
+	"io"
+	"os"
+
+	log "github.com/golang/glog"
+	"github.com/youtube/vitess/go/exit"
+	"github.com/youtube/vitess/go/vt/tabletserver/querylogparser"
+)
+
+var format = flag.String("format", "text", "output format: text, the format of the vttablet query log that querylogparser parses, or json, one object per line")
+
+func main() {
+	defer exit.Recover()
+	flag.Parse()
+	if *format != "text" && *format != "json" {
+		log.Errorf("invalid -format %q: it must be text or json", *format)
+		exit.Return(1)
+	}
+
+	w := bufio.NewWriter(os.Stdout)
+	defer w.Flush()
+	if flag.NArg() == 0 {
+		if err := cat(w, os.Stdin); err != nil {
+			log.Errorf("cannot read the standard input: %v", err)
+			exit.Return(1)
+		}
+		return
+	}
+	for _, filename := range flag.Args() {
+		if err := catFile(w, filename); err != nil {
+			log.Errorf("cannot read %v: %v", filename, err)
+			exit.Return(1)
+		}
+	}
+}
+
+func catFile(w io.Writer, filename string) error {
+	f, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return cat(w, f)
+}
+
+// cat prints the records of r to w, until the end of r.
+func cat(w io.Writer, r io.Reader) error {
+	br := querylogparser.NewBinaryReader(r)
+	for {
+		record, err := br.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		line := querylogparser.FormatText(record)
+		if *format == "json" {
+			if line, err = querylogparser.FormatJSON(record); err != nil {
+				return err
+			}
+		}
+		if _, err := io.WriteString(w, line); err != nil {
+			return err
+		}
+	}
+}
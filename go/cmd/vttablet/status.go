@@ -60,6 +60,7 @@ var (
       <a href="/queryz">Query&nbsp;Stats</a></br>
       <a href="/debug/consolidations">Consolidations</a></br>
       <a href="/querylogz">Current&nbsp;Query&nbsp;Log</a></br>
+      <a href="/querylogz/csv">Recent&nbsp;Query&nbsp;Log&nbsp;(CSV)</a></br>
       <a href="/txlogz">Current&nbsp;Transaction&nbsp;Log</a></br>
     </td>
     <td width="25%" border="">
@@ -141,6 +142,33 @@ Binlog player state: {{.State}}</br>
 {{else}}
 No binlog player is running.
 {{end}}
+`
+
+	// queryWarmerTemplate is about the query warmer
+	queryWarmerTemplate = `
+Query warmer state: {{.State}}</br>
+{{if ne .State "Idle"}}
+<table>
+  <tr>
+    <th>Master</th>
+    <th>Started</th>
+    <th>Ended</th>
+    <th>Fetched</th>
+    <th>Warmed</th>
+    <th>Failed</th>
+    <th>Last Error</th>
+  </tr>
+  <tr>
+    <td>{{.Master}}</td>
+    <td>{{.StartTime}}</td>
+    <td>{{if not .EndTime.IsZero}}{{.EndTime}}{{end}}</td>
+    <td>{{.Fetched}}</td>
+    <td>{{.Warmed}}</td>
+    <td>{{.Failed}}</td>
+    <td>{{.LastError}}</td>
+  </tr>
+</table>
+{{end}}
 `
 )
 
@@ -193,6 +221,9 @@ func addStatusParts(qsc tabletserver.Controller) {
 	servenv.AddStatusPart("Binlog Player", binlogTemplate, func() interface{} {
 		return agent.BinlogPlayerMap.Status()
 	})
+	servenv.AddStatusPart("Query Warmer", queryWarmerTemplate, func() interface{} {
+		return agent.QueryWarmer.Status()
+	})
 	if onStatusRegistered != nil {
 		onStatusRegistered()
 	}
@@ -80,6 +80,7 @@ func main() {
 
 	// creates and registers the query service
 	qsc := tabletserver.NewServer()
+	qsc.SetTabletAlias(topoproto.TabletAliasString(tabletAlias))
 	servenv.OnRun(func() {
 		qsc.Register()
 		addStatusParts(qsc)
@@ -105,6 +105,27 @@ func (mc *Connection) Cas(key string, flags uint16, timeout uint64, value []byte
 	return mc.store("cas", key, flags, timeout, value, cas), nil
 }
 
+// SetMulti sets the same value for all the keys, and returns the
+// number of keys that were stored. The set commands are pipelined: they
+// are all sent before reading the replies, so the callers should bound
+// the number of keys.
+func (mc *Connection) SetMulti(keys []string, flags uint16, timeout uint64, value []byte) (stored int, err error) {
+	defer handleError(&err)
+	if len(value) > 1000000 || len(keys) == 0 {
+		return 0, nil
+	}
+	mc.setDeadline()
+	for _, key := range keys {
+		mc.writeStore("set", key, flags, timeout, value, 0)
+	}
+	for range keys {
+		if mc.readStoreReply() {
+			stored++
+		}
+	}
+	return stored, nil
+}
+
 // Delete delete the value for the specified cache key.
 func (mc *Connection) Delete(key string) (deleted bool, err error) {
 	defer handleError(&err)
@@ -208,6 +229,12 @@ func (mc *Connection) store(command, key string, flags uint16, timeout uint64, v
 	}
 
 	mc.setDeadline()
+	mc.writeStore(command, key, flags, timeout, value, cas)
+	return mc.readStoreReply()
+}
+
+// writeStore writes a storage command to the buffer, without flushing it.
+func (mc *Connection) writeStore(command, key string, flags uint16, timeout uint64, value []byte, cas uint64) {
 	// <command name> <key> <flags> <exptime> <bytes> [noreply]\r\n
 	mc.writestrings(command, " ", key, " ")
 	mc.write(strconv.AppendUint(nil, uint64(flags), 10))
@@ -223,6 +250,11 @@ func (mc *Connection) store(command, key string, flags uint16, timeout uint64, v
 	// <data block>\r\n
 	mc.write(value)
 	mc.writestring("\r\n")
+}
+
+// readStoreReply reads the reply of a storage command, and returns
+// whether the value was stored.
+func (mc *Connection) readStoreReply() (stored bool) {
 	reply := mc.readline()
 	if strings.Contains(reply, "ERROR") {
 		panic(NewError("Server error"))
@@ -99,6 +99,17 @@ func TestMemcache(t *testing.T) {
 	}
 	expect(t, c, "Hello", "")
 
+	// SetMulti
+	count, err := c.SetMulti([]string{"Hello", "Hola"}, 0, 0, []byte("multi"))
+	if err != nil {
+		t.Errorf("SetMulti: %v", err)
+	}
+	if count != 2 {
+		t.Errorf("want 2, got %v", count)
+	}
+	expect(t, c, "Hello", "multi")
+	expect(t, c, "Hola", "multi")
+
 	// Flags
 	stored, err = c.Set("Hello", 0xFFFF, 0, []byte("world"))
 	if err != nil {
@@ -145,6 +145,9 @@ const (
 	// ErrDataOutOfRange is C.ER_WARN_DATA_OUT_OF_RANGE
 	ErrDataOutOfRange = C.ER_WARN_DATA_OUT_OF_RANGE
 
+	// ErrNetPacketTooLarge is C.ER_NET_PACKET_TOO_LARGE
+	ErrNetPacketTooLarge = C.ER_NET_PACKET_TOO_LARGE
+
 	// ErrServerLost is C.CR_SERVER_LOST.
 	// It's hard-coded for now because it causes problems on import.
 	ErrServerLost = 2013
@@ -379,6 +382,7 @@ func (conn *Connection) lastError(query string) error {
 	if err := C.vt_error(&conn.c); *err != 0 {
 		return &sqldb.SQLError{
 			Num:     int(C.vt_errno(&conn.c)),
+			State:   C.GoString(C.vt_sqlstate(&conn.c)),
 			Message: C.GoString(err),
 			Query:   query,
 		}
@@ -8,6 +8,8 @@ import (
 	"fmt"
 	"sync"
 	"time"
+
+	"github.com/youtube/vitess/go/clock"
 )
 
 // Numbered allows you to manage resources by tracking them with numbers.
@@ -16,6 +18,7 @@ type Numbered struct {
 	mu        sync.Mutex
 	empty     *sync.Cond // Broadcast when pool becomes empty
 	resources map[int64]*numberedWrapper
+	clock     clock.Clock
 }
 
 type numberedWrapper struct {
@@ -27,7 +30,16 @@ type numberedWrapper struct {
 }
 
 func NewNumbered() *Numbered {
-	n := &Numbered{resources: make(map[int64]*numberedWrapper)}
+	return NewNumberedWithClock(clock.Real)
+}
+
+// NewNumberedWithClock returns a Numbered that measures the age and the
+// idle time of the resources with c.
+func NewNumberedWithClock(c clock.Clock) *Numbered {
+	n := &Numbered{
+		resources: make(map[int64]*numberedWrapper),
+		clock:     c,
+	}
 	n.empty = sync.NewCond(&n.mu)
 	return n
 }
@@ -41,7 +53,7 @@ func (nu *Numbered) Register(id int64, val interface{}) error {
 	if _, ok := nu.resources[id]; ok {
 		return fmt.Errorf("already present")
 	}
-	now := time.Now()
+	now := nu.clock.Now()
 	nu.resources[id] = &numberedWrapper{
 		val:         val,
 		timeCreated: now,
@@ -86,7 +98,7 @@ func (nu *Numbered) Put(id int64) {
 	if nw, ok := nu.resources[id]; ok {
 		nw.inUse = false
 		nw.purpose = ""
-		nw.timeUsed = time.Now()
+		nw.timeUsed = nu.clock.Now()
 	}
 }
 
@@ -106,7 +118,7 @@ func (nu *Numbered) GetAll() (vals []interface{}) {
 func (nu *Numbered) GetOutdated(age time.Duration, purpose string) (vals []interface{}) {
 	nu.mu.Lock()
 	defer nu.mu.Unlock()
-	now := time.Now()
+	now := nu.clock.Now()
 	for _, nw := range nu.resources {
 		if nw.inUse {
 			continue
@@ -126,7 +138,7 @@ func (nu *Numbered) GetOutdated(age time.Duration, purpose string) (vals []inter
 func (nu *Numbered) GetIdle(timeout time.Duration, purpose string) (vals []interface{}) {
 	nu.mu.Lock()
 	defer nu.mu.Unlock()
-	now := time.Now()
+	now := nu.clock.Now()
 	for _, nw := range nu.resources {
 		if nw.inUse {
 			continue
@@ -95,6 +95,13 @@ func (rp *ResourcePool) Get(ctx context.Context) (resource Resource, err error)
 	return rp.get(ctx, true)
 }
 
+// TryGet will return the next available resource. If none is available, and capacity
+// has not been reached, it will create a new one using the factory. Otherwise,
+// it will return nil with no error.
+func (rp *ResourcePool) TryGet() (resource Resource, err error) {
+	return rp.get(context.Background(), false)
+}
+
 func (rp *ResourcePool) get(ctx context.Context, wait bool) (resource Resource, err error) {
 	// If ctx has already expired, avoid racing with rp's resource channel.
 	select {
@@ -200,6 +207,34 @@ func (rp *ResourcePool) SetCapacity(capacity int) error {
 	return nil
 }
 
+// Prune calls check on the idle resources, one at a time, and closes
+// the ones it returns false for. They are replaced by new resources
+// when they're needed. A resource is not available to Get while it's
+// checked. Prune returns the number of resources it closed.
+func (rp *ResourcePool) Prune(check func(resource Resource) bool) (pruned int) {
+	for i := len(rp.resources); i > 0; i-- {
+		var wrapper resourceWrapper
+		var ok bool
+		select {
+		case wrapper, ok = <-rp.resources:
+		default:
+			return pruned
+		}
+		if !ok {
+			return pruned
+		}
+		if wrapper.resource != nil && !check(wrapper.resource) {
+			wrapper.resource.Close()
+			wrapper = resourceWrapper{}
+			pruned++
+		}
+		// The wrapper keeps its timeUsed: a check doesn't
+		// extend the idle timeout.
+		rp.resources <- wrapper
+	}
+	return pruned
+}
+
 func (rp *ResourcePool) recordWait(start time.Time) {
 	rp.waitCount.Add(1)
 	rp.waitTime.Add(time.Now().Sub(start))
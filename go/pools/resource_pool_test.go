@@ -473,6 +473,24 @@ func TestTimeout(t *testing.T) {
 	p.Put(r)
 }
 
+func TestTryGet(t *testing.T) {
+	lastID.Set(0)
+	count.Set(0)
+	p := NewResourcePool(PoolFactory, 1, 1, time.Second)
+	defer p.Close()
+	r, err := p.TryGet()
+	if r == nil || err != nil {
+		t.Fatalf("TryGet: %v, %v, want a resource", r, err)
+	}
+	if r, err := p.TryGet(); r != nil || err != nil {
+		t.Errorf("TryGet on a full pool: %v, %v, want nil, nil", r, err)
+	}
+	if waitCount := p.WaitCount(); waitCount != 0 {
+		t.Errorf("WaitCount: %v, want 0", waitCount)
+	}
+	p.Put(r)
+}
+
 func TestExpired(t *testing.T) {
 	lastID.Set(0)
 	count.Set(0)
@@ -489,3 +507,60 @@ func TestExpired(t *testing.T) {
 		t.Errorf("got %v, want %s", err, want)
 	}
 }
+
+func TestPrune(t *testing.T) {
+	ctx := context.Background()
+	lastID.Set(0)
+	count.Set(0)
+	p := NewResourcePool(PoolFactory, 3, 3, time.Second)
+	defer p.Close()
+
+	var resources [3]Resource
+	for i := range resources {
+		r, err := p.Get(ctx)
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		resources[i] = r
+	}
+	// The first resource is in use, the others are idle.
+	p.Put(resources[1])
+	p.Put(resources[2])
+
+	var checked []int64
+	pruned := p.Prune(func(r Resource) bool {
+		checked = append(checked, r.(*TestResource).num)
+		return r.(*TestResource).num != 2
+	})
+	if pruned != 1 {
+		t.Errorf("Prune: %v, want 1", pruned)
+	}
+	if len(checked) != 2 {
+		t.Errorf("checked: %v, want the 2 idle resources", checked)
+	}
+	if !resources[1].(*TestResource).closed || resources[2].(*TestResource).closed {
+		t.Errorf("closed: %v, %v, want true, false", resources[1].(*TestResource).closed, resources[2].(*TestResource).closed)
+	}
+	if count.Get() != 2 {
+		t.Errorf("Expecting 2, received %d", count.Get())
+	}
+	if p.Available() != 2 {
+		t.Errorf("Expecting 2, received %d", p.Available())
+	}
+
+	// The pruned resource is replaced by a new one.
+	p.Put(resources[0])
+	for i := 0; i < 3; i++ {
+		r, err := p.Get(ctx)
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		resources[i] = r
+	}
+	if lastID.Get() != 4 {
+		t.Errorf("Expecting 4, received %d", lastID.Get())
+	}
+	for _, r := range resources {
+		p.Put(r)
+	}
+}
@@ -9,6 +9,7 @@ import "fmt"
 // SQLError is the error structure returned from calling a db library function
 type SQLError struct {
 	Num     int
+	State   string
 	Message string
 	Query   string
 }
@@ -30,3 +31,8 @@ func (se *SQLError) Error() string {
 func (se *SQLError) Number() int {
 	return se.Num
 }
+
+// SQLState returns the SQLSTATE value, if known
+func (se *SQLError) SQLState() string {
+	return se.State
+}
@@ -67,6 +67,7 @@ func ResultToProto3(qr *Result) *querypb.QueryResult {
 		RowsAffected: qr.RowsAffected,
 		InsertId:     qr.InsertID,
 		Rows:         RowsToProto3(qr.Rows),
+		Warnings:     qr.Warnings,
 	}
 }
 
@@ -81,6 +82,7 @@ func Proto3ToResult(qr *querypb.QueryResult) *Result {
 		RowsAffected: qr.RowsAffected,
 		InsertID:     qr.InsertId,
 		Rows:         proto3ToRows(qr.Fields, qr.Rows),
+		Warnings:     qr.Warnings,
 	}
 }
 
@@ -96,6 +98,7 @@ func CustomProto3ToResult(fields []*querypb.Field, qr *querypb.QueryResult) *Res
 		RowsAffected: qr.RowsAffected,
 		InsertID:     qr.InsertId,
 		Rows:         proto3ToRows(fields, qr.Rows),
+		Warnings:     qr.Warnings,
 	}
 }
 
@@ -35,6 +35,7 @@ func TestResult(t *testing.T) {
 			NULL,
 			NULL,
 		}},
+		Warnings: []string{"truncated"},
 	}
 	p3Result := &querypb.QueryResult{
 		Fields:       fields,
@@ -47,6 +48,7 @@ func TestResult(t *testing.T) {
 			Lengths: []int64{2, -1, -1},
 			Values:  []byte("bb"),
 		}},
+		Warnings: []string{"truncated"},
 	}
 	p3converted := ResultToProto3(sqlResult)
 	if !reflect.DeepEqual(p3converted, p3Result) {
@@ -67,6 +69,29 @@ func TestResult(t *testing.T) {
 	}
 }
 
+func TestResultJSON(t *testing.T) {
+	fields := []*querypb.Field{{
+		Name: "doc",
+		Type: TypeJSON,
+	}}
+	docs := []string{
+		`{"a": {"b": [1, 2.5, {"c": null}], "d": true}}`,
+		`["é", "日本語", "\ud83d\ude00"]`,
+		`{"nul": "a\u0000b", "empty": ""}`,
+	}
+	sqlResult := &Result{
+		Fields:       fields,
+		RowsAffected: uint64(len(docs)),
+	}
+	for _, doc := range docs {
+		sqlResult.Rows = append(sqlResult.Rows, []Value{MakeTrusted(TypeJSON, []byte(doc))})
+	}
+	reverse := Proto3ToResult(ResultToProto3(sqlResult))
+	if !reflect.DeepEqual(reverse, sqlResult) {
+		t.Errorf("reverse:\n%#v, want\n%#v", reverse, sqlResult)
+	}
+}
+
 func TestResults(t *testing.T) {
 	fields1 := []*querypb.Field{{
 		Name: "col1",
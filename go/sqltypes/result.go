@@ -12,6 +12,9 @@ type Result struct {
 	RowsAffected uint64           `json:"rows_affected"`
 	InsertID     uint64           `json:"insert_id"`
 	Rows         [][]Value        `json:"rows"`
+	// Warnings are the warnings of the query, like the truncation
+	// of the result by vtgate's -max_unbounded_query_rows.
+	Warnings []string `json:"warnings,omitempty"`
 }
 
 // ResultStream is an interface for receiving Result. It is used for
@@ -67,6 +70,9 @@ func (result *Result) Copy() *Result {
 		}
 		out.Rows = rows
 	}
+	if result.Warnings != nil {
+		out.Warnings = append([]string(nil), result.Warnings...)
+	}
 	return out
 }
 
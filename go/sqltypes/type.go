@@ -94,6 +94,7 @@ const (
 	Enum      = querypb.Type_ENUM
 	Set       = querypb.Type_SET
 	Tuple     = querypb.Type_TUPLE
+	TypeJSON  = querypb.Type_JSON
 )
 
 // bit-shift the mysql flags by two byte so we
@@ -122,6 +123,7 @@ var mysqlToType = map[int64]querypb.Type{
 	12:  Datetime,
 	13:  Year,
 	16:  Bit,
+	245: TypeJSON,
 	246: Decimal,
 	249: Text,
 	250: Text,
@@ -229,6 +231,7 @@ var typeToMySQL = map[querypb.Type]struct {
 	Binary:    {typ: 254, flags: mysqlBinary},
 	Enum:      {typ: 254, flags: mysqlEnum},
 	Set:       {typ: 254, flags: mysqlSet},
+	TypeJSON:  {typ: 245},
 }
 
 // TypeToMySQL returns the equivalent mysql type and flag for a vitess type.
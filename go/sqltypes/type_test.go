@@ -101,6 +101,9 @@ func TestTypeValues(t *testing.T) {
 	}, {
 		defined:  Tuple,
 		expected: 28,
+	}, {
+		defined:  TypeJSON,
+		expected: 29 | flagIsQuoted,
 	}}
 	for _, tcase := range testcases {
 		if int(tcase.defined) != tcase.expected {
@@ -169,6 +172,10 @@ func TestTypeToMySQL(t *testing.T) {
 	if f != mysqlBinary {
 		t.Errorf("Bit flag: %x, want %x", f, mysqlBinary)
 	}
+	v, f = TypeToMySQL(TypeJSON)
+	if v != 245 || f != 0 {
+		t.Errorf("TypeJSON: %d, %x, want 245, 0", v, f)
+	}
 }
 
 func TestMySQLToType(t *testing.T) {
@@ -279,6 +286,15 @@ func TestMySQLToType(t *testing.T) {
 		intype:  254,
 		inflags: mysqlSet,
 		outtype: Set,
+	}, {
+		intype:  245,
+		outtype: TypeJSON,
+	}, {
+		// MySQL reports the JSON columns with the binary
+		// charset, the binary flag must be ignored.
+		intype:  245,
+		inflags: mysqlBinary,
+		outtype: TypeJSON,
 	}, {
 		// Binary flag must be ignored.
 		intype:  8,
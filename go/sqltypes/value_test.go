@@ -610,6 +610,12 @@ func TestEncode(t *testing.T) {
 		in:       testVal(VarChar, "\x00'\"\b\n\r\t\x1A\\"),
 		outSQL:   "'\\0\\'\\\"\\b\\n\\r\\t\\Z\\\\'",
 		outASCII: "'ACciCAoNCRpc'",
+	}, {
+		// The backslashes of the JSON escapes are escaped, so that
+		// MySQL gets the document unchanged.
+		in:       testVal(TypeJSON, `{"a": "\u0000'"}`),
+		outSQL:   `'{\"a\": \"\\u0000\'\"}'`,
+		outASCII: "'eyJhIjogIlx1MDAwMCcifQ=='",
 	}}
 	for _, tcase := range testcases {
 		buf := &bytes.Buffer{}
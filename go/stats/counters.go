@@ -6,12 +6,19 @@ package stats
 
 import (
 	"bytes"
+	"flag"
 	"fmt"
+	"io"
+	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
+
+	"github.com/youtube/vitess/go/sync2"
 )
 
+var multiCountersMaxCombinations = flag.Int("stats_multi_counters_max_combinations", 0, "maximum number of label combinations of each MultiCounters. The values of the combinations beyond it are added to a single combination where all the labels are 'other'. 0 means no limit. MultiCounters.SetMaxCombinations overrides it.")
+
 // Counters is similar to expvar.Map, except that
 // it doesn't allow floats. In addition, it provides
 // a Counts method which can be used for tracking rates.
@@ -57,6 +64,48 @@ func (c *Counters) String() string {
 	return b.String()
 }
 
+// WriteJSON writes the same JSON as String to w, without building
+// it in memory first.
+func (c *Counters) WriteJSON(w io.Writer) {
+	// Only the values are copied, to not hold the lock while writing
+	// to w. The names are not copied.
+	c.mu.RLock()
+	values := make([]namedCount, 0, len(c.counts))
+	for k, a := range c.counts {
+		values = append(values, namedCount{k, atomic.LoadInt64(a)})
+	}
+	c.mu.RUnlock()
+	writeNamedCounts(w, values)
+}
+
+// namedCount is a value of Counters, with its name.
+type namedCount struct {
+	name  string
+	value int64
+}
+
+// writeNamedCounts writes values as a JSON object, in the format of
+// Counters.String.
+func writeNamedCounts(w io.Writer, values []namedCount) {
+	buf := make([]byte, 0, 256)
+	buf = append(buf, '{')
+	for i, v := range values {
+		if i != 0 {
+			buf = append(buf, ", "...)
+		}
+		buf = append(buf, '"')
+		buf = append(buf, v.name...)
+		buf = append(buf, `": `...)
+		buf = strconv.AppendInt(buf, v.value, 10)
+		if len(buf) > 128 {
+			w.Write(buf)
+			buf = buf[:0]
+		}
+	}
+	buf = append(buf, '}')
+	w.Write(buf)
+}
+
 func (c *Counters) getValueAddr(name string) *int64 {
 	c.mu.RLock()
 	a, ok := c.counts[name]
@@ -79,6 +128,35 @@ func (c *Counters) getValueAddr(name string) *int64 {
 	return a
 }
 
+// getValueAddrBounded is getValueAddr, except that if name doesn't
+// exist and there are already max names, it returns the address of
+// the value of other. other is created beyond max.
+func (c *Counters) getValueAddrBounded(name, other string, max int) *int64 {
+	c.mu.RLock()
+	a, ok := c.counts[name]
+	c.mu.RUnlock()
+
+	if ok {
+		return a
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	a, ok = c.counts[name]
+	if ok {
+		return a
+	}
+	if len(c.counts) >= max {
+		name = other
+		if a, ok = c.counts[name]; ok {
+			return a
+		}
+	}
+	a = new(int64)
+	c.counts[name] = a
+	return a
+}
+
 // Add adds a value to a named counter.
 func (c *Counters) Add(name string, value int64) {
 	a := c.getValueAddr(name)
@@ -119,6 +197,17 @@ func (f CountersFunc) Counts() map[string]int64 {
 	return f()
 }
 
+// WriteJSON writes the same JSON as String to w, without building
+// it in memory first.
+func (f CountersFunc) WriteJSON(w io.Writer) {
+	m := f()
+	values := make([]namedCount, 0, len(m))
+	for k, v := range m {
+		values = append(values, namedCount{k, v})
+	}
+	writeNamedCounts(w, values)
+}
+
 // String is used by expvar.
 func (f CountersFunc) String() string {
 	m := f()
@@ -146,6 +235,12 @@ func (f CountersFunc) String() string {
 type MultiCounters struct {
 	Counters
 	labels []string
+	// maxCombinations is the limit set by SetMaxCombinations. If 0,
+	// the limit is -stats_multi_counters_max_combinations.
+	maxCombinations sync2.AtomicInt64
+	// other is the name of the combination where all the labels
+	// are "other".
+	other string
 }
 
 // NewMultiCounters creates a new MultiCounters instance, and publishes it
@@ -154,6 +249,7 @@ func NewMultiCounters(name string, labels []string) *MultiCounters {
 	t := &MultiCounters{
 		Counters: Counters{counts: make(map[string]*int64)},
 		labels:   labels,
+		other:    strings.TrimPrefix(strings.Repeat(".other", len(labels)), "."),
 	}
 	if name != "" {
 		Publish(name, t)
@@ -161,6 +257,26 @@ func NewMultiCounters(name string, labels []string) *MultiCounters {
 	return t
 }
 
+// SetMaxCombinations sets the maximum number of label combinations,
+// overriding -stats_multi_counters_max_combinations. Beyond it, Add
+// and Set update the combination where all the labels are "other".
+// 0 restores the default.
+func (mc *MultiCounters) SetMaxCombinations(max int) {
+	mc.maxCombinations.Set(int64(max))
+}
+
+func (mc *MultiCounters) valueAddr(names []string) *int64 {
+	max := int(mc.maxCombinations.Get())
+	if max == 0 {
+		max = *multiCountersMaxCombinations
+	}
+	name := strings.Join(names, ".")
+	if max <= 0 {
+		return mc.Counters.getValueAddr(name)
+	}
+	return mc.Counters.getValueAddrBounded(name, mc.other, max)
+}
+
 // Labels returns the list of labels.
 func (mc *MultiCounters) Labels() []string {
 	return mc.labels
@@ -172,7 +288,7 @@ func (mc *MultiCounters) Add(names []string, value int64) {
 	if len(names) != len(mc.labels) {
 		panic("MultiCounters: wrong number of values in Add")
 	}
-	mc.Counters.Add(strings.Join(names, "."), value)
+	atomic.AddInt64(mc.valueAddr(names), value)
 }
 
 // Set sets the value of a named counter. len(names) must be equal to
@@ -181,7 +297,7 @@ func (mc *MultiCounters) Set(names []string, value int64) {
 	if len(names) != len(mc.labels) {
 		panic("MultiCounters: wrong number of values in Set")
 	}
-	mc.Counters.Set(strings.Join(names, "."), value)
+	atomic.StoreInt64(mc.valueAddr(names), value)
 }
 
 // MultiCountersFunc is a multidimensional CountersFunc implementation
@@ -88,6 +88,33 @@ func TestMultiCounters(t *testing.T) {
 	}
 }
 
+func TestMultiCountersMaxCombinations(t *testing.T) {
+	clear()
+	c := NewMultiCounters("", []string{"aaa", "bbb"})
+	c.SetMaxCombinations(2)
+	c.Add([]string{"c1a", "c1b"}, 1)
+	c.Add([]string{"c2a", "c2b"}, 1)
+	c.Add([]string{"c3a", "c3b"}, 1)
+	c.Set([]string{"c4a", "c4b"}, 5)
+	c.Add([]string{"c1a", "c1b"}, 1)
+	want := map[string]int64{"c1a.c1b": 2, "c2a.c2b": 1, "other.other": 5}
+	if got := c.Counts(); !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+
+	// The flag applies to the counters without a limit of their own.
+	defer func(v int) { *multiCountersMaxCombinations = v }(*multiCountersMaxCombinations)
+	*multiCountersMaxCombinations = 1
+	c.SetMaxCombinations(0)
+	c.Reset()
+	c.Add([]string{"c1a", "c1b"}, 1)
+	c.Add([]string{"c2a", "c2b"}, 1)
+	want = map[string]int64{"c1a.c1b": 1, "other.other": 1}
+	if got := c.Counts(); !reflect.DeepEqual(got, want) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+}
+
 func TestCountersHook(t *testing.T) {
 	var gotname string
 	var gotv *Counters
@@ -0,0 +1,85 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import (
+	"bufio"
+	"expvar"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// JSONWriter is implemented by the variables that can write their
+// JSON representation directly, instead of building it with String.
+// ServeVars uses it for the variables with many values.
+type JSONWriter interface {
+	WriteJSON(w io.Writer)
+}
+
+// ServeVars serves the same JSON as the /debug/vars handler of
+// expvar, but it streams the variables to the response: the ones
+// that implement JSONWriter are not built in memory first.
+// The "prefix" parameter restricts the output to the variables whose
+// name starts with one of its values. It can be repeated, or have
+// values separated by commas.
+func ServeVars(w http.ResponseWriter, r *http.Request) {
+	var prefixes []string
+	if err := r.ParseForm(); err == nil {
+		for _, value := range r.Form["prefix"] {
+			for _, prefix := range strings.Split(value, ",") {
+				if prefix != "" {
+					prefixes = append(prefixes, prefix)
+				}
+			}
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	bw := bufio.NewWriterSize(w, 32*1024)
+	defer bw.Flush()
+	writeVars(bw, prefixes)
+}
+
+// writeVars writes the expvar variables whose name starts with one
+// of prefixes, or all of them if prefixes is empty. The variables are
+// listed first: expvar.Do holds the lock of expvar, which a slow
+// client must not hold while it reads the output.
+func writeVars(w *bufio.Writer, prefixes []string) {
+	var vars []expvar.KeyValue
+	expvar.Do(func(kv expvar.KeyValue) {
+		if hasAnyPrefix(kv.Key, prefixes) {
+			vars = append(vars, kv)
+		}
+	})
+
+	w.WriteString("{\n")
+	for i, kv := range vars {
+		if i != 0 {
+			w.WriteString(",\n")
+		}
+		w.WriteString(strconv.Quote(kv.Key))
+		w.WriteString(": ")
+		if jw, ok := kv.Value.(JSONWriter); ok {
+			jw.WriteJSON(w)
+		} else {
+			w.WriteString(kv.Value.String())
+		}
+	}
+	w.WriteString("\n}\n")
+}
+
+func hasAnyPrefix(name string, prefixes []string) bool {
+	if len(prefixes) == 0 {
+		return true
+	}
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
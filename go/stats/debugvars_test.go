@@ -0,0 +1,186 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stats
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestServeVars(t *testing.T) {
+	clear()
+	c := NewMultiCounters("DebugVarsCounts", []string{"Table", "Plan"})
+	c.Add([]string{"t1", "select"}, 1)
+	c.Add([]string{"t2", "insert"}, 2)
+	NewInt("DebugVarsInt").Set(3)
+	NewString("OtherDebugVarsString").Set("s")
+
+	testcases := []struct {
+		query string
+		want  map[string]interface{}
+	}{{
+		query: "prefix=DebugVars",
+		want: map[string]interface{}{
+			"DebugVarsCounts": map[string]interface{}{"t1.select": 1.0, "t2.insert": 2.0},
+			"DebugVarsInt":    3.0,
+		},
+	}, {
+		query: "prefix=DebugVarsI,OtherDebugVars",
+		want: map[string]interface{}{
+			"DebugVarsInt":         3.0,
+			"OtherDebugVarsString": "s",
+		},
+	}, {
+		query: "prefix=DebugVarsCounts&prefix=OtherDebugVars",
+		want: map[string]interface{}{
+			"DebugVarsCounts":      map[string]interface{}{"t1.select": 1.0, "t2.insert": 2.0},
+			"OtherDebugVarsString": "s",
+		},
+	}, {
+		query: "prefix=Unknown",
+		want:  map[string]interface{}{},
+	}}
+	for _, tcase := range testcases {
+		req, _ := http.NewRequest("GET", "/debug/vars?"+tcase.query, nil)
+		w := httptest.NewRecorder()
+		ServeVars(w, req)
+		got := make(map[string]interface{})
+		if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+			t.Errorf("%v: invalid JSON: %v, body: %s", tcase.query, err, w.Body.String())
+			continue
+		}
+		if !reflect.DeepEqual(got, tcase.want) {
+			t.Errorf("%v: %v, want %v", tcase.query, got, tcase.want)
+		}
+	}
+
+	// Without prefix, all the variables are served, including the
+	// ones of expvar.
+	req, _ := http.NewRequest("GET", "/debug/vars", nil)
+	w := httptest.NewRecorder()
+	ServeVars(w, req)
+	got := make(map[string]interface{})
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON: %v, body: %s", err, w.Body.String())
+	}
+	for _, name := range []string{"DebugVarsCounts", "DebugVarsInt", "OtherDebugVarsString", "memstats", "cmdline"} {
+		if _, ok := got[name]; !ok {
+			t.Errorf("variable %v missing from %v", name, w.Body.String())
+		}
+	}
+	if ct := w.HeaderMap.Get("Content-Type"); ct != "application/json; charset=utf-8" {
+		t.Errorf("Content-Type: %v, want application/json; charset=utf-8", ct)
+	}
+}
+
+// blockingWriter blocks its first Write until unblock is closed.
+type blockingWriter struct {
+	blocked chan struct{}
+	unblock chan struct{}
+}
+
+func (bw *blockingWriter) Write(p []byte) (int, error) {
+	select {
+	case <-bw.blocked:
+	default:
+		close(bw.blocked)
+		<-bw.unblock
+	}
+	return len(p), nil
+}
+
+func TestWriteVarsSlowReader(t *testing.T) {
+	NewInt("DebugVarsSlowReaderInt").Set(1)
+	bw := &blockingWriter{blocked: make(chan struct{}), unblock: make(chan struct{})}
+	done := make(chan struct{})
+	go func() {
+		writeVars(bufio.NewWriterSize(bw, 16), []string{"DebugVarsSlowReader"})
+		close(done)
+	}()
+	<-bw.blocked
+
+	// The variables can be published while a client reads them.
+	published := make(chan struct{})
+	go func() {
+		NewInt("DebugVarsSlowReaderPublished")
+		close(published)
+	}()
+	select {
+	case <-published:
+	case <-time.After(5 * time.Second):
+		t.Errorf("Publish blocked by a slow reader of the variables")
+	}
+	close(bw.unblock)
+	<-done
+}
+
+func TestWriteJSON(t *testing.T) {
+	c := NewCounters("")
+	for i := 0; i < 100; i++ {
+		c.Add(fmt.Sprintf("c%v", i), int64(i))
+	}
+	f := CountersFunc(c.Counts)
+	for _, v := range []JSONWriter{c, f, CountersFunc(func() map[string]int64 { return nil })} {
+		buf := &bytes.Buffer{}
+		v.WriteJSON(buf)
+		got := make(map[string]int64)
+		if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+			t.Errorf("invalid JSON: %v, %s", err, buf.String())
+			continue
+		}
+		want := make(map[string]int64)
+		if err := json.Unmarshal([]byte(v.(fmt.Stringer).String()), &want); err != nil {
+			t.Errorf("invalid JSON: %v, %s", err, v.(fmt.Stringer).String())
+			continue
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("WriteJSON: %v, want %v", got, want)
+		}
+	}
+}
+
+// newBenchMultiCounters returns a MultiCounters with 50k label
+// combinations, like the per-table and per-caller ones of a big
+// tablet.
+func newBenchMultiCounters() *MultiCounters {
+	c := NewMultiCounters("", []string{"Table", "Caller"})
+	for i := 0; i < 500; i++ {
+		for j := 0; j < 100; j++ {
+			c.Add([]string{fmt.Sprintf("table%v", i), fmt.Sprintf("caller%v", j)}, 1)
+		}
+	}
+	return c
+}
+
+// BenchmarkMultiCountersString is the cost of a scrape of the
+// counters with the handler of expvar, that builds the whole JSON in
+// memory.
+func BenchmarkMultiCountersString(b *testing.B) {
+	c := newBenchMultiCounters()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ioutil.Discard.Write([]byte(c.String()))
+	}
+}
+
+// BenchmarkMultiCountersWriteJSON is the cost of a scrape of the
+// counters with ServeVars, that streams them.
+func BenchmarkMultiCountersWriteJSON(b *testing.B) {
+	c := newBenchMultiCounters()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.WriteJSON(ioutil.Discard)
+	}
+}
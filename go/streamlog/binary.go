@@ -0,0 +1,66 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamlog
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/golang/protobuf/proto"
+)
+
+// BinaryFormat is the value of the "format" param that asks ServeLogs
+// for protobuf messages instead of text. Each message returned by the
+// formatting function must then be encoded by EncodeBinary.
+const BinaryFormat = "binary"
+
+// maxBinaryMessageSize is the size above which BinaryReader refuses
+// a message, so that a corrupted stream doesn't allocate too much.
+const maxBinaryMessageSize = 64 * 1024 * 1024
+
+// EncodeBinary serializes pb and prefixes it with its length, as a
+// varint.
+func EncodeBinary(pb proto.Message) (string, error) {
+	data, err := proto.Marshal(pb)
+	if err != nil {
+		return "", err
+	}
+	prefix := make([]byte, binary.MaxVarintLen64)
+	n := binary.PutUvarint(prefix, uint64(len(data)))
+	return string(prefix[:n]) + string(data), nil
+}
+
+// BinaryReader decodes the messages of a stream served in BinaryFormat.
+type BinaryReader struct {
+	r *bufio.Reader
+}
+
+// NewBinaryReader returns a BinaryReader that reads the messages from r.
+func NewBinaryReader(r io.Reader) *BinaryReader {
+	return &BinaryReader{r: bufio.NewReader(r)}
+}
+
+// Read decodes the next message of the stream into pb. It returns
+// io.EOF at the end of the stream, and io.ErrUnexpectedEOF if the
+// stream ends in the middle of a message.
+func (br *BinaryReader) Read(pb proto.Message) error {
+	size, err := binary.ReadUvarint(br.r)
+	if err != nil {
+		return err
+	}
+	if size > maxBinaryMessageSize {
+		return fmt.Errorf("message of %v bytes is larger than the limit of %v bytes", size, maxBinaryMessageSize)
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(br.r, data); err != nil {
+		if err == io.EOF {
+			return io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	return proto.Unmarshal(data, pb)
+}
@@ -0,0 +1,54 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamlog
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	logutilpb "github.com/youtube/vitess/go/vt/proto/logutil"
+)
+
+func TestBinaryReader(t *testing.T) {
+	events := []*logutilpb.Event{
+		{Level: logutilpb.Level_WARNING, File: "file.go", Line: 12, Value: "first\tline\n"},
+		{},
+		{Time: &logutilpb.Time{Seconds: 1458000000, Nanoseconds: 123}, Value: strings.Repeat("x", 300)},
+	}
+	var stream string
+	for _, event := range events {
+		encoded, err := EncodeBinary(event)
+		if err != nil {
+			t.Fatalf("EncodeBinary(%v): %v", event, err)
+		}
+		stream += encoded
+	}
+
+	br := NewBinaryReader(strings.NewReader(stream))
+	for _, want := range events {
+		got := &logutilpb.Event{}
+		if err := br.Read(got); err != nil {
+			t.Fatalf("Read: %v", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Read: %v, want %v", got, want)
+		}
+	}
+	if err := br.Read(&logutilpb.Event{}); err != io.EOF {
+		t.Errorf("Read at the end of the stream: %v, want %v", err, io.EOF)
+	}
+
+	// The stream ends in the middle of a message.
+	br = NewBinaryReader(strings.NewReader(stream[:len(stream)-1]))
+	var err error
+	for err == nil {
+		err = br.Read(&logutilpb.Event{})
+	}
+	if err != io.ErrUnexpectedEOF {
+		t.Errorf("Read of a truncated stream: %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+}
@@ -0,0 +1,257 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamlog
+
+import (
+	"container/list"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"github.com/youtube/vitess/go/timer"
+)
+
+// dedupMessage is implemented by messages that can be deduplicated:
+// the messages with the same DedupKey are duplicates.
+type dedupMessage interface {
+	DedupKey() string
+}
+
+// DedupSummary is sent by a logger with deduplication after an
+// interval during which it suppressed duplicates of a message. See
+// SetDedup.
+type DedupSummary struct {
+	// Message is the last suppressed duplicate.
+	Message interface{}
+	// Suppressed is the number of duplicates that were not sent.
+	Suppressed int64
+	// TotalTime is the sum of the TotalTime of the suppressed
+	// duplicates, if they have a TotalTime method.
+	TotalTime time.Duration
+	// Start is the beginning of the interval, and End when the
+	// summary was made.
+	Start time.Time
+	End   time.Time
+}
+
+// WithDedup makes the logger deduplicate its messages. See SetDedup.
+func WithDedup(limit int, interval time.Duration, maxKeys int, minDuration time.Duration) Option {
+	return func(logger *StreamLogger) {
+		logger.SetDedup(limit, interval, maxKeys, minDuration)
+	}
+}
+
+// dedupFlushesPerInterval is how many times per interval the deduper
+// looks for the intervals that ended, to send their summaries even if
+// no other message comes.
+const dedupFlushesPerInterval = 10
+
+// SetDedup makes the logger send only the first limit messages with
+// the same DedupKey method per interval. Only the messages that have a
+// TotalTime method and took at least minDuration are deduplicated, all
+// of them if minDuration is 0. The duplicates beyond limit are
+// counted, and a DedupSummary is sent soon after the end of the
+// interval. The keys of at most maxKeys messages are remembered: the
+// least recently seen is forgotten, and its summary sent, when a new
+// one comes. A limit of 0 disables deduplication. The summaries of
+// the previous settings are sent when they change.
+func (logger *StreamLogger) SetDedup(limit int, interval time.Duration, maxKeys int, minDuration time.Duration) {
+	var d *deduper
+	if limit > 0 {
+		d = newDeduper(limit, interval, maxKeys, minDuration)
+		d.timer.Start(func() {
+			for _, summary := range d.expired() {
+				logger.broadcast(summary)
+			}
+		})
+	}
+	previous, _ := logger.dedup.Load().(*deduper)
+	logger.dedup.Store(d)
+	if previous != nil {
+		for _, summary := range previous.stop() {
+			logger.broadcast(summary)
+		}
+	}
+}
+
+// deduplicate returns true if message must be sent, and the summaries
+// to send before it.
+func (logger *StreamLogger) deduplicate(message interface{}) (bool, []*DedupSummary) {
+	d, _ := logger.dedup.Load().(*deduper)
+	if d == nil {
+		return true, nil
+	}
+	return d.filter(message)
+}
+
+// deduper counts the messages by key, in a LRU list.
+type deduper struct {
+	limit       int
+	interval    time.Duration
+	maxKeys     int
+	minDuration time.Duration
+	// now returns the current time. The tests replace it.
+	now func() time.Time
+	// timer sends the summaries of the intervals that ended.
+	timer *timer.Timer
+
+	mu sync.Mutex
+	// lru has the *dedupEntry, the most recently seen first.
+	lru     *list.List
+	entries map[uint64]*list.Element
+	// lastFlush is the last time the expired entries were removed.
+	lastFlush time.Time
+}
+
+// dedupEntry counts the duplicates of a message since start.
+type dedupEntry struct {
+	key        uint64
+	start      time.Time
+	count      int
+	suppressed int64
+	totalTime  time.Duration
+	last       interface{}
+}
+
+func newDeduper(limit int, interval time.Duration, maxKeys int, minDuration time.Duration) *deduper {
+	if maxKeys < 1 {
+		maxKeys = 1
+	}
+	return &deduper{
+		limit:       limit,
+		interval:    interval,
+		maxKeys:     maxKeys,
+		minDuration: minDuration,
+		now:         time.Now,
+		timer:       timer.NewTimer(interval / dedupFlushesPerInterval),
+		lru:         list.New(),
+		entries:     make(map[uint64]*list.Element),
+	}
+}
+
+// dedupKey hashes the key of message, so that the entries don't keep
+// long keys in memory.
+func dedupKey(dm dedupMessage) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(dm.DedupKey()))
+	return h.Sum64()
+}
+
+// filter returns true if message must be sent, and the summaries of
+// the intervals that ended, or whose entries were evicted.
+func (d *deduper) filter(message interface{}) (bool, []*DedupSummary) {
+	dm, ok := message.(dedupMessage)
+	if !ok {
+		return true, nil
+	}
+	if d.minDuration > 0 {
+		if tm, ok := message.(timedMessage); !ok || tm.TotalTime() < d.minDuration {
+			return true, nil
+		}
+	}
+	key := dedupKey(dm)
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	now := d.now()
+	var summaries []*DedupSummary
+	if now.Sub(d.lastFlush) >= d.interval {
+		summaries = d.flush(now)
+	}
+
+	var entry *dedupEntry
+	if element, ok := d.entries[key]; ok {
+		entry = element.Value.(*dedupEntry)
+		if now.Sub(entry.start) >= d.interval {
+			if s := entry.summary(now); s != nil {
+				summaries = append(summaries, s)
+			}
+			*entry = dedupEntry{key: key, start: now}
+		}
+		d.lru.MoveToFront(element)
+	} else {
+		entry = &dedupEntry{key: key, start: now}
+		d.entries[key] = d.lru.PushFront(entry)
+		if d.lru.Len() > d.maxKeys {
+			if s := d.evict(d.lru.Back(), now); s != nil {
+				summaries = append(summaries, s)
+			}
+		}
+	}
+
+	entry.count++
+	if entry.count <= d.limit {
+		return true, summaries
+	}
+	entry.suppressed++
+	if tm, ok := message.(timedMessage); ok {
+		entry.totalTime += tm.TotalTime()
+	}
+	entry.last = message
+	return false, summaries
+}
+
+// expired removes the entries whose interval ended, and returns their
+// summaries.
+func (d *deduper) expired() []*DedupSummary {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.flush(d.now())
+}
+
+// stop stops the timer of the deduper, and returns the summaries of
+// all its entries.
+func (d *deduper) stop() []*DedupSummary {
+	d.timer.Stop()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	now := d.now()
+	var summaries []*DedupSummary
+	for d.lru.Len() > 0 {
+		if s := d.evict(d.lru.Front(), now); s != nil {
+			summaries = append(summaries, s)
+		}
+	}
+	return summaries
+}
+
+// flush removes the entries whose interval ended, and returns their
+// summaries.
+func (d *deduper) flush(now time.Time) []*DedupSummary {
+	d.lastFlush = now
+	var summaries []*DedupSummary
+	for element := d.lru.Front(); element != nil; {
+		next := element.Next()
+		if now.Sub(element.Value.(*dedupEntry).start) >= d.interval {
+			if s := d.evict(element, now); s != nil {
+				summaries = append(summaries, s)
+			}
+		}
+		element = next
+	}
+	return summaries
+}
+
+// evict removes the entry of element, and returns its summary.
+func (d *deduper) evict(element *list.Element, now time.Time) *DedupSummary {
+	entry := d.lru.Remove(element).(*dedupEntry)
+	delete(d.entries, entry.key)
+	return entry.summary(now)
+}
+
+// summary returns the summary of the entry, or nil if no duplicate was
+// suppressed.
+func (entry *dedupEntry) summary(now time.Time) *DedupSummary {
+	if entry.suppressed == 0 {
+		return nil
+	}
+	return &DedupSummary{
+		Message:    entry.last,
+		Suppressed: entry.suppressed,
+		TotalTime:  entry.totalTime,
+		Start:      entry.start,
+		End:        now,
+	}
+}
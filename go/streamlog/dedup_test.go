@@ -0,0 +1,180 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamlog
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+type dedupLogMessage struct {
+	key      string
+	duration time.Duration
+}
+
+func (l *dedupLogMessage) DedupKey() string {
+	return l.key
+}
+
+func (l *dedupLogMessage) TotalTime() time.Duration {
+	return l.duration
+}
+
+// receive returns the messages waiting in ch.
+func receive(ch chan interface{}) []interface{} {
+	var messages []interface{}
+	for len(ch) > 0 {
+		messages = append(messages, <-ch)
+	}
+	return messages
+}
+
+func TestDedup(t *testing.T) {
+	logger := New("dedup", 100, WithDedup(2, time.Minute, 2, 0))
+	now := time.Unix(1000, 0)
+	d := logger.dedup.Load().(*deduper)
+	d.now = func() time.Time { return now }
+	ch := logger.Subscribe("test")
+	defer logger.Unsubscribe(ch)
+	before := dedupCount.Counts()["dedup"]
+
+	a1 := &dedupLogMessage{"a", time.Second}
+	a2 := &dedupLogMessage{"a", 2 * time.Second}
+	a3 := &dedupLogMessage{"a", 3 * time.Second}
+	a4 := &dedupLogMessage{"a", 4 * time.Second}
+	b := &dedupLogMessage{"b", time.Second}
+	other := &logMessage{"other"}
+	for _, m := range []interface{}{a1, b, a2, a3, other, other, other, a4} {
+		logger.Send(m)
+	}
+	if got, want := receive(ch), []interface{}{a1, b, a2, other, other, other}; !reflect.DeepEqual(got, want) {
+		t.Errorf("sent messages: %v, want %v", got, want)
+	}
+	if got, want := dedupCount.Counts()["dedup"]-before, int64(2); got != want {
+		t.Errorf("StreamlogDeduplicated: %v, want %v", got, want)
+	}
+
+	// The summary comes with the first message after the interval.
+	start := now
+	now = now.Add(time.Minute)
+	logger.Send(b)
+	want := []interface{}{
+		&DedupSummary{Message: a4, Suppressed: 2, TotalTime: 7 * time.Second, Start: start, End: now},
+		b,
+	}
+	if got := receive(ch); !reflect.DeepEqual(got, want) {
+		t.Errorf("sent messages after the interval: %v, want %v", got, want)
+	}
+
+	// The least recently seen key is evicted, with its summary.
+	start = now
+	for _, m := range []interface{}{a1, a2, a3, b} {
+		logger.Send(m)
+	}
+	c := &dedupLogMessage{"c", time.Second}
+	logger.Send(c)
+	want = []interface{}{
+		a1,
+		a2,
+		b,
+		&DedupSummary{Message: a3, Suppressed: 1, TotalTime: 3 * time.Second, Start: start, End: now},
+		c,
+	}
+	if got := receive(ch); !reflect.DeepEqual(got, want) {
+		t.Errorf("sent messages with an eviction: %v, want %v", got, want)
+	}
+	if got := d.lru.Len(); got != 2 {
+		t.Errorf("remembered keys: %v, want 2", got)
+	}
+
+	// Deduplication can be disabled.
+	logger.SetDedup(0, time.Minute, 2, 0)
+	for i := 0; i < 5; i++ {
+		logger.Send(a1)
+	}
+	if got := len(receive(ch)); got != 5 {
+		t.Errorf("sent messages without dedup: %v, want 5", got)
+	}
+}
+
+func TestDedupWindowEnd(t *testing.T) {
+	logger := New("dedup", 100, WithDedup(1, time.Minute, 10, 0))
+	now := time.Unix(1000, 0)
+	d := logger.dedup.Load().(*deduper)
+	d.now = func() time.Time { return now }
+	ch := logger.Subscribe("test")
+	defer logger.Unsubscribe(ch)
+
+	a := &dedupLogMessage{"a", time.Second}
+	for i := 0; i < 3; i++ {
+		logger.Send(a)
+	}
+	if got := <-ch; got != a {
+		t.Errorf("sent message: %v, want %v", got, a)
+	}
+
+	// The summary is sent at the end of the interval, without waiting
+	// for another message.
+	start := now
+	now = now.Add(time.Minute)
+	d.timer.Trigger()
+	want := &DedupSummary{Message: a, Suppressed: 2, TotalTime: 2 * time.Second, Start: start, End: now}
+	select {
+	case got := <-ch:
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("summary: %v, want %v", got, want)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("no summary at the end of the interval")
+	}
+
+	// The summaries are also sent when the deduplication is disabled.
+	for i := 0; i < 2; i++ {
+		logger.Send(a)
+	}
+	<-ch
+	logger.SetDedup(0, time.Minute, 10, 0)
+	want = &DedupSummary{Message: a, Suppressed: 1, TotalTime: time.Second, Start: now, End: now}
+	if got := receive(ch); !reflect.DeepEqual(got, []interface{}{want}) {
+		t.Errorf("sent messages when disabled: %v, want %v", got, want)
+	}
+}
+
+func TestDedupMinDuration(t *testing.T) {
+	logger := New("dedup", 100, WithDedup(1, time.Minute, 10, time.Second))
+	defer logger.SetDedup(0, time.Minute, 10, 0)
+	ch := logger.Subscribe("test")
+	defer logger.Unsubscribe(ch)
+
+	fast := &dedupLogMessage{"fast", time.Millisecond}
+	slow := &dedupLogMessage{"slow", time.Second}
+	for i := 0; i < 3; i++ {
+		logger.Send(fast)
+		logger.Send(slow)
+	}
+	if got, want := receive(ch), []interface{}{fast, slow, fast, fast}; !reflect.DeepEqual(got, want) {
+		t.Errorf("sent messages: %v, want %v", got, want)
+	}
+}
+
+func TestDedupFilter(t *testing.T) {
+	f, err := newMessageFilter(map[string][]string{"min_duration": {"2s"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tcase := range []struct {
+		duration time.Duration
+		want     bool
+	}{
+		{time.Second, true},
+		{3 * time.Second, false},
+	} {
+		summary := &DedupSummary{Message: &dedupLogMessage{"a", tcase.duration}, Suppressed: 10}
+		if got := f.skip(summary); got != tcase.want {
+			t.Errorf("skip of the summary of a %v message: %v, want %v", tcase.duration, got, tcase.want)
+		}
+	}
+}
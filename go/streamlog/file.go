@@ -0,0 +1,110 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamlog
+
+import (
+	"bufio"
+	"io"
+	"net/url"
+	"os"
+	"os/signal"
+	"syscall"
+
+	log "github.com/golang/glog"
+	"github.com/youtube/vitess/go/stats"
+)
+
+var fileErrorCount = stats.NewCounters("StreamlogFileErrors")
+
+// LogToFile appends the messages of logger to the named file, in the
+// format messageFmt returns without params. The file is reopened when
+// the process receives SIGHUP, so that it can be rotated. SIGUSR1 can't
+// be used for that, because servenv stops the process when it gets it.
+// The failures to write or reopen the file are counted by logger name
+// in StreamlogFileErrors. The messages are dropped while the file
+// can't be written, like for any subscriber that can't keep up.
+// LogToFile returns a function that flushes and closes the file.
+func (logger *StreamLogger) LogToFile(path string, messageFmt func(url.Values, interface{}) string) (func(), error) {
+	file, err := openLogFile(path)
+	if err != nil {
+		return nil, err
+	}
+	ch := logger.Subscribe("FileLog")
+	rotate := make(chan os.Signal, 1)
+	signal.Notify(rotate, syscall.SIGHUP)
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		w := bufio.NewWriter(file)
+		// failing is set after the first error, so that only that
+		// one is logged until the file is reopened.
+		failing := false
+		fileError := func(err error) {
+			fileErrorCount.Add(logger.name, 1)
+			if !failing {
+				log.Errorf("Unable to write %s log to '%s': %v", logger.name, path, err)
+				failing = true
+			}
+		}
+		closeFile := func() {
+			if file == nil {
+				return
+			}
+			if err := w.Flush(); err != nil {
+				fileError(err)
+			}
+			file.Close()
+			file = nil
+		}
+		defer closeFile()
+
+		for {
+			select {
+			case message, ok := <-ch:
+				if !ok {
+					return
+				}
+				if file == nil {
+					fileErrorCount.Add(logger.name, 1)
+					continue
+				}
+				if _, err := io.WriteString(w, messageFmt(url.Values{}, message)); err != nil {
+					fileError(err)
+					continue
+				}
+				// Flush when caught up, so that the file
+				// doesn't lag behind in quiet times.
+				if len(ch) == 0 {
+					if err := w.Flush(); err != nil {
+						fileError(err)
+					}
+				}
+			case <-rotate:
+				closeFile()
+				failing = false
+				newFile, err := openLogFile(path)
+				if err != nil {
+					fileError(err)
+					continue
+				}
+				file = newFile
+				w = bufio.NewWriter(file)
+			}
+		}
+	}()
+
+	return func() {
+		signal.Stop(rotate)
+		logger.Unsubscribe(ch)
+		// Send doesn't use ch after Unsubscribe returns.
+		close(ch)
+		<-done
+	}, nil
+}
+
+func openLogFile(path string) (*os.File, error) {
+	return os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+}
@@ -0,0 +1,79 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamlog
+
+import (
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func testLogFmt(params url.Values, message interface{}) string {
+	return message.(*logMessage).Format(params)
+}
+
+func TestLogToFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "streamlog")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	logPath := path.Join(dir, "test.log")
+	rotatedPath := path.Join(dir, "test.log.1")
+
+	logger := New("file", 10)
+	stop, err := logger.LogToFile(logPath, testLogFmt)
+	if err != nil {
+		t.Fatalf("LogToFile: %v", err)
+	}
+	logger.Send(&logMessage{"first"})
+	// Wait for the message to be written before rotating.
+	waitForFile(t, logPath, "first\n")
+
+	if err := os.Rename(logPath, rotatedPath); err != nil {
+		t.Fatal(err)
+	}
+	syscall.Kill(os.Getpid(), syscall.SIGHUP)
+	waitForFile(t, logPath, "")
+	logger.Send(&logMessage{"second"})
+	stop()
+
+	if got, want := readFile(t, rotatedPath), "first\n"; got != want {
+		t.Errorf("rotated log: %q, want %q", got, want)
+	}
+	if got, want := readFile(t, logPath), "second\n"; got != want {
+		t.Errorf("log after SIGHUP: %q, want %q", got, want)
+	}
+}
+
+func TestLogToFileError(t *testing.T) {
+	logger := New("badfile", 10)
+	if _, err := logger.LogToFile("/nonexistent/dir/test.log", testLogFmt); err == nil {
+		t.Errorf("LogToFile with bad path: nil, want error")
+	}
+}
+
+func readFile(t *testing.T, name string) string {
+	data, err := ioutil.ReadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+// waitForFile waits until the file exists with the given content.
+func waitForFile(t *testing.T, name, content string) {
+	for i := 0; i < 500; i++ {
+		if data, err := ioutil.ReadFile(name); err == nil && string(data) == content {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("%v: timed out waiting for %q", name, content)
+}
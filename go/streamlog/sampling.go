@@ -0,0 +1,124 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamlog
+
+import (
+	"math"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Option configures a StreamLogger. Options are passed to New.
+type Option func(*StreamLogger)
+
+// WithSamplingRate makes the logger send only a fraction of its
+// messages, between 0.0 (none) and 1.0 (all). The messages that have
+// a HasError method and failed are always sent. So are the messages
+// that have a TotalTime method and are slower than the threshold set
+// by WithSlowThreshold.
+func WithSamplingRate(rate float64) Option {
+	return func(logger *StreamLogger) {
+		logger.SetSamplingRate(rate)
+	}
+}
+
+// WithSlowThreshold makes a sampling logger always send the messages
+// that have a TotalTime method and took at least threshold. 0 means
+// no message is exempt from sampling.
+func WithSlowThreshold(threshold time.Duration) Option {
+	return func(logger *StreamLogger) {
+		logger.SetSlowThreshold(threshold)
+	}
+}
+
+// SetSamplingRate changes the fraction of the messages the logger
+// sends. rate is capped between 0.0 and 1.0. See WithSamplingRate.
+func (logger *StreamLogger) SetSamplingRate(rate float64) {
+	rate = math.Max(0, math.Min(1, rate))
+	atomic.StoreUint64(&logger.samplingRate, math.Float64bits(rate))
+	samplingRates.Set(logger.name, strconv.FormatFloat(rate, 'g', -1, 64))
+}
+
+// SamplingRate returns the fraction of the messages the logger sends.
+func (logger *StreamLogger) SamplingRate() float64 {
+	return math.Float64frombits(atomic.LoadUint64(&logger.samplingRate))
+}
+
+// SetSlowThreshold changes the duration above which the messages are
+// sent regardless of sampling. See WithSlowThreshold.
+func (logger *StreamLogger) SetSlowThreshold(threshold time.Duration) {
+	atomic.StoreInt64(&logger.slowThreshold, int64(threshold))
+}
+
+// SlowThreshold returns the duration above which the messages are sent
+// regardless of sampling.
+func (logger *StreamLogger) SlowThreshold() time.Duration {
+	return time.Duration(atomic.LoadInt64(&logger.slowThreshold))
+}
+
+// SampledOut returns the number of messages the logger dropped because
+// they were not sampled.
+func (logger *StreamLogger) SampledOut() int64 {
+	return atomic.LoadInt64(&logger.sampledOut)
+}
+
+// Sample returns true if message must be sent according to the
+// sampling rate, and counts it as sampled out otherwise. The senders
+// that have work to do before sending a message can call it first,
+// and then send the sampled messages with SendSampled.
+func (logger *StreamLogger) Sample(message interface{}) bool {
+	if logger.sample(message) {
+		return true
+	}
+	atomic.AddInt64(&logger.sampledOut, 1)
+	sampledOutCount.Add(logger.name, 1)
+	return false
+}
+
+// sample returns true if message must be sent.
+func (logger *StreamLogger) sample(message interface{}) bool {
+	rate := logger.SamplingRate()
+	if rate >= 1 {
+		return true
+	}
+	if fm, ok := message.(failedMessage); ok && fm.HasError() {
+		return true
+	}
+	if tm, ok := message.(timedMessage); ok {
+		if threshold := logger.SlowThreshold(); threshold > 0 && tm.TotalTime() >= threshold {
+			return true
+		}
+	}
+	rng := rngPool.Get().(*xorshift)
+	n := rng.next()
+	rngPool.Put(rng)
+	return float64(n) < rate*(1<<64)
+}
+
+// xorshift is a xorshift64* pseudo random number generator. It's not
+// safe for concurrent use: the sampling loggers share a pool of them,
+// so that concurrent senders don't contend on a lock.
+type xorshift uint64
+
+var (
+	rngSeed uint64
+	rngPool = sync.Pool{
+		New: func() interface{} {
+			// The seed must not be 0. Each generator gets a
+			// different one.
+			seed := xorshift(uint64(time.Now().UnixNano()) ^ atomic.AddUint64(&rngSeed, 0x9E3779B97F4A7C15) | 1)
+			return &seed
+		},
+	}
+)
+
+func (x *xorshift) next() uint64 {
+	*x ^= *x >> 12
+	*x ^= *x << 25
+	*x ^= *x >> 27
+	return uint64(*x) * 2685821657736338717
+}
@@ -0,0 +1,99 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamlog
+
+import (
+	"testing"
+	"time"
+)
+
+type sampledLogMessage struct {
+	failed   bool
+	duration time.Duration
+}
+
+func (l *sampledLogMessage) HasError() bool {
+	return l.failed
+}
+
+func (l *sampledLogMessage) TotalTime() time.Duration {
+	return l.duration
+}
+
+func TestSampling(t *testing.T) {
+	logger := New("sampled", 10000, WithSamplingRate(0), WithSlowThreshold(time.Second))
+	ch := logger.Subscribe("test")
+	defer logger.Unsubscribe(ch)
+	before := sampledOutCount.Counts()["sampled"]
+
+	logger.Send(&sampledLogMessage{})
+	logger.Send(&logMessage{"other"})
+	logger.Send(&sampledLogMessage{failed: true})
+	logger.Send(&sampledLogMessage{duration: 2 * time.Second})
+	if got, want := len(ch), 2; got != want {
+		t.Errorf("sent messages with rate 0: %v, want %v", got, want)
+	}
+	if got, want := sampledOutCount.Counts()["sampled"]-before, int64(2); got != want {
+		t.Errorf("StreamlogSampledOut: %v, want %v", got, want)
+	}
+	for len(ch) > 0 {
+		<-ch
+	}
+
+	logger.SetSamplingRate(0.5)
+	for i := 0; i < 10000; i++ {
+		logger.Send(&sampledLogMessage{})
+	}
+	if got := len(ch); got < 4000 || got > 6000 {
+		t.Errorf("sent messages with rate 0.5: %v, want about 5000", got)
+	}
+	if got, want := samplingRates.Get("sampled"), "0.5"; got != want {
+		t.Errorf("StreamlogSamplingRate: %v, want %v", got, want)
+	}
+}
+
+func TestSamplingRate(t *testing.T) {
+	logger := New("unsampled", 1)
+	if got := logger.SamplingRate(); got != 1 {
+		t.Errorf("default SamplingRate: %v, want 1", got)
+	}
+	logger.SetSamplingRate(2)
+	if got := logger.SamplingRate(); got != 1 {
+		t.Errorf("SamplingRate after SetSamplingRate(2): %v, want 1", got)
+	}
+	logger.SetSamplingRate(-1)
+	if got := logger.SamplingRate(); got != 0 {
+		t.Errorf("SamplingRate after SetSamplingRate(-1): %v, want 0", got)
+	}
+}
+
+func TestSampleAndSendSampled(t *testing.T) {
+	logger := New("presampled", 10, WithSamplingRate(0))
+	ch := logger.Subscribe("test")
+	defer logger.Unsubscribe(ch)
+
+	if logger.Sample(&sampledLogMessage{}) {
+		t.Errorf("Sample with rate 0: true, want false")
+	}
+	if got := logger.SampledOut(); got != 1 {
+		t.Errorf("SampledOut: %v, want 1", got)
+	}
+	logger.SetSlowThreshold(time.Second)
+	if got := logger.SlowThreshold(); got != time.Second {
+		t.Errorf("SlowThreshold: %v, want 1s", got)
+	}
+	if !logger.Sample(&sampledLogMessage{duration: time.Second}) {
+		t.Errorf("Sample of a slow message: false, want true")
+	}
+
+	// SendSampled doesn't sample the message again.
+	logger.SendSampled(&sampledLogMessage{})
+	if got := len(ch); got != 1 {
+		t.Errorf("sent messages: %v, want 1", got)
+	}
+	if got := logger.SampledOut(); got != 1 {
+		t.Errorf("SampledOut after SendSampled: %v, want 1", got)
+	}
+}
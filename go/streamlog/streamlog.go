@@ -6,10 +6,15 @@
 package streamlog
 
 import (
+	"fmt"
 	"io"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	log "github.com/golang/glog"
 	"github.com/youtube/vitess/go/acl"
@@ -20,6 +25,9 @@ var (
 	sendCount         = stats.NewCounters("StreamlogSend")
 	deliveredCount    = stats.NewMultiCounters("StreamlogDelivered", []string{"Log", "Subscriber"})
 	deliveryDropCount = stats.NewMultiCounters("StreamlogDeliveryDroppedMessages", []string{"Log", "Subscriber"})
+	sampledOutCount   = stats.NewCounters("StreamlogSampledOut")
+	dedupCount        = stats.NewCounters("StreamlogDeduplicated")
+	samplingRates     = stats.NewStringMap("StreamlogSamplingRate")
 )
 
 // StreamLogger is a non-blocking broadcaster of messages.
@@ -29,21 +37,59 @@ type StreamLogger struct {
 	size       int
 	mu         sync.Mutex
 	subscribed map[chan interface{}]string
+
+	// samplingRate holds the bits of the float64 sampling rate.
+	// It's accessed atomically, like slowThreshold and sampledOut.
+	samplingRate  uint64
+	slowThreshold int64
+	sampledOut    int64
+	// dedup holds the *deduper set by SetDedup, nil if the messages
+	// are not deduplicated.
+	dedup atomic.Value
 }
 
 // New returns a new StreamLogger that can stream events to subscribers.
 // The size parameter defines the channel size for the subscribers.
-func New(name string, size int) *StreamLogger {
-	return &StreamLogger{
+func New(name string, size int, options ...Option) *StreamLogger {
+	logger := &StreamLogger{
 		name:       name,
 		size:       size,
 		subscribed: make(map[chan interface{}]string),
 	}
+	logger.SetSamplingRate(1)
+	for _, option := range options {
+		option(logger)
+	}
+	return logger
 }
 
 // Send sends message to all the writers subscribed to logger. Calling
-// Send does not block.
+// Send does not block. If the logger samples its messages, the
+// messages that are not sampled are dropped. If it deduplicates them,
+// the duplicates beyond the limit are dropped.
 func (logger *StreamLogger) Send(message interface{}) {
+	if !logger.Sample(message) {
+		return
+	}
+	logger.SendSampled(message)
+}
+
+// SendSampled is like Send, for a message that was already sampled
+// with Sample, or that must be sent regardless of sampling.
+func (logger *StreamLogger) SendSampled(message interface{}) {
+	send, summaries := logger.deduplicate(message)
+	for _, summary := range summaries {
+		logger.broadcast(summary)
+	}
+	if !send {
+		dedupCount.Add(logger.name, 1)
+		return
+	}
+	logger.broadcast(message)
+}
+
+// broadcast sends message to all the subscribers.
+func (logger *StreamLogger) broadcast(message interface{}) {
 	logger.mu.Lock()
 	defer logger.mu.Unlock()
 
@@ -82,8 +128,195 @@ func (logger *StreamLogger) Name() string {
 	return logger.name
 }
 
+// timedMessage is implemented by messages that know how long the
+// operation they describe took.
+type timedMessage interface {
+	TotalTime() time.Duration
+}
+
+// failedMessage is implemented by messages that know whether the
+// operation they describe failed.
+type failedMessage interface {
+	HasError() bool
+}
+
+// plannedMessage is implemented by messages that know the method
+// that was called and the type of plan that served it.
+type plannedMessage interface {
+	MethodName() string
+	PlanTypeName() string
+}
+
+// callerMessage is implemented by messages that know who sent the
+// request they describe.
+type callerMessage interface {
+	EffectiveCaller() string
+	RemoteAddrUsername() (string, string)
+}
+
+// tabletMessage is implemented by messages that know the tablet that
+// logged them.
+type tabletMessage interface {
+	TabletIdentity() (keyspace, shard, tabletAlias string)
+}
+
+// messageFilter holds the filters requested by a subscriber.
+type messageFilter struct {
+	minDuration time.Duration
+	errorsOnly  bool
+	// plans and methods are lowercase. They match everything
+	// if they're empty.
+	plans   map[string]bool
+	methods map[string]bool
+	// caller and username are nil if they match everything.
+	caller   *namePattern
+	username *namePattern
+	// keyspace, shard and tabletAlias are nil if they match
+	// everything.
+	keyspace    *namePattern
+	shard       *namePattern
+	tabletAlias *namePattern
+}
+
+// newMessageFilter parses the min_duration, errors_only, plan, method,
+// caller, username, keyspace, shard and tablet_alias params.
+func newMessageFilter(params url.Values) (*messageFilter, error) {
+	f := &messageFilter{
+		plans:       parseNameList(params.Get("plan")),
+		methods:     parseNameList(params.Get("method")),
+		caller:      parseNamePattern(params, "caller"),
+		username:    parseNamePattern(params, "username"),
+		keyspace:    parseNamePattern(params, "keyspace"),
+		shard:       parseNamePattern(params, "shard"),
+		tabletAlias: parseNamePattern(params, "tablet_alias"),
+	}
+	if v := params.Get("min_duration"); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid min_duration: %v", err)
+		}
+		f.minDuration = d
+	}
+	if v := params.Get("errors_only"); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid errors_only: %v", err)
+		}
+		f.errorsOnly = b
+	}
+	return f, nil
+}
+
+// NewMessageFilter parses the filter params of ServeLogs, and returns
+// a function that returns true if a message must be skipped. It's for
+// the handlers that show the messages a logger sent before, instead of
+// subscribing to it.
+func NewMessageFilter(params url.Values) (func(message interface{}) bool, error) {
+	f, err := newMessageFilter(params)
+	if err != nil {
+		return nil, err
+	}
+	return f.skip, nil
+}
+
+// parseNameList splits a comma separated list of names into a set of
+// lowercase names.
+func parseNameList(v string) map[string]bool {
+	names := make(map[string]bool)
+	for _, name := range strings.Split(v, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names[strings.ToLower(name)] = true
+		}
+	}
+	return names
+}
+
+// namePattern matches a name exactly, or by prefix if it ends with
+// "*". Only the empty pattern matches the empty name.
+type namePattern string
+
+// parseNamePattern returns the pattern of the key param, or nil if
+// the param is not set.
+func parseNamePattern(params url.Values, key string) *namePattern {
+	values, ok := params[key]
+	if !ok || len(values) == 0 {
+		return nil
+	}
+	p := namePattern(values[0])
+	return &p
+}
+
+func (p *namePattern) match(name string) bool {
+	if p == nil {
+		return true
+	}
+	pattern := string(*p)
+	if name == "" || !strings.HasSuffix(pattern, "*") {
+		return name == pattern
+	}
+	return strings.HasPrefix(name, strings.TrimSuffix(pattern, "*"))
+}
+
+// skip returns true if message must not be sent to the subscriber.
+func (f *messageFilter) skip(message interface{}) bool {
+	// The summaries are filtered like the duplicates they count.
+	if summary, ok := message.(*DedupSummary); ok {
+		message = summary.Message
+	}
+	if tm, ok := message.(timedMessage); ok && tm.TotalTime() < f.minDuration {
+		return true
+	}
+	if fm, ok := message.(failedMessage); ok && f.errorsOnly && !fm.HasError() {
+		return true
+	}
+	if pm, ok := message.(plannedMessage); ok {
+		if len(f.plans) != 0 && !f.plans[strings.ToLower(pm.PlanTypeName())] {
+			return true
+		}
+		if len(f.methods) != 0 && !f.methods[strings.ToLower(pm.MethodName())] {
+			return true
+		}
+	}
+	if cm, ok := message.(callerMessage); ok {
+		if !f.caller.match(cm.EffectiveCaller()) {
+			return true
+		}
+		if _, username := cm.RemoteAddrUsername(); !f.username.match(username) {
+			return true
+		}
+	}
+	if tm, ok := message.(tabletMessage); ok {
+		keyspace, shard, tabletAlias := tm.TabletIdentity()
+		if !f.keyspace.match(keyspace) || !f.shard.match(shard) || !f.tabletAlias.match(tabletAlias) {
+			return true
+		}
+	}
+	return false
+}
+
 // ServeLogs registers the URL on which messages will be broadcast.
 // It is safe to register multiple URLs for the same StreamLogger.
+// The request's form values are passed to messageFmt, so each
+// subscriber can choose its own output format. Each subscriber can
+// also filter the messages it receives:
+// - min_duration (e.g. min_duration=100ms) skips the messages that
+// have a TotalTime method and took less than that.
+// - errors_only=true skips the messages that have a HasError method
+// and didn't fail.
+// - plan and method (e.g. plan=PASS_DML,INSERT_PK&method=Execute) skip
+// the messages that have MethodName and PlanTypeName methods, and
+// whose plan type or method is not in the list. The names are case
+// insensitive.
+// - caller and username (e.g. caller=payments-*) skip the messages
+// that have EffectiveCaller and RemoteAddrUsername methods, and whose
+// effective caller or username don't match. A trailing "*" matches
+// any suffix. The messages without a caller only match an empty
+// caller= filter.
+// - keyspace, shard and tablet_alias (e.g. shard=-80) skip the messages
+// that have a TabletIdentity method, and whose tablet doesn't match.
+// They match like caller.
+// If format=binary (BinaryFormat) is set, the messages are sent as
+// application/octet-stream, and can be decoded by BinaryReader.
 func (logger *StreamLogger) ServeLogs(url string, messageFmt func(url.Values, interface{}) string) {
 	http.HandleFunc(url, func(w http.ResponseWriter, r *http.Request) {
 		if err := acl.CheckAccessHTTP(r, acl.DEBUGGING); err != nil {
@@ -92,15 +325,28 @@ func (logger *StreamLogger) ServeLogs(url string, messageFmt func(url.Values, in
 		}
 		if err := r.ParseForm(); err != nil {
 			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		filter, err := newMessageFilter(r.Form)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
 		}
 		ch := logger.Subscribe("ServeLogs")
 		defer logger.Unsubscribe(ch)
 
+		if r.Form.Get("format") == BinaryFormat {
+			w.Header().Set("Content-Type", "application/octet-stream")
+		}
+
 		// Notify client that we're set up. Helpful to distinguish low-traffic streams from connection issues.
 		w.WriteHeader(http.StatusOK)
 		w.(http.Flusher).Flush()
 
 		for message := range ch {
+			if filter.skip(message) {
+				continue
+			}
 			if _, err := io.WriteString(w, messageFmt(r.Form, message)); err != nil {
 				return
 			}
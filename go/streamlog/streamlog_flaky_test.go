@@ -100,6 +100,307 @@ func TestHTTP(t *testing.T) {
 	logger.mu.Unlock()
 }
 
+type timedLogMessage struct {
+	val      string
+	duration time.Duration
+}
+
+func (l *timedLogMessage) Format(params url.Values) string {
+	return l.val + "\n"
+}
+
+func (l *timedLogMessage) TotalTime() time.Duration {
+	return l.duration
+}
+
+func TestHTTPMinDuration(t *testing.T) {
+	l, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	addr := l.Addr().String()
+
+	go http.Serve(l, nil)
+
+	logger := New("logger", 10)
+	logger.ServeLogs("/timedlog", func(params url.Values, x interface{}) string { return x.(*timedLogMessage).Format(params) })
+
+	// An invalid duration is rejected.
+	resp, err := http.Get(fmt.Sprintf("http://%s/timedlog?min_duration=bad", addr))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("min_duration=bad: got status %d, want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+
+	resp, err = http.Get(fmt.Sprintf("http://%s/timedlog?min_duration=100ms", addr))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body := bufio.NewReader(resp.Body)
+
+	logger.Send(&timedLogMessage{"fast", 10 * time.Millisecond})
+	logger.Send(&timedLogMessage{"slow", 200 * time.Millisecond})
+	val, err := body.ReadString('\n')
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "slow\n"; val != want {
+		t.Errorf("want %q, got %q", want, val)
+	}
+}
+
+type failedLogMessage struct {
+	val    string
+	failed bool
+}
+
+func (l *failedLogMessage) HasError() bool {
+	return l.failed
+}
+
+func TestMessageFilter(t *testing.T) {
+	filter, err := newMessageFilter(url.Values{"errors_only": []string{"true"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !filter.skip(&failedLogMessage{"ok", false}) {
+		t.Errorf("errors_only: successful message was not skipped")
+	}
+	if filter.skip(&failedLogMessage{"failed", true}) {
+		t.Errorf("errors_only: failed message was skipped")
+	}
+	if filter.skip(&logMessage{"other"}) {
+		t.Errorf("errors_only: message without HasError was skipped")
+	}
+
+	filter, err = newMessageFilter(url.Values{"errors_only": []string{"false"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if filter.skip(&failedLogMessage{"ok", false}) {
+		t.Errorf("errors_only=false: successful message was skipped")
+	}
+
+	if _, err := newMessageFilter(url.Values{"errors_only": []string{"maybe"}}); err == nil {
+		t.Errorf("errors_only=maybe: want an error")
+	}
+}
+
+type plannedLogMessage struct {
+	val    string
+	method string
+	plan   string
+}
+
+func (l *plannedLogMessage) Format(params url.Values) string {
+	if _, ok := params["full"]; ok {
+		return fmt.Sprintf("%v %v %v\n", l.val, l.method, l.plan)
+	}
+	return l.val + "\n"
+}
+
+func (l *plannedLogMessage) MethodName() string {
+	return l.method
+}
+
+func (l *plannedLogMessage) PlanTypeName() string {
+	return l.plan
+}
+
+func TestMessageFilterPlanMethod(t *testing.T) {
+	insert := &plannedLogMessage{"insert", "Execute", "INSERT_PK"}
+	dml := &plannedLogMessage{"dml", "Execute", "PASS_DML"}
+	stream := &plannedLogMessage{"stream", "StreamExecute", "SELECT_STREAM"}
+	testcases := []struct {
+		params url.Values
+		want   []bool
+	}{{
+		params: url.Values{},
+		want:   []bool{false, false, false},
+	}, {
+		params: url.Values{"plan": []string{"PASS_DML,INSERT_PK"}},
+		want:   []bool{false, false, true},
+	}, {
+		params: url.Values{"plan": []string{" pass_dml "}},
+		want:   []bool{true, false, true},
+	}, {
+		params: url.Values{"method": []string{"streamexecute"}},
+		want:   []bool{true, true, false},
+	}, {
+		params: url.Values{"plan": []string{"INSERT_PK,SELECT_STREAM"}, "method": []string{"Execute"}},
+		want:   []bool{false, true, true},
+	}, {
+		params: url.Values{"plan": []string{"NO_SUCH_PLAN"}},
+		want:   []bool{true, true, true},
+	}}
+	for _, tcase := range testcases {
+		filter, err := newMessageFilter(tcase.params)
+		if err != nil {
+			t.Fatalf("newMessageFilter(%v): %v", tcase.params, err)
+		}
+		for i, message := range []*plannedLogMessage{insert, dml, stream} {
+			if got := filter.skip(message); got != tcase.want[i] {
+				t.Errorf("%v: skip(%v): %v, want %v", tcase.params, message.val, got, tcase.want[i])
+			}
+		}
+		if filter.skip(&logMessage{"other"}) {
+			t.Errorf("%v: message without a plan was skipped", tcase.params)
+		}
+	}
+}
+
+type callerLogMessage struct {
+	val      string
+	caller   string
+	username string
+}
+
+func (l *callerLogMessage) EffectiveCaller() string {
+	return l.caller
+}
+
+func (l *callerLogMessage) RemoteAddrUsername() (string, string) {
+	return "127.0.0.1", l.username
+}
+
+func TestMessageFilterCaller(t *testing.T) {
+	payments := &callerLogMessage{"payments", "payments-job-1", "vt_app"}
+	orders := &callerLogMessage{"orders", "orders", "vt_orders"}
+	anonymous := &callerLogMessage{"anonymous", "", "vt_app"}
+	testcases := []struct {
+		params url.Values
+		want   []bool
+	}{{
+		params: url.Values{},
+		want:   []bool{false, false, false},
+	}, {
+		params: url.Values{"caller": []string{"payments-*"}},
+		want:   []bool{false, true, true},
+	}, {
+		params: url.Values{"caller": []string{"orders"}},
+		want:   []bool{true, false, true},
+	}, {
+		params: url.Values{"caller": []string{"payments"}},
+		want:   []bool{true, true, true},
+	}, {
+		params: url.Values{"caller": []string{"*"}},
+		want:   []bool{false, false, true},
+	}, {
+		params: url.Values{"caller": []string{""}},
+		want:   []bool{true, true, false},
+	}, {
+		params: url.Values{"username": []string{"vt_app"}},
+		want:   []bool{false, true, false},
+	}, {
+		params: url.Values{"caller": []string{"*"}, "username": []string{"vt_*"}},
+		want:   []bool{false, false, true},
+	}}
+	for _, tcase := range testcases {
+		filter, err := newMessageFilter(tcase.params)
+		if err != nil {
+			t.Fatalf("newMessageFilter(%v): %v", tcase.params, err)
+		}
+		for i, message := range []*callerLogMessage{payments, orders, anonymous} {
+			if got := filter.skip(message); got != tcase.want[i] {
+				t.Errorf("%v: skip(%v): %v, want %v", tcase.params, message.val, got, tcase.want[i])
+			}
+		}
+		if filter.skip(&logMessage{"other"}) {
+			t.Errorf("%v: message without a caller was skipped", tcase.params)
+		}
+	}
+}
+
+type tabletLogMessage struct {
+	val         string
+	keyspace    string
+	shard       string
+	tabletAlias string
+}
+
+func (l *tabletLogMessage) TabletIdentity() (string, string, string) {
+	return l.keyspace, l.shard, l.tabletAlias
+}
+
+func TestMessageFilterTablet(t *testing.T) {
+	low := &tabletLogMessage{"low", "ks", "-80", "cell1-0000000100"}
+	high := &tabletLogMessage{"high", "ks", "80-", "cell1-0000000200"}
+	unknown := &tabletLogMessage{"unknown", "", "", ""}
+	testcases := []struct {
+		params url.Values
+		want   []bool
+	}{{
+		params: url.Values{},
+		want:   []bool{false, false, false},
+	}, {
+		params: url.Values{"shard": []string{"-80"}},
+		want:   []bool{false, true, true},
+	}, {
+		params: url.Values{"keyspace": []string{"ks"}},
+		want:   []bool{false, false, true},
+	}, {
+		params: url.Values{"keyspace": []string{"ks"}, "tablet_alias": []string{"cell1-*"}},
+		want:   []bool{false, false, true},
+	}, {
+		params: url.Values{"tablet_alias": []string{"cell1-0000000200"}},
+		want:   []bool{true, false, true},
+	}, {
+		params: url.Values{"shard": []string{""}},
+		want:   []bool{true, true, false},
+	}}
+	for _, tcase := range testcases {
+		filter, err := newMessageFilter(tcase.params)
+		if err != nil {
+			t.Fatalf("newMessageFilter(%v): %v", tcase.params, err)
+		}
+		for i, message := range []*tabletLogMessage{low, high, unknown} {
+			if got := filter.skip(message); got != tcase.want[i] {
+				t.Errorf("%v: skip(%v): %v, want %v", tcase.params, message.val, got, tcase.want[i])
+			}
+		}
+		if filter.skip(&logMessage{"other"}) {
+			t.Errorf("%v: message without a tablet was skipped", tcase.params)
+		}
+	}
+}
+
+func TestHTTPPlanMethodFilter(t *testing.T) {
+	l, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	addr := l.Addr().String()
+
+	go http.Serve(l, nil)
+
+	logger := New("logger", 10)
+	logger.ServeLogs("/plannedlog", func(params url.Values, x interface{}) string { return x.(*plannedLogMessage).Format(params) })
+
+	resp, err := http.Get(fmt.Sprintf("http://%s/plannedlog?plan=pass_dml&method=Execute&full", addr))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body := bufio.NewReader(resp.Body)
+
+	logger.Send(&plannedLogMessage{"stream", "StreamExecute", "PASS_DML"})
+	logger.Send(&plannedLogMessage{"insert", "Execute", "INSERT_PK"})
+	logger.Send(&plannedLogMessage{"dml", "Execute", "PASS_DML"})
+	val, err := body.ReadString('\n')
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "dml Execute PASS_DML\n"; val != want {
+		t.Errorf("want %q, got %q", want, val)
+	}
+}
+
 func TestChannel(t *testing.T) {
 	logger := New("logger", 1)
 
@@ -0,0 +1,155 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamlog
+
+import (
+	"fmt"
+	"log/syslog"
+	"net/url"
+	"strings"
+	"time"
+
+	log "github.com/golang/glog"
+	"github.com/youtube/vitess/go/stats"
+)
+
+// SyslogTruncatedMarker ends the syslog messages that were truncated
+// to the maximum message size.
+const SyslogTruncatedMarker = "...[truncated]"
+
+const (
+	syslogMinBackoff = 100 * time.Millisecond
+	syslogMaxBackoff = 30 * time.Second
+)
+
+var syslogDroppedCount = stats.NewCounters("StreamlogSyslogDropped")
+
+// syslogWriter is the part of syslog.Writer used by LogToSyslog, so
+// that it can be faked.
+type syslogWriter interface {
+	Info(string) error
+	Close() error
+}
+
+// syslogDial connects to the local syslog daemon. It's replaced in
+// tests.
+var syslogDial = func(priority syslog.Priority, tag string) (syslogWriter, error) {
+	return syslog.New(priority, tag)
+}
+
+var syslogFacilities = map[string]syslog.Priority{
+	"kern":     syslog.LOG_KERN,
+	"user":     syslog.LOG_USER,
+	"mail":     syslog.LOG_MAIL,
+	"daemon":   syslog.LOG_DAEMON,
+	"auth":     syslog.LOG_AUTH,
+	"syslog":   syslog.LOG_SYSLOG,
+	"lpr":      syslog.LOG_LPR,
+	"news":     syslog.LOG_NEWS,
+	"uucp":     syslog.LOG_UUCP,
+	"cron":     syslog.LOG_CRON,
+	"authpriv": syslog.LOG_AUTHPRIV,
+	"ftp":      syslog.LOG_FTP,
+	"local0":   syslog.LOG_LOCAL0,
+	"local1":   syslog.LOG_LOCAL1,
+	"local2":   syslog.LOG_LOCAL2,
+	"local3":   syslog.LOG_LOCAL3,
+	"local4":   syslog.LOG_LOCAL4,
+	"local5":   syslog.LOG_LOCAL5,
+	"local6":   syslog.LOG_LOCAL6,
+	"local7":   syslog.LOG_LOCAL7,
+}
+
+// ParseSyslogFacility returns the syslog facility of the given name,
+// like "user" or "local0".
+func ParseSyslogFacility(name string) (syslog.Priority, error) {
+	facility, ok := syslogFacilities[strings.ToLower(name)]
+	if !ok {
+		return 0, fmt.Errorf("unknown syslog facility: %v", name)
+	}
+	return facility, nil
+}
+
+// LogToSyslog sends the messages of logger to the local syslog daemon,
+// with the given facility and tag, at the info severity. The messages
+// are formatted by messageFmt without params, and the ones longer than
+// maxSize bytes are truncated and end with SyslogTruncatedMarker.
+// 0 means no limit. If the connection fails, LogToSyslog reconnects
+// with an exponential backoff, and the messages are dropped and
+// counted by logger name in StreamlogSyslogDropped until then.
+// LogToSyslog returns a function that closes the connection.
+func (logger *StreamLogger) LogToSyslog(facility syslog.Priority, tag string, maxSize int, messageFmt func(url.Values, interface{}) string) (func(), error) {
+	priority := facility | syslog.LOG_INFO
+	w, err := syslogDial(priority, tag)
+	if err != nil {
+		return nil, err
+	}
+	ch := logger.Subscribe("Syslog")
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		defer func() {
+			if w != nil {
+				w.Close()
+			}
+		}()
+		backoff := syslogMinBackoff
+		// reconnect is only set while w is nil.
+		var reconnect <-chan time.Time
+		for {
+			select {
+			case message, ok := <-ch:
+				if !ok {
+					return
+				}
+				if w == nil {
+					syslogDroppedCount.Add(logger.name, 1)
+					continue
+				}
+				if err := w.Info(truncateSyslogMessage(messageFmt(url.Values{}, message), maxSize)); err != nil {
+					log.Errorf("Unable to send %s log to syslog, reconnecting in %v: %v", logger.name, backoff, err)
+					syslogDroppedCount.Add(logger.name, 1)
+					w.Close()
+					w = nil
+					reconnect = time.After(backoff)
+				}
+			case <-reconnect:
+				newWriter, err := syslogDial(priority, tag)
+				if err != nil {
+					backoff *= 2
+					if backoff > syslogMaxBackoff {
+						backoff = syslogMaxBackoff
+					}
+					reconnect = time.After(backoff)
+					continue
+				}
+				log.Infof("Reconnected %s log to syslog", logger.name)
+				w = newWriter
+				reconnect = nil
+				backoff = syslogMinBackoff
+			}
+		}
+	}()
+
+	return func() {
+		logger.Unsubscribe(ch)
+		close(ch)
+		<-done
+	}, nil
+}
+
+// truncateSyslogMessage removes the trailing newline of message, and
+// truncates it to maxSize bytes if it's longer.
+func truncateSyslogMessage(message string, maxSize int) string {
+	message = strings.TrimSuffix(message, "\n")
+	if maxSize <= 0 || len(message) <= maxSize {
+		return message
+	}
+	if maxSize <= len(SyslogTruncatedMarker) {
+		return SyslogTruncatedMarker[:maxSize]
+	}
+	return message[:maxSize-len(SyslogTruncatedMarker)] + SyslogTruncatedMarker
+}
@@ -0,0 +1,151 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package streamlog
+
+import (
+	"errors"
+	"log/syslog"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeSyslog records the messages sent to the fake syslog daemon.
+type fakeSyslog struct {
+	mu       sync.Mutex
+	messages []string
+	dials    int
+	// down makes the connections fail until it's cleared.
+	down bool
+}
+
+type fakeSyslogWriter struct {
+	daemon *fakeSyslog
+}
+
+func (w *fakeSyslogWriter) Info(message string) error {
+	w.daemon.mu.Lock()
+	defer w.daemon.mu.Unlock()
+	if w.daemon.down {
+		return errors.New("connection refused")
+	}
+	w.daemon.messages = append(w.daemon.messages, message)
+	return nil
+}
+
+func (w *fakeSyslogWriter) Close() error {
+	return nil
+}
+
+func (daemon *fakeSyslog) dial(priority syslog.Priority, tag string) (syslogWriter, error) {
+	daemon.mu.Lock()
+	defer daemon.mu.Unlock()
+	daemon.dials++
+	if daemon.down {
+		return nil, errors.New("connection refused")
+	}
+	return &fakeSyslogWriter{daemon: daemon}, nil
+}
+
+func (daemon *fakeSyslog) setDown(down bool) {
+	daemon.mu.Lock()
+	defer daemon.mu.Unlock()
+	daemon.down = down
+}
+
+// waitFor waits until the daemon has received n messages, and returns
+// them.
+func (daemon *fakeSyslog) waitFor(t *testing.T, n int) []string {
+	for i := 0; i < 500; i++ {
+		daemon.mu.Lock()
+		messages := daemon.messages
+		daemon.mu.Unlock()
+		if len(messages) >= n {
+			return messages
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d syslog messages", n)
+	return nil
+}
+
+func TestLogToSyslog(t *testing.T) {
+	daemon := &fakeSyslog{}
+	defer func(dial func(syslog.Priority, string) (syslogWriter, error)) {
+		syslogDial = dial
+	}(syslogDial)
+	syslogDial = daemon.dial
+
+	logger := New("syslog", 10)
+	stop, err := logger.LogToSyslog(syslog.LOG_LOCAL0, "test", 20, testLogFmt)
+	if err != nil {
+		t.Fatalf("LogToSyslog: %v", err)
+	}
+	defer stop()
+	logger.Send(&logMessage{"short"})
+	logger.Send(&logMessage{"a message longer than the maximum size"})
+	messages := daemon.waitFor(t, 2)
+	if got, want := messages[0], "short"; got != want {
+		t.Errorf("short message: %q, want %q", got, want)
+	}
+	if got, want := messages[1], "a mess"+SyslogTruncatedMarker; got != want {
+		t.Errorf("long message: %q, want %q", got, want)
+	}
+
+	// The messages are dropped while syslog is down.
+	before := syslogDroppedCount.Counts()["syslog"]
+	daemon.setDown(true)
+	logger.Send(&logMessage{"dropped"})
+	for i := 0; i < 500 && syslogDroppedCount.Counts()["syslog"] == before; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := syslogDroppedCount.Counts()["syslog"] - before; got != 1 {
+		t.Errorf("StreamlogSyslogDropped: %v, want 1", got)
+	}
+
+	daemon.setDown(false)
+	// Wait for the reconnection, the messages sent before it are
+	// dropped.
+	for i := 0; i < 500; i++ {
+		logger.Send(&logMessage{"after"})
+		daemon.mu.Lock()
+		n := len(daemon.messages)
+		daemon.mu.Unlock()
+		if n > 2 {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got, want := daemon.waitFor(t, 3)[2], "after"; got != want {
+		t.Errorf("message after reconnection: %q, want %q", got, want)
+	}
+}
+
+func TestTruncateSyslogMessage(t *testing.T) {
+	testcases := []struct {
+		message string
+		maxSize int
+		want    string
+	}{
+		{"abc\n", 0, "abc"},
+		{"abc", 3, "abc"},
+		{"0123456789abcdefghij", 16, "01" + SyslogTruncatedMarker},
+		{"0123456789abcdefghij", 4, "...["},
+	}
+	for _, tcase := range testcases {
+		if got := truncateSyslogMessage(tcase.message, tcase.maxSize); got != tcase.want {
+			t.Errorf("truncateSyslogMessage(%q, %d): %q, want %q", tcase.message, tcase.maxSize, got, tcase.want)
+		}
+	}
+}
+
+func TestParseSyslogFacility(t *testing.T) {
+	if got, err := ParseSyslogFacility("LOCAL3"); err != nil || got != syslog.LOG_LOCAL3 {
+		t.Errorf("ParseSyslogFacility(LOCAL3): %v, %v, want %v", got, err, syslog.LOG_LOCAL3)
+	}
+	if _, err := ParseSyslogFacility("nope"); err == nil {
+		t.Errorf("ParseSyslogFacility(nope): nil, want error")
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/youtube/vitess/go/clock"
 	"github.com/youtube/vitess/go/sync2"
 )
 
@@ -45,6 +46,7 @@ will react only to an explicit Trigger or Stop.
 */
 type Timer struct {
 	interval sync2.AtomicDuration
+	clock    clock.Clock
 
 	// state management
 	mu      sync.Mutex
@@ -56,8 +58,15 @@ type Timer struct {
 
 // NewTimer creates a new Timer object
 func NewTimer(interval time.Duration) *Timer {
+	return NewTimerWithClock(interval, clock.Real)
+}
+
+// NewTimerWithClock creates a new Timer object whose interval is
+// measured by c.
+func NewTimerWithClock(interval time.Duration, c clock.Clock) *Timer {
 	tm := &Timer{
-		msg: make(chan typeAction),
+		clock: c,
+		msg:   make(chan typeAction),
 	}
 	tm.interval.Set(interval)
 	return tm
@@ -81,7 +90,7 @@ func (tm *Timer) run(keephouse func()) {
 		if interval <= 0 {
 			ch = nil
 		} else {
-			ch = time.After(interval)
+			ch = tm.clock.After(interval)
 		}
 		select {
 		case action := <-tm.msg:
@@ -121,7 +130,7 @@ func (tm *Timer) Trigger() {
 // TriggerAfter waits for the specified duration and triggers the next event.
 func (tm *Timer) TriggerAfter(duration time.Duration) {
 	go func() {
-		time.Sleep(duration)
+		tm.clock.Sleep(duration)
 		tm.Trigger()
 	}()
 }
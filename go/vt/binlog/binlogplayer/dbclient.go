@@ -11,6 +11,7 @@ import (
 	"github.com/youtube/vitess/go/sqldb"
 	"github.com/youtube/vitess/go/sqltypes"
 	"github.com/youtube/vitess/go/vt/dbconfigs"
+	"github.com/youtube/vitess/go/vt/dbconnpool"
 )
 
 // DBClient is a real VtClient backed by a mysql connection
@@ -44,7 +45,7 @@ func (dc *DBClient) Connect() error {
 	if err != nil {
 		return err
 	}
-	dc.dbConn, err = sqldb.Connect(params)
+	dc.dbConn, err = dbconnpool.ConnectWithAddrRefresh(params)
 	if err != nil {
 		return fmt.Errorf("error in connecting to mysql db, err %v", err)
 	}
@@ -120,13 +120,33 @@ func (dbc *DBConnection) VerifyStrict() bool {
 	return strings.Contains(qr.Rows[0][0].String(), "STRICT_TRANS_TABLES")
 }
 
+var getMaxAllowedPacketSQL = "select @@max_allowed_packet"
+
+// MaxAllowedPacket returns the max_allowed_packet of the connection,
+// which is the max size in bytes of a statement MySQL accepts.
+func (dbc *DBConnection) MaxAllowedPacket() (int, error) {
+	qr, err := dbc.ExecuteFetch(getMaxAllowedPacketSQL, 1, false)
+	if err != nil {
+		return 0, err
+	}
+	if len(qr.Rows) != 1 || len(qr.Rows[0]) != 1 {
+		return 0, fmt.Errorf("unexpected result for %v: %v", getMaxAllowedPacketSQL, qr.Rows)
+	}
+	size, err := qr.Rows[0][0].ParseInt64()
+	if err != nil {
+		return 0, fmt.Errorf("unexpected max_allowed_packet %v: %v", qr.Rows[0][0], err)
+	}
+	return int(size), nil
+}
+
 // NewDBConnection returns a new DBConnection based on the ConnParams
 // and will use the provided stats to collect timing.
+// See ConnectWithAddrRefresh for the retry on host errors.
 func NewDBConnection(info *sqldb.ConnParams, mysqlStats *stats.Timings) (*DBConnection, error) {
 	params, err := dbconfigs.MysqlParams(info)
 	if err != nil {
 		return nil, err
 	}
-	c, err := sqldb.Connect(params)
+	c, err := ConnectWithAddrRefresh(params)
 	return &DBConnection{c, mysqlStats}, err
 }
@@ -0,0 +1,105 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbconnpool
+
+import (
+	"flag"
+	"net"
+	"reflect"
+	"sort"
+	"sync"
+
+	log "github.com/golang/glog"
+	"github.com/youtube/vitess/go/sqldb"
+)
+
+var dbAddrRefreshOnFailure = flag.Bool("db_addr_refresh_on_failure", true, "when a new MySQL connection fails because the host is unknown or refuses the connection, resolve the MySQL host name again, and retry once. The old and new addresses are logged when they change. This applies to all the MySQL connections of the tablet: the query service pools, the dba pool and the filtered replication.")
+
+const (
+	// mysqlConnHostError is CR_CONN_HOST_ERROR, returned when the
+	// server refuses the connection.
+	mysqlConnHostError = 2003
+	// mysqlUnknownHost is CR_UNKNOWN_HOST.
+	mysqlUnknownHost = 2005
+)
+
+// lookupHost is net.LookupHost. The tests replace it with a fake resolver.
+var lookupHost = net.LookupHost
+
+// sqldbConnect is sqldb.Connect. The tests replace it to fail the
+// connections.
+var sqldbConnect = sqldb.Connect
+
+// dbAddrs keeps the addresses the MySQL host names were last resolved to.
+var dbAddrs = newDBAddrCache()
+
+// dbAddrCache remembers the addresses of the MySQL host names, so that
+// a change of address can be logged when they're resolved again.
+type dbAddrCache struct {
+	mu    sync.Mutex
+	addrs map[string][]string
+}
+
+func newDBAddrCache() *dbAddrCache {
+	return &dbAddrCache{addrs: make(map[string][]string)}
+}
+
+// refresh resolves host again, and logs its old and new addresses if
+// they changed. It returns false if host cannot be resolved.
+func (dc *dbAddrCache) refresh(host string) bool {
+	addrs, err := lookupHost(host)
+	if err != nil {
+		log.Warningf("cannot resolve MySQL host %v: %v", host, err)
+		return false
+	}
+	sort.Strings(addrs)
+	dc.mu.Lock()
+	defer dc.mu.Unlock()
+	old, ok := dc.addrs[host]
+	switch {
+	case !ok:
+		log.Infof("MySQL host %v resolved to %v", host, addrs)
+	case !reflect.DeepEqual(old, addrs):
+		log.Warningf("MySQL host %v moved from %v to %v", host, old, addrs)
+	}
+	dc.addrs[host] = addrs
+	return true
+}
+
+// isHostErr returns true if err means that the MySQL host is unknown
+// or refused the connection, which is what happens on a connect after
+// MySQL moved to another address.
+func isHostErr(err error) bool {
+	sqlErr, ok := err.(*sqldb.SQLError)
+	if !ok {
+		return false
+	}
+	switch sqlErr.Number() {
+	case mysqlConnHostError, mysqlUnknownHost:
+		return true
+	}
+	return false
+}
+
+// ConnectWithAddrRefresh is sqldb.Connect, for params returned by
+// dbconfigs.MysqlParams. If the connection fails with a host error and
+// -db_addr_refresh_on_failure is set, the host name is resolved again
+// and the connection is retried once. The MySQL client resolves the
+// host on each connect, so the retry goes to the new address.
+func ConnectWithAddrRefresh(params sqldb.ConnParams) (sqldb.Conn, error) {
+	c, err := sqldbConnect(params)
+	if err == nil || !*dbAddrRefreshOnFailure || !isHostErr(err) {
+		return c, err
+	}
+	// Unix sockets and IP addresses have nothing to refresh.
+	if params.UnixSocket != "" || params.Host == "" || net.ParseIP(params.Host) != nil {
+		return c, err
+	}
+	if !dbAddrs.refresh(params.Host) {
+		return c, err
+	}
+	log.Infof("retrying the MySQL connection to %v after %v", params.Host, err)
+	return sqldbConnect(params)
+}
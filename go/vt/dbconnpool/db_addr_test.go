@@ -0,0 +1,147 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package dbconnpool
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/youtube/vitess/go/sqldb"
+	"github.com/youtube/vitess/go/vt/vttest/fakesqldb"
+)
+
+func TestDBAddrCacheRefresh(t *testing.T) {
+	defer func(f func(string) ([]string, error)) { lookupHost = f }(lookupHost)
+	addrs := map[string][]string{"db1": {"10.0.0.2", "10.0.0.1"}}
+	lookupHost = func(host string) ([]string, error) {
+		if a, ok := addrs[host]; ok {
+			return a, nil
+		}
+		return nil, fmt.Errorf("lookup %v: no such host", host)
+	}
+
+	dc := newDBAddrCache()
+	if !dc.refresh("db1") {
+		t.Errorf("refresh(db1): false, want true")
+	}
+	if got, want := fmt.Sprint(dc.addrs["db1"]), "[10.0.0.1 10.0.0.2]"; got != want {
+		t.Errorf("addrs[db1]: %v, want %v", got, want)
+	}
+	addrs["db1"] = []string{"10.0.0.3"}
+	if !dc.refresh("db1") {
+		t.Errorf("refresh(db1) after the move: false, want true")
+	}
+	if got, want := fmt.Sprint(dc.addrs["db1"]), "[10.0.0.3]"; got != want {
+		t.Errorf("addrs[db1] after the move: %v, want %v", got, want)
+	}
+	if dc.refresh("db2") {
+		t.Errorf("refresh(db2): true, want false")
+	}
+}
+
+func TestConnectWithAddrRefresh(t *testing.T) {
+	db := fakesqldb.Register()
+	defer func(f func(string) ([]string, error)) { lookupHost = f }(lookupHost)
+	lookupHost = func(host string) ([]string, error) { return []string{"10.0.0.1"}, nil }
+	defer func(f func(sqldb.ConnParams) (sqldb.Conn, error)) { sqldbConnect = f }(sqldbConnect)
+	defer func(v bool) { *dbAddrRefreshOnFailure = v }(*dbAddrRefreshOnFailure)
+
+	var failures, attempts int
+	errNum := mysqlConnHostError
+	sqldbConnect = func(params sqldb.ConnParams) (sqldb.Conn, error) {
+		attempts++
+		if attempts <= failures {
+			return nil, sqldb.NewSQLError(errNum, "cannot connect")
+		}
+		return sqldb.Connect(params)
+	}
+
+	testcases := []struct {
+		desc     string
+		info     sqldb.ConnParams
+		refresh  bool
+		errNum   int
+		failures int
+		attempts int
+		wantErr  bool
+	}{{
+		desc:     "no failure",
+		info:     sqldb.ConnParams{Engine: db.Name, Host: "db1"},
+		refresh:  true,
+		errNum:   mysqlConnHostError,
+		failures: 0,
+		attempts: 1,
+	}, {
+		desc:     "connection refused",
+		info:     sqldb.ConnParams{Engine: db.Name, Host: "db1"},
+		refresh:  true,
+		errNum:   mysqlConnHostError,
+		failures: 1,
+		attempts: 2,
+	}, {
+		desc:     "unknown host",
+		info:     sqldb.ConnParams{Engine: db.Name, Host: "db1"},
+		refresh:  true,
+		errNum:   mysqlUnknownHost,
+		failures: 1,
+		attempts: 2,
+	}, {
+		desc:     "retried only once",
+		info:     sqldb.ConnParams{Engine: db.Name, Host: "db1"},
+		refresh:  true,
+		errNum:   mysqlConnHostError,
+		failures: 2,
+		attempts: 2,
+		wantErr:  true,
+	}, {
+		desc:     "flag off",
+		info:     sqldb.ConnParams{Engine: db.Name, Host: "db1"},
+		refresh:  false,
+		errNum:   mysqlConnHostError,
+		failures: 1,
+		attempts: 1,
+		wantErr:  true,
+	}, {
+		desc:     "other error",
+		info:     sqldb.ConnParams{Engine: db.Name, Host: "db1"},
+		refresh:  true,
+		errNum:   1045,
+		failures: 1,
+		attempts: 1,
+		wantErr:  true,
+	}, {
+		desc:     "unix socket",
+		info:     sqldb.ConnParams{Engine: db.Name, Host: "db1", UnixSocket: "/tmp/mysql.sock"},
+		refresh:  true,
+		errNum:   mysqlConnHostError,
+		failures: 1,
+		attempts: 1,
+		wantErr:  true,
+	}, {
+		desc:     "IP address",
+		info:     sqldb.ConnParams{Engine: db.Name, Host: "10.0.0.1"},
+		refresh:  true,
+		errNum:   mysqlConnHostError,
+		failures: 1,
+		attempts: 1,
+		wantErr:  true,
+	}}
+	for _, tcase := range testcases {
+		*dbAddrRefreshOnFailure = tcase.refresh
+		errNum = tcase.errNum
+		failures = tcase.failures
+		attempts = 0
+		c, err := ConnectWithAddrRefresh(tcase.info)
+		if c != nil {
+			c.Close()
+		}
+		if (err != nil) != tcase.wantErr {
+			t.Errorf("%s: err: %v, want error: %v", tcase.desc, err, tcase.wantErr)
+		}
+		if attempts != tcase.attempts {
+			t.Errorf("%s: attempts: %d, want %d", tcase.desc, attempts, tcase.attempts)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package discovery
+
+import (
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/golang/glog"
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/netutil"
+	"github.com/youtube/vitess/go/vt/topo"
+
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+)
+
+// lookupSRV is net.LookupSRV. The tests replace it with a fake resolver.
+var lookupSRV = net.LookupSRV
+
+// DNSName returns the name of the SRV records that list the tablets of
+// a keyspace/shard in a cell: _vitess._tcp.{keyspace}.{shard}.{cell},
+// followed by domain if it's not empty. The shard is encoded by
+// DNSShardLabel.
+func DNSName(keyspace, shard, cell, domain string) string {
+	name := fmt.Sprintf("_vitess._tcp.%v.%v.%v", keyspace, DNSShardLabel(shard), cell)
+	if domain != "" {
+		name += "." + domain
+	}
+	return name
+}
+
+// DNSShardLabel returns the DNS label of a shard name. The key range
+// shards keep their hex bounds, with "0" for an empty bound, since a
+// label can't start or end with a hyphen: "-80" is "0-80", "80-" is
+// "80-0", "40-80" is unchanged and "-" is "0-0". The real bounds have
+// an even number of digits, so they are never "0". The other names are
+// unchanged if they are valid labels, like "0", and are otherwise "x"
+// followed by their hex encoding.
+func DNSShardLabel(shard string) string {
+	if parts := strings.Split(shard, "-"); len(parts) == 2 {
+		for i, bound := range parts {
+			if bound == "" {
+				parts[i] = "0"
+			}
+		}
+		label := strings.ToLower(parts[0] + "-" + parts[1])
+		if isDNSLabel(label) {
+			return label
+		}
+	}
+	if isDNSLabel(shard) {
+		return shard
+	}
+	return "x" + hex.EncodeToString([]byte(shard))
+}
+
+// isDNSLabel returns true if label is a valid DNS label: 1 to 63
+// letters, digits and hyphens, that doesn't start or end with a hyphen.
+func isDNSLabel(label string) bool {
+	if len(label) == 0 || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+		return false
+	}
+	for _, c := range label {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '-':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// DNSWatcher pulls the endpoints of a set of shards from the DNS SRV
+// records periodically, instead of reading the tablets from the
+// topology server. The endpoints only have the host and the port of
+// the SRV records, under portName. The health check finds out the
+// rest from the tablets.
+type DNSWatcher struct {
+	// set at construction time
+	hc              HealthCheck
+	cell            string
+	shards          []topo.KeyspaceShard
+	domain          string
+	portName        string
+	refreshInterval time.Duration
+	ctx             context.Context
+	cancelFunc      context.CancelFunc
+
+	// mu protects all variables below
+	mu sync.Mutex
+	// shardEndPoints contains the endpoints of each DNS name.
+	shardEndPoints map[string]map[string]*tabletEndPoint
+}
+
+// NewDNSWatcher returns a DNSWatcher that monitors the tablets of
+// shards in a cell, and starts refreshing.
+func NewDNSWatcher(hc HealthCheck, cell string, shards []topo.KeyspaceShard, domain, portName string, refreshInterval time.Duration) *DNSWatcher {
+	dw := &DNSWatcher{
+		hc:              hc,
+		cell:            cell,
+		shards:          shards,
+		domain:          domain,
+		portName:        portName,
+		refreshInterval: refreshInterval,
+		shardEndPoints:  make(map[string]map[string]*tabletEndPoint),
+	}
+	dw.ctx, dw.cancelFunc = context.WithCancel(context.Background())
+	go dw.watch()
+	return dw
+}
+
+// watch resolves all endpoints and notifies HealthCheck by adding/removing endpoints.
+func (dw *DNSWatcher) watch() {
+	ticker := time.NewTicker(dw.refreshInterval)
+	defer ticker.Stop()
+	for {
+		dw.loadEndPoints()
+		select {
+		case <-dw.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// resolve returns the endpoints of the SRV records of name.
+func (dw *DNSWatcher) resolve(name string) (map[string]*tabletEndPoint, error) {
+	_, addrs, err := lookupSRV("", "", name)
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]*tabletEndPoint, len(addrs))
+	for _, addr := range addrs {
+		endPoint := &topodatapb.EndPoint{
+			Host:    strings.TrimSuffix(addr.Target, "."),
+			PortMap: map[string]int32{dw.portName: int32(addr.Port)},
+		}
+		result[EndPointToMapKey(endPoint)] = &tabletEndPoint{
+			alias:    netutil.JoinHostPort(endPoint.Host, int32(addr.Port)),
+			endPoint: endPoint,
+		}
+	}
+	return result, nil
+}
+
+// loadEndPoints resolves the DNS names of all the shards, and updates
+// HealthCheck. If a name cannot be resolved, its previous endpoints
+// are kept, so that a DNS outage doesn't remove the tablets.
+func (dw *DNSWatcher) loadEndPoints() {
+	var wg sync.WaitGroup
+	newShardEndPoints := make(map[string]map[string]*tabletEndPoint)
+	for _, ks := range dw.shards {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			endPoints, err := dw.resolve(name)
+			dw.mu.Lock()
+			defer dw.mu.Unlock()
+			if err != nil {
+				select {
+				case <-dw.ctx.Done():
+					return
+				default:
+				}
+				log.Errorf("cannot resolve tablets for %v: %v", name, err)
+				endPoints = dw.shardEndPoints[name]
+			}
+			newShardEndPoints[name] = endPoints
+		}(DNSName(ks.Keyspace, ks.Shard, dw.cell, dw.domain))
+	}
+
+	wg.Wait()
+	dw.mu.Lock()
+	oldEndPoints := mergeEndPoints(dw.shardEndPoints)
+	newEndPoints := mergeEndPoints(newShardEndPoints)
+	for key, tep := range newEndPoints {
+		if _, ok := oldEndPoints[key]; !ok {
+			dw.hc.AddEndPoint(dw.cell, tep.alias, tep.endPoint)
+		}
+	}
+	for key, tep := range oldEndPoints {
+		if _, ok := newEndPoints[key]; !ok {
+			dw.hc.RemoveEndPoint(tep.endPoint)
+		}
+	}
+	dw.shardEndPoints = newShardEndPoints
+	dw.mu.Unlock()
+}
+
+// mergeEndPoints returns the endpoints of all the DNS names. A tablet
+// listed under several names is only returned once.
+func mergeEndPoints(shardEndPoints map[string]map[string]*tabletEndPoint) map[string]*tabletEndPoint {
+	result := make(map[string]*tabletEndPoint)
+	for _, endPoints := range shardEndPoints {
+		for key, tep := range endPoints {
+			result[key] = tep
+		}
+	}
+	return result
+}
+
+// Stop stops the watcher. It does not clean up the endpoints added to HealthCheck.
+func (dw *DNSWatcher) Stop() {
+	dw.cancelFunc()
+}
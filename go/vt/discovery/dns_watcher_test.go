@@ -0,0 +1,113 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package discovery
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/youtube/vitess/go/vt/topo"
+)
+
+// fakeResolver returns the SRV records set by the test.
+type fakeResolver struct {
+	mu      sync.Mutex
+	records map[string][]*net.SRV
+}
+
+func (fr *fakeResolver) set(name string, records []*net.SRV) {
+	fr.mu.Lock()
+	defer fr.mu.Unlock()
+	fr.records[name] = records
+}
+
+func (fr *fakeResolver) lookupSRV(service, proto, name string) (string, []*net.SRV, error) {
+	fr.mu.Lock()
+	defer fr.mu.Unlock()
+	records, ok := fr.records[name]
+	if !ok {
+		return "", nil, fmt.Errorf("lookup %v: no such host", name)
+	}
+	return name, records, nil
+}
+
+func TestDNSName(t *testing.T) {
+	if got, want := DNSName("ks", "-80", "aa", ""), "_vitess._tcp.ks.0-80.aa"; got != want {
+		t.Errorf("DNSName: %v, want %v", got, want)
+	}
+	if got, want := DNSName("ks", "-80", "aa", "example.com"), "_vitess._tcp.ks.0-80.aa.example.com"; got != want {
+		t.Errorf("DNSName with domain: %v, want %v", got, want)
+	}
+}
+
+func TestDNSShardLabel(t *testing.T) {
+	testcases := []struct {
+		shard string
+		want  string
+	}{
+		{"-80", "0-80"},
+		{"80-", "80-0"},
+		{"40-80", "40-80"},
+		{"-", "0-0"},
+		{"A0-C0", "a0-c0"},
+		{"0", "0"},
+		{"main", "main"},
+		{"my_shard", "x6d795f7368617264"},
+	}
+	for _, tc := range testcases {
+		if got := DNSShardLabel(tc.shard); got != tc.want {
+			t.Errorf("DNSShardLabel(%q): %v, want %v", tc.shard, got, tc.want)
+		}
+	}
+}
+
+func TestDNSWatcher(t *testing.T) {
+	fr := &fakeResolver{records: make(map[string][]*net.SRV)}
+	defer func(f func(string, string, string) (string, []*net.SRV, error)) {
+		lookupSRV = f
+	}(lookupSRV)
+	lookupSRV = fr.lookupSRV
+
+	fr.set("_vitess._tcp.ks.0.aa", []*net.SRV{{Target: "host1.", Port: 123}})
+	fhc := newFakeHealthCheck()
+	dw := NewDNSWatcher(fhc, "aa", []topo.KeyspaceShard{{Keyspace: "ks", Shard: "0"}}, "", "grpc", 10*time.Minute)
+	defer dw.Stop()
+	// Wait for the first refresh.
+	for i := 0; i < 500 && len(fhc.GetAllEndPoints()) == 0; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	checkEndPoints := func(desc string, want ...string) {
+		allEPs := fhc.GetAllEndPoints()
+		for _, key := range want {
+			if _, ok := allEPs[key]; !ok {
+				t.Errorf("%v: fhc.GetAllEndPoints() = %+v, want %v", desc, allEPs, key)
+			}
+		}
+		if len(allEPs) != len(want) {
+			t.Errorf("%v: fhc.GetAllEndPoints() = %+v, want %v", desc, allEPs, want)
+		}
+	}
+	checkEndPoints("first refresh", "host1,grpc:123")
+
+	// The tablet moves to another port, and a new one is added.
+	fr.set("_vitess._tcp.ks.0.aa", []*net.SRV{{Target: "host1.", Port: 456}, {Target: "host2.", Port: 123}})
+	dw.loadEndPoints()
+	checkEndPoints("new records", "host1,grpc:456", "host2,grpc:123")
+
+	// The endpoints are kept if DNS fails.
+	fr.mu.Lock()
+	delete(fr.records, "_vitess._tcp.ks.0.aa")
+	fr.mu.Unlock()
+	dw.loadEndPoints()
+	checkEndPoints("lookup error", "host1,grpc:456", "host2,grpc:123")
+
+	// The endpoints are removed when they're not in the records.
+	fr.set("_vitess._tcp.ks.0.aa", []*net.SRV{{Target: "host2.", Port: 123}})
+	dw.loadEndPoints()
+	checkEndPoints("removed record", "host2,grpc:123")
+}
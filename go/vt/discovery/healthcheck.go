@@ -1,16 +1,23 @@
 package discovery
 
 import (
+	"encoding/json"
+	"flag"
 	"fmt"
 	"html/template"
+	"net/http"
 	"sort"
 	"strings"
 	"sync"
 	"time"
 
 	log "github.com/golang/glog"
+	"github.com/youtube/vitess/go/acl"
+	"github.com/youtube/vitess/go/clock"
+	"github.com/youtube/vitess/go/history"
 	"github.com/youtube/vitess/go/netutil"
 	"github.com/youtube/vitess/go/stats"
+	"github.com/youtube/vitess/go/sync2"
 	querypb "github.com/youtube/vitess/go/vt/proto/query"
 	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
 	"github.com/youtube/vitess/go/vt/tabletserver/tabletconn"
@@ -18,13 +25,21 @@ import (
 	"golang.org/x/net/context"
 )
 
+var (
+	healthHistorySize = flag.Int("discovery_health_history_size", 100, "number of health check results kept for each tablet, see /debug/tablet_health_history")
+)
+
 var (
 	hcConnCounters  *stats.MultiCountersFunc
 	hcErrorCounters *stats.MultiCounters
+	// hcLagFilteredCounters counts the health check responses of each
+	// tablet that were not serving because of the replication lag.
+	hcLagFilteredCounters *stats.MultiCounters
 )
 
 func init() {
 	hcErrorCounters = stats.NewMultiCounters("HealthcheckErrors", []string{"keyspace", "shardname", "tablettype"})
+	hcLagFilteredCounters = stats.NewMultiCounters("HealthcheckReplicationLagFiltered", []string{"keyspace", "shardname", "tablet"})
 }
 
 // HealthCheckStatsListener is the listener to receive health check stats update.
@@ -45,6 +60,15 @@ type EndPointStats struct {
 	LastError                           error
 }
 
+// HealthCheckResult is the result of a health check, kept in the
+// history of the tablet.
+type HealthCheckResult struct {
+	Time                time.Time
+	SecondsBehindMaster uint32
+	Error               string
+	Serving             bool
+}
+
 // HealthCheck defines the interface of health checking module.
 type HealthCheck interface {
 	// SetListener sets the listener for healthcheck updates. It should not block.
@@ -66,18 +90,42 @@ type HealthCheck interface {
 }
 
 // NewHealthCheck creates a new HealthCheck object.
+// The failed health checks are retried every retryDelay.
 func NewHealthCheck(connTimeout time.Duration, retryDelay time.Duration, healthCheckTimeout time.Duration, statsSuffix string) HealthCheck {
+	return NewHealthCheckWithBackoff(connTimeout, retryDelay, retryDelay, 1, healthCheckTimeout, statsSuffix)
+}
+
+// NewHealthCheckWithBackoff creates a new HealthCheckImpl object whose
+// retries back off exponentially. The first retry of an endpoint is
+// after retryDelay, and each following one waits retryMultiplier times
+// longer, up to retryMaxDelay. The delay goes back to retryDelay after
+// a successful health check. Each endpoint has its own delay.
+func NewHealthCheckWithBackoff(connTimeout, retryDelay, retryMaxDelay time.Duration, retryMultiplier float64, healthCheckTimeout time.Duration, statsSuffix string) *HealthCheckImpl {
+	return newHealthCheck(connTimeout, retryDelay, retryMaxDelay, retryMultiplier, healthCheckTimeout, statsSuffix, clock.Real)
+}
+
+// newHealthCheck is NewHealthCheckWithBackoff, with the retry delays and
+// the health check timeout measured by c.
+func newHealthCheck(connTimeout, retryDelay, retryMaxDelay time.Duration, retryMultiplier float64, healthCheckTimeout time.Duration, statsSuffix string, c clock.Clock) *HealthCheckImpl {
 	hc := &HealthCheckImpl{
 		addrToConns:        make(map[string]*healthCheckConn),
 		targetToEPs:        make(map[string]map[string]map[topodatapb.TabletType][]*topodatapb.EndPoint),
 		connTimeout:        connTimeout,
 		retryDelay:         retryDelay,
+		retryMaxDelay:      retryMaxDelay,
+		retryMultiplier:    retryMultiplier,
 		healthCheckTimeout: healthCheckTimeout,
 		closeChan:          make(chan struct{}),
+		clock:              c,
+		historySize:        *healthHistorySize,
+	}
+	if hc.historySize < 1 {
+		hc.historySize = 1
 	}
 	if hcConnCounters == nil {
 		hcConnCounters = stats.NewMultiCountersFunc("HealthcheckConnections"+statsSuffix, []string{"keyspace", "shardname", "tablettype"}, hc.servingConnStats)
 	}
+	t := c.NewTicker(healthCheckTimeout / 3)
 	go func() {
 		// Start another go routine to check timeout.
 		// Currently vttablet sends healthcheck response every 20 seconds.
@@ -85,13 +133,12 @@ func NewHealthCheck(connTimeout time.Duration, retryDelay time.Duration, healthC
 		// and also perform the timeout check in sync with vttablet frequency.
 		// When we change the healthcheck frequency on vttablet,
 		// we should also adjust here.
-		t := time.NewTicker(healthCheckTimeout / 3)
 		defer t.Stop()
 		for {
 			select {
 			case <-hc.closeChan:
 				return
-			case _, ok := <-t.C:
+			case _, ok := <-t.Chan():
 				if !ok {
 					// the ticker stoped
 					return
@@ -109,8 +156,16 @@ type HealthCheckImpl struct {
 	listener           HealthCheckStatsListener
 	connTimeout        time.Duration
 	retryDelay         time.Duration
+	retryMaxDelay      time.Duration
+	retryMultiplier    float64
 	healthCheckTimeout time.Duration
 	closeChan          chan struct{} // signals the process gorouting to terminate
+	clock              clock.Clock   // measures the retry delays and the health check timeout
+	historySize        int           // number of results in the history of each endpoint
+
+	// maxReplicationLag is the lag above which the non-master
+	// endpoints are not serving. 0 means no limit.
+	maxReplicationLag sync2.AtomicDuration
 
 	// mu protects all the following fields
 	// when locking both mutex from HealthCheck and healthCheckConn, HealthCheck.mu goes first.
@@ -127,6 +182,7 @@ type healthCheckConn struct {
 	ctx        context.Context
 	cancelFunc context.CancelFunc
 	endPoint   *topodatapb.EndPoint
+	clock      clock.Clock
 
 	// mu protects all the following fields
 	// when locking both mutex from HealthCheck and healthCheckConn, HealthCheck.mu goes first.
@@ -138,7 +194,28 @@ type healthCheckConn struct {
 	tabletExternallyReparentedTimestamp int64
 	stats                               *querypb.RealtimeStats
 	lastError                           error
-	lastResponseTimestamp               time.Time // timestamp of the last healthcheck response
+	lastResponseTimestamp               time.Time     // timestamp of the last healthcheck response
+	retryDelay                          time.Duration // delay before the next retry
+
+	// history has the last health check results. It has its own
+	// internal mutex.
+	history *history.History
+}
+
+// addHistory adds the current health check result to the history.
+// LOCK_REQUIRED hcc.mu
+func (hcc *healthCheckConn) addHistory() {
+	result := &HealthCheckResult{
+		Time:    hcc.clock.Now(),
+		Serving: hcc.serving,
+	}
+	if hcc.stats != nil {
+		result.SecondsBehindMaster = hcc.stats.SecondsBehindMaster
+	}
+	if hcc.lastError != nil {
+		result.Error = hcc.lastError.Error()
+	}
+	hcc.history.Add(result)
 }
 
 // servingConnStats returns the number of serving endpoints per keyspace/shard/tablet type.
@@ -181,18 +258,22 @@ func (hc *HealthCheckImpl) checkConn(hcc *healthCheckConn, cell, name string, en
 			hcc.mu.Lock()
 			hcc.serving = false
 			hcc.lastError = err
+			hcc.addHistory()
 			target := hcc.target
 			hcc.mu.Unlock()
 			hcErrorCounters.Add([]string{target.Keyspace, target.Shard, strings.ToLower(target.TabletType.String())}, 1)
-			time.Sleep(hc.retryDelay)
+			hc.clock.Sleep(hcc.nextRetryDelay(hc))
 			continue
 		}
 		for {
 			reconnect, err := hcc.processResponse(hc, endPoint, stream)
-			if err != nil {
+			if err == nil {
+				hcc.resetRetryDelay(hc)
+			} else {
 				hcc.mu.Lock()
 				hcc.serving = false
 				hcc.lastError = err
+				hcc.addHistory()
 				eps := &EndPointStats{
 					EndPoint: endPoint,
 					Cell:     hcc.cell,
@@ -222,7 +303,7 @@ func (hc *HealthCheckImpl) checkConn(hcc *healthCheckConn, cell, name string, en
 					hcc.conn = nil
 					hcc.target = &querypb.Target{}
 					hcc.mu.Unlock()
-					time.Sleep(hc.retryDelay)
+					hc.clock.Sleep(hcc.nextRetryDelay(hc))
 					break
 				}
 			}
@@ -230,6 +311,31 @@ func (hc *HealthCheckImpl) checkConn(hcc *healthCheckConn, cell, name string, en
 	}
 }
 
+// nextRetryDelay returns the delay to wait before retrying the health
+// check, and backs off the delay of the following retry.
+func (hcc *healthCheckConn) nextRetryDelay(hc *HealthCheckImpl) time.Duration {
+	hcc.mu.Lock()
+	defer hcc.mu.Unlock()
+	delay := hcc.retryDelay
+	next := time.Duration(float64(delay) * hc.retryMultiplier)
+	if next > hc.retryMaxDelay {
+		next = hc.retryMaxDelay
+	}
+	if next < hc.retryDelay {
+		next = hc.retryDelay
+	}
+	hcc.retryDelay = next
+	return delay
+}
+
+// resetRetryDelay sets the delay of the next retry back to the base
+// delay, after a successful health check.
+func (hcc *healthCheckConn) resetRetryDelay(hc *HealthCheckImpl) {
+	hcc.mu.Lock()
+	hcc.retryDelay = hc.retryDelay
+	hcc.mu.Unlock()
+}
+
 // connect creates connection to the endpoint and starts streaming.
 func (hcc *healthCheckConn) connect(hc *HealthCheckImpl, endPoint *topodatapb.EndPoint) (tabletconn.StreamHealthReader, error) {
 	// Keyspace, shard and tabletType are not known yet, but they're unused
@@ -275,6 +381,19 @@ func (hcc *healthCheckConn) processResponse(hc *HealthCheckImpl, endPoint *topod
 		healthErr = fmt.Errorf("vttablet error: %v", shr.RealtimeStats.HealthError)
 		serving = false
 	}
+	// a lagging replica doesn't serve the reads, the master is kept
+	// for the writes.
+	if maxLag := hc.maxReplicationLag.Get(); serving && maxLag > 0 && shr.Target.TabletType != topodatapb.TabletType_MASTER {
+		if lag := time.Duration(shr.RealtimeStats.SecondsBehindMaster) * time.Second; lag > maxLag {
+			healthErr = fmt.Errorf("replication lag %v is higher than %v", lag, maxLag)
+			serving = false
+			name := hcc.name
+			if name == "" {
+				name = EndPointToMapKey(endPoint)
+			}
+			hcLagFilteredCounters.Add([]string{shr.Target.Keyspace, shr.Target.Shard, name}, 1)
+		}
+	}
 
 	if hcc.target.TabletType == topodatapb.TabletType_UNKNOWN {
 		// The first time we see response for the endpoint.
@@ -315,12 +434,13 @@ func (hcc *healthCheckConn) processResponse(hc *HealthCheckImpl, endPoint *topod
 
 func (hcc *healthCheckConn) update(shr *querypb.StreamHealthResponse, serving bool, healthErr error, setTarget bool) {
 	hcc.mu.Lock()
-	hcc.lastResponseTimestamp = time.Now()
+	hcc.lastResponseTimestamp = hcc.clock.Now()
 	hcc.target = shr.Target
 	hcc.serving = serving
 	hcc.tabletExternallyReparentedTimestamp = shr.TabletExternallyReparentedTimestamp
 	hcc.stats = shr.RealtimeStats
 	hcc.lastError = healthErr
+	hcc.addHistory()
 	if setTarget {
 		hcc.conn.SetTarget(hcc.target.Keyspace, hcc.target.Shard, hcc.target.TabletType)
 	}
@@ -341,7 +461,7 @@ func (hc *HealthCheckImpl) checkHealthCheckTimeout() {
 			hcc.mu.RUnlock()
 			continue
 		}
-		if time.Now().Sub(hcc.lastResponseTimestamp) < hc.healthCheckTimeout {
+		if hc.clock.Now().Sub(hcc.lastResponseTimestamp) < hc.healthCheckTimeout {
 			// received a healthcheck response recently
 			hcc.mu.RUnlock()
 			continue
@@ -355,13 +475,14 @@ func (hc *HealthCheckImpl) checkHealthCheckTimeout() {
 			hcc.mu.Unlock()
 			continue
 		}
-		if time.Now().Sub(hcc.lastResponseTimestamp) < hc.healthCheckTimeout {
+		if hc.clock.Now().Sub(hcc.lastResponseTimestamp) < hc.healthCheckTimeout {
 			// received a healthcheck response recently
 			hcc.mu.Unlock()
 			continue
 		}
 		hcc.serving = false
 		hcc.lastError = fmt.Errorf("healthcheck timed out (latest %v)", hcc.lastResponseTimestamp)
+		hcc.addHistory()
 		eps := &EndPointStats{
 			EndPoint: hcc.endPoint,
 			Cell:     hcc.cell,
@@ -401,6 +522,14 @@ func (hc *HealthCheckImpl) deleteConn(endPoint *topodatapb.EndPoint) {
 	hc.deleteEndPointFromTargetProtected(hcc.target, endPoint)
 }
 
+// SetMaxReplicationLag sets the replication lag above which the
+// non-master endpoints are not serving. 0 disables the limit. It can
+// be changed at any time, and applies from the next health check
+// response of each endpoint.
+func (hc *HealthCheckImpl) SetMaxReplicationLag(d time.Duration) {
+	hc.maxReplicationLag.Set(d)
+}
+
 // SetListener sets the listener for healthcheck updates. It should not block.
 func (hc *HealthCheckImpl) SetListener(listener HealthCheckStatsListener) {
 	hc.listener = listener
@@ -419,6 +548,9 @@ func (hc *HealthCheckImpl) AddEndPoint(cell, name string, endPoint *topodatapb.E
 		endPoint:   endPoint,
 		target:     &querypb.Target{},
 		up:         true,
+		retryDelay: hc.retryDelay,
+		history:    history.New(hc.historySize),
+		clock:      hc.clock,
 	}
 	key := EndPointToMapKey(endPoint)
 	hc.mu.Lock()
@@ -710,6 +842,97 @@ func (hc *HealthCheckImpl) CacheStatus() EndPointsCacheStatusList {
 	return epcsl
 }
 
+// RetryDelays returns the delay before the next health check retry of
+// each endpoint, by EndPointToMapKey.
+func (hc *HealthCheckImpl) RetryDelays() map[string]time.Duration {
+	hc.mu.RLock()
+	defer hc.mu.RUnlock()
+	delays := make(map[string]time.Duration, len(hc.addrToConns))
+	for key, hcc := range hc.addrToConns {
+		hcc.mu.RLock()
+		delays[key] = hcc.retryDelay
+		hcc.mu.RUnlock()
+	}
+	return delays
+}
+
+// GetTabletHistory returns the last health check results of the
+// tablet, most recent first. The tablet is identified by the name it
+// was added with, or by its endpoint key if it has no name.
+func (hc *HealthCheckImpl) GetTabletHistory(tabletAlias string) []HealthCheckResult {
+	hc.mu.RLock()
+	defer hc.mu.RUnlock()
+	for key, hcc := range hc.addrToConns {
+		if hcc.name != tabletAlias && (hcc.name != "" || key != tabletAlias) {
+			continue
+		}
+		records := hcc.history.Records()
+		results := make([]HealthCheckResult, 0, len(records))
+		for _, record := range records {
+			results = append(results, *record.(*HealthCheckResult))
+		}
+		return results
+	}
+	return nil
+}
+
+// ServeTabletHistory serves the health check history of the tablet
+// given by the "alias" parameter as JSON, or of all the tablets if
+// there's no parameter.
+func (hc *HealthCheckImpl) ServeTabletHistory(w http.ResponseWriter, r *http.Request) {
+	if err := acl.CheckAccessHTTP(r, acl.DEBUGGING); err != nil {
+		acl.SendError(w, err)
+		return
+	}
+	var data interface{}
+	if alias := r.FormValue("alias"); alias != "" {
+		data = hc.GetTabletHistory(alias)
+	} else {
+		hc.mu.RLock()
+		names := make([]string, 0, len(hc.addrToConns))
+		for key, hcc := range hc.addrToConns {
+			if hcc.name != "" {
+				key = hcc.name
+			}
+			names = append(names, key)
+		}
+		hc.mu.RUnlock()
+		all := make(map[string][]HealthCheckResult, len(names))
+		for _, name := range names {
+			all[name] = hc.GetTabletHistory(name)
+		}
+		data = all
+	}
+	b, err := json.MarshalIndent(data, "", " ")
+	if err != nil {
+		log.Errorf("Cannot marshal health check history: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Write(b)
+}
+
+// ServeHTTP serves the retry delays of the endpoints as JSON.
+func (hc *HealthCheckImpl) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := acl.CheckAccessHTTP(r, acl.DEBUGGING); err != nil {
+		acl.SendError(w, err)
+		return
+	}
+	delays := make(map[string]string)
+	for key, delay := range hc.RetryDelays() {
+		delays[key] = delay.String()
+	}
+	b, err := json.MarshalIndent(delays, "", " ")
+	if err != nil {
+		log.Errorf("Cannot marshal health check retry delays: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Write(b)
+}
+
 // Close stops the healthcheck.
 func (hc *HealthCheckImpl) Close() error {
 	hc.mu.Lock()
@@ -4,9 +4,13 @@ import (
 	"flag"
 	"fmt"
 	"reflect"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/youtube/vitess/go/clock"
+	"github.com/youtube/vitess/go/clock/fakeclock"
 	"github.com/youtube/vitess/go/sqltypes"
 	"github.com/youtube/vitess/go/vt/tabletserver/querytypes"
 	"github.com/youtube/vitess/go/vt/tabletserver/tabletconn"
@@ -17,7 +21,12 @@ import (
 	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
 )
 
-var connMap map[string]*fakeConn
+// connMap is guarded by connMapMu: the checkConn go routines of a
+// test can still dial after it returned.
+var (
+	connMapMu sync.Mutex
+	connMap   map[string]*fakeConn
+)
 
 func init() {
 	tabletconn.RegisterDialer("fake_discovery", discoveryDialer)
@@ -188,14 +197,15 @@ func TestHealthCheck(t *testing.T) {
 }
 
 func TestHealthCheckTimeout(t *testing.T) {
-	timeout := 500 * time.Millisecond
+	timeout := time.Minute
 	ep := topo.NewEndPoint(0, "a")
 	ep.PortMap["vt"] = 1
 	input := make(chan *querypb.StreamHealthResponse)
 	createFakeConn(ep, input)
 	t.Logf(`createFakeConn({Host: "a", PortMap: {"vt": 1}}, c)`)
 	l := newListener()
-	hc := NewHealthCheck(1*time.Millisecond, 1*time.Millisecond, timeout, "" /* statsSuffix */).(*HealthCheckImpl)
+	clk := fakeclock.New(time.Now())
+	hc := newHealthCheck(1*time.Millisecond, 1*time.Millisecond, 1*time.Millisecond, 1, timeout, "" /* statsSuffix */, clk)
 	hc.SetListener(l)
 	hc.AddEndPoint("cell", "", ep)
 	t.Logf(`hc = HealthCheck(); hc.AddEndPoint("cell", "", {Host: "a", PortMap: {"vt": 1}})`)
@@ -227,8 +237,8 @@ func TestHealthCheckTimeout(t *testing.T) {
 		t.Errorf(`hc.GetEndPointStatsFromKeyspaceShard("k", "s") = %+v; want %+v`, epsList, want)
 	}
 	// wait for timeout period
-	time.Sleep(2 * timeout)
-	t.Logf(`Sleep(2 * timeout)`)
+	clk.Advance(2 * timeout)
+	t.Logf(`Advance(2 * timeout)`)
 	res = <-l.output
 	if res.Serving {
 		t.Errorf(`<-l.output: %+v; want not serving`, res)
@@ -252,6 +262,163 @@ func TestHealthCheckTimeout(t *testing.T) {
 	hc.Close()
 }
 
+func TestHealthCheckBackoff(t *testing.T) {
+	ep := topo.NewEndPoint(0, "b")
+	ep.PortMap["vt"] = 1
+	// The stream fails right away.
+	input := make(chan *querypb.StreamHealthResponse)
+	close(input)
+	fc := createFakeConn(ep, input)
+	// The fake sleep records the delays, and runs in the checkConn
+	// go routine, so it can change the stream between the retries.
+	// Once the checks are done, it blocks until the test stops
+	// the health check, so that the delays don't change under it.
+	var delays []time.Duration
+	blocked := make(chan struct{})
+	stop := make(chan struct{})
+	sleep := func(d time.Duration) {
+		if len(delays) == 8 {
+			select {
+			case <-blocked:
+			default:
+				close(blocked)
+			}
+			<-stop
+			return
+		}
+		delays = append(delays, d)
+		if len(delays) == 6 {
+			// The next stream gets a response before failing.
+			c := make(chan *querypb.StreamHealthResponse, 1)
+			c <- &querypb.StreamHealthResponse{
+				Target:        &querypb.Target{Keyspace: "k", Shard: "s", TabletType: topodatapb.TabletType_REPLICA},
+				Serving:       true,
+				RealtimeStats: &querypb.RealtimeStats{},
+			}
+			close(c)
+			fc.hcChan = c
+		}
+	}
+	hc := newHealthCheck(1*time.Millisecond, 2*time.Millisecond, 20*time.Millisecond, 2.0, time.Hour, "" /* statsSuffix */, sleepClock{clock.Real, sleep})
+	hc.AddEndPoint("cell", "", ep)
+	<-blocked
+
+	want := []time.Duration{2 * time.Millisecond, 4 * time.Millisecond, 8 * time.Millisecond, 16 * time.Millisecond, 20 * time.Millisecond, 20 * time.Millisecond, 2 * time.Millisecond, 4 * time.Millisecond}
+	if !reflect.DeepEqual(delays, want) {
+		t.Errorf("retry delays: %v, want %v", delays, want)
+	}
+	key := EndPointToMapKey(ep)
+	if got, want := hc.RetryDelays()[key], 16*time.Millisecond; got != want {
+		t.Errorf("RetryDelays()[%v]: %v, want %v", key, got, want)
+	}
+	hc.Close()
+	close(stop)
+}
+
+// sleepClock is a clock.Clock whose Sleep is replaced by sleep.
+type sleepClock struct {
+	clock.Clock
+	sleep func(time.Duration)
+}
+
+func (c sleepClock) Sleep(d time.Duration) {
+	c.sleep(d)
+}
+
+func TestHealthCheckMaxReplicationLag(t *testing.T) {
+	ep := topo.NewEndPoint(0, "c")
+	ep.PortMap["vt"] = 1
+	input := make(chan *querypb.StreamHealthResponse)
+	createFakeConn(ep, input)
+	l := newListener()
+	hc := NewHealthCheck(1*time.Millisecond, 1*time.Millisecond, time.Hour, "" /* statsSuffix */).(*HealthCheckImpl)
+	hc.SetMaxReplicationLag(30 * time.Second)
+	hc.SetListener(l)
+	hc.AddEndPoint("cell", "", ep)
+	key := []string{"k", "s", EndPointToMapKey(ep)}
+	counterKey := strings.Join(key, ".")
+
+	// A replica with too much lag is not serving.
+	shr := &querypb.StreamHealthResponse{
+		Target:        &querypb.Target{Keyspace: "k", Shard: "s", TabletType: topodatapb.TabletType_REPLICA},
+		Serving:       true,
+		RealtimeStats: &querypb.RealtimeStats{SecondsBehindMaster: 60},
+	}
+	input <- shr
+	res := <-l.output
+	if res.Serving || res.LastError == nil {
+		t.Errorf(`<-l.output: %+v; want not serving with a lag error`, res)
+	}
+	epsList := hc.GetEndPointStatsFromTarget("k", "s", topodatapb.TabletType_REPLICA)
+	if len(epsList) != 1 || epsList[0].Serving {
+		t.Errorf(`hc.GetEndPointStatsFromTarget("k", "s", REPLICA) = %+v; want not serving`, epsList)
+	}
+	if got := FilterByReplicationLag(epsList); len(got) != 0 {
+		t.Errorf("FilterByReplicationLag: %+v, want empty", got)
+	}
+	if got := hcLagFilteredCounters.Counts()[counterKey]; got != 1 {
+		t.Errorf("HealthcheckReplicationLagFiltered[%v]: %v, want 1", counterKey, got)
+	}
+
+	// The master serves whatever its lag.
+	shr.Target = &querypb.Target{Keyspace: "k", Shard: "s", TabletType: topodatapb.TabletType_MASTER}
+	input <- shr
+	if res = <-l.output; !res.Serving {
+		t.Errorf(`<-l.output: %+v; want serving master`, res)
+	}
+
+	// The threshold is live: the replica serves again once it's raised.
+	hc.SetMaxReplicationLag(2 * time.Minute)
+	shr.Target = &querypb.Target{Keyspace: "k", Shard: "s", TabletType: topodatapb.TabletType_REPLICA}
+	input <- shr
+	if res = <-l.output; !res.Serving || res.LastError != nil {
+		t.Errorf(`<-l.output: %+v; want serving`, res)
+	}
+	if got := hcLagFilteredCounters.Counts()[counterKey]; got != 1 {
+		t.Errorf("HealthcheckReplicationLagFiltered[%v]: %v, want 1", counterKey, got)
+	}
+	hc.Close()
+}
+
+func TestHealthCheckTabletHistory(t *testing.T) {
+	ep := topo.NewEndPoint(0, "d")
+	ep.PortMap["vt"] = 1
+	input := make(chan *querypb.StreamHealthResponse)
+	createFakeConn(ep, input)
+	l := newListener()
+	hc := NewHealthCheck(1*time.Millisecond, 1*time.Millisecond, time.Hour, "" /* statsSuffix */).(*HealthCheckImpl)
+	hc.historySize = 3
+	hc.SetListener(l)
+	hc.AddEndPoint("cell", "cell-0000000001", ep)
+
+	// Fill the history beyond its size.
+	for lag := uint32(1); lag <= 5; lag++ {
+		input <- &querypb.StreamHealthResponse{
+			Target:        &querypb.Target{Keyspace: "k", Shard: "s", TabletType: topodatapb.TabletType_REPLICA},
+			Serving:       lag != 4,
+			RealtimeStats: &querypb.RealtimeStats{SecondsBehindMaster: lag},
+		}
+		<-l.output
+	}
+	got := hc.GetTabletHistory("cell-0000000001")
+	if len(got) != 3 {
+		t.Fatalf("GetTabletHistory: %+v, want 3 results", got)
+	}
+	// The most recent result is first, the oldest ones are evicted.
+	for i, want := range []struct {
+		lag     uint32
+		serving bool
+	}{{5, true}, {4, false}, {3, true}} {
+		if got[i].SecondsBehindMaster != want.lag || got[i].Serving != want.serving || got[i].Error != "" || got[i].Time.IsZero() {
+			t.Errorf("GetTabletHistory[%d]: %+v, want lag %v serving %v", i, got[i], want.lag, want.serving)
+		}
+	}
+	if got := hc.GetTabletHistory("unknown"); got != nil {
+		t.Errorf("GetTabletHistory(unknown): %+v, want nil", got)
+	}
+	hc.Close()
+}
+
 type listener struct {
 	output chan *EndPointStats
 }
@@ -267,12 +434,16 @@ func (l *listener) StatsUpdate(eps *EndPointStats) {
 func createFakeConn(endPoint *topodatapb.EndPoint, c chan *querypb.StreamHealthResponse) *fakeConn {
 	key := EndPointToMapKey(endPoint)
 	conn := &fakeConn{endPoint: endPoint, hcChan: c}
+	connMapMu.Lock()
 	connMap[key] = conn
+	connMapMu.Unlock()
 	return conn
 }
 
 func discoveryDialer(ctx context.Context, endPoint *topodatapb.EndPoint, keyspace, shard string, tabletType topodatapb.TabletType, timeout time.Duration) (tabletconn.TabletConn, error) {
 	key := EndPointToMapKey(endPoint)
+	connMapMu.Lock()
+	defer connMapMu.Unlock()
 	return connMap[key], nil
 }
 
@@ -312,11 +483,51 @@ func (fc *fakeConn) ExecuteBatch(ctx context.Context, queries []querytypes.Bound
 	return nil, fmt.Errorf("not implemented")
 }
 
+// WaitForGTID implements tabletconn.TabletConn.
+func (fc *fakeConn) WaitForGTID(ctx context.Context, gtid string) error {
+	return fmt.Errorf("not implemented")
+}
+
+// XAStart implements tabletconn.TabletConn.
+func (fc *fakeConn) XAStart(ctx context.Context, xid string) (int64, error) {
+	return 0, fmt.Errorf("not implemented")
+}
+
+// XAPrepare implements tabletconn.TabletConn.
+func (fc *fakeConn) XAPrepare(ctx context.Context, xid string) error {
+	return fmt.Errorf("not implemented")
+}
+
+// XACommit implements tabletconn.TabletConn.
+func (fc *fakeConn) XACommit(ctx context.Context, xid string) error {
+	return fmt.Errorf("not implemented")
+}
+
+// XARollback implements tabletconn.TabletConn.
+func (fc *fakeConn) XARollback(ctx context.Context, xid string) error {
+	return fmt.Errorf("not implemented")
+}
+
+// XARecover implements tabletconn.TabletConn.
+func (fc *fakeConn) XARecover(ctx context.Context) ([]string, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
+// HotQueries implements tabletconn.TabletConn.
+func (fc *fakeConn) HotQueries(ctx context.Context) ([]querytypes.BoundQuery, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
 // StreamExecute implements tabletconn.TabletConn.
 func (fc *fakeConn) StreamExecute(ctx context.Context, query string, bindVars map[string]interface{}) (sqltypes.ResultStream, error) {
 	return nil, fmt.Errorf("not implemented")
 }
 
+// StreamExecuteResumable implements tabletconn.TabletConn.
+func (fc *fakeConn) StreamExecuteResumable(ctx context.Context, query string, bindVars map[string]interface{}, resumeToken []byte) (tabletconn.ResumableResultStream, error) {
+	return nil, fmt.Errorf("not implemented")
+}
+
 // Begin implements tabletconn.TabletConn.
 func (fc *fakeConn) Begin(ctx context.Context) (int64, error) {
 	return 0, fmt.Errorf("not implemented")
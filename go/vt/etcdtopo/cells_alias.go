@@ -0,0 +1,92 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package etcdtopo
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/youtube/vitess/go/vt/topo"
+	"golang.org/x/net/context"
+
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+)
+
+/*
+This file contains the cells alias management code for etcdtopo.Server
+*/
+
+// CreateCellsAlias implements topo.Server.
+func (s *Server) CreateCellsAlias(ctx context.Context, alias string, cellsAlias *topodatapb.CellsAlias) error {
+	data, err := json.MarshalIndent(cellsAlias, "", "  ")
+	if err != nil {
+		return err
+	}
+	if _, err := s.getGlobal().Create(cellsAliasFilePath(alias), string(data), 0 /* ttl */); err != nil {
+		return convertError(err)
+	}
+	return nil
+}
+
+// UpdateCellsAlias implements topo.Server.
+func (s *Server) UpdateCellsAlias(ctx context.Context, alias string, cellsAlias *topodatapb.CellsAlias) error {
+	data, err := json.MarshalIndent(cellsAlias, "", "  ")
+	if err != nil {
+		return err
+	}
+	global := s.getGlobal()
+
+	// The alias must exist: it's only swapped with its current
+	// version.
+	resp, err := global.Get(cellsAliasFilePath(alias), false /* sort */, false /* recursive */)
+	if err != nil {
+		return convertError(err)
+	}
+	if resp.Node == nil {
+		return ErrBadResponse
+	}
+	if _, err := global.CompareAndSwap(cellsAliasFilePath(alias), string(data), 0 /* ttl */, "" /* prevValue */, resp.Node.ModifiedIndex); err != nil {
+		return convertError(err)
+	}
+	return nil
+}
+
+// DeleteCellsAlias implements topo.Server.
+func (s *Server) DeleteCellsAlias(ctx context.Context, alias string) error {
+	if _, err := s.getGlobal().Delete(cellsAliasFilePath(alias), false /* recursive */); err != nil {
+		return convertError(err)
+	}
+	return nil
+}
+
+// GetCellsAlias implements topo.Server.
+func (s *Server) GetCellsAlias(ctx context.Context, alias string) (*topodatapb.CellsAlias, error) {
+	resp, err := s.getGlobal().Get(cellsAliasFilePath(alias), false /* sort */, false /* recursive */)
+	if err != nil {
+		return nil, convertError(err)
+	}
+	if resp.Node == nil {
+		return nil, ErrBadResponse
+	}
+
+	value := &topodatapb.CellsAlias{}
+	if err := json.Unmarshal([]byte(resp.Node.Value), value); err != nil {
+		return nil, fmt.Errorf("bad cells alias data (%v): %q", err, resp.Node.Value)
+	}
+	return value, nil
+}
+
+// GetCellsAliases implements topo.Server.
+func (s *Server) GetCellsAliases(ctx context.Context) ([]string, error) {
+	resp, err := s.getGlobal().Get(cellsAliasesDirPath, true /* sort */, false /* recursive */)
+	if err != nil {
+		err = convertError(err)
+		if err == topo.ErrNoNode {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return getNodeNames(resp)
+}
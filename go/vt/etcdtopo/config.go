@@ -17,12 +17,13 @@ import (
 
 const (
 	// Paths within the etcd keyspace.
-	rootPath           = "/vt"
-	cellsDirPath       = rootPath + "/cells"
-	keyspacesDirPath   = rootPath + "/keyspaces"
-	tabletsDirPath     = rootPath + "/tablets"
-	replicationDirPath = rootPath + "/replication"
-	servingDirPath     = rootPath + "/ns"
+	rootPath            = "/vt"
+	cellsDirPath        = rootPath + "/cells"
+	cellsAliasesDirPath = rootPath + "/cells_aliases"
+	keyspacesDirPath    = rootPath + "/keyspaces"
+	tabletsDirPath      = rootPath + "/tablets"
+	replicationDirPath  = rootPath + "/replication"
+	servingDirPath      = rootPath + "/ns"
 
 	// Magic file names. Directories in etcd cannot have data. Files whose names
 	// begin with '_' are hidden from directory listings.
@@ -50,6 +51,10 @@ func cellFilePath(cell string) string {
 	return path.Join(cellsDirPath, cell)
 }
 
+func cellsAliasFilePath(alias string) string {
+	return path.Join(cellsAliasesDirPath, alias)
+}
+
 func keyspaceDirPath(keyspace string) string {
 	return path.Join(keyspacesDirPath, keyspace)
 }
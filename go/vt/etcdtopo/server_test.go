@@ -40,6 +40,13 @@ func TestKeyspace(t *testing.T) {
 	test.CheckKeyspace(ctx, t, ts)
 }
 
+func TestCellsAlias(t *testing.T) {
+	ctx := context.Background()
+	ts := newTestServer(t, []string{"test"})
+	defer ts.Close()
+	test.CheckCellsAlias(ctx, t, ts)
+}
+
 func TestShard(t *testing.T) {
 	ctx := context.Background()
 	ts := newTestServer(t, []string{"test"})
@@ -15,6 +15,7 @@ import (
 	"github.com/youtube/vitess/go/sqldb"
 	"github.com/youtube/vitess/go/sync2"
 	"github.com/youtube/vitess/go/vt/dbconfigs"
+	"github.com/youtube/vitess/go/vt/dbconnpool"
 	"github.com/youtube/vitess/go/vt/mysqlctl/replication"
 )
 
@@ -42,7 +43,7 @@ func (mysqld *Mysqld) NewSlaveConnection() (*SlaveConnection, error) {
 		return nil, err
 	}
 
-	conn, err := sqldb.Connect(params)
+	conn, err := dbconnpool.ConnectWithAddrRefresh(params)
 	if err != nil {
 		return nil, err
 	}
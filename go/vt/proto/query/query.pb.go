@@ -41,6 +41,20 @@ It has these top-level messages:
 	StreamHealthRequest
 	RealtimeStats
 	StreamHealthResponse
+	WaitForGTIDRequest
+	WaitForGTIDResponse
+	XAStartRequest
+	XAStartResponse
+	XAPrepareRequest
+	XAPrepareResponse
+	XACommitRequest
+	XACommitResponse
+	XARollbackRequest
+	XARollbackResponse
+	XARecoverRequest
+	XARecoverResponse
+	HotQueriesRequest
+	HotQueriesResponse
 */
 package query
 
@@ -189,6 +203,10 @@ const (
 	// be sent as a bind var.
 	// Properties: 28, None.
 	Type_TUPLE Type = 28
+	// JSON specifies a JSON type, the textual representation of
+	// a MySQL 5.7 JSON value.
+	// Properties: 29, IsQuoted.
+	Type_JSON Type = 2077
 )
 
 var Type_name = map[int32]string{
@@ -221,6 +239,7 @@ var Type_name = map[int32]string{
 	2074:  "ENUM",
 	2075:  "SET",
 	28:    "TUPLE",
+	2077:  "JSON",
 }
 var Type_value = map[string]int32{
 	"NULL_TYPE": 0,
@@ -252,6 +271,7 @@ var Type_value = map[string]int32{
 	"ENUM":      2074,
 	"SET":       2075,
 	"TUPLE":     28,
+	"JSON":      2077,
 }
 
 func (x Type) String() string {
@@ -406,6 +426,9 @@ type QueryResult struct {
 	RowsAffected uint64   `protobuf:"varint,2,opt,name=rows_affected,json=rowsAffected" json:"rows_affected,omitempty"`
 	InsertId     uint64   `protobuf:"varint,3,opt,name=insert_id,json=insertId" json:"insert_id,omitempty"`
 	Rows         []*Row   `protobuf:"bytes,4,rep,name=rows" json:"rows,omitempty"`
+	// warnings are the warnings of the query, like the truncation of
+	// the result by vtgate's -max_unbounded_query_rows.
+	Warnings []string `protobuf:"bytes,5,rep,name=warnings" json:"warnings,omitempty"`
 }
 
 func (m *QueryResult) Reset()                    { *m = QueryResult{} }
@@ -472,6 +495,16 @@ type ExecuteRequest struct {
 	Query             *BoundQuery     `protobuf:"bytes,4,opt,name=query" json:"query,omitempty"`
 	TransactionId     int64           `protobuf:"varint,5,opt,name=transaction_id,json=transactionId" json:"transaction_id,omitempty"`
 	SessionId         int64           `protobuf:"varint,6,opt,name=session_id,json=sessionId" json:"session_id,omitempty"`
+	// wait_for_gtid, if set, is a replication position that the tablet
+	// waits for before executing the query. See WaitForGTID.
+	WaitForGtid string `protobuf:"bytes,7,opt,name=wait_for_gtid,json=waitForGtid" json:"wait_for_gtid,omitempty"`
+	// include_row_version asks for the version token of the row read by
+	// a primary key read of the rowcache. See ExecuteResponse.row_version.
+	IncludeRowVersion bool `protobuf:"varint,8,opt,name=include_row_version,json=includeRowVersion" json:"include_row_version,omitempty"`
+	// expect_row_version, if set, is the version token of the row updated
+	// by a primary key DML. The DML affects no rows if the row changed
+	// since the token was returned.
+	ExpectRowVersion string `protobuf:"bytes,9,opt,name=expect_row_version,json=expectRowVersion" json:"expect_row_version,omitempty"`
 }
 
 func (m *ExecuteRequest) Reset()                    { *m = ExecuteRequest{} }
@@ -510,6 +543,10 @@ func (m *ExecuteRequest) GetQuery() *BoundQuery {
 // ExecuteResponse is the returned value from Execute
 type ExecuteResponse struct {
 	Result *QueryResult `protobuf:"bytes,1,opt,name=result" json:"result,omitempty"`
+	// row_version is the version token of the row read, if
+	// include_row_version was set and the query was a rowcache read of
+	// a single primary key.
+	RowVersion string `protobuf:"bytes,2,opt,name=row_version,json=rowVersion" json:"row_version,omitempty"`
 }
 
 func (m *ExecuteResponse) Reset()                    { *m = ExecuteResponse{} }
@@ -533,6 +570,9 @@ type ExecuteBatchRequest struct {
 	AsTransaction     bool            `protobuf:"varint,5,opt,name=as_transaction,json=asTransaction" json:"as_transaction,omitempty"`
 	TransactionId     int64           `protobuf:"varint,6,opt,name=transaction_id,json=transactionId" json:"transaction_id,omitempty"`
 	SessionId         int64           `protobuf:"varint,7,opt,name=session_id,json=sessionId" json:"session_id,omitempty"`
+	// wait_for_gtid, if set, is a replication position that the tablet
+	// waits for before executing the queries. See WaitForGTID.
+	WaitForGtid string `protobuf:"bytes,8,opt,name=wait_for_gtid,json=waitForGtid" json:"wait_for_gtid,omitempty"`
 }
 
 func (m *ExecuteBatchRequest) Reset()                    { *m = ExecuteBatchRequest{} }
@@ -592,6 +632,17 @@ type StreamExecuteRequest struct {
 	Target            *Target         `protobuf:"bytes,3,opt,name=target" json:"target,omitempty"`
 	Query             *BoundQuery     `protobuf:"bytes,4,opt,name=query" json:"query,omitempty"`
 	SessionId         int64           `protobuf:"varint,5,opt,name=session_id,json=sessionId" json:"session_id,omitempty"`
+	// resumable asks for a resume_token in each response, that
+	// resumes the stream after its rows, e.g. when the stream broke.
+	// Only the selects of a single table, with its primary key
+	// columns and without aggregation, limit or other order than the
+	// primary key, can be resumed: the rows are streamed in the order
+	// of the primary key.
+	Resumable bool `protobuf:"varint,6,opt,name=resumable" json:"resumable,omitempty"`
+	// resume_token makes the stream resume after the rows of the
+	// response that returned it, for the same query. It implies
+	// resumable. The fields are sent again.
+	ResumeToken []byte `protobuf:"bytes,7,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
 }
 
 func (m *StreamExecuteRequest) Reset()                    { *m = StreamExecuteRequest{} }
@@ -630,6 +681,9 @@ func (m *StreamExecuteRequest) GetQuery() *BoundQuery {
 // StreamExecuteResponse is the returned value from StreamExecute
 type StreamExecuteResponse struct {
 	Result *QueryResult `protobuf:"bytes,1,opt,name=result" json:"result,omitempty"`
+	// resume_token is set if the request was resumable, and the result
+	// has rows.
+	ResumeToken []byte `protobuf:"bytes,2,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"`
 }
 
 func (m *StreamExecuteResponse) Reset()                    { *m = StreamExecuteResponse{} }
@@ -650,6 +704,7 @@ type BeginRequest struct {
 	ImmediateCallerId *VTGateCallerID `protobuf:"bytes,2,opt,name=immediate_caller_id,json=immediateCallerId" json:"immediate_caller_id,omitempty"`
 	Target            *Target         `protobuf:"bytes,3,opt,name=target" json:"target,omitempty"`
 	SessionId         int64           `protobuf:"varint,4,opt,name=session_id,json=sessionId" json:"session_id,omitempty"`
+	Options           *ExecuteOptions `protobuf:"bytes,5,opt,name=options" json:"options,omitempty"`
 }
 
 func (m *BeginRequest) Reset()                    { *m = BeginRequest{} }
@@ -678,6 +733,13 @@ func (m *BeginRequest) GetTarget() *Target {
 	return nil
 }
 
+func (m *BeginRequest) GetOptions() *ExecuteOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
 // BeginResponse is the returned value from Begin
 type BeginResponse struct {
 	TransactionId int64 `protobuf:"varint,1,opt,name=transaction_id,json=transactionId" json:"transaction_id,omitempty"`
@@ -695,6 +757,9 @@ type CommitRequest struct {
 	Target            *Target         `protobuf:"bytes,3,opt,name=target" json:"target,omitempty"`
 	TransactionId     int64           `protobuf:"varint,4,opt,name=transaction_id,json=transactionId" json:"transaction_id,omitempty"`
 	SessionId         int64           `protobuf:"varint,5,opt,name=session_id,json=sessionId" json:"session_id,omitempty"`
+	// return_gtid asks the tablet to return its replication position
+	// after the commit.
+	ReturnGtid bool `protobuf:"varint,6,opt,name=return_gtid,json=returnGtid" json:"return_gtid,omitempty"`
 }
 
 func (m *CommitRequest) Reset()                    { *m = CommitRequest{} }
@@ -725,6 +790,9 @@ func (m *CommitRequest) GetTarget() *Target {
 
 // CommitResponse is the returned value from Commit
 type CommitResponse struct {
+	// gtid is the replication position of the tablet after the commit,
+	// if return_gtid was set.
+	Gtid string `protobuf:"bytes,1,opt,name=gtid" json:"gtid,omitempty"`
 }
 
 func (m *CommitResponse) Reset()                    { *m = CommitResponse{} }
@@ -782,6 +850,7 @@ type BeginExecuteRequest struct {
 	ImmediateCallerId *VTGateCallerID `protobuf:"bytes,2,opt,name=immediate_caller_id,json=immediateCallerId" json:"immediate_caller_id,omitempty"`
 	Target            *Target         `protobuf:"bytes,3,opt,name=target" json:"target,omitempty"`
 	Query             *BoundQuery     `protobuf:"bytes,4,opt,name=query" json:"query,omitempty"`
+	Options           *ExecuteOptions `protobuf:"bytes,5,opt,name=options" json:"options,omitempty"`
 }
 
 func (m *BeginExecuteRequest) Reset()                    { *m = BeginExecuteRequest{} }
@@ -817,6 +886,13 @@ func (m *BeginExecuteRequest) GetQuery() *BoundQuery {
 	return nil
 }
 
+func (m *BeginExecuteRequest) GetOptions() *ExecuteOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
 // BeginExecuteResponse is the returned value from BeginExecute
 type BeginExecuteResponse struct {
 	// error contains an application level error if necessary. Note the
@@ -854,6 +930,7 @@ type BeginExecuteBatchRequest struct {
 	Target            *Target         `protobuf:"bytes,3,opt,name=target" json:"target,omitempty"`
 	Queries           []*BoundQuery   `protobuf:"bytes,4,rep,name=queries" json:"queries,omitempty"`
 	AsTransaction     bool            `protobuf:"varint,5,opt,name=as_transaction,json=asTransaction" json:"as_transaction,omitempty"`
+	Options           *ExecuteOptions `protobuf:"bytes,6,opt,name=options" json:"options,omitempty"`
 }
 
 func (m *BeginExecuteBatchRequest) Reset()                    { *m = BeginExecuteBatchRequest{} }
@@ -889,6 +966,13 @@ func (m *BeginExecuteBatchRequest) GetQueries() []*BoundQuery {
 	return nil
 }
 
+func (m *BeginExecuteBatchRequest) GetOptions() *ExecuteOptions {
+	if m != nil {
+		return m.Options
+	}
+	return nil
+}
+
 // BeginExecuteBatchResponse is the returned value from BeginExecuteBatch
 type BeginExecuteBatchResponse struct {
 	// error contains an application level error if necessary. Note the
@@ -1097,6 +1181,343 @@ func (m *StreamHealthResponse) GetRealtimeStats() *RealtimeStats {
 	return nil
 }
 
+// WaitForGTIDRequest is the payload for WaitForGTID
+type WaitForGTIDRequest struct {
+	EffectiveCallerId *vtrpc.CallerID `protobuf:"bytes,1,opt,name=effective_caller_id,json=effectiveCallerId" json:"effective_caller_id,omitempty"`
+	ImmediateCallerId *VTGateCallerID `protobuf:"bytes,2,opt,name=immediate_caller_id,json=immediateCallerId" json:"immediate_caller_id,omitempty"`
+	Target            *Target         `protobuf:"bytes,3,opt,name=target" json:"target,omitempty"`
+	// gtid is the replication position to wait for, as returned by the
+	// Commit of the master.
+	Gtid string `protobuf:"bytes,4,opt,name=gtid" json:"gtid,omitempty"`
+}
+
+func (m *WaitForGTIDRequest) Reset()                    { *m = WaitForGTIDRequest{} }
+func (m *WaitForGTIDRequest) String() string            { return proto.CompactTextString(m) }
+func (*WaitForGTIDRequest) ProtoMessage()               {}
+func (*WaitForGTIDRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{32} }
+
+func (m *WaitForGTIDRequest) GetEffectiveCallerId() *vtrpc.CallerID {
+	if m != nil {
+		return m.EffectiveCallerId
+	}
+	return nil
+}
+
+func (m *WaitForGTIDRequest) GetImmediateCallerId() *VTGateCallerID {
+	if m != nil {
+		return m.ImmediateCallerId
+	}
+	return nil
+}
+
+func (m *WaitForGTIDRequest) GetTarget() *Target {
+	if m != nil {
+		return m.Target
+	}
+	return nil
+}
+
+// WaitForGTIDResponse is the returned value from WaitForGTID
+type WaitForGTIDResponse struct {
+}
+
+func (m *WaitForGTIDResponse) Reset()                    { *m = WaitForGTIDResponse{} }
+func (m *WaitForGTIDResponse) String() string            { return proto.CompactTextString(m) }
+func (*WaitForGTIDResponse) ProtoMessage()               {}
+func (*WaitForGTIDResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{33} }
+
+// XAStartRequest is the payload for XAStart
+type XAStartRequest struct {
+	EffectiveCallerId *vtrpc.CallerID `protobuf:"bytes,1,opt,name=effective_caller_id,json=effectiveCallerId" json:"effective_caller_id,omitempty"`
+	ImmediateCallerId *VTGateCallerID `protobuf:"bytes,2,opt,name=immediate_caller_id,json=immediateCallerId" json:"immediate_caller_id,omitempty"`
+	Target            *Target         `protobuf:"bytes,3,opt,name=target" json:"target,omitempty"`
+	SessionId         int64           `protobuf:"varint,4,opt,name=session_id,json=sessionId" json:"session_id,omitempty"`
+	// xid is the id of the XA transaction to start.
+	Xid string `protobuf:"bytes,5,opt,name=xid" json:"xid,omitempty"`
+}
+
+func (m *XAStartRequest) Reset()                    { *m = XAStartRequest{} }
+func (m *XAStartRequest) String() string            { return proto.CompactTextString(m) }
+func (*XAStartRequest) ProtoMessage()               {}
+func (*XAStartRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{34} }
+
+func (m *XAStartRequest) GetEffectiveCallerId() *vtrpc.CallerID {
+	if m != nil {
+		return m.EffectiveCallerId
+	}
+	return nil
+}
+
+func (m *XAStartRequest) GetImmediateCallerId() *VTGateCallerID {
+	if m != nil {
+		return m.ImmediateCallerId
+	}
+	return nil
+}
+
+func (m *XAStartRequest) GetTarget() *Target {
+	if m != nil {
+		return m.Target
+	}
+	return nil
+}
+
+// XAStartResponse is the returned value from XAStart
+type XAStartResponse struct {
+	// transaction_id is the id of the transaction on the tablet, to
+	// use in the Execute calls of the XA transaction.
+	TransactionId int64 `protobuf:"varint,1,opt,name=transaction_id,json=transactionId" json:"transaction_id,omitempty"`
+}
+
+func (m *XAStartResponse) Reset()                    { *m = XAStartResponse{} }
+func (m *XAStartResponse) String() string            { return proto.CompactTextString(m) }
+func (*XAStartResponse) ProtoMessage()               {}
+func (*XAStartResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+
+// XAPrepareRequest is the payload for XAPrepare
+type XAPrepareRequest struct {
+	EffectiveCallerId *vtrpc.CallerID `protobuf:"bytes,1,opt,name=effective_caller_id,json=effectiveCallerId" json:"effective_caller_id,omitempty"`
+	ImmediateCallerId *VTGateCallerID `protobuf:"bytes,2,opt,name=immediate_caller_id,json=immediateCallerId" json:"immediate_caller_id,omitempty"`
+	Target            *Target         `protobuf:"bytes,3,opt,name=target" json:"target,omitempty"`
+	SessionId         int64           `protobuf:"varint,4,opt,name=session_id,json=sessionId" json:"session_id,omitempty"`
+	Xid               string          `protobuf:"bytes,5,opt,name=xid" json:"xid,omitempty"`
+}
+
+func (m *XAPrepareRequest) Reset()                    { *m = XAPrepareRequest{} }
+func (m *XAPrepareRequest) String() string            { return proto.CompactTextString(m) }
+func (*XAPrepareRequest) ProtoMessage()               {}
+func (*XAPrepareRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+
+func (m *XAPrepareRequest) GetEffectiveCallerId() *vtrpc.CallerID {
+	if m != nil {
+		return m.EffectiveCallerId
+	}
+	return nil
+}
+
+func (m *XAPrepareRequest) GetImmediateCallerId() *VTGateCallerID {
+	if m != nil {
+		return m.ImmediateCallerId
+	}
+	return nil
+}
+
+func (m *XAPrepareRequest) GetTarget() *Target {
+	if m != nil {
+		return m.Target
+	}
+	return nil
+}
+
+// XAPrepareResponse is the returned value from XAPrepare
+type XAPrepareResponse struct {
+}
+
+func (m *XAPrepareResponse) Reset()                    { *m = XAPrepareResponse{} }
+func (m *XAPrepareResponse) String() string            { return proto.CompactTextString(m) }
+func (*XAPrepareResponse) ProtoMessage()               {}
+func (*XAPrepareResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+
+// XACommitRequest is the payload for XACommit
+type XACommitRequest struct {
+	EffectiveCallerId *vtrpc.CallerID `protobuf:"bytes,1,opt,name=effective_caller_id,json=effectiveCallerId" json:"effective_caller_id,omitempty"`
+	ImmediateCallerId *VTGateCallerID `protobuf:"bytes,2,opt,name=immediate_caller_id,json=immediateCallerId" json:"immediate_caller_id,omitempty"`
+	Target            *Target         `protobuf:"bytes,3,opt,name=target" json:"target,omitempty"`
+	SessionId         int64           `protobuf:"varint,4,opt,name=session_id,json=sessionId" json:"session_id,omitempty"`
+	Xid               string          `protobuf:"bytes,5,opt,name=xid" json:"xid,omitempty"`
+}
+
+func (m *XACommitRequest) Reset()                    { *m = XACommitRequest{} }
+func (m *XACommitRequest) String() string            { return proto.CompactTextString(m) }
+func (*XACommitRequest) ProtoMessage()               {}
+func (*XACommitRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+
+func (m *XACommitRequest) GetEffectiveCallerId() *vtrpc.CallerID {
+	if m != nil {
+		return m.EffectiveCallerId
+	}
+	return nil
+}
+
+func (m *XACommitRequest) GetImmediateCallerId() *VTGateCallerID {
+	if m != nil {
+		return m.ImmediateCallerId
+	}
+	return nil
+}
+
+func (m *XACommitRequest) GetTarget() *Target {
+	if m != nil {
+		return m.Target
+	}
+	return nil
+}
+
+// XACommitResponse is the returned value from XACommit
+type XACommitResponse struct {
+}
+
+func (m *XACommitResponse) Reset()                    { *m = XACommitResponse{} }
+func (m *XACommitResponse) String() string            { return proto.CompactTextString(m) }
+func (*XACommitResponse) ProtoMessage()               {}
+func (*XACommitResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+
+// XARollbackRequest is the payload for XARollback
+type XARollbackRequest struct {
+	EffectiveCallerId *vtrpc.CallerID `protobuf:"bytes,1,opt,name=effective_caller_id,json=effectiveCallerId" json:"effective_caller_id,omitempty"`
+	ImmediateCallerId *VTGateCallerID `protobuf:"bytes,2,opt,name=immediate_caller_id,json=immediateCallerId" json:"immediate_caller_id,omitempty"`
+	Target            *Target         `protobuf:"bytes,3,opt,name=target" json:"target,omitempty"`
+	SessionId         int64           `protobuf:"varint,4,opt,name=session_id,json=sessionId" json:"session_id,omitempty"`
+	Xid               string          `protobuf:"bytes,5,opt,name=xid" json:"xid,omitempty"`
+}
+
+func (m *XARollbackRequest) Reset()                    { *m = XARollbackRequest{} }
+func (m *XARollbackRequest) String() string            { return proto.CompactTextString(m) }
+func (*XARollbackRequest) ProtoMessage()               {}
+func (*XARollbackRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+
+func (m *XARollbackRequest) GetEffectiveCallerId() *vtrpc.CallerID {
+	if m != nil {
+		return m.EffectiveCallerId
+	}
+	return nil
+}
+
+func (m *XARollbackRequest) GetImmediateCallerId() *VTGateCallerID {
+	if m != nil {
+		return m.ImmediateCallerId
+	}
+	return nil
+}
+
+func (m *XARollbackRequest) GetTarget() *Target {
+	if m != nil {
+		return m.Target
+	}
+	return nil
+}
+
+// XARollbackResponse is the returned value from XARollback
+type XARollbackResponse struct {
+}
+
+func (m *XARollbackResponse) Reset()                    { *m = XARollbackResponse{} }
+func (m *XARollbackResponse) String() string            { return proto.CompactTextString(m) }
+func (*XARollbackResponse) ProtoMessage()               {}
+func (*XARollbackResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
+
+// XARecoverRequest is the payload for XARecover
+type XARecoverRequest struct {
+	EffectiveCallerId *vtrpc.CallerID `protobuf:"bytes,1,opt,name=effective_caller_id,json=effectiveCallerId" json:"effective_caller_id,omitempty"`
+	ImmediateCallerId *VTGateCallerID `protobuf:"bytes,2,opt,name=immediate_caller_id,json=immediateCallerId" json:"immediate_caller_id,omitempty"`
+	Target            *Target         `protobuf:"bytes,3,opt,name=target" json:"target,omitempty"`
+	SessionId         int64           `protobuf:"varint,4,opt,name=session_id,json=sessionId" json:"session_id,omitempty"`
+}
+
+func (m *XARecoverRequest) Reset()                    { *m = XARecoverRequest{} }
+func (m *XARecoverRequest) String() string            { return proto.CompactTextString(m) }
+func (*XARecoverRequest) ProtoMessage()               {}
+func (*XARecoverRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
+
+func (m *XARecoverRequest) GetEffectiveCallerId() *vtrpc.CallerID {
+	if m != nil {
+		return m.EffectiveCallerId
+	}
+	return nil
+}
+
+func (m *XARecoverRequest) GetImmediateCallerId() *VTGateCallerID {
+	if m != nil {
+		return m.ImmediateCallerId
+	}
+	return nil
+}
+
+func (m *XARecoverRequest) GetTarget() *Target {
+	if m != nil {
+		return m.Target
+	}
+	return nil
+}
+
+// XARecoverResponse is the returned value from XARecover
+type XARecoverResponse struct {
+	// xids are the ids of the prepared XA transactions of the tablet.
+	Xids []string `protobuf:"bytes,1,rep,name=xids" json:"xids,omitempty"`
+}
+
+func (m *XARecoverResponse) Reset()                    { *m = XARecoverResponse{} }
+func (m *XARecoverResponse) String() string            { return proto.CompactTextString(m) }
+func (*XARecoverResponse) ProtoMessage()               {}
+func (*XARecoverResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
+
+// HotQueriesRequest is the payload for HotQueries
+type HotQueriesRequest struct {
+	EffectiveCallerId *vtrpc.CallerID `protobuf:"bytes,1,opt,name=effective_caller_id,json=effectiveCallerId" json:"effective_caller_id,omitempty"`
+	ImmediateCallerId *VTGateCallerID `protobuf:"bytes,2,opt,name=immediate_caller_id,json=immediateCallerId" json:"immediate_caller_id,omitempty"`
+	Target            *Target         `protobuf:"bytes,3,opt,name=target" json:"target,omitempty"`
+}
+
+func (m *HotQueriesRequest) Reset()                    { *m = HotQueriesRequest{} }
+func (m *HotQueriesRequest) String() string            { return proto.CompactTextString(m) }
+func (*HotQueriesRequest) ProtoMessage()               {}
+func (*HotQueriesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
+
+func (m *HotQueriesRequest) GetEffectiveCallerId() *vtrpc.CallerID {
+	if m != nil {
+		return m.EffectiveCallerId
+	}
+	return nil
+}
+
+func (m *HotQueriesRequest) GetImmediateCallerId() *VTGateCallerID {
+	if m != nil {
+		return m.ImmediateCallerId
+	}
+	return nil
+}
+
+func (m *HotQueriesRequest) GetTarget() *Target {
+	if m != nil {
+		return m.Target
+	}
+	return nil
+}
+
+// HotQueriesResponse is the returned value from HotQueries
+type HotQueriesResponse struct {
+	// queries are the hot queries, the most executed first, each with
+	// the bind variables of one of its executions.
+	Queries []*BoundQuery `protobuf:"bytes,1,rep,name=queries" json:"queries,omitempty"`
+}
+
+func (m *HotQueriesResponse) Reset()                    { *m = HotQueriesResponse{} }
+func (m *HotQueriesResponse) String() string            { return proto.CompactTextString(m) }
+func (*HotQueriesResponse) ProtoMessage()               {}
+func (*HotQueriesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
+
+func (m *HotQueriesResponse) GetQueries() []*BoundQuery {
+	if m != nil {
+		return m.Queries
+	}
+	return nil
+}
+
+// ExecuteOptions are the options of the execution of a query or a
+// transaction that the caller can set.
+type ExecuteOptions struct {
+	// tx_pool_fail_fast makes Begin fail right away with
+	// RESOURCE_EXHAUSTED if the transaction pool has no free
+	// connection, instead of waiting for one, for the callers that
+	// prefer to retry elsewhere.
+	TxPoolFailFast bool `protobuf:"varint,1,opt,name=tx_pool_fail_fast,json=txPoolFailFast" json:"tx_pool_fail_fast,omitempty"`
+}
+
+func (m *ExecuteOptions) Reset()                    { *m = ExecuteOptions{} }
+func (m *ExecuteOptions) String() string            { return proto.CompactTextString(m) }
+func (*ExecuteOptions) ProtoMessage()               {}
+func (*ExecuteOptions) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{46} }
+
 func init() {
 	proto.RegisterType((*Target)(nil), "query.Target")
 	proto.RegisterType((*VTGateCallerID)(nil), "query.VTGateCallerID")
@@ -1130,119 +1551,160 @@ func init() {
 	proto.RegisterType((*StreamHealthRequest)(nil), "query.StreamHealthRequest")
 	proto.RegisterType((*RealtimeStats)(nil), "query.RealtimeStats")
 	proto.RegisterType((*StreamHealthResponse)(nil), "query.StreamHealthResponse")
+	proto.RegisterType((*WaitForGTIDRequest)(nil), "query.WaitForGTIDRequest")
+	proto.RegisterType((*WaitForGTIDResponse)(nil), "query.WaitForGTIDResponse")
+	proto.RegisterType((*XAStartRequest)(nil), "query.XAStartRequest")
+	proto.RegisterType((*XAStartResponse)(nil), "query.XAStartResponse")
+	proto.RegisterType((*XAPrepareRequest)(nil), "query.XAPrepareRequest")
+	proto.RegisterType((*XAPrepareResponse)(nil), "query.XAPrepareResponse")
+	proto.RegisterType((*XACommitRequest)(nil), "query.XACommitRequest")
+	proto.RegisterType((*XACommitResponse)(nil), "query.XACommitResponse")
+	proto.RegisterType((*XARollbackRequest)(nil), "query.XARollbackRequest")
+	proto.RegisterType((*XARollbackResponse)(nil), "query.XARollbackResponse")
+	proto.RegisterType((*XARecoverRequest)(nil), "query.XARecoverRequest")
+	proto.RegisterType((*XARecoverResponse)(nil), "query.XARecoverResponse")
+	proto.RegisterType((*HotQueriesRequest)(nil), "query.HotQueriesRequest")
+	proto.RegisterType((*HotQueriesResponse)(nil), "query.HotQueriesResponse")
+	proto.RegisterType((*ExecuteOptions)(nil), "query.ExecuteOptions")
 	proto.RegisterEnum("query.Flag", Flag_name, Flag_value)
 	proto.RegisterEnum("query.Type", Type_name, Type_value)
 	proto.RegisterEnum("query.SplitQueryRequest_Algorithm", SplitQueryRequest_Algorithm_name, SplitQueryRequest_Algorithm_value)
 }
 
 var fileDescriptor0 = []byte{
-	// 1717 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xec, 0x58, 0x5b, 0x73, 0xeb, 0x48,
-	0x11, 0x46, 0xf2, 0x25, 0x76, 0x3b, 0x76, 0x94, 0xb1, 0x03, 0x26, 0xdc, 0x16, 0xed, 0xb2, 0x1c,
-	0xc2, 0x29, 0x57, 0xf0, 0x9e, 0x3d, 0x6c, 0x71, 0x5d, 0xdb, 0x51, 0xb2, 0x2e, 0x12, 0xc7, 0x67,
-	0x2c, 0xa7, 0x38, 0xbc, 0xa8, 0x64, 0x7b, 0xe2, 0xa8, 0x22, 0x5b, 0x8e, 0x34, 0xce, 0x6e, 0xde,
-	0x02, 0x2c, 0xcb, 0x75, 0x61, 0x29, 0x58, 0x96, 0x4b, 0xf1, 0x40, 0xf1, 0xce, 0x8f, 0xe0, 0x0f,
-	0xf0, 0x07, 0x28, 0xde, 0x79, 0xa0, 0xf8, 0x0b, 0xcc, 0x8c, 0x46, 0xb2, 0x9c, 0x78, 0x09, 0x9c,
-	0x27, 0x0e, 0x67, 0x9f, 0x3c, 0xdd, 0x5f, 0xcf, 0x74, 0xf7, 0xd7, 0xdd, 0x23, 0x4b, 0x50, 0xb8,
-	0x98, 0x13, 0xff, 0xaa, 0x36, 0xf3, 0x3d, 0xea, 0xa1, 0x8c, 0x10, 0xb6, 0x4b, 0xd4, 0x9b, 0x79,
-	0x23, 0x9b, 0xda, 0xa1, 0x7a, 0xbb, 0x70, 0x49, 0xfd, 0xd9, 0x30, 0x14, 0xf4, 0x0b, 0xc8, 0x9a,
-	0xb6, 0x3f, 0x26, 0x14, 0x6d, 0x43, 0xee, 0x9c, 0x5c, 0x05, 0x33, 0x7b, 0x48, 0xaa, 0xca, 0x73,
-	0xca, 0xbd, 0x3c, 0x8e, 0x65, 0x54, 0x81, 0x4c, 0x70, 0x66, 0xfb, 0xa3, 0xaa, 0x2a, 0x80, 0x50,
-	0x40, 0x2f, 0x43, 0x81, 0xda, 0x03, 0x97, 0x50, 0x8b, 0x5e, 0xcd, 0x48, 0x35, 0xc5, 0xb0, 0x52,
-	0xbd, 0x52, 0x8b, 0xdd, 0x99, 0x02, 0x34, 0x19, 0x86, 0x81, 0xc6, 0x6b, 0xfd, 0x3e, 0x94, 0x4e,
-	0xcc, 0x03, 0x9b, 0x92, 0x96, 0xed, 0xba, 0xc4, 0x6f, 0xef, 0x71, 0xd7, 0xf3, 0x80, 0xf8, 0x53,
-	0x7b, 0x12, 0xbb, 0x8e, 0x64, 0xfd, 0x6b, 0x90, 0x39, 0xb1, 0xdd, 0x39, 0x41, 0x9f, 0x82, 0xb4,
-	0x70, 0xa3, 0x08, 0x37, 0x85, 0x5a, 0x98, 0xa9, 0x38, 0x5d, 0x00, 0x3c, 0xc8, 0x4b, 0x6e, 0x29,
-	0x82, 0x5c, 0xc7, 0xa1, 0xa0, 0x9f, 0xc3, 0x7a, 0xd3, 0x99, 0x8e, 0x4e, 0x6c, 0xdf, 0xe1, 0x21,
-	0x3c, 0xe1, 0x31, 0xe8, 0x05, 0xc8, 0x8a, 0x45, 0xc0, 0xd2, 0x4c, 0xdd, 0x2b, 0xd4, 0xd7, 0xe5,
-	0x46, 0x11, 0x1b, 0x96, 0x98, 0xfe, 0x67, 0x05, 0xa0, 0xe9, 0xcd, 0xa7, 0xa3, 0x47, 0x1c, 0x44,
-	0x1a, 0xa4, 0x82, 0x0b, 0x57, 0xa6, 0xc4, 0x97, 0xe8, 0x1b, 0x50, 0x1a, 0xb0, 0x68, 0xac, 0x4b,
-	0x19, 0x4e, 0xc0, 0xbc, 0xf0, 0xe3, 0x5e, 0x90, 0xc7, 0x2d, 0x36, 0xd7, 0x92, 0x51, 0x07, 0xc6,
-	0x94, 0xfa, 0x57, 0xb8, 0x38, 0x48, 0xea, 0xb6, 0xfb, 0x80, 0x6e, 0x1b, 0x71, 0xa7, 0xac, 0x6e,
-	0x91, 0x53, 0xb6, 0x44, 0x9f, 0x4b, 0x66, 0x54, 0xa8, 0x97, 0x23, 0x5f, 0x89, 0xbd, 0x32, 0xcd,
-	0x2f, 0xa9, 0xaf, 0x28, 0xfa, 0x57, 0x20, 0xb3, 0xef, 0x10, 0x77, 0x84, 0x10, 0xa4, 0x13, 0x25,
-	0x11, 0xeb, 0x98, 0x3e, 0xf5, 0x7d, 0xe8, 0xd3, 0xbf, 0x08, 0x29, 0xec, 0xbd, 0x8e, 0xaa, 0xb0,
-	0xe6, 0x92, 0xe9, 0x98, 0x9e, 0x05, 0x6c, 0x7b, 0xea, 0x1e, 0xc2, 0x91, 0x88, 0x3e, 0x1c, 0x33,
-	0x19, 0x12, 0x1c, 0x71, 0xf7, 0xae, 0x02, 0x05, 0x91, 0x39, 0x26, 0xc1, 0xdc, 0xa5, 0x9c, 0xf1,
-	0x53, 0x1e, 0x46, 0x78, 0xc0, 0x82, 0x71, 0x11, 0x1b, 0x96, 0x18, 0x7a, 0x1e, 0x8a, 0xbe, 0xf7,
-	0x7a, 0x60, 0xd9, 0xa7, 0xa7, 0x64, 0x48, 0x49, 0xd8, 0xa1, 0x69, 0xbc, 0xce, 0x95, 0x0d, 0xa9,
-	0x43, 0x1f, 0x83, 0xbc, 0x33, 0x65, 0x0d, 0x45, 0x2d, 0x67, 0x24, 0xda, 0x34, 0x8d, 0x73, 0xa1,
-	0xa2, 0x3d, 0x42, 0x9f, 0x84, 0x34, 0x37, 0xae, 0xa6, 0x85, 0x17, 0x90, 0x5e, 0x58, 0x0e, 0x58,
-	0xe8, 0xf5, 0xbf, 0x28, 0x50, 0x3e, 0x20, 0xb4, 0x47, 0x82, 0xc0, 0xf1, 0xa6, 0xed, 0x11, 0x26,
-	0xcc, 0x22, 0xa0, 0xe8, 0xeb, 0x50, 0x26, 0xc2, 0x81, 0x73, 0x49, 0xac, 0xa1, 0x68, 0x65, 0x7e,
-	0xbc, 0x22, 0x38, 0xde, 0xa8, 0x85, 0x43, 0x16, 0xb5, 0x38, 0xde, 0x8c, 0x6d, 0xa5, 0x6a, 0x84,
-	0x0c, 0x28, 0x3b, 0x93, 0x09, 0x19, 0x39, 0x6c, 0x14, 0x12, 0x07, 0x84, 0x45, 0xda, 0x8a, 0xfa,
-	0x6b, 0x69, 0x52, 0xf0, 0x66, 0xbc, 0x23, 0x3e, 0x26, 0x39, 0xb7, 0xa9, 0xf7, 0x9b, 0xdb, 0x74,
-	0x62, 0x6e, 0xf5, 0x97, 0xa1, 0xb2, 0x9c, 0x50, 0x30, 0xf3, 0x18, 0x1b, 0xe8, 0x13, 0x00, 0x41,
-	0xa8, 0x8c, 0x12, 0x49, 0xe1, 0x7c, 0x10, 0x99, 0xe9, 0x7f, 0x52, 0xa1, 0x64, 0xbc, 0x41, 0x86,
-	0x73, 0x4a, 0xfe, 0xd7, 0x38, 0xf8, 0x0c, 0x64, 0xa9, 0xb8, 0xc5, 0x04, 0x03, 0x85, 0x7a, 0x31,
-	0xea, 0x4b, 0xa1, 0xc4, 0x12, 0x44, 0x9f, 0x85, 0xf0, 0x4a, 0x14, 0x74, 0x14, 0xea, 0x9b, 0xb7,
-	0x86, 0x0e, 0x87, 0x38, 0x3b, 0xaf, 0x44, 0x7d, 0x7b, 0x1a, 0xd8, 0x2c, 0xda, 0x90, 0x8d, 0x8c,
-	0x60, 0xa3, 0x98, 0xd0, 0x32, 0xb7, 0xcb, 0x84, 0x65, 0x6f, 0x12, 0xf6, 0x55, 0xd8, 0x88, 0xf9,
-	0x92, 0x14, 0xef, 0x40, 0xd6, 0x17, 0xed, 0x2d, 0x39, 0x42, 0x32, 0x84, 0x44, 0xe3, 0x63, 0x69,
-	0xa1, 0xff, 0x4d, 0x85, 0xb2, 0xdc, 0xdf, 0xb4, 0xe9, 0xf0, 0xec, 0x29, 0x25, 0xfd, 0xf3, 0xb0,
-	0xc6, 0xf5, 0x0e, 0x89, 0x46, 0x6c, 0x05, 0xed, 0x91, 0x05, 0x27, 0xde, 0x0e, 0xac, 0x04, 0xcb,
-	0x82, 0xf8, 0x1c, 0x2e, 0xda, 0x81, 0xb9, 0x50, 0xae, 0xa8, 0x4f, 0xf6, 0xee, 0xfa, 0xac, 0xdd,
-	0xac, 0xcf, 0x1e, 0x54, 0x96, 0xf9, 0x95, 0x45, 0xba, 0x0f, 0x6b, 0x61, 0x09, 0xa2, 0xab, 0x67,
-	0x55, 0x95, 0x22, 0x13, 0xfd, 0x6d, 0x15, 0x2a, 0x3d, 0xea, 0x13, 0x7b, 0xf2, 0x8c, 0x0c, 0xc7,
-	0x32, 0xab, 0x99, 0x9b, 0xac, 0xb6, 0x60, 0xeb, 0x06, 0x1d, 0x4f, 0xd0, 0xfb, 0x7f, 0x55, 0xd8,
-	0x63, 0x9b, 0x8c, 0x9d, 0xe9, 0x53, 0x4a, 0xe6, 0x32, 0x47, 0xe9, 0x9b, 0x1c, 0x3d, 0x84, 0xa2,
-	0xcc, 0x4e, 0x72, 0x73, 0xbb, 0xa1, 0x95, 0x15, 0x0d, 0xad, 0xbf, 0xa9, 0x42, 0xb1, 0xe5, 0x4d,
-	0x26, 0x0e, 0x7d, 0x4a, 0x79, 0xb9, 0x9d, 0x67, 0xfa, 0xee, 0xc1, 0xbd, 0xd5, 0x62, 0x1a, 0x94,
-	0x22, 0x16, 0x42, 0xfe, 0xf4, 0xb7, 0x54, 0xd8, 0xc0, 0x9e, 0xeb, 0x0e, 0xec, 0xe1, 0xf9, 0x33,
-	0x4d, 0x0d, 0x02, 0x6d, 0xc1, 0x83, 0x24, 0xe7, 0x1f, 0xec, 0x1f, 0x8c, 0x68, 0xb7, 0x67, 0xe3,
-	0x82, 0xd2, 0xdf, 0x51, 0xa0, 0xb2, 0x9c, 0x6f, 0x3c, 0x65, 0x19, 0xe2, 0xfb, 0x9e, 0x7f, 0x23,
-	0x45, 0xdc, 0x6d, 0x19, 0x5c, 0x8d, 0x43, 0x34, 0x71, 0x51, 0xa9, 0x77, 0x5d, 0x54, 0x2b, 0xaa,
-	0x96, 0x5a, 0x35, 0xb8, 0xbf, 0x57, 0xa1, 0x9a, 0x0c, 0xe9, 0x83, 0x07, 0xfa, 0xd2, 0x03, 0x5d,
-	0x7f, 0x4f, 0x81, 0x8f, 0xae, 0xe0, 0xe7, 0xbf, 0xab, 0x5b, 0xe2, 0xb9, 0xad, 0xde, 0xf9, 0xdc,
-	0xfe, 0x4f, 0x2b, 0xf7, 0x87, 0x34, 0x6c, 0xf6, 0x66, 0xae, 0x43, 0xe5, 0x21, 0xff, 0xdf, 0xcf,
-	0xf6, 0x4f, 0xc3, 0x7a, 0xc0, 0x93, 0xb5, 0x86, 0x9e, 0x3b, 0x9f, 0xf0, 0x62, 0xa5, 0xd8, 0x7b,
-	0x43, 0x41, 0xe8, 0x5a, 0x42, 0xc5, 0xde, 0x00, 0x0b, 0x91, 0xc9, 0x7c, 0x4a, 0xe5, 0x1f, 0x2f,
-	0x90, 0x16, 0x4c, 0x83, 0x1e, 0xc0, 0x47, 0xa6, 0xf3, 0x89, 0x25, 0x5e, 0xcb, 0x66, 0x2c, 0x2d,
-	0x71, 0xb2, 0x35, 0xb3, 0x7d, 0x5a, 0xcd, 0x09, 0xe3, 0x32, 0x83, 0xd9, 0xfb, 0x55, 0xd0, 0x25,
-	0xbe, 0x70, 0xde, 0x65, 0xd0, 0x1d, 0xff, 0xd5, 0xd0, 0xab, 0x90, 0xb7, 0xdd, 0xb1, 0xe7, 0x3b,
-	0xf4, 0x6c, 0x52, 0xcd, 0x8b, 0x97, 0x4f, 0x5d, 0x66, 0x71, 0xab, 0x3a, 0xb5, 0x46, 0x64, 0x89,
-	0x17, 0x9b, 0x58, 0xdb, 0xa2, 0x79, 0x40, 0xac, 0x30, 0xf6, 0x30, 0xa6, 0xcb, 0x7a, 0x15, 0x44,
-	0x37, 0x6e, 0x30, 0x64, 0x71, 0xcc, 0x49, 0x5d, 0xbf, 0x0f, 0xf9, 0xf8, 0x10, 0xf6, 0x46, 0xbd,
-	0x6e, 0x3c, 0xea, 0x37, 0x0e, 0xad, 0x5e, 0xf7, 0xb0, 0x6d, 0xf6, 0xb4, 0x0f, 0xa1, 0x22, 0xe4,
-	0xf7, 0xfb, 0x87, 0x4c, 0xd1, 0x6a, 0x74, 0x34, 0x45, 0xc7, 0x00, 0x62, 0xa3, 0x38, 0x62, 0x41,
-	0xb6, 0x72, 0x07, 0xd9, 0xec, 0xb5, 0x94, 0x91, 0x24, 0x79, 0x54, 0x45, 0xc6, 0x39, 0xa6, 0x10,
-	0x2c, 0xea, 0x0d, 0x40, 0xc9, 0xc4, 0xe4, 0x24, 0x24, 0x66, 0x4f, 0x59, 0x9a, 0xbd, 0x85, 0xff,
-	0x78, 0xf6, 0xf4, 0x2d, 0x28, 0x87, 0xff, 0xc4, 0x5e, 0x23, 0xb6, 0x4b, 0xa3, 0xeb, 0x46, 0xff,
-	0x23, 0xfb, 0x13, 0x81, 0xb9, 0xc6, 0x99, 0x90, 0x1e, 0xb5, 0xd9, 0x28, 0xb0, 0xaa, 0x9f, 0x09,
-	0x13, 0x6b, 0x31, 0x66, 0xac, 0xea, 0xa1, 0x4e, 0x8c, 0x18, 0xaa, 0xc3, 0x56, 0x40, 0x86, 0xde,
-	0x74, 0x14, 0x58, 0x03, 0x72, 0xc6, 0x3f, 0x61, 0x4c, 0xec, 0x80, 0x12, 0x5f, 0xc4, 0x5d, 0xc4,
-	0x65, 0x09, 0x36, 0x05, 0x76, 0x24, 0x20, 0xb4, 0x0b, 0x95, 0x81, 0x33, 0x75, 0xbd, 0xb1, 0x35,
-	0x73, 0xed, 0x2b, 0xe2, 0x07, 0x32, 0x55, 0xde, 0xaa, 0x19, 0x8c, 0x42, 0xac, 0x1b, 0x42, 0x61,
-	0xeb, 0x7c, 0x0b, 0x76, 0x56, 0x7a, 0xb1, 0x4e, 0x1d, 0x97, 0xfd, 0x90, 0x91, 0xe5, 0x13, 0x96,
-	0xe3, 0xd0, 0x16, 0x37, 0x49, 0xf8, 0x7c, 0x7c, 0x71, 0x85, 0xeb, 0x7d, 0x69, 0x8e, 0x17, 0xd6,
-	0x9c, 0xed, 0xe1, 0x6c, 0x6e, 0xcd, 0x03, 0x7b, 0x4c, 0xc4, 0x25, 0xa4, 0xe0, 0x1c, 0x53, 0xf4,
-	0xb9, 0xcc, 0x3f, 0x9a, 0x5c, 0xcc, 0x02, 0xd1, 0xcc, 0x0a, 0xe6, 0x4b, 0xfd, 0xef, 0x4a, 0xf4,
-	0xb7, 0x3e, 0x62, 0x2f, 0xbe, 0x8c, 0xa2, 0x91, 0x53, 0xfe, 0xdd, 0xc8, 0x55, 0x61, 0x2d, 0x20,
-	0xfe, 0xa5, 0x33, 0x1d, 0x0b, 0x8a, 0x72, 0x38, 0x12, 0x51, 0x0f, 0x5e, 0x94, 0x9f, 0xcd, 0xc8,
-	0x1b, 0x94, 0x7f, 0xe5, 0x72, 0xdd, 0x2b, 0x9e, 0x97, 0xed, 0x93, 0x29, 0x65, 0x29, 0xf2, 0xba,
-	0x04, 0xd4, 0x9e, 0xcc, 0xe4, 0x85, 0xf4, 0x7c, 0x68, 0x6d, 0xc4, 0xc6, 0x38, 0xb6, 0x35, 0x23,
-	0x53, 0xf4, 0x65, 0x28, 0xf9, 0xb2, 0xa6, 0x56, 0xc0, 0x8b, 0x2a, 0x47, 0xbd, 0x12, 0x7d, 0xcf,
-	0x48, 0x16, 0x1c, 0x17, 0xfd, 0xa4, 0xb8, 0x73, 0x0e, 0xe9, 0x7d, 0xd7, 0x1e, 0xa3, 0x1c, 0xa4,
-	0x3b, 0xc7, 0x1d, 0x83, 0x35, 0xf8, 0x06, 0x40, 0xbb, 0xd7, 0xee, 0x98, 0xc6, 0x01, 0x6e, 0x1c,
-	0x6a, 0xd7, 0x6a, 0xa8, 0xe8, 0x77, 0x7a, 0xed, 0x83, 0x8e, 0xb1, 0xa7, 0x5d, 0xa7, 0xd1, 0x3a,
-	0xac, 0xb5, 0x7b, 0xfb, 0x87, 0xc7, 0x0d, 0x53, 0xbb, 0xce, 0xb1, 0x81, 0xc8, 0xb5, 0x7b, 0x8f,
-	0xfa, 0xc7, 0x26, 0x07, 0x35, 0x54, 0x80, 0x6c, 0xbb, 0x67, 0x1a, 0xdf, 0x64, 0xd8, 0x73, 0x21,
-	0xd6, 0x6c, 0x77, 0x1a, 0xf8, 0xb1, 0x76, 0xfd, 0xea, 0xce, 0x3f, 0x55, 0x48, 0xf3, 0xef, 0x45,
-	0x7c, 0x88, 0x3a, 0x7c, 0x88, 0xcc, 0xc7, 0x5d, 0xee, 0x32, 0x0f, 0x69, 0xe6, 0xf0, 0x15, 0xed,
-	0xdb, 0x2a, 0x02, 0xc8, 0xf4, 0xc5, 0xfa, 0x3b, 0x59, 0xbe, 0x66, 0xcb, 0x2f, 0x3c, 0xd4, 0xbe,
-	0xab, 0xf2, 0x63, 0xfb, 0xa1, 0xf0, 0x66, 0x04, 0xd4, 0x1f, 0x68, 0xdf, 0x8b, 0x01, 0x26, 0xbc,
-	0x15, 0x01, 0x2f, 0xd5, 0xb5, 0xef, 0xc7, 0x00, 0x13, 0x7e, 0x10, 0x01, 0x0f, 0x1f, 0x68, 0x3f,
-	0x8c, 0x01, 0x26, 0xfc, 0x28, 0xcb, 0x73, 0x11, 0x99, 0x30, 0xb3, 0x1f, 0xe7, 0x62, 0x89, 0x61,
-	0x3f, 0xc9, 0xa1, 0x12, 0xe4, 0xcd, 0xf6, 0x91, 0xd1, 0x33, 0x1b, 0x47, 0x5d, 0xed, 0x6d, 0x8d,
-	0x87, 0xb9, 0xd7, 0x30, 0x0d, 0xed, 0xa7, 0x62, 0xc9, 0x21, 0xed, 0x67, 0x1a, 0xcf, 0x91, 0x6b,
-	0x85, 0xf8, 0x8e, 0x40, 0x1e, 0x1b, 0x0d, 0xac, 0xfd, 0x3c, 0xcb, 0x1c, 0xad, 0xed, 0x19, 0xad,
-	0xf6, 0x11, 0xa3, 0x11, 0x89, 0x1d, 0x9c, 0x95, 0x5f, 0xec, 0xf2, 0x65, 0xf3, 0xf0, 0xb8, 0xa9,
-	0xfd, 0xb2, 0xcb, 0x1d, 0x9e, 0x34, 0x70, 0xeb, 0x35, 0xb6, 0xe1, 0xdd, 0x5d, 0xee, 0x90, 0x49,
-	0x92, 0xaf, 0x5f, 0x75, 0xb9, 0xa1, 0x80, 0xde, 0xdb, 0xe5, 0x41, 0x4b, 0xfd, 0xaf, 0xbb, 0xac,
-	0x58, 0xa9, 0x66, 0xdb, 0xd4, 0x7e, 0x23, 0xbc, 0x19, 0x9d, 0xfe, 0x91, 0xf6, 0x5b, 0x8d, 0x2b,
-	0x7b, 0x86, 0xa9, 0xfd, 0x8e, 0x2b, 0x33, 0x66, 0xbf, 0x7b, 0x68, 0x68, 0x1f, 0x6f, 0x6e, 0x43,
-	0x75, 0xe8, 0x4d, 0x6a, 0x57, 0xde, 0x9c, 0xce, 0x07, 0xa4, 0x76, 0xe9, 0x50, 0x76, 0xad, 0x86,
-	0xdf, 0x7f, 0x07, 0x59, 0xf1, 0xf3, 0xd2, 0xbf, 0x02, 0x00, 0x00, 0xff, 0xff, 0x87, 0x97, 0xc9,
-	0x7a, 0x39, 0x16, 0x00, 0x00,
+	// 2134 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xec, 0x59, 0x5b, 0x73, 0x23, 0x47,
+	0xf5, 0xcf, 0xe8, 0x66, 0xe9, 0xc8, 0x92, 0xdb, 0x2d, 0xef, 0xff, 0x2f, 0x36, 0x81, 0x38, 0x93,
+	0xdb, 0x66, 0xb3, 0x65, 0x16, 0xad, 0xb3, 0xa4, 0x08, 0x05, 0x91, 0x6d, 0x79, 0x57, 0xe0, 0x95,
+	0xb5, 0xad, 0xb1, 0xd9, 0xe5, 0x81, 0xa9, 0xb1, 0xd4, 0x96, 0xa7, 0x3c, 0x9a, 0xd1, 0x4e, 0xf7,
+	0xf8, 0xf2, 0xb6, 0x04, 0x08, 0xd7, 0x40, 0x28, 0x2e, 0xe1, 0x52, 0x50, 0x45, 0x51, 0xbc, 0xc3,
+	0x57, 0xe0, 0x03, 0x84, 0x47, 0x5e, 0x79, 0xe5, 0x8d, 0xcb, 0x1b, 0x4f, 0x54, 0x5f, 0x66, 0x34,
+	0xb2, 0x1d, 0x9c, 0xcd, 0x13, 0xde, 0xe5, 0xc5, 0xee, 0x3e, 0xe7, 0x74, 0x9f, 0x73, 0x7e, 0xe7,
+	0xa2, 0xd3, 0x12, 0x94, 0x1f, 0x44, 0x34, 0x3c, 0x5e, 0x1a, 0x87, 0x01, 0x0f, 0x70, 0x5e, 0x6e,
+	0x2e, 0x57, 0x79, 0x30, 0x0e, 0x06, 0x0e, 0x77, 0x14, 0xf9, 0x72, 0xf9, 0x80, 0x87, 0xe3, 0xbe,
+	0xda, 0x98, 0x0f, 0xa0, 0x60, 0x39, 0xe1, 0x90, 0x72, 0x7c, 0x19, 0x8a, 0xfb, 0xf4, 0x98, 0x8d,
+	0x9d, 0x3e, 0xad, 0x1b, 0x8b, 0xc6, 0x95, 0x12, 0x49, 0xf6, 0x78, 0x01, 0xf2, 0x6c, 0xcf, 0x09,
+	0x07, 0xf5, 0x8c, 0x64, 0xa8, 0x0d, 0x7e, 0x0d, 0xca, 0xdc, 0xd9, 0xf1, 0x28, 0xb7, 0xf9, 0xf1,
+	0x98, 0xd6, 0xb3, 0x8b, 0xc6, 0x95, 0x6a, 0x63, 0x61, 0x29, 0x51, 0x67, 0x49, 0xa6, 0x75, 0x3c,
+	0xa6, 0x04, 0x78, 0xb2, 0x36, 0xaf, 0x41, 0x75, 0xdb, 0xba, 0xe5, 0x70, 0xba, 0xea, 0x78, 0x1e,
+	0x0d, 0xdb, 0x6b, 0x42, 0x75, 0xc4, 0x68, 0xe8, 0x3b, 0xa3, 0x44, 0x75, 0xbc, 0x37, 0x3f, 0x07,
+	0xf9, 0x6d, 0xc7, 0x8b, 0x28, 0x7e, 0x16, 0x72, 0x52, 0x8d, 0x21, 0xd5, 0x94, 0x97, 0x94, 0xa7,
+	0xf2, 0x76, 0xc9, 0x10, 0x46, 0x1e, 0x08, 0x49, 0x69, 0xe4, 0x2c, 0x51, 0x1b, 0x73, 0x1f, 0x66,
+	0x57, 0x5c, 0x7f, 0xb0, 0xed, 0x84, 0xae, 0x30, 0xe1, 0x23, 0x5e, 0x83, 0x5f, 0x80, 0x82, 0x5c,
+	0xb0, 0x7a, 0x76, 0x31, 0x7b, 0xa5, 0xdc, 0x98, 0xd5, 0x07, 0xa5, 0x6d, 0x44, 0xf3, 0xcc, 0x3f,
+	0x1a, 0x00, 0x2b, 0x41, 0xe4, 0x0f, 0xee, 0x0a, 0x26, 0x46, 0x90, 0x65, 0x0f, 0x3c, 0xed, 0x92,
+	0x58, 0xe2, 0x2f, 0x42, 0x75, 0xc7, 0xf5, 0x07, 0xf6, 0x81, 0x36, 0x87, 0xd5, 0x33, 0xf2, 0xba,
+	0x17, 0xf4, 0x75, 0x93, 0xc3, 0x4b, 0x69, 0xab, 0x59, 0xcb, 0xe7, 0xe1, 0x31, 0xa9, 0xec, 0xa4,
+	0x69, 0x97, 0xb7, 0x00, 0x9f, 0x16, 0x12, 0x4a, 0xf7, 0xe9, 0x71, 0xac, 0x74, 0x9f, 0x1e, 0xe3,
+	0x57, 0xd2, 0x1e, 0x95, 0x1b, 0xb5, 0x58, 0x57, 0xea, 0xac, 0x76, 0xf3, 0x33, 0x99, 0xd7, 0x0d,
+	0xf3, 0xb3, 0x90, 0x5f, 0x77, 0xa9, 0x37, 0xc0, 0x18, 0x72, 0xa9, 0x90, 0xc8, 0x75, 0x02, 0x5f,
+	0xe6, 0x03, 0xe0, 0x33, 0x3f, 0x0d, 0x59, 0x12, 0x1c, 0xe2, 0x3a, 0xcc, 0x78, 0xd4, 0x1f, 0xf2,
+	0x3d, 0x56, 0x37, 0x16, 0xb3, 0x57, 0x30, 0x89, 0xb7, 0xf8, 0xff, 0x12, 0x24, 0x15, 0xc0, 0x31,
+	0x76, 0x7f, 0x30, 0xa0, 0x2c, 0x3d, 0x27, 0x94, 0x45, 0x1e, 0x17, 0x88, 0xef, 0x0a, 0x33, 0xd4,
+	0x05, 0x13, 0xc4, 0xa5, 0x6d, 0x44, 0xf3, 0xf0, 0xf3, 0x50, 0x09, 0x83, 0x43, 0x66, 0x3b, 0xbb,
+	0xbb, 0xb4, 0xcf, 0xa9, 0xca, 0xd0, 0x1c, 0x99, 0x15, 0xc4, 0xa6, 0xa6, 0xe1, 0xa7, 0xa1, 0xe4,
+	0xfa, 0x8c, 0x86, 0xdc, 0x76, 0x07, 0x32, 0x4d, 0x73, 0xa4, 0xa8, 0x08, 0xed, 0x01, 0xfe, 0x04,
+	0xe4, 0x84, 0x70, 0x3d, 0x27, 0xb5, 0x80, 0xd6, 0x42, 0x82, 0x43, 0x22, 0xe9, 0x22, 0x39, 0x0f,
+	0x9d, 0xd0, 0x77, 0xfd, 0x21, 0xab, 0xe7, 0x17, 0xb3, 0x22, 0x39, 0xe3, 0xbd, 0xf9, 0x27, 0x03,
+	0x6a, 0xb7, 0x28, 0xef, 0x51, 0xc6, 0xdc, 0xc0, 0x6f, 0x0f, 0x08, 0x7d, 0x10, 0x51, 0xc6, 0xf1,
+	0xe7, 0xa1, 0x46, 0xa5, 0x72, 0xf7, 0x80, 0xda, 0x7d, 0x99, 0xe6, 0x42, 0xb5, 0x21, 0xf1, 0x9f,
+	0x5b, 0x52, 0x05, 0x18, 0xa7, 0x3f, 0x99, 0x4f, 0x64, 0x35, 0x69, 0x80, 0x5b, 0x50, 0x73, 0x47,
+	0x23, 0x3a, 0x70, 0x1d, 0x9e, 0xbe, 0x40, 0x05, 0xf0, 0x52, 0x9c, 0x7b, 0x53, 0x55, 0x44, 0xe6,
+	0x93, 0x13, 0xc9, 0x35, 0xe9, 0x9a, 0xce, 0x7e, 0x50, 0x4d, 0xe7, 0x52, 0x35, 0x6d, 0xbe, 0x06,
+	0x0b, 0xd3, 0x0e, 0xb1, 0x71, 0xe0, 0x33, 0x8a, 0x3f, 0x0e, 0xc0, 0x14, 0x31, 0x76, 0x24, 0x4b,
+	0x4a, 0x2c, 0x16, 0x33, 0x7f, 0x9f, 0x85, 0x6a, 0xeb, 0x88, 0xf6, 0x23, 0x4e, 0xff, 0xdb, 0x30,
+	0x78, 0x11, 0x0a, 0x5c, 0x76, 0x38, 0x89, 0x40, 0xb9, 0x51, 0x89, 0x73, 0x56, 0x12, 0x89, 0x66,
+	0xe2, 0x97, 0x41, 0xb5, 0x4b, 0x09, 0x47, 0xb9, 0x31, 0x7f, 0xaa, 0x20, 0x89, 0xe2, 0xe3, 0x17,
+	0xa1, 0xca, 0x43, 0xc7, 0x67, 0x4e, 0x9f, 0x6b, 0x34, 0xf2, 0x12, 0x8d, 0x4a, 0x8a, 0xda, 0x1e,
+	0x9c, 0x00, 0xac, 0x70, 0x02, 0x30, 0x6c, 0x42, 0xe5, 0xd0, 0x71, 0xb9, 0xbd, 0x1b, 0x84, 0xf6,
+	0x90, 0xbb, 0x83, 0xfa, 0x8c, 0x8c, 0x42, 0x59, 0x10, 0xd7, 0x83, 0xf0, 0x16, 0x77, 0x07, 0x78,
+	0x09, 0x6a, 0xae, 0xdf, 0xf7, 0xa2, 0x01, 0xb5, 0xc3, 0xe0, 0xd0, 0x3e, 0xa0, 0xa1, 0x38, 0x5c,
+	0x2f, 0x2e, 0x1a, 0x57, 0x8a, 0x64, 0x5e, 0xb3, 0x48, 0x70, 0xb8, 0xad, 0x18, 0xf8, 0x1a, 0x60,
+	0x7a, 0x34, 0xa6, 0x7d, 0x3e, 0x25, 0x5e, 0x92, 0x17, 0x23, 0xc5, 0x99, 0x48, 0x9b, 0x5f, 0x81,
+	0xb9, 0x24, 0x62, 0x3a, 0xc8, 0x57, 0xa1, 0x10, 0xca, 0xe2, 0xd3, 0x51, 0xc2, 0x1a, 0x84, 0x54,
+	0x59, 0x12, 0x2d, 0x81, 0x9f, 0x85, 0x72, 0x5a, 0x8b, 0xfa, 0x60, 0x80, 0x70, 0x72, 0xff, 0x5b,
+	0x59, 0xa8, 0x69, 0x05, 0x2b, 0x0e, 0xef, 0xef, 0x5d, 0xd0, 0xbc, 0x78, 0x15, 0x66, 0x04, 0xdd,
+	0xa5, 0x71, 0x87, 0x38, 0x23, 0x33, 0x62, 0x09, 0x91, 0x1b, 0x0e, 0xb3, 0x53, 0x89, 0x20, 0x73,
+	0xa3, 0x48, 0x2a, 0x0e, 0xb3, 0x26, 0xc4, 0x33, 0x52, 0xa8, 0x70, 0x7e, 0x0a, 0xcd, 0x9c, 0x9b,
+	0x42, 0xc5, 0x53, 0x29, 0x64, 0xae, 0xc1, 0xc2, 0x74, 0x0c, 0x74, 0xa4, 0xaf, 0xc1, 0x8c, 0x8a,
+	0x63, 0xdc, 0x5d, 0xcf, 0x0a, 0x75, 0x2c, 0x62, 0xbe, 0x9f, 0x81, 0x85, 0x1e, 0x0f, 0xa9, 0x33,
+	0x7a, 0x42, 0x6a, 0x7c, 0x1a, 0xf9, 0xfc, 0x49, 0xe4, 0x9f, 0x81, 0x92, 0x80, 0x66, 0x24, 0x3e,
+	0x39, 0x65, 0xe8, 0x8a, 0x64, 0x42, 0xc0, 0xcf, 0xc1, 0xac, 0xdc, 0x50, 0x9b, 0x07, 0xfb, 0xd4,
+	0x97, 0x81, 0x9b, 0x25, 0x65, 0x45, 0xb3, 0x04, 0xc9, 0xdc, 0x85, 0x4b, 0x27, 0xf0, 0xfc, 0x08,
+	0x15, 0x78, 0x52, 0x4f, 0xe6, 0xb4, 0x9e, 0x77, 0x32, 0x30, 0xbb, 0x42, 0x87, 0xae, 0x7f, 0x41,
+	0x03, 0x36, 0x1d, 0x87, 0xdc, 0xc9, 0x38, 0x7c, 0x12, 0x66, 0x82, 0xb1, 0x28, 0x16, 0x56, 0xcf,
+	0x4f, 0x19, 0xa0, 0x61, 0xdd, 0x54, 0x4c, 0x12, 0x4b, 0x99, 0x37, 0xa1, 0xa2, 0xe1, 0xd0, 0x78,
+	0x9f, 0xae, 0x44, 0xe3, 0x8c, 0x4a, 0x34, 0x7f, 0x97, 0x81, 0xca, 0x6a, 0x30, 0x1a, 0xb9, 0xfc,
+	0x82, 0x02, 0x79, 0xda, 0xcf, 0xdc, 0xf9, 0x1d, 0xe7, 0x54, 0xde, 0x8b, 0x9e, 0x4f, 0x79, 0x14,
+	0xfa, 0xaa, 0xdf, 0xa8, 0xcc, 0x07, 0x45, 0x92, 0xed, 0xe6, 0x05, 0xa8, 0xc6, 0x30, 0x69, 0x80,
+	0x31, 0xe4, 0x86, 0x5c, 0x03, 0x53, 0x22, 0x72, 0x6d, 0xbe, 0x9d, 0x81, 0x39, 0x12, 0x78, 0xde,
+	0x8e, 0xd3, 0xdf, 0x7f, 0x92, 0xf1, 0x34, 0x31, 0xa0, 0x09, 0x0e, 0x0a, 0x30, 0xf3, 0xd7, 0x19,
+	0xa8, 0xc9, 0x1c, 0x7d, 0x42, 0x5a, 0xed, 0x23, 0xd7, 0xf0, 0xbb, 0x06, 0x2c, 0x4c, 0x03, 0x94,
+	0xd4, 0x72, 0x9e, 0x86, 0x61, 0x10, 0x9e, 0xc0, 0x84, 0x74, 0x57, 0x5b, 0x82, 0x4c, 0x14, 0x37,
+	0xd5, 0x62, 0x33, 0xe7, 0xb6, 0xd8, 0xd3, 0x61, 0xce, 0x9e, 0xd5, 0x1e, 0xde, 0xcf, 0x40, 0x3d,
+	0x6d, 0xd2, 0xff, 0xe6, 0x9d, 0xe9, 0x79, 0x27, 0x15, 0xe3, 0xc2, 0x87, 0x8a, 0xf1, 0x7b, 0x06,
+	0x7c, 0xec, 0x0c, 0x40, 0x1f, 0x2d, 0xd0, 0xa9, 0x19, 0x27, 0x73, 0xee, 0x8c, 0xf3, 0x61, 0x43,
+	0xfd, 0x9b, 0x1c, 0xcc, 0xf7, 0xc6, 0x9e, 0xcb, 0xf5, 0x25, 0x8f, 0x77, 0x71, 0x3e, 0x07, 0xb3,
+	0x4c, 0x38, 0x6b, 0xf7, 0x03, 0x2f, 0x1a, 0xf9, 0xfa, 0xfd, 0x5b, 0x96, 0xb4, 0x55, 0x49, 0x12,
+	0x9f, 0x09, 0xb1, 0x48, 0xe4, 0x73, 0x3d, 0xc8, 0x82, 0x96, 0x88, 0x7c, 0x8e, 0x97, 0xe1, 0xff,
+	0xfd, 0x68, 0x64, 0xcb, 0x57, 0xfa, 0x98, 0x86, 0xb6, 0xbc, 0xd9, 0x1e, 0x3b, 0x21, 0x97, 0x03,
+	0x6b, 0x96, 0xd4, 0xfc, 0x68, 0x44, 0x82, 0x43, 0xd6, 0xa5, 0xa1, 0x54, 0xde, 0x75, 0x42, 0x7e,
+	0xde, 0xec, 0xfb, 0x26, 0x94, 0x1c, 0x6f, 0x18, 0x84, 0x2e, 0xdf, 0x1b, 0xc9, 0x17, 0x4e, 0xb5,
+	0x61, 0x6a, 0x2f, 0x4e, 0x45, 0x67, 0xa9, 0x19, 0x4b, 0x92, 0xc9, 0x21, 0xfc, 0x2a, 0xe0, 0x88,
+	0x51, 0x5b, 0xd9, 0xae, 0x6c, 0x3a, 0x68, 0xd4, 0x41, 0xa6, 0xef, 0x5c, 0xc4, 0xe8, 0xe4, 0x9a,
+	0xed, 0x86, 0x79, 0x0d, 0x4a, 0xc9, 0x25, 0x18, 0xc1, 0x6c, 0xeb, 0xee, 0x56, 0x73, 0xc3, 0xee,
+	0x75, 0x37, 0xda, 0x56, 0x0f, 0x3d, 0x85, 0x2b, 0x50, 0x5a, 0xdf, 0xda, 0xd8, 0xb0, 0x7b, 0xab,
+	0xcd, 0x0e, 0x32, 0x4c, 0x02, 0x20, 0x0f, 0xca, 0x2b, 0x26, 0x60, 0x1b, 0xe7, 0x80, 0xfd, 0x34,
+	0x94, 0xc4, 0x8b, 0x4a, 0xe1, 0x98, 0x91, 0x1e, 0x17, 0xc3, 0xe0, 0x50, 0xa2, 0x68, 0x36, 0x01,
+	0xa7, 0x1d, 0xd3, 0x95, 0x90, 0x2a, 0x56, 0x63, 0xaa, 0x58, 0x27, 0xfa, 0x93, 0x62, 0x35, 0x2f,
+	0x41, 0x4d, 0x0d, 0x9d, 0xb7, 0xa9, 0xe3, 0xf1, 0xb8, 0x3f, 0x99, 0xbf, 0xcd, 0x40, 0x85, 0x08,
+	0x8a, 0x3b, 0xa2, 0x3d, 0xee, 0x70, 0x26, 0xa2, 0xbe, 0x27, 0x45, 0xec, 0x49, 0x99, 0x95, 0x48,
+	0x59, 0xd1, 0x64, 0x89, 0xe1, 0x06, 0x5c, 0x62, 0xb4, 0x1f, 0xf8, 0x03, 0x66, 0xef, 0xd0, 0x3d,
+	0xf1, 0x8d, 0xd6, 0xc8, 0x61, 0x9c, 0x86, 0xd2, 0xee, 0x0a, 0xa9, 0x69, 0xe6, 0x8a, 0xe4, 0xdd,
+	0x91, 0x2c, 0x7c, 0x1d, 0x16, 0x76, 0x5c, 0xdf, 0x0b, 0x86, 0xf6, 0xd8, 0x73, 0x8e, 0x69, 0xc8,
+	0xb4, 0xab, 0x22, 0x55, 0xf3, 0x04, 0x2b, 0x5e, 0x57, 0xb1, 0x54, 0xea, 0x7c, 0x19, 0xae, 0x9e,
+	0xa9, 0xc5, 0xde, 0x75, 0x3d, 0x4e, 0x43, 0x3a, 0xb0, 0x43, 0x3a, 0xf6, 0xdc, 0xbe, 0x23, 0x5b,
+	0x8f, 0xfa, 0x04, 0x7e, 0xe9, 0x0c, 0xd5, 0xeb, 0x5a, 0x9c, 0x4c, 0xa4, 0x05, 0xda, 0xfd, 0x71,
+	0x64, 0x47, 0xcc, 0x19, 0x52, 0xd9, 0xb5, 0x0c, 0x52, 0xec, 0x8f, 0xa3, 0x2d, 0xb1, 0x17, 0xdf,
+	0xa1, 0x3d, 0x18, 0xab, 0x66, 0x65, 0x10, 0xb1, 0x34, 0xff, 0x6a, 0xc0, 0xc2, 0x34, 0x7a, 0x49,
+	0x33, 0x8a, 0x4b, 0xce, 0xf8, 0x4f, 0x25, 0x57, 0x87, 0x19, 0x46, 0xc3, 0x03, 0xd7, 0x1f, 0x4a,
+	0x88, 0x8a, 0x24, 0xde, 0xe2, 0x1e, 0xbc, 0xa4, 0xbf, 0x45, 0xa5, 0x47, 0x9c, 0x86, 0xbe, 0xe3,
+	0x79, 0xc7, 0xc2, 0x2f, 0x27, 0xa4, 0x3e, 0xa7, 0x03, 0x5b, 0xc4, 0x85, 0x71, 0x67, 0x34, 0xd6,
+	0x0d, 0xe9, 0x79, 0x25, 0xdd, 0x4a, 0x84, 0x49, 0x22, 0x6b, 0xc5, 0xa2, 0xf8, 0x0d, 0xa8, 0x86,
+	0x3a, 0xa6, 0x36, 0x13, 0x41, 0xd5, 0xa5, 0xbe, 0xa0, 0xad, 0x9b, 0x0a, 0x38, 0xa9, 0x84, 0xe9,
+	0xad, 0xf9, 0x67, 0x03, 0xf0, 0x97, 0xf4, 0x23, 0xd2, 0x6a, 0xaf, 0x5d, 0xd0, 0x26, 0x17, 0x4f,
+	0x9e, 0xb9, 0xd4, 0xe4, 0x79, 0x09, 0x6a, 0x53, 0x8e, 0xe9, 0x99, 0xeb, 0x6f, 0x06, 0x54, 0xef,
+	0x35, 0x7b, 0xdc, 0x09, 0xf9, 0xe3, 0xf9, 0x50, 0x42, 0x90, 0x3d, 0xd2, 0x03, 0x68, 0x89, 0x88,
+	0xa5, 0xf9, 0x3a, 0xcc, 0x25, 0x1e, 0x3f, 0xda, 0x5b, 0xe8, 0x1f, 0x06, 0xa0, 0x7b, 0xcd, 0xae,
+	0xca, 0xd0, 0x27, 0x05, 0xae, 0x1a, 0xcc, 0xa7, 0x7c, 0xd6, 0x69, 0xf3, 0x77, 0x43, 0x80, 0x78,
+	0xa1, 0xdf, 0x85, 0x8f, 0x0c, 0x04, 0x06, 0x34, 0x71, 0x59, 0xe3, 0xf0, 0x4f, 0x43, 0xa0, 0x73,
+	0xc1, 0x5f, 0x74, 0x8f, 0x8c, 0xc4, 0x02, 0xe0, 0xb4, 0xd3, 0x1a, 0x8b, 0xbf, 0xc8, 0xea, 0x20,
+	0xb4, 0x1f, 0x1c, 0xd0, 0xf0, 0xb1, 0x84, 0xc2, 0x7c, 0x19, 0xe6, 0x53, 0x1e, 0x4e, 0xde, 0xf9,
+	0x47, 0xae, 0xfe, 0xad, 0xa6, 0x44, 0xe4, 0x5a, 0xfc, 0x1a, 0x36, 0x7f, 0x3b, 0x90, 0x23, 0x8b,
+	0x4b, 0xd9, 0xc5, 0x04, 0x43, 0x0c, 0x5e, 0x69, 0x1f, 0xce, 0x1b, 0xbc, 0xce, 0x78, 0x25, 0x99,
+	0x6f, 0x40, 0x75, 0xfa, 0xa1, 0x83, 0x5f, 0x81, 0x79, 0x7e, 0x64, 0x8f, 0x83, 0xc0, 0xb3, 0x77,
+	0x1d, 0x57, 0xfc, 0x61, 0x6a, 0x7e, 0x28, 0x92, 0x2a, 0x3f, 0xea, 0x06, 0x81, 0xb7, 0xee, 0xb8,
+	0xde, 0xba, 0xc3, 0xf8, 0xd5, 0x7d, 0xc8, 0xad, 0x7b, 0xce, 0x10, 0x17, 0x21, 0xd7, 0xd9, 0xec,
+	0xb4, 0xd0, 0x53, 0x78, 0x0e, 0xa0, 0xdd, 0x6b, 0x77, 0xac, 0xd6, 0x2d, 0xd2, 0xdc, 0x40, 0x0f,
+	0x33, 0x8a, 0xb0, 0xd5, 0xe9, 0xb5, 0x6f, 0x75, 0x5a, 0x6b, 0xe8, 0x61, 0x0e, 0xcf, 0xc2, 0x4c,
+	0xbb, 0xb7, 0xbe, 0xb1, 0xd9, 0xb4, 0xd0, 0xc3, 0x22, 0xae, 0x40, 0xb1, 0xdd, 0xbb, 0xbb, 0xb5,
+	0x69, 0x09, 0x26, 0xc2, 0x65, 0x28, 0xb4, 0x7b, 0x56, 0xeb, 0x9e, 0x85, 0x1e, 0x2e, 0x2a, 0xde,
+	0x4a, 0xbb, 0xd3, 0x24, 0xf7, 0xd1, 0xc3, 0x37, 0xaf, 0xfe, 0x2b, 0x03, 0x39, 0xf1, 0x5b, 0x9e,
+	0x98, 0x68, 0x3b, 0x62, 0xa2, 0xb5, 0xee, 0x77, 0x85, 0xca, 0x12, 0xe4, 0xda, 0x1d, 0xeb, 0x75,
+	0xf4, 0xd5, 0x0c, 0x06, 0xc8, 0x6f, 0xc9, 0xf5, 0x5b, 0x05, 0xb1, 0x6e, 0x77, 0xac, 0x4f, 0xdd,
+	0x44, 0x5f, 0xcb, 0x88, 0x6b, 0xb7, 0xd4, 0xe6, 0xeb, 0x31, 0xa3, 0xb1, 0x8c, 0xbe, 0x91, 0x30,
+	0x1a, 0xcb, 0xe8, 0xed, 0x98, 0x71, 0xa3, 0x81, 0xbe, 0x99, 0x30, 0x6e, 0x34, 0xd0, 0xb7, 0x62,
+	0xc6, 0xcd, 0x65, 0xf4, 0xed, 0x84, 0x71, 0x73, 0x19, 0x7d, 0xa7, 0x20, 0x7c, 0x91, 0x9e, 0xdc,
+	0x68, 0xa0, 0xef, 0x16, 0x93, 0xdd, 0xcd, 0x65, 0xf4, 0xbd, 0x22, 0xae, 0x42, 0xc9, 0x6a, 0xdf,
+	0x69, 0xf5, 0xac, 0xe6, 0x9d, 0x2e, 0x7a, 0x07, 0x09, 0x33, 0xd7, 0x9a, 0x56, 0x0b, 0x7d, 0x5f,
+	0x2e, 0x05, 0x0b, 0xfd, 0x00, 0x09, 0x1f, 0x05, 0x55, 0x6e, 0xdf, 0x95, 0x9c, 0xfb, 0xad, 0x26,
+	0x41, 0x3f, 0x2c, 0xe0, 0x32, 0xcc, 0xac, 0xb5, 0x56, 0xdb, 0x77, 0x9a, 0x1b, 0x08, 0xcb, 0x13,
+	0x02, 0x95, 0x1f, 0x5d, 0x17, 0xcb, 0x95, 0x8d, 0xcd, 0x15, 0xf4, 0xe3, 0xae, 0x50, 0xb8, 0xdd,
+	0x24, 0xab, 0xb7, 0x9b, 0x04, 0xfd, 0xe4, 0xba, 0x50, 0xb8, 0xdd, 0x24, 0x1a, 0xaf, 0x9f, 0x76,
+	0x85, 0xa0, 0x64, 0xbd, 0x77, 0x5d, 0x18, 0xad, 0xe9, 0x3f, 0xeb, 0xe2, 0x22, 0x64, 0x57, 0xda,
+	0x16, 0xfa, 0xb9, 0xd4, 0xd6, 0xea, 0x6c, 0xdd, 0x41, 0xbf, 0x40, 0x82, 0xd8, 0x6b, 0x59, 0xe8,
+	0x97, 0x82, 0x98, 0xb7, 0xb6, 0xba, 0x1b, 0x2d, 0xf4, 0x8c, 0xe0, 0x7f, 0xa1, 0xb7, 0xd9, 0x41,
+	0xbf, 0x42, 0x2b, 0x97, 0xa1, 0xde, 0x0f, 0x46, 0x4b, 0xc7, 0x41, 0xc4, 0xa3, 0x1d, 0xba, 0x74,
+	0xe0, 0x72, 0xca, 0x98, 0xfa, 0x99, 0x7e, 0xa7, 0x20, 0xff, 0xdd, 0xf8, 0xf7, 0x00, 0x01, 0x48,
+	0x0c, 0xd2, 0xe0, 0x1f, 0x00, 0x00,
 }
@@ -0,0 +1,285 @@
+// Code generated by protoc-gen-go.
+// source: querylog.proto
+// DO NOT EDIT!
+
+/*
+Package querylog is a generated protocol buffer package.
+
+It is generated from these files:
+	querylog.proto
+
+It has these top-level messages:
+	PerfSchemaStats
+	LogStats
+	DedupSummary
+*/
+package querylog
+
+import proto "github.com/golang/protobuf/proto"
+import fmt "fmt"
+import math "math"
+import logutil "github.com/youtube/vitess/go/vt/proto/logutil"
+import query "github.com/youtube/vitess/go/vt/proto/query"
+import vtrpc "github.com/youtube/vitess/go/vt/proto/vtrpc"
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+const _ = proto.ProtoPackageIsVersion1
+
+// PerfSchemaStats are the execution details of the statements of a
+// query, as recorded by MySQL in performance_schema.
+type PerfSchemaStats struct {
+	RowsExamined         int64 `protobuf:"varint,1,opt,name=rows_examined,json=rowsExamined" json:"rows_examined,omitempty"`
+	CreatedTmpDiskTables int64 `protobuf:"varint,2,opt,name=created_tmp_disk_tables,json=createdTmpDiskTables" json:"created_tmp_disk_tables,omitempty"`
+	CreatedTmpTables     int64 `protobuf:"varint,3,opt,name=created_tmp_tables,json=createdTmpTables" json:"created_tmp_tables,omitempty"`
+	SelectFullJoin       int64 `protobuf:"varint,4,opt,name=select_full_join,json=selectFullJoin" json:"select_full_join,omitempty"`
+	SelectScan           int64 `protobuf:"varint,5,opt,name=select_scan,json=selectScan" json:"select_scan,omitempty"`
+	SortRows             int64 `protobuf:"varint,6,opt,name=sort_rows,json=sortRows" json:"sort_rows,omitempty"`
+	NoIndexUsed          int64 `protobuf:"varint,7,opt,name=no_index_used,json=noIndexUsed" json:"no_index_used,omitempty"`
+}
+
+func (m *PerfSchemaStats) Reset()                    { *m = PerfSchemaStats{} }
+func (m *PerfSchemaStats) String() string            { return proto.CompactTextString(m) }
+func (*PerfSchemaStats) ProtoMessage()               {}
+func (*PerfSchemaStats) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0} }
+
+// LogStats is a query served by vttablet.
+// Durations are in nanoseconds.
+type LogStats struct {
+	Method          string                         `protobuf:"bytes,1,opt,name=method" json:"method,omitempty"`
+	RemoteAddr      string                         `protobuf:"bytes,2,opt,name=remote_addr,json=remoteAddr" json:"remote_addr,omitempty"`
+	Username        string                         `protobuf:"bytes,3,opt,name=username" json:"username,omitempty"`
+	ImmediateCaller string                         `protobuf:"bytes,4,opt,name=immediate_caller,json=immediateCaller" json:"immediate_caller,omitempty"`
+	EffectiveCaller string                         `protobuf:"bytes,5,opt,name=effective_caller,json=effectiveCaller" json:"effective_caller,omitempty"`
+	StartTime       *logutil.Time                  `protobuf:"bytes,6,opt,name=start_time,json=startTime" json:"start_time,omitempty"`
+	EndTime         *logutil.Time                  `protobuf:"bytes,7,opt,name=end_time,json=endTime" json:"end_time,omitempty"`
+	TotalTime       int64                          `protobuf:"varint,8,opt,name=total_time,json=totalTime" json:"total_time,omitempty"`
+	PlanType        string                         `protobuf:"bytes,9,opt,name=plan_type,json=planType" json:"plan_type,omitempty"`
+	OriginalSql     string                         `protobuf:"bytes,10,opt,name=original_sql,json=originalSql" json:"original_sql,omitempty"`
+	BindVariables   map[string]*query.BindVariable `protobuf:"bytes,11,rep,name=bind_variables,json=bindVariables" json:"bind_variables,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	NumberOfQueries int64                          `protobuf:"varint,12,opt,name=number_of_queries,json=numberOfQueries" json:"number_of_queries,omitempty"`
+	// rewritten_sql are the statements sent to MySQL, and
+	// rewritten_sql_times how long each of them took.
+	RewrittenSql         []string         `protobuf:"bytes,13,rep,name=rewritten_sql,json=rewrittenSql" json:"rewritten_sql,omitempty"`
+	RewrittenSqlTimes    []int64          `protobuf:"varint,14,rep,packed,name=rewritten_sql_times,json=rewrittenSqlTimes" json:"rewritten_sql_times,omitempty"`
+	QuerySources         []string         `protobuf:"bytes,15,rep,name=query_sources,json=querySources" json:"query_sources,omitempty"`
+	MysqlResponseTime    int64            `protobuf:"varint,16,opt,name=mysql_response_time,json=mysqlResponseTime" json:"mysql_response_time,omitempty"`
+	WaitingForConnection int64            `protobuf:"varint,17,opt,name=waiting_for_connection,json=waitingForConnection" json:"waiting_for_connection,omitempty"`
+	RowsAffected         int64            `protobuf:"varint,18,opt,name=rows_affected,json=rowsAffected" json:"rows_affected,omitempty"`
+	SizeOfResponse       int64            `protobuf:"varint,19,opt,name=size_of_response,json=sizeOfResponse" json:"size_of_response,omitempty"`
+	SizeOfRequest        int64            `protobuf:"varint,20,opt,name=size_of_request,json=sizeOfRequest" json:"size_of_request,omitempty"`
+	CacheHits            int64            `protobuf:"varint,21,opt,name=cache_hits,json=cacheHits" json:"cache_hits,omitempty"`
+	CacheMisses          int64            `protobuf:"varint,22,opt,name=cache_misses,json=cacheMisses" json:"cache_misses,omitempty"`
+	CacheAbsent          int64            `protobuf:"varint,23,opt,name=cache_absent,json=cacheAbsent" json:"cache_absent,omitempty"`
+	CacheInvalidations   int64            `protobuf:"varint,24,opt,name=cache_invalidations,json=cacheInvalidations" json:"cache_invalidations,omitempty"`
+	TransactionId        int64            `protobuf:"varint,25,opt,name=transaction_id,json=transactionId" json:"transaction_id,omitempty"`
+	Error                string           `protobuf:"bytes,26,opt,name=error" json:"error,omitempty"`
+	ErrorCode            vtrpc.ErrorCode  `protobuf:"varint,27,opt,name=error_code,json=errorCode,enum=vtrpc.ErrorCode" json:"error_code,omitempty"`
+	MysqlErrno           int64            `protobuf:"varint,28,opt,name=mysql_errno,json=mysqlErrno" json:"mysql_errno,omitempty"`
+	MysqlState           string           `protobuf:"bytes,29,opt,name=mysql_state,json=mysqlState" json:"mysql_state,omitempty"`
+	Fingerprint          string           `protobuf:"bytes,30,opt,name=fingerprint" json:"fingerprint,omitempty"`
+	TableHits            map[string]int64 `protobuf:"bytes,31,rep,name=table_hits,json=tableHits" json:"table_hits,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	DeadlineExceeded     bool             `protobuf:"varint,32,opt,name=deadline_exceeded,json=deadlineExceeded" json:"deadline_exceeded,omitempty"`
+	SemiSyncFallback     bool             `protobuf:"varint,33,opt,name=semi_sync_fallback,json=semiSyncFallback" json:"semi_sync_fallback,omitempty"`
+	PerfSchema           *PerfSchemaStats `protobuf:"bytes,34,opt,name=perf_schema,json=perfSchema" json:"perf_schema,omitempty"`
+	Keyspace             string           `protobuf:"bytes,35,opt,name=keyspace" json:"keyspace,omitempty"`
+	Shard                string           `protobuf:"bytes,36,opt,name=shard" json:"shard,omitempty"`
+	TabletAlias          string           `protobuf:"bytes,37,opt,name=tablet_alias,json=tabletAlias" json:"tablet_alias,omitempty"`
+	// dedup_summary is only set on the summaries of the duplicates
+	// that were not logged. Their other fields are the ones of the
+	// last duplicate.
+	DedupSummary *DedupSummary `protobuf:"bytes,38,opt,name=dedup_summary,json=dedupSummary" json:"dedup_summary,omitempty"`
+	// conn_pool_wait_time and tx_pool_wait_time are the parts of
+	// waiting_for_connection spent waiting for the connection pool and
+	// the transaction pool.
+	ConnPoolWaitTime int64 `protobuf:"varint,39,opt,name=conn_pool_wait_time,json=connPoolWaitTime" json:"conn_pool_wait_time,omitempty"`
+	TxPoolWaitTime   int64 `protobuf:"varint,40,opt,name=tx_pool_wait_time,json=txPoolWaitTime" json:"tx_pool_wait_time,omitempty"`
+	// deadline_budget is the time between start_time and the deadline
+	// of the context of the query, and deadline_remaining the time
+	// left at end_time, negative if the deadline was exceeded. Both
+	// are only set if has_deadline is.
+	DeadlineBudget    int64 `protobuf:"varint,41,opt,name=deadline_budget,json=deadlineBudget" json:"deadline_budget,omitempty"`
+	DeadlineRemaining int64 `protobuf:"varint,42,opt,name=deadline_remaining,json=deadlineRemaining" json:"deadline_remaining,omitempty"`
+	// query_id identifies the query in the logs of the tablet, and in
+	// the MySQL logs with -query_id_comment, where it's in a trailing
+	// comment of the query.
+	QueryId string `protobuf:"bytes,43,opt,name=query_id,json=queryId" json:"query_id,omitempty"`
+	// version is the version of the format of the record. It's 0 for
+	// the records written before it was added.
+	Version int32 `protobuf:"varint,44,opt,name=version" json:"version,omitempty"`
+	// schema_validation_error is the error of the validation of the
+	// query against the schema, with -schema_pre_validation.
+	SchemaValidationError string `protobuf:"bytes,45,opt,name=schema_validation_error,json=schemaValidationError" json:"schema_validation_error,omitempty"`
+	// has_deadline is true if the context of the query had a deadline.
+	HasDeadline bool `protobuf:"varint,46,opt,name=has_deadline,json=hasDeadline" json:"has_deadline,omitempty"`
+}
+
+func (m *LogStats) Reset()                    { *m = LogStats{} }
+func (m *LogStats) String() string            { return proto.CompactTextString(m) }
+func (*LogStats) ProtoMessage()               {}
+func (*LogStats) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{1} }
+
+func (m *LogStats) GetStartTime() *logutil.Time {
+	if m != nil {
+		return m.StartTime
+	}
+	return nil
+}
+
+func (m *LogStats) GetEndTime() *logutil.Time {
+	if m != nil {
+		return m.EndTime
+	}
+	return nil
+}
+
+func (m *LogStats) GetBindVariables() map[string]*query.BindVariable {
+	if m != nil {
+		return m.BindVariables
+	}
+	return nil
+}
+
+func (m *LogStats) GetTableHits() map[string]int64 {
+	if m != nil {
+		return m.TableHits
+	}
+	return nil
+}
+
+func (m *LogStats) GetPerfSchema() *PerfSchemaStats {
+	if m != nil {
+		return m.PerfSchema
+	}
+	return nil
+}
+
+func (m *LogStats) GetDedupSummary() *DedupSummary {
+	if m != nil {
+		return m.DedupSummary
+	}
+	return nil
+}
+
+// DedupSummary counts the duplicates of a query, by plan type and
+// SQL, that were not logged during an interval because of
+// -querylog-dedup-limit.
+type DedupSummary struct {
+	Suppressed int64 `protobuf:"varint,1,opt,name=suppressed" json:"suppressed,omitempty"`
+	// total_time is the sum of the durations of the suppressed queries.
+	TotalTime int64         `protobuf:"varint,2,opt,name=total_time,json=totalTime" json:"total_time,omitempty"`
+	StartTime *logutil.Time `protobuf:"bytes,3,opt,name=start_time,json=startTime" json:"start_time,omitempty"`
+	EndTime   *logutil.Time `protobuf:"bytes,4,opt,name=end_time,json=endTime" json:"end_time,omitempty"`
+}
+
+func (m *DedupSummary) Reset()                    { *m = DedupSummary{} }
+func (m *DedupSummary) String() string            { return proto.CompactTextString(m) }
+func (*DedupSummary) ProtoMessage()               {}
+func (*DedupSummary) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{2} }
+
+func (m *DedupSummary) GetStartTime() *logutil.Time {
+	if m != nil {
+		return m.StartTime
+	}
+	return nil
+}
+
+func (m *DedupSummary) GetEndTime() *logutil.Time {
+	if m != nil {
+		return m.EndTime
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterType((*PerfSchemaStats)(nil), "querylog.PerfSchemaStats")
+	proto.RegisterType((*LogStats)(nil), "querylog.LogStats")
+	proto.RegisterType((*DedupSummary)(nil), "querylog.DedupSummary")
+}
+
+var fileDescriptor0 = []byte{
+	// 1245 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x94, 0x56, 0x6b, 0x73, 0x13, 0x37,
+	0x17, 0x9e, 0xc4, 0x84, 0xc4, 0x72, 0x9c, 0x8b, 0x12, 0x82, 0x08, 0x2f, 0x60, 0xc2, 0x0b, 0x38,
+	0x5c, 0xc2, 0x4c, 0x7a, 0x99, 0x0e, 0xed, 0x87, 0x06, 0x08, 0xd3, 0x74, 0xe8, 0x40, 0xd7, 0x81,
+	0x7e, 0xd4, 0xc8, 0xab, 0x63, 0x5b, 0xcd, 0xae, 0xb4, 0x91, 0xb4, 0x49, 0xcc, 0xff, 0xe9, 0xf4,
+	0xa7, 0xf5, 0x6f, 0x74, 0x74, 0xb4, 0x6b, 0x3b, 0x29, 0xd3, 0x99, 0x7e, 0xdb, 0xf3, 0x3c, 0x8f,
+	0x34, 0x47, 0xe7, 0xba, 0x64, 0xe5, 0xb4, 0x04, 0x3b, 0xce, 0xcc, 0x70, 0xaf, 0xb0, 0xc6, 0x1b,
+	0xba, 0x54, 0xdb, 0xdb, 0xed, 0xcc, 0x0c, 0x4b, 0xaf, 0xb2, 0x48, 0x6c, 0xb7, 0x90, 0xa8, 0x8d,
+	0x33, 0x6f, 0x8b, 0x34, 0x1a, 0x3b, 0x7f, 0xce, 0x93, 0xd5, 0x0f, 0x60, 0x07, 0xbd, 0x74, 0x04,
+	0xb9, 0xe8, 0x79, 0xe1, 0x1d, 0x7d, 0x40, 0xda, 0xd6, 0x9c, 0x3b, 0x0e, 0x17, 0x22, 0x57, 0x1a,
+	0x24, 0x9b, 0xeb, 0xcc, 0x75, 0x1b, 0xc9, 0x72, 0x00, 0x0f, 0x2b, 0x8c, 0x7e, 0x43, 0x6e, 0xa6,
+	0x16, 0x84, 0x07, 0xc9, 0x7d, 0x5e, 0x70, 0xa9, 0xdc, 0x09, 0xf7, 0xa2, 0x9f, 0x81, 0x63, 0xf3,
+	0x28, 0xdf, 0xac, 0xe8, 0xe3, 0xbc, 0x78, 0xa3, 0xdc, 0xc9, 0x31, 0x72, 0xf4, 0x19, 0xa1, 0xb3,
+	0xc7, 0xaa, 0x13, 0x0d, 0x3c, 0xb1, 0x36, 0x3d, 0x51, 0xa9, 0xbb, 0x64, 0xcd, 0x41, 0x06, 0xa9,
+	0xe7, 0x83, 0x32, 0xcb, 0xf8, 0xef, 0x46, 0x69, 0x76, 0x0d, 0xb5, 0x2b, 0x11, 0x7f, 0x5b, 0x66,
+	0xd9, 0xcf, 0x46, 0x69, 0x7a, 0x8f, 0xb4, 0x2a, 0xa5, 0x4b, 0x85, 0x66, 0x0b, 0x28, 0x22, 0x11,
+	0xea, 0xa5, 0x42, 0xd3, 0xdb, 0xa4, 0xe9, 0x8c, 0xf5, 0x3c, 0x3c, 0x82, 0x5d, 0x47, 0x7a, 0x29,
+	0x00, 0x89, 0x39, 0x77, 0x74, 0x87, 0xb4, 0xb5, 0xe1, 0x4a, 0x4b, 0xb8, 0xe0, 0xa5, 0x03, 0xc9,
+	0x16, 0x51, 0xd0, 0xd2, 0xe6, 0x28, 0x60, 0x1f, 0x1d, 0xc8, 0x9d, 0xbf, 0x56, 0xc9, 0xd2, 0x3b,
+	0x33, 0x8c, 0x21, 0xda, 0x22, 0xd7, 0x73, 0xf0, 0x23, 0x13, 0x63, 0xd3, 0x4c, 0x2a, 0x2b, 0xb8,
+	0x61, 0x21, 0x37, 0x1e, 0xb8, 0x90, 0xd2, 0x62, 0x24, 0x9a, 0x09, 0x89, 0xd0, 0x81, 0x94, 0x96,
+	0x6e, 0x93, 0xa5, 0xd2, 0x81, 0xd5, 0x22, 0x07, 0x7c, 0x75, 0x33, 0x99, 0xd8, 0x74, 0x97, 0xac,
+	0xa9, 0x3c, 0x07, 0xa9, 0x84, 0x07, 0x9e, 0x8a, 0x2c, 0x03, 0x8b, 0xaf, 0x6d, 0x26, 0xab, 0x13,
+	0xfc, 0x35, 0xc2, 0x41, 0x0a, 0x83, 0x01, 0xa4, 0x5e, 0x9d, 0x4d, 0xa4, 0x0b, 0x51, 0x3a, 0xc1,
+	0x2b, 0xe9, 0x33, 0x42, 0x9c, 0x17, 0xd6, 0x73, 0xaf, 0x72, 0xc0, 0x97, 0xb7, 0xf6, 0xdb, 0x7b,
+	0x75, 0x7d, 0x1c, 0xab, 0x1c, 0x92, 0x26, 0x0a, 0xc2, 0x27, 0xed, 0x92, 0x25, 0xd0, 0x32, 0x6a,
+	0x17, 0xbf, 0xa4, 0x5d, 0x04, 0x2d, 0x51, 0x79, 0x87, 0x10, 0x6f, 0xbc, 0xc8, 0xa2, 0x76, 0x09,
+	0x03, 0xd6, 0x44, 0x04, 0xe9, 0xdb, 0xa4, 0x59, 0x64, 0x42, 0x73, 0x3f, 0x2e, 0x80, 0x35, 0xe3,
+	0x4b, 0x03, 0x70, 0x3c, 0x2e, 0x80, 0xde, 0x27, 0xcb, 0xc6, 0xaa, 0xa1, 0xd2, 0x22, 0xe3, 0xee,
+	0x34, 0x63, 0x04, 0xf9, 0x56, 0x8d, 0xf5, 0x4e, 0x33, 0xfa, 0x8e, 0xac, 0xf4, 0x95, 0x96, 0xfc,
+	0x4c, 0x58, 0x15, 0x8b, 0xa4, 0xd5, 0x69, 0x74, 0x5b, 0xfb, 0x0f, 0xf7, 0x26, 0x45, 0x5f, 0x67,
+	0x63, 0xef, 0x95, 0xd2, 0xf2, 0x53, 0xad, 0x3b, 0xd4, 0xde, 0x8e, 0x93, 0x76, 0x7f, 0x16, 0xa3,
+	0x4f, 0xc8, 0xba, 0x2e, 0xf3, 0x3e, 0x58, 0x6e, 0x06, 0x3c, 0x5c, 0xa0, 0xc0, 0xb1, 0x65, 0xf4,
+	0x79, 0x35, 0x12, 0xef, 0x07, 0xbf, 0x46, 0x18, 0xcb, 0x1f, 0xce, 0xad, 0xf2, 0x1e, 0x34, 0x7a,
+	0xd7, 0xee, 0x34, 0xba, 0xcd, 0x64, 0x79, 0x02, 0x06, 0xf7, 0xf6, 0xc8, 0xc6, 0x25, 0x11, 0x46,
+	0xc1, 0xb1, 0x95, 0x4e, 0xa3, 0xdb, 0x48, 0xd6, 0x67, 0xa5, 0x21, 0x1a, 0x78, 0x29, 0xfa, 0xcd,
+	0x9d, 0x29, 0x6d, 0x0a, 0x8e, 0xad, 0xc6, 0x4b, 0x11, 0xec, 0x45, 0x2c, 0x5c, 0x9a, 0x8f, 0xc3,
+	0x65, 0x16, 0x5c, 0x61, 0xb4, 0x83, 0x18, 0xdb, 0x35, 0xf4, 0x73, 0x1d, 0xa9, 0xa4, 0x62, 0x30,
+	0xc6, 0x5f, 0x93, 0xad, 0x73, 0xa1, 0xbc, 0xd2, 0x43, 0x3e, 0x30, 0x96, 0xa7, 0x46, 0xeb, 0x90,
+	0x7a, 0xa3, 0xd9, 0x7a, 0x6c, 0xc1, 0x8a, 0x7d, 0x6b, 0xec, 0xeb, 0x09, 0x37, 0x69, 0x6f, 0x81,
+	0x85, 0x02, 0x92, 0xd1, 0x69, 0x7b, 0x1f, 0x54, 0x18, 0x76, 0x9e, 0xfa, 0x0c, 0x21, 0x5c, 0xb5,
+	0x33, 0x6c, 0xa3, 0xea, 0x3c, 0xf5, 0x19, 0xde, 0x0f, 0x6a, 0x47, 0xe8, 0x23, 0xb2, 0x3a, 0x55,
+	0x9e, 0x96, 0xe0, 0x3c, 0xdb, 0x44, 0x61, 0xbb, 0x16, 0x22, 0x18, 0xea, 0x25, 0x15, 0xe9, 0x08,
+	0xf8, 0x48, 0x79, 0xc7, 0x6e, 0xc4, 0x7a, 0x41, 0xe4, 0x27, 0xe5, 0x5d, 0x28, 0x89, 0x48, 0xe7,
+	0xca, 0x39, 0x70, 0x6c, 0x2b, 0x76, 0x20, 0x62, 0xbf, 0x20, 0x34, 0x95, 0x88, 0xbe, 0x03, 0xed,
+	0xd9, 0xcd, 0x19, 0xc9, 0x01, 0x42, 0xf4, 0x05, 0xd9, 0x88, 0x12, 0xa5, 0xcf, 0x44, 0xa6, 0xa4,
+	0x08, 0x2f, 0x76, 0x8c, 0xa1, 0x92, 0x22, 0x75, 0x34, 0xcb, 0xd0, 0x87, 0x64, 0xc5, 0x5b, 0xa1,
+	0x9d, 0xc0, 0xd8, 0x70, 0x25, 0xd9, 0xad, 0xe8, 0xfc, 0x0c, 0x7a, 0x24, 0xe9, 0x26, 0x59, 0x00,
+	0x6b, 0x8d, 0x65, 0xdb, 0x58, 0xa9, 0xd1, 0xa0, 0x2f, 0x08, 0xc1, 0x0f, 0x9e, 0x1a, 0x09, 0xec,
+	0x76, 0x67, 0xae, 0xbb, 0xb2, 0xbf, 0xb6, 0x17, 0xc7, 0xeb, 0x61, 0x20, 0x5e, 0x1b, 0x09, 0x49,
+	0x13, 0xea, 0xcf, 0x30, 0x1e, 0x62, 0x82, 0xc1, 0x5a, 0x6d, 0xd8, 0xff, 0xe2, 0x94, 0x42, 0xe8,
+	0x30, 0x20, 0x53, 0x81, 0xf3, 0xc2, 0x03, 0xbb, 0x13, 0xe7, 0x07, 0x42, 0xa1, 0xd4, 0x81, 0x76,
+	0x48, 0x6b, 0xa0, 0xf4, 0x10, 0x6c, 0x61, 0x95, 0xf6, 0xec, 0x6e, 0x6c, 0x9c, 0x19, 0x88, 0xfe,
+	0x48, 0x08, 0x4e, 0xd5, 0x18, 0xe7, 0x7b, 0xd8, 0x34, 0xf7, 0xbf, 0xd0, 0x34, 0x38, 0x62, 0x43,
+	0xe8, 0x63, 0xc3, 0x34, 0x7d, 0x6d, 0xd3, 0xa7, 0x64, 0x5d, 0x82, 0x90, 0x99, 0xd2, 0xc0, 0xe1,
+	0x22, 0x05, 0x90, 0x20, 0x59, 0xa7, 0x33, 0xd7, 0x5d, 0x4a, 0xd6, 0x6a, 0xe2, 0xb0, 0xc2, 0xc3,
+	0x40, 0x77, 0x90, 0x2b, 0xee, 0xc6, 0x3a, 0xe5, 0x03, 0x91, 0x65, 0x7d, 0x91, 0x9e, 0xb0, 0xfb,
+	0x51, 0x1d, 0x98, 0xde, 0x58, 0xa7, 0x6f, 0x2b, 0x9c, 0xbe, 0x24, 0xad, 0x02, 0xec, 0x80, 0x3b,
+	0x5c, 0x37, 0x6c, 0x07, 0x27, 0xcc, 0xad, 0xa9, 0x77, 0x57, 0x56, 0x51, 0x42, 0x8a, 0x09, 0x10,
+	0x46, 0xe7, 0x09, 0x8c, 0x5d, 0x21, 0x52, 0x60, 0x0f, 0xe2, 0x40, 0xa9, 0xed, 0x90, 0x1f, 0x37,
+	0x12, 0x56, 0xb2, 0xff, 0xc7, 0xfc, 0xa0, 0x11, 0x0a, 0x06, 0x5f, 0xe5, 0xb9, 0xc8, 0x94, 0x70,
+	0xec, 0x61, 0x8c, 0x56, 0xc4, 0x0e, 0x02, 0x44, 0xbf, 0x27, 0x6d, 0x09, 0xb2, 0x2c, 0xb8, 0x2b,
+	0xf3, 0x5c, 0xd8, 0x31, 0x7b, 0x84, 0x2e, 0x6d, 0x4d, 0x5d, 0x7a, 0x13, 0xe8, 0x5e, 0x64, 0x93,
+	0x65, 0x39, 0x63, 0xd1, 0xe7, 0x64, 0x23, 0xf4, 0x1c, 0x2f, 0x8c, 0xc9, 0x78, 0xe8, 0xb5, 0xd8,
+	0xaf, 0x8f, 0xab, 0x6d, 0x66, 0xb4, 0xfe, 0x60, 0x4c, 0xf6, 0x9b, 0x50, 0x71, 0xb6, 0xee, 0x92,
+	0x75, 0x7f, 0x71, 0x55, 0xdc, 0x8d, 0x4d, 0xe5, 0x2f, 0x2e, 0x49, 0x1f, 0x93, 0xd5, 0x49, 0x0a,
+	0xfa, 0xa5, 0x1c, 0x82, 0x67, 0xbb, 0x51, 0x58, 0xc3, 0xaf, 0x10, 0xa5, 0xcf, 0x09, 0x9d, 0x08,
+	0x2d, 0xe4, 0x42, 0x69, 0xa5, 0x87, 0xec, 0x49, 0x9c, 0x18, 0x35, 0x93, 0xd4, 0x04, 0xbd, 0x45,
+	0xe2, 0x3f, 0x42, 0x28, 0xf4, 0xa7, 0x18, 0x8d, 0x45, 0xb4, 0x8f, 0x24, 0x65, 0x64, 0xf1, 0x0c,
+	0xac, 0x0b, 0xd3, 0xe3, 0x59, 0x67, 0xae, 0xbb, 0x90, 0xd4, 0x26, 0xfd, 0x96, 0xdc, 0x8c, 0xf9,
+	0xe2, 0xd3, 0xce, 0xe1, 0xb1, 0x1d, 0x9e, 0xe3, 0x1d, 0x37, 0x22, 0xfd, 0x69, 0xc2, 0x62, 0xf1,
+	0x87, 0xf0, 0x8f, 0x84, 0xe3, 0xb5, 0x17, 0x6c, 0x0f, 0x8b, 0xa2, 0x35, 0x12, 0xee, 0x4d, 0x05,
+	0x6d, 0x7f, 0x24, 0xf4, 0x9f, 0xc3, 0x9b, 0xae, 0x91, 0xc6, 0x09, 0x8c, 0xab, 0xd5, 0x1a, 0x3e,
+	0xe9, 0x2e, 0x59, 0x38, 0x13, 0x59, 0x09, 0xb8, 0x51, 0x5b, 0xfb, 0x1b, 0x31, 0x3d, 0x97, 0x06,
+	0x7f, 0x12, 0x15, 0x2f, 0xe7, 0xbf, 0x9b, 0xdb, 0xfe, 0x81, 0xac, 0x5c, 0x2e, 0xef, 0x2f, 0x5c,
+	0xb9, 0x39, 0x7b, 0x65, 0x63, 0xe6, 0xf4, 0xce, 0x1f, 0x73, 0x64, 0x79, 0x36, 0xeb, 0xf4, 0x2e,
+	0x21, 0xae, 0x2c, 0x0a, 0x0b, 0xce, 0x4d, 0xfe, 0x86, 0x66, 0x90, 0x2b, 0xab, 0x70, 0xfe, 0xea,
+	0x2a, 0xbc, 0xbc, 0x81, 0x1b, 0xff, 0x61, 0x03, 0x5f, 0xfb, 0xb7, 0x0d, 0xdc, 0xbf, 0x8e, 0xbf,
+	0x70, 0x5f, 0xfd, 0x3d, 0x00, 0x34, 0xb3, 0x4d, 0x61, 0x07, 0x0a, 0x00, 0x00,
+}
@@ -73,6 +73,24 @@ type QueryClient interface {
 	// StreamHealth runs a streaming RPC to the tablet, that returns the
 	// current health of the tablet on a regular basis.
 	StreamHealth(ctx context.Context, in *query.StreamHealthRequest, opts ...grpc.CallOption) (Query_StreamHealthClient, error)
+	// WaitForGTID waits until the tablet has replicated up to a given
+	// replication position.
+	WaitForGTID(ctx context.Context, in *query.WaitForGTIDRequest, opts ...grpc.CallOption) (*query.WaitForGTIDResponse, error)
+	// XAStart starts a MySQL XA transaction with the given xid.
+	XAStart(ctx context.Context, in *query.XAStartRequest, opts ...grpc.CallOption) (*query.XAStartResponse, error)
+	// XAPrepare ends and prepares an XA transaction, the first phase of
+	// a two-phase commit.
+	XAPrepare(ctx context.Context, in *query.XAPrepareRequest, opts ...grpc.CallOption) (*query.XAPrepareResponse, error)
+	// XACommit commits an XA transaction. A transaction that wasn't
+	// prepared is committed in one phase.
+	XACommit(ctx context.Context, in *query.XACommitRequest, opts ...grpc.CallOption) (*query.XACommitResponse, error)
+	// XARollback rolls back an XA transaction.
+	XARollback(ctx context.Context, in *query.XARollbackRequest, opts ...grpc.CallOption) (*query.XARollbackResponse, error)
+	// XARecover returns the xids of the prepared XA transactions.
+	XARecover(ctx context.Context, in *query.XARecoverRequest, opts ...grpc.CallOption) (*query.XARecoverResponse, error)
+	// HotQueries returns the most executed SELECT queries of the tablet,
+	// for the replicas to warm their buffer pool with.
+	HotQueries(ctx context.Context, in *query.HotQueriesRequest, opts ...grpc.CallOption) (*query.HotQueriesResponse, error)
 }
 
 type queryClient struct {
@@ -228,6 +246,69 @@ func (x *queryStreamHealthClient) Recv() (*query.StreamHealthResponse, error) {
 	return m, nil
 }
 
+func (c *queryClient) WaitForGTID(ctx context.Context, in *query.WaitForGTIDRequest, opts ...grpc.CallOption) (*query.WaitForGTIDResponse, error) {
+	out := new(query.WaitForGTIDResponse)
+	err := grpc.Invoke(ctx, "/queryservice.Query/WaitForGTID", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) XAStart(ctx context.Context, in *query.XAStartRequest, opts ...grpc.CallOption) (*query.XAStartResponse, error) {
+	out := new(query.XAStartResponse)
+	err := grpc.Invoke(ctx, "/queryservice.Query/XAStart", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) XAPrepare(ctx context.Context, in *query.XAPrepareRequest, opts ...grpc.CallOption) (*query.XAPrepareResponse, error) {
+	out := new(query.XAPrepareResponse)
+	err := grpc.Invoke(ctx, "/queryservice.Query/XAPrepare", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) XACommit(ctx context.Context, in *query.XACommitRequest, opts ...grpc.CallOption) (*query.XACommitResponse, error) {
+	out := new(query.XACommitResponse)
+	err := grpc.Invoke(ctx, "/queryservice.Query/XACommit", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) XARollback(ctx context.Context, in *query.XARollbackRequest, opts ...grpc.CallOption) (*query.XARollbackResponse, error) {
+	out := new(query.XARollbackResponse)
+	err := grpc.Invoke(ctx, "/queryservice.Query/XARollback", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) XARecover(ctx context.Context, in *query.XARecoverRequest, opts ...grpc.CallOption) (*query.XARecoverResponse, error) {
+	out := new(query.XARecoverResponse)
+	err := grpc.Invoke(ctx, "/queryservice.Query/XARecover", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *queryClient) HotQueries(ctx context.Context, in *query.HotQueriesRequest, opts ...grpc.CallOption) (*query.HotQueriesResponse, error) {
+	out := new(query.HotQueriesResponse)
+	err := grpc.Invoke(ctx, "/queryservice.Query/HotQueries", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Query service
 
 type QueryServer interface {
@@ -262,6 +343,24 @@ type QueryServer interface {
 	// StreamHealth runs a streaming RPC to the tablet, that returns the
 	// current health of the tablet on a regular basis.
 	StreamHealth(*query.StreamHealthRequest, Query_StreamHealthServer) error
+	// WaitForGTID waits until the tablet has replicated up to a given
+	// replication position.
+	WaitForGTID(context.Context, *query.WaitForGTIDRequest) (*query.WaitForGTIDResponse, error)
+	// XAStart starts a MySQL XA transaction with the given xid.
+	XAStart(context.Context, *query.XAStartRequest) (*query.XAStartResponse, error)
+	// XAPrepare ends and prepares an XA transaction, the first phase of
+	// a two-phase commit.
+	XAPrepare(context.Context, *query.XAPrepareRequest) (*query.XAPrepareResponse, error)
+	// XACommit commits an XA transaction. A transaction that wasn't
+	// prepared is committed in one phase.
+	XACommit(context.Context, *query.XACommitRequest) (*query.XACommitResponse, error)
+	// XARollback rolls back an XA transaction.
+	XARollback(context.Context, *query.XARollbackRequest) (*query.XARollbackResponse, error)
+	// XARecover returns the xids of the prepared XA transactions.
+	XARecover(context.Context, *query.XARecoverRequest) (*query.XARecoverResponse, error)
+	// HotQueries returns the most executed SELECT queries of the tablet,
+	// for the replicas to warm their buffer pool with.
+	HotQueries(context.Context, *query.HotQueriesRequest) (*query.HotQueriesResponse, error)
 }
 
 func RegisterQueryServer(s *grpc.Server, srv QueryServer) {
@@ -472,6 +571,132 @@ func (x *queryStreamHealthServer) Send(m *query.StreamHealthResponse) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _Query_WaitForGTID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(query.WaitForGTIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).WaitForGTID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/queryservice.Query/WaitForGTID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).WaitForGTID(ctx, req.(*query.WaitForGTIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_XAStart_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(query.XAStartRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).XAStart(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/queryservice.Query/XAStart",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).XAStart(ctx, req.(*query.XAStartRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_XAPrepare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(query.XAPrepareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).XAPrepare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/queryservice.Query/XAPrepare",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).XAPrepare(ctx, req.(*query.XAPrepareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_XACommit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(query.XACommitRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).XACommit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/queryservice.Query/XACommit",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).XACommit(ctx, req.(*query.XACommitRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_XARollback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(query.XARollbackRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).XARollback(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/queryservice.Query/XARollback",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).XARollback(ctx, req.(*query.XARollbackRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_XARecover_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(query.XARecoverRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).XARecover(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/queryservice.Query/XARecover",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).XARecover(ctx, req.(*query.XARecoverRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Query_HotQueries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(query.HotQueriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QueryServer).HotQueries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/queryservice.Query/HotQueries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QueryServer).HotQueries(ctx, req.(*query.HotQueriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Query_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "queryservice.Query",
 	HandlerType: (*QueryServer)(nil),
@@ -512,6 +737,34 @@ var _Query_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SplitQuery",
 			Handler:    _Query_SplitQuery_Handler,
 		},
+		{
+			MethodName: "WaitForGTID",
+			Handler:    _Query_WaitForGTID_Handler,
+		},
+		{
+			MethodName: "XAStart",
+			Handler:    _Query_XAStart_Handler,
+		},
+		{
+			MethodName: "XAPrepare",
+			Handler:    _Query_XAPrepare_Handler,
+		},
+		{
+			MethodName: "XACommit",
+			Handler:    _Query_XACommit_Handler,
+		},
+		{
+			MethodName: "XARollback",
+			Handler:    _Query_XARollback_Handler,
+		},
+		{
+			MethodName: "XARecover",
+			Handler:    _Query_XARecover_Handler,
+		},
+		{
+			MethodName: "HotQueries",
+			Handler:    _Query_HotQueries_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -528,25 +781,32 @@ var _Query_serviceDesc = grpc.ServiceDesc{
 }
 
 var fileDescriptor0 = []byte{
-	// 313 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x74, 0x93, 0xcf, 0x4a, 0xc3, 0x40,
-	0x10, 0xc6, 0xf5, 0xd0, 0x28, 0x63, 0x3c, 0x38, 0x5a, 0xff, 0xa4, 0x82, 0xe2, 0x03, 0x14, 0x51,
-	0x41, 0x10, 0xbc, 0xb4, 0x88, 0x16, 0x41, 0xb0, 0xb9, 0x78, 0x4d, 0xe3, 0xa0, 0xc1, 0x24, 0x1b,
-	0xb3, 0x5b, 0xd1, 0x97, 0xf0, 0x99, 0xad, 0xd9, 0xcc, 0x76, 0xb3, 0xae, 0xc7, 0xf9, 0x7d, 0x33,
-	0x1f, 0xdf, 0x64, 0x36, 0x80, 0xef, 0x73, 0xaa, 0xbf, 0x24, 0xd5, 0x1f, 0x59, 0x4a, 0xc3, 0xaa,
-	0x16, 0x4a, 0x60, 0x68, 0xb3, 0x68, 0xa3, 0xa9, 0xb4, 0x74, 0xf6, 0x1d, 0x40, 0xef, 0xf1, 0xb7,
-	0xc6, 0x09, 0x84, 0xb7, 0xa4, 0x62, 0x92, 0x32, 0x13, 0xe5, 0xe4, 0x19, 0xa3, 0xa1, 0xee, 0xb3,
-	0xe1, 0x94, 0x16, 0x4c, 0xaa, 0x68, 0xe0, 0xd5, 0x64, 0x25, 0x4a, 0x49, 0x27, 0x2b, 0x78, 0x05,
-	0x6b, 0x37, 0x9f, 0x94, 0xce, 0x15, 0x61, 0xbf, 0xed, 0x6c, 0x6b, 0x36, 0xd8, 0x75, 0xb1, 0x99,
-	0x5d, 0xc4, 0x68, 0xe1, 0x28, 0x51, 0xe9, 0xab, 0x89, 0x61, 0x43, 0x37, 0x46, 0x57, 0x33, 0x56,
-	0x0f, 0xb0, 0x19, 0xab, 0x9a, 0x92, 0x82, 0xc3, 0x70, 0x7f, 0x87, 0xb2, 0xd9, 0xa1, 0x5f, 0x64,
-	0xb7, 0xd3, 0x55, 0xbc, 0x80, 0xde, 0x88, 0x5e, 0xb2, 0x12, 0xb7, 0xdb, 0xd6, 0xa6, 0xe2, 0xf9,
-	0x9d, 0x2e, 0x34, 0x29, 0x2e, 0x21, 0x18, 0x8b, 0xa2, 0xc8, 0x14, 0x72, 0x87, 0x2e, 0x79, 0xae,
-	0xef, 0x50, 0x33, 0x78, 0x0d, 0xeb, 0x53, 0x91, 0xe7, 0xb3, 0x24, 0x7d, 0x43, 0xfe, 0x5e, 0x0c,
-	0x78, 0x78, 0xef, 0x0f, 0xb7, 0x3f, 0x64, 0x13, 0x85, 0x97, 0x8f, 0xec, 0x7c, 0xce, 0xee, 0x03,
-	0xaf, 0x66, 0xac, 0x9e, 0x60, 0xcb, 0x56, 0xf4, 0x61, 0x8e, 0x3c, 0x33, 0x9d, 0xeb, 0x1c, 0xff,
-	0xdf, 0x60, 0x9c, 0xc7, 0x00, 0x71, 0x95, 0x67, 0x4a, 0x3f, 0xc1, 0x7d, 0x3e, 0x81, 0x41, 0xec,
-	0x75, 0xe0, 0x51, 0x8c, 0xc9, 0x3d, 0x84, 0xfa, 0x68, 0x77, 0x94, 0xe4, 0x6a, 0xf9, 0x64, 0x6c,
-	0xe8, 0x6e, 0xda, 0xd5, 0x96, 0x47, 0x9e, 0x05, 0xcd, 0x7f, 0x71, 0xfe, 0x13, 0x00, 0x00, 0xff,
-	0xff, 0x80, 0xaa, 0x0c, 0xf7, 0x48, 0x03, 0x00, 0x00,
+	// 429 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x74, 0x94, 0x51, 0x6f, 0xda, 0x40,
+	0x0c, 0xc7, 0xb7, 0x07, 0xd8, 0x38, 0xb2, 0x87, 0xdd, 0xc6, 0x80, 0x30, 0x69, 0xd3, 0x3e, 0x00,
+	0x9a, 0xb6, 0x49, 0x93, 0x26, 0x4d, 0x5a, 0x60, 0x05, 0xa2, 0x4a, 0x55, 0x4b, 0x2a, 0x35, 0xaf,
+	0x21, 0xb5, 0xda, 0xa8, 0x81, 0x0b, 0x97, 0x03, 0xb5, 0x9f, 0xb6, 0x5f, 0xa5, 0x6a, 0x72, 0x76,
+	0x2e, 0x97, 0xf4, 0xd1, 0xbf, 0xbf, 0xfd, 0x8f, 0x8d, 0x7d, 0x30, 0xbe, 0x3f, 0x80, 0x7c, 0xc8,
+	0x41, 0x1e, 0x93, 0x18, 0xa6, 0x99, 0x14, 0x4a, 0x70, 0xc7, 0x64, 0x6e, 0xbf, 0x88, 0x4a, 0xe9,
+	0xc7, 0x63, 0x8f, 0x75, 0x2e, 0x9e, 0x63, 0xee, 0x33, 0x67, 0x09, 0x2a, 0x80, 0x3c, 0x4f, 0xc4,
+	0xce, 0xbf, 0xe6, 0xee, 0xb4, 0xcc, 0x33, 0xe1, 0x1a, 0xf6, 0x07, 0xc8, 0x95, 0x3b, 0x69, 0xd5,
+	0xf2, 0x4c, 0xec, 0x72, 0xf8, 0xf6, 0x8a, 0xff, 0x61, 0x6f, 0x4e, 0xee, 0x21, 0x3e, 0x28, 0xe0,
+	0x03, 0x9d, 0xa9, 0x63, 0x34, 0xf8, 0x64, 0x63, 0xaa, 0xf5, 0x99, 0xa3, 0xe1, 0x2c, 0x52, 0xf1,
+	0x2d, 0xb5, 0x61, 0x42, 0xbb, 0x8d, 0xba, 0x46, 0x56, 0x67, 0xec, 0x5d, 0xa0, 0x24, 0x44, 0x5b,
+	0x6c, 0x06, 0xf3, 0x6b, 0x14, 0xcd, 0x3e, 0xb7, 0x8b, 0xe8, 0xf6, 0xfd, 0x35, 0xff, 0xc5, 0x3a,
+	0x33, 0xb8, 0x49, 0x76, 0xfc, 0x83, 0x4e, 0x2d, 0x22, 0xac, 0xff, 0x58, 0x87, 0xd4, 0xc5, 0x6f,
+	0xd6, 0x9d, 0x8b, 0xed, 0x36, 0x51, 0x1c, 0x33, 0xca, 0x10, 0xeb, 0x06, 0x16, 0xa5, 0xc2, 0xbf,
+	0xec, 0xed, 0x5a, 0xa4, 0xe9, 0x26, 0x8a, 0xef, 0x38, 0xfe, 0x5e, 0x08, 0xb0, 0x78, 0xd8, 0xe0,
+	0xe6, 0x0f, 0x59, 0xb4, 0x82, 0xc3, 0xbb, 0x66, 0x7f, 0xd6, 0xec, 0x93, 0x56, 0x8d, 0xac, 0x42,
+	0xf6, 0xde, 0x54, 0xca, 0xc5, 0x7c, 0x69, 0xa9, 0xa9, 0x6d, 0xe7, 0xeb, 0xcb, 0x09, 0xe4, 0x3c,
+	0x67, 0x2c, 0xc8, 0xd2, 0x44, 0x95, 0x27, 0x38, 0xc2, 0x15, 0x10, 0x42, 0xaf, 0x71, 0x8b, 0x42,
+	0x26, 0xa7, 0xcc, 0x29, 0x97, 0xb6, 0x82, 0x28, 0x55, 0xd5, 0xc9, 0x98, 0xd0, 0x9e, 0xb4, 0xae,
+	0x19, 0x4b, 0x5e, 0xb0, 0xfe, 0x55, 0x94, 0xa8, 0x85, 0x90, 0xcb, 0x4b, 0xff, 0x3f, 0xc7, 0x0f,
+	0x1b, 0x0c, 0xad, 0xdc, 0x36, 0xc9, 0x7c, 0x03, 0xa1, 0x17, 0xa8, 0x48, 0x2a, 0x7a, 0x03, 0x3a,
+	0xb6, 0xdf, 0x00, 0x61, 0xaa, 0xfd, 0xc7, 0x7a, 0xa1, 0x77, 0x2e, 0x21, 0x8b, 0x24, 0xf0, 0x21,
+	0xa5, 0x69, 0x82, 0xf5, 0xa3, 0xa6, 0x60, 0xde, 0x4e, 0xe8, 0xe9, 0xb3, 0xab, 0xbe, 0x53, 0x3f,
+	0xbc, 0x61, 0x83, 0x9b, 0x6b, 0x09, 0x3d, 0x3a, 0xbe, 0xea, 0x43, 0xf6, 0xf9, 0x8d, 0x5b, 0x94,
+	0xfa, 0x14, 0x6b, 0x88, 0xc5, 0x11, 0xa4, 0x31, 0x85, 0x26, 0xcd, 0x29, 0x48, 0x30, 0xdb, 0x58,
+	0x89, 0x62, 0xdd, 0x09, 0xe4, 0xd4, 0x46, 0x85, 0xec, 0x36, 0x4c, 0x05, 0x4d, 0x36, 0xdd, 0xe2,
+	0x8f, 0xee, 0xe7, 0xd3, 0x00, 0xf5, 0xaa, 0x9b, 0x2a, 0x19, 0x05, 0x00, 0x00,
 }
@@ -95,6 +95,8 @@ It has these top-level messages:
 	PromoteSlaveResponse
 	BackupRequest
 	BackupResponse
+	WarmQueriesRequest
+	WarmQueriesResponse
 */
 package tabletmanagerdata
 
@@ -1111,6 +1113,22 @@ func (m *BackupResponse) GetEvent() *logutil.Event {
 	return nil
 }
 
+type WarmQueriesRequest struct {
+}
+
+func (m *WarmQueriesRequest) Reset()                    { *m = WarmQueriesRequest{} }
+func (m *WarmQueriesRequest) String() string            { return proto.CompactTextString(m) }
+func (*WarmQueriesRequest) ProtoMessage()               {}
+func (*WarmQueriesRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{86} }
+
+type WarmQueriesResponse struct {
+}
+
+func (m *WarmQueriesResponse) Reset()                    { *m = WarmQueriesResponse{} }
+func (m *WarmQueriesResponse) String() string            { return proto.CompactTextString(m) }
+func (*WarmQueriesResponse) ProtoMessage()               {}
+func (*WarmQueriesResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{87} }
+
 func init() {
 	proto.RegisterType((*TableDefinition)(nil), "tabletmanagerdata.TableDefinition")
 	proto.RegisterType((*SchemaDefinition)(nil), "tabletmanagerdata.SchemaDefinition")
@@ -1198,130 +1216,133 @@ func init() {
 	proto.RegisterType((*PromoteSlaveResponse)(nil), "tabletmanagerdata.PromoteSlaveResponse")
 	proto.RegisterType((*BackupRequest)(nil), "tabletmanagerdata.BackupRequest")
 	proto.RegisterType((*BackupResponse)(nil), "tabletmanagerdata.BackupResponse")
+	proto.RegisterType((*WarmQueriesRequest)(nil), "tabletmanagerdata.WarmQueriesRequest")
+	proto.RegisterType((*WarmQueriesResponse)(nil), "tabletmanagerdata.WarmQueriesResponse")
 }
 
 var fileDescriptor0 = []byte{
-	// 1946 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xcc, 0x58, 0x5b, 0x6f, 0xdb, 0xc8,
-	0x15, 0x86, 0x6c, 0xc7, 0x71, 0x0e, 0x25, 0x59, 0xa2, 0x6f, 0x8a, 0x17, 0x48, 0x1c, 0x66, 0xdb,
-	0x4d, 0x53, 0xd4, 0xbb, 0xf1, 0x6e, 0x8b, 0x45, 0x17, 0x5b, 0xd4, 0xb1, 0x9d, 0x4d, 0xf6, 0x92,
-	0x78, 0x99, 0x5b, 0xd1, 0x17, 0x62, 0x44, 0x8e, 0x25, 0x22, 0x14, 0xc9, 0xe5, 0x0c, 0x15, 0x0b,
-	0x28, 0xfa, 0x2f, 0xfa, 0xd6, 0xb7, 0x02, 0xed, 0x43, 0xdf, 0xfa, 0x63, 0x5a, 0xf4, 0x97, 0xf4,
-	0xa1, 0x2f, 0x3d, 0x73, 0x93, 0x86, 0x92, 0x1c, 0x2b, 0x69, 0x0a, 0xf4, 0x45, 0xe0, 0x7c, 0x73,
-	0xe6, 0xdc, 0xe7, 0x9c, 0x33, 0x82, 0x1d, 0x4e, 0xba, 0x09, 0xe5, 0x03, 0x92, 0x92, 0x1e, 0x2d,
-	0x22, 0xc2, 0xc9, 0x7e, 0x5e, 0x64, 0x3c, 0x73, 0xdb, 0x33, 0x1b, 0xbb, 0xce, 0x0f, 0x25, 0x2d,
-	0x46, 0x6a, 0x7f, 0xb7, 0xc9, 0xb3, 0x3c, 0x9b, 0xd0, 0xef, 0x6e, 0x15, 0x34, 0x4f, 0xe2, 0x90,
-	0xf0, 0x38, 0x4b, 0x2d, 0xb8, 0x91, 0x64, 0xbd, 0x92, 0xc7, 0x89, 0x5a, 0x7a, 0xff, 0xac, 0xc1,
-	0xfa, 0x33, 0xc1, 0xf8, 0x98, 0x9e, 0xc5, 0x69, 0x2c, 0x88, 0x5d, 0x17, 0x56, 0x52, 0x32, 0xa0,
-	0x9d, 0xda, 0x5e, 0xed, 0xce, 0x35, 0x5f, 0x7e, 0xbb, 0xdb, 0xb0, 0xca, 0xc2, 0x3e, 0x1d, 0x90,
-	0xce, 0x92, 0x44, 0xf5, 0xca, 0xed, 0xc0, 0xd5, 0x30, 0x4b, 0xca, 0x41, 0xca, 0x3a, 0xcb, 0x7b,
-	0xcb, 0xb8, 0x61, 0x96, 0xee, 0x3e, 0x6c, 0xe4, 0x45, 0x3c, 0x20, 0xc5, 0x28, 0x78, 0x45, 0x47,
-	0x81, 0xa1, 0x5a, 0x91, 0x54, 0x6d, 0xbd, 0xf5, 0x0d, 0x1d, 0x1d, 0x69, 0x7a, 0x94, 0xca, 0x47,
-	0x39, 0xed, 0x5c, 0x51, 0x52, 0xc5, 0xb7, 0x7b, 0x13, 0x1c, 0xa1, 0x7a, 0x90, 0xd0, 0xb4, 0xc7,
-	0xfb, 0x9d, 0x55, 0xdc, 0x5a, 0xf1, 0x41, 0x40, 0xdf, 0x4a, 0xc4, 0xfd, 0x00, 0xae, 0x15, 0xd9,
-	0x6b, 0x64, 0x5e, 0xa6, 0xbc, 0x73, 0x55, 0x6e, 0xaf, 0x21, 0x70, 0x24, 0xd6, 0xde, 0x9f, 0x6b,
-	0xd0, 0x7a, 0x2a, 0xd5, 0xb4, 0x8c, 0xfb, 0x08, 0xd6, 0xc5, 0xf9, 0x2e, 0x61, 0x34, 0xd0, 0x16,
-	0x29, 0x3b, 0x9b, 0x06, 0x56, 0x47, 0xdc, 0x27, 0xa0, 0x3c, 0x1e, 0x44, 0xe3, 0xc3, 0x0c, 0x8d,
-	0x5f, 0xbe, 0xe3, 0x1c, 0x78, 0xfb, 0xb3, 0x41, 0x9a, 0x72, 0xa2, 0xdf, 0xe2, 0x55, 0x80, 0x09,
-	0x57, 0x0d, 0x69, 0xc1, 0xf0, 0x1b, 0x5d, 0x25, 0x24, 0x9a, 0xa5, 0xf7, 0xaf, 0x1a, 0x34, 0x9f,
-	0x33, 0x5a, 0x9c, 0xd2, 0x62, 0x10, 0x33, 0xa6, 0x63, 0xd0, 0xcf, 0x18, 0x37, 0x31, 0x10, 0xdf,
-	0x02, 0x2b, 0x91, 0x4a, 0x47, 0x40, 0x7e, 0xbb, 0x3f, 0x85, 0x76, 0x4e, 0x18, 0x7b, 0x9d, 0x15,
-	0x51, 0x80, 0x7a, 0x87, 0xaf, 0x58, 0x39, 0x90, 0xec, 0x57, 0xfc, 0x96, 0xd9, 0x38, 0xd2, 0xb8,
-	0xfb, 0x3d, 0x00, 0xfa, 0x7d, 0x18, 0x27, 0xb4, 0x47, 0x55, 0x24, 0x9c, 0x83, 0x7b, 0x73, 0x6c,
-	0xa9, 0xea, 0xb2, 0x7f, 0x3a, 0x3e, 0x73, 0x92, 0xf2, 0x62, 0xe4, 0x5b, 0x4c, 0x76, 0xbf, 0x84,
-	0xf5, 0xa9, 0x6d, 0xb7, 0x05, 0xcb, 0x18, 0x70, 0xad, 0xb9, 0xf8, 0x74, 0x37, 0xe1, 0xca, 0x90,
-	0x24, 0x25, 0xd5, 0x9a, 0xab, 0xc5, 0x2f, 0x97, 0x3e, 0xaf, 0x79, 0x7f, 0xaf, 0x41, 0xfd, 0xb8,
-	0x7b, 0x89, 0xdd, 0x4d, 0x58, 0x8a, 0xba, 0xfa, 0x2c, 0x7e, 0x8d, 0xfd, 0xb0, 0x6c, 0xf9, 0xe1,
-	0xc9, 0x1c, 0xd3, 0x3e, 0x9e, 0x63, 0x9a, 0x2d, 0xec, 0x7f, 0x69, 0xd8, 0x9f, 0x6a, 0xe0, 0x4c,
-	0x24, 0x31, 0xf7, 0x5b, 0x68, 0x09, 0x3d, 0x83, 0x7c, 0x82, 0x21, 0x23, 0xa1, 0xe5, 0xad, 0x4b,
-	0x03, 0xe0, 0xaf, 0x97, 0x95, 0x35, 0x73, 0x1f, 0x40, 0x33, 0xea, 0x56, 0x78, 0xa9, 0xc4, 0xbc,
-	0x79, 0x89, 0xc5, 0x7e, 0x23, 0xb2, 0x56, 0xcc, 0xfb, 0x02, 0x9c, 0xfb, 0x49, 0x7e, 0x9a, 0x31,
-	0x75, 0x37, 0xd0, 0xc0, 0x32, 0x8e, 0xa4, 0x81, 0x0d, 0x5f, 0x7c, 0xba, 0xbb, 0xb0, 0x96, 0xeb,
-	0x5d, 0x6d, 0xe3, 0x78, 0xed, 0x7d, 0x84, 0x16, 0xc6, 0x69, 0xcf, 0xa7, 0x58, 0x85, 0x30, 0x4a,
-	0x98, 0xde, 0x39, 0x19, 0x25, 0x19, 0x89, 0xb4, 0x87, 0xcc, 0xd2, 0xbb, 0x03, 0x75, 0x45, 0xc8,
-	0x72, 0x14, 0x4a, 0xdf, 0x40, 0x79, 0x17, 0xea, 0x4f, 0x13, 0x4a, 0x73, 0xc3, 0x13, 0xc5, 0x47,
-	0x65, 0x21, 0x4b, 0x98, 0x24, 0x5d, 0xf6, 0xc7, 0x6b, 0x6f, 0x1d, 0x1a, 0x9a, 0x56, 0xb1, 0xf5,
-	0xfe, 0x51, 0x03, 0xf7, 0xe4, 0x9c, 0x86, 0x25, 0xa7, 0x0f, 0xb3, 0xec, 0x95, 0xe1, 0x31, 0xaf,
-	0x9a, 0xdd, 0xc0, 0x6c, 0x21, 0x05, 0x7e, 0x71, 0xbc, 0x81, 0xd2, 0x77, 0xd7, 0x7c, 0x0b, 0x71,
-	0x4f, 0xe1, 0x1a, 0x3d, 0xe7, 0x05, 0x09, 0x68, 0x3a, 0x94, 0x75, 0xcd, 0x39, 0xf8, 0x74, 0x8e,
-	0x6b, 0x67, 0xa5, 0x21, 0x84, 0xc7, 0x4e, 0xd2, 0xa1, 0x4a, 0xa8, 0x35, 0xaa, 0x97, 0xbb, 0x5f,
-	0x40, 0xa3, 0xb2, 0xf5, 0x56, 0xc9, 0x74, 0x06, 0x1b, 0x15, 0x51, 0xda, 0x8f, 0x58, 0x1d, 0xe9,
-	0x79, 0xcc, 0x03, 0xc6, 0x09, 0x2f, 0x99, 0x76, 0x10, 0x08, 0xe8, 0xa9, 0x44, 0x64, 0xd1, 0xe6,
-	0x51, 0x56, 0xf2, 0x71, 0xd1, 0x96, 0x2b, 0x8d, 0xd3, 0xc2, 0x5c, 0x21, 0xbd, 0xf2, 0x86, 0xd0,
-	0xfa, 0x8a, 0x72, 0x55, 0xff, 0x8c, 0xfb, 0x90, 0x56, 0x1a, 0xae, 0xd2, 0x15, 0x69, 0xd5, 0xca,
-	0xbd, 0x0d, 0x8d, 0x38, 0x0d, 0x93, 0x32, 0xa2, 0xc1, 0x30, 0xa6, 0xaf, 0x99, 0x14, 0xb1, 0xe6,
-	0xd7, 0x35, 0xf8, 0x42, 0x60, 0xee, 0x8f, 0xa0, 0x49, 0xcf, 0x15, 0x91, 0x66, 0xa2, 0x9a, 0x44,
-	0x43, 0xa3, 0xb2, 0x68, 0x32, 0x8f, 0x42, 0xdb, 0x92, 0xab, 0xad, 0x3b, 0x85, 0xb6, 0xaa, 0xcf,
-	0x56, 0x01, 0x96, 0x36, 0x3a, 0x07, 0xb7, 0xe7, 0xc4, 0x62, 0xba, 0xd0, 0xfb, 0x2d, 0x36, 0x85,
-	0x78, 0x3b, 0xb0, 0x85, 0x62, 0xac, 0xfc, 0xd7, 0x36, 0x7a, 0xbf, 0x85, 0xed, 0xe9, 0x0d, 0xad,
-	0xc4, 0xaf, 0xc1, 0xa9, 0xde, 0x58, 0x21, 0xfe, 0xc6, 0x1c, 0xf1, 0xf6, 0x61, 0xfb, 0x88, 0xb7,
-	0x09, 0xee, 0x53, 0xca, 0x7d, 0x4a, 0xa2, 0x27, 0x69, 0x32, 0x32, 0x12, 0xb7, 0x60, 0xa3, 0x82,
-	0xea, 0x14, 0x9e, 0xc0, 0x2f, 0x8b, 0x98, 0x53, 0x43, 0xbd, 0x0d, 0x9b, 0x55, 0x58, 0x93, 0x7f,
-	0x0d, 0xed, 0xa3, 0x3e, 0x49, 0x7b, 0xf4, 0x19, 0x36, 0x4b, 0x13, 0xb0, 0x9f, 0x83, 0xa3, 0xd4,
-	0x0b, 0x64, 0x3b, 0x15, 0x2a, 0x37, 0x0f, 0x36, 0xf7, 0xc7, 0xd3, 0x81, 0xf4, 0x39, 0x97, 0x27,
-	0x80, 0x8f, 0xbf, 0x85, 0x9e, 0x36, 0xaf, 0x89, 0x42, 0x3e, 0x3d, 0x2b, 0x28, 0xeb, 0x8b, 0x94,
-	0xb2, 0x15, 0xaa, 0xc2, 0x9a, 0xfc, 0x31, 0x6c, 0xf9, 0x65, 0xfa, 0x90, 0x92, 0x84, 0xf7, 0x65,
-	0xd7, 0xf9, 0x2f, 0x95, 0xea, 0xc0, 0xf6, 0x34, 0x3f, 0x2d, 0xe9, 0x33, 0xe8, 0x3c, 0xea, 0xa5,
-	0x59, 0x41, 0xd5, 0xe6, 0x49, 0x51, 0x64, 0x45, 0xa5, 0x12, 0x71, 0xbc, 0xc8, 0xe9, 0xa4, 0xbe,
-	0xc8, 0xa5, 0xf7, 0x01, 0x5c, 0x9f, 0x73, 0xca, 0xb6, 0x55, 0x94, 0xa1, 0xca, 0x05, 0x50, 0xb6,
-	0xda, 0xb0, 0x26, 0xff, 0x04, 0xb6, 0x4f, 0x0b, 0x7a, 0x96, 0xc4, 0xbd, 0xfe, 0xec, 0x95, 0x09,
-	0xa5, 0x2b, 0xb5, 0x78, 0xbd, 0xf2, 0xfe, 0x5a, 0x83, 0x9d, 0x99, 0x23, 0x3a, 0xd1, 0x1e, 0x42,
-	0xa3, 0x4b, 0xcf, 0x50, 0x33, 0x7b, 0x28, 0x59, 0x30, 0xd3, 0xeb, 0xea, 0xa4, 0x9e, 0x5b, 0x1e,
-	0x40, 0x9d, 0x9c, 0xa1, 0xb5, 0x81, 0x35, 0xaf, 0x2d, 0xc8, 0xc8, 0x91, 0x07, 0x15, 0xec, 0xfd,
-	0x1b, 0xcb, 0xe9, 0x61, 0x9e, 0x27, 0xa3, 0xaa, 0x71, 0x58, 0xb7, 0xd8, 0x0f, 0x89, 0xa9, 0x5b,
-	0xf8, 0x29, 0xea, 0x16, 0x8a, 0x0f, 0xa9, 0xae, 0x00, 0x6a, 0x21, 0x06, 0x13, 0x92, 0x24, 0x38,
-	0x9b, 0x59, 0x63, 0xa8, 0x2c, 0x37, 0x6b, 0x7e, 0x4b, 0x6e, 0xf8, 0x13, 0x7c, 0xd6, 0xfa, 0x95,
-	0xf7, 0x65, 0xfd, 0x95, 0x77, 0xb4, 0xfe, 0x2f, 0x35, 0xd8, 0xa8, 0x58, 0xff, 0x7f, 0x1b, 0xa7,
-	0xbf, 0xd5, 0xa0, 0xa3, 0xbb, 0xc3, 0x03, 0xca, 0xc3, 0xfe, 0x21, 0x3b, 0xee, 0x8e, 0xa3, 0x85,
-	0xb1, 0x91, 0x6f, 0x04, 0x1d, 0x2f, 0xb5, 0x70, 0x77, 0xe0, 0x2a, 0x8e, 0x0f, 0xb2, 0x2b, 0xea,
-	0xc6, 0x10, 0x75, 0x1f, 0x8b, 0xbe, 0x78, 0x1d, 0xd6, 0x06, 0xe4, 0x3c, 0xc0, 0x09, 0x9a, 0xe9,
-	0x21, 0xf2, 0x2a, 0xae, 0x7d, 0x5c, 0xca, 0xb9, 0x39, 0x66, 0x72, 0x20, 0xee, 0xc6, 0x29, 0x3e,
-	0x22, 0x98, 0x0c, 0xd2, 0x1a, 0xce, 0xcd, 0x0a, 0xbe, 0xaf, 0x50, 0xd1, 0x18, 0x0a, 0x79, 0x5f,
-	0xec, 0x10, 0x60, 0x63, 0x28, 0xac, 0x4b, 0xe4, 0x7d, 0x05, 0xd7, 0xe7, 0xe8, 0xac, 0x7d, 0x7c,
-	0x17, 0x56, 0xb1, 0xb4, 0x94, 0x09, 0xd7, 0xce, 0x75, 0xf7, 0xd5, 0x3b, 0xe7, 0x7b, 0xf1, 0xeb,
-	0xcb, 0x1d, 0x5f, 0x53, 0x78, 0xdf, 0x4c, 0x1b, 0x8f, 0x41, 0x7b, 0xb3, 0xf1, 0xb6, 0x8d, 0x4b,
-	0x15, 0x1b, 0x67, 0xb5, 0x92, 0xcc, 0xde, 0x41, 0x2b, 0x51, 0xf4, 0x13, 0x32, 0xa4, 0xaa, 0x0f,
-	0x9b, 0x4a, 0xf2, 0x00, 0xab, 0xbb, 0x8d, 0x6a, 0xc6, 0x1f, 0x8b, 0x6e, 0x3c, 0xee, 0xe0, 0xce,
-	0xc1, 0xce, 0xfe, 0xf4, 0xcb, 0x4d, 0x1f, 0xd0, 0x64, 0xa2, 0x8f, 0x7d, 0x47, 0x18, 0x66, 0x80,
-	0x19, 0xdc, 0x8c, 0x80, 0xcf, 0x60, 0x7b, 0x7a, 0x43, 0xcb, 0xb0, 0xe7, 0xb8, 0xda, 0xd4, 0x1c,
-	0xe7, 0xe2, 0x2b, 0x09, 0x0b, 0xb1, 0x54, 0xcd, 0x70, 0xda, 0x80, 0xb6, 0x85, 0xe9, 0x8a, 0xf7,
-	0x1b, 0xd8, 0x19, 0x83, 0xdf, 0x61, 0x2e, 0x0e, 0xca, 0x81, 0x35, 0xa8, 0x5d, 0xc4, 0xdf, 0xbd,
-	0x05, 0xf5, 0xd7, 0x04, 0xc7, 0x14, 0x1e, 0x0f, 0xa8, 0x99, 0x45, 0x96, 0x7d, 0x47, 0x60, 0xcf,
-	0x14, 0xe4, 0xfd, 0x02, 0x3a, 0xb3, 0x9c, 0x17, 0x50, 0x5d, 0xaa, 0x49, 0x0a, 0x5e, 0xd1, 0x5d,
-	0x38, 0xdf, 0x02, 0xb5, 0xf2, 0xc7, 0x70, 0x4b, 0x35, 0x19, 0x1c, 0xc3, 0xb0, 0x15, 0x60, 0x09,
-	0xc2, 0xa0, 0xe1, 0xc8, 0x47, 0x53, 0x4e, 0x23, 0x63, 0x86, 0x9c, 0xa8, 0xd4, 0x76, 0x10, 0x9b,
-	0xe9, 0x14, 0x0c, 0xf4, 0x28, 0xf2, 0x3e, 0x04, 0xef, 0x4d, 0x5c, 0xb4, 0xac, 0x3d, 0xb8, 0x31,
-	0x4d, 0x75, 0x92, 0xd0, 0x70, 0x22, 0xc8, 0xbb, 0x05, 0x37, 0x2f, 0xa4, 0xd0, 0x4c, 0x5c, 0x35,
-	0x8c, 0x09, 0x23, 0xc6, 0x19, 0xf4, 0x13, 0x35, 0x28, 0x69, 0x4c, 0x3b, 0x08, 0xd3, 0x9c, 0x44,
-	0x51, 0x61, 0x06, 0x34, 0xb5, 0xf0, 0x7e, 0x0f, 0xdb, 0x2f, 0xd1, 0xc3, 0xd6, 0x78, 0x6f, 0x8c,
-	0x3c, 0x84, 0x7a, 0x37, 0xc9, 0x83, 0x8a, 0x53, 0xe7, 0x0f, 0x35, 0xf6, 0x61, 0xa7, 0x6b, 0x3d,
-	0x14, 0x16, 0x08, 0xe9, 0x75, 0xd8, 0x99, 0x91, 0xaf, 0x2d, 0x6b, 0x41, 0x53, 0x44, 0x1b, 0xb7,
-	0x8c, 0x5d, 0x2f, 0x60, 0x7d, 0x8c, 0x68, 0xab, 0x8e, 0xb0, 0xd0, 0x5a, 0x5a, 0x9a, 0xd7, 0xd2,
-	0x65, 0x6a, 0xd6, 0x2d, 0x35, 0x99, 0xd7, 0x16, 0x7c, 0x31, 0x15, 0x2c, 0x51, 0x32, 0xdb, 0x0d,
-	0xa4, 0x15, 0xfa, 0x1d, 0xb8, 0x38, 0x66, 0x20, 0xf2, 0x3c, 0xe5, 0x71, 0x62, 0xfc, 0xf4, 0x3e,
-	0x34, 0x58, 0xc4, 0x53, 0xf7, 0x70, 0xee, 0xb0, 0xa5, 0x2f, 0x90, 0xf7, 0xe8, 0x5c, 0xa4, 0x13,
-	0x23, 0xe1, 0xb8, 0x50, 0x18, 0xfb, 0x76, 0xa1, 0x33, 0xbb, 0xa5, 0xed, 0xc4, 0xeb, 0xf2, 0x08,
-	0x5b, 0x88, 0xaa, 0x11, 0xe6, 0xc0, 0x27, 0xe0, 0xda, 0xe0, 0x02, 0xd2, 0xf1, 0xd1, 0x7e, 0xe3,
-	0x34, 0xcb, 0xcb, 0x44, 0x8e, 0x7e, 0x2a, 0xfb, 0xbf, 0xce, 0x4a, 0x91, 0xc6, 0xc6, 0x77, 0x3f,
-	0x86, 0x75, 0x61, 0x71, 0x10, 0x16, 0x14, 0x89, 0xa2, 0x20, 0x35, 0xcf, 0x93, 0x86, 0x80, 0x8f,
-	0x14, 0xfa, 0x98, 0x89, 0x0b, 0x47, 0x42, 0xc1, 0xd4, 0xee, 0x46, 0xa0, 0x20, 0xd9, 0x91, 0x3e,
-	0x87, 0xfa, 0x40, 0x6a, 0x16, 0x90, 0x24, 0x26, 0xaa, 0x2b, 0x39, 0x07, 0x5b, 0xd3, 0x93, 0xe3,
-	0xa1, 0xd8, 0xf4, 0x1d, 0x45, 0x2a, 0x17, 0xee, 0x3d, 0xd8, 0xb4, 0xea, 0xe8, 0x24, 0xdd, 0x57,
-	0xa4, 0x8c, 0x0d, 0x6b, 0xcf, 0x44, 0x4b, 0xdc, 0xca, 0x0b, 0xed, 0xd2, 0x2e, 0xfc, 0x63, 0x0d,
-	0x5a, 0xc2, 0x5d, 0x76, 0xc5, 0x71, 0x7f, 0x06, 0xab, 0x8a, 0x5a, 0xdf, 0xa5, 0x0b, 0xd4, 0xd3,
-	0x44, 0x17, 0x6a, 0xb6, 0x74, 0xa1, 0x66, 0xf3, 0xfc, 0xb9, 0x3c, 0xc7, 0x9f, 0x26, 0xc2, 0xd5,
-	0xd2, 0x87, 0x83, 0xed, 0x31, 0x1d, 0x64, 0x9c, 0x56, 0x03, 0x7f, 0x00, 0x9b, 0x55, 0x78, 0x81,
-	0xd0, 0x7f, 0x89, 0x1e, 0x2a, 0x32, 0x71, 0x48, 0x8a, 0x78, 0xd9, 0xa7, 0xe9, 0x11, 0x29, 0x71,
-	0x9c, 0x7d, 0x9e, 0x2f, 0xd0, 0x0a, 0xbc, 0x5f, 0xc1, 0xde, 0xc5, 0xc7, 0x17, 0xcb, 0x7b, 0x75,
-	0x90, 0x30, 0xcd, 0x27, 0xb2, 0xf2, 0x7e, 0x76, 0x4b, 0x3b, 0xe0, 0x0f, 0xe2, 0x8f, 0x40, 0x5a,
-	0xcd, 0xfb, 0xb7, 0x0d, 0xda, 0x9c, 0x08, 0x2c, 0xcd, 0xcb, 0xe8, 0xbb, 0xd0, 0x96, 0x03, 0xb0,
-	0x78, 0x95, 0x17, 0xf8, 0x36, 0x17, 0x3a, 0xe9, 0xb9, 0x77, 0x5d, 0x6e, 0x4c, 0x7a, 0x93, 0x6c,
-	0x5f, 0x74, 0xea, 0xe6, 0x79, 0x8f, 0x26, 0x86, 0x20, 0x26, 0x88, 0x27, 0xfd, 0xe9, 0xed, 0x74,
-	0x16, 0xcf, 0x9d, 0x39, 0xac, 0xb4, 0x1c, 0x6c, 0x65, 0xa2, 0xe6, 0x5a, 0x75, 0xe2, 0x30, 0x8d,
-	0x44, 0x77, 0xa9, 0xcc, 0x2c, 0x2f, 0xe0, 0xf6, 0x1b, 0xa9, 0xde, 0x75, 0x86, 0xc1, 0x9c, 0xb4,
-	0x33, 0xc1, 0xca, 0xc9, 0x2a, 0xbc, 0x40, 0x52, 0xdc, 0x83, 0xc6, 0x7d, 0x12, 0xbe, 0x2a, 0xc7,
-	0x19, 0xb8, 0x07, 0x4e, 0x98, 0xa5, 0x61, 0x59, 0xa0, 0x13, 0xc2, 0x91, 0x2e, 0x3c, 0x36, 0x84,
-	0xf3, 0x46, 0xd3, 0x1c, 0xd1, 0x02, 0x3e, 0x84, 0x2b, 0x74, 0x38, 0x71, 0x6c, 0x73, 0xdf, 0xfc,
-	0x4d, 0x7e, 0x22, 0x50, 0x5f, 0x6d, 0x76, 0x57, 0xe5, 0x9f, 0xe6, 0x9f, 0xfe, 0x27, 0x00, 0x00,
-	0xff, 0xff, 0x57, 0x17, 0x30, 0xf4, 0xa5, 0x17, 0x00, 0x00,
+	// 1962 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xcc, 0x58, 0x4b, 0x6f, 0x23, 0xc7,
+	0x11, 0x06, 0x45, 0x49, 0x2b, 0xd5, 0x90, 0x14, 0x39, 0xd4, 0x83, 0x92, 0x81, 0x95, 0x76, 0x76,
+	0x13, 0x2b, 0x1b, 0x44, 0xf6, 0xca, 0x8e, 0x61, 0xc4, 0x70, 0x10, 0xbd, 0xf6, 0xe1, 0xc7, 0xae,
+	0x3c, 0xda, 0x47, 0x90, 0xcb, 0xa0, 0xc9, 0x29, 0x91, 0x03, 0x0d, 0x67, 0x66, 0xbb, 0x7b, 0x24,
+	0x11, 0x08, 0xf2, 0x2f, 0x72, 0xcb, 0x2d, 0x40, 0x72, 0xc8, 0x2d, 0x3f, 0xc6, 0x41, 0x7e, 0x49,
+	0x0e, 0xb9, 0x04, 0xfd, 0x22, 0x7b, 0x48, 0x4a, 0x4b, 0x6f, 0x8c, 0x20, 0x17, 0x81, 0xf5, 0x75,
+	0x75, 0xbd, 0xbb, 0xaa, 0x46, 0xb0, 0xc1, 0x49, 0x3b, 0x46, 0xde, 0x27, 0x09, 0xe9, 0x22, 0x0d,
+	0x09, 0x27, 0x7b, 0x19, 0x4d, 0x79, 0xea, 0x36, 0x26, 0x0e, 0xb6, 0x9c, 0xb7, 0x39, 0xd2, 0x81,
+	0x3a, 0xdf, 0xaa, 0xf1, 0x34, 0x4b, 0x47, 0xfc, 0x5b, 0x6b, 0x14, 0xb3, 0x38, 0xea, 0x10, 0x1e,
+	0xa5, 0x89, 0x05, 0x57, 0xe3, 0xb4, 0x9b, 0xf3, 0x28, 0x56, 0xa4, 0xf7, 0xcf, 0x12, 0xac, 0xbc,
+	0x14, 0x82, 0x8f, 0xf1, 0x3c, 0x4a, 0x22, 0xc1, 0xec, 0xba, 0x30, 0x9f, 0x90, 0x3e, 0xb6, 0x4a,
+	0x3b, 0xa5, 0xdd, 0x65, 0x5f, 0xfe, 0x76, 0xd7, 0x61, 0x91, 0x75, 0x7a, 0xd8, 0x27, 0xad, 0x39,
+	0x89, 0x6a, 0xca, 0x6d, 0xc1, 0x9d, 0x4e, 0x1a, 0xe7, 0xfd, 0x84, 0xb5, 0xca, 0x3b, 0xe5, 0xdd,
+	0x65, 0xdf, 0x90, 0xee, 0x1e, 0x34, 0x33, 0x1a, 0xf5, 0x09, 0x1d, 0x04, 0x17, 0x38, 0x08, 0x0c,
+	0xd7, 0xbc, 0xe4, 0x6a, 0xe8, 0xa3, 0xaf, 0x71, 0x70, 0xa4, 0xf9, 0x5d, 0x98, 0xe7, 0x83, 0x0c,
+	0x5b, 0x0b, 0x4a, 0xab, 0xf8, 0xed, 0x6e, 0x83, 0x23, 0x4c, 0x0f, 0x62, 0x4c, 0xba, 0xbc, 0xd7,
+	0x5a, 0xdc, 0x29, 0xed, 0xce, 0xfb, 0x20, 0xa0, 0x6f, 0x24, 0xe2, 0x7e, 0x00, 0xcb, 0x34, 0xbd,
+	0x0a, 0x3a, 0x69, 0x9e, 0xf0, 0xd6, 0x1d, 0x79, 0xbc, 0x44, 0xd3, 0xab, 0x23, 0x41, 0x7b, 0x7f,
+	0x29, 0x41, 0xfd, 0x4c, 0x9a, 0x69, 0x39, 0xf7, 0x21, 0xac, 0x88, 0xfb, 0x6d, 0xc2, 0x30, 0xd0,
+	0x1e, 0x29, 0x3f, 0x6b, 0x06, 0x56, 0x57, 0xdc, 0x17, 0xa0, 0x22, 0x1e, 0x84, 0xc3, 0xcb, 0xac,
+	0x35, 0xb7, 0x53, 0xde, 0x75, 0xf6, 0xbd, 0xbd, 0xc9, 0x24, 0x8d, 0x05, 0xd1, 0xaf, 0xf3, 0x22,
+	0xc0, 0x44, 0xa8, 0x2e, 0x91, 0xb2, 0x28, 0x4d, 0x5a, 0x65, 0xa9, 0xd1, 0x90, 0xde, 0xbf, 0x4a,
+	0x50, 0x7b, 0xc5, 0x90, 0x9e, 0x22, 0xed, 0x47, 0x8c, 0xe9, 0x1c, 0xf4, 0x52, 0xc6, 0x4d, 0x0e,
+	0xc4, 0x6f, 0x81, 0xe5, 0x0c, 0xa9, 0xce, 0x80, 0xfc, 0xed, 0xfe, 0x1c, 0x1a, 0x19, 0x61, 0xec,
+	0x2a, 0xa5, 0x61, 0xd0, 0xe9, 0x61, 0xe7, 0x82, 0xe5, 0x7d, 0x29, 0x7e, 0xde, 0xaf, 0x9b, 0x83,
+	0x23, 0x8d, 0xbb, 0xdf, 0x01, 0x64, 0x34, 0xba, 0x8c, 0x62, 0xec, 0xa2, 0xca, 0x84, 0xb3, 0xff,
+	0x68, 0x8a, 0x2f, 0x45, 0x5b, 0xf6, 0x4e, 0x87, 0x77, 0x4e, 0x12, 0x4e, 0x07, 0xbe, 0x25, 0x64,
+	0xeb, 0x4b, 0x58, 0x19, 0x3b, 0x76, 0xeb, 0x50, 0xbe, 0xc0, 0x81, 0xb6, 0x5c, 0xfc, 0x74, 0x57,
+	0x61, 0xe1, 0x92, 0xc4, 0x39, 0x6a, 0xcb, 0x15, 0xf1, 0xab, 0xb9, 0xcf, 0x4b, 0xde, 0xf7, 0x25,
+	0xa8, 0x1c, 0xb7, 0xdf, 0xe1, 0x77, 0x0d, 0xe6, 0xc2, 0xb6, 0xbe, 0x3b, 0x17, 0xb6, 0x87, 0x71,
+	0x28, 0x5b, 0x71, 0x78, 0x31, 0xc5, 0xb5, 0x8f, 0xa6, 0xb8, 0x76, 0xdc, 0xfe, 0xdf, 0x38, 0xf6,
+	0xe7, 0x12, 0x38, 0x23, 0x4d, 0xcc, 0xfd, 0x06, 0xea, 0xc2, 0xce, 0x20, 0x1b, 0x61, 0xad, 0x92,
+	0xb4, 0xf2, 0xde, 0x3b, 0x13, 0xe0, 0xaf, 0xe4, 0x05, 0x9a, 0xb9, 0x8f, 0xa1, 0x16, 0xb6, 0x0b,
+	0xb2, 0x54, 0x61, 0x6e, 0xbf, 0xc3, 0x63, 0xbf, 0x1a, 0x5a, 0x14, 0xf3, 0xbe, 0x00, 0xe7, 0x30,
+	0xce, 0x4e, 0x53, 0xa6, 0xde, 0x46, 0x1d, 0xca, 0x79, 0x14, 0x4a, 0x07, 0xab, 0xbe, 0xf8, 0xe9,
+	0x6e, 0xc1, 0x52, 0xa6, 0x4f, 0xb5, 0x8f, 0x43, 0xda, 0xfb, 0x10, 0x9c, 0xd3, 0x28, 0xe9, 0xfa,
+	0xf8, 0x36, 0x47, 0xc6, 0x45, 0x79, 0x67, 0x64, 0x10, 0xa7, 0x24, 0xd4, 0x11, 0x32, 0xa4, 0xb7,
+	0x0b, 0x15, 0xc5, 0xc8, 0xb2, 0x34, 0x61, 0x78, 0x0b, 0xe7, 0x43, 0xa8, 0x9c, 0xc5, 0x88, 0x99,
+	0x91, 0xb9, 0x05, 0x4b, 0x61, 0x4e, 0x65, 0x0b, 0x93, 0xac, 0x65, 0x7f, 0x48, 0x7b, 0x2b, 0x50,
+	0xd5, 0xbc, 0x4a, 0xac, 0xf7, 0x8f, 0x12, 0xb8, 0x27, 0xd7, 0xd8, 0xc9, 0x39, 0x3e, 0x4d, 0xd3,
+	0x0b, 0x23, 0x63, 0x5a, 0x37, 0xbb, 0x0b, 0x90, 0x11, 0x4a, 0xfa, 0xc8, 0x91, 0xaa, 0xd8, 0x2d,
+	0xfb, 0x16, 0xe2, 0x9e, 0xc2, 0x32, 0x5e, 0x73, 0x4a, 0x02, 0x4c, 0x2e, 0x65, 0x5f, 0x73, 0xf6,
+	0x3f, 0x99, 0x12, 0xda, 0x49, 0x6d, 0x7b, 0x27, 0xe2, 0xda, 0x49, 0x72, 0xa9, 0x0a, 0x6a, 0x09,
+	0x35, 0xb9, 0xf5, 0x05, 0x54, 0x0b, 0x47, 0x3f, 0xa8, 0x98, 0xce, 0xa1, 0x59, 0x50, 0xa5, 0xe3,
+	0xb8, 0x0d, 0x0e, 0x5e, 0x47, 0x3c, 0x60, 0x9c, 0xf0, 0x9c, 0xe9, 0x00, 0x81, 0x80, 0xce, 0x24,
+	0x22, 0x9b, 0x36, 0x0f, 0xd3, 0x9c, 0x0f, 0x9b, 0xb6, 0xa4, 0x34, 0x8e, 0xd4, 0x3c, 0x21, 0x4d,
+	0x79, 0x97, 0x50, 0x7f, 0x82, 0x5c, 0xf5, 0x3f, 0x13, 0xbe, 0x75, 0x58, 0x94, 0x8e, 0xab, 0x72,
+	0x5d, 0xf6, 0x35, 0xe5, 0xde, 0x87, 0x6a, 0x94, 0x74, 0xe2, 0x3c, 0xc4, 0xe0, 0x32, 0xc2, 0x2b,
+	0x26, 0x55, 0x2c, 0xf9, 0x15, 0x0d, 0xbe, 0x16, 0x98, 0xfb, 0x13, 0xa8, 0xe1, 0xb5, 0x62, 0xd2,
+	0x42, 0xd4, 0x90, 0xa8, 0x6a, 0x54, 0x36, 0x4d, 0xe6, 0x21, 0x34, 0x2c, 0xbd, 0xda, 0xbb, 0x53,
+	0x68, 0xa8, 0xfe, 0x6c, 0x35, 0x60, 0xe9, 0xa3, 0xb3, 0x7f, 0x7f, 0x4a, 0x2e, 0xc6, 0x1b, 0xbd,
+	0x5f, 0x67, 0x63, 0x88, 0xb7, 0x01, 0x6b, 0x4f, 0x90, 0x5b, 0xf5, 0xaf, 0x7d, 0xf4, 0x7e, 0x07,
+	0xeb, 0xe3, 0x07, 0xda, 0x88, 0xdf, 0x80, 0x53, 0x7c, 0xb1, 0x42, 0xfd, 0xdd, 0x29, 0xea, 0xed,
+	0xcb, 0xf6, 0x15, 0x6f, 0x15, 0xdc, 0x33, 0xe4, 0x3e, 0x92, 0xf0, 0x45, 0x12, 0x0f, 0x8c, 0xc6,
+	0x35, 0x68, 0x16, 0x50, 0x5d, 0xc2, 0x23, 0xf8, 0x0d, 0x8d, 0x38, 0x1a, 0xee, 0x75, 0x58, 0x2d,
+	0xc2, 0x9a, 0xfd, 0x2b, 0x68, 0x1c, 0xf5, 0x48, 0xd2, 0xc5, 0x97, 0x83, 0xcc, 0x30, 0xbb, 0xbf,
+	0x04, 0x47, 0x99, 0x17, 0xc8, 0x71, 0x2a, 0x4c, 0xae, 0xed, 0xaf, 0xee, 0x0d, 0xb7, 0x03, 0x19,
+	0x73, 0x2e, 0x6f, 0x00, 0x1f, 0xfe, 0x16, 0x76, 0xda, 0xb2, 0x46, 0x06, 0xf9, 0x78, 0x4e, 0x91,
+	0xf5, 0x44, 0x49, 0xd9, 0x06, 0x15, 0x61, 0xcd, 0xfe, 0x1c, 0xd6, 0xfc, 0x3c, 0x79, 0x8a, 0x24,
+	0xe6, 0x3d, 0x39, 0x75, 0xfe, 0x4b, 0xa3, 0x5a, 0xb0, 0x3e, 0x2e, 0x4f, 0x6b, 0xfa, 0x14, 0x5a,
+	0xcf, 0xba, 0x49, 0x4a, 0x51, 0x1d, 0x9e, 0x50, 0x9a, 0xd2, 0x42, 0x27, 0xe2, 0x1c, 0x69, 0x32,
+	0xea, 0x2f, 0x92, 0xf4, 0x3e, 0x80, 0xcd, 0x29, 0xb7, 0x6c, 0x5f, 0x45, 0x1b, 0x2a, 0x3c, 0x00,
+	0xe5, 0xab, 0x0d, 0x6b, 0xf6, 0x8f, 0x61, 0xfd, 0x94, 0xe2, 0x79, 0x1c, 0x75, 0x7b, 0x93, 0x4f,
+	0xa6, 0x23, 0x43, 0xa9, 0xd5, 0x6b, 0xca, 0xfb, 0x5b, 0x09, 0x36, 0x26, 0xae, 0xe8, 0x42, 0x7b,
+	0x0a, 0xd5, 0x36, 0x9e, 0xa7, 0xb4, 0xb0, 0x94, 0xcc, 0x58, 0xe9, 0x15, 0x75, 0x53, 0xe1, 0xee,
+	0x63, 0xa8, 0x90, 0x73, 0x8e, 0x34, 0xb0, 0xf6, 0xb5, 0x19, 0x05, 0x39, 0xf2, 0xa2, 0x82, 0xbd,
+	0x7f, 0x97, 0xc0, 0x3d, 0xc8, 0xb2, 0x78, 0x50, 0x74, 0xae, 0x0e, 0x65, 0xf6, 0x36, 0x36, 0x7d,
+	0x8b, 0xbd, 0x8d, 0x45, 0xdf, 0x3a, 0x4f, 0x69, 0x07, 0x75, 0x07, 0x50, 0x84, 0x58, 0x4c, 0x48,
+	0x1c, 0xa7, 0x57, 0x81, 0xb5, 0x86, 0xca, 0x76, 0xb3, 0xe4, 0xd7, 0xe5, 0x81, 0x3f, 0xc2, 0x27,
+	0xbd, 0x9f, 0xff, 0xb1, 0xbc, 0x5f, 0x78, 0x4f, 0xef, 0xff, 0x5a, 0x82, 0x66, 0xc1, 0xfb, 0xff,
+	0xdb, 0x3c, 0xfd, 0xbd, 0x04, 0x2d, 0x3d, 0x1d, 0x1e, 0x23, 0xef, 0xf4, 0x0e, 0xd8, 0x71, 0x7b,
+	0x98, 0xad, 0x55, 0x58, 0x90, 0xdf, 0x08, 0x3a, 0x5f, 0x8a, 0x70, 0x37, 0xe0, 0x4e, 0xd8, 0x0e,
+	0xe4, 0x54, 0xd4, 0x83, 0x21, 0x6c, 0x3f, 0x17, 0x73, 0x71, 0x13, 0x96, 0xfa, 0xe4, 0x3a, 0xa0,
+	0xe9, 0x15, 0xd3, 0x4b, 0xe4, 0x9d, 0x3e, 0xb9, 0xf6, 0xd3, 0x2b, 0x26, 0xf7, 0xe6, 0x88, 0xc9,
+	0x85, 0xb8, 0x1d, 0x25, 0x71, 0xda, 0x65, 0x32, 0x49, 0x4b, 0x7e, 0x4d, 0xc3, 0x87, 0x0a, 0x15,
+	0x83, 0x81, 0xca, 0xf7, 0x62, 0xa7, 0x60, 0xc9, 0xaf, 0x50, 0xeb, 0x11, 0x79, 0x4f, 0x60, 0x73,
+	0x8a, 0xcd, 0x3a, 0xc6, 0x0f, 0x61, 0x91, 0x22, 0xcb, 0x63, 0xae, 0x83, 0xeb, 0xee, 0xa9, 0xef,
+	0x9c, 0xef, 0xc4, 0x5f, 0x5f, 0x9e, 0xf8, 0x9a, 0xc3, 0xfb, 0x7a, 0xdc, 0xf9, 0x83, 0x2c, 0xbb,
+	0xdd, 0x79, 0xdb, 0xc7, 0xb9, 0x82, 0x8f, 0x93, 0x56, 0x49, 0x61, 0xef, 0x61, 0x95, 0x68, 0xfa,
+	0x31, 0xb9, 0x44, 0x35, 0x87, 0x4d, 0x27, 0x79, 0x0c, 0xcd, 0x02, 0xaa, 0x05, 0x7f, 0x24, 0xa6,
+	0xf1, 0x70, 0x82, 0x3b, 0xfb, 0x1b, 0x7b, 0xe3, 0x5f, 0x6e, 0xfa, 0x82, 0x66, 0x13, 0x73, 0xec,
+	0x5b, 0xc2, 0x38, 0x52, 0xb3, 0xb8, 0x19, 0x05, 0x9f, 0xc2, 0xfa, 0xf8, 0x81, 0xd6, 0x61, 0xef,
+	0x71, 0xa5, 0xb1, 0x3d, 0xce, 0x85, 0xfa, 0x19, 0x4f, 0x33, 0x69, 0x9a, 0x91, 0xd4, 0x84, 0x86,
+	0x85, 0xe9, 0x8e, 0xf7, 0x5b, 0xd8, 0x18, 0x82, 0xdf, 0x46, 0x49, 0xd4, 0xcf, 0xfb, 0xd6, 0xa2,
+	0x76, 0x93, 0x7c, 0xf7, 0x1e, 0x54, 0xae, 0x48, 0xc4, 0x03, 0x1e, 0xf5, 0xd1, 0xec, 0x22, 0x65,
+	0xdf, 0x11, 0xd8, 0x4b, 0x05, 0x79, 0x9f, 0x41, 0x6b, 0x52, 0xf2, 0x0c, 0xa6, 0x4b, 0x33, 0x09,
+	0xe5, 0x05, 0xdb, 0x45, 0xf0, 0x2d, 0x50, 0x1b, 0x7f, 0x0c, 0xf7, 0xd4, 0x90, 0x39, 0xb9, 0x16,
+	0xa3, 0x80, 0xc4, 0x62, 0xec, 0x66, 0x84, 0x62, 0xc2, 0x31, 0x34, 0x6e, 0xc8, 0x8d, 0x4a, 0x1d,
+	0x07, 0x91, 0xd9, 0x4e, 0xc1, 0x40, 0xcf, 0x42, 0xef, 0x01, 0x78, 0xb7, 0x49, 0xd1, 0xba, 0x76,
+	0xe0, 0xee, 0x38, 0xd7, 0x49, 0x8c, 0x9d, 0x91, 0x22, 0xef, 0x1e, 0x6c, 0xdf, 0xc8, 0xa1, 0x85,
+	0xb8, 0x6a, 0x19, 0x13, 0x4e, 0x0c, 0x2b, 0xe8, 0x67, 0xd0, 0xb0, 0x30, 0x1d, 0xa0, 0x55, 0x58,
+	0x20, 0x61, 0x48, 0xcd, 0x82, 0xa6, 0x08, 0xef, 0x0f, 0xb0, 0xfe, 0x86, 0x44, 0xdc, 0x5a, 0xef,
+	0x8d, 0x93, 0x07, 0x50, 0x69, 0xc7, 0x59, 0x50, 0x08, 0xea, 0xf4, 0xa5, 0xc6, 0xbe, 0xec, 0xb4,
+	0x47, 0xc4, 0x2c, 0x29, 0xdd, 0x84, 0x8d, 0x09, 0xfd, 0xda, 0xb3, 0x3a, 0xd4, 0x44, 0xb6, 0x0f,
+	0x63, 0xf3, 0x52, 0xbd, 0xd7, 0xb0, 0x32, 0x44, 0xb4, 0x57, 0x47, 0x50, 0xb5, 0xad, 0x34, 0x5f,
+	0x4b, 0xef, 0x32, 0xb3, 0x62, 0x99, 0xc9, 0xbc, 0x86, 0x90, 0x4b, 0x28, 0xb7, 0x54, 0xc9, 0x6a,
+	0x37, 0x90, 0x36, 0xe8, 0xf7, 0xe0, 0xfa, 0x79, 0x72, 0x18, 0x67, 0xaf, 0x12, 0x1e, 0xc5, 0x26,
+	0x4e, 0x3f, 0x86, 0x05, 0xb3, 0x44, 0xea, 0x11, 0x34, 0x0b, 0xda, 0x67, 0xa8, 0xfb, 0x4d, 0xd8,
+	0xf0, 0x91, 0x21, 0xb7, 0x66, 0xa8, 0xf1, 0x6f, 0x0b, 0x5a, 0x93, 0x47, 0xda, 0xcf, 0x26, 0x34,
+	0x9e, 0x25, 0x11, 0x57, 0x3d, 0xc2, 0x5c, 0xf8, 0x18, 0x5c, 0x1b, 0x9c, 0x41, 0xfb, 0xf7, 0x25,
+	0xb8, 0x7b, 0x9a, 0x66, 0x79, 0x2c, 0x57, 0x3f, 0x55, 0xfd, 0x5f, 0xa5, 0xb9, 0x28, 0x63, 0x13,
+	0xbb, 0x9f, 0xc2, 0x8a, 0xf0, 0x38, 0xe8, 0x50, 0x24, 0x1c, 0xc3, 0x20, 0x31, 0x9f, 0x27, 0x55,
+	0x01, 0x1f, 0x29, 0xf4, 0x39, 0x13, 0x0f, 0x8e, 0x74, 0x84, 0x50, 0x7b, 0x1a, 0x81, 0x82, 0xe4,
+	0x44, 0xfa, 0x1c, 0x2a, 0x7d, 0x69, 0x59, 0x40, 0xe2, 0x88, 0xa8, 0xa9, 0xe4, 0xec, 0xaf, 0x8d,
+	0x6f, 0x8e, 0x07, 0xe2, 0xd0, 0x77, 0x14, 0xab, 0x24, 0xdc, 0x47, 0xb0, 0x6a, 0xf5, 0xd1, 0x51,
+	0xb9, 0xcf, 0x4b, 0x1d, 0x4d, 0xeb, 0xcc, 0x64, 0x4b, 0xbc, 0xca, 0x1b, 0xfd, 0xd2, 0x21, 0xfc,
+	0x53, 0x09, 0xea, 0x22, 0x5c, 0x76, 0xc7, 0x71, 0x7f, 0x01, 0x8b, 0x8a, 0xbb, 0x55, 0xba, 0xcd,
+	0x3c, 0xcd, 0x74, 0xa3, 0x65, 0x73, 0x37, 0x5a, 0x36, 0x2d, 0x9e, 0xe5, 0x29, 0xf1, 0x34, 0x19,
+	0x2e, 0xb6, 0xbe, 0x35, 0x68, 0x1e, 0x63, 0x3f, 0xe5, 0x58, 0x4c, 0xfc, 0x3e, 0xac, 0x16, 0xe1,
+	0x19, 0x52, 0xff, 0x25, 0x6c, 0x9f, 0xd2, 0x54, 0x5c, 0x92, 0x2a, 0xde, 0xf4, 0x30, 0x39, 0x22,
+	0x79, 0xb7, 0xc7, 0x5f, 0x65, 0x33, 0x8c, 0x02, 0xef, 0xd7, 0xb0, 0x73, 0xf3, 0xf5, 0xd9, 0xea,
+	0x5e, 0x5d, 0x24, 0x4c, 0xcb, 0x09, 0xad, 0xba, 0x9f, 0x3c, 0xd2, 0x01, 0xf8, 0xa3, 0xf8, 0x47,
+	0x20, 0x16, 0xeb, 0xfe, 0x87, 0x26, 0x6d, 0x4a, 0x06, 0xe6, 0xa6, 0x55, 0xf4, 0x43, 0x68, 0xc8,
+	0x05, 0x58, 0x7c, 0x95, 0x53, 0x1e, 0x30, 0x61, 0x93, 0xde, 0x7b, 0x57, 0xe4, 0xc1, 0x68, 0x36,
+	0xc9, 0xf1, 0x85, 0x63, 0x2f, 0xcf, 0x7b, 0x36, 0x72, 0xc4, 0x47, 0x29, 0x04, 0xc3, 0xf7, 0xb3,
+	0x59, 0x7c, 0xee, 0x4c, 0x11, 0xa5, 0xf5, 0x3c, 0x00, 0x4f, 0xf4, 0x5c, 0xab, 0x4f, 0x1c, 0x24,
+	0xa1, 0x98, 0x2e, 0x85, 0x9d, 0xe5, 0x35, 0xdc, 0xbf, 0x95, 0xeb, 0x7d, 0x77, 0x98, 0x35, 0x68,
+	0xda, 0x95, 0x60, 0xd5, 0x64, 0x11, 0x9e, 0xa1, 0x28, 0x1e, 0x41, 0xf5, 0x90, 0x74, 0x2e, 0xf2,
+	0x61, 0x05, 0xee, 0x80, 0xd3, 0x49, 0x93, 0x4e, 0x4e, 0x29, 0x26, 0x9d, 0x81, 0x6e, 0x3c, 0x36,
+	0xe4, 0x7d, 0x06, 0x35, 0x73, 0x45, 0x2b, 0x78, 0x00, 0x0b, 0x78, 0x39, 0x0a, 0x6c, 0x6d, 0xcf,
+	0xfc, 0x9b, 0xfc, 0x44, 0xa0, 0xbe, 0x3a, 0x14, 0xab, 0xc5, 0x1b, 0x42, 0xfb, 0x62, 0xe5, 0x8b,
+	0x46, 0x53, 0x79, 0x0d, 0x9a, 0x05, 0x54, 0x89, 0x6c, 0x2f, 0xca, 0xff, 0xb0, 0x7f, 0xf2, 0x9f,
+	0x01, 0x00, 0x28, 0xb7, 0xee, 0x3d, 0xd2, 0x17, 0x00, 0x00,
 }
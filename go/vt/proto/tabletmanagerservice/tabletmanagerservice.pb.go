@@ -139,6 +139,9 @@ type TabletManagerClient interface {
 	// PromoteSlave makes the slave the new master
 	PromoteSlave(ctx context.Context, in *tabletmanagerdata.PromoteSlaveRequest, opts ...grpc.CallOption) (*tabletmanagerdata.PromoteSlaveResponse, error)
 	Backup(ctx context.Context, in *tabletmanagerdata.BackupRequest, opts ...grpc.CallOption) (TabletManager_BackupClient, error)
+	// WarmQueries starts the query warmer of a replica, which executes
+	// the hot queries of the master to warm the MySQL buffer pool.
+	WarmQueries(ctx context.Context, in *tabletmanagerdata.WarmQueriesRequest, opts ...grpc.CallOption) (*tabletmanagerdata.WarmQueriesResponse, error)
 }
 
 type tabletManagerClient struct {
@@ -532,6 +535,15 @@ func (x *tabletManagerBackupClient) Recv() (*tabletmanagerdata.BackupResponse, e
 	return m, nil
 }
 
+func (c *tabletManagerClient) WarmQueries(ctx context.Context, in *tabletmanagerdata.WarmQueriesRequest, opts ...grpc.CallOption) (*tabletmanagerdata.WarmQueriesResponse, error) {
+	out := new(tabletmanagerdata.WarmQueriesResponse)
+	err := grpc.Invoke(ctx, "/tabletmanagerservice.TabletManager/WarmQueries", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for TabletManager service
 
 type TabletManagerServer interface {
@@ -632,6 +644,9 @@ type TabletManagerServer interface {
 	// PromoteSlave makes the slave the new master
 	PromoteSlave(context.Context, *tabletmanagerdata.PromoteSlaveRequest) (*tabletmanagerdata.PromoteSlaveResponse, error)
 	Backup(*tabletmanagerdata.BackupRequest, TabletManager_BackupServer) error
+	// WarmQueries starts the query warmer of a replica, which executes
+	// the hot queries of the master to warm the MySQL buffer pool.
+	WarmQueries(context.Context, *tabletmanagerdata.WarmQueriesRequest) (*tabletmanagerdata.WarmQueriesResponse, error)
 }
 
 func RegisterTabletManagerServer(s *grpc.Server, srv TabletManagerServer) {
@@ -1361,6 +1376,24 @@ func (x *tabletManagerBackupServer) Send(m *tabletmanagerdata.BackupResponse) er
 	return x.ServerStream.SendMsg(m)
 }
 
+func _TabletManager_WarmQueries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(tabletmanagerdata.WarmQueriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TabletManagerServer).WarmQueries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/tabletmanagerservice.TabletManager/WarmQueries",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TabletManagerServer).WarmQueries(ctx, req.(*tabletmanagerdata.WarmQueriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _TabletManager_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "tabletmanagerservice.TabletManager",
 	HandlerType: (*TabletManagerServer)(nil),
@@ -1521,6 +1554,10 @@ var _TabletManager_serviceDesc = grpc.ServiceDesc{
 			MethodName: "PromoteSlave",
 			Handler:    _TabletManager_PromoteSlave_Handler,
 		},
+		{
+			MethodName: "WarmQueries",
+			Handler:    _TabletManager_WarmQueries_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1532,63 +1569,64 @@ var _TabletManager_serviceDesc = grpc.ServiceDesc{
 }
 
 var fileDescriptor0 = []byte{
-	// 919 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x94, 0x98, 0x5b, 0x6f, 0xd3, 0x4a,
-	0x10, 0xc7, 0x4f, 0xa4, 0x73, 0x7a, 0xce, 0xd9, 0x73, 0xb8, 0xad, 0x90, 0x8a, 0x82, 0x04, 0xbd,
-	0x72, 0x69, 0x51, 0x05, 0x2d, 0xe5, 0xbd, 0x97, 0x40, 0x8b, 0xa8, 0x08, 0x49, 0xab, 0x22, 0x21,
-	0x21, 0x6d, 0x9d, 0x69, 0x6c, 0xea, 0xd8, 0xc6, 0x5e, 0x57, 0xed, 0x2b, 0x12, 0x4f, 0x48, 0x7c,
-	0x0d, 0xbe, 0x26, 0xbe, 0xed, 0x66, 0x36, 0x19, 0x6f, 0x92, 0x47, 0xfc, 0xff, 0xed, 0xcc, 0x64,
-	0x76, 0x66, 0x76, 0x28, 0x6b, 0x4a, 0x71, 0xe6, 0x83, 0x1c, 0x88, 0x40, 0xf4, 0x21, 0x4e, 0x20,
-	0xbe, 0xf4, 0x1c, 0xd8, 0x88, 0xe2, 0x50, 0x86, 0xfc, 0x2e, 0xa5, 0x35, 0xe7, 0x8d, 0xaf, 0x3d,
-	0x21, 0x45, 0x89, 0x6f, 0xfe, 0x5a, 0x60, 0x37, 0x8e, 0x0b, 0xed, 0xa8, 0xd4, 0xf8, 0x21, 0xfb,
-	0xb3, 0xed, 0x05, 0x7d, 0xfe, 0x60, 0x63, 0xfc, 0x4c, 0x2e, 0x74, 0xe0, 0x6b, 0x0a, 0x89, 0x6c,
-	0x3e, 0xac, 0xd5, 0x93, 0x28, 0x0c, 0x12, 0x58, 0xfa, 0x83, 0xbf, 0x63, 0x7f, 0x75, 0x7d, 0x80,
-	0x88, 0x53, 0x6c, 0xa1, 0x28, 0x63, 0x0b, 0xf5, 0x80, 0xb6, 0xf6, 0x99, 0xfd, 0xd7, 0xba, 0x02,
-	0x27, 0x95, 0x70, 0x10, 0x86, 0x17, 0x7c, 0x95, 0x38, 0x82, 0x74, 0x65, 0xf9, 0xd1, 0x24, 0x4c,
-	0xdb, 0xff, 0xc8, 0xfe, 0x7d, 0x03, 0xb2, 0xeb, 0xb8, 0x30, 0x10, 0x7c, 0x99, 0x38, 0xa6, 0x55,
-	0x65, 0x7b, 0xc5, 0x0e, 0x69, 0xcb, 0x7d, 0x76, 0x33, 0xfb, 0xdc, 0x86, 0x78, 0xe0, 0x25, 0x89,
-	0x97, 0x7d, 0xe4, 0x4f, 0xe8, 0x93, 0x08, 0x51, 0x3e, 0x9e, 0x4e, 0x41, 0xe2, 0x14, 0x75, 0x41,
-	0x76, 0x40, 0xf4, 0xde, 0x07, 0xfe, 0x35, 0x99, 0x22, 0xa4, 0xdb, 0x52, 0x64, 0x60, 0xda, 0xbe,
-	0x60, 0xff, 0x57, 0xc2, 0x69, 0xec, 0x49, 0xe0, 0x96, 0x93, 0x05, 0xa0, 0x3c, 0x3c, 0x9e, 0xc8,
-	0x69, 0x17, 0x9f, 0x18, 0xdb, 0x73, 0x45, 0xd0, 0x87, 0xe3, 0xeb, 0x08, 0x38, 0x95, 0xe1, 0xa1,
-	0xac, 0xcc, 0xaf, 0x4e, 0xa0, 0x70, 0xfc, 0x1d, 0x38, 0x8f, 0x21, 0x71, 0xbb, 0x52, 0xd4, 0xc4,
-	0x8f, 0x01, 0x5b, 0xfc, 0x26, 0x87, 0xef, 0xba, 0x93, 0x06, 0x07, 0x20, 0x7c, 0xe9, 0xee, 0xb9,
-	0xe0, 0x5c, 0x90, 0x77, 0x6d, 0x22, 0xb6, 0xbb, 0x1e, 0x25, 0xb5, 0xa3, 0x88, 0xdd, 0x39, 0xec,
-	0x07, 0x61, 0x0c, 0xa5, 0xdc, 0x8a, 0xe3, 0x30, 0xe6, 0xeb, 0x84, 0x85, 0x31, 0x4a, 0xb9, 0x7b,
-	0x36, 0x1d, 0x6c, 0x66, 0xcf, 0x0f, 0x45, 0xaf, 0xea, 0x11, 0x3a, 0x7b, 0x43, 0xc0, 0x9e, 0x3d,
-	0xcc, 0x69, 0x17, 0x5f, 0xd8, 0xad, 0x76, 0x0c, 0xe7, 0xbe, 0xd7, 0x77, 0x55, 0x27, 0x52, 0x49,
-	0x19, 0x61, 0x94, 0xa3, 0xb5, 0x69, 0x50, 0xdc, 0x2c, 0x3b, 0x51, 0xe4, 0x5f, 0x57, 0x7e, 0xa8,
-	0x22, 0x42, 0xba, 0xad, 0x59, 0x0c, 0x0c, 0x5f, 0x50, 0x35, 0x68, 0x5e, 0x83, 0x74, 0xdc, 0x9d,
-	0x64, 0xff, 0x4c, 0x90, 0x17, 0x34, 0x46, 0xd9, 0x2e, 0x88, 0x80, 0xeb, 0x3d, 0x66, 0x81, 0x4d,
-	0xe1, 0x31, 0xa3, 0xa6, 0xf7, 0x58, 0xc0, 0xc6, 0xc0, 0xf1, 0xc5, 0x25, 0xe4, 0x5d, 0x90, 0x26,
-	0xf4, 0xc0, 0x19, 0xea, 0xd6, 0x81, 0x83, 0x31, 0xdc, 0x4d, 0x47, 0x22, 0x91, 0x10, 0xb7, 0xc3,
-	0xc4, 0x93, 0xd9, 0xb4, 0x23, 0xbb, 0xc9, 0x44, 0x6c, 0xdd, 0x34, 0x4a, 0xe2, 0xe1, 0xdf, 0x95,
-	0x61, 0x54, 0x44, 0x41, 0x0e, 0x7f, 0xad, 0xda, 0x86, 0x3f, 0x82, 0xb4, 0xe5, 0x01, 0xbb, 0xad,
-	0x3f, 0x1f, 0x79, 0x81, 0x37, 0x48, 0x07, 0x7c, 0xcd, 0x76, 0xb6, 0x82, 0x94, 0x9f, 0xf5, 0xa9,
-	0x58, 0x3c, 0x3f, 0xb3, 0x2c, 0xc6, 0xb2, 0xfc, 0x25, 0x74, 0x90, 0x4a, 0xb6, 0xcd, 0x4f, 0x4c,
-	0x69, 0xe3, 0x3f, 0x1a, 0xac, 0x59, 0x6e, 0x0b, 0xad, 0xab, 0x2c, 0x8f, 0x81, 0xf0, 0xf3, 0xe7,
-	0x21, 0x12, 0x31, 0x04, 0x12, 0x7a, 0xfc, 0x25, 0x61, 0xa7, 0x1e, 0x57, 0xde, 0xb7, 0x67, 0x3c,
-	0xa5, 0xa3, 0xf9, 0xd6, 0x60, 0xf3, 0xa3, 0x60, 0xcb, 0x07, 0x27, 0x0f, 0xe5, 0xc5, 0x14, 0x46,
-	0x2b, 0x56, 0xc5, 0xb1, 0x39, 0xcb, 0x91, 0xd1, 0xad, 0x21, 0x4f, 0x54, 0x52, 0xbb, 0x35, 0x14,
-	0xea, 0xa4, 0xad, 0xa1, 0x82, 0xf0, 0x2c, 0x3c, 0x15, 0x9e, 0xdc, 0xf5, 0x23, 0x5d, 0xfc, 0x54,
-	0x49, 0x8f, 0x30, 0xb6, 0x59, 0x38, 0x86, 0x6a, 0x5f, 0x1d, 0xf6, 0x77, 0x5e, 0x53, 0x99, 0xc8,
-	0x17, 0x6b, 0xea, 0x2d, 0xd3, 0x94, 0xed, 0x25, 0x1b, 0xa2, 0x6d, 0x9e, 0xb0, 0x7f, 0x8a, 0x22,
-	0xca, 0x8d, 0x2e, 0xd5, 0x55, 0x18, 0xb2, 0xba, 0x6c, 0x65, 0xf0, 0xc8, 0xc9, 0xde, 0xc4, 0xec,
-	0xdb, 0x49, 0x20, 0x3d, 0x9f, 0x1c, 0x39, 0x48, 0xb7, 0x8d, 0x1c, 0x03, 0xc3, 0xfd, 0x9a, 0xfd,
-	0x2b, 0x5f, 0x4e, 0x22, 0xdf, 0x73, 0x44, 0x91, 0xf7, 0x35, 0xf2, 0x05, 0x33, 0x21, 0x5b, 0xbf,
-	0x8e, 0xb3, 0xb8, 0x5f, 0x0f, 0x03, 0x4f, 0x96, 0x83, 0x89, 0xec, 0xd7, 0xa1, 0x6c, 0xeb, 0x57,
-	0x4c, 0x19, 0x1d, 0xd2, 0x0e, 0xa3, 0xd4, 0x2f, 0x76, 0x94, 0xb2, 0x85, 0xde, 0x86, 0x69, 0x5e,
-	0xcb, 0x64, 0x87, 0xd4, 0xb0, 0xb6, 0x0e, 0xa9, 0x3d, 0x82, 0x3b, 0x24, 0x0f, 0xae, 0x7e, 0xb4,
-	0x6a, 0xd5, 0xd6, 0x21, 0x08, 0xc2, 0x0b, 0xc9, 0x3e, 0x0c, 0x42, 0x09, 0x55, 0xf6, 0xa8, 0x4b,
-	0xc6, 0x80, 0x6d, 0x21, 0x31, 0x39, 0xed, 0xe2, 0x7b, 0x83, 0xdd, 0x6b, 0xc7, 0x61, 0xae, 0x15,
-	0xde, 0x4f, 0x5d, 0x08, 0xf6, 0x44, 0x9a, 0xed, 0x13, 0x27, 0x11, 0x27, 0xf3, 0x51, 0x03, 0x2b,
-	0xdf, 0x5b, 0x33, 0x9d, 0x31, 0x5e, 0x91, 0x42, 0x16, 0x49, 0x45, 0xf7, 0xe8, 0x57, 0x64, 0x04,
-	0xb2, 0xbe, 0x22, 0x63, 0xac, 0xf1, 0x1c, 0x82, 0x2a, 0xca, 0x65, 0x7a, 0x7b, 0x37, 0x73, 0xba,
-	0x62, 0x87, 0xf0, 0x8e, 0xa2, 0xfc, 0x66, 0x5f, 0xf3, 0xf6, 0xce, 0x7e, 0x89, 0x2d, 0x3a, 0x4d,
-	0xd9, 0x76, 0x14, 0x02, 0xd6, 0x1e, 0x7f, 0x36, 0xd8, 0xfd, 0x7c, 0x3a, 0xa1, 0xfe, 0xdb, 0x09,
-	0x7a, 0xf9, 0xc4, 0x2d, 0x97, 0x96, 0xed, 0x9a, 0x69, 0x56, 0xc3, 0xab, 0x30, 0x5e, 0xcd, 0x7a,
-	0x0c, 0x97, 0x2d, 0xbe, 0x71, 0xb2, 0x6c, 0x31, 0x60, 0x2b, 0x5b, 0x93, 0xd3, 0x2e, 0x3e, 0xb0,
-	0xb9, 0x5d, 0xe1, 0x5c, 0xa4, 0x11, 0xa7, 0xfe, 0x67, 0x5d, 0x4a, 0xca, 0xec, 0xa2, 0x85, 0x50,
-	0x06, 0x9f, 0x37, 0xce, 0xe6, 0x8a, 0x3f, 0x18, 0x6c, 0xfd, 0x0e, 0x00, 0x00, 0xff, 0xff, 0xa3,
-	0xff, 0x3d, 0xd0, 0x7d, 0x10, 0x00, 0x00,
+	// 933 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x94, 0x98, 0xed, 0x6f, 0x1c, 0x35,
+	0x10, 0xc6, 0x39, 0x09, 0x0a, 0x98, 0x77, 0x0b, 0xa9, 0x28, 0x48, 0x40, 0xd2, 0x96, 0x97, 0x16,
+	0x55, 0xd0, 0x52, 0xbe, 0xdf, 0xa5, 0xa1, 0x0d, 0x22, 0xe2, 0xba, 0xd7, 0x28, 0x48, 0x48, 0x48,
+	0xce, 0xdd, 0xf4, 0x76, 0x89, 0xd7, 0x36, 0xb6, 0xb7, 0x4a, 0xbe, 0x22, 0x21, 0x3e, 0x20, 0xf1,
+	0x37, 0x57, 0xfb, 0x62, 0x67, 0x76, 0x6f, 0xd6, 0xb9, 0x7c, 0xcd, 0xf3, 0xf3, 0xcc, 0xdc, 0x78,
+	0xe6, 0x59, 0xb7, 0x6c, 0xc7, 0x8b, 0x53, 0x09, 0xbe, 0x14, 0x4a, 0xac, 0xc1, 0x3a, 0xb0, 0x2f,
+	0x8b, 0x25, 0xdc, 0x37, 0x56, 0x7b, 0xcd, 0x3f, 0xa6, 0xb4, 0x9d, 0x9b, 0xbd, 0xbf, 0xae, 0x84,
+	0x17, 0x2d, 0xfe, 0xe0, 0xdf, 0x5d, 0xf6, 0xde, 0xf3, 0x46, 0x3b, 0x6a, 0x35, 0x7e, 0xc8, 0x5e,
+	0x9f, 0x17, 0x6a, 0xcd, 0x3f, 0xbb, 0xbf, 0x79, 0xa6, 0x16, 0x32, 0xf8, 0xab, 0x02, 0xe7, 0x77,
+	0x3e, 0x1f, 0xd5, 0x9d, 0xd1, 0xca, 0xc1, 0xde, 0x6b, 0xfc, 0x17, 0xf6, 0xc6, 0x42, 0x02, 0x18,
+	0x4e, 0xb1, 0x8d, 0x12, 0x82, 0x7d, 0x31, 0x0e, 0xc4, 0x68, 0x7f, 0xb0, 0x77, 0x0e, 0xce, 0x61,
+	0x59, 0x79, 0x78, 0xaa, 0xf5, 0x19, 0xbf, 0x43, 0x1c, 0x41, 0x7a, 0x88, 0xfc, 0xe5, 0x55, 0x58,
+	0x8c, 0xff, 0x1b, 0x7b, 0xfb, 0x09, 0xf8, 0xc5, 0x32, 0x87, 0x52, 0xf0, 0x5b, 0xc4, 0xb1, 0xa8,
+	0x86, 0xd8, 0xb7, 0xd3, 0x50, 0x8c, 0xbc, 0x66, 0xef, 0x3f, 0x01, 0x3f, 0x07, 0x5b, 0x16, 0xce,
+	0x15, 0x5a, 0x39, 0xfe, 0x35, 0x7d, 0x12, 0x21, 0x21, 0xc7, 0x37, 0x5b, 0x90, 0xb8, 0x45, 0x0b,
+	0xf0, 0x19, 0x88, 0xd5, 0xaf, 0x4a, 0x5e, 0x90, 0x2d, 0x42, 0x7a, 0xaa, 0x45, 0x3d, 0x2c, 0xc6,
+	0x17, 0xec, 0xdd, 0x4e, 0x38, 0xb1, 0x85, 0x07, 0x9e, 0x38, 0xd9, 0x00, 0x21, 0xc3, 0x57, 0x57,
+	0x72, 0x31, 0xc5, 0xef, 0x8c, 0xed, 0xe7, 0x42, 0xad, 0xe1, 0xf9, 0x85, 0x01, 0x4e, 0x75, 0xf8,
+	0x52, 0x0e, 0xe1, 0xef, 0x5c, 0x41, 0xe1, 0xfa, 0x33, 0x78, 0x61, 0xc1, 0xe5, 0x0b, 0x2f, 0x46,
+	0xea, 0xc7, 0x40, 0xaa, 0xfe, 0x3e, 0x87, 0xef, 0x3a, 0xab, 0xd4, 0x53, 0x10, 0xd2, 0xe7, 0xfb,
+	0x39, 0x2c, 0xcf, 0xc8, 0xbb, 0xee, 0x23, 0xa9, 0xbb, 0x1e, 0x92, 0x31, 0x91, 0x61, 0x1f, 0x1d,
+	0xae, 0x95, 0xb6, 0xd0, 0xca, 0x07, 0xd6, 0x6a, 0xcb, 0xef, 0x11, 0x11, 0x36, 0xa8, 0x90, 0xee,
+	0xdb, 0xed, 0xe0, 0x7e, 0xf7, 0xa4, 0x16, 0xab, 0x6e, 0x47, 0xe8, 0xee, 0x5d, 0x02, 0xe9, 0xee,
+	0x61, 0x2e, 0xa6, 0xf8, 0x93, 0x7d, 0x30, 0xb7, 0xf0, 0x42, 0x16, 0xeb, 0x3c, 0x6c, 0x22, 0xd5,
+	0x94, 0x01, 0x13, 0x12, 0xdd, 0xdd, 0x06, 0xc5, 0xcb, 0x32, 0x35, 0x46, 0x5e, 0x74, 0x79, 0xa8,
+	0x21, 0x42, 0x7a, 0x6a, 0x59, 0x7a, 0x18, 0xbe, 0xa0, 0xce, 0x68, 0x7e, 0x02, 0xbf, 0xcc, 0xa7,
+	0xee, 0xf1, 0xa9, 0x20, 0x2f, 0x68, 0x83, 0x4a, 0x5d, 0x10, 0x01, 0x8f, 0x67, 0x9c, 0x1a, 0xb3,
+	0x45, 0xc6, 0xa9, 0x31, 0xdb, 0x67, 0x6c, 0xe0, 0x9e, 0xe1, 0x48, 0xf1, 0x12, 0x16, 0x5e, 0xf8,
+	0xca, 0xd1, 0x86, 0x73, 0xa9, 0x27, 0x0d, 0x07, 0x63, 0x78, 0x9b, 0x8e, 0x84, 0xf3, 0x60, 0xe7,
+	0xda, 0x15, 0xbe, 0xd0, 0x8a, 0xdc, 0xa6, 0x3e, 0x92, 0xda, 0xa6, 0x21, 0x89, 0xcd, 0x7f, 0xe1,
+	0xb5, 0x69, 0xaa, 0x20, 0xcd, 0x3f, 0xaa, 0x29, 0xf3, 0x47, 0x50, 0x8c, 0x5c, 0xb2, 0x0f, 0xe3,
+	0x9f, 0x8f, 0x0a, 0x55, 0x94, 0x55, 0xc9, 0xef, 0xa6, 0xce, 0x76, 0x50, 0xc8, 0x73, 0x6f, 0x2b,
+	0x16, 0xfb, 0xe7, 0xc2, 0x0b, 0xeb, 0xdb, 0x5f, 0x42, 0x17, 0x19, 0xe4, 0x94, 0x7f, 0x62, 0x2a,
+	0x06, 0xff, 0x6f, 0xc2, 0x76, 0xda, 0xd7, 0xc2, 0xc1, 0xb9, 0x07, 0xab, 0x84, 0xac, 0x3f, 0x0f,
+	0x46, 0x58, 0x50, 0x1e, 0x56, 0xfc, 0x07, 0x22, 0xce, 0x38, 0x1e, 0xb2, 0x3f, 0xba, 0xe6, 0xa9,
+	0x58, 0xcd, 0xdf, 0x13, 0x76, 0x73, 0x08, 0x1e, 0x48, 0x58, 0xd6, 0xa5, 0x7c, 0xbf, 0x45, 0xd0,
+	0x8e, 0x0d, 0x75, 0x3c, 0xb8, 0xce, 0x91, 0xe1, 0xab, 0xa1, 0x6e, 0x94, 0x1b, 0x7d, 0x35, 0x34,
+	0xea, 0x55, 0xaf, 0x86, 0x0e, 0xc2, 0x5e, 0x78, 0x22, 0x0a, 0x3f, 0x93, 0x26, 0x0e, 0x3f, 0x35,
+	0xd2, 0x03, 0x26, 0xe5, 0x85, 0x1b, 0x68, 0xcc, 0x95, 0xb1, 0x37, 0xeb, 0x99, 0x9a, 0x49, 0xc3,
+	0x77, 0x47, 0xe6, 0x6d, 0x26, 0xa3, 0x4b, 0xec, 0xa5, 0x90, 0x18, 0xf3, 0x98, 0xbd, 0xd5, 0x0c,
+	0x51, 0x1d, 0x74, 0x6f, 0x6c, 0xc2, 0x50, 0xd4, 0x5b, 0x49, 0x06, 0x5b, 0x4e, 0x56, 0xa9, 0x99,
+	0x34, 0xc7, 0xca, 0x17, 0x92, 0xb4, 0x1c, 0xa4, 0xa7, 0x2c, 0xa7, 0x87, 0xe1, 0x7d, 0xcd, 0xc0,
+	0x81, 0xcf, 0xc0, 0xc8, 0x62, 0x29, 0x9a, 0xbe, 0x53, 0xcd, 0x1c, 0x42, 0xa9, 0x7d, 0xdd, 0x64,
+	0xf1, 0xbe, 0x1e, 0xaa, 0xc2, 0xb7, 0xc6, 0x44, 0xee, 0xeb, 0xa5, 0x9c, 0xda, 0x57, 0x4c, 0xf5,
+	0x36, 0x64, 0xae, 0x4d, 0x25, 0x85, 0x87, 0xb0, 0x42, 0x3f, 0xeb, 0xaa, 0x9e, 0x65, 0x72, 0x43,
+	0x46, 0xd8, 0xd4, 0x86, 0x8c, 0x1e, 0xc1, 0x1b, 0x52, 0x17, 0x37, 0x6e, 0xad, 0x51, 0x4d, 0x6d,
+	0x08, 0x82, 0xf0, 0x83, 0xe4, 0x31, 0x94, 0xda, 0x43, 0xd7, 0x3d, 0xea, 0x92, 0x31, 0x90, 0x7a,
+	0x90, 0xf4, 0xb9, 0x98, 0xe2, 0x9f, 0x09, 0xfb, 0x64, 0x6e, 0x75, 0xad, 0x35, 0xd9, 0x4f, 0x72,
+	0x50, 0xfb, 0xa2, 0x5a, 0xe7, 0xfe, 0xd8, 0x70, 0xb2, 0x1f, 0x23, 0x70, 0xc8, 0xfd, 0xf0, 0x5a,
+	0x67, 0x7a, 0x5f, 0x91, 0x46, 0x16, 0xae, 0xa3, 0x57, 0xf4, 0x57, 0x64, 0x00, 0x25, 0xbf, 0x22,
+	0x1b, 0x6c, 0xef, 0x73, 0x08, 0x61, 0x28, 0xc9, 0xc5, 0x84, 0xc1, 0x4c, 0xde, 0x4e, 0x43, 0xf8,
+	0x8d, 0x12, 0xf2, 0x66, 0xe0, 0xbc, 0xb0, 0xf5, 0x2f, 0x49, 0x55, 0x17, 0xa9, 0xd4, 0x1b, 0x85,
+	0x80, 0x63, 0xc6, 0xff, 0x27, 0xec, 0xd3, 0xda, 0x9d, 0xd0, 0xfe, 0x4d, 0xd5, 0xaa, 0x76, 0xdc,
+	0xf6, 0xd1, 0xf2, 0x68, 0xc4, 0xcd, 0x46, 0xf8, 0x50, 0xc6, 0x8f, 0xd7, 0x3d, 0x86, 0xc7, 0x16,
+	0xdf, 0x38, 0x39, 0xb6, 0x18, 0x48, 0x8d, 0x6d, 0x9f, 0x8b, 0x29, 0x9e, 0xb1, 0x1b, 0x33, 0xb1,
+	0x3c, 0xab, 0x0c, 0xa7, 0xfe, 0x65, 0xdd, 0x4a, 0x21, 0xec, 0x6e, 0x82, 0x08, 0x01, 0xbf, 0x9b,
+	0xd4, 0xbe, 0x7b, 0x22, 0x6c, 0xf9, 0xac, 0x02, 0x5b, 0x00, 0xfd, 0xd4, 0x43, 0x7a, 0xca, 0x77,
+	0x7b, 0x58, 0xc8, 0x70, 0x7a, 0xa3, 0xf9, 0x0f, 0x89, 0x87, 0xaf, 0x06, 0x00, 0x6e, 0x09, 0x97,
+	0x12, 0xdd, 0x10, 0x00, 0x00,
 }
@@ -20,6 +20,7 @@ It has these top-level messages:
 	SrvShard
 	ShardReference
 	SrvKeyspace
+	CellsAlias
 */
 package topodata
 
@@ -316,6 +317,9 @@ type Shard_TabletControl struct {
 	// what to do
 	DisableQueryService bool     `protobuf:"varint,3,opt,name=disable_query_service,json=disableQueryService" json:"disable_query_service,omitempty"`
 	BlacklistedTables   []string `protobuf:"bytes,4,rep,name=blacklisted_tables,json=blacklistedTables" json:"blacklisted_tables,omitempty"`
+	// enforce_key_range restricts the queries to the rows of the
+	// key range of the shard, with -enforce_key_range on the tablets
+	EnforceKeyRange bool `protobuf:"varint,5,opt,name=enforce_key_range,json=enforceKeyRange" json:"enforce_key_range,omitempty"`
 }
 
 func (m *Shard_TabletControl) Reset()                    { *m = Shard_TabletControl{} }
@@ -337,6 +341,10 @@ type Keyspace struct {
 	// ServedFrom will redirect the appropriate traffic to
 	// another keyspace.
 	ServedFroms []*Keyspace_ServedFrom `protobuf:"bytes,4,rep,name=served_froms,json=servedFroms" json:"served_froms,omitempty"`
+	// FallbackCells are the cells, in order, where vtgate sends the
+	// non-master queries of the keyspace when its own cell has no
+	// serving tablet. They override the -fallback-cells of vtgate.
+	FallbackCells []string `protobuf:"bytes,5,rep,name=fallback_cells,json=fallbackCells" json:"fallback_cells,omitempty"`
 }
 
 func (m *Keyspace) Reset()                    { *m = Keyspace{} }
@@ -501,6 +509,7 @@ type SrvKeyspace struct {
 	ShardingColumnType KeyspaceIdType            `protobuf:"varint,3,opt,name=sharding_column_type,json=shardingColumnType,enum=topodata.KeyspaceIdType" json:"sharding_column_type,omitempty"`
 	ServedFrom         []*SrvKeyspace_ServedFrom `protobuf:"bytes,4,rep,name=served_from,json=servedFrom" json:"served_from,omitempty"`
 	SplitShardCount    int32                     `protobuf:"varint,5,opt,name=split_shard_count,json=splitShardCount" json:"split_shard_count,omitempty"`
+	FallbackCells      []string                  `protobuf:"bytes,6,rep,name=fallback_cells,json=fallbackCells" json:"fallback_cells,omitempty"`
 }
 
 func (m *SrvKeyspace) Reset()                    { *m = SrvKeyspace{} }
@@ -557,6 +566,18 @@ func (m *SrvKeyspace_ServedFrom) String() string            { return proto.Compa
 func (*SrvKeyspace_ServedFrom) ProtoMessage()               {}
 func (*SrvKeyspace_ServedFrom) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{10, 1} }
 
+// CellsAlias is a named group of cells, e.g. the availability zones
+// of a region, that vtgate can treat as one locality.
+type CellsAlias struct {
+	// cells are the cells of the alias.
+	Cells []string `protobuf:"bytes,1,rep,name=cells" json:"cells,omitempty"`
+}
+
+func (m *CellsAlias) Reset()                    { *m = CellsAlias{} }
+func (m *CellsAlias) String() string            { return proto.CompactTextString(m) }
+func (*CellsAlias) ProtoMessage()               {}
+func (*CellsAlias) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{11} }
+
 func init() {
 	proto.RegisterType((*KeyRange)(nil), "topodata.KeyRange")
 	proto.RegisterType((*TabletAlias)(nil), "topodata.TabletAlias")
@@ -576,85 +597,90 @@ func init() {
 	proto.RegisterType((*SrvKeyspace)(nil), "topodata.SrvKeyspace")
 	proto.RegisterType((*SrvKeyspace_KeyspacePartition)(nil), "topodata.SrvKeyspace.KeyspacePartition")
 	proto.RegisterType((*SrvKeyspace_ServedFrom)(nil), "topodata.SrvKeyspace.ServedFrom")
+	proto.RegisterType((*CellsAlias)(nil), "topodata.CellsAlias")
 	proto.RegisterEnum("topodata.KeyspaceIdType", KeyspaceIdType_name, KeyspaceIdType_value)
 	proto.RegisterEnum("topodata.TabletType", TabletType_name, TabletType_value)
 }
 
 var fileDescriptor0 = []byte{
-	// 1196 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xb4, 0x57, 0xdd, 0x72, 0x22, 0x45,
-	0x14, 0x96, 0xe1, 0x27, 0x70, 0x60, 0xd9, 0xd9, 0x36, 0x6b, 0x4d, 0x8d, 0x65, 0x6d, 0xe4, 0xc6,
-	0xad, 0xa8, 0x68, 0x65, 0xfd, 0x89, 0x29, 0xb5, 0x42, 0x90, 0x75, 0x63, 0x12, 0x82, 0x0d, 0xa9,
-	0x35, 0x57, 0x53, 0x03, 0xf4, 0x26, 0x53, 0x0b, 0x33, 0xe3, 0x74, 0x43, 0x15, 0xcf, 0xb0, 0x17,
-	0xde, 0xfb, 0x04, 0x3e, 0x81, 0xb7, 0x3e, 0x91, 0x97, 0xde, 0xdb, 0x7d, 0x7a, 0x06, 0x06, 0x48,
-	0x62, 0xa2, 0xf1, 0x8a, 0x73, 0xfa, 0xfc, 0xf4, 0xf9, 0xfd, 0x7a, 0x80, 0xaa, 0x08, 0xc2, 0x60,
-	0xe8, 0x0a, 0xb7, 0x1e, 0x46, 0x81, 0x08, 0x48, 0x31, 0xe1, 0x6b, 0x3b, 0x50, 0x3c, 0x62, 0x33,
-	0xea, 0xfa, 0x17, 0x8c, 0x6c, 0x42, 0x9e, 0x0b, 0x37, 0x12, 0x56, 0x66, 0x2b, 0xf3, 0xb4, 0x42,
-	0x35, 0x43, 0x4c, 0xc8, 0x32, 0x7f, 0x68, 0x19, 0x78, 0xa6, 0xc8, 0xda, 0x33, 0x28, 0xf7, 0xdc,
-	0xfe, 0x88, 0x89, 0xc6, 0xc8, 0x73, 0x39, 0x21, 0x90, 0x1b, 0xb0, 0xd1, 0x08, 0xad, 0x4a, 0x14,
-	0x69, 0x65, 0x34, 0xf1, 0xb4, 0xd1, 0x03, 0xaa, 0xc8, 0xda, 0x5f, 0x39, 0x28, 0x68, 0x2b, 0xf2,
-	0x21, 0xe4, 0x5d, 0x65, 0x89, 0x16, 0xe5, 0x9d, 0xc7, 0xf5, 0x79, 0x74, 0x29, 0xb7, 0x54, 0xeb,
-	0x10, 0x1b, 0x8a, 0x97, 0x01, 0x17, 0xbe, 0x3b, 0x66, 0xe8, 0xae, 0x44, 0xe7, 0x3c, 0xa9, 0x82,
-	0xe1, 0x85, 0x56, 0x16, 0x4f, 0x25, 0x45, 0x76, 0xa1, 0x18, 0x06, 0x91, 0x70, 0xc6, 0x6e, 0x68,
-	0xe5, 0xb6, 0xb2, 0xd2, 0xf7, 0x7b, 0xab, 0xbe, 0xeb, 0x1d, 0xa9, 0x70, 0xe2, 0x86, 0x2d, 0x5f,
-	0x44, 0x33, 0xba, 0x11, 0x6a, 0x4e, 0xdd, 0xf2, 0x9a, 0xcd, 0x78, 0xe8, 0x0e, 0x98, 0x95, 0xd7,
-	0xb7, 0x24, 0x3c, 0x96, 0xe5, 0xd2, 0x8d, 0x86, 0x56, 0x01, 0x05, 0x9a, 0x21, 0x9f, 0x40, 0x49,
-	0x6a, 0x38, 0x91, 0xaa, 0x9c, 0xb5, 0x81, 0x89, 0x90, 0xc5, 0x65, 0x49, 0x4d, 0xd1, 0x8d, 0xae,
-	0xee, 0x53, 0xc8, 0x89, 0x59, 0xc8, 0xac, 0xa2, 0xd4, 0xad, 0xee, 0x6c, 0xae, 0x06, 0xd6, 0x93,
-	0x32, 0x8a, 0x1a, 0x52, 0xd3, 0x1c, 0xf6, 0x1d, 0x95, 0xa1, 0x13, 0x4c, 0x59, 0x14, 0x79, 0x43,
-	0x66, 0x95, 0xf0, 0xee, 0xea, 0xb0, 0xdf, 0x96, 0xc7, 0xa7, 0xf1, 0x29, 0xa9, 0x4b, 0x9f, 0xee,
-	0x05, 0xb7, 0x00, 0x93, 0xb5, 0xd7, 0x92, 0xed, 0x49, 0xa1, 0xce, 0x14, 0xf5, 0xc8, 0xb7, 0x00,
-	0x97, 0xcc, 0x1d, 0x89, 0x4b, 0x2c, 0x51, 0x19, 0xad, 0x9e, 0xac, 0x59, 0xbd, 0x40, 0x95, 0x79,
-	0x91, 0x4a, 0x97, 0x09, 0x6f, 0xef, 0x41, 0x25, 0x5d, 0x3f, 0xd5, 0x66, 0x99, 0x5f, 0xdc, 0x79,
-	0x45, 0xaa, 0x62, 0x4d, 0xdd, 0xd1, 0x44, 0xf7, 0x2a, 0x4f, 0x35, 0xb3, 0x67, 0xec, 0x66, 0xec,
-	0x2f, 0xa1, 0x34, 0x0f, 0xe7, 0x9f, 0x0c, 0x4b, 0x69, 0xc3, 0xaf, 0xa1, 0xba, 0x1c, 0xd1, 0x5d,
-	0xac, 0x6b, 0x6f, 0x0a, 0x90, 0xef, 0x62, 0xc7, 0x76, 0xa1, 0x32, 0x76, 0xb9, 0x60, 0x91, 0x73,
-	0x8b, 0xe9, 0x2b, 0x6b, 0x55, 0x3d, 0xe1, 0x4b, 0xbd, 0x36, 0x6e, 0xd1, 0xeb, 0x6f, 0xa0, 0xc2,
-	0x59, 0x34, 0x65, 0x43, 0x47, 0x35, 0x94, 0xcb, 0x11, 0x5d, 0xe9, 0x0f, 0x46, 0x54, 0xef, 0xa2,
-	0x0e, 0x76, 0xbe, 0xcc, 0xe7, 0x34, 0x27, 0xfb, 0xf0, 0x80, 0x07, 0x93, 0x68, 0xc0, 0x1c, 0x9c,
-	0x35, 0x1e, 0x0f, 0xf3, 0xbb, 0x6b, 0xf6, 0xa8, 0x84, 0x34, 0xad, 0xf0, 0x05, 0xc3, 0x55, 0x3d,
-	0xd4, 0x1e, 0x72, 0x39, 0xcc, 0x59, 0x55, 0x0f, 0x64, 0xc8, 0x73, 0x78, 0x28, 0x30, 0x47, 0x67,
-	0x10, 0xc8, 0x42, 0x06, 0x52, 0x5e, 0x58, 0x5d, 0x13, 0xed, 0x59, 0x97, 0xa2, 0xa9, 0xb5, 0x68,
-	0x55, 0xa4, 0x59, 0x6e, 0x9f, 0x03, 0x2c, 0x42, 0x27, 0x9f, 0x43, 0x39, 0xf6, 0x8a, 0xf3, 0x9d,
-	0xb9, 0x61, 0xbe, 0x41, 0xcc, 0xe9, 0x45, 0x88, 0x46, 0x2a, 0x44, 0xfb, 0xd7, 0x0c, 0x94, 0x53,
-	0x69, 0x25, 0x40, 0x92, 0x99, 0x03, 0xc9, 0xd2, 0xaa, 0x1a, 0xd7, 0xad, 0x6a, 0xf6, 0xda, 0x55,
-	0xcd, 0xdd, 0xa2, 0x7d, 0xef, 0x40, 0x01, 0x03, 0x4d, 0xca, 0x17, 0x73, 0xf6, 0x1f, 0x19, 0x78,
-	0xb0, 0x54, 0x99, 0x7b, 0xcd, 0x9d, 0xec, 0xc0, 0xe3, 0xa1, 0xc7, 0x95, 0x96, 0xf3, 0xf3, 0x84,
-	0x45, 0x33, 0x47, 0xcd, 0x84, 0x27, 0xd3, 0x54, 0xd9, 0x14, 0xe9, 0xdb, 0xb1, 0xf0, 0x47, 0x25,
-	0xeb, 0x6a, 0x11, 0xf9, 0x18, 0x48, 0x7f, 0xe4, 0x0e, 0x5e, 0x8f, 0x3c, 0x39, 0xae, 0x72, 0xdc,
-	0x74, 0xd8, 0x39, 0x74, 0xfb, 0x28, 0x25, 0xc1, 0x40, 0x78, 0xed, 0x4f, 0x03, 0xf1, 0x5e, 0x57,
-	0xeb, 0x53, 0xd8, 0xc4, 0x02, 0x79, 0xfe, 0x85, 0x1c, 0x88, 0xd1, 0x64, 0xec, 0x23, 0xe8, 0xc4,
-	0x7b, 0x45, 0x12, 0x59, 0x13, 0x45, 0x0a, 0x77, 0xc8, 0x0f, 0xeb, 0x16, 0x98, 0xb7, 0x81, 0x79,
-	0x5b, 0x4b, 0x45, 0xc5, 0x3b, 0x0e, 0xf5, 0x74, 0xaf, 0xf8, 0xc2, 0x1a, 0x6c, 0xc3, 0x23, 0x1e,
-	0x8e, 0x3c, 0xa1, 0x67, 0x5c, 0xba, 0x9b, 0xf8, 0x02, 0x33, 0xcd, 0xd3, 0x87, 0x28, 0xc0, 0x01,
-	0x68, 0xaa, 0x63, 0xb9, 0x10, 0xc9, 0x3e, 0xbd, 0x8a, 0x82, 0x31, 0x5f, 0x07, 0xf7, 0xe4, 0xbe,
-	0x78, 0xa5, 0x9e, 0x4b, 0xad, 0x64, 0xa5, 0x14, 0xcd, 0xed, 0x49, 0x32, 0xb2, 0x8a, 0xbd, 0xdf,
-	0xb6, 0xa5, 0x07, 0x32, 0xbb, 0x3c, 0x90, 0xb5, 0x37, 0x19, 0x30, 0xf5, 0x7e, 0x32, 0x99, 0xd2,
-	0xc0, 0x15, 0x5e, 0xe0, 0xcb, 0xdb, 0xf3, 0x7e, 0x30, 0x64, 0x0a, 0x81, 0x56, 0x00, 0x78, 0x55,
-	0xb5, 0xde, 0x96, 0x7a, 0x54, 0x6b, 0xdb, 0xfb, 0x90, 0x53, 0xac, 0xc2, 0xb1, 0x38, 0xf8, 0xdb,
-	0xe0, 0x98, 0x58, 0x30, 0xb5, 0xdf, 0x64, 0xf7, 0x5b, 0xfe, 0xb0, 0x13, 0x78, 0xbe, 0xb8, 0x62,
-	0xb3, 0xe4, 0x43, 0xae, 0x9e, 0xd6, 0x78, 0xab, 0x90, 0x26, 0x7b, 0xa9, 0x27, 0x35, 0xbb, 0x1a,
-	0x6e, 0xe2, 0xeb, 0x9a, 0x47, 0x75, 0x7f, 0xe9, 0xb5, 0xd1, 0x3d, 0x7b, 0xff, 0x0a, 0xeb, 0xff,
-	0xe7, 0xbd, 0xf9, 0x6f, 0xcf, 0xc6, 0x57, 0x50, 0x4a, 0xe2, 0xe3, 0xe4, 0x23, 0xd8, 0x60, 0xd2,
-	0x83, 0x37, 0x6f, 0x19, 0x59, 0xcf, 0x82, 0x26, 0x2a, 0xb5, 0x10, 0x8a, 0xdd, 0x68, 0xaa, 0xe1,
-	0x4b, 0x96, 0x34, 0xb5, 0x52, 0x48, 0xdf, 0xfd, 0x35, 0x79, 0x02, 0xf1, 0x6b, 0xe4, 0xe0, 0x77,
-	0x96, 0x9e, 0x31, 0xd0, 0x47, 0x4d, 0x79, 0x52, 0x3b, 0x83, 0x6a, 0x3c, 0x39, 0xaf, 0x58, 0xc4,
-	0x7c, 0xb9, 0xda, 0xf7, 0x71, 0x6f, 0xed, 0xf7, 0x9c, 0xc4, 0xe2, 0x68, 0x3a, 0xc7, 0x8b, 0xef,
-	0x01, 0x42, 0xf9, 0x45, 0xe8, 0xa9, 0xc9, 0x4c, 0x2a, 0xf1, 0x41, 0x6a, 0x78, 0x17, 0xaa, 0xf3,
-	0x7d, 0xec, 0x24, 0xfa, 0x34, 0x65, 0x7a, 0x2d, 0xf0, 0x18, 0x77, 0x06, 0x9e, 0xec, 0xbf, 0x00,
-	0x9e, 0x06, 0x94, 0x53, 0x60, 0x12, 0xcf, 0xe5, 0xd6, 0xd5, 0x79, 0xa4, 0xe0, 0x04, 0x16, 0x70,
-	0x72, 0x35, 0x76, 0xe5, 0xaf, 0xc4, 0x2e, 0xfb, 0x97, 0x0c, 0x3c, 0x5a, 0x2b, 0x87, 0x42, 0xa0,
-	0xd4, 0x17, 0xc2, 0xcd, 0x08, 0xb4, 0xf8, 0x34, 0x20, 0x4d, 0x30, 0xf5, 0x95, 0x51, 0xd2, 0x6a,
-	0x0d, 0x46, 0xe5, 0x74, 0x0d, 0x96, 0x67, 0x41, 0x46, 0xb4, 0xc4, 0x73, 0xdb, 0xb9, 0x0f, 0x2c,
-	0xbc, 0xe1, 0x19, 0xde, 0xde, 0x81, 0xea, 0x72, 0x1f, 0x48, 0x09, 0xf2, 0x67, 0xed, 0x6e, 0xab,
-	0x67, 0xbe, 0x45, 0x00, 0x0a, 0x67, 0x87, 0xed, 0xde, 0x17, 0x9f, 0x99, 0x19, 0x75, 0x7c, 0x70,
-	0xde, 0x6b, 0x75, 0x4d, 0x63, 0x5b, 0x96, 0x09, 0x16, 0x57, 0x91, 0x32, 0x6c, 0x9c, 0xb5, 0x8f,
-	0xda, 0xa7, 0x2f, 0xdb, 0xda, 0xe4, 0xa4, 0xd1, 0xed, 0xb5, 0xa8, 0x34, 0x91, 0x02, 0xda, 0xea,
-	0x1c, 0x1f, 0x36, 0x1b, 0xa6, 0xa1, 0x04, 0xf4, 0xbb, 0xd3, 0xf6, 0xf1, 0xb9, 0x99, 0x45, 0x5f,
-	0x8d, 0x5e, 0xf3, 0x85, 0x26, 0xbb, 0x9d, 0x06, 0x6d, 0x99, 0x39, 0xb9, 0xf3, 0x95, 0xd6, 0x4f,
-	0x9d, 0x16, 0x3d, 0x3c, 0x69, 0xb5, 0x7b, 0x8d, 0x63, 0x33, 0xaf, 0x6c, 0x0e, 0x1a, 0xcd, 0xa3,
-	0xb3, 0x8e, 0x59, 0xd0, 0xce, 0xba, 0xbd, 0x53, 0xa9, 0xba, 0xa1, 0x04, 0x2f, 0x4f, 0xe9, 0x91,
-	0xbc, 0xa5, 0x68, 0x1b, 0x66, 0xe6, 0xc0, 0x06, 0x6b, 0x10, 0x8c, 0xeb, 0xb3, 0x60, 0x22, 0x26,
-	0x7d, 0x56, 0x9f, 0x7a, 0x82, 0x71, 0xae, 0xff, 0x40, 0xf5, 0x0b, 0xf8, 0xf3, 0xec, 0xef, 0x00,
-	0x00, 0x00, 0xff, 0xff, 0xb0, 0x7e, 0xeb, 0x5d, 0x59, 0x0d, 0x00, 0x00,
+	// 1256 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xb4, 0x57, 0xdd, 0x72, 0xdb, 0xc4,
+	0x17, 0xff, 0x4b, 0xfe, 0x88, 0x7d, 0x9c, 0x38, 0xca, 0xfe, 0x5b, 0x46, 0x63, 0x86, 0x69, 0xd0,
+	0x0c, 0x43, 0x26, 0x80, 0x61, 0x52, 0x0a, 0xa5, 0x03, 0x4c, 0x5c, 0xa3, 0xd2, 0x90, 0xd6, 0x31,
+	0x6b, 0x67, 0x4a, 0xaf, 0x34, 0xb2, 0xbc, 0x49, 0x34, 0x91, 0x25, 0xa1, 0x5d, 0x67, 0xc6, 0xcf,
+	0xc0, 0x45, 0x6f, 0xb8, 0xe2, 0x09, 0x78, 0x03, 0x9e, 0x86, 0x27, 0xe0, 0x9a, 0x7b, 0x66, 0xcf,
+	0x4a, 0xb2, 0xfc, 0x91, 0x92, 0x42, 0xb8, 0xf2, 0x39, 0x7b, 0x3e, 0xf6, 0x7c, 0xfe, 0x56, 0x86,
+	0xa6, 0x88, 0xe2, 0x68, 0xec, 0x0a, 0xb7, 0x1d, 0x27, 0x91, 0x88, 0x48, 0x2d, 0xe3, 0xad, 0x03,
+	0xa8, 0x1d, 0xb3, 0x19, 0x75, 0xc3, 0x73, 0x46, 0xee, 0x40, 0x85, 0x0b, 0x37, 0x11, 0xa6, 0xb6,
+	0xab, 0xed, 0x6d, 0x52, 0xc5, 0x10, 0x03, 0x4a, 0x2c, 0x1c, 0x9b, 0x3a, 0x9e, 0x49, 0xd2, 0xba,
+	0x0f, 0x8d, 0xa1, 0x3b, 0x0a, 0x98, 0xe8, 0x04, 0xbe, 0xcb, 0x09, 0x81, 0xb2, 0xc7, 0x82, 0x00,
+	0xad, 0xea, 0x14, 0x69, 0x69, 0x34, 0xf5, 0x95, 0xd1, 0x16, 0x95, 0xa4, 0xf5, 0x67, 0x19, 0xaa,
+	0xca, 0x8a, 0x7c, 0x00, 0x15, 0x57, 0x5a, 0xa2, 0x45, 0xe3, 0xe0, 0x6e, 0x3b, 0x8f, 0xae, 0xe0,
+	0x96, 0x2a, 0x1d, 0xd2, 0x82, 0xda, 0x45, 0xc4, 0x45, 0xe8, 0x4e, 0x18, 0xba, 0xab, 0xd3, 0x9c,
+	0x27, 0x4d, 0xd0, 0xfd, 0xd8, 0x2c, 0xe1, 0xa9, 0xee, 0xc7, 0xe4, 0x21, 0xd4, 0xe2, 0x28, 0x11,
+	0xce, 0xc4, 0x8d, 0xcd, 0xf2, 0x6e, 0x69, 0xaf, 0x71, 0xf0, 0xce, 0xb2, 0xef, 0x76, 0x3f, 0x4a,
+	0xc4, 0x73, 0x37, 0xb6, 0x43, 0x91, 0xcc, 0xe8, 0x46, 0xac, 0x38, 0x79, 0xcb, 0x25, 0x9b, 0xf1,
+	0xd8, 0xf5, 0x98, 0x59, 0x51, 0xb7, 0x64, 0x3c, 0x96, 0xe5, 0xc2, 0x4d, 0xc6, 0x66, 0x15, 0x05,
+	0x8a, 0x21, 0x1f, 0x43, 0xfd, 0x92, 0xcd, 0x9c, 0x44, 0x56, 0xce, 0xdc, 0xc0, 0x44, 0xc8, 0xfc,
+	0xb2, 0xac, 0xa6, 0xe8, 0x06, 0x29, 0xb2, 0x07, 0x65, 0x31, 0x8b, 0x99, 0x59, 0xdb, 0xd5, 0xf6,
+	0x9a, 0x07, 0x77, 0x96, 0x03, 0x1b, 0xce, 0x62, 0x46, 0x51, 0x83, 0xec, 0x81, 0x31, 0x1e, 0x39,
+	0x32, 0x43, 0x27, 0xba, 0x62, 0x49, 0xe2, 0x8f, 0x99, 0x59, 0xc7, 0xbb, 0x9b, 0xe3, 0x51, 0xcf,
+	0x9d, 0xb0, 0x93, 0xf4, 0x94, 0xb4, 0xa1, 0x2c, 0xdc, 0x73, 0x6e, 0x02, 0x26, 0xdb, 0x5a, 0x49,
+	0x76, 0xe8, 0x9e, 0x73, 0x95, 0x29, 0xea, 0x91, 0xaf, 0x01, 0x2e, 0x98, 0x1b, 0x88, 0x0b, 0x2c,
+	0x51, 0x03, 0xad, 0xee, 0xad, 0x58, 0x3d, 0x45, 0x95, 0xbc, 0x48, 0xf5, 0x8b, 0x8c, 0x6f, 0x3d,
+	0x82, 0xcd, 0x62, 0xfd, 0x64, 0x9b, 0x2f, 0xd9, 0x2c, 0xed, 0xbc, 0x24, 0x65, 0xb1, 0xae, 0xdc,
+	0x60, 0xaa, 0x7a, 0x55, 0xa1, 0x8a, 0x79, 0xa4, 0x3f, 0xd4, 0x5a, 0x9f, 0x43, 0x3d, 0x0f, 0xe7,
+	0xef, 0x0c, 0xeb, 0x45, 0xc3, 0x2f, 0xa1, 0xb9, 0x18, 0xd1, 0x9b, 0x58, 0x5b, 0xbf, 0x55, 0xa1,
+	0x32, 0xc0, 0x8e, 0x3d, 0x84, 0xcd, 0x89, 0xcb, 0x05, 0x4b, 0x9c, 0x1b, 0x4c, 0x5f, 0x43, 0xa9,
+	0x22, 0xb3, 0xd8, 0x6b, 0xfd, 0x06, 0xbd, 0xfe, 0x0a, 0x36, 0x39, 0x4b, 0xae, 0xd8, 0xd8, 0x91,
+	0x0d, 0xe5, 0x66, 0x69, 0xb9, 0x3f, 0x18, 0x51, 0x7b, 0x80, 0x3a, 0xd8, 0xf9, 0x06, 0xcf, 0x69,
+	0x4e, 0x0e, 0x61, 0x8b, 0x47, 0xd3, 0xc4, 0x63, 0x0e, 0xce, 0x1a, 0x4f, 0x87, 0xf9, 0xed, 0x15,
+	0x7b, 0x54, 0x42, 0x9a, 0x6e, 0xf2, 0x39, 0xc3, 0x65, 0x3d, 0xe4, 0x1e, 0x72, 0xb3, 0xb2, 0x5b,
+	0x92, 0xf5, 0x40, 0x86, 0x3c, 0x81, 0x6d, 0x81, 0x39, 0x3a, 0x5e, 0x14, 0x8a, 0x24, 0x0a, 0xb8,
+	0x59, 0x5d, 0x5e, 0x13, 0xe5, 0x59, 0x95, 0xa2, 0xab, 0xb4, 0x68, 0x53, 0x14, 0x59, 0xde, 0x7a,
+	0x09, 0x30, 0x0f, 0x9d, 0x3c, 0x80, 0x46, 0xea, 0x15, 0xe7, 0x5b, 0x7b, 0xcd, 0x7c, 0x83, 0xc8,
+	0xe9, 0x79, 0x88, 0x7a, 0x21, 0xc4, 0xd6, 0x2f, 0x1a, 0x34, 0x0a, 0x69, 0x65, 0x40, 0xa2, 0xe5,
+	0x40, 0xb2, 0xb0, 0xaa, 0xfa, 0x75, 0xab, 0x5a, 0xba, 0x76, 0x55, 0xcb, 0x37, 0x68, 0xdf, 0x5b,
+	0x50, 0xc5, 0x40, 0xb3, 0xf2, 0xa5, 0x5c, 0xeb, 0x0f, 0x0d, 0xb6, 0x16, 0x2a, 0x73, 0xab, 0xb9,
+	0x93, 0x03, 0xb8, 0x3b, 0xf6, 0xb9, 0xd4, 0x72, 0x7e, 0x9c, 0xb2, 0x64, 0xe6, 0xc8, 0x99, 0xf0,
+	0x3d, 0x86, 0xd9, 0xd4, 0xe8, 0xff, 0x53, 0xe1, 0xf7, 0x52, 0x36, 0x50, 0x22, 0xf2, 0x11, 0x90,
+	0x51, 0xe0, 0x7a, 0x97, 0x81, 0xcf, 0x85, 0x1c, 0x37, 0x15, 0x76, 0x19, 0xdd, 0xee, 0x14, 0x24,
+	0x18, 0x08, 0x27, 0xfb, 0xb0, 0xc3, 0xc2, 0xb3, 0x48, 0x8e, 0xd6, 0xbc, 0x24, 0x15, 0x74, 0xbf,
+	0x9d, 0x0a, 0xb2, 0x7a, 0x58, 0x3f, 0x97, 0xf0, 0x6d, 0x50, 0x95, 0xfd, 0x04, 0xee, 0x60, 0x31,
+	0xfd, 0xf0, 0xdc, 0xf1, 0xa2, 0x60, 0x3a, 0x09, 0x11, 0xa0, 0xd2, 0x1d, 0x24, 0x99, 0xac, 0x8b,
+	0x22, 0x89, 0x51, 0xe4, 0xbb, 0x55, 0x0b, 0xac, 0x91, 0x8e, 0x35, 0x32, 0x17, 0x1a, 0x80, 0x77,
+	0x1c, 0xa9, 0x4d, 0x58, 0xf2, 0x85, 0xf5, 0xda, 0x87, 0x1d, 0x1e, 0x07, 0xbe, 0x50, 0xfb, 0xe0,
+	0x78, 0xd1, 0x34, 0x14, 0x58, 0x95, 0x0a, 0xdd, 0x46, 0x01, 0x0e, 0x4b, 0x57, 0x1e, 0x93, 0xc3,
+	0x7c, 0xf7, 0xce, 0x92, 0x68, 0xc2, 0x57, 0x1f, 0x82, 0xec, 0xbe, 0x74, 0xfd, 0x9e, 0x24, 0xd1,
+	0x24, 0x5b, 0x3f, 0x49, 0x73, 0xf2, 0x1e, 0x34, 0xcf, 0xdc, 0x20, 0x18, 0xb9, 0xde, 0xa5, 0x53,
+	0xdc, 0xa2, 0xad, 0xec, 0xb4, 0x8b, 0xa3, 0x3a, 0xcd, 0xb6, 0x40, 0x5a, 0xdd, 0xee, 0x24, 0x14,
+	0x67, 0xbc, 0xb4, 0x38, 0xe3, 0xd6, 0x4f, 0x1a, 0x18, 0x6a, 0xe5, 0x59, 0x1c, 0xf8, 0x9e, 0x2b,
+	0xfc, 0x28, 0x24, 0x0f, 0xa0, 0x12, 0x46, 0x63, 0x26, 0x41, 0x6d, 0x09, 0xd3, 0x97, 0x55, 0xdb,
+	0xbd, 0x68, 0xcc, 0xa8, 0xd2, 0x6e, 0x1d, 0x42, 0x59, 0xb2, 0x12, 0x1a, 0xd3, 0xe0, 0x6f, 0x02,
+	0x8d, 0x62, 0xce, 0x58, 0xbf, 0xea, 0x50, 0xb3, 0xc3, 0x71, 0x3f, 0xf2, 0x43, 0xb1, 0x66, 0x59,
+	0x09, 0x94, 0xe5, 0x6b, 0x9d, 0x2e, 0x2a, 0xd2, 0xe4, 0x51, 0xe1, 0x95, 0x2e, 0x2d, 0x87, 0x9b,
+	0xf9, 0xba, 0xe6, 0x9d, 0x3e, 0x5c, 0x78, 0xc0, 0x54, 0x6b, 0xdf, 0x5d, 0x63, 0xfd, 0xdf, 0x3c,
+	0x61, 0xff, 0xee, 0x25, 0xfa, 0x02, 0xea, 0x59, 0x7c, 0x9c, 0x7c, 0x08, 0x1b, 0x2c, 0x14, 0x89,
+	0x9f, 0xb7, 0x8c, 0xac, 0x66, 0x41, 0x33, 0x15, 0x2b, 0x86, 0xda, 0x20, 0xb9, 0x52, 0x88, 0x48,
+	0xa0, 0x5c, 0xd8, 0x3c, 0xa4, 0xdf, 0xfc, 0x81, 0xba, 0x07, 0xe9, 0x03, 0x87, 0x03, 0x9e, 0xce,
+	0x18, 0xa8, 0x23, 0x39, 0xdd, 0xd6, 0x29, 0x34, 0xd3, 0xc9, 0x39, 0x63, 0x09, 0x0b, 0x3d, 0x76,
+	0x2b, 0xf7, 0x5a, 0xbf, 0x97, 0xa1, 0x31, 0x48, 0xae, 0x72, 0x58, 0xf9, 0x16, 0x20, 0x76, 0x13,
+	0xe1, 0xcb, 0xc9, 0xcc, 0x2a, 0xf1, 0x7e, 0x61, 0x78, 0xe7, 0xaa, 0xf9, 0xda, 0xf6, 0x33, 0x7d,
+	0x5a, 0x30, 0xbd, 0x16, 0x9f, 0xf4, 0x37, 0xc6, 0xa7, 0xd2, 0x3f, 0xc0, 0xa7, 0x0e, 0x34, 0x0a,
+	0x98, 0x93, 0xce, 0xe5, 0xee, 0xfa, 0x3c, 0x0a, 0xa8, 0x03, 0x73, 0xd4, 0x59, 0x0f, 0x71, 0x95,
+	0xf5, 0x10, 0xb7, 0x0a, 0x50, 0xd5, 0x75, 0x00, 0xf5, 0x4a, 0x83, 0x9d, 0x95, 0xaa, 0x49, 0xa0,
+	0x2a, 0x7c, 0x9b, 0xbc, 0x1e, 0xa8, 0xe6, 0x1f, 0x25, 0xa4, 0x0b, 0x86, 0x8a, 0x2c, 0xc9, 0x26,
+	0x42, 0x61, 0x56, 0xa3, 0x58, 0xaa, 0xc5, 0x91, 0xa1, 0xdb, 0x7c, 0x81, 0xe7, 0x2d, 0xe7, 0x36,
+	0x20, 0xf3, 0x35, 0x1f, 0x00, 0x96, 0x05, 0x80, 0xb9, 0xab, 0xef, 0xb6, 0x1c, 0x5c, 0xb5, 0x02,
+	0xb8, 0xee, 0x1f, 0x40, 0x73, 0xb1, 0xa5, 0xa4, 0x0e, 0x95, 0xd3, 0xde, 0xc0, 0x1e, 0x1a, 0xff,
+	0x23, 0x00, 0xd5, 0xd3, 0xa3, 0xde, 0xf0, 0xb3, 0x4f, 0x0d, 0x4d, 0x1e, 0x3f, 0x7e, 0x39, 0xb4,
+	0x07, 0x86, 0xbe, 0xff, 0x4a, 0x03, 0x98, 0x87, 0x43, 0x1a, 0xb0, 0x71, 0xda, 0x3b, 0xee, 0x9d,
+	0xbc, 0xe8, 0x29, 0x93, 0xe7, 0x9d, 0xc1, 0xd0, 0xa6, 0x86, 0x26, 0x05, 0xd4, 0xee, 0x3f, 0x3b,
+	0xea, 0x76, 0x0c, 0x5d, 0x0a, 0xe8, 0x37, 0x27, 0xbd, 0x67, 0x2f, 0x8d, 0x12, 0xfa, 0xea, 0x0c,
+	0xbb, 0x4f, 0x15, 0x39, 0xe8, 0x77, 0xa8, 0x6d, 0x94, 0x89, 0x01, 0x9b, 0xf6, 0x0f, 0x7d, 0x9b,
+	0x1e, 0x3d, 0xb7, 0x7b, 0xc3, 0xce, 0x33, 0xa3, 0x22, 0x6d, 0x1e, 0x77, 0xba, 0xc7, 0xa7, 0x7d,
+	0xa3, 0xaa, 0x9c, 0x0d, 0x86, 0x27, 0xd4, 0x36, 0x36, 0xa4, 0xe0, 0xc5, 0x09, 0x3d, 0xb6, 0xa9,
+	0x51, 0x6b, 0xe9, 0x86, 0xf6, 0xb8, 0x05, 0xa6, 0x17, 0x4d, 0xda, 0xb3, 0x68, 0x2a, 0xa6, 0x23,
+	0xd6, 0xbe, 0xf2, 0x05, 0xe3, 0x5c, 0xfd, 0xbd, 0x1b, 0x55, 0xf1, 0xe7, 0xfe, 0x5f, 0x03, 0x00,
+	0xff, 0x3b, 0xd4, 0x28, 0xf7, 0x0d, 0x00, 0x00,
 }
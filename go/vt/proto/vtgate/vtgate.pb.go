@@ -44,6 +44,17 @@ It has these top-level messages:
 	SplitQueryResponse
 	GetSrvKeyspaceRequest
 	GetSrvKeyspaceResponse
+	PrepareRequest
+	PrepareResponse
+	ExecutePreparedRequest
+	ExecutePreparedResponse
+	ClosePreparedRequest
+	ClosePreparedResponse
+	MultiplexRequest
+	MultiplexResponse
+	GetKeyspaceSchemaRequest
+	SchemaDrift
+	GetKeyspaceSchemaResponse
 */
 package vtgate
 
@@ -51,6 +62,7 @@ import proto "github.com/golang/protobuf/proto"
 import fmt "fmt"
 import math "math"
 import query "github.com/youtube/vitess/go/vt/proto/query"
+import tabletmanagerdata "github.com/youtube/vitess/go/vt/proto/tabletmanagerdata"
 import topodata "github.com/youtube/vitess/go/vt/proto/topodata"
 import vtrpc "github.com/youtube/vitess/go/vt/proto/vtrpc"
 
@@ -69,6 +81,13 @@ const _ = proto.ProtoPackageIsVersion1
 type Session struct {
 	InTransaction bool                    `protobuf:"varint,1,opt,name=in_transaction,json=inTransaction" json:"in_transaction,omitempty"`
 	ShardSessions []*Session_ShardSession `protobuf:"bytes,2,rep,name=shard_sessions,json=shardSessions" json:"shard_sessions,omitempty"`
+	// shard_gtids are used by vtgate -session-gtid-consistency to read
+	// the writes of the session on the replicas.
+	ShardGtids []*Session_ShardGtid `protobuf:"bytes,3,rep,name=shard_gtids,json=shardGtids" json:"shard_gtids,omitempty"`
+	// max_scatter_parallelism overrides vtgate -max-scatter-parallelism
+	// for the queries of the session. It's set by
+	// SET VITESS_MAX_SCATTER_PARALLELISM = N.
+	MaxScatterParallelism int64 `protobuf:"varint,4,opt,name=max_scatter_parallelism,json=maxScatterParallelism" json:"max_scatter_parallelism,omitempty"`
 }
 
 func (m *Session) Reset()                    { *m = Session{} }
@@ -83,6 +102,13 @@ func (m *Session) GetShardSessions() []*Session_ShardSession {
 	return nil
 }
 
+func (m *Session) GetShardGtids() []*Session_ShardGtid {
+	if m != nil {
+		return m.ShardGtids
+	}
+	return nil
+}
+
 type Session_ShardSession struct {
 	Target        *query.Target `protobuf:"bytes,1,opt,name=target" json:"target,omitempty"`
 	TransactionId int64         `protobuf:"varint,2,opt,name=transaction_id,json=transactionId" json:"transaction_id,omitempty"`
@@ -100,6 +126,19 @@ func (m *Session_ShardSession) GetTarget() *query.Target {
 	return nil
 }
 
+// ShardGtid is the replication position of a shard after the last
+// commit of the session on it.
+type Session_ShardGtid struct {
+	Keyspace string `protobuf:"bytes,1,opt,name=keyspace" json:"keyspace,omitempty"`
+	Shard    string `protobuf:"bytes,2,opt,name=shard" json:"shard,omitempty"`
+	Gtid     string `protobuf:"bytes,3,opt,name=gtid" json:"gtid,omitempty"`
+}
+
+func (m *Session_ShardGtid) Reset()                    { *m = Session_ShardGtid{} }
+func (m *Session_ShardGtid) String() string            { return proto.CompactTextString(m) }
+func (*Session_ShardGtid) ProtoMessage()               {}
+func (*Session_ShardGtid) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{0, 1} }
+
 // ExecuteRequest is the payload to Execute.
 type ExecuteRequest struct {
 	// caller_id identifies the caller. This is the effective caller ID,
@@ -778,6 +817,10 @@ type StreamExecuteRequest struct {
 	TabletType topodata.TabletType `protobuf:"varint,3,opt,name=tablet_type,json=tabletType,enum=topodata.TabletType" json:"tablet_type,omitempty"`
 	// keyspace to target the query to.
 	Keyspace string `protobuf:"bytes,4,opt,name=keyspace" json:"keyspace,omitempty"`
+	// max_scatter_parallelism overrides vtgate -max-scatter-parallelism
+	// for the query, like Session.max_scatter_parallelism for the
+	// non-streaming queries.
+	MaxScatterParallelism int64 `protobuf:"varint,5,opt,name=max_scatter_parallelism,json=maxScatterParallelism" json:"max_scatter_parallelism,omitempty"`
 }
 
 func (m *StreamExecuteRequest) Reset()                    { *m = StreamExecuteRequest{} }
@@ -832,6 +875,10 @@ type StreamExecuteShardsRequest struct {
 	Shards []string `protobuf:"bytes,4,rep,name=shards" json:"shards,omitempty"`
 	// tablet_type is the type of tablets that this query is targeted to.
 	TabletType topodata.TabletType `protobuf:"varint,5,opt,name=tablet_type,json=tabletType,enum=topodata.TabletType" json:"tablet_type,omitempty"`
+	// max_scatter_parallelism overrides vtgate -max-scatter-parallelism
+	// for the query, like Session.max_scatter_parallelism for the
+	// non-streaming queries.
+	MaxScatterParallelism int64 `protobuf:"varint,6,opt,name=max_scatter_parallelism,json=maxScatterParallelism" json:"max_scatter_parallelism,omitempty"`
 }
 
 func (m *StreamExecuteShardsRequest) Reset()                    { *m = StreamExecuteShardsRequest{} }
@@ -887,6 +934,10 @@ type StreamExecuteKeyspaceIdsRequest struct {
 	KeyspaceIds [][]byte `protobuf:"bytes,4,rep,name=keyspace_ids,json=keyspaceIds,proto3" json:"keyspace_ids,omitempty"`
 	// tablet_type is the type of tablets that this query is targeted to.
 	TabletType topodata.TabletType `protobuf:"varint,5,opt,name=tablet_type,json=tabletType,enum=topodata.TabletType" json:"tablet_type,omitempty"`
+	// max_scatter_parallelism overrides vtgate -max-scatter-parallelism
+	// for the query, like Session.max_scatter_parallelism for the
+	// non-streaming queries.
+	MaxScatterParallelism int64 `protobuf:"varint,6,opt,name=max_scatter_parallelism,json=maxScatterParallelism" json:"max_scatter_parallelism,omitempty"`
 }
 
 func (m *StreamExecuteKeyspaceIdsRequest) Reset()         { *m = StreamExecuteKeyspaceIdsRequest{} }
@@ -946,6 +997,10 @@ type StreamExecuteKeyRangesRequest struct {
 	KeyRanges []*topodata.KeyRange `protobuf:"bytes,4,rep,name=key_ranges,json=keyRanges" json:"key_ranges,omitempty"`
 	// tablet_type is the type of tablets that this query is targeted to.
 	TabletType topodata.TabletType `protobuf:"varint,5,opt,name=tablet_type,json=tabletType,enum=topodata.TabletType" json:"tablet_type,omitempty"`
+	// max_scatter_parallelism overrides vtgate -max-scatter-parallelism
+	// for the query, like Session.max_scatter_parallelism for the
+	// non-streaming queries.
+	MaxScatterParallelism int64 `protobuf:"varint,6,opt,name=max_scatter_parallelism,json=maxScatterParallelism" json:"max_scatter_parallelism,omitempty"`
 }
 
 func (m *StreamExecuteKeyRangesRequest) Reset()                    { *m = StreamExecuteKeyRangesRequest{} }
@@ -1061,6 +1116,9 @@ func (m *CommitRequest) GetSession() *Session {
 
 // CommitResponse is the returned value from Commit.
 type CommitResponse struct {
+	// session carries the GTIDs of the commit, with
+	// -session-gtid-consistency.
+	Session *Session `protobuf:"bytes,1,opt,name=session" json:"session,omitempty"`
 }
 
 func (m *CommitResponse) Reset()                    { *m = CommitResponse{} }
@@ -1068,6 +1126,13 @@ func (m *CommitResponse) String() string            { return proto.CompactTextSt
 func (*CommitResponse) ProtoMessage()               {}
 func (*CommitResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{28} }
 
+func (m *CommitResponse) GetSession() *Session {
+	if m != nil {
+		return m.Session
+	}
+	return nil
+}
+
 // RollbackRequest is the payload to Rollback.
 type RollbackRequest struct {
 	// caller_id identifies the caller. This is the effective caller ID,
@@ -1339,9 +1404,294 @@ func (m *GetSrvKeyspaceResponse) GetSrvKeyspace() *topodata.SrvKeyspace {
 	return nil
 }
 
+// PrepareRequest is the payload to Prepare.
+type PrepareRequest struct {
+	// caller_id identifies the caller. This is the effective caller ID,
+	// set by the application to further identify the caller.
+	CallerId *vtrpc.CallerID `protobuf:"bytes,1,opt,name=caller_id,json=callerId" json:"caller_id,omitempty"`
+	// sql is the V3 query to prepare, with its bind variables.
+	Sql string `protobuf:"bytes,2,opt,name=sql" json:"sql,omitempty"`
+	// keyspace is the default keyspace of the query.
+	Keyspace string `protobuf:"bytes,3,opt,name=keyspace" json:"keyspace,omitempty"`
+	// tablet_type is the type of tablets that the executions of the
+	// statement are targeted to.
+	TabletType topodata.TabletType `protobuf:"varint,4,opt,name=tablet_type,json=tabletType,enum=topodata.TabletType" json:"tablet_type,omitempty"`
+}
+
+func (m *PrepareRequest) Reset()                    { *m = PrepareRequest{} }
+func (m *PrepareRequest) String() string            { return proto.CompactTextString(m) }
+func (*PrepareRequest) ProtoMessage()               {}
+func (*PrepareRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{35} }
+
+func (m *PrepareRequest) GetCallerId() *vtrpc.CallerID {
+	if m != nil {
+		return m.CallerId
+	}
+	return nil
+}
+
+// PrepareResponse is the returned value from Prepare.
+type PrepareResponse struct {
+	// error contains an application level error if necessary.
+	Error *vtrpc.RPCError `protobuf:"bytes,1,opt,name=error" json:"error,omitempty"`
+	// statement_id identifies the prepared statement in
+	// ExecutePrepared and ClosePrepared.
+	StatementId int64 `protobuf:"varint,2,opt,name=statement_id,json=statementId" json:"statement_id,omitempty"`
+	// param_count is the number of distinct bind variables of the query.
+	ParamCount int64 `protobuf:"varint,3,opt,name=param_count,json=paramCount" json:"param_count,omitempty"`
+	// fields are the names of the columns of a select, when they can
+	// be known without executing it. Their types are in the results.
+	Fields []*query.Field `protobuf:"bytes,4,rep,name=fields" json:"fields,omitempty"`
+}
+
+func (m *PrepareResponse) Reset()                    { *m = PrepareResponse{} }
+func (m *PrepareResponse) String() string            { return proto.CompactTextString(m) }
+func (*PrepareResponse) ProtoMessage()               {}
+func (*PrepareResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{36} }
+
+func (m *PrepareResponse) GetError() *vtrpc.RPCError {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *PrepareResponse) GetFields() []*query.Field {
+	if m != nil {
+		return m.Fields
+	}
+	return nil
+}
+
+// ExecutePreparedRequest is the payload to ExecutePrepared.
+type ExecutePreparedRequest struct {
+	// caller_id identifies the caller. This is the effective caller ID,
+	// set by the application to further identify the caller.
+	CallerId *vtrpc.CallerID `protobuf:"bytes,1,opt,name=caller_id,json=callerId" json:"caller_id,omitempty"`
+	// session carries the current transaction data. It is returned by Begin.
+	// Do not fill it in if outside of a transaction.
+	Session *Session `protobuf:"bytes,2,opt,name=session" json:"session,omitempty"`
+	// statement_id is returned by Prepare.
+	StatementId int64 `protobuf:"varint,3,opt,name=statement_id,json=statementId" json:"statement_id,omitempty"`
+	// bind_variables are the values of the bind variables of the query.
+	BindVariables map[string]*query.BindVariable `protobuf:"bytes,4,rep,name=bind_variables,json=bindVariables" json:"bind_variables,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// not_in_transaction is deprecated and should not be used.
+	NotInTransaction bool `protobuf:"varint,5,opt,name=not_in_transaction,json=notInTransaction" json:"not_in_transaction,omitempty"`
+}
+
+func (m *ExecutePreparedRequest) Reset()                    { *m = ExecutePreparedRequest{} }
+func (m *ExecutePreparedRequest) String() string            { return proto.CompactTextString(m) }
+func (*ExecutePreparedRequest) ProtoMessage()               {}
+func (*ExecutePreparedRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{37} }
+
+func (m *ExecutePreparedRequest) GetCallerId() *vtrpc.CallerID {
+	if m != nil {
+		return m.CallerId
+	}
+	return nil
+}
+
+func (m *ExecutePreparedRequest) GetSession() *Session {
+	if m != nil {
+		return m.Session
+	}
+	return nil
+}
+
+func (m *ExecutePreparedRequest) GetBindVariables() map[string]*query.BindVariable {
+	if m != nil {
+		return m.BindVariables
+	}
+	return nil
+}
+
+// ExecutePreparedResponse is the returned value from ExecutePrepared.
+type ExecutePreparedResponse struct {
+	// error contains an application level error if necessary. Note the
+	// session may have changed, even when an error is returned (for
+	// instance if a database integrity error happened).
+	Error *vtrpc.RPCError `protobuf:"bytes,1,opt,name=error" json:"error,omitempty"`
+	// session is the updated session information (only returned inside a transaction).
+	Session *Session `protobuf:"bytes,2,opt,name=session" json:"session,omitempty"`
+	// result contains the query result, only set if error is unset.
+	Result *query.QueryResult `protobuf:"bytes,3,opt,name=result" json:"result,omitempty"`
+}
+
+func (m *ExecutePreparedResponse) Reset()                    { *m = ExecutePreparedResponse{} }
+func (m *ExecutePreparedResponse) String() string            { return proto.CompactTextString(m) }
+func (*ExecutePreparedResponse) ProtoMessage()               {}
+func (*ExecutePreparedResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{38} }
+
+func (m *ExecutePreparedResponse) GetError() *vtrpc.RPCError {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *ExecutePreparedResponse) GetSession() *Session {
+	if m != nil {
+		return m.Session
+	}
+	return nil
+}
+
+func (m *ExecutePreparedResponse) GetResult() *query.QueryResult {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+// ClosePreparedRequest is the payload to ClosePrepared.
+type ClosePreparedRequest struct {
+	// caller_id identifies the caller. This is the effective caller ID,
+	// set by the application to further identify the caller.
+	CallerId *vtrpc.CallerID `protobuf:"bytes,1,opt,name=caller_id,json=callerId" json:"caller_id,omitempty"`
+	// statement_ids are the prepared statements to close.
+	StatementIds []int64 `protobuf:"varint,2,rep,name=statement_ids,json=statementIds" json:"statement_ids,omitempty"`
+}
+
+func (m *ClosePreparedRequest) Reset()                    { *m = ClosePreparedRequest{} }
+func (m *ClosePreparedRequest) String() string            { return proto.CompactTextString(m) }
+func (*ClosePreparedRequest) ProtoMessage()               {}
+func (*ClosePreparedRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{39} }
+
+func (m *ClosePreparedRequest) GetCallerId() *vtrpc.CallerID {
+	if m != nil {
+		return m.CallerId
+	}
+	return nil
+}
+
+// ClosePreparedResponse is the returned value from ClosePrepared.
+type ClosePreparedResponse struct {
+}
+
+func (m *ClosePreparedResponse) Reset()                    { *m = ClosePreparedResponse{} }
+func (m *ClosePreparedResponse) String() string            { return proto.CompactTextString(m) }
+func (*ClosePreparedResponse) ProtoMessage()               {}
+func (*ClosePreparedResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{40} }
+
+// MultiplexRequest is an Execute request sent on the stream of the
+// RequestMultiplexer service.
+type MultiplexRequest struct {
+	// request_id is assigned by the client, and returned with the
+	// response. It must be unique among the pending requests of the stream.
+	RequestId uint64 `protobuf:"varint,1,opt,name=request_id,json=requestId" json:"request_id,omitempty"`
+	// timeout is the time left before the deadline of the client for
+	// this request, in nanoseconds. 0 means no deadline.
+	Timeout int64 `protobuf:"varint,2,opt,name=timeout" json:"timeout,omitempty"`
+	// execute is the request.
+	Execute *ExecuteRequest `protobuf:"bytes,3,opt,name=execute" json:"execute,omitempty"`
+}
+
+func (m *MultiplexRequest) Reset()                    { *m = MultiplexRequest{} }
+func (m *MultiplexRequest) String() string            { return proto.CompactTextString(m) }
+func (*MultiplexRequest) ProtoMessage()               {}
+func (*MultiplexRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{41} }
+
+func (m *MultiplexRequest) GetExecute() *ExecuteRequest {
+	if m != nil {
+		return m.Execute
+	}
+	return nil
+}
+
+// MultiplexResponse is the response to a MultiplexRequest.
+type MultiplexResponse struct {
+	// request_id is the request_id of the request.
+	RequestId uint64 `protobuf:"varint,1,opt,name=request_id,json=requestId" json:"request_id,omitempty"`
+	// error is set if the request failed in vtgate, in place of the
+	// gRPC error an Execute call would return. The errors of the query
+	// are in execute.error.
+	Error *vtrpc.RPCError `protobuf:"bytes,2,opt,name=error" json:"error,omitempty"`
+	// execute is the response, only set if error is unset.
+	Execute *ExecuteResponse `protobuf:"bytes,3,opt,name=execute" json:"execute,omitempty"`
+}
+
+func (m *MultiplexResponse) Reset()                    { *m = MultiplexResponse{} }
+func (m *MultiplexResponse) String() string            { return proto.CompactTextString(m) }
+func (*MultiplexResponse) ProtoMessage()               {}
+func (*MultiplexResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{42} }
+
+func (m *MultiplexResponse) GetError() *vtrpc.RPCError {
+	if m != nil {
+		return m.Error
+	}
+	return nil
+}
+
+func (m *MultiplexResponse) GetExecute() *ExecuteResponse {
+	if m != nil {
+		return m.Execute
+	}
+	return nil
+}
+
+// GetKeyspaceSchemaRequest is the payload to GetKeyspaceSchema.
+type GetKeyspaceSchemaRequest struct {
+	// keyspace name to fetch.
+	Keyspace string `protobuf:"bytes,1,opt,name=keyspace" json:"keyspace,omitempty"`
+}
+
+func (m *GetKeyspaceSchemaRequest) Reset()                    { *m = GetKeyspaceSchemaRequest{} }
+func (m *GetKeyspaceSchemaRequest) String() string            { return proto.CompactTextString(m) }
+func (*GetKeyspaceSchemaRequest) ProtoMessage()               {}
+func (*GetKeyspaceSchemaRequest) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{43} }
+
+// SchemaDrift is a difference between the schema of a shard and the
+// schema of the reference shard.
+type SchemaDrift struct {
+	// shard is the shard that differs from the reference shard.
+	Shard string `protobuf:"bytes,1,opt,name=shard" json:"shard,omitempty"`
+	// table is the table that differs.
+	Table string `protobuf:"bytes,2,opt,name=table" json:"table,omitempty"`
+	// columns are the columns of the table that differ, if any.
+	Columns []string `protobuf:"bytes,3,rep,name=columns" json:"columns,omitempty"`
+	// description describes the difference.
+	Description string `protobuf:"bytes,4,opt,name=description" json:"description,omitempty"`
+}
+
+func (m *SchemaDrift) Reset()                    { *m = SchemaDrift{} }
+func (m *SchemaDrift) String() string            { return proto.CompactTextString(m) }
+func (*SchemaDrift) ProtoMessage()               {}
+func (*SchemaDrift) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{44} }
+
+// GetKeyspaceSchemaResponse is the returned value from GetKeyspaceSchema.
+type GetKeyspaceSchemaResponse struct {
+	// reference_shard is the shard the schema was read from. The other
+	// shards are compared to it.
+	ReferenceShard string `protobuf:"bytes,1,opt,name=reference_shard,json=referenceShard" json:"reference_shard,omitempty"`
+	// schema is the schema of the keyspace.
+	Schema *tabletmanagerdata.SchemaDefinition `protobuf:"bytes,2,opt,name=schema" json:"schema,omitempty"`
+	// drifts are the differences between the shards. The schema is only
+	// the schema of all the shards if it's empty.
+	Drifts []*SchemaDrift `protobuf:"bytes,3,rep,name=drifts" json:"drifts,omitempty"`
+}
+
+func (m *GetKeyspaceSchemaResponse) Reset()                    { *m = GetKeyspaceSchemaResponse{} }
+func (m *GetKeyspaceSchemaResponse) String() string            { return proto.CompactTextString(m) }
+func (*GetKeyspaceSchemaResponse) ProtoMessage()               {}
+func (*GetKeyspaceSchemaResponse) Descriptor() ([]byte, []int) { return fileDescriptor0, []int{45} }
+
+func (m *GetKeyspaceSchemaResponse) GetSchema() *tabletmanagerdata.SchemaDefinition {
+	if m != nil {
+		return m.Schema
+	}
+	return nil
+}
+
+func (m *GetKeyspaceSchemaResponse) GetDrifts() []*SchemaDrift {
+	if m != nil {
+		return m.Drifts
+	}
+	return nil
+}
 func init() {
 	proto.RegisterType((*Session)(nil), "vtgate.Session")
 	proto.RegisterType((*Session_ShardSession)(nil), "vtgate.Session.ShardSession")
+	proto.RegisterType((*Session_ShardGtid)(nil), "vtgate.Session.ShardGtid")
 	proto.RegisterType((*ExecuteRequest)(nil), "vtgate.ExecuteRequest")
 	proto.RegisterType((*ExecuteResponse)(nil), "vtgate.ExecuteResponse")
 	proto.RegisterType((*ExecuteShardsRequest)(nil), "vtgate.ExecuteShardsRequest")
@@ -1380,91 +1730,135 @@ func init() {
 	proto.RegisterType((*SplitQueryResponse_Part)(nil), "vtgate.SplitQueryResponse.Part")
 	proto.RegisterType((*GetSrvKeyspaceRequest)(nil), "vtgate.GetSrvKeyspaceRequest")
 	proto.RegisterType((*GetSrvKeyspaceResponse)(nil), "vtgate.GetSrvKeyspaceResponse")
+	proto.RegisterType((*PrepareRequest)(nil), "vtgate.PrepareRequest")
+	proto.RegisterType((*PrepareResponse)(nil), "vtgate.PrepareResponse")
+	proto.RegisterType((*ExecutePreparedRequest)(nil), "vtgate.ExecutePreparedRequest")
+	proto.RegisterType((*ExecutePreparedResponse)(nil), "vtgate.ExecutePreparedResponse")
+	proto.RegisterType((*ClosePreparedRequest)(nil), "vtgate.ClosePreparedRequest")
+	proto.RegisterType((*ClosePreparedResponse)(nil), "vtgate.ClosePreparedResponse")
+	proto.RegisterType((*MultiplexRequest)(nil), "vtgate.MultiplexRequest")
+	proto.RegisterType((*MultiplexResponse)(nil), "vtgate.MultiplexResponse")
+	proto.RegisterType((*GetKeyspaceSchemaRequest)(nil), "vtgate.GetKeyspaceSchemaRequest")
+	proto.RegisterType((*SchemaDrift)(nil), "vtgate.SchemaDrift")
+	proto.RegisterType((*GetKeyspaceSchemaResponse)(nil), "vtgate.GetKeyspaceSchemaResponse")
 }
 
 var fileDescriptor0 = []byte{
-	// 1316 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xd4, 0x59, 0x4f, 0x6f, 0xe3, 0x44,
-	0x14, 0x97, 0xe3, 0x34, 0x69, 0x9e, 0x93, 0xb4, 0xeb, 0x6d, 0x77, 0x43, 0x58, 0xb6, 0xc5, 0x02,
-	0x6d, 0x81, 0x95, 0xa5, 0xcd, 0xf2, 0x4f, 0x08, 0x09, 0x68, 0xa8, 0x50, 0xb4, 0xb0, 0x2a, 0xd3,
-	0x0a, 0x71, 0x00, 0x59, 0x6e, 0x32, 0x6a, 0x4d, 0x13, 0x3b, 0x78, 0xc6, 0x81, 0x72, 0xe0, 0x86,
-	0xc4, 0x6d, 0x0f, 0x08, 0x09, 0x21, 0x2e, 0x7c, 0x04, 0xbe, 0x01, 0x9c, 0x38, 0x72, 0xe0, 0xc0,
-	0x8d, 0x8f, 0x00, 0x5f, 0x80, 0x03, 0xe3, 0x99, 0xf1, 0x9f, 0xb8, 0x49, 0x9a, 0x26, 0x6d, 0x95,
-	0x9e, 0xea, 0x99, 0xf7, 0x66, 0xde, 0x7b, 0xbf, 0xdf, 0x9b, 0x37, 0xf3, 0x1a, 0x28, 0x0f, 0xe8,
-	0xa1, 0x4d, 0xb1, 0xd9, 0xf7, 0x3d, 0xea, 0xe9, 0x05, 0x31, 0xaa, 0x6b, 0x9f, 0x07, 0xd8, 0x3f,
-	0x11, 0x93, 0xf5, 0x2a, 0xf5, 0xfa, 0x5e, 0xc7, 0xa6, 0xb6, 0x1c, 0x6b, 0x03, 0xea, 0xf7, 0xdb,
-	0x62, 0x60, 0xfc, 0xa9, 0x40, 0x71, 0x0f, 0x13, 0xe2, 0x78, 0xae, 0xfe, 0x3c, 0x54, 0x1d, 0xd7,
-	0xa2, 0xbe, 0xed, 0x12, 0xbb, 0x4d, 0xd9, 0x4c, 0x4d, 0xd9, 0x54, 0xb6, 0x96, 0x51, 0xc5, 0x71,
-	0xf7, 0x93, 0x49, 0xbd, 0x09, 0x55, 0x72, 0x64, 0xfb, 0x1d, 0x8b, 0x88, 0x75, 0xa4, 0x96, 0xdb,
-	0x54, 0xb7, 0xb4, 0xc6, 0x1d, 0x53, 0xfa, 0x22, 0xf7, 0x33, 0xf7, 0x42, 0x2d, 0x39, 0x40, 0x15,
-	0x92, 0x1a, 0x91, 0xfa, 0x27, 0x50, 0x4e, 0x8b, 0x99, 0xed, 0x02, 0xb5, 0xfd, 0x43, 0x4c, 0xb9,
-	0x4d, 0xad, 0x51, 0x31, 0x45, 0x08, 0xfb, 0x7c, 0x12, 0x49, 0x61, 0xe8, 0x62, 0xca, 0x3f, 0xcb,
-	0xe9, 0x30, 0xdb, 0xca, 0x96, 0x8a, 0x2a, 0xa9, 0xd9, 0x56, 0xc7, 0xf8, 0x2e, 0x07, 0xd5, 0x9d,
-	0x2f, 0x71, 0x3b, 0xa0, 0x18, 0x61, 0xb6, 0x11, 0xa1, 0xfa, 0x7d, 0x28, 0xb5, 0xed, 0x6e, 0x17,
-	0xfb, 0xe1, 0x22, 0x61, 0x63, 0xc5, 0x14, 0x48, 0x34, 0xf9, 0x7c, 0xeb, 0x5d, 0xb4, 0x2c, 0x34,
-	0x5a, 0x1d, 0xfd, 0x05, 0x28, 0xca, 0xe8, 0xb8, 0x01, 0xa1, 0x9b, 0x0e, 0x0e, 0x45, 0x72, 0xfd,
-	0x1e, 0x2c, 0x71, 0x57, 0x6b, 0x2a, 0x57, 0xbc, 0x21, 0x1d, 0xdf, 0xf6, 0x02, 0xb7, 0xf3, 0x61,
-	0xf8, 0x89, 0x84, 0x5c, 0x7f, 0x05, 0x34, 0x6a, 0x1f, 0x74, 0x31, 0xb5, 0xe8, 0x49, 0x1f, 0xd7,
-	0xf2, 0x4c, 0xbd, 0xda, 0x58, 0x33, 0x63, 0x76, 0xf6, 0xb9, 0x70, 0x9f, 0xc9, 0x10, 0xd0, 0xf8,
-	0x9b, 0x39, 0xae, 0xbb, 0x1e, 0xb5, 0x32, 0xcc, 0x2c, 0x71, 0x66, 0x56, 0x99, 0xa4, 0x35, 0x44,
-	0x4e, 0x1d, 0x96, 0x8f, 0xf1, 0x09, 0xe9, 0xdb, 0x6d, 0x5c, 0x2b, 0x30, 0x9d, 0x12, 0x8a, 0xc7,
-	0xc6, 0x13, 0x05, 0x56, 0x62, 0x54, 0x48, 0x9f, 0xd1, 0x80, 0x19, 0xa0, 0x4b, 0xd8, 0xf7, 0x3d,
-	0x3f, 0x03, 0x09, 0xda, 0x6d, 0xee, 0x84, 0xd3, 0x48, 0x48, 0xcf, 0x83, 0xc7, 0x8b, 0x50, 0xf0,
-	0x31, 0x09, 0xba, 0x54, 0x02, 0xa2, 0x4b, 0x40, 0x04, 0x16, 0x5c, 0x82, 0xa4, 0x86, 0xf1, 0x4b,
-	0x0e, 0xd6, 0xa4, 0x47, 0x3c, 0x1b, 0xc8, 0xe2, 0xb0, 0x95, 0x06, 0x32, 0x3f, 0x0c, 0xa4, 0x7e,
-	0x0b, 0x0a, 0x3c, 0x9b, 0x09, 0xa3, 0x41, 0x65, 0x12, 0x39, 0xca, 0x32, 0x5c, 0x98, 0x8b, 0xe1,
-	0xe2, 0x68, 0x86, 0x8d, 0xef, 0x15, 0x58, 0xcf, 0x60, 0xb6, 0x10, 0x5c, 0xfe, 0x96, 0x83, 0xa7,
-	0xa4, 0x5f, 0x8f, 0x24, 0x50, 0xad, 0xeb, 0x42, 0xe8, 0xb3, 0x50, 0x8e, 0xbe, 0x99, 0x7f, 0x82,
-	0xd6, 0x32, 0xd2, 0x8e, 0x93, 0x38, 0xae, 0x86, 0xdb, 0x1f, 0x15, 0xa8, 0x8f, 0xc2, 0x70, 0x21,
-	0x08, 0xfe, 0x23, 0x07, 0xb7, 0x13, 0xe7, 0x90, 0xed, 0x1e, 0xe2, 0x6b, 0x42, 0xef, 0x03, 0x00,
-	0xf6, 0x6d, 0xf9, 0xdc, 0x65, 0x4e, 0x6e, 0x18, 0x69, 0x4c, 0x5d, 0x14, 0x0d, 0x2a, 0x1d, 0x47,
-	0x71, 0x5d, 0x0d, 0xdd, 0x3f, 0x28, 0x50, 0x3b, 0x8d, 0xe8, 0x42, 0x90, 0xfd, 0x6d, 0x3e, 0x26,
-	0x7b, 0xc7, 0xa5, 0x0e, 0x3d, 0xb9, 0x36, 0x67, 0x99, 0x51, 0x80, 0xb9, 0xc7, 0x56, 0xdb, 0xeb,
-	0x06, 0x3d, 0xd7, 0x72, 0xed, 0x1e, 0xe6, 0xf7, 0x65, 0x09, 0xad, 0x0a, 0x49, 0x93, 0x0b, 0x1e,
-	0xb3, 0x79, 0xfd, 0x63, 0xb8, 0x29, 0xb5, 0x87, 0x0a, 0x40, 0x81, 0xe7, 0xc8, 0x56, 0xe4, 0xe9,
-	0x18, 0x24, 0xcc, 0x68, 0x02, 0xdd, 0x10, 0x9b, 0x3c, 0x1a, 0x5f, 0x30, 0x8a, 0x73, 0x65, 0xd0,
-	0xf2, 0xe8, 0x0c, 0xaa, 0x1f, 0xc0, 0x72, 0xe4, 0x83, 0xbe, 0x01, 0x79, 0x6e, 0x49, 0xe1, 0x96,
-	0xb4, 0xe8, 0x01, 0x15, 0x1a, 0xe0, 0x02, 0x7d, 0x0d, 0x96, 0x06, 0x76, 0x37, 0xc0, 0x9c, 0x87,
-	0x32, 0x12, 0x03, 0xb6, 0x4c, 0x4b, 0x85, 0xce, 0xa1, 0x2f, 0x23, 0x48, 0x4a, 0x5f, 0x3a, 0x4b,
-	0x53, 0x00, 0x2c, 0x44, 0x96, 0xba, 0xb0, 0xc2, 0x93, 0x83, 0x5f, 0x84, 0x5c, 0x21, 0xc9, 0x21,
-	0xe5, 0x1c, 0x39, 0x94, 0x1b, 0x7b, 0xc1, 0xab, 0xe9, 0x0b, 0xde, 0xf8, 0x26, 0xb9, 0xe3, 0xb6,
-	0x6d, 0xda, 0x3e, 0xba, 0xa2, 0x47, 0xcb, 0x03, 0x28, 0x86, 0x3e, 0x3b, 0x58, 0xf8, 0xa3, 0x35,
-	0x6e, 0x47, 0xaa, 0x99, 0xe8, 0x51, 0xa4, 0x37, 0xeb, 0x63, 0x93, 0xbd, 0xaf, 0x6d, 0x32, 0xe2,
-	0xa1, 0x59, 0xb1, 0x49, 0xba, 0x70, 0xfd, 0x94, 0xdc, 0x53, 0x43, 0x38, 0x5c, 0x5a, 0x52, 0xdc,
-	0x87, 0xa2, 0xa0, 0x3c, 0x42, 0x60, 0x54, 0x56, 0x44, 0x2a, 0xc6, 0xd7, 0xb0, 0xc6, 0x81, 0x49,
-	0x8e, 0xe3, 0x05, 0xe6, 0x46, 0xf6, 0xad, 0xa0, 0x9e, 0x7a, 0x2b, 0x18, 0x4f, 0x72, 0x70, 0x37,
-	0x0d, 0xcf, 0x55, 0xbe, 0x87, 0x5e, 0xcd, 0xe6, 0xca, 0x9d, 0xa1, 0x5c, 0xc9, 0x40, 0x72, 0x55,
-	0x09, 0xf3, 0xb3, 0x02, 0x1b, 0x63, 0x11, 0x59, 0x90, 0xac, 0xf9, 0x55, 0x81, 0xb5, 0x3d, 0xea,
-	0x63, 0xbb, 0x37, 0x57, 0xeb, 0x18, 0x27, 0x59, 0xee, 0x7c, 0xfd, 0xa0, 0x3a, 0x25, 0xe2, 0x13,
-	0xee, 0x3e, 0xa3, 0x09, 0xeb, 0x99, 0x08, 0x24, 0xb6, 0x49, 0x51, 0x55, 0xce, 0x2c, 0xaa, 0x7f,
-	0xb3, 0xc3, 0x3d, 0xb4, 0xcb, 0x3c, 0x55, 0x6e, 0x6a, 0x34, 0xd2, 0x61, 0xa9, 0x63, 0xcb, 0x71,
-	0x7e, 0x52, 0xbf, 0xb5, 0x34, 0x1d, 0x82, 0x46, 0x0b, 0x9e, 0x1e, 0x19, 0xdf, 0x0c, 0x58, 0xfd,
-	0xc3, 0xf2, 0x7a, 0x68, 0xaf, 0xb9, 0x8f, 0xfa, 0x85, 0x00, 0x96, 0xad, 0x51, 0xf9, 0x33, 0xfb,
-	0x99, 0x69, 0xb1, 0x7b, 0x0c, 0x9b, 0xe3, 0xe3, 0x9d, 0x01, 0xc0, 0xff, 0x14, 0x78, 0x26, 0xbb,
-	0xe1, 0x3c, 0xad, 0xc5, 0x85, 0xc0, 0x37, 0xdc, 0x2f, 0xe4, 0x67, 0xe8, 0x17, 0xa6, 0x85, 0xf3,
-	0x7d, 0xb8, 0x3b, 0x2e, 0xfa, 0x19, 0xc0, 0x7c, 0x13, 0xca, 0xdb, 0xf8, 0xd0, 0x71, 0x67, 0x82,
-	0xce, 0x78, 0x03, 0x2a, 0x72, 0xb5, 0x34, 0x9d, 0xaa, 0xb4, 0xca, 0xe4, 0x4a, 0x6b, 0x1c, 0x41,
-	0xa5, 0xe9, 0xf5, 0x7a, 0x0e, 0xbd, 0xec, 0xfb, 0xcd, 0x58, 0x85, 0x6a, 0x64, 0x49, 0xb8, 0x69,
-	0x7c, 0x06, 0x2b, 0xc8, 0xeb, 0x76, 0x0f, 0xec, 0xf6, 0xf1, 0xa5, 0x5b, 0xd7, 0x61, 0x35, 0xb1,
-	0x25, 0xed, 0xff, 0x9b, 0x83, 0x1b, 0x7b, 0xfd, 0xae, 0x43, 0x25, 0x25, 0xb3, 0xb8, 0x30, 0xe9,
-	0xc1, 0x31, 0x75, 0x57, 0xc4, 0x4e, 0x3d, 0x09, 0xfd, 0x90, 0x8d, 0x8f, 0x2c, 0x96, 0x1a, 0x9f,
-	0x13, 0x2d, 0x4f, 0xf8, 0xd8, 0x8f, 0x54, 0x02, 0x97, 0xf2, 0x34, 0x55, 0x11, 0x48, 0x0d, 0x36,
-	0xa3, 0xbf, 0x0c, 0xb7, 0xdd, 0xa0, 0x67, 0xf9, 0xde, 0x17, 0xc4, 0xea, 0x33, 0xe7, 0xf9, 0xce,
-	0x56, 0xdf, 0xf6, 0x29, 0xef, 0x81, 0x55, 0x74, 0x93, 0x89, 0x11, 0x93, 0xee, 0x62, 0x9f, 0x1b,
-	0xdf, 0x65, 0x22, 0xfd, 0x6d, 0x28, 0xd9, 0xdd, 0x43, 0xcf, 0x77, 0xe8, 0x51, 0x4f, 0x76, 0x3a,
-	0x86, 0x74, 0xf3, 0x14, 0x32, 0xe6, 0x3b, 0x91, 0x26, 0x4a, 0x16, 0xe9, 0x2f, 0x81, 0x1e, 0x10,
-	0x6c, 0x09, 0xe7, 0x84, 0xd1, 0x41, 0x43, 0xb6, 0x3d, 0x2b, 0x4c, 0x92, 0x6c, 0xf3, 0x51, 0xc3,
-	0xf8, 0x5d, 0x05, 0x3d, 0xbd, 0xaf, 0xcc, 0xd7, 0xd7, 0xd8, 0x35, 0x11, 0xce, 0x12, 0x86, 0x77,
-	0x78, 0x64, 0x37, 0x62, 0x1a, 0x4f, 0xe9, 0x9a, 0xa1, 0xdb, 0x48, 0xaa, 0xd7, 0x3f, 0x85, 0x72,
-	0x74, 0xf0, 0x78, 0x38, 0x69, 0x36, 0x94, 0x89, 0xb5, 0x21, 0x37, 0x45, 0x6d, 0xa8, 0xbf, 0x05,
-	0x25, 0x7e, 0xc5, 0x9c, 0xb9, 0x77, 0x72, 0xcf, 0xe5, 0xd2, 0xf7, 0x5c, 0xfd, 0x2f, 0x05, 0xf2,
-	0x7c, 0xf1, 0xd4, 0x0f, 0xd8, 0x0f, 0xa0, 0x1a, 0x7b, 0x29, 0xd8, 0x13, 0x99, 0x7d, 0x6f, 0x02,
-	0x24, 0x69, 0x08, 0x50, 0xf9, 0x38, 0x0d, 0x48, 0x13, 0x40, 0xfc, 0xcb, 0x9f, 0x6f, 0x25, 0xf2,
-	0xf0, 0xb9, 0x09, 0x5b, 0xc5, 0xe1, 0xa2, 0x12, 0x89, 0x23, 0xd7, 0x21, 0x4f, 0x9c, 0xaf, 0xc4,
-	0xa3, 0x45, 0x45, 0xfc, 0xdb, 0x78, 0x08, 0xeb, 0xef, 0x61, 0xba, 0xe7, 0x0f, 0xa2, 0x7b, 0x24,
-	0x3a, 0x3e, 0x13, 0x60, 0x32, 0x10, 0xdc, 0xca, 0x2e, 0x92, 0x19, 0xf0, 0x3a, 0x3b, 0x01, 0xfe,
-	0xc0, 0x1a, 0x5a, 0xa9, 0x35, 0xd6, 0x13, 0x7a, 0xd2, 0x8b, 0x34, 0x92, 0x0c, 0xb6, 0xeb, 0x50,
-	0x6b, 0x7b, 0x3d, 0xf3, 0xc4, 0x0b, 0x68, 0x70, 0x80, 0xcd, 0x81, 0x43, 0xd9, 0x89, 0x17, 0xbf,
-	0x91, 0x1c, 0x14, 0xf8, 0x9f, 0x87, 0xff, 0x07, 0x00, 0x00, 0xff, 0xff, 0x12, 0x77, 0x8b, 0xc2,
-	0x6c, 0x19, 0x00, 0x00,
+	// 1849 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0xd4, 0x5a, 0xcd, 0x6f, 0x1b, 0xc7,
+	0x15, 0xc7, 0x2e, 0x29, 0x4a, 0x7c, 0x4b, 0x52, 0xf2, 0x58, 0x32, 0x69, 0x36, 0x89, 0x94, 0x4d,
+	0x0c, 0x2b, 0x8d, 0x41, 0xd4, 0x4c, 0xeb, 0x06, 0x4e, 0x81, 0xb6, 0x62, 0x5c, 0x43, 0x48, 0x13,
+	0x28, 0x23, 0x37, 0xc8, 0xa1, 0xc5, 0x62, 0x45, 0x8e, 0xa5, 0xad, 0xf6, 0x83, 0x9e, 0x99, 0x65,
+	0xcc, 0x02, 0xed, 0xad, 0x40, 0x0f, 0x05, 0x72, 0x28, 0xfa, 0x01, 0xa3, 0x97, 0xb6, 0x40, 0x8f,
+	0x05, 0x0a, 0xf4, 0x1f, 0xe8, 0xa9, 0xc7, 0x1e, 0xfb, 0x67, 0xf4, 0xd0, 0x4b, 0x8f, 0xc1, 0xce,
+	0xc7, 0xee, 0x72, 0x49, 0x51, 0x12, 0x65, 0x09, 0xf2, 0x89, 0xf3, 0xf1, 0x66, 0xe6, 0xbd, 0xdf,
+	0xfb, 0xed, 0x7b, 0xf3, 0x76, 0x09, 0xb5, 0x11, 0x3f, 0x74, 0x39, 0xe9, 0x0c, 0x69, 0xc4, 0x23,
+	0x54, 0x91, 0xbd, 0xb6, 0xf5, 0x2c, 0x26, 0x74, 0x2c, 0x07, 0xdb, 0x4d, 0xee, 0x1e, 0xf8, 0x84,
+	0x07, 0x6e, 0xe8, 0x1e, 0x12, 0x3a, 0x70, 0xb9, 0xab, 0x26, 0x1a, 0x3c, 0x1a, 0x46, 0xb9, 0xbe,
+	0x35, 0xe2, 0x74, 0xd8, 0x97, 0x1d, 0xfb, 0x45, 0x09, 0x96, 0xf7, 0x09, 0x63, 0x5e, 0x14, 0xa2,
+	0x3b, 0xd0, 0xf0, 0x42, 0x87, 0x53, 0x37, 0x64, 0x6e, 0x9f, 0x7b, 0x51, 0xd8, 0x32, 0xb6, 0x8c,
+	0xed, 0x15, 0x5c, 0xf7, 0xc2, 0x27, 0xd9, 0x20, 0xea, 0x41, 0x83, 0x1d, 0xb9, 0x74, 0xe0, 0x30,
+	0xb9, 0x8e, 0xb5, 0xcc, 0xad, 0xd2, 0xb6, 0xd5, 0x7d, 0xad, 0xa3, 0x94, 0x54, 0xfb, 0x75, 0xf6,
+	0x13, 0x29, 0xd5, 0xc1, 0x75, 0x96, 0xeb, 0x31, 0xf4, 0x10, 0x2c, 0xb9, 0xc9, 0x21, 0xf7, 0x06,
+	0xac, 0x55, 0x12, 0x3b, 0xdc, 0x9e, 0xb9, 0xc3, 0x63, 0xee, 0x0d, 0x30, 0x30, 0xdd, 0x64, 0xe8,
+	0x01, 0x34, 0x03, 0xf7, 0xb9, 0xc3, 0xfa, 0x2e, 0xe7, 0x84, 0x3a, 0x43, 0x97, 0xba, 0xbe, 0x4f,
+	0x7c, 0x8f, 0x05, 0xad, 0xf2, 0x96, 0xb1, 0x5d, 0xc2, 0x1b, 0x81, 0xfb, 0x7c, 0x5f, 0xce, 0xee,
+	0x65, 0x93, 0xed, 0x1f, 0x43, 0x2d, 0xaf, 0x12, 0xba, 0x03, 0x15, 0xee, 0xd2, 0x43, 0xc2, 0x85,
+	0x9d, 0x56, 0xb7, 0xde, 0x91, 0x78, 0x3e, 0x11, 0x83, 0x58, 0x4d, 0x26, 0xb0, 0xe4, 0x30, 0x71,
+	0xbc, 0x41, 0xcb, 0x14, 0xa7, 0xd4, 0x73, 0xa3, 0xbb, 0x83, 0xf6, 0xa7, 0x50, 0x4d, 0xd5, 0x45,
+	0x6d, 0x58, 0x39, 0x26, 0x63, 0x36, 0x74, 0xfb, 0x44, 0x6c, 0x5e, 0xc5, 0x69, 0x1f, 0xad, 0xc3,
+	0x92, 0x30, 0x46, 0x6c, 0x53, 0xc5, 0xb2, 0x83, 0x10, 0x94, 0x13, 0x28, 0x5a, 0x25, 0x31, 0x28,
+	0xda, 0xf6, 0x6f, 0x4c, 0x68, 0x3c, 0x7a, 0x4e, 0xfa, 0x31, 0x27, 0x98, 0x3c, 0x8b, 0x09, 0xe3,
+	0xe8, 0x1e, 0x54, 0xfb, 0x89, 0x41, 0x34, 0xd1, 0x43, 0xaa, 0xbd, 0xda, 0x91, 0x0e, 0xed, 0x89,
+	0xf1, 0xdd, 0x0f, 0xf1, 0x8a, 0x94, 0xd8, 0x1d, 0xa0, 0x77, 0x60, 0x59, 0x39, 0xa9, 0x65, 0xa6,
+	0xb2, 0x79, 0x84, 0xb1, 0x9e, 0x47, 0x77, 0x61, 0x49, 0x58, 0x2f, 0x14, 0xb0, 0xba, 0x37, 0x14,
+	0x16, 0x3b, 0x51, 0x1c, 0x0e, 0x3e, 0x4d, 0x9a, 0x58, 0xce, 0xa3, 0x6f, 0x81, 0x25, 0x99, 0xe6,
+	0xf0, 0xf1, 0x90, 0x08, 0xc4, 0x1b, 0xdd, 0xf5, 0x4e, 0x4a, 0xb2, 0x27, 0x62, 0xf2, 0xc9, 0x78,
+	0x48, 0x30, 0xf0, 0xb4, 0x8d, 0xee, 0x01, 0x0a, 0x23, 0xee, 0x14, 0x08, 0xb6, 0x24, 0x08, 0xb6,
+	0x16, 0x46, 0x7c, 0x77, 0x82, 0x63, 0x79, 0xfc, 0x2a, 0x93, 0xf8, 0xd9, 0x5f, 0x1a, 0xb0, 0x9a,
+	0xa2, 0xc2, 0x86, 0x51, 0xc8, 0x08, 0xba, 0x03, 0x4b, 0x84, 0xd2, 0x88, 0x16, 0x20, 0xc1, 0x7b,
+	0xbd, 0x47, 0xc9, 0x30, 0x96, 0xb3, 0xe7, 0xc1, 0xe3, 0xeb, 0x50, 0xa1, 0x84, 0xc5, 0x3e, 0x57,
+	0x80, 0x20, 0x05, 0x88, 0xc4, 0x42, 0xcc, 0x60, 0x25, 0x61, 0xff, 0xdd, 0x84, 0x75, 0xa5, 0x91,
+	0xa0, 0x00, 0xbb, 0x3e, 0xde, 0xca, 0x03, 0x59, 0x2e, 0x10, 0xf1, 0x16, 0x54, 0x04, 0xf7, 0x58,
+	0x6b, 0x69, 0xab, 0xb4, 0x5d, 0xc5, 0xaa, 0x57, 0xf4, 0x70, 0xe5, 0x42, 0x1e, 0x5e, 0x9e, 0xed,
+	0x61, 0xfb, 0xb7, 0x06, 0x6c, 0x14, 0x30, 0xbb, 0x16, 0xbe, 0xfc, 0xa7, 0x09, 0xb7, 0x95, 0x5e,
+	0x1f, 0x29, 0xa0, 0x76, 0x5f, 0x15, 0x87, 0xbe, 0x09, 0x35, 0xdd, 0x76, 0x3c, 0xe5, 0xd6, 0x1a,
+	0xb6, 0x8e, 0x33, 0x3b, 0xae, 0xc6, 0xb7, 0x2f, 0x0c, 0x68, 0xcf, 0xc2, 0xf0, 0x5a, 0x38, 0xf8,
+	0xdf, 0x26, 0x34, 0x33, 0xe5, 0xb0, 0x1b, 0x1e, 0x92, 0x57, 0xc4, 0xbd, 0xf7, 0x01, 0x8e, 0xc9,
+	0xd8, 0xa1, 0x42, 0x65, 0xe1, 0xdc, 0xc4, 0xd2, 0xd4, 0x75, 0xda, 0x1a, 0x5c, 0x3d, 0x56, 0xad,
+	0x2b, 0x72, 0xf7, 0x1f, 0x0c, 0x68, 0x4d, 0x23, 0x7a, 0x2d, 0x9c, 0xfd, 0xab, 0x72, 0xea, 0xec,
+	0x47, 0x21, 0xf7, 0xf8, 0xf8, 0x95, 0x79, 0x96, 0xef, 0x01, 0x22, 0x42, 0x63, 0xa7, 0x1f, 0xf9,
+	0x71, 0x10, 0x3a, 0xa1, 0x1b, 0x10, 0x91, 0x2f, 0xab, 0x78, 0x4d, 0xce, 0xf4, 0xc4, 0xc4, 0x27,
+	0x6e, 0x40, 0xd0, 0xe7, 0x70, 0x53, 0x49, 0x4f, 0x04, 0x80, 0x8a, 0xe0, 0xc8, 0xb6, 0xd6, 0xf4,
+	0x04, 0x24, 0x3a, 0x7a, 0x00, 0xdf, 0x90, 0x9b, 0x7c, 0x74, 0x72, 0xc0, 0x58, 0xbe, 0x10, 0x83,
+	0x56, 0x66, 0x33, 0xa8, 0x7d, 0x00, 0x2b, 0x5a, 0x07, 0xb4, 0x09, 0x65, 0x71, 0x92, 0x21, 0x4e,
+	0xb2, 0xf4, 0x9d, 0x2c, 0x39, 0x40, 0x4c, 0x24, 0xf7, 0xa7, 0x91, 0xeb, 0xc7, 0x44, 0xf8, 0xa1,
+	0x86, 0x65, 0x07, 0x6d, 0x82, 0x95, 0x33, 0x5d, 0x40, 0x5f, 0xc3, 0x90, 0x85, 0xbe, 0x3c, 0x4b,
+	0x73, 0x00, 0x5c, 0x0b, 0x96, 0x86, 0xb0, 0x2a, 0xc8, 0x21, 0x12, 0xa1, 0x10, 0xc8, 0x38, 0x64,
+	0x9c, 0x83, 0x43, 0xe6, 0x89, 0x09, 0xbe, 0x94, 0x4f, 0xf0, 0xf6, 0x2f, 0xb3, 0x1c, 0xb7, 0xe3,
+	0xf2, 0xfe, 0xd1, 0x15, 0x5d, 0x5a, 0xee, 0xc3, 0x72, 0xa2, 0xb3, 0x47, 0xf4, 0x7d, 0xbf, 0xa9,
+	0x45, 0x0b, 0xd6, 0x63, 0x2d, 0xb7, 0xe8, 0x65, 0xf3, 0x0e, 0x34, 0x5c, 0x36, 0xe3, 0xa2, 0x59,
+	0x77, 0x59, 0x3e, 0x70, 0xfd, 0x31, 0xcb, 0x53, 0x13, 0x38, 0x5c, 0x1a, 0x29, 0xee, 0xc1, 0xb2,
+	0x74, 0xb9, 0x46, 0x60, 0x16, 0x2b, 0xb4, 0x88, 0xfd, 0x0b, 0x58, 0x17, 0xc0, 0x64, 0x8f, 0xe3,
+	0x4b, 0xe4, 0x46, 0xf1, 0xae, 0x50, 0x9a, 0xba, 0x2b, 0xd8, 0x5f, 0x9a, 0xf0, 0x46, 0x1e, 0x9e,
+	0xab, 0xbc, 0x0f, 0x3d, 0x28, 0x72, 0xe5, 0xb5, 0x09, 0xae, 0x14, 0x20, 0xb9, 0x2a, 0xc2, 0xfc,
+	0xc9, 0x80, 0xcd, 0x13, 0x11, 0xb9, 0x26, 0xac, 0xf9, 0xbf, 0x01, 0xeb, 0xfb, 0x9c, 0x12, 0x37,
+	0xb8, 0x50, 0xe9, 0x98, 0x92, 0xcc, 0x3c, 0x5f, 0x3d, 0x58, 0x3a, 0x23, 0xe2, 0xf3, 0x72, 0xdf,
+	0x9c, 0x02, 0x7f, 0x69, 0x4e, 0x81, 0x6f, 0xf7, 0x60, 0xa3, 0x60, 0xb9, 0xf2, 0x49, 0x16, 0x8c,
+	0x8d, 0x53, 0x83, 0xf1, 0xef, 0x4c, 0x68, 0x4f, 0xec, 0x72, 0x91, 0xe8, 0x78, 0x66, 0x14, 0xf3,
+	0x70, 0x94, 0x4e, 0x0c, 0xe3, 0xe5, 0x79, 0x75, 0xda, 0xd2, 0x19, 0x91, 0x9f, 0x83, 0x6e, 0x65,
+	0x1e, 0xba, 0xbb, 0xf0, 0xb5, 0x99, 0xb8, 0x2c, 0x80, 0xf1, 0x5f, 0x4d, 0xd8, 0x9c, 0xd8, 0xeb,
+	0xc2, 0xa1, 0xe5, 0xa5, 0x00, 0x5d, 0x8c, 0x89, 0xe5, 0x53, 0xeb, 0xa7, 0xcb, 0xc6, 0xfc, 0x13,
+	0xd8, 0x3a, 0x19, 0xa7, 0x05, 0x80, 0xff, 0x87, 0x09, 0xaf, 0x17, 0x37, 0xbc, 0x48, 0x09, 0xf4,
+	0x52, 0x60, 0x9f, 0xac, 0x6b, 0xca, 0x0b, 0xd4, 0x35, 0x97, 0xed, 0x86, 0x1f, 0xc2, 0x1b, 0x27,
+	0xa1, 0xb6, 0x80, 0x13, 0xbe, 0x03, 0xb5, 0x1d, 0x72, 0xe8, 0x85, 0x0b, 0x41, 0x6e, 0x3f, 0x84,
+	0xba, 0x5a, 0xad, 0x8e, 0xce, 0x65, 0x12, 0x63, 0x7e, 0x26, 0xb1, 0x8f, 0xa0, 0xde, 0x8b, 0x82,
+	0xc0, 0xe3, 0x97, 0x9d, 0xbf, 0xed, 0x0f, 0xa0, 0xa1, 0x4f, 0x3a, 0xbf, 0x9a, 0x3f, 0x85, 0x55,
+	0x1c, 0xf9, 0xfe, 0x81, 0xdb, 0x3f, 0xbe, 0x74, 0x45, 0x11, 0xac, 0x65, 0x67, 0x49, 0x55, 0xed,
+	0xff, 0x9a, 0x70, 0x63, 0x7f, 0xe8, 0x7b, 0x5c, 0x79, 0x6f, 0x11, 0x15, 0xe6, 0xdd, 0xbd, 0xce,
+	0x5c, 0x20, 0xbe, 0x09, 0x35, 0x96, 0xe8, 0xa1, 0x6a, 0x40, 0x15, 0xff, 0x2d, 0x31, 0x26, 0xab,
+	0xbf, 0xa4, 0xee, 0xd1, 0x22, 0x71, 0xc8, 0x55, 0x7e, 0x04, 0x25, 0x11, 0x87, 0x1c, 0x7d, 0x13,
+	0x9a, 0x61, 0x1c, 0x38, 0x34, 0xfa, 0x82, 0x39, 0x43, 0x42, 0x1d, 0xb1, 0x73, 0x42, 0x7d, 0xae,
+	0x38, 0x7f, 0x33, 0x8c, 0x03, 0x1c, 0x7d, 0xc1, 0xf6, 0x08, 0x15, 0x87, 0xef, 0xb9, 0x94, 0xa3,
+	0xef, 0x41, 0xd5, 0xf5, 0x0f, 0x23, 0xea, 0xf1, 0xa3, 0x40, 0x15, 0x7d, 0xb6, 0x52, 0x73, 0x0a,
+	0x99, 0xce, 0xf7, 0xb5, 0x24, 0xce, 0x16, 0xa1, 0x77, 0x01, 0xc5, 0x8c, 0x38, 0x52, 0x39, 0x79,
+	0xe8, 0xa8, 0xab, 0x2a, 0xc0, 0xd5, 0x98, 0x91, 0x6c, 0x9b, 0xcf, 0xba, 0xf6, 0xbf, 0x4a, 0x80,
+	0xf2, 0xfb, 0x2a, 0xce, 0x7c, 0x1b, 0x2a, 0x62, 0x3d, 0x6b, 0x19, 0x22, 0x2a, 0x6c, 0xa6, 0x6e,
+	0x9c, 0x92, 0xed, 0x24, 0x6a, 0x63, 0x25, 0xde, 0xfe, 0x09, 0xd4, 0xf4, 0x33, 0x2a, 0xcc, 0x99,
+	0xf7, 0x3e, 0x7e, 0x32, 0xfc, 0x98, 0x67, 0x08, 0x3f, 0xed, 0xef, 0xaa, 0x77, 0xfd, 0xa7, 0xee,
+	0x9d, 0xa5, 0x6e, 0x33, 0x9f, 0xba, 0xdb, 0xff, 0x31, 0xa0, 0x2c, 0x16, 0x9f, 0xf9, 0x2e, 0xff,
+	0x31, 0x34, 0x52, 0x2d, 0xa5, 0xf7, 0x24, 0xb3, 0xef, 0xce, 0x81, 0x24, 0x0f, 0x01, 0xae, 0x1d,
+	0xe7, 0x01, 0xe9, 0x81, 0xfc, 0xa2, 0x22, 0xb7, 0x92, 0x3c, 0x7c, 0x7b, 0xce, 0x56, 0xa9, 0xb9,
+	0xb8, 0xca, 0x52, 0xcb, 0x11, 0x94, 0x99, 0xf7, 0x33, 0xa2, 0xbe, 0xba, 0x88, 0xb6, 0xfd, 0x1e,
+	0x6c, 0x3c, 0x26, 0x7c, 0x9f, 0x8e, 0x74, 0xaa, 0xd2, 0x8f, 0xcf, 0x1c, 0x98, 0x6c, 0x0c, 0xb7,
+	0x8a, 0x8b, 0x14, 0x03, 0xde, 0x87, 0x1a, 0xa3, 0x23, 0x67, 0x62, 0xa5, 0xd5, 0xdd, 0xc8, 0xdc,
+	0x93, 0x5f, 0x64, 0xb1, 0xac, 0x63, 0xff, 0xc5, 0x80, 0xc6, 0x1e, 0x25, 0x43, 0x97, 0x2e, 0x78,
+	0x03, 0x5e, 0x83, 0x12, 0x7b, 0xe6, 0xab, 0x87, 0x37, 0x69, 0xce, 0x4d, 0x62, 0x8b, 0x15, 0x1e,
+	0xf6, 0x9f, 0x0d, 0x58, 0x4d, 0xb5, 0x3c, 0x5f, 0x05, 0x91, 0x04, 0x07, 0xee, 0x72, 0x12, 0x90,
+	0x90, 0x67, 0x5f, 0xa5, 0xac, 0x74, 0x4c, 0xbc, 0x4b, 0xb1, 0x92, 0x1c, 0x17, 0xa8, 0xe0, 0x50,
+	0x12, 0x12, 0x20, 0x86, 0x64, 0x70, 0x78, 0x1b, 0x2a, 0x4f, 0x3d, 0xe2, 0x0f, 0x74, 0xda, 0xad,
+	0x29, 0xfe, 0xfd, 0x20, 0x19, 0xc4, 0x6a, 0xce, 0xfe, 0x9f, 0x09, 0xb7, 0x54, 0xe6, 0x53, 0xba,
+	0x0e, 0x2e, 0xbd, 0x00, 0x2c, 0x5a, 0x57, 0x9a, 0xb6, 0xee, 0x73, 0x68, 0x1c, 0x78, 0xe1, 0xc0,
+	0x19, 0xb9, 0xd4, 0x4b, 0x10, 0xd5, 0x46, 0xdc, 0x2f, 0xbc, 0xef, 0x2a, 0xe8, 0xdc, 0xd9, 0xf1,
+	0xc2, 0xc1, 0x67, 0x7a, 0xcd, 0xa3, 0x90, 0xd3, 0x31, 0xae, 0x1f, 0xe4, 0xc7, 0xce, 0xf7, 0xb1,
+	0xaa, 0xfd, 0x23, 0x40, 0xd3, 0x5b, 0x26, 0xf4, 0x39, 0x26, 0x63, 0x45, 0xf5, 0xa4, 0x89, 0xde,
+	0xc9, 0xbf, 0xb8, 0xb2, 0xba, 0x37, 0xf5, 0xb3, 0x9e, 0x5b, 0xab, 0xde, 0x66, 0x3d, 0x34, 0xdf,
+	0x37, 0xec, 0xdf, 0x1b, 0xd0, 0x9c, 0xb2, 0xe0, 0x5a, 0xbc, 0xaf, 0xf2, 0x60, 0xbd, 0xe7, 0x47,
+	0xec, 0x82, 0x64, 0x78, 0x0b, 0xea, 0x79, 0x0f, 0xcb, 0x10, 0x59, 0xc2, 0xb5, 0x9c, 0x8b, 0x99,
+	0xdd, 0x84, 0x8d, 0xc2, 0x51, 0x2a, 0x47, 0xff, 0x1c, 0xd6, 0x3e, 0x8e, 0x7d, 0xee, 0x0d, 0x7d,
+	0xf2, 0x5c, 0x9f, 0xff, 0x3a, 0x00, 0x95, 0x4d, 0xad, 0x40, 0x19, 0x57, 0xd5, 0xc8, 0xee, 0x00,
+	0xb5, 0x60, 0x99, 0x7b, 0x01, 0x89, 0x62, 0xae, 0x9e, 0x15, 0xdd, 0x45, 0xdf, 0x80, 0x65, 0x22,
+	0x91, 0x56, 0xd6, 0xdf, 0x2a, 0x50, 0x48, 0x9d, 0x80, 0xb5, 0x98, 0xfd, 0x6b, 0x03, 0x6e, 0xe4,
+	0xce, 0x57, 0x6e, 0x39, 0x45, 0x81, 0xd4, 0x6b, 0xe6, 0x5c, 0xaf, 0xdd, 0x2f, 0x6a, 0xd3, 0x9c,
+	0xd2, 0x46, 0x9e, 0x97, 0xa9, 0xf3, 0x00, 0x5a, 0x8f, 0x09, 0xd7, 0xb1, 0x6f, 0xbf, 0x7f, 0x44,
+	0x02, 0xf7, 0x2c, 0x81, 0x37, 0x06, 0x4b, 0x0a, 0x7f, 0x48, 0xbd, 0xa7, 0x3c, 0xfb, 0x34, 0x6d,
+	0xe4, 0x3f, 0x4d, 0xaf, 0xc3, 0x92, 0x88, 0x58, 0xfa, 0x83, 0xb5, 0xe8, 0x24, 0x68, 0xca, 0x5b,
+	0x89, 0x7e, 0xbb, 0xa8, 0xbb, 0x68, 0x0b, 0xac, 0x01, 0x61, 0x7d, 0xea, 0x0d, 0xc5, 0x63, 0x23,
+	0xab, 0xfb, 0xfc, 0x90, 0xfd, 0x37, 0x03, 0x6e, 0xcf, 0xd0, 0x57, 0xa1, 0x78, 0x17, 0x56, 0x29,
+	0x79, 0x4a, 0x28, 0x09, 0xfb, 0xc4, 0xc9, 0xeb, 0xd3, 0x48, 0x87, 0x45, 0x3a, 0x42, 0x1f, 0x40,
+	0x85, 0x89, 0xa5, 0x0a, 0xd0, 0xb7, 0x3a, 0xd3, 0xff, 0x81, 0x50, 0xe6, 0x91, 0xa7, 0x5e, 0xe8,
+	0x25, 0x67, 0x63, 0xb5, 0x04, 0xbd, 0x0b, 0x95, 0x41, 0x62, 0xb4, 0x7e, 0xa9, 0x72, 0x33, 0x7d,
+	0x34, 0x32, 0x40, 0xb0, 0x12, 0xd9, 0x69, 0x43, 0xab, 0x1f, 0x05, 0x9d, 0x71, 0x14, 0xf3, 0xf8,
+	0x80, 0x74, 0x46, 0x1e, 0x27, 0x8c, 0xc9, 0xbf, 0x50, 0x1c, 0x54, 0xc4, 0xcf, 0x7b, 0x5f, 0x0d,
+	0x00, 0xde, 0x9a, 0xe0, 0x0a, 0xa4, 0x21, 0x00, 0x00,
 }
@@ -104,6 +104,20 @@ type VitessClient interface {
 	// using custom sharding.
 	// API group: Topology
 	GetSrvKeyspace(ctx context.Context, in *vtgate.GetSrvKeyspaceRequest, opts ...grpc.CallOption) (*vtgate.GetSrvKeyspaceResponse, error)
+	// Prepare parses and plans a V3 query once, for the ExecutePrepared
+	// calls of its statement.
+	// API group: v3 API (alpha)
+	Prepare(ctx context.Context, in *vtgate.PrepareRequest, opts ...grpc.CallOption) (*vtgate.PrepareResponse, error)
+	// ExecutePrepared executes a statement returned by Prepare.
+	// API group: v3 API (alpha)
+	ExecutePrepared(ctx context.Context, in *vtgate.ExecutePreparedRequest, opts ...grpc.CallOption) (*vtgate.ExecutePreparedResponse, error)
+	// ClosePrepared releases statements returned by Prepare.
+	// API group: v3 API (alpha)
+	ClosePrepared(ctx context.Context, in *vtgate.ClosePreparedRequest, opts ...grpc.CallOption) (*vtgate.ClosePreparedResponse, error)
+	// GetKeyspaceSchema returns the schema of a keyspace, read from the
+	// master of each of its shards, and the differences between them.
+	// API group: Topology
+	GetKeyspaceSchema(ctx context.Context, in *vtgate.GetKeyspaceSchemaRequest, opts ...grpc.CallOption) (*vtgate.GetKeyspaceSchemaResponse, error)
 }
 
 type vitessClient struct {
@@ -350,6 +364,42 @@ func (c *vitessClient) GetSrvKeyspace(ctx context.Context, in *vtgate.GetSrvKeys
 	return out, nil
 }
 
+func (c *vitessClient) Prepare(ctx context.Context, in *vtgate.PrepareRequest, opts ...grpc.CallOption) (*vtgate.PrepareResponse, error) {
+	out := new(vtgate.PrepareResponse)
+	err := grpc.Invoke(ctx, "/vtgateservice.Vitess/Prepare", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vitessClient) ExecutePrepared(ctx context.Context, in *vtgate.ExecutePreparedRequest, opts ...grpc.CallOption) (*vtgate.ExecutePreparedResponse, error) {
+	out := new(vtgate.ExecutePreparedResponse)
+	err := grpc.Invoke(ctx, "/vtgateservice.Vitess/ExecutePrepared", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vitessClient) ClosePrepared(ctx context.Context, in *vtgate.ClosePreparedRequest, opts ...grpc.CallOption) (*vtgate.ClosePreparedResponse, error) {
+	out := new(vtgate.ClosePreparedResponse)
+	err := grpc.Invoke(ctx, "/vtgateservice.Vitess/ClosePrepared", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *vitessClient) GetKeyspaceSchema(ctx context.Context, in *vtgate.GetKeyspaceSchemaRequest, opts ...grpc.CallOption) (*vtgate.GetKeyspaceSchemaResponse, error) {
+	out := new(vtgate.GetKeyspaceSchemaResponse)
+	err := grpc.Invoke(ctx, "/vtgateservice.Vitess/GetKeyspaceSchema", in, out, c.cc, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // Server API for Vitess service
 
 type VitessServer interface {
@@ -415,6 +465,20 @@ type VitessServer interface {
 	// using custom sharding.
 	// API group: Topology
 	GetSrvKeyspace(context.Context, *vtgate.GetSrvKeyspaceRequest) (*vtgate.GetSrvKeyspaceResponse, error)
+	// Prepare parses and plans a V3 query once, for the ExecutePrepared
+	// calls of its statement.
+	// API group: v3 API (alpha)
+	Prepare(context.Context, *vtgate.PrepareRequest) (*vtgate.PrepareResponse, error)
+	// ExecutePrepared executes a statement returned by Prepare.
+	// API group: v3 API (alpha)
+	ExecutePrepared(context.Context, *vtgate.ExecutePreparedRequest) (*vtgate.ExecutePreparedResponse, error)
+	// ClosePrepared releases statements returned by Prepare.
+	// API group: v3 API (alpha)
+	ClosePrepared(context.Context, *vtgate.ClosePreparedRequest) (*vtgate.ClosePreparedResponse, error)
+	// GetKeyspaceSchema returns the schema of a keyspace, read from the
+	// master of each of its shards, and the differences between them.
+	// API group: Topology
+	GetKeyspaceSchema(context.Context, *vtgate.GetKeyspaceSchemaRequest) (*vtgate.GetKeyspaceSchemaResponse, error)
 }
 
 func RegisterVitessServer(s *grpc.Server, srv VitessServer) {
@@ -721,6 +785,78 @@ func _Vitess_GetSrvKeyspace_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Vitess_Prepare_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtgate.PrepareRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VitessServer).Prepare(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtgateservice.Vitess/Prepare",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VitessServer).Prepare(ctx, req.(*vtgate.PrepareRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vitess_ExecutePrepared_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtgate.ExecutePreparedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VitessServer).ExecutePrepared(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtgateservice.Vitess/ExecutePrepared",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VitessServer).ExecutePrepared(ctx, req.(*vtgate.ExecutePreparedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vitess_ClosePrepared_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtgate.ClosePreparedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VitessServer).ClosePrepared(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtgateservice.Vitess/ClosePrepared",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VitessServer).ClosePrepared(ctx, req.(*vtgate.ClosePreparedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Vitess_GetKeyspaceSchema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(vtgate.GetKeyspaceSchemaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VitessServer).GetKeyspaceSchema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/vtgateservice.Vitess/GetKeyspaceSchema",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VitessServer).GetKeyspaceSchema(ctx, req.(*vtgate.GetKeyspaceSchemaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _Vitess_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "vtgateservice.Vitess",
 	HandlerType: (*VitessServer)(nil),
@@ -773,6 +909,22 @@ var _Vitess_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetSrvKeyspace",
 			Handler:    _Vitess_GetSrvKeyspace_Handler,
 		},
+		{
+			MethodName: "Prepare",
+			Handler:    _Vitess_Prepare_Handler,
+		},
+		{
+			MethodName: "ExecutePrepared",
+			Handler:    _Vitess_ExecutePrepared_Handler,
+		},
+		{
+			MethodName: "ClosePrepared",
+			Handler:    _Vitess_ClosePrepared_Handler,
+		},
+		{
+			MethodName: "GetKeyspaceSchema",
+			Handler:    _Vitess_GetKeyspaceSchema_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -798,34 +950,144 @@ var _Vitess_serviceDesc = grpc.ServiceDesc{
 	},
 }
 
+// Client API for RequestMultiplexer service
+
+type RequestMultiplexerClient interface {
+	// Multiplex executes the requests of the stream concurrently, and
+	// sends their responses as they complete, tagged with the request_id
+	// of their request.
+	// API group: v3 API (alpha)
+	Multiplex(ctx context.Context, opts ...grpc.CallOption) (RequestMultiplexer_MultiplexClient, error)
+}
+
+type requestMultiplexerClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewRequestMultiplexerClient(cc *grpc.ClientConn) RequestMultiplexerClient {
+	return &requestMultiplexerClient{cc}
+}
+
+func (c *requestMultiplexerClient) Multiplex(ctx context.Context, opts ...grpc.CallOption) (RequestMultiplexer_MultiplexClient, error) {
+	stream, err := grpc.NewClientStream(ctx, &_RequestMultiplexer_serviceDesc.Streams[0], c.cc, "/vtgateservice.RequestMultiplexer/Multiplex", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &requestMultiplexerMultiplexClient{stream}
+	return x, nil
+}
+
+type RequestMultiplexer_MultiplexClient interface {
+	Send(*vtgate.MultiplexRequest) error
+	Recv() (*vtgate.MultiplexResponse, error)
+	grpc.ClientStream
+}
+
+type requestMultiplexerMultiplexClient struct {
+	grpc.ClientStream
+}
+
+func (x *requestMultiplexerMultiplexClient) Send(m *vtgate.MultiplexRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *requestMultiplexerMultiplexClient) Recv() (*vtgate.MultiplexResponse, error) {
+	m := new(vtgate.MultiplexResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Server API for RequestMultiplexer service
+
+type RequestMultiplexerServer interface {
+	// Multiplex executes the requests of the stream concurrently, and
+	// sends their responses as they complete, tagged with the request_id
+	// of their request.
+	// API group: v3 API (alpha)
+	Multiplex(RequestMultiplexer_MultiplexServer) error
+}
+
+func RegisterRequestMultiplexerServer(s *grpc.Server, srv RequestMultiplexerServer) {
+	s.RegisterService(&_RequestMultiplexer_serviceDesc, srv)
+}
+
+func _RequestMultiplexer_Multiplex_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(RequestMultiplexerServer).Multiplex(&requestMultiplexerMultiplexServer{stream})
+}
+
+type RequestMultiplexer_MultiplexServer interface {
+	Send(*vtgate.MultiplexResponse) error
+	Recv() (*vtgate.MultiplexRequest, error)
+	grpc.ServerStream
+}
+
+type requestMultiplexerMultiplexServer struct {
+	grpc.ServerStream
+}
+
+func (x *requestMultiplexerMultiplexServer) Send(m *vtgate.MultiplexResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *requestMultiplexerMultiplexServer) Recv() (*vtgate.MultiplexRequest, error) {
+	m := new(vtgate.MultiplexRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+var _RequestMultiplexer_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "vtgateservice.RequestMultiplexer",
+	HandlerType: (*RequestMultiplexerServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Multiplex",
+			Handler:       _RequestMultiplexer_Multiplex_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+}
+
 var fileDescriptor0 = []byte{
-	// 443 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x84, 0x94, 0x5f, 0x8b, 0xd3, 0x40,
-	0x14, 0xc5, 0xf5, 0xc1, 0x2a, 0x17, 0x2b, 0x32, 0xd5, 0xb6, 0x16, 0x6b, 0xb5, 0x62, 0xeb, 0x53,
-	0x10, 0x05, 0x41, 0x10, 0x84, 0x4a, 0x11, 0x59, 0x58, 0xb6, 0x0d, 0xec, 0x3e, 0xed, 0x43, 0x92,
-	0xbd, 0xa4, 0xa1, 0xf9, 0xd7, 0xcc, 0x24, 0x6c, 0xbe, 0xe9, 0x7e, 0x9c, 0xed, 0x36, 0x99, 0xd9,
-	0x99, 0xc9, 0xa4, 0x7d, 0xdb, 0x39, 0xe7, 0xdc, 0xdf, 0x6c, 0x4f, 0x2e, 0x03, 0xbd, 0x82, 0xf9,
-	0x0e, 0x43, 0x8a, 0x59, 0x11, 0x78, 0x68, 0xa5, 0x59, 0xc2, 0x12, 0xd2, 0x55, 0xc4, 0xd1, 0xcb,
-	0xea, 0x58, 0x99, 0xdf, 0xef, 0x00, 0x3a, 0x97, 0xc1, 0xde, 0xa5, 0xe4, 0x37, 0x3c, 0x5f, 0xde,
-	0xa2, 0x97, 0x33, 0x24, 0x7d, 0xab, 0x0e, 0xd5, 0xc2, 0x1a, 0x77, 0x39, 0x52, 0x36, 0x1a, 0x34,
-	0x74, 0x9a, 0x26, 0x31, 0xc5, 0xe9, 0x13, 0x72, 0x0e, 0xdd, 0x5a, 0xb4, 0x37, 0x4e, 0x76, 0x43,
-	0xc9, 0x7b, 0x2d, 0x5b, 0xc9, 0x9c, 0x34, 0x6e, 0x71, 0x05, 0xef, 0x1a, 0x48, 0x6d, 0x9d, 0x61,
-	0x49, 0x53, 0xc7, 0xc3, 0xff, 0x7b, 0xe8, 0x27, 0x6d, 0x4c, 0xf2, 0x38, 0x79, 0x7a, 0x2c, 0x22,
-	0xf0, 0x57, 0xf0, 0xfa, 0xd1, 0x5f, 0x3b, 0xb1, 0x8f, 0x94, 0x4c, 0x9a, 0x93, 0x95, 0xc3, 0xd1,
-	0x1f, 0xdb, 0x03, 0x06, 0xf0, 0x32, 0x66, 0x01, 0x2b, 0x1f, 0xfe, 0x6b, 0x1d, 0x2c, 0x9c, 0x36,
-	0xb0, 0x14, 0x30, 0x14, 0xb2, 0x70, 0x98, 0xb7, 0xa9, 0x5b, 0xd6, 0x0b, 0x91, 0xbc, 0xb6, 0x42,
-	0x94, 0x88, 0xc0, 0x87, 0x30, 0x90, 0x7d, 0xb9, 0xf4, 0x99, 0x09, 0x60, 0x68, 0x7e, 0x7e, 0x32,
-	0x27, 0x6e, 0xbb, 0x80, 0xae, 0xcd, 0x32, 0x74, 0x22, 0xbe, 0x71, 0x62, 0x5b, 0x14, 0xb9, 0xb1,
-	0x2d, 0x9a, 0xcb, 0x79, 0xdf, 0x9e, 0x12, 0x17, 0x7a, 0x8a, 0x59, 0xf7, 0x33, 0x35, 0x4e, 0xaa,
-	0x05, 0x7d, 0x3e, 0x9a, 0x91, 0xee, 0xd8, 0xc1, 0x50, 0x89, 0xc8, 0x25, 0xcd, 0x8d, 0x10, 0x43,
-	0x4b, 0x5f, 0x4f, 0x07, 0xa5, 0x2b, 0xb7, 0xd0, 0xd7, 0x73, 0xf5, 0xb6, 0x7e, 0x69, 0xe3, 0xa8,
-	0x3b, 0x3b, 0x3b, 0x15, 0x93, 0x2e, 0xfb, 0x09, 0xcf, 0x16, 0xe8, 0x07, 0x31, 0x79, 0xc3, 0x87,
-	0x0e, 0x47, 0x8e, 0x7a, 0xab, 0xa9, 0xe2, 0x6b, 0xfe, 0x82, 0xce, 0xdf, 0x24, 0x8a, 0x02, 0x46,
-	0x44, 0xa4, 0x3a, 0xf3, 0xc9, 0xbe, 0x2e, 0x8b, 0xd1, 0x3f, 0xf0, 0x62, 0x9d, 0x84, 0xa1, 0xeb,
-	0x78, 0x5b, 0x22, 0x5e, 0x17, 0xae, 0xf0, 0xf1, 0x61, 0xd3, 0x10, 0x80, 0x25, 0x80, 0x9d, 0x86,
-	0x01, 0x5b, 0xe5, 0x98, 0x95, 0xe4, 0x9d, 0xf8, 0xb5, 0x42, 0xe3, 0x90, 0x91, 0xc9, 0x12, 0x98,
-	0x15, 0xbc, 0xfa, 0x87, 0xcc, 0xce, 0x0a, 0xfe, 0x21, 0x88, 0xd8, 0x39, 0x55, 0xe7, 0xb8, 0x0f,
-	0x6d, 0x36, 0x47, 0x2e, 0x26, 0x30, 0xf6, 0x92, 0xc8, 0x2a, 0x93, 0x9c, 0xe5, 0x2e, 0x5a, 0xc5,
-	0xe1, 0x95, 0xad, 0x9e, 0x5d, 0xcb, 0xcf, 0x52, 0xcf, 0xed, 0x1c, 0xfe, 0xfe, 0x71, 0x1f, 0x00,
-	0x00, 0xff, 0xff, 0x36, 0x12, 0x3e, 0x98, 0xb6, 0x05, 0x00, 0x00,
+	// 547 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x09, 0x6e, 0x88, 0x02, 0xff, 0x84, 0x95, 0x6f, 0x6b, 0x13, 0x41,
+	0x10, 0xc6, 0xed, 0x0b, 0x53, 0x1d, 0x8c, 0x7f, 0xb6, 0x9a, 0xb6, 0xc1, 0x36, 0x6d, 0xc4, 0xb6,
+	0xaf, 0x42, 0xa9, 0x20, 0x08, 0x05, 0x21, 0x25, 0x96, 0x22, 0x4a, 0x9b, 0x13, 0x05, 0xd1, 0x17,
+	0x97, 0xcb, 0x90, 0x1c, 0xbd, 0xcb, 0x5d, 0x77, 0xf7, 0x42, 0xf3, 0x4d, 0xfc, 0xb8, 0x42, 0x6e,
+	0x67, 0xba, 0x7b, 0x7f, 0x92, 0x77, 0xd9, 0xe7, 0x79, 0xe6, 0xb7, 0x64, 0x76, 0x76, 0x0f, 0xb6,
+	0xe6, 0x7a, 0xe2, 0x6b, 0x54, 0x28, 0xe7, 0x61, 0x80, 0xbd, 0x54, 0x26, 0x3a, 0x11, 0x4d, 0x47,
+	0x6c, 0x3f, 0xcb, 0x97, 0xb9, 0x79, 0xf6, 0xaf, 0x09, 0x8d, 0x9f, 0xa1, 0x46, 0xa5, 0xc4, 0x39,
+	0x6c, 0x0e, 0xee, 0x31, 0xc8, 0x34, 0x8a, 0x56, 0xcf, 0x84, 0x8c, 0x30, 0xc4, 0xbb, 0x0c, 0x95,
+	0x6e, 0x6f, 0x97, 0x74, 0x95, 0x26, 0x33, 0x85, 0xdd, 0x47, 0xe2, 0x3b, 0x34, 0x8d, 0xe8, 0x4d,
+	0x7d, 0x39, 0x56, 0xe2, 0x6d, 0x21, 0x9b, 0xcb, 0x44, 0xda, 0xab, 0x71, 0x99, 0xf7, 0x17, 0x84,
+	0xb1, 0xbe, 0xe2, 0x42, 0xa5, 0x7e, 0x80, 0x57, 0x63, 0x25, 0x0e, 0x0b, 0x65, 0x96, 0x47, 0xe4,
+	0xee, 0xaa, 0x08, 0xe3, 0x7f, 0xc1, 0xcb, 0x07, 0x7f, 0xe8, 0xcf, 0x26, 0xa8, 0x44, 0xa7, 0x5c,
+	0x99, 0x3b, 0x84, 0x3e, 0xa8, 0x0f, 0x54, 0x80, 0x07, 0x33, 0x1d, 0xea, 0xc5, 0xd5, 0xb8, 0x0c,
+	0x66, 0xa7, 0x0e, 0x6c, 0x05, 0x2a, 0x1a, 0xd2, 0xf7, 0x75, 0x30, 0x35, 0x5d, 0x2e, 0x36, 0xc4,
+	0xf2, 0xea, 0x1a, 0xe2, 0x44, 0x18, 0x1f, 0xc1, 0xb6, 0xed, 0xdb, 0x4d, 0x3f, 0xaa, 0x02, 0x54,
+	0x74, 0xfe, 0x78, 0x6d, 0x8e, 0x77, 0xbb, 0x86, 0xa6, 0xa7, 0x25, 0xfa, 0x31, 0x4d, 0x1c, 0x4f,
+	0x8b, 0x23, 0x97, 0xa6, 0xa5, 0xe0, 0x12, 0xef, 0x74, 0x43, 0x8c, 0x60, 0xcb, 0x31, 0x4d, 0x7f,
+	0xba, 0x95, 0x95, 0x6e, 0x83, 0xde, 0xad, 0xcc, 0x58, 0x7b, 0xdc, 0xc1, 0x8e, 0x13, 0xb1, 0x9b,
+	0x74, 0x5c, 0x09, 0xa9, 0xe8, 0xd2, 0xc9, 0xfa, 0xa0, 0xb5, 0xe5, 0x2d, 0xb4, 0x8a, 0x39, 0x33,
+	0xad, 0xef, 0xeb, 0x38, 0xee, 0xcc, 0x1e, 0xad, 0x8b, 0x59, 0x9b, 0x7d, 0x84, 0xc7, 0x7d, 0x9c,
+	0x84, 0x33, 0xf1, 0x9a, 0x8a, 0x96, 0x4b, 0x42, 0xbd, 0x29, 0xa8, 0x7c, 0x9a, 0x9f, 0xa0, 0x71,
+	0x91, 0xc4, 0x71, 0xa8, 0x05, 0x47, 0xf2, 0x35, 0x55, 0xb6, 0x8a, 0x32, 0x97, 0x7e, 0x86, 0x27,
+	0xc3, 0x24, 0x8a, 0x46, 0x7e, 0x70, 0x2b, 0xf8, 0x75, 0x21, 0x85, 0xca, 0x77, 0xca, 0x06, 0x03,
+	0x06, 0x00, 0x5e, 0x1a, 0x85, 0xfa, 0x26, 0x43, 0xb9, 0x10, 0xbb, 0xfc, 0x6f, 0x59, 0x23, 0x48,
+	0xbb, 0xca, 0x62, 0xcc, 0x0d, 0x3c, 0xbf, 0x44, 0xed, 0xc9, 0x39, 0x1d, 0x84, 0xe0, 0x99, 0x73,
+	0x75, 0xc2, 0xed, 0xd7, 0xd9, 0x8c, 0x3c, 0x87, 0xcd, 0x6b, 0x89, 0xa9, 0x2f, 0xad, 0xf7, 0xd4,
+	0x08, 0xa5, 0xf7, 0x94, 0x75, 0xae, 0xfe, 0x01, 0x2f, 0xcc, 0x59, 0x19, 0x6f, 0x2c, 0xf6, 0x0b,
+	0xf7, 0x8b, 0x0c, 0xa2, 0x75, 0x6a, 0x7d, 0xfb, 0x95, 0xbe, 0x88, 0x12, 0xf5, 0xc0, 0xe4, 0x7b,
+	0xe7, 0xc8, 0xa5, 0x7b, 0x57, 0x70, 0x99, 0xf7, 0x1b, 0x5e, 0x5d, 0xa2, 0xa6, 0x3f, 0xef, 0x05,
+	0x53, 0x8c, 0x7d, 0x71, 0x60, 0xb5, 0xc6, 0xb5, 0x88, 0x7b, 0xb8, 0x22, 0x41, 0xec, 0xb3, 0x3f,
+	0x20, 0x4c, 0xfe, 0x5b, 0x16, 0xe9, 0x30, 0x8d, 0xf0, 0x1e, 0xa5, 0xf8, 0x02, 0x4f, 0x79, 0x29,
+	0x78, 0x30, 0x58, 0xa2, 0x1d, 0x76, 0x2b, 0x1c, 0x22, 0x9f, 0x6c, 0x9c, 0x6e, 0xf4, 0x3b, 0xb0,
+	0x17, 0x24, 0x71, 0x6f, 0x91, 0x64, 0x3a, 0x1b, 0x61, 0x6f, 0xbe, 0xfc, 0x06, 0xe6, 0x1f, 0xc5,
+	0xde, 0x44, 0xa6, 0xc1, 0xa8, 0xb1, 0xfc, 0xfd, 0xe1, 0xff, 0x00, 0x52, 0x58, 0x79, 0x85, 0x54,
+	0x07, 0x00, 0x00,
 }
@@ -0,0 +1,60 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package rowversion stores in the Context the version tokens of the
+// optimistic concurrency of the read-modify-write flows: a primary key
+// read of the rowcache returns the token of its row in a Recorder, and
+// a primary key DML given that token back only changes the row if it
+// didn't change in between. Like for callerid, the RPC layers copy
+// them to and from the requests.
+package rowversion
+
+import (
+	"golang.org/x/net/context"
+)
+
+// The datatype for the row version Context Keys
+type versionKey int
+
+var (
+	// internal Context key for the Recorder of a read
+	recorderKey versionKey
+	// internal Context key for the token a DML expects
+	expectKey versionKey = 1
+)
+
+// Recorder receives the version token of the row of a read.
+type Recorder struct {
+	// Token is the version of the row read, or "" if the read was not
+	// a rowcache read of a single primary key.
+	Token string
+}
+
+// NewRecorderContext returns a Context whose primary key read stores
+// the version token of its row in the returned Recorder. A Recorder is
+// for one read, it must not be shared by concurrent calls.
+func NewRecorderContext(ctx context.Context) (context.Context, *Recorder) {
+	recorder := &Recorder{}
+	return context.WithValue(ctx, recorderKey, recorder), recorder
+}
+
+// RecorderFromContext returns the Recorder stored by
+// NewRecorderContext, or nil if there's none.
+func RecorderFromContext(ctx context.Context) *Recorder {
+	recorder, _ := ctx.Value(recorderKey).(*Recorder)
+	return recorder
+}
+
+// NewExpectContext returns a Context whose primary key DML only
+// changes its row if the row still has the version token.
+func NewExpectContext(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, expectKey, token)
+}
+
+// ExpectFromContext returns the token stored by NewExpectContext, or ""
+// if there's none.
+func ExpectFromContext(ctx context.Context) string {
+	token, _ := ctx.Value(expectKey).(string)
+	return token
+}
@@ -0,0 +1,40 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package rowversion
+
+import (
+	"testing"
+
+	"golang.org/x/net/context"
+)
+
+func TestRecorder(t *testing.T) {
+	ctx := context.Background()
+	if recorder := RecorderFromContext(ctx); recorder != nil {
+		t.Errorf("RecorderFromContext(Background): %v, want nil", recorder)
+	}
+	ctx, recorder := NewRecorderContext(ctx)
+	RecorderFromContext(ctx).Token = "3f2a:1b.7"
+	if got, want := recorder.Token, "3f2a:1b.7"; got != want {
+		t.Errorf("Recorder.Token: %q, want %q", got, want)
+	}
+	if got := ExpectFromContext(ctx); got != "" {
+		t.Errorf("ExpectFromContext: %q, want \"\"", got)
+	}
+}
+
+func TestExpect(t *testing.T) {
+	ctx := context.Background()
+	if got := ExpectFromContext(ctx); got != "" {
+		t.Errorf("ExpectFromContext(Background): %q, want \"\"", got)
+	}
+	ctx = NewExpectContext(ctx, "3f2a:1b.7")
+	if got, want := ExpectFromContext(ctx), "3f2a:1b.7"; got != want {
+		t.Errorf("ExpectFromContext: %q, want %q", got, want)
+	}
+	if recorder := RecorderFromContext(ctx); recorder != nil {
+		t.Errorf("RecorderFromContext: %v, want nil", recorder)
+	}
+}
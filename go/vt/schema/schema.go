@@ -38,6 +38,7 @@ type Table struct {
 	Indexes   []*Index
 	PKColumns []int
 	Type      int
+	RowFormat string
 
 	// These vars can be accessed concurrently.
 	TableRows   sync2.AtomicInt64
@@ -0,0 +1,159 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package servenv
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	log "github.com/golang/glog"
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/stats"
+	"github.com/youtube/vitess/go/streamlog"
+	"github.com/youtube/vitess/go/vt/callinfo"
+)
+
+var (
+	adminLogFile = flag.String("admin_log_file", "", "If set, the admin actions streamed on /debug/adminlog are also appended to the named file.")
+
+	// AdminLogger receives an *AdminAction for each action that
+	// changed the state of the running server.
+	AdminLogger = streamlog.New("AdminLog", 100)
+
+	adminActionCounts = stats.NewCounters("AdminActions")
+)
+
+// AdminResultOK is the Result of the admin actions that succeeded.
+const AdminResultOK = "OK"
+
+// AdminAction is the record of an action that changed the state of
+// the running server, like a pool resize from a debug page or a
+// tablet manager RPC.
+type AdminAction struct {
+	Time       time.Time
+	Actor      string
+	RemoteAddr string
+	Action     string
+	Params     string
+	// Result is AdminResultOK or the error of the action.
+	Result string
+}
+
+// Format returns a tab separated version of the action.
+func (aa *AdminAction) Format(params url.Values) string {
+	return fmt.Sprintf(
+		"%v\t%v\t%v\t%v\t%q\t%q\t\n",
+		aa.Time.Format(time.StampMicro),
+		aa.Actor,
+		aa.RemoteAddr,
+		aa.Action,
+		aa.Params,
+		aa.Result,
+	)
+}
+
+// RecordAdminAction records that action was executed with params by
+// the caller of the RPC in ctx. err is the result of the action.
+func RecordAdminAction(ctx context.Context, action, params string, err error) {
+	aa := &AdminAction{
+		Action: action,
+		Params: params,
+	}
+	if ci, ok := callinfo.FromContext(ctx); ok {
+		aa.Actor = ci.Username()
+		aa.RemoteAddr = ci.RemoteAddr()
+	}
+	aa.Result = AdminResultOK
+	if err != nil {
+		aa.Result = err.Error()
+	}
+	sendAdminAction(aa)
+}
+
+// RecordAdminHTTPAction records that action was executed by the HTTP
+// request r. The params are the form values of r. result is
+// AdminResultOK or the reason why the action failed.
+func RecordAdminHTTPAction(r *http.Request, action, result string) {
+	aa := &AdminAction{
+		Actor:      httpActor(r),
+		RemoteAddr: r.RemoteAddr,
+		Action:     action,
+		Result:     result,
+	}
+	if r.Form != nil {
+		aa.Params = r.Form.Encode()
+	} else {
+		aa.Params = r.URL.RawQuery
+	}
+	sendAdminAction(aa)
+}
+
+// AuditHTTP returns a handler that calls handler, and records an
+// admin action for each request. The result of the action is the
+// HTTP status of the response. Handlers that always change the state
+// of the server should be registered with it.
+func AuditHTTP(action string, handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		sw := &statusWriter{ResponseWriter: w, status: http.StatusOK}
+		handler(sw, r)
+		result := AdminResultOK
+		if sw.status != http.StatusOK {
+			result = fmt.Sprintf("%d %s", sw.status, http.StatusText(sw.status))
+		}
+		RecordAdminHTTPAction(r, action, result)
+	}
+}
+
+// statusWriter remembers the status written to a ResponseWriter.
+type statusWriter struct {
+	http.ResponseWriter
+	status int
+}
+
+func (sw *statusWriter) WriteHeader(status int) {
+	sw.status = status
+	sw.ResponseWriter.WriteHeader(status)
+}
+
+// httpActor returns the common name of the TLS client certificate of
+// r, or the user of its basic authentication, if any.
+func httpActor(r *http.Request) string {
+	if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+		return r.TLS.PeerCertificates[0].Subject.CommonName
+	}
+	if user, _, ok := r.BasicAuth(); ok {
+		return user
+	}
+	return ""
+}
+
+func sendAdminAction(aa *AdminAction) {
+	aa.Time = time.Now()
+	adminActionCounts.Add(aa.Action, 1)
+	log.Infof("admin action %v by %v (%v): %v: %v", aa.Action, aa.Actor, aa.RemoteAddr, aa.Params, aa.Result)
+	AdminLogger.Send(aa)
+}
+
+func init() {
+	onInit(func() {
+		format := func(params url.Values, x interface{}) string {
+			return x.(*AdminAction).Format(params)
+		}
+		AdminLogger.ServeLogs("/debug/adminlog", format)
+		if *adminLogFile == "" {
+			return
+		}
+		stop, err := AdminLogger.LogToFile(*adminLogFile, format)
+		if err != nil {
+			log.Errorf("Unable to open admin log file '%s': %v", *adminLogFile, err)
+			return
+		}
+		OnClose(stop)
+	})
+}
@@ -0,0 +1,61 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package servenv
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/context"
+)
+
+func TestAuditHTTP(t *testing.T) {
+	ch := AdminLogger.Subscribe("test")
+	defer AdminLogger.Unsubscribe(ch)
+
+	handler := AuditHTTP("TestAction", func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("fail") != "" {
+			http.Error(w, "failed", http.StatusBadRequest)
+		}
+	})
+	before := adminActionCounts.Counts()["TestAction"]
+
+	request, _ := http.NewRequest("POST", "/test?size=10", nil)
+	request.RemoteAddr = "1.2.3.4:5"
+	request.SetBasicAuth("admin", "secret")
+	handler(httptest.NewRecorder(), request)
+	aa := (<-ch).(*AdminAction)
+	if aa.Action != "TestAction" || aa.Actor != "admin" || aa.RemoteAddr != "1.2.3.4:5" || aa.Params != "size=10" || aa.Result != AdminResultOK {
+		t.Errorf("admin action: %+v, want TestAction by admin from 1.2.3.4:5 with size=10: OK", aa)
+	}
+	if got := aa.Format(nil); !strings.HasSuffix(got, "\tadmin\t1.2.3.4:5\tTestAction\t\"size=10\"\t\"OK\"\t\n") {
+		t.Errorf("Format: %q", got)
+	}
+
+	request, _ = http.NewRequest("POST", "/test?fail=1", nil)
+	handler(httptest.NewRecorder(), request)
+	aa = (<-ch).(*AdminAction)
+	if want := "400 Bad Request"; aa.Result != want {
+		t.Errorf("failed admin action result: %q, want %q", aa.Result, want)
+	}
+
+	if got, want := adminActionCounts.Counts()["TestAction"], before+2; got != want {
+		t.Errorf("AdminActions[TestAction]: %v, want %v", got, want)
+	}
+}
+
+func TestRecordAdminAction(t *testing.T) {
+	ch := AdminLogger.Subscribe("test")
+	defer AdminLogger.Unsubscribe(ch)
+
+	RecordAdminAction(context.Background(), "TestRPC", "arg", errors.New("rpc failed"))
+	aa := (<-ch).(*AdminAction)
+	if aa.Action != "TestRPC" || aa.Params != "arg" || aa.Result != "rpc failed" {
+		t.Errorf("admin action: %+v, want TestRPC with arg: rpc failed", aa)
+	}
+}
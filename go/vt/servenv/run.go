@@ -9,6 +9,7 @@ import (
 	log "github.com/golang/glog"
 	"github.com/youtube/vitess/go/event"
 	"github.com/youtube/vitess/go/proc"
+	"github.com/youtube/vitess/go/stats"
 )
 
 var (
@@ -28,7 +29,7 @@ func Run(port int) {
 	if err != nil {
 		log.Fatal(err)
 	}
-	go http.Serve(l, nil)
+	go http.Serve(l, httpHandler())
 
 	proc.Wait()
 	l.Close()
@@ -48,6 +49,20 @@ func Run(port int) {
 	Close()
 }
 
+// httpHandler returns the handler of the HTTP server: it serves
+// /debug/vars with stats.ServeVars, which streams the variables,
+// instead of the handler expvar registers in http.DefaultServeMux.
+// All the other requests go to http.DefaultServeMux.
+func httpHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/debug/vars" {
+			stats.ServeVars(w, r)
+			return
+		}
+		http.DefaultServeMux.ServeHTTP(w, r)
+	})
+}
+
 // Close runs any registered exit hooks in parallel.
 func Close() {
 	onCloseHooks.Fire()
@@ -0,0 +1,59 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package sessiongtid stores in the Context the GTIDs that vtgate uses
+// to let the sessions read their own writes on the replicas, with
+// -session-gtid-consistency: the GTID a read waits for on the tablet,
+// and the GTID a commit returns from the master. Like for callerid,
+// the RPC layers copy them to and from the requests.
+package sessiongtid
+
+import (
+	"golang.org/x/net/context"
+)
+
+// The datatype for the GTID Context Keys
+type gtidKey int
+
+var (
+	// internal Context key for the GTID to wait for
+	waitGTIDKey gtidKey
+	// internal Context key for the Recorder of a commit
+	recorderKey gtidKey = 1
+)
+
+// NewWaitContext returns a Context whose queries wait until the tablet
+// has replicated up to gtid before they execute.
+func NewWaitContext(ctx context.Context, gtid string) context.Context {
+	return context.WithValue(ctx, waitGTIDKey, gtid)
+}
+
+// WaitGTIDFromContext returns the GTID stored by NewWaitContext, or ""
+// if there's none.
+func WaitGTIDFromContext(ctx context.Context) string {
+	gtid, _ := ctx.Value(waitGTIDKey).(string)
+	return gtid
+}
+
+// Recorder receives the GTID of a commit.
+type Recorder struct {
+	// GTID is the replication position of the master after the
+	// commit, or "" if the master didn't return one.
+	GTID string
+}
+
+// NewRecorderContext returns a Context whose commit stores the GTID of
+// the master in the returned Recorder. A Recorder is for one commit,
+// it must not be shared by concurrent calls.
+func NewRecorderContext(ctx context.Context) (context.Context, *Recorder) {
+	recorder := &Recorder{}
+	return context.WithValue(ctx, recorderKey, recorder), recorder
+}
+
+// RecorderFromContext returns the Recorder stored by
+// NewRecorderContext, or nil if there's none.
+func RecorderFromContext(ctx context.Context) *Recorder {
+	recorder, _ := ctx.Value(recorderKey).(*Recorder)
+	return recorder
+}
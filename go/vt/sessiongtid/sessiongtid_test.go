@@ -0,0 +1,36 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sessiongtid
+
+import (
+	"testing"
+
+	"golang.org/x/net/context"
+)
+
+func TestWaitGTID(t *testing.T) {
+	ctx := context.Background()
+	if got := WaitGTIDFromContext(ctx); got != "" {
+		t.Errorf("WaitGTIDFromContext(Background): %q, want \"\"", got)
+	}
+	ctx = NewWaitContext(ctx, "MySQL56/f9a1c5d2-4e1b-11e6-9f2c-0242ac110002:1-12")
+	if got, want := WaitGTIDFromContext(ctx), "MySQL56/f9a1c5d2-4e1b-11e6-9f2c-0242ac110002:1-12"; got != want {
+		t.Errorf("WaitGTIDFromContext: %q, want %q", got, want)
+	}
+	if recorder := RecorderFromContext(ctx); recorder != nil {
+		t.Errorf("RecorderFromContext: %v, want nil", recorder)
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	ctx, recorder := NewRecorderContext(context.Background())
+	RecorderFromContext(ctx).GTID = "MySQL56/f9a1c5d2-4e1b-11e6-9f2c-0242ac110002:1-13"
+	if got, want := recorder.GTID, "MySQL56/f9a1c5d2-4e1b-11e6-9f2c-0242ac110002:1-13"; got != want {
+		t.Errorf("Recorder.GTID: %q, want %q", got, want)
+	}
+	if got := WaitGTIDFromContext(ctx); got != "" {
+		t.Errorf("WaitGTIDFromContext: %q, want \"\"", got)
+	}
+}
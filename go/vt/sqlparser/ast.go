@@ -92,14 +92,16 @@ type Statement interface {
 	SQLNode
 }
 
-func (*Union) iStatement()  {}
-func (*Select) iStatement() {}
-func (*Insert) iStatement() {}
-func (*Update) iStatement() {}
-func (*Delete) iStatement() {}
-func (*Set) iStatement()    {}
-func (*DDL) iStatement()    {}
-func (*Other) iStatement()  {}
+func (*Union) iStatement()        {}
+func (*Select) iStatement()       {}
+func (*Insert) iStatement()       {}
+func (*Update) iStatement()       {}
+func (*Delete) iStatement()       {}
+func (*Set) iStatement()          {}
+func (*DDL) iStatement()          {}
+func (*Other) iStatement()        {}
+func (*LockTables) iStatement()   {}
+func (*UnlockTables) iStatement() {}
 
 // SelectStatement any SELECT statement.
 type SelectStatement interface {
@@ -137,6 +139,7 @@ const (
 const (
 	ForUpdateStr = " for update"
 	ShareModeStr = " lock in share mode"
+	ForShareStr  = " for share"
 )
 
 // Format formats the node.
@@ -427,6 +430,96 @@ func (node *Other) WalkSubtree(visit Visit) error {
 	return nil
 }
 
+// LockTables represents a LOCK TABLES statement.
+type LockTables struct {
+	Tables LockTableList
+}
+
+// Format formats the node.
+func (node *LockTables) Format(buf *TrackedBuffer) {
+	buf.Myprintf("lock tables %v", node.Tables)
+}
+
+// WalkSubtree walks the nodes of the subtree
+func (node *LockTables) WalkSubtree(visit Visit) error {
+	if node == nil {
+		return nil
+	}
+	return Walk(visit, node.Tables)
+}
+
+// LockTableList represents the tables of a LOCK TABLES statement.
+type LockTableList []*LockTable
+
+// Format formats the node.
+func (node LockTableList) Format(buf *TrackedBuffer) {
+	var prefix string
+	for _, n := range node {
+		buf.Myprintf("%s%v", prefix, n)
+		prefix = ", "
+	}
+}
+
+// WalkSubtree walks the nodes of the subtree
+func (node LockTableList) WalkSubtree(visit Visit) error {
+	for _, n := range node {
+		if err := Walk(visit, n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LockTable represents a table of a LOCK TABLES statement,
+// with its optional alias and its lock type.
+type LockTable struct {
+	Table *TableName
+	As    SQLName
+	Lock  string
+}
+
+// LockTable.Lock
+const (
+	ReadStr             = "read"
+	ReadLocalStr        = "read local"
+	WriteStr            = "write"
+	LowPriorityWriteStr = "low_priority write"
+)
+
+// Format formats the node.
+func (node *LockTable) Format(buf *TrackedBuffer) {
+	buf.Myprintf("%v", node.Table)
+	if node.As != "" {
+		buf.Myprintf(" as %v", node.As)
+	}
+	buf.Myprintf(" %s", node.Lock)
+}
+
+// WalkSubtree walks the nodes of the subtree
+func (node *LockTable) WalkSubtree(visit Visit) error {
+	if node == nil {
+		return nil
+	}
+	return Walk(
+		visit,
+		node.Table,
+		node.As,
+	)
+}
+
+// UnlockTables represents an UNLOCK TABLES statement.
+type UnlockTables struct{}
+
+// Format formats the node.
+func (node *UnlockTables) Format(buf *TrackedBuffer) {
+	buf.WriteString("unlock tables")
+}
+
+// WalkSubtree walks the nodes of the subtree
+func (node *UnlockTables) WalkSubtree(visit Visit) error {
+	return nil
+}
+
 // Comments represents a list of comments.
 type Comments [][]byte
 
@@ -71,6 +71,8 @@ func TestValid(t *testing.T) {
 		input: "select /* for update */ 1 from t for update",
 	}, {
 		input: "select /* lock in share mode */ 1 from t lock in share mode",
+	}, {
+		input: "select /* for share */ 1 from t for share",
 	}, {
 		input: "select /* select list */ 1, 2 from t",
 	}, {
@@ -538,6 +540,16 @@ func TestValid(t *testing.T) {
 	}, {
 		input:  "explain foobar",
 		output: "other",
+	}, {
+		input: "lock tables a read",
+	}, {
+		input:  "LOCK TABLE a AS b READ LOCAL, c.d WRITE, e f LOW_PRIORITY WRITE",
+		output: "lock tables a as b read local, c.d write, e as f low_priority write",
+	}, {
+		input: "unlock tables",
+	}, {
+		input:  "UNLOCK TABLE",
+		output: "unlock tables",
 	}}
 	for _, tcase := range validSQL {
 		if tcase.output == "" {
@@ -735,6 +747,21 @@ func TestErrors(t *testing.T) {
 	}, {
 		input:  "select next id from a",
 		output: "expecting value after next at position 23",
+	}, {
+		input:  "select * from t for sharing",
+		output: "expecting share at position 28 near 'sharing'",
+	}, {
+		input:  "lock tablez a read",
+		output: "expecting tables at position 20",
+	}, {
+		input:  "lock tables a",
+		output: "syntax error at position 15",
+	}, {
+		input:  "lock tables a read remote",
+		output: "expecting local at position 26 near 'remote'",
+	}, {
+		input:  "unlock a",
+		output: "expecting tables at position 9 near 'a'",
 	}}
 	for _, tcase := range invalidSQL {
 		if tcase.output == "" {
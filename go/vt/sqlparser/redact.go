@@ -0,0 +1,375 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+import (
+	"strconv"
+
+	"github.com/youtube/vitess/go/sqltypes"
+)
+
+// RedactSQLQuery returns the sql with all its string and numeric
+// literals replaced by "?". It returns an error if sql can't be parsed.
+func RedactSQLQuery(sql string) (string, error) {
+	stmt, err := Parse(sql)
+	if err != nil {
+		return "", err
+	}
+	buf := NewTrackedBuffer(formatRedacted)
+	buf.Myprintf("%v", stmt)
+	return buf.String(), nil
+}
+
+// formatRedacted is a node formatter that prints literals as "?".
+func formatRedacted(buf *TrackedBuffer, node SQLNode) {
+	switch node.(type) {
+	case StrVal, NumVal:
+		buf.WriteString("?")
+	default:
+		node.Format(buf)
+	}
+}
+
+// QueryFingerprint returns a normalized form of sql that identifies
+// its shape: literals are replaced by "?", comments are removed,
+// keywords are lowercased and whitespace is canonicalized. Queries
+// that differ only by their literals or formatting have the same
+// fingerprint. It follows the rules of the Tokenizer, without
+// parsing, so it also works for queries that can't be parsed. It is
+// called on every query, so it scans sql itself and writes the
+// fingerprint in a single buffer, instead of allocating each token.
+func QueryFingerprint(sql string) string {
+	f := &fingerprinter{
+		sql:     sql,
+		idx:     -1,
+		out:     make([]byte, 0, len(sql)+len(sql)/2),
+		noSpace: true,
+	}
+	for f.scan() {
+	}
+	return string(f.out)
+}
+
+// fingerprinter scans a query like the Tokenizer, and writes the text
+// of its tokens to out. cur is the character at idx, like lastChar.
+type fingerprinter struct {
+	sql         string
+	idx         int
+	cur         uint16
+	posVarIndex int
+	out         []byte
+	// noSpace is true if the next token is not preceded by a space.
+	noSpace bool
+	// tokenStart is where the current token was started in out,
+	// and textStart where its text starts, after its space.
+	tokenStart, textStart int
+}
+
+func (f *fingerprinter) next() {
+	f.idx++
+	if f.idx < len(f.sql) {
+		f.cur = uint16(f.sql[f.idx])
+	} else {
+		f.idx = len(f.sql)
+		f.cur = eofChar
+	}
+}
+
+// scan writes the next token, and returns false at the end of sql.
+func (f *fingerprinter) scan() bool {
+	if f.cur == 0 {
+		f.next()
+	}
+	for f.cur == ' ' || f.cur == '\n' || f.cur == '\r' || f.cur == '\t' {
+		f.next()
+	}
+	start := f.idx
+	f.tokenStart = len(f.out)
+	if !f.noSpace {
+		f.out = append(f.out, ' ')
+	}
+	f.textStart = len(f.out)
+	switch ch := f.cur; {
+	case isLetter(ch):
+		f.scanIdentifier(start)
+	case isDigit(ch):
+		f.scanNumber(start, false)
+	case ch == ':':
+		f.scanBindVar(start)
+	default:
+		f.next()
+		switch ch {
+		case eofChar:
+			f.out = f.out[:f.tokenStart]
+			return false
+		case '=', ',', ';', '(', ')', '+', '*', '%', '&', '|', '^', '~':
+			f.out = append(f.out, byte(ch))
+		case '?':
+			f.posVarIndex++
+			f.out = strconv.AppendInt(append(f.out, ":v"...), int64(f.posVarIndex), 10)
+		case '.':
+			if isDigit(f.cur) {
+				f.scanNumber(start, true)
+			} else {
+				f.out = append(f.out, '.')
+			}
+		case '/':
+			switch f.cur {
+			case '/':
+				f.next()
+				f.skipCommentType1()
+				f.out = f.out[:f.tokenStart]
+				return true
+			case '*':
+				f.next()
+				if f.skipCommentType2() {
+					f.out = f.out[:f.tokenStart]
+					return true
+				}
+				f.out = append(f.out, f.sql[start:f.idx]...)
+			default:
+				f.out = append(f.out, '/')
+			}
+		case '-':
+			if f.cur == '-' {
+				f.next()
+				f.skipCommentType1()
+				f.out = f.out[:f.tokenStart]
+				return true
+			}
+			f.out = append(f.out, '-')
+		case '<':
+			switch f.cur {
+			case '>':
+				f.next()
+				f.out = append(f.out, "!="...)
+			case '<':
+				f.next()
+				f.out = append(f.out, "<<"...)
+			case '=':
+				f.next()
+				if f.cur == '>' {
+					f.next()
+					f.out = append(f.out, "<=>"...)
+				} else {
+					f.out = append(f.out, "<="...)
+				}
+			default:
+				f.out = append(f.out, '<')
+			}
+		case '>':
+			switch f.cur {
+			case '=':
+				f.next()
+				f.out = append(f.out, ">="...)
+			case '>':
+				f.next()
+				f.out = append(f.out, ">>"...)
+			default:
+				f.out = append(f.out, '>')
+			}
+		case '!':
+			if f.cur == '=' {
+				f.next()
+			}
+			f.out = append(f.out, f.sql[start:f.idx]...)
+		case '\'', '"':
+			f.scanString(ch)
+		case '`':
+			f.scanLiteralIdentifier()
+		default:
+			f.out = append(f.out, byte(ch))
+		}
+	}
+	f.endToken()
+	return true
+}
+
+// endToken removes the space before the tokens that are not preceded
+// by one, and sets noSpace for the tokens that are not followed by one.
+func (f *fingerprinter) endToken() {
+	text := f.out[f.textStart:]
+	if len(text) != 1 {
+		f.noSpace = false
+		return
+	}
+	switch c := text[0]; c {
+	case ',', ')', '.':
+		if f.textStart > f.tokenStart {
+			f.out[f.tokenStart] = c
+			f.out = f.out[:f.tokenStart+1]
+		}
+	}
+	f.noSpace = text[0] == '(' || text[0] == '.'
+}
+
+// scanIdentifier writes the keywords and dual lowercased, and the other
+// identifiers as they are.
+func (f *fingerprinter) scanIdentifier(start int) {
+	for f.next(); isLetter(f.cur) || isDigit(f.cur); f.next() {
+	}
+	raw := f.sql[start:f.idx]
+	for i := 0; i < len(raw); i++ {
+		c := raw[i]
+		if 'A' <= c && c <= 'Z' {
+			c += 'a' - 'A'
+		}
+		f.out = append(f.out, c)
+	}
+	lowered := f.out[f.textStart:]
+	if _, ok := keywords[string(lowered)]; ok || string(lowered) == "dual" {
+		return
+	}
+	copy(lowered, raw)
+}
+
+func (f *fingerprinter) scanLiteralIdentifier() {
+	if !isLetter(f.cur) {
+		f.out = append(f.out, byte(f.cur))
+		return
+	}
+	start := f.idx
+	for f.next(); isLetter(f.cur) || isDigit(f.cur); f.next() {
+	}
+	f.out = append(f.out, f.sql[start:f.idx]...)
+	if f.cur == '`' {
+		f.next()
+	}
+}
+
+func (f *fingerprinter) scanBindVar(start int) {
+	f.next()
+	if f.cur == ':' {
+		f.next()
+	}
+	if isLetter(f.cur) {
+		for isLetter(f.cur) || isDigit(f.cur) || f.cur == '.' {
+			f.next()
+		}
+	}
+	f.out = append(f.out, f.sql[start:f.idx]...)
+}
+
+func (f *fingerprinter) scanMantissa(base int) {
+	for digitVal(f.cur) < base {
+		f.next()
+	}
+}
+
+// scanNumber writes "?" for a number, or its text if it's invalid.
+func (f *fingerprinter) scanNumber(start int, seenDecimalPoint bool) {
+	if seenDecimalPoint {
+		f.scanMantissa(10)
+		goto exponent
+	}
+
+	if f.cur == '0' {
+		// int or float
+		f.next()
+		if f.cur == 'x' || f.cur == 'X' {
+			// hexadecimal int
+			f.next()
+			f.scanMantissa(16)
+		} else {
+			// octal int or float
+			seenDecimalDigit := false
+			f.scanMantissa(8)
+			if f.cur == '8' || f.cur == '9' {
+				// illegal octal int or float
+				seenDecimalDigit = true
+				f.scanMantissa(10)
+			}
+			if f.cur == '.' || f.cur == 'e' || f.cur == 'E' {
+				goto fraction
+			}
+			// octal int
+			if seenDecimalDigit {
+				f.out = append(f.out, f.sql[start:f.idx]...)
+				return
+			}
+		}
+		goto exit
+	}
+
+	// decimal int or float
+	f.scanMantissa(10)
+
+fraction:
+	if f.cur == '.' {
+		f.next()
+		f.scanMantissa(10)
+	}
+
+exponent:
+	if f.cur == 'e' || f.cur == 'E' {
+		f.next()
+		if f.cur == '+' || f.cur == '-' {
+			f.next()
+		}
+		f.scanMantissa(10)
+	}
+
+exit:
+	f.out = append(f.out, '?')
+}
+
+// scanString writes "?" for a string. Like the Tokenizer, it writes the
+// decoded text if the string is not terminated.
+func (f *fingerprinter) scanString(delim uint16) {
+	for {
+		ch := f.cur
+		f.next()
+		if ch == delim {
+			if f.cur == delim {
+				f.next()
+			} else {
+				break
+			}
+		} else if ch == '\\' {
+			if f.cur == eofChar {
+				return
+			}
+			if decodedChar := sqltypes.SQLDecodeMap[byte(f.cur)]; decodedChar == sqltypes.DontEscape {
+				ch = f.cur
+			} else {
+				ch = uint16(decodedChar)
+			}
+			f.next()
+		}
+		if ch == eofChar {
+			return
+		}
+		f.out = append(f.out, byte(ch))
+	}
+	f.out = append(f.out[:f.textStart], '?')
+}
+
+func (f *fingerprinter) skipCommentType1() {
+	for f.cur != eofChar {
+		if f.cur == '\n' {
+			f.next()
+			break
+		}
+		f.next()
+	}
+}
+
+// skipCommentType2 returns false if the comment is not terminated.
+func (f *fingerprinter) skipCommentType2() bool {
+	for {
+		if f.cur == '*' {
+			f.next()
+			if f.cur == '/' {
+				f.next()
+				return true
+			}
+			continue
+		}
+		if f.cur == eofChar {
+			return false
+		}
+		f.next()
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package sqlparser
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+)
+
+func TestRedactSQLQuery(t *testing.T) {
+	testcases := []struct {
+		in, out string
+	}{{
+		in:  "select a, b from t where c = 'secret' and d = 123 and e = :e",
+		out: "select a, b from t where c = ? and d = ? and e = :e",
+	}, {
+		in:  "insert into t(a, b) values ('x', 1), ('y', 2.5)",
+		out: "insert into t(a, b) values (?, ?), (?, ?)",
+	}, {
+		in:  "update t set a = 'x' where id in (1, 2) limit 10",
+		out: "update t set a = ? where id in (?, ?) limit ?",
+	}, {
+		in:  "select /* comment */ * from t where a = null",
+		out: "select /* comment */ * from t where a = null",
+	}}
+	for _, tc := range testcases {
+		got, err := RedactSQLQuery(tc.in)
+		if err != nil {
+			t.Errorf("RedactSQLQuery(%q): %v", tc.in, err)
+			continue
+		}
+		if got != tc.out {
+			t.Errorf("RedactSQLQuery(%q): %q, want %q", tc.in, got, tc.out)
+		}
+	}
+
+	if _, err := RedactSQLQuery("not a query"); err == nil {
+		t.Errorf("RedactSQLQuery(invalid): nil error, want non-nil")
+	}
+}
+
+func TestQueryFingerprint(t *testing.T) {
+	testcases := []struct {
+		in, out string
+	}{{
+		in:  "select a,b from t where c = 'secret'",
+		out: "select a, b from t where c = ?",
+	}, {
+		in:  "SELECT  a, b\n\tFROM t WHERE c = \"other\"",
+		out: "select a, b from t where c = ?",
+	}, {
+		in:  "select /* comment */ a, b from t where c = 1",
+		out: "select a, b from t where c = ?",
+	}, {
+		in:  "select count( * ), t.a from `t` where b>=1.5 and c!=:c and d in ::d",
+		out: "select count (*), t.a from t where b >= ? and c != :c and d in ::d",
+	}, {
+		in:  "update t set a = 'x' where id = :id -- trailing comment",
+		out: "update t set a = ? where id = :id",
+	}, {
+		in:  "not a  'query'",
+		out: "not a ?",
+	}}
+	for _, tc := range testcases {
+		if got := QueryFingerprint(tc.in); got != tc.out {
+			t.Errorf("QueryFingerprint(%q): %q, want %q", tc.in, got, tc.out)
+		}
+	}
+}
+
+// tokenizerFingerprint is QueryFingerprint written with the Tokenizer.
+// QueryFingerprint must return the same fingerprints.
+func tokenizerFingerprint(sql string) string {
+	operators := map[int]string{
+		NE:              "!=",
+		LE:              "<=",
+		GE:              ">=",
+		SHIFT_LEFT:      "<<",
+		SHIFT_RIGHT:     ">>",
+		NULL_SAFE_EQUAL: "<=>",
+	}
+	tkn := NewStringTokenizer(sql)
+	buf := &bytes.Buffer{}
+	noSpace := true
+	for {
+		typ, val := tkn.Scan()
+		var text string
+		switch typ {
+		case 0:
+			return buf.String()
+		case COMMENT:
+			continue
+		case STRING, NUMBER:
+			text = "?"
+		case LEX_ERROR:
+			// The errors at the end of sql have no value.
+			text = string(val)
+		default:
+			if val != nil {
+				text = string(val)
+			} else if op, ok := operators[typ]; ok {
+				text = op
+			} else {
+				text = string(rune(typ))
+			}
+		}
+		switch text {
+		case ",", ")", ".":
+		default:
+			if !noSpace {
+				buf.WriteByte(' ')
+			}
+		}
+		buf.WriteString(text)
+		noSpace = text == "(" || text == "."
+	}
+}
+
+func TestQueryFingerprintMatchesTokenizer(t *testing.T) {
+	queries := []string{
+		"",
+		"   ",
+		"SELECT DuAl.a FROM DUAL",
+		"select a.b, `Select`, `1a`, `a from t",
+		"select 0x1F, 012, 09, 09.5, 1e10, 1.5E-3, .5, t.5 from t",
+		"select 'it''s', \"a\\\"b\", 'unterminated\\",
+		"select 'x",
+		"select ( a ) , ( ( b ) ) from t",
+		"a<>b a<=b a<=>b a>=b a<<b a>>b a!=b a!b a<b a>b",
+		"select ?, ?, :a, ::b, :c.d, :1, :",
+		"/* x */ select -- y\n a // z\n from t /* unterminated",
+		"select a-b, a/b, a*b, a%b, a&b, a|b, a^b, ~a; #$",
+		"select a\x00b",
+		"insert into t(a, b) values (1, 'x') on duplicate key update a = values(a)",
+	}
+	r := rand.New(rand.NewSource(1))
+	const alphabet = "aZ_@09.x eE+-*/<>=!?:,;()'\"\\`\n#"
+	for i := 0; i < 5000; i++ {
+		b := make([]byte, r.Intn(20))
+		for j := range b {
+			b[j] = alphabet[r.Intn(len(alphabet))]
+		}
+		queries = append(queries, string(b))
+	}
+	for _, sql := range queries {
+		if got, want := QueryFingerprint(sql), tokenizerFingerprint(sql); got != want {
+			t.Errorf("QueryFingerprint(%q): %q, want %q", sql, got, want)
+		}
+	}
+}
+
+func TestQueryFingerprintAllocs(t *testing.T) {
+	sql := "select /* user.lookup */ id, name, email from users where account_id = 12345 and status = 'active'"
+	// The buffer, and the string returned.
+	if got := testing.AllocsPerRun(100, func() { QueryFingerprint(sql) }); got > 2 {
+		t.Errorf("QueryFingerprint: %v allocations, want at most 2", got)
+	}
+}
+
+func BenchmarkQueryFingerprint(b *testing.B) {
+	// A typical query of about 200 bytes.
+	sql := "select /* user.lookup */ id, name, email, created_at, updated_at from users where account_id = 12345 and status = 'active' and email like '%@example.com' order by created_at desc limit 100"
+	for i := 0; i < b.N; i++ {
+		QueryFingerprint(sql)
+	}
+}
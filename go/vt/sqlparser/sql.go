@@ -67,6 +67,8 @@ type yySymType struct {
 	updateExpr  *UpdateExpr
 	sqlID       SQLName
 	sqlIDs      []SQLName
+	lockTables  LockTableList
+	lockTable   *LockTable
 }
 
 const LEX_ERROR = 57346
@@ -156,7 +158,11 @@ const USING = 57429
 const SHOW = 57430
 const DESCRIBE = 57431
 const EXPLAIN = 57432
-const UNUSED = 57433
+const UNLOCK = 57433
+const READ = 57434
+const WRITE = 57435
+const LOW_PRIORITY = 57436
+const UNUSED = 57437
 
 var yyToknames = [...]string{
 	"$end",
@@ -265,6 +271,10 @@ var yyToknames = [...]string{
 	"SHOW",
 	"DESCRIBE",
 	"EXPLAIN",
+	"UNLOCK",
+	"READ",
+	"WRITE",
+	"LOW_PRIORITY",
 	"UNUSED",
 }
 var yyStatenames = [...]string{}
@@ -278,298 +288,313 @@ var yyExca = [...]int{
 	-1, 1,
 	1, -1,
 	-2, 0,
-	-1, 67,
-	89, 221,
-	-2, 220,
+	-1, 76,
+	89, 235,
+	-2, 234,
 }
 
-const yyNprod = 225
+const yyNprod = 239
 const yyPrivate = 57344
 
 var yyTokenNames []string
 var yyStates []string
 
-const yyLast = 778
+const yyLast = 850
 
 var yyAct = [...]int{
 
-	120, 285, 195, 401, 265, 198, 215, 131, 294, 336,
-	114, 227, 62, 226, 326, 115, 276, 243, 139, 237,
-	197, 3, 225, 229, 35, 103, 37, 149, 77, 104,
-	38, 354, 356, 69, 40, 65, 41, 41, 71, 47,
-	224, 74, 98, 125, 384, 64, 383, 382, 50, 70,
-	108, 43, 44, 45, 73, 83, 58, 46, 42, 364,
-	219, 155, 63, 166, 91, 48, 49, 126, 87, 179,
-	67, 127, 128, 129, 153, 414, 130, 123, 124, 67,
-	102, 111, 167, 132, 109, 88, 169, 65, 90, 355,
-	65, 277, 144, 142, 93, 157, 169, 64, 126, 137,
-	64, 84, 116, 117, 105, 246, 305, 164, 118, 136,
-	119, 95, 72, 234, 97, 199, 277, 67, 324, 200,
-	201, 202, 203, 121, 185, 186, 180, 181, 182, 183,
-	184, 179, 146, 206, 60, 209, 168, 167, 60, 152,
-	154, 151, 160, 238, 240, 241, 218, 143, 239, 94,
-	221, 169, 141, 78, 156, 89, 214, 210, 182, 183,
-	184, 179, 194, 196, 268, 109, 233, 144, 371, 230,
-	309, 310, 311, 242, 410, 266, 251, 252, 253, 245,
-	255, 256, 257, 258, 259, 260, 261, 262, 263, 264,
-	222, 217, 232, 368, 165, 126, 254, 250, 60, 126,
-	168, 167, 267, 269, 270, 378, 366, 109, 109, 271,
-	248, 249, 247, 65, 65, 169, 266, 327, 235, 236,
-	288, 284, 72, 64, 283, 281, 168, 167, 14, 112,
-	272, 274, 280, 180, 181, 182, 183, 184, 179, 230,
-	28, 169, 162, 266, 291, 268, 266, 218, 292, 266,
-	312, 314, 315, 316, 308, 245, 332, 266, 140, 112,
-	112, 14, 15, 16, 17, 313, 76, 126, 204, 205,
-	60, 318, 327, 207, 89, 220, 109, 319, 161, 321,
-	212, 101, 266, 18, 213, 82, 333, 331, 112, 334,
-	337, 140, 323, 292, 381, 329, 330, 126, 380, 320,
-	230, 230, 230, 230, 346, 342, 341, 344, 343, 231,
-	112, 352, 79, 359, 162, 112, 112, 360, 349, 244,
-	362, 345, 86, 350, 55, 363, 89, 365, 325, 338,
-	39, 347, 387, 65, 14, 126, 348, 54, 67, 127,
-	128, 129, 367, 369, 130, 85, 19, 20, 22, 21,
-	23, 132, 112, 112, 351, 407, 300, 301, 279, 24,
-	25, 26, 57, 147, 385, 100, 307, 408, 135, 386,
-	116, 117, 286, 389, 337, 134, 118, 377, 119, 231,
-	218, 390, 391, 393, 388, 66, 51, 52, 287, 216,
-	376, 121, 340, 400, 140, 244, 61, 402, 402, 402,
-	65, 403, 404, 413, 399, 14, 409, 28, 411, 412,
-	64, 415, 405, 30, 1, 416, 306, 417, 59, 303,
-	29, 112, 163, 148, 36, 112, 223, 392, 75, 394,
-	395, 150, 80, 68, 373, 374, 31, 32, 33, 34,
-	231, 231, 231, 231, 133, 282, 211, 59, 406, 296,
-	299, 300, 301, 297, 92, 298, 302, 372, 335, 96,
-	375, 339, 99, 322, 208, 275, 122, 107, 328, 113,
-	278, 59, 273, 138, 125, 170, 110, 145, 353, 295,
-	293, 228, 106, 81, 158, 53, 27, 159, 178, 177,
-	185, 186, 180, 181, 182, 183, 184, 179, 126, 56,
-	266, 67, 127, 128, 129, 13, 12, 130, 123, 124,
-	11, 10, 111, 9, 132, 8, 7, 6, 5, 59,
-	4, 14, 2, 0, 112, 0, 112, 112, 0, 0,
-	396, 397, 398, 116, 117, 105, 125, 0, 0, 118,
-	0, 119, 0, 0, 0, 0, 0, 59, 107, 0,
-	0, 0, 145, 0, 121, 0, 0, 0, 0, 125,
-	126, 0, 0, 67, 127, 128, 129, 0, 0, 130,
-	123, 124, 0, 0, 111, 0, 132, 0, 0, 0,
-	0, 0, 0, 126, 0, 0, 67, 127, 128, 129,
-	107, 107, 130, 123, 124, 116, 117, 111, 370, 132,
-	0, 118, 14, 119, 0, 0, 289, 0, 0, 290,
-	0, 0, 0, 0, 0, 304, 121, 59, 116, 117,
-	0, 0, 0, 0, 118, 0, 119, 0, 178, 177,
-	185, 186, 180, 181, 182, 183, 184, 179, 0, 121,
-	0, 126, 361, 0, 67, 127, 128, 129, 0, 0,
-	130, 0, 0, 0, 0, 0, 0, 132, 0, 107,
-	178, 177, 185, 186, 180, 181, 182, 183, 184, 179,
-	0, 0, 0, 0, 0, 0, 116, 117, 59, 59,
-	59, 59, 118, 0, 119, 0, 0, 0, 0, 0,
-	0, 357, 358, 172, 175, 72, 0, 121, 0, 187,
-	188, 189, 190, 191, 192, 193, 176, 173, 174, 171,
-	178, 177, 185, 186, 180, 181, 182, 183, 184, 179,
-	317, 0, 0, 178, 177, 185, 186, 180, 181, 182,
-	183, 184, 179, 0, 0, 0, 0, 0, 178, 177,
-	185, 186, 180, 181, 182, 183, 184, 179, 178, 177,
-	185, 186, 180, 181, 182, 183, 184, 179, 177, 185,
-	186, 180, 181, 182, 183, 184, 179, 296, 299, 300,
-	301, 297, 0, 298, 302, 0, 0, 379,
+	136, 309, 217, 220, 422, 358, 237, 131, 250, 318,
+	71, 348, 130, 251, 267, 300, 116, 249, 155, 289,
+	219, 3, 119, 261, 248, 51, 253, 124, 120, 90,
+	54, 57, 179, 180, 181, 165, 85, 171, 78, 74,
+	147, 39, 80, 41, 72, 82, 44, 42, 45, 45,
+	169, 73, 59, 52, 53, 376, 378, 47, 48, 49,
+	81, 246, 110, 67, 95, 405, 404, 403, 79, 50,
+	56, 173, 56, 46, 385, 202, 203, 204, 205, 206,
+	201, 91, 91, 241, 201, 188, 103, 99, 114, 204,
+	205, 206, 201, 76, 436, 102, 125, 100, 189, 74,
+	191, 301, 74, 346, 160, 158, 107, 96, 109, 153,
+	301, 73, 191, 377, 73, 168, 170, 167, 58, 105,
+	55, 89, 290, 186, 152, 84, 142, 258, 56, 69,
+	162, 221, 190, 189, 172, 222, 223, 224, 225, 274,
+	176, 142, 91, 190, 189, 177, 157, 191, 270, 387,
+	159, 231, 272, 273, 271, 216, 218, 434, 191, 190,
+	189, 76, 240, 262, 264, 265, 243, 69, 263, 331,
+	332, 333, 236, 16, 191, 87, 232, 92, 431, 290,
+	247, 32, 228, 106, 239, 86, 118, 125, 257, 160,
+	101, 187, 244, 184, 290, 266, 292, 56, 275, 276,
+	277, 183, 279, 280, 281, 282, 283, 284, 285, 286,
+	287, 288, 142, 256, 69, 69, 392, 259, 260, 56,
+	292, 290, 278, 290, 254, 391, 294, 316, 290, 125,
+	125, 354, 290, 389, 269, 74, 74, 184, 115, 291,
+	293, 142, 312, 308, 128, 305, 295, 73, 307, 296,
+	298, 349, 234, 101, 304, 200, 199, 207, 208, 202,
+	203, 204, 205, 206, 201, 399, 156, 156, 315, 142,
+	327, 240, 334, 330, 349, 336, 337, 338, 128, 128,
+	242, 113, 94, 43, 371, 369, 335, 226, 227, 372,
+	370, 373, 229, 324, 325, 340, 254, 402, 401, 368,
+	125, 316, 101, 235, 367, 98, 16, 128, 64, 408,
+	355, 351, 269, 356, 359, 345, 341, 352, 343, 66,
+	342, 63, 388, 97, 163, 363, 353, 365, 112, 347,
+	303, 364, 329, 366, 255, 128, 374, 60, 61, 360,
+	128, 128, 383, 310, 268, 151, 398, 311, 362, 386,
+	380, 428, 150, 238, 381, 74, 397, 254, 254, 254,
+	254, 156, 384, 429, 70, 420, 16, 390, 199, 207,
+	208, 202, 203, 204, 205, 206, 201, 128, 128, 75,
+	320, 323, 324, 325, 321, 406, 322, 326, 32, 33,
+	407, 34, 1, 328, 410, 359, 185, 409, 411, 164,
+	40, 240, 414, 412, 245, 166, 255, 35, 36, 37,
+	38, 77, 149, 306, 421, 233, 68, 427, 423, 423,
+	423, 74, 268, 424, 425, 413, 83, 415, 416, 435,
+	88, 426, 437, 73, 68, 68, 393, 438, 357, 439,
+	396, 361, 430, 344, 432, 433, 230, 299, 128, 138,
+	68, 350, 128, 129, 302, 192, 126, 104, 375, 394,
+	395, 108, 319, 317, 111, 252, 122, 255, 255, 255,
+	255, 117, 93, 123, 62, 31, 65, 68, 178, 154,
+	15, 14, 13, 161, 16, 17, 18, 19, 12, 11,
+	174, 10, 9, 175, 8, 68, 142, 7, 182, 76,
+	143, 144, 145, 6, 5, 146, 20, 29, 4, 2,
+	0, 0, 148, 200, 199, 207, 208, 202, 203, 204,
+	205, 206, 201, 0, 0, 0, 0, 0, 0, 68,
+	0, 132, 133, 0, 0, 0, 0, 134, 0, 135,
+	207, 208, 202, 203, 204, 205, 206, 201, 128, 16,
+	128, 128, 137, 0, 417, 418, 419, 0, 0, 0,
+	0, 0, 0, 68, 123, 0, 0, 0, 161, 21,
+	22, 24, 23, 25, 0, 0, 0, 0, 0, 0,
+	0, 0, 26, 27, 28, 30, 0, 0, 142, 0,
+	0, 76, 143, 144, 145, 0, 0, 146, 0, 0,
+	0, 0, 0, 0, 148, 0, 123, 123, 0, 0,
+	0, 297, 0, 141, 0, 0, 0, 0, 0, 0,
+	0, 0, 313, 132, 133, 314, 0, 0, 0, 134,
+	0, 135, 0, 117, 0, 68, 382, 142, 0, 290,
+	76, 143, 144, 145, 137, 0, 146, 139, 140, 0,
+	0, 127, 0, 148, 200, 199, 207, 208, 202, 203,
+	204, 205, 206, 201, 339, 0, 0, 0, 0, 0,
+	0, 0, 132, 133, 121, 0, 141, 123, 134, 16,
+	135, 0, 200, 199, 207, 208, 202, 203, 204, 205,
+	206, 201, 0, 137, 141, 0, 68, 68, 68, 68,
+	142, 0, 0, 76, 143, 144, 145, 0, 379, 146,
+	139, 140, 0, 0, 127, 0, 148, 0, 142, 0,
+	0, 76, 143, 144, 145, 0, 0, 146, 139, 140,
+	0, 0, 127, 0, 148, 132, 133, 121, 141, 0,
+	0, 134, 0, 135, 0, 0, 0, 0, 0, 0,
+	0, 56, 0, 132, 133, 0, 137, 0, 0, 134,
+	0, 135, 142, 0, 0, 76, 143, 144, 145, 0,
+	0, 146, 139, 140, 137, 0, 127, 0, 148, 200,
+	199, 207, 208, 202, 203, 204, 205, 206, 201, 320,
+	323, 324, 325, 321, 0, 322, 326, 132, 133, 400,
+	0, 0, 0, 134, 0, 135, 0, 0, 0, 0,
+	0, 0, 0, 194, 197, 0, 0, 0, 137, 209,
+	210, 211, 212, 213, 214, 215, 198, 195, 196, 193,
+	200, 199, 207, 208, 202, 203, 204, 205, 206, 201,
+	200, 199, 207, 208, 202, 203, 204, 205, 206, 201,
 }
 var yyPact = [...]int{
 
-	255, -1000, -1000, 402, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -72,
-	-64, -38, -45, -39, -1000, -1000, -1000, 399, 368, 305,
-	-1000, -63, 90, 386, 69, -68, -48, 64, -1000, -42,
-	64, -1000, 90, -73, 105, -73, 90, -1000, -1000, -1000,
-	-1000, -1000, -1000, 250, 64, -1000, 48, 321, 294, -21,
-	-1000, 90, 109, -1000, 23, -1000, -25, -1000, 90, 35,
-	101, -1000, -1000, 90, -1000, -57, 90, 344, 237, 64,
-	-1000, 22, -1000, 358, -1000, 90, 69, 90, 383, 69,
-	290, 69, -1000, 342, -76, -1000, 47, -1000, 90, -1000,
-	-1000, 90, -1000, 268, -1000, -1000, 174, -26, 79, 634,
-	-1000, 538, 515, -1000, -1000, -1000, 290, 290, 290, 290,
-	154, 154, -1000, -1000, -1000, 154, -1000, -1000, -1000, -1000,
-	-1000, -1000, 290, 90, -1000, -1000, 252, 280, -1000, 375,
-	538, -1000, 672, 596, -1000, -29, -1000, -1000, 231, 64,
-	-1000, -59, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
-	-1000, 150, 22, -1000, -1000, 64, 31, 538, 538, 89,
-	290, 53, 137, 290, 290, 290, 89, 290, 290, 290,
-	290, 290, 290, 290, 290, 290, 290, -1000, -1000, -1000,
-	-1000, -1000, -1000, -1000, 14, 634, 169, 235, 199, 634,
-	-1000, -1000, -1000, 647, 453, 22, -1000, 399, 29, 672,
-	-1000, 328, 69, 69, 375, 356, 373, 79, 672, 64,
-	90, -1000, -1000, 90, -1000, 247, 415, -1000, -1000, 86,
-	346, 222, -1000, -1000, -1000, 14, 24, -1000, -1000, 116,
-	-1000, -1000, 672, -1000, 596, -1000, -1000, 53, 290, 290,
-	290, 672, 672, 662, -1000, 46, 681, -1000, 76, 76,
-	-16, -16, -16, 153, 153, -1000, -1000, -1000, 290, -1000,
-	-1000, -1000, 196, 22, 196, 54, -1000, 538, 173, 154,
-	402, 228, 210, -1000, 356, -1000, 290, 290, -1000, -1000,
-	-1000, 380, 150, 150, 150, 150, -1000, 287, 270, -1000,
-	297, 284, 320, -11, -1000, 90, 90, -1000, 202, -1000,
-	-1000, -1000, 199, -1000, 672, 672, 584, 290, 672, -1000,
-	196, -1000, -31, -1000, 290, 143, -1000, 317, 147, -1000,
-	-1000, -1000, 69, -1000, 552, 122, -1000, 412, -1000, 377,
-	362, 415, 161, 733, -1000, -1000, -1000, -1000, 264, -1000,
-	260, -1000, -1000, -1000, -50, -51, -53, -1000, -1000, -1000,
-	-1000, 290, 672, -1000, -1000, 672, 290, 306, 154, -1000,
-	290, 290, -1000, -1000, -1000, 375, 538, 290, 538, 538,
-	-1000, -1000, 154, 154, 154, 672, 672, 396, -1000, 672,
-	-1000, 356, 79, 118, 79, 79, 64, 64, 64, 69,
-	338, 128, -1000, 128, 128, 109, -1000, 395, 0, -1000,
-	64, -1000, -1000, -1000, 64, -1000, 64, -1000,
+	478, -1000, -1000, 383, -1000, -1000, -1000, -1000, -1000, -1000,
+	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000,
+	-1000, -55, -52, -23, -39, -27, -1000, -1000, -1000, 24,
+	22, 360, 319, 289, -1000, -51, 119, 354, 113, -63,
+	-29, 80, -1000, -36, 80, -1000, 119, -65, 137, -65,
+	119, -1000, -1000, -1000, 119, 119, -1000, -1000, -1000, -1000,
+	-1000, -1000, 247, 80, -1000, 54, 299, 277, -2, -1000,
+	119, 144, -1000, 30, -1000, -3, -1000, 119, 60, 135,
+	-1000, 119, -1000, -37, 119, 307, 237, 80, -1000, 192,
+	-1000, 166, 192, 655, -1000, 335, -1000, 119, 113, 119,
+	350, 113, 451, 113, -1000, 303, -68, -1000, 23, -1000,
+	119, -1000, -1000, 119, -1000, 119, -76, -1000, 119, 191,
+	-1000, -1000, 171, -4, 102, 754, -1000, 717, 673, -1000,
+	-1000, -1000, 451, 451, 451, 451, 196, 196, -1000, -1000,
+	-1000, 196, -1000, -1000, -1000, -1000, -1000, -1000, 451, 119,
+	-1000, -1000, 224, 256, -1000, 339, 717, -1000, 764, 543,
+	-1000, -6, -1000, -1000, 236, 80, -1000, -38, -1000, -1000,
+	-1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, -1000, 80,
+	-1000, -85, -1000, 81, 655, -1000, -1000, 80, 45, 717,
+	717, 109, 451, 96, 79, 451, 451, 451, 109, 451,
+	451, 451, 451, 451, 451, 451, 451, 451, 451, -1000,
+	-1000, -1000, -1000, -1000, -1000, -1000, 28, 754, 75, 176,
+	174, 754, -1000, -1000, -1000, 703, 592, 655, -1000, 360,
+	48, 764, -1000, 300, 113, 113, 339, 327, 332, 102,
+	764, 80, 119, -1000, -1000, 119, -1000, -1000, -1000, 255,
+	346, -1000, -1000, 166, 312, 167, -1000, -1000, -1000, 28,
+	40, -1000, -1000, 115, -1000, -1000, 764, -1000, 543, -1000,
+	-1000, 96, 451, 451, 451, 764, 764, 606, -1000, 462,
+	291, -1000, 7, 7, -1, -1, -1, -5, -5, -1000,
+	-1000, -1000, 451, -1000, -1000, -1000, 147, 655, 147, 39,
+	-1000, 717, 230, 196, 383, 207, 185, -1000, 327, -1000,
+	451, 451, -1000, -1000, -1000, 336, 81, 81, 81, 81,
+	-1000, 270, 265, -1000, 251, 250, 257, 13, 119, -1000,
+	181, -1000, -1000, -1000, 174, -1000, 764, 764, 578, 451,
+	764, -1000, 147, -1000, -16, -1000, 451, 86, -1000, 297,
+	187, -1000, -1000, -1000, 113, -1000, 179, 170, -1000, 437,
+	-1000, 343, 331, 346, 221, 755, -1000, -1000, -1000, -1000,
+	264, -1000, 263, -1000, -1000, -1000, -30, -31, -32, -1000,
+	-1000, -1000, 451, 764, -1000, -1000, 764, 451, 283, 196,
+	-1000, 451, 451, -1000, -1000, -1000, 339, 717, 451, 717,
+	717, -1000, -1000, 196, 196, 196, 764, 764, 357, -1000,
+	764, -1000, 327, 102, 150, 102, 102, 80, 80, 80,
+	113, 334, 132, -1000, 132, 132, 144, -1000, 149, 19,
+	-1000, 80, -1000, -1000, -1000, -1000, 80, -1000, 80, -1000,
 }
 var yyPgo = [...]int{
 
-	0, 522, 20, 520, 518, 517, 516, 515, 513, 511,
-	510, 506, 505, 420, 499, 486, 485, 483, 25, 29,
-	482, 22, 13, 11, 481, 480, 8, 479, 23, 478,
-	3, 18, 50, 476, 475, 470, 469, 2, 19, 17,
-	5, 468, 15, 7, 10, 466, 465, 16, 464, 463,
-	461, 460, 6, 458, 9, 457, 1, 448, 446, 445,
-	14, 12, 62, 444, 330, 266, 433, 431, 426, 424,
-	423, 0, 422, 385, 419, 416, 39, 414, 413, 147,
-	4,
+	0, 509, 20, 508, 504, 503, 497, 494, 492, 491,
+	489, 488, 482, 481, 480, 121, 29, 478, 389, 476,
+	475, 474, 472, 22, 28, 466, 17, 8, 13, 465,
+	463, 9, 462, 26, 458, 4, 18, 27, 456, 455,
+	454, 453, 2, 23, 14, 3, 451, 7, 40, 12,
+	449, 447, 15, 446, 443, 441, 440, 6, 438, 5,
+	436, 1, 417, 415, 413, 11, 10, 44, 412, 283,
+	125, 411, 405, 404, 400, 399, 0, 396, 379, 16,
+	393, 25, 392, 391, 150, 19,
 }
 var yyR1 = [...]int{
 
-	0, 77, 1, 1, 1, 1, 1, 1, 1, 1,
-	1, 1, 1, 2, 2, 2, 3, 3, 4, 5,
-	6, 7, 7, 7, 8, 8, 8, 9, 10, 10,
-	10, 11, 12, 12, 12, 78, 13, 14, 14, 15,
-	15, 15, 16, 16, 17, 17, 18, 18, 19, 19,
-	19, 20, 20, 72, 72, 72, 21, 21, 22, 22,
-	23, 23, 23, 24, 24, 24, 24, 75, 75, 74,
-	74, 74, 25, 25, 25, 25, 26, 26, 26, 26,
-	27, 27, 28, 28, 29, 29, 29, 29, 30, 30,
-	31, 31, 32, 32, 32, 32, 32, 32, 33, 33,
-	33, 33, 33, 33, 33, 33, 33, 33, 33, 33,
-	33, 38, 38, 38, 38, 38, 38, 34, 34, 34,
-	34, 34, 34, 34, 39, 39, 39, 43, 40, 40,
-	37, 37, 37, 37, 37, 37, 37, 37, 37, 37,
-	37, 37, 37, 37, 37, 37, 37, 37, 37, 37,
-	37, 37, 45, 48, 48, 46, 46, 47, 49, 49,
-	44, 44, 44, 36, 36, 36, 36, 50, 50, 51,
-	51, 52, 52, 53, 53, 54, 55, 55, 55, 56,
-	56, 56, 57, 57, 57, 58, 58, 59, 59, 60,
-	60, 35, 35, 41, 41, 42, 42, 61, 61, 62,
-	63, 63, 65, 65, 66, 66, 64, 64, 67, 67,
-	67, 67, 67, 67, 68, 68, 69, 69, 70, 70,
-	71, 73, 79, 80, 76,
+	0, 82, 1, 1, 1, 1, 1, 1, 1, 1,
+	1, 1, 1, 1, 1, 2, 2, 2, 3, 3,
+	4, 5, 6, 7, 7, 7, 8, 8, 8, 9,
+	10, 10, 10, 11, 12, 12, 12, 13, 13, 15,
+	15, 16, 17, 17, 17, 17, 14, 14, 83, 18,
+	19, 19, 20, 20, 20, 21, 21, 22, 22, 23,
+	23, 24, 24, 24, 25, 25, 77, 77, 77, 26,
+	26, 27, 27, 28, 28, 28, 29, 29, 29, 29,
+	80, 80, 79, 79, 79, 30, 30, 30, 30, 31,
+	31, 31, 31, 32, 32, 33, 33, 34, 34, 34,
+	34, 35, 35, 36, 36, 37, 37, 37, 37, 37,
+	37, 38, 38, 38, 38, 38, 38, 38, 38, 38,
+	38, 38, 38, 38, 43, 43, 43, 43, 43, 43,
+	39, 39, 39, 39, 39, 39, 39, 44, 44, 44,
+	48, 45, 45, 42, 42, 42, 42, 42, 42, 42,
+	42, 42, 42, 42, 42, 42, 42, 42, 42, 42,
+	42, 42, 42, 42, 42, 50, 53, 53, 51, 51,
+	52, 54, 54, 49, 49, 49, 41, 41, 41, 41,
+	55, 55, 56, 56, 57, 57, 58, 58, 59, 60,
+	60, 60, 61, 61, 61, 62, 62, 62, 62, 63,
+	63, 64, 64, 65, 65, 40, 40, 46, 46, 47,
+	47, 66, 66, 67, 68, 68, 70, 70, 71, 71,
+	69, 69, 72, 72, 72, 72, 72, 72, 73, 73,
+	74, 74, 75, 75, 76, 78, 84, 85, 81,
 }
 var yyR2 = [...]int{
 
 	0, 1, 1, 1, 1, 1, 1, 1, 1, 1,
-	1, 1, 1, 13, 6, 3, 8, 8, 8, 7,
-	3, 5, 8, 4, 6, 7, 4, 5, 4, 5,
-	5, 3, 2, 2, 2, 0, 2, 0, 2, 1,
-	2, 2, 0, 1, 0, 1, 1, 3, 1, 2,
-	3, 1, 1, 0, 1, 2, 1, 3, 1, 1,
-	3, 3, 3, 3, 5, 5, 3, 0, 1, 0,
-	1, 2, 1, 2, 2, 1, 2, 3, 2, 3,
-	2, 2, 1, 3, 0, 5, 5, 5, 1, 3,
-	0, 2, 1, 3, 3, 2, 3, 3, 1, 1,
-	3, 3, 4, 3, 4, 3, 4, 5, 6, 3,
-	2, 1, 2, 1, 2, 1, 2, 1, 1, 1,
-	1, 1, 1, 1, 3, 1, 1, 3, 1, 3,
-	1, 1, 1, 3, 3, 3, 3, 3, 3, 3,
-	3, 3, 3, 2, 2, 2, 3, 3, 4, 5,
-	4, 1, 5, 0, 1, 1, 2, 4, 0, 2,
-	1, 3, 5, 1, 1, 1, 1, 0, 3, 0,
-	2, 0, 3, 1, 3, 2, 0, 1, 1, 0,
-	2, 4, 0, 2, 4, 0, 3, 1, 3, 0,
-	5, 2, 1, 1, 3, 3, 1, 1, 3, 3,
-	1, 1, 0, 2, 0, 3, 0, 1, 1, 1,
-	1, 1, 1, 1, 0, 1, 0, 1, 0, 2,
-	1, 1, 1, 1, 0,
+	1, 1, 1, 1, 1, 13, 6, 3, 8, 8,
+	8, 7, 3, 5, 8, 4, 6, 7, 4, 5,
+	4, 5, 5, 3, 2, 2, 2, 3, 3, 1,
+	3, 3, 1, 2, 1, 2, 2, 2, 0, 2,
+	0, 2, 1, 2, 2, 0, 1, 0, 1, 1,
+	3, 1, 2, 3, 1, 1, 0, 1, 2, 1,
+	3, 1, 1, 3, 3, 3, 3, 5, 5, 3,
+	0, 1, 0, 1, 2, 1, 2, 2, 1, 2,
+	3, 2, 3, 2, 2, 1, 3, 0, 5, 5,
+	5, 1, 3, 0, 2, 1, 3, 3, 2, 3,
+	3, 1, 1, 3, 3, 4, 3, 4, 3, 4,
+	5, 6, 3, 2, 1, 2, 1, 2, 1, 2,
+	1, 1, 1, 1, 1, 1, 1, 3, 1, 1,
+	3, 1, 3, 1, 1, 1, 3, 3, 3, 3,
+	3, 3, 3, 3, 3, 3, 2, 2, 2, 3,
+	3, 4, 5, 4, 1, 5, 0, 1, 1, 2,
+	4, 0, 2, 1, 3, 5, 1, 1, 1, 1,
+	0, 3, 0, 2, 0, 3, 1, 3, 2, 0,
+	1, 1, 0, 2, 4, 0, 2, 2, 4, 0,
+	3, 1, 3, 0, 5, 2, 1, 1, 3, 3,
+	1, 1, 3, 3, 1, 1, 0, 2, 0, 3,
+	0, 1, 1, 1, 1, 1, 1, 1, 0, 1,
+	0, 1, 0, 2, 1, 1, 1, 1, 0,
 }
 var yyChk = [...]int{
 
-	-1000, -77, -1, -2, -3, -4, -5, -6, -7, -8,
-	-9, -10, -11, -12, 6, 7, 8, 9, 28, 91,
-	92, 94, 93, 95, 104, 105, 106, -15, 5, -13,
-	-78, -13, -13, -13, -13, 96, -69, 98, 102, -64,
-	98, 100, 96, 96, 97, 98, 96, -76, -76, -76,
-	-2, 18, 19, -16, 32, 19, -14, -64, -28, -73,
-	48, 10, -61, -62, -44, -71, -73, 48, -66, 101,
-	97, -71, 48, 96, -71, -73, -65, 101, 48, -65,
-	-73, -17, 35, -71, 53, 24, 28, 89, -28, 46,
-	65, 89, -73, 59, 48, -76, -73, -76, 99, -73,
-	21, 44, -71, -18, -19, 82, -20, -73, -32, -37,
-	-33, 59, -79, -36, -44, -42, 80, 81, 86, 88,
-	-71, 101, -45, 55, 56, 21, 45, 49, 50, 51,
-	54, -43, 61, -63, 17, 10, -28, -61, -73, -31,
-	11, -62, -37, -79, -71, -73, -76, 21, -70, 103,
-	-67, 94, 92, 27, 93, 14, 107, 48, -73, -73,
-	-76, 10, 46, -72, -71, 20, 89, 58, 57, 72,
-	-34, 75, 59, 73, 74, 60, 72, 77, 76, 85,
-	80, 81, 82, 83, 84, 78, 79, 65, 66, 67,
-	68, 69, 70, 71, -32, -37, -32, -2, -40, -37,
-	-37, -37, -37, -37, -79, -79, -43, -79, -48, -37,
-	-28, -58, 28, -79, -31, -52, 14, -32, -37, 89,
-	44, -71, -76, -68, 99, -21, -22, -23, -24, -28,
-	-43, -79, -19, -71, 82, -32, -32, -38, 54, 59,
-	55, 56, -37, -39, -79, -43, 52, 75, 73, 74,
-	60, -37, -37, -37, -38, -37, -37, -37, -37, -37,
-	-37, -37, -37, -37, -37, -80, 47, -80, 46, -80,
-	-71, -80, -18, 19, -18, -46, -47, 62, -35, 30,
-	-2, -61, -59, -44, -52, -56, 16, 15, -71, -73,
-	-73, -31, 46, -25, -26, -27, 34, 38, 40, 35,
-	36, 37, 41, -74, -73, 20, -75, 20, -21, 54,
-	55, 56, -40, -39, -37, -37, -37, 58, -37, -80,
-	-18, -80, -49, -47, 64, -32, -60, 44, -41, -42,
-	-60, -80, 46, -56, -37, -53, -54, -37, -76, -50,
-	12, -22, -23, -22, -23, 34, 34, 34, 39, 34,
-	39, 34, -26, -29, 42, 100, 43, -73, -73, -80,
-	-80, 58, -37, -80, 90, -37, 63, 25, 46, -44,
-	46, 46, -55, 22, 23, -51, 13, 15, 44, 44,
-	34, 34, 97, 97, 97, -37, -37, 26, -42, -37,
-	-54, -52, -32, -40, -32, -32, -79, -79, -79, 8,
-	-56, -30, -71, -30, -30, -61, -57, 17, 29, -80,
-	46, -80, -80, 8, 75, -71, -71, -71,
+	-1000, -82, -1, -2, -3, -4, -5, -6, -7, -8,
+	-9, -10, -11, -12, -13, -14, 6, 7, 8, 9,
+	28, 91, 92, 94, 93, 95, 104, 105, 106, 29,
+	107, -20, 5, -18, -83, -18, -18, -18, -18, 96,
+	-74, 98, 102, -69, 98, 100, 96, 96, 97, 98,
+	96, -81, -81, -81, -76, 96, 48, -76, 96, -2,
+	18, 19, -21, 32, 19, -19, -69, -33, -78, 48,
+	10, -66, -67, -49, -76, -78, 48, -71, 101, 97,
+	-76, 96, -76, -78, -70, 101, 48, -70, -78, -15,
+	-16, -33, -15, -22, 35, -76, 53, 24, 28, 89,
+	-33, 46, 65, 89, -78, 59, 48, -81, -78, -81,
+	99, -78, 21, 44, -76, 46, -79, -78, 20, -23,
+	-24, 82, -25, -78, -37, -42, -38, 59, -84, -41,
+	-49, -47, 80, 81, 86, 88, -76, 101, -50, 55,
+	56, 21, 45, 49, 50, 51, 54, -48, 61, -68,
+	17, 10, -33, -66, -78, -36, 11, -67, -42, -84,
+	-76, -78, -81, 21, -75, 103, -72, 94, 92, 27,
+	93, 14, 111, 48, -78, -78, -81, -16, -17, 108,
+	109, 110, -78, 10, 46, -77, -76, 20, 89, 58,
+	57, 72, -39, 75, 59, 73, 74, 60, 72, 77,
+	76, 85, 80, 81, 82, 83, 84, 78, 79, 65,
+	66, 67, 68, 69, 70, 71, -37, -42, -37, -2,
+	-45, -42, -42, -42, -42, -42, -84, -84, -48, -84,
+	-53, -42, -33, -63, 28, -84, -36, -57, 14, -37,
+	-42, 89, 44, -76, -81, -73, 99, -76, 109, -26,
+	-27, -28, -29, -33, -48, -84, -24, -76, 82, -37,
+	-37, -43, 54, 59, 55, 56, -42, -44, -84, -48,
+	52, 75, 73, 74, 60, -42, -42, -42, -43, -42,
+	-42, -42, -42, -42, -42, -42, -42, -42, -42, -85,
+	47, -85, 46, -85, -76, -85, -23, 19, -23, -51,
+	-52, 62, -40, 30, -2, -66, -64, -49, -57, -61,
+	16, 15, -76, -78, -78, -36, 46, -30, -31, -32,
+	34, 38, 40, 35, 36, 37, 41, -79, -80, 20,
+	-26, 54, 55, 56, -45, -44, -42, -42, -42, 58,
+	-42, -85, -23, -85, -54, -52, 64, -37, -65, 44,
+	-46, -47, -65, -85, 46, -61, -42, -58, -59, -42,
+	-81, -55, 12, -27, -28, -27, -28, 34, 34, 34,
+	39, 34, 39, 34, -31, -34, 42, 100, 43, -78,
+	-85, -85, 58, -42, -85, 90, -42, 63, 25, 46,
+	-49, 46, 46, -60, 22, 23, -56, 13, 15, 44,
+	44, 34, 34, 97, 97, 97, -42, -42, 26, -47,
+	-42, -59, -57, -37, -45, -37, -37, -84, -84, -84,
+	8, -61, -35, -76, -35, -35, -66, -62, 17, 29,
+	-85, 46, -85, -85, 8, -76, 75, -76, -76, -76,
 }
 var yyDef = [...]int{
 
 	0, -2, 1, 2, 3, 4, 5, 6, 7, 8,
-	9, 10, 11, 12, 35, 35, 35, 35, 35, 216,
-	206, 0, 0, 0, 224, 224, 224, 0, 39, 42,
-	37, 206, 0, 0, 0, 204, 0, 0, 217, 0,
-	0, 207, 0, 202, 0, 202, 0, 32, 33, 34,
-	15, 40, 41, 44, 0, 43, 36, 0, 0, 82,
-	221, 0, 20, 197, 0, 160, 0, -2, 0, 0,
-	0, 224, 220, 0, 224, 0, 0, 0, 0, 0,
-	31, 0, 45, 0, 38, 0, 0, 0, 90, 0,
-	0, 0, 224, 0, 218, 23, 0, 26, 0, 28,
-	203, 0, 224, 0, 46, 48, 53, 0, 51, 52,
-	92, 0, 0, 130, 131, 132, 0, 0, 0, 0,
-	160, 0, 151, 98, 99, 0, 222, 163, 164, 165,
-	166, 196, 153, 0, 200, 201, 185, 90, 83, 171,
-	0, 198, 199, 0, 161, 0, 21, 205, 0, 0,
-	224, 214, 208, 209, 210, 211, 212, 213, 27, 29,
-	30, 0, 0, 49, 54, 0, 0, 0, 0, 0,
+	9, 10, 11, 12, 13, 14, 48, 48, 48, 48,
+	48, 230, 220, 0, 0, 0, 238, 238, 238, 0,
+	0, 0, 52, 55, 50, 220, 0, 0, 0, 218,
+	0, 0, 231, 0, 0, 221, 0, 216, 0, 216,
+	0, 34, 35, 36, 0, 0, 234, 46, 47, 17,
+	53, 54, 57, 0, 56, 49, 0, 0, 95, 235,
+	0, 22, 211, 0, 173, 0, -2, 0, 0, 0,
+	238, 0, 238, 0, 0, 0, 0, 0, 33, 37,
+	39, 82, 38, 0, 58, 0, 51, 0, 0, 0,
+	103, 0, 0, 0, 238, 0, 232, 25, 0, 28,
+	0, 30, 217, 0, 238, 0, 0, 83, 0, 0,
+	59, 61, 66, 0, 64, 65, 105, 0, 0, 143,
+	144, 145, 0, 0, 0, 0, 173, 0, 164, 111,
+	112, 0, 236, 176, 177, 178, 179, 210, 166, 0,
+	214, 215, 199, 103, 96, 184, 0, 212, 213, 0,
+	174, 0, 23, 219, 0, 0, 238, 228, 222, 223,
+	224, 225, 226, 227, 29, 31, 32, 40, 41, 42,
+	44, 0, 84, 0, 0, 62, 67, 0, 0, 0,
 	0, 0, 0, 0, 0, 0, 0, 0, 0, 0,
-	0, 0, 0, 0, 0, 0, 0, 117, 118, 119,
-	120, 121, 122, 123, 95, 0, 0, 0, 0, 128,
-	143, 144, 145, 0, 0, 0, 110, 0, 0, 154,
-	14, 0, 0, 0, 171, 179, 0, 91, 128, 0,
-	0, 219, 24, 0, 215, 90, 56, 58, 59, 69,
-	67, 0, 47, 55, 50, 93, 94, 97, 111, 0,
-	113, 115, 100, 101, 0, 125, 126, 0, 0, 0,
-	0, 103, 105, 0, 109, 133, 134, 135, 136, 137,
-	138, 139, 140, 141, 142, 96, 223, 127, 0, 195,
-	146, 147, 0, 0, 0, 158, 155, 0, 189, 0,
-	192, 189, 0, 187, 179, 19, 0, 0, 162, 224,
-	25, 167, 0, 0, 0, 0, 72, 0, 0, 75,
-	0, 0, 0, 84, 70, 0, 0, 68, 0, 112,
-	114, 116, 0, 102, 104, 106, 0, 0, 129, 148,
-	0, 150, 0, 156, 0, 0, 16, 0, 191, 193,
-	17, 186, 0, 18, 180, 172, 173, 176, 22, 169,
-	0, 57, 63, 0, 66, 73, 74, 76, 0, 78,
-	0, 80, 81, 60, 0, 0, 0, 71, 61, 62,
-	124, 0, 107, 149, 152, 159, 0, 0, 0, 188,
-	0, 0, 175, 177, 178, 171, 0, 0, 0, 0,
-	77, 79, 0, 0, 0, 108, 157, 0, 194, 181,
-	174, 179, 170, 168, 64, 65, 0, 0, 0, 0,
-	182, 0, 88, 0, 0, 190, 13, 0, 0, 85,
-	0, 86, 87, 183, 0, 89, 0, 184,
+	0, 0, 0, 0, 0, 0, 0, 0, 0, 130,
+	131, 132, 133, 134, 135, 136, 108, 0, 0, 0,
+	0, 141, 156, 157, 158, 0, 0, 0, 123, 0,
+	0, 167, 16, 0, 0, 0, 184, 192, 0, 104,
+	141, 0, 0, 233, 26, 0, 229, 43, 45, 103,
+	69, 71, 72, 82, 80, 0, 60, 68, 63, 106,
+	107, 110, 124, 0, 126, 128, 113, 114, 0, 138,
+	139, 0, 0, 0, 0, 116, 118, 0, 122, 146,
+	147, 148, 149, 150, 151, 152, 153, 154, 155, 109,
+	237, 140, 0, 209, 159, 160, 0, 0, 0, 171,
+	168, 0, 203, 0, 206, 203, 0, 201, 192, 21,
+	0, 0, 175, 238, 27, 180, 0, 0, 0, 0,
+	85, 0, 0, 88, 0, 0, 0, 97, 0, 81,
+	0, 125, 127, 129, 0, 115, 117, 119, 0, 0,
+	142, 161, 0, 163, 0, 169, 0, 0, 18, 0,
+	205, 207, 19, 200, 0, 20, 193, 185, 186, 189,
+	24, 182, 0, 70, 76, 0, 79, 86, 87, 89,
+	0, 91, 0, 93, 94, 73, 0, 0, 0, 74,
+	75, 137, 0, 120, 162, 165, 172, 0, 0, 0,
+	202, 0, 0, 188, 190, 191, 184, 0, 0, 0,
+	0, 90, 92, 0, 0, 0, 121, 170, 0, 208,
+	194, 187, 192, 183, 181, 77, 78, 0, 0, 0,
+	0, 195, 0, 101, 0, 0, 204, 15, 0, 0,
+	98, 0, 99, 100, 196, 197, 0, 102, 0, 198,
 }
 var yyTok1 = [...]int{
 
@@ -598,6 +623,7 @@ var yyTok2 = [...]int{
 	68, 69, 70, 71, 72, 73, 74, 75, 78, 79,
 	87, 88, 90, 91, 92, 93, 94, 95, 96, 97,
 	98, 99, 100, 101, 102, 103, 104, 105, 106, 107,
+	108, 109, 110, 111,
 }
 var yyTok3 = [...]int{
 	0,
@@ -942,25 +968,25 @@ yydefault:
 
 	case 1:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:171
+		//line sql.y:180
 		{
 			setParseTree(yylex, yyDollar[1].statement)
 		}
 	case 2:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:177
+		//line sql.y:186
 		{
 			yyVAL.statement = yyDollar[1].selStmt
 		}
-	case 13:
+	case 15:
 		yyDollar = yyS[yypt-13 : yypt+1]
-		//line sql.y:193
+		//line sql.y:204
 		{
 			yyVAL.selStmt = &Select{Comments: Comments(yyDollar[2].bytes2), Distinct: yyDollar[3].str, Hints: yyDollar[4].str, SelectExprs: yyDollar[5].selectExprs, From: yyDollar[7].tableExprs, Where: NewWhere(WhereStr, yyDollar[8].boolExpr), GroupBy: GroupBy(yyDollar[9].valExprs), Having: NewWhere(HavingStr, yyDollar[10].boolExpr), OrderBy: yyDollar[11].orderBy, Limit: yyDollar[12].limit, Lock: yyDollar[13].str}
 		}
-	case 14:
+	case 16:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line sql.y:197
+		//line sql.y:208
 		{
 			if yyDollar[4].sqlID != "value" {
 				yylex.Error("expecting value after next")
@@ -968,21 +994,21 @@ yydefault:
 			}
 			yyVAL.selStmt = &Select{Comments: Comments(yyDollar[2].bytes2), SelectExprs: SelectExprs{Nextval{}}, From: TableExprs{&AliasedTableExpr{Expr: yyDollar[6].tableName}}}
 		}
-	case 15:
+	case 17:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:205
+		//line sql.y:216
 		{
 			yyVAL.selStmt = &Union{Type: yyDollar[2].str, Left: yyDollar[1].selStmt, Right: yyDollar[3].selStmt}
 		}
-	case 16:
+	case 18:
 		yyDollar = yyS[yypt-8 : yypt+1]
-		//line sql.y:211
+		//line sql.y:222
 		{
 			yyVAL.statement = &Insert{Comments: Comments(yyDollar[2].bytes2), Ignore: yyDollar[3].str, Table: yyDollar[5].tableName, Columns: yyDollar[6].columns, Rows: yyDollar[7].insRows, OnDup: OnDup(yyDollar[8].updateExprs)}
 		}
-	case 17:
+	case 19:
 		yyDollar = yyS[yypt-8 : yypt+1]
-		//line sql.y:215
+		//line sql.y:226
 		{
 			cols := make(Columns, 0, len(yyDollar[7].updateExprs))
 			vals := make(ValTuple, 0, len(yyDollar[7].updateExprs))
@@ -992,379 +1018,457 @@ yydefault:
 			}
 			yyVAL.statement = &Insert{Comments: Comments(yyDollar[2].bytes2), Ignore: yyDollar[3].str, Table: yyDollar[5].tableName, Columns: cols, Rows: Values{vals}, OnDup: OnDup(yyDollar[8].updateExprs)}
 		}
-	case 18:
+	case 20:
 		yyDollar = yyS[yypt-8 : yypt+1]
-		//line sql.y:227
+		//line sql.y:238
 		{
 			yyVAL.statement = &Update{Comments: Comments(yyDollar[2].bytes2), Table: yyDollar[3].tableName, Exprs: yyDollar[5].updateExprs, Where: NewWhere(WhereStr, yyDollar[6].boolExpr), OrderBy: yyDollar[7].orderBy, Limit: yyDollar[8].limit}
 		}
-	case 19:
+	case 21:
 		yyDollar = yyS[yypt-7 : yypt+1]
-		//line sql.y:233
+		//line sql.y:244
 		{
 			yyVAL.statement = &Delete{Comments: Comments(yyDollar[2].bytes2), Table: yyDollar[4].tableName, Where: NewWhere(WhereStr, yyDollar[5].boolExpr), OrderBy: yyDollar[6].orderBy, Limit: yyDollar[7].limit}
 		}
-	case 20:
+	case 22:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:239
+		//line sql.y:250
 		{
 			yyVAL.statement = &Set{Comments: Comments(yyDollar[2].bytes2), Exprs: yyDollar[3].updateExprs}
 		}
-	case 21:
+	case 23:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line sql.y:245
+		//line sql.y:256
 		{
 			yyVAL.statement = &DDL{Action: CreateStr, NewName: yyDollar[4].sqlID}
 		}
-	case 22:
+	case 24:
 		yyDollar = yyS[yypt-8 : yypt+1]
-		//line sql.y:249
+		//line sql.y:260
 		{
 			// Change this to an alter statement
 			yyVAL.statement = &DDL{Action: AlterStr, Table: yyDollar[7].sqlID, NewName: yyDollar[7].sqlID}
 		}
-	case 23:
+	case 25:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line sql.y:254
+		//line sql.y:265
 		{
 			yyVAL.statement = &DDL{Action: CreateStr, NewName: SQLName(yyDollar[3].sqlID)}
 		}
-	case 24:
+	case 26:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line sql.y:260
+		//line sql.y:271
 		{
 			yyVAL.statement = &DDL{Action: AlterStr, Table: yyDollar[4].sqlID, NewName: yyDollar[4].sqlID}
 		}
-	case 25:
+	case 27:
 		yyDollar = yyS[yypt-7 : yypt+1]
-		//line sql.y:264
+		//line sql.y:275
 		{
 			// Change this to a rename statement
 			yyVAL.statement = &DDL{Action: RenameStr, Table: yyDollar[4].sqlID, NewName: yyDollar[7].sqlID}
 		}
-	case 26:
+	case 28:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line sql.y:269
+		//line sql.y:280
 		{
 			yyVAL.statement = &DDL{Action: AlterStr, Table: SQLName(yyDollar[3].sqlID), NewName: SQLName(yyDollar[3].sqlID)}
 		}
-	case 27:
+	case 29:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line sql.y:275
+		//line sql.y:286
 		{
 			yyVAL.statement = &DDL{Action: RenameStr, Table: yyDollar[3].sqlID, NewName: yyDollar[5].sqlID}
 		}
-	case 28:
+	case 30:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line sql.y:281
+		//line sql.y:292
 		{
 			yyVAL.statement = &DDL{Action: DropStr, Table: yyDollar[4].sqlID}
 		}
-	case 29:
+	case 31:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line sql.y:285
+		//line sql.y:296
 		{
 			// Change this to an alter statement
 			yyVAL.statement = &DDL{Action: AlterStr, Table: yyDollar[5].sqlID, NewName: yyDollar[5].sqlID}
 		}
-	case 30:
+	case 32:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line sql.y:290
+		//line sql.y:301
 		{
 			yyVAL.statement = &DDL{Action: DropStr, Table: SQLName(yyDollar[4].sqlID)}
 		}
-	case 31:
+	case 33:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:296
+		//line sql.y:307
 		{
 			yyVAL.statement = &DDL{Action: AlterStr, Table: yyDollar[3].sqlID, NewName: yyDollar[3].sqlID}
 		}
-	case 32:
+	case 34:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:302
+		//line sql.y:313
 		{
 			yyVAL.statement = &Other{}
 		}
-	case 33:
+	case 35:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:306
+		//line sql.y:317
 		{
 			yyVAL.statement = &Other{}
 		}
-	case 34:
+	case 36:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:310
+		//line sql.y:321
 		{
 			yyVAL.statement = &Other{}
 		}
-	case 35:
+	case 37:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line sql.y:327
+		{
+			if yyDollar[2].sqlID != "tables" {
+				yylex.Error("expecting tables")
+				return 1
+			}
+			yyVAL.statement = &LockTables{Tables: yyDollar[3].lockTables}
+		}
+	case 38:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line sql.y:335
+		{
+			yyVAL.statement = &LockTables{Tables: yyDollar[3].lockTables}
+		}
+	case 39:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line sql.y:341
+		{
+			yyVAL.lockTables = LockTableList{yyDollar[1].lockTable}
+		}
+	case 40:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line sql.y:345
+		{
+			yyVAL.lockTables = append(yyDollar[1].lockTables, yyDollar[3].lockTable)
+		}
+	case 41:
+		yyDollar = yyS[yypt-3 : yypt+1]
+		//line sql.y:351
+		{
+			yyVAL.lockTable = &LockTable{Table: yyDollar[1].tableName, As: yyDollar[2].sqlID, Lock: yyDollar[3].str}
+		}
+	case 42:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line sql.y:357
+		{
+			yyVAL.str = ReadStr
+		}
+	case 43:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line sql.y:361
+		{
+			if yyDollar[2].sqlID != "local" {
+				yylex.Error("expecting local")
+				return 1
+			}
+			yyVAL.str = ReadLocalStr
+		}
+	case 44:
+		yyDollar = yyS[yypt-1 : yypt+1]
+		//line sql.y:369
+		{
+			yyVAL.str = WriteStr
+		}
+	case 45:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line sql.y:373
+		{
+			yyVAL.str = LowPriorityWriteStr
+		}
+	case 46:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line sql.y:379
+		{
+			if yyDollar[2].sqlID != "tables" {
+				yylex.Error("expecting tables")
+				return 1
+			}
+			yyVAL.statement = &UnlockTables{}
+		}
+	case 47:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line sql.y:387
+		{
+			yyVAL.statement = &UnlockTables{}
+		}
+	case 48:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:315
+		//line sql.y:392
 		{
 			setAllowComments(yylex, true)
 		}
-	case 36:
+	case 49:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:319
+		//line sql.y:396
 		{
 			yyVAL.bytes2 = yyDollar[2].bytes2
 			setAllowComments(yylex, false)
 		}
-	case 37:
+	case 50:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:325
+		//line sql.y:402
 		{
 			yyVAL.bytes2 = nil
 		}
-	case 38:
+	case 51:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:329
+		//line sql.y:406
 		{
 			yyVAL.bytes2 = append(yyDollar[1].bytes2, yyDollar[2].bytes)
 		}
-	case 39:
+	case 52:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:335
+		//line sql.y:412
 		{
 			yyVAL.str = UnionStr
 		}
-	case 40:
+	case 53:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:339
+		//line sql.y:416
 		{
 			yyVAL.str = UnionAllStr
 		}
-	case 41:
+	case 54:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:343
+		//line sql.y:420
 		{
 			yyVAL.str = UnionDistinctStr
 		}
-	case 42:
+	case 55:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:348
+		//line sql.y:425
 		{
 			yyVAL.str = ""
 		}
-	case 43:
+	case 56:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:352
+		//line sql.y:429
 		{
 			yyVAL.str = DistinctStr
 		}
-	case 44:
+	case 57:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:357
+		//line sql.y:434
 		{
 			yyVAL.str = ""
 		}
-	case 45:
+	case 58:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:361
+		//line sql.y:438
 		{
 			yyVAL.str = StraightJoinHint
 		}
-	case 46:
+	case 59:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:367
+		//line sql.y:444
 		{
 			yyVAL.selectExprs = SelectExprs{yyDollar[1].selectExpr}
 		}
-	case 47:
+	case 60:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:371
+		//line sql.y:448
 		{
-			yyVAL.selectExprs = append(yyVAL.selectExprs, yyDollar[3].selectExpr)
+			yyVAL.selectExprs = append(yyDollar[1].selectExprs, yyDollar[3].selectExpr)
 		}
-	case 48:
+	case 61:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:377
+		//line sql.y:454
 		{
 			yyVAL.selectExpr = &StarExpr{}
 		}
-	case 49:
+	case 62:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:381
+		//line sql.y:458
 		{
 			yyVAL.selectExpr = &NonStarExpr{Expr: yyDollar[1].expr, As: yyDollar[2].sqlID}
 		}
-	case 50:
+	case 63:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:385
+		//line sql.y:462
 		{
 			yyVAL.selectExpr = &StarExpr{TableName: yyDollar[1].sqlID}
 		}
-	case 51:
+	case 64:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:391
+		//line sql.y:468
 		{
 			yyVAL.expr = yyDollar[1].boolExpr
 		}
-	case 52:
+	case 65:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:395
+		//line sql.y:472
 		{
 			yyVAL.expr = yyDollar[1].valExpr
 		}
-	case 53:
+	case 66:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:400
+		//line sql.y:477
 		{
 			yyVAL.sqlID = ""
 		}
-	case 54:
+	case 67:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:404
+		//line sql.y:481
 		{
 			yyVAL.sqlID = yyDollar[1].sqlID
 		}
-	case 55:
+	case 68:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:408
+		//line sql.y:485
 		{
 			yyVAL.sqlID = yyDollar[2].sqlID
 		}
-	case 56:
+	case 69:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:414
+		//line sql.y:491
 		{
 			yyVAL.tableExprs = TableExprs{yyDollar[1].tableExpr}
 		}
-	case 57:
+	case 70:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:418
+		//line sql.y:495
 		{
-			yyVAL.tableExprs = append(yyVAL.tableExprs, yyDollar[3].tableExpr)
+			yyVAL.tableExprs = append(yyDollar[1].tableExprs, yyDollar[3].tableExpr)
 		}
-	case 60:
+	case 73:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:428
+		//line sql.y:505
 		{
 			yyVAL.tableExpr = &AliasedTableExpr{Expr: yyDollar[1].tableName, As: yyDollar[2].sqlID, Hints: yyDollar[3].indexHints}
 		}
-	case 61:
+	case 74:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:432
+		//line sql.y:509
 		{
 			yyVAL.tableExpr = &AliasedTableExpr{Expr: yyDollar[1].subquery, As: yyDollar[3].sqlID}
 		}
-	case 62:
+	case 75:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:436
+		//line sql.y:513
 		{
 			yyVAL.tableExpr = &ParenTableExpr{Exprs: yyDollar[2].tableExprs}
 		}
-	case 63:
+	case 76:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:449
+		//line sql.y:526
 		{
 			yyVAL.tableExpr = &JoinTableExpr{LeftExpr: yyDollar[1].tableExpr, Join: yyDollar[2].str, RightExpr: yyDollar[3].tableExpr}
 		}
-	case 64:
+	case 77:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line sql.y:453
+		//line sql.y:530
 		{
 			yyVAL.tableExpr = &JoinTableExpr{LeftExpr: yyDollar[1].tableExpr, Join: yyDollar[2].str, RightExpr: yyDollar[3].tableExpr, On: yyDollar[5].boolExpr}
 		}
-	case 65:
+	case 78:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line sql.y:457
+		//line sql.y:534
 		{
 			yyVAL.tableExpr = &JoinTableExpr{LeftExpr: yyDollar[1].tableExpr, Join: yyDollar[2].str, RightExpr: yyDollar[3].tableExpr, On: yyDollar[5].boolExpr}
 		}
-	case 66:
+	case 79:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:461
+		//line sql.y:538
 		{
 			yyVAL.tableExpr = &JoinTableExpr{LeftExpr: yyDollar[1].tableExpr, Join: yyDollar[2].str, RightExpr: yyDollar[3].tableExpr}
 		}
-	case 67:
+	case 80:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:466
+		//line sql.y:543
 		{
 			yyVAL.empty = struct{}{}
 		}
-	case 68:
+	case 81:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:468
+		//line sql.y:545
 		{
 			yyVAL.empty = struct{}{}
 		}
-	case 69:
+	case 82:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:471
+		//line sql.y:548
 		{
 			yyVAL.sqlID = ""
 		}
-	case 70:
+	case 83:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:475
+		//line sql.y:552
 		{
 			yyVAL.sqlID = yyDollar[1].sqlID
 		}
-	case 71:
+	case 84:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:479
+		//line sql.y:556
 		{
 			yyVAL.sqlID = yyDollar[2].sqlID
 		}
-	case 72:
+	case 85:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:485
+		//line sql.y:562
 		{
 			yyVAL.str = JoinStr
 		}
-	case 73:
+	case 86:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:489
+		//line sql.y:566
 		{
 			yyVAL.str = JoinStr
 		}
-	case 74:
+	case 87:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:493
+		//line sql.y:570
 		{
 			yyVAL.str = JoinStr
 		}
-	case 75:
+	case 88:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:497
+		//line sql.y:574
 		{
 			yyVAL.str = StraightJoinStr
 		}
-	case 76:
+	case 89:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:503
+		//line sql.y:580
 		{
 			yyVAL.str = LeftJoinStr
 		}
-	case 77:
+	case 90:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:507
+		//line sql.y:584
 		{
 			yyVAL.str = LeftJoinStr
 		}
-	case 78:
+	case 91:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:511
+		//line sql.y:588
 		{
 			yyVAL.str = RightJoinStr
 		}
-	case 79:
+	case 92:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:515
+		//line sql.y:592
 		{
 			yyVAL.str = RightJoinStr
 		}
-	case 80:
+	case 93:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:521
+		//line sql.y:598
 		{
 			yyVAL.str = NaturalJoinStr
 		}
-	case 81:
+	case 94:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:525
+		//line sql.y:602
 		{
 			if yyDollar[2].str == LeftJoinStr {
 				yyVAL.str = NaturalLeftJoinStr
@@ -1372,369 +1476,369 @@ yydefault:
 				yyVAL.str = NaturalRightJoinStr
 			}
 		}
-	case 82:
+	case 95:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:535
+		//line sql.y:612
 		{
 			yyVAL.tableName = &TableName{Name: yyDollar[1].sqlID}
 		}
-	case 83:
+	case 96:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:539
+		//line sql.y:616
 		{
 			yyVAL.tableName = &TableName{Qualifier: yyDollar[1].sqlID, Name: yyDollar[3].sqlID}
 		}
-	case 84:
+	case 97:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:544
+		//line sql.y:621
 		{
 			yyVAL.indexHints = nil
 		}
-	case 85:
+	case 98:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line sql.y:548
+		//line sql.y:625
 		{
 			yyVAL.indexHints = &IndexHints{Type: UseStr, Indexes: yyDollar[4].sqlIDs}
 		}
-	case 86:
+	case 99:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line sql.y:552
+		//line sql.y:629
 		{
 			yyVAL.indexHints = &IndexHints{Type: IgnoreStr, Indexes: yyDollar[4].sqlIDs}
 		}
-	case 87:
+	case 100:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line sql.y:556
+		//line sql.y:633
 		{
 			yyVAL.indexHints = &IndexHints{Type: ForceStr, Indexes: yyDollar[4].sqlIDs}
 		}
-	case 88:
+	case 101:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:562
+		//line sql.y:639
 		{
 			yyVAL.sqlIDs = []SQLName{yyDollar[1].sqlID}
 		}
-	case 89:
+	case 102:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:566
+		//line sql.y:643
 		{
 			yyVAL.sqlIDs = append(yyDollar[1].sqlIDs, yyDollar[3].sqlID)
 		}
-	case 90:
+	case 103:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:571
+		//line sql.y:648
 		{
 			yyVAL.boolExpr = nil
 		}
-	case 91:
+	case 104:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:575
+		//line sql.y:652
 		{
 			yyVAL.boolExpr = yyDollar[2].boolExpr
 		}
-	case 93:
+	case 106:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:582
+		//line sql.y:659
 		{
 			yyVAL.boolExpr = &AndExpr{Left: yyDollar[1].boolExpr, Right: yyDollar[3].boolExpr}
 		}
-	case 94:
+	case 107:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:586
+		//line sql.y:663
 		{
 			yyVAL.boolExpr = &OrExpr{Left: yyDollar[1].boolExpr, Right: yyDollar[3].boolExpr}
 		}
-	case 95:
+	case 108:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:590
+		//line sql.y:667
 		{
 			yyVAL.boolExpr = &NotExpr{Expr: yyDollar[2].boolExpr}
 		}
-	case 96:
+	case 109:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:594
+		//line sql.y:671
 		{
 			yyVAL.boolExpr = &ParenBoolExpr{Expr: yyDollar[2].boolExpr}
 		}
-	case 97:
+	case 110:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:598
+		//line sql.y:675
 		{
 			yyVAL.boolExpr = &IsExpr{Operator: yyDollar[3].str, Expr: yyDollar[1].boolExpr}
 		}
-	case 98:
+	case 111:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:604
+		//line sql.y:681
 		{
 			yyVAL.boolExpr = BoolVal(true)
 		}
-	case 99:
+	case 112:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:608
+		//line sql.y:685
 		{
 			yyVAL.boolExpr = BoolVal(false)
 		}
-	case 100:
+	case 113:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:612
+		//line sql.y:689
 		{
 			yyVAL.boolExpr = &ComparisonExpr{Left: yyDollar[1].valExpr, Operator: yyDollar[2].str, Right: yyDollar[3].valExpr}
 		}
-	case 101:
+	case 114:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:616
+		//line sql.y:693
 		{
 			yyVAL.boolExpr = &ComparisonExpr{Left: yyDollar[1].valExpr, Operator: InStr, Right: yyDollar[3].colTuple}
 		}
-	case 102:
+	case 115:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line sql.y:620
+		//line sql.y:697
 		{
 			yyVAL.boolExpr = &ComparisonExpr{Left: yyDollar[1].valExpr, Operator: NotInStr, Right: yyDollar[4].colTuple}
 		}
-	case 103:
+	case 116:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:624
+		//line sql.y:701
 		{
 			yyVAL.boolExpr = &ComparisonExpr{Left: yyDollar[1].valExpr, Operator: LikeStr, Right: yyDollar[3].valExpr}
 		}
-	case 104:
+	case 117:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line sql.y:628
+		//line sql.y:705
 		{
 			yyVAL.boolExpr = &ComparisonExpr{Left: yyDollar[1].valExpr, Operator: NotLikeStr, Right: yyDollar[4].valExpr}
 		}
-	case 105:
+	case 118:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:632
+		//line sql.y:709
 		{
 			yyVAL.boolExpr = &ComparisonExpr{Left: yyDollar[1].valExpr, Operator: RegexpStr, Right: yyDollar[3].valExpr}
 		}
-	case 106:
+	case 119:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line sql.y:636
+		//line sql.y:713
 		{
 			yyVAL.boolExpr = &ComparisonExpr{Left: yyDollar[1].valExpr, Operator: NotRegexpStr, Right: yyDollar[4].valExpr}
 		}
-	case 107:
+	case 120:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line sql.y:640
+		//line sql.y:717
 		{
 			yyVAL.boolExpr = &RangeCond{Left: yyDollar[1].valExpr, Operator: BetweenStr, From: yyDollar[3].valExpr, To: yyDollar[5].valExpr}
 		}
-	case 108:
+	case 121:
 		yyDollar = yyS[yypt-6 : yypt+1]
-		//line sql.y:644
+		//line sql.y:721
 		{
 			yyVAL.boolExpr = &RangeCond{Left: yyDollar[1].valExpr, Operator: NotBetweenStr, From: yyDollar[4].valExpr, To: yyDollar[6].valExpr}
 		}
-	case 109:
+	case 122:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:648
+		//line sql.y:725
 		{
 			yyVAL.boolExpr = &IsExpr{Operator: yyDollar[3].str, Expr: yyDollar[1].valExpr}
 		}
-	case 110:
+	case 123:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:652
+		//line sql.y:729
 		{
 			yyVAL.boolExpr = &ExistsExpr{Subquery: yyDollar[2].subquery}
 		}
-	case 111:
+	case 124:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:658
+		//line sql.y:735
 		{
 			yyVAL.str = IsNullStr
 		}
-	case 112:
+	case 125:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:662
+		//line sql.y:739
 		{
 			yyVAL.str = IsNotNullStr
 		}
-	case 113:
+	case 126:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:666
+		//line sql.y:743
 		{
 			yyVAL.str = IsTrueStr
 		}
-	case 114:
+	case 127:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:670
+		//line sql.y:747
 		{
 			yyVAL.str = IsNotTrueStr
 		}
-	case 115:
+	case 128:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:674
+		//line sql.y:751
 		{
 			yyVAL.str = IsFalseStr
 		}
-	case 116:
+	case 129:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:678
+		//line sql.y:755
 		{
 			yyVAL.str = IsNotFalseStr
 		}
-	case 117:
+	case 130:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:684
+		//line sql.y:761
 		{
 			yyVAL.str = EqualStr
 		}
-	case 118:
+	case 131:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:688
+		//line sql.y:765
 		{
 			yyVAL.str = LessThanStr
 		}
-	case 119:
+	case 132:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:692
+		//line sql.y:769
 		{
 			yyVAL.str = GreaterThanStr
 		}
-	case 120:
+	case 133:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:696
+		//line sql.y:773
 		{
 			yyVAL.str = LessEqualStr
 		}
-	case 121:
+	case 134:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:700
+		//line sql.y:777
 		{
 			yyVAL.str = GreaterEqualStr
 		}
-	case 122:
+	case 135:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:704
+		//line sql.y:781
 		{
 			yyVAL.str = NotEqualStr
 		}
-	case 123:
+	case 136:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:708
+		//line sql.y:785
 		{
 			yyVAL.str = NullSafeEqualStr
 		}
-	case 124:
+	case 137:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:714
+		//line sql.y:791
 		{
 			yyVAL.colTuple = ValTuple(yyDollar[2].valExprs)
 		}
-	case 125:
+	case 138:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:718
+		//line sql.y:795
 		{
 			yyVAL.colTuple = yyDollar[1].subquery
 		}
-	case 126:
+	case 139:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:722
+		//line sql.y:799
 		{
 			yyVAL.colTuple = ListArg(yyDollar[1].bytes)
 		}
-	case 127:
+	case 140:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:728
+		//line sql.y:805
 		{
 			yyVAL.subquery = &Subquery{yyDollar[2].selStmt}
 		}
-	case 128:
+	case 141:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:734
+		//line sql.y:811
 		{
 			yyVAL.valExprs = ValExprs{yyDollar[1].valExpr}
 		}
-	case 129:
+	case 142:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:738
+		//line sql.y:815
 		{
 			yyVAL.valExprs = append(yyDollar[1].valExprs, yyDollar[3].valExpr)
 		}
-	case 130:
+	case 143:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:744
+		//line sql.y:821
 		{
 			yyVAL.valExpr = yyDollar[1].valExpr
 		}
-	case 131:
+	case 144:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:748
+		//line sql.y:825
 		{
 			yyVAL.valExpr = yyDollar[1].colName
 		}
-	case 132:
+	case 145:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:752
+		//line sql.y:829
 		{
 			yyVAL.valExpr = yyDollar[1].rowTuple
 		}
-	case 133:
+	case 146:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:756
+		//line sql.y:833
 		{
 			yyVAL.valExpr = &BinaryExpr{Left: yyDollar[1].valExpr, Operator: BitAndStr, Right: yyDollar[3].valExpr}
 		}
-	case 134:
+	case 147:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:760
+		//line sql.y:837
 		{
 			yyVAL.valExpr = &BinaryExpr{Left: yyDollar[1].valExpr, Operator: BitOrStr, Right: yyDollar[3].valExpr}
 		}
-	case 135:
+	case 148:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:764
+		//line sql.y:841
 		{
 			yyVAL.valExpr = &BinaryExpr{Left: yyDollar[1].valExpr, Operator: BitXorStr, Right: yyDollar[3].valExpr}
 		}
-	case 136:
+	case 149:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:768
+		//line sql.y:845
 		{
 			yyVAL.valExpr = &BinaryExpr{Left: yyDollar[1].valExpr, Operator: PlusStr, Right: yyDollar[3].valExpr}
 		}
-	case 137:
+	case 150:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:772
+		//line sql.y:849
 		{
 			yyVAL.valExpr = &BinaryExpr{Left: yyDollar[1].valExpr, Operator: MinusStr, Right: yyDollar[3].valExpr}
 		}
-	case 138:
+	case 151:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:776
+		//line sql.y:853
 		{
 			yyVAL.valExpr = &BinaryExpr{Left: yyDollar[1].valExpr, Operator: MultStr, Right: yyDollar[3].valExpr}
 		}
-	case 139:
+	case 152:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:780
+		//line sql.y:857
 		{
 			yyVAL.valExpr = &BinaryExpr{Left: yyDollar[1].valExpr, Operator: DivStr, Right: yyDollar[3].valExpr}
 		}
-	case 140:
+	case 153:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:784
+		//line sql.y:861
 		{
 			yyVAL.valExpr = &BinaryExpr{Left: yyDollar[1].valExpr, Operator: ModStr, Right: yyDollar[3].valExpr}
 		}
-	case 141:
+	case 154:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:788
+		//line sql.y:865
 		{
 			yyVAL.valExpr = &BinaryExpr{Left: yyDollar[1].valExpr, Operator: ShiftLeftStr, Right: yyDollar[3].valExpr}
 		}
-	case 142:
+	case 155:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:792
+		//line sql.y:869
 		{
 			yyVAL.valExpr = &BinaryExpr{Left: yyDollar[1].valExpr, Operator: ShiftRightStr, Right: yyDollar[3].valExpr}
 		}
-	case 143:
+	case 156:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:796
+		//line sql.y:873
 		{
 			if num, ok := yyDollar[2].valExpr.(NumVal); ok {
 				yyVAL.valExpr = num
@@ -1742,9 +1846,9 @@ yydefault:
 				yyVAL.valExpr = &UnaryExpr{Operator: UPlusStr, Expr: yyDollar[2].valExpr}
 			}
 		}
-	case 144:
+	case 157:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:804
+		//line sql.y:881
 		{
 			if num, ok := yyDollar[2].valExpr.(NumVal); ok {
 				// Handle double negative
@@ -1757,15 +1861,15 @@ yydefault:
 				yyVAL.valExpr = &UnaryExpr{Operator: UMinusStr, Expr: yyDollar[2].valExpr}
 			}
 		}
-	case 145:
+	case 158:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:817
+		//line sql.y:894
 		{
 			yyVAL.valExpr = &UnaryExpr{Operator: TildaStr, Expr: yyDollar[2].valExpr}
 		}
-	case 146:
+	case 159:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:821
+		//line sql.y:898
 		{
 			// This rule prevents the usage of INTERVAL
 			// as a function. If support is needed for that,
@@ -1773,231 +1877,241 @@ yydefault:
 			// will be non-trivial because of grammar conflicts.
 			yyVAL.valExpr = &IntervalExpr{Expr: yyDollar[2].valExpr, Unit: yyDollar[3].sqlID}
 		}
-	case 147:
+	case 160:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:829
+		//line sql.y:906
 		{
 			yyVAL.valExpr = &FuncExpr{Name: string(yyDollar[1].sqlID)}
 		}
-	case 148:
+	case 161:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line sql.y:833
+		//line sql.y:910
 		{
 			yyVAL.valExpr = &FuncExpr{Name: string(yyDollar[1].sqlID), Exprs: yyDollar[3].selectExprs}
 		}
-	case 149:
+	case 162:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line sql.y:837
+		//line sql.y:914
 		{
 			yyVAL.valExpr = &FuncExpr{Name: string(yyDollar[1].sqlID), Distinct: true, Exprs: yyDollar[4].selectExprs}
 		}
-	case 150:
+	case 163:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line sql.y:841
+		//line sql.y:918
 		{
 			yyVAL.valExpr = &FuncExpr{Name: "if", Exprs: yyDollar[3].selectExprs}
 		}
-	case 151:
+	case 164:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:845
+		//line sql.y:922
 		{
 			yyVAL.valExpr = yyDollar[1].caseExpr
 		}
-	case 152:
+	case 165:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line sql.y:851
+		//line sql.y:928
 		{
 			yyVAL.caseExpr = &CaseExpr{Expr: yyDollar[2].valExpr, Whens: yyDollar[3].whens, Else: yyDollar[4].valExpr}
 		}
-	case 153:
+	case 166:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:856
+		//line sql.y:933
 		{
 			yyVAL.valExpr = nil
 		}
-	case 154:
+	case 167:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:860
+		//line sql.y:937
 		{
 			yyVAL.valExpr = yyDollar[1].valExpr
 		}
-	case 155:
+	case 168:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:866
+		//line sql.y:943
 		{
 			yyVAL.whens = []*When{yyDollar[1].when}
 		}
-	case 156:
+	case 169:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:870
+		//line sql.y:947
 		{
 			yyVAL.whens = append(yyDollar[1].whens, yyDollar[2].when)
 		}
-	case 157:
+	case 170:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line sql.y:876
+		//line sql.y:953
 		{
 			yyVAL.when = &When{Cond: yyDollar[2].boolExpr, Val: yyDollar[4].valExpr}
 		}
-	case 158:
+	case 171:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:881
+		//line sql.y:958
 		{
 			yyVAL.valExpr = nil
 		}
-	case 159:
+	case 172:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:885
+		//line sql.y:962
 		{
 			yyVAL.valExpr = yyDollar[2].valExpr
 		}
-	case 160:
+	case 173:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:891
+		//line sql.y:968
 		{
 			yyVAL.colName = &ColName{Name: yyDollar[1].sqlID}
 		}
-	case 161:
+	case 174:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:895
+		//line sql.y:972
 		{
 			yyVAL.colName = &ColName{Qualifier: &TableName{Name: yyDollar[1].sqlID}, Name: yyDollar[3].sqlID}
 		}
-	case 162:
+	case 175:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line sql.y:899
+		//line sql.y:976
 		{
 			yyVAL.colName = &ColName{Qualifier: &TableName{Qualifier: yyDollar[1].sqlID, Name: yyDollar[3].sqlID}, Name: yyDollar[5].sqlID}
 		}
-	case 163:
+	case 176:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:905
+		//line sql.y:982
 		{
 			yyVAL.valExpr = StrVal(yyDollar[1].bytes)
 		}
-	case 164:
+	case 177:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:909
+		//line sql.y:986
 		{
 			yyVAL.valExpr = NumVal(yyDollar[1].bytes)
 		}
-	case 165:
+	case 178:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:913
+		//line sql.y:990
 		{
 			yyVAL.valExpr = ValArg(yyDollar[1].bytes)
 		}
-	case 166:
+	case 179:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:917
+		//line sql.y:994
 		{
 			yyVAL.valExpr = &NullVal{}
 		}
-	case 167:
+	case 180:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:922
+		//line sql.y:999
 		{
 			yyVAL.valExprs = nil
 		}
-	case 168:
+	case 181:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:926
+		//line sql.y:1003
 		{
 			yyVAL.valExprs = yyDollar[3].valExprs
 		}
-	case 169:
+	case 182:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:931
+		//line sql.y:1008
 		{
 			yyVAL.boolExpr = nil
 		}
-	case 170:
+	case 183:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:935
+		//line sql.y:1012
 		{
 			yyVAL.boolExpr = yyDollar[2].boolExpr
 		}
-	case 171:
+	case 184:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:940
+		//line sql.y:1017
 		{
 			yyVAL.orderBy = nil
 		}
-	case 172:
+	case 185:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:944
+		//line sql.y:1021
 		{
 			yyVAL.orderBy = yyDollar[3].orderBy
 		}
-	case 173:
+	case 186:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:950
+		//line sql.y:1027
 		{
 			yyVAL.orderBy = OrderBy{yyDollar[1].order}
 		}
-	case 174:
+	case 187:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:954
+		//line sql.y:1031
 		{
 			yyVAL.orderBy = append(yyDollar[1].orderBy, yyDollar[3].order)
 		}
-	case 175:
+	case 188:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:960
+		//line sql.y:1037
 		{
 			yyVAL.order = &Order{Expr: yyDollar[1].valExpr, Direction: yyDollar[2].str}
 		}
-	case 176:
+	case 189:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:965
+		//line sql.y:1042
 		{
 			yyVAL.str = AscScr
 		}
-	case 177:
+	case 190:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:969
+		//line sql.y:1046
 		{
 			yyVAL.str = AscScr
 		}
-	case 178:
+	case 191:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:973
+		//line sql.y:1050
 		{
 			yyVAL.str = DescScr
 		}
-	case 179:
+	case 192:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:978
+		//line sql.y:1055
 		{
 			yyVAL.limit = nil
 		}
-	case 180:
+	case 193:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:982
+		//line sql.y:1059
 		{
 			yyVAL.limit = &Limit{Rowcount: yyDollar[2].valExpr}
 		}
-	case 181:
+	case 194:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line sql.y:986
+		//line sql.y:1063
 		{
 			yyVAL.limit = &Limit{Offset: yyDollar[2].valExpr, Rowcount: yyDollar[4].valExpr}
 		}
-	case 182:
+	case 195:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:991
+		//line sql.y:1068
 		{
 			yyVAL.str = ""
 		}
-	case 183:
+	case 196:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:995
+		//line sql.y:1072
 		{
 			yyVAL.str = ForUpdateStr
 		}
-	case 184:
+	case 197:
+		yyDollar = yyS[yypt-2 : yypt+1]
+		//line sql.y:1076
+		{
+			if yyDollar[2].sqlID != "share" {
+				yylex.Error("expecting share")
+				return 1
+			}
+			yyVAL.str = ForShareStr
+		}
+	case 198:
 		yyDollar = yyS[yypt-4 : yypt+1]
-		//line sql.y:999
+		//line sql.y:1084
 		{
 			if yyDollar[3].sqlID != "share" {
 				yylex.Error("expecting share")
@@ -2009,234 +2123,234 @@ yydefault:
 			}
 			yyVAL.str = ShareModeStr
 		}
-	case 185:
+	case 199:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:1012
+		//line sql.y:1097
 		{
 			yyVAL.columns = nil
 		}
-	case 186:
+	case 200:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:1016
+		//line sql.y:1101
 		{
 			yyVAL.columns = yyDollar[2].columns
 		}
-	case 187:
+	case 201:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1022
+		//line sql.y:1107
 		{
 			yyVAL.columns = Columns{&NonStarExpr{Expr: yyDollar[1].colName}}
 		}
-	case 188:
+	case 202:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:1026
+		//line sql.y:1111
 		{
 			yyVAL.columns = append(yyVAL.columns, &NonStarExpr{Expr: yyDollar[3].colName})
 		}
-	case 189:
+	case 203:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:1031
+		//line sql.y:1116
 		{
 			yyVAL.updateExprs = nil
 		}
-	case 190:
+	case 204:
 		yyDollar = yyS[yypt-5 : yypt+1]
-		//line sql.y:1035
+		//line sql.y:1120
 		{
 			yyVAL.updateExprs = yyDollar[5].updateExprs
 		}
-	case 191:
+	case 205:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:1041
+		//line sql.y:1126
 		{
 			yyVAL.insRows = yyDollar[2].values
 		}
-	case 192:
+	case 206:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1045
+		//line sql.y:1130
 		{
 			yyVAL.insRows = yyDollar[1].selStmt
 		}
-	case 193:
+	case 207:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1051
+		//line sql.y:1136
 		{
 			yyVAL.values = Values{yyDollar[1].rowTuple}
 		}
-	case 194:
+	case 208:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:1055
+		//line sql.y:1140
 		{
 			yyVAL.values = append(yyDollar[1].values, yyDollar[3].rowTuple)
 		}
-	case 195:
+	case 209:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:1061
+		//line sql.y:1146
 		{
 			yyVAL.rowTuple = ValTuple(yyDollar[2].valExprs)
 		}
-	case 196:
+	case 210:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1065
+		//line sql.y:1150
 		{
 			yyVAL.rowTuple = yyDollar[1].subquery
 		}
-	case 197:
+	case 211:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1071
+		//line sql.y:1156
 		{
 			yyVAL.updateExprs = UpdateExprs{yyDollar[1].updateExpr}
 		}
-	case 198:
+	case 212:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:1075
+		//line sql.y:1160
 		{
 			yyVAL.updateExprs = append(yyDollar[1].updateExprs, yyDollar[3].updateExpr)
 		}
-	case 199:
+	case 213:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:1081
+		//line sql.y:1166
 		{
 			yyVAL.updateExpr = &UpdateExpr{Name: yyDollar[1].colName, Expr: yyDollar[3].valExpr}
 		}
-	case 202:
+	case 216:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:1090
+		//line sql.y:1175
 		{
 			yyVAL.empty = struct{}{}
 		}
-	case 203:
+	case 217:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:1092
+		//line sql.y:1177
 		{
 			yyVAL.empty = struct{}{}
 		}
-	case 204:
+	case 218:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:1095
+		//line sql.y:1180
 		{
 			yyVAL.empty = struct{}{}
 		}
-	case 205:
+	case 219:
 		yyDollar = yyS[yypt-3 : yypt+1]
-		//line sql.y:1097
+		//line sql.y:1182
 		{
 			yyVAL.empty = struct{}{}
 		}
-	case 206:
+	case 220:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:1100
+		//line sql.y:1185
 		{
 			yyVAL.str = ""
 		}
-	case 207:
+	case 221:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1102
+		//line sql.y:1187
 		{
 			yyVAL.str = IgnoreStr
 		}
-	case 208:
+	case 222:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1106
+		//line sql.y:1191
 		{
 			yyVAL.empty = struct{}{}
 		}
-	case 209:
+	case 223:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1108
+		//line sql.y:1193
 		{
 			yyVAL.empty = struct{}{}
 		}
-	case 210:
+	case 224:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1110
+		//line sql.y:1195
 		{
 			yyVAL.empty = struct{}{}
 		}
-	case 211:
+	case 225:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1112
+		//line sql.y:1197
 		{
 			yyVAL.empty = struct{}{}
 		}
-	case 212:
+	case 226:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1114
+		//line sql.y:1199
 		{
 			yyVAL.empty = struct{}{}
 		}
-	case 213:
+	case 227:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1116
+		//line sql.y:1201
 		{
 			yyVAL.empty = struct{}{}
 		}
-	case 214:
+	case 228:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:1119
+		//line sql.y:1204
 		{
 			yyVAL.empty = struct{}{}
 		}
-	case 215:
+	case 229:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1121
+		//line sql.y:1206
 		{
 			yyVAL.empty = struct{}{}
 		}
-	case 216:
+	case 230:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:1124
+		//line sql.y:1209
 		{
 			yyVAL.empty = struct{}{}
 		}
-	case 217:
+	case 231:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1126
+		//line sql.y:1211
 		{
 			yyVAL.empty = struct{}{}
 		}
-	case 218:
+	case 232:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:1129
+		//line sql.y:1214
 		{
 			yyVAL.empty = struct{}{}
 		}
-	case 219:
+	case 233:
 		yyDollar = yyS[yypt-2 : yypt+1]
-		//line sql.y:1131
+		//line sql.y:1216
 		{
 			yyVAL.empty = struct{}{}
 		}
-	case 220:
+	case 234:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1135
+		//line sql.y:1220
 		{
 			yyVAL.sqlID = SQLName(strings.ToLower(string(yyDollar[1].bytes)))
 		}
-	case 221:
+	case 235:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1141
+		//line sql.y:1226
 		{
 			yyVAL.sqlID = SQLName(yyDollar[1].bytes)
 		}
-	case 222:
+	case 236:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1147
+		//line sql.y:1232
 		{
 			if incNesting(yylex) {
 				yylex.Error("max nesting level reached")
 				return 1
 			}
 		}
-	case 223:
+	case 237:
 		yyDollar = yyS[yypt-1 : yypt+1]
-		//line sql.y:1156
+		//line sql.y:1241
 		{
 			decNesting(yylex)
 		}
-	case 224:
+	case 238:
 		yyDollar = yyS[yypt-0 : yypt+1]
-		//line sql.y:1161
+		//line sql.y:1246
 		{
 			forceEOF(yylex)
 		}
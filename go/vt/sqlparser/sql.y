@@ -68,6 +68,8 @@ func forceEOF(yylex interface{}) {
   updateExpr  *UpdateExpr
   sqlID       SQLName
   sqlIDs      []SQLName
+  lockTables  LockTableList
+  lockTable   *LockTable
 }
 
 %token LEX_ERROR
@@ -107,6 +109,9 @@ func forceEOF(yylex interface{}) {
 %token <empty> TABLE INDEX VIEW TO IGNORE IF UNIQUE USING
 %token <empty> SHOW DESCRIBE EXPLAIN
 
+// LOCK TABLES Tokens
+%token <empty> UNLOCK READ WRITE LOW_PRIORITY
+
 // MySQL reserved words that are unused by this grammar will map to this token.
 %token <empty> UNUSED
 
@@ -115,6 +120,10 @@ func forceEOF(yylex interface{}) {
 %type <statement> insert_statement update_statement delete_statement set_statement
 %type <statement> create_statement alter_statement rename_statement drop_statement
 %type <statement> analyze_statement other_statement
+%type <statement> lock_statement unlock_statement
+%type <lockTables> lock_table_list
+%type <lockTable> lock_table
+%type <str> lock_type
 %type <bytes2> comment_opt comment_list
 %type <str> union_op
 %type <str> distinct_opt straight_join_opt
@@ -187,6 +196,8 @@ command:
 | drop_statement
 | analyze_statement
 | other_statement
+| lock_statement
+| unlock_statement
 
 select_statement:
   SELECT comment_opt distinct_opt straight_join_opt select_expression_list FROM table_references where_expression_opt group_by_opt having_opt order_by_opt limit_opt lock_opt
@@ -311,6 +322,72 @@ other_statement:
     $$ = &Other{}
   }
 
+lock_statement:
+  LOCK sql_id lock_table_list
+  {
+    if $2 != "tables" {
+      yylex.Error("expecting tables")
+      return 1
+    }
+    $$ = &LockTables{Tables: $3}
+  }
+| LOCK TABLE lock_table_list
+  {
+    $$ = &LockTables{Tables: $3}
+  }
+
+lock_table_list:
+  lock_table
+  {
+    $$ = LockTableList{$1}
+  }
+| lock_table_list ',' lock_table
+  {
+    $$ = append($1, $3)
+  }
+
+lock_table:
+  table_name as_opt_id lock_type
+  {
+    $$ = &LockTable{Table: $1, As: $2, Lock: $3}
+  }
+
+lock_type:
+  READ
+  {
+    $$ = ReadStr
+  }
+| READ sql_id
+  {
+    if $2 != "local" {
+      yylex.Error("expecting local")
+      return 1
+    }
+    $$ = ReadLocalStr
+  }
+| WRITE
+  {
+    $$ = WriteStr
+  }
+| LOW_PRIORITY WRITE
+  {
+    $$ = LowPriorityWriteStr
+  }
+
+unlock_statement:
+  UNLOCK sql_id
+  {
+    if $2 != "tables" {
+      yylex.Error("expecting tables")
+      return 1
+    }
+    $$ = &UnlockTables{}
+  }
+| UNLOCK TABLE
+  {
+    $$ = &UnlockTables{}
+  }
+
 comment_opt:
   {
     setAllowComments(yylex, true)
@@ -369,7 +446,7 @@ select_expression_list:
   }
 | select_expression_list ',' select_expression
   {
-    $$ = append($$, $3)
+    $$ = append($1, $3)
   }
 
 select_expression:
@@ -416,7 +493,7 @@ table_references:
   }
 | table_references ',' table_reference
   {
-    $$ = append($$, $3)
+    $$ = append($1, $3)
   }
 
 table_reference:
@@ -995,6 +1072,14 @@ lock_opt:
   {
     $$ = ForUpdateStr
   }
+| FOR sql_id
+  {
+    if $2 != "share" {
+      yylex.Error("expecting share")
+      return 1
+    }
+    $$ = ForShareStr
+  }
 | LOCK IN sql_id sql_id
   {
     if $3 != "share" {
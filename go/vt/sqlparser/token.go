@@ -162,7 +162,7 @@ var keywords = map[string]int{
 	"longblob":            UNUSED,
 	"longtext":            UNUSED,
 	"loop":                UNUSED,
-	"low_priority":        UNUSED,
+	"low_priority":        LOW_PRIORITY,
 	"master_bind":         UNUSED,
 	"match":               UNUSED,
 	"maxvalue":            UNUSED,
@@ -195,7 +195,7 @@ var keywords = map[string]int{
 	"primary":             UNUSED,
 	"procedure":           UNUSED,
 	"range":               UNUSED,
-	"read":                UNUSED,
+	"read":                READ,
 	"reads":               UNUSED,
 	"read_write":          UNUSED,
 	"real":                UNUSED,
@@ -248,7 +248,7 @@ var keywords = map[string]int{
 	"undo":                UNUSED,
 	"union":               UNION,
 	"unique":              UNIQUE,
-	"unlock":              UNUSED,
+	"unlock":              UNLOCK,
 	"unsigned":            UNUSED,
 	"update":              UPDATE,
 	"usage":               UNUSED,
@@ -268,7 +268,7 @@ var keywords = map[string]int{
 	"where":               WHERE,
 	"while":               UNUSED,
 	"with":                UNUSED,
-	"write":               UNUSED,
+	"write":               WRITE,
 	"xor":                 UNUSED,
 	"year_month":          UNUSED,
 	"zerofill":            UNUSED,
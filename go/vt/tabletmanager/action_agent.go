@@ -76,6 +76,7 @@ type ActionAgent struct {
 	DBConfigs           dbconfigs.DBConfigs
 	SchemaOverrides     []tabletserver.SchemaOverride
 	BinlogPlayerMap     *BinlogPlayerMap
+	QueryWarmer         *QueryWarmer
 
 	// exportStats is set only for production tablet.
 	exportStats bool
@@ -211,6 +212,10 @@ func NewActionAgent(
 	servenv.OnTerm(agent.BinlogPlayerMap.StopAllPlayersAndReset)
 	RegisterBinlogPlayerMap(agent.BinlogPlayerMap)
 
+	// The query warmer is started when the tablet is serving, or by
+	// the WarmQueries RPC.
+	agent.QueryWarmer = NewQueryWarmer(topoServer, queryServiceControl, *warmQueriesRate)
+
 	// try to figure out the mysql port
 	mysqlPort := mycnf.MysqlPort
 	if mysqlPort == 0 {
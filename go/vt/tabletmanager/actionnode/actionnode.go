@@ -163,6 +163,9 @@ const (
 	// TabletActionBackup takes a db backup and stores it into BackupStorage
 	TabletActionBackup = "Backup"
 
+	// TabletActionWarmQueries starts the query warmer of a replica.
+	TabletActionWarmQueries = "WarmQueries"
+
 	//
 	// Shard actions - involve all tablets in a shard.
 	// These are just descriptive and used for locking / logging.
@@ -213,6 +216,9 @@ const (
 	// KeyspaceActionSetServedFrom updates ServedFrom
 	KeyspaceActionSetServedFrom = "SetKeyspaceServedFrom"
 
+	// KeyspaceActionSetFallbackCells updates FallbackCells
+	KeyspaceActionSetFallbackCells = "SetKeyspaceFallbackCells"
+
 	// KeyspaceActionCreateShard protects shard creation within the keyspace
 	KeyspaceActionCreateShard = "KeyspaceCreateShard"
 
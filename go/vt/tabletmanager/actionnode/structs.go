@@ -141,6 +141,13 @@ func SetKeyspaceServedFrom() *ActionNode {
 	}).SetGuid()
 }
 
+// SetKeyspaceFallbackCells returns an ActionNode
+func SetKeyspaceFallbackCells() *ActionNode {
+	return (&ActionNode{
+		Action: KeyspaceActionSetFallbackCells,
+	}).SetGuid()
+}
+
 // ApplySchemaKeyspace returns an ActionNode
 func ApplySchemaKeyspace(change string) *ActionNode {
 	return (&ActionNode{
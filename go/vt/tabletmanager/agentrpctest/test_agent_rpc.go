@@ -1143,6 +1143,30 @@ func agentRPCTestBackupPanic(ctx context.Context, t *testing.T, client tmclient.
 	expectRPCWrapLockActionPanic(t, err)
 }
 
+//
+// Query warming
+//
+
+var testWarmQueriesCalled = false
+
+func (fra *fakeRPCAgent) WarmQueries(ctx context.Context) error {
+	if fra.panics {
+		panic(fmt.Errorf("test-triggered panic"))
+	}
+	testWarmQueriesCalled = true
+	return nil
+}
+
+func agentRPCTestWarmQueries(ctx context.Context, t *testing.T, client tmclient.TabletManagerClient, ti *topo.TabletInfo) {
+	err := client.WarmQueries(ctx, ti)
+	compareError(t, "WarmQueries", err, true, testWarmQueriesCalled)
+}
+
+func agentRPCTestWarmQueriesPanic(ctx context.Context, t *testing.T, client tmclient.TabletManagerClient, ti *topo.TabletInfo) {
+	err := client.WarmQueries(ctx, ti)
+	expectRPCWrapPanic(t, err)
+}
+
 //
 // RPC helpers
 //
@@ -1238,6 +1262,9 @@ func Run(t *testing.T, client tmclient.TabletManagerClient, ti *topo.TabletInfo,
 	// Backup / restore related methods
 	agentRPCTestBackup(ctx, t, client, ti)
 
+	// Query warming
+	agentRPCTestWarmQueries(ctx, t, client, ti)
+
 	//
 	// Tests panic handling everywhere now
 	//
@@ -1288,4 +1315,7 @@ func Run(t *testing.T, client tmclient.TabletManagerClient, ti *topo.TabletInfo,
 
 	// Backup / restore related methods
 	agentRPCTestBackupPanic(ctx, t, client, ti)
+
+	// Query warming
+	agentRPCTestWarmQueriesPanic(ctx, t, client, ti)
 }
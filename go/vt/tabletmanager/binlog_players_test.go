@@ -138,11 +138,51 @@ func (ftc *fakeTabletConn) ExecuteBatch(ctx context.Context, queries []querytype
 	return nil, fmt.Errorf("not implemented in this test")
 }
 
+// WaitForGTID is part of the TabletConn interface
+func (ftc *fakeTabletConn) WaitForGTID(ctx context.Context, gtid string) error {
+	return fmt.Errorf("not implemented in this test")
+}
+
+// XAStart is part of the TabletConn interface
+func (ftc *fakeTabletConn) XAStart(ctx context.Context, xid string) (int64, error) {
+	return 0, fmt.Errorf("not implemented in this test")
+}
+
+// XAPrepare is part of the TabletConn interface
+func (ftc *fakeTabletConn) XAPrepare(ctx context.Context, xid string) error {
+	return fmt.Errorf("not implemented in this test")
+}
+
+// XACommit is part of the TabletConn interface
+func (ftc *fakeTabletConn) XACommit(ctx context.Context, xid string) error {
+	return fmt.Errorf("not implemented in this test")
+}
+
+// XARollback is part of the TabletConn interface
+func (ftc *fakeTabletConn) XARollback(ctx context.Context, xid string) error {
+	return fmt.Errorf("not implemented in this test")
+}
+
+// XARecover is part of the TabletConn interface
+func (ftc *fakeTabletConn) XARecover(ctx context.Context) ([]string, error) {
+	return nil, fmt.Errorf("not implemented in this test")
+}
+
+// HotQueries is part of the TabletConn interface
+func (ftc *fakeTabletConn) HotQueries(ctx context.Context) ([]querytypes.BoundQuery, error) {
+	return nil, fmt.Errorf("not implemented in this test")
+}
+
 // StreamExecute is part of the TabletConn interface
 func (ftc *fakeTabletConn) StreamExecute(ctx context.Context, query string, bindVars map[string]interface{}) (sqltypes.ResultStream, error) {
 	return nil, fmt.Errorf("not implemented in this test")
 }
 
+// StreamExecuteResumable is part of the TabletConn interface
+func (ftc *fakeTabletConn) StreamExecuteResumable(ctx context.Context, query string, bindVars map[string]interface{}, resumeToken []byte) (tabletconn.ResumableResultStream, error) {
+	return nil, fmt.Errorf("not implemented in this test")
+}
+
 // Begin is part of the TabletConn interface
 func (ftc *fakeTabletConn) Begin(ctx context.Context) (transactionID int64, err error) {
 	return 0, fmt.Errorf("not implemented in this test")
@@ -0,0 +1,81 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tabletmanager
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"sync"
+)
+
+// hostCPU samples the CPU usage of the host for the health broadcasts,
+// which vtgate uses to balance the queries.
+var hostCPU = &cpuSampler{readStat: readProcStat}
+
+// cpuSampler computes the CPU usage of the host between two of its
+// samples.
+type cpuSampler struct {
+	readStat func() (busy, total uint64, err error)
+
+	mu    sync.Mutex
+	busy  uint64
+	total uint64
+	usage float64
+}
+
+// sample returns the fraction of the CPU time of the host that was
+// busy since the previous sample, or the previous usage if no time
+// passed. It returns 0 if the CPU times can't be read.
+func (cs *cpuSampler) sample() float64 {
+	busy, total, err := cs.readStat()
+	if err != nil {
+		return 0
+	}
+	cs.mu.Lock()
+	defer cs.mu.Unlock()
+	if cs.total != 0 && total > cs.total && busy >= cs.busy {
+		cs.usage = float64(busy-cs.busy) / float64(total-cs.total)
+	}
+	cs.busy, cs.total = busy, total
+	return cs.usage
+}
+
+// readProcStat returns the busy and total CPU times of the host from
+// /proc/stat, in clock ticks.
+func readProcStat() (busy, total uint64, err error) {
+	data, err := ioutil.ReadFile("/proc/stat")
+	if err != nil {
+		return 0, 0, err
+	}
+	return parseProcStat(data)
+}
+
+// parseProcStat parses the aggregate cpu line of /proc/stat: the user,
+// nice, system, idle, iowait, irq, softirq and steal times. The idle
+// and iowait times are not busy.
+func parseProcStat(data []byte) (busy, total uint64, err error) {
+	line := data
+	if i := bytes.IndexByte(data, '\n'); i != -1 {
+		line = data[:i]
+	}
+	fields := bytes.Fields(line)
+	if len(fields) < 9 || string(fields[0]) != "cpu" {
+		return 0, 0, fmt.Errorf("unexpected /proc/stat line: %q", line)
+	}
+	var idle uint64
+	for i, f := range fields[1:9] {
+		v, err := strconv.ParseUint(string(f), 10, 64)
+		if err != nil {
+			return 0, 0, fmt.Errorf("unexpected /proc/stat line: %q: %v", line, err)
+		}
+		total += v
+		if i == 3 || i == 4 {
+			idle += v
+		}
+	}
+	return total - idle, total, nil
+}
@@ -0,0 +1,49 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tabletmanager
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseProcStat(t *testing.T) {
+	data := []byte("cpu  100 10 50 800 40 5 5 0 0 0\ncpu0 50 5 25 400 20 2 3 0 0 0\n")
+	busy, total, err := parseProcStat(data)
+	if err != nil {
+		t.Fatalf("parseProcStat: %v", err)
+	}
+	if busy != 170 || total != 1010 {
+		t.Errorf("parseProcStat: %v, %v, want 170, 1010", busy, total)
+	}
+
+	if _, _, err := parseProcStat([]byte("intr 1 2 3\n")); err == nil {
+		t.Errorf("parseProcStat of an unexpected line: nil error")
+	}
+}
+
+func TestCPUSampler(t *testing.T) {
+	var busy, total uint64
+	var readErr error
+	cs := &cpuSampler{readStat: func() (uint64, uint64, error) {
+		return busy, total, readErr
+	}}
+	busy, total = 100, 1000
+	if got := cs.sample(); got != 0 {
+		t.Errorf("first sample: %v, want 0", got)
+	}
+	busy, total = 150, 1100
+	if got := cs.sample(); got != 0.5 {
+		t.Errorf("sample: %v, want 0.5", got)
+	}
+	// No time passed: the previous usage.
+	if got := cs.sample(); got != 0.5 {
+		t.Errorf("sample without new ticks: %v, want 0.5", got)
+	}
+	readErr = errors.New("no /proc/stat")
+	if got := cs.sample(); got != 0 {
+		t.Errorf("sample after a read error: %v, want 0", got)
+	}
+}
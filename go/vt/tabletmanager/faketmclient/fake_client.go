@@ -284,6 +284,15 @@ func (client *FakeTabletManagerClient) Backup(ctx context.Context, tablet *topo.
 	return &eofEventStream{}, nil
 }
 
+//
+// Query warming
+//
+
+// WarmQueries is part of the tmclient.TabletManagerClient interface.
+func (client *FakeTabletManagerClient) WarmQueries(ctx context.Context, tablet *topo.TabletInfo) error {
+	return nil
+}
+
 //
 // RPC related methods
 //
@@ -682,6 +682,21 @@ func (client *Client) Backup(ctx context.Context, tablet *topo.TabletInfo, concu
 	}, nil
 }
 
+//
+// Query warming
+//
+
+// WarmQueries is part of the tmclient.TabletManagerClient interface.
+func (client *Client) WarmQueries(ctx context.Context, tablet *topo.TabletInfo) error {
+	cc, c, err := client.dial(ctx, tablet)
+	if err != nil {
+		return err
+	}
+	defer cc.Close()
+	_, err = c.WarmQueries(ctx, &tabletmanagerdatapb.WarmQueriesRequest{})
+	return err
+}
+
 //
 // RPC related methods
 //
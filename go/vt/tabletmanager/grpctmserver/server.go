@@ -461,6 +461,14 @@ func (s *server) Backup(request *tabletmanagerdatapb.BackupRequest, stream table
 	})
 }
 
+func (s *server) WarmQueries(ctx context.Context, request *tabletmanagerdatapb.WarmQueriesRequest) (*tabletmanagerdatapb.WarmQueriesResponse, error) {
+	ctx = callinfo.GRPCCallInfo(ctx)
+	response := &tabletmanagerdatapb.WarmQueriesResponse{}
+	return response, s.agent.RPCWrap(ctx, actionnode.TabletActionWarmQueries, request, response, func() error {
+		return s.agent.WarmQueries(ctx)
+	})
+}
+
 // registration glue
 
 func init() {
@@ -237,7 +237,7 @@ func (agent *ActionAgent) runHealthCheck(targetTabletType topodatapb.TabletType)
 			//
 			// We don't care if the QueryService state actually changed because we'll
 			// broadcast the latest health status after this immediately anway.
-			_ /* state changed */, healthErr = agent.allowQueries(desiredType)
+			_ /* state changed */, healthErr = agent.allowQueries(desiredType, "health-check success")
 		}
 	} else {
 		if isServing {
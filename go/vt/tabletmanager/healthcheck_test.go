@@ -364,7 +364,7 @@ func TestQueryServiceStopped(t *testing.T) {
 	// shut down query service and prevent it from starting again
 	// (this is to simulate mysql going away, tablet server detecting it
 	// and shutting itself down)
-	agent.QueryServiceControl.SetServingType(topodatapb.TabletType_REPLICA, false, nil)
+	agent.QueryServiceControl.SetServingType(topodatapb.TabletType_REPLICA, false, nil, "test")
 	agent.QueryServiceControl.(*tabletservermock.Controller).SetServingTypeError = fmt.Errorf("test cannot start query service")
 
 	// health check should now fail
@@ -632,6 +632,68 @@ func TestTabletControl(t *testing.T) {
 	}
 }
 
+// TestTabletControlEnforceKeyRange verifies that a tablet stops its query
+// service if its tablet control asks to enforce a key range it can't
+// enforce, instead of serving without the filter.
+func TestTabletControlEnforceKeyRange(t *testing.T) {
+	ctx := context.Background()
+	agent, _ := createTestAgent(ctx, t)
+	targetTabletType := topodatapb.TabletType_REPLICA
+	if _, err := expectBroadcastData(agent.QueryServiceControl, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := expectStateChange(agent.QueryServiceControl, false, topodatapb.TabletType_SPARE); err != nil {
+		t.Fatal(err)
+	}
+	agent.runHealthCheck(targetTabletType)
+	if !agent.QueryServiceControl.IsServing() {
+		t.Errorf("Query service should be running")
+	}
+	if _, err := expectBroadcastData(agent.QueryServiceControl, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := expectStateChange(agent.QueryServiceControl, true, targetTabletType); err != nil {
+		t.Fatal(err)
+	}
+
+	// test_keyspace has no sharding column.
+	if _, err := agent.TopoServer.UpdateTabletFields(ctx, tabletAlias, func(tablet *topodatapb.Tablet) error {
+		tablet.KeyRange = &topodatapb.KeyRange{End: []byte{0x80}}
+		return nil
+	}); err != nil {
+		t.Fatalf("UpdateTabletFields failed: %v", err)
+	}
+	si, err := agent.TopoServer.GetShard(ctx, "test_keyspace", "0")
+	if err != nil {
+		t.Fatalf("GetShard failed: %v", err)
+	}
+	si.TabletControls = []*topodatapb.Shard_TabletControl{
+		{
+			TabletType:      targetTabletType,
+			EnforceKeyRange: true,
+		},
+	}
+	if err := agent.TopoServer.UpdateShard(ctx, si); err != nil {
+		t.Fatalf("UpdateShard failed: %v", err)
+	}
+	agent.RPCWrapLockAction(ctx, actionnode.TabletActionRefreshState, "", "", true, func() error {
+		agent.RefreshState(ctx)
+		return nil
+	})
+	if agent.QueryServiceControl.IsServing() {
+		t.Errorf("Query service should not be running")
+	}
+	if got := agent.QueryServiceControl.(*tabletservermock.Controller).KeyRangeFilter; got != nil {
+		t.Errorf("KeyRangeFilter: %v, want nil", got)
+	}
+
+	// The healthcheck doesn't start it again.
+	agent.runHealthCheck(targetTabletType)
+	if agent.QueryServiceControl.IsServing() {
+		t.Errorf("Query service should not be running after a health check")
+	}
+}
+
 // TestQueryServiceChangeImmediateHealthcheckResponse verifies that a change
 // of the QueryService state or the tablet type will result into a broadcast
 // of a StreamHealthResponse message.
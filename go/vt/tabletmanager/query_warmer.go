@@ -0,0 +1,254 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tabletmanager
+
+// This file handles the query warmer of the replicas: it executes the
+// hot queries of the master against the local MySQL, to warm its
+// buffer pool after a restart.
+
+import (
+	"flag"
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/golang/glog"
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/vt/callerid"
+	"github.com/youtube/vitess/go/vt/tabletserver"
+	"github.com/youtube/vitess/go/vt/tabletserver/querytypes"
+	"github.com/youtube/vitess/go/vt/tabletserver/tabletconn"
+	"github.com/youtube/vitess/go/vt/topo"
+	"github.com/youtube/vitess/go/vt/topo/topoproto"
+
+	querypb "github.com/youtube/vitess/go/vt/proto/query"
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+)
+
+var (
+	warmQueriesOnStartup = flag.Bool("warm_queries_on_startup", false, "when a replica or rdonly tablet starts serving, execute the hot queries of the master of its shard against its MySQL, to warm its buffer pool. The WarmQueries vtctl command starts it on demand.")
+	warmQueriesRate      = flag.Int("warm_queries_rate", 20, "maximum number of hot queries per second executed by the query warmer, 0 for no limit")
+	warmQueriesCallerID  = flag.String("warm_queries_caller_id", "", "if set, the username of the caller ID of the query warmer, for its HotQueries call to the master and the queries it executes. With -queryserver-config-strict-table-acl, it must be allowed to read the tables.")
+	warmQueriesTimeout   = flag.Duration("warm_queries_timeout", 30*time.Second, "timeout of the query warmer to fetch the hot queries of the master")
+)
+
+// The states of the query warmer.
+const (
+	queryWarmerIdle    = "Idle"
+	queryWarmerRunning = "Running"
+	queryWarmerDone    = "Done"
+	queryWarmerFailed  = "Failed"
+)
+
+// QueryWarmerStatus is the status of the query warmer, for the status page.
+type QueryWarmerStatus struct {
+	State string
+	// Master is the alias of the tablet the hot queries were fetched from.
+	Master string
+	// Fetched is the number of hot queries returned by the master.
+	// Warmed and Failed are the numbers of them executed so far.
+	Fetched   int
+	Warmed    int
+	Failed    int
+	LastError string
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+// QueryWarmer fetches the hot queries of the master of the shard, and
+// executes them at a throttled rate against the local tabletserver.
+type QueryWarmer struct {
+	ts   topo.Server
+	qsc  tabletserver.Controller
+	rate int
+	// dial is tabletconn.GetDialer(), except in the tests.
+	dial tabletconn.TabletDialer
+
+	// mu protects the following fields.
+	mu sync.Mutex
+	// startedOnStartup is set once the -warm_queries_on_startup run
+	// was started, so that it only happens once.
+	startedOnStartup bool
+	status           QueryWarmerStatus
+}
+
+// NewQueryWarmer creates a QueryWarmer that executes at most rate
+// queries per second, 0 for no limit.
+func NewQueryWarmer(ts topo.Server, qsc tabletserver.Controller, rate int) *QueryWarmer {
+	return &QueryWarmer{
+		ts:     ts,
+		qsc:    qsc,
+		rate:   rate,
+		status: QueryWarmerStatus{State: queryWarmerIdle},
+	}
+}
+
+// Start starts warming the tablet in the background, until all the
+// hot queries are executed or ctx is done.
+func (qw *QueryWarmer) Start(ctx context.Context, tablet *topodatapb.Tablet) error {
+	qw.mu.Lock()
+	defer qw.mu.Unlock()
+	return qw.startLocked(ctx, tablet)
+}
+
+// StartOnStartup starts warming the tablet if -warm_queries_on_startup
+// is set, the tablet is a replica or rdonly, and it wasn't done before.
+// The errors are only logged.
+func (qw *QueryWarmer) StartOnStartup(ctx context.Context, tablet *topodatapb.Tablet) {
+	if !*warmQueriesOnStartup {
+		return
+	}
+	if tablet.Type != topodatapb.TabletType_REPLICA && tablet.Type != topodatapb.TabletType_RDONLY {
+		return
+	}
+	qw.mu.Lock()
+	defer qw.mu.Unlock()
+	if qw.startedOnStartup {
+		return
+	}
+	qw.startedOnStartup = true
+	if err := qw.startLocked(ctx, tablet); err != nil {
+		log.Warningf("Cannot start the query warmer: %v", err)
+	}
+}
+
+// startLocked starts the warming. qw.mu must be held.
+func (qw *QueryWarmer) startLocked(ctx context.Context, tablet *topodatapb.Tablet) error {
+	if tablet.Type != topodatapb.TabletType_REPLICA && tablet.Type != topodatapb.TabletType_RDONLY {
+		return fmt.Errorf("the query warmer only runs on the replica and rdonly tablets, not on a %v tablet", tablet.Type)
+	}
+	if qw.status.State == queryWarmerRunning {
+		return fmt.Errorf("the query warmer is already running")
+	}
+	qw.status = QueryWarmerStatus{
+		State:     queryWarmerRunning,
+		StartTime: time.Now(),
+	}
+	go qw.run(ctx, tablet.Keyspace, tablet.Shard, tablet.Type)
+	return nil
+}
+
+// Status returns the current status of the query warmer.
+func (qw *QueryWarmer) Status() QueryWarmerStatus {
+	qw.mu.Lock()
+	defer qw.mu.Unlock()
+	return qw.status
+}
+
+func (qw *QueryWarmer) run(ctx context.Context, keyspace, shard string, tabletType topodatapb.TabletType) {
+	err := qw.warm(ctx, keyspace, shard, tabletType)
+
+	qw.mu.Lock()
+	defer qw.mu.Unlock()
+	qw.status.EndTime = time.Now()
+	if err != nil {
+		qw.status.State = queryWarmerFailed
+		qw.status.LastError = err.Error()
+		log.Warningf("Query warmer failed: %v", err)
+		return
+	}
+	qw.status.State = queryWarmerDone
+	log.Infof("Query warmer executed %v hot queries from %v, %v of them failed", qw.status.Warmed+qw.status.Failed, qw.status.Master, qw.status.Failed)
+}
+
+func (qw *QueryWarmer) warm(ctx context.Context, keyspace, shard string, tabletType topodatapb.TabletType) error {
+	if *warmQueriesCallerID != "" {
+		ctx = callerid.NewContext(ctx,
+			callerid.NewEffectiveCallerID(*warmQueriesCallerID, "", "QueryWarmer"),
+			callerid.NewImmediateCallerID(*warmQueriesCallerID))
+	}
+	queries, err := qw.fetch(ctx, keyspace, shard)
+	if err != nil {
+		return err
+	}
+	qw.mu.Lock()
+	qw.status.Fetched = len(queries)
+	qw.mu.Unlock()
+
+	var tick <-chan time.Time
+	if qw.rate > 0 {
+		ticker := time.NewTicker(time.Second / time.Duration(qw.rate))
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+	target := &querypb.Target{
+		Keyspace:   keyspace,
+		Shard:      shard,
+		TabletType: tabletType,
+	}
+	for _, q := range queries {
+		if tick != nil {
+			select {
+			case <-tick:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		} else if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		_, err := qw.qsc.QueryService().Execute(ctx, target, q.Sql, q.BindVariables, 0, 0)
+
+		qw.mu.Lock()
+		if err != nil {
+			qw.status.Failed++
+			qw.status.LastError = err.Error()
+		} else {
+			qw.status.Warmed++
+		}
+		qw.mu.Unlock()
+	}
+	return nil
+}
+
+// fetch returns the hot queries of the master of the shard.
+func (qw *QueryWarmer) fetch(ctx context.Context, keyspace, shard string) ([]querytypes.BoundQuery, error) {
+	ctx, cancel := context.WithTimeout(ctx, *warmQueriesTimeout)
+	defer cancel()
+
+	si, err := qw.ts.GetShard(ctx, keyspace, shard)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the shard %v/%v: %v", keyspace, shard, err)
+	}
+	if !si.HasMaster() {
+		return nil, fmt.Errorf("the shard %v/%v has no master", keyspace, shard)
+	}
+	qw.mu.Lock()
+	qw.status.Master = topoproto.TabletAliasString(si.MasterAlias)
+	qw.mu.Unlock()
+
+	ti, err := qw.ts.GetTablet(ctx, si.MasterAlias)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the master tablet %v: %v", topoproto.TabletAliasString(si.MasterAlias), err)
+	}
+	endPoint, err := topo.TabletEndPoint(ti.Tablet)
+	if err != nil {
+		return nil, err
+	}
+	dial := qw.dial
+	if dial == nil {
+		dial = tabletconn.GetDialer()
+	}
+	conn, err := dial(ctx, endPoint, keyspace, shard, topodatapb.TabletType_MASTER, *warmQueriesTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("cannot connect to the master tablet %v: %v", topoproto.TabletAliasString(si.MasterAlias), err)
+	}
+	defer conn.Close()
+	queries, err := conn.HotQueries(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("HotQueries on the master tablet %v failed: %v", topoproto.TabletAliasString(si.MasterAlias), err)
+	}
+	return queries, nil
+}
+
+// WarmQueries starts the query warmer of the tablet.
+// Should be called under RPCWrap.
+func (agent *ActionAgent) WarmQueries(ctx context.Context) error {
+	if agent.QueryWarmer == nil {
+		return fmt.Errorf("the query warmer is not enabled on this tablet")
+	}
+	// Warming outlives the RPC.
+	return agent.QueryWarmer.Start(agent.batchCtx, agent.Tablet())
+}
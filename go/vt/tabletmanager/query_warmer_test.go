@@ -0,0 +1,130 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tabletmanager
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/sqltypes"
+	"github.com/youtube/vitess/go/vt/tabletserver/queryservice"
+	"github.com/youtube/vitess/go/vt/tabletserver/querytypes"
+	"github.com/youtube/vitess/go/vt/tabletserver/tabletconn"
+	"github.com/youtube/vitess/go/vt/tabletserver/tabletservermock"
+	"github.com/youtube/vitess/go/vt/topo"
+	"github.com/youtube/vitess/go/vt/topo/test/faketopo"
+
+	querypb "github.com/youtube/vitess/go/vt/proto/query"
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+)
+
+var warmerMasterAlias = &topodatapb.TabletAlias{Cell: "cell1", Uid: 1}
+
+// warmerTopo has a shard ks/0 with a master.
+type warmerTopo struct {
+	faketopo.FakeTopo
+}
+
+func (wt warmerTopo) GetShard(ctx context.Context, keyspace, shard string) (*topodatapb.Shard, int64, error) {
+	return &topodatapb.Shard{MasterAlias: warmerMasterAlias}, 1, nil
+}
+
+func (wt warmerTopo) GetTablet(ctx context.Context, alias *topodatapb.TabletAlias) (*topodatapb.Tablet, int64, error) {
+	return &topodatapb.Tablet{
+		Alias:    alias,
+		Hostname: "master",
+		PortMap:  map[string]int32{"vt": 100},
+		Keyspace: "ks",
+		Shard:    "0",
+		Type:     topodatapb.TabletType_MASTER,
+	}, 1, nil
+}
+
+// warmerConn returns the hot queries of the master.
+type warmerConn struct {
+	tabletconn.TabletConn
+	queries []querytypes.BoundQuery
+}
+
+func (wc *warmerConn) HotQueries(ctx context.Context) ([]querytypes.BoundQuery, error) {
+	return wc.queries, nil
+}
+
+func (wc *warmerConn) Close() {}
+
+// warmerQueryService records the executed queries, and fails the
+// ones on the table "fail".
+type warmerQueryService struct {
+	queryservice.ErrorQueryService
+	mu       sync.Mutex
+	executed []string
+}
+
+func (wqs *warmerQueryService) Execute(ctx context.Context, target *querypb.Target, sql string, bindVariables map[string]interface{}, sessionID, transactionID int64) (*sqltypes.Result, error) {
+	if target.TabletType != topodatapb.TabletType_REPLICA {
+		return nil, fmt.Errorf("wrong target: %v", target)
+	}
+	wqs.mu.Lock()
+	defer wqs.mu.Unlock()
+	wqs.executed = append(wqs.executed, sql)
+	if sql == "select * from fail" {
+		return nil, fmt.Errorf("failed")
+	}
+	return &sqltypes.Result{}, nil
+}
+
+type warmerController struct {
+	*tabletservermock.Controller
+	qs *warmerQueryService
+}
+
+func (wc *warmerController) QueryService() queryservice.QueryService {
+	return wc.qs
+}
+
+func TestQueryWarmer(t *testing.T) {
+	qs := &warmerQueryService{}
+	qw := NewQueryWarmer(topo.Server{Impl: warmerTopo{}}, &warmerController{tabletservermock.NewController(), qs}, 0)
+	qw.dial = func(ctx context.Context, endPoint *topodatapb.EndPoint, keyspace, shard string, tabletType topodatapb.TabletType, timeout time.Duration) (tabletconn.TabletConn, error) {
+		if endPoint.Host != "master" || tabletType != topodatapb.TabletType_MASTER {
+			return nil, fmt.Errorf("wrong tablet: %v %v", endPoint, tabletType)
+		}
+		return &warmerConn{queries: []querytypes.BoundQuery{
+			{Sql: "select * from a"},
+			{Sql: "select * from fail"},
+			{Sql: "select * from b"},
+		}}, nil
+	}
+
+	master := &topodatapb.Tablet{Keyspace: "ks", Shard: "0", Type: topodatapb.TabletType_MASTER}
+	if err := qw.Start(context.Background(), master); err == nil {
+		t.Errorf("Start on a master: nil, want an error")
+	}
+
+	replica := &topodatapb.Tablet{Keyspace: "ks", Shard: "0", Type: topodatapb.TabletType_REPLICA}
+	if err := qw.Start(context.Background(), replica); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	var status QueryWarmerStatus
+	for i := 0; i < 100; i++ {
+		status = qw.Status()
+		if status.State != queryWarmerRunning {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if status.State != queryWarmerDone || status.Master != "cell1-0000000001" || status.Fetched != 3 || status.Warmed != 2 || status.Failed != 1 || status.LastError != "failed" {
+		t.Errorf("Status: %+v, want Done with 2 warmed and 1 failed query of cell1-0000000001", status)
+	}
+	want := []string{"select * from a", "select * from fail", "select * from b"}
+	if !reflect.DeepEqual(qs.executed, want) {
+		t.Errorf("executed: %v, want %v", qs.executed, want)
+	}
+}
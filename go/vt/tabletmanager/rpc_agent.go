@@ -110,6 +110,10 @@ type RPCAgent interface {
 
 	Backup(ctx context.Context, concurrency int, logger logutil.Logger) error
 
+	// Query warming
+
+	WarmQueries(ctx context.Context) error
+
 	// RPC helpers
 	RPCWrap(ctx context.Context, name string, args, reply interface{}, f func() error) error
 	RPCWrapLock(ctx context.Context, name string, args, reply interface{}, verbose bool, f func() error) error
@@ -15,11 +15,14 @@ import (
 	"golang.org/x/net/context"
 
 	log "github.com/golang/glog"
+	"github.com/golang/protobuf/proto"
 	"github.com/youtube/vitess/go/event"
 	"github.com/youtube/vitess/go/trace"
+	"github.com/youtube/vitess/go/vt/key"
 	"github.com/youtube/vitess/go/vt/mysqlctl"
 	"github.com/youtube/vitess/go/vt/tabletmanager/events"
 	"github.com/youtube/vitess/go/vt/tabletserver"
+	"github.com/youtube/vitess/go/vt/tabletserver/planbuilder"
 	"github.com/youtube/vitess/go/vt/topo"
 	"github.com/youtube/vitess/go/vt/topo/topoproto"
 
@@ -71,10 +74,34 @@ func (agent *ActionAgent) loadBlacklistRules(tablet *topodatapb.Tablet, blacklis
 	return nil
 }
 
+// loadKeyRangeFilter restricts the queries of QueryService to the key
+// range of the tablet if its tablet control has EnforceKeyRange set,
+// and lifts the restriction otherwise. It returns an error, and leaves
+// the filter unchanged, if the key range cannot be enforced.
+func (agent *ActionAgent) loadKeyRangeFilter(ctx context.Context, tablet *topodatapb.Tablet, tabletControl *topodatapb.Shard_TabletControl) error {
+	if tabletControl == nil || !tabletControl.EnforceKeyRange || !key.KeyRangeIsPartial(tablet.KeyRange) {
+		agent.QueryServiceControl.SetKeyRangeFilter(nil)
+		return nil
+	}
+	ki, err := agent.TopoServer.GetKeyspace(ctx, tablet.Keyspace)
+	if err != nil {
+		return err
+	}
+	if ki.ShardingColumnName == "" {
+		return fmt.Errorf("keyspace %v has no sharding column, cannot enforce the key range %v", tablet.Keyspace, key.KeyRangeString(tablet.KeyRange))
+	}
+	agent.QueryServiceControl.SetKeyRangeFilter(&planbuilder.KeyRangeFilter{
+		ShardingColumnName: ki.ShardingColumnName,
+		ShardingColumnType: ki.ShardingColumnType,
+		KeyRange:           tablet.KeyRange,
+	})
+	return nil
+}
+
 // allowQueries tells QueryService to go in the serving state.
 // Returns true if the state of QueryService or the tablet type changed.
-func (agent *ActionAgent) allowQueries(tabletType topodatapb.TabletType) (bool, error) {
-	return agent.QueryServiceControl.SetServingType(tabletType, true, nil)
+func (agent *ActionAgent) allowQueries(tabletType topodatapb.TabletType, reason string) (bool, error) {
+	return agent.QueryServiceControl.SetServingType(tabletType, true, nil, reason)
 }
 
 // disallowQueries tells QueryService to go in the *not* serving state.
@@ -82,7 +109,7 @@ func (agent *ActionAgent) allowQueries(tabletType topodatapb.TabletType) (bool,
 func (agent *ActionAgent) disallowQueries(tabletType topodatapb.TabletType, reason string) (bool, error) {
 	log.Infof("Agent is going to disallow queries, reason: %v", reason)
 
-	return agent.QueryServiceControl.SetServingType(tabletType, false, nil)
+	return agent.QueryServiceControl.SetServingType(tabletType, false, nil, reason)
 }
 
 func (agent *ActionAgent) enterLameduck(reason string) {
@@ -100,9 +127,9 @@ func (agent *ActionAgent) broadcastHealth() {
 	agent.mutex.Unlock()
 
 	// send it to our observers
-	// FIXME(alainjobart,liguo) add CpuUsage
 	stats := &querypb.RealtimeStats{
 		SecondsBehindMaster: uint32(replicationDelay.Seconds()),
+		CpuUsage:            hostCPU.sample(),
 	}
 	if agent.BinlogPlayerMap != nil {
 		stats.SecondsBehindMasterFilteredReplication, stats.BinlogPlayersCount = agent.BinlogPlayerMap.StatusSummary()
@@ -230,6 +257,16 @@ func (agent *ActionAgent) changeCallback(ctx context.Context, oldTablet, newTabl
 			// FIXME(alainjobart) how to handle this error?
 			log.Errorf("Cannot update blacklisted tables rule: %v", err)
 		}
+		if err := agent.loadKeyRangeFilter(ctx, newTablet, tabletControl); err != nil {
+			// Serving without the filter would let the misrouted
+			// queries through: stop the query service instead, and
+			// have the healthcheck keep it stopped.
+			log.Errorf("Cannot update the key range filter: %v", err)
+			allowQuery = false
+			disallowQueryReason = fmt.Sprintf("cannot enforce the key range: %v", err)
+			tabletControl = proto.Clone(tabletControl).(*topodatapb.Shard_TabletControl)
+			tabletControl.DisableQueryService = true
+		}
 	}
 
 	if allowQuery {
@@ -239,7 +276,7 @@ func (agent *ActionAgent) changeCallback(ctx context.Context, oldTablet, newTabl
 			// When promoting from replica to master, allow both master and replica
 			// queries to be served during gracePeriod.
 			if _, err := agent.QueryServiceControl.SetServingType(newTablet.Type,
-				true, []topodatapb.TabletType{oldTablet.Type}); err == nil {
+				true, []topodatapb.TabletType{oldTablet.Type}, "promoted to master, serving the replica queries during the grace period"); err == nil {
 				// If successful, broadcast to vtgate and then wait.
 				agent.broadcastHealth()
 				time.Sleep(*gracePeriod)
@@ -248,7 +285,7 @@ func (agent *ActionAgent) changeCallback(ctx context.Context, oldTablet, newTabl
 			}
 		}
 
-		if stateChanged, err := agent.allowQueries(newTablet.Type); err == nil {
+		if stateChanged, err := agent.allowQueries(newTablet.Type, fmt.Sprintf("serving tablet type(%v)", newTablet.Type)); err == nil {
 			// If the state changed, broadcast to vtgate.
 			// (e.g. this happens when the tablet was already master, but it just
 			// changed from NOT_SERVING to SERVING due to
@@ -256,6 +293,9 @@ func (agent *ActionAgent) changeCallback(ctx context.Context, oldTablet, newTabl
 			if stateChanged {
 				broadcastHealth = true
 			}
+			if agent.QueryWarmer != nil {
+				agent.QueryWarmer.StartOnStartup(agent.batchCtx, newTablet)
+			}
 		} else {
 			log.Errorf("Cannot start query service: %v", err)
 		}
@@ -185,6 +185,14 @@ type TabletManagerClient interface {
 	// Backup creates a database backup
 	Backup(ctx context.Context, tablet *topo.TabletInfo, concurrency int) (logutil.EventStream, error)
 
+	//
+	// Query warming
+	//
+
+	// WarmQueries starts the query warmer of a replica, which
+	// executes the hot queries of the master to warm its MySQL.
+	WarmQueries(ctx context.Context, tablet *topo.TabletInfo) error
+
 	//
 	// RPC related methods
 	//
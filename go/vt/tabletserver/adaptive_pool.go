@@ -0,0 +1,185 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tabletserver
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	log "github.com/golang/glog"
+	"github.com/youtube/vitess/go/sync2"
+	"github.com/youtube/vitess/go/timer"
+)
+
+// poolSizerConfig is the configuration of a poolSizer.
+type poolSizerConfig struct {
+	// MinSize is the capacity the pool starts with, and never shrinks
+	// below. The maximum is the capacity of the pool.
+	MinSize int
+	// Step is the number of connections added or removed at once.
+	Step int
+	// GrowWaitTime is the 95th percentile wait time above which
+	// the pool grows.
+	GrowWaitTime time.Duration
+	// ShrinkWaitTime is the 95th percentile wait time below which
+	// the pool shrinks, after ShrinkChecks consecutive checks.
+	ShrinkWaitTime time.Duration
+	ShrinkChecks   int
+	// CheckInterval is how often the wait times are checked.
+	CheckInterval time.Duration
+	// MaxSamples is the maximum number of wait times kept between
+	// two checks. The older ones are overwritten.
+	MaxSamples int
+}
+
+// poolSizer adapts the capacity of a ConnPool to the time the
+// queries wait for a connection. Every CheckInterval, it computes the
+// 95th percentile of the wait times of the Get calls since the previous
+// check. The pool grows by Step connections when it is above
+// GrowWaitTime, and shrinks by Step when it stays below ShrinkWaitTime
+// for ShrinkChecks checks, if the peak number of connections in use
+// stayed below the capacity minus Step: a pool that is just big
+// enough for a steady load has short wait times, but must not shrink.
+type poolSizer struct {
+	config poolSizerConfig
+	ticks  *timer.Timer
+
+	mu          sync.Mutex
+	samples     []time.Duration
+	next        int
+	peakInUse   int
+	belowChecks int
+
+	grows   sync2.AtomicInt64
+	shrinks sync2.AtomicInt64
+	p95     sync2.AtomicDuration
+}
+
+func newPoolSizer(config poolSizerConfig) *poolSizer {
+	if config.MinSize < 1 {
+		config.MinSize = 1
+	}
+	if config.Step < 1 {
+		config.Step = 1
+	}
+	if config.MaxSamples < 1 {
+		config.MaxSamples = 1
+	}
+	return &poolSizer{
+		config:  config,
+		ticks:   timer.NewTimer(config.CheckInterval),
+		samples: make([]time.Duration, 0, config.MaxSamples),
+	}
+}
+
+// record adds the wait time of a Get call, and the number of
+// connections in use after it.
+func (ps *poolSizer) record(wait time.Duration, inUse int) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	if inUse > ps.peakInUse {
+		ps.peakInUse = inUse
+	}
+	if len(ps.samples) < ps.config.MaxSamples {
+		ps.samples = append(ps.samples, wait)
+		return
+	}
+	ps.samples[ps.next] = wait
+	ps.next = (ps.next + 1) % ps.config.MaxSamples
+}
+
+// takeSamples returns the 95th percentile of the wait times recorded
+// since the last call, and the peak number of connections in use, and
+// forgets them. The percentile is 0 if there are no wait times.
+func (ps *poolSizer) takeSamples() (p95 time.Duration, peakInUse int) {
+	ps.mu.Lock()
+	samples := ps.samples
+	peakInUse = ps.peakInUse
+	ps.samples = make([]time.Duration, 0, ps.config.MaxSamples)
+	ps.next = 0
+	ps.peakInUse = 0
+	ps.mu.Unlock()
+
+	if len(samples) == 0 {
+		return 0, peakInUse
+	}
+	sort.Sort(durations(samples))
+	return samples[(len(samples)*95-1)/100], peakInUse
+}
+
+// newCapacity returns the capacity the pool should have, given its
+// current and maximum capacity and the samples since the last check.
+func (ps *poolSizer) newCapacity(capacity, maxCap int) int {
+	p95, peakInUse := ps.takeSamples()
+	ps.p95.Set(p95)
+	switch {
+	case p95 > ps.config.GrowWaitTime:
+		ps.belowChecks = 0
+		capacity += ps.config.Step
+		if capacity > maxCap {
+			capacity = maxCap
+		}
+	case p95 < ps.config.ShrinkWaitTime && peakInUse < capacity-ps.config.Step:
+		ps.belowChecks++
+		if ps.belowChecks < ps.config.ShrinkChecks {
+			break
+		}
+		ps.belowChecks = 0
+		capacity -= ps.config.Step
+		if capacity < ps.config.MinSize {
+			capacity = ps.config.MinSize
+		}
+	default:
+		ps.belowChecks = 0
+	}
+	return capacity
+}
+
+// check resizes the pool of cp if needed.
+func (ps *poolSizer) check(cp *ConnPool) {
+	p := cp.pool()
+	if p == nil {
+		return
+	}
+	capacity := int(p.Capacity())
+	newCapacity := ps.newCapacity(capacity, int(p.MaxCap()))
+	if newCapacity == capacity {
+		return
+	}
+	// This waits for the connections in use to be returned if the pool
+	// shrinks, so cp.mu must not be held.
+	if err := p.SetCapacity(newCapacity); err != nil {
+		log.Warningf("Cannot resize the connection pool from %v to %v: %v", capacity, newCapacity, err)
+		return
+	}
+	if newCapacity > capacity {
+		ps.grows.Add(1)
+	} else {
+		ps.shrinks.Add(1)
+	}
+	log.Infof("Resized the connection pool from %v to %v, the 95th percentile wait time was %v", capacity, newCapacity, ps.p95.Get())
+}
+
+// Grows returns the number of times the pool grew.
+func (ps *poolSizer) Grows() int64 {
+	return ps.grows.Get()
+}
+
+// Shrinks returns the number of times the pool shrank.
+func (ps *poolSizer) Shrinks() int64 {
+	return ps.shrinks.Get()
+}
+
+// WaitTimeP95 returns the 95th percentile wait time of the last check.
+func (ps *poolSizer) WaitTimeP95() time.Duration {
+	return ps.p95.Get()
+}
+
+type durations []time.Duration
+
+func (d durations) Len() int           { return len(d) }
+func (d durations) Less(i, j int) bool { return d[i] < d[j] }
+func (d durations) Swap(i, j int)      { d[i], d[j] = d[j], d[i] }
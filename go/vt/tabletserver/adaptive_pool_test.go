@@ -0,0 +1,196 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tabletserver
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/youtube/vitess/go/sqldb"
+	"github.com/youtube/vitess/go/vt/vttest/fakesqldb"
+	"golang.org/x/net/context"
+)
+
+func TestPoolSizerNewCapacity(t *testing.T) {
+	ps := newPoolSizer(poolSizerConfig{
+		MinSize:        2,
+		Step:           3,
+		GrowWaitTime:   10 * time.Millisecond,
+		ShrinkWaitTime: time.Millisecond,
+		ShrinkChecks:   2,
+		MaxSamples:     100,
+	})
+	record := func(wait time.Duration, n int) {
+		for i := 0; i < n; i++ {
+			ps.record(wait, 0)
+		}
+	}
+
+	// 5% of slow waits is not enough to grow.
+	record(0, 95)
+	record(time.Second, 5)
+	if got := ps.newCapacity(4, 8); got != 4 {
+		t.Errorf("newCapacity with 5%% of slow waits: %v, want 4", got)
+	}
+	if got, want := ps.WaitTimeP95(), time.Duration(0); got != want {
+		t.Errorf("WaitTimeP95: %v, want %v", got, want)
+	}
+
+	// The pool grows by step up to the maximum.
+	record(0, 90)
+	record(20*time.Millisecond, 10)
+	if got := ps.newCapacity(4, 8); got != 7 {
+		t.Errorf("newCapacity with 10%% of slow waits: %v, want 7", got)
+	}
+	if got, want := ps.WaitTimeP95(), 20*time.Millisecond; got != want {
+		t.Errorf("WaitTimeP95: %v, want %v", got, want)
+	}
+	record(20*time.Millisecond, 10)
+	if got := ps.newCapacity(7, 8); got != 8 {
+		t.Errorf("newCapacity at the maximum: %v, want 8", got)
+	}
+
+	// The pool shrinks after two checks below the lower threshold,
+	// one without any query.
+	record(0, 100)
+	if got := ps.newCapacity(8, 8); got != 8 {
+		t.Errorf("newCapacity after one low check: %v, want 8", got)
+	}
+	if got := ps.newCapacity(8, 8); got != 5 {
+		t.Errorf("newCapacity after two low checks: %v, want 5", got)
+	}
+
+	// A wait time between the thresholds resets the count.
+	record(5*time.Millisecond, 1)
+	ps.newCapacity(5, 8)
+	if got := ps.newCapacity(5, 8); got != 5 {
+		t.Errorf("newCapacity after a medium check: %v, want 5", got)
+	}
+	if got := ps.newCapacity(5, 8); got != 2 {
+		t.Errorf("newCapacity above the minimum: %v, want 2", got)
+	}
+	ps.newCapacity(2, 8)
+	if got := ps.newCapacity(2, 8); got != 2 {
+		t.Errorf("newCapacity at the minimum: %v, want 2", got)
+	}
+
+	// Only the last MaxSamples wait times are kept.
+	record(time.Second, 100)
+	record(0, 100)
+	if got := ps.newCapacity(2, 8); got != 2 {
+		t.Errorf("newCapacity with the slow waits overwritten: %v, want 2", got)
+	}
+}
+
+func TestPoolSizerSteadyLoad(t *testing.T) {
+	ps := newPoolSizer(poolSizerConfig{
+		MinSize:        2,
+		Step:           2,
+		GrowWaitTime:   10 * time.Millisecond,
+		ShrinkWaitTime: time.Millisecond,
+		ShrinkChecks:   2,
+		MaxSamples:     100,
+	})
+	// A steady load that uses all the connections, without waiting,
+	// doesn't shrink the pool.
+	for check := 0; check < 5; check++ {
+		for i := 0; i < 100; i++ {
+			ps.record(0, 8)
+		}
+		if got := ps.newCapacity(8, 10); got != 8 {
+			t.Fatalf("newCapacity at check %v with all the connections in use: %v, want 8", check, got)
+		}
+	}
+	// Nor does a load that would not fit after shrinking.
+	for check := 0; check < 5; check++ {
+		ps.record(0, 7)
+		if got := ps.newCapacity(8, 10); got != 8 {
+			t.Fatalf("newCapacity at check %v with 7 connections in use: %v, want 8", check, got)
+		}
+	}
+	// The pool shrinks once the load fits in the smaller pool.
+	ps.record(0, 5)
+	ps.newCapacity(8, 10)
+	ps.record(0, 5)
+	if got := ps.newCapacity(8, 10); got != 6 {
+		t.Errorf("newCapacity with 5 connections in use: %v, want 6", got)
+	}
+}
+
+func TestConnPoolAdaptiveSizing(t *testing.T) {
+	db := fakesqldb.Register()
+	testUtils := newTestUtils()
+	appParams := &sqldb.ConnParams{Engine: db.Name}
+	dbaParams := &sqldb.ConnParams{Engine: db.Name}
+	connPool := testUtils.newConnPool()
+	// A 0 check interval disables the timer, the test checks by itself.
+	connPool.enableAdaptiveSizing(poolSizerConfig{
+		MinSize:        2,
+		Step:           2,
+		GrowWaitTime:   time.Millisecond,
+		ShrinkWaitTime: time.Microsecond,
+		ShrinkChecks:   1,
+		MaxSamples:     100,
+	})
+	connPool.Open(appParams, dbaParams)
+	defer connPool.Close()
+	if got := connPool.Capacity(); got != 2 {
+		t.Fatalf("Capacity: %v, want 2", got)
+	}
+	if got := connPool.MaxCap(); got != 100 {
+		t.Fatalf("MaxCap: %v, want 100", got)
+	}
+
+	// Saturate the pool: the other queries wait for a connection.
+	ctx := context.Background()
+	var conns []*DBConn
+	for i := 0; i < 2; i++ {
+		conn, err := connPool.Get(ctx)
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		conns = append(conns, conn)
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			conn, err := connPool.Get(ctx)
+			if err != nil {
+				t.Errorf("Get: %v", err)
+				return
+			}
+			time.Sleep(5 * time.Millisecond)
+			conn.Recycle()
+		}()
+	}
+	time.Sleep(20 * time.Millisecond)
+	for _, conn := range conns {
+		conn.Recycle()
+	}
+	wg.Wait()
+
+	connPool.sizer.check(connPool)
+	if got := connPool.Capacity(); got != 4 {
+		t.Errorf("Capacity after saturation: %v, want 4", got)
+	}
+	if got := connPool.sizer.Grows(); got != 1 {
+		t.Errorf("Grows: %v, want 1", got)
+	}
+	if got := connPool.sizer.WaitTimeP95(); got < time.Millisecond {
+		t.Errorf("WaitTimeP95: %v, want at least 1ms", got)
+	}
+
+	// Without any query, the pool shrinks back.
+	connPool.sizer.check(connPool)
+	if got := connPool.Capacity(); got != 2 {
+		t.Errorf("Capacity when idle: %v, want 2", got)
+	}
+	if got := connPool.sizer.Shrinks(); got != 1 {
+		t.Errorf("Shrinks: %v, want 1", got)
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tabletserver
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+)
+
+// redactedBindVariable replaces the values of the bind variables whose
+// names match the redact patterns.
+const redactedBindVariable = "<redacted>"
+
+// redactedBindVariableJSON is redactedBindVariable marshaled by
+// json.Marshal.
+const redactedBindVariableJSON = `"\u003credacted\u003e"`
+
+var (
+	// redactPatternsMu protects redactPatterns.
+	redactPatternsMu sync.RWMutex
+	// redactPatterns are the lowercase glob patterns of the names of
+	// the bind variables that are always redacted in the query log.
+	redactPatterns []string
+)
+
+// parseRedactPatterns parses a comma separated list of glob patterns,
+// as accepted by path.Match.
+func parseRedactPatterns(value string) ([]string, error) {
+	var patterns []string
+	for _, pattern := range strings.Split(value, ",") {
+		pattern = strings.ToLower(strings.TrimSpace(pattern))
+		if pattern == "" {
+			continue
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid bind variable redact pattern %q: %v", pattern, err)
+		}
+		patterns = append(patterns, pattern)
+	}
+	return patterns, nil
+}
+
+// SetBindVariableRedactPatterns changes the comma separated list of glob
+// patterns of the names of the bind variables whose values are always
+// redacted in the query log. The patterns are case-insensitive.
+func SetBindVariableRedactPatterns(value string) error {
+	patterns, err := parseRedactPatterns(value)
+	if err != nil {
+		return err
+	}
+	redactPatternsMu.Lock()
+	redactPatterns = patterns
+	redactPatternsMu.Unlock()
+	return nil
+}
+
+// BindVariableRedactPatterns returns the glob patterns of the names of
+// the bind variables that are always redacted.
+func BindVariableRedactPatterns() []string {
+	redactPatternsMu.RLock()
+	defer redactPatternsMu.RUnlock()
+	return redactPatterns
+}
+
+// isRedactedBindVariable returns true if the value of the bind variable
+// name must be redacted.
+func isRedactedBindVariable(name string) bool {
+	patterns := BindVariableRedactPatterns()
+	if len(patterns) == 0 {
+		return false
+	}
+	name = strings.ToLower(name)
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
@@ -5,11 +5,14 @@
 package tabletserver
 
 import (
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"os"
 	"os/exec"
 	"path"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -37,6 +40,10 @@ type CachePool struct {
 	queryServiceStats *QueryServiceStats
 	mu                sync.Mutex
 	statsURL          string
+
+	// encryption encrypts the sensitive columns of the rows, it's
+	// nil if there's none.
+	encryption *rowcacheEncryption
 }
 
 // NewCachePool creates a new pool for rowcache connections.
@@ -84,6 +91,11 @@ func NewCachePool(
 		return cp
 	}
 	cp.rowCacheConfig = rowCacheConfig
+	encryption, err := loadRowcacheEncryption(rowCacheConfig.EncryptedColumnsFile, rowCacheConfig.EncryptionKeyFile)
+	if err != nil {
+		log.Fatalf("invalid rowcache encryption: %v", err)
+	}
+	cp.encryption = encryption
 
 	// Start with memcached defaults
 	cp.capacity = 1024 - 50
@@ -311,6 +323,41 @@ func (cp *CachePool) IdleTimeout() time.Duration {
 	return pool.IdleTimeout()
 }
 
+// MemoryUsage returns the bytes used by the items of memcache, and
+// its memory limit. They are 0 if the pool is closed.
+func (cp *CachePool) MemoryUsage() (used, limit int64, err error) {
+	if cp.IsClosed() {
+		return 0, 0, nil
+	}
+	defer func() {
+		if x := recover(); x != nil {
+			err = fmt.Errorf("%v", x)
+		}
+	}()
+	conn := cp.Get(context.Background())
+	// This is not the same as defer cp.Put(conn)
+	defer func() { cp.Put(conn) }()
+	stats, err := conn.Stats("")
+	if err != nil {
+		conn.Close()
+		conn = nil
+		return 0, 0, err
+	}
+	for _, line := range strings.Split(string(stats), "\n") {
+		items := strings.Split(line, " ")
+		if len(items) < 3 {
+			continue
+		}
+		switch items[1] {
+		case "bytes":
+			used, _ = strconv.ParseInt(strings.TrimSpace(items[2]), 10, 64)
+		case "limit_maxbytes":
+			limit, _ = strconv.ParseInt(strings.TrimSpace(items[2]), 10, 64)
+		}
+	}
+	return used, limit, nil
+}
+
 // ServeHTTP serves memcache stats as HTTP.
 func (cp *CachePool) ServeHTTP(response http.ResponseWriter, request *http.Request) {
 	if err := acl.CheckAccessHTTP(request, acl.MONITORING); err != nil {
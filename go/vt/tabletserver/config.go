@@ -7,12 +7,18 @@ package tabletserver
 import (
 	"flag"
 	"fmt"
+	"net/http"
 	"net/url"
 	"strconv"
+	"time"
 
+	log "github.com/golang/glog"
+	"github.com/youtube/vitess/go/ratelimiter"
 	"github.com/youtube/vitess/go/streamlog"
 	"github.com/youtube/vitess/go/vt/dbconfigs"
 	"github.com/youtube/vitess/go/vt/mysqlctl"
+	"github.com/youtube/vitess/go/vt/servenv"
+	"github.com/youtube/vitess/go/vt/tabletserver/planbuilder"
 	"github.com/youtube/vitess/go/vt/tabletserver/queryservice"
 
 	querypb "github.com/youtube/vitess/go/vt/proto/query"
@@ -22,6 +28,71 @@ import (
 var (
 	queryLogHandler = flag.String("query-log-stream-handler", "/debug/querylog", "URL handler for streaming queries log")
 	txLogHandler    = flag.String("transaction-log-stream-handler", "/debug/txlog", "URL handler for streaming transactions log")
+	queryLogFile    = flag.String("querylog-file", "", "If set, the queries log is also appended to the named file, in the format of -querylog-file-format. The file is reopened on SIGHUP, so that it can be rotated.")
+
+	queryLogFileFormat = flag.String("querylog-file-format", "text", "format of -querylog-file: text, json, or binary for length-prefixed querylog.LogStats protos, that vtquerylogcat converts back to text or JSON")
+
+	queryLogSyslog         = flag.Bool("querylog-syslog", false, "If set, the queries log is also sent to the local syslog daemon, one message per query")
+	queryLogSyslogFacility = flag.String("querylog-syslog-facility", "user", "syslog facility of the queries log messages, e.g. user or local0")
+	queryLogSyslogTag      = flag.String("querylog-syslog-tag", "vttablet", "syslog tag of the queries log messages")
+	queryLogSyslogMaxSize  = flag.Int("querylog-syslog-max-size", 8192, "maximum size in bytes of the queries log messages sent to syslog, longer ones are truncated. 0 means no limit.")
+
+	queryLogMaxSQLLen = flag.Int("querylog-max-sql-len", 4096, "maximum length in bytes of the original and rewritten SQL in the queries log, longer statements are truncated and end with '... [truncated N bytes]'. 0 means no limit.")
+
+	queryLogDedupLimit       = flag.Int("querylog-dedup-limit", 0, "if set, the queries log only sends the first N slow queries with the same plan type and SQL per -querylog-dedup-interval, and then a summary with the number and total time of the other ones, at the end of the interval. The subscribers of the queries log, like querylogz, only see the sent queries. 0 disables it.")
+	queryLogDedupInterval    = flag.Duration("querylog-dedup-interval", time.Minute, "interval over which -querylog-dedup-limit counts the duplicate queries")
+	queryLogDedupMaxQueries  = flag.Int("querylog-dedup-max-queries", 10000, "number of distinct queries -querylog-dedup-limit keeps track of. When it's exceeded, the least recently seen query is forgotten, and its summary sent.")
+	queryLogDedupMinDuration = flag.Duration("querylog-dedup-min-duration", time.Second, "queries faster than this are never deduplicated by -querylog-dedup-limit. 0 deduplicates all the queries.")
+
+	logQueriesLongerThan      = flag.Duration("log-queries-longer-than", 0, "queries that take longer than this are also logged as glog warnings, with their SQL redacted. 0 disables it.")
+	logQueriesLongerThanLimit = flag.Int("log-queries-longer-than-per-second", 10, "maximum number of queries logged per second by -log-queries-longer-than, the other ones are only counted")
+
+	redactDebugUIQueries = flag.Bool("redact-debug-ui-queries", false, "redact literals and bind variable values from the queries shown in the query log and querylogz, and from the queries in their errors")
+
+	queryLogRedactBindVariables    = flag.String("querylog-redact-bind-variables", "", "comma separated list of case-insensitive glob patterns of bind variable names, e.g. *password*,ssn. The values of the matching bind variables are always shown as \"<redacted>\" in the query log, even with the full param, and the rewritten SQL of their queries is redacted. The list can be changed at runtime with /debug/querylog_redact.")
+	queryLogShowShortBindVariables = flag.Int("querylog-show-short-bind-variables", 0, "string and bytes bind variables of at most this many bytes, like enum values, are shown in the query log as is, instead of their type and length. 0 disables it.")
+
+	tableStatsMaxTables = flag.Int("table_stats_max_tables", 1000, "maximum number of tables in the per table query stats of /debug/table_stats, the queries of the other tables are counted under \"<other>\". 0 disables the per table stats.")
+	querylogzCSVSize    = flag.Int("querylogz_csv_size", 1000, "number of the last query log records kept in memory for /querylogz, which shows them the newest first, and /querylogz/csv, which serves them as CSV. 0 disables /querylogz/csv, and /querylogz then waits for the next records.")
+
+	rowcacheInvalidationBatchSize      = flag.Int("rowcache-invalidation-batch-size", 100, "number of rowcache invalidations of a binlog event that are pipelined to memcache at once")
+	rowcacheInvalidationRate           = flag.Int("rowcache-invalidation-rate", 0, "maximum number of rowcache invalidations per second sent to memcache by the invalidator. 0 means no limit.")
+	rowcacheInvalidationFlushThreshold = flag.Int("rowcache-invalidation-flush-threshold", 10000, "when a transaction invalidates more than this many rows of a table, the rowcache of the whole table is flushed instead. 0 means the tables are never flushed.")
+
+	schemaChangeViaBinlog = flag.Bool("schema_change_via_binlog", false, "watch the binlogs for DDLs, and update the schema of the tables they change right away. The periodic schema reloads still happen.")
+
+	enrichFromPerfSchema = flag.Bool("enrich_from_perf_schema", false, "after each statement, read its execution details, like the number of rows examined, from performance_schema.events_statements_history_long, and add them to the query log. This runs an additional query for each statement.")
+
+	schemaPreValidation = flag.Bool("schema_pre_validation", false, "before executing a query, check that its tables and columns exist in the schema, and that the bind variables compared to or assigned to numeric columns are numbers. The queries that fail get a schema validation error instead of the MySQL one. This parses each query once more when its plan is built.")
+
+	waitForSemiSync = flag.Bool("wait_for_semi_sync", false, "check after each commit that MySQL is still waiting for semi-sync replication acknowledgments, and flag the transactions that were committed after it fell back to asynchronous replication in the query log")
+
+	checkGrants      = flag.Bool("check_grants", true, "when the schema is loaded or reloaded, check with SHOW GRANTS that the app user can select, insert, update and delete on the tables, and that the dba user has REPLICATION CLIENT. The missing privileges are logged, shown on the status page, and reported as the health error of the tablet.")
+	checkGrantsFatal = flag.Bool("check_grants_fatal", false, "fail to start the query service if -check_grants finds missing privileges")
+
+	schemaChangeDegradedDuration = flag.Duration("schema_change_degraded_duration", 0, "after a schema reload finds a table whose rowcache eligibility or primary key changed, show the tablet as degraded for this long on its status page and in SchemaPlanChangeDegraded. The query service keeps serving: the other tablets of the shard usually get the same change. 0 disables it, the changes are still logged, counted in SchemaPlanChanges and listed on the status page.")
+
+	countQuerySourcesByPlan = flag.Bool("query_source_counts_by_plan", false, "also count the sources of the query results by plan type, in QuerySourceCountsByPlan. QuerySourceCounts always counts them in total.")
+
+	queryIDComment = flag.Bool("query_id_comment", false, "add the query ID of the query log record of each query in a trailing comment of its statements sent to MySQL, e.g. /* query_id:15a2b3c4d5e6f708-2a */, so that they can be found in the MySQL slow log. The comment is unique to each statement, so it disables the hits of the MySQL query cache.")
+
+	maxTransactionDuration = flag.Duration("max_transaction_duration", 0, "transactions that are open for longer than this are rolled back, and their next statements fail with a transaction expired error. Unlike -queryserver-config-transaction-timeout, the connection is kept. 0 means there is no limit.")
+
+	maxLockTablesDuration = flag.Duration("max_lock_tables_duration", 0, "transactions that have been in the locked mode of LOCK TABLES for longer than this are rolled back, their tables are unlocked, and their next statements fail with a tables unlocked error. 0 means they're only limited by -queryserver-config-transaction-timeout.")
+
+	adaptivePoolSizing         = flag.Bool("adaptive_pool_sizing", false, "adapt the size of the query server connection pool to the time the queries wait for a connection. The pool starts with -adaptive_pool_min_size connections, grows up to -queryserver-config-pool-size, and it's exported as ConnPoolCapacity. The resizes are counted in ConnPoolGrows and ConnPoolShrinks.")
+	adaptivePoolMinSize        = flag.Int("adaptive_pool_min_size", 4, "size the connection pool starts with and never shrinks below with -adaptive_pool_sizing")
+	adaptivePoolStep           = flag.Int("adaptive_pool_step", 2, "number of connections added to or removed from the pool at once with -adaptive_pool_sizing")
+	adaptivePoolGrowWaitTime   = flag.Duration("adaptive_pool_grow_wait_time", 10*time.Millisecond, "the pool grows when the 95th percentile time the queries waited for a connection since the last check is above this")
+	adaptivePoolShrinkWaitTime = flag.Duration("adaptive_pool_shrink_wait_time", time.Millisecond, "the pool shrinks when the 95th percentile time the queries waited for a connection is below this for -adaptive_pool_shrink_checks checks in a row, and the connections in use would fit in the smaller pool")
+	adaptivePoolShrinkChecks   = flag.Int("adaptive_pool_shrink_checks", 6, "number of consecutive checks with a low wait time before the pool shrinks")
+	adaptivePoolCheckInterval  = flag.Duration("adaptive_pool_check_interval", 10*time.Second, "how often the wait times of -adaptive_pool_sizing are checked")
+	adaptivePoolMaxSamples     = flag.Int("adaptive_pool_max_samples", 10000, "maximum number of wait times kept between two checks, the older ones are overwritten")
+	poolConnLife               = flag.Duration("pool_conn_life", 0, "how long the connections of the query server pools are used: a connection is closed when it's returned to its pool after that, and a new one is created when needed. 0 means the connections are used until they fail or they're idle for -queryserver-config-idle-timeout.")
+	poolConnLifeJitter         = flag.Float64("pool_conn_life_jitter", 0, "in [0, 1], the life of each connection of -pool_conn_life is extended by a random duration up to this fraction of -pool_conn_life, so that the connections created together, e.g. when a pool opens, don't all reconnect to MySQL at the same time")
+	poolHealthProbeInterval    = flag.Duration("pool_health_probe_interval", 30*time.Second, "how often the idle connections of the query server pools are probed: the ones that don't answer, e.g. after a network partition from MySQL, are closed and replaced by new connections when needed. 0 disables the probes.")
+
+	enforceKeyRange = flag.Bool("enforce_key_range", false, "when the TabletControl record of the shard for this tablet type has enforce_key_range set, add a predicate on the sharding column of the keyspace to the selects, updates and deletes of the tables that have it, and reject the inserts and updates that write a sharding column value out of it, so that they only see and change the rows of the key range of the shard, even if they were misrouted during a resharding. The tablet stops serving if its keyspace has no sharding column")
 )
 
 func init() {
@@ -32,10 +103,15 @@ func init() {
 	flag.IntVar(&qsConfig.MaxResultSize, "queryserver-config-max-result-size", DefaultQsConfig.MaxResultSize, "query server max result size, maximum number of rows allowed to return from vttablet for non-streaming queries.")
 	flag.IntVar(&qsConfig.MaxDMLRows, "queryserver-config-max-dml-rows", DefaultQsConfig.MaxDMLRows, "query server max dml rows per statement, maximum number of rows allowed to return at a time for an upadte or delete with either 1) an equality where clauses on primary keys, or 2) a subselect statement. For update and delete statements in above two categories, vttablet will split the original query into multiple small queries based on this configuration value. ")
 	flag.IntVar(&qsConfig.StreamBufferSize, "queryserver-config-stream-buffer-size", DefaultQsConfig.StreamBufferSize, "query server stream buffer size, the maximum number of bytes sent from vttablet for each stream call.")
+	flag.IntVar(&qsConfig.CompressedStreamBufferSize, "queryserver-config-compressed-stream-buffer-size", DefaultQsConfig.CompressedStreamBufferSize, "the stream buffer size of the tables with the COMPRESSED row format. MySQL reads them from compressed pages, which hold more rows, so bigger stream calls make fewer round trips for the same pages. 0 uses -queryserver-config-stream-buffer-size.")
 	flag.IntVar(&qsConfig.QueryCacheSize, "queryserver-config-query-cache-size", DefaultQsConfig.QueryCacheSize, "query server query cache size, maximum number of queries to be cached. vttablet analyzes every incoming query and generate a query plan, these plans are being cached in a lru cache. This config controls the capacity of the lru cache.")
 	flag.Float64Var(&qsConfig.SchemaReloadTime, "queryserver-config-schema-reload-time", DefaultQsConfig.SchemaReloadTime, "query server schema reload time, how often vttablet reloads schemas from underlying MySQL instance in seconds. vttablet keeps table schemas in its own memory and periodically refreshes it from MySQL. This config controls the reload time.")
 	flag.Float64Var(&qsConfig.QueryTimeout, "queryserver-config-query-timeout", DefaultQsConfig.QueryTimeout, "query server query timeout (in seconds), this is the query timeout in vttablet side. If a query takes more than this timeout, it will be killed.")
+	flag.Float64Var(&qsConfig.SlowQueryThreshold, "queryserver-config-slow-query-threshold", DefaultQsConfig.SlowQueryThreshold, "query server slow query threshold (in seconds), only queries that take longer than this value or fail are sent to the query log. 0 means all queries are logged.")
+	flag.IntVar(&qsConfig.QueryLogSampleRate, "queryserver-config-query-log-sample-rate", DefaultQsConfig.QueryLogSampleRate, "query server query log sample rate, one in this many successful queries, picked at random, is sent to the query log. Failed queries and queries slower than queryserver-config-query-log-sample-exempt-time are always sent. 0 or 1 means there is no sampling.")
+	flag.Float64Var(&qsConfig.QueryLogSampleExempt, "queryserver-config-query-log-sample-exempt-time", DefaultQsConfig.QueryLogSampleExempt, "query server query log sample exempt time (in seconds), queries that take longer than this value are sent to the query log regardless of sampling. 0 means all successful queries are sampled.")
 	flag.Float64Var(&qsConfig.TxPoolTimeout, "queryserver-config-txpool-timeout", DefaultQsConfig.TxPoolTimeout, "query server transaction pool timeout, it is how long vttablet waits if tx pool is full")
+	flag.IntVar(&qsConfig.TxPoolMaxWaiters, "queryserver-config-txpool-max-waiters", DefaultQsConfig.TxPoolMaxWaiters, "query server transaction pool max waiters, the maximum number of Begin calls that can wait for a connection if tx pool is full. Additional Begin calls fail right away. 0 means there is no limit.")
 	flag.Float64Var(&qsConfig.IdleTimeout, "queryserver-config-idle-timeout", DefaultQsConfig.IdleTimeout, "query server idle timeout (in seconds), vttablet manages various mysql connection pools. This config means if a connection has not been used in given idle timeout, this connection will be removed from pool. This effectively manages number of connection objects and optimize the pool performance.")
 	flag.Float64Var(&qsConfig.SpotCheckRatio, "queryserver-config-spot-check-ratio", DefaultQsConfig.SpotCheckRatio, "query server rowcache spot check frequency (in [0, 1]), if rowcache is enabled, this value determines how often a row retrieved from the rowcache is spot-checked against MySQL.")
 	flag.BoolVar(&qsConfig.StrictMode, "queryserver-config-strict-mode", DefaultQsConfig.StrictMode, "allow only predictable DMLs and enforces MySQL's STRICT_TRANS_TABLES")
@@ -53,6 +129,8 @@ func init() {
 	flag.IntVar(&qsConfig.RowCache.Threads, "rowcache-threads", DefaultQsConfig.RowCache.Threads, "rowcache number of threads")
 	flag.BoolVar(&qsConfig.RowCache.LockPaged, "rowcache-lock-paged", DefaultQsConfig.RowCache.LockPaged, "whether rowcache locks down paged memory")
 	flag.StringVar(&qsConfig.RowCache.StatsPrefix, "rowcache-stats-prefix", DefaultQsConfig.RowCache.StatsPrefix, "rowcache stats prefix, rowcache will export various metrics and this config specifies the metric prefix")
+	flag.StringVar(&qsConfig.RowCache.EncryptedColumnsFile, "encrypted_columns_file", DefaultQsConfig.RowCache.EncryptedColumnsFile, "JSON file of a map of table names to lists of sensitive column names, e.g. {\"user\": [\"ssn\"]}. The values of these columns are encrypted with AES-256-GCM in the rowcache, with the key of -cache_encryption_key_file.")
+	flag.StringVar(&qsConfig.RowCache.EncryptionKeyFile, "cache_encryption_key_file", DefaultQsConfig.RowCache.EncryptionKeyFile, "file with the AES-256 key of -encrypted_columns_file, as 32 raw bytes or 64 hex digits")
 	flag.StringVar(&qsConfig.StatsPrefix, "stats-prefix", DefaultQsConfig.StatsPrefix, "prefix for variable names exported via expvar")
 	flag.StringVar(&qsConfig.DebugURLPrefix, "debug-url-prefix", DefaultQsConfig.DebugURLPrefix, "debug url prefix, vttablet will report various system debug pages and this config controls the prefix of these debug urls")
 	flag.StringVar(&qsConfig.PoolNamePrefix, "pool-name-prefix", DefaultQsConfig.PoolNamePrefix, "pool name prefix, vttablet has several pools and each of them has a name. This config specifies the prefix of these pool names")
@@ -61,8 +139,52 @@ func init() {
 
 // Init must be called after flag.Parse, and before doing any other operations.
 func Init() {
+	if *queryLogDedupLimit > 0 {
+		StatsLogger.SetDedup(*queryLogDedupLimit, *queryLogDedupInterval, *queryLogDedupMaxQueries, *queryLogDedupMinDuration)
+	}
 	StatsLogger.ServeLogs(*queryLogHandler, buildFmter(StatsLogger))
 	TxLogger.ServeLogs(*txLogHandler, buildFmter(TxLogger))
+	if *queryLogFile != "" {
+		switch *queryLogFileFormat {
+		case "text", "json", streamlog.BinaryFormat:
+		default:
+			log.Fatalf("Invalid -querylog-file-format %q: it must be text, json or binary", *queryLogFileFormat)
+		}
+		fmter := buildFmter(StatsLogger)
+		fileParams := url.Values{"format": {*queryLogFileFormat}}
+		stop, err := StatsLogger.LogToFile(*queryLogFile, func(_ url.Values, val interface{}) string {
+			return fmter(fileParams, val)
+		})
+		if err != nil {
+			log.Fatalf("Unable to open queries log file: %v", err)
+		}
+		servenv.OnClose(stop)
+	}
+	if *logQueriesLongerThan > 0 {
+		slowQueryGlogLimiter = ratelimiter.NewRateLimiter(*logQueriesLongerThanLimit, time.Second)
+		slowQueryGlogThreshold.Set(*logQueriesLongerThan)
+	}
+	if *tableStatsMaxTables > 0 {
+		tableStats = NewTableStats(*tableStatsMaxTables)
+		tableStats.Publish("TableStats")
+		http.Handle("/debug/table_stats", tableStats)
+	}
+	if *querylogzCSVSize > 0 {
+		recentQueries = NewQueryLogRing(*querylogzCSVSize)
+		http.Handle("/querylogz/csv", recentQueries)
+		recentQueries.Run(StatsLogger)
+	}
+	if *queryLogSyslog {
+		facility, err := streamlog.ParseSyslogFacility(*queryLogSyslogFacility)
+		if err != nil {
+			log.Fatalf("Invalid -querylog-syslog-facility: %v", err)
+		}
+		stop, err := StatsLogger.LogToSyslog(facility, *queryLogSyslogTag, *queryLogSyslogMaxSize, buildFmter(StatsLogger))
+		if err != nil {
+			log.Fatalf("Unable to connect to syslog for the queries log: %v", err)
+		}
+		servenv.OnClose(stop)
+	}
 }
 
 // RowCacheConfig encapsulates the configuration for RowCache
@@ -75,6 +197,12 @@ type RowCacheConfig struct {
 	Threads     int
 	LockPaged   bool
 	StatsPrefix string
+
+	// EncryptedColumnsFile is a JSON map of table names to the
+	// column names whose values are encrypted with the key of
+	// EncryptionKeyFile in the rowcache.
+	EncryptedColumnsFile string
+	EncryptionKeyFile    string
 }
 
 // GetSubprocessFlags returns the flags to use to call memcached
@@ -113,7 +241,11 @@ type Config struct {
 	QueryCacheSize       int
 	SchemaReloadTime     float64
 	QueryTimeout         float64
+	SlowQueryThreshold   float64
+	QueryLogSampleRate   int
+	QueryLogSampleExempt float64
 	TxPoolTimeout        float64
+	TxPoolMaxWaiters     int
 	IdleTimeout          float64
 	RowCache             RowCacheConfig
 	SpotCheckRatio       float64
@@ -127,6 +259,10 @@ type Config struct {
 	DebugURLPrefix       string
 	PoolNamePrefix       string
 	TableAclExemptACL    string
+
+	// CompressedStreamBufferSize replaces StreamBufferSize for the
+	// tables with the COMPRESSED row format, if it's not 0.
+	CompressedStreamBufferSize int
 }
 
 // DefaultQsConfig is the default value for the query service config.
@@ -146,7 +282,11 @@ var DefaultQsConfig = Config{
 	QueryCacheSize:       5000,
 	SchemaReloadTime:     30 * 60,
 	QueryTimeout:         0,
+	SlowQueryThreshold:   0,
+	QueryLogSampleRate:   0,
+	QueryLogSampleExempt: 0,
 	TxPoolTimeout:        1,
+	TxPoolMaxWaiters:     0,
 	IdleTimeout:          30 * 60,
 	StreamBufferSize:     32 * 1024,
 	RowCache:             RowCacheConfig{Memory: -1, Connections: -1, Threads: -1},
@@ -161,6 +301,8 @@ var DefaultQsConfig = Config{
 	DebugURLPrefix:       "/debug",
 	PoolNamePrefix:       "",
 	TableAclExemptACL:    "",
+
+	CompressedStreamBufferSize: 64 * 1024,
 }
 
 var qsConfig Config
@@ -170,7 +312,16 @@ func buildFmter(logger *streamlog.StreamLogger) func(url.Values, interface{}) st
 		Format(url.Values) string
 	}
 
+	type summaryFormatter interface {
+		FormatDedupSummary(url.Values, *streamlog.DedupSummary) string
+	}
+
 	return func(params url.Values, val interface{}) string {
+		if summary, ok := val.(*streamlog.DedupSummary); ok {
+			if fmter, ok := summary.Message.(summaryFormatter); ok {
+				return fmter.FormatDedupSummary(params, summary)
+			}
+		}
 		fmter, ok := val.(formatter)
 		if !ok {
 			return fmt.Sprintf("Error: unexpected value of type %T in %s!", val, logger.Name())
@@ -196,8 +347,9 @@ type Controller interface {
 	InitDBConfig(querypb.Target, dbconfigs.DBConfigs, []SchemaOverride, mysqlctl.MysqlDaemon) error
 
 	// SetServingType transitions the query service to the required serving type.
+	// The reason is reported when the query service is not serving.
 	// Returns true if the state of QueryService or the tablet type changed.
-	SetServingType(tabletType topodatapb.TabletType, serving bool, alsoAllow []topodatapb.TabletType) (bool, error)
+	SetServingType(tabletType topodatapb.TabletType, serving bool, alsoAllow []topodatapb.TabletType, reason string) (bool, error)
 
 	// EnterLameduck causes tabletserver to enter the lameduck state.
 	EnterLameduck()
@@ -211,6 +363,10 @@ type Controller interface {
 	// ClearQueryPlanCache clears internal query plan cache
 	ClearQueryPlanCache()
 
+	// SetKeyRangeFilter restricts the queries to the rows of a key range,
+	// if -enforce_key_range is set. nil removes the restriction.
+	SetKeyRangeFilter(filter *planbuilder.KeyRangeFilter)
+
 	// ReloadSchema makes the quey service reload its schema cache
 	ReloadSchema()
 
@@ -5,16 +5,29 @@
 package tabletserver
 
 import (
+	"math/rand"
 	"sync"
 	"time"
 
+	log "github.com/golang/glog"
 	"github.com/youtube/vitess/go/pools"
 	"github.com/youtube/vitess/go/sqldb"
 	"github.com/youtube/vitess/go/stats"
+	"github.com/youtube/vitess/go/sync2"
+	"github.com/youtube/vitess/go/timer"
 	"github.com/youtube/vitess/go/vt/dbconnpool"
 	"golang.org/x/net/context"
 )
 
+const (
+	// healthProbeQuery is sent to the idle connections to find the
+	// dead ones.
+	healthProbeQuery = "select 1 from dual"
+	// healthProbeTimeout is how long a connection has to answer
+	// healthProbeQuery.
+	healthProbeTimeout = 5 * time.Second
+)
+
 // ConnPool implements a custom connection pool for tabletserver.
 // It's similar to dbconnpool.ConnPool, but the connections it creates
 // come with built-in ability to kill in-flight queries. These connections
@@ -29,6 +42,22 @@ type ConnPool struct {
 	dbaPool           *dbconnpool.ConnectionPool
 	queryServiceStats *QueryServiceStats
 	checker           MySQLChecker
+	name              string
+	publishStats      bool
+	// sizer adapts the capacity of the pool if not nil. capacity
+	// is then its maximum.
+	sizer *poolSizer
+	// connLife is how long a connection is used, extended by up
+	// to jitterFactor*connLife at random. 0 means forever.
+	connLife     time.Duration
+	jitterFactor float64
+	// rngMu protects rng, which draws the extensions.
+	rngMu sync.Mutex
+	rng   *rand.Rand
+	// prober probes the idle connections if not nil, and pruned
+	// counts the dead ones it closed.
+	prober *timer.Timer
+	pruned sync2.AtomicInt64
 }
 
 // NewConnPool creates a new ConnPool. The name is used
@@ -46,6 +75,8 @@ func NewConnPool(
 		dbaPool:           dbconnpool.NewConnectionPool("", 1, idleTimeout),
 		queryServiceStats: queryServiceStats,
 		checker:           checker,
+		name:              name,
+		publishStats:      enablePublishStats,
 	}
 	if name == "" {
 		return cp
@@ -61,6 +92,88 @@ func NewConnPool(
 	return cp
 }
 
+// enableAdaptiveSizing makes the pool start with config.MinSize
+// connections, and grow up to its capacity as the wait times increase.
+// It must be called before Open.
+func (cp *ConnPool) enableAdaptiveSizing(config poolSizerConfig) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	cp.sizer = newPoolSizer(config)
+	if cp.name == "" || !cp.publishStats {
+		return
+	}
+	stats.Publish(cp.name+"Grows", stats.IntFunc(cp.sizer.Grows))
+	stats.Publish(cp.name+"Shrinks", stats.IntFunc(cp.sizer.Shrinks))
+	stats.Publish(cp.name+"WaitTimeP95", stats.DurationFunc(cp.sizer.WaitTimeP95))
+}
+
+// setConnLife makes the connections expire after connLife, each one
+// extended by a random duration up to jitterFactor*connLife so that
+// the connections created together don't all reconnect at the same
+// time. jitterFactor is capped to [0, 1]. It must be called before
+// Open.
+func (cp *ConnPool) setConnLife(connLife time.Duration, jitterFactor float64) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	if jitterFactor < 0 {
+		jitterFactor = 0
+	}
+	if jitterFactor > 1 {
+		jitterFactor = 1
+	}
+	cp.connLife = connLife
+	cp.jitterFactor = jitterFactor
+	cp.rng = rand.New(rand.NewSource(time.Now().UnixNano()))
+}
+
+// enableHealthProbe makes the pool send healthProbeQuery to its idle
+// connections every interval, and close the ones that fail. It must
+// be called before Open.
+func (cp *ConnPool) enableHealthProbe(interval time.Duration) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	cp.prober = timer.NewTimer(interval)
+	if cp.name == "" || !cp.publishStats {
+		return
+	}
+	stats.Publish(cp.name+"PrunedConns", stats.IntFunc(cp.pruned.Get))
+}
+
+// pruneDeadConns probes the idle connections, and closes the ones
+// that don't answer. A network partition between the tablet and MySQL
+// is then found before the queries fail on the dead connections.
+func (cp *ConnPool) pruneDeadConns() int {
+	p := cp.pool()
+	if p == nil {
+		return 0
+	}
+	pruned := p.Prune(func(r pools.Resource) bool {
+		ctx, cancel := context.WithTimeout(context.Background(), healthProbeTimeout)
+		defer cancel()
+		_, err := r.(*DBConn).execOnce(ctx, healthProbeQuery, 1, false)
+		return err == nil
+	})
+	if pruned == 0 {
+		return 0
+	}
+	cp.pruned.Add(int64(pruned))
+	log.Warningf("%v: pruned %d dead connections", cp.name, pruned)
+	cp.checker.CheckMySQL()
+	return pruned
+}
+
+// connExpiry returns the time after which a connection created now
+// must be closed, or the zero time if it doesn't expire.
+func (cp *ConnPool) connExpiry(now time.Time) time.Time {
+	if cp.connLife <= 0 {
+		return time.Time{}
+	}
+	cp.rngMu.Lock()
+	jitter := time.Duration(cp.rng.Float64() * cp.jitterFactor * float64(cp.connLife))
+	cp.rngMu.Unlock()
+	return now.Add(cp.connLife + jitter)
+}
+
 func (cp *ConnPool) pool() (p *pools.ResourcePool) {
 	cp.mu.Lock()
 	p = cp.connections
@@ -76,8 +189,19 @@ func (cp *ConnPool) Open(appParams, dbaParams *sqldb.ConnParams) {
 	f := func() (pools.Resource, error) {
 		return NewDBConn(cp, appParams, dbaParams, cp.queryServiceStats)
 	}
-	cp.connections = pools.NewResourcePool(f, cp.capacity, cp.capacity, cp.idleTimeout)
+	capacity := cp.capacity
+	if cp.sizer != nil && cp.sizer.config.MinSize < capacity {
+		capacity = cp.sizer.config.MinSize
+	}
+	cp.connections = pools.NewResourcePool(f, capacity, cp.capacity, cp.idleTimeout)
 	cp.dbaPool.Open(dbconnpool.DBConnectionCreator(dbaParams, cp.queryServiceStats.MySQLStats))
+	if cp.sizer != nil {
+		sizer := cp.sizer
+		sizer.ticks.Start(func() { sizer.check(cp) })
+	}
+	if cp.prober != nil {
+		cp.prober.Start(func() { cp.pruneDeadConns() })
+	}
 }
 
 // Close will close the pool and wait for connections to be returned before
@@ -87,6 +211,12 @@ func (cp *ConnPool) Close() {
 	if p == nil {
 		return
 	}
+	if cp.sizer != nil {
+		cp.sizer.ticks.Stop()
+	}
+	if cp.prober != nil {
+		cp.prober.Stop()
+	}
 	// We should not hold the lock while calling Close
 	// because it waits for connections to be returned.
 	p.Close()
@@ -103,13 +233,31 @@ func (cp *ConnPool) Get(ctx context.Context) (*DBConn, error) {
 	if p == nil {
 		return nil, ErrConnPoolClosed
 	}
+	start := time.Now()
 	r, err := p.Get(ctx)
+	if cp.sizer != nil {
+		cp.sizer.record(time.Now().Sub(start), int(p.Capacity()-p.Available()))
+	}
 	if err != nil {
 		return nil, err
 	}
 	return r.(*DBConn), nil
 }
 
+// TryGet returns a connection if one is free, or nil if all of them
+// are in use. It doesn't wait.
+func (cp *ConnPool) TryGet() (*DBConn, error) {
+	p := cp.pool()
+	if p == nil {
+		return nil, ErrConnPoolClosed
+	}
+	r, err := p.TryGet()
+	if err != nil || r == nil {
+		return nil, err
+	}
+	return r.(*DBConn), nil
+}
+
 // Put puts a connection into the pool.
 func (cp *ConnPool) Put(conn *DBConn) {
 	p := cp.pool()
@@ -5,10 +5,12 @@
 package tabletserver
 
 import (
+	"math"
 	"testing"
 	"time"
 
 	"github.com/youtube/vitess/go/sqldb"
+	"github.com/youtube/vitess/go/sqltypes"
 	"github.com/youtube/vitess/go/vt/vttest/fakesqldb"
 	"golang.org/x/net/context"
 )
@@ -31,6 +33,122 @@ func TestConnPoolGet(t *testing.T) {
 	dbConn.Recycle()
 }
 
+func TestConnPoolConnLifeJitter(t *testing.T) {
+	db := fakesqldb.Register()
+	testUtils := newTestUtils()
+	appParams := &sqldb.ConnParams{Engine: db.Name}
+	dbaParams := &sqldb.ConnParams{Engine: db.Name}
+	connPool := testUtils.newConnPool()
+	connLife := time.Hour
+	connPool.setConnLife(connLife, 0.5)
+	connPool.Open(appParams, dbaParams)
+	defer connPool.Close()
+
+	start := time.Now()
+	var conns []*DBConn
+	var lives []float64
+	for i := 0; i < 100; i++ {
+		dbConn, err := connPool.Get(context.Background())
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		conns = append(conns, dbConn)
+		life := dbConn.expiry.Sub(start)
+		if life < connLife || life > connLife*3/2+time.Minute {
+			t.Errorf("life of conn %v: %v, want between %v and %v", i, life, connLife, connLife*3/2)
+		}
+		lives = append(lives, float64(life))
+	}
+	for _, dbConn := range conns {
+		dbConn.Recycle()
+	}
+	// The lives are uniform over [connLife, 1.5*connLife], their
+	// standard deviation is 0.5/sqrt(12), about 14% of connLife.
+	var mean, variance float64
+	for _, life := range lives {
+		mean += life / float64(len(lives))
+	}
+	for _, life := range lives {
+		variance += (life - mean) * (life - mean) / float64(len(lives))
+	}
+	if stddev := math.Sqrt(variance); stddev < 0.1*float64(connLife) {
+		t.Errorf("standard deviation of the lives: %v, want at least %v", time.Duration(stddev), connLife/10)
+	}
+}
+
+func TestConnPoolConnLifeExpired(t *testing.T) {
+	db := fakesqldb.Register()
+	testUtils := newTestUtils()
+	appParams := &sqldb.ConnParams{Engine: db.Name}
+	dbaParams := &sqldb.ConnParams{Engine: db.Name}
+	connPool := testUtils.newConnPool()
+	connPool.setConnLife(time.Nanosecond, 0)
+	connPool.Open(appParams, dbaParams)
+	defer connPool.Close()
+	dbConn, err := connPool.Get(context.Background())
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	time.Sleep(time.Millisecond)
+	dbConn.Recycle()
+	if !dbConn.IsClosed() {
+		t.Errorf("an expired conn should be closed when it's recycled")
+	}
+	if got, want := connPool.Available(), connPool.Capacity(); got != want {
+		t.Errorf("Available: %v, want %v", got, want)
+	}
+}
+
+func TestConnPoolPruneDeadConns(t *testing.T) {
+	db := fakesqldb.Register()
+	db.AddQuery(healthProbeQuery, &sqltypes.Result{})
+	testUtils := newTestUtils()
+	appParams := &sqldb.ConnParams{Engine: db.Name}
+	dbaParams := &sqldb.ConnParams{Engine: db.Name}
+	connPool := testUtils.newConnPool()
+	connPool.enableHealthProbe(time.Hour)
+	connPool.Open(appParams, dbaParams)
+	defer connPool.Close()
+	var conns []*DBConn
+	for i := 0; i < 2; i++ {
+		dbConn, err := connPool.Get(context.Background())
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		conns = append(conns, dbConn)
+	}
+	inUse, err := connPool.Get(context.Background())
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	for _, dbConn := range conns {
+		dbConn.Recycle()
+	}
+
+	if got := connPool.pruneDeadConns(); got != 0 {
+		t.Errorf("pruneDeadConns with live conns: %v, want 0", got)
+	}
+	db.AddRejectedQuery(healthProbeQuery, errRejected)
+	if got := connPool.pruneDeadConns(); got != 2 {
+		t.Errorf("pruneDeadConns with dead conns: %v, want 2", got)
+	}
+	for _, dbConn := range conns {
+		if !dbConn.IsClosed() {
+			t.Errorf("a dead idle conn should be closed")
+		}
+	}
+	if inUse.IsClosed() {
+		t.Errorf("the conn in use should not be probed")
+	}
+	if got := connPool.pruned.Get(); got != 2 {
+		t.Errorf("pruned: %v, want 2", got)
+	}
+	inUse.Recycle()
+	if got, want := connPool.Available(), connPool.Capacity(); got != want {
+		t.Errorf("Available: %v, want %v", got, want)
+	}
+}
+
 func TestConnPoolPutWhilePoolIsClosed(t *testing.T) {
 	fakesqldb.Register()
 	testUtils := newTestUtils()
@@ -14,6 +14,7 @@ import (
 	"github.com/youtube/vitess/go/sync2"
 	"github.com/youtube/vitess/go/trace"
 	"github.com/youtube/vitess/go/vt/dbconnpool"
+	"github.com/youtube/vitess/go/vt/logutil"
 	vtrpcpb "github.com/youtube/vitess/go/vt/proto/vtrpc"
 	"golang.org/x/net/context"
 )
@@ -29,8 +30,16 @@ type DBConn struct {
 	pool              *ConnPool
 	queryServiceStats *QueryServiceStats
 	current           sync2.AtomicString
+	// maxAllowedPacket is the max_allowed_packet of the MySQL
+	// connection, read when it's established. 0 means it's unknown.
+	maxAllowedPacket int
+	// expiry is when Recycle closes the connection instead of
+	// returning it to the pool. The zero time means never.
+	expiry time.Time
 }
 
+var logMaxAllowedPacket = logutil.NewThrottledLogger("MaxAllowedPacket", 1*time.Minute)
+
 // NewDBConn creates a new DBConn. It triggers a CheckMySQL if creation fails.
 func NewDBConn(
 	cp *ConnPool,
@@ -47,9 +56,46 @@ func NewDBConn(
 		info:              appParams,
 		pool:              cp,
 		queryServiceStats: qStats,
+		maxAllowedPacket:  readMaxAllowedPacket(c),
+		expiry:            cp.connExpiry(time.Now()),
 	}, nil
 }
 
+// readMaxAllowedPacket returns the max_allowed_packet of conn, or 0
+// if it can't be read. The statements are then sent to MySQL
+// without checking their size first.
+func readMaxAllowedPacket(conn *dbconnpool.DBConnection) int {
+	size, err := conn.MaxAllowedPacket()
+	if err != nil {
+		logMaxAllowedPacket.Warningf("Cannot read max_allowed_packet, the size of the statements won't be checked: %v", err)
+		return 0
+	}
+	return size
+}
+
+// checkPacketSize returns an error if query is too large to be sent
+// to MySQL, instead of having MySQL reject it and close the
+// connection. The packet has a 1 byte command before the query.
+func (dbc *DBConn) checkPacketSize(query string) error {
+	if dbc.maxAllowedPacket == 0 || len(query)+1 <= dbc.maxAllowedPacket {
+		return nil
+	}
+	return newPacketTooLargeError(len(query), dbc.maxAllowedPacket)
+}
+
+// handlePacketTooLarge returns the error to send back if MySQL
+// rejected the query because it exceeds its max_allowed_packet, or nil
+// if err is another error. MySQL closes the connection after this
+// error, so it's closed here too, and Recycle discards it instead of
+// returning it to the pool.
+func (dbc *DBConn) handlePacketTooLarge(query string, err error) error {
+	if err == nil || !isPacketTooLarge(err) {
+		return nil
+	}
+	dbc.conn.Close()
+	return newPacketTooLargeError(len(query), dbc.maxAllowedPacket)
+}
+
 // Exec executes the specified query. If there is a connection error, it will reconnect
 // and retry. A failed reconnect will trigger a CheckMySQL.
 func (dbc *DBConn) Exec(ctx context.Context, query string, maxrows int, wantfields bool) (*sqltypes.Result, error) {
@@ -57,8 +103,14 @@ func (dbc *DBConn) Exec(ctx context.Context, query string, maxrows int, wantfiel
 	span.StartClient("DBConn.Exec")
 	defer span.Finish()
 
+	if err := dbc.checkPacketSize(query); err != nil {
+		return nil, err
+	}
 	for attempt := 1; attempt <= 2; attempt++ {
 		r, err := dbc.execOnce(ctx, query, maxrows, wantfields)
+		if terr := dbc.handlePacketTooLarge(query, err); terr != nil {
+			return nil, terr
+		}
 		switch {
 		case err == nil:
 			return r, nil
@@ -95,7 +147,14 @@ func (dbc *DBConn) execOnce(ctx context.Context, query string, maxrows int, want
 
 // ExecOnce executes the specified query, but does not retry on connection errors.
 func (dbc *DBConn) ExecOnce(ctx context.Context, query string, maxrows int, wantfields bool) (*sqltypes.Result, error) {
-	return dbc.execOnce(ctx, query, maxrows, wantfields)
+	if err := dbc.checkPacketSize(query); err != nil {
+		return nil, err
+	}
+	r, err := dbc.execOnce(ctx, query, maxrows, wantfields)
+	if terr := dbc.handlePacketTooLarge(query, err); terr != nil {
+		return nil, terr
+	}
+	return r, err
 }
 
 // Stream executes the query and streams the results.
@@ -104,6 +163,9 @@ func (dbc *DBConn) Stream(ctx context.Context, query string, callback func(*sqlt
 	span.StartClient("DBConn.Stream")
 	defer span.Finish()
 
+	if err := dbc.checkPacketSize(query); err != nil {
+		return err
+	}
 	for attempt := 1; attempt <= 2; attempt++ {
 		resultSent := false
 		err := dbc.streamOnce(
@@ -115,6 +177,9 @@ func (dbc *DBConn) Stream(ctx context.Context, query string, callback func(*sqlt
 			},
 			streamBufferSize,
 		)
+		if terr := dbc.handlePacketTooLarge(query, err); terr != nil {
+			return terr
+		}
 		switch {
 		case err == nil:
 			return nil
@@ -157,11 +222,16 @@ func (dbc *DBConn) IsClosed() bool {
 	return dbc.conn.IsClosed()
 }
 
-// Recycle returns the DBConn to the pool.
+// Recycle returns the DBConn to the pool. The connections that are
+// closed or expired are replaced by new ones when they're needed.
 func (dbc *DBConn) Recycle() {
-	if dbc.conn.IsClosed() {
+	switch {
+	case dbc.conn.IsClosed():
+		dbc.pool.Put(nil)
+	case !dbc.expiry.IsZero() && time.Now().After(dbc.expiry):
+		dbc.conn.Close()
 		dbc.pool.Put(nil)
-	} else {
+	default:
 		dbc.pool.Put(dbc)
 	}
 }
@@ -206,6 +276,7 @@ func (dbc *DBConn) reconnect() error {
 		return err
 	}
 	dbc.conn = newConn
+	dbc.maxAllowedPacket = readMaxAllowedPacket(newConn)
 	return nil
 }
 
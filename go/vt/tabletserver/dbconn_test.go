@@ -12,6 +12,7 @@ import (
 
 	"golang.org/x/net/context"
 
+	"github.com/youtube/vitess/go/mysql"
 	"github.com/youtube/vitess/go/sqldb"
 	"github.com/youtube/vitess/go/sqltypes"
 	"github.com/youtube/vitess/go/vt/vttest/fakesqldb"
@@ -56,6 +57,59 @@ func TestDBConnExec(t *testing.T) {
 	testUtils.checkTabletError(t, err, vtrpcpb.ErrorCode_INTERNAL_ERROR, "")
 }
 
+func TestDBConnMaxAllowedPacket(t *testing.T) {
+	db := fakesqldb.Register()
+	testUtils := newTestUtils()
+	db.AddQuery("select @@max_allowed_packet", &sqltypes.Result{
+		RowsAffected: 1,
+		Rows: [][]sqltypes.Value{
+			{sqltypes.MakeString([]byte("40"))},
+		},
+	})
+	largeSQL := "select * from test_table where name = 'a_long_name'"
+	db.AddQuery(largeSQL, &sqltypes.Result{})
+	connPool := testUtils.newConnPool()
+	appParams := &sqldb.ConnParams{Engine: db.Name}
+	dbaParams := &sqldb.ConnParams{Engine: db.Name}
+	connPool.Open(appParams, dbaParams)
+	defer connPool.Close()
+	ctx := context.Background()
+	queryServiceStats := NewQueryServiceStats("", false)
+	dbConn, err := NewDBConn(connPool, appParams, dbaParams, queryServiceStats)
+	if err != nil {
+		t.Fatalf("should not get an error, err: %v", err)
+	}
+	defer dbConn.Close()
+	if dbConn.maxAllowedPacket != 40 {
+		t.Errorf("maxAllowedPacket: %d, want 40", dbConn.maxAllowedPacket)
+	}
+
+	// The statements over max_allowed_packet are not sent to MySQL.
+	want := fmt.Sprintf("statement of %d bytes exceeds max_allowed_packet (40 bytes)", len(largeSQL))
+	_, err = dbConn.Exec(ctx, largeSQL, 1, false)
+	testUtils.checkTabletError(t, err, vtrpcpb.ErrorCode_BAD_INPUT, want)
+	_, err = dbConn.ExecOnce(ctx, largeSQL, 1, false)
+	testUtils.checkTabletError(t, err, vtrpcpb.ErrorCode_BAD_INPUT, want)
+	err = dbConn.Stream(ctx, largeSQL, func(*sqltypes.Result) error { return nil }, 4096)
+	testUtils.checkTabletError(t, err, vtrpcpb.ErrorCode_BAD_INPUT, want)
+	if n := db.GetQueryCalledNum(largeSQL); n != 0 {
+		t.Errorf("%v was executed %d times, want 0", largeSQL, n)
+	}
+	if dbConn.IsClosed() {
+		t.Errorf("the connection was closed, want it open")
+	}
+
+	// When MySQL rejects a statement because of its size, the
+	// connection is closed and not retried.
+	sql := "select 1"
+	db.AddRejectedQuery(sql, sqldb.NewSQLError(mysql.ErrNetPacketTooLarge, "Got a packet bigger than 'max_allowed_packet' bytes"))
+	_, err = dbConn.Exec(ctx, sql, 1, false)
+	testUtils.checkTabletError(t, err, vtrpcpb.ErrorCode_BAD_INPUT, "statement of 8 bytes exceeds max_allowed_packet (40 bytes)")
+	if !dbConn.IsClosed() {
+		t.Errorf("the connection is open, want it closed")
+	}
+}
+
 func TestDBConnKill(t *testing.T) {
 	db := fakesqldb.Register()
 	testUtils := newTestUtils()
@@ -6,6 +6,7 @@ package endtoend
 
 import (
 	"reflect"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -365,6 +366,52 @@ func TestMiscTypes(t *testing.T) {
 	}
 }
 
+func TestJSON(t *testing.T) {
+	client := framework.NewClient()
+	// The table is created here, because the JSON type needs MySQL 5.7.
+	// It's cached, so that the second select reads it from the rowcache.
+	if _, err := client.Execute("create table vitess_json(id int, j json, primary key(id))", nil); err != nil {
+		t.Skipf("MySQL doesn't support the JSON type: %v", err)
+	}
+	defer client.Execute("drop table vitess_json", nil)
+
+	docs := []string{
+		`{"a": {"b": [1, 2.5, {"c": null}], "d": true}}`,
+		`["é", "日本語", "😀"]`,
+		`{"nul": "a\u0000b", "empty": ""}`,
+	}
+	for i, doc := range docs {
+		if _, err := client.Execute("insert into vitess_json values(:id, :j)", map[string]interface{}{"id": i, "j": doc}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i, doc := range docs {
+		want := sqltypes.Result{
+			Fields: []*querypb.Field{{
+				Name: "id",
+				Type: sqltypes.Int32,
+			}, {
+				Name: "j",
+				Type: sqltypes.TypeJSON,
+			}},
+			RowsAffected: 1,
+			Rows: [][]sqltypes.Value{{
+				sqltypes.MakeTrusted(sqltypes.Int32, []byte(strconv.Itoa(i))),
+				sqltypes.MakeTrusted(sqltypes.TypeJSON, []byte(doc)),
+			}},
+		}
+		for _, source := range []string{"mysql", "rowcache"} {
+			qr, err := client.Execute("select * from vitess_json where id = :id", map[string]interface{}{"id": i})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(*qr, want) {
+				t.Errorf("Execute from %v: \n%#v, want \n%#v", source, *qr, want)
+			}
+		}
+	}
+}
+
 func TestNull(t *testing.T) {
 	client := framework.NewClient()
 	qr, err := client.Execute("select null from dual", nil)
@@ -36,6 +36,9 @@ func TestConfigVars(t *testing.T) {
 	}{{
 		tag: "BeginTimeout",
 		val: int(framework.BaseConfig.TxPoolTimeout * 1e9),
+	}, {
+		tag: "CompressedStreamBufferSize",
+		val: framework.BaseConfig.CompressedStreamBufferSize,
 	}, {
 		tag: "ConnPoolAvailable",
 		val: framework.BaseConfig.PoolSize,
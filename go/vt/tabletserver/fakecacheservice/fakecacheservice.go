@@ -129,6 +129,22 @@ func (service *FakeCacheService) Set(key string, flags uint16, timeout uint64, v
 	return true, nil
 }
 
+// SetMulti sets the same value for all the keys.
+func (service *FakeCacheService) SetMulti(keys []string, flags uint16, timeout uint64, value []byte) (int, error) {
+	if service.cache.enableCacheServiceError.Get() == 1 {
+		return 0, errCacheService
+	}
+	for _, key := range keys {
+		service.cache.Set(key, &cs.Result{
+			Key:   key,
+			Value: value,
+			Flags: flags,
+			Cas:   0,
+		})
+	}
+	return len(keys), nil
+}
+
 // Add store the value only if it does not already exist.
 func (service *FakeCacheService) Add(key string, flags uint16, timeout uint64, value []byte) (bool, error) {
 	if service.cache.enableCacheServiceError.Get() == 1 {
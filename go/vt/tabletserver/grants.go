@@ -0,0 +1,278 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tabletserver
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	log "github.com/golang/glog"
+	"github.com/youtube/vitess/go/sqltypes"
+	"golang.org/x/net/context"
+)
+
+// appTablePrivileges are the privileges the app user needs on each
+// served table.
+var appTablePrivileges = []string{"SELECT", "INSERT", "UPDATE", "DELETE"}
+
+// dbaGlobalPrivileges are the privileges the dba user needs, e.g.
+// REPLICATION CLIENT for the health reporter.
+var dbaGlobalPrivileges = []string{"REPLICATION CLIENT"}
+
+// grant is a line of SHOW GRANTS. db and table are "*" for all of
+// them, db may contain the % and _ wildcards.
+type grant struct {
+	privileges map[string]bool
+	db         string
+	table      string
+}
+
+// userGrants are the grants of a MySQL user.
+type userGrants []grant
+
+// parseGrants parses the lines returned by SHOW GRANTS. The lines it
+// doesn't understand, like the grants on routines or columns, are
+// skipped: they never give a table or global privilege.
+func parseGrants(lines []string) userGrants {
+	var grants userGrants
+	for _, line := range lines {
+		if g, ok := parseGrant(line); ok {
+			grants = append(grants, g)
+		}
+	}
+	return grants
+}
+
+// parseGrant parses a line like:
+// GRANT SELECT, INSERT ON `db`.* TO 'user'@'%' WITH GRANT OPTION
+func parseGrant(line string) (grant, bool) {
+	if !strings.HasPrefix(line, "GRANT ") {
+		return grant{}, false
+	}
+	line = line[len("GRANT "):]
+	on := strings.Index(line, " ON ")
+	to := strings.LastIndex(line, " TO ")
+	if on == -1 || to < on {
+		return grant{}, false
+	}
+	g := grant{privileges: make(map[string]bool)}
+	for _, privilege := range strings.Split(line[:on], ",") {
+		privilege = strings.ToUpper(strings.TrimSpace(privilege))
+		// Column privileges like SELECT (a) don't apply to the table.
+		if strings.Contains(privilege, "(") || strings.Contains(privilege, ")") {
+			continue
+		}
+		if privilege == "ALL" {
+			privilege = "ALL PRIVILEGES"
+		}
+		g.privileges[privilege] = true
+	}
+	object := strings.TrimSpace(line[on+len(" ON ") : to])
+	if strings.HasPrefix(object, "FUNCTION ") || strings.HasPrefix(object, "PROCEDURE ") {
+		return grant{}, false
+	}
+	object = strings.TrimPrefix(object, "TABLE ")
+	db, rest, ok := parseGrantIdentifier(object)
+	if !ok || !strings.HasPrefix(rest, ".") {
+		return grant{}, false
+	}
+	table, rest, ok := parseGrantIdentifier(rest[1:])
+	if !ok || rest != "" {
+		return grant{}, false
+	}
+	g.db, g.table = db, table
+	return g, true
+}
+
+// parseGrantIdentifier reads a database or table name, quoted with
+// backquotes or not, or "*", and returns the rest of s.
+func parseGrantIdentifier(s string) (string, string, bool) {
+	if !strings.HasPrefix(s, "`") {
+		end := strings.IndexByte(s, '.')
+		if end == -1 {
+			end = len(s)
+		}
+		return s[:end], s[end:], end != 0
+	}
+	var name []byte
+	for i := 1; i < len(s); i++ {
+		if s[i] != '`' {
+			name = append(name, s[i])
+			continue
+		}
+		// A doubled backquote is a backquote of the name.
+		if i+1 < len(s) && s[i+1] == '`' {
+			name = append(name, '`')
+			i++
+			continue
+		}
+		return string(name), s[i+1:], true
+	}
+	return "", "", false
+}
+
+// matchDB returns true if the db of a grant, which may contain the
+// % and _ wildcards, matches dbName.
+func matchDB(pattern, dbName string) bool {
+	if pattern == "*" || pattern == dbName {
+		return true
+	}
+	if !strings.ContainsAny(pattern, "%_") {
+		return false
+	}
+	var expr []string
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case c == '\\' && i+1 < len(pattern):
+			i++
+			expr = append(expr, regexp.QuoteMeta(pattern[i:i+1]))
+		case c == '%':
+			expr = append(expr, ".*")
+		case c == '_':
+			expr = append(expr, ".")
+		default:
+			expr = append(expr, regexp.QuoteMeta(pattern[i:i+1]))
+		}
+	}
+	matched, err := regexp.MatchString("^"+strings.Join(expr, "")+"$", dbName)
+	return err == nil && matched
+}
+
+func (g grant) has(privilege string) bool {
+	return g.privileges[privilege] || g.privileges["ALL PRIVILEGES"]
+}
+
+// hasGlobal returns true if the user has privilege on *.*.
+func (ug userGrants) hasGlobal(privilege string) bool {
+	for _, g := range ug {
+		if g.db == "*" && g.table == "*" && g.has(privilege) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasTable returns true if the user has privilege on dbName.table.
+func (ug userGrants) hasTable(privilege, dbName, table string) bool {
+	for _, g := range ug {
+		if !g.has(privilege) || !matchDB(g.db, dbName) {
+			continue
+		}
+		if g.table == "*" || g.table == table {
+			return true
+		}
+	}
+	return false
+}
+
+// missingPrivileges returns a description of the privileges the app
+// and dba users miss, sorted, or nil if they have them all.
+func missingPrivileges(app, dba userGrants, dbName string, tables []string) []string {
+	var missing []string
+	for _, table := range tables {
+		var privileges []string
+		for _, privilege := range appTablePrivileges {
+			if !app.hasTable(privilege, dbName, table) {
+				privileges = append(privileges, privilege)
+			}
+		}
+		if len(privileges) != 0 {
+			missing = append(missing, fmt.Sprintf("app user is missing %v on %v", strings.Join(privileges, ", "), table))
+		}
+	}
+	for _, privilege := range dbaGlobalPrivileges {
+		if !dba.hasGlobal(privilege) {
+			missing = append(missing, fmt.Sprintf("dba user is missing %v", privilege))
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// checkGrants reads the grants of the app and dba users, and records
+// the privileges they miss on the tables of the schema. It returns an
+// error if some are missing. If the grants cannot be read, it only
+// logs a warning and keeps the previous result.
+func (si *SchemaInfo) checkGrants(ctx context.Context) error {
+	if !*checkGrants {
+		return nil
+	}
+	app, err := si.readAppGrants(ctx)
+	if err != nil {
+		log.Warningf("Cannot read the grants of the app user: %v", err)
+		return nil
+	}
+	dba, err := si.readDbaGrants()
+	if err != nil {
+		log.Warningf("Cannot read the grants of the dba user: %v", err)
+		return nil
+	}
+
+	si.mu.Lock()
+	tables := make([]string, 0, len(si.tables))
+	for name := range si.tables {
+		if name != "dual" {
+			tables = append(tables, name)
+		}
+	}
+	dbName := si.dbName
+	si.mu.Unlock()
+
+	missing := missingPrivileges(app, dba, dbName, tables)
+	si.mu.Lock()
+	si.missingPrivileges = missing
+	si.mu.Unlock()
+	if len(missing) == 0 {
+		return nil
+	}
+	log.Warningf("Missing MySQL privileges: %v", strings.Join(missing, "; "))
+	return fmt.Errorf("missing MySQL privileges: %v", strings.Join(missing, "; "))
+}
+
+func (si *SchemaInfo) readAppGrants(ctx context.Context) (userGrants, error) {
+	conn, err := si.connPool.Get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Recycle()
+	qr, err := conn.Exec(ctx, "show grants", 1000, false)
+	if err != nil {
+		return nil, err
+	}
+	return parseGrantsResult(qr.Rows), nil
+}
+
+func (si *SchemaInfo) readDbaGrants() (userGrants, error) {
+	conn, err := si.connPool.dbaPool.Get(0)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Recycle()
+	qr, err := conn.ExecuteFetch("show grants", 1000, false)
+	if err != nil {
+		return nil, err
+	}
+	return parseGrantsResult(qr.Rows), nil
+}
+
+func parseGrantsResult(rows [][]sqltypes.Value) userGrants {
+	lines := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if len(row) != 0 {
+			lines = append(lines, row[0].String())
+		}
+	}
+	return parseGrants(lines)
+}
+
+// MissingPrivileges returns the privileges the app and dba users miss,
+// as found by the last check of the grants.
+func (si *SchemaInfo) MissingPrivileges() []string {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+	return si.missingPrivileges
+}
@@ -0,0 +1,183 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tabletserver
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/youtube/vitess/go/sqldb"
+	"github.com/youtube/vitess/go/sqltypes"
+	"github.com/youtube/vitess/go/vt/tabletserver/fakecacheservice"
+	"github.com/youtube/vitess/go/vt/vttest/fakesqldb"
+
+	vtrpcpb "github.com/youtube/vitess/go/vt/proto/vtrpc"
+)
+
+func TestParseGrant(t *testing.T) {
+	testcases := []struct {
+		line       string
+		ok         bool
+		privileges []string
+		db         string
+		table      string
+	}{{
+		line:       "GRANT USAGE ON *.* TO 'vt_app'@'localhost'",
+		ok:         true,
+		privileges: []string{"USAGE"},
+		db:         "*",
+		table:      "*",
+	}, {
+		line:       "GRANT SELECT, INSERT, UPDATE, DELETE ON `vt_test`.* TO 'vt_app'@'localhost'",
+		ok:         true,
+		privileges: []string{"DELETE", "INSERT", "SELECT", "UPDATE"},
+		db:         "vt_test",
+		table:      "*",
+	}, {
+		line:       "GRANT ALL ON vt_test.`my``table` TO 'vt_app'@'%' WITH GRANT OPTION",
+		ok:         true,
+		privileges: []string{"ALL PRIVILEGES"},
+		db:         "vt_test",
+		table:      "my`table",
+	}, {
+		line:       "GRANT SELECT (a, b), INSERT ON `vt_test`.`t` TO 'vt_app'@'%'",
+		ok:         true,
+		privileges: []string{"INSERT"},
+		db:         "vt_test",
+		table:      "t",
+	}, {
+		line: "GRANT EXECUTE ON PROCEDURE `vt_test`.`p` TO 'vt_app'@'%'",
+		ok:   false,
+	}, {
+		line: "GRANT PROXY ON ''@'' TO 'root'@'localhost' WITH GRANT OPTION",
+		ok:   false,
+	}, {
+		line: "not a grant",
+		ok:   false,
+	}}
+	for _, tcase := range testcases {
+		g, ok := parseGrant(tcase.line)
+		if ok != tcase.ok {
+			t.Errorf("parseGrant(%s): %v, want %v", tcase.line, ok, tcase.ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		want := make(map[string]bool)
+		for _, privilege := range tcase.privileges {
+			want[privilege] = true
+		}
+		if !reflect.DeepEqual(g.privileges, want) || g.db != tcase.db || g.table != tcase.table {
+			t.Errorf("parseGrant(%s): %v on %v.%v, want %v on %v.%v", tcase.line, g.privileges, g.db, g.table, want, tcase.db, tcase.table)
+		}
+	}
+}
+
+func TestMatchDB(t *testing.T) {
+	testcases := []struct {
+		pattern string
+		dbName  string
+		want    bool
+	}{
+		{"*", "vt_test", true},
+		{"vt_test", "vt_test", true},
+		{"vt_other", "vt_test", false},
+		{"vt%", "vt_test", true},
+		{"vt_tes_", "vt_test", true},
+		{`vt\_test`, "vt_test", true},
+		{`vt\_test`, "vtxtest", false},
+		{"vt.test", "vt_test", false},
+	}
+	for _, tcase := range testcases {
+		if got := matchDB(tcase.pattern, tcase.dbName); got != tcase.want {
+			t.Errorf("matchDB(%s, %s): %v, want %v", tcase.pattern, tcase.dbName, got, tcase.want)
+		}
+	}
+}
+
+func TestMissingPrivileges(t *testing.T) {
+	app := parseGrants([]string{
+		"GRANT USAGE ON *.* TO 'vt_app'@'localhost'",
+		"GRANT SELECT, INSERT, UPDATE, DELETE ON `vt_test`.* TO 'vt_app'@'localhost'",
+		"GRANT SELECT ON `vt_other`.* TO 'vt_app'@'localhost'",
+	})
+	dba := parseGrants([]string{"GRANT ALL PRIVILEGES ON *.* TO 'vt_dba'@'localhost'"})
+	if got := missingPrivileges(app, dba, "vt_test", []string{"t1", "t2"}); got != nil {
+		t.Errorf("missingPrivileges(vt_test): %v, want none", got)
+	}
+	got := missingPrivileges(app, app, "vt_other", []string{"t2", "t1"})
+	want := []string{
+		"app user is missing INSERT, UPDATE, DELETE on t1",
+		"app user is missing INSERT, UPDATE, DELETE on t2",
+		"dba user is missing REPLICATION CLIENT",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("missingPrivileges(vt_other): %v, want %v", got, want)
+	}
+
+	// Table grants.
+	app = parseGrants([]string{"GRANT SELECT, INSERT, UPDATE, DELETE ON `vt_test`.`t1` TO 'vt_app'@'localhost'"})
+	got = missingPrivileges(app, dba, "vt_test", []string{"t1", "t2"})
+	want = []string{"app user is missing SELECT, INSERT, UPDATE, DELETE on t2"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("missingPrivileges with table grants: %v, want %v", got, want)
+	}
+}
+
+func TestSchemaInfoCheckGrants(t *testing.T) {
+	fakecacheservice.Register()
+	db := fakesqldb.Register()
+	for query, result := range getSchemaInfoTestSupportedQueries() {
+		db.AddQuery(query, result)
+	}
+	db.AddQuery("show grants", &sqltypes.Result{
+		RowsAffected: 1,
+		Rows: [][]sqltypes.Value{
+			{sqltypes.MakeString([]byte("GRANT SELECT, INSERT, UPDATE, DELETE ON `vt_test`.* TO 'vt_app'@'localhost'"))},
+		},
+	})
+	schemaInfo := newTestSchemaInfo(10, 10*time.Second, 10*time.Second, false)
+	appParams := sqldb.ConnParams{Engine: db.Name, DbName: "vt_test"}
+	dbaParams := sqldb.ConnParams{Engine: db.Name, DbName: "vt_test"}
+	schemaInfo.cachePool.Open()
+	defer schemaInfo.cachePool.Close()
+	schemaInfo.Open(&appParams, &dbaParams, nil, false)
+	want := []string{"dba user is missing REPLICATION CLIENT"}
+	if got := schemaInfo.MissingPrivileges(); !reflect.DeepEqual(got, want) {
+		t.Errorf("MissingPrivileges after Open: %v, want %v", got, want)
+	}
+
+	// The privileges are checked again on reload.
+	db.AddQuery("show grants", &sqltypes.Result{
+		RowsAffected: 1,
+		Rows: [][]sqltypes.Value{
+			{sqltypes.MakeString([]byte("GRANT ALL PRIVILEGES ON *.* TO 'vt_app'@'localhost'"))},
+		},
+	})
+	schemaInfo.Reload()
+	if got := schemaInfo.MissingPrivileges(); got != nil {
+		t.Errorf("MissingPrivileges after Reload: %v, want none", got)
+	}
+	schemaInfo.Close()
+
+	// In fatal mode, Open fails.
+	defer func(v bool) { *checkGrantsFatal = v }(*checkGrantsFatal)
+	*checkGrantsFatal = true
+	db.AddQuery("show grants", &sqltypes.Result{
+		RowsAffected: 1,
+		Rows: [][]sqltypes.Value{
+			{sqltypes.MakeString([]byte("GRANT SELECT ON `vt_test`.* TO 'vt_app'@'localhost'"))},
+		},
+	})
+	schemaInfo = newTestSchemaInfo(10, 10*time.Second, 10*time.Second, false)
+	defer handleAndVerifyTabletError(
+		t,
+		"schema info Open should fail because of the missing privileges",
+		vtrpcpb.ErrorCode_INTERNAL_ERROR,
+	)
+	schemaInfo.Open(&appParams, &dbaParams, nil, false)
+}
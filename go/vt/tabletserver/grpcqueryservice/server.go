@@ -10,7 +10,9 @@ import (
 	"github.com/youtube/vitess/go/sqltypes"
 	"github.com/youtube/vitess/go/vt/callerid"
 	"github.com/youtube/vitess/go/vt/callinfo"
+	"github.com/youtube/vitess/go/vt/rowversion"
 	"github.com/youtube/vitess/go/vt/servenv"
+	"github.com/youtube/vitess/go/vt/sessiongtid"
 	"github.com/youtube/vitess/go/vt/tabletserver"
 	"github.com/youtube/vitess/go/vt/tabletserver/queryservice"
 	"github.com/youtube/vitess/go/vt/tabletserver/querytypes"
@@ -47,6 +49,16 @@ func (q *query) Execute(ctx context.Context, request *querypb.ExecuteRequest) (r
 		request.EffectiveCallerId,
 		request.ImmediateCallerId,
 	)
+	if request.WaitForGtid != "" {
+		ctx = sessiongtid.NewWaitContext(ctx, request.WaitForGtid)
+	}
+	var recorder *rowversion.Recorder
+	if request.IncludeRowVersion {
+		ctx, recorder = rowversion.NewRecorderContext(ctx)
+	}
+	if request.ExpectRowVersion != "" {
+		ctx = rowversion.NewExpectContext(ctx, request.ExpectRowVersion)
+	}
 	bv, err := querytypes.Proto3ToBindVariables(request.Query.BindVariables)
 	if err != nil {
 		return nil, tabletserver.ToGRPCError(err)
@@ -55,9 +67,13 @@ func (q *query) Execute(ctx context.Context, request *querypb.ExecuteRequest) (r
 	if err != nil {
 		return nil, tabletserver.ToGRPCError(err)
 	}
-	return &querypb.ExecuteResponse{
+	response = &querypb.ExecuteResponse{
 		Result: sqltypes.ResultToProto3(result),
-	}, nil
+	}
+	if recorder != nil {
+		response.RowVersion = recorder.Token
+	}
+	return response, nil
 }
 
 // ExecuteBatch is part of the queryservice.QueryServer interface
@@ -67,6 +83,9 @@ func (q *query) ExecuteBatch(ctx context.Context, request *querypb.ExecuteBatchR
 		request.EffectiveCallerId,
 		request.ImmediateCallerId,
 	)
+	if request.WaitForGtid != "" {
+		ctx = sessiongtid.NewWaitContext(ctx, request.WaitForGtid)
+	}
 	bql, err := querytypes.Proto3ToBoundQueryList(request.Queries)
 	if err != nil {
 		return nil, tabletserver.ToGRPCError(err)
@@ -91,6 +110,17 @@ func (q *query) StreamExecute(request *querypb.StreamExecuteRequest, stream quer
 	if err != nil {
 		return tabletserver.ToGRPCError(err)
 	}
+	if request.Resumable || len(request.ResumeToken) != 0 {
+		if err := q.server.StreamExecuteResumable(ctx, request.Target, request.Query.Sql, bv, request.SessionId, request.ResumeToken, func(reply *sqltypes.Result, resumeToken []byte) error {
+			return stream.Send(&querypb.StreamExecuteResponse{
+				Result:      sqltypes.ResultToProto3(reply),
+				ResumeToken: resumeToken,
+			})
+		}); err != nil {
+			return tabletserver.ToGRPCError(err)
+		}
+		return nil
+	}
 	if err := q.server.StreamExecute(ctx, request.Target, request.Query.Sql, bv, request.SessionId, func(reply *sqltypes.Result) error {
 		return stream.Send(&querypb.StreamExecuteResponse{
 			Result: sqltypes.ResultToProto3(reply),
@@ -108,6 +138,9 @@ func (q *query) Begin(ctx context.Context, request *querypb.BeginRequest) (respo
 		request.EffectiveCallerId,
 		request.ImmediateCallerId,
 	)
+	if request.Options != nil {
+		ctx = querytypes.NewExecuteOptionsContext(ctx, request.Options)
+	}
 	transactionID, err := q.server.Begin(ctx, request.Target, request.SessionId)
 	if err != nil {
 		return nil, tabletserver.ToGRPCError(err)
@@ -125,10 +158,18 @@ func (q *query) Commit(ctx context.Context, request *querypb.CommitRequest) (res
 		request.EffectiveCallerId,
 		request.ImmediateCallerId,
 	)
+	var recorder *sessiongtid.Recorder
+	if request.ReturnGtid {
+		ctx, recorder = sessiongtid.NewRecorderContext(ctx)
+	}
 	if err := q.server.Commit(ctx, request.Target, request.SessionId, request.TransactionId); err != nil {
 		return nil, tabletserver.ToGRPCError(err)
 	}
-	return &querypb.CommitResponse{}, nil
+	response = &querypb.CommitResponse{}
+	if recorder != nil {
+		response.Gtid = recorder.GTID
+	}
+	return response, nil
 }
 
 // Rollback is part of the queryservice.QueryServer interface
@@ -152,6 +193,9 @@ func (q *query) BeginExecute(ctx context.Context, request *querypb.BeginExecuteR
 		request.EffectiveCallerId,
 		request.ImmediateCallerId,
 	)
+	if request.Options != nil {
+		ctx = querytypes.NewExecuteOptionsContext(ctx, request.Options)
+	}
 	bv, err := querytypes.Proto3ToBindVariables(request.Query.BindVariables)
 	if err != nil {
 		return nil, tabletserver.ToGRPCError(err)
@@ -184,6 +228,9 @@ func (q *query) BeginExecuteBatch(ctx context.Context, request *querypb.BeginExe
 		request.EffectiveCallerId,
 		request.ImmediateCallerId,
 	)
+	if request.Options != nil {
+		ctx = querytypes.NewExecuteOptionsContext(ctx, request.Options)
+	}
 	bql, err := querytypes.Proto3ToBoundQueryList(request.Queries)
 	if err != nil {
 		return nil, tabletserver.ToGRPCError(err)
@@ -209,6 +256,109 @@ func (q *query) BeginExecuteBatch(ctx context.Context, request *querypb.BeginExe
 	}, nil
 }
 
+// WaitForGTID is part of the queryservice.QueryServer interface
+func (q *query) WaitForGTID(ctx context.Context, request *querypb.WaitForGTIDRequest) (response *querypb.WaitForGTIDResponse, err error) {
+	defer q.server.HandlePanic(&err)
+	ctx = callerid.NewContext(callinfo.GRPCCallInfo(ctx),
+		request.EffectiveCallerId,
+		request.ImmediateCallerId,
+	)
+	if err := q.server.WaitForGTID(ctx, request.Target, request.Gtid); err != nil {
+		return nil, tabletserver.ToGRPCError(err)
+	}
+	return &querypb.WaitForGTIDResponse{}, nil
+}
+
+// XAStart is part of the queryservice.QueryServer interface
+func (q *query) XAStart(ctx context.Context, request *querypb.XAStartRequest) (response *querypb.XAStartResponse, err error) {
+	defer q.server.HandlePanic(&err)
+	ctx = callerid.NewContext(callinfo.GRPCCallInfo(ctx),
+		request.EffectiveCallerId,
+		request.ImmediateCallerId,
+	)
+	transactionID, err := q.server.XAStart(ctx, request.Target, request.SessionId, request.Xid)
+	if err != nil {
+		return nil, tabletserver.ToGRPCError(err)
+	}
+	return &querypb.XAStartResponse{TransactionId: transactionID}, nil
+}
+
+// XAPrepare is part of the queryservice.QueryServer interface
+func (q *query) XAPrepare(ctx context.Context, request *querypb.XAPrepareRequest) (response *querypb.XAPrepareResponse, err error) {
+	defer q.server.HandlePanic(&err)
+	ctx = callerid.NewContext(callinfo.GRPCCallInfo(ctx),
+		request.EffectiveCallerId,
+		request.ImmediateCallerId,
+	)
+	if err := q.server.XAPrepare(ctx, request.Target, request.SessionId, request.Xid); err != nil {
+		return nil, tabletserver.ToGRPCError(err)
+	}
+	return &querypb.XAPrepareResponse{}, nil
+}
+
+// XACommit is part of the queryservice.QueryServer interface
+func (q *query) XACommit(ctx context.Context, request *querypb.XACommitRequest) (response *querypb.XACommitResponse, err error) {
+	defer q.server.HandlePanic(&err)
+	ctx = callerid.NewContext(callinfo.GRPCCallInfo(ctx),
+		request.EffectiveCallerId,
+		request.ImmediateCallerId,
+	)
+	if err := q.server.XACommit(ctx, request.Target, request.SessionId, request.Xid); err != nil {
+		return nil, tabletserver.ToGRPCError(err)
+	}
+	return &querypb.XACommitResponse{}, nil
+}
+
+// XARollback is part of the queryservice.QueryServer interface
+func (q *query) XARollback(ctx context.Context, request *querypb.XARollbackRequest) (response *querypb.XARollbackResponse, err error) {
+	defer q.server.HandlePanic(&err)
+	ctx = callerid.NewContext(callinfo.GRPCCallInfo(ctx),
+		request.EffectiveCallerId,
+		request.ImmediateCallerId,
+	)
+	if err := q.server.XARollback(ctx, request.Target, request.SessionId, request.Xid); err != nil {
+		return nil, tabletserver.ToGRPCError(err)
+	}
+	return &querypb.XARollbackResponse{}, nil
+}
+
+// XARecover is part of the queryservice.QueryServer interface
+func (q *query) XARecover(ctx context.Context, request *querypb.XARecoverRequest) (response *querypb.XARecoverResponse, err error) {
+	defer q.server.HandlePanic(&err)
+	ctx = callerid.NewContext(callinfo.GRPCCallInfo(ctx),
+		request.EffectiveCallerId,
+		request.ImmediateCallerId,
+	)
+	xids, err := q.server.XARecover(ctx, request.Target, request.SessionId)
+	if err != nil {
+		return nil, tabletserver.ToGRPCError(err)
+	}
+	return &querypb.XARecoverResponse{Xids: xids}, nil
+}
+
+// HotQueries is part of the queryservice.QueryServer interface
+func (q *query) HotQueries(ctx context.Context, request *querypb.HotQueriesRequest) (response *querypb.HotQueriesResponse, err error) {
+	defer q.server.HandlePanic(&err)
+	ctx = callerid.NewContext(callinfo.GRPCCallInfo(ctx),
+		request.EffectiveCallerId,
+		request.ImmediateCallerId,
+	)
+	queries, err := q.server.HotQueries(ctx, request.Target)
+	if err != nil {
+		return nil, tabletserver.ToGRPCError(err)
+	}
+	response = &querypb.HotQueriesResponse{}
+	for _, bq := range queries {
+		pbq, err := querytypes.BoundQueryToProto3(bq.Sql, bq.BindVariables)
+		if err != nil {
+			// The bind variables of this query can't be sent.
+			continue
+		}
+		response.Queries = append(response.Queries, pbq)
+	}
+	return response, nil
+}
+
 // SplitQuery is part of the queryservice.QueryServer interface
 func (q *query) SplitQuery(ctx context.Context, request *querypb.SplitQueryRequest) (response *querypb.SplitQueryResponse, err error) {
 	defer q.server.HandlePanic(&err)
@@ -14,7 +14,9 @@ import (
 	"github.com/youtube/vitess/go/netutil"
 	"github.com/youtube/vitess/go/sqltypes"
 	"github.com/youtube/vitess/go/vt/callerid"
+	"github.com/youtube/vitess/go/vt/rowversion"
 	"github.com/youtube/vitess/go/vt/servenv/grpcutils"
+	"github.com/youtube/vitess/go/vt/sessiongtid"
 	"github.com/youtube/vitess/go/vt/tabletserver/querytypes"
 	"github.com/youtube/vitess/go/vt/tabletserver/tabletconn"
 	"golang.org/x/net/context"
@@ -98,11 +100,18 @@ func (conn *gRPCQueryClient) Execute(ctx context.Context, query string, bindVars
 		ImmediateCallerId: callerid.ImmediateCallerIDFromContext(ctx),
 		Query:             q,
 		TransactionId:     transactionID,
+		WaitForGtid:       sessiongtid.WaitGTIDFromContext(ctx),
+		ExpectRowVersion:  rowversion.ExpectFromContext(ctx),
 	}
+	recorder := rowversion.RecorderFromContext(ctx)
+	req.IncludeRowVersion = recorder != nil
 	er, err := conn.c.Execute(ctx, req)
 	if err != nil {
 		return nil, tabletconn.TabletErrorFromGRPC(err)
 	}
+	if recorder != nil {
+		recorder.Token = er.RowVersion
+	}
 	return sqltypes.Proto3ToResult(er.Result), nil
 }
 
@@ -121,6 +130,7 @@ func (conn *gRPCQueryClient) ExecuteBatch(ctx context.Context, queries []queryty
 		Queries:           make([]*querypb.BoundQuery, len(queries)),
 		AsTransaction:     asTransaction,
 		TransactionId:     transactionID,
+		WaitForGtid:       sessiongtid.WaitGTIDFromContext(ctx),
 	}
 	for i, q := range queries {
 		qq, err := querytypes.BoundQueryToProto3(q.Sql, q.BindVariables)
@@ -181,6 +191,53 @@ func (conn *gRPCQueryClient) StreamExecute(ctx context.Context, query string, bi
 	return &streamExecuteAdapter{stream: stream}, err
 }
 
+type resumableStreamExecuteAdapter struct {
+	stream queryservicepb.Query_StreamExecuteClient
+	fields []*querypb.Field
+}
+
+func (a *resumableStreamExecuteAdapter) Recv() (*sqltypes.Result, []byte, error) {
+	ser, err := a.stream.Recv()
+	switch err {
+	case nil:
+		if a.fields == nil {
+			a.fields = ser.Result.Fields
+		}
+		return sqltypes.CustomProto3ToResult(a.fields, ser.Result), ser.ResumeToken, nil
+	case io.EOF:
+		return nil, nil, err
+	default:
+		return nil, nil, tabletconn.TabletErrorFromGRPC(err)
+	}
+}
+
+// StreamExecuteResumable starts a resumable streaming query to VTTablet.
+func (conn *gRPCQueryClient) StreamExecuteResumable(ctx context.Context, query string, bindVars map[string]interface{}, resumeToken []byte) (tabletconn.ResumableResultStream, error) {
+	conn.mu.RLock()
+	defer conn.mu.RUnlock()
+	if conn.cc == nil {
+		return nil, tabletconn.ConnClosed
+	}
+
+	q, err := querytypes.BoundQueryToProto3(query, bindVars)
+	if err != nil {
+		return nil, err
+	}
+	req := &querypb.StreamExecuteRequest{
+		Target:            conn.target,
+		EffectiveCallerId: callerid.EffectiveCallerIDFromContext(ctx),
+		ImmediateCallerId: callerid.ImmediateCallerIDFromContext(ctx),
+		Query:             q,
+		Resumable:         true,
+		ResumeToken:       resumeToken,
+	}
+	stream, err := conn.c.StreamExecute(ctx, req)
+	if err != nil {
+		return nil, tabletconn.TabletErrorFromGRPC(err)
+	}
+	return &resumableStreamExecuteAdapter{stream: stream}, nil
+}
+
 // Begin starts a transaction.
 func (conn *gRPCQueryClient) Begin(ctx context.Context) (transactionID int64, err error) {
 	conn.mu.RLock()
@@ -193,6 +250,7 @@ func (conn *gRPCQueryClient) Begin(ctx context.Context) (transactionID int64, er
 		Target:            conn.target,
 		EffectiveCallerId: callerid.EffectiveCallerIDFromContext(ctx),
 		ImmediateCallerId: callerid.ImmediateCallerIDFromContext(ctx),
+		Options:           querytypes.ExecuteOptionsFromContext(ctx),
 	}
 	br, err := conn.c.Begin(ctx, req)
 	if err != nil {
@@ -209,16 +267,21 @@ func (conn *gRPCQueryClient) Commit(ctx context.Context, transactionID int64) er
 		return tabletconn.ConnClosed
 	}
 
+	recorder := sessiongtid.RecorderFromContext(ctx)
 	req := &querypb.CommitRequest{
 		Target:            conn.target,
 		EffectiveCallerId: callerid.EffectiveCallerIDFromContext(ctx),
 		ImmediateCallerId: callerid.ImmediateCallerIDFromContext(ctx),
 		TransactionId:     transactionID,
+		ReturnGtid:        recorder != nil,
 	}
-	_, err := conn.c.Commit(ctx, req)
+	cr, err := conn.c.Commit(ctx, req)
 	if err != nil {
 		return tabletconn.TabletErrorFromGRPC(err)
 	}
+	if recorder != nil {
+		recorder.GTID = cr.Gtid
+	}
 	return nil
 }
 
@@ -243,6 +306,148 @@ func (conn *gRPCQueryClient) Rollback(ctx context.Context, transactionID int64)
 	return nil
 }
 
+// WaitForGTID waits until the tablet has replicated up to gtid.
+func (conn *gRPCQueryClient) WaitForGTID(ctx context.Context, gtid string) error {
+	conn.mu.RLock()
+	defer conn.mu.RUnlock()
+	if conn.cc == nil {
+		return tabletconn.ConnClosed
+	}
+
+	req := &querypb.WaitForGTIDRequest{
+		Target:            conn.target,
+		EffectiveCallerId: callerid.EffectiveCallerIDFromContext(ctx),
+		ImmediateCallerId: callerid.ImmediateCallerIDFromContext(ctx),
+		Gtid:              gtid,
+	}
+	_, err := conn.c.WaitForGTID(ctx, req)
+	if err != nil {
+		return tabletconn.TabletErrorFromGRPC(err)
+	}
+	return nil
+}
+
+// XAStart starts the XA transaction xid.
+func (conn *gRPCQueryClient) XAStart(ctx context.Context, xid string) (transactionID int64, err error) {
+	conn.mu.RLock()
+	defer conn.mu.RUnlock()
+	if conn.cc == nil {
+		return 0, tabletconn.ConnClosed
+	}
+
+	req := &querypb.XAStartRequest{
+		Target:            conn.target,
+		EffectiveCallerId: callerid.EffectiveCallerIDFromContext(ctx),
+		ImmediateCallerId: callerid.ImmediateCallerIDFromContext(ctx),
+		Xid:               xid,
+	}
+	response, err := conn.c.XAStart(ctx, req)
+	if err != nil {
+		return 0, tabletconn.TabletErrorFromGRPC(err)
+	}
+	return response.TransactionId, nil
+}
+
+// XAPrepare prepares the XA transaction xid.
+func (conn *gRPCQueryClient) XAPrepare(ctx context.Context, xid string) error {
+	conn.mu.RLock()
+	defer conn.mu.RUnlock()
+	if conn.cc == nil {
+		return tabletconn.ConnClosed
+	}
+
+	req := &querypb.XAPrepareRequest{
+		Target:            conn.target,
+		EffectiveCallerId: callerid.EffectiveCallerIDFromContext(ctx),
+		ImmediateCallerId: callerid.ImmediateCallerIDFromContext(ctx),
+		Xid:               xid,
+	}
+	if _, err := conn.c.XAPrepare(ctx, req); err != nil {
+		return tabletconn.TabletErrorFromGRPC(err)
+	}
+	return nil
+}
+
+// XACommit commits the XA transaction xid.
+func (conn *gRPCQueryClient) XACommit(ctx context.Context, xid string) error {
+	conn.mu.RLock()
+	defer conn.mu.RUnlock()
+	if conn.cc == nil {
+		return tabletconn.ConnClosed
+	}
+
+	req := &querypb.XACommitRequest{
+		Target:            conn.target,
+		EffectiveCallerId: callerid.EffectiveCallerIDFromContext(ctx),
+		ImmediateCallerId: callerid.ImmediateCallerIDFromContext(ctx),
+		Xid:               xid,
+	}
+	if _, err := conn.c.XACommit(ctx, req); err != nil {
+		return tabletconn.TabletErrorFromGRPC(err)
+	}
+	return nil
+}
+
+// XARollback rolls back the XA transaction xid.
+func (conn *gRPCQueryClient) XARollback(ctx context.Context, xid string) error {
+	conn.mu.RLock()
+	defer conn.mu.RUnlock()
+	if conn.cc == nil {
+		return tabletconn.ConnClosed
+	}
+
+	req := &querypb.XARollbackRequest{
+		Target:            conn.target,
+		EffectiveCallerId: callerid.EffectiveCallerIDFromContext(ctx),
+		ImmediateCallerId: callerid.ImmediateCallerIDFromContext(ctx),
+		Xid:               xid,
+	}
+	if _, err := conn.c.XARollback(ctx, req); err != nil {
+		return tabletconn.TabletErrorFromGRPC(err)
+	}
+	return nil
+}
+
+// XARecover returns the xids of the prepared XA transactions.
+func (conn *gRPCQueryClient) XARecover(ctx context.Context) ([]string, error) {
+	conn.mu.RLock()
+	defer conn.mu.RUnlock()
+	if conn.cc == nil {
+		return nil, tabletconn.ConnClosed
+	}
+
+	req := &querypb.XARecoverRequest{
+		Target:            conn.target,
+		EffectiveCallerId: callerid.EffectiveCallerIDFromContext(ctx),
+		ImmediateCallerId: callerid.ImmediateCallerIDFromContext(ctx),
+	}
+	response, err := conn.c.XARecover(ctx, req)
+	if err != nil {
+		return nil, tabletconn.TabletErrorFromGRPC(err)
+	}
+	return response.Xids, nil
+}
+
+// HotQueries returns the most executed SELECT queries of the tablet.
+func (conn *gRPCQueryClient) HotQueries(ctx context.Context) ([]querytypes.BoundQuery, error) {
+	conn.mu.RLock()
+	defer conn.mu.RUnlock()
+	if conn.cc == nil {
+		return nil, tabletconn.ConnClosed
+	}
+
+	req := &querypb.HotQueriesRequest{
+		Target:            conn.target,
+		EffectiveCallerId: callerid.EffectiveCallerIDFromContext(ctx),
+		ImmediateCallerId: callerid.ImmediateCallerIDFromContext(ctx),
+	}
+	response, err := conn.c.HotQueries(ctx, req)
+	if err != nil {
+		return nil, tabletconn.TabletErrorFromGRPC(err)
+	}
+	return querytypes.Proto3ToBoundQueryList(response.Queries)
+}
+
 // BeginExecute starts a transaction and runs an Execute.
 func (conn *gRPCQueryClient) BeginExecute(ctx context.Context, query string, bindVars map[string]interface{}) (result *sqltypes.Result, transactionID int64, err error) {
 	conn.mu.RLock()
@@ -263,6 +468,7 @@ func (conn *gRPCQueryClient) BeginExecute(ctx context.Context, query string, bin
 			EffectiveCallerId: callerid.EffectiveCallerIDFromContext(ctx),
 			ImmediateCallerId: callerid.ImmediateCallerIDFromContext(ctx),
 			Query:             q,
+			Options:           querytypes.ExecuteOptionsFromContext(ctx),
 		}
 		reply, err := conn.c.BeginExecute(ctx, req)
 		if err != nil {
@@ -318,6 +524,7 @@ func (conn *gRPCQueryClient) BeginExecuteBatch(ctx context.Context, queries []qu
 			ImmediateCallerId: callerid.ImmediateCallerIDFromContext(ctx),
 			Queries:           make([]*querypb.BoundQuery, len(queries)),
 			AsTransaction:     asTransaction,
+			Options:           querytypes.ExecuteOptionsFromContext(ctx),
 		}
 		for i, q := range queries {
 			qq, err := querytypes.BoundQueryToProto3(q.Sql, q.BindVariables)
@@ -0,0 +1,170 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tabletserver
+
+import (
+	"flag"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/youtube/vitess/go/sync2"
+	"github.com/youtube/vitess/go/vt/sqlparser"
+	"github.com/youtube/vitess/go/vt/tabletserver/querytypes"
+)
+
+var (
+	hotQueriesSize       = flag.Int("hot_queries_size", 100, "number of the most executed SELECT fingerprints returned by the HotQueries RPC of the tablet, each with the bind variables of one of its executions, that the replicas execute to warm their buffer pool. The queries with bind variables matching -querylog-redact-bind-variables are left out. 0 disables it.")
+	hotQueriesWindow     = flag.Duration("hot_queries_window", 5*time.Minute, "the hot queries are counted over the current and the previous window of this duration")
+	hotQueriesSampleRate = flag.Int("hot_queries_sample_rate", 10, "only 1 of this many SELECT queries outside of transactions is counted in the hot queries")
+)
+
+// hotQueriesTracked is the number of fingerprints counted in a window,
+// as a multiple of the number of hot queries. The fingerprints seen
+// once the window is full are only counted in the next one.
+const hotQueriesTracked = 10
+
+// hotQuery is a fingerprint counted by hotQueries, with the first
+// query of the window that has it.
+type hotQuery struct {
+	count    int64
+	sql      string
+	bindVars map[string]interface{}
+}
+
+// hotQueries keeps the most executed SELECT fingerprints, sampled over
+// the current and the previous window, so that the older queries fade
+// out without the counts dropping to zero at each window end.
+type hotQueries struct {
+	size       int
+	window     time.Duration
+	sampleRate int64
+	// calls counts the Record calls, for the sampling.
+	calls sync2.AtomicInt64
+	// now is time.Now, except in the tests.
+	now func() time.Time
+
+	mu          sync.Mutex
+	windowStart time.Time
+	current     map[string]*hotQuery
+	previous    map[string]*hotQuery
+}
+
+// newHotQueries creates a hotQueries that keeps size fingerprints, 0
+// to disable it, and counts 1 of sampleRate queries.
+func newHotQueries(size int, window time.Duration, sampleRate int) *hotQueries {
+	if sampleRate < 1 {
+		sampleRate = 1
+	}
+	return &hotQueries{
+		size:        size,
+		window:      window,
+		sampleRate:  int64(sampleRate),
+		now:         time.Now,
+		windowStart: time.Now(),
+		current:     make(map[string]*hotQuery),
+		previous:    make(map[string]*hotQuery),
+	}
+}
+
+// Record counts a SELECT query that was executed successfully. The
+// queries with redacted bind variables are not counted: their values
+// can't be sent to the other tablets.
+func (hq *hotQueries) Record(sql string, bindVars map[string]interface{}) {
+	if hq.size <= 0 || hq.calls.Add(1)%hq.sampleRate != 0 {
+		return
+	}
+	for name := range bindVars {
+		if isRedactedBindVariable(name) {
+			return
+		}
+	}
+	fingerprint := sqlparser.QueryFingerprint(sql)
+
+	hq.mu.Lock()
+	defer hq.mu.Unlock()
+	hq.rotate()
+	q, ok := hq.current[fingerprint]
+	if !ok {
+		if len(hq.current) >= hq.size*hotQueriesTracked {
+			return
+		}
+		q = &hotQuery{sql: sql, bindVars: make(map[string]interface{}, len(bindVars))}
+		for name, value := range bindVars {
+			if name != trailingComment {
+				q.bindVars[name] = value
+			}
+		}
+		hq.current[fingerprint] = q
+	}
+	q.count++
+}
+
+// rotate starts a new window if the current one is over.
+// hq.mu must be held.
+func (hq *hotQueries) rotate() {
+	now := hq.now()
+	elapsed := now.Sub(hq.windowStart)
+	if elapsed < hq.window {
+		return
+	}
+	if elapsed < 2*hq.window {
+		hq.previous = hq.current
+	} else {
+		hq.previous = make(map[string]*hotQuery)
+	}
+	hq.current = make(map[string]*hotQuery)
+	hq.windowStart = now
+}
+
+// Top returns the hot queries, the most executed first.
+func (hq *hotQueries) Top() []querytypes.BoundQuery {
+	hq.mu.Lock()
+	defer hq.mu.Unlock()
+	hq.rotate()
+
+	all := make(byHotCount, 0, len(hq.current)+len(hq.previous))
+	for fingerprint, q := range hq.current {
+		count := q.count
+		if prev, ok := hq.previous[fingerprint]; ok {
+			count += prev.count
+		}
+		all = append(all, countedHotQuery{fingerprint, count, q})
+	}
+	for fingerprint, q := range hq.previous {
+		if _, ok := hq.current[fingerprint]; !ok {
+			all = append(all, countedHotQuery{fingerprint, q.count, q})
+		}
+	}
+	sort.Sort(all)
+	if len(all) > hq.size {
+		all = all[:hq.size]
+	}
+	queries := make([]querytypes.BoundQuery, 0, len(all))
+	for _, c := range all {
+		queries = append(queries, querytypes.BoundQuery{Sql: c.query.sql, BindVariables: c.query.bindVars})
+	}
+	return queries
+}
+
+// countedHotQuery is a hotQuery with its count over both windows.
+type countedHotQuery struct {
+	fingerprint string
+	count       int64
+	query       *hotQuery
+}
+
+// byHotCount sorts the most executed queries first, and then by
+// fingerprint.
+type byHotCount []countedHotQuery
+
+func (a byHotCount) Len() int      { return len(a) }
+func (a byHotCount) Swap(i, j int) { a[i], a[j] = a[j], a[i] }
+func (a byHotCount) Less(i, j int) bool {
+	if a[i].count != a[j].count {
+		return a[i].count > a[j].count
+	}
+	return a[i].fingerprint < a[j].fingerprint
+}
@@ -0,0 +1,79 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tabletserver
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/youtube/vitess/go/vt/tabletserver/querytypes"
+)
+
+func TestHotQueries(t *testing.T) {
+	now := time.Now()
+	hq := newHotQueries(2, time.Minute, 1)
+	hq.now = func() time.Time { return now }
+	hq.windowStart = now
+
+	for i := 0; i < 3; i++ {
+		hq.Record("select * from a where id = :id", map[string]interface{}{"id": int64(i), trailingComment: "/* c */"})
+	}
+	hq.Record("select * from b where id = 1", nil)
+	hq.Record("select * from b where id = 2", nil)
+	hq.Record("select * from c", nil)
+	want := []querytypes.BoundQuery{
+		{Sql: "select * from a where id = :id", BindVariables: map[string]interface{}{"id": int64(0)}},
+		{Sql: "select * from b where id = 1", BindVariables: map[string]interface{}{}},
+	}
+	if got := hq.Top(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Top: %v, want %v", got, want)
+	}
+
+	// The counts of the previous window are added to the current one.
+	now = now.Add(time.Minute)
+	for i := 0; i < 5; i++ {
+		hq.Record("select * from c", nil)
+	}
+	want = []querytypes.BoundQuery{
+		{Sql: "select * from c", BindVariables: map[string]interface{}{}},
+		{Sql: "select * from a where id = :id", BindVariables: map[string]interface{}{"id": int64(0)}},
+	}
+	if got := hq.Top(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Top in the next window: %v, want %v", got, want)
+	}
+
+	// After two idle windows, nothing is left.
+	now = now.Add(2 * time.Minute)
+	if got := hq.Top(); len(got) != 0 {
+		t.Errorf("Top after two windows: %v, want none", got)
+	}
+}
+
+func TestHotQueriesRedactedAndSampled(t *testing.T) {
+	if err := SetBindVariableRedactPatterns("*password*"); err != nil {
+		t.Fatal(err)
+	}
+	defer SetBindVariableRedactPatterns("")
+	hq := newHotQueries(10, time.Minute, 1)
+	hq.Record("select * from users where password = :password", map[string]interface{}{"password": "secret"})
+	if got := hq.Top(); len(got) != 0 {
+		t.Errorf("Top with a redacted bind variable: %v, want none", got)
+	}
+
+	hq = newHotQueries(10, time.Minute, 3)
+	for i := 0; i < 5; i++ {
+		hq.Record("select 1", nil)
+	}
+	if got := hq.current["select ?"]; got == nil || got.count != 1 {
+		t.Errorf("sampled count: %+v, want 1", got)
+	}
+
+	hq = newHotQueries(0, time.Minute, 1)
+	hq.Record("select 1", nil)
+	if got := hq.Top(); len(got) != 0 {
+		t.Errorf("Top when disabled: %v, want none", got)
+	}
+}
@@ -7,22 +7,74 @@ package tabletserver
 import (
 	"encoding/json"
 	"fmt"
+	"hash/fnv"
 	"html/template"
 	"net/url"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
 
 	log "github.com/golang/glog"
+	"github.com/youtube/vitess/go/ratelimiter"
 	"github.com/youtube/vitess/go/sqltypes"
+	"github.com/youtube/vitess/go/stats"
 	"github.com/youtube/vitess/go/streamlog"
+	"github.com/youtube/vitess/go/sync2"
 	"github.com/youtube/vitess/go/vt/callerid"
 	"github.com/youtube/vitess/go/vt/callinfo"
+	"github.com/youtube/vitess/go/vt/logutil"
+	"github.com/youtube/vitess/go/vt/sqlparser"
+	"github.com/youtube/vitess/go/vt/tabletserver/querytypes"
 	"golang.org/x/net/context"
+
+	querypb "github.com/youtube/vitess/go/vt/proto/query"
+	querylogpb "github.com/youtube/vitess/go/vt/proto/querylog"
 )
 
 // StatsLogger is the main stream logger object
 var StatsLogger = streamlog.New("TabletServer", 50)
 
+// slowQueryThreshold is the minimum duration of a query for it to be
+// sent to StatsLogger. Queries that fail are always sent.
+var slowQueryThreshold sync2.AtomicDuration
+
+var (
+	// queryLogSampleRate is the rate at which successful queries
+	// are sent to StatsLogger: about one in queryLogSampleRate is
+	// sent. Values lower than 2 disable sampling. It's set with
+	// setQueryLogSampleRate, the sampling is done by StatsLogger.
+	queryLogSampleRate sync2.AtomicInt64
+	// deadlineExceededCount counts the queries whose context
+	// deadline was exceeded.
+	deadlineExceededCount sync2.AtomicInt64
+)
+
+var (
+	// querySourceCounts counts the queries by source: MySQL,
+	// Rowcache and Consolidator. A query with several sources is
+	// counted once for each of them, and a query without a source
+	// is counted in None.
+	querySourceCounts = stats.NewCounters("")
+	// querySourceCountsByPlan counts them by source and plan type,
+	// if -query_source_counts_by_plan is set.
+	querySourceCountsByPlan = stats.NewMultiCounters("", []string{"Source", "PlanType"})
+)
+
+var (
+	// slowQueryGlogThreshold is the duration above which queries are
+	// also logged to glog, regardless of the query log settings.
+	// 0 disables it. It's set by Init.
+	slowQueryGlogThreshold sync2.AtomicDuration
+	// slowQueryGlogLimiter limits the number of slow queries logged
+	// to glog per second.
+	slowQueryGlogLimiter *ratelimiter.RateLimiter
+	// slowQueryGlogSuppressed counts the slow queries that were not
+	// logged to glog because of the rate limit.
+	slowQueryGlogSuppressed sync2.AtomicInt64
+)
+
 const (
 	// QuerySourceRowcache means query result is found in rowcache.
 	QuerySourceRowcache = 1 << iota
@@ -38,7 +90,7 @@ type LogStats struct {
 	PlanType             string
 	OriginalSQL          string
 	BindVariables        map[string]interface{}
-	rewrittenSqls        []string
+	rewrittenSqls        []rewrittenSQL
 	RowsAffected         int
 	NumberOfQueries      int
 	StartTime            time.Time
@@ -51,23 +103,241 @@ type LogStats struct {
 	CacheInvalidations   int64
 	QuerySources         byte
 	Rows                 [][]sqltypes.Value
+	StreamedRows         int
+	StreamedSize         int
 	TransactionID        int64
 	ctx                  context.Context
 	Error                *TabletError
+	// ForceLog sends the record to StatsLogger even if it's
+	// faster than the slow query threshold.
+	ForceLog bool
+	// Fingerprint is the normalized form of OriginalSQL, which
+	// can be used to aggregate queries by shape. It's computed
+	// by Send.
+	Fingerprint string
+	// MysqlErrno and MysqlState are the MySQL error number and
+	// SQLSTATE of Error, if any. They're set by Send.
+	MysqlErrno int
+	MysqlState string
+	// TableNames are the tables of the schema referenced by the
+	// query plan.
+	TableNames []string
+	// TableHits counts the accesses to each table of the schema
+	// the query referenced. See RecordTableAccess.
+	TableHits map[string]int64
+	// ContextDeadlineExceeded is set by Finish if the deadline of
+	// the query's context was exceeded when the query finished.
+	ContextDeadlineExceeded bool
+	// SemiSyncFallback is set by Commit if -wait_for_semi_sync is on
+	// and MySQL was not waiting for semi-sync acknowledgments
+	// anymore when the transaction was committed.
+	SemiSyncFallback bool
+	// PerfSchema is set if -enrich_from_perf_schema is on, and the
+	// stats of the statements were found in performance_schema.
+	PerfSchema *PerfSchemaStats
+	// SchemaValidationError is set if -schema_pre_validation is on,
+	// and the query failed it.
+	SchemaValidationError string
+	// Keyspace, Shard and TabletAlias identify the tablet that
+	// served the query. They're empty if the tablet doesn't know
+	// them.
+	Keyspace    string
+	Shard       string
+	TabletAlias string
+	// ConnPoolWaitTime and TxPoolWaitTime are the times spent
+	// waiting for a connection of the connection pools and of the
+	// transaction pool. WaitingForConnection is their sum: use
+	// addConnPoolWait and addTxPoolWait to update them.
+	ConnPoolWaitTime time.Duration
+	TxPoolWaitTime   time.Duration
+	// connPoolWaits and txPoolWaits count the connections the query
+	// waited for, in ConnPoolWaitTime and TxPoolWaitTime.
+	connPoolWaits int
+	txPoolWaits   int
+	// Deadline is the deadline of the context of the query when the
+	// record was created, zero if it had none.
+	Deadline time.Time
+	// QueryID identifies the record in the query log. With
+	// -query_id_comment, it's also in a trailing comment of the queries
+	// sent to MySQL, to find them in the MySQL slow log.
+	QueryID string
+	// sent is set by Send if the record was sent to StatsLogger.
+	sent bool
+}
+
+// PerfSchemaStats are the execution details of the statements of a
+// query, as recorded by MySQL in
+// performance_schema.events_statements_history_long. They're summed
+// over the statements sent to MySQL for the query.
+type PerfSchemaStats struct {
+	RowsExamined         int64
+	CreatedTmpDiskTables int64
+	CreatedTmpTables     int64
+	SelectFullJoin       int64
+	SelectScan           int64
+	SortRows             int64
+	NoIndexUsed          int64
 }
 
 func newLogStats(methodName string, ctx context.Context) *LogStats {
-	return &LogStats{
-		Method:    methodName,
-		StartTime: time.Now(),
-		ctx:       ctx,
+	stats := logStatsPool.Get().(*LogStats)
+	stats.Method = methodName
+	stats.StartTime = time.Now()
+	stats.QueryID = newQueryID()
+	stats.ctx = ctx
+	if ctx != nil {
+		if deadline, ok := ctx.Deadline(); ok {
+			stats.Deadline = deadline
+		}
 	}
+	return stats
 }
 
-// Send finalizes a record and sends it
-func (stats *LogStats) Send() {
+var (
+	// queryIDPrefix is the start time of the process, which makes the
+	// query IDs unique across restarts.
+	queryIDPrefix = strconv.FormatInt(time.Now().UnixNano(), 16) + "-"
+	// lastQueryID is the counter of the query IDs of the process.
+	lastQueryID sync2.AtomicInt64
+)
+
+// newQueryID returns a query ID that is unique on the host: the start
+// time of the process and a counter, in hexadecimal.
+func newQueryID() string {
+	return queryIDPrefix + strconv.FormatInt(lastQueryID.Add(1), 16)
+}
+
+// logStatsPool recycles the records that were not sent to StatsLogger.
+// See release.
+var logStatsPool = sync.Pool{
+	New: func() interface{} {
+		return &LogStats{}
+	},
+}
+
+// Reset clears all the fields of stats. It keeps the memory of the
+// rewritten SQL list, for the next query.
+func (stats *LogStats) Reset() {
+	rewrittenSqls := stats.rewrittenSqls
+	for i := range rewrittenSqls {
+		rewrittenSqls[i] = rewrittenSQL{}
+	}
+	*stats = LogStats{rewrittenSqls: rewrittenSqls[:0]}
+}
+
+// release returns stats to the pool after Send, unless it was sent to
+// StatsLogger: the subscribers read the records asynchronously, and
+// -querylog-dedup-limit keeps the last duplicate of each query, so a
+// sent record is never reused. stats must not be used after release.
+func (stats *LogStats) release() {
+	if stats.sent {
+		return
+	}
+	stats.Reset()
+	logStatsPool.Put(stats)
+}
+
+// Finish records that the query finished: it sets EndTime, and
+// ContextDeadlineExceeded if the deadline of the context had passed by
+// then. Only the first call counts, Send calls it if the query
+// handler didn't.
+func (stats *LogStats) Finish() {
+	if !stats.EndTime.IsZero() {
+		return
+	}
 	stats.EndTime = time.Now()
-	StatsLogger.Send(stats)
+	if !stats.Deadline.IsZero() && !stats.EndTime.Before(stats.Deadline) {
+		stats.ContextDeadlineExceeded = true
+		deadlineExceededCount.Add(1)
+	}
+}
+
+// Send finalizes a record and sends it. Records of successful queries
+// that took less than the slow query threshold or that are not
+// sampled are dropped, unless ForceLog is set. The fingerprint is only
+// computed for the records that are sent. The records that are not
+// sent can be recycled with release.
+func (stats *LogStats) Send() {
+	stats.Finish()
+	stats.countQuerySources()
+	if threshold := slowQueryGlogThreshold.Get(); threshold > 0 && stats.TotalTime() > threshold {
+		stats.logSlowQuery()
+	}
+	if !stats.ForceLog && stats.Error == nil {
+		if stats.TotalTime() < slowQueryThreshold.Get() {
+			return
+		}
+		if !StatsLogger.Sample(stats) {
+			return
+		}
+	}
+	stats.Fingerprint = sqlparser.QueryFingerprint(stats.OriginalSQL)
+	if stats.Error != nil {
+		stats.MysqlErrno = stats.Error.SQLError
+		stats.MysqlState = stats.Error.SQLState
+	}
+	stats.sent = true
+	StatsLogger.SendSampled(stats)
+}
+
+// countQuerySources adds the query to querySourceCounts, and to
+// querySourceCountsByPlan if -query_source_counts_by_plan is set.
+func (stats *LogStats) countQuerySources() {
+	var sources []string
+	if stats.QuerySources&QuerySourceMySQL != 0 {
+		sources = append(sources, "MySQL")
+	}
+	if stats.QuerySources&QuerySourceRowcache != 0 {
+		sources = append(sources, "Rowcache")
+	}
+	if stats.QuerySources&QuerySourceConsolidator != 0 {
+		sources = append(sources, "Consolidator")
+	}
+	if len(sources) == 0 {
+		sources = append(sources, "None")
+	}
+	for _, source := range sources {
+		querySourceCounts.Add(source, 1)
+		if *countQuerySourcesByPlan {
+			querySourceCountsByPlan.Add([]string{source, stats.PlanType}, 1)
+		}
+	}
+}
+
+// logSlowQuery logs the query as a glog warning, unless too many slow
+// queries were logged in the last second. The SQL is redacted.
+func (stats *LogStats) logSlowQuery() {
+	if !slowQueryGlogLimiter.Allow() {
+		slowQueryGlogSuppressed.Add(1)
+		return
+	}
+	log.Warningf("Slow query: method %v, plan type %v, caller %v (%v), duration %v, mysql time %v: %s",
+		stats.Method,
+		stats.PlanType,
+		stats.EffectiveCaller(),
+		stats.ImmediateCaller(),
+		stats.TotalTime(),
+		stats.MysqlResponseTime,
+		stats.redactSQL(stats.OriginalSQL))
+}
+
+// setQueryLogSampleRate makes StatsLogger send about one in rate
+// successful queries. Values lower than 2 disable sampling. The failed
+// queries, and the queries slower than the slow threshold of
+// StatsLogger, are always sent.
+func setQueryLogSampleRate(rate int) {
+	queryLogSampleRate.Set(int64(rate))
+	if rate < 2 {
+		StatsLogger.SetSamplingRate(1)
+		return
+	}
+	StatsLogger.SetSamplingRate(1 / float64(rate))
+}
+
+// TabletIdentity returns the keyspace, shard and alias of the tablet.
+// It's used by the streamlog filters.
+func (stats *LogStats) TabletIdentity() (string, string, string) {
+	return stats.Keyspace, stats.Shard, stats.TabletAlias
 }
 
 // ImmediateCaller returns the immediate caller stored in LogStats.ctx
@@ -89,8 +359,32 @@ func (stats *LogStats) EventTime() time.Time {
 func (stats *LogStats) AddRewrittenSQL(sql string, start time.Time) {
 	stats.QuerySources |= QuerySourceMySQL
 	stats.NumberOfQueries++
-	stats.rewrittenSqls = append(stats.rewrittenSqls, sql)
-	stats.MysqlResponseTime += time.Now().Sub(start)
+	duration := time.Now().Sub(start)
+	stats.rewrittenSqls = append(stats.rewrittenSqls, rewrittenSQL{sql: sql, duration: duration})
+	stats.MysqlResponseTime += duration
+}
+
+// addConnPoolWait adds d, the time spent waiting for a connection of
+// the connection pools, to ConnPoolWaitTime and WaitingForConnection.
+func (stats *LogStats) addConnPoolWait(d time.Duration) {
+	stats.ConnPoolWaitTime += d
+	stats.WaitingForConnection += d
+	stats.connPoolWaits++
+}
+
+// addTxPoolWait adds d, the time spent waiting for a connection of the
+// transaction pool, to TxPoolWaitTime and WaitingForConnection.
+func (stats *LogStats) addTxPoolWait(d time.Duration) {
+	stats.TxPoolWaitTime += d
+	stats.WaitingForConnection += d
+	stats.txPoolWaits++
+}
+
+// rewrittenSQL is a statement sent to MySQL for a query, and how
+// long MySQL took to execute it.
+type rewrittenSQL struct {
+	sql      string
+	duration time.Duration
 }
 
 // TotalTime returns how long this query has been running
@@ -99,20 +393,83 @@ func (stats *LogStats) TotalTime() time.Duration {
 }
 
 // RewrittenSQL returns a semicolon separated list of SQL statements
-// that were executed.
+// that were executed. They are redacted if one of the bind variables
+// matches the redact patterns, see loggedSQL.
 func (stats *LogStats) RewrittenSQL() string {
-	return strings.Join(stats.rewrittenSqls, "; ")
+	_, sqls := stats.loggedSQL(false)
+	return strings.Join(sqls, "; ")
+}
+
+// RewrittenSQLTimings returns the SQL statements that were executed,
+// each followed by how long it took, as "sql:duration".
+// MysqlResponseTime is the sum of these durations.
+func (stats *LogStats) RewrittenSQLTimings() []string {
+	_, sqls := stats.loggedSQL(false)
+	return stats.formatTimings(sqls)
+}
+
+// RewrittenSQLTimes returns how long each of the SQL statements of
+// RewrittenSQL took, in the same order.
+func (stats *LogStats) RewrittenSQLTimes() []time.Duration {
+	times := make([]time.Duration, 0, len(stats.rewrittenSqls))
+	for _, rs := range stats.rewrittenSqls {
+		times = append(times, rs.duration)
+	}
+	return times
+}
+
+// formatTimings appends their duration to the rewritten SQL
+// statements sqls, which may be redacted.
+func (stats *LogStats) formatTimings(sqls []string) []string {
+	timings := make([]string, 0, len(sqls))
+	for i, sql := range sqls {
+		timings = append(timings, fmt.Sprintf("%s:%v", sql, stats.rewrittenSqls[i].duration))
+	}
+	return timings
+}
+
+// rewrittenSQLTimingJSON is the JSON representation of a rewritten
+// SQL statement and its duration, in seconds.
+type rewrittenSQLTimingJSON struct {
+	SQL  string
+	Time float64
+}
+
+// jsonTimings pairs the rewritten SQL statements sqls, which may be
+// redacted, with their duration.
+func (stats *LogStats) jsonTimings(sqls []string) []rewrittenSQLTimingJSON {
+	timings := make([]rewrittenSQLTimingJSON, 0, len(sqls))
+	for i, sql := range sqls {
+		timings = append(timings, rewrittenSQLTimingJSON{SQL: sql, Time: stats.rewrittenSqls[i].duration.Seconds()})
+	}
+	return timings
 }
 
 // SizeOfResponse returns the approximate size of the response in
-// bytes (this does not take in account protocol encoding). It will return
-// 0 for streaming requests.
+// bytes (this does not take in account protocol encoding), buffered
+// or streamed.
 func (stats *LogStats) SizeOfResponse() int {
-	if stats.Rows == nil {
-		return 0
-	}
+	return sizeOfRows(stats.Rows) + stats.StreamedSize
+}
+
+// RowsReturned returns the number of rows of the response, buffered or
+// streamed.
+func (stats *LogStats) RowsReturned() int {
+	return len(stats.Rows) + stats.StreamedRows
+}
+
+// addStreamedResult adds a result sent by a streaming query to the
+// streamed rows and size. The results are sent one at a time by the
+// goroutine of the query, so it needs no lock.
+func (stats *LogStats) addStreamedResult(result *sqltypes.Result) {
+	stats.StreamedRows += len(result.Rows)
+	stats.StreamedSize += sizeOfRows(result.Rows)
+}
+
+// sizeOfRows returns the total length of the values of rows.
+func sizeOfRows(rows [][]sqltypes.Value) int {
 	size := 0
-	for _, row := range stats.Rows {
+	for _, row := range rows {
 		for _, field := range row {
 			size += field.Len()
 		}
@@ -120,34 +477,173 @@ func (stats *LogStats) SizeOfResponse() int {
 	return size
 }
 
-// FmtBindVariables returns the map of bind variables as JSON. For
-// values that are strings or byte slices it only reports their type
-// and length.
-func (stats *LogStats) FmtBindVariables(full bool) string {
-	var out map[string]interface{}
-	if full {
-		out = stats.BindVariables
-	} else {
-		// NOTE(szopa): I am getting rid of potentially large bind
-		// variables.
-		out = make(map[string]interface{})
+// SizeOfRequest returns the approximate size of the request in
+// bytes: the length of the original SQL plus the size of the bind
+// variables.
+func (stats *LogStats) SizeOfRequest() int {
+	size := len(stats.OriginalSQL)
+	for _, v := range stats.BindVariables {
+		size += sizeOfBindVariable(v)
+	}
+	return size
+}
+
+// sizeOfBindVariable returns the approximate size of a bind variable
+// value in bytes. Strings and byte slices count for their length and
+// numbers for 8 bytes. Lists count for the size of their values.
+func sizeOfBindVariable(v interface{}) int {
+	switch val := v.(type) {
+	case nil:
+		return 0
+	case string:
+		return len(val)
+	case []byte:
+		return len(val)
+	case sqltypes.Value:
+		return val.Len()
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return 8
+	case []interface{}:
+		size := 0
+		for _, item := range val {
+			size += sizeOfBindVariable(item)
+		}
+		return size
+	}
+	return len(fmt.Sprintf("%v", v))
+}
+
+// BindVariableDisplayMode controls how much of the bind variable
+// values is shown in the query log.
+type BindVariableDisplayMode int
+
+const (
+	// BindVariablesCompact shows strings and byte slices as their
+	// type and length, and all other values as is.
+	BindVariablesCompact BindVariableDisplayMode = iota
+	// BindVariablesFull shows all the values.
+	BindVariablesFull
+	// BindVariablesRedact shows only the type of every value.
+	BindVariablesRedact
+)
+
+// bindVariableDisplayMode returns the display mode requested by the
+// "full" and "redact" params. Redaction takes precedence.
+func bindVariableDisplayMode(params url.Values) BindVariableDisplayMode {
+	if shouldRedact(params) {
+		return BindVariablesRedact
+	}
+	if _, ok := params["full"]; ok {
+		return BindVariablesFull
+	}
+	return BindVariablesCompact
+}
+
+// FmtBindVariables returns the map of bind variables as JSON,
+// displayed according to mode.
+func (stats *LogStats) FmtBindVariables(mode BindVariableDisplayMode) string {
+	bindVars := stats.logBindVariables(mode)
+	b, err := json.Marshal(bindVars)
+	if err != nil {
+		b, err = json.Marshal(stringifyUnmarshalable(bindVars))
+	}
+	if err != nil {
+		log.Warningf("could not marshal %q", stats.BindVariables)
+		return ""
+	}
+	// json.Marshal escapes the angle brackets of the redacted values.
+	return strings.Replace(string(b), redactedBindVariableJSON, `"`+redactedBindVariable+`"`, -1)
+}
+
+// logBindVariables returns the bind variables the way they should be
+// logged in the given mode. The bind variables whose names match the
+// redact patterns are redacted in all modes.
+func (stats *LogStats) logBindVariables(mode BindVariableDisplayMode) map[string]interface{} {
+	switch mode {
+	case BindVariablesFull:
+		if !stats.hasRedactedBindVariables() {
+			return stats.BindVariables
+		}
+		out := make(map[string]interface{}, len(stats.BindVariables))
+		for k, v := range stats.BindVariables {
+			if isRedactedBindVariable(k) {
+				out[k] = redactedBindVariable
+				continue
+			}
+			out[k] = v
+		}
+		return out
+	case BindVariablesRedact:
+		out := make(map[string]interface{}, len(stats.BindVariables))
 		for k, v := range stats.BindVariables {
-			switch val := v.(type) {
+			if isRedactedBindVariable(k) {
+				out[k] = redactedBindVariable
+				continue
+			}
+			switch v.(type) {
 			case string:
-				out[k] = fmt.Sprintf("string %v", len(val))
+				out[k] = "string"
 			case []byte:
-				out[k] = fmt.Sprintf("bytes %v", len(val))
+				out[k] = "bytes"
 			default:
-				out[k] = v
+				out[k] = fmt.Sprintf("%T", v)
 			}
 		}
+		return out
 	}
-	b, err := json.Marshal(out)
-	if err != nil {
-		log.Warningf("could not marshal %q", stats.BindVariables)
-		return ""
+	// NOTE(szopa): I am getting rid of potentially large bind
+	// variables.
+	maxShown := *queryLogShowShortBindVariables
+	out := make(map[string]interface{})
+	for k, v := range stats.BindVariables {
+		if isRedactedBindVariable(k) {
+			out[k] = redactedBindVariable
+			continue
+		}
+		switch val := v.(type) {
+		case string:
+			if maxShown > 0 && len(val) <= maxShown {
+				out[k] = val
+				continue
+			}
+			out[k] = fmt.Sprintf("string %v", len(val))
+		case []byte:
+			if maxShown > 0 && len(val) <= maxShown {
+				out[k] = string(val)
+				continue
+			}
+			out[k] = fmt.Sprintf("bytes %v", len(val))
+		default:
+			out[k] = v
+		}
 	}
-	return string(b)
+	return out
+}
+
+// hasRedactedBindVariables returns true if the name of one of the bind
+// variables matches the redact patterns.
+func (stats *LogStats) hasRedactedBindVariables() bool {
+	for k := range stats.BindVariables {
+		if isRedactedBindVariable(k) {
+			return true
+		}
+	}
+	return false
+}
+
+// stringifyUnmarshalable returns a copy of bindVars where the values
+// that cannot be marshaled to JSON are replaced by their string
+// representation.
+func stringifyUnmarshalable(bindVars map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(bindVars))
+	for k, v := range bindVars {
+		if _, err := json.Marshal(v); err != nil {
+			out[k] = fmt.Sprintf("%v", v)
+			continue
+		}
+		out[k] = v
+	}
+	return out
 }
 
 // FmtQuerySources returns a comma separated list of query
@@ -157,21 +653,22 @@ func (stats *LogStats) FmtQuerySources() string {
 	if stats.QuerySources == 0 {
 		return "none"
 	}
-	sources := make([]string, 3)
-	n := 0
+	return strings.Join(stats.querySources(), ",")
+}
+
+// querySources returns the list of query sources.
+func (stats *LogStats) querySources() []string {
+	sources := make([]string, 0, 3)
 	if stats.QuerySources&QuerySourceMySQL != 0 {
-		sources[n] = "mysql"
-		n++
+		sources = append(sources, "mysql")
 	}
 	if stats.QuerySources&QuerySourceRowcache != 0 {
-		sources[n] = "rowcache"
-		n++
+		sources = append(sources, "rowcache")
 	}
 	if stats.QuerySources&QuerySourceConsolidator != 0 {
-		sources[n] = "consolidator"
-		n++
+		sources = append(sources, "consolidator")
 	}
-	return strings.Join(sources[:n], ",")
+	return sources
 }
 
 // ContextHTML returns the HTML version of the context that was used, or "".
@@ -181,6 +678,27 @@ func (stats *LogStats) ContextHTML() template.HTML {
 	return callinfo.HTMLFromContext(stats.ctx)
 }
 
+// RecordTableAccess adds n accesses to table.
+func (stats *LogStats) RecordTableAccess(table string, n int64) {
+	if stats.TableHits == nil {
+		stats.TableHits = make(map[string]int64)
+	}
+	stats.TableHits[table] += n
+}
+
+// FmtTableHits returns the table hits as JSON, or "{}".
+func (stats *LogStats) FmtTableHits() string {
+	if len(stats.TableHits) == 0 {
+		return "{}"
+	}
+	b, err := json.Marshal(stats.TableHits)
+	if err != nil {
+		log.Warningf("could not marshal %v", stats.TableHits)
+		return "{}"
+	}
+	return string(b)
+}
+
 // ErrorStr returns the error string or ""
 func (stats *LogStats) ErrorStr() string {
 	if stats.Error != nil {
@@ -189,6 +707,128 @@ func (stats *LogStats) ErrorStr() string {
 	return ""
 }
 
+// loggedError returns the error the way it should be logged. If
+// redact is true, the literals of the query in the MySQL errors are
+// replaced by "?".
+func (stats *LogStats) loggedError(redact bool) string {
+	if redact {
+		return redactErrorQuery(stats.ErrorStr(), stats.PlanType)
+	}
+	return stats.ErrorStr()
+}
+
+// ErrorCode returns the error code of the error, e.g. BAD_INPUT, or "".
+func (stats *LogStats) ErrorCode() string {
+	if stats.Error != nil {
+		return stats.Error.ErrorCode.String()
+	}
+	return ""
+}
+
+// HasError returns true if the query failed. It lets streamlog
+// subscribers receive only the failed queries.
+func (stats *LogStats) HasError() bool {
+	return stats.Error != nil
+}
+
+// MethodName returns the method that was called, e.g. Execute. It
+// lets streamlog subscribers filter the queries by method.
+func (stats *LogStats) MethodName() string {
+	return stats.Method
+}
+
+// DedupKey returns the plan type and the original SQL. The queries
+// with the same ones are duplicates for -querylog-dedup-limit.
+func (stats *LogStats) DedupKey() string {
+	return stats.PlanType + "\x00" + stats.OriginalSQL
+}
+
+// PlanTypeName returns the type of the plan, e.g. PASS_SELECT. It lets
+// streamlog subscribers filter the queries by plan type.
+func (stats *LogStats) PlanTypeName() string {
+	return stats.PlanType
+}
+
+// loggedSQL returns the original SQL and the rewritten SQL statements
+// the way they should be logged. If redact is true, their literals are
+// replaced by "?". The rewritten statements are also redacted if one
+// of the bind variables matches the redact patterns, since their
+// values are inlined there.
+func (stats *LogStats) loggedSQL(redact bool) (string, []string) {
+	redactRewritten := redact || stats.hasRedactedBindVariables()
+	var rewritten []string
+	for _, rs := range stats.rewrittenSqls {
+		if redactRewritten {
+			rewritten = append(rewritten, stats.redactSQL(rs.sql))
+		} else {
+			rewritten = append(rewritten, rs.sql)
+		}
+	}
+	if redact {
+		return stats.redactSQL(stats.OriginalSQL), rewritten
+	}
+	return stats.OriginalSQL, rewritten
+}
+
+// truncateLoggedSQL cuts the SQL statements returned by loggedSQL to
+// -querylog-max-sql-len bytes. The LogStats keeps the full statements.
+func truncateLoggedSQL(originalSQL string, rewrittenSQLs []string) (string, []string) {
+	truncated := make([]string, 0, len(rewrittenSQLs))
+	for _, sql := range rewrittenSQLs {
+		truncated = append(truncated, truncateSQL(sql, *queryLogMaxSQLLen))
+	}
+	return truncateSQL(originalSQL, *queryLogMaxSQLLen), truncated
+}
+
+// truncateSQL returns the first maxLen bytes of sql followed by a
+// marker with the number of bytes that were cut, if sql is longer
+// than maxLen. It doesn't split the UTF-8 sequences, so it may keep a
+// few bytes less. 0 means no limit.
+func truncateSQL(sql string, maxLen int) string {
+	if maxLen <= 0 || len(sql) <= maxLen {
+		return sql
+	}
+	cut := maxLen
+	for cut > 0 && !utf8.RuneStart(sql[cut]) {
+		cut--
+	}
+	return fmt.Sprintf("%s... [truncated %d bytes]", sql[:cut], len(sql)-cut)
+}
+
+// redactSQL replaces the literals in sql by "?". If sql can't be
+// parsed, it returns the plan type and a hash of sql instead, so that
+// the raw text never makes it to the logs.
+func (stats *LogStats) redactSQL(sql string) string {
+	return redactSQL(sql, stats.PlanType)
+}
+
+// redactSQL is LogStats.redactSQL, for the queries of the given plan
+// type.
+func redactSQL(sql, planType string) string {
+	redacted, err := sqlparser.RedactSQLQuery(sql)
+	if err != nil {
+		h := fnv.New64a()
+		h.Write([]byte(sql))
+		return fmt.Sprintf("%s:%016x", planType, h.Sum64())
+	}
+	return redacted
+}
+
+// duringQuery precedes the query in the MySQL errors, see
+// sqldb.SQLError.
+const duringQuery = " during query: "
+
+// redactErrorQuery redacts the query at the end of the error message
+// msg, if any, like redactSQL.
+func redactErrorQuery(msg, planType string) string {
+	i := strings.Index(msg, duringQuery)
+	if i < 0 {
+		return msg
+	}
+	i += len(duringQuery)
+	return msg[:i] + redactSQL(msg[i:], planType)
+}
+
 // RemoteAddrUsername returns some parts of CallInfo if set
 func (stats *LogStats) RemoteAddrUsername() (string, string) {
 	ci, ok := callinfo.FromContext(stats.ctx)
@@ -198,14 +838,69 @@ func (stats *LogStats) RemoteAddrUsername() (string, string) {
 	return ci.RemoteAddr(), ci.Username()
 }
 
-// Format returns a tab separated list of logged fields.
+// DeadlineBudget returns the time the client gave the query, between
+// StartTime and the deadline of its context, and false if the context
+// had no deadline.
+func (stats *LogStats) DeadlineBudget() (time.Duration, bool) {
+	if stats.Deadline.IsZero() {
+		return 0, false
+	}
+	return stats.Deadline.Sub(stats.StartTime), true
+}
+
+// DeadlineRemaining returns the time that was left before the deadline
+// of the context at EndTime, negative if it was exceeded, and false if
+// the context had no deadline.
+func (stats *LogStats) DeadlineRemaining() (time.Duration, bool) {
+	if stats.Deadline.IsZero() {
+		return 0, false
+	}
+	return stats.Deadline.Sub(stats.EndTime), true
+}
+
+// fmtDeadline formats a duration returned by DeadlineBudget or
+// DeadlineRemaining in seconds, or as "" if there's no deadline.
+func fmtDeadline(d time.Duration, ok bool) string {
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("%.6f", d.Seconds())
+}
+
+// jsonDeadline is fmtDeadline for the JSON format: nil if there's no
+// deadline.
+func jsonDeadline(d time.Duration, ok bool) *float64 {
+	if !ok {
+		return nil
+	}
+	seconds := d.Seconds()
+	return &seconds
+}
+
+// Format returns a tab separated list of logged fields. If the
+// "format" param is set to "json", it returns a JSON object instead,
+// and if it's set to "binary", a length-prefixed protobuf message.
+// If the "redact" param is set, or -redact-debug-ui-queries is on,
+// literals and bind variable values are removed from the output.
+//
+// A text record is always one line with the same number of columns:
+// the strings that come from the clients, MySQL or the topology are
+// quoted in Go syntax, the bind variables and table hits are compact
+// JSON, and the other columns never contain a tab or a newline. The
+// querylogparser package parses it back.
 func (stats *LogStats) Format(params url.Values) string {
-	_, fullBindParams := params["full"]
+	switch params.Get("format") {
+	case "json":
+		return stats.FormatJSON(params)
+	case streamlog.BinaryFormat:
+		return stats.FormatBinary(params)
+	}
+	originalSQL, rewrittenSQLs := truncateLoggedSQL(stats.loggedSQL(shouldRedact(params)))
 
 	// TODO: remove username here we fully enforce immediate caller id
 	remoteAddr, username := stats.RemoteAddrUsername()
 	return fmt.Sprintf(
-		"%v\t%v\t%v\t'%v'\t'%v'\t%v\t%v\t%.6f\t%v\t%q\t%v\t%v\t%q\t%v\t%.6f\t%.6f\t%v\t%v\t%v\t%v\t%v\t%v\t%q\t\n",
+		"%v\t%q\t%q\t%q\t%q\t%v\t%v\t%.6f\t%v\t%q\t%v\t%v\t%q\t%v\t%.6f\t%.6f\t%v\t%v\t%v\t%v\t%v\t%v\t%q\t%q\t%v\t%q\t%v\t%v\t%v\t%v\t%v\t%q\t%q\t%q\t%q\t%.6f\t%.6f\t%v\t%v\t%q\t%q\t\n",
 		stats.Method,
 		remoteAddr,
 		username,
@@ -215,10 +910,10 @@ func (stats *LogStats) Format(params url.Values) string {
 		stats.EndTime.Format(time.StampMicro),
 		stats.TotalTime().Seconds(),
 		stats.PlanType,
-		stats.OriginalSQL,
-		stats.FmtBindVariables(fullBindParams),
+		originalSQL,
+		stats.FmtBindVariables(bindVariableDisplayMode(params)),
 		stats.NumberOfQueries,
-		stats.RewrittenSQL(),
+		strings.Join(rewrittenSQLs, "; "),
 		stats.FmtQuerySources(),
 		stats.MysqlResponseTime.Seconds(),
 		stats.WaitingForConnection.Seconds(),
@@ -228,6 +923,320 @@ func (stats *LogStats) Format(params url.Values) string {
 		stats.CacheMisses,
 		stats.CacheAbsent,
 		stats.CacheInvalidations,
-		stats.ErrorStr(),
+		stats.loggedError(shouldRedact(params)),
+		stats.Fingerprint,
+		stats.MysqlErrno,
+		stats.MysqlState,
+		stats.ErrorCode(),
+		stats.FmtTableHits(),
+		stats.ContextDeadlineExceeded,
+		stats.SemiSyncFallback,
+		stats.SizeOfRequest(),
+		strings.Join(stats.formatTimings(rewrittenSQLs), "; "),
+		stats.Keyspace,
+		stats.Shard,
+		stats.TabletAlias,
+		stats.ConnPoolWaitTime.Seconds(),
+		stats.TxPoolWaitTime.Seconds(),
+		fmtDeadline(stats.DeadlineBudget()),
+		fmtDeadline(stats.DeadlineRemaining()),
+		stats.QueryID,
+		stats.SchemaValidationError,
 	)
 }
+
+// logStatsJSON is the JSON representation of LogStats.
+type logStatsJSON struct {
+	Method               string
+	RemoteAddr           string
+	Username             string
+	ImmediateCaller      string
+	EffectiveCaller      string
+	StartTime            time.Time
+	EndTime              time.Time
+	TotalTime            float64
+	PlanType             string
+	OriginalSQL          string
+	BindVariables        map[string]interface{}
+	NumberOfQueries      int
+	RewrittenSQL         []string
+	RewrittenSQLTimings  []rewrittenSQLTimingJSON
+	QuerySources         []string
+	MysqlResponseTime    float64
+	WaitingForConnection float64
+	ConnPoolWaitTime     float64
+	TxPoolWaitTime       float64
+	RowsAffected         int
+	RowsReturned         int
+	SizeOfResponse       int
+	SizeOfRequest        int
+	CacheHits            int64
+	CacheMisses          int64
+	CacheAbsent          int64
+	CacheInvalidations   int64
+	TransactionID        int64
+	Error                string
+	Fingerprint          string
+	MysqlErrno           int
+	MysqlState           string
+	ErrorCode            string
+	TableHits            map[string]int64
+	DeadlineExceeded     bool `json:"ContextDeadlineExceeded"`
+	SemiSyncFallback     bool
+	PerfSchema           *PerfSchemaStats `json:",omitempty"`
+	SchemaError          string           `json:"SchemaValidationError,omitempty"`
+	Keyspace             string
+	Shard                string
+	TabletAlias          string
+	QueryID              string
+	DeadlineBudget       *float64          `json:",omitempty"`
+	DeadlineRemaining    *float64          `json:",omitempty"`
+	DedupSummary         *dedupSummaryJSON `json:",omitempty"`
+}
+
+// dedupSummaryJSON is the JSON representation of a
+// streamlog.DedupSummary.
+type dedupSummaryJSON struct {
+	Suppressed int64
+	TotalTime  float64
+	StartTime  time.Time
+	EndTime    time.Time
+}
+
+// FormatJSON returns the logged fields as a single line JSON object.
+// Durations are reported in seconds and times in RFC 3339 format.
+// It honors the same params as Format.
+func (stats *LogStats) FormatJSON(params url.Values) string {
+	return stats.formatJSON(params, nil)
+}
+
+// formatJSON is FormatJSON, with the summary the record is part of,
+// if any.
+func (stats *LogStats) formatJSON(params url.Values, summary *streamlog.DedupSummary) string {
+	originalSQL, rewrittenSQLs := truncateLoggedSQL(stats.loggedSQL(shouldRedact(params)))
+	remoteAddr, username := stats.RemoteAddrUsername()
+	out := &logStatsJSON{
+		Method:               stats.Method,
+		RemoteAddr:           remoteAddr,
+		Username:             username,
+		ImmediateCaller:      stats.ImmediateCaller(),
+		EffectiveCaller:      stats.EffectiveCaller(),
+		StartTime:            stats.StartTime,
+		EndTime:              stats.EndTime,
+		TotalTime:            stats.TotalTime().Seconds(),
+		PlanType:             stats.PlanType,
+		OriginalSQL:          originalSQL,
+		BindVariables:        stats.logBindVariables(bindVariableDisplayMode(params)),
+		NumberOfQueries:      stats.NumberOfQueries,
+		RewrittenSQL:         rewrittenSQLs,
+		RewrittenSQLTimings:  stats.jsonTimings(rewrittenSQLs),
+		QuerySources:         stats.querySources(),
+		MysqlResponseTime:    stats.MysqlResponseTime.Seconds(),
+		WaitingForConnection: stats.WaitingForConnection.Seconds(),
+		ConnPoolWaitTime:     stats.ConnPoolWaitTime.Seconds(),
+		TxPoolWaitTime:       stats.TxPoolWaitTime.Seconds(),
+		RowsAffected:         stats.RowsAffected,
+		RowsReturned:         stats.RowsReturned(),
+		SizeOfResponse:       stats.SizeOfResponse(),
+		SizeOfRequest:        stats.SizeOfRequest(),
+		CacheHits:            stats.CacheHits,
+		CacheMisses:          stats.CacheMisses,
+		CacheAbsent:          stats.CacheAbsent,
+		CacheInvalidations:   stats.CacheInvalidations,
+		TransactionID:        stats.TransactionID,
+		Error:                stats.loggedError(shouldRedact(params)),
+		Fingerprint:          stats.Fingerprint,
+		MysqlErrno:           stats.MysqlErrno,
+		MysqlState:           stats.MysqlState,
+		ErrorCode:            stats.ErrorCode(),
+		TableHits:            stats.TableHits,
+		DeadlineExceeded:     stats.ContextDeadlineExceeded,
+		SemiSyncFallback:     stats.SemiSyncFallback,
+		PerfSchema:           stats.PerfSchema,
+		SchemaError:          stats.SchemaValidationError,
+		Keyspace:             stats.Keyspace,
+		Shard:                stats.Shard,
+		TabletAlias:          stats.TabletAlias,
+		DeadlineBudget:       jsonDeadline(stats.DeadlineBudget()),
+		DeadlineRemaining:    jsonDeadline(stats.DeadlineRemaining()),
+		QueryID:              stats.QueryID,
+	}
+	if summary != nil {
+		out.DedupSummary = &dedupSummaryJSON{
+			Suppressed: summary.Suppressed,
+			TotalTime:  summary.TotalTime.Seconds(),
+			StartTime:  summary.Start,
+			EndTime:    summary.End,
+		}
+	}
+	b, err := json.Marshal(out)
+	if err != nil {
+		// Retry with the bind variables that cannot be
+		// marshaled replaced by their string representation.
+		out.BindVariables = stringifyUnmarshalable(out.BindVariables)
+		b, err = json.Marshal(out)
+	}
+	if err != nil {
+		log.Warningf("could not marshal log stats for %q: %v", originalSQL, err)
+		return ""
+	}
+	return string(b) + "\n"
+}
+
+// QueryLogRecordVersion is the version of the querylogpb.LogStats
+// records that FormatBinary writes. It's incremented when the meaning
+// of existing fields changes, so that the readers of older files can
+// tell them apart. In version 2, the records without a deadline have
+// has_deadline unset, instead of -1 deadline durations.
+const QueryLogRecordVersion = 2
+
+// FormatBinary returns the logged fields as a querylogpb.LogStats,
+// serialized by streamlog.EncodeBinary. It honors the same params as
+// Format. The bind variables that cannot be converted to proto are
+// sent as their string representation.
+func (stats *LogStats) FormatBinary(params url.Values) string {
+	return stats.formatBinary(params, nil)
+}
+
+// formatBinary is FormatBinary, with the summary the record is part
+// of, if any.
+func (stats *LogStats) formatBinary(params url.Values, summary *streamlog.DedupSummary) string {
+	originalSQL, rewrittenSQLs := truncateLoggedSQL(stats.loggedSQL(shouldRedact(params)))
+	remoteAddr, username := stats.RemoteAddrUsername()
+	out := &querylogpb.LogStats{
+		Method:                stats.Method,
+		RemoteAddr:            remoteAddr,
+		Username:              username,
+		ImmediateCaller:       stats.ImmediateCaller(),
+		EffectiveCaller:       stats.EffectiveCaller(),
+		StartTime:             logutil.TimeToProto(stats.StartTime),
+		EndTime:               logutil.TimeToProto(stats.EndTime),
+		TotalTime:             int64(stats.TotalTime()),
+		PlanType:              stats.PlanType,
+		OriginalSql:           originalSQL,
+		BindVariables:         bindVariablesToLogProto(stats.logBindVariables(bindVariableDisplayMode(params))),
+		NumberOfQueries:       int64(stats.NumberOfQueries),
+		RewrittenSql:          rewrittenSQLs,
+		QuerySources:          stats.querySources(),
+		MysqlResponseTime:     int64(stats.MysqlResponseTime),
+		WaitingForConnection:  int64(stats.WaitingForConnection),
+		ConnPoolWaitTime:      int64(stats.ConnPoolWaitTime),
+		TxPoolWaitTime:        int64(stats.TxPoolWaitTime),
+		RowsAffected:          int64(stats.RowsAffected),
+		SizeOfResponse:        int64(stats.SizeOfResponse()),
+		SizeOfRequest:         int64(stats.SizeOfRequest()),
+		CacheHits:             stats.CacheHits,
+		CacheMisses:           stats.CacheMisses,
+		CacheAbsent:           stats.CacheAbsent,
+		CacheInvalidations:    stats.CacheInvalidations,
+		TransactionId:         stats.TransactionID,
+		Error:                 stats.loggedError(shouldRedact(params)),
+		MysqlErrno:            int64(stats.MysqlErrno),
+		MysqlState:            stats.MysqlState,
+		Fingerprint:           stats.Fingerprint,
+		TableHits:             stats.TableHits,
+		DeadlineExceeded:      stats.ContextDeadlineExceeded,
+		SemiSyncFallback:      stats.SemiSyncFallback,
+		Keyspace:              stats.Keyspace,
+		Shard:                 stats.Shard,
+		TabletAlias:           stats.TabletAlias,
+		QueryId:               stats.QueryID,
+		Version:               QueryLogRecordVersion,
+		SchemaValidationError: stats.SchemaValidationError,
+	}
+	if budget, ok := stats.DeadlineBudget(); ok {
+		remaining, _ := stats.DeadlineRemaining()
+		out.HasDeadline = true
+		out.DeadlineBudget = int64(budget)
+		out.DeadlineRemaining = int64(remaining)
+	}
+	for _, rs := range stats.rewrittenSqls {
+		out.RewrittenSqlTimes = append(out.RewrittenSqlTimes, int64(rs.duration))
+	}
+	if stats.Error != nil {
+		out.ErrorCode = stats.Error.ErrorCode
+	}
+	if ps := stats.PerfSchema; ps != nil {
+		out.PerfSchema = &querylogpb.PerfSchemaStats{
+			RowsExamined:         ps.RowsExamined,
+			CreatedTmpDiskTables: ps.CreatedTmpDiskTables,
+			CreatedTmpTables:     ps.CreatedTmpTables,
+			SelectFullJoin:       ps.SelectFullJoin,
+			SelectScan:           ps.SelectScan,
+			SortRows:             ps.SortRows,
+			NoIndexUsed:          ps.NoIndexUsed,
+		}
+	}
+	if summary != nil {
+		out.DedupSummary = &querylogpb.DedupSummary{
+			Suppressed: summary.Suppressed,
+			TotalTime:  int64(summary.TotalTime),
+			StartTime:  logutil.TimeToProto(summary.Start),
+			EndTime:    logutil.TimeToProto(summary.End),
+		}
+	}
+	encoded, err := streamlog.EncodeBinary(out)
+	if err != nil {
+		log.Warningf("could not marshal log stats for %q: %v", originalSQL, err)
+		return ""
+	}
+	return encoded
+}
+
+// FormatDedupSummary formats summary, that counts the duplicates of
+// stats that were not logged, according to the same params as Format.
+// The text format is a tab separated list that starts with
+// "DedupSummary", then the number of suppressed queries, the start and
+// end of the interval, their total time, and the plan type, SQL,
+// fingerprint and tablet of stats. The JSON and binary formats are the
+// ones of stats, with an additional DedupSummary field.
+func (stats *LogStats) FormatDedupSummary(params url.Values, summary *streamlog.DedupSummary) string {
+	switch params.Get("format") {
+	case "json":
+		return stats.formatJSON(params, summary)
+	case streamlog.BinaryFormat:
+		return stats.formatBinary(params, summary)
+	}
+	originalSQL, _ := truncateLoggedSQL(stats.loggedSQL(shouldRedact(params)))
+	return fmt.Sprintf(
+		"DedupSummary\t%v\t%v\t%v\t%.6f\t%v\t%q\t%q\t%q\t%q\t%q\t\n",
+		summary.Suppressed,
+		summary.Start.Format(time.StampMicro),
+		summary.End.Format(time.StampMicro),
+		summary.TotalTime.Seconds(),
+		stats.PlanType,
+		originalSQL,
+		stats.Fingerprint,
+		stats.Keyspace,
+		stats.Shard,
+		stats.TabletAlias,
+	)
+}
+
+// bindVariablesToLogProto converts the bind variables to proto one by
+// one, so that a value querytypes doesn't support only replaces that
+// value by its string representation.
+func bindVariablesToLogProto(bindVars map[string]interface{}) map[string]*querypb.BindVariable {
+	if len(bindVars) == 0 {
+		return nil
+	}
+	out := make(map[string]*querypb.BindVariable, len(bindVars))
+	for k, v := range bindVars {
+		bv, err := querytypes.BindVariablesToProto3(map[string]interface{}{k: v})
+		if err != nil {
+			out[k] = &querypb.BindVariable{
+				Type:  sqltypes.VarChar,
+				Value: []byte(fmt.Sprintf("%v", v)),
+			}
+			continue
+		}
+		out[k] = bv[k]
+	}
+	return out
+}
+
+// shouldRedact returns true if the logged queries must be redacted.
+func shouldRedact(params url.Values) bool {
+	_, redact := params["redact"]
+	return redact || *redactDebugUIQueries
+}
@@ -5,16 +5,31 @@
 package tabletserver
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
 	"net/url"
+	"reflect"
 	"strings"
+	"sync"
 	"testing"
+	"testing/quick"
 	"time"
 
 	"golang.org/x/net/context"
 
+	"github.com/youtube/vitess/go/ratelimiter"
+	"github.com/youtube/vitess/go/sqldb"
 	"github.com/youtube/vitess/go/sqltypes"
+	"github.com/youtube/vitess/go/streamlog"
 	"github.com/youtube/vitess/go/vt/callinfo"
+	"github.com/youtube/vitess/go/vt/logutil"
+	"github.com/youtube/vitess/go/vt/tabletserver/querylogparser"
 
+	querypb "github.com/youtube/vitess/go/vt/proto/query"
+	querylogpb "github.com/youtube/vitess/go/vt/proto/querylog"
 	vtrpcpb "github.com/youtube/vitess/go/vt/proto/vtrpc"
 )
 
@@ -35,18 +50,422 @@ func TestLogStats(t *testing.T) {
 		t.Fatalf("log stats has some rows, should have positive response size")
 	}
 
+	logStats.addStreamedResult(&sqltypes.Result{Rows: [][]sqltypes.Value{
+		{sqltypes.MakeString([]byte("bc"))},
+		{sqltypes.MakeString([]byte("def"))},
+	}})
+	if got, want := logStats.SizeOfResponse(), 6; got != want {
+		t.Errorf("SizeOfResponse with streamed rows: %v, want %v", got, want)
+	}
+	if got, want := logStats.RowsReturned(), 3; got != want {
+		t.Errorf("RowsReturned with streamed rows: %v, want %v", got, want)
+	}
+
+	// The JSON documents count their bytes, not their characters.
+	logStats.Rows = nil
+	logStats.StreamedSize = 0
+	for _, doc := range testJSONDocuments {
+		logStats.Rows = append(logStats.Rows, []sqltypes.Value{sqltypes.MakeTrusted(sqltypes.TypeJSON, []byte(doc))})
+	}
+	want := 0
+	for _, doc := range testJSONDocuments {
+		want += len(doc)
+	}
+	if got := logStats.SizeOfResponse(); got != want {
+		t.Errorf("SizeOfResponse of JSON documents: %v, want %v", got, want)
+	}
+
 	params := map[string][]string{"full": {}}
 
 	logStats.Format(url.Values(params))
 }
 
+func TestLogStatsFormatJSON(t *testing.T) {
+	callInfo := &fakeCallInfo{
+		remoteAddr: "1.2.3.4",
+		username:   "vt",
+	}
+	logStats := newLogStats("test", callinfo.NewContext(context.Background(), callInfo))
+	logStats.PlanType = "PASS_SELECT"
+	logStats.OriginalSQL = "select * from t where a = :a"
+	logStats.BindVariables = map[string]interface{}{"a": "val", "b": 1}
+	logStats.AddRewrittenSQL("sql1", time.Now())
+	logStats.QuerySources |= QuerySourceRowcache
+	logStats.RowsAffected = 2
+	logStats.addConnPoolWait(2 * time.Second)
+	logStats.addTxPoolWait(time.Second)
+	logStats.CacheHits = 4
+	logStats.CacheMisses = 5
+	logStats.CacheAbsent = 6
+	logStats.CacheInvalidations = 7
+	logStats.TransactionID = 8
+	logStats.Rows = [][]sqltypes.Value{{sqltypes.MakeString([]byte("a"))}}
+	logStats.Error = &TabletError{
+		ErrorCode: vtrpcpb.ErrorCode_UNKNOWN_ERROR,
+		Message:   "unknown error",
+	}
+	logStats.EndTime = logStats.StartTime.Add(10 * time.Second)
+	logStats.Fingerprint = "select * from t where a = :a"
+
+	formatted := logStats.Format(url.Values{"format": {"json"}, "full": {}})
+	if !strings.HasSuffix(formatted, "\n") {
+		t.Errorf("Format(json) = %q, want trailing newline", formatted)
+	}
+	var got logStatsJSON
+	if err := json.Unmarshal([]byte(formatted), &got); err != nil {
+		t.Fatalf("json.Unmarshal(%q) failed: %v", formatted, err)
+	}
+	want := logStatsJSON{
+		Method:               "test",
+		RemoteAddr:           "1.2.3.4",
+		Username:             "vt",
+		StartTime:            logStats.StartTime,
+		EndTime:              logStats.EndTime,
+		TotalTime:            10,
+		PlanType:             "PASS_SELECT",
+		OriginalSQL:          "select * from t where a = :a",
+		BindVariables:        map[string]interface{}{"a": "val", "b": float64(1)},
+		NumberOfQueries:      1,
+		RewrittenSQL:         []string{"sql1"},
+		RewrittenSQLTimings:  []rewrittenSQLTimingJSON{{SQL: "sql1", Time: logStats.MysqlResponseTime.Seconds()}},
+		QuerySources:         []string{"mysql", "rowcache"},
+		MysqlResponseTime:    logStats.MysqlResponseTime.Seconds(),
+		WaitingForConnection: 3,
+		ConnPoolWaitTime:     2,
+		TxPoolWaitTime:       1,
+		RowsAffected:         2,
+		RowsReturned:         1,
+		SizeOfResponse:       1,
+		SizeOfRequest:        39,
+		CacheHits:            4,
+		CacheMisses:          5,
+		CacheAbsent:          6,
+		CacheInvalidations:   7,
+		TransactionID:        8,
+		Error:                logStats.ErrorStr(),
+		Fingerprint:          "select * from t where a = :a",
+		ErrorCode:            "UNKNOWN_ERROR",
+		QueryID:              logStats.QueryID,
+	}
+	if !got.StartTime.Equal(want.StartTime) || !got.EndTime.Equal(want.EndTime) {
+		t.Errorf("got times %v, %v, want %v, %v", got.StartTime, got.EndTime, want.StartTime, want.EndTime)
+	}
+	got.StartTime, got.EndTime = want.StartTime, want.EndTime
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Format(json):\n%+v, want\n%+v", got, want)
+	}
+
+	// Without "full", string bind variables are summarized.
+	formatted = logStats.Format(url.Values{"format": {"json"}})
+	if err := json.Unmarshal([]byte(formatted), &got); err != nil {
+		t.Fatalf("json.Unmarshal(%q) failed: %v", formatted, err)
+	}
+	if bv := got.BindVariables["a"]; bv != "string 3" {
+		t.Errorf("BindVariables[a] = %v, want \"string 3\"", bv)
+	}
+}
+
+func TestLogStatsFormatJSONUnmarshalableBindVariables(t *testing.T) {
+	logStats := newLogStats("test", context.Background())
+	logStats.BindVariables = map[string]interface{}{
+		"ch":  make(chan int),
+		"key": 1,
+	}
+	formatted := logStats.Format(url.Values{"format": {"json"}})
+	var got logStatsJSON
+	if err := json.Unmarshal([]byte(formatted), &got); err != nil {
+		t.Fatalf("json.Unmarshal(%q) failed: %v", formatted, err)
+	}
+	if _, ok := got.BindVariables["ch"].(string); !ok {
+		t.Errorf("BindVariables[ch] = %#v, want a string", got.BindVariables["ch"])
+	}
+	if got.BindVariables["key"] != float64(1) {
+		t.Errorf("BindVariables[key] = %#v, want 1", got.BindVariables["key"])
+	}
+	if formatted := logStats.FmtBindVariables(BindVariablesFull); !strings.Contains(formatted, `"ch":"0x`) {
+		t.Errorf("FmtBindVariables(BindVariablesFull) = %q, want a stringified channel", formatted)
+	}
+}
+
+func TestLogStatsFormatBinary(t *testing.T) {
+	callInfo := &fakeCallInfo{
+		remoteAddr: "1.2.3.4",
+		username:   "vt",
+	}
+	logStats := newLogStats("test", callinfo.NewContext(context.Background(), callInfo))
+	logStats.PlanType = "PASS_SELECT"
+	logStats.OriginalSQL = "select * from t where a = :a"
+	logStats.BindVariables = map[string]interface{}{
+		"a":  "val\tue",
+		"b":  1,
+		"l":  []interface{}{1, "x"},
+		"ch": make(chan int),
+	}
+	logStats.AddRewrittenSQL("sql1", time.Now())
+	logStats.RowsAffected = 2
+	logStats.TransactionID = 8
+	logStats.TableHits = map[string]int64{"t": 3}
+	logStats.PerfSchema = &PerfSchemaStats{RowsExamined: 10}
+	logStats.Error = &TabletError{
+		ErrorCode: vtrpcpb.ErrorCode_UNKNOWN_ERROR,
+		Message:   "unknown error",
+	}
+	logStats.EndTime = logStats.StartTime.Add(10 * time.Second)
+
+	formatted := logStats.Format(url.Values{"format": {"binary"}, "full": {}})
+	br := streamlog.NewBinaryReader(strings.NewReader(formatted))
+	got := &querylogpb.LogStats{}
+	if err := br.Read(got); err != nil {
+		t.Fatalf("Read(%q): %v", formatted, err)
+	}
+	if err := br.Read(&querylogpb.LogStats{}); err != io.EOF {
+		t.Errorf("Read after the message: %v, want %v", err, io.EOF)
+	}
+	if !logutil.ProtoToTime(got.StartTime).Equal(logStats.StartTime) || got.TotalTime != int64(10*time.Second) {
+		t.Errorf("got start time %v and total time %v, want %v and %v", logutil.ProtoToTime(got.StartTime), got.TotalTime, logStats.StartTime, int64(10*time.Second))
+	}
+	if got.Method != "test" || got.RemoteAddr != "1.2.3.4" || got.Username != "vt" || got.OriginalSql != logStats.OriginalSQL || got.RowsAffected != 2 || got.TransactionId != 8 {
+		t.Errorf("Format(binary): %v, want the fields of %+v", got, logStats)
+	}
+	if want := []string{"sql1"}; !reflect.DeepEqual(got.RewrittenSql, want) {
+		t.Errorf("RewrittenSql: %v, want %v", got.RewrittenSql, want)
+	}
+	if want := []int64{int64(logStats.MysqlResponseTime)}; !reflect.DeepEqual(got.RewrittenSqlTimes, want) {
+		t.Errorf("RewrittenSqlTimes: %v, want %v", got.RewrittenSqlTimes, want)
+	}
+	if got.ErrorCode != vtrpcpb.ErrorCode_UNKNOWN_ERROR || got.Error != logStats.ErrorStr() {
+		t.Errorf("error: %v, %v, want %v, %v", got.ErrorCode, got.Error, vtrpcpb.ErrorCode_UNKNOWN_ERROR, logStats.ErrorStr())
+	}
+	if want := map[string]int64{"t": 3}; !reflect.DeepEqual(got.TableHits, want) {
+		t.Errorf("TableHits: %v, want %v", got.TableHits, want)
+	}
+	if got.PerfSchema == nil || got.PerfSchema.RowsExamined != 10 {
+		t.Errorf("PerfSchema: %v, want 10 rows examined", got.PerfSchema)
+	}
+
+	wantBindVars := map[string]*querypb.BindVariable{
+		"a": {Type: sqltypes.VarChar, Value: []byte("val\tue")},
+		"b": {Type: sqltypes.Int64, Value: []byte("1")},
+		"l": {Type: sqltypes.Tuple, Values: []*querypb.Value{
+			{Type: sqltypes.Int64, Value: []byte("1")},
+			{Type: sqltypes.VarChar, Value: []byte("x")},
+		}},
+		"ch": {Type: sqltypes.VarChar, Value: []byte(fmt.Sprintf("%v", logStats.BindVariables["ch"]))},
+	}
+	if !reflect.DeepEqual(got.BindVariables, wantBindVars) {
+		t.Errorf("BindVariables:\n%v, want\n%v", got.BindVariables, wantBindVars)
+	}
+
+	// Without "full", string bind variables are summarized.
+	formatted = logStats.Format(url.Values{"format": {"binary"}})
+	if err := streamlog.NewBinaryReader(strings.NewReader(formatted)).Read(got); err != nil {
+		t.Fatalf("Read(%q): %v", formatted, err)
+	}
+	if bv := got.BindVariables["a"]; string(bv.Value) != "string 6" {
+		t.Errorf("BindVariables[a] = %v, want \"string 6\"", bv)
+	}
+}
+
+func TestLogStatsFormatBinaryToText(t *testing.T) {
+	callInfo := &fakeCallInfo{
+		remoteAddr: "1.2.3.4",
+		username:   "vt",
+	}
+	ctx, cancel := context.WithTimeout(callinfo.NewContext(context.Background(), callInfo), time.Minute)
+	defer cancel()
+	logStats := newLogStats("Execute", ctx)
+	logStats.PlanType = "PASS_SELECT"
+	logStats.OriginalSQL = "select * from t where a = :a and b in ::l"
+	logStats.BindVariables = map[string]interface{}{
+		"a": "val\tue",
+		"l": []interface{}{1, "x"},
+	}
+	logStats.AddRewrittenSQL("select * from t where a = 'val\tue'", time.Now())
+	logStats.AddRewrittenSQL("select 1", time.Now())
+	logStats.QuerySources |= QuerySourceMySQL
+	logStats.TableHits = map[string]int64{"t": 3}
+	logStats.Error = NewTabletError(vtrpcpb.ErrorCode_BAD_INPUT, "bad\nquery")
+	logStats.Keyspace = "ks"
+	logStats.Shard = "-80"
+	logStats.QueryID = "ab12"
+	logStats.EndTime = logStats.StartTime.Add(3 * time.Second)
+
+	for _, params := range []url.Values{{"full": {}}, {}} {
+		want := logStats.Format(params)
+		binaryParams := url.Values{"format": {streamlog.BinaryFormat}}
+		for k, v := range params {
+			binaryParams[k] = v
+		}
+		formatted := logStats.Format(binaryParams)
+		br := querylogparser.NewBinaryReader(strings.NewReader(formatted + formatted[:len(formatted)-1]))
+		record, err := br.Read()
+		if err != nil {
+			t.Fatalf("Read(%q): %v", formatted, err)
+		}
+		if record.Version != QueryLogRecordVersion {
+			t.Errorf("Version: %v, want %v", record.Version, QueryLogRecordVersion)
+		}
+		if got := querylogparser.FormatText(record); got != want {
+			t.Errorf("FormatText(%v):\n%q, want\n%q", params, got, want)
+		}
+		if _, err := br.Read(); err != io.ErrUnexpectedEOF {
+			t.Errorf("Read of a truncated record: %v, want %v", err, io.ErrUnexpectedEOF)
+		}
+	}
+
+	summary := &streamlog.DedupSummary{
+		Message:    logStats,
+		Suppressed: 4,
+		TotalTime:  2 * time.Second,
+		Start:      logStats.StartTime,
+		End:        logStats.EndTime,
+	}
+	want := logStats.FormatDedupSummary(url.Values{}, summary)
+	formatted := logStats.FormatDedupSummary(url.Values{"format": {streamlog.BinaryFormat}}, summary)
+	record, err := querylogparser.NewBinaryReader(strings.NewReader(formatted)).Read()
+	if err != nil {
+		t.Fatalf("Read(%q): %v", formatted, err)
+	}
+	if got := querylogparser.FormatText(record); got != want {
+		t.Errorf("FormatText of a summary:\n%q, want\n%q", got, want)
+	}
+}
+
+func TestLogStatsFormatDedupSummary(t *testing.T) {
+	logStats := newLogStats("test", context.Background())
+	logStats.PlanType = "PASS_SELECT"
+	logStats.OriginalSQL = "select * from t"
+	logStats.Fingerprint = "select * from t"
+	logStats.Keyspace = "ks"
+	logStats.Shard = "0"
+	logStats.TabletAlias = "cell-1"
+	start := time.Date(2016, time.March, 14, 1, 2, 3, 0, time.UTC)
+	summary := &streamlog.DedupSummary{
+		Message:    logStats,
+		Suppressed: 12,
+		TotalTime:  30 * time.Second,
+		Start:      start,
+		End:        start.Add(time.Minute),
+	}
+	fmter := buildFmter(StatsLogger)
+
+	got := fmter(url.Values{}, summary)
+	want := "DedupSummary\t12\tMar 14 01:02:03.000000\tMar 14 01:03:03.000000\t30.000000\tPASS_SELECT\t\"select * from t\"\t\"select * from t\"\t\"ks\"\t\"0\"\t\"cell-1\"\t\n"
+	if got != want {
+		t.Errorf("text summary: %q, want %q", got, want)
+	}
+
+	var gotJSON map[string]interface{}
+	if err := json.Unmarshal([]byte(fmter(url.Values{"format": {"json"}}, summary)), &gotJSON); err != nil {
+		t.Fatalf("invalid JSON summary: %v", err)
+	}
+	wantSummary := map[string]interface{}{
+		"Suppressed": 12.0,
+		"TotalTime":  30.0,
+		"StartTime":  "2016-03-14T01:02:03Z",
+		"EndTime":    "2016-03-14T01:03:03Z",
+	}
+	if !reflect.DeepEqual(gotJSON["DedupSummary"], wantSummary) || gotJSON["OriginalSQL"] != "select * from t" {
+		t.Errorf("JSON summary: %v, want DedupSummary %v", gotJSON, wantSummary)
+	}
+	if strings.Contains(logStats.FormatJSON(url.Values{}), "DedupSummary") {
+		t.Errorf("FormatJSON of a query has a DedupSummary: %v", logStats.FormatJSON(url.Values{}))
+	}
+
+	formatted := fmter(url.Values{"format": {"binary"}}, summary)
+	gotBinary := &querylogpb.LogStats{}
+	if err := streamlog.NewBinaryReader(strings.NewReader(formatted)).Read(gotBinary); err != nil {
+		t.Fatalf("Read(%q): %v", formatted, err)
+	}
+	ds := gotBinary.DedupSummary
+	if ds == nil || ds.Suppressed != 12 || ds.TotalTime != int64(30*time.Second) || !logutil.ProtoToTime(ds.EndTime).Equal(summary.End) || gotBinary.OriginalSql != "select * from t" {
+		t.Errorf("binary summary: %v, want the summary of %v", gotBinary, summary)
+	}
+}
+
+func TestLogStatsDedupKey(t *testing.T) {
+	logStats := &LogStats{PlanType: "PASS_SELECT", OriginalSQL: "select 1"}
+	testcases := []struct {
+		planType, sql string
+		want          bool
+	}{
+		{"PASS_SELECT", "select 1", true},
+		{"SELECT_PK", "select 1", false},
+		{"PASS_SELECT", "select 2", false},
+	}
+	for _, tcase := range testcases {
+		other := &LogStats{PlanType: tcase.planType, OriginalSQL: tcase.sql}
+		if got := other.DedupKey() == logStats.DedupKey(); got != tcase.want {
+			t.Errorf("DedupKey of %v, %q equal to %v, %q: %v, want %v", tcase.planType, tcase.sql, logStats.PlanType, logStats.OriginalSQL, got, tcase.want)
+		}
+	}
+}
+
+func TestLogStatsSendSlowQueryThreshold(t *testing.T) {
+	defer slowQueryThreshold.Set(slowQueryThreshold.Get())
+	slowQueryThreshold.Set(1 * time.Hour)
+	ch := StatsLogger.Subscribe("test")
+	defer StatsLogger.Unsubscribe(ch)
+
+	// A fast query is not logged.
+	newLogStats("fast", context.Background()).Send()
+	select {
+	case got := <-ch:
+		t.Errorf("got %v, want no record below the threshold", got.(*LogStats).Method)
+	default:
+	}
+
+	// A failing query is always logged.
+	logStats := newLogStats("error", context.Background())
+	logStats.Error = &TabletError{
+		ErrorCode: vtrpcpb.ErrorCode_UNKNOWN_ERROR,
+		Message:   "unknown error",
+	}
+	logStats.Send()
+	select {
+	case got := <-ch:
+		if method := got.(*LogStats).Method; method != "error" {
+			t.Errorf("Method: %s, want error", method)
+		}
+	default:
+		t.Errorf("failed query was not logged")
+	}
+
+	// ForceLog bypasses the threshold.
+	logStats = newLogStats("forced", context.Background())
+	logStats.ForceLog = true
+	logStats.Send()
+	select {
+	case got := <-ch:
+		if method := got.(*LogStats).Method; method != "forced" {
+			t.Errorf("Method: %s, want forced", method)
+		}
+	default:
+		t.Errorf("forced record was not logged")
+	}
+
+	// A slow query is logged.
+	slowQueryThreshold.Set(1 * time.Millisecond)
+	logStats = newLogStats("slow", context.Background())
+	logStats.StartTime = logStats.StartTime.Add(-1 * time.Second)
+	logStats.Send()
+	select {
+	case got := <-ch:
+		if method := got.(*LogStats).Method; method != "slow" {
+			t.Errorf("Method: %s, want slow", method)
+		}
+	default:
+		t.Errorf("slow query was not logged")
+	}
+}
+
 func TestLogStatsFormatBindVariables(t *testing.T) {
 	logStats := newLogStats("test", context.Background())
 	logStats.BindVariables = make(map[string]interface{})
 	logStats.BindVariables["key_1"] = "val_1"
 	logStats.BindVariables["key_2"] = 789
 
-	formattedStr := logStats.FmtBindVariables(true)
+	formattedStr := logStats.FmtBindVariables(BindVariablesFull)
 	if !strings.Contains(formattedStr, "key_1") ||
 		!strings.Contains(formattedStr, "val_1") {
 		t.Fatalf("bind variable 'key_1': 'val_1' is not formatted")
@@ -57,7 +476,7 @@ func TestLogStatsFormatBindVariables(t *testing.T) {
 	}
 
 	logStats.BindVariables["key_3"] = []byte("val_3")
-	formattedStr = logStats.FmtBindVariables(false)
+	formattedStr = logStats.FmtBindVariables(BindVariablesCompact)
 	if !strings.Contains(formattedStr, "key_1") {
 		t.Fatalf("bind variable 'key_1' is not formatted")
 	}
@@ -68,6 +487,97 @@ func TestLogStatsFormatBindVariables(t *testing.T) {
 	if !strings.Contains(formattedStr, "key_3") {
 		t.Fatalf("bind variable 'key_3' is not formatted")
 	}
+
+	logStats.BindVariables["key_4"] = int64(123456)
+	formattedStr = logStats.FmtBindVariables(BindVariablesRedact)
+	want := `{"key_1":"string","key_2":"int","key_3":"bytes","key_4":"int64"}`
+	if formattedStr != want {
+		t.Errorf("FmtBindVariables(BindVariablesRedact): %s, want %s", formattedStr, want)
+	}
+}
+
+func TestLogStatsFormatBindVariablesRedactPatterns(t *testing.T) {
+	defer SetBindVariableRedactPatterns(strings.Join(BindVariableRedactPatterns(), ","))
+	defer func(v int) { *queryLogShowShortBindVariables = v }(*queryLogShowShortBindVariables)
+	if err := SetBindVariableRedactPatterns("*PASSWORD*, ssn"); err != nil {
+		t.Fatalf("SetBindVariableRedactPatterns: %v", err)
+	}
+	*queryLogShowShortBindVariables = 4
+
+	logStats := newLogStats("test", context.Background())
+	logStats.BindVariables = map[string]interface{}{
+		"user_password": "hunter2",
+		"SSN":           int64(123456789),
+		"ssn_hash":      []byte("abc"),
+		"state":         "open",
+		"name":          "Alice Smith",
+		"id":            42,
+	}
+	testcases := []struct {
+		mode BindVariableDisplayMode
+		want string
+	}{{
+		mode: BindVariablesFull,
+		want: `{"SSN":"<redacted>","id":42,"name":"Alice Smith","ssn_hash":"YWJj","state":"open","user_password":"<redacted>"}`,
+	}, {
+		mode: BindVariablesCompact,
+		want: `{"SSN":"<redacted>","id":42,"name":"string 11","ssn_hash":"abc","state":"open","user_password":"<redacted>"}`,
+	}, {
+		mode: BindVariablesRedact,
+		want: `{"SSN":"<redacted>","id":"int","name":"string","ssn_hash":"bytes","state":"string","user_password":"<redacted>"}`,
+	}}
+	for _, tcase := range testcases {
+		if got := logStats.FmtBindVariables(tcase.mode); got != tcase.want {
+			t.Errorf("FmtBindVariables(%v): %s, want %s", tcase.mode, got, tcase.want)
+		}
+	}
+	if logStats.BindVariables["user_password"] != "hunter2" {
+		t.Errorf("BindVariables were changed: %v", logStats.BindVariables)
+	}
+
+	// The values are also in the rewritten SQL, which is redacted.
+	logStats.OriginalSQL = "select * from users where user_password = :user_password"
+	logStats.AddRewrittenSQL("select * from users where user_password = 'hunter2'", time.Now())
+	want := "select * from users where user_password = ?"
+	if got := logStats.RewrittenSQL(); got != want {
+		t.Errorf("RewrittenSQL: %q, want %q", got, want)
+	}
+	if got := logStats.RewrittenSQLTimings(); len(got) != 1 || !strings.HasPrefix(got[0], want+":") {
+		t.Errorf("RewrittenSQLTimings: %q, want %q with its duration", got, want)
+	}
+	if got := logStats.Format(url.Values{"full": nil}); strings.Contains(got, "hunter2") || !strings.Contains(got, logStats.OriginalSQL) {
+		t.Errorf("Format(full): %q, want the original SQL without the password", got)
+	}
+	delete(logStats.BindVariables, "user_password")
+	delete(logStats.BindVariables, "SSN")
+	delete(logStats.BindVariables, "ssn_hash")
+	if got := logStats.RewrittenSQL(); !strings.Contains(got, "hunter2") {
+		t.Errorf("RewrittenSQL without a redacted bind variable: %q, want it unchanged", got)
+	}
+
+	if err := SetBindVariableRedactPatterns("[pass"); err == nil {
+		t.Errorf("SetBindVariableRedactPatterns([pass): nil, want error")
+	}
+	if got := BindVariableRedactPatterns(); !reflect.DeepEqual(got, []string{"*password*", "ssn"}) {
+		t.Errorf("BindVariableRedactPatterns: %v, want the previous patterns", got)
+	}
+}
+
+func TestLogStatsBindVariableDisplayMode(t *testing.T) {
+	testCases := []struct {
+		params url.Values
+		want   BindVariableDisplayMode
+	}{
+		{url.Values{}, BindVariablesCompact},
+		{url.Values{"full": nil}, BindVariablesFull},
+		{url.Values{"redact": nil}, BindVariablesRedact},
+		{url.Values{"full": nil, "redact": nil}, BindVariablesRedact},
+	}
+	for _, tcase := range testCases {
+		if got := bindVariableDisplayMode(tcase.params); got != tcase.want {
+			t.Errorf("bindVariableDisplayMode(%v): %v, want %v", tcase.params, got, tcase.want)
+		}
+	}
 }
 
 func TestLogStatsFormatQuerySources(t *testing.T) {
@@ -92,6 +602,54 @@ func TestLogStatsFormatQuerySources(t *testing.T) {
 	}
 }
 
+func TestLogStatsSendQuerySourceCounts(t *testing.T) {
+	defer func(v bool) { *countQuerySourcesByPlan = v }(*countQuerySourcesByPlan)
+	*countQuerySourcesByPlan = true
+	sources := []string{"MySQL", "Rowcache", "Consolidator", "None"}
+	counts := func() map[string]int64 {
+		all := querySourceCounts.Counts()
+		byPlan := querySourceCountsByPlan.Counts()
+		got := make(map[string]int64)
+		for _, source := range sources {
+			got[source] = all[source]
+			got[source+".PASS_SELECT"] = byPlan[source+".PASS_SELECT"]
+		}
+		return got
+	}
+
+	for _, tcase := range []struct {
+		sources byte
+		want    []string
+	}{
+		{QuerySourceMySQL | QuerySourceRowcache, []string{"MySQL", "Rowcache"}},
+		{QuerySourceMySQL | QuerySourceRowcache | QuerySourceConsolidator, []string{"MySQL", "Rowcache", "Consolidator"}},
+		{0, []string{"None"}},
+	} {
+		before := counts()
+		logStats := newLogStats("test", context.Background())
+		logStats.PlanType = "PASS_SELECT"
+		logStats.QuerySources = tcase.sources
+		logStats.Send()
+		after := counts()
+		want := make(map[string]bool)
+		for _, source := range tcase.want {
+			want[source] = true
+		}
+		for _, source := range sources {
+			wantDelta := int64(0)
+			if want[source] {
+				wantDelta = 1
+			}
+			if got := after[source] - before[source]; got != wantDelta {
+				t.Errorf("sources %v: %v count: +%v, want +%v", tcase.sources, source, got, wantDelta)
+			}
+			if got := after[source+".PASS_SELECT"] - before[source+".PASS_SELECT"]; got != wantDelta {
+				t.Errorf("sources %v: %v count of PASS_SELECT: +%v, want +%v", tcase.sources, source, got, wantDelta)
+			}
+		}
+	}
+}
+
 func TestLogStatsContextHTML(t *testing.T) {
 	html := "HtmlContext"
 	callInfo := &fakeCallInfo{
@@ -119,6 +677,283 @@ func TestLogStatsErrorStr(t *testing.T) {
 	}
 }
 
+func TestLogStatsRewrittenSQLTimings(t *testing.T) {
+	logStats := newLogStats("test", context.Background())
+	durations := []time.Duration{30 * time.Millisecond, 10 * time.Millisecond, 20 * time.Millisecond}
+	for i, d := range durations {
+		logStats.AddRewrittenSQL(fmt.Sprintf("sql%d", i+1), time.Now().Add(-d))
+	}
+	var total time.Duration
+	for i, rs := range logStats.rewrittenSqls {
+		if rs.duration < durations[i] || rs.duration > durations[i]+time.Second {
+			t.Errorf("duration of %s: %v, want about %v", rs.sql, rs.duration, durations[i])
+		}
+		total += rs.duration
+	}
+	if logStats.MysqlResponseTime != total {
+		t.Errorf("MysqlResponseTime: %v, want %v", logStats.MysqlResponseTime, total)
+	}
+
+	// Use exact durations to check the output.
+	for i := range logStats.rewrittenSqls {
+		logStats.rewrittenSqls[i].duration = durations[i]
+	}
+	want := []string{"sql1:30ms", "sql2:10ms", "sql3:20ms"}
+	if got := logStats.RewrittenSQLTimings(); !reflect.DeepEqual(got, want) {
+		t.Errorf("RewrittenSQLTimings: %v, want %v", got, want)
+	}
+	if got := logStats.RewrittenSQLTimes(); !reflect.DeepEqual(got, durations) {
+		t.Errorf("RewrittenSQLTimes: %v, want %v", got, durations)
+	}
+	if got, want := logStats.RewrittenSQL(), "sql1; sql2; sql3"; got != want {
+		t.Errorf("RewrittenSQL: %q, want %q", got, want)
+	}
+	if got := logStats.Format(url.Values{}); !strings.HasSuffix(got, fmt.Sprintf("\t\"sql1:30ms; sql2:10ms; sql3:20ms\"\t\"\"\t\"\"\t\"\"\t0.000000\t0.000000\t\t\t%q\t\"\"\t\n", logStats.QueryID)) {
+		t.Errorf("Format: %q, want the timings before the deadline columns", got)
+	}
+}
+
+func TestLogStatsSizeOfRequest(t *testing.T) {
+	testcases := []struct {
+		name     string
+		bindVars map[string]interface{}
+		want     int
+	}{{
+		name:     "nil",
+		bindVars: nil,
+		want:     10,
+	}, {
+		name:     "empty",
+		bindVars: map[string]interface{}{},
+		want:     10,
+	}, {
+		name: "mixed",
+		bindVars: map[string]interface{}{
+			"str":   "abc",
+			"bytes": []byte("abcde"),
+			"int":   1,
+			"int64": int64(1),
+			"uint":  uint64(1),
+			"float": 1.5,
+			"value": sqltypes.MakeString([]byte("ab")),
+			"list":  []interface{}{"a", 1},
+			"null":  nil,
+		},
+		want: 10 + 3 + 5 + 8 + 8 + 8 + 8 + 2 + 1 + 8,
+	}}
+	for _, tcase := range testcases {
+		logStats := newLogStats("test", context.Background())
+		logStats.OriginalSQL = "select 1 a"
+		logStats.BindVariables = tcase.bindVars
+		if got := logStats.SizeOfRequest(); got != tcase.want {
+			t.Errorf("%s: SizeOfRequest: %d, want %d", tcase.name, got, tcase.want)
+		}
+	}
+}
+
+func TestLogStatsTableHits(t *testing.T) {
+	logStats := newLogStats("test", context.Background())
+	logStats.RecordTableAccess("b", 1)
+	logStats.RecordTableAccess("a", 2)
+	logStats.RecordTableAccess("b", 1)
+	if want := map[string]int64{"a": 2, "b": 2}; !reflect.DeepEqual(logStats.TableHits, want) {
+		t.Errorf("TableHits: %v, want %v", logStats.TableHits, want)
+	}
+	if got := logStats.Format(url.Values{}); !strings.HasSuffix(got, fmt.Sprintf("\t{\"a\":2,\"b\":2}\tfalse\tfalse\t0\t\"\"\t\"\"\t\"\"\t\"\"\t0.000000\t0.000000\t\t\t%q\t\"\"\t\n", logStats.QueryID)) {
+		t.Errorf("Format: %q, want the table hits before the deadline column", got)
+	}
+	var got logStatsJSON
+	formatted := logStats.FormatJSON(url.Values{})
+	if err := json.Unmarshal([]byte(formatted), &got); err != nil {
+		t.Fatalf("json.Unmarshal(%q) failed: %v", formatted, err)
+	}
+	if !reflect.DeepEqual(got.TableHits, logStats.TableHits) {
+		t.Errorf("TableHits: %v, want %v", got.TableHits, logStats.TableHits)
+	}
+}
+
+func TestLogStatsSendDeadlineExceeded(t *testing.T) {
+	ch := StatsLogger.Subscribe("test")
+	defer StatsLogger.Unsubscribe(ch)
+	count := deadlineExceededCount.Get()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Nanosecond)
+	defer cancel()
+	<-ctx.Done()
+	logStats := newLogStats("test", ctx)
+	logStats.Error = NewTabletError(vtrpcpb.ErrorCode_DEADLINE_EXCEEDED, "query timed out")
+	logStats.Send()
+	if !logStats.ContextDeadlineExceeded {
+		t.Errorf("ContextDeadlineExceeded: false, want true")
+	}
+	if got := deadlineExceededCount.Get() - count; got != 1 {
+		t.Errorf("deadlineExceededCount: %d, want 1", got)
+	}
+	<-ch
+	if got := logStats.Format(url.Values{}); !strings.Contains(got, "\ttrue\tfalse\t0\t\"\"\t\"\"\t\"\"\t\"\"\t0.000000\t0.000000\t") {
+		t.Errorf("Format: %q, want true in the deadline column", got)
+	}
+	var got logStatsJSON
+	formatted := logStats.FormatJSON(url.Values{})
+	if err := json.Unmarshal([]byte(formatted), &got); err != nil {
+		t.Fatalf("json.Unmarshal(%q) failed: %v", formatted, err)
+	}
+	if !got.DeadlineExceeded || !strings.Contains(formatted, `"ContextDeadlineExceeded":true`) {
+		t.Errorf("FormatJSON: %s, want ContextDeadlineExceeded", formatted)
+	}
+
+	// Cancellation is not a deadline.
+	ctx, cancel = context.WithCancel(context.Background())
+	cancel()
+	logStats = newLogStats("test", ctx)
+	logStats.Send()
+	if logStats.ContextDeadlineExceeded {
+		t.Errorf("ContextDeadlineExceeded after cancel: true, want false")
+	}
+	if got := deadlineExceededCount.Get() - count; got != 1 {
+		t.Errorf("deadlineExceededCount after cancel: %d, want 1", got)
+	}
+
+	// A query that finished before its deadline didn't exceed it, even
+	// if the deadline passed before it was sent.
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	logStats = newLogStats("test", ctx)
+	logStats.Finish()
+	endTime := logStats.EndTime
+	<-ctx.Done()
+	logStats.Send()
+	if logStats.ContextDeadlineExceeded || !logStats.EndTime.Equal(endTime) {
+		t.Errorf("ContextDeadlineExceeded and EndTime of a query that finished in time: %v, %v, want false, %v", logStats.ContextDeadlineExceeded, logStats.EndTime, endTime)
+	}
+	if got := deadlineExceededCount.Get() - count; got != 1 {
+		t.Errorf("deadlineExceededCount after a query that finished in time: %d, want 1", got)
+	}
+}
+
+func TestLogStatsDeadline(t *testing.T) {
+	start := time.Now()
+	ctx, cancel := context.WithDeadline(context.Background(), start.Add(30*time.Second))
+	defer cancel()
+	logStats := newLogStats("test", ctx)
+	logStats.StartTime = start
+	logStats.EndTime = start.Add(1500 * time.Millisecond)
+	if got, ok := logStats.DeadlineBudget(); !ok || got != 30*time.Second {
+		t.Errorf("DeadlineBudget: %v, %v, want %v, true", got, ok, 30*time.Second)
+	}
+	if got, ok := logStats.DeadlineRemaining(); !ok || got != 28500*time.Millisecond {
+		t.Errorf("DeadlineRemaining: %v, %v, want %v, true", got, ok, 28500*time.Millisecond)
+	}
+
+	if got := logStats.Format(url.Values{}); !strings.HasSuffix(got, fmt.Sprintf("\t30.000000\t28.500000\t%q\t\"\"\t\n", logStats.QueryID)) {
+		t.Errorf("Format: %q, want the deadline budget and remaining time before the query ID", got)
+	}
+	var gotJSON logStatsJSON
+	formatted := logStats.FormatJSON(url.Values{})
+	if err := json.Unmarshal([]byte(formatted), &gotJSON); err != nil {
+		t.Fatalf("json.Unmarshal(%q) failed: %v", formatted, err)
+	}
+	if gotJSON.DeadlineBudget == nil || *gotJSON.DeadlineBudget != 30 || gotJSON.DeadlineRemaining == nil || *gotJSON.DeadlineRemaining != 28.5 {
+		t.Errorf("FormatJSON: %s, want a deadline budget of 30 and 28.5 remaining", formatted)
+	}
+	gotBinary := &querylogpb.LogStats{}
+	formatted = logStats.Format(url.Values{"format": {"binary"}})
+	if err := streamlog.NewBinaryReader(strings.NewReader(formatted)).Read(gotBinary); err != nil {
+		t.Fatalf("Read(%q): %v", formatted, err)
+	}
+	if !gotBinary.HasDeadline || gotBinary.DeadlineBudget != int64(30*time.Second) || gotBinary.DeadlineRemaining != int64(28500*time.Millisecond) {
+		t.Errorf("Format(binary): %v, %v, %v, want true, %v, %v", gotBinary.HasDeadline, gotBinary.DeadlineBudget, gotBinary.DeadlineRemaining, int64(30*time.Second), int64(28500*time.Millisecond))
+	}
+
+	// Without a deadline, the columns are empty, the JSON fields are
+	// omitted and the proto fields are unset.
+	logStats = newLogStats("test", context.Background())
+	logStats.EndTime = logStats.StartTime
+	if _, ok := logStats.DeadlineBudget(); ok {
+		t.Errorf("DeadlineBudget without a deadline: true, want false")
+	}
+	if got := logStats.Format(url.Values{}); !strings.HasSuffix(got, fmt.Sprintf("\t0.000000\t0.000000\t\t\t%q\t\"\"\t\n", logStats.QueryID)) {
+		t.Errorf("Format without a deadline: %q, want empty deadline columns", got)
+	}
+	if formatted := logStats.FormatJSON(url.Values{}); strings.Contains(formatted, "DeadlineBudget") || strings.Contains(formatted, "DeadlineRemaining") {
+		t.Errorf("FormatJSON without a deadline: %s, want no deadline fields", formatted)
+	}
+	formatted = logStats.Format(url.Values{"format": {"binary"}})
+	if err := streamlog.NewBinaryReader(strings.NewReader(formatted)).Read(gotBinary); err != nil {
+		t.Fatalf("Read(%q): %v", formatted, err)
+	}
+	if gotBinary.HasDeadline || gotBinary.DeadlineBudget != 0 || gotBinary.DeadlineRemaining != 0 {
+		t.Errorf("Format(binary) without a deadline: %v, %v, %v, want false, 0, 0", gotBinary.HasDeadline, gotBinary.DeadlineBudget, gotBinary.DeadlineRemaining)
+	}
+}
+
+func TestLogStatsQueryID(t *testing.T) {
+	logStats1 := newLogStats("test", context.Background())
+	logStats2 := newLogStats("test", context.Background())
+	if logStats1.QueryID == logStats2.QueryID {
+		t.Errorf("QueryID: %v twice, want unique IDs", logStats1.QueryID)
+	}
+	for _, id := range []string{logStats1.QueryID, logStats2.QueryID} {
+		if !strings.HasPrefix(id, queryIDPrefix) || len(id) == len(queryIDPrefix) {
+			t.Errorf("QueryID: %v, want %v and a counter", id, queryIDPrefix)
+		}
+	}
+
+	// The ID is in the binary format too.
+	logStats1.EndTime = logStats1.StartTime
+	got := &querylogpb.LogStats{}
+	formatted := logStats1.Format(url.Values{"format": {streamlog.BinaryFormat}})
+	if err := streamlog.NewBinaryReader(strings.NewReader(formatted)).Read(got); err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if got.QueryId != logStats1.QueryID {
+		t.Errorf("QueryId: %v, want %v", got.QueryId, logStats1.QueryID)
+	}
+
+	// A recycled record gets a new ID.
+	id := logStats2.QueryID
+	logStats2.release()
+	if logStats := newLogStats("test", context.Background()); logStats.QueryID == id {
+		t.Errorf("QueryID of a recycled record: %v, want a new ID", logStats.QueryID)
+	}
+}
+
+func TestLogStatsErrorCode(t *testing.T) {
+	logStats := newLogStats("test", context.Background())
+	if logStats.HasError() || logStats.ErrorCode() != "" {
+		t.Errorf("HasError: %v, ErrorCode: %q, want false, \"\"", logStats.HasError(), logStats.ErrorCode())
+	}
+	logStats.Error = NewTabletError(vtrpcpb.ErrorCode_BAD_INPUT, "bad query")
+	if !logStats.HasError() {
+		t.Errorf("HasError: false, want true")
+	}
+	if got, want := logStats.ErrorCode(), "BAD_INPUT"; got != want {
+		t.Errorf("ErrorCode: %q, want %q", got, want)
+	}
+	if got := logStats.Format(url.Values{}); !strings.HasSuffix(got, fmt.Sprintf("\tBAD_INPUT\t{}\tfalse\tfalse\t0\t\"\"\t\"\"\t\"\"\t\"\"\t0.000000\t0.000000\t\t\t%q\t\"\"\t\n", logStats.QueryID)) {
+		t.Errorf("Format: %q, want the error code before the table hits", got)
+	}
+	var got logStatsJSON
+	formatted := logStats.FormatJSON(url.Values{})
+	if err := json.Unmarshal([]byte(formatted), &got); err != nil {
+		t.Fatalf("json.Unmarshal(%q) failed: %v", formatted, err)
+	}
+	if got.ErrorCode != "BAD_INPUT" {
+		t.Errorf("ErrorCode: %q, want BAD_INPUT", got.ErrorCode)
+	}
+}
+
+func TestLogStatsPlanTypeName(t *testing.T) {
+	logStats := newLogStats("StreamExecute", context.Background())
+	logStats.PlanType = "SELECT_STREAM"
+	if got, want := logStats.MethodName(), "StreamExecute"; got != want {
+		t.Errorf("MethodName: %q, want %q", got, want)
+	}
+	if got, want := logStats.PlanTypeName(), "SELECT_STREAM"; got != want {
+		t.Errorf("PlanTypeName: %q, want %q", got, want)
+	}
+}
+
 func TestLogStatsRemoteAddrUsername(t *testing.T) {
 	logStats := newLogStats("test", context.Background())
 	addr, user := logStats.RemoteAddrUsername()
@@ -145,3 +980,428 @@ func TestLogStatsRemoteAddrUsername(t *testing.T) {
 		t.Fatalf("expected to get username: %s, but got: %s", username, user)
 	}
 }
+
+func TestLogStatsFormatRedacted(t *testing.T) {
+	logStats := newLogStats("test", context.Background())
+	logStats.PlanType = "PASS_SELECT"
+	logStats.OriginalSQL = "select * from t where a = 'secret' and b = 12"
+	logStats.BindVariables = map[string]interface{}{"c": "secret", "d": 12}
+	logStats.AddRewrittenSQL("select * from t where a = 'secret' and b = 12 limit 10001", time.Now())
+	logStats.Error = NewTabletErrorSQL(vtrpcpb.ErrorCode_UNKNOWN_ERROR, &sqldb.SQLError{
+		Num:     1105,
+		Message: "unknown error",
+		Query:   "select * from t where a = 'secret' and b = 12 limit 10001",
+	})
+
+	formatted := logStats.Format(url.Values{"redact": {}, "full": {}})
+	if strings.Contains(formatted, "secret") {
+		t.Errorf("Format(redact) = %q, want no literals", formatted)
+	}
+	if !strings.Contains(formatted, "select * from t where a = ? and b = ?") {
+		t.Errorf("Format(redact) = %q, want redacted query", formatted)
+	}
+	if !strings.Contains(formatted, `{"c":"string","d":"int"}`) {
+		t.Errorf("Format(redact) = %q, want bind variable types only", formatted)
+	}
+
+	formatted = logStats.Format(url.Values{"redact": {}, "format": {"json"}})
+	var got logStatsJSON
+	if err := json.Unmarshal([]byte(formatted), &got); err != nil {
+		t.Fatalf("json.Unmarshal(%q) failed: %v", formatted, err)
+	}
+	if want := "select * from t where a = ? and b = ?"; got.OriginalSQL != want {
+		t.Errorf("OriginalSQL: %q, want %q", got.OriginalSQL, want)
+	}
+	if want := []string{"select * from t where a = ? and b = ? limit ?"}; !reflect.DeepEqual(got.RewrittenSQL, want) {
+		t.Errorf("RewrittenSQL: %q, want %q", got.RewrittenSQL, want)
+	}
+	if want := map[string]interface{}{"c": "string", "d": "int"}; !reflect.DeepEqual(got.BindVariables, want) {
+		t.Errorf("BindVariables: %v, want %v", got.BindVariables, want)
+	}
+	if want := "error: unknown error (errno 1105) during query: select * from t where a = ? and b = ? limit ?"; got.Error != want {
+		t.Errorf("Error: %q, want %q", got.Error, want)
+	}
+	if formatted := logStats.Format(url.Values{"redact": {}, "format": {streamlog.BinaryFormat}}); strings.Contains(formatted, "secret") {
+		t.Errorf("Format(redact, binary) = %q, want no literals", formatted)
+	}
+	if formatted := logStats.Format(url.Values{}); !strings.Contains(formatted, "during query: select * from t where a = 'secret'") {
+		t.Errorf("Format = %q, want the query in the error", formatted)
+	}
+
+	// Queries that can't be parsed are replaced by their plan type and a hash.
+	logStats.OriginalSQL = "not a 'secret' query"
+	formatted = logStats.Format(url.Values{"redact": {}})
+	if strings.Contains(formatted, "secret") {
+		t.Errorf("Format(redact) = %q, want no raw query", formatted)
+	}
+	if !strings.Contains(formatted, "PASS_SELECT:") {
+		t.Errorf("Format(redact) = %q, want plan type and hash", formatted)
+	}
+}
+
+func TestLogStatsFormatTruncatedSQL(t *testing.T) {
+	defer func(maxLen int) { *queryLogMaxSQLLen = maxLen }(*queryLogMaxSQLLen)
+	*queryLogMaxSQLLen = 20
+	logStats := newLogStats("test", context.Background())
+	logStats.OriginalSQL = "insert into t values ('aaaa'), ('bbbb')"
+	logStats.AddRewrittenSQL("insert into t values ('aaaa'), ('bbbb') /* _stream */", time.Now())
+
+	formatted := logStats.Format(url.Values{"format": {"json"}})
+	var got logStatsJSON
+	if err := json.Unmarshal([]byte(formatted), &got); err != nil {
+		t.Fatalf("json.Unmarshal(%q) failed: %v", formatted, err)
+	}
+	if want := "insert into t values... [truncated 19 bytes]"; got.OriginalSQL != want {
+		t.Errorf("OriginalSQL: %q, want %q", got.OriginalSQL, want)
+	}
+	if want := []string{"insert into t values... [truncated 33 bytes]"}; !reflect.DeepEqual(got.RewrittenSQL, want) {
+		t.Errorf("RewrittenSQL: %q, want %q", got.RewrittenSQL, want)
+	}
+	if formatted := logStats.Format(url.Values{}); strings.Contains(formatted, "bbbb") {
+		t.Errorf("Format: %q, want the SQL truncated", formatted)
+	}
+	// The LogStats keeps the full SQL.
+	if logStats.OriginalSQL != "insert into t values ('aaaa'), ('bbbb')" {
+		t.Errorf("OriginalSQL was changed to %q", logStats.OriginalSQL)
+	}
+
+	testcases := []struct {
+		sql    string
+		maxLen int
+		want   string
+	}{
+		{"select 1", 0, "select 1"},
+		{"select 1", 8, "select 1"},
+		{"select 12", 8, "select 1... [truncated 1 bytes]"},
+		// "é" is 2 bytes: it's not split.
+		{"select 'é'", 9, "select '... [truncated 3 bytes]"},
+		{"select 'é'", 10, "select 'é... [truncated 1 bytes]"},
+	}
+	for _, tcase := range testcases {
+		if got := truncateSQL(tcase.sql, tcase.maxLen); got != tcase.want {
+			t.Errorf("truncateSQL(%q, %d): %q, want %q", tcase.sql, tcase.maxLen, got, tcase.want)
+		}
+	}
+}
+
+func TestLogStatsSendFingerprint(t *testing.T) {
+	ch := StatsLogger.Subscribe("test")
+	defer StatsLogger.Unsubscribe(ch)
+
+	logStats := newLogStats("test", context.Background())
+	logStats.OriginalSQL = "SELECT a,b FROM t WHERE c = 'x'"
+	logStats.ForceLog = true
+	logStats.Send()
+	want := "select a, b from t where c = ?"
+	select {
+	case got := <-ch:
+		if fp := got.(*LogStats).Fingerprint; fp != want {
+			t.Errorf("Fingerprint: %q, want %q", fp, want)
+		}
+	default:
+		t.Fatalf("record was not logged")
+	}
+	if formatted := logStats.Format(url.Values{}); !strings.HasSuffix(formatted, fmt.Sprintf("\t%q\t0\t\"\"\t\t{}\tfalse\tfalse\t31\t\"\"\t\"\"\t\"\"\t\"\"\t0.000000\t0.000000\t\t\t%q\t\"\"\t\n", want, logStats.QueryID)) {
+		t.Errorf("Format() = %q, want fingerprint %q before the MySQL error columns", formatted, want)
+	}
+}
+
+func TestLogStatsSendSlowQueryGlog(t *testing.T) {
+	defer func(limiter *ratelimiter.RateLimiter) {
+		slowQueryGlogLimiter = limiter
+	}(slowQueryGlogLimiter)
+	defer slowQueryGlogThreshold.Set(slowQueryGlogThreshold.Get())
+	slowQueryGlogLimiter = ratelimiter.NewRateLimiter(2, time.Hour)
+	slowQueryGlogThreshold.Set(1 * time.Millisecond)
+
+	suppressed := slowQueryGlogSuppressed.Get()
+	// Fast queries are not counted.
+	newLogStats("fast", context.Background()).Send()
+	for i := 0; i < 5; i++ {
+		logStats := newLogStats("slow", context.Background())
+		logStats.StartTime = time.Now().Add(-1 * time.Second)
+		logStats.OriginalSQL = "select * from t where id = 1"
+		logStats.Send()
+	}
+	if got := slowQueryGlogSuppressed.Get() - suppressed; got != 3 {
+		t.Errorf("slowQueryGlogSuppressed: %d, want 3", got)
+	}
+
+	// 0 disables it.
+	slowQueryGlogThreshold.Set(0)
+	suppressed = slowQueryGlogSuppressed.Get()
+	logStats := newLogStats("slow", context.Background())
+	logStats.StartTime = time.Now().Add(-1 * time.Second)
+	logStats.Send()
+	if got := slowQueryGlogSuppressed.Get() - suppressed; got != 0 {
+		t.Errorf("slowQueryGlogSuppressed with no threshold: %d, want 0", got)
+	}
+}
+
+func TestLogStatsSendSampling(t *testing.T) {
+	defer setQueryLogSampleRate(int(queryLogSampleRate.Get()))
+	defer StatsLogger.SetSlowThreshold(StatsLogger.SlowThreshold())
+	setQueryLogSampleRate(1 << 40)
+	StatsLogger.SetSlowThreshold(0)
+	ch := StatsLogger.Subscribe("test")
+	defer StatsLogger.Unsubscribe(ch)
+
+	sampledOut := StatsLogger.SampledOut()
+	for i := 0; i < 9; i++ {
+		newLogStats("sampled", context.Background()).Send()
+	}
+	if len(ch) != 0 {
+		t.Errorf("sent %d records, want none", len(ch))
+	}
+	if got := StatsLogger.SampledOut() - sampledOut; got != 9 {
+		t.Errorf("StatsLogger.SampledOut: %d, want 9", got)
+	}
+
+	// ForceLog, failed and slow queries are always sent.
+	StatsLogger.SetSlowThreshold(1 * time.Millisecond)
+	logStats := newLogStats("forced", context.Background())
+	logStats.ForceLog = true
+	logStats.Send()
+	logStats = newLogStats("error", context.Background())
+	logStats.Error = &TabletError{
+		ErrorCode: vtrpcpb.ErrorCode_UNKNOWN_ERROR,
+		Message:   "unknown error",
+	}
+	logStats.Send()
+	logStats = newLogStats("slow", context.Background())
+	logStats.StartTime = time.Now().Add(-1 * time.Second)
+	logStats.Send()
+	for _, want := range []string{"forced", "error", "slow"} {
+		select {
+		case got := <-ch:
+			if method := got.(*LogStats).Method; method != want {
+				t.Errorf("Method: %s, want %s", method, want)
+			}
+		default:
+			t.Errorf("%s query was not logged", want)
+		}
+	}
+}
+
+// adversarialString is a string made of the characters that could break
+// the alignment of the text query log: separators, quotes, escapes,
+// control characters and invalid UTF-8.
+type adversarialString string
+
+var adversarialPieces = []string{
+	"\t", "\n", "\r", "\r\n", " ", "'", "\"", "`", "\\", "\\t", "\\n", "\x00",
+	"\x1b", "\x7f", "\xff", "\xc3", "\u00e9", "\u2028", "\u2029", "\ufeff", "%v",
+	"; ", ":", ",", "{}", "select", "a",
+}
+
+// Generate is part of the quick.Generator interface.
+func (adversarialString) Generate(rand *rand.Rand, size int) reflect.Value {
+	var b bytes.Buffer
+	for n := rand.Intn(size + 1); n > 0; n-- {
+		b.WriteString(adversarialPieces[rand.Intn(len(adversarialPieces))])
+	}
+	return reflect.ValueOf(adversarialString(b.String()))
+}
+
+// TestLogStatsFormatAdversarial checks that any text record can be parsed
+// back by querylogparser. It runs -quickchecks times: set it higher to
+// fuzz for longer.
+func TestLogStatsFormatAdversarial(t *testing.T) {
+	check := func(sql, bindVar, errStr, username, keyspace adversarialString) bool {
+		callInfo := &fakeCallInfo{
+			remoteAddr: string(keyspace),
+			username:   string(username),
+		}
+		logStats := newLogStats("Execute", callinfo.NewContext(context.Background(), callInfo))
+		logStats.PlanType = "PASS_SELECT"
+		logStats.OriginalSQL = string(sql)
+		logStats.AddRewrittenSQL(string(sql)+string(bindVar), time.Now())
+		logStats.BindVariables = map[string]interface{}{
+			string(bindVar): string(sql),
+			"bytes":         []byte(bindVar),
+		}
+		if errStr != "" {
+			logStats.Error = NewTabletError(vtrpcpb.ErrorCode_BAD_INPUT, "%s", errStr)
+		}
+		logStats.Keyspace = string(keyspace)
+		logStats.Shard = string(bindVar)
+		logStats.TabletAlias = string(username)
+		logStats.Fingerprint = string(sql)
+		logStats.TableHits = map[string]int64{string(keyspace): 1}
+		params := url.Values{"full": {}}
+
+		formatted := logStats.Format(params)
+		if strings.Count(formatted, "\n") != 1 || !strings.HasSuffix(formatted, "\n") {
+			t.Errorf("Format: %q, want exactly one line", formatted)
+			return false
+		}
+		record, err := querylogparser.Parse(formatted)
+		if err != nil {
+			t.Errorf("Parse(%q): %v", formatted, err)
+			return false
+		}
+		originalSQL, rewrittenSQLs := truncateLoggedSQL(logStats.loggedSQL(false))
+		want := map[string][2]string{
+			"RemoteAddr":          {record.RemoteAddr, callInfo.remoteAddr},
+			"Username":            {record.Username, callInfo.username},
+			"OriginalSQL":         {record.OriginalSQL, originalSQL},
+			"BindVariables":       {record.BindVariables, logStats.FmtBindVariables(BindVariablesFull)},
+			"RewrittenSQL":        {record.RewrittenSQL, strings.Join(rewrittenSQLs, "; ")},
+			"Error":               {record.Error, logStats.ErrorStr()},
+			"ErrorCode":           {record.ErrorCode, logStats.ErrorCode()},
+			"Fingerprint":         {record.Fingerprint, logStats.Fingerprint},
+			"TableHits":           {record.TableHits, logStats.FmtTableHits()},
+			"RewrittenSQLTimings": {record.RewrittenSQLTimings, strings.Join(logStats.formatTimings(rewrittenSQLs), "; ")},
+			"Keyspace":            {record.Keyspace, logStats.Keyspace},
+			"Shard":               {record.Shard, logStats.Shard},
+			"TabletAlias":         {record.TabletAlias, logStats.TabletAlias},
+		}
+		ok := true
+		for name, values := range want {
+			if values[0] != values[1] {
+				t.Errorf("Parse(%q).%v: %q, want %q", formatted, name, values[0], values[1])
+				ok = false
+			}
+		}
+
+		formatted = logStats.Format(url.Values{"format": {"json"}, "full": {}})
+		if strings.Count(formatted, "\n") != 1 || !strings.HasSuffix(formatted, "\n") {
+			t.Errorf("Format(json): %q, want exactly one line", formatted)
+			return false
+		}
+		var got logStatsJSON
+		if err := json.Unmarshal([]byte(formatted), &got); err != nil {
+			t.Errorf("Format(json): %q is not JSON: %v", formatted, err)
+			return false
+		}
+		return ok
+	}
+	if err := quick.Check(check, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestLogStatsReset(t *testing.T) {
+	logStats := newLogStats("test", context.Background())
+	logStats.OriginalSQL = "select * from t"
+	logStats.BindVariables = map[string]interface{}{"a": 1}
+	logStats.AddRewrittenSQL("select * from t limit 10001", time.Now())
+	logStats.RecordTableAccess("t", 1)
+	logStats.Rows = [][]sqltypes.Value{{sqltypes.MakeString([]byte("a"))}}
+	logStats.Error = NewTabletError(vtrpcpb.ErrorCode_BAD_INPUT, "bad")
+	logStats.Keyspace = "ks"
+	logStats.sent = true
+	logStats.Reset()
+	if got := logStats.RewrittenSQL(); got != "" {
+		t.Errorf("RewrittenSQL after Reset: %q, want \"\"", got)
+	}
+	logStats.rewrittenSqls = nil
+	if !reflect.DeepEqual(logStats, &LogStats{}) {
+		t.Errorf("Reset: %+v, want all the fields cleared", logStats)
+	}
+}
+
+func TestLogStatsRelease(t *testing.T) {
+	defer slowQueryThreshold.Set(slowQueryThreshold.Get())
+	slowQueryThreshold.Set(1 * time.Hour)
+	ch := StatsLogger.Subscribe("test")
+	defer StatsLogger.Unsubscribe(ch)
+
+	// A record that was not sent is cleared by release.
+	logStats := newLogStats("fast", context.Background())
+	logStats.OriginalSQL = "select 1"
+	logStats.Send()
+	logStats.release()
+	if logStats.Method != "" || logStats.OriginalSQL != "" {
+		t.Errorf("record after release: %+v, want it reset", logStats)
+	}
+
+	// A record that was sent is left alone.
+	logStats = newLogStats("forced", context.Background())
+	logStats.OriginalSQL = "select 2"
+	logStats.ForceLog = true
+	logStats.Send()
+	logStats.release()
+	got := (<-ch).(*LogStats)
+	if got.Method != "forced" || got.OriginalSQL != "select 2" {
+		t.Errorf("sent record after release: %+v, want it unchanged", got)
+	}
+}
+
+// TestLogStatsReleaseRace sends records from concurrent queries while a
+// subscriber reads them, to let the race detector catch the reuse of a
+// sent record.
+func TestLogStatsReleaseRace(t *testing.T) {
+	defer slowQueryThreshold.Set(slowQueryThreshold.Get())
+	slowQueryThreshold.Set(1 * time.Hour)
+	ch := StatsLogger.Subscribe("test")
+	defer StatsLogger.Unsubscribe(ch)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for message := range ch {
+			logStats := message.(*LogStats)
+			if want := logStats.OriginalSQL + " limit 10001"; logStats.RewrittenSQL() != want || logStats.Method != "forced" {
+				t.Errorf("record %v: %q, want %q", logStats.Method, logStats.RewrittenSQL(), want)
+			}
+			logStats.Format(url.Values{})
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 500; j++ {
+				logStats := newLogStats("fast", context.Background())
+				logStats.OriginalSQL = fmt.Sprintf("select %v from t%v", j, i)
+				if j%10 == 0 {
+					logStats.Method = "forced"
+					logStats.ForceLog = true
+				}
+				logStats.AddRewrittenSQL(logStats.OriginalSQL+" limit 10001", time.Now())
+				logStats.RecordTableAccess("t", 1)
+				logStats.Send()
+				logStats.release()
+			}
+		}(i)
+	}
+	wg.Wait()
+	StatsLogger.Unsubscribe(ch)
+	close(ch)
+	<-done
+}
+
+// logStatsBenchmarkQuery records a query that is not sent.
+func logStatsBenchmarkQuery(logStats *LogStats) {
+	logStats.OriginalSQL = "select * from t where id = :id"
+	logStats.AddRewrittenSQL("select * from t where id = 1 limit 10001", time.Now())
+	logStats.AddRewrittenSQL("select * from t where id = 2 limit 10001", time.Now())
+	logStats.Send()
+}
+
+// BenchmarkLogStatsNew allocates a record for each query, as it was
+// done before the pool, to compare with BenchmarkLogStatsPool.
+func BenchmarkLogStatsNew(b *testing.B) {
+	defer slowQueryThreshold.Set(slowQueryThreshold.Get())
+	slowQueryThreshold.Set(1 * time.Hour)
+	ctx := context.Background()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		logStatsBenchmarkQuery(&LogStats{Method: "Execute", StartTime: time.Now(), ctx: ctx})
+	}
+}
+
+func BenchmarkLogStatsPool(b *testing.B) {
+	defer slowQueryThreshold.Set(slowQueryThreshold.Get())
+	slowQueryThreshold.Set(1 * time.Hour)
+	ctx := context.Background()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		logStats := newLogStats("Execute", ctx)
+		logStatsBenchmarkQuery(logStats)
+		logStats.release()
+	}
+}
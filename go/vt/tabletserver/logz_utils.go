@@ -33,7 +33,7 @@ func startHTMLTable(w http.ResponseWriter) {
 			white-space: nowrap;
 		}
 		table.gridtable tr.low {
-			background-color: #f0f0f0;
+			background-color: #ccffcc;
 		}
 		table.gridtable tr.medium {
 			background-color: #ffcc00;
@@ -0,0 +1,193 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tabletserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"runtime"
+	"runtime/pprof"
+	"unsafe"
+
+	"github.com/youtube/vitess/go/acl"
+	"github.com/youtube/vitess/go/vt/sqlparser"
+)
+
+const (
+	// connBufferSize is the net_buffer_length of libmysqlclient: each
+	// connection in use has a read and a write buffer of that size.
+	connBufferSize = 2 * 16 * 1024
+
+	// planSampleSize is the number of plans whose size is computed to
+	// estimate the average size of the plans of the cache.
+	planSampleSize = 100
+)
+
+// memoryBreakdown is reported by /debug/memory_breakdown.
+type memoryBreakdown struct {
+	// Runtime has the memory stats of the Go runtime.
+	Runtime struct {
+		HeapAlloc  uint64
+		HeapSys    uint64
+		HeapInuse  uint64
+		StackInuse uint64
+		Sys        uint64
+		NumGC      uint32
+	}
+	// PlanCache is estimated from the average size of a sample of
+	// the plans.
+	PlanCache struct {
+		Entries         int64
+		AveragePlanSize int64
+		EstimatedBytes  int64
+	}
+	// RowCache is the memory used by memcache, it's not in the heap
+	// of the tablet.
+	RowCache struct {
+		Bytes      int64
+		LimitBytes int64
+		Error      string `json:",omitempty"`
+	}
+	// ConnPools has the buffers of the MySQL connections in use,
+	// they are allocated by libmysqlclient.
+	ConnPools []connPoolMemory
+	// PendingResults has the results fetched from MySQL that are held
+	// by the queries being executed, and the buffers of the streaming
+	// queries.
+	PendingResults struct {
+		ResultBytes       int64
+		Streams           int
+		StreamBufferBytes int64
+	}
+}
+
+// connPoolMemory is the memory used by a pool of MySQL connections.
+type connPoolMemory struct {
+	Name           string
+	Capacity       int64
+	InUse          int64
+	EstimatedBytes int64
+}
+
+// getMemoryBreakdown returns the memory used by the caches, the
+// connection pools and the results of the tablet.
+func (tsv *TabletServer) getMemoryBreakdown() *memoryBreakdown {
+	mb := &memoryBreakdown{}
+	var ms runtime.MemStats
+	runtime.ReadMemStats(&ms)
+	mb.Runtime.HeapAlloc = ms.HeapAlloc
+	mb.Runtime.HeapSys = ms.HeapSys
+	mb.Runtime.HeapInuse = ms.HeapInuse
+	mb.Runtime.StackInuse = ms.StackInuse
+	mb.Runtime.Sys = ms.Sys
+	mb.Runtime.NumGC = ms.NumGC
+
+	qe := tsv.qe
+	mb.PlanCache.Entries = qe.schemaInfo.queries.Length()
+	mb.PlanCache.AveragePlanSize = averagePlanSize(qe.schemaInfo)
+	mb.PlanCache.EstimatedBytes = mb.PlanCache.Entries * mb.PlanCache.AveragePlanSize
+
+	used, limit, err := qe.cachePool.MemoryUsage()
+	mb.RowCache.Bytes = used
+	mb.RowCache.LimitBytes = limit
+	if err != nil {
+		mb.RowCache.Error = err.Error()
+	}
+
+	for _, p := range []struct {
+		name string
+		pool *ConnPool
+	}{
+		{"Conn", qe.connPool},
+		{"StreamConn", qe.streamConnPool},
+		{"Transaction", qe.txPool.pool},
+	} {
+		capacity := p.pool.Capacity()
+		inUse := capacity - p.pool.Available()
+		mb.ConnPools = append(mb.ConnPools, connPoolMemory{
+			Name:           p.name,
+			Capacity:       capacity,
+			InUse:          inUse,
+			EstimatedBytes: inUse * connBufferSize,
+		})
+	}
+
+	mb.PendingResults.ResultBytes = qe.pendingResultBytes.Get()
+	mb.PendingResults.Streams = qe.streamQList.Len()
+	mb.PendingResults.StreamBufferBytes = int64(mb.PendingResults.Streams) * qe.streamBufferSize.Get()
+	return mb
+}
+
+// averagePlanSize returns the average size of the first plans of the
+// cache, 0 if it's empty.
+func averagePlanSize(si *SchemaInfo) int64 {
+	items := si.queries.Items()
+	if len(items) > planSampleSize {
+		items = items[:planSampleSize]
+	}
+	if len(items) == 0 {
+		return 0
+	}
+	var total int64
+	for _, item := range items {
+		total += int64(len(item.Key))
+		if plan, ok := item.Value.(*ExecPlan); ok {
+			total += planSize(plan)
+		}
+	}
+	return total / int64(len(items))
+}
+
+// planSize returns the approximate size of a plan: its structs, and
+// the strings of its queries, fields and tables.
+func planSize(plan *ExecPlan) int64 {
+	size := int64(unsafe.Sizeof(*plan))
+	for _, f := range plan.Fields {
+		size += int64(unsafe.Sizeof(*f)) + int64(len(f.Name))
+	}
+	if plan.ExecPlan == nil {
+		return size
+	}
+	size += int64(unsafe.Sizeof(*plan.ExecPlan))
+	for _, pq := range []*sqlparser.ParsedQuery{
+		plan.FieldQuery,
+		plan.FullQuery,
+		plan.OuterQuery,
+		plan.Subquery,
+		plan.UpsertQuery,
+	} {
+		if pq != nil {
+			size += int64(unsafe.Sizeof(*pq)) + int64(len(pq.Query))
+		}
+	}
+	for _, name := range plan.TableNames {
+		size += int64(len(name))
+	}
+	return size
+}
+
+func (tsv *TabletServer) registerMemoryBreakdownHandler() {
+	http.HandleFunc("/debug/memory_breakdown", func(w http.ResponseWriter, r *http.Request) {
+		if err := acl.CheckAccessHTTP(r, acl.DEBUGGING); err != nil {
+			acl.SendError(w, err)
+			return
+		}
+		// The heap profile can be read by pprof and go-torch.
+		if r.FormValue("format") == "pprof" {
+			w.Header().Set("Content-Type", "application/octet-stream")
+			if err := pprof.Lookup("heap").WriteTo(w, 0); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+			}
+			return
+		}
+		b, err := json.MarshalIndent(tsv.getMemoryBreakdown(), "", " ")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Write(b)
+	})
+}
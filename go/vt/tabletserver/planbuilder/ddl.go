@@ -4,13 +4,21 @@
 
 package planbuilder
 
-import "github.com/youtube/vitess/go/vt/sqlparser"
+import (
+	"regexp"
+	"strings"
+
+	"github.com/youtube/vitess/go/vt/sqlparser"
+)
 
 // DDLPlan provides a plan for DDLs.
 type DDLPlan struct {
 	Action    string
 	TableName string
 	NewName   string
+	// InstantColumns is set if the DDL only appends columns to
+	// the table with ALGORITHM=INSTANT.
+	InstantColumns []string
 }
 
 // DDLParse parses a DDL and produces a DDLPlan.
@@ -23,11 +31,93 @@ func DDLParse(sql string) (plan *DDLPlan) {
 	if !ok {
 		return &DDLPlan{Action: ""}
 	}
-	return &DDLPlan{
+	plan = &DDLPlan{
 		Action:    stmt.Action,
 		TableName: string(stmt.Table),
 		NewName:   string(stmt.NewName),
 	}
+	if plan.Action == sqlparser.AlterStr && plan.TableName == plan.NewName {
+		plan.InstantColumns = instantAddColumns(sql)
+	}
+	return plan
+}
+
+var (
+	alterTableRE  = regexp.MustCompile("(?is)^\\s*alter\\s+table\\s+\\S+\\s+(.*)$")
+	addColumnRE   = regexp.MustCompile("(?is)^add\\s+(column\\s+)?(`[^`]+`|\\w+)\\s+\\S")
+	algorithmRE   = regexp.MustCompile("(?i)^algorithm\\s*=?\\s*instant$")
+	positionRE    = regexp.MustCompile("(?i)\\b(first|after)\\b")
+	notColumnKeys = map[string]bool{
+		"check":      true,
+		"constraint": true,
+		"foreign":    true,
+		"fulltext":   true,
+		"index":      true,
+		"key":        true,
+		"partition":  true,
+		"primary":    true,
+		"spatial":    true,
+		"unique":     true,
+	}
+)
+
+// instantAddColumns returns the names of the columns added by sql if
+// it's an ALTER TABLE that only contains ADD COLUMN clauses and
+// ALGORITHM=INSTANT. Columns added with FIRST or AFTER are not
+// supported because they don't go at the end of the table.
+// It returns nil otherwise.
+func instantAddColumns(sql string) []string {
+	match := alterTableRE.FindStringSubmatch(sql)
+	if match == nil {
+		return nil
+	}
+	var columns []string
+	instant := false
+	for _, clause := range splitAlterClauses(match[1]) {
+		if algorithmRE.MatchString(clause) {
+			instant = true
+			continue
+		}
+		add := addColumnRE.FindStringSubmatch(clause)
+		if add == nil || positionRE.MatchString(clause) {
+			return nil
+		}
+		name := add[2]
+		if add[1] == "" && notColumnKeys[strings.ToLower(name)] {
+			return nil
+		}
+		columns = append(columns, strings.Trim(name, "`"))
+	}
+	if !instant {
+		return nil
+	}
+	return columns
+}
+
+// splitAlterClauses splits the body of an ALTER TABLE on the commas
+// that are not within parenthesis or quotes.
+func splitAlterClauses(body string) []string {
+	var clauses []string
+	depth, start := 0, 0
+	var quote rune
+	for i, c := range body {
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '(':
+			depth++
+		case c == ')':
+			depth--
+		case c == ',' && depth == 0:
+			clauses = append(clauses, strings.TrimSpace(body[start:i]))
+			start = i + 1
+		}
+	}
+	return append(clauses, strings.TrimSpace(body[start:]))
 }
 
 func analyzeDDL(ddl *sqlparser.DDL, getTable TableGetter) *ExecPlan {
@@ -128,6 +128,25 @@ func analyzeSet(set *sqlparser.Set) (plan *ExecPlan) {
 	return plan
 }
 
+func analyzeLockTables(lock *sqlparser.LockTables, getTable TableGetter) (plan *ExecPlan, err error) {
+	plan = &ExecPlan{
+		PlanID:    PlanLockTables,
+		FullQuery: GenerateFullQuery(lock),
+	}
+	// Only the tables of the schema can be locked. The table ACL
+	// is checked against the first one.
+	for _, table := range lock.Tables {
+		tableName := sqlparser.GetTableName(table.Table)
+		if _, ok := getTable(tableName); !ok {
+			return nil, fmt.Errorf("table %s not found in schema", sqlparser.String(table.Table))
+		}
+	}
+	if _, err := plan.setTableInfo(sqlparser.GetTableName(lock.Tables[0].Table), getTable); err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
 func analyzeUpdateExpressions(exprs sqlparser.UpdateExprs, pkIndex *schema.Index) (pkValues []interface{}, err error) {
 	for _, expr := range exprs {
 		index := pkIndex.FindColumn(sqlparser.GetColName(expr.Name))
@@ -0,0 +1,360 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package planbuilder
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/youtube/vitess/go/sqltypes"
+	"github.com/youtube/vitess/go/vt/key"
+	"github.com/youtube/vitess/go/vt/sqlparser"
+
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+)
+
+// KeyRangeFilter restricts the rows a query can see or modify to the
+// key range of a shard. The tables that have the sharding column get
+// an additional predicate on it in every select, update and delete.
+// The values inserts and updates write to the sharding column are
+// checked at execution time, see KeyRangeCheck.
+type KeyRangeFilter struct {
+	ShardingColumnName string
+	ShardingColumnType topodatapb.KeyspaceIdType
+	KeyRange           *topodatapb.KeyRange
+}
+
+// String returns a printable representation of the filter.
+func (krf *KeyRangeFilter) String() string {
+	if krf == nil {
+		return "<nil>"
+	}
+	return fmt.Sprintf("%v(%v) in %v", krf.ShardingColumnName, krf.ShardingColumnType, key.KeyRangeString(krf.KeyRange))
+}
+
+// condition returns the predicate on the sharding column of the
+// table qualified by qualifier, or nil if the key range is full.
+func (krf *KeyRangeFilter) condition(qualifier string) (sqlparser.BoolExpr, error) {
+	if !key.KeyRangeIsPartial(krf.KeyRange) {
+		return nil, nil
+	}
+	col := &sqlparser.ColName{Name: sqlparser.SQLName(krf.ShardingColumnName)}
+	if qualifier != "" {
+		col.Qualifier = &sqlparser.TableName{Name: sqlparser.SQLName(qualifier)}
+	}
+
+	var left sqlparser.ValExpr
+	var value func([]byte) (sqlparser.ValExpr, error)
+	switch krf.ShardingColumnType {
+	case topodatapb.KeyspaceIdType_UINT64:
+		left = col
+		value = func(keyspaceID []byte) (sqlparser.ValExpr, error) {
+			if len(keyspaceID) > 8 {
+				return nil, fmt.Errorf("the key range bound %x is longer than 8 bytes, cannot compare it to the uint64 sharding column %v", keyspaceID, krf.ShardingColumnName)
+			}
+			h := hex.EncodeToString(keyspaceID)
+			return sqlparser.NumVal("0x" + h + strings.Repeat("0", 16-len(h))), nil
+		}
+	case topodatapb.KeyspaceIdType_BYTES:
+		left = &sqlparser.FuncExpr{Name: "hex", Exprs: sqlparser.SelectExprs{&sqlparser.NonStarExpr{Expr: col}}}
+		value = func(keyspaceID []byte) (sqlparser.ValExpr, error) {
+			return sqlparser.StrVal(strings.ToUpper(hex.EncodeToString(keyspaceID))), nil
+		}
+	default:
+		return nil, fmt.Errorf("unsupported sharding column type for key range enforcement: %v", krf.ShardingColumnType)
+	}
+
+	var cond sqlparser.BoolExpr
+	if len(krf.KeyRange.Start) > 0 {
+		start, err := value(krf.KeyRange.Start)
+		if err != nil {
+			return nil, err
+		}
+		cond = &sqlparser.ComparisonExpr{Operator: sqlparser.GreaterEqualStr, Left: left, Right: start}
+	}
+	if len(krf.KeyRange.End) > 0 {
+		end, err := value(krf.KeyRange.End)
+		if err != nil {
+			return nil, err
+		}
+		cond = andConditions(cond, &sqlparser.ComparisonExpr{Operator: sqlparser.LessThanStr, Left: left, Right: end})
+	}
+	return cond, nil
+}
+
+// keyspaceID returns the keyspace id of a value of the sharding column.
+func (krf *KeyRangeFilter) keyspaceID(value sqltypes.Value) ([]byte, error) {
+	if value.IsNull() {
+		return nil, fmt.Errorf("the sharding column %v cannot be NULL", krf.ShardingColumnName)
+	}
+	switch krf.ShardingColumnType {
+	case topodatapb.KeyspaceIdType_UINT64:
+		id, err := strconv.ParseUint(value.String(), 0, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %v for the uint64 sharding column %v", value, krf.ShardingColumnName)
+		}
+		return key.Uint64Key(id).Bytes(), nil
+	case topodatapb.KeyspaceIdType_BYTES:
+		return value.Raw(), nil
+	}
+	return nil, fmt.Errorf("unsupported sharding column type for key range enforcement: %v", krf.ShardingColumnType)
+}
+
+// KeyRangeCheck checks the values an insert or an update writes to
+// the sharding column, so it can't create rows out of the key range
+// of its filter.
+type KeyRangeCheck struct {
+	Filter *KeyRangeFilter
+	// Values are sqltypes.Value literals, or bind variable names
+	// starting with ':'.
+	Values []interface{}
+}
+
+// Check returns an error if one of the values is not in the key range.
+func (krc *KeyRangeCheck) Check(bindVars map[string]interface{}) error {
+	for _, v := range krc.Values {
+		if name, ok := v.(string); ok {
+			bv, _, err := sqlparser.FetchBindVar(name, bindVars)
+			if err != nil {
+				return err
+			}
+			v = bv
+		}
+		value, err := sqltypes.BuildValue(v)
+		if err != nil {
+			return err
+		}
+		keyspaceID, err := krc.Filter.keyspaceID(value)
+		if err != nil {
+			return err
+		}
+		if !key.KeyRangeContains(krc.Filter.KeyRange, keyspaceID) {
+			return fmt.Errorf("the sharding column value %v is not in the key range %v", value, key.KeyRangeString(krc.Filter.KeyRange))
+		}
+	}
+	return nil
+}
+
+// keyRangeCheck returns the check of the values the insert or the
+// update statement writes to the sharding column, or nil if it writes
+// none. The statements whose values can't be checked are rejected.
+func keyRangeCheck(statement sqlparser.Statement, filter *KeyRangeFilter, getTable TableGetter) (*KeyRangeCheck, error) {
+	if filter == nil || !key.KeyRangeIsPartial(filter.KeyRange) {
+		return nil, nil
+	}
+	var tableName string
+	var exprs sqlparser.UpdateExprs
+	check := &KeyRangeCheck{Filter: filter}
+	switch stmt := statement.(type) {
+	case *sqlparser.Insert:
+		tableName = sqlparser.GetTableName(stmt.Table)
+		tableInfo, ok := getTable(tableName)
+		if !ok {
+			return nil, nil
+		}
+		index := tableInfo.FindColumn(filter.ShardingColumnName)
+		if index == -1 {
+			return nil, nil
+		}
+		rows, ok := stmt.Rows.(sqlparser.Values)
+		if !ok {
+			return nil, fmt.Errorf("cannot enforce the key range on an insert into %v from a select", tableName)
+		}
+		// Without a column list, the values are in the order of the
+		// table columns.
+		columnNumber := index
+		if len(stmt.Columns) != 0 {
+			columnNumber = -1
+			for i, column := range stmt.Columns {
+				if sqlparser.GetColName(column.(*sqlparser.NonStarExpr).Expr) == filter.ShardingColumnName {
+					columnNumber = i
+				}
+			}
+		}
+		for _, row := range rows {
+			if columnNumber == -1 {
+				check.Values = append(check.Values, tableInfo.Columns[index].Default)
+				continue
+			}
+			tuple, ok := row.(sqlparser.ValTuple)
+			if !ok {
+				return nil, fmt.Errorf("cannot enforce the key range on an insert into %v with a row subquery", tableName)
+			}
+			if columnNumber >= len(tuple) {
+				return nil, errors.New("column count doesn't match value count")
+			}
+			value, err := keyRangeValue(tableName, tuple[columnNumber])
+			if err != nil {
+				return nil, err
+			}
+			check.Values = append(check.Values, value)
+		}
+		exprs = sqlparser.UpdateExprs(stmt.OnDup)
+	case *sqlparser.Update:
+		tableName = sqlparser.GetTableName(stmt.Table)
+		exprs = stmt.Exprs
+	default:
+		return nil, nil
+	}
+	for _, expr := range exprs {
+		if string(expr.Name.Name) != filter.ShardingColumnName {
+			continue
+		}
+		value, err := keyRangeValue(tableName, expr.Expr)
+		if err != nil {
+			return nil, err
+		}
+		check.Values = append(check.Values, value)
+	}
+	if check.Values == nil {
+		return nil, nil
+	}
+	return check, nil
+}
+
+// keyRangeValue returns the value to check for node, which must be a
+// literal or a bind variable.
+func keyRangeValue(tableName string, node sqlparser.ValExpr) (interface{}, error) {
+	switch node.(type) {
+	case sqlparser.ValArg, sqlparser.StrVal, sqlparser.NumVal, *sqlparser.NullVal:
+		return sqlparser.AsInterface(node)
+	}
+	return nil, fmt.Errorf("cannot enforce the key range on the value %v of the sharding column of %v: it must be a literal or a bind variable", sqlparser.String(node), tableName)
+}
+
+// andConditions returns left and right, either of which can be nil.
+func andConditions(left, right sqlparser.BoolExpr) sqlparser.BoolExpr {
+	if left == nil {
+		return right
+	}
+	if right == nil {
+		return left
+	}
+	// AND binds tighter than OR: keep the original condition whole.
+	if or, ok := left.(*sqlparser.OrExpr); ok {
+		left = &sqlparser.ParenBoolExpr{Expr: or}
+	}
+	if or, ok := right.(*sqlparser.OrExpr); ok {
+		right = &sqlparser.ParenBoolExpr{Expr: or}
+	}
+	return &sqlparser.AndExpr{Left: left, Right: right}
+}
+
+// addWhere adds cond to where and returns the new where clause.
+func addWhere(where *sqlparser.Where, cond sqlparser.BoolExpr) *sqlparser.Where {
+	if where == nil {
+		return sqlparser.NewWhere(sqlparser.WhereStr, cond)
+	}
+	where.Expr = andConditions(where.Expr, cond)
+	return where
+}
+
+// addKeyRangeFilter rewrites the selects, updates and deletes of
+// statement so they only apply to the rows in the key range of filter.
+func addKeyRangeFilter(statement sqlparser.Statement, filter *KeyRangeFilter, getTable TableGetter) error {
+	if filter == nil || !key.KeyRangeIsPartial(filter.KeyRange) {
+		return nil
+	}
+	hasColumn := func(node sqlparser.SimpleTableExpr) bool {
+		tableName := sqlparser.GetTableName(node)
+		if tableName == "" {
+			return false
+		}
+		tableInfo, ok := getTable(tableName)
+		return ok && tableInfo.FindColumn(filter.ShardingColumnName) != -1
+	}
+	return sqlparser.Walk(func(node sqlparser.SQLNode) (kontinue bool, err error) {
+		switch node := node.(type) {
+		case *sqlparser.Select:
+			if err := addSelectKeyRangeFilter(node, filter, hasColumn); err != nil {
+				return false, err
+			}
+		case *sqlparser.Update:
+			if hasColumn(node.Table) {
+				cond, err := filter.condition("")
+				if err != nil {
+					return false, err
+				}
+				node.Where = addWhere(node.Where, cond)
+			}
+		case *sqlparser.Delete:
+			if hasColumn(node.Table) {
+				cond, err := filter.condition("")
+				if err != nil {
+					return false, err
+				}
+				node.Where = addWhere(node.Where, cond)
+			}
+		}
+		return true, nil
+	}, statement)
+}
+
+// addSelectKeyRangeFilter adds the key range condition of every table
+// of the from clause of sel. The tables of the inner side of an outer
+// join are filtered in its on clause, the others in the where clause.
+func addSelectKeyRangeFilter(sel *sqlparser.Select, filter *KeyRangeFilter, hasColumn func(sqlparser.SimpleTableExpr) bool) error {
+	qualify := len(sel.From) > 1
+	if len(sel.From) == 1 {
+		_, ok := sel.From[0].(*sqlparser.AliasedTableExpr)
+		qualify = !ok
+	}
+	var addTableExpr func(node sqlparser.TableExpr, outer *sqlparser.JoinTableExpr) error
+	addTableExpr = func(node sqlparser.TableExpr, outer *sqlparser.JoinTableExpr) error {
+		switch node := node.(type) {
+		case *sqlparser.AliasedTableExpr:
+			if !hasColumn(node.Expr) {
+				return nil
+			}
+			qualifier := ""
+			if qualify {
+				qualifier = string(node.As)
+				if qualifier == "" {
+					qualifier = sqlparser.GetTableName(node.Expr)
+				}
+			}
+			cond, err := filter.condition(qualifier)
+			if err != nil {
+				return err
+			}
+			if outer == nil {
+				sel.Where = addWhere(sel.Where, cond)
+				return nil
+			}
+			if outer.On == nil {
+				return fmt.Errorf("cannot enforce the key range on the table %v of a %v", sqlparser.GetTableName(node.Expr), outer.Join)
+			}
+			outer.On = andConditions(outer.On, cond)
+		case *sqlparser.ParenTableExpr:
+			for _, expr := range node.Exprs {
+				if err := addTableExpr(expr, outer); err != nil {
+					return err
+				}
+			}
+		case *sqlparser.JoinTableExpr:
+			left, right := outer, outer
+			switch node.Join {
+			case sqlparser.LeftJoinStr, sqlparser.NaturalLeftJoinStr:
+				right = node
+			case sqlparser.RightJoinStr, sqlparser.NaturalRightJoinStr:
+				left = node
+			}
+			if err := addTableExpr(node.LeftExpr, left); err != nil {
+				return err
+			}
+			return addTableExpr(node.RightExpr, right)
+		}
+		return nil
+	}
+	for _, expr := range sel.From {
+		if err := addTableExpr(expr, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,239 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package planbuilder
+
+import (
+	"testing"
+
+	"github.com/youtube/vitess/go/sqltypes"
+	"github.com/youtube/vitess/go/vt/schema"
+
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+)
+
+func TestKeyRangeFilter(t *testing.T) {
+	testSchema := loadSchema("schema_test.json")
+	getTable := func(name string) (*schema.Table, bool) {
+		r, ok := testSchema[name]
+		return r, ok
+	}
+	filter := &KeyRangeFilter{
+		ShardingColumnName: "id",
+		ShardingColumnType: topodatapb.KeyspaceIdType_UINT64,
+		KeyRange:           &topodatapb.KeyRange{Start: []byte{0x40}, End: []byte{0x80}},
+	}
+	testCases := []struct {
+		sql  string
+		want string
+	}{{
+		"select * from b",
+		"select * from b where id >= 0x4000000000000000 and id < 0x8000000000000000 limit :#maxLimit",
+	}, {
+		"select * from b where eid = 1 or name = 'a'",
+		"select * from b where (eid = 1 or name = 'a') and id >= 0x4000000000000000 and id < 0x8000000000000000 limit :#maxLimit",
+	}, {
+		"select * from a as x join b on x.eid = b.eid",
+		"select * from a as x join b on x.eid = b.eid where x.id >= 0x4000000000000000 and x.id < 0x8000000000000000 and b.id >= 0x4000000000000000 and b.id < 0x8000000000000000 limit :#maxLimit",
+	}, {
+		"select * from a left join b on a.eid = b.eid",
+		"select * from a left join b on a.eid = b.eid and b.id >= 0x4000000000000000 and b.id < 0x8000000000000000 where a.id >= 0x4000000000000000 and a.id < 0x8000000000000000 limit :#maxLimit",
+	}, {
+		"select * from dual where 1 in (select eid from b)",
+		"select * from dual where 1 in (select eid from b where id >= 0x4000000000000000 and id < 0x8000000000000000) limit :#maxLimit",
+	}, {
+		"update b set eid = 2 where eid = 1",
+		"update b set eid = 2 where eid = 1 and id >= 0x4000000000000000 and id < 0x8000000000000000",
+	}, {
+		"delete from b",
+		"delete from b where id >= 0x4000000000000000 and id < 0x8000000000000000",
+	}, {
+		"insert into b(eid, id) values (1, 0x4000000000000001)",
+		"insert into b(eid, id) values (1, 0x4000000000000001)",
+	}}
+	for _, tcase := range testCases {
+		plan, err := GetExecPlan(tcase.sql, getTable, filter)
+		if err != nil {
+			t.Errorf("GetExecPlan(%q): %v", tcase.sql, err)
+			continue
+		}
+		if got := plan.FullQuery.Query; got != tcase.want {
+			t.Errorf("GetExecPlan(%q).FullQuery: %v, want %v", tcase.sql, got, tcase.want)
+		}
+	}
+
+	// The range condition disables the pk plans.
+	plan, err := GetExecPlan("delete from b where eid = 1 and id = 1", getTable, filter)
+	if err != nil {
+		t.Fatalf("GetExecPlan: %v", err)
+	}
+	if plan.PlanID != PlanDMLSubquery {
+		t.Errorf("PlanID: %v, want %v", plan.PlanID, PlanDMLSubquery)
+	}
+	want := "select eid, id from b where eid = 1 and id = 1 and id >= 0x4000000000000000 and id < 0x8000000000000000 limit :#maxLimit for update"
+	if got := plan.Subquery.Query; got != want {
+		t.Errorf("Subquery: %v, want %v", got, want)
+	}
+
+	plan, err = GetStreamExecPlan("select * from b", getTable, filter)
+	if err != nil {
+		t.Fatalf("GetStreamExecPlan: %v", err)
+	}
+	if got, want := plan.FullQuery.Query, "select * from b where id >= 0x4000000000000000 and id < 0x8000000000000000"; got != want {
+		t.Errorf("GetStreamExecPlan.FullQuery: %v, want %v", got, want)
+	}
+
+	_, err = GetExecPlan("select * from a natural left join b", getTable, filter)
+	if want := "cannot enforce the key range on the table b of a natural left join"; err == nil || err.Error() != want {
+		t.Errorf("natural left join: %v, want %v", err, want)
+	}
+
+	filter.KeyRange = &topodatapb.KeyRange{Start: []byte{1, 2, 3, 4, 5, 6, 7, 8, 9}}
+	_, err = GetExecPlan("select * from b", getTable, filter)
+	if want := "the key range bound 010203040506070809 is longer than 8 bytes, cannot compare it to the uint64 sharding column id"; err == nil || err.Error() != want {
+		t.Errorf("long key range bound: %v, want %v", err, want)
+	}
+
+	// A full key range or no filter leave the query unchanged.
+	for _, filter := range []*KeyRangeFilter{nil, {ShardingColumnName: "id", KeyRange: &topodatapb.KeyRange{}}} {
+		plan, err := GetExecPlan("select * from b", getTable, filter)
+		if err != nil {
+			t.Fatalf("GetExecPlan(%v): %v", filter, err)
+		}
+		if got, want := plan.FullQuery.Query, "select * from b limit :#maxLimit"; got != want {
+			t.Errorf("GetExecPlan(%v).FullQuery: %v, want %v", filter, got, want)
+		}
+	}
+}
+
+func TestKeyRangeFilterBytes(t *testing.T) {
+	filter := &KeyRangeFilter{
+		ShardingColumnName: "id",
+		ShardingColumnType: topodatapb.KeyspaceIdType_BYTES,
+		KeyRange:           &topodatapb.KeyRange{Start: []byte{0xa0}},
+	}
+	getTable := func(name string) (*schema.Table, bool) {
+		table := schema.NewTable(name)
+		table.AddColumn("id", sqltypes.VarBinary, sqltypes.NULL, "")
+		return table, true
+	}
+	plan, err := GetExecPlan("select * from t", getTable, filter)
+	if err != nil {
+		t.Fatalf("GetExecPlan: %v", err)
+	}
+	if got, want := plan.FullQuery.Query, "select * from t where hex(id) >= 'A0' limit :#maxLimit"; got != want {
+		t.Errorf("FullQuery: %v, want %v", got, want)
+	}
+
+	filter.ShardingColumnType = topodatapb.KeyspaceIdType_UNSET
+	_, err = GetExecPlan("select * from t", getTable, filter)
+	if want := "unsupported sharding column type for key range enforcement: UNSET"; err == nil || err.Error() != want {
+		t.Errorf("GetExecPlan: %v, want %v", err, want)
+	}
+}
+
+func TestKeyRangeCheck(t *testing.T) {
+	testSchema := loadSchema("schema_test.json")
+	getTable := func(name string) (*schema.Table, bool) {
+		r, ok := testSchema[name]
+		return r, ok
+	}
+	filter := &KeyRangeFilter{
+		ShardingColumnName: "id",
+		ShardingColumnType: topodatapb.KeyspaceIdType_UINT64,
+		KeyRange:           &topodatapb.KeyRange{Start: []byte{0x40}, End: []byte{0x80}},
+	}
+	in := uint64(0x4000000000000001)
+	out := uint64(0x8000000000000001)
+	testCases := []struct {
+		sql      string
+		bindVars map[string]interface{}
+		want     string
+	}{{
+		sql:      "insert into b(eid, id) values (1, 0x4000000000000001), (2, :id)",
+		bindVars: map[string]interface{}{"id": in},
+	}, {
+		sql:  "insert into b(eid, id) values (1, 0x4000000000000001), (2, 1)",
+		want: "the sharding column value 1 is not in the key range 40-80",
+	}, {
+		sql:      "insert into b values (1, :id)",
+		bindVars: map[string]interface{}{"id": out},
+		want:     "the sharding column value 9223372036854775809 is not in the key range 40-80",
+	}, {
+		sql:  "insert into b(eid) values (1)",
+		want: "the sharding column value 0 is not in the key range 40-80",
+	}, {
+		sql:  "insert into b(eid, id) values (1, null)",
+		want: "the sharding column id cannot be NULL",
+	}, {
+		sql: "insert into b(eid, id) values (1, 0x4000000000000001) on duplicate key update id = 0x4000000000000002",
+	}, {
+		sql:  "insert into b(eid, id) values (1, 0x4000000000000001) on duplicate key update id = 1",
+		want: "the sharding column value 1 is not in the key range 40-80",
+	}, {
+		sql:      "update b set id = :id where eid = 1",
+		bindVars: map[string]interface{}{"id": out},
+		want:     "the sharding column value 9223372036854775809 is not in the key range 40-80",
+	}, {
+		sql:      "update b set id = :id where eid = 1",
+		bindVars: map[string]interface{}{},
+		want:     "missing bind var id",
+	}}
+	for _, tcase := range testCases {
+		plan, err := GetExecPlan(tcase.sql, getTable, filter)
+		if err != nil {
+			t.Errorf("GetExecPlan(%q): %v", tcase.sql, err)
+			continue
+		}
+		if plan.KeyRangeCheck == nil {
+			t.Errorf("GetExecPlan(%q).KeyRangeCheck: nil, want a check", tcase.sql)
+			continue
+		}
+		err = plan.KeyRangeCheck.Check(tcase.bindVars)
+		if tcase.want == "" {
+			if err != nil {
+				t.Errorf("Check(%q): %v, want nil", tcase.sql, err)
+			}
+			continue
+		}
+		if err == nil || err.Error() != tcase.want {
+			t.Errorf("Check(%q): %v, want %v", tcase.sql, err, tcase.want)
+		}
+	}
+
+	// The statements that don't write the sharding column have no check.
+	for _, sql := range []string{
+		"update b set eid = 2 where id = 1",
+		"delete from b where eid = 1",
+		"select * from b",
+	} {
+		plan, err := GetExecPlan(sql, getTable, filter)
+		if err != nil {
+			t.Fatalf("GetExecPlan(%q): %v", sql, err)
+		}
+		if plan.KeyRangeCheck != nil {
+			t.Errorf("GetExecPlan(%q).KeyRangeCheck: %+v, want nil", sql, plan.KeyRangeCheck)
+		}
+	}
+
+	// The values that can't be checked are rejected.
+	for _, tcase := range []struct {
+		sql  string
+		want string
+	}{{
+		"insert into b(eid, id) select eid, id from a",
+		"cannot enforce the key range on an insert into b from a select",
+	}, {
+		"insert into b(eid, id) values (1, 0x4000000000000001) on duplicate key update id = id + 1",
+		"cannot enforce the key range on the value id + 1 of the sharding column of b: it must be a literal or a bind variable",
+	}, {
+		"update b set id = eid where eid = 1",
+		"cannot enforce the key range on the value eid of the sharding column of b: it must be a literal or a bind variable",
+	}} {
+		_, err := GetExecPlan(tcase.sql, getTable, filter)
+		if err == nil || err.Error() != tcase.want {
+			t.Errorf("GetExecPlan(%q): %v, want %v", tcase.sql, err, tcase.want)
+		}
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strings"
 
 	log "github.com/golang/glog"
 	"github.com/youtube/vitess/go/vt/schema"
@@ -59,6 +60,10 @@ const (
 	PlanUpsertPK
 	// PlanNextval is for NEXTVAL
 	PlanNextval
+	// PlanLockTables is for LOCK TABLES
+	PlanLockTables
+	// PlanUnlockTables is for UNLOCK TABLES
+	PlanUnlockTables
 	// NumPlans stores the total number of plans
 	NumPlans
 )
@@ -80,6 +85,8 @@ var planName = []string{
 	"OTHER",
 	"UPSERT_PK",
 	"NEXTVAL",
+	"LOCK_TABLES",
+	"UNLOCK_TABLES",
 }
 
 func (pt PlanType) String() string {
@@ -129,6 +136,8 @@ var tableAclRoles = map[PlanType]tableacl.Role{
 	PlanOther:          tableacl.ADMIN,
 	PlanUpsertPK:       tableacl.WRITER,
 	PlanNextval:        tableacl.WRITER,
+	PlanLockTables:     tableacl.WRITER,
+	PlanUnlockTables:   tableacl.READER,
 }
 
 // ReasonType indicates why a query plan fails to build
@@ -230,14 +239,35 @@ type ExecPlan struct {
 	// PlanSet
 	SetKey   string      `json:",omitempty"`
 	SetValue interface{} `json:",omitempty"`
+
+	// TableNames are the tables of the schema referenced
+	// by the query, including those of joins and subqueries.
+	TableNames []string `json:"-"`
+
+	// Resumable is set for the plans of GetResumableStreamExecPlan.
+	Resumable *ResumableStream `json:",omitempty"`
+
+	// KeyRangeCheck is set for the inserts and updates that write to
+	// the sharding column of a KeyRangeFilter.
+	KeyRangeCheck *KeyRangeCheck `json:",omitempty"`
+
+	// Compressed is set if the table has the COMPRESSED row format.
+	// The streams of these tables are sent in bigger chunks, see
+	// -queryserver-config-compressed-stream-buffer-size.
+	Compressed bool `json:",omitempty"`
 }
 
+// rowFormatCompressed is the row_format of information_schema.tables
+// for the tables created with ROW_FORMAT=COMPRESSED.
+const rowFormatCompressed = "Compressed"
+
 func (plan *ExecPlan) setTableInfo(tableName string, getTable TableGetter) (*schema.Table, error) {
 	tableInfo, ok := getTable(tableName)
 	if !ok {
 		return nil, fmt.Errorf("table %s not found in schema", tableName)
 	}
 	plan.TableName = tableInfo.Name
+	plan.Compressed = strings.EqualFold(tableInfo.RowFormat, rowFormatCompressed)
 	return tableInfo, nil
 }
 
@@ -245,27 +275,77 @@ func (plan *ExecPlan) setTableInfo(tableName string, getTable TableGetter) (*sch
 type TableGetter func(tableName string) (*schema.Table, bool)
 
 // GetExecPlan generates a ExecPlan given a sql query and a TableGetter.
-func GetExecPlan(sql string, getTable TableGetter) (plan *ExecPlan, err error) {
+// If filter is not nil, the query is restricted to its key range.
+func GetExecPlan(sql string, getTable TableGetter, filter *KeyRangeFilter) (plan *ExecPlan, err error) {
 	statement, err := sqlparser.Parse(sql)
 	if err != nil {
 		return nil, err
 	}
+	if err := addKeyRangeFilter(statement, filter, getTable); err != nil {
+		return nil, err
+	}
+	check, err := keyRangeCheck(statement, filter, getTable)
+	if err != nil {
+		return nil, err
+	}
 	plan, err = analyzeSQL(statement, getTable)
 	if err != nil {
 		return nil, err
 	}
+	plan.KeyRangeCheck = check
 	if plan.PlanID == PlanPassDML {
 		log.Warningf("PASS_DML: %s", sql)
 	}
+	plan.TableNames = tableNames(statement, getTable)
 	return plan, nil
 }
 
+// tableNames returns the names of the tables of the schema that are
+// referenced by statement.
+func tableNames(statement sqlparser.Statement, getTable TableGetter) []string {
+	var names []string
+	addTable := func(node sqlparser.SimpleTableExpr) {
+		name := sqlparser.GetTableName(node)
+		if name == "" || name == "dual" {
+			return
+		}
+		for _, n := range names {
+			if n == name {
+				return
+			}
+		}
+		if _, ok := getTable(name); ok {
+			names = append(names, name)
+		}
+	}
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (kontinue bool, err error) {
+		switch node := node.(type) {
+		case *sqlparser.AliasedTableExpr:
+			addTable(node.Expr)
+		case *sqlparser.Insert:
+			addTable(node.Table)
+		case *sqlparser.Update:
+			addTable(node.Table)
+		case *sqlparser.Delete:
+			addTable(node.Table)
+		case *sqlparser.LockTable:
+			addTable(node.Table)
+		}
+		return true, nil
+	}, statement)
+	return names
+}
+
 // GetStreamExecPlan generates a ExecPlan given a sql query and a TableGetter.
-func GetStreamExecPlan(sql string, getTable TableGetter) (plan *ExecPlan, err error) {
+// If filter is not nil, the query is restricted to its key range.
+func GetStreamExecPlan(sql string, getTable TableGetter, filter *KeyRangeFilter) (plan *ExecPlan, err error) {
 	statement, err := sqlparser.Parse(sql)
 	if err != nil {
 		return nil, err
 	}
+	if err := addKeyRangeFilter(statement, filter, getTable); err != nil {
+		return nil, err
+	}
 
 	plan = &ExecPlan{
 		PlanID:    PlanSelectStream,
@@ -291,6 +371,7 @@ func GetStreamExecPlan(sql string, getTable TableGetter) (plan *ExecPlan, err er
 	default:
 		return nil, fmt.Errorf("'%v' not allowed for streaming", sqlparser.String(stmt))
 	}
+	plan.TableNames = tableNames(statement, getTable)
 
 	return plan, nil
 }
@@ -318,6 +399,10 @@ func analyzeSQL(statement sqlparser.Statement, getTable TableGetter) (plan *Exec
 		return analyzeDDL(stmt, getTable), nil
 	case *sqlparser.Other:
 		return &ExecPlan{PlanID: PlanOther}, nil
+	case *sqlparser.LockTables:
+		return analyzeLockTables(stmt, getTable)
+	case *sqlparser.UnlockTables:
+		return &ExecPlan{PlanID: PlanUnlockTables, FullQuery: GenerateFullQuery(stmt)}, nil
 	}
 	return nil, errors.New("invalid SQL")
 }
@@ -15,6 +15,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -28,7 +29,7 @@ func TestPlan(t *testing.T) {
 		plan, err := GetExecPlan(tcase.input, func(name string) (*schema.Table, bool) {
 			r, ok := testSchema[name]
 			return r, ok
-		})
+		}, nil)
 		var out string
 		if err != nil {
 			out = err.Error()
@@ -69,7 +70,7 @@ func TestCustom(t *testing.T) {
 		for _, file := range files {
 			t.Logf("Testing file %s", file)
 			for tcase := range iterateExecFile(file) {
-				plan, err := GetExecPlan(tcase.input, getter)
+				plan, err := GetExecPlan(tcase.input, getter, nil)
 				var out string
 				if err != nil {
 					out = err.Error()
@@ -94,7 +95,7 @@ func TestStreamPlan(t *testing.T) {
 		plan, err := GetStreamExecPlan(tcase.input, func(name string) (*schema.Table, bool) {
 			r, ok := testSchema[name]
 			return r, ok
-		})
+		}, nil)
 		var out string
 		if err != nil {
 			out = err.Error()
@@ -112,6 +113,67 @@ func TestStreamPlan(t *testing.T) {
 	}
 }
 
+func TestPlanCompressed(t *testing.T) {
+	testSchema := loadSchema("schema_test.json")
+	testSchema["b"].RowFormat = "COMPRESSED"
+	getTable := func(name string) (*schema.Table, bool) {
+		r, ok := testSchema[name]
+		return r, ok
+	}
+	for _, tcase := range []struct {
+		sql  string
+		want bool
+	}{
+		{"select * from a", false},
+		{"select * from b", true},
+	} {
+		plan, err := GetStreamExecPlan(tcase.sql, getTable, nil)
+		if err != nil {
+			t.Errorf("GetStreamExecPlan(%q): %v", tcase.sql, err)
+			continue
+		}
+		if plan.Compressed != tcase.want {
+			t.Errorf("GetStreamExecPlan(%q).Compressed: %v, want %v", tcase.sql, plan.Compressed, tcase.want)
+		}
+	}
+}
+
+func TestPlanTableNames(t *testing.T) {
+	testSchema := loadSchema("schema_test.json")
+	getTable := func(name string) (*schema.Table, bool) {
+		r, ok := testSchema[name]
+		return r, ok
+	}
+	testCases := []struct {
+		sql  string
+		want []string
+	}{
+		{"select * from a", []string{"a"}},
+		{"select a.eid, b.eid from a join b on a.eid = b.eid", []string{"a", "b"}},
+		{"select * from a where eid in (select eid from b) union select * from a", []string{"a", "b"}},
+		{"select * from (select * from b) as t, unknown", []string{"b"}},
+		{"insert into a(eid, id) values (1, 1)", []string{"a"}},
+		{"select 1 from dual", nil},
+	}
+	for _, tcase := range testCases {
+		plan, err := GetExecPlan(tcase.sql, getTable, nil)
+		if err != nil {
+			t.Errorf("GetExecPlan(%q): %v", tcase.sql, err)
+			continue
+		}
+		if !reflect.DeepEqual(plan.TableNames, tcase.want) {
+			t.Errorf("GetExecPlan(%q).TableNames: %v, want %v", tcase.sql, plan.TableNames, tcase.want)
+		}
+	}
+	plan, err := GetStreamExecPlan("select * from a join b", getTable, nil)
+	if err != nil {
+		t.Fatalf("GetStreamExecPlan: %v", err)
+	}
+	if want := []string{"a", "b"}; !reflect.DeepEqual(plan.TableNames, want) {
+		t.Errorf("GetStreamExecPlan.TableNames: %v, want %v", plan.TableNames, want)
+	}
+}
+
 func TestDDLPlan(t *testing.T) {
 	for tcase := range iterateExecFile("ddl_cases.txt") {
 		plan := DDLParse(tcase.input)
@@ -123,6 +185,9 @@ func TestDDLPlan(t *testing.T) {
 		matchString(t, tcase.lineno, expected["Action"], plan.Action)
 		matchString(t, tcase.lineno, expected["TableName"], plan.TableName)
 		matchString(t, tcase.lineno, expected["NewName"], plan.NewName)
+		if cols, ok := expected["InstantColumns"]; ok || plan.InstantColumns != nil {
+			matchString(t, tcase.lineno, fmt.Sprintf("%v", cols), fmt.Sprintf("%v", plan.InstantColumns))
+		}
 	}
 }
 
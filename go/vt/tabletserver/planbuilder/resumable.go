@@ -0,0 +1,187 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package planbuilder
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/youtube/vitess/go/vt/schema"
+	"github.com/youtube/vitess/go/vt/sqlparser"
+)
+
+// ResumableStream is the part of the plan of a resumable stream that
+// resumes it after a row. The rows are streamed in the order of the
+// primary key, so the stream resumes with the rows whose primary key
+// is greater than the one of the last row received.
+type ResumableStream struct {
+	// ResumeQuery is FullQuery restricted to the rows after the
+	// primary key given in the ResumeBindVar bind variables.
+	ResumeQuery *sqlparser.ParsedQuery
+
+	// PKFields are the indexes in the fields of the result of the
+	// primary key columns, in the order of the primary key.
+	PKFields []int
+}
+
+// ResumeBindVar returns the name of the bind variable of the i-th
+// primary key column of ResumeQuery.
+func ResumeBindVar(i int) string {
+	return fmt.Sprintf("_resume_pk_%d", i)
+}
+
+// GetResumableStreamExecPlan is like GetStreamExecPlan, for a stream
+// that can be resumed after any of its rows. Only the selects of a
+// single table, with all its primary key columns and without
+// distinct, group by, having, limit, lock or other order than the
+// primary key ascending, can be resumed. FullQuery is the query
+// ordered by the primary key.
+func GetResumableStreamExecPlan(sql string, getTable TableGetter, filter *KeyRangeFilter) (*ExecPlan, error) {
+	statement, err := sqlparser.Parse(sql)
+	if err != nil {
+		return nil, err
+	}
+	sel, ok := statement.(*sqlparser.Select)
+	if !ok {
+		return nil, fmt.Errorf("'%v' cannot be resumed: only selects can", sqlparser.String(statement))
+	}
+	if err := addKeyRangeFilter(statement, filter, getTable); err != nil {
+		return nil, err
+	}
+	switch {
+	case sel.Lock != "":
+		return nil, errors.New("select with lock not allowed for streaming")
+	case sel.Distinct != "", sel.GroupBy != nil, sel.Having != nil:
+		return nil, errors.New("select with aggregation cannot be resumed")
+	case sel.Limit != nil:
+		return nil, errors.New("select with limit cannot be resumed")
+	}
+	tableName, _ := analyzeFrom(sel.From)
+	if tableName == "" {
+		return nil, errors.New("select of several tables cannot be resumed")
+	}
+	if tableName == "dual" {
+		return nil, errors.New("select from dual not allowed for streaming")
+	}
+	plan := &ExecPlan{PlanID: PlanSelectStream}
+	tableInfo, err := plan.setTableInfo(tableName, getTable)
+	if err != nil {
+		return nil, err
+	}
+	if len(tableInfo.PKColumns) == 0 {
+		return nil, fmt.Errorf("select of the table %v without primary key cannot be resumed", tableName)
+	}
+	qualifier := tableName
+	if as := sel.From[0].(*sqlparser.AliasedTableExpr).As; as != "" {
+		qualifier = string(as)
+	}
+
+	pkFields, err := resumePKFields(sel.SelectExprs, tableInfo, qualifier)
+	if err != nil {
+		return nil, err
+	}
+	pkOrder := make(sqlparser.OrderBy, 0, len(tableInfo.PKColumns))
+	for i := range tableInfo.PKColumns {
+		pkOrder = append(pkOrder, &sqlparser.Order{
+			Expr:      resumeColumn(tableInfo.GetPKColumn(i).Name, qualifier),
+			Direction: sqlparser.AscScr,
+		})
+	}
+	if sel.OrderBy != nil && !isPKOrder(sel.OrderBy, tableInfo, qualifier) {
+		return nil, errors.New("select with an order other than the primary key cannot be resumed")
+	}
+	sel.OrderBy = pkOrder
+
+	plan.FullQuery = GenerateFullQuery(statement)
+	sel.Where = addWhere(sel.Where, resumeCondition(tableInfo, qualifier, 0))
+	plan.Resumable = &ResumableStream{
+		ResumeQuery: GenerateFullQuery(statement),
+		PKFields:    pkFields,
+	}
+	plan.TableNames = tableNames(statement, getTable)
+	return plan, nil
+}
+
+// resumePKFields returns the indexes in the fields of exprs of the
+// primary key columns of tableInfo, or an error if one is missing.
+func resumePKFields(exprs sqlparser.SelectExprs, tableInfo *schema.Table, qualifier string) ([]int, error) {
+	columns := make(map[string]int)
+	field := 0
+	for _, expr := range exprs {
+		switch expr := expr.(type) {
+		case *sqlparser.StarExpr:
+			for i, col := range tableInfo.Columns {
+				if _, ok := columns[col.Name]; !ok {
+					columns[col.Name] = field + i
+				}
+			}
+			field += len(tableInfo.Columns)
+		case *sqlparser.NonStarExpr:
+			if col, ok := expr.Expr.(*sqlparser.ColName); ok && isColumn(col, qualifier) {
+				if _, ok := columns[string(col.Name)]; !ok {
+					columns[string(col.Name)] = field
+				}
+			}
+			field++
+		default:
+			field++
+		}
+	}
+	pkFields := make([]int, 0, len(tableInfo.PKColumns))
+	for i := range tableInfo.PKColumns {
+		name := tableInfo.GetPKColumn(i).Name
+		f, ok := columns[name]
+		if !ok {
+			return nil, fmt.Errorf("select without the primary key column %v cannot be resumed", name)
+		}
+		pkFields = append(pkFields, f)
+	}
+	return pkFields, nil
+}
+
+// isPKOrder returns true if orderBy orders by the primary key of
+// tableInfo ascending.
+func isPKOrder(orderBy sqlparser.OrderBy, tableInfo *schema.Table, qualifier string) bool {
+	if len(orderBy) != len(tableInfo.PKColumns) {
+		return false
+	}
+	for i, order := range orderBy {
+		col, ok := order.Expr.(*sqlparser.ColName)
+		if !ok || !isColumn(col, qualifier) || string(col.Name) != tableInfo.GetPKColumn(i).Name || order.Direction != sqlparser.AscScr {
+			return false
+		}
+	}
+	return true
+}
+
+// isColumn returns true if col is unqualified, or qualified by
+// qualifier.
+func isColumn(col *sqlparser.ColName, qualifier string) bool {
+	return col.Qualifier == nil || string(col.Qualifier.Name) == qualifier
+}
+
+func resumeColumn(name, qualifier string) *sqlparser.ColName {
+	return &sqlparser.ColName{
+		Name:      sqlparser.SQLName(name),
+		Qualifier: &sqlparser.TableName{Name: sqlparser.SQLName(qualifier)},
+	}
+}
+
+// resumeCondition returns the condition of the rows whose primary key
+// columns from the i-th are after the resume bind variables:
+// pk_i > :v_i or (pk_i = :v_i and <the condition of i+1>).
+func resumeCondition(tableInfo *schema.Table, qualifier string, i int) sqlparser.BoolExpr {
+	col := resumeColumn(tableInfo.GetPKColumn(i).Name, qualifier)
+	arg := sqlparser.ValArg(":" + ResumeBindVar(i))
+	after := &sqlparser.ComparisonExpr{Operator: sqlparser.GreaterThanStr, Left: col, Right: arg}
+	if i == len(tableInfo.PKColumns)-1 {
+		return after
+	}
+	equal := &sqlparser.ComparisonExpr{Operator: sqlparser.EqualStr, Left: col, Right: arg}
+	return &sqlparser.OrExpr{
+		Left:  after,
+		Right: andConditions(equal, resumeCondition(tableInfo, qualifier, i+1)),
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package planbuilder
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/youtube/vitess/go/vt/schema"
+)
+
+func TestResumableStreamExecPlan(t *testing.T) {
+	testSchema := loadSchema("schema_test.json")
+	getTable := func(name string) (*schema.Table, bool) {
+		r, ok := testSchema[name]
+		return r, ok
+	}
+	testCases := []struct {
+		sql      string
+		full     string
+		resume   string
+		pkFields []int
+	}{{
+		sql:      "select * from a",
+		full:     "select * from a order by a.eid asc, a.id asc",
+		resume:   "select * from a where a.eid > :_resume_pk_0 or a.eid = :_resume_pk_0 and a.id > :_resume_pk_1 order by a.eid asc, a.id asc",
+		pkFields: []int{0, 1},
+	}, {
+		sql:      "select name, id, eid from a as x where name = 'a' or foo = 1 order by eid, x.id asc",
+		full:     "select name, id, eid from a as x where name = 'a' or foo = 1 order by x.eid asc, x.id asc",
+		resume:   "select name, id, eid from a as x where (name = 'a' or foo = 1) and (x.eid > :_resume_pk_0 or x.eid = :_resume_pk_0 and x.id > :_resume_pk_1) order by x.eid asc, x.id asc",
+		pkFields: []int{2, 1},
+	}, {
+		sql:      "select foo, * from d",
+		full:     "select foo, * from d order by d.name asc",
+		resume:   "select foo, * from d where d.name > :_resume_pk_0 order by d.name asc",
+		pkFields: []int{1},
+	}}
+	for _, tcase := range testCases {
+		plan, err := GetResumableStreamExecPlan(tcase.sql, getTable, nil)
+		if err != nil {
+			t.Errorf("GetResumableStreamExecPlan(%q): %v", tcase.sql, err)
+			continue
+		}
+		if got := plan.FullQuery.Query; got != tcase.full {
+			t.Errorf("GetResumableStreamExecPlan(%q).FullQuery: %q, want %q", tcase.sql, got, tcase.full)
+		}
+		if got := plan.Resumable.ResumeQuery.Query; got != tcase.resume {
+			t.Errorf("GetResumableStreamExecPlan(%q).ResumeQuery: %q, want %q", tcase.sql, got, tcase.resume)
+		}
+		if got := plan.Resumable.PKFields; !reflect.DeepEqual(got, tcase.pkFields) {
+			t.Errorf("GetResumableStreamExecPlan(%q).PKFields: %v, want %v", tcase.sql, got, tcase.pkFields)
+		}
+	}
+
+	errorCases := []struct {
+		sql  string
+		want string
+	}{
+		{"select name from a", "without the primary key column eid"},
+		{"select x.eid, id from a as x join b", "several tables"},
+		{"select eid, id from a order by name", "order other than the primary key"},
+		{"select eid, id from a order by eid desc, id desc", "order other than the primary key"},
+		{"select eid, id from a limit 10", "limit"},
+		{"select distinct eid, id from a", "aggregation"},
+		{"select eid, id, count(*) from a group by eid, id", "aggregation"},
+		{"select * from c", "without primary key"},
+		{"select * from a for update", "lock"},
+		{"select * from a union select * from b", "only selects"},
+	}
+	for _, tcase := range errorCases {
+		_, err := GetResumableStreamExecPlan(tcase.sql, getTable, nil)
+		if err == nil || !strings.Contains(err.Error(), tcase.want) {
+			t.Errorf("GetResumableStreamExecPlan(%q): %v, want %v", tcase.sql, err, tcase.want)
+		}
+	}
+}
@@ -6,6 +6,7 @@ package tabletserver
 
 import (
 	"net/http"
+	"strings"
 	"sync"
 	"time"
 
@@ -50,11 +51,15 @@ type QueryEngine struct {
 	cachePool      *CachePool
 	connPool       *ConnPool
 	streamConnPool *ConnPool
+	userPools      *userPools
 
 	// Services
 	txPool       *TxPool
 	consolidator *sync2.Consolidator
 	streamQList  *QueryList
+	rowVersions  *rowVersions
+	tableLimits  *tableLimits
+	hotQueries   *hotQueries
 	tasks        sync.WaitGroup
 
 	// Vars
@@ -64,6 +69,12 @@ type QueryEngine struct {
 	maxResultSize    sync2.AtomicInt64
 	maxDMLRows       sync2.AtomicInt64
 	streamBufferSize sync2.AtomicInt64
+	// compressedStreamBufferSize replaces streamBufferSize for the
+	// plans of compressed tables, if it's not 0.
+	compressedStreamBufferSize sync2.AtomicInt64
+	// pendingResultBytes is the size of the results fetched from
+	// MySQL that are held by the queries being executed.
+	pendingResultBytes sync2.AtomicInt64
 	// tableaclExemptCount count the number of accesses allowed
 	// based on membership in the superuser ACL
 	tableaclExemptCount  sync2.AtomicInt64
@@ -76,6 +87,8 @@ type QueryEngine struct {
 
 	// Loggers
 	accessCheckerLogger *logutil.ThrottledLogger
+	semiSyncLogger      *logutil.ThrottledLogger
+	perfSchemaLogger    *logutil.ThrottledLogger
 
 	// Stats
 	queryServiceStats *QueryServiceStats
@@ -150,6 +163,17 @@ func NewQueryEngine(checker MySQLChecker, config Config) *QueryEngine {
 		qe.queryServiceStats,
 		checker,
 	)
+	if *adaptivePoolSizing {
+		qe.connPool.enableAdaptiveSizing(poolSizerConfig{
+			MinSize:        *adaptivePoolMinSize,
+			Step:           *adaptivePoolStep,
+			GrowWaitTime:   *adaptivePoolGrowWaitTime,
+			ShrinkWaitTime: *adaptivePoolShrinkWaitTime,
+			ShrinkChecks:   *adaptivePoolShrinkChecks,
+			CheckInterval:  *adaptivePoolCheckInterval,
+			MaxSamples:     *adaptivePoolMaxSamples,
+		})
+	}
 	qe.streamConnPool = NewConnPool(
 		config.PoolNamePrefix+"StreamConnPool",
 		config.StreamPoolSize,
@@ -163,15 +187,33 @@ func NewQueryEngine(checker MySQLChecker, config Config) *QueryEngine {
 		config.PoolNamePrefix+"TransactionPool",
 		config.StatsPrefix,
 		config.TransactionCap,
+		config.TxPoolMaxWaiters,
 		time.Duration(config.TransactionTimeout*1e9),
 		time.Duration(config.IdleTimeout*1e9),
 		config.EnablePublishStats,
 		qe.queryServiceStats,
 		checker,
 	)
+	poolSizes, err := loadUserPoolSizes(*userPoolsFile)
+	if err != nil {
+		log.Fatalf("invalid user pools: %v", err)
+	}
+	qe.userPools = newUserPools(poolSizes, time.Duration(config.IdleTimeout*1e9), qe.queryServiceStats, checker)
+
+	if *poolConnLife > 0 {
+		qe.connPool.setConnLife(*poolConnLife, *poolConnLifeJitter)
+		qe.streamConnPool.setConnLife(*poolConnLife, *poolConnLifeJitter)
+		qe.txPool.pool.setConnLife(*poolConnLife, *poolConnLifeJitter)
+	}
+	if *poolHealthProbeInterval > 0 {
+		qe.connPool.enableHealthProbe(*poolHealthProbeInterval)
+		qe.streamConnPool.enableHealthProbe(*poolHealthProbeInterval)
+		qe.txPool.pool.enableHealthProbe(*poolHealthProbeInterval)
+	}
 	qe.consolidator = sync2.NewConsolidator()
 	http.Handle(config.DebugURLPrefix+"/consolidations", qe.consolidator)
 	qe.streamQList = NewQueryList()
+	qe.rowVersions = newRowVersions()
 
 	qe.spotCheckFreq = sync2.NewAtomicInt64(int64(config.SpotCheckRatio * spotCheckMultiplier))
 	if config.StrictMode {
@@ -199,29 +241,52 @@ func NewQueryEngine(checker MySQLChecker, config Config) *QueryEngine {
 	qe.maxResultSize = sync2.NewAtomicInt64(int64(config.MaxResultSize))
 	qe.maxDMLRows = sync2.NewAtomicInt64(int64(config.MaxDMLRows))
 	qe.streamBufferSize = sync2.NewAtomicInt64(int64(config.StreamBufferSize))
+	qe.compressedStreamBufferSize = sync2.NewAtomicInt64(int64(config.CompressedStreamBufferSize))
 
 	qe.accessCheckerLogger = logutil.NewThrottledLogger("accessChecker", 1*time.Second)
+	qe.semiSyncLogger = logutil.NewThrottledLogger("semiSync", 1*time.Second)
+	qe.perfSchemaLogger = logutil.NewThrottledLogger("perfSchema", 1*time.Second)
 
 	var tableACLAllowedName string
 	var tableACLDeniedName string
 	var tableACLPseudoDeniedName string
+	var tableLimitRejectionsName string
 	if config.EnablePublishStats {
 		stats.Publish(config.StatsPrefix+"MaxResultSize", stats.IntFunc(qe.maxResultSize.Get))
 		stats.Publish(config.StatsPrefix+"MaxDMLRows", stats.IntFunc(qe.maxDMLRows.Get))
 		stats.Publish(config.StatsPrefix+"StreamBufferSize", stats.IntFunc(qe.streamBufferSize.Get))
+		stats.Publish(config.StatsPrefix+"CompressedStreamBufferSize", stats.IntFunc(qe.compressedStreamBufferSize.Get))
 		stats.Publish(config.StatsPrefix+"RowcacheSpotCheckRatio", stats.FloatFunc(func() float64 {
 			return float64(qe.spotCheckFreq.Get()) / spotCheckMultiplier
 		}))
 		stats.Publish(config.StatsPrefix+"TableACLExemptCount", stats.IntFunc(qe.tableaclExemptCount.Get))
+		stats.Publish(config.StatsPrefix+"RowVersionConflicts", stats.IntFunc(qe.rowVersions.conflicts.Get))
 		tableACLAllowedName = "TableACLAllowed"
 		tableACLDeniedName = "TableACLDenied"
 		tableACLPseudoDeniedName = "TableACLPseudoDenied"
+		tableLimitRejectionsName = config.StatsPrefix + "TableLimitRejections"
 	}
 
 	qe.tableaclAllowed = stats.NewMultiCounters(tableACLAllowedName, []string{"TableName", "TableGroup", "PlanID", "Username"})
 	qe.tableaclDenied = stats.NewMultiCounters(tableACLDeniedName, []string{"TableName", "TableGroup", "PlanID", "Username"})
 	qe.tableaclPseudoDenied = stats.NewMultiCounters(tableACLPseudoDeniedName, []string{"TableName", "TableGroup", "PlanID", "Username"})
 
+	qe.tableLimits = newTableLimits(tableLimitRejectionsName)
+	qe.hotQueries = newHotQueries(*hotQueriesSize, *hotQueriesWindow, *hotQueriesSampleRate)
+	limits, err := loadTableLimits(*tableLimitsFile)
+	if err == nil {
+		err = qe.tableLimits.SetLimits(limits)
+	}
+	if err != nil {
+		log.Fatalf("invalid table limits: %v", err)
+	}
+	if config.EnablePublishStats {
+		stats.Publish(config.StatsPrefix+"TableLimitConcurrency", stats.CountersFunc(qe.tableLimits.Concurrency))
+		stats.Publish(config.StatsPrefix+"UserPoolCapacity", stats.NewMultiCountersFunc("", []string{"User", "Pool"}, qe.userPools.Capacity))
+		stats.Publish(config.StatsPrefix+"UserPoolInUse", stats.NewMultiCountersFunc("", []string{"User", "Pool"}, qe.userPools.InUse))
+		stats.Publish(config.StatsPrefix+"UserPoolWaitCount", stats.NewMultiCountersFunc("", []string{"User", "Pool"}, qe.userPools.WaitCount))
+	}
+
 	return qe
 }
 
@@ -259,6 +324,7 @@ func (qe *QueryEngine) Open(dbconfigs dbconfigs.DBConfigs, schemaOverrides []Sch
 
 	qe.connPool.Open(&appParams, &dbaParams)
 	qe.streamConnPool.Open(&appParams, &dbaParams)
+	qe.userPools.Open(&appParams, &dbaParams)
 	qe.txPool.Open(&appParams, &dbaParams)
 }
 
@@ -309,6 +375,7 @@ func (qe *QueryEngine) Close() {
 	qe.tasks.Wait()
 	// Close in reverse order of Open.
 	qe.txPool.Close()
+	qe.userPools.Close()
 	qe.streamConnPool.Close()
 	qe.connPool.Close()
 	qe.schemaInfo.Close()
@@ -317,10 +384,22 @@ func (qe *QueryEngine) Close() {
 
 // Commit commits the specified transaction.
 func (qe *QueryEngine) Commit(ctx context.Context, logStats *LogStats, transactionID int64) {
+	// The version tokens of the dirty rows must not validate from the
+	// commit until the rows are deleted from the rowcache.
+	conn := qe.txPool.Get(transactionID)
+	for tableName, invalidList := range conn.dirtyTables {
+		for key := range invalidList {
+			qe.rowVersions.beginInvalidation(tableName, key)
+		}
+	}
+	conn.Recycle()
 	dirtyTables, err := qe.txPool.SafeCommit(ctx, transactionID)
 	for tableName, invalidList := range dirtyTables {
 		tableInfo := qe.schemaInfo.GetTable(tableName)
 		if tableInfo == nil {
+			for key := range invalidList {
+				qe.rowVersions.endInvalidation(tableName, key)
+			}
 			continue
 		}
 		invalidations := int64(0)
@@ -328,6 +407,7 @@ func (qe *QueryEngine) Commit(ctx context.Context, logStats *LogStats, transacti
 			// Use context.Background, becaause we don't want to fail
 			// these deletes.
 			tableInfo.Cache.Delete(context.Background(), key)
+			qe.rowVersions.endInvalidation(tableName, key)
 			invalidations++
 		}
 		logStats.CacheInvalidations += invalidations
@@ -336,6 +416,39 @@ func (qe *QueryEngine) Commit(ctx context.Context, logStats *LogStats, transacti
 	if err != nil {
 		panic(err)
 	}
+	if *waitForSemiSync {
+		qe.checkSemiSync(ctx, logStats)
+	}
+}
+
+// semiSyncStatusQuery returns ON while the master waits for semi-sync
+// acknowledgments, and OFF after it fell back to asynchronous
+// replication because no replica acknowledged a transaction in time.
+const semiSyncStatusQuery = "show global status like 'Rpl_semi_sync_master_status'"
+
+// checkSemiSync sets logStats.SemiSyncFallback if MySQL is not waiting
+// for semi-sync acknowledgments, which means the transaction that was
+// just committed may not have reached any replica.
+func (qe *QueryEngine) checkSemiSync(ctx context.Context, logStats *LogStats) {
+	conn, err := qe.connPool.Get(ctx)
+	if err != nil {
+		qe.semiSyncLogger.Warningf("could not check the semi-sync status: %v", err)
+		return
+	}
+	defer conn.Recycle()
+	qr, err := conn.Exec(ctx, semiSyncStatusQuery, 1, false)
+	if err != nil {
+		qe.semiSyncLogger.Warningf("could not check the semi-sync status: %v", err)
+		return
+	}
+	if len(qr.Rows) != 1 || len(qr.Rows[0]) != 2 {
+		qe.semiSyncLogger.Warningf("semi-sync status is not available, is the semi-sync plugin loaded?")
+		return
+	}
+	if status := qr.Rows[0][1].String(); !strings.EqualFold(status, "ON") {
+		logStats.SemiSyncFallback = true
+		qe.semiSyncLogger.Warningf("transaction %v was committed without a semi-sync acknowledgment (Rpl_semi_sync_master_status: %v)", logStats.TransactionID, status)
+	}
 }
 
 // ClearRowcache invalidates all items in the rowcache.
@@ -11,6 +11,7 @@ import (
 	"time"
 
 	log "github.com/golang/glog"
+	"github.com/golang/protobuf/proto"
 	"github.com/youtube/vitess/go/hack"
 	"github.com/youtube/vitess/go/mysql"
 	"github.com/youtube/vitess/go/sqltypes"
@@ -19,6 +20,7 @@ import (
 	"github.com/youtube/vitess/go/vt/callinfo"
 	querypb "github.com/youtube/vitess/go/vt/proto/query"
 	vtrpcpb "github.com/youtube/vitess/go/vt/proto/vtrpc"
+	"github.com/youtube/vitess/go/vt/rowversion"
 	"github.com/youtube/vitess/go/vt/sqlparser"
 	"github.com/youtube/vitess/go/vt/tabletserver/planbuilder"
 	"golang.org/x/net/context"
@@ -33,6 +35,12 @@ type QueryExecutor struct {
 	ctx           context.Context
 	logStats      *LogStats
 	qe            *QueryEngine
+	// resultBytes is the size of the results fetched by execSQL,
+	// until releaseResults is called.
+	resultBytes int64
+	// noConsolidation makes qFetch send its query to MySQL, instead of
+	// waiting for the result of an identical query in flight.
+	noConsolidation bool
 }
 
 // poolConn is the interface implemented by users of this specialized pool.
@@ -56,24 +64,45 @@ func (qre *QueryExecutor) Execute() (reply *sqltypes.Result, err error) {
 	qre.logStats.TransactionID = qre.transactionID
 	planName := qre.plan.PlanID.String()
 	qre.logStats.PlanType = planName
+	qre.logStats.TableNames = qre.plan.TableNames
+	defer qre.releaseResults()
 	defer func(start time.Time) {
 		duration := time.Now().Sub(start)
 		qre.qe.queryServiceStats.QueryStats.Add(planName, duration)
+		qre.qe.queryServiceStats.addConnWaitStats(planName, qre.logStats)
+		qre.qe.queryServiceStats.RequestSizeStats.Add(int64(qre.logStats.SizeOfRequest()))
 		addUserTableQueryStats(qre.qe.queryServiceStats, qre.ctx, qre.plan.TableName, "Execute", int64(duration))
 
 		if reply == nil {
 			qre.plan.AddStats(1, duration, 0, 1)
-			return
+		} else {
+			qre.plan.AddStats(1, duration, int64(reply.RowsAffected), 0)
+			qre.recordTableAccess()
+			qre.logStats.RowsAffected = int(reply.RowsAffected)
+			qre.logStats.Rows = reply.Rows
+			qre.qe.queryServiceStats.ResultStats.Add(int64(len(reply.Rows)))
+		}
+		if tableStats != nil {
+			tableStats.Add(qre.logStats, duration)
 		}
-		qre.plan.AddStats(1, duration, int64(reply.RowsAffected), 0)
-		qre.logStats.RowsAffected = int(reply.RowsAffected)
-		qre.logStats.Rows = reply.Rows
-		qre.qe.queryServiceStats.ResultStats.Add(int64(len(reply.Rows)))
 	}(time.Now())
 
 	if err := qre.checkPermissions(); err != nil {
 		return nil, err
 	}
+	release, err := qre.qe.tableLimits.acquire(qre.plan.TableName)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	if err := checkBindVarTypes(qre.plan.bindVarColumns, qre.bindVars); err != nil {
+		return nil, schemaValidationError(qre.logStats, err)
+	}
+	if qre.plan.KeyRangeCheck != nil {
+		if err := qre.plan.KeyRangeCheck.Check(qre.bindVars); err != nil {
+			return nil, NewTabletError(vtrpcpb.ErrorCode_BAD_INPUT, "%v", err)
+		}
+	}
 
 	switch qre.plan.PlanID {
 	case planbuilder.PlanDDL:
@@ -87,6 +116,15 @@ func (qre *QueryExecutor) Execute() (reply *sqltypes.Result, err error) {
 		conn := qre.qe.txPool.Get(qre.transactionID)
 		defer conn.Recycle()
 		conn.RecordQuery(qre.query)
+		switch qre.plan.PlanID {
+		case planbuilder.PlanLockTables:
+			return qre.execLockTables(conn)
+		case planbuilder.PlanUnlockTables:
+			return qre.execUnlockTables(conn)
+		}
+		if err := conn.CheckLockedTables(qre.plan.TableNames); err != nil {
+			return nil, err
+		}
 		var invalidator CacheInvalidator
 		if qre.plan.TableInfo != nil && qre.plan.TableInfo.IsCached() {
 			invalidator = conn.DirtyKeys(qre.plan.TableName)
@@ -126,12 +164,14 @@ func (qre *QueryExecutor) Execute() (reply *sqltypes.Result, err error) {
 		case planbuilder.PlanSet:
 			reply, err = qre.execSet()
 		case planbuilder.PlanOther:
-			conn, connErr := qre.getConn(qre.qe.connPool)
+			conn, connErr := qre.getConn(qre.pools().ConnPool)
 			if connErr != nil {
 				return nil, connErr
 			}
 			defer conn.Recycle()
 			reply, err = qre.execSQL(conn, qre.query, true)
+		case planbuilder.PlanLockTables, planbuilder.PlanUnlockTables:
+			return nil, NewTabletError(vtrpcpb.ErrorCode_BAD_INPUT, "Disallowed outside transaction")
 		default:
 			if qre.qe.autoCommit.Get() == 0 {
 				return nil, NewTabletError(vtrpcpb.ErrorCode_BAD_INPUT,
@@ -145,19 +185,105 @@ func (qre *QueryExecutor) Execute() (reply *sqltypes.Result, err error) {
 
 // Stream performs a streaming query execution.
 func (qre *QueryExecutor) Stream(sendReply func(*sqltypes.Result) error) error {
+	return qre.stream(qre.plan.FullQuery, sendReply)
+}
+
+// StreamResumable performs the streaming execution of a plan of
+// GetResumableStreamPlan. Every result with rows is sent with the
+// token that resumes the stream after its last row. If resumeToken is
+// not empty, the stream resumes after the row it was sent with.
+func (qre *QueryExecutor) StreamResumable(resumeToken []byte, sendReply func(*sqltypes.Result, []byte) error) error {
+	resumable := qre.plan.Resumable
+	query := qre.plan.FullQuery
+	if len(resumeToken) != 0 {
+		if err := qre.addResumeBindVars(resumeToken); err != nil {
+			return err
+		}
+		query = resumable.ResumeQuery
+	}
+	return qre.stream(query, func(result *sqltypes.Result) error {
+		if len(result.Rows) == 0 {
+			return sendReply(result, nil)
+		}
+		last := result.Rows[len(result.Rows)-1]
+		pk := make([]sqltypes.Value, 0, len(resumable.PKFields))
+		for _, f := range resumable.PKFields {
+			pk = append(pk, last[f])
+		}
+		token, err := proto.Marshal(sqltypes.RowsToProto3([][]sqltypes.Value{pk})[0])
+		if err != nil {
+			return NewTabletError(vtrpcpb.ErrorCode_INTERNAL_ERROR, "cannot build the resume token: %v", err)
+		}
+		return sendReply(result, token)
+	})
+}
+
+// addResumeBindVars adds to the bind variables the primary key values
+// of resumeToken, the ones of the row the stream resumes after.
+func (qre *QueryExecutor) addResumeBindVars(resumeToken []byte) error {
+	table := qre.plan.TableInfo.Table
+	row := &querypb.Row{}
+	if err := proto.Unmarshal(resumeToken, row); err != nil {
+		return NewTabletError(vtrpcpb.ErrorCode_BAD_INPUT, "invalid resume token: %v", err)
+	}
+	if len(row.Lengths) != len(table.PKColumns) {
+		return NewTabletError(vtrpcpb.ErrorCode_BAD_INPUT, "invalid resume token: %v values for the %v primary key columns of %v", len(row.Lengths), len(table.PKColumns), table.Name)
+	}
+	var offset int64
+	for i, length := range row.Lengths {
+		if length < 0 || offset+length > int64(len(row.Values)) {
+			return NewTabletError(vtrpcpb.ErrorCode_BAD_INPUT, "invalid resume token: bad length %v of the value %v", length, i)
+		}
+		col := table.GetPKColumn(i)
+		v, err := sqltypes.ValueFromBytes(col.Type, row.Values[offset:offset+length])
+		if err != nil {
+			return NewTabletError(vtrpcpb.ErrorCode_BAD_INPUT, "invalid resume token: bad value of %v: %v", col.Name, err)
+		}
+		qre.bindVars[planbuilder.ResumeBindVar(i)] = v
+		offset += length
+	}
+	return nil
+}
+
+func (qre *QueryExecutor) stream(query *sqlparser.ParsedQuery, sendReply func(*sqltypes.Result) error) error {
 	qre.logStats.OriginalSQL = qre.query
+	qre.logStats.BindVariables = qre.bindVars
 	qre.logStats.PlanType = qre.plan.PlanID.String()
+	qre.logStats.TableNames = qre.plan.TableNames
 
 	defer func(start time.Time) {
 		qre.qe.queryServiceStats.QueryStats.Record(qre.plan.PlanID.String(), start)
-		addUserTableQueryStats(qre.qe.queryServiceStats, qre.ctx, qre.plan.TableName, "Stream", int64(time.Now().Sub(start)))
+		qre.qe.queryServiceStats.addConnWaitStats(qre.plan.PlanID.String(), qre.logStats)
+		qre.qe.queryServiceStats.RequestSizeStats.Add(int64(qre.logStats.SizeOfRequest()))
+		qre.qe.queryServiceStats.ResultStats.Add(int64(qre.logStats.StreamedRows))
+		duration := time.Now().Sub(start)
+		addUserTableQueryStats(qre.qe.queryServiceStats, qre.ctx, qre.plan.TableName, "Stream", int64(duration))
+		if tableStats != nil {
+			tableStats.Add(qre.logStats, duration)
+		}
 	}(time.Now())
 
 	if err := qre.checkPermissions(); err != nil {
 		return err
 	}
+	release, err := qre.qe.tableLimits.acquire(qre.plan.TableName)
+	if err != nil {
+		return err
+	}
+	defer release()
+	if *schemaPreValidation {
+		// The stream plans are not cached, their schema is validated
+		// at each execution.
+		bindVarColumns, err := qre.qe.schemaInfo.validateSchema(qre.query)
+		if err == nil {
+			err = checkBindVarTypes(bindVarColumns, qre.bindVars)
+		}
+		if err != nil {
+			return schemaValidationError(qre.logStats, err)
+		}
+	}
 
-	conn, err := qre.getConn(qre.qe.streamConnPool)
+	conn, err := qre.getConn(qre.pools().StreamConnPool)
 	if err != nil {
 		return err
 	}
@@ -167,7 +293,65 @@ func (qre *QueryExecutor) Stream(sendReply func(*sqltypes.Result) error) error {
 	qre.qe.streamQList.Add(qd)
 	defer qre.qe.streamQList.Remove(qd)
 
-	return qre.fullStreamFetch(conn, qre.plan.FullQuery, qre.bindVars, nil, sendReply)
+	// The results are counted as they are sent, so that the ones sent
+	// before an error are in the log.
+	callback := func(result *sqltypes.Result) error {
+		if err := sendReply(result); err != nil {
+			return err
+		}
+		qre.logStats.addStreamedResult(result)
+		return nil
+	}
+	if err := qre.fullStreamFetch(conn, query, qre.bindVars, nil, callback); err != nil {
+		return err
+	}
+	qre.recordTableAccess()
+	return nil
+}
+
+// recordTableAccess records in logStats that the tables of the plan
+// were accessed.
+func (qre *QueryExecutor) recordTableAccess() {
+	for _, table := range qre.plan.TableNames {
+		qre.logStats.RecordTableAccess(table, 1)
+	}
+}
+
+// execLockTables puts the transaction in the locked mode. LOCK TABLES
+// implicitly commits the current transaction, so it must be its first
+// statement, and autocommit is disabled first to keep the statements
+// that follow in a transaction.
+func (qre *QueryExecutor) execLockTables(conn *TxConnection) (*sqltypes.Result, error) {
+	if len(conn.Queries) > 1 {
+		return nil, NewTabletError(vtrpcpb.ErrorCode_BAD_INPUT, "LOCK TABLES must be the first statement of the transaction")
+	}
+	if _, err := qre.execSQL(conn, "set autocommit=0", false); err != nil {
+		return nil, err
+	}
+	// Autocommit is restored at the end of the transaction, even if
+	// LOCK TABLES fails.
+	conn.autocommitOff = true
+	reply, err := qre.execSQL(conn, qre.query, false)
+	if err != nil {
+		return nil, err
+	}
+	conn.LockTables(qre.plan.TableNames)
+	return reply, nil
+}
+
+// execUnlockTables ends the locked mode of the transaction. Like in
+// MySQL, this commits the statements executed so far, and the
+// transaction goes on with the next ones.
+func (qre *QueryExecutor) execUnlockTables(conn *TxConnection) (*sqltypes.Result, error) {
+	if !conn.IsLocked() {
+		return nil, NewTabletError(vtrpcpb.ErrorCode_BAD_INPUT, "UNLOCK TABLES without LOCK TABLES")
+	}
+	reply, err := qre.execSQL(conn, qre.query, false)
+	if err != nil {
+		return nil, err
+	}
+	conn.UnlockTables()
+	return reply, nil
 }
 
 func (qre *QueryExecutor) execDmlAutoCommit() (reply *sqltypes.Result, err error) {
@@ -201,19 +385,24 @@ func (qre *QueryExecutor) execDmlAutoCommit() (reply *sqltypes.Result, err error
 }
 
 func (qre *QueryExecutor) execAsTransaction(f func(conn *TxConnection) (*sqltypes.Result, error)) (reply *sqltypes.Result, err error) {
-	transactionID := qre.qe.txPool.Begin(qre.ctx)
-	qre.logStats.AddRewrittenSQL("begin", time.Now())
+	start := time.Now()
+	txPoolWait := qre.logStats.TxPoolWaitTime
+	transactionID := qre.qe.txPool.begin(qre.ctx, qre.logStats)
+	// The begin statement was sent once the wait for a connection
+	// was over.
+	qre.logStats.AddRewrittenSQL("begin", start.Add(qre.logStats.TxPoolWaitTime-txPoolWait))
 	defer func() {
 		// TxPool.Get may panic
 		if panicErr := recover(); panicErr != nil {
 			err = fmt.Errorf("DML autocommit got panic: %v", panicErr)
 		}
+		start := time.Now()
 		if err != nil {
 			qre.qe.txPool.Rollback(qre.ctx, transactionID)
-			qre.logStats.AddRewrittenSQL("rollback", time.Now())
+			qre.logStats.AddRewrittenSQL("rollback", start)
 		} else {
 			qre.qe.Commit(qre.ctx, qre.logStats, transactionID)
-			qre.logStats.AddRewrittenSQL("commit", time.Now())
+			qre.logStats.AddRewrittenSQL("commit", start)
 		}
 	}()
 	conn := qre.qe.txPool.Get(transactionID)
@@ -303,7 +492,7 @@ func (qre *QueryExecutor) execDDL() (*sqltypes.Result, error) {
 		return nil, NewTabletError(vtrpcpb.ErrorCode_BAD_INPUT, "DDL is not understood")
 	}
 
-	txid := qre.qe.txPool.Begin(qre.ctx)
+	txid := qre.qe.txPool.begin(qre.ctx, qre.logStats)
 	defer qre.qe.txPool.SafeCommit(qre.ctx, txid)
 
 	// Stolen from Execute
@@ -317,6 +506,13 @@ func (qre *QueryExecutor) execDDL() (*sqltypes.Result, error) {
 		// It's a drop or rename.
 		qre.qe.schemaInfo.DropTable(ddlPlan.TableName)
 	}
+	if len(ddlPlan.InstantColumns) != 0 {
+		err := qre.qe.schemaInfo.AddColumns(qre.ctx, ddlPlan.NewName, ddlPlan.InstantColumns)
+		if err == nil {
+			return result, nil
+		}
+		log.Infof("Reloading table %s after instant DDL: %v", ddlPlan.NewName, err)
+	}
 	if ddlPlan.NewName != "" {
 		qre.qe.schemaInfo.CreateOrUpdateTable(qre.ctx, ddlPlan.NewName)
 	}
@@ -332,7 +528,21 @@ func (qre *QueryExecutor) execPKIN() (*sqltypes.Result, error) {
 	if err != nil {
 		return nil, err
 	}
-	return qre.fetchMulti(pkRows, limit)
+	if recorder := rowversion.RecorderFromContext(qre.ctx); recorder != nil && len(pkRows) == 1 {
+		// The sequence of the token must be read before the row, and
+		// the row must not come from a query that started before. The
+		// prefix of the token is the one the row was read with.
+		seq := qre.qe.rowVersions.seq(qre.plan.TableInfo.Name, buildKey(pkRows[0]))
+		qre.noConsolidation = true
+		result, prefix, err := qre.fetchMulti(pkRows, limit)
+		if err != nil {
+			return nil, err
+		}
+		recorder.Token = qre.qe.rowVersions.token(prefix, seq)
+		return result, nil
+	}
+	result, _, err := qre.fetchMulti(pkRows, limit)
+	return result, err
 }
 
 func (qre *QueryExecutor) execNextval() (*sqltypes.Result, error) {
@@ -403,25 +613,28 @@ func (qre *QueryExecutor) execSubquery() (*sqltypes.Result, error) {
 	if err != nil {
 		return nil, err
 	}
-	return qre.fetchMulti(innerResult.Rows, -1)
+	result, _, err := qre.fetchMulti(innerResult.Rows, -1)
+	return result, err
 }
 
-func (qre *QueryExecutor) fetchMulti(pkRows [][]sqltypes.Value, limit int64) (*sqltypes.Result, error) {
+// fetchMulti returns the rows of pkRows, from the rowcache or from the
+// db, and the rowcache prefix they were read with.
+func (qre *QueryExecutor) fetchMulti(pkRows [][]sqltypes.Value, limit int64) (*sqltypes.Result, string, error) {
 	if qre.plan.Fields == nil {
 		// TODO(aaijazi): Is this due to a bad query, or an internal error? We might want to change
 		// this to ErrorCode_BAD_INPUT instead.
-		return nil, NewTabletError(vtrpcpb.ErrorCode_INTERNAL_ERROR, "query plan.Fields is empty")
+		return nil, "", NewTabletError(vtrpcpb.ErrorCode_INTERNAL_ERROR, "query plan.Fields is empty")
 	}
 	result := &sqltypes.Result{Fields: qre.plan.Fields}
+	tableInfo := qre.plan.TableInfo
 	if len(pkRows) == 0 || limit == 0 {
-		return result, nil
+		return result, tableInfo.Cache.prefix.Get(), nil
 	}
-	tableInfo := qre.plan.TableInfo
 	keys := make([]string, len(pkRows))
 	for i, pk := range pkRows {
 		keys[i] = buildKey(pk)
 	}
-	rcresults := tableInfo.Cache.Get(qre.ctx, keys)
+	rcresults, prefix := tableInfo.Cache.Get(qre.ctx, keys)
 	rows := make([][]sqltypes.Value, 0, len(pkRows))
 	missingRows := make([][]sqltypes.Value, 0, len(pkRows))
 	var hits, absent, misses int64
@@ -431,7 +644,7 @@ func (qre *QueryExecutor) fetchMulti(pkRows [][]sqltypes.Value, limit int64) (*s
 			if qre.mustVerify() {
 				err := qre.spotCheck(rcresult, pk)
 				if err != nil {
-					return nil, err
+					return nil, "", err
 				}
 			}
 			rows = append(rows, applyFilter(qre.plan.ColumnNumbers, rcresult.Row))
@@ -449,14 +662,14 @@ func (qre *QueryExecutor) fetchMulti(pkRows [][]sqltypes.Value, limit int64) (*s
 		}
 		resultFromdb, err := qre.qFetch(qre.logStats, qre.plan.OuterQuery, bv)
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
 		misses = int64(len(resultFromdb.Rows))
 		absent = int64(len(pkRows)) - hits - misses
 		for _, row := range resultFromdb.Rows {
 			rows = append(rows, applyFilter(qre.plan.ColumnNumbers, row))
 			key := buildKey(applyFilter(qre.plan.TableInfo.PKColumns, row))
-			tableInfo.Cache.Set(qre.ctx, key, row, rcresults[key].Cas)
+			tableInfo.Cache.Set(qre.ctx, prefix, key, row, rcresults[key].Cas)
 		}
 	}
 
@@ -476,7 +689,7 @@ func (qre *QueryExecutor) fetchMulti(pkRows [][]sqltypes.Value, limit int64) (*s
 		result.Rows = result.Rows[:limit]
 		result.RowsAffected = uint64(limit)
 	}
-	return result, nil
+	return result, prefix, nil
 }
 
 func (qre *QueryExecutor) mustVerify() bool {
@@ -509,7 +722,8 @@ func (qre *QueryExecutor) recheckLater(rcresult RCResult, dbrow []sqltypes.Value
 	time.Sleep(10 * time.Second)
 	keys := make([]string, 1)
 	keys[0] = buildKey(pk)
-	reloaded := qre.plan.TableInfo.Cache.Get(context.Background(), keys)[keys[0]]
+	results, _ := qre.plan.TableInfo.Cache.Get(context.Background(), keys)
+	reloaded := results[keys[0]]
 	// If reloaded row is absent or has changed, we're good
 	if reloaded.Row == nil || reloaded.Cas != rcresult.Cas {
 		return
@@ -545,7 +759,7 @@ func (qre *QueryExecutor) execSelect() (*sqltypes.Result, error) {
 		newResult.Fields = qre.plan.Fields
 		return &newResult, nil
 	}
-	conn, err := qre.getConn(qre.qe.connPool)
+	conn, err := qre.getConn(qre.pools().ConnPool)
 	if err != nil {
 		return nil, err
 	}
@@ -630,9 +844,42 @@ func (qre *QueryExecutor) execDMLPK(conn poolConn, invalidator CacheInvalidator)
 	if err != nil {
 		return nil, err
 	}
+	if token := rowversion.ExpectFromContext(qre.ctx); token != "" {
+		ok, err := qre.checkRowVersion(conn, pkRows, token)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			qre.qe.rowVersions.conflicts.Add(1)
+			return &sqltypes.Result{RowsAffected: 0}, nil
+		}
+	}
 	return qre.execDMLPKRows(conn, qre.plan.OuterQuery, pkRows, invalidator)
 }
 
+// checkRowVersion locks the row of a primary key DML, and returns true
+// if it still has the version token the DML expects. The row is locked
+// first because the commit of a change of the row begins its
+// invalidation before it releases the lock.
+func (qre *QueryExecutor) checkRowVersion(conn poolConn, pkRows [][]sqltypes.Value, token string) (bool, error) {
+	tableInfo := qre.plan.TableInfo
+	if !tableInfo.IsCached() || len(pkRows) != 1 {
+		return false, NewTabletError(vtrpcpb.ErrorCode_BAD_INPUT, "a row version needs a DML of a single primary key of a cached table")
+	}
+	buf := sqlparser.NewTrackedBuffer(nil)
+	buf.Myprintf("select 1 from `%s` where %a for update", tableInfo.Name, ":#pk")
+	bv := map[string]interface{}{
+		"#pk": sqlparser.TupleEqualityList{
+			Columns: tableInfo.Indexes[0].Columns,
+			Rows:    pkRows,
+		},
+	}
+	if _, err := qre.directFetch(conn, buf.ParsedQuery(), bv, nil); err != nil {
+		return false, err
+	}
+	return qre.qe.rowVersions.check(tableInfo, buildKey(pkRows[0]), token), nil
+}
+
 func (qre *QueryExecutor) execDMLSubquery(conn poolConn, invalidator CacheInvalidator) (*sqltypes.Result, error) {
 	innerResult, err := qre.directFetch(conn, qre.plan.Subquery, qre.bindVars, nil)
 	if err != nil {
@@ -685,7 +932,7 @@ func (qre *QueryExecutor) execDMLPKRows(conn poolConn, query *sqlparser.ParsedQu
 }
 
 func (qre *QueryExecutor) execSet() (*sqltypes.Result, error) {
-	conn, err := qre.getConn(qre.qe.connPool)
+	conn, err := qre.getConn(qre.pools().ConnPool)
 	if err != nil {
 		return nil, err
 	}
@@ -735,6 +982,11 @@ func rowsAreEqual(row1, row2 []sqltypes.Value) bool {
 	return true
 }
 
+// pools returns the connection pools of the effective caller.
+func (qre *QueryExecutor) pools() UserConnPools {
+	return qre.qe.GetOrCreatePool(callerid.GetPrincipal(callerid.EffectiveCallerIDFromContext(qre.ctx)))
+}
+
 func (qre *QueryExecutor) getConn(pool *ConnPool) (*DBConn, error) {
 	span := trace.NewSpanFromContext(qre.ctx)
 	span.StartLocal("QueryExecutor.getConn")
@@ -744,7 +996,7 @@ func (qre *QueryExecutor) getConn(pool *ConnPool) (*DBConn, error) {
 	conn, err := pool.Get(qre.ctx)
 	switch err {
 	case nil:
-		qre.logStats.WaitingForConnection += time.Now().Sub(start)
+		qre.logStats.addConnPoolWait(time.Now().Sub(start))
 		return conn, nil
 	case ErrConnPoolClosed:
 		return nil, err
@@ -757,12 +1009,20 @@ func (qre *QueryExecutor) qFetch(logStats *LogStats, parsedQuery *sqlparser.Pars
 	if err != nil {
 		return nil, err
 	}
+	if qre.noConsolidation {
+		conn, err := qre.getConn(qre.pools().ConnPool)
+		if err != nil {
+			return nil, err
+		}
+		defer conn.Recycle()
+		return qre.execSQL(conn, sql, false)
+	}
 	q, ok := qre.qe.consolidator.Create(string(sql))
 	if ok {
 		defer q.Broadcast()
 		waitingForConnectionStart := time.Now()
-		conn, err := qre.qe.connPool.Get(qre.ctx)
-		logStats.WaitingForConnection += time.Now().Sub(waitingForConnectionStart)
+		conn, err := qre.pools().ConnPool.Get(qre.ctx)
+		logStats.addConnPoolWait(time.Now().Sub(waitingForConnectionStart))
 		if err != nil {
 			q.Err = NewTabletErrorSQL(vtrpcpb.ErrorCode_INTERNAL_ERROR, err)
 		} else {
@@ -821,15 +1081,98 @@ func (qre *QueryExecutor) generateFinalSQL(parsedQuery *sqlparser.ParsedQuery, b
 }
 
 func (qre *QueryExecutor) execSQL(conn poolConn, sql string, wantfields bool) (*sqltypes.Result, error) {
-	defer qre.logStats.AddRewrittenSQL(sql, time.Now())
-	return conn.Exec(qre.ctx, sql, int(qre.qe.maxResultSize.Get()), wantfields)
+	start := time.Now()
+	qr, err := conn.Exec(qre.ctx, qre.withQueryID(sql), int(qre.qe.maxResultSize.Get()), wantfields)
+	qre.logStats.AddRewrittenSQL(sql, start)
+	if err == nil && *enrichFromPerfSchema {
+		qre.addPerfSchemaStats(conn)
+	}
+	if qr != nil {
+		size := int64(sizeOfRows(qr.Rows))
+		qre.resultBytes += size
+		qre.qe.pendingResultBytes.Add(size)
+	}
+	return qr, err
+}
+
+// withQueryID returns sql with the query ID of the record in a
+// trailing comment if -query_id_comment is on. The query log keeps
+// sql without it.
+func (qre *QueryExecutor) withQueryID(sql string) string {
+	if !*queryIDComment || qre.logStats.QueryID == "" {
+		return sql
+	}
+	return sql + " /* query_id:" + qre.logStats.QueryID + " */"
+}
+
+// releaseResults removes the results fetched by the query from the
+// pending ones of the QueryEngine.
+func (qre *QueryExecutor) releaseResults() {
+	qre.qe.pendingResultBytes.Add(-qre.resultBytes)
+	qre.resultBytes = 0
+}
+
+// perfSchemaStatementQuery returns the execution details of the last
+// statement that completed on the connection.
+const perfSchemaStatementQuery = "select rows_examined, created_tmp_disk_tables, created_tmp_tables, select_full_join, select_scan, sort_rows, no_index_used " +
+	"from performance_schema.events_statements_history_long " +
+	"where thread_id = (select thread_id from performance_schema.threads where processlist_id = connection_id()) " +
+	"order by event_id desc limit 1"
+
+// addPerfSchemaStats adds the performance_schema stats of the statement
+// that was just executed on conn to the LogStats. The statements must
+// be recorded by MySQL, with the events_statements_history_long
+// consumer enabled.
+func (qre *QueryExecutor) addPerfSchemaStats(conn poolConn) {
+	qr, err := conn.Exec(qre.ctx, perfSchemaStatementQuery, 1, false)
+	if err != nil {
+		qre.qe.perfSchemaLogger.Warningf("could not read the statement stats from performance_schema: %v", err)
+		return
+	}
+	if len(qr.Rows) != 1 || len(qr.Rows[0]) != 7 {
+		qre.qe.perfSchemaLogger.Warningf("statement stats not found in performance_schema, is the events_statements_history_long consumer enabled?")
+		return
+	}
+	var values [7]int64
+	for i, v := range qr.Rows[0] {
+		if values[i], err = v.ParseInt64(); err != nil {
+			qre.qe.perfSchemaLogger.Warningf("invalid statement stats in performance_schema: %v", err)
+			return
+		}
+	}
+	if qre.logStats.PerfSchema == nil {
+		qre.logStats.PerfSchema = &PerfSchemaStats{}
+	}
+	pss := qre.logStats.PerfSchema
+	pss.RowsExamined += values[0]
+	pss.CreatedTmpDiskTables += values[1]
+	pss.CreatedTmpTables += values[2]
+	pss.SelectFullJoin += values[3]
+	pss.SelectScan += values[4]
+	pss.SortRows += values[5]
+	pss.NoIndexUsed += values[6]
+}
+
+// streamBufferSize returns the stream buffer size of the plan, which
+// is bigger for the compressed tables.
+func (qre *QueryExecutor) streamBufferSize() int {
+	if qre.plan.Compressed {
+		if size := qre.qe.compressedStreamBufferSize.Get(); size > 0 {
+			return int(size)
+		}
+	}
+	return int(qre.qe.streamBufferSize.Get())
 }
 
 func (qre *QueryExecutor) execStreamSQL(conn *DBConn, sql string, callback func(*sqltypes.Result) error) error {
 	start := time.Now()
-	err := conn.Stream(qre.ctx, sql, callback, int(qre.qe.streamBufferSize.Get()))
+	err := conn.Stream(qre.ctx, qre.withQueryID(sql), callback, qre.streamBufferSize())
 	qre.logStats.AddRewrittenSQL(sql, start)
 	if err != nil {
+		if terr, ok := err.(*TabletError); ok {
+			// The statement is over max_allowed_packet.
+			return terr
+		}
 		// MySQL error that isn't due to a connection issue
 		return NewTabletErrorSQL(vtrpcpb.ErrorCode_UNKNOWN_ERROR, err)
 	}
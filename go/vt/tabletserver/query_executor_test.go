@@ -5,19 +5,23 @@
 package tabletserver
 
 import (
+	"errors"
 	"fmt"
 	"math/rand"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"golang.org/x/net/context"
 
 	"github.com/youtube/vitess/go/mysql"
 	"github.com/youtube/vitess/go/sqldb"
 	"github.com/youtube/vitess/go/sqltypes"
+	"github.com/youtube/vitess/go/stats"
 	"github.com/youtube/vitess/go/vt/callerid"
 	"github.com/youtube/vitess/go/vt/callinfo"
+	"github.com/youtube/vitess/go/vt/rowversion"
 	"github.com/youtube/vitess/go/vt/tableacl"
 	"github.com/youtube/vitess/go/vt/tableacl/simpleacl"
 	"github.com/youtube/vitess/go/vt/tabletserver/fakecacheservice"
@@ -153,6 +157,32 @@ func TestQueryExecutorPlanInsertPk(t *testing.T) {
 	}
 }
 
+func TestQueryExecutorPlanInsertPkKeyRange(t *testing.T) {
+	db := setUpQueryExecutorTest()
+	db.AddQuery("insert into test_table values (1) /* _stream test_table (pk ) (1 ); */", &sqltypes.Result{})
+	ctx := context.Background()
+	tsv := newTestTabletServer(ctx, enableRowCache|enableStrict, db)
+	defer tsv.StopService()
+	tsv.qe.schemaInfo.SetKeyRangeFilter(&planbuilder.KeyRangeFilter{
+		ShardingColumnName: "pk",
+		ShardingColumnType: topodatapb.KeyspaceIdType_UINT64,
+		KeyRange:           &topodatapb.KeyRange{End: []byte{0x80}},
+	})
+
+	qre := newTestQueryExecutor(ctx, tsv, "insert into test_table values(1)", 0)
+	if _, err := qre.Execute(); err != nil {
+		t.Fatalf("qre.Execute() = %v, want nil", err)
+	}
+
+	qre = newTestQueryExecutor(ctx, tsv, "insert into test_table values(:pk)", 0)
+	qre.bindVars["pk"] = uint64(0x9000000000000000)
+	_, err := qre.Execute()
+	want := "error: the sharding column value 10376293541461622784 is not in the key range -80"
+	if err == nil || err.Error() != want {
+		t.Errorf("qre.Execute() = %v, want %v", err, want)
+	}
+}
+
 func TestQueryExecutorPlanInsertSubQueryAutoCommmit(t *testing.T) {
 	db := setUpQueryExecutorTest()
 	query := "insert into test_table(pk) select pk from test_table where pk = 1 limit 1000"
@@ -316,6 +346,45 @@ func TestQueryExecutorPlanDmlAutoCommit(t *testing.T) {
 	}
 }
 
+func TestQueryExecutorSemiSyncFallback(t *testing.T) {
+	defer func(v bool) { *waitForSemiSync = v }(*waitForSemiSync)
+	*waitForSemiSync = true
+
+	db := setUpQueryExecutorTest()
+	query := "update test_table set name = 2 where pk in (1) /* _stream test_table (pk ) (1 ); */"
+	db.AddQuery(query, &sqltypes.Result{})
+	ctx := context.Background()
+	tsv := newTestTabletServer(ctx, enableRowCache|enableStrict, db)
+	defer tsv.StopService()
+
+	for _, status := range []string{"ON", "OFF"} {
+		db.AddQuery(semiSyncStatusQuery, &sqltypes.Result{
+			RowsAffected: 1,
+			Rows: [][]sqltypes.Value{{
+				sqltypes.MakeString([]byte("Rpl_semi_sync_master_status")),
+				sqltypes.MakeString([]byte(status)),
+			}},
+		})
+		qre := newTestQueryExecutor(ctx, tsv, query, 0)
+		if _, err := qre.Execute(); err != nil {
+			t.Fatalf("qre.Execute() = %v, want nil", err)
+		}
+		if got, want := qre.logStats.SemiSyncFallback, status == "OFF"; got != want {
+			t.Errorf("Rpl_semi_sync_master_status %v: SemiSyncFallback = %v, want %v", status, got, want)
+		}
+	}
+
+	// The flag is not set when the status is not available.
+	db.AddQuery(semiSyncStatusQuery, &sqltypes.Result{})
+	qre := newTestQueryExecutor(ctx, tsv, query, 0)
+	if _, err := qre.Execute(); err != nil {
+		t.Fatalf("qre.Execute() = %v, want nil", err)
+	}
+	if qre.logStats.SemiSyncFallback {
+		t.Errorf("semi-sync status not available: SemiSyncFallback = true, want false")
+	}
+}
+
 func TestQueryExecutorPlanDmlSubQuery(t *testing.T) {
 	db := setUpQueryExecutorTest()
 	query := "update test_table set addr = 3 where name = 1 limit 1000"
@@ -469,6 +538,414 @@ func TestQueryExecutorPlanPassSelect(t *testing.T) {
 	}
 }
 
+func TestQueryExecutorTableStats(t *testing.T) {
+	defer func(saved *TableStats) { tableStats = saved }(tableStats)
+	tableStats = NewTableStats(10)
+	db := setUpQueryExecutorTest()
+	query := "select * from test_table limit 1000"
+	db.AddQuery(query, &sqltypes.Result{
+		Fields:       getTestTableFields(),
+		RowsAffected: 2,
+	})
+	db.AddQuery("select * from test_table where 1 != 1", &sqltypes.Result{
+		Fields: getTestTableFields(),
+	})
+	ctx := context.Background()
+	tsv := newTestTabletServer(ctx, enableRowCache|enableSchemaOverrides|enableStrict, db)
+	defer tsv.StopService()
+	for i := 0; i < 2; i++ {
+		qre := newTestQueryExecutor(ctx, tsv, query, 0)
+		if _, err := qre.Execute(); err != nil {
+			t.Fatalf("qre.Execute() = %v, want nil", err)
+		}
+	}
+	// The queries are counted even if the query log drops them.
+	got := tableStats.counts(func(e *tableStatsEntry) int64 { return e.Queries })
+	if want := map[string]int64{"test_table": 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("table stats queries: %v, want %v", got, want)
+	}
+	got = tableStats.counts(func(e *tableStatsEntry) int64 { return e.RowsAffected })
+	if want := map[string]int64{"test_table": 4}; !reflect.DeepEqual(got, want) {
+		t.Errorf("table stats rows affected: %v, want %v", got, want)
+	}
+}
+
+func TestQueryExecutorPerfSchema(t *testing.T) {
+	defer func(v bool) { *enrichFromPerfSchema = v }(*enrichFromPerfSchema)
+	*enrichFromPerfSchema = true
+
+	db := setUpQueryExecutorTest()
+	query := "select * from test_table limit 1000"
+	db.AddQuery(query, &sqltypes.Result{
+		Fields: getTestTableFields(),
+		Rows:   [][]sqltypes.Value{},
+	})
+	db.AddQuery("select * from test_table where 1 != 1", &sqltypes.Result{
+		Fields: getTestTableFields(),
+	})
+	var row []sqltypes.Value
+	for _, v := range []string{"100", "1", "2", "0", "1", "10", "1"} {
+		row = append(row, sqltypes.MakeTrusted(sqltypes.Int64, []byte(v)))
+	}
+	db.AddQuery(perfSchemaStatementQuery, &sqltypes.Result{
+		RowsAffected: 1,
+		Rows:         [][]sqltypes.Value{row},
+	})
+	ctx := context.Background()
+	tsv := newTestTabletServer(ctx, enableRowCache|enableSchemaOverrides|enableStrict, db)
+	defer tsv.StopService()
+
+	qre := newTestQueryExecutor(ctx, tsv, query, 0)
+	if _, err := qre.Execute(); err != nil {
+		t.Fatalf("qre.Execute() = %v, want nil", err)
+	}
+	want := &PerfSchemaStats{
+		RowsExamined:         100,
+		CreatedTmpDiskTables: 1,
+		CreatedTmpTables:     2,
+		SelectScan:           1,
+		SortRows:             10,
+		NoIndexUsed:          1,
+	}
+	if !reflect.DeepEqual(qre.logStats.PerfSchema, want) {
+		t.Errorf("PerfSchema = %+v, want %+v", qre.logStats.PerfSchema, want)
+	}
+	if got := qre.logStats.RewrittenSQL(); strings.Contains(got, "performance_schema") {
+		t.Errorf("RewrittenSQL() = %q, want no performance_schema query", got)
+	}
+
+	// The stats are not set when performance_schema has no record.
+	db.AddQuery(perfSchemaStatementQuery, &sqltypes.Result{})
+	qre = newTestQueryExecutor(ctx, tsv, query, 0)
+	if _, err := qre.Execute(); err != nil {
+		t.Fatalf("qre.Execute() = %v, want nil", err)
+	}
+	if qre.logStats.PerfSchema != nil {
+		t.Errorf("PerfSchema = %+v, want nil", qre.logStats.PerfSchema)
+	}
+}
+
+func TestQueryExecutorConnWaitStats(t *testing.T) {
+	db := setUpQueryExecutorTest()
+	query := "select * from test_table limit 1000"
+	db.AddQuery(query, &sqltypes.Result{
+		Fields: getTestTableFields(),
+		Rows:   [][]sqltypes.Value{},
+	})
+	db.AddQuery("select * from test_table where 1 != 1", &sqltypes.Result{
+		Fields: getTestTableFields(),
+	})
+	ctx := context.Background()
+	tsv := newTestTabletServer(ctx, enableStrict, db)
+	defer tsv.StopService()
+	tsv.SetPoolSize(1)
+
+	const delay = 10 * time.Millisecond
+	for i := 0; i < 3; i++ {
+		qre := newTestQueryExecutor(ctx, tsv, query, 0)
+		checkPlanID(t, planbuilder.PlanPassSelect, qre.plan.PlanID)
+		// Hold the only connection for a while so that the query
+		// has to wait for it.
+		conn, err := tsv.qe.connPool.Get(ctx)
+		if err != nil {
+			t.Fatalf("connPool.Get: %v", err)
+		}
+		go func() {
+			time.Sleep(delay)
+			conn.Recycle()
+		}()
+		if _, err := qre.Execute(); err != nil {
+			t.Fatalf("qre.Execute() = %v, want nil", err)
+		}
+	}
+
+	histogram, ok := tsv.qe.queryServiceStats.ConnWaitStats.Histograms()["PASS_SELECT"]
+	if !ok {
+		t.Fatalf("ConnWaitStats has no PASS_SELECT histogram")
+	}
+	if got, want := histogram.Count(), int64(3); got != want {
+		t.Errorf("ConnWaitStats[PASS_SELECT] count: %v, want %v", got, want)
+	}
+	if got, want := time.Duration(histogram.Total()), 3*delay; got < want {
+		t.Errorf("ConnWaitStats[PASS_SELECT] total: %v, want at least %v", got, want)
+	}
+	if got, want := tsv.qe.queryServiceStats.ConnWaitStats.Cutoffs(), tsv.qe.queryServiceStats.QueryStats.Cutoffs(); !reflect.DeepEqual(got, want) {
+		t.Errorf("ConnWaitStats buckets: %v, want the QueryStats buckets %v", got, want)
+	}
+}
+
+func TestQueryExecutorTxPoolWaitStats(t *testing.T) {
+	db := setUpQueryExecutorTest()
+	db.AddQuery("insert into test_table values (1) /* _stream test_table (pk ) (1 ); */", &sqltypes.Result{})
+	query := "insert into test_table values(1)"
+	ctx := context.Background()
+	tsv := newTestTabletServer(ctx, enableStrict, db)
+	defer tsv.StopService()
+	tsv.SetTxPoolSize(1)
+
+	const delay = 10 * time.Millisecond
+	qre := newTestQueryExecutor(ctx, tsv, query, 0)
+	checkPlanID(t, planbuilder.PlanInsertPK, qre.plan.PlanID)
+	// Hold the only transaction so that the autocommit has to wait
+	// for it.
+	transactionID := tsv.qe.txPool.Begin(ctx)
+	go func() {
+		time.Sleep(delay)
+		tsv.qe.txPool.Rollback(ctx, transactionID)
+	}()
+	if _, err := qre.Execute(); err != nil {
+		t.Fatalf("qre.Execute() = %v, want nil", err)
+	}
+
+	logStats := qre.logStats
+	if logStats.TxPoolWaitTime < delay {
+		t.Errorf("TxPoolWaitTime: %v, want at least %v", logStats.TxPoolWaitTime, delay)
+	}
+	if got, want := logStats.WaitingForConnection, logStats.ConnPoolWaitTime+logStats.TxPoolWaitTime; got != want {
+		t.Errorf("WaitingForConnection: %v, want the sum of the pool waits %v", got, want)
+	}
+	qss := tsv.qe.queryServiceStats
+	for _, tcase := range []struct {
+		name    string
+		timings *stats.Timings
+		want    time.Duration
+	}{
+		{"ConnWaitStats", qss.ConnWaitStats, logStats.WaitingForConnection},
+		{"TxPoolWaitStats", qss.TxPoolWaitStats, logStats.TxPoolWaitTime},
+	} {
+		histogram, ok := tcase.timings.Histograms()["INSERT_PK"]
+		if !ok {
+			t.Errorf("%v has no INSERT_PK histogram", tcase.name)
+			continue
+		}
+		if got := time.Duration(histogram.Total()); got != tcase.want {
+			t.Errorf("%v[INSERT_PK] total: %v, want %v", tcase.name, got, tcase.want)
+		}
+	}
+	// The insert took no connection from the conn pools.
+	if _, ok := qss.ConnPoolWaitStats.Histograms()["INSERT_PK"]; ok {
+		t.Errorf("ConnPoolWaitStats has an INSERT_PK histogram, want none")
+	}
+}
+
+func TestAddConnWaitStatsWithoutConnection(t *testing.T) {
+	qss := NewQueryServiceStats("", false)
+	// A rowcache hit takes no connection.
+	qss.addConnWaitStats("PK_IN", &LogStats{})
+	for name, timings := range map[string]*stats.Timings{
+		"ConnWaitStats":     qss.ConnWaitStats,
+		"ConnPoolWaitStats": qss.ConnPoolWaitStats,
+		"TxPoolWaitStats":   qss.TxPoolWaitStats,
+	} {
+		if _, ok := timings.Histograms()["PK_IN"]; ok {
+			t.Errorf("%v has a PK_IN histogram, want none", name)
+		}
+	}
+}
+
+func TestQueryExecutorTableHits(t *testing.T) {
+	db := setUpQueryExecutorTest()
+	want := &sqltypes.Result{
+		Fields: getTestTableFields(),
+		Rows:   [][]sqltypes.Value{},
+	}
+	db.AddQuery("select * from test_table as a join seq as b limit 10001", want)
+	db.AddQuery("select * from test_table as a join seq as b", want)
+	db.AddQuery("select * from test_table as a join seq as b where 1 != 1", &sqltypes.Result{
+		Fields: getTestTableFields(),
+	})
+	ctx := context.Background()
+	tsv := newTestTabletServer(ctx, enableRowCache|enableSchemaOverrides|enableStrict, db)
+	qre := newTestQueryExecutor(ctx, tsv, "select * from test_table a join seq b", 0)
+	defer tsv.StopService()
+	checkPlanID(t, planbuilder.PlanPassSelect, qre.plan.PlanID)
+	if _, err := qre.Execute(); err != nil {
+		t.Fatalf("qre.Execute() = %v, want nil", err)
+	}
+	if want := map[string]int64{"test_table": 1, "seq": 1}; !reflect.DeepEqual(qre.logStats.TableHits, want) {
+		t.Errorf("TableHits: %v, want %v", qre.logStats.TableHits, want)
+	}
+
+	qre = newTestQueryExecutor(ctx, tsv, "select * from test_table a join seq b", 0)
+	qre.plan = tsv.qe.schemaInfo.GetStreamPlan(qre.query)
+	if err := qre.Stream(func(*sqltypes.Result) error { return nil }); err != nil {
+		t.Fatalf("qre.Stream() = %v, want nil", err)
+	}
+	if want := map[string]int64{"test_table": 1, "seq": 1}; !reflect.DeepEqual(qre.logStats.TableHits, want) {
+		t.Errorf("Stream TableHits: %v, want %v", qre.logStats.TableHits, want)
+	}
+}
+
+func TestQueryExecutorStreamBufferSize(t *testing.T) {
+	db := setUpQueryExecutorTest()
+	query := "select * from test_table"
+	db.AddQuery("select * from test_table where 1 != 1", &sqltypes.Result{
+		Fields: getTestTableFields(),
+	})
+	ctx := context.Background()
+	tsv := newTestTabletServer(ctx, enableRowCache|enableSchemaOverrides|enableStrict, db)
+	defer tsv.StopService()
+	tsv.qe.streamBufferSize.Set(100)
+	tsv.qe.compressedStreamBufferSize.Set(200)
+
+	qre := newTestQueryExecutor(ctx, tsv, query, 0)
+	qre.plan = tsv.qe.schemaInfo.GetStreamPlan(qre.query)
+	if got, want := qre.streamBufferSize(), 100; got != want {
+		t.Errorf("streamBufferSize: %v, want %v", got, want)
+	}
+	qre.plan.Compressed = true
+	if got, want := qre.streamBufferSize(), 200; got != want {
+		t.Errorf("streamBufferSize of a compressed table: %v, want %v", got, want)
+	}
+	tsv.qe.compressedStreamBufferSize.Set(0)
+	if got, want := qre.streamBufferSize(), 100; got != want {
+		t.Errorf("streamBufferSize of a compressed table without a compressed size: %v, want %v", got, want)
+	}
+}
+
+func TestQueryExecutorStreamLogStats(t *testing.T) {
+	db := setUpQueryExecutorTest()
+	query := "select * from test_table"
+	row := []sqltypes.Value{
+		sqltypes.MakeTrusted(sqltypes.Int32, []byte("1")),
+		sqltypes.MakeTrusted(sqltypes.Int32, []byte("20")),
+		sqltypes.MakeTrusted(sqltypes.Int32, []byte("30")),
+	}
+	db.AddQuery(query, &sqltypes.Result{
+		Fields:       getTestTableFields(),
+		RowsAffected: 3,
+		Rows:         [][]sqltypes.Value{row, row, row},
+	})
+	db.AddQuery("select * from test_table where 1 != 1", &sqltypes.Result{
+		Fields: getTestTableFields(),
+	})
+	ctx := context.Background()
+	tsv := newTestTabletServer(ctx, enableRowCache|enableSchemaOverrides|enableStrict, db)
+	defer tsv.StopService()
+	// Each row is sent in its own result.
+	tsv.qe.streamBufferSize.Set(1)
+
+	qre := newTestQueryExecutor(ctx, tsv, query, 0)
+	qre.plan = tsv.qe.schemaInfo.GetStreamPlan(qre.query)
+	if err := qre.Stream(func(*sqltypes.Result) error { return nil }); err != nil {
+		t.Fatalf("qre.Stream() = %v, want nil", err)
+	}
+	if got, want := qre.logStats.RowsReturned(), 3; got != want {
+		t.Errorf("RowsReturned: %v, want %v", got, want)
+	}
+	if got, want := qre.logStats.SizeOfResponse(), 15; got != want {
+		t.Errorf("SizeOfResponse: %v, want %v", got, want)
+	}
+
+	// The results sent before an error are counted, not the failed one.
+	qre = newTestQueryExecutor(ctx, tsv, query, 0)
+	qre.plan = tsv.qe.schemaInfo.GetStreamPlan(qre.query)
+	sent := 0
+	err := qre.Stream(func(result *sqltypes.Result) error {
+		sent += len(result.Rows)
+		if sent == 2 {
+			return errors.New("client gone")
+		}
+		return nil
+	})
+	if err == nil {
+		t.Fatalf("qre.Stream() = nil, want error")
+	}
+	if got, want := qre.logStats.RowsReturned(), 1; got != want {
+		t.Errorf("RowsReturned after an error: %v, want %v", got, want)
+	}
+	if got, want := qre.logStats.SizeOfResponse(), 5; got != want {
+		t.Errorf("SizeOfResponse after an error: %v, want %v", got, want)
+	}
+}
+
+func TestQueryExecutorQueryIDComment(t *testing.T) {
+	defer func() { *queryIDComment = false }()
+	db := setUpQueryExecutorTest()
+	query := "select * from test_table limit 1000"
+	db.AddQuery("select * from test_table where 1 != 1", &sqltypes.Result{
+		Fields: getTestTableFields(),
+	})
+	ctx := context.Background()
+	tsv := newTestTabletServer(ctx, enableRowCache|enableSchemaOverrides|enableStrict, db)
+	defer tsv.StopService()
+
+	// MySQL gets the query ID in a trailing comment, the query log
+	// keeps the query without it.
+	*queryIDComment = true
+	qre := newTestQueryExecutor(ctx, tsv, query, 0)
+	sql := query + " /* query_id:" + qre.logStats.QueryID + " */"
+	db.AddQuery(sql, &sqltypes.Result{Fields: getTestTableFields()})
+	if _, err := qre.Execute(); err != nil {
+		t.Fatalf("qre.Execute() = %v, want nil", err)
+	}
+	if got := db.GetQueryCalledNum(sql); got != 1 {
+		t.Errorf("calls of %q: %v, want 1", sql, got)
+	}
+	if got := qre.logStats.RewrittenSQL(); !strings.HasSuffix(got, "; "+query) {
+		t.Errorf("RewrittenSQL: %q, want the field query and %q", got, query)
+	}
+
+	*queryIDComment = false
+	db.AddQuery(query, &sqltypes.Result{Fields: getTestTableFields()})
+	if _, err := newTestQueryExecutor(ctx, tsv, query, 0).Execute(); err != nil {
+		t.Fatalf("qre.Execute() without the comment = %v, want nil", err)
+	}
+	if got := db.GetQueryCalledNum(query); got != 1 {
+		t.Errorf("calls of %q: %v, want 1", query, got)
+	}
+}
+
+func TestQueryExecutorTableLimits(t *testing.T) {
+	db := setUpQueryExecutorTest()
+	query := "select * from test_table limit 1000"
+	db.AddQuery(query, &sqltypes.Result{Fields: getTestTableFields()})
+	db.AddQuery("select * from test_table", &sqltypes.Result{Fields: getTestTableFields()})
+	db.AddQuery("select * from test_table where 1 != 1", &sqltypes.Result{
+		Fields: getTestTableFields(),
+	})
+	ctx := context.Background()
+	tsv := newTestTabletServer(ctx, enableRowCache|enableSchemaOverrides|enableStrict, db)
+	defer tsv.StopService()
+	if err := tsv.SetTableLimits(map[string]TableLimit{"test_table": {MaxConcurrency: 1}}); err != nil {
+		t.Fatalf("SetTableLimits: %v", err)
+	}
+
+	// The queries of the table are rejected while a stream of it is
+	// in flight.
+	qre := newTestQueryExecutor(ctx, tsv, "select * from test_table", 0)
+	qre.plan = tsv.qe.schemaInfo.GetStreamPlan(qre.query)
+	var executeErr error
+	err := qre.Stream(func(*sqltypes.Result) error {
+		if got := tsv.qe.tableLimits.Concurrency()["test_table"]; got != 1 {
+			t.Errorf("concurrency of test_table: %v, want 1", got)
+		}
+		_, executeErr = newTestQueryExecutor(ctx, tsv, query, 0).Execute()
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("qre.Stream() = %v, want nil", err)
+	}
+	want := "tx_pool_full: table test_table has 1 queries in flight, over its limit of 1 concurrent queries"
+	if executeErr == nil || executeErr.Error() != want {
+		t.Errorf("Execute over the limit: %v, want %v", executeErr, want)
+	}
+	if tabletErr, ok := executeErr.(*TabletError); !ok || tabletErr.ErrorCode != vtrpcpb.ErrorCode_RESOURCE_EXHAUSTED {
+		t.Errorf("Execute over the limit: %#v, want a RESOURCE_EXHAUSTED TabletError", executeErr)
+	}
+	if got := tsv.qe.tableLimits.Concurrency()["test_table"]; got != 0 {
+		t.Errorf("concurrency of test_table: %v, want 0", got)
+	}
+	if got := tsv.qe.tableLimits.rejections.Counts()["test_table.MaxConcurrency"]; got != 1 {
+		t.Errorf("rejections of test_table: %v, want 1", got)
+	}
+
+	// The query succeeds once the stream is done.
+	if _, err := newTestQueryExecutor(ctx, tsv, query, 0).Execute(); err != nil {
+		t.Errorf("Execute after the stream: %v", err)
+	}
+}
+
 func TestQueryExecutorPlanPKIn(t *testing.T) {
 	db := setUpQueryExecutorTest()
 	query := "select * from test_table where pk in (1, 2, 3) limit 1000"
@@ -528,6 +1005,69 @@ func TestQueryExecutorPlanPKIn(t *testing.T) {
 	}
 }
 
+func TestQueryExecutorRowVersion(t *testing.T) {
+	db := setUpQueryExecutorTest()
+	query := "select * from test_table where pk in (1) limit 1000"
+	want := &sqltypes.Result{
+		Fields:       getTestTableFields(),
+		RowsAffected: 1,
+		Rows: [][]sqltypes.Value{
+			{
+				sqltypes.MakeTrusted(sqltypes.Int32, []byte("1")),
+				sqltypes.MakeTrusted(sqltypes.Int32, []byte("20")),
+				sqltypes.MakeTrusted(sqltypes.Int32, []byte("30")),
+			},
+		},
+	}
+	db.AddQuery(query, want)
+	db.AddQuery("select pk, name, addr from test_table where pk in (1)", want)
+	db.AddQuery("select * from test_table where 1 != 1", &sqltypes.Result{
+		Fields: getTestTableFields(),
+	})
+	dml := "update test_table set name = 2 where pk in (1) /* _stream test_table (pk ) (1 ); */"
+	db.AddQuery(dml, &sqltypes.Result{RowsAffected: 1})
+	db.AddQuery("select 1 from `test_table` where pk in (1) for update", &sqltypes.Result{RowsAffected: 1})
+	tsv := newTestTabletServer(context.Background(), enableRowCache|enableSchemaOverrides|enableStrict, db)
+	defer tsv.StopService()
+
+	ctx, recorder := rowversion.NewRecorderContext(context.Background())
+	qre := newTestQueryExecutor(ctx, tsv, query, 0)
+	checkPlanID(t, planbuilder.PlanPKIn, qre.plan.PlanID)
+	if _, err := qre.Execute(); err != nil {
+		t.Fatalf("qre.Execute() = %v, want nil", err)
+	}
+	token := recorder.Token
+	if token == "" {
+		t.Fatalf("recorder.Token is empty")
+	}
+
+	// The first update has the version of the row, and changes it.
+	ctx = rowversion.NewExpectContext(context.Background(), token)
+	qre = newTestQueryExecutor(ctx, tsv, dml, newTransaction(tsv))
+	got, err := qre.Execute()
+	if err != nil {
+		t.Fatalf("qre.Execute() = %v, want nil", err)
+	}
+	if got.RowsAffected != 1 {
+		t.Errorf("RowsAffected: %v, want 1", got.RowsAffected)
+	}
+	testCommitHelper(t, tsv, qre)
+
+	// The second one doesn't.
+	qre = newTestQueryExecutor(ctx, tsv, dml, newTransaction(tsv))
+	got, err = qre.Execute()
+	if err != nil {
+		t.Fatalf("qre.Execute() = %v, want nil", err)
+	}
+	if got.RowsAffected != 0 {
+		t.Errorf("RowsAffected with a stale version: %v, want 0", got.RowsAffected)
+	}
+	testCommitHelper(t, tsv, qre)
+	if got, want := tsv.qe.rowVersions.conflicts.Get(), int64(1); got != want {
+		t.Errorf("conflicts: %v, want %v", got, want)
+	}
+}
+
 func TestQueryExecutorPlanSelectSubQuery(t *testing.T) {
 	db := setUpQueryExecutorTest()
 	query := "select * from test_table where name = 1 limit 1000"
@@ -638,6 +1178,87 @@ func TestQueryExecutorPlanNextval(t *testing.T) {
 	}
 }
 
+func TestQueryExecutorPlanLockTables(t *testing.T) {
+	db := setUpQueryExecutorTest()
+	lockQuery := "lock tables test_table write"
+	db.AddQuery("set autocommit=0", &sqltypes.Result{})
+	db.AddQuery(lockQuery, &sqltypes.Result{})
+	db.AddQuery("unlock tables", &sqltypes.Result{})
+	db.AddQuery("set autocommit=1", &sqltypes.Result{})
+	selQuery := "select * from test_table"
+	want := &sqltypes.Result{
+		Fields: getTestTableFields(),
+		Rows:   [][]sqltypes.Value{},
+	}
+	db.AddQuery(selQuery+" limit 10001", want)
+	db.AddQuery(selQuery+" where 1 != 1", &sqltypes.Result{
+		Fields: getTestTableFields(),
+	})
+	db.AddQuery("select * from seq where 1 != 1", &sqltypes.Result{})
+	ctx := context.Background()
+	tsv := newTestTabletServer(ctx, enableStrict, db)
+	defer tsv.StopService()
+
+	qre := newTestQueryExecutor(ctx, tsv, lockQuery, 0)
+	checkPlanID(t, planbuilder.PlanLockTables, qre.plan.PlanID)
+	if _, err := qre.Execute(); err == nil || !strings.Contains(err.Error(), "Disallowed outside transaction") {
+		t.Fatalf("LOCK TABLES outside a transaction: %v, want Disallowed outside transaction", err)
+	}
+
+	qre = newTestQueryExecutor(ctx, tsv, lockQuery, newTransaction(tsv))
+	if _, err := qre.Execute(); err != nil {
+		t.Fatalf("LOCK TABLES: %v", err)
+	}
+	transactionID := qre.transactionID
+	qre = newTestQueryExecutor(ctx, tsv, "select * from seq", transactionID)
+	if _, err := qre.Execute(); err == nil || !strings.Contains(err.Error(), "table seq was not locked with LOCK TABLES") {
+		t.Errorf("select of a table that is not locked: %v, want table seq was not locked with LOCK TABLES", err)
+	}
+	qre = newTestQueryExecutor(ctx, tsv, selQuery, transactionID)
+	got, err := qre.Execute()
+	if err != nil {
+		t.Fatalf("select of the locked table: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("select of the locked table: %v, want %v", got, want)
+	}
+	qre = newTestQueryExecutor(ctx, tsv, "unlock tables", transactionID)
+	checkPlanID(t, planbuilder.PlanUnlockTables, qre.plan.PlanID)
+	if _, err := qre.Execute(); err != nil {
+		t.Fatalf("UNLOCK TABLES: %v", err)
+	}
+	// Once unlocked, the other tables can be accessed.
+	qre = newTestQueryExecutor(ctx, tsv, "select * from seq", transactionID)
+	conn := tsv.qe.txPool.Get(transactionID)
+	if err := conn.CheckLockedTables(qre.plan.TableNames); err != nil {
+		t.Errorf("CheckLockedTables after UNLOCK TABLES: %v", err)
+	}
+	conn.Recycle()
+	testCommitHelper(t, tsv, qre)
+	// The session is reset before the connection goes back to the pool.
+	if got := db.GetQueryCalledNum("unlock tables"); got != 2 {
+		t.Errorf("unlock tables: %v calls, want 2", got)
+	}
+	if got := db.GetQueryCalledNum("set autocommit=1"); got != 1 {
+		t.Errorf("set autocommit=1: %v calls, want 1", got)
+	}
+
+	transactionID = newTransaction(tsv)
+	qre = newTestQueryExecutor(ctx, tsv, selQuery, transactionID)
+	if _, err := qre.Execute(); err != nil {
+		t.Fatalf("select: %v", err)
+	}
+	qre = newTestQueryExecutor(ctx, tsv, lockQuery, transactionID)
+	if _, err := qre.Execute(); err == nil || !strings.Contains(err.Error(), "LOCK TABLES must be the first statement of the transaction") {
+		t.Errorf("LOCK TABLES after a select: %v, want LOCK TABLES must be the first statement of the transaction", err)
+	}
+	qre = newTestQueryExecutor(ctx, tsv, "unlock tables", transactionID)
+	if _, err := qre.Execute(); err == nil || !strings.Contains(err.Error(), "UNLOCK TABLES without LOCK TABLES") {
+		t.Errorf("UNLOCK TABLES without LOCK TABLES: %v, want UNLOCK TABLES without LOCK TABLES", err)
+	}
+	testCommitHelper(t, tsv, qre)
+}
+
 func TestQueryExecutorTableAcl(t *testing.T) {
 	aclName := fmt.Sprintf("simpleacl-test-%d", rand.Int63())
 	tableacl.Register(aclName, &simpleacl.Factory{})
@@ -1149,6 +1770,7 @@ func getQueryExecutorSupportedQueries() map[string]*sqltypes.Result {
 					sqltypes.MakeTrusted(sqltypes.Int32, []byte("2")),
 					sqltypes.MakeTrusted(sqltypes.Int32, []byte("3")),
 					sqltypes.MakeTrusted(sqltypes.Int32, []byte("4")),
+					sqltypes.MakeString([]byte("Compact")),
 				},
 				{
 					sqltypes.MakeString([]byte("seq")),
@@ -1159,6 +1781,7 @@ func getQueryExecutorSupportedQueries() map[string]*sqltypes.Result {
 					sqltypes.MakeTrusted(sqltypes.Int32, []byte("2")),
 					sqltypes.MakeTrusted(sqltypes.Int32, []byte("3")),
 					sqltypes.MakeTrusted(sqltypes.Int32, []byte("4")),
+					sqltypes.MakeString([]byte("Compact")),
 				},
 			},
 		},
@@ -1241,6 +1864,7 @@ func getQueryExecutorSupportedQueries() map[string]*sqltypes.Result {
 					sqltypes.MakeTrusted(sqltypes.Int32, []byte("2")),
 					sqltypes.MakeTrusted(sqltypes.Int32, []byte("3")),
 					sqltypes.MakeTrusted(sqltypes.Int32, []byte("4")),
+					sqltypes.MakeString([]byte("Compact")),
 				},
 			},
 		},
@@ -1323,6 +1947,7 @@ func getQueryExecutorSupportedQueries() map[string]*sqltypes.Result {
 					sqltypes.MakeTrusted(sqltypes.Int32, []byte("2")),
 					sqltypes.MakeTrusted(sqltypes.Int32, []byte("3")),
 					sqltypes.MakeTrusted(sqltypes.Int32, []byte("4")),
+					sqltypes.MakeString([]byte("Compact")),
 				},
 			},
 		},
@@ -55,6 +55,13 @@ func (ql *QueryList) Remove(qd *QueryDetail) {
 	delete(ql.queryDetails, qd.connID)
 }
 
+// Len returns the number of queries in the QueryList.
+func (ql *QueryList) Len() int {
+	ql.mu.Lock()
+	defer ql.mu.Unlock()
+	return len(ql.queryDetails)
+}
+
 // Terminate updates the query status and kills the connection
 func (ql *QueryList) Terminate(connID int64) error {
 	ql.mu.Lock()
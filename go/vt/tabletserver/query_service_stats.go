@@ -18,6 +18,14 @@ type QueryServiceStats struct {
 	QueryStats *stats.Timings
 	// WaitStats shows the time histogram for wait operations
 	WaitStats *stats.Timings
+	// ConnWaitStats shows the time histogram for each type of
+	// queries spent waiting for a connection.
+	ConnWaitStats *stats.Timings
+	// ConnPoolWaitStats and TxPoolWaitStats break ConnWaitStats down
+	// into the time spent waiting for the connection pools and for
+	// the transaction pool.
+	ConnPoolWaitStats *stats.Timings
+	TxPoolWaitStats   *stats.Timings
 	// KillStats shows number of connections being killed.
 	KillStats *stats.Counters
 	// InfoErrors shows number of various non critical errors happened.
@@ -26,6 +34,8 @@ type QueryServiceStats struct {
 	ErrorStats *stats.Counters
 	// InternalErros shows number of errors from internal components.
 	InternalErrors *stats.Counters
+	// MySQLErrors shows number of MySQL errors by errno.
+	MySQLErrors *stats.Counters
 	// UserTableQueryCount shows number of queries received for each CallerID/table combination.
 	UserTableQueryCount *stats.MultiCounters
 	// UserTableQueryTimesNs shows total latency for each CallerID/table combination.
@@ -40,6 +50,9 @@ type QueryServiceStats struct {
 	ResultStats *stats.Histogram
 	// SpotCheckCount shows the number of spot check events happened.
 	SpotCheckCount *stats.Int
+	// RequestSizeStats shows the histogram of the size of the queries
+	// and their bind variables, in bytes.
+	RequestSizeStats *stats.Histogram
 }
 
 // NewQueryServiceStats returns a new QueryServiceStats instance.
@@ -48,12 +61,17 @@ func NewQueryServiceStats(statsPrefix string, enablePublishStats bool) *QuerySer
 	queryStatsName := ""
 	qpsRateName := ""
 	waitStatsName := ""
+	connWaitStatsName := ""
+	connPoolWaitStatsName := ""
+	txPoolWaitStatsName := ""
 	killStatsName := ""
 	infoErrorsName := ""
 	errorStatsName := ""
 	internalErrorsName := ""
+	mysqlErrorsName := ""
 	resultStatsName := ""
 	spotCheckCountName := ""
+	requestSizeStatsName := ""
 	userTableQueryCountName := ""
 	userTableQueryTimesNsName := ""
 	userTransactionCountName := ""
@@ -63,18 +81,24 @@ func NewQueryServiceStats(statsPrefix string, enablePublishStats bool) *QuerySer
 		queryStatsName = statsPrefix + "Queries"
 		qpsRateName = statsPrefix + "QPS"
 		waitStatsName = statsPrefix + "Waits"
+		connWaitStatsName = statsPrefix + "ConnWaitTime"
+		connPoolWaitStatsName = statsPrefix + "ConnWaitTimeConnPool"
+		txPoolWaitStatsName = statsPrefix + "ConnWaitTimeTxPool"
 		killStatsName = statsPrefix + "Kills"
 		infoErrorsName = statsPrefix + "InfoErrors"
 		errorStatsName = statsPrefix + "Errors"
 		internalErrorsName = statsPrefix + "InternalErrors"
+		mysqlErrorsName = statsPrefix + "MysqlErrors"
 		resultStatsName = statsPrefix + "Results"
 		spotCheckCountName = statsPrefix + "RowcacheSpotCheckCount"
+		requestSizeStatsName = statsPrefix + "RequestSizeBytes"
 		userTableQueryCountName = statsPrefix + "UserTableQueryCount"
 		userTableQueryTimesNsName = statsPrefix + "UserTableQueryTimesNs"
 		userTransactionCountName = statsPrefix + "UserTransactionCount"
 		userTransactionTimesNsName = statsPrefix + "UserTransactionTimesNs"
 	}
 	resultBuckets := []int64{0, 1, 5, 10, 50, 100, 500, 1000, 5000, 10000}
+	requestSizeBuckets := []int64{100, 1000, 10000, 100000, 1000000, 10000000}
 	queryStats := stats.NewTimings(queryStatsName)
 	return &QueryServiceStats{
 		MySQLStats: stats.NewTimings(mysqlStatsName),
@@ -82,9 +106,10 @@ func NewQueryServiceStats(statsPrefix string, enablePublishStats bool) *QuerySer
 		WaitStats:  stats.NewTimings(waitStatsName),
 		KillStats:  stats.NewCounters(killStatsName, "Transactions", "Queries"),
 		InfoErrors: stats.NewCounters(infoErrorsName, "Retry", "Fatal", "DupKey"),
-		ErrorStats: stats.NewCounters(errorStatsName, "Fail", "TxPoolFull", "NotInTx", "Deadlock"),
+		ErrorStats: stats.NewCounters(errorStatsName, "Fail", "TxPoolFull", "NotInTx", "Deadlock", "PacketTooLarge"),
 		InternalErrors: stats.NewCounters(internalErrorsName, "Task", "MemcacheStats",
 			"Mismatch", "StrayTransactions", "Invalidation", "Panic", "HungQuery", "Schema"),
+		MySQLErrors: stats.NewCounters(mysqlErrorsName),
 		UserTableQueryCount: stats.NewMultiCounters(
 			userTableQueryCountName, []string{"TableName", "CallerID", "Type"}),
 		UserTableQueryTimesNs: stats.NewMultiCounters(
@@ -97,5 +122,28 @@ func NewQueryServiceStats(statsPrefix string, enablePublishStats bool) *QuerySer
 		QPSRates:       stats.NewRates(qpsRateName, queryStats, 15*60/5, 5*time.Second),
 		ResultStats:    stats.NewHistogram(resultStatsName, resultBuckets),
 		SpotCheckCount: stats.NewInt(spotCheckCountName),
+		// ConnWaitStats and its breakdown have the same buckets as
+		// QueryStats.
+		ConnWaitStats:     stats.NewTimings(connWaitStatsName),
+		ConnPoolWaitStats: stats.NewTimings(connPoolWaitStatsName),
+		TxPoolWaitStats:   stats.NewTimings(txPoolWaitStatsName),
+		RequestSizeStats:  stats.NewHistogram(requestSizeStatsName, requestSizeBuckets),
+	}
+}
+
+// addConnWaitStats adds the times logStats spent waiting for
+// connections to ConnWaitStats and its breakdown, under name. Only
+// the pools the query took a connection from are counted: the queries
+// served without a connection, like the rowcache hits, are not.
+func (qss *QueryServiceStats) addConnWaitStats(name string, logStats *LogStats) {
+	if logStats.connPoolWaits == 0 && logStats.txPoolWaits == 0 {
+		return
+	}
+	qss.ConnWaitStats.Add(name, logStats.WaitingForConnection)
+	if logStats.connPoolWaits > 0 {
+		qss.ConnPoolWaitStats.Add(name, logStats.ConnPoolWaitTime)
+	}
+	if logStats.txPoolWaits > 0 {
+		qss.TxPoolWaitStats.Add(name, logStats.TxPoolWaitTime)
 	}
 }
@@ -0,0 +1,230 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package querylogparser
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/youtube/vitess/go/sqltypes"
+	"github.com/youtube/vitess/go/streamlog"
+	"github.com/youtube/vitess/go/vt/logutil"
+
+	logutilpb "github.com/youtube/vitess/go/vt/proto/logutil"
+	querypb "github.com/youtube/vitess/go/vt/proto/query"
+	querylogpb "github.com/youtube/vitess/go/vt/proto/querylog"
+)
+
+// redactedBindVariableJSON is the JSON of the value of the redacted
+// bind variables, as json.Marshal escapes it, and redactedBindVariable
+// the way vttablet writes it.
+const (
+	redactedBindVariableJSON = `"\u003credacted\u003e"`
+	redactedBindVariable     = `"<redacted>"`
+)
+
+// BinaryReader reads the records of a query log written in the binary
+// format, e.g. by vttablet with -querylog-file-format binary: a
+// sequence of querylog.LogStats protos, each prefixed by its length
+// as a varint.
+//
+// The records of all versions can be read: the fields added after a
+// record was written have their zero value, and the Version field of
+// the record tells which fields it may have.
+type BinaryReader struct {
+	r *streamlog.BinaryReader
+}
+
+// NewBinaryReader returns a BinaryReader that reads the records from r.
+func NewBinaryReader(r io.Reader) *BinaryReader {
+	return &BinaryReader{r: streamlog.NewBinaryReader(r)}
+}
+
+// Read returns the next record. It returns io.EOF at the end of the
+// log, and io.ErrUnexpectedEOF if the log ends in the middle of a
+// record, e.g. while vttablet is still writing it.
+func (br *BinaryReader) Read() (*querylogpb.LogStats, error) {
+	record := &querylogpb.LogStats{}
+	if err := br.r.Read(record); err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+// FormatText returns the record in the text format of the query log,
+// that Parse parses, terminated by a newline. It's the line vttablet
+// would have logged, except for the times, which are shown in the
+// local time zone, and the bytes bind variables, which are shown as
+// strings instead of base64. The dedup summaries are formatted like
+// vttablet does too.
+func FormatText(record *querylogpb.LogStats) string {
+	if summary := record.DedupSummary; summary != nil {
+		return fmt.Sprintf(
+			"DedupSummary\t%v\t%v\t%v\t%.6f\t%v\t%q\t%q\t%q\t%q\t%q\t\n",
+			summary.Suppressed,
+			formatTime(summary.StartTime),
+			formatTime(summary.EndTime),
+			seconds(summary.TotalTime),
+			record.PlanType,
+			record.OriginalSql,
+			record.Fingerprint,
+			record.Keyspace,
+			record.Shard,
+			record.TabletAlias,
+		)
+	}
+	errorCode := ""
+	if record.Error != "" {
+		errorCode = record.ErrorCode.String()
+	}
+	return fmt.Sprintf(
+		"%v\t%q\t%q\t%q\t%q\t%v\t%v\t%.6f\t%v\t%q\t%v\t%v\t%q\t%v\t%.6f\t%.6f\t%v\t%v\t%v\t%v\t%v\t%v\t%q\t%q\t%v\t%q\t%v\t%v\t%v\t%v\t%v\t%q\t%q\t%q\t%q\t%.6f\t%.6f\t%v\t%v\t%q\t%q\t\n",
+		record.Method,
+		record.RemoteAddr,
+		record.Username,
+		record.ImmediateCaller,
+		record.EffectiveCaller,
+		formatTime(record.StartTime),
+		formatTime(record.EndTime),
+		seconds(record.TotalTime),
+		record.PlanType,
+		record.OriginalSql,
+		formatBindVariables(record.BindVariables),
+		record.NumberOfQueries,
+		strings.Join(record.RewrittenSql, "; "),
+		formatQuerySources(record.QuerySources),
+		seconds(record.MysqlResponseTime),
+		seconds(record.WaitingForConnection),
+		record.RowsAffected,
+		record.SizeOfResponse,
+		record.CacheHits,
+		record.CacheMisses,
+		record.CacheAbsent,
+		record.CacheInvalidations,
+		record.Error,
+		record.Fingerprint,
+		record.MysqlErrno,
+		record.MysqlState,
+		errorCode,
+		formatTableHits(record.TableHits),
+		record.DeadlineExceeded,
+		record.SemiSyncFallback,
+		record.SizeOfRequest,
+		strings.Join(formatTimings(record), "; "),
+		record.Keyspace,
+		record.Shard,
+		record.TabletAlias,
+		seconds(record.ConnPoolWaitTime),
+		seconds(record.TxPoolWaitTime),
+		formatDeadline(record, record.DeadlineBudget),
+		formatDeadline(record, record.DeadlineRemaining),
+		record.QueryId,
+		record.SchemaValidationError,
+	)
+}
+
+// FormatJSON returns the record as a single line JSON object,
+// terminated by a newline. Its fields are the ones of the proto, with
+// their zero values omitted, and the durations are in nanoseconds.
+func FormatJSON(record *querylogpb.LogStats) (string, error) {
+	b, err := json.Marshal(record)
+	if err != nil {
+		return "", err
+	}
+	return string(b) + "\n", nil
+}
+
+func formatTime(t *logutilpb.Time) string {
+	return logutil.ProtoToTime(t).Local().Format(time.StampMicro)
+}
+
+func seconds(nanoseconds int64) float64 {
+	return time.Duration(nanoseconds).Seconds()
+}
+
+// formatDeadline shows the deadline durations like vttablet: empty
+// if the query had no deadline. The records before version 2 have no
+// has_deadline field, and mark the queries without a deadline with
+// -1 durations instead.
+func formatDeadline(record *querylogpb.LogStats, nanoseconds int64) string {
+	hasDeadline := record.HasDeadline
+	if record.Version < 2 {
+		hasDeadline = nanoseconds != -1
+	}
+	if !hasDeadline {
+		return ""
+	}
+	return fmt.Sprintf("%.6f", seconds(nanoseconds))
+}
+
+func formatQuerySources(sources []string) string {
+	if len(sources) == 0 {
+		return "none"
+	}
+	return strings.Join(sources, ",")
+}
+
+func formatTableHits(hits map[string]int64) string {
+	if len(hits) == 0 {
+		return "{}"
+	}
+	b, err := json.Marshal(hits)
+	if err != nil {
+		return "{}"
+	}
+	return string(b)
+}
+
+// formatTimings appends their duration to the rewritten statements.
+// The records written by a faulty vttablet may have fewer timings
+// than statements, the missing ones are shown as 0s.
+func formatTimings(record *querylogpb.LogStats) []string {
+	timings := make([]string, 0, len(record.RewrittenSql))
+	for i, sql := range record.RewrittenSql {
+		var d time.Duration
+		if i < len(record.RewrittenSqlTimes) {
+			d = time.Duration(record.RewrittenSqlTimes[i])
+		}
+		timings = append(timings, fmt.Sprintf("%s:%v", sql, d))
+	}
+	return timings
+}
+
+// formatBindVariables returns the bind variables as a JSON object,
+// with the numbers as JSON numbers and the other values as strings.
+func formatBindVariables(bindVars map[string]*querypb.BindVariable) string {
+	out := make(map[string]interface{}, len(bindVars))
+	for k, bv := range bindVars {
+		if bv == nil {
+			continue
+		}
+		if bv.Type == sqltypes.Tuple {
+			list := make([]interface{}, 0, len(bv.Values))
+			for _, v := range bv.Values {
+				list = append(list, jsonValue(v.Type, v.Value))
+			}
+			out[k] = list
+			continue
+		}
+		out[k] = jsonValue(bv.Type, bv.Value)
+	}
+	b, err := json.Marshal(out)
+	if err != nil {
+		return ""
+	}
+	return strings.Replace(string(b), redactedBindVariableJSON, redactedBindVariable, -1)
+}
+
+// jsonValue returns the value of typ as a json.Number if it's a
+// number, and as a string otherwise.
+func jsonValue(typ querypb.Type, value []byte) interface{} {
+	if sqltypes.IsIntegral(typ) || sqltypes.IsFloat(typ) {
+		return json.Number(value)
+	}
+	return string(value)
+}
@@ -0,0 +1,127 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package querylogparser
+
+import (
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/youtube/vitess/go/sqltypes"
+	"github.com/youtube/vitess/go/streamlog"
+	"github.com/youtube/vitess/go/vt/logutil"
+
+	querypb "github.com/youtube/vitess/go/vt/proto/query"
+	querylogpb "github.com/youtube/vitess/go/vt/proto/querylog"
+)
+
+func TestBinaryReader(t *testing.T) {
+	start := time.Date(2016, 3, 14, 1, 2, 3, 0, time.Local)
+	// A record written before the version and the query ID were
+	// added, without a deadline.
+	old := &querylogpb.LogStats{
+		Method:      "Execute",
+		StartTime:   logutil.TimeToProto(start),
+		EndTime:     logutil.TimeToProto(start.Add(1500 * time.Millisecond)),
+		TotalTime:   int64(1500 * time.Millisecond),
+		PlanType:    "PASS_SELECT",
+		OriginalSql: "select 'a\tb' from t where id in ::ids",
+		BindVariables: map[string]*querypb.BindVariable{
+			"ids": {Type: sqltypes.Tuple, Values: []*querypb.Value{
+				{Type: sqltypes.Int64, Value: []byte("1")},
+				{Type: sqltypes.VarBinary, Value: []byte("x")},
+			}},
+			"secret": {Type: sqltypes.VarChar, Value: []byte("<redacted>")},
+		},
+		RewrittenSql:      []string{"select 1", "select 2"},
+		RewrittenSqlTimes: []int64{int64(time.Millisecond)},
+		DeadlineBudget:    -1,
+		DeadlineRemaining: -1,
+	}
+	encoded, err := streamlog.EncodeBinary(old)
+	if err != nil {
+		t.Fatal(err)
+	}
+	br := NewBinaryReader(strings.NewReader(encoded))
+	got, err := br.Read()
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if got.Version != 0 || got.QueryId != "" {
+		t.Errorf("Read: version %v and query ID %q, want 0 and \"\"", got.Version, got.QueryId)
+	}
+	if _, err := br.Read(); err != io.EOF {
+		t.Errorf("Read at the end: %v, want %v", err, io.EOF)
+	}
+
+	parsed, err := Parse(FormatText(got))
+	if err != nil {
+		t.Fatalf("Parse(%q): %v", FormatText(got), err)
+	}
+	if parsed.StartTime != "Mar 14 01:02:03.000000" || parsed.TotalTime != 1.5 || parsed.OriginalSQL != old.OriginalSql {
+		t.Errorf("Parse: %+v, want the fields of %v", parsed, old)
+	}
+	if want := `{"ids":[1,"x"],"secret":"<redacted>"}`; parsed.BindVariables != want {
+		t.Errorf("BindVariables: %v, want %v", parsed.BindVariables, want)
+	}
+	if want := "select 1:1ms; select 2:0s"; parsed.RewrittenSQLTimings != want {
+		t.Errorf("RewrittenSQLTimings: %q, want %q", parsed.RewrittenSQLTimings, want)
+	}
+	if parsed.HasDeadline || parsed.QuerySources != "none" || parsed.TableHits != "{}" || parsed.ErrorCode != "" {
+		t.Errorf("Parse: %+v, want no deadline, query sources, table hits nor error code", parsed)
+	}
+
+	formatted, err := FormatJSON(got)
+	if err != nil {
+		t.Fatalf("FormatJSON: %v", err)
+	}
+	if strings.Count(formatted, "\n") != 1 || !strings.HasSuffix(formatted, "\n") {
+		t.Errorf("FormatJSON: %q, want exactly one line", formatted)
+	}
+	fromJSON := &querylogpb.LogStats{}
+	if err := json.Unmarshal([]byte(formatted), fromJSON); err != nil || fromJSON.OriginalSql != old.OriginalSql {
+		t.Errorf("FormatJSON: %q, %v, want the JSON of %v", formatted, err, old)
+	}
+}
+
+func TestFormatTextDeadline(t *testing.T) {
+	start := time.Date(2016, time.March, 14, 1, 2, 3, 0, time.Local)
+	testcases := []struct {
+		desc      string
+		record    *querylogpb.LogStats
+		want      bool
+		remaining float64
+	}{{
+		desc:   "no deadline",
+		record: &querylogpb.LogStats{Version: 2},
+	}, {
+		desc:      "deadline exceeded by 1ns",
+		record:    &querylogpb.LogStats{Version: 2, HasDeadline: true, DeadlineBudget: int64(time.Second), DeadlineRemaining: -1},
+		want:      true,
+		remaining: 0,
+	}, {
+		desc:   "no deadline before version 2",
+		record: &querylogpb.LogStats{Version: 1, DeadlineBudget: -1, DeadlineRemaining: -1},
+	}, {
+		desc:      "deadline before version 2",
+		record:    &querylogpb.LogStats{Version: 1, DeadlineBudget: int64(time.Second), DeadlineRemaining: int64(500 * time.Millisecond)},
+		want:      true,
+		remaining: 0.5,
+	}}
+	for _, tc := range testcases {
+		tc.record.Method = "Execute"
+		tc.record.StartTime = logutil.TimeToProto(start)
+		tc.record.EndTime = logutil.TimeToProto(start)
+		parsed, err := Parse(FormatText(tc.record))
+		if err != nil {
+			t.Fatalf("%v: Parse(%q): %v", tc.desc, FormatText(tc.record), err)
+		}
+		if parsed.HasDeadline != tc.want || parsed.DeadlineRemaining != tc.remaining {
+			t.Errorf("%v: Parse: %v, %v, want %v, %v", tc.desc, parsed.HasDeadline, parsed.DeadlineRemaining, tc.want, tc.remaining)
+		}
+	}
+}
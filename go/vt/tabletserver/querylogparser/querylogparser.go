@@ -0,0 +1,249 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package querylogparser parses the records of the text format of the
+// vttablet query log, the output of LogStats.Format without a
+// "format" param. It also reads the records of the binary format,
+// with BinaryReader, and converts them to the text format with
+// FormatText.
+//
+// A record is exactly one line, terminated by "\t\n", with NumColumns
+// tab separated columns. Each column is one of:
+//   - a quoted string, in Go syntax (strconv.Quote): the remote
+//     address, the username, the callers, the SQL, the
+//     rewritten SQL and its timings, the error, the MySQL state, the
+//     fingerprint, the keyspace, the shard, the tablet alias, the
+//     query ID and the schema validation error.
+//     Tabs, newlines, quotes and invalid UTF-8 are escaped, so any
+//     string is recovered byte for byte by strconv.Unquote.
+//   - compact JSON: the bind variables and the table hits. JSON
+//     escapes the control characters, so it never contains a tab or
+//     a newline.
+//   - a token written by vttablet that never contains a tab or a
+//     newline: the times, durations and counts, the plan type, the
+//     query sources, the error code and the booleans. The deadline
+//     durations are empty if the query had no deadline.
+package querylogparser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// NumColumns is the number of columns of a record.
+const NumColumns = 41
+
+// Record is a parsed record of the query log. The string fields are
+// the values that were logged, after the redaction and truncation
+// done by vttablet.
+type Record struct {
+	Method          string
+	RemoteAddr      string
+	Username        string
+	ImmediateCaller string
+	EffectiveCaller string
+	// StartTime and EndTime are in the time.StampMicro layout.
+	StartTime string
+	EndTime   string
+	// TotalTime and the other durations are in seconds.
+	TotalTime   float64
+	PlanType    string
+	OriginalSQL string
+	// BindVariables is a JSON object.
+	BindVariables   string
+	NumberOfQueries int64
+	// RewrittenSQL is the rewritten statements, joined by "; ".
+	RewrittenSQL string
+	// QuerySources is a comma separated list, or "none".
+	QuerySources         string
+	MysqlResponseTime    float64
+	WaitingForConnection float64
+	RowsAffected         int64
+	SizeOfResponse       int64
+	CacheHits            int64
+	CacheMisses          int64
+	CacheAbsent          int64
+	CacheInvalidations   int64
+	Error                string
+	Fingerprint          string
+	MysqlErrno           int64
+	MysqlState           string
+	ErrorCode            string
+	// TableHits is a JSON object.
+	TableHits               string
+	ContextDeadlineExceeded bool
+	SemiSyncFallback        bool
+	SizeOfRequest           int64
+	// RewrittenSQLTimings is "sql:duration" for each rewritten
+	// statement, joined by "; ".
+	RewrittenSQLTimings string
+	Keyspace            string
+	Shard               string
+	TabletAlias         string
+	ConnPoolWaitTime    float64
+	TxPoolWaitTime      float64
+	// HasDeadline is true if the context of the query had a
+	// deadline. DeadlineBudget is the time between StartTime and the
+	// deadline, and DeadlineRemaining the time left at EndTime. They
+	// are only set if HasDeadline.
+	HasDeadline       bool
+	DeadlineBudget    float64
+	DeadlineRemaining float64
+	// QueryID is also in a trailing comment of the statements sent
+	// to MySQL if vttablet runs with -query_id_comment.
+	QueryID string
+	// SchemaValidationError is set if vttablet runs with
+	// -schema_pre_validation and the query failed the validation.
+	SchemaValidationError string
+}
+
+// Parse parses a record. The final newline is optional.
+func Parse(line string) (*Record, error) {
+	line = strings.TrimSuffix(line, "\n")
+	if !strings.HasSuffix(line, "\t") {
+		return nil, fmt.Errorf("record doesn't end with a tab: %q", line)
+	}
+	columns := strings.Split(strings.TrimSuffix(line, "\t"), "\t")
+	if len(columns) != NumColumns {
+		return nil, fmt.Errorf("record has %v columns, want %v: %q", len(columns), NumColumns, line)
+	}
+
+	r := &columnReader{columns: columns}
+	record := &Record{
+		Method:                  r.token("Method"),
+		RemoteAddr:              r.quoted("RemoteAddr"),
+		Username:                r.quoted("Username"),
+		ImmediateCaller:         r.quoted("ImmediateCaller"),
+		EffectiveCaller:         r.quoted("EffectiveCaller"),
+		StartTime:               r.token("StartTime"),
+		EndTime:                 r.token("EndTime"),
+		TotalTime:               r.float("TotalTime"),
+		PlanType:                r.token("PlanType"),
+		OriginalSQL:             r.quoted("OriginalSQL"),
+		BindVariables:           r.token("BindVariables"),
+		NumberOfQueries:         r.int("NumberOfQueries"),
+		RewrittenSQL:            r.quoted("RewrittenSQL"),
+		QuerySources:            r.token("QuerySources"),
+		MysqlResponseTime:       r.float("MysqlResponseTime"),
+		WaitingForConnection:    r.float("WaitingForConnection"),
+		RowsAffected:            r.int("RowsAffected"),
+		SizeOfResponse:          r.int("SizeOfResponse"),
+		CacheHits:               r.int("CacheHits"),
+		CacheMisses:             r.int("CacheMisses"),
+		CacheAbsent:             r.int("CacheAbsent"),
+		CacheInvalidations:      r.int("CacheInvalidations"),
+		Error:                   r.quoted("Error"),
+		Fingerprint:             r.quoted("Fingerprint"),
+		MysqlErrno:              r.int("MysqlErrno"),
+		MysqlState:              r.quoted("MysqlState"),
+		ErrorCode:               r.token("ErrorCode"),
+		TableHits:               r.token("TableHits"),
+		ContextDeadlineExceeded: r.bool("ContextDeadlineExceeded"),
+		SemiSyncFallback:        r.bool("SemiSyncFallback"),
+		SizeOfRequest:           r.int("SizeOfRequest"),
+		RewrittenSQLTimings:     r.quoted("RewrittenSQLTimings"),
+		Keyspace:                r.quoted("Keyspace"),
+		Shard:                   r.quoted("Shard"),
+		TabletAlias:             r.quoted("TabletAlias"),
+		ConnPoolWaitTime:        r.float("ConnPoolWaitTime"),
+		TxPoolWaitTime:          r.float("TxPoolWaitTime"),
+	}
+	record.DeadlineBudget, record.HasDeadline = r.optionalFloat("DeadlineBudget")
+	record.DeadlineRemaining, _ = r.optionalFloat("DeadlineRemaining")
+	record.QueryID = r.quoted("QueryID")
+	record.SchemaValidationError = r.quoted("SchemaValidationError")
+	if r.err != nil {
+		return nil, r.err
+	}
+	return record, nil
+}
+
+// columnReader reads the columns of a record in order. It keeps the
+// first error, and returns zero values after it.
+type columnReader struct {
+	columns []string
+	next    int
+	err     error
+}
+
+func (r *columnReader) token(name string) string {
+	if r.err != nil {
+		return ""
+	}
+	column := r.columns[r.next]
+	r.next++
+	return column
+}
+
+func (r *columnReader) fail(name, column string, err error) {
+	r.err = fmt.Errorf("column %v (%v): cannot parse %q: %v", r.next, name, column, err)
+}
+
+func (r *columnReader) quoted(name string) string {
+	column := r.token(name)
+	if r.err != nil {
+		return ""
+	}
+	if !strings.HasPrefix(column, `"`) {
+		r.fail(name, column, fmt.Errorf("not a quoted string"))
+		return ""
+	}
+	value, err := strconv.Unquote(column)
+	if err != nil {
+		r.fail(name, column, err)
+		return ""
+	}
+	return value
+}
+
+func (r *columnReader) int(name string) int64 {
+	column := r.token(name)
+	if r.err != nil {
+		return 0
+	}
+	value, err := strconv.ParseInt(column, 10, 64)
+	if err != nil {
+		r.fail(name, column, err)
+		return 0
+	}
+	return value
+}
+
+func (r *columnReader) float(name string) float64 {
+	column := r.token(name)
+	if r.err != nil {
+		return 0
+	}
+	value, err := strconv.ParseFloat(column, 64)
+	if err != nil {
+		r.fail(name, column, err)
+		return 0
+	}
+	return value
+}
+
+// optionalFloat is float for a column that can be empty. It returns
+// false if it is.
+func (r *columnReader) optionalFloat(name string) (float64, bool) {
+	if r.err == nil && r.columns[r.next] == "" {
+		r.next++
+		return 0, false
+	}
+	value := r.float(name)
+	return value, r.err == nil
+}
+
+func (r *columnReader) bool(name string) bool {
+	column := r.token(name)
+	if r.err != nil {
+		return false
+	}
+	value, err := strconv.ParseBool(column)
+	if err != nil {
+		r.fail(name, column, err)
+		return false
+	}
+	return value
+}
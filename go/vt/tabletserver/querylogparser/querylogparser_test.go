@@ -0,0 +1,141 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package querylogparser
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// record is a query log record, with the columns in order.
+var record = []string{
+	"Execute", `"1.2.3.4"`, `"vt"`, `"app"`, `"user\tname"`,
+	"Mar 14 01:02:03.000000", "Mar 14 01:02:04.500000", "1.500000",
+	"PASS_SELECT", `"select 'a\tb\n' from t"`, `{"a":"x\ty"}`, "1",
+	`"select 'a\tb\n' from t limit 10001"`, "mysql", "1.000000", "0.250000",
+	"0", "42", "1", "2", "3", "4", `"error: \"bad\"\n"`,
+	`"select ? from t"`, "1105", `"HY000"`, "UNKNOWN_ERROR", `{"t":1}`,
+	"true", "false", "80", `"select 'a\tb\n' from t limit 10001:1.5s"`,
+	`"ks"`, `"-80"`, `"cell-0000000100"`, "0.000100", "0.000000",
+	"30.000000", "28.500000", `"15a2b3c4d5e6f708-2a"`, `"column c not found in table t"`,
+}
+
+func TestParse(t *testing.T) {
+	got, err := Parse(strings.Join(record, "\t") + "\t\n")
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	want := &Record{
+		Method:                  "Execute",
+		RemoteAddr:              "1.2.3.4",
+		Username:                "vt",
+		ImmediateCaller:         "app",
+		EffectiveCaller:         "user\tname",
+		StartTime:               "Mar 14 01:02:03.000000",
+		EndTime:                 "Mar 14 01:02:04.500000",
+		TotalTime:               1.5,
+		PlanType:                "PASS_SELECT",
+		OriginalSQL:             "select 'a\tb\n' from t",
+		BindVariables:           `{"a":"x\ty"}`,
+		NumberOfQueries:         1,
+		RewrittenSQL:            "select 'a\tb\n' from t limit 10001",
+		QuerySources:            "mysql",
+		MysqlResponseTime:       1,
+		WaitingForConnection:    0.25,
+		SizeOfResponse:          42,
+		CacheHits:               1,
+		CacheMisses:             2,
+		CacheAbsent:             3,
+		CacheInvalidations:      4,
+		Error:                   "error: \"bad\"\n",
+		Fingerprint:             "select ? from t",
+		MysqlErrno:              1105,
+		MysqlState:              "HY000",
+		ErrorCode:               "UNKNOWN_ERROR",
+		TableHits:               `{"t":1}`,
+		ContextDeadlineExceeded: true,
+		SizeOfRequest:           80,
+		RewrittenSQLTimings:     "select 'a\tb\n' from t limit 10001:1.5s",
+		Keyspace:                "ks",
+		Shard:                   "-80",
+		TabletAlias:             "cell-0000000100",
+		ConnPoolWaitTime:        0.0001,
+		HasDeadline:             true,
+		DeadlineBudget:          30,
+		DeadlineRemaining:       28.5,
+		QueryID:                 "15a2b3c4d5e6f708-2a",
+		SchemaValidationError:   "column c not found in table t",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Parse:\n%+v, want\n%+v", got, want)
+	}
+
+	// The deadline columns are empty without a deadline.
+	columns := append([]string(nil), record...)
+	columns[37], columns[38] = "", ""
+	got, err = Parse(strings.Join(columns, "\t") + "\t\n")
+	if err != nil {
+		t.Fatalf("Parse without a deadline: %v", err)
+	}
+	if got.HasDeadline || got.DeadlineBudget != 0 || got.DeadlineRemaining != 0 {
+		t.Errorf("Parse without a deadline: %v, %v, %v, want false, 0, 0", got.HasDeadline, got.DeadlineBudget, got.DeadlineRemaining)
+	}
+
+	// The final newline is optional.
+	if _, err := Parse(strings.Join(record, "\t") + "\t"); err != nil {
+		t.Errorf("Parse without a newline: %v", err)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	replace := func(i int, column string) string {
+		columns := append([]string(nil), record...)
+		columns[i] = column
+		return strings.Join(columns, "\t") + "\t\n"
+	}
+	testcases := []struct {
+		line string
+		want string
+	}{{
+		line: strings.Join(record, "\t") + "\n",
+		want: "record doesn't end with a tab",
+	}, {
+		line: strings.Join(record[1:], "\t") + "\t\n",
+		want: "record has 40 columns, want 41",
+	}, {
+		line: "DedupSummary\t12\tMar 14 01:02:03.000000\tMar 14 01:03:03.000000\t30.000000\tPASS_SELECT\t\"select 1\"\t\"select 1\"\t\"ks\"\t\"0\"\t\"cell-1\"\t\n",
+		want: "record has 11 columns, want 41",
+	}, {
+		line: replace(1, "1.2.3.4"),
+		want: "column 2 (RemoteAddr): cannot parse \"1.2.3.4\": not a quoted string",
+	}, {
+		line: replace(9, `"select \q"`),
+		want: "column 10 (OriginalSQL): cannot parse",
+	}, {
+		line: replace(16, "many"),
+		want: "column 17 (RowsAffected): cannot parse \"many\"",
+	}, {
+		line: replace(7, "fast"),
+		want: "column 8 (TotalTime): cannot parse \"fast\"",
+	}, {
+		line: replace(29, "maybe"),
+		want: "column 30 (SemiSyncFallback): cannot parse \"maybe\"",
+	}, {
+		line: replace(39, "15a2b3c4d5e6f708-2a"),
+		want: "column 40 (QueryID): cannot parse \"15a2b3c4d5e6f708-2a\": not a quoted string",
+	}, {
+		line: replace(40, "none"),
+		want: "column 41 (SchemaValidationError): cannot parse \"none\": not a quoted string",
+	}, {
+		line: replace(37, "soon"),
+		want: "column 38 (DeadlineBudget): cannot parse \"soon\"",
+	}}
+	for _, tcase := range testcases {
+		if _, err := Parse(tcase.line); err == nil || !strings.Contains(err.Error(), tcase.want) {
+			t.Errorf("Parse(%q): %v, want %v", tcase.line, err, tcase.want)
+		}
+	}
+}
@@ -6,14 +6,16 @@ package tabletserver
 
 import (
 	"fmt"
+	"html/template"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
-	"text/template"
 	"time"
 
 	log "github.com/golang/glog"
 	"github.com/youtube/vitess/go/acl"
+	"github.com/youtube/vitess/go/streamlog"
 )
 
 var (
@@ -50,7 +52,7 @@ var (
 		"unquote":      func(s string) string { return strings.Trim(s, "\"") },
 	}
 	querylogzTmpl = template.Must(template.New("example").Funcs(querylogzFuncMap).Parse(`
-		<tr class=".ColorLevel">
+		<tr class="{{.ColorLevel}}">
 			<td>{{.Method}}</td>
 			<td>{{.ContextHTML}}</td>
 			<td>{{.EffectiveCaller}}</td>
@@ -78,12 +80,21 @@ var (
 
 func init() {
 	http.HandleFunc("/querylogz", func(w http.ResponseWriter, r *http.Request) {
+		if recentQueries != nil {
+			querylogzRecentHandler(recentQueries, w, r)
+			return
+		}
 		ch := StatsLogger.Subscribe("querylogz")
 		defer StatsLogger.Unsubscribe(ch)
 		querylogzHandler(ch, w, r)
 	})
 }
 
+// recentQueries keeps the last records of the query log for
+// /querylogz and /querylogz/csv. It's nil if -querylogz_csv_size is 0,
+// /querylogz then shows the next records instead.
+var recentQueries *QueryLogRing
+
 // querylogzHandler serves a human readable snapshot of the
 // current query log.
 func querylogzHandler(ch chan interface{}, w http.ResponseWriter, r *http.Request) {
@@ -91,6 +102,11 @@ func querylogzHandler(ch chan interface{}, w http.ResponseWriter, r *http.Reques
 		acl.SendError(w, err)
 		return
 	}
+	skip, err := streamlog.NewMessageFilter(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 	timeout, limit := parseTimeoutLimitParams(r)
 	startHTMLTable(w)
 	defer endHTMLTable(w)
@@ -106,6 +122,9 @@ func querylogzHandler(ch chan interface{}, w http.ResponseWriter, r *http.Reques
 				return
 			default:
 			}
+			if _, ok := out.(*streamlog.DedupSummary); ok {
+				continue
+			}
 			stats, ok := out.(*LogStats)
 			if !ok {
 				err := fmt.Errorf("Unexpected value in %s: %#v (expecting value of type %T)", TxLogger.Name(), out, &LogStats{})
@@ -115,23 +134,136 @@ func querylogzHandler(ch chan interface{}, w http.ResponseWriter, r *http.Reques
 				log.Error(err)
 				continue
 			}
-			var level string
-			if stats.TotalTime().Seconds() < 0.01 {
-				level = "low"
-			} else if stats.TotalTime().Seconds() < 0.1 {
-				level = "medium"
-			} else {
-				level = "high"
-			}
-			tmplData := struct {
-				*LogStats
-				ColorLevel string
-			}{stats, level}
-			if err := querylogzTmpl.Execute(w, tmplData); err != nil {
-				log.Errorf("querylogz: couldn't execute template: %v", err)
+			if skip(stats) {
+				i--
+				continue
 			}
+			writeQuerylogzRow(w, stats)
 		case <-tmr.C:
 			return
 		}
 	}
 }
+
+// querylogzRecentHandler serves the last records of qlr, the newest
+// first. It takes the same limit param as querylogzHandler, and the
+// filter params of the query log stream.
+func querylogzRecentHandler(qlr *QueryLogRing, w http.ResponseWriter, r *http.Request) {
+	if err := acl.CheckAccessHTTP(r, acl.DEBUGGING); err != nil {
+		acl.SendError(w, err)
+		return
+	}
+	skip, err := streamlog.NewMessageFilter(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	_, limit := parseTimeoutLimitParams(r)
+	startHTMLTable(w)
+	defer endHTMLTable(w)
+	w.Write(querylogzHeader)
+
+	records := qlr.Last(cap(qlr.records))
+	for i := len(records) - 1; i >= 0 && limit > 0; i-- {
+		if skip(records[i]) {
+			continue
+		}
+		writeQuerylogzRow(w, records[i])
+		limit--
+	}
+}
+
+// writeQuerylogzRow writes the row of stats, colored by its latency:
+// low under 10ms, medium under 100ms, high above.
+func writeQuerylogzRow(w http.ResponseWriter, stats *LogStats) {
+	var level string
+	if stats.TotalTime().Seconds() < 0.01 {
+		level = "low"
+	} else if stats.TotalTime().Seconds() < 0.1 {
+		level = "medium"
+	} else {
+		level = "high"
+	}
+	originalSQL, _ := stats.loggedSQL(*redactDebugUIQueries)
+	tmplData := struct {
+		*LogStats
+		ColorLevel  string
+		OriginalSQL string
+		ErrorStr    string
+	}{stats, level, originalSQL, stats.loggedError(*redactDebugUIQueries)}
+	if err := querylogzTmpl.Execute(w, tmplData); err != nil {
+		log.Errorf("querylogz: couldn't execute template: %v", err)
+	}
+}
+
+// queryLogSampleHandler shows the query log sampling settings, and the
+// slow query threshold. If the "rate", "exempt" or
+// "slow_query_threshold" params are set, it changes them first.
+func queryLogSampleHandler(tsv *TabletServer, w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, fmt.Sprintf("cannot parse form: %s", err), http.StatusInternalServerError)
+		return
+	}
+	rate, exempt, threshold := r.FormValue("rate"), r.FormValue("exempt"), r.FormValue("slow_query_threshold")
+	role := acl.DEBUGGING
+	if rate != "" || exempt != "" || threshold != "" {
+		role = acl.ADMIN
+	}
+	if err := acl.CheckAccessHTTP(r, role); err != nil {
+		acl.SendError(w, err)
+		return
+	}
+	if rate != "" {
+		val, err := strconv.Atoi(rate)
+		if err != nil {
+			http.Error(w, "invalid rate", http.StatusBadRequest)
+			return
+		}
+		tsv.SetQueryLogSampleRate(val)
+	}
+	if exempt != "" {
+		val, err := time.ParseDuration(exempt)
+		if err != nil {
+			http.Error(w, "invalid exempt", http.StatusBadRequest)
+			return
+		}
+		tsv.SetQueryLogSampleExempt(val)
+	}
+	if threshold != "" {
+		val, err := time.ParseDuration(threshold)
+		if err != nil {
+			http.Error(w, "invalid slow_query_threshold", http.StatusBadRequest)
+			return
+		}
+		tsv.SetSlowQueryThreshold(val)
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	fmt.Fprintf(w, "rate: %d\nexempt: %v\nslow query threshold: %v\nsampled out: %d\n", tsv.QueryLogSampleRate(), tsv.QueryLogSampleExempt(), tsv.SlowQueryThreshold(), StatsLogger.SampledOut())
+}
+
+// queryLogRedactHandler shows the patterns of the bind variables that
+// are always redacted in the query log. If the "patterns" param is
+// set, it replaces them first. An empty value removes them all.
+func queryLogRedactHandler(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, fmt.Sprintf("cannot parse form: %s", err), http.StatusInternalServerError)
+		return
+	}
+	patterns, set := r.Form["patterns"]
+	role := acl.DEBUGGING
+	if set {
+		role = acl.ADMIN
+	}
+	if err := acl.CheckAccessHTTP(r, role); err != nil {
+		acl.SendError(w, err)
+		return
+	}
+	if set {
+		if err := SetBindVariableRedactPatterns(strings.Join(patterns, ",")); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	fmt.Fprintf(w, "patterns: %v\n", strings.Join(BindVariableRedactPatterns(), ","))
+}
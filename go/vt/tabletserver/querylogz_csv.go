@@ -0,0 +1,161 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tabletserver
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/youtube/vitess/go/acl"
+	"github.com/youtube/vitess/go/streamlog"
+)
+
+// queryLogCSVHeader is the header row of /querylogz/csv.
+var queryLogCSVHeader = []string{
+	"Method",
+	"RemoteAddr",
+	"Username",
+	"ImmediateCaller",
+	"EffectiveCaller",
+	"StartTime",
+	"EndTime",
+	"TotalTime",
+	"PlanType",
+	"OriginalSQL",
+	"BindVariables",
+	"NumberOfQueries",
+	"RewrittenSQL",
+	"QuerySources",
+	"MysqlResponseTime",
+	"WaitingForConnection",
+	"RowsAffected",
+	"SizeOfResponse",
+	"CacheHits",
+	"CacheMisses",
+	"CacheAbsent",
+	"CacheInvalidations",
+	"TransactionID",
+	"Error",
+	"QueryID",
+}
+
+// QueryLogRing keeps the last LogStats sent to a StreamLogger, to
+// serve them as CSV. It only costs its fixed buffer and the copy of
+// each record pointer: the records are formatted when they're served.
+type QueryLogRing struct {
+	mu      sync.Mutex
+	records []*LogStats
+	next    int
+}
+
+// NewQueryLogRing creates a QueryLogRing that keeps the last size records.
+func NewQueryLogRing(size int) *QueryLogRing {
+	return &QueryLogRing{
+		records: make([]*LogStats, 0, size),
+	}
+}
+
+// Add adds a record, replacing the oldest one if the buffer is full.
+func (qlr *QueryLogRing) Add(logStats *LogStats) {
+	qlr.mu.Lock()
+	defer qlr.mu.Unlock()
+	if len(qlr.records) < cap(qlr.records) {
+		qlr.records = append(qlr.records, logStats)
+		return
+	}
+	qlr.records[qlr.next] = logStats
+	qlr.next = (qlr.next + 1) % len(qlr.records)
+}
+
+// Last returns the last n records, the oldest first.
+func (qlr *QueryLogRing) Last(n int) []*LogStats {
+	qlr.mu.Lock()
+	defer qlr.mu.Unlock()
+	if n > len(qlr.records) {
+		n = len(qlr.records)
+	}
+	last := make([]*LogStats, 0, n)
+	for i := len(qlr.records) - n; i < len(qlr.records); i++ {
+		last = append(last, qlr.records[(qlr.next+i)%len(qlr.records)])
+	}
+	return last
+}
+
+// Run subscribes to logger, and adds the LogStats it sends in a goroutine.
+func (qlr *QueryLogRing) Run(logger *streamlog.StreamLogger) {
+	ch := logger.Subscribe("QueryLogRing")
+	go func() {
+		for message := range ch {
+			if logStats, ok := message.(*LogStats); ok {
+				qlr.Add(logStats)
+			}
+		}
+	}()
+}
+
+// ServeHTTP serves the last records as CSV, with a header row. The
+// limit param is the number of records, 300 by default, up to the
+// size of the buffer. The queries are redacted like in querylogz.
+func (qlr *QueryLogRing) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := acl.CheckAccessHTTP(r, acl.DEBUGGING); err != nil {
+		acl.SendError(w, err)
+		return
+	}
+	limit := 300
+	if l, err := strconv.Atoi(r.FormValue("limit")); err == nil {
+		limit = adjustValue(l, 1, cap(qlr.records))
+	}
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="querylog.csv"`)
+	cw := csv.NewWriter(w)
+	cw.Write(queryLogCSVHeader)
+	for _, stats := range qlr.Last(limit) {
+		cw.Write(stats.csvRecord(*redactDebugUIQueries))
+	}
+	cw.Flush()
+}
+
+// csvRecord returns the fields of the record for /querylogz/csv,
+// in the order of queryLogCSVHeader.
+func (stats *LogStats) csvRecord(redact bool) []string {
+	originalSQL, rewrittenSQLs := truncateLoggedSQL(stats.loggedSQL(redact))
+	bindVariablesMode := BindVariablesCompact
+	if redact {
+		bindVariablesMode = BindVariablesRedact
+	}
+	remoteAddr, username := stats.RemoteAddrUsername()
+	return []string{
+		stats.Method,
+		remoteAddr,
+		username,
+		stats.ImmediateCaller(),
+		stats.EffectiveCaller(),
+		stats.StartTime.Format(time.RFC3339Nano),
+		stats.EndTime.Format(time.RFC3339Nano),
+		fmt.Sprintf("%.6f", stats.TotalTime().Seconds()),
+		stats.PlanType,
+		originalSQL,
+		stats.FmtBindVariables(bindVariablesMode),
+		strconv.Itoa(stats.NumberOfQueries),
+		strings.Join(rewrittenSQLs, "; "),
+		stats.FmtQuerySources(),
+		fmt.Sprintf("%.6f", stats.MysqlResponseTime.Seconds()),
+		fmt.Sprintf("%.6f", stats.WaitingForConnection.Seconds()),
+		strconv.Itoa(stats.RowsAffected),
+		strconv.Itoa(stats.SizeOfResponse()),
+		strconv.FormatInt(stats.CacheHits, 10),
+		strconv.FormatInt(stats.CacheMisses, 10),
+		strconv.FormatInt(stats.CacheAbsent, 10),
+		strconv.FormatInt(stats.CacheInvalidations, 10),
+		strconv.FormatInt(stats.TransactionID, 10),
+		stats.loggedError(redact),
+		stats.QueryID,
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tabletserver
+
+import (
+	"encoding/csv"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"golang.org/x/net/context"
+)
+
+func newCSVLogStats(sql string) *LogStats {
+	logStats := newLogStats("Execute", context.Background())
+	logStats.OriginalSQL = sql
+	return logStats
+}
+
+func TestQueryLogRing(t *testing.T) {
+	qlr := NewQueryLogRing(2)
+	if got := qlr.Last(5); len(got) != 0 {
+		t.Errorf("Last of an empty ring: %v, want none", got)
+	}
+	a, b, c := newCSVLogStats("a"), newCSVLogStats("b"), newCSVLogStats("c")
+	qlr.Add(a)
+	if got, want := qlr.Last(5), []*LogStats{a}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Last: %v, want %v", got, want)
+	}
+	qlr.Add(b)
+	qlr.Add(c)
+	if got, want := qlr.Last(5), []*LogStats{b, c}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Last after a wrap: %v, want %v", got, want)
+	}
+	if got, want := qlr.Last(1), []*LogStats{c}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Last(1): %v, want %v", got, want)
+	}
+}
+
+func TestQueryLogRingServeHTTP(t *testing.T) {
+	qlr := NewQueryLogRing(2)
+	qlr.Add(newCSVLogStats("select 1 from dual"))
+	qlr.Add(newCSVLogStats("select \"a\"\t'b', c from t"))
+	qlr.Add(newCSVLogStats("select 3 from dual"))
+
+	for _, tcase := range []struct {
+		url  string
+		want []string
+	}{
+		{"/querylogz/csv", []string{"select \"a\"\t'b', c from t", "select 3 from dual"}},
+		// The limit is clamped to the size of the buffer.
+		{"/querylogz/csv?limit=1000", []string{"select \"a\"\t'b', c from t", "select 3 from dual"}},
+		{"/querylogz/csv?limit=1", []string{"select 3 from dual"}},
+	} {
+		req, _ := http.NewRequest("GET", tcase.url, nil)
+		response := httptest.NewRecorder()
+		qlr.ServeHTTP(response, req)
+		if got, want := response.Header().Get("Content-Type"), "text/csv; charset=utf-8"; got != want {
+			t.Errorf("%v: Content-Type: %v, want %v", tcase.url, got, want)
+		}
+		records, err := csv.NewReader(response.Body).ReadAll()
+		if err != nil {
+			t.Fatalf("%v: cannot parse the CSV: %v", tcase.url, err)
+		}
+		if len(records) != len(tcase.want)+1 {
+			t.Fatalf("%v: %v records, want %v and a header", tcase.url, len(records), len(tcase.want))
+		}
+		if !reflect.DeepEqual(records[0], queryLogCSVHeader) {
+			t.Errorf("%v: header: %v, want %v", tcase.url, records[0], queryLogCSVHeader)
+		}
+		for i, want := range tcase.want {
+			record := records[i+1]
+			if len(record) != len(queryLogCSVHeader) {
+				t.Errorf("%v: record %v has %v fields, want %v", tcase.url, i, len(record), len(queryLogCSVHeader))
+				continue
+			}
+			if got := record[9]; got != want {
+				t.Errorf("%v: OriginalSQL of record %v: %q, want %q", tcase.url, i, got, want)
+			}
+		}
+	}
+}
@@ -150,9 +150,143 @@ func TestQuerylogzHandler(t *testing.T) {
 	checkQuerylogzHasStats(t, slowQueryPattern, logStats, body)
 }
 
+func TestQuerylogzRecentHandler(t *testing.T) {
+	qlr := NewQueryLogRing(5)
+	for i, sql := range []string{"select 1 from dual", "update t set a = 1", "select '<b>' from dual"} {
+		logStats := newLogStats("Execute", context.Background())
+		logStats.PlanType = planbuilder.PlanPassSelect.String()
+		if i == 1 {
+			logStats.PlanType = planbuilder.PlanPassDML.String()
+		}
+		logStats.OriginalSQL = sql
+		logStats.EndTime = logStats.StartTime.Add(time.Duration(i) * 50 * time.Millisecond)
+		qlr.Add(logStats)
+	}
+
+	for _, tcase := range []struct {
+		url  string
+		rows int
+		want []string
+	}{
+		// The newest records come first, and the SQL is escaped.
+		{"/querylogz?limit=2", 2, []string{
+			`<tr class="high">`, `<td>select &#39;&lt;b&gt;&#39; from dual</td>`,
+			`<tr class="medium">`, `<td>update t set a = 1</td>`,
+		}},
+		{"/querylogz?plan=pass_select", 2, []string{
+			`<td>select &#39;&lt;b&gt;&#39; from dual</td>`,
+			`<tr class="low">`, `<td>select 1 from dual</td>`,
+		}},
+		{"/querylogz?min_duration=60ms", 1, []string{
+			`<td>select &#39;&lt;b&gt;&#39; from dual</td>`,
+		}},
+	} {
+		req, _ := http.NewRequest("GET", tcase.url, nil)
+		response := httptest.NewRecorder()
+		querylogzRecentHandler(qlr, response, req)
+		body := response.Body.String()
+		matcher := regexp.MustCompile(`(?s)` + strings.Join(tcase.want, `.*`))
+		if !matcher.MatchString(body) {
+			t.Errorf("%v: page does not contain %v in order: %s", tcase.url, tcase.want, body)
+		}
+		if got := strings.Count(body, "<tr class="); got != tcase.rows {
+			t.Errorf("%v: %v rows, want %v", tcase.url, got, tcase.rows)
+		}
+	}
+
+	req, _ := http.NewRequest("GET", "/querylogz?min_duration=bad", nil)
+	response := httptest.NewRecorder()
+	querylogzRecentHandler(qlr, response, req)
+	if response.Code != http.StatusBadRequest {
+		t.Errorf("min_duration=bad: code %v, want %v", response.Code, http.StatusBadRequest)
+	}
+}
+
 func checkQuerylogzHasStats(t *testing.T, pattern []string, logStats *LogStats, page []byte) {
 	matcher := regexp.MustCompile(strings.Join(pattern, `\s*`))
 	if !matcher.Match(page) {
 		t.Fatalf("querylogz page does not contain stats: %v, pattern: %v, page: %s", logStats, pattern, string(page))
 	}
 }
+
+func TestQueryLogSampleHandler(t *testing.T) {
+	defer setQueryLogSampleRate(int(queryLogSampleRate.Get()))
+	defer StatsLogger.SetSlowThreshold(StatsLogger.SlowThreshold())
+	tsv := &TabletServer{}
+
+	req, _ := http.NewRequest("GET", "/debug/querylog_sample?rate=10&exempt=1s", nil)
+	response := httptest.NewRecorder()
+	queryLogSampleHandler(tsv, response, req)
+	if got := tsv.QueryLogSampleRate(); got != 10 {
+		t.Errorf("QueryLogSampleRate: %d, want 10", got)
+	}
+	if got := tsv.QueryLogSampleExempt(); got != 1*time.Second {
+		t.Errorf("QueryLogSampleExempt: %v, want 1s", got)
+	}
+	if body := response.Body.String(); !strings.Contains(body, "rate: 10\nexempt: 1s\n") {
+		t.Errorf("got body %q, want the new settings", body)
+	}
+
+	req, _ = http.NewRequest("GET", "/debug/querylog_sample?rate=bad", nil)
+	response = httptest.NewRecorder()
+	queryLogSampleHandler(tsv, response, req)
+	if response.Code != http.StatusBadRequest {
+		t.Errorf("got code %d, want %d", response.Code, http.StatusBadRequest)
+	}
+	if got := tsv.QueryLogSampleRate(); got != 10 {
+		t.Errorf("QueryLogSampleRate: %d, want 10", got)
+	}
+}
+
+func TestQueryLogSampleHandlerSlowQueryThreshold(t *testing.T) {
+	defer slowQueryThreshold.Set(slowQueryThreshold.Get())
+	tsv := &TabletServer{}
+
+	req, _ := http.NewRequest("GET", "/debug/querylog_sample?slow_query_threshold=250ms", nil)
+	response := httptest.NewRecorder()
+	queryLogSampleHandler(tsv, response, req)
+	if got, want := tsv.SlowQueryThreshold(), 250*time.Millisecond; got != want {
+		t.Errorf("SlowQueryThreshold: %v, want %v", got, want)
+	}
+	if body := response.Body.String(); !strings.Contains(body, "slow query threshold: 250ms\n") {
+		t.Errorf("got body %q, want the new threshold", body)
+	}
+
+	req, _ = http.NewRequest("GET", "/debug/querylog_sample?slow_query_threshold=bad", nil)
+	response = httptest.NewRecorder()
+	queryLogSampleHandler(tsv, response, req)
+	if response.Code != http.StatusBadRequest {
+		t.Errorf("got code %d, want %d", response.Code, http.StatusBadRequest)
+	}
+	if got, want := tsv.SlowQueryThreshold(), 250*time.Millisecond; got != want {
+		t.Errorf("SlowQueryThreshold after a bad value: %v, want %v", got, want)
+	}
+}
+
+func TestQueryLogRedactHandler(t *testing.T) {
+	defer SetBindVariableRedactPatterns(strings.Join(BindVariableRedactPatterns(), ","))
+
+	req, _ := http.NewRequest("GET", "/debug/querylog_redact?patterns=*Secret*,token", nil)
+	response := httptest.NewRecorder()
+	queryLogRedactHandler(response, req)
+	if body, want := response.Body.String(), "patterns: *secret*,token\n"; body != want {
+		t.Errorf("got body %q, want %q", body, want)
+	}
+	if !isRedactedBindVariable("api_SECRET_key") {
+		t.Errorf("api_SECRET_key is not redacted")
+	}
+
+	req, _ = http.NewRequest("GET", "/debug/querylog_redact?patterns=[bad", nil)
+	response = httptest.NewRecorder()
+	queryLogRedactHandler(response, req)
+	if response.Code != http.StatusBadRequest {
+		t.Errorf("got code %d, want %d", response.Code, http.StatusBadRequest)
+	}
+
+	req, _ = http.NewRequest("GET", "/debug/querylog_redact?patterns=", nil)
+	response = httptest.NewRecorder()
+	queryLogRedactHandler(response, req)
+	if got := BindVariableRedactPatterns(); len(got) != 0 {
+		t.Errorf("BindVariableRedactPatterns: %v, want none", got)
+	}
+}
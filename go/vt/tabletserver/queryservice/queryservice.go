@@ -38,8 +38,29 @@ type QueryService interface {
 
 	Execute(ctx context.Context, target *querypb.Target, sql string, bindVariables map[string]interface{}, sessionID, transactionID int64) (*sqltypes.Result, error)
 	StreamExecute(ctx context.Context, target *querypb.Target, sql string, bindVariables map[string]interface{}, sessionID int64, sendReply func(*sqltypes.Result) error) error
+
+	// StreamExecuteResumable is like StreamExecute, but each result
+	// with rows is sent with a resume token, that resumes the stream
+	// after it when it's given back as resumeToken.
+	StreamExecuteResumable(ctx context.Context, target *querypb.Target, sql string, bindVariables map[string]interface{}, sessionID int64, resumeToken []byte, sendReply func(*sqltypes.Result, []byte) error) error
+
 	ExecuteBatch(ctx context.Context, target *querypb.Target, queries []querytypes.BoundQuery, sessionID int64, asTransaction bool, transactionID int64) ([]sqltypes.Result, error)
 
+	// WaitForGTID waits until the tablet has replicated up to gtid,
+	// a replication position returned by the Commit of the master.
+	WaitForGTID(ctx context.Context, target *querypb.Target, gtid string) error
+
+	// XA transactions, for two-phase commits with MySQL XA.
+	XAStart(ctx context.Context, target *querypb.Target, sessionID int64, xid string) (int64, error)
+	XAPrepare(ctx context.Context, target *querypb.Target, sessionID int64, xid string) error
+	XACommit(ctx context.Context, target *querypb.Target, sessionID int64, xid string) error
+	XARollback(ctx context.Context, target *querypb.Target, sessionID int64, xid string) error
+	XARecover(ctx context.Context, target *querypb.Target, sessionID int64) ([]string, error)
+
+	// HotQueries returns the most executed SELECT queries, for the
+	// replicas to warm their buffer pool with.
+	HotQueries(ctx context.Context, target *querypb.Target) ([]querytypes.BoundQuery, error)
+
 	// SplitQuery is a map reduce helper function
 	// TODO(erez): Remove this and rename the following func to SplitQuery
 	// once we migrate to SplitQuery V2.
@@ -106,11 +127,51 @@ func (e *ErrorQueryService) StreamExecute(ctx context.Context, target *querypb.T
 	return fmt.Errorf("ErrorQueryService does not implement any method")
 }
 
+// StreamExecuteResumable is part of QueryService interface
+func (e *ErrorQueryService) StreamExecuteResumable(ctx context.Context, target *querypb.Target, sql string, bindVariables map[string]interface{}, sessionID int64, resumeToken []byte, sendReply func(*sqltypes.Result, []byte) error) error {
+	return fmt.Errorf("ErrorQueryService does not implement any method")
+}
+
 // ExecuteBatch is part of QueryService interface
 func (e *ErrorQueryService) ExecuteBatch(ctx context.Context, target *querypb.Target, queries []querytypes.BoundQuery, sessionID int64, asTransaction bool, transactionID int64) ([]sqltypes.Result, error) {
 	return nil, fmt.Errorf("ErrorQueryService does not implement any method")
 }
 
+// WaitForGTID is part of QueryService interface
+func (e *ErrorQueryService) WaitForGTID(ctx context.Context, target *querypb.Target, gtid string) error {
+	return fmt.Errorf("ErrorQueryService does not implement any method")
+}
+
+// XAStart is part of QueryService interface
+func (e *ErrorQueryService) XAStart(ctx context.Context, target *querypb.Target, sessionID int64, xid string) (int64, error) {
+	return 0, fmt.Errorf("ErrorQueryService does not implement any method")
+}
+
+// XAPrepare is part of QueryService interface
+func (e *ErrorQueryService) XAPrepare(ctx context.Context, target *querypb.Target, sessionID int64, xid string) error {
+	return fmt.Errorf("ErrorQueryService does not implement any method")
+}
+
+// XACommit is part of QueryService interface
+func (e *ErrorQueryService) XACommit(ctx context.Context, target *querypb.Target, sessionID int64, xid string) error {
+	return fmt.Errorf("ErrorQueryService does not implement any method")
+}
+
+// XARollback is part of QueryService interface
+func (e *ErrorQueryService) XARollback(ctx context.Context, target *querypb.Target, sessionID int64, xid string) error {
+	return fmt.Errorf("ErrorQueryService does not implement any method")
+}
+
+// XARecover is part of QueryService interface
+func (e *ErrorQueryService) XARecover(ctx context.Context, target *querypb.Target, sessionID int64) ([]string, error) {
+	return nil, fmt.Errorf("ErrorQueryService does not implement any method")
+}
+
+// HotQueries is part of QueryService interface
+func (e *ErrorQueryService) HotQueries(ctx context.Context, target *querypb.Target) ([]querytypes.BoundQuery, error) {
+	return nil, fmt.Errorf("ErrorQueryService does not implement any method")
+}
+
 // SplitQuery is part of QueryService interface
 // TODO(erez): Remove once the migration to SplitQuery V2 is done.
 func (e *ErrorQueryService) SplitQuery(ctx context.Context, target *querypb.Target, sql string, bindVariables map[string]interface{}, splitColumn string, splitCount int64, sessionID int64) ([]querytypes.QuerySplit, error) {
@@ -95,6 +95,16 @@ func (_mr *_MockQueryServiceRecorder) StreamExecute(arg0, arg1, arg2, arg3, arg4
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "StreamExecute", arg0, arg1, arg2, arg3, arg4, arg5)
 }
 
+func (_m *MockQueryService) StreamExecuteResumable(ctx context.Context, target *query.Target, sql string, bindVariables map[string]interface{}, sessionID int64, resumeToken []byte, sendReply func(*sqltypes.Result, []byte) error) error {
+	ret := _m.ctrl.Call(_m, "StreamExecuteResumable", ctx, target, sql, bindVariables, sessionID, resumeToken, sendReply)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockQueryServiceRecorder) StreamExecuteResumable(arg0, arg1, arg2, arg3, arg4, arg5, arg6 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "StreamExecuteResumable", arg0, arg1, arg2, arg3, arg4, arg5, arg6)
+}
+
 func (_m *MockQueryService) ExecuteBatch(ctx context.Context, target *query.Target, queries []querytypes.BoundQuery, sessionID int64, asTransaction bool, transactionID int64) ([]sqltypes.Result, error) {
 	ret := _m.ctrl.Call(_m, "ExecuteBatch", ctx, target, queries, sessionID, asTransaction, transactionID)
 	ret0, _ := ret[0].([]sqltypes.Result)
@@ -106,6 +116,79 @@ func (_mr *_MockQueryServiceRecorder) ExecuteBatch(arg0, arg1, arg2, arg3, arg4,
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "ExecuteBatch", arg0, arg1, arg2, arg3, arg4, arg5)
 }
 
+func (_m *MockQueryService) WaitForGTID(ctx context.Context, target *query.Target, gtid string) error {
+	ret := _m.ctrl.Call(_m, "WaitForGTID", ctx, target, gtid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockQueryServiceRecorder) WaitForGTID(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "WaitForGTID", arg0, arg1, arg2)
+}
+
+func (_m *MockQueryService) XAStart(ctx context.Context, target *query.Target, sessionID int64, xid string) (int64, error) {
+	ret := _m.ctrl.Call(_m, "XAStart", ctx, target, sessionID, xid)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockQueryServiceRecorder) XAStart(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "XAStart", arg0, arg1, arg2, arg3)
+}
+
+func (_m *MockQueryService) XAPrepare(ctx context.Context, target *query.Target, sessionID int64, xid string) error {
+	ret := _m.ctrl.Call(_m, "XAPrepare", ctx, target, sessionID, xid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockQueryServiceRecorder) XAPrepare(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "XAPrepare", arg0, arg1, arg2, arg3)
+}
+
+func (_m *MockQueryService) XACommit(ctx context.Context, target *query.Target, sessionID int64, xid string) error {
+	ret := _m.ctrl.Call(_m, "XACommit", ctx, target, sessionID, xid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockQueryServiceRecorder) XACommit(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "XACommit", arg0, arg1, arg2, arg3)
+}
+
+func (_m *MockQueryService) XARollback(ctx context.Context, target *query.Target, sessionID int64, xid string) error {
+	ret := _m.ctrl.Call(_m, "XARollback", ctx, target, sessionID, xid)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockQueryServiceRecorder) XARollback(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "XARollback", arg0, arg1, arg2, arg3)
+}
+
+func (_m *MockQueryService) XARecover(ctx context.Context, target *query.Target, sessionID int64) ([]string, error) {
+	ret := _m.ctrl.Call(_m, "XARecover", ctx, target, sessionID)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockQueryServiceRecorder) XARecover(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "XARecover", arg0, arg1, arg2)
+}
+
+func (_m *MockQueryService) HotQueries(ctx context.Context, target *query.Target) ([]querytypes.BoundQuery, error) {
+	ret := _m.ctrl.Call(_m, "HotQueries", ctx, target)
+	ret0, _ := ret[0].([]querytypes.BoundQuery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockQueryServiceRecorder) HotQueries(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "HotQueries", arg0, arg1)
+}
+
 func (_m *MockQueryService) SplitQuery(ctx context.Context, target *query.Target, sql string, bindVariables map[string]interface{}, splitColumn string, splitCount int64, sessionID int64) ([]querytypes.QuerySplit, error) {
 	ret := _m.ctrl.Call(_m, "SplitQuery", ctx, target, sql, bindVariables, splitColumn, splitCount, sessionID)
 	ret0, _ := ret[0].([]querytypes.QuerySplit)
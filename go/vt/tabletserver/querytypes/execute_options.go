@@ -0,0 +1,27 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package querytypes
+
+import (
+	"golang.org/x/net/context"
+
+	querypb "github.com/youtube/vitess/go/vt/proto/query"
+)
+
+type executeOptionsKey struct{}
+
+// NewExecuteOptionsContext returns a Context that carries options.
+// Like for callerid, the RPC layers copy them to and from the
+// requests that accept ExecuteOptions.
+func NewExecuteOptionsContext(ctx context.Context, options *querypb.ExecuteOptions) context.Context {
+	return context.WithValue(ctx, executeOptionsKey{}, options)
+}
+
+// ExecuteOptionsFromContext returns the options stored by
+// NewExecuteOptionsContext, or nil if there are none.
+func ExecuteOptionsFromContext(ctx context.Context) *querypb.ExecuteOptions {
+	options, _ := ctx.Value(executeOptionsKey{}).(*querypb.ExecuteOptions)
+	return options
+}
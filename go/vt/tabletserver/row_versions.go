@@ -0,0 +1,110 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tabletserver
+
+import (
+	"hash/fnv"
+	"strconv"
+	"sync"
+
+	"github.com/youtube/vitess/go/sync2"
+)
+
+// rowVersionStripes is the number of invalidation sequences shared by
+// the rows. Two rows that share a sequence can get a false conflict,
+// never a false validation.
+const rowVersionStripes = 4096
+
+// rowVersions maintains the invalidation sequences the version tokens
+// of the rows of the cached tables are made of. The token of a row is
+// given by a primary key read of the rowcache, before the row is read,
+// and is checked by a primary key DML that gives it back, after the
+// row is locked.
+//
+// A row is invalidated in two steps, around the deletion of its keys
+// from the rowcache: beginInvalidation, before the commit in MySQL, and
+// endInvalidation, after the rowcache is updated. A token is only
+// valid if no invalidation of its row began since it was given, and
+// all the ones that began before ended. So a token taken before the
+// commit of a change, or while the rowcache still had the previous
+// row, never validates after it. The DMLs of vttablet and the events
+// of the rowcache invalidator both invalidate the rows this way.
+//
+// The tokens also have the rowcache prefix their row was read with,
+// which changes when the table is flushed, and an epoch, which changes when the
+// tablet serves a new type. The tokens don't survive a restart.
+type rowVersions struct {
+	epoch sync2.AtomicInt64
+	// conflicts counts the DMLs that didn't run because their row
+	// changed since their token.
+	conflicts sync2.AtomicInt64
+	stripes   [rowVersionStripes]struct {
+		mu      sync.Mutex
+		seq     int64
+		pending int
+	}
+}
+
+func newRowVersions() *rowVersions {
+	rv := &rowVersions{}
+	rv.reset()
+	return rv
+}
+
+// reset changes the epoch, which invalidates all the tokens.
+func (rv *rowVersions) reset() {
+	rv.epoch.Set(Rand())
+}
+
+func (rv *rowVersions) stripe(tableName, key string) int {
+	h := fnv.New32a()
+	h.Write([]byte(tableName))
+	h.Write([]byte{0})
+	h.Write([]byte(key))
+	return int(h.Sum32() % rowVersionStripes)
+}
+
+// seq returns the current invalidation sequence of the row key of
+// tableName. It must be read before the row the token is given for.
+func (rv *rowVersions) seq(tableName, key string) int64 {
+	s := &rv.stripes[rv.stripe(tableName, key)]
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.seq
+}
+
+// token returns the version token of a row read with the rowcache
+// prefix, whose invalidation sequence was seq before it was read.
+func (rv *rowVersions) token(prefix string, seq int64) string {
+	return strconv.FormatInt(rv.epoch.Get(), 36) + ":" + prefix + strconv.FormatInt(seq, 36)
+}
+
+// check returns true if token is still the version of the row key of
+// tableInfo, and no invalidation of its row is in progress.
+func (rv *rowVersions) check(tableInfo *TableInfo, key, token string) bool {
+	s := &rv.stripes[rv.stripe(tableInfo.Name, key)]
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pending == 0 && rv.token(tableInfo.Cache.prefix.Get(), s.seq) == token
+}
+
+// beginInvalidation starts the invalidation of the row key of
+// tableName. It must be followed by endInvalidation.
+func (rv *rowVersions) beginInvalidation(tableName, key string) {
+	s := &rv.stripes[rv.stripe(tableName, key)]
+	s.mu.Lock()
+	s.seq++
+	s.pending++
+	s.mu.Unlock()
+}
+
+// endInvalidation ends the invalidation of the row key of tableName.
+func (rv *rowVersions) endInvalidation(tableName, key string) {
+	s := &rv.stripes[rv.stripe(tableName, key)]
+	s.mu.Lock()
+	s.seq++
+	s.pending--
+	s.mu.Unlock()
+}
@@ -0,0 +1,79 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tabletserver
+
+import (
+	"testing"
+
+	"github.com/youtube/vitess/go/vt/schema"
+)
+
+func newRowVersionsTestTable() *TableInfo {
+	tableInfo := &TableInfo{Table: schema.NewTable("test_table")}
+	tableInfo.Cache = NewRowCache(tableInfo, &CachePool{})
+	return tableInfo
+}
+
+// currentToken returns the token of a read of the row key of tableInfo
+// with the current rowcache prefix.
+func currentToken(rv *rowVersions, tableInfo *TableInfo, key string) string {
+	return rv.token(tableInfo.Cache.prefix.Get(), rv.seq(tableInfo.Name, key))
+}
+
+func TestRowVersions(t *testing.T) {
+	rv := newRowVersions()
+	tableInfo := newRowVersionsTestTable()
+	token := currentToken(rv, tableInfo, "1")
+	if !rv.check(tableInfo, "1", token) {
+		t.Errorf("check(%q): false, want true", token)
+	}
+	if rv.check(tableInfo, "1", "") {
+		t.Errorf("check(\"\"): true, want false")
+	}
+
+	// An invalidation in progress fails all the tokens of the row.
+	rv.beginInvalidation(tableInfo.Name, "1")
+	inProgress := currentToken(rv, tableInfo, "1")
+	if rv.check(tableInfo, "1", inProgress) {
+		t.Errorf("check(%q) during an invalidation: true, want false", inProgress)
+	}
+	rv.endInvalidation(tableInfo.Name, "1")
+	for _, stale := range []string{token, inProgress} {
+		if rv.check(tableInfo, "1", stale) {
+			t.Errorf("check(%q) after an invalidation: true, want false", stale)
+		}
+	}
+	token = currentToken(rv, tableInfo, "1")
+	if !rv.check(tableInfo, "1", token) {
+		t.Errorf("check(%q) after an invalidation: false, want true", token)
+	}
+
+	// The other rows are not invalidated.
+	other := currentToken(rv, tableInfo, "2")
+	rv.beginInvalidation(tableInfo.Name, "1")
+	rv.endInvalidation(tableInfo.Name, "1")
+	if rv.stripe(tableInfo.Name, "1") != rv.stripe(tableInfo.Name, "2") && !rv.check(tableInfo, "2", other) {
+		t.Errorf("check(%q) of another row: false, want true", other)
+	}
+}
+
+func TestRowVersionsFlushAndReset(t *testing.T) {
+	rv := newRowVersions()
+	tableInfo := newRowVersionsTestTable()
+	token := currentToken(rv, tableInfo, "1")
+	tableInfo.Cache.Flush()
+	if rv.check(tableInfo, "1", token) {
+		t.Errorf("check(%q) after a Flush: true, want false", token)
+	}
+	token = currentToken(rv, tableInfo, "1")
+	epoch := rv.epoch.Get()
+	rv.reset()
+	if rv.epoch.Get() == epoch {
+		t.Skipf("reset chose the same epoch")
+	}
+	if rv.check(tableInfo, "1", token) {
+		t.Errorf("check(%q) after a reset: true, want false", token)
+	}
+}
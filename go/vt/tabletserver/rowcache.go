@@ -9,8 +9,10 @@ import (
 	"strconv"
 	"time"
 
+	log "github.com/golang/glog"
 	"github.com/youtube/vitess/go/sqltypes"
 	"github.com/youtube/vitess/go/stats"
+	"github.com/youtube/vitess/go/sync2"
 	vtrpcpb "github.com/youtube/vitess/go/vt/proto/vtrpc"
 	"golang.org/x/net/context"
 )
@@ -32,8 +34,11 @@ const (
 // RowCache gives a table-level view into the rowcache.
 type RowCache struct {
 	tableInfo *TableInfo
-	prefix    string
+	prefix    sync2.AtomicString
 	cachePool *CachePool
+	// encrypted says which columns are encrypted in the rowcache, it's
+	// nil if none is.
+	encrypted []bool
 }
 
 // RCResult represents the result of a cache multi-fetch.
@@ -44,20 +49,39 @@ type RCResult struct {
 
 // NewRowCache creates a new RowCache.
 func NewRowCache(tableInfo *TableInfo, cachePool *CachePool) *RowCache {
-	prefix := strconv.FormatInt(cachePool.maxPrefix.Add(1), 36) + "."
-	return &RowCache{tableInfo, prefix, cachePool}
+	rc := &RowCache{
+		tableInfo: tableInfo,
+		cachePool: cachePool,
+		encrypted: cachePool.encryption.encryptedColumns(tableInfo),
+	}
+	rc.prefix.Set(rc.newPrefix())
+	return rc
+}
+
+func (rc *RowCache) newPrefix() string {
+	return strconv.FormatInt(rc.cachePool.maxPrefix.Add(1), 36) + "."
 }
 
-// Get fetches the values for the specified keys.
-func (rc *RowCache) Get(ctx context.Context, keys []string) (results map[string]RCResult) {
+// Flush invalidates all the rows of the table, by switching to a new
+// prefix. The rows of the old prefix are left for memcache to evict.
+func (rc *RowCache) Flush() {
+	rc.prefix.Set(rc.newPrefix())
+}
+
+// Get fetches the values for the specified keys. It also returns the
+// prefix they were read with, which must be given to the Set of the
+// rows read from the db after them: a row that was read before a Flush
+// must not be cached after it.
+func (rc *RowCache) Get(ctx context.Context, keys []string) (results map[string]RCResult, prefix string) {
+	prefix = rc.prefix.Get()
 	mkeys := make([]string, 0, len(keys))
 	for _, key := range keys {
 		if len(key) > maxKeyLen {
 			continue
 		}
-		mkeys = append(mkeys, rc.prefix+key)
+		mkeys = append(mkeys, prefix+key)
 	}
-	prefixlen := len(rc.prefix)
+	prefixlen := len(prefix)
 	conn := rc.cachePool.Get(ctx)
 	// This is not the same as defer rc.cachePool.Put(conn)
 	defer func() { rc.cachePool.Put(conn) }()
@@ -82,25 +106,41 @@ func (rc *RowCache) Get(ctx context.Context, keys []string) (results map[string]
 		if row == nil {
 			panic(NewTabletError(vtrpcpb.ErrorCode_INTERNAL_ERROR, "Corrupt data for %s", mcresult.Key))
 		}
-		results[mcresult.Key[prefixlen:]] = RCResult{Row: row, Cas: mcresult.Cas}
+		key := mcresult.Key[prefixlen:]
+		if err := rc.decryptRow(key, row); err != nil {
+			// The row is read from the db, and its Cas lets the caller
+			// replace it.
+			log.Errorf("Cannot decrypt the rowcache row %s: %v", mcresult.Key, err)
+			if rc.cachePool.queryServiceStats != nil {
+				rc.cachePool.queryServiceStats.InternalErrors.Add("RowcacheDecrypt", 1)
+			}
+			results[key] = RCResult{Cas: mcresult.Cas}
+			continue
+		}
+		results[key] = RCResult{Row: row, Cas: mcresult.Cas}
 	}
 	return
 }
 
-// Set pushes the specified row into the rowcache.
-func (rc *RowCache) Set(ctx context.Context, key string, row []sqltypes.Value, cas uint64) {
-	if len(key) > maxKeyLen {
+// Set pushes the specified row into the rowcache. prefix is the prefix
+// returned by the Get that missed the row. The row isn't cached if the
+// table was flushed since.
+func (rc *RowCache) Set(ctx context.Context, prefix, key string, row []sqltypes.Value, cas uint64) {
+	if len(key) > maxKeyLen || prefix != rc.prefix.Get() {
 		return
 	}
+	row, err := rc.encryptRow(key, row)
+	if err != nil {
+		panic(NewTabletError(vtrpcpb.ErrorCode_INTERNAL_ERROR, "cannot encrypt the row: %v", err))
+	}
 	b := rc.encodeRow(row)
 	if b == nil {
 		return
 	}
 	conn := rc.cachePool.Get(ctx)
 	defer func() { rc.cachePool.Put(conn) }()
-	mkey := rc.prefix + key
+	mkey := prefix + key
 
-	var err error
 	if cas == 0 {
 		// Either caller didn't find the value at all
 		// or they didn't look for it in the first place.
@@ -123,7 +163,7 @@ func (rc *RowCache) Delete(ctx context.Context, key string) {
 	}
 	conn := rc.cachePool.Get(ctx)
 	defer func() { rc.cachePool.Put(conn) }()
-	mkey := rc.prefix + key
+	mkey := rc.prefix.Get() + key
 
 	_, err := conn.Set(mkey, rcDeleted, 0, nil)
 	if err != nil {
@@ -133,6 +173,73 @@ func (rc *RowCache) Delete(ctx context.Context, key string) {
 	}
 }
 
+// DeleteMulti marks the rows as deleted. The keys are sent to memcache
+// in a single pipelined batch.
+func (rc *RowCache) DeleteMulti(ctx context.Context, keys []string) {
+	prefix := rc.prefix.Get()
+	mkeys := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if len(key) > maxKeyLen {
+			continue
+		}
+		mkeys = append(mkeys, prefix+key)
+	}
+	if len(mkeys) == 0 {
+		return
+	}
+	conn := rc.cachePool.Get(ctx)
+	defer func() { rc.cachePool.Put(conn) }()
+
+	_, err := conn.SetMulti(mkeys, rcDeleted, 0, nil)
+	if err != nil {
+		conn.Close()
+		conn = nil
+		panic(NewTabletError(vtrpcpb.ErrorCode_INTERNAL_ERROR, "%s", err))
+	}
+}
+
+// encryptRow returns a copy of row whose encrypted columns are
+// encrypted, or row if it has none.
+func (rc *RowCache) encryptRow(key string, row []sqltypes.Value) ([]sqltypes.Value, error) {
+	if rc.encrypted == nil {
+		return row, nil
+	}
+	encryption := rc.cachePool.encryption
+	sealed := make([]sqltypes.Value, len(row))
+	for i, v := range row {
+		if i >= len(rc.encrypted) || !rc.encrypted[i] || v.IsNull() {
+			sealed[i] = v
+			continue
+		}
+		b, err := encryption.seal(v.Raw(), encryption.additionalData(rc.tableInfo.Name, rc.tableInfo.Columns[i].Name, key))
+		if err != nil {
+			return nil, err
+		}
+		sealed[i] = sqltypes.MakeTrusted(v.Type(), b)
+	}
+	return sealed, nil
+}
+
+// decryptRow decrypts in place the encrypted columns of a row read
+// from the rowcache.
+func (rc *RowCache) decryptRow(key string, row []sqltypes.Value) error {
+	if rc.encrypted == nil {
+		return nil
+	}
+	encryption := rc.cachePool.encryption
+	for i, v := range row {
+		if i >= len(rc.encrypted) || !rc.encrypted[i] || v.IsNull() {
+			continue
+		}
+		b, err := encryption.open(v.Raw(), encryption.additionalData(rc.tableInfo.Name, rc.tableInfo.Columns[i].Name, key))
+		if err != nil {
+			return err
+		}
+		row[i] = sqltypes.MakeTrusted(v.Type(), b)
+	}
+	return nil
+}
+
 func (rc *RowCache) encodeRow(row []sqltypes.Value) (b []byte) {
 	length := 0
 	for _, v := range row {
@@ -0,0 +1,127 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tabletserver
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+)
+
+// rowcacheEncryption encrypts the values of the sensitive columns of
+// the rows in the rowcache with AES-256-GCM. The other columns, and
+// the keys of the rows, are not encrypted.
+type rowcacheEncryption struct {
+	aead cipher.AEAD
+	// columns has the lowercase names of the encrypted columns of
+	// each table.
+	columns map[string]map[string]bool
+}
+
+// loadRowcacheEncryption reads the JSON map of table names to
+// encrypted column names in columnsFile, and the AES-256 key in
+// keyFile, either 32 raw bytes or 64 hex digits. It returns nil if
+// columnsFile is empty.
+func loadRowcacheEncryption(columnsFile, keyFile string) (*rowcacheEncryption, error) {
+	if columnsFile == "" {
+		return nil, nil
+	}
+	if keyFile == "" {
+		return nil, errors.New("an encrypted columns file needs a cache encryption key file")
+	}
+	data, err := ioutil.ReadFile(columnsFile)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the encrypted columns file: %v", err)
+	}
+	var tables map[string][]string
+	if err := json.Unmarshal(data, &tables); err != nil {
+		return nil, fmt.Errorf("cannot parse the encrypted columns file %s: %v", columnsFile, err)
+	}
+	key, err := ioutil.ReadFile(keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the cache encryption key file: %v", err)
+	}
+	return newRowcacheEncryption(tables, key)
+}
+
+func newRowcacheEncryption(tables map[string][]string, key []byte) (*rowcacheEncryption, error) {
+	if len(key) != 32 {
+		decoded, err := hex.DecodeString(strings.TrimSpace(string(key)))
+		if err != nil || len(decoded) != 32 {
+			return nil, errors.New("the cache encryption key must be 32 raw bytes or 64 hex digits")
+		}
+		key = decoded
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	re := &rowcacheEncryption{
+		aead:    aead,
+		columns: make(map[string]map[string]bool, len(tables)),
+	}
+	for table, columns := range tables {
+		names := make(map[string]bool, len(columns))
+		for _, column := range columns {
+			names[strings.ToLower(column)] = true
+		}
+		re.columns[table] = names
+	}
+	return re, nil
+}
+
+// encryptedColumns returns which columns of tableInfo are encrypted,
+// or nil if none is.
+func (re *rowcacheEncryption) encryptedColumns(tableInfo *TableInfo) []bool {
+	if re == nil {
+		return nil
+	}
+	names := re.columns[tableInfo.Name]
+	if len(names) == 0 {
+		return nil
+	}
+	encrypted := make([]bool, len(tableInfo.Columns))
+	for i, column := range tableInfo.Columns {
+		encrypted[i] = names[strings.ToLower(column.Name)]
+	}
+	return encrypted
+}
+
+// additionalData binds a ciphertext to its table, column and row, so
+// it can't be copied to another one.
+func (re *rowcacheEncryption) additionalData(tableName, columnName, key string) []byte {
+	return []byte(tableName + "\x00" + columnName + "\x00" + key)
+}
+
+// seal encrypts value, and returns the nonce followed by the
+// ciphertext.
+func (re *rowcacheEncryption) seal(value, additionalData []byte) ([]byte, error) {
+	nonceSize := re.aead.NonceSize()
+	b := make([]byte, nonceSize, nonceSize+len(value)+re.aead.Overhead())
+	if _, err := io.ReadFull(rand.Reader, b); err != nil {
+		return nil, err
+	}
+	return re.aead.Seal(b, b, value, additionalData), nil
+}
+
+// open decrypts a value encrypted by seal.
+func (re *rowcacheEncryption) open(b, additionalData []byte) ([]byte, error) {
+	nonceSize := re.aead.NonceSize()
+	if len(b) < nonceSize {
+		return nil, errors.New("encrypted value too short")
+	}
+	return re.aead.Open(nil, b[:nonceSize], b[nonceSize:], additionalData)
+}
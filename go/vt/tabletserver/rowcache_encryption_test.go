@@ -0,0 +1,143 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tabletserver
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/sqltypes"
+	"github.com/youtube/vitess/go/vt/schema"
+	"github.com/youtube/vitess/go/vt/tabletserver/fakecacheservice"
+)
+
+const testRowcacheEncryptionKey = "000102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f"
+
+func TestLoadRowcacheEncryption(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rowcache_encryption")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	write := func(name, content string) string {
+		file := path.Join(dir, name)
+		if err := ioutil.WriteFile(file, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		return file
+	}
+	columnsFile := write("columns.json", `{"user": ["SSN", "email"]}`)
+	hexKeyFile := write("hex.key", testRowcacheEncryptionKey+"\n")
+	rawKeyFile := write("raw.key", strings.Repeat("k", 32))
+
+	if re, err := loadRowcacheEncryption("", ""); re != nil || err != nil {
+		t.Errorf("loadRowcacheEncryption without file: %v, %v, want nil, nil", re, err)
+	}
+	for _, keyFile := range []string{hexKeyFile, rawKeyFile} {
+		re, err := loadRowcacheEncryption(columnsFile, keyFile)
+		if err != nil {
+			t.Fatalf("loadRowcacheEncryption(%s): %v", keyFile, err)
+		}
+		if want := map[string]map[string]bool{"user": {"ssn": true, "email": true}}; !reflect.DeepEqual(re.columns, want) {
+			t.Errorf("columns: %v, want %v", re.columns, want)
+		}
+	}
+
+	testcases := []struct {
+		columnsFile, keyFile, err string
+	}{
+		{columnsFile, "", "needs a cache encryption key file"},
+		{columnsFile, write("short.key", "0011"), "must be 32 raw bytes or 64 hex digits"},
+		{write("bad.json", `["user"]`), hexKeyFile, "cannot parse the encrypted columns file"},
+		{path.Join(dir, "missing.json"), hexKeyFile, "cannot read the encrypted columns file"},
+	}
+	for _, tcase := range testcases {
+		_, err := loadRowcacheEncryption(tcase.columnsFile, tcase.keyFile)
+		if err == nil || !strings.Contains(err.Error(), tcase.err) {
+			t.Errorf("loadRowcacheEncryption(%s, %s): %v, want %s", tcase.columnsFile, tcase.keyFile, err, tcase.err)
+		}
+	}
+}
+
+func TestRowCacheEncryption(t *testing.T) {
+	cache := fakecacheservice.Register()
+	encryption, err := newRowcacheEncryption(map[string][]string{"user": {"ssn"}}, []byte(testRowcacheEncryptionKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cachePool := newTestSchemaInfoCachePool(false, nil)
+	cachePool.encryption = encryption
+	cachePool.Open()
+	defer cachePool.Close()
+
+	tableInfo := &TableInfo{Table: schema.NewTable("user")}
+	tableInfo.AddColumn("id", sqltypes.Int64, sqltypes.Value{}, "")
+	tableInfo.AddColumn("ssn", sqltypes.VarChar, sqltypes.Value{}, "")
+	tableInfo.AddColumn("note", sqltypes.VarChar, sqltypes.Value{}, "")
+	tableInfo.Cache = NewRowCache(tableInfo, cachePool)
+	if want := []bool{false, true, false}; !reflect.DeepEqual(tableInfo.Cache.encrypted, want) {
+		t.Fatalf("encrypted: %v, want %v", tableInfo.Cache.encrypted, want)
+	}
+
+	ctx := context.Background()
+	row := []sqltypes.Value{
+		sqltypes.MakeTrusted(sqltypes.Int64, []byte("1")),
+		sqltypes.MakeTrusted(sqltypes.VarChar, []byte("123-45-6789")),
+		sqltypes.MakeTrusted(sqltypes.VarChar, []byte("plain")),
+	}
+	tableInfo.Cache.Set(ctx, tableInfo.Cache.prefix.Get(), "1", row, 0)
+	mkey := tableInfo.Cache.prefix.Get() + "1"
+	stored, ok := cache.Get(mkey)
+	if !ok {
+		t.Fatalf("cache.Get(%s): not found", mkey)
+	}
+	if bytes.Contains(stored.Value, []byte("123-45-6789")) {
+		t.Errorf("the rowcache has the plain ssn: %q", stored.Value)
+	}
+	if !bytes.Contains(stored.Value, []byte("plain")) {
+		t.Errorf("the rowcache doesn't have the plain note: %q", stored.Value)
+	}
+	results, _ := tableInfo.Cache.Get(ctx, []string{"1"})
+	if got := results["1"].Row; !reflect.DeepEqual(got, row) {
+		t.Errorf("Get: %v, want %v", got, row)
+	}
+
+	// A value copied from another row doesn't decrypt, and is a miss
+	// that can be replaced.
+	stored.Key = tableInfo.Cache.prefix.Get() + "2"
+	cache.Set(stored.Key, stored)
+	results, _ = tableInfo.Cache.Get(ctx, []string{"2"})
+	if result, ok := results["2"]; !ok || result.Row != nil || result.Cas == 0 {
+		t.Errorf("Get of a copied row: %+v, %v, want no row and a cas", result, ok)
+	}
+}
+
+func TestRowCacheEncryptionNull(t *testing.T) {
+	encryption, err := newRowcacheEncryption(map[string][]string{"user": {"ssn"}}, []byte(testRowcacheEncryptionKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+	tableInfo := &TableInfo{Table: schema.NewTable("user")}
+	tableInfo.AddColumn("ssn", sqltypes.VarChar, sqltypes.Value{}, "")
+	tableInfo.Cache = NewRowCache(tableInfo, &CachePool{encryption: encryption})
+	row := []sqltypes.Value{{}}
+	sealed, err := tableInfo.Cache.encryptRow("1", row)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sealed[0].IsNull() {
+		t.Errorf("encryptRow of NULL: %v, want NULL", sealed[0])
+	}
+	if err := tableInfo.Cache.decryptRow("1", sealed); err != nil || !sealed[0].IsNull() {
+		t.Errorf("decryptRow of NULL: %v, %v, want NULL", sealed[0], err)
+	}
+}
@@ -38,6 +38,44 @@ type RowcacheInvalidator struct {
 	posMutex   sync.Mutex
 	pos        replication.Position
 	lagSeconds sync2.AtomicInt64
+
+	// The invalidations of a DML event are sent to the rowcache in
+	// batches of batchSize keys, at up to throttle.rate keys per second.
+	// Once a transaction has invalidated more than flushThreshold keys
+	// of a table, the whole table is flushed instead.
+	batchSize      int
+	flushThreshold int
+	throttle       invalidationThrottle
+	// txInvalidations counts the keys invalidated by the current
+	// transaction for each table. -1 means the table was flushed.
+	txInvalidations map[string]int
+	queueDepth      sync2.AtomicInt64
+	flushes         sync2.AtomicInt64
+}
+
+// invalidationThrottle paces the invalidations to a maximum rate.
+// It's only used by the invalidation loop, so it's not thread safe.
+type invalidationThrottle struct {
+	// rate is the maximum number of invalidations per second.
+	// 0 means no limit.
+	rate  int
+	next  time.Time
+	sleep func(time.Duration)
+}
+
+// wait blocks until n more invalidations are allowed.
+func (it *invalidationThrottle) wait(n int) {
+	if it.rate <= 0 {
+		return
+	}
+	now := time.Now()
+	if it.next.Before(now) {
+		it.next = now
+	}
+	if d := it.next.Sub(now); d > 0 {
+		it.sleep(d)
+	}
+	it.next = it.next.Add(time.Duration(n) * time.Second / time.Duration(it.rate))
 }
 
 // AppendGTID updates the current replication position by appending a GTID to
@@ -71,11 +109,20 @@ func (rci *RowcacheInvalidator) PositionString() string {
 // Just like QueryEngine, this is a singleton class.
 // You must call this only once.
 func NewRowcacheInvalidator(statsPrefix string, checker MySQLChecker, qe *QueryEngine, enablePublishStats bool) *RowcacheInvalidator {
-	rci := &RowcacheInvalidator{checker: checker, qe: qe}
+	rci := &RowcacheInvalidator{
+		checker:         checker,
+		qe:              qe,
+		batchSize:       *rowcacheInvalidationBatchSize,
+		flushThreshold:  *rowcacheInvalidationFlushThreshold,
+		throttle:        invalidationThrottle{rate: *rowcacheInvalidationRate, sleep: time.Sleep},
+		txInvalidations: make(map[string]int),
+	}
 	if enablePublishStats {
 		stats.Publish(statsPrefix+"RowcacheInvalidatorState", stats.StringFunc(rci.svm.StateName))
 		stats.Publish(statsPrefix+"RowcacheInvalidatorPosition", stats.StringFunc(rci.PositionString))
 		stats.Publish(statsPrefix+"RowcacheInvalidatorLagSeconds", stats.IntFunc(rci.lagSeconds.Get))
+		stats.Publish(statsPrefix+"RowcacheInvalidatorQueueDepth", stats.IntFunc(rci.queueDepth.Get))
+		stats.Publish(statsPrefix+"RowcacheInvalidatorFlushes", stats.IntFunc(rci.flushes.Get))
 	}
 	return rci
 }
@@ -173,6 +220,8 @@ func (rci *RowcacheInvalidator) processEvent(event *binlogdatapb.StreamEvent) er
 			return err
 		}
 		rci.AppendGTID(gtid)
+		// The transaction is complete.
+		rci.txInvalidations = make(map[string]int)
 	default:
 		log.Errorf("unknown event: %#v", event)
 		rci.qe.queryServiceStats.InternalErrors.Add("Invalidation", 1)
@@ -183,7 +232,6 @@ func (rci *RowcacheInvalidator) processEvent(event *binlogdatapb.StreamEvent) er
 }
 
 func (rci *RowcacheInvalidator) handleDMLEvent(event *binlogdatapb.StreamEvent) {
-	invalidations := int64(0)
 	tableInfo := rci.qe.schemaInfo.GetTable(event.TableName)
 	if tableInfo == nil {
 		panic(NewTabletError(vtrpcpb.ErrorCode_BAD_INPUT, "Table %s not found", event.TableName))
@@ -192,13 +240,53 @@ func (rci *RowcacheInvalidator) handleDMLEvent(event *binlogdatapb.StreamEvent)
 		return
 	}
 
+	done := rci.txInvalidations[event.TableName]
+	if done < 0 {
+		// The table was already flushed by this transaction. The rows
+		// were committed before the flush, so the new rows of the
+		// cache are up to date.
+		return
+	}
+	if rci.flushThreshold > 0 && done+len(event.PrimaryKeyValues) > rci.flushThreshold {
+		log.Infof("Flushing the rowcache of table %s: the transaction invalidates more than %d rows", event.TableName, rci.flushThreshold)
+		tableInfo.Cache.Flush()
+		rci.txInvalidations[event.TableName] = -1
+		rci.flushes.Add(1)
+		return
+	}
+	rci.txInvalidations[event.TableName] = done + len(event.PrimaryKeyValues)
+
+	keys := make([]string, 0, len(event.PrimaryKeyValues))
 	for _, pkTuple := range event.PrimaryKeyValues {
 		// We can trust values coming from EventStreamer.
 		row := sqltypes.MakeRowTrusted(event.PrimaryKeyFields, pkTuple)
-		tableInfo.Cache.Delete(context.Background(), buildKey(row))
-		invalidations++
+		keys = append(keys, buildKey(row))
+	}
+	rci.queueDepth.Set(int64(len(keys)))
+	defer rci.queueDepth.Set(0)
+	batchSize := rci.batchSize
+	if batchSize <= 0 {
+		batchSize = 1
+	}
+	for len(keys) > 0 {
+		n := batchSize
+		if n > len(keys) {
+			n = len(keys)
+		}
+		rci.throttle.wait(n)
+		// Like for the commits of vttablet, the version tokens of the
+		// rows must not validate until they're deleted.
+		for _, key := range keys[:n] {
+			rci.qe.rowVersions.beginInvalidation(event.TableName, key)
+		}
+		tableInfo.Cache.DeleteMulti(context.Background(), keys[:n])
+		for _, key := range keys[:n] {
+			rci.qe.rowVersions.endInvalidation(event.TableName, key)
+		}
+		tableInfo.invalidations.Add(int64(n))
+		rci.queueDepth.Add(int64(-n))
+		keys = keys[n:]
 	}
-	tableInfo.invalidations.Add(invalidations)
 }
 
 func (rci *RowcacheInvalidator) handleDDLEvent(ddl string) {
@@ -0,0 +1,119 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tabletserver
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/youtube/vitess/go/sqldb"
+	"github.com/youtube/vitess/go/sqltypes"
+	"github.com/youtube/vitess/go/vt/tabletserver/fakecacheservice"
+	"github.com/youtube/vitess/go/vt/vttest/fakesqldb"
+
+	binlogdatapb "github.com/youtube/vitess/go/vt/proto/binlogdata"
+	querypb "github.com/youtube/vitess/go/vt/proto/query"
+)
+
+// newTestDMLEvent returns a DML event for the given pks of test_table_01.
+func newTestDMLEvent(pks ...int) *binlogdatapb.StreamEvent {
+	event := &binlogdatapb.StreamEvent{
+		Category:         binlogdatapb.StreamEvent_SE_DML,
+		TableName:        "test_table_01",
+		PrimaryKeyFields: []*querypb.Field{{Name: "pk", Type: sqltypes.Int32}},
+	}
+	for _, pk := range pks {
+		v := strconv.Itoa(pk)
+		event.PrimaryKeyValues = append(event.PrimaryKeyValues, &querypb.Row{
+			Lengths: []int64{int64(len(v))},
+			Values:  []byte(v),
+		})
+	}
+	return event
+}
+
+func TestRowcacheInvalidatorDML(t *testing.T) {
+	cache := fakecacheservice.Register()
+	db := fakesqldb.Register()
+	for query, result := range getSchemaInfoTestSupportedQueries() {
+		db.AddQuery(query, result)
+	}
+	schemaInfo := newTestSchemaInfo(10, 10*time.Second, 10*time.Second, false)
+	appParams := sqldb.ConnParams{Engine: db.Name}
+	dbaParams := sqldb.ConnParams{Engine: db.Name}
+	schemaInfo.cachePool.Open()
+	defer schemaInfo.cachePool.Close()
+	schemaInfo.Open(&appParams, &dbaParams, getSchemaInfoTestSchemaOverride(), false)
+	defer schemaInfo.Close()
+	tableInfo := schemaInfo.GetTable("test_table_01")
+
+	rci := NewRowcacheInvalidator("", nil, &QueryEngine{schemaInfo: schemaInfo, rowVersions: newRowVersions()}, false)
+	rci.batchSize = 2
+	rci.flushThreshold = 5
+	var sleeps []time.Duration
+	rci.throttle = invalidationThrottle{
+		rate:  1000,
+		sleep: func(d time.Duration) { sleeps = append(sleeps, d) },
+	}
+
+	// The invalidations are sent in batches, and the second batch
+	// waits for the first one.
+	event := newTestDMLEvent(1, 2, 3)
+	rci.handleDMLEvent(event)
+	prefix := tableInfo.Cache.prefix.Get()
+	for _, pkTuple := range event.PrimaryKeyValues {
+		key := prefix + buildKey(sqltypes.MakeRowTrusted(event.PrimaryKeyFields, pkTuple))
+		if result, ok := cache.Get(key); !ok || result.Flags != rcDeleted {
+			t.Errorf("cache.Get(%s): %+v, %v, want the deleted flag", key, result, ok)
+		}
+	}
+	if got := tableInfo.invalidations.Get(); got != 3 {
+		t.Errorf("invalidations: %d, want 3", got)
+	}
+	if len(sleeps) != 1 || sleeps[0] <= 0 || sleeps[0] > 2*time.Millisecond {
+		t.Errorf("throttle sleeps: %v, want one sleep of up to 2ms", sleeps)
+	}
+	if got := rci.queueDepth.Get(); got != 0 {
+		t.Errorf("queueDepth: %d, want 0", got)
+	}
+
+	// The transaction goes over the threshold: the table is flushed.
+	rci.handleDMLEvent(newTestDMLEvent(4, 5, 6))
+	if tableInfo.Cache.prefix.Get() == prefix {
+		t.Errorf("the rowcache prefix is still %s after the flush", prefix)
+	}
+	if got := rci.flushes.Get(); got != 1 {
+		t.Errorf("flushes: %d, want 1", got)
+	}
+	if got := tableInfo.invalidations.Get(); got != 3 {
+		t.Errorf("invalidations after the flush: %d, want 3", got)
+	}
+
+	// The following events of the transaction are ignored.
+	prefix = tableInfo.Cache.prefix.Get()
+	rci.handleDMLEvent(newTestDMLEvent(7))
+	if tableInfo.Cache.prefix.Get() != prefix || rci.flushes.Get() != 1 || tableInfo.invalidations.Get() != 3 {
+		t.Errorf("the event after the flush was not ignored")
+	}
+}
+
+func TestInvalidationThrottle(t *testing.T) {
+	var sleeps []time.Duration
+	it := invalidationThrottle{sleep: func(d time.Duration) { sleeps = append(sleeps, d) }}
+	// No limit.
+	it.wait(1000)
+	it.wait(1000)
+	if len(sleeps) != 0 {
+		t.Errorf("sleeps without rate: %v, want none", sleeps)
+	}
+
+	it.rate = 10
+	it.wait(5)
+	it.wait(5)
+	if len(sleeps) != 1 || sleeps[0] <= 400*time.Millisecond || sleeps[0] > 500*time.Millisecond {
+		t.Errorf("sleeps: %v, want one sleep of about 500ms", sleeps)
+	}
+}
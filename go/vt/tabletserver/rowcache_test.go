@@ -0,0 +1,78 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tabletserver
+
+import (
+	"reflect"
+	"strconv"
+	"testing"
+
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/sqltypes"
+	"github.com/youtube/vitess/go/vt/schema"
+	"github.com/youtube/vitess/go/vt/tabletserver/fakecacheservice"
+)
+
+// testJSONDocuments are MySQL JSON values, in the textual
+// representation MySQL returns.
+var testJSONDocuments = []string{
+	`{"a": {"b": [1, 2.5, {"c": null}], "d": true}}`,
+	`["é", "日本語", "😀"]`,
+	`{"nul": "a\u0000b", "empty": ""}`,
+	`null`,
+}
+
+func TestRowCacheJSON(t *testing.T) {
+	fakecacheservice.Register()
+	cachePool := newTestSchemaInfoCachePool(false, nil)
+	cachePool.Open()
+	defer cachePool.Close()
+
+	tableInfo := &TableInfo{Table: schema.NewTable("docs")}
+	tableInfo.AddColumn("id", sqltypes.Int64, sqltypes.Value{}, "")
+	tableInfo.AddColumn("doc", sqltypes.TypeJSON, sqltypes.Value{}, "")
+	tableInfo.Cache = NewRowCache(tableInfo, cachePool)
+
+	ctx := context.Background()
+	for i, doc := range testJSONDocuments {
+		key := strconv.Itoa(i)
+		row := []sqltypes.Value{
+			sqltypes.MakeTrusted(sqltypes.Int64, []byte(key)),
+			sqltypes.MakeTrusted(sqltypes.TypeJSON, []byte(doc)),
+		}
+		_, prefix := tableInfo.Cache.Get(ctx, []string{key})
+		tableInfo.Cache.Set(ctx, prefix, key, row, 0)
+		results, _ := tableInfo.Cache.Get(ctx, []string{key})
+		if got := results[key].Row; !reflect.DeepEqual(got, row) {
+			t.Errorf("Get of %q: %v, want %v", doc, got, row)
+		}
+	}
+}
+
+func TestRowCacheSetAfterFlush(t *testing.T) {
+	fakecacheservice.Register()
+	cachePool := newTestSchemaInfoCachePool(false, nil)
+	cachePool.Open()
+	defer cachePool.Close()
+
+	tableInfo := &TableInfo{Table: schema.NewTable("t")}
+	tableInfo.AddColumn("id", sqltypes.Int64, sqltypes.Value{}, "")
+	tableInfo.Cache = NewRowCache(tableInfo, cachePool)
+
+	// The row is read from the db before the Flush, and cached after it.
+	ctx := context.Background()
+	row := []sqltypes.Value{sqltypes.MakeTrusted(sqltypes.Int64, []byte("1"))}
+	_, prefix := tableInfo.Cache.Get(ctx, []string{"1"})
+	tableInfo.Cache.Flush()
+	tableInfo.Cache.Set(ctx, prefix, "1", row, 0)
+	results, newPrefix := tableInfo.Cache.Get(ctx, []string{"1"})
+	if newPrefix == prefix {
+		t.Errorf("Get after a Flush: prefix %q, want a new one", newPrefix)
+	}
+	if got := results["1"].Row; got != nil {
+		t.Errorf("Get of a row read before the Flush: %v, want nil", got)
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tabletserver
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	log "github.com/golang/glog"
+	"github.com/youtube/vitess/go/stats"
+	"github.com/youtube/vitess/go/sync2"
+	"github.com/youtube/vitess/go/tb"
+	"github.com/youtube/vitess/go/vt/binlog"
+	"github.com/youtube/vitess/go/vt/mysqlctl"
+	"github.com/youtube/vitess/go/vt/mysqlctl/replication"
+	"github.com/youtube/vitess/go/vt/tabletserver/planbuilder"
+	"golang.org/x/net/context"
+
+	binlogdatapb "github.com/youtube/vitess/go/vt/proto/binlogdata"
+)
+
+// SchemaChangeWatcher runs the service that updates the schema
+// when it sees a DDL in the binlog events. It only reloads the tables
+// touched by the DDL, so that schema changes are picked up without
+// waiting for the next periodic reload.
+type SchemaChangeWatcher struct {
+	qe      *QueryEngine
+	checker MySQLChecker
+	dbname  string
+	mysqld  mysqlctl.MysqlDaemon
+
+	svm sync2.ServiceManager
+
+	posMutex sync.Mutex
+	pos      replication.Position
+
+	// reloads counts the tables reloaded or dropped after a DDL.
+	reloads sync2.AtomicInt64
+}
+
+// NewSchemaChangeWatcher creates a new SchemaChangeWatcher.
+// Just like QueryEngine, this is a singleton class.
+// You must call this only once.
+func NewSchemaChangeWatcher(statsPrefix string, checker MySQLChecker, qe *QueryEngine, enablePublishStats bool) *SchemaChangeWatcher {
+	scw := &SchemaChangeWatcher{checker: checker, qe: qe}
+	if enablePublishStats {
+		stats.Publish(statsPrefix+"SchemaChangeWatcherState", stats.StringFunc(scw.svm.StateName))
+		stats.Publish(statsPrefix+"SchemaChangeWatcherPosition", stats.StringFunc(scw.PositionString))
+		stats.Publish(statsPrefix+"SchemaChangeWatcherReloads", stats.IntFunc(scw.reloads.Get))
+	}
+	return scw
+}
+
+// appendGTID updates the current replication position by appending a
+// GTID to the set of transactions that have been processed.
+func (scw *SchemaChangeWatcher) appendGTID(gtid replication.GTID) {
+	scw.posMutex.Lock()
+	defer scw.posMutex.Unlock()
+	scw.pos = replication.AppendGTID(scw.pos, gtid)
+}
+
+// Position returns the current ReplicationPosition.
+func (scw *SchemaChangeWatcher) Position() replication.Position {
+	scw.posMutex.Lock()
+	defer scw.posMutex.Unlock()
+	return scw.pos
+}
+
+// PositionString returns the current ReplicationPosition as a string.
+func (scw *SchemaChangeWatcher) PositionString() string {
+	return scw.Position().String()
+}
+
+// Open starts watching the binlog events. Unlike the rowcache
+// invalidator, the watcher is optional: if it cannot start, the error
+// is logged and the schema is only updated by the periodic reloads.
+func (scw *SchemaChangeWatcher) Open(dbname string, mysqld mysqlctl.MysqlDaemon) {
+	// Perform an early check to see if we're already running.
+	if scw.svm.State() == sync2.SERVICE_RUNNING {
+		return
+	}
+	if mysqld.Cnf().BinLogPath == "" {
+		log.Errorf("Schema change watcher not starting: binlog path not specified")
+		return
+	}
+	rp, err := mysqld.MasterPosition()
+	if err != nil {
+		log.Errorf("Schema change watcher not starting: cannot determine replication position: %v", err)
+		return
+	}
+
+	scw.dbname = dbname
+	scw.mysqld = mysqld
+	scw.posMutex.Lock()
+	scw.pos = rp
+	scw.posMutex.Unlock()
+
+	if scw.svm.Go(scw.run) {
+		log.Infof("Schema change watcher starting, dbname: %s, position: %v", dbname, rp)
+	} else {
+		log.Infof("Schema change watcher already running")
+	}
+}
+
+// Close terminates the watch loop. It returns only once the loop has
+// terminated.
+func (scw *SchemaChangeWatcher) Close() {
+	scw.svm.Stop()
+}
+
+func (scw *SchemaChangeWatcher) run(ctx *sync2.ServiceContext) error {
+	for {
+		evs := binlog.NewEventStreamer(scw.dbname, scw.mysqld, scw.Position(), scw.processEvent)
+		// As in the rowcache invalidator, the panics are caught,
+		// and the stream is restarted a second after an error.
+		err := func() (inner error) {
+			defer func() {
+				if x := recover(); x != nil {
+					inner = fmt.Errorf("%v: uncaught panic:\n%s", x, tb.Stack(4))
+				}
+			}()
+			return evs.Stream(ctx)
+		}()
+		if err == nil || !ctx.IsRunning() {
+			break
+		}
+		if IsConnErr(err) {
+			scw.checker.CheckMySQL()
+		}
+		log.Errorf("Schema change watcher stream returned err '%v', retrying in 1 second.", err)
+		scw.qe.queryServiceStats.InternalErrors.Add("Schema", 1)
+		time.Sleep(1 * time.Second)
+	}
+	log.Infof("Schema change watcher stopped")
+	return nil
+}
+
+func (scw *SchemaChangeWatcher) processEvent(event *binlogdatapb.StreamEvent) error {
+	switch event.Category {
+	case binlogdatapb.StreamEvent_SE_DDL:
+		scw.handleDDL(event.Sql)
+	case binlogdatapb.StreamEvent_SE_POS:
+		gtid, err := replication.DecodeGTID(event.TransactionId)
+		if err != nil {
+			return err
+		}
+		scw.appendGTID(gtid)
+	}
+	return nil
+}
+
+// handleDDL updates the tables changed by ddl. New tables are
+// loaded, and the other ones are only updated if they're already in
+// the schema.
+func (scw *SchemaChangeWatcher) handleDDL(ddl string) {
+	ddlPlan := planbuilder.DDLParse(ddl)
+	if ddlPlan.Action == "" {
+		log.Warningf("Schema change watcher: DDL is not understood, waiting for the next schema reload: %s", ddl)
+		return
+	}
+	si := scw.qe.schemaInfo
+	if ddlPlan.TableName != "" && ddlPlan.TableName != ddlPlan.NewName {
+		// It's a drop or rename.
+		if si.GetTable(ddlPlan.TableName) != nil {
+			log.Infof("Schema change watcher: dropping table %s: %s", ddlPlan.TableName, ddl)
+			si.DropTable(ddlPlan.TableName)
+			scw.reloads.Add(1)
+		}
+	}
+	if ddlPlan.NewName != "" {
+		// It's a create or rename, or an alter of a tracked table.
+		if ddlPlan.TableName != ddlPlan.NewName || si.GetTable(ddlPlan.NewName) != nil {
+			log.Infof("Schema change watcher: reloading table %s: %s", ddlPlan.NewName, ddl)
+			si.CreateOrUpdateTable(context.Background(), ddlPlan.NewName)
+			scw.reloads.Add(1)
+		}
+	}
+}
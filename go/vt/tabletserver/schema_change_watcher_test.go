@@ -0,0 +1,86 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tabletserver
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/youtube/vitess/go/sqldb"
+	"github.com/youtube/vitess/go/sqltypes"
+	"github.com/youtube/vitess/go/vt/vttest/fakesqldb"
+
+	binlogdatapb "github.com/youtube/vitess/go/vt/proto/binlogdata"
+	querypb "github.com/youtube/vitess/go/vt/proto/query"
+)
+
+func TestSchemaChangeWatcherDDL(t *testing.T) {
+	db := fakesqldb.Register()
+	for query, result := range getSchemaInfoTestSupportedQueries() {
+		db.AddQuery(query, result)
+	}
+	newTable := "test_table_04"
+	db.AddQuery(fmt.Sprintf("%s and table_name = '%s'", baseShowTables, newTable), &sqltypes.Result{
+		RowsAffected: 1,
+		Rows:         [][]sqltypes.Value{createTestTableBaseShowTable(newTable)},
+	})
+	db.AddQuery("select * from `test_table_04` where 1 != 1", &sqltypes.Result{
+		Fields: []*querypb.Field{{
+			Name: "pk",
+			Type: sqltypes.Int32,
+		}},
+	})
+	db.AddQuery("describe `test_table_04`", &sqltypes.Result{
+		RowsAffected: 1,
+		Rows:         [][]sqltypes.Value{createTestTableDescribe("pk")},
+	})
+	db.AddQuery("show index from `test_table_04`", &sqltypes.Result{
+		RowsAffected: 1,
+		Rows:         [][]sqltypes.Value{createTestTableShowIndex("pk")},
+	})
+	schemaInfo := newTestSchemaInfo(10, 10*time.Second, 10*time.Second, false)
+	appParams := sqldb.ConnParams{Engine: db.Name}
+	dbaParams := sqldb.ConnParams{Engine: db.Name}
+	schemaInfo.Open(&appParams, &dbaParams, nil, false)
+	defer schemaInfo.Close()
+	scw := NewSchemaChangeWatcher("", nil, &QueryEngine{schemaInfo: schemaInfo}, false)
+
+	testcases := []struct {
+		ddl     string
+		reloads int64
+	}{{
+		// Untracked tables are ignored.
+		ddl:     "alter table other_table add column c int",
+		reloads: 0,
+	}, {
+		ddl:     "not a ddl",
+		reloads: 0,
+	}, {
+		ddl:     "drop table test_table_02",
+		reloads: 1,
+	}, {
+		ddl:     "create table test_table_04 (pk int)",
+		reloads: 1,
+	}}
+	for _, tcase := range testcases {
+		before := scw.reloads.Get()
+		if err := scw.processEvent(&binlogdatapb.StreamEvent{Category: binlogdatapb.StreamEvent_SE_DDL, Sql: tcase.ddl}); err != nil {
+			t.Errorf("processEvent(%s): %v", tcase.ddl, err)
+		}
+		if got := scw.reloads.Get() - before; got != tcase.reloads {
+			t.Errorf("%s: reloads: %d, want %d", tcase.ddl, got, tcase.reloads)
+		}
+	}
+	if schemaInfo.GetTable("other_table") != nil {
+		t.Errorf("other_table was loaded, want it ignored")
+	}
+	if schemaInfo.GetTable("test_table_02") != nil {
+		t.Errorf("test_table_02 is still in the schema after the drop")
+	}
+	if schemaInfo.GetTable(newTable) == nil {
+		t.Errorf("%s is not in the schema after the create", newTable)
+	}
+}
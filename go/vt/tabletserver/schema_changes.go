@@ -0,0 +1,122 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tabletserver
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	log "github.com/golang/glog"
+)
+
+// maxPlanChanges is the number of TablePlanChange kept for the status
+// page.
+const maxPlanChanges = 20
+
+// TablePlanInfo is what the plans of a table depend on.
+type TablePlanInfo struct {
+	Cached     bool
+	PKColumns  []string
+	NumColumns int
+}
+
+func newTablePlanInfo(tableInfo *TableInfo) TablePlanInfo {
+	info := TablePlanInfo{
+		Cached:     tableInfo.IsCached(),
+		NumColumns: len(tableInfo.Columns),
+	}
+	for _, i := range tableInfo.PKColumns {
+		info.PKColumns = append(info.PKColumns, tableInfo.Columns[i].Name)
+	}
+	return info
+}
+
+// TablePlanChange is a change of the rowcache eligibility or the
+// primary key of a table found when its schema was loaded again.
+type TablePlanChange struct {
+	Time   time.Time
+	Table  string
+	Before TablePlanInfo
+	After  TablePlanInfo
+}
+
+// RowcacheChanged returns true if the table started or stopped using
+// the rowcache.
+func (c *TablePlanChange) RowcacheChanged() bool {
+	return c.Before.Cached != c.After.Cached
+}
+
+// PKChanged returns true if the primary key columns of the table
+// changed.
+func (c *TablePlanChange) PKChanged() bool {
+	return !reflect.DeepEqual(c.Before.PKColumns, c.After.PKColumns)
+}
+
+// String describes the change for the logs.
+func (c *TablePlanChange) String() string {
+	return fmt.Sprintf("table=%v rowcache=%v->%v pk=%v->%v columns=%v->%v", c.Table, c.Before.Cached, c.After.Cached, c.Before.PKColumns, c.After.PKColumns, c.Before.NumColumns, c.After.NumColumns)
+}
+
+// recordPlanChange logs and counts the change from before to the
+// current schema of tableName, if its rowcache eligibility or its
+// primary key changed. It must be called with a lock on mu held.
+func (si *SchemaInfo) recordPlanChange(tableName string, before TablePlanInfo, now time.Time) {
+	tableInfo, ok := si.tables[tableName]
+	if !ok {
+		return
+	}
+	change := &TablePlanChange{
+		Time:   now,
+		Table:  tableName,
+		Before: before,
+		After:  newTablePlanInfo(tableInfo),
+	}
+	if !change.RowcacheChanged() && !change.PKChanged() {
+		return
+	}
+	log.Warningf("Plan-changing schema change: %v", change)
+	if change.RowcacheChanged() {
+		si.planChangeCounts.Add("RowcacheEligibility", 1)
+	}
+	if change.PKChanged() {
+		si.planChangeCounts.Add("PrimaryKey", 1)
+	}
+	si.planChanges = append(si.planChanges, change)
+	if len(si.planChanges) > maxPlanChanges {
+		si.planChanges = si.planChanges[len(si.planChanges)-maxPlanChanges:]
+	}
+}
+
+// PlanChanges returns the last changes of the rowcache eligibility or
+// the primary key of the tables, the newest first.
+func (si *SchemaInfo) PlanChanges() []*TablePlanChange {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+	changes := make([]*TablePlanChange, 0, len(si.planChanges))
+	for i := len(si.planChanges) - 1; i >= 0; i-- {
+		changes = append(changes, si.planChanges[i])
+	}
+	return changes
+}
+
+// PlanChangeDegraded returns a description of the last plan-changing
+// schema change if it happened less than degradedDuration before now,
+// or "".
+func (si *SchemaInfo) PlanChangeDegraded(degradedDuration time.Duration, now time.Time) string {
+	if degradedDuration <= 0 {
+		return ""
+	}
+	si.mu.Lock()
+	defer si.mu.Unlock()
+	if len(si.planChanges) == 0 {
+		return ""
+	}
+	last := si.planChanges[len(si.planChanges)-1]
+	if now.Sub(last.Time) >= degradedDuration {
+		return ""
+	}
+	return fmt.Sprintf("plan-changing schema change %v ago: %v", now.Sub(last.Time), last)
+}
@@ -29,7 +29,7 @@ import (
 	"golang.org/x/net/context"
 )
 
-const baseShowTables = "SELECT table_name, table_type, unix_timestamp(create_time), table_comment, table_rows, data_length, index_length, data_free FROM information_schema.tables WHERE table_schema = database()"
+const baseShowTables = "SELECT table_name, table_type, unix_timestamp(create_time), table_comment, table_rows, data_length, index_length, data_free, row_format FROM information_schema.tables WHERE table_schema = database()"
 
 const maxTableCount = 10000
 
@@ -50,6 +50,10 @@ type ExecPlan struct {
 	Rules      *QueryRules
 	Authorized *tableacl.ACLResult
 
+	// bindVarColumns are the columns of the bind variables found by
+	// -schema_pre_validation. Their types are checked at execution.
+	bindVarColumns map[string]bindVarColumn
+
 	mu         sync.Mutex
 	QueryCount int64
 	Time       time.Duration
@@ -107,6 +111,15 @@ type SchemaInfo struct {
 	overrides  []SchemaOverride
 	lastChange int64
 	reloadTime time.Duration
+	dbName     string
+	// missingPrivileges is the result of the last checkGrants.
+	missingPrivileges []string
+	// keyRangeFilter restricts the plans to the key range of the
+	// shard, if not nil.
+	keyRangeFilter *planbuilder.KeyRangeFilter
+	// planChanges has the last changes of the rowcache eligibility
+	// or the primary key of the tables, the oldest first.
+	planChanges []*TablePlanChange
 
 	// The following vars are either read-only or have
 	// their own synchronization.
@@ -117,6 +130,9 @@ type SchemaInfo struct {
 	endpoints         map[string]string
 	queryRuleSources  *QueryRuleInfo
 	queryServiceStats *QueryServiceStats
+	// planChangeCounts counts the tables whose rowcache eligibility
+	// (RowcacheEligibility) or primary key (PrimaryKey) changed.
+	planChangeCounts *stats.Counters
 }
 
 // NewSchemaInfo creates a new SchemaInfo.
@@ -139,8 +155,16 @@ func NewSchemaInfo(
 		reloadTime:        reloadTime,
 		queryRuleSources:  NewQueryRuleInfo(),
 		queryServiceStats: queryServiceStats,
+		planChangeCounts:  stats.NewCounters(""),
 	}
 	if enablePublishStats {
+		stats.Publish(statsPrefix+"SchemaPlanChanges", si.planChangeCounts)
+		stats.Publish(statsPrefix+"SchemaPlanChangeDegraded", stats.IntFunc(func() int64 {
+			if si.PlanChangeDegraded(*schemaChangeDegradedDuration, time.Now()) != "" {
+				return 1
+			}
+			return 0
+		}))
 		stats.Publish(statsPrefix+"QueryCacheLength", stats.IntFunc(si.queries.Length))
 		stats.Publish(statsPrefix+"QueryCacheSize", stats.IntFunc(si.queries.Size))
 		stats.Publish(statsPrefix+"QueryCacheCapacity", stats.IntFunc(si.queries.Capacity))
@@ -148,6 +172,9 @@ func NewSchemaInfo(
 			return fmt.Sprintf("%v", si.queries.Oldest())
 		}))
 		stats.Publish(statsPrefix+"SchemaReloadTime", stats.DurationFunc(si.ticks.Interval))
+		stats.Publish(statsPrefix+"MissingPrivileges", stats.IntFunc(func() int64 {
+			return int64(len(si.MissingPrivileges()))
+		}))
 		_ = stats.NewMultiCountersFunc(statsPrefix+"RowcacheStats", []string{"Table", "Stats"}, si.getRowcacheStats)
 		_ = stats.NewMultiCountersFunc(statsPrefix+"RowcacheInvalidations", []string{"Table"}, si.getRowcacheInvalidations)
 		_ = stats.NewMultiCountersFunc(statsPrefix+"QueryCounts", []string{"Table", "Plan"}, si.getQueryCount)
@@ -210,6 +237,7 @@ func (si *SchemaInfo) Open(appParams, dbaParams *sqldb.ConnParams, schemaOverrid
 				return
 			}
 			tableInfo.SetMysqlStats(row[4], row[5], row[6], row[7])
+			tableInfo.RowFormat = row[8].String()
 			mu.Lock()
 			tables[tableName] = tableInfo
 			mu.Unlock()
@@ -230,7 +258,11 @@ func (si *SchemaInfo) Open(appParams, dbaParams *sqldb.ConnParams, schemaOverrid
 			si.override()
 		}
 		si.lastChange = curTime
+		si.dbName = appParams.DbName
 	}()
+	if err := si.checkGrants(ctx); err != nil && *checkGrantsFatal {
+		panic(NewTabletError(vtrpcpb.ErrorCode_INTERNAL_ERROR, "%v", err))
+	}
 	// Clear is not really needed. Doing it for good measure.
 	si.queries.Clear()
 	si.ticks.Start(si.Reload)
@@ -342,6 +374,7 @@ func (si *SchemaInfo) Reload() {
 		}
 		si.lastChange = curTime
 	}()
+	si.checkGrants(ctx)
 }
 
 func (si *SchemaInfo) mysqlTime(ctx context.Context) int64 {
@@ -372,6 +405,22 @@ func (si *SchemaInfo) ClearQueryPlanCache() {
 	si.queries.Clear()
 }
 
+// SetKeyRangeFilter changes the key range the plans are restricted to,
+// and clears the query plan cache. A nil filter removes the restriction.
+func (si *SchemaInfo) SetKeyRangeFilter(filter *planbuilder.KeyRangeFilter) {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+	si.keyRangeFilter = filter
+	si.queries.Clear()
+}
+
+// KeyRangeFilter returns the key range the plans are restricted to.
+func (si *SchemaInfo) KeyRangeFilter() *planbuilder.KeyRangeFilter {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+	return si.keyRangeFilter
+}
+
 // CreateOrUpdateTable must be called if a DDL was applied to that table.
 func (si *SchemaInfo) CreateOrUpdateTable(ctx context.Context, tableName string) {
 	conn := getOrPanic(ctx, si.connPool)
@@ -399,16 +448,19 @@ func (si *SchemaInfo) CreateOrUpdateTable(ctx context.Context, tableName string)
 	}
 	// table_rows, data_length, index_length
 	tableInfo.SetMysqlStats(row[4], row[5], row[6], row[7])
+	tableInfo.RowFormat = row[8].String()
 
 	// Need to acquire lock now.
 	si.mu.Lock()
 	defer si.mu.Unlock()
-	if _, ok := si.tables[tableName]; ok {
+	if oldInfo, ok := si.tables[tableName]; ok {
 		// If the table already exists, we overwrite it with the latest info.
 		// This also means that the query cache needs to be cleared.
 		// Otherwise, the query plans may not be in sync with the schema.
 		si.queries.Clear()
 		log.Infof("Updating table %s", tableName)
+		// The overrides are applied to the new info first.
+		defer si.recordPlanChange(tableName, newTablePlanInfo(oldInfo), time.Now())
 	}
 	si.tables[tableName] = tableInfo
 
@@ -416,9 +468,9 @@ func (si *SchemaInfo) CreateOrUpdateTable(ctx context.Context, tableName string)
 	case schema.CacheNone:
 		log.Infof("Initialized table: %s", tableName)
 	case schema.CacheRW:
-		log.Infof("Initialized cached table: %s, prefix: %s", tableName, tableInfo.Cache.prefix)
+		log.Infof("Initialized cached table: %s, prefix: %s", tableName, tableInfo.Cache.prefix.Get())
 	case schema.CacheW:
-		log.Infof("Initialized write-only cached table: %s, prefix: %s", tableName, tableInfo.Cache.prefix)
+		log.Infof("Initialized write-only cached table: %s, prefix: %s", tableName, tableInfo.Cache.prefix.Get())
 	case schema.Sequence:
 		log.Infof("Initialized sequence: %s", tableName)
 	}
@@ -432,6 +484,55 @@ func (si *SchemaInfo) CreateOrUpdateTable(ctx context.Context, tableName string)
 	}
 }
 
+// AddColumns must be called if columns were appended to a table by an
+// ALTER TABLE ... ALGORITHM=INSTANT. Unlike CreateOrUpdateTable, it
+// only fetches the new columns. It only supports tables without a
+// rowcache or a schema override. If it fails, the caller should fall
+// back to CreateOrUpdateTable.
+func (si *SchemaInfo) AddColumns(ctx context.Context, tableName string, columns []string) error {
+	tableInfo, err := si.instantDDLTable(tableName)
+	if err != nil {
+		return err
+	}
+
+	conn := getOrPanic(ctx, si.connPool)
+	defer conn.Recycle()
+	newTableInfo, err := tableInfo.withColumns(conn, columns)
+	if err != nil {
+		return err
+	}
+
+	si.mu.Lock()
+	defer si.mu.Unlock()
+	if si.tables[tableName] != tableInfo {
+		return fmt.Errorf("table %s was changed concurrently", tableName)
+	}
+	si.tables[tableName] = newTableInfo
+	si.queries.Clear()
+	log.Infof("Added columns %v to table %s", columns, tableName)
+	return nil
+}
+
+// instantDDLTable returns the table info of tableName if AddColumns
+// can update it.
+func (si *SchemaInfo) instantDDLTable(tableName string) (*TableInfo, error) {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+	tableInfo, ok := si.tables[tableName]
+	if !ok {
+		return nil, fmt.Errorf("table %s not found in schema", tableName)
+	}
+	if tableInfo.Type != schema.CacheNone {
+		return nil, fmt.Errorf("table %s has type %d", tableName, tableInfo.Type)
+	}
+	for _, o := range si.overrides {
+		if o.Name == tableName {
+			return nil, fmt.Errorf("table %s has a schema override", tableName)
+		}
+	}
+	return tableInfo, nil
+}
+
 // DropTable must be called if a table was dropped.
 func (si *SchemaInfo) DropTable(tableName string) {
 	si.mu.Lock()
@@ -466,6 +567,16 @@ func (si *SchemaInfo) GetPlan(ctx context.Context, logStats *LogStats, sql strin
 		return plan
 	}
 
+	var bindVarColumns map[string]bindVarColumn
+	if *schemaPreValidation {
+		// This must happen before the planbuilder and the field query,
+		// which would fail with their own errors.
+		var err error
+		bindVarColumns, err = validateSchema(sql, si.tables)
+		if err != nil {
+			panic(schemaValidationError(logStats, err))
+		}
+	}
 	var tableInfo *TableInfo
 	GetTable := func(tableName string) (table *schema.Table, ok bool) {
 		tableInfo, ok = si.tables[tableName]
@@ -474,11 +585,11 @@ func (si *SchemaInfo) GetPlan(ctx context.Context, logStats *LogStats, sql strin
 		}
 		return tableInfo.Table, true
 	}
-	splan, err := planbuilder.GetExecPlan(sql, GetTable)
+	splan, err := planbuilder.GetExecPlan(sql, GetTable, si.keyRangeFilter)
 	if err != nil {
 		panic(PrefixTabletError(vtrpcpb.ErrorCode_UNKNOWN_ERROR, err, ""))
 	}
-	plan := &ExecPlan{ExecPlan: splan, TableInfo: tableInfo}
+	plan := &ExecPlan{ExecPlan: splan, TableInfo: tableInfo, bindVarColumns: bindVarColumns}
 	plan.Rules = si.queryRuleSources.filterByPlan(sql, plan.PlanID, plan.TableName)
 	plan.Authorized = tableacl.Authorized(plan.TableName, plan.PlanID.MinRole())
 	if plan.PlanID.IsSelect() {
@@ -506,6 +617,17 @@ func (si *SchemaInfo) GetPlan(ctx context.Context, logStats *LogStats, sql strin
 // GetStreamPlan is similar to GetPlan, but doesn't use the cache
 // and doesn't enforce a limit. It just returns the parsed query.
 func (si *SchemaInfo) GetStreamPlan(sql string) *ExecPlan {
+	return si.getStreamPlan(sql, planbuilder.GetStreamExecPlan, vtrpcpb.ErrorCode_UNKNOWN_ERROR)
+}
+
+// GetResumableStreamPlan is like GetStreamPlan, for a stream that
+// can be resumed. It panics with a BAD_INPUT error if the query can't
+// be resumed.
+func (si *SchemaInfo) GetResumableStreamPlan(sql string) *ExecPlan {
+	return si.getStreamPlan(sql, planbuilder.GetResumableStreamExecPlan, vtrpcpb.ErrorCode_BAD_INPUT)
+}
+
+func (si *SchemaInfo) getStreamPlan(sql string, build func(string, planbuilder.TableGetter, *planbuilder.KeyRangeFilter) (*planbuilder.ExecPlan, error), errCode vtrpcpb.ErrorCode) *ExecPlan {
 	var tableInfo *TableInfo
 	GetTable := func(tableName string) (table *schema.Table, ok bool) {
 		si.mu.Lock()
@@ -516,9 +638,12 @@ func (si *SchemaInfo) GetStreamPlan(sql string) *ExecPlan {
 		}
 		return tableInfo.Table, true
 	}
-	splan, err := planbuilder.GetStreamExecPlan(sql, GetTable)
+	si.mu.Lock()
+	filter := si.keyRangeFilter
+	si.mu.Unlock()
+	splan, err := build(sql, GetTable, filter)
 	if err != nil {
-		panic(PrefixTabletError(vtrpcpb.ErrorCode_UNKNOWN_ERROR, err, ""))
+		panic(PrefixTabletError(errCode, err, ""))
 	}
 	plan := &ExecPlan{ExecPlan: splan, TableInfo: tableInfo}
 	plan.Rules = si.queryRuleSources.filterByPlan(sql, plan.PlanID, plan.TableName)
@@ -526,6 +651,13 @@ func (si *SchemaInfo) GetStreamPlan(sql string) *ExecPlan {
 	return plan
 }
 
+// validateSchema is validateSchema on the current schema.
+func (si *SchemaInfo) validateSchema(sql string) (map[string]bindVarColumn, error) {
+	si.mu.Lock()
+	defer si.mu.Unlock()
+	return validateSchema(sql, si.tables)
+}
+
 // GetTable returns the TableInfo for a table.
 func (si *SchemaInfo) GetTable(tableName string) *TableInfo {
 	si.mu.Lock()
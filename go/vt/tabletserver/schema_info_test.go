@@ -11,6 +11,8 @@ import (
 	"math/rand"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -20,9 +22,11 @@ import (
 	"github.com/youtube/vitess/go/sqltypes"
 	"github.com/youtube/vitess/go/vt/schema"
 	"github.com/youtube/vitess/go/vt/tabletserver/fakecacheservice"
+	"github.com/youtube/vitess/go/vt/tabletserver/planbuilder"
 	"github.com/youtube/vitess/go/vt/vttest/fakesqldb"
 
 	querypb "github.com/youtube/vitess/go/vt/proto/query"
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
 	vtrpcpb "github.com/youtube/vitess/go/vt/proto/vtrpc"
 )
 
@@ -333,6 +337,118 @@ func TestSchemaInfoCreateOrUpdateTable(t *testing.T) {
 	schemaInfo.Close()
 }
 
+func TestSchemaInfoPlanChanges(t *testing.T) {
+	db := fakesqldb.Register()
+	for query, result := range getSchemaInfoTestSupportedQueries() {
+		db.AddQuery(query, result)
+	}
+	tableName := "test_table_01"
+	db.AddQuery(fmt.Sprintf("%s and table_name = '%s'", baseShowTables, tableName), &sqltypes.Result{
+		RowsAffected: 1,
+		Rows: [][]sqltypes.Value{
+			createTestTableBaseShowTable(tableName),
+		},
+	})
+	schemaInfo := newTestSchemaInfo(10, 1*time.Second, 1*time.Second, false)
+	appParams := sqldb.ConnParams{Engine: db.Name}
+	dbaParams := sqldb.ConnParams{Engine: db.Name}
+	schemaInfo.Open(&appParams, &dbaParams, nil, false)
+	defer schemaInfo.Close()
+
+	// Reloading the same schema is not a change.
+	schemaInfo.CreateOrUpdateTable(context.Background(), tableName)
+	if got := schemaInfo.PlanChanges(); len(got) != 0 {
+		t.Errorf("PlanChanges: %v, want none", got)
+	}
+
+	// The primary key is dropped.
+	db.AddQuery("show index from `test_table_01`", &sqltypes.Result{})
+	count := schemaInfo.planChangeCounts.Counts()["PrimaryKey"]
+	schemaInfo.CreateOrUpdateTable(context.Background(), tableName)
+	changes := schemaInfo.PlanChanges()
+	if len(changes) != 1 {
+		t.Fatalf("PlanChanges: %v, want 1 change", changes)
+	}
+	want := "table=test_table_01 rowcache=false->false pk=[pk]->[] columns=1->1"
+	if got := changes[0].String(); got != want {
+		t.Errorf("change: %v, want %v", got, want)
+	}
+	if got := schemaInfo.planChangeCounts.Counts()["PrimaryKey"] - count; got != 1 {
+		t.Errorf("PrimaryKey changes: %v, want 1", got)
+	}
+
+	if got := schemaInfo.PlanChangeDegraded(0, time.Now()); got != "" {
+		t.Errorf("PlanChangeDegraded without a duration: %v, want none", got)
+	}
+	if got := schemaInfo.PlanChangeDegraded(time.Minute, time.Now()); !strings.Contains(got, want) {
+		t.Errorf("PlanChangeDegraded: %v, want the change", got)
+	}
+	if got := schemaInfo.PlanChangeDegraded(time.Minute, time.Now().Add(2*time.Minute)); got != "" {
+		t.Errorf("PlanChangeDegraded after the duration: %v, want none", got)
+	}
+}
+
+func TestSchemaInfoAddColumns(t *testing.T) {
+	db := fakesqldb.Register()
+	for query, result := range getSchemaInfoTestSupportedQueries() {
+		db.AddQuery(query, result)
+	}
+	schemaInfo := newTestSchemaInfo(10, 1*time.Second, 1*time.Second, false)
+	appParams := sqldb.ConnParams{Engine: db.Name}
+	dbaParams := sqldb.ConnParams{Engine: db.Name}
+	schemaInfo.Open(&appParams, &dbaParams, nil, false)
+	defer schemaInfo.Close()
+
+	db.AddQuery("select `new_col` from `test_table_02` where 1 != 1", &sqltypes.Result{
+		Fields: []*querypb.Field{{
+			Name: "new_col",
+			Type: sqltypes.VarChar,
+		}},
+	})
+	db.AddQuery("select column_name, column_default, extra, ordinal_position from information_schema.columns where table_schema = database() and table_name = 'test_table_02' and column_name in ('new_col') order by ordinal_position", &sqltypes.Result{
+		RowsAffected: 1,
+		Rows: [][]sqltypes.Value{
+			{
+				sqltypes.MakeString([]byte("new_col")),
+				sqltypes.MakeString([]byte("aa")),
+				sqltypes.MakeString([]byte{}),
+				sqltypes.MakeString([]byte("2")),
+			},
+		},
+	})
+	// Only the new columns are fetched.
+	db.AddRejectedQuery("describe `test_table_02`", errRejected)
+	oldTableInfo := schemaInfo.GetTable("test_table_02")
+	if err := schemaInfo.AddColumns(context.Background(), "test_table_02", []string{"new_col"}); err != nil {
+		t.Fatalf("AddColumns: %v", err)
+	}
+	tableInfo := schemaInfo.GetTable("test_table_02")
+	if tableInfo == oldTableInfo {
+		t.Errorf("AddColumns did not replace the table info")
+	}
+	if len(oldTableInfo.Columns) != 1 {
+		t.Errorf("old table columns: %v, want 1 column", oldTableInfo.Columns)
+	}
+	if len(tableInfo.Columns) != 2 {
+		t.Fatalf("table columns: %v, want 2 columns", tableInfo.Columns)
+	}
+	col := tableInfo.Columns[1]
+	if col.Name != "new_col" || col.Type != sqltypes.VarChar || col.Default.String() != "aa" {
+		t.Errorf("new column: %+v, want new_col varchar default aa", col)
+	}
+	if !reflect.DeepEqual(tableInfo.PKColumns, oldTableInfo.PKColumns) {
+		t.Errorf("PKColumns: %v, want %v", tableInfo.PKColumns, oldTableInfo.PKColumns)
+	}
+
+	// The column was not added at the end.
+	if err := schemaInfo.AddColumns(context.Background(), "test_table_02", []string{"new_col"}); err == nil {
+		t.Errorf("AddColumns with a column that is not the last one should fail")
+	}
+	if err := schemaInfo.AddColumns(context.Background(), "unknown_table", []string{"new_col"}); err == nil {
+		t.Errorf("AddColumns on unknown table should fail")
+	}
+}
+
 func TestSchemaInfoDropTable(t *testing.T) {
 	fakecacheservice.Register()
 	db := fakesqldb.Register()
@@ -453,6 +569,48 @@ func TestSchemaInfoQueryCache(t *testing.T) {
 	schemaInfo.ClearQueryPlanCache()
 }
 
+func TestSchemaInfoKeyRangeFilter(t *testing.T) {
+	fakecacheservice.Register()
+	db := fakesqldb.Register()
+	for query, result := range getSchemaInfoTestSupportedQueries() {
+		db.AddQuery(query, result)
+	}
+	schemaInfo := newTestSchemaInfo(10, 10*time.Second, 10*time.Second, false)
+	appParams := sqldb.ConnParams{Engine: db.Name}
+	dbaParams := sqldb.ConnParams{Engine: db.Name}
+	schemaInfo.cachePool.Open()
+	defer schemaInfo.cachePool.Close()
+	schemaInfo.Open(&appParams, &dbaParams, []SchemaOverride{}, true)
+	defer schemaInfo.Close()
+
+	ctx := context.Background()
+	logStats := newLogStats("GetPlanStats", ctx)
+	query := "delete from test_table_01"
+	if got, want := schemaInfo.GetPlan(ctx, logStats, query).FullQuery.Query, query; got != want {
+		t.Errorf("FullQuery without filter: %v, want %v", got, want)
+	}
+
+	// Setting the filter clears the cached plans.
+	schemaInfo.SetKeyRangeFilter(&planbuilder.KeyRangeFilter{
+		ShardingColumnName: "pk",
+		ShardingColumnType: topodatapb.KeyspaceIdType_UINT64,
+		KeyRange:           &topodatapb.KeyRange{End: []byte{0x80}},
+	})
+	want := "delete from test_table_01 where pk < 0x8000000000000000"
+	if got := schemaInfo.GetPlan(ctx, logStats, query).FullQuery.Query; got != want {
+		t.Errorf("FullQuery with filter: %v, want %v", got, want)
+	}
+	want = "select * from test_table_01 where pk < 0x8000000000000000"
+	if got := schemaInfo.GetStreamPlan("select * from test_table_01").FullQuery.Query; got != want {
+		t.Errorf("stream FullQuery with filter: %v, want %v", got, want)
+	}
+
+	schemaInfo.SetKeyRangeFilter(nil)
+	if got, want := schemaInfo.GetPlan(ctx, logStats, query).FullQuery.Query, query; got != want {
+		t.Errorf("FullQuery after removing the filter: %v, want %v", got, want)
+	}
+}
+
 func TestSchemaInfoExportVars(t *testing.T) {
 	fakecacheservice.Register()
 	db := fakesqldb.Register()
@@ -524,6 +682,9 @@ func TestUpdatedMysqlStats(t *testing.T) {
 	if tableInfo == nil {
 		t.Fatalf("table: %s should exist", tableName)
 	}
+	if tableInfo.RowFormat != "Compact" {
+		t.Errorf("RowFormat: %s, want Compact", tableInfo.RowFormat)
+	}
 	tr1 := tableInfo.TableRows
 	dl1 := tableInfo.DataLength
 	il1 := tableInfo.IndexLength
@@ -726,6 +887,7 @@ func createTestTableBaseShowTable(tableName string) []sqltypes.Value {
 		sqltypes.MakeTrusted(sqltypes.Int32, []byte("2")),
 		sqltypes.MakeTrusted(sqltypes.Int32, []byte("3")),
 		sqltypes.MakeTrusted(sqltypes.Int32, []byte("4")),
+		sqltypes.MakeString([]byte("Compact")),
 	}
 }
 
@@ -739,6 +901,7 @@ func createTestTableUpdatedStats(tableName string) []sqltypes.Value {
 		sqltypes.MakeTrusted(sqltypes.Int32, []byte("5")),
 		sqltypes.MakeTrusted(sqltypes.Int32, []byte("6")),
 		sqltypes.MakeTrusted(sqltypes.Int32, []byte("7")),
+		sqltypes.MakeString([]byte("Compact")),
 	}
 }
 
@@ -792,6 +955,7 @@ func getSchemaInfoTestSupportedQueries() map[string]*sqltypes.Result {
 					sqltypes.MakeTrusted(sqltypes.Int32, []byte("2")),
 					sqltypes.MakeTrusted(sqltypes.Int32, []byte("3")),
 					sqltypes.MakeTrusted(sqltypes.Int32, []byte("4")),
+					sqltypes.MakeString([]byte("Compact")),
 				},
 				{
 					sqltypes.MakeString([]byte("test_table_02")),
@@ -802,6 +966,7 @@ func getSchemaInfoTestSupportedQueries() map[string]*sqltypes.Result {
 					sqltypes.MakeTrusted(sqltypes.Int32, []byte("2")),
 					sqltypes.MakeTrusted(sqltypes.Int32, []byte("3")),
 					sqltypes.MakeTrusted(sqltypes.Int32, []byte("4")),
+					sqltypes.MakeString([]byte("Compact")),
 				},
 				{
 					sqltypes.MakeString([]byte("test_table_03")),
@@ -812,6 +977,7 @@ func getSchemaInfoTestSupportedQueries() map[string]*sqltypes.Result {
 					sqltypes.MakeTrusted(sqltypes.Int32, []byte("2")),
 					sqltypes.MakeTrusted(sqltypes.Int32, []byte("3")),
 					sqltypes.MakeTrusted(sqltypes.Int32, []byte("4")),
+					sqltypes.MakeString([]byte("Compact")),
 				},
 			},
 		},
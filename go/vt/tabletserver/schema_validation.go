@@ -0,0 +1,271 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tabletserver
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/youtube/vitess/go/sqltypes"
+	"github.com/youtube/vitess/go/vt/schema"
+	"github.com/youtube/vitess/go/vt/sqlparser"
+
+	querypb "github.com/youtube/vitess/go/vt/proto/query"
+	vtrpcpb "github.com/youtube/vitess/go/vt/proto/vtrpc"
+)
+
+// bindVarColumn is the column a bind variable is compared to or
+// assigned to, found by the schema pre-validation.
+type bindVarColumn struct {
+	table  string
+	column string
+	typ    querypb.Type
+}
+
+// schemaValidator checks a statement against the tables of the schema,
+// for -schema_pre_validation.
+type schemaValidator struct {
+	tables map[string]*TableInfo
+	// refs are the tables of the statement, by name and alias. Their
+	// value is nil for the tables of another database and the derived
+	// tables, which are not checked.
+	refs map[string]*schema.Table
+	// aliases are the aliases of the select expressions, which can
+	// be used as columns in the GROUP BY, HAVING and ORDER BY.
+	aliases  map[string]bool
+	bindVars map[string]bindVarColumn
+}
+
+// validateSchema checks that the tables and columns referenced by sql
+// exist in tables. It returns the columns of the bind variables, whose
+// values checkBindVarTypes checks at execution. Statements that can't
+// be parsed are left to MySQL.
+func validateSchema(sql string, tables map[string]*TableInfo) (map[string]bindVarColumn, error) {
+	statement, err := sqlparser.Parse(sql)
+	if err != nil {
+		return nil, nil
+	}
+	sv := &schemaValidator{
+		tables:   tables,
+		refs:     make(map[string]*schema.Table),
+		aliases:  make(map[string]bool),
+		bindVars: make(map[string]bindVarColumn),
+	}
+	if err := sqlparser.Walk(sv.collectTables, statement); err != nil {
+		return nil, err
+	}
+	if err := sqlparser.Walk(sv.checkColumns, statement); err != nil {
+		return nil, err
+	}
+	return sv.bindVars, nil
+}
+
+// collectTables adds the tables of node to refs, and fails if one is
+// not in the schema.
+func (sv *schemaValidator) collectTables(node sqlparser.SQLNode) (bool, error) {
+	switch node := node.(type) {
+	case *sqlparser.AliasedTableExpr:
+		switch expr := node.Expr.(type) {
+		case *sqlparser.TableName:
+			return true, sv.addTable(expr, string(node.As))
+		case *sqlparser.Subquery:
+			// The columns of a derived table are not checked.
+			if node.As != "" {
+				sv.refs[string(node.As)] = nil
+			}
+		}
+	case *sqlparser.Insert:
+		return true, sv.addTable(node.Table, "")
+	case *sqlparser.Update:
+		return true, sv.addTable(node.Table, "")
+	case *sqlparser.Delete:
+		return true, sv.addTable(node.Table, "")
+	case *sqlparser.NonStarExpr:
+		if node.As != "" {
+			sv.aliases[strings.ToLower(string(node.As))] = true
+		}
+	}
+	return true, nil
+}
+
+func (sv *schemaValidator) addTable(tableName *sqlparser.TableName, alias string) error {
+	name := string(tableName.Name)
+	var table *schema.Table
+	if tableName.Qualifier == "" && name != "dual" {
+		tableInfo, ok := sv.tables[name]
+		if !ok {
+			return fmt.Errorf("table %v not found in schema", name)
+		}
+		table = tableInfo.Table
+	}
+	sv.refs[name] = table
+	if alias != "" {
+		sv.refs[alias] = table
+	}
+	return nil
+}
+
+// checkColumns fails if a column of node is not in its table, and
+// records the columns of the bind variables.
+func (sv *schemaValidator) checkColumns(node sqlparser.SQLNode) (bool, error) {
+	switch node := node.(type) {
+	case *sqlparser.ColName:
+		_, err := sv.findColumn(node)
+		return false, err
+	case *sqlparser.ComparisonExpr:
+		if col, ok := node.Left.(*sqlparser.ColName); ok {
+			return true, sv.addBindVars(col, node.Right)
+		}
+		if col, ok := node.Right.(*sqlparser.ColName); ok {
+			return true, sv.addBindVars(col, node.Left)
+		}
+	case *sqlparser.UpdateExpr:
+		return true, sv.addBindVars(node.Name, node.Expr)
+	case *sqlparser.Insert:
+		rows, ok := node.Rows.(sqlparser.Values)
+		if !ok {
+			return true, nil
+		}
+		for _, row := range rows {
+			tuple, ok := row.(sqlparser.ValTuple)
+			if !ok {
+				continue
+			}
+			for i, expr := range node.Columns {
+				nonStar, ok := expr.(*sqlparser.NonStarExpr)
+				if !ok || i >= len(tuple) {
+					continue
+				}
+				if col, ok := nonStar.Expr.(*sqlparser.ColName); ok {
+					if err := sv.addBindVars(col, tuple[i]); err != nil {
+						return false, err
+					}
+				}
+			}
+		}
+	}
+	return true, nil
+}
+
+// findColumn returns the column of col, or nil if it can't be checked.
+func (sv *schemaValidator) findColumn(col *sqlparser.ColName) (*bindVarColumn, error) {
+	name := string(col.Name)
+	if col.Qualifier != nil {
+		qualifier := string(col.Qualifier.Name)
+		table, ok := sv.refs[qualifier]
+		if !ok {
+			return nil, fmt.Errorf("unknown table %v in column %v.%v", qualifier, qualifier, name)
+		}
+		if table == nil {
+			return nil, nil
+		}
+		column := findTableColumn(table, name)
+		if column == nil {
+			return nil, fmt.Errorf("column %v not found in table %v", name, table.Name)
+		}
+		return &bindVarColumn{table: table.Name, column: column.Name, typ: column.Type}, nil
+	}
+	checked := true
+	for _, table := range sv.refs {
+		if table == nil {
+			checked = false
+			continue
+		}
+		if column := findTableColumn(table, name); column != nil {
+			return &bindVarColumn{table: table.Name, column: column.Name, typ: column.Type}, nil
+		}
+	}
+	if !checked || len(sv.refs) == 0 || sv.aliases[strings.ToLower(name)] {
+		return nil, nil
+	}
+	return nil, fmt.Errorf("column %v not found in the tables of the query", name)
+}
+
+// addBindVars records the column of the bind variables of expr, which
+// is compared to or assigned to col.
+func (sv *schemaValidator) addBindVars(col *sqlparser.ColName, expr sqlparser.SQLNode) error {
+	column, err := sv.findColumn(col)
+	if err != nil || column == nil {
+		return err
+	}
+	switch expr := expr.(type) {
+	case sqlparser.ValArg:
+		sv.bindVars[string(expr[1:])] = *column
+	case sqlparser.ValTuple:
+		for _, val := range expr {
+			if arg, ok := val.(sqlparser.ValArg); ok {
+				sv.bindVars[string(arg[1:])] = *column
+			}
+		}
+	}
+	return nil
+}
+
+// findTableColumn returns the column of table with name, compared
+// case-insensitively like MySQL does, or nil.
+func findTableColumn(table *schema.Table, name string) *schema.TableColumn {
+	for i := range table.Columns {
+		if strings.EqualFold(table.Columns[i].Name, name) {
+			return &table.Columns[i]
+		}
+	}
+	return nil
+}
+
+// checkBindVarTypes checks that the bind variables compared to or
+// assigned to numeric columns are numbers.
+func checkBindVarTypes(columns map[string]bindVarColumn, bindVars map[string]interface{}) error {
+	for name, column := range columns {
+		if !sqltypes.IsIntegral(column.typ) && !sqltypes.IsFloat(column.typ) && column.typ != sqltypes.Decimal {
+			continue
+		}
+		value, ok := bindVars[name]
+		if !ok {
+			continue
+		}
+		if !isNumericBindValue(value) {
+			return fmt.Errorf("bind variable %v is not a number, but column %v.%v is %v", name, column.table, column.column, column.typ)
+		}
+	}
+	return nil
+}
+
+// isNumericBindValue returns true if value is a number, a string that
+// MySQL reads as a number, or a list of them.
+func isNumericBindValue(value interface{}) bool {
+	switch value := value.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return true
+	case string:
+		return isNumericString(value)
+	case []byte:
+		return isNumericString(string(value))
+	case sqltypes.Value:
+		return value.IsNull() || value.IsIntegral() || value.IsFloat() || isNumericString(value.String())
+	case []interface{}:
+		for _, v := range value {
+			if !isNumericBindValue(v) {
+				return false
+			}
+		}
+		return true
+	}
+	return true
+}
+
+func isNumericString(value string) bool {
+	_, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	return err == nil
+}
+
+// schemaValidationError returns the error of a failed schema
+// pre-validation, and records it in logStats.
+func schemaValidationError(logStats *LogStats, err error) *TabletError {
+	if logStats != nil {
+		logStats.SchemaValidationError = err.Error()
+	}
+	return NewTabletError(vtrpcpb.ErrorCode_BAD_INPUT, "schema validation: %v", err)
+}
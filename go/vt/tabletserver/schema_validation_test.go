@@ -0,0 +1,189 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tabletserver
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/sqltypes"
+	"github.com/youtube/vitess/go/vt/schema"
+)
+
+func newSchemaValidationTables() map[string]*TableInfo {
+	t1 := schema.NewTable("t1")
+	t1.AddColumn("id", sqltypes.Int64, sqltypes.Value{}, "")
+	t1.AddColumn("name", sqltypes.VarChar, sqltypes.Value{}, "")
+	t2 := schema.NewTable("t2")
+	t2.AddColumn("id", sqltypes.Int64, sqltypes.Value{}, "")
+	t2.AddColumn("price", sqltypes.Decimal, sqltypes.Value{}, "")
+	return map[string]*TableInfo{
+		"t1": {Table: t1},
+		"t2": {Table: t2},
+	}
+}
+
+func TestValidateSchema(t *testing.T) {
+	testcases := []struct {
+		sql      string
+		bindVars map[string]bindVarColumn
+		err      string
+	}{{
+		sql: "select id, name from t1 where id = :id",
+		bindVars: map[string]bindVarColumn{
+			"id": {table: "t1", column: "id", typ: sqltypes.Int64},
+		},
+	}, {
+		sql: "select a.NAME, b.price from t1 as a join t2 as b on a.id = b.id where b.price > :p and a.name in (:n1, :n2)",
+		bindVars: map[string]bindVarColumn{
+			"p":  {table: "t2", column: "price", typ: sqltypes.Decimal},
+			"n1": {table: "t1", column: "name", typ: sqltypes.VarChar},
+			"n2": {table: "t1", column: "name", typ: sqltypes.VarChar},
+		},
+	}, {
+		sql: "select count(*) as c from t1 group by name order by c",
+	}, {
+		sql: "insert into t2(id, price) values (:id, :price) on duplicate key update price = :price2",
+		bindVars: map[string]bindVarColumn{
+			"id":     {table: "t2", column: "id", typ: sqltypes.Int64},
+			"price":  {table: "t2", column: "price", typ: sqltypes.Decimal},
+			"price2": {table: "t2", column: "price", typ: sqltypes.Decimal},
+		},
+	}, {
+		sql: "update t1 set name = :name where id = 1",
+		bindVars: map[string]bindVarColumn{
+			"name": {table: "t1", column: "name", typ: sqltypes.VarChar},
+		},
+	}, {
+		// The tables of other databases, and dual, are not checked.
+		sql: "select x from other.t3 where y = :y",
+	}, {
+		sql: "select 1 from dual",
+	}, {
+		// The columns of the derived tables are not checked, the
+		// tables of their subqueries are.
+		sql: "select d.x, y from (select name as x, id as y from t1) as d where d.x = :x",
+	}, {
+		sql: "select d.x from (select x from t4) as d",
+		err: "table t4 not found in schema",
+	}, {
+		// The statements that can't be parsed are left to MySQL.
+		sql: "select from where",
+	}, {
+		sql: "select * from dropped",
+		err: "table dropped not found in schema",
+	}, {
+		sql: "delete from t1 where missing = 1",
+		err: "column missing not found in the tables of the query",
+	}, {
+		sql: "select t2.name from t2",
+		err: "column name not found in table t2",
+	}, {
+		sql: "select t3.id from t1",
+		err: "unknown table t3 in column t3.id",
+	}, {
+		sql: "select id from t1 where id in (select id from t4)",
+		err: "table t4 not found in schema",
+	}}
+	for _, tcase := range testcases {
+		bindVars, err := validateSchema(tcase.sql, newSchemaValidationTables())
+		if tcase.err != "" {
+			if err == nil || err.Error() != tcase.err {
+				t.Errorf("validateSchema(%q): %v, want %v", tcase.sql, err, tcase.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("validateSchema(%q): %v", tcase.sql, err)
+			continue
+		}
+		if len(bindVars) == 0 && len(tcase.bindVars) == 0 {
+			continue
+		}
+		if !reflect.DeepEqual(bindVars, tcase.bindVars) {
+			t.Errorf("validateSchema(%q): %+v, want %+v", tcase.sql, bindVars, tcase.bindVars)
+		}
+	}
+}
+
+func TestCheckBindVarTypes(t *testing.T) {
+	columns := map[string]bindVarColumn{
+		"id":    {table: "t1", column: "id", typ: sqltypes.Int64},
+		"price": {table: "t2", column: "price", typ: sqltypes.Decimal},
+		"name":  {table: "t1", column: "name", typ: sqltypes.VarChar},
+	}
+	testcases := []struct {
+		bindVars map[string]interface{}
+		err      string
+	}{{
+		bindVars: map[string]interface{}{"id": 1, "price": "12.50", "name": "x"},
+	}, {
+		bindVars: map[string]interface{}{"id": []byte("3"), "price": 1.5},
+	}, {
+		bindVars: map[string]interface{}{"id": sqltypes.MakeString([]byte(" 4 ")), "price": sqltypes.NULL},
+	}, {
+		bindVars: map[string]interface{}{"id": []interface{}{1, "2"}},
+	}, {
+		bindVars: map[string]interface{}{"id": "abc"},
+		err:      "bind variable id is not a number, but column t1.id is INT64",
+	}, {
+		bindVars: map[string]interface{}{"price": []interface{}{1, "x"}},
+		err:      "bind variable price is not a number, but column t2.price is DECIMAL",
+	}}
+	for _, tcase := range testcases {
+		err := checkBindVarTypes(columns, tcase.bindVars)
+		if tcase.err == "" && err != nil || tcase.err != "" && (err == nil || err.Error() != tcase.err) {
+			t.Errorf("checkBindVarTypes(%v): %v, want %q", tcase.bindVars, err, tcase.err)
+		}
+	}
+}
+
+func TestQueryExecutorSchemaPreValidation(t *testing.T) {
+	defer func(v bool) { *schemaPreValidation = v }(*schemaPreValidation)
+	*schemaPreValidation = true
+
+	db := setUpQueryExecutorTest()
+	ctx := context.Background()
+	tsv := newTestTabletServer(ctx, enableRowCache|enableSchemaOverrides|enableStrict, db)
+	defer tsv.StopService()
+
+	// A missing table fails when the plan is built, without MySQL.
+	logStats := newLogStats("TestQueryExecutor", ctx)
+	func() {
+		defer func() {
+			x := recover()
+			want := "schema validation: table missing_table not found in schema"
+			if terr, ok := x.(*TabletError); !ok || !strings.Contains(terr.Error(), want) {
+				t.Errorf("GetPlan: %v, want %v", x, want)
+			}
+		}()
+		tsv.qe.schemaInfo.GetPlan(ctx, logStats, "select * from missing_table")
+	}()
+	if want := "table missing_table not found in schema"; logStats.SchemaValidationError != want {
+		t.Errorf("SchemaValidationError: %q, want %q", logStats.SchemaValidationError, want)
+	}
+	if got, want := logStats.Format(url.Values{}), "\t\"table missing_table not found in schema\"\t\n"; !strings.HasSuffix(got, want) {
+		t.Errorf("Format: %q, want the suffix %q", got, want)
+	}
+
+	// A bind variable that doesn't match its column fails at execution.
+	query := "select * from test_table where pk = :pk"
+	db.AddQuery("select * from test_table where 1 != 1", &sqltypes.Result{
+		Fields: getTestTableFields(),
+	})
+	qre := newTestQueryExecutor(ctx, tsv, query, 0)
+	qre.bindVars["pk"] = "abc"
+	_, err := qre.Execute()
+	if want := "schema validation: bind variable pk is not a number, but column test_table.pk is INT32"; err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("Execute: %v, want %v", err, want)
+	}
+	if qre.logStats.SchemaValidationError == "" {
+		t.Errorf("SchemaValidationError is not set")
+	}
+}
@@ -21,6 +21,7 @@ var (
 			<th>Columns</th>
 			<th>Indexes</th>
 			<th>Type</th>
+			<th>RowFormat</th>
 			<th>TableRows</th>
 			<th>DataLength</th>
 			<th>IndexLength</th>
@@ -33,6 +34,7 @@ var (
 			<td>{{range .Columns}}{{.Name}}: {{.Type}}, {{if .IsAuto}}autoinc{{end}}, {{.Default}}<br>{{end}}</td>
 			<td>{{range .Indexes}}{{.Name}}: ({{range .Columns}}{{.}},{{end}}), ({{range .Cardinality}}{{.}},{{end}})<br>{{end}}</td>
 			<td>{{index $top.Type .Type}}</td>
+			<td>{{.RowFormat}}</td>
 			<td>{{.TableRows.Get}}</td>
 			<td>{{.DataLength.Get}}</td>
 			<td>{{.IndexLength.Get}}</td>
@@ -34,6 +34,7 @@ func TestSchamazHandler(t *testing.T) {
 	tableC.AddColumn("column3", sqltypes.VarChar, sqltypes.MakeString([]byte("")), "")
 	tableC.AddIndex("index3").AddColumn("index_column3", 500)
 	tableC.Type = schema.CacheNone
+	tableC.RowFormat = "Compressed"
 
 	tables := []*schema.Table{
 		tableA, tableB, tableC,
@@ -45,6 +46,7 @@ func TestSchamazHandler(t *testing.T) {
 		`<td>column3: VARCHAR, , <br></td>`,
 		`<td>index3: \(index_column3,\), \(500,\)<br></td>`,
 		`<td>none</td>`,
+		`<td>Compressed</td>`,
 	}
 	matched, err := regexp.Match(strings.Join(tableCPattern, `\s*`), body)
 	if err != nil {
@@ -10,18 +10,52 @@ import (
 
 var queryserviceStatusTemplate = `
 <h2>State: {{.State}}</h2>
+{{if .MissingPrivileges}}
+<h2 style="color:red">Missing MySQL privileges</h2>
+<ul>
+  {{range .MissingPrivileges}}
+  <li>{{.}}</li>
+  {{end}}
+</ul>
+{{end}}
+{{if .PlanChangeDegraded}}
+<h2 style="color:orange">Degraded: {{.PlanChangeDegraded}}</h2>
+{{end}}
+{{if .PlanChanges}}
+<h2>Plan-changing Schema Changes</h2>
+<table>
+  <tr>
+    <th>Time</th>
+    <th>Table</th>
+    <th>Rowcache</th>
+    <th>Primary Key</th>
+    <th>Columns</th>
+  </tr>
+  {{range .PlanChanges}}
+  <tr>
+    <td>{{.Time.Format "Jan 2, 2006 at 15:04:05 (MST)"}}</td>
+    <td>{{.Table}}</td>
+    <td>{{.Before.Cached}} &rarr; {{.After.Cached}}</td>
+    <td>{{.Before.PKColumns}} &rarr; {{.After.PKColumns}}</td>
+    <td>{{.Before.NumColumns}} &rarr; {{.After.NumColumns}}</td>
+  </tr>
+  {{end}}
+</table>
+{{end}}
 <h2>Queryservice History</h2>
 <table>
   <tr>
     <th>Time</th>
     <th>Target Tablet Type</th>
     <th>Serving State</th>
+    <th>Reason</th>
   </tr>
   {{range .History}}
   <tr>
     <td>{{.Time.Format "Jan 2, 2006 at 15:04:05 (MST)"}}</td>
     <td>{{.TabletType}}</td>
     <td>{{.ServingState}}</td>
+    <td>{{.Reason}}</td>
   </tr>
   {{end}}
 </table>
@@ -104,17 +138,25 @@ google.setOnLoadCallback(drawQPSChart);
 `
 
 type queryserviceStatus struct {
-	State      string
-	History    []interface{}
-	CurrentQPS float64
+	State             string
+	History           []interface{}
+	CurrentQPS        float64
+	MissingPrivileges []string
+	// PlanChanges are the last changes of the rowcache eligibility
+	// or the primary key of the tables, the newest first.
+	PlanChanges        []*TablePlanChange
+	PlanChangeDegraded string
 }
 
 // AddStatusPart registers the status part for the status page.
 func (tsv *TabletServer) AddStatusPart() {
 	servenv.AddStatusPart("Queryservice", queryserviceStatusTemplate, func() interface{} {
 		status := queryserviceStatus{
-			State:   tsv.GetState(),
-			History: tsv.history.Records(),
+			State:              tsv.GetState(),
+			History:            tsv.history.Records(),
+			MissingPrivileges:  tsv.qe.schemaInfo.MissingPrivileges(),
+			PlanChanges:        tsv.qe.schemaInfo.PlanChanges(),
+			PlanChangeDegraded: tsv.qe.schemaInfo.PlanChangeDegraded(*schemaChangeDegradedDuration, time.Now()),
 		}
 		rates := tsv.qe.queryServiceStats.QPSRates.Get()
 		if qps, ok := rates["All"]; ok && len(qps) > 0 {
@@ -128,6 +170,7 @@ type historyRecord struct {
 	Time         time.Time
 	TabletType   string
 	ServingState string
+	Reason       string
 }
 
 // IsDuplicate implements history.Deduplicable
@@ -136,5 +179,5 @@ func (r *historyRecord) IsDuplicate(other interface{}) bool {
 	if !ok {
 		return false
 	}
-	return r.TabletType == rother.TabletType && r.ServingState == rother.ServingState
+	return r.TabletType == rother.TabletType && r.ServingState == rother.ServingState && r.Reason == rother.Reason
 }
@@ -85,6 +85,65 @@ func (ti *TableInfo) fetchColumns(conn *DBConn) error {
 	return nil
 }
 
+// columnsOfTableQuery returns the name, default, extra and position
+// of some columns of a table, in the order of the table.
+const columnsOfTableQuery = "select column_name, column_default, extra, ordinal_position from information_schema.columns where table_schema = database() and table_name = '%s' and column_name in (%s) order by ordinal_position"
+
+// withColumns returns a copy of ti with the columns that were just
+// appended to the table in MySQL. Only the new columns are fetched,
+// the rest of the table info is reused as is.
+func (ti *TableInfo) withColumns(conn *DBConn, columns []string) (*TableInfo, error) {
+	quoted := make([]string, len(columns))
+	for i, col := range columns {
+		quoted[i] = fmt.Sprintf("`%s`", col)
+	}
+	qr, err := conn.Exec(context.Background(), fmt.Sprintf("select %s from `%s` where 1 != 1", strings.Join(quoted, ", "), ti.Name), 10000, true)
+	if err != nil {
+		return nil, err
+	}
+	if len(qr.Fields) != len(columns) {
+		return nil, fmt.Errorf("table %s: got %d fields for columns %v", ti.Name, len(qr.Fields), columns)
+	}
+	quotedNames := make([]string, len(columns))
+	for i, col := range columns {
+		quotedNames[i] = fmt.Sprintf("'%s'", col)
+	}
+	desc, err := conn.Exec(context.Background(), fmt.Sprintf(columnsOfTableQuery, ti.Name, strings.Join(quotedNames, ", ")), 10000, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(desc.Rows) != len(columns) {
+		return nil, fmt.Errorf("table %s: got %d columns, want %d", ti.Name, len(desc.Rows), len(columns))
+	}
+	table := schema.NewTable(ti.Name)
+	table.Columns = append(table.Columns, ti.Columns...)
+	table.Indexes = ti.Indexes
+	table.PKColumns = ti.PKColumns
+	table.Type = ti.Type
+	table.RowFormat = ti.RowFormat
+	table.TableRows.Set(ti.TableRows.Get())
+	table.DataLength.Set(ti.DataLength.Get())
+	table.IndexLength.Set(ti.IndexLength.Get())
+	table.DataFree.Set(ti.DataFree.Get())
+	for i, row := range desc.Rows {
+		name := row[0].String()
+		if !strings.EqualFold(name, columns[i]) {
+			return nil, fmt.Errorf("table %s: got column %s, want %s", ti.Name, name, columns[i])
+		}
+		// The new columns must be the last ones, or the column
+		// numbers would not match MySQL anymore.
+		position, err := row[3].ParseInt64()
+		if err != nil {
+			return nil, fmt.Errorf("table %s: unexpected position %v for column %s: %v", ti.Name, row[3], name, err)
+		}
+		if want := int64(len(ti.Columns) + i + 1); position != want {
+			return nil, fmt.Errorf("table %s: column %s is at position %d, want %d", ti.Name, name, position, want)
+		}
+		table.AddColumn(name, qr.Fields[i].Type, row[1], row[2].String())
+	}
+	return &TableInfo{Table: table, Cache: ti.Cache}, nil
+}
+
 // SetPK sets the pk columns for a TableInfo.
 func (ti *TableInfo) SetPK(colnames []string) error {
 	pkIndex := schema.NewIndex("PRIMARY")
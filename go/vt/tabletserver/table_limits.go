@@ -0,0 +1,247 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tabletserver
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/youtube/vitess/go/acl"
+	"github.com/youtube/vitess/go/ratelimiter"
+	"github.com/youtube/vitess/go/stats"
+	"github.com/youtube/vitess/go/sync2"
+	vtrpcpb "github.com/youtube/vitess/go/vt/proto/vtrpc"
+	"github.com/youtube/vitess/go/vt/servenv"
+)
+
+var tableLimitsFile = flag.String("table_limits_file", "", "JSON file of a map of table names to their MaxConcurrency and MaxQPS, e.g. {\"user\": {\"MaxConcurrency\": 10, \"MaxQPS\": 500}}. The queries of a table over one of its limits fail with a retryable resource exhausted error. 0 means there is no limit. The limits can be changed on /debug/table_limits.")
+
+// TableLimit is the limit of the queries of a table.
+// 0 means there is no limit.
+type TableLimit struct {
+	// MaxConcurrency is the max number of queries of the table that
+	// are executed at the same time.
+	MaxConcurrency int64
+	// MaxQPS is the max number of queries of the table per second.
+	MaxQPS int64
+}
+
+// loadTableLimits reads the limits of -table_limits_file.
+func loadTableLimits(limitsFile string) (map[string]TableLimit, error) {
+	if limitsFile == "" {
+		return nil, nil
+	}
+	data, err := ioutil.ReadFile(limitsFile)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the table limits file: %v", err)
+	}
+	var limits map[string]TableLimit
+	if err := json.Unmarshal(data, &limits); err != nil {
+		return nil, fmt.Errorf("cannot parse the table limits file %s: %v", limitsFile, err)
+	}
+	return limits, nil
+}
+
+// tableLimits caps the concurrent queries and the QPS of the tables,
+// so that one hot table can't starve the others. The queries only
+// load the current limiters and update the counters of their table:
+// mu is only taken to change the limits and to read the counters.
+type tableLimits struct {
+	mu sync.Mutex
+	// limiters is the map[string]*tableLimiter of the tables that have
+	// a limit. SetLimits replaces it, and it's never modified.
+	limiters atomic.Value
+	// concurrency has the number of queries being executed, for the
+	// tables that have a limit or had one when their queries started.
+	// The limiters of a table share its counter across SetLimits.
+	concurrency map[string]*sync2.AtomicInt64
+
+	// rejections counts the rejected queries by table and limit.
+	rejections *stats.MultiCounters
+}
+
+// tableLimiter enforces the limit of a table.
+type tableLimiter struct {
+	limit       TableLimit
+	rateLimiter *ratelimiter.RateLimiter
+	concurrency *sync2.AtomicInt64
+}
+
+func newTableLimits(rejectionsName string) *tableLimits {
+	tl := &tableLimits{
+		concurrency: make(map[string]*sync2.AtomicInt64),
+		rejections:  stats.NewMultiCounters(rejectionsName, []string{"Table", "Limit"}),
+	}
+	tl.limiters.Store(make(map[string]*tableLimiter))
+	return tl
+}
+
+func (tl *tableLimits) currentLimiters() map[string]*tableLimiter {
+	return tl.limiters.Load().(map[string]*tableLimiter)
+}
+
+// SetLimits replaces all the limits. The QPS of the tables restart
+// from 0.
+func (tl *tableLimits) SetLimits(limits map[string]TableLimit) error {
+	for table, limit := range limits {
+		if limit.MaxConcurrency < 0 || limit.MaxQPS < 0 {
+			return fmt.Errorf("invalid limits of table %v: %+v, they can't be negative", table, limit)
+		}
+	}
+	tl.mu.Lock()
+	defer tl.mu.Unlock()
+	limiters := make(map[string]*tableLimiter, len(limits))
+	for table, limit := range limits {
+		if limit == (TableLimit{}) {
+			continue
+		}
+		concurrency, ok := tl.concurrency[table]
+		if !ok {
+			concurrency = new(sync2.AtomicInt64)
+			tl.concurrency[table] = concurrency
+		}
+		limiter := &tableLimiter{limit: limit, concurrency: concurrency}
+		if limit.MaxQPS > 0 {
+			limiter.rateLimiter = ratelimiter.NewRateLimiter(int(limit.MaxQPS), time.Second)
+		}
+		limiters[table] = limiter
+	}
+	for table, concurrency := range tl.concurrency {
+		if _, ok := limiters[table]; !ok && concurrency.Get() == 0 {
+			delete(tl.concurrency, table)
+		}
+	}
+	tl.limiters.Store(limiters)
+	return nil
+}
+
+// Limits returns a copy of the limits.
+func (tl *tableLimits) Limits() map[string]TableLimit {
+	limiters := tl.currentLimiters()
+	limits := make(map[string]TableLimit, len(limiters))
+	for table, limiter := range limiters {
+		limits[table] = limiter.limit
+	}
+	return limits
+}
+
+// Concurrency returns the number of queries being executed for each
+// table that has a limit, or that still has queries that started
+// when it had one.
+func (tl *tableLimits) Concurrency() map[string]int64 {
+	limiters := tl.currentLimiters()
+	tl.mu.Lock()
+	defer tl.mu.Unlock()
+	concurrency := make(map[string]int64, len(tl.concurrency))
+	for table, count := range tl.concurrency {
+		if _, ok := limiters[table]; ok || count.Get() != 0 {
+			concurrency[table] = count.Get()
+		}
+	}
+	return concurrency
+}
+
+// acquire checks that a new query of table is within its limits. If it
+// is, the caller must call the returned release function once the
+// query is done. Otherwise, it returns a RESOURCE_EXHAUSTED error.
+func (tl *tableLimits) acquire(table string) (release func(), err error) {
+	limiter, ok := tl.currentLimiters()[table]
+	if !ok {
+		return func() {}, nil
+	}
+	for {
+		count := limiter.concurrency.Get()
+		if limiter.limit.MaxConcurrency > 0 && count >= limiter.limit.MaxConcurrency {
+			tl.rejections.Add([]string{table, "MaxConcurrency"}, 1)
+			return nil, NewTabletError(vtrpcpb.ErrorCode_RESOURCE_EXHAUSTED, "table %s has %d queries in flight, over its limit of %d concurrent queries", table, count, limiter.limit.MaxConcurrency)
+		}
+		if limiter.concurrency.CompareAndSwap(count, count+1) {
+			break
+		}
+	}
+	if limiter.rateLimiter != nil && !limiter.rateLimiter.Allow() {
+		limiter.concurrency.Add(-1)
+		tl.rejections.Add([]string{table, "MaxQPS"}, 1)
+		return nil, NewTabletError(vtrpcpb.ErrorCode_RESOURCE_EXHAUSTED, "table %s is over its limit of %d queries per second", table, limiter.limit.MaxQPS)
+	}
+	return func() {
+		limiter.concurrency.Add(-1)
+	}, nil
+}
+
+// SetTableLimits replaces the limits of the queries of the tables.
+func (tsv *TabletServer) SetTableLimits(limits map[string]TableLimit) error {
+	return tsv.qe.tableLimits.SetLimits(limits)
+}
+
+// TableLimits returns the limits of the queries of the tables.
+func (tsv *TabletServer) TableLimits() map[string]TableLimit {
+	return tsv.qe.tableLimits.Limits()
+}
+
+func (tsv *TabletServer) registerTableLimitsHandler() {
+	setHandler := servenv.AuditHTTP("SetTableLimits", func(w http.ResponseWriter, r *http.Request) {
+		tableLimitsHandler(tsv.qe.tableLimits, w, r)
+	})
+	http.HandleFunc("/debug/table_limits", func(w http.ResponseWriter, r *http.Request) {
+		// Only the requests that change the limits are audited.
+		if r.FormValue("limits") == "" {
+			tableLimitsHandler(tsv.qe.tableLimits, w, r)
+			return
+		}
+		setHandler(w, r)
+	})
+}
+
+// tableLimitsHandler shows the limits of the tables, with their
+// concurrency and rejections. If the "limits" param is set, it
+// replaces the limits first, with a JSON map like the one of
+// -table_limits_file. "{}" removes them all.
+func tableLimitsHandler(tl *tableLimits, w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, fmt.Sprintf("cannot parse form: %s", err), http.StatusInternalServerError)
+		return
+	}
+	value := r.FormValue("limits")
+	role := acl.DEBUGGING
+	if value != "" {
+		role = acl.ADMIN
+	}
+	if err := acl.CheckAccessHTTP(r, role); err != nil {
+		acl.SendError(w, err)
+		return
+	}
+	if value != "" {
+		var limits map[string]TableLimit
+		if err := json.Unmarshal([]byte(value), &limits); err != nil {
+			http.Error(w, fmt.Sprintf("invalid limits: %v", err), http.StatusBadRequest)
+			return
+		}
+		if err := tl.SetLimits(limits); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	limits := tl.Limits()
+	concurrency := tl.Concurrency()
+	rejections := tl.rejections.Counts()
+	tables := make([]string, 0, len(concurrency))
+	for table := range concurrency {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+	w.Header().Set("Content-Type", "text/plain")
+	for _, table := range tables {
+		limit := limits[table]
+		fmt.Fprintf(w, "%s: max concurrency: %d, max qps: %d, concurrency: %d, rejected over max concurrency: %d, rejected over max qps: %d\n", table, limit.MaxConcurrency, limit.MaxQPS, concurrency[table], rejections[table+".MaxConcurrency"], rejections[table+".MaxQPS"])
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tabletserver
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/youtube/vitess/go/sync2"
+)
+
+func TestTableLimitsAcquire(t *testing.T) {
+	tl := newTableLimits("")
+	if err := tl.SetLimits(map[string]TableLimit{
+		"t1": {MaxConcurrency: 2},
+		"t2": {MaxQPS: 1},
+		"t3": {},
+	}); err != nil {
+		t.Fatalf("SetLimits: %v", err)
+	}
+	if got, want := tl.Limits(), map[string]TableLimit{"t1": {MaxConcurrency: 2}, "t2": {MaxQPS: 1}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Limits: %v, want %v", got, want)
+	}
+
+	release1, err := tl.acquire("t1")
+	if err != nil {
+		t.Fatalf("acquire(t1): %v", err)
+	}
+	release2, err := tl.acquire("t1")
+	if err != nil {
+		t.Fatalf("acquire(t1): %v", err)
+	}
+	if _, err := tl.acquire("t1"); err == nil || !strings.Contains(err.Error(), "table t1 has 2 queries in flight, over its limit of 2 concurrent queries") {
+		t.Errorf("acquire(t1) over the limit: %v, want a concurrency error", err)
+	}
+	if got, want := tl.Concurrency(), map[string]int64{"t1": 2, "t2": 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Concurrency: %v, want %v", got, want)
+	}
+	release1()
+	if _, err := tl.acquire("t1"); err != nil {
+		t.Errorf("acquire(t1) after a release: %v", err)
+	}
+	release2()
+
+	release3, err := tl.acquire("t2")
+	if err != nil {
+		t.Fatalf("acquire(t2): %v", err)
+	}
+	release3()
+	if _, err := tl.acquire("t2"); err == nil || !strings.Contains(err.Error(), "table t2 is over its limit of 1 queries per second") {
+		t.Errorf("acquire(t2) over the limit: %v, want a qps error", err)
+	}
+	if want := map[string]int64{"t1.MaxConcurrency": 1, "t2.MaxQPS": 1}; !reflect.DeepEqual(tl.rejections.Counts(), want) {
+		t.Errorf("rejections: %v, want %v", tl.rejections.Counts(), want)
+	}
+
+	// The tables without limits are not counted.
+	for i := 0; i < 10; i++ {
+		if _, err := tl.acquire("t3"); err != nil {
+			t.Fatalf("acquire(t3): %v", err)
+		}
+	}
+
+	// The queries in flight when the limits change are still counted
+	// until they are done.
+	release, err := tl.acquire("t1")
+	if err != nil {
+		t.Fatalf("acquire(t1): %v", err)
+	}
+	if err := tl.SetLimits(nil); err != nil {
+		t.Fatalf("SetLimits(nil): %v", err)
+	}
+	if got, want := tl.Concurrency(), map[string]int64{"t1": 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Concurrency after the limits are removed: %v, want %v", got, want)
+	}
+	release()
+	if got := tl.Concurrency(); len(got) != 1 || got["t1"] != 1 {
+		t.Errorf("Concurrency after a release: %v, want t1: 1", got)
+	}
+
+	if err := tl.SetLimits(map[string]TableLimit{"t1": {MaxQPS: -1}}); err == nil {
+		t.Errorf("SetLimits with a negative limit: nil, want error")
+	}
+}
+
+func TestTableLimitsConcurrentAcquire(t *testing.T) {
+	tl := newTableLimits("")
+	if err := tl.SetLimits(map[string]TableLimit{"t1": {MaxConcurrency: 5}}); err != nil {
+		t.Fatalf("SetLimits: %v", err)
+	}
+	var inFlight, maxInFlight sync2.AtomicInt64
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				release, err := tl.acquire("t1")
+				if err != nil {
+					continue
+				}
+				n := inFlight.Add(1)
+				for max := maxInFlight.Get(); n > max && !maxInFlight.CompareAndSwap(max, n); max = maxInFlight.Get() {
+				}
+				inFlight.Add(-1)
+				release()
+			}
+		}()
+	}
+	wg.Wait()
+	if got := maxInFlight.Get(); got > 5 {
+		t.Errorf("max queries in flight: %v, want at most 5", got)
+	}
+	if got, want := tl.Concurrency(), map[string]int64{"t1": 0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Concurrency: %v, want %v", got, want)
+	}
+}
+
+func TestLoadTableLimits(t *testing.T) {
+	limits, err := loadTableLimits("")
+	if err != nil || limits != nil {
+		t.Errorf("loadTableLimits(\"\"): %v, %v, want nil, nil", limits, err)
+	}
+
+	f, err := ioutil.TempFile("", "table_limits")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.WriteString(`{"t1": {"MaxConcurrency": 10, "MaxQPS": 500}}`); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	limits, err = loadTableLimits(f.Name())
+	if err != nil {
+		t.Fatalf("loadTableLimits: %v", err)
+	}
+	if want := map[string]TableLimit{"t1": {MaxConcurrency: 10, MaxQPS: 500}}; !reflect.DeepEqual(limits, want) {
+		t.Errorf("loadTableLimits: %v, want %v", limits, want)
+	}
+
+	if _, err := loadTableLimits(f.Name() + ".missing"); err == nil {
+		t.Errorf("loadTableLimits of a missing file: nil, want error")
+	}
+}
+
+func TestTableLimitsHandler(t *testing.T) {
+	tl := newTableLimits("")
+	req, _ := http.NewRequest("GET", "/debug/table_limits?limits="+url.QueryEscape(`{"t1": {"MaxConcurrency": 3}, "t2": {"MaxQPS": 20}}`), nil)
+	response := httptest.NewRecorder()
+	tableLimitsHandler(tl, response, req)
+	want := "t1: max concurrency: 3, max qps: 0, concurrency: 0, rejected over max concurrency: 0, rejected over max qps: 0\n" +
+		"t2: max concurrency: 0, max qps: 20, concurrency: 0, rejected over max concurrency: 0, rejected over max qps: 0\n"
+	if body := response.Body.String(); body != want {
+		t.Errorf("got body %q, want %q", body, want)
+	}
+
+	req, _ = http.NewRequest("GET", "/debug/table_limits?limits=bad", nil)
+	response = httptest.NewRecorder()
+	tableLimitsHandler(tl, response, req)
+	if response.Code != http.StatusBadRequest {
+		t.Errorf("got code %d, want %d", response.Code, http.StatusBadRequest)
+	}
+
+	req, _ = http.NewRequest("GET", "/debug/table_limits?limits={}", nil)
+	response = httptest.NewRecorder()
+	tableLimitsHandler(tl, response, req)
+	if got := tl.Limits(); len(got) != 0 {
+		t.Errorf("Limits: %v, want none", got)
+	}
+}
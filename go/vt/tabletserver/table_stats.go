@@ -0,0 +1,131 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tabletserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	log "github.com/golang/glog"
+	"github.com/youtube/vitess/go/acl"
+	"github.com/youtube/vitess/go/stats"
+)
+
+// tableStatsOther is the table the queries are counted under once
+// the maximum number of tables is tracked. It can't be the name of a
+// table.
+const tableStatsOther = "<other>"
+
+// tableStats has the per table query stats, it's nil if they're
+// disabled by -table_stats_max_tables.
+var tableStats *TableStats
+
+// tableStatsEntry is the rollup of the queries of a table.
+type tableStatsEntry struct {
+	Queries      int64
+	RowsAffected int64
+	TotalTime    time.Duration
+	MysqlTime    time.Duration
+	CacheHits    int64
+	CacheMisses  int64
+}
+
+// TableStats aggregates the queries executed by QueryExecutor by table.
+// A query that references several tables is counted for each of them.
+// All the queries are counted, whether the query log keeps them or not.
+type TableStats struct {
+	maxTables int
+
+	mu     sync.Mutex
+	tables map[string]*tableStatsEntry
+}
+
+// NewTableStats creates a TableStats that tracks up to maxTables
+// tables. The queries of the other tables are counted under "<other>".
+func NewTableStats(maxTables int) *TableStats {
+	return &TableStats{
+		maxTables: maxTables,
+		tables:    make(map[string]*tableStatsEntry),
+	}
+}
+
+// Add adds a query that took totalTime to the stats of its tables.
+func (ts *TableStats) Add(logStats *LogStats, totalTime time.Duration) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	for _, table := range logStats.TableNames {
+		entry, ok := ts.tables[table]
+		if !ok {
+			if len(ts.tables) >= ts.maxTables {
+				table = tableStatsOther
+			}
+			entry = ts.tables[table]
+			if entry == nil {
+				entry = &tableStatsEntry{}
+				ts.tables[table] = entry
+			}
+		}
+		entry.Queries++
+		entry.RowsAffected += int64(logStats.RowsAffected)
+		entry.TotalTime += totalTime
+		entry.MysqlTime += logStats.MysqlResponseTime
+		entry.CacheHits += logStats.CacheHits
+		entry.CacheMisses += logStats.CacheMisses
+	}
+}
+
+// counts returns the value of a field for each table.
+func (ts *TableStats) counts(field func(*tableStatsEntry) int64) map[string]int64 {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	counts := make(map[string]int64, len(ts.tables))
+	for table, entry := range ts.tables {
+		counts[table] = field(entry)
+	}
+	return counts
+}
+
+// Publish exports the stats by table, as the vars with the given prefix.
+func (ts *TableStats) Publish(prefix string) {
+	stats.Publish(prefix+"Queries", stats.CountersFunc(func() map[string]int64 {
+		return ts.counts(func(e *tableStatsEntry) int64 { return e.Queries })
+	}))
+	stats.Publish(prefix+"RowsAffected", stats.CountersFunc(func() map[string]int64 {
+		return ts.counts(func(e *tableStatsEntry) int64 { return e.RowsAffected })
+	}))
+	stats.Publish(prefix+"TotalTimeNs", stats.CountersFunc(func() map[string]int64 {
+		return ts.counts(func(e *tableStatsEntry) int64 { return int64(e.TotalTime) })
+	}))
+	stats.Publish(prefix+"MysqlTimeNs", stats.CountersFunc(func() map[string]int64 {
+		return ts.counts(func(e *tableStatsEntry) int64 { return int64(e.MysqlTime) })
+	}))
+	stats.Publish(prefix+"CacheHits", stats.CountersFunc(func() map[string]int64 {
+		return ts.counts(func(e *tableStatsEntry) int64 { return e.CacheHits })
+	}))
+	stats.Publish(prefix+"CacheMisses", stats.CountersFunc(func() map[string]int64 {
+		return ts.counts(func(e *tableStatsEntry) int64 { return e.CacheMisses })
+	}))
+}
+
+// ServeHTTP serves the stats by table as JSON. The times are in
+// nanoseconds.
+func (ts *TableStats) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := acl.CheckAccessHTTP(r, acl.DEBUGGING); err != nil {
+		acl.SendError(w, err)
+		return
+	}
+	ts.mu.Lock()
+	b, err := json.MarshalIndent(ts.tables, "", " ")
+	ts.mu.Unlock()
+	if err != nil {
+		log.Errorf("Cannot marshal table stats: %v", err)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Write(b)
+}
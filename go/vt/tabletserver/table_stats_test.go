@@ -0,0 +1,90 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tabletserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+func newTableLogStats(rowsAffected int, cacheHits int64, tables ...string) *LogStats {
+	logStats := newLogStats("test", context.Background())
+	logStats.TableNames = tables
+	logStats.RowsAffected = rowsAffected
+	logStats.CacheHits = cacheHits
+	logStats.CacheMisses = 1
+	logStats.MysqlResponseTime = time.Millisecond
+	return logStats
+}
+
+func TestTableStats(t *testing.T) {
+	ts := NewTableStats(2)
+	ts.Add(newTableLogStats(1, 2, "a"), 2*time.Millisecond)
+	ts.Add(newTableLogStats(3, 4, "a", "b"), 2*time.Millisecond)
+	ts.Add(newTableLogStats(5, 6, "c"), 2*time.Millisecond)
+	ts.Add(newTableLogStats(7, 8, "d"), 2*time.Millisecond)
+	// Queries without tables are not counted.
+	ts.Add(newTableLogStats(9, 10), 2*time.Millisecond)
+
+	testcases := []struct {
+		name  string
+		field func(*tableStatsEntry) int64
+		want  map[string]int64
+	}{{
+		name:  "Queries",
+		field: func(e *tableStatsEntry) int64 { return e.Queries },
+		want:  map[string]int64{"a": 2, "b": 1, "<other>": 2},
+	}, {
+		name:  "RowsAffected",
+		field: func(e *tableStatsEntry) int64 { return e.RowsAffected },
+		want:  map[string]int64{"a": 4, "b": 3, "<other>": 12},
+	}, {
+		name:  "TotalTime",
+		field: func(e *tableStatsEntry) int64 { return int64(e.TotalTime) },
+		want:  map[string]int64{"a": int64(4 * time.Millisecond), "b": int64(2 * time.Millisecond), "<other>": int64(4 * time.Millisecond)},
+	}, {
+		name:  "MysqlTime",
+		field: func(e *tableStatsEntry) int64 { return int64(e.MysqlTime) },
+		want:  map[string]int64{"a": int64(2 * time.Millisecond), "b": int64(time.Millisecond), "<other>": int64(2 * time.Millisecond)},
+	}, {
+		name:  "CacheHits",
+		field: func(e *tableStatsEntry) int64 { return e.CacheHits },
+		want:  map[string]int64{"a": 6, "b": 4, "<other>": 14},
+	}, {
+		name:  "CacheMisses",
+		field: func(e *tableStatsEntry) int64 { return e.CacheMisses },
+		want:  map[string]int64{"a": 2, "b": 1, "<other>": 2},
+	}}
+	for _, tcase := range testcases {
+		if got := ts.counts(tcase.field); !reflect.DeepEqual(got, tcase.want) {
+			t.Errorf("%s: %v, want %v", tcase.name, got, tcase.want)
+		}
+	}
+
+	req, _ := http.NewRequest("GET", "/debug/table_stats", nil)
+	w := httptest.NewRecorder()
+	ts.ServeHTTP(w, req)
+	var got map[string]tableStatsEntry
+	if err := json.Unmarshal(w.Body.Bytes(), &got); err != nil {
+		t.Fatalf("json.Unmarshal(%s): %v", w.Body.String(), err)
+	}
+	want := tableStatsEntry{
+		Queries:      1,
+		RowsAffected: 3,
+		TotalTime:    2 * time.Millisecond,
+		MysqlTime:    time.Millisecond,
+		CacheHits:    4,
+		CacheMisses:  1,
+	}
+	if got["b"] != want {
+		t.Errorf("/debug/table_stats[b]: %+v, want %+v", got["b"], want)
+	}
+}
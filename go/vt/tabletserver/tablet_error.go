@@ -38,6 +38,8 @@ var logTxPoolFull = logutil.NewThrottledLogger("TxPoolFull", 1*time.Minute)
 type TabletError struct {
 	Message  string
 	SQLError int
+	// SQLState is the SQLSTATE value of the MySQL error, if any.
+	SQLState string
 	// ErrorCode will be used to transmit the error across RPC boundaries
 	ErrorCode vtrpcpb.ErrorCode
 }
@@ -47,6 +49,11 @@ type hasNumber interface {
 	Number() int
 }
 
+// This is how go-mysql exports its SQLSTATE
+type hasSQLState interface {
+	SQLState() string
+}
+
 // NewTabletError returns a TabletError of the given type
 func NewTabletError(errCode vtrpcpb.ErrorCode, format string, args ...interface{}) *TabletError {
 	return &TabletError{
@@ -58,7 +65,11 @@ func NewTabletError(errCode vtrpcpb.ErrorCode, format string, args ...interface{
 // NewTabletErrorSQL returns a TabletError based on the error
 func NewTabletErrorSQL(errCode vtrpcpb.ErrorCode, err error) *TabletError {
 	var errnum int
+	var sqlState string
 	errstr := err.Error()
+	if sqlErr, ok := err.(hasSQLState); ok {
+		sqlState = sqlErr.SQLState()
+	}
 	if sqlErr, ok := err.(hasNumber); ok {
 		errnum = sqlErr.Number()
 		switch errnum {
@@ -76,16 +87,79 @@ func NewTabletErrorSQL(errCode vtrpcpb.ErrorCode, err error) *TabletError {
 	return &TabletError{
 		Message:   printable(errstr),
 		SQLError:  errnum,
+		SQLState:  sqlState,
 		ErrorCode: errCode,
 	}
 }
 
+// IsRetryableAutocommit returns true if the MySQL error is expected
+// to go away if the statement is tried again, like a deadlock or a
+// lock wait timeout. MySQL rolls back the transaction in these cases,
+// so this is only safe for statements executed outside of a
+// transaction.
+func (te *TabletError) IsRetryableAutocommit() bool {
+	switch te.SQLError {
+	case mysql.ErrLockDeadlock, mysql.ErrLockWaitTimeout:
+		return true
+	}
+	return false
+}
+
+// classifyAutocommitError turns the errors of a statement executed
+// outside of a transaction that can be retried into TRANSIENT_ERROR,
+// so vtgate knows it can try again.
+func classifyAutocommitError(err error) error {
+	terr, ok := err.(*TabletError)
+	if !ok || !terr.IsRetryableAutocommit() {
+		return err
+	}
+	return &TabletError{
+		Message:   terr.Message,
+		SQLError:  terr.SQLError,
+		SQLState:  terr.SQLState,
+		ErrorCode: vtrpcpb.ErrorCode_TRANSIENT_ERROR,
+	}
+}
+
+// newPacketTooLargeError returns the error of a statement of size
+// bytes that is over the max_allowed_packet of MySQL. It's a
+// BAD_INPUT error, since retrying the statement can't succeed. The
+// message has the errno, like the MySQL errors, for the clients.
+// maxAllowedPacket is 0 if it's unknown.
+func newPacketTooLargeError(size, maxAllowedPacket int) *TabletError {
+	limit := "max_allowed_packet"
+	if maxAllowedPacket != 0 {
+		limit = fmt.Sprintf("max_allowed_packet (%d bytes)", maxAllowedPacket)
+	}
+	terr := NewTabletError(vtrpcpb.ErrorCode_BAD_INPUT, "statement of %d bytes exceeds %s (errno %d)", size, limit, mysql.ErrNetPacketTooLarge)
+	terr.SQLError = mysql.ErrNetPacketTooLarge
+	terr.SQLState = "08S01"
+	return terr
+}
+
+// isPacketTooLarge returns true if MySQL rejected a statement because
+// it's over its max_allowed_packet.
+func isPacketTooLarge(err error) bool {
+	switch err := err.(type) {
+	case *TabletError:
+		return err.SQLError == mysql.ErrNetPacketTooLarge
+	case hasNumber:
+		return err.Number() == mysql.ErrNetPacketTooLarge
+	}
+	return false
+}
+
 // PrefixTabletError attempts to add a string prefix to a TabletError,
 // while preserving its ErrorCode. If the given error is not a
 // TabletError, a new TabletError is returned with the desired ErrorCode.
 func PrefixTabletError(errCode vtrpcpb.ErrorCode, err error, prefix string) error {
 	if terr, ok := err.(*TabletError); ok {
-		return NewTabletError(terr.ErrorCode, "%s%s", prefix, terr.Message)
+		return &TabletError{
+			Message:   printable(prefix + terr.Message),
+			SQLError:  terr.SQLError,
+			SQLState:  terr.SQLState,
+			ErrorCode: terr.ErrorCode,
+		}
 	}
 	return NewTabletError(errCode, "%s%s", prefix, err)
 }
@@ -197,6 +271,9 @@ func (te *TabletError) Prefix() string {
 
 // RecordStats will record the error in the proper stat bucket
 func (te *TabletError) RecordStats(queryServiceStats *QueryServiceStats) {
+	if te.SQLError != 0 {
+		queryServiceStats.MySQLErrors.Add(strconv.Itoa(te.SQLError), 1)
+	}
 	switch te.ErrorCode {
 	case vtrpcpb.ErrorCode_QUERY_NOT_SERVED:
 		queryServiceStats.InfoErrors.Add("Retry", 1)
@@ -212,6 +289,8 @@ func (te *TabletError) RecordStats(queryServiceStats *QueryServiceStats) {
 			queryServiceStats.InfoErrors.Add("DupKey", 1)
 		case mysql.ErrLockWaitTimeout, mysql.ErrLockDeadlock:
 			queryServiceStats.ErrorStats.Add("Deadlock", 1)
+		case mysql.ErrNetPacketTooLarge:
+			queryServiceStats.ErrorStats.Add("PacketTooLarge", 1)
 		default:
 			queryServiceStats.ErrorStats.Add("Fail", 1)
 		}
@@ -219,15 +298,20 @@ func (te *TabletError) RecordStats(queryServiceStats *QueryServiceStats) {
 }
 
 func handleError(err *error, logStats *LogStats, queryServiceStats *QueryServiceStats) {
+	if logStats != nil {
+		logStats.Finish()
+	}
 	var terr *TabletError
 	defer func() {
 		if logStats != nil {
 			logStats.Error = terr
 			logStats.Send()
+			logStats.release()
 		}
 	}()
 	if x := recover(); x != nil {
-		terr, ok := x.(*TabletError)
+		var ok bool
+		terr, ok = x.(*TabletError)
 		if !ok {
 			log.Errorf("Uncaught panic:\n%v\n%s", x, tb.Stack(4))
 			terr = NewTabletError(vtrpcpb.ErrorCode_UNKNOWN_ERROR, "%v: uncaught panic", x)
@@ -31,6 +31,43 @@ func TestTabletErrorRetriableErrorTypeOverwrite(t *testing.T) {
 	}
 }
 
+func TestTabletErrorSQLState(t *testing.T) {
+	sqlErr := &sqldb.SQLError{Num: mysql.ErrLockDeadlock, State: "40001", Message: "deadlock"}
+	tabletErr := NewTabletErrorSQL(vtrpcpb.ErrorCode_UNKNOWN_ERROR, sqlErr)
+	if tabletErr.SQLError != mysql.ErrLockDeadlock || tabletErr.SQLState != "40001" {
+		t.Errorf("got errno %d, state %q, want %d, 40001", tabletErr.SQLError, tabletErr.SQLState, mysql.ErrLockDeadlock)
+	}
+	prefixed := PrefixTabletError(vtrpcpb.ErrorCode_INTERNAL_ERROR, tabletErr, "prefix: ").(*TabletError)
+	if prefixed.SQLError != mysql.ErrLockDeadlock || prefixed.SQLState != "40001" {
+		t.Errorf("PrefixTabletError: got errno %d, state %q, want %d, 40001", prefixed.SQLError, prefixed.SQLState, mysql.ErrLockDeadlock)
+	}
+}
+
+func TestTabletErrorClassifyAutocommit(t *testing.T) {
+	testcases := []struct {
+		err  error
+		want vtrpcpb.ErrorCode
+	}{{
+		err:  NewTabletErrorSQL(vtrpcpb.ErrorCode_UNKNOWN_ERROR, sqldb.NewSQLError(mysql.ErrLockDeadlock, "test")),
+		want: vtrpcpb.ErrorCode_TRANSIENT_ERROR,
+	}, {
+		err:  NewTabletErrorSQL(vtrpcpb.ErrorCode_UNKNOWN_ERROR, sqldb.NewSQLError(mysql.ErrLockWaitTimeout, "test")),
+		want: vtrpcpb.ErrorCode_TRANSIENT_ERROR,
+	}, {
+		err:  NewTabletErrorSQL(vtrpcpb.ErrorCode_UNKNOWN_ERROR, sqldb.NewSQLError(mysql.ErrDupEntry, "test")),
+		want: vtrpcpb.ErrorCode_INTEGRITY_ERROR,
+	}, {
+		err:  NewTabletError(vtrpcpb.ErrorCode_BAD_INPUT, "test"),
+		want: vtrpcpb.ErrorCode_BAD_INPUT,
+	}}
+	for _, tc := range testcases {
+		got := classifyAutocommitError(tc.err).(*TabletError)
+		if got.ErrorCode != tc.want {
+			t.Errorf("classifyAutocommitError(%v): %v, want %v", tc.err, got.ErrorCode, tc.want)
+		}
+	}
+}
+
 func TestTabletErrorMsgTooLong(t *testing.T) {
 	buf := make([]byte, 2*maxErrLen)
 	for i := 0; i < 2*maxErrLen; i++ {
@@ -160,6 +197,9 @@ func TestTabletErrorRecordStats(t *testing.T) {
 	if deadlockCounterAfter-deadlockCounterBefore != 1 {
 		t.Fatalf("sql error with SQL error mysql.ErrLockDeadlock should increase Deadlock error count by 1")
 	}
+	if count := queryServiceStats.MySQLErrors.Counts()["1213"]; count != 1 {
+		t.Fatalf("sql error with SQL error mysql.ErrLockDeadlock should increase MySQLErrors[1213] by 1, got %d", count)
+	}
 
 	tabletErr = NewTabletErrorSQL(vtrpcpb.ErrorCode_UNKNOWN_ERROR, sqldb.NewSQLError(mysql.ErrOptionPreventsStatement, "test"))
 	failCounterBefore := queryServiceStats.ErrorStats.Counts()["Fail"]
@@ -168,6 +208,37 @@ func TestTabletErrorRecordStats(t *testing.T) {
 	if failCounterAfter-failCounterBefore != 1 {
 		t.Fatalf("sql error with SQL error mysql.ErrOptionPreventsStatement should increase Fail error count by 1")
 	}
+
+	tabletErr = newPacketTooLargeError(2000, 1024)
+	packetTooLargeCounterBefore := queryServiceStats.ErrorStats.Counts()["PacketTooLarge"]
+	tabletErr.RecordStats(queryServiceStats)
+	packetTooLargeCounterAfter := queryServiceStats.ErrorStats.Counts()["PacketTooLarge"]
+	if packetTooLargeCounterAfter-packetTooLargeCounterBefore != 1 {
+		t.Fatalf("sql error with SQL error mysql.ErrNetPacketTooLarge should increase PacketTooLarge error count by 1")
+	}
+}
+
+func TestTabletErrorPacketTooLarge(t *testing.T) {
+	tabletErr := newPacketTooLargeError(2000, 1024)
+	if want := "error: statement of 2000 bytes exceeds max_allowed_packet (1024 bytes) (errno 1153)"; tabletErr.Error() != want {
+		t.Errorf("error: %v, want %v", tabletErr, want)
+	}
+	if tabletErr.ErrorCode != vtrpcpb.ErrorCode_BAD_INPUT {
+		t.Errorf("error code: %v, want %v", tabletErr.ErrorCode, vtrpcpb.ErrorCode_BAD_INPUT)
+	}
+	if want := "error: statement of 2000 bytes exceeds max_allowed_packet (errno 1153)"; newPacketTooLargeError(2000, 0).Error() != want {
+		t.Errorf("error with an unknown max_allowed_packet: %v, want %v", newPacketTooLargeError(2000, 0), want)
+	}
+
+	if !isPacketTooLarge(sqldb.NewSQLError(mysql.ErrNetPacketTooLarge, "Got a packet bigger than 'max_allowed_packet' bytes")) {
+		t.Errorf("isPacketTooLarge(ErrNetPacketTooLarge): false, want true")
+	}
+	if !isPacketTooLarge(tabletErr) {
+		t.Errorf("isPacketTooLarge(%v): false, want true", tabletErr)
+	}
+	if isPacketTooLarge(sqldb.NewSQLError(mysql.ErrDupEntry, "test")) {
+		t.Errorf("isPacketTooLarge(ErrDupEntry): true, want false")
+	}
 }
 
 func TestTabletErrorHandleUncaughtError(t *testing.T) {
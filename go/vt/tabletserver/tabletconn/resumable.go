@@ -0,0 +1,101 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tabletconn
+
+import (
+	"io"
+
+	"github.com/youtube/vitess/go/sqltypes"
+	"golang.org/x/net/context"
+
+	vtrpcpb "github.com/youtube/vitess/go/vt/proto/vtrpc"
+)
+
+// ResumableResultStream is the stream of StreamExecuteResumable.
+type ResumableResultStream interface {
+	// Recv returns the next result, and the token that resumes
+	// the stream after it, nil if the result has no rows. It
+	// returns io.EOF at the end of the stream.
+	Recv() (*sqltypes.Result, []byte, error)
+}
+
+// IsResumable returns true if a resumable stream that failed with
+// err can be resumed: the connection to the tablet broke, or the
+// tablet stopped serving, e.g. because it's being restarted.
+func IsResumable(err error) bool {
+	switch err := err.(type) {
+	case OperationalError:
+		return err != Cancelled
+	case *ServerError:
+		return err.ServerCode == vtrpcpb.ErrorCode_QUERY_NOT_SERVED || err.ServerCode == vtrpcpb.ErrorCode_TRANSIENT_ERROR
+	}
+	return false
+}
+
+// StreamResumed streams query with StreamExecuteResumable, on the
+// connection returned by getConn. When the stream fails with an error
+// it can be resumed after, it's resumed on a new connection of getConn
+// after the last result received, at most maxResumes times. sendReply
+// gets the results as a single stream: the fields are only sent once.
+func StreamResumed(ctx context.Context, getConn func(context.Context) (TabletConn, error), query string, bindVars map[string]interface{}, maxResumes int, sendReply func(*sqltypes.Result) error) error {
+	rs := &resumedStream{sendReply: sendReply}
+	for resumes := 0; ; resumes++ {
+		conn, err := getConn(ctx)
+		if err != nil {
+			return err
+		}
+		err = rs.stream(ctx, conn, query, bindVars)
+		if err == nil || rs.replyErr || !IsResumable(err) || resumes == maxResumes || ctx.Err() != nil {
+			return err
+		}
+	}
+}
+
+// resumedStream is the state of StreamResumed across the resumes.
+type resumedStream struct {
+	sendReply func(*sqltypes.Result) error
+
+	// resumeToken is the token of the last result sent.
+	resumeToken []byte
+	// fieldsSent is true once a result with fields was sent.
+	fieldsSent bool
+	// replyErr is true if the error of stream is the one of sendReply.
+	replyErr bool
+}
+
+func (rs *resumedStream) stream(ctx context.Context, conn TabletConn, query string, bindVars map[string]interface{}) error {
+	stream, err := conn.StreamExecuteResumable(ctx, query, bindVars, rs.resumeToken)
+	if err != nil {
+		return err
+	}
+	for {
+		result, resumeToken, err := stream.Recv()
+		switch err {
+		case nil:
+		case io.EOF:
+			return nil
+		default:
+			return err
+		}
+		if result.Fields != nil {
+			if rs.fieldsSent {
+				if len(result.Rows) == 0 {
+					continue
+				}
+				withoutFields := *result
+				withoutFields.Fields = nil
+				result = &withoutFields
+			}
+			rs.fieldsSent = true
+		}
+		if err := rs.sendReply(result); err != nil {
+			rs.replyErr = true
+			return err
+		}
+		if resumeToken != nil {
+			rs.resumeToken = resumeToken
+		}
+	}
+}
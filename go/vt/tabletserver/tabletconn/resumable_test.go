@@ -0,0 +1,115 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tabletconn
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/youtube/vitess/go/sqltypes"
+	"golang.org/x/net/context"
+
+	querypb "github.com/youtube/vitess/go/vt/proto/query"
+	vtrpcpb "github.com/youtube/vitess/go/vt/proto/vtrpc"
+)
+
+var resumableFields = []*querypb.Field{{Name: "id", Type: sqltypes.Int64}}
+
+// resumableConn streams the rows whose id is after the resume token,
+// one per result, and breaks with err after sending breakAfter rows.
+type resumableConn struct {
+	TabletConn
+	rows       int
+	breakAfter int
+	err        error
+}
+
+func (conn *resumableConn) StreamExecuteResumable(ctx context.Context, query string, bindVars map[string]interface{}, resumeToken []byte) (ResumableResultStream, error) {
+	next := 1
+	if resumeToken != nil {
+		last, err := strconv.Atoi(string(resumeToken))
+		if err != nil {
+			return nil, err
+		}
+		next = last + 1
+	}
+	return &resumableStream{conn: conn, next: next}, nil
+}
+
+type resumableStream struct {
+	conn       *resumableConn
+	next       int
+	sent       int
+	fieldsSent bool
+}
+
+func (rs *resumableStream) Recv() (*sqltypes.Result, []byte, error) {
+	if !rs.fieldsSent {
+		rs.fieldsSent = true
+		return &sqltypes.Result{Fields: resumableFields}, nil, nil
+	}
+	if rs.sent == rs.conn.breakAfter {
+		return nil, nil, rs.conn.err
+	}
+	if rs.next > rs.conn.rows {
+		return nil, nil, io.EOF
+	}
+	id := strconv.Itoa(rs.next)
+	rs.next++
+	rs.sent++
+	return &sqltypes.Result{Rows: [][]sqltypes.Value{{sqltypes.MakeTrusted(sqltypes.Int64, []byte(id))}}}, []byte(id), nil
+}
+
+func TestStreamResumed(t *testing.T) {
+	conn := &resumableConn{
+		rows:       5,
+		breakAfter: 2,
+		err:        OperationalError("connection reset"),
+	}
+	getConn := func(context.Context) (TabletConn, error) { return conn, nil }
+	var results []*sqltypes.Result
+	sendReply := func(result *sqltypes.Result) error {
+		results = append(results, result)
+		return nil
+	}
+	if err := StreamResumed(context.Background(), getConn, "select id from t", nil, 2, sendReply); err != nil {
+		t.Fatalf("StreamResumed: %v", err)
+	}
+	want := []*sqltypes.Result{{Fields: resumableFields}}
+	for id := 1; id <= 5; id++ {
+		want = append(want, &sqltypes.Result{Rows: [][]sqltypes.Value{{sqltypes.MakeTrusted(sqltypes.Int64, []byte(strconv.Itoa(id)))}}})
+	}
+	if !reflect.DeepEqual(results, want) {
+		t.Errorf("StreamResumed results: %v, want %v", results, want)
+	}
+
+	// The stream is resumed at most maxResumes times.
+	results = nil
+	err := StreamResumed(context.Background(), getConn, "select id from t", nil, 1, sendReply)
+	if err != conn.err {
+		t.Errorf("StreamResumed with 1 resume: %v, want %v", err, conn.err)
+	}
+	if len(results) != 5 {
+		t.Errorf("StreamResumed with 1 resume: %v results, want 5", len(results))
+	}
+
+	// The errors the stream can't be resumed after, and the errors
+	// of sendReply, are returned.
+	conn.err = &ServerError{Err: "syntax error", ServerCode: vtrpcpb.ErrorCode_BAD_INPUT}
+	results = nil
+	err = StreamResumed(context.Background(), getConn, "select id from t", nil, 2, sendReply)
+	if err != conn.err || len(results) != 3 {
+		t.Errorf("StreamResumed with a BAD_INPUT error: %v and %v results, want %v and 3", err, len(results), conn.err)
+	}
+	errReply := errors.New("reply error")
+	conn.err = OperationalError("connection reset")
+	err = StreamResumed(context.Background(), getConn, "select id from t", nil, 2, func(*sqltypes.Result) error { return errReply })
+	if err != errReply {
+		t.Errorf("StreamResumed with a sendReply error: %v, want %v", err, errReply)
+	}
+}
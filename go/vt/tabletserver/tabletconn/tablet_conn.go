@@ -83,6 +83,24 @@ type TabletConn interface {
 	// ExecuteBatch executes a group of queries.
 	ExecuteBatch(ctx context.Context, queries []querytypes.BoundQuery, asTransaction bool, transactionID int64) ([]sqltypes.Result, error)
 
+	// WaitForGTID waits until vttablet has replicated up to gtid.
+	WaitForGTID(ctx context.Context, gtid string) error
+
+	// XAStart starts an XA transaction, and returns its transaction id.
+	XAStart(ctx context.Context, xid string) (transactionID int64, err error)
+	// XAPrepare prepares an XA transaction.
+	XAPrepare(ctx context.Context, xid string) error
+	// XACommit commits an XA transaction.
+	XACommit(ctx context.Context, xid string) error
+	// XARollback rolls back an XA transaction.
+	XARollback(ctx context.Context, xid string) error
+	// XARecover returns the xids of the prepared XA transactions.
+	XARecover(ctx context.Context) ([]string, error)
+
+	// HotQueries returns the most executed SELECT queries of the
+	// tablet, the most executed first.
+	HotQueries(ctx context.Context) ([]querytypes.BoundQuery, error)
+
 	// StreamExecute executes a streaming query on vttablet. It
 	// returns a sqltypes.ResultStream to get results from. If
 	// error is non-nil, it means that the StreamExecute failed to
@@ -90,6 +108,12 @@ type TabletConn interface {
 	// ResultStream until io.EOF, or any other error.
 	StreamExecute(ctx context.Context, query string, bindVars map[string]interface{}) (sqltypes.ResultStream, error)
 
+	// StreamExecuteResumable is like StreamExecute, but each result
+	// with rows comes with a resume token: given back as resumeToken
+	// for the same query, it resumes the stream after that result.
+	// See StreamResumed to use it.
+	StreamExecuteResumable(ctx context.Context, query string, bindVars map[string]interface{}, resumeToken []byte) (ResumableResultStream, error)
+
 	// Transaction support
 	Begin(ctx context.Context) (transactionID int64, err error)
 	Commit(ctx context.Context, transactionID int64) error
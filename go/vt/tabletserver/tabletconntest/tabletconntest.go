@@ -17,6 +17,8 @@ import (
 
 	"github.com/youtube/vitess/go/sqltypes"
 	"github.com/youtube/vitess/go/vt/callerid"
+	"github.com/youtube/vitess/go/vt/rowversion"
+	"github.com/youtube/vitess/go/vt/sessiongtid"
 	"github.com/youtube/vitess/go/vt/tabletserver"
 	"github.com/youtube/vitess/go/vt/tabletserver/querytypes"
 	"github.com/youtube/vitess/go/vt/tabletserver/tabletconn"
@@ -45,6 +47,12 @@ type FakeQueryService struct {
 
 	// expectedTransactionID is what transactionID to expect for Execute
 	expectedTransactionID int64
+
+	// expectedWaitGTID is what GTID to expect Execute to wait for
+	expectedWaitGTID string
+
+	// expectedRowVersion is what row version to expect Execute to check
+	expectedRowVersion string
 }
 
 // HandlePanic is part of the queryservice.QueryService interface
@@ -218,11 +226,16 @@ func (f *FakeQueryService) Commit(ctx context.Context, target *querypb.Target, s
 	if transactionID != commitTransactionID {
 		f.t.Errorf("Commit: invalid TransactionId: got %v expected %v", transactionID, commitTransactionID)
 	}
+	if recorder := sessiongtid.RecorderFromContext(ctx); recorder != nil {
+		recorder.GTID = commitGTID
+	}
 	return nil
 }
 
 const commitTransactionID int64 = 999044
 
+const commitGTID = "MySQL56/3e11fa47-71ca-11e1-9e33-c80aa9429562:1-23"
+
 func testCommit(t *testing.T, conn tabletconn.TabletConn, f *FakeQueryService) {
 	ctx := context.Background()
 	ctx = callerid.NewContext(ctx, testCallerID, testVTGateCallerID)
@@ -232,6 +245,19 @@ func testCommit(t *testing.T, conn tabletconn.TabletConn, f *FakeQueryService) {
 	}
 }
 
+func testCommitGTID(t *testing.T, conn tabletconn.TabletConn, f *FakeQueryService) {
+	ctx := context.Background()
+	ctx = callerid.NewContext(ctx, testCallerID, testVTGateCallerID)
+	ctx, recorder := sessiongtid.NewRecorderContext(ctx)
+	err := conn.Commit(ctx, commitTransactionID)
+	if err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+	if recorder.GTID != commitGTID {
+		t.Errorf("Unexpected GTID from Commit: got %v wanted %v", recorder.GTID, commitGTID)
+	}
+}
+
 func testCommitError(t *testing.T, conn tabletconn.TabletConn, f *FakeQueryService) {
 	f.hasError = true
 	testErrorHelper(t, f, "Commit", func(ctx context.Context) error {
@@ -303,6 +329,15 @@ func (f *FakeQueryService) Execute(ctx context.Context, target *querypb.Target,
 	if transactionID != f.expectedTransactionID {
 		f.t.Errorf("invalid Execute.TransactionId: got %v expected %v", transactionID, f.expectedTransactionID)
 	}
+	if gtid := sessiongtid.WaitGTIDFromContext(ctx); gtid != f.expectedWaitGTID {
+		f.t.Errorf("invalid Execute.WaitForGtid: got %v expected %v", gtid, f.expectedWaitGTID)
+	}
+	if token := rowversion.ExpectFromContext(ctx); token != f.expectedRowVersion {
+		f.t.Errorf("invalid Execute.ExpectRowVersion: got %v expected %v", token, f.expectedRowVersion)
+	}
+	if recorder := rowversion.RecorderFromContext(ctx); recorder != nil {
+		recorder.Token = executeRowVersion
+	}
 	return &executeQueryResult, nil
 }
 
@@ -352,6 +387,44 @@ func testExecute(t *testing.T, conn tabletconn.TabletConn, f *FakeQueryService)
 	}
 }
 
+const executeRowVersion = "1x2y3z:1b.4"
+
+func testExecuteRowVersion(t *testing.T, conn tabletconn.TabletConn, f *FakeQueryService) {
+	f.expectedTransactionID = 0
+	f.expectedRowVersion = executeRowVersion
+	ctx := context.Background()
+	ctx = callerid.NewContext(ctx, testCallerID, testVTGateCallerID)
+	ctx = rowversion.NewExpectContext(ctx, executeRowVersion)
+	ctx, recorder := rowversion.NewRecorderContext(ctx)
+	qr, err := conn.Execute(ctx, executeQuery, executeBindVars, 0)
+	f.expectedRowVersion = ""
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if !reflect.DeepEqual(*qr, executeQueryResult) {
+		t.Errorf("Unexpected result from Execute: got %v wanted %v", qr, executeQueryResult)
+	}
+	if recorder.Token != executeRowVersion {
+		t.Errorf("Unexpected row version from Execute: got %v wanted %v", recorder.Token, executeRowVersion)
+	}
+}
+
+func testExecuteWaitForGTID(t *testing.T, conn tabletconn.TabletConn, f *FakeQueryService) {
+	f.expectedTransactionID = 0
+	f.expectedWaitGTID = waitForGTIDPosition
+	ctx := context.Background()
+	ctx = callerid.NewContext(ctx, testCallerID, testVTGateCallerID)
+	ctx = sessiongtid.NewWaitContext(ctx, waitForGTIDPosition)
+	qr, err := conn.Execute(ctx, executeQuery, executeBindVars, 0)
+	f.expectedWaitGTID = ""
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if !reflect.DeepEqual(*qr, executeQueryResult) {
+		t.Errorf("Unexpected result from Execute: got %v wanted %v", qr, executeQueryResult)
+	}
+}
+
 func testExecuteError(t *testing.T, conn tabletconn.TabletConn, f *FakeQueryService) {
 	f.hasError = true
 	testErrorHelper(t, f, "Execute", func(ctx context.Context) error {
@@ -514,6 +587,64 @@ func testStreamExecute(t *testing.T, conn tabletconn.TabletConn, f *FakeQuerySer
 	}
 }
 
+// StreamExecuteResumable is part of the queryservice.QueryService interface
+func (f *FakeQueryService) StreamExecuteResumable(ctx context.Context, target *querypb.Target, sql string, bindVariables map[string]interface{}, sessionID int64, resumeToken []byte, sendReply func(*sqltypes.Result, []byte) error) error {
+	if sql != streamExecuteQuery {
+		f.t.Errorf("invalid StreamExecuteResumable.Sql: got %v expected %v", sql, streamExecuteQuery)
+	}
+	if !reflect.DeepEqual(bindVariables, streamExecuteBindVars) {
+		f.t.Errorf("invalid StreamExecuteResumable.BindVariables: got %v expected %v", bindVariables, streamExecuteBindVars)
+	}
+	if !reflect.DeepEqual(resumeToken, streamExecuteResumeToken) {
+		f.t.Errorf("invalid StreamExecuteResumable.ResumeToken: got %v expected %v", resumeToken, streamExecuteResumeToken)
+	}
+	f.checkTargetCallerID(ctx, "StreamExecuteResumable", target)
+	if err := sendReply(&streamExecuteQueryResult1, nil); err != nil {
+		f.t.Errorf("sendReply1 failed: %v", err)
+	}
+	if err := sendReply(&streamExecuteQueryResult2, streamExecuteResultResumeToken); err != nil {
+		f.t.Errorf("sendReply2 failed: %v", err)
+	}
+	return nil
+}
+
+var streamExecuteResumeToken = []byte("resume token")
+
+var streamExecuteResultResumeToken = []byte("result resume token")
+
+func testStreamExecuteResumable(t *testing.T, conn tabletconn.TabletConn, f *FakeQueryService) {
+	ctx := context.Background()
+	ctx = callerid.NewContext(ctx, testCallerID, testVTGateCallerID)
+	stream, err := conn.StreamExecuteResumable(ctx, streamExecuteQuery, streamExecuteBindVars, streamExecuteResumeToken)
+	if err != nil {
+		t.Fatalf("StreamExecuteResumable failed: %v", err)
+	}
+	qr, resumeToken, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("StreamExecuteResumable failed: cannot read result1: %v", err)
+	}
+	if len(qr.Rows) == 0 {
+		qr.Rows = nil
+	}
+	if !reflect.DeepEqual(*qr, streamExecuteQueryResult1) || len(resumeToken) != 0 {
+		t.Errorf("Unexpected result1 from StreamExecuteResumable: got %v, %v wanted %v, nil", qr, resumeToken, streamExecuteQueryResult1)
+	}
+	qr, resumeToken, err = stream.Recv()
+	if err != nil {
+		t.Fatalf("StreamExecuteResumable failed: cannot read result2: %v", err)
+	}
+	if len(qr.Fields) == 0 {
+		qr.Fields = nil
+	}
+	if !reflect.DeepEqual(*qr, streamExecuteQueryResult2) || !reflect.DeepEqual(resumeToken, streamExecuteResultResumeToken) {
+		t.Errorf("Unexpected result2 from StreamExecuteResumable: got %v, %v wanted %v, %v", qr, resumeToken, streamExecuteQueryResult2, streamExecuteResultResumeToken)
+	}
+	_, _, err = stream.Recv()
+	if err != io.EOF {
+		t.Fatalf("StreamExecuteResumable errFunc failed: %v", err)
+	}
+}
+
 func testStreamExecuteError(t *testing.T, conn tabletconn.TabletConn, f *FakeQueryService) {
 	f.hasError = true
 	testErrorHelper(t, f, "StreamExecute", func(ctx context.Context) error {
@@ -986,6 +1117,204 @@ func testStreamHealthPanics(t *testing.T, conn tabletconn.TabletConn, f *FakeQue
 	})
 }
 
+// WaitForGTID is part of the queryservice.QueryService interface
+func (f *FakeQueryService) WaitForGTID(ctx context.Context, target *querypb.Target, gtid string) error {
+	if f.hasError {
+		return f.tabletError
+	}
+	if f.panics {
+		panic(fmt.Errorf("test-triggered panic"))
+	}
+	f.checkTargetCallerID(ctx, "WaitForGTID", target)
+	if gtid != waitForGTIDPosition {
+		f.t.Errorf("WaitForGTID: invalid GTID: got %v expected %v", gtid, waitForGTIDPosition)
+	}
+	return nil
+}
+
+const waitForGTIDPosition = "MySQL56/3e11fa47-71ca-11e1-9e33-c80aa9429562:1-5"
+
+func testWaitForGTID(t *testing.T, conn tabletconn.TabletConn, f *FakeQueryService) {
+	ctx := context.Background()
+	ctx = callerid.NewContext(ctx, testCallerID, testVTGateCallerID)
+	err := conn.WaitForGTID(ctx, waitForGTIDPosition)
+	if err != nil {
+		t.Fatalf("WaitForGTID failed: %v", err)
+	}
+}
+
+func testWaitForGTIDError(t *testing.T, conn tabletconn.TabletConn, f *FakeQueryService) {
+	f.hasError = true
+	testErrorHelper(t, f, "WaitForGTID", func(ctx context.Context) error {
+		return conn.WaitForGTID(ctx, waitForGTIDPosition)
+	})
+	f.hasError = false
+}
+
+func testWaitForGTIDPanics(t *testing.T, conn tabletconn.TabletConn, f *FakeQueryService) {
+	testPanicHelper(t, f, "WaitForGTID", func(ctx context.Context) error {
+		return conn.WaitForGTID(ctx, waitForGTIDPosition)
+	})
+}
+
+// XAStart is part of the queryservice.QueryService interface
+func (f *FakeQueryService) XAStart(ctx context.Context, target *querypb.Target, sessionID int64, xid string) (int64, error) {
+	if err := f.checkXA(ctx, "XAStart", target, xid); err != nil {
+		return 0, err
+	}
+	return xaTransactionID, nil
+}
+
+// XAPrepare is part of the queryservice.QueryService interface
+func (f *FakeQueryService) XAPrepare(ctx context.Context, target *querypb.Target, sessionID int64, xid string) error {
+	return f.checkXA(ctx, "XAPrepare", target, xid)
+}
+
+// XACommit is part of the queryservice.QueryService interface
+func (f *FakeQueryService) XACommit(ctx context.Context, target *querypb.Target, sessionID int64, xid string) error {
+	return f.checkXA(ctx, "XACommit", target, xid)
+}
+
+// XARollback is part of the queryservice.QueryService interface
+func (f *FakeQueryService) XARollback(ctx context.Context, target *querypb.Target, sessionID int64, xid string) error {
+	return f.checkXA(ctx, "XARollback", target, xid)
+}
+
+// XARecover is part of the queryservice.QueryService interface
+func (f *FakeQueryService) XARecover(ctx context.Context, target *querypb.Target, sessionID int64) ([]string, error) {
+	if err := f.checkXA(ctx, "XARecover", target, xaXid); err != nil {
+		return nil, err
+	}
+	return xaRecoverXids, nil
+}
+
+// checkXA returns the error of the XA calls, or panics for them,
+// and checks their arguments.
+func (f *FakeQueryService) checkXA(ctx context.Context, name string, target *querypb.Target, xid string) error {
+	if f.hasError {
+		return f.tabletError
+	}
+	if f.panics {
+		panic(fmt.Errorf("test-triggered panic"))
+	}
+	f.checkTargetCallerID(ctx, name, target)
+	if xid != xaXid {
+		f.t.Errorf("%v: invalid xid: got %v expected %v", name, xid, xaXid)
+	}
+	return nil
+}
+
+const xaXid = "vtgate-2pc-1234"
+
+const xaTransactionID int64 = 99911
+
+var xaRecoverXids = []string{"vtgate-2pc-1234", "vtgate-2pc-1235"}
+
+// xaCalls calls the XA methods of conn in order, and returns the first
+// error.
+func xaCalls(ctx context.Context, conn tabletconn.TabletConn) (transactionID int64, xids []string, err error) {
+	if transactionID, err = conn.XAStart(ctx, xaXid); err != nil {
+		return 0, nil, err
+	}
+	for _, call := range []func(context.Context, string) error{conn.XAPrepare, conn.XACommit, conn.XARollback} {
+		if err = call(ctx, xaXid); err != nil {
+			return 0, nil, err
+		}
+	}
+	xids, err = conn.XARecover(ctx)
+	return transactionID, xids, err
+}
+
+func testXA(t *testing.T, conn tabletconn.TabletConn, f *FakeQueryService) {
+	ctx := context.Background()
+	ctx = callerid.NewContext(ctx, testCallerID, testVTGateCallerID)
+	transactionID, xids, err := xaCalls(ctx, conn)
+	if err != nil {
+		t.Fatalf("XA calls failed: %v", err)
+	}
+	if transactionID != xaTransactionID {
+		t.Errorf("Unexpected result from XAStart: got %v wanted %v", transactionID, xaTransactionID)
+	}
+	if !reflect.DeepEqual(xids, xaRecoverXids) {
+		t.Errorf("Unexpected result from XARecover: got %v wanted %v", xids, xaRecoverXids)
+	}
+}
+
+func testXAError(t *testing.T, conn tabletconn.TabletConn, f *FakeQueryService) {
+	f.hasError = true
+	testErrorHelper(t, f, "XAStart", func(ctx context.Context) error {
+		_, err := conn.XAStart(ctx, xaXid)
+		return err
+	})
+	testErrorHelper(t, f, "XARecover", func(ctx context.Context) error {
+		_, err := conn.XARecover(ctx)
+		return err
+	})
+	f.hasError = false
+}
+
+func testXAPanics(t *testing.T, conn tabletconn.TabletConn, f *FakeQueryService) {
+	testPanicHelper(t, f, "XAStart", func(ctx context.Context) error {
+		_, err := conn.XAStart(ctx, xaXid)
+		return err
+	})
+	testPanicHelper(t, f, "XACommit", func(ctx context.Context) error {
+		return conn.XACommit(ctx, xaXid)
+	})
+}
+
+// HotQueries is part of the queryservice.QueryService interface
+func (f *FakeQueryService) HotQueries(ctx context.Context, target *querypb.Target) ([]querytypes.BoundQuery, error) {
+	if f.hasError {
+		return nil, f.tabletError
+	}
+	if f.panics {
+		panic(fmt.Errorf("test-triggered panic"))
+	}
+	f.checkTargetCallerID(ctx, "HotQueries", target)
+	return hotQueries, nil
+}
+
+var hotQueries = []querytypes.BoundQuery{
+	{
+		Sql: "select * from t where id = :id",
+		BindVariables: map[string]interface{}{
+			"id": int64(17),
+		},
+	},
+	{
+		Sql: "select * from t2",
+	},
+}
+
+func testHotQueries(t *testing.T, conn tabletconn.TabletConn, f *FakeQueryService) {
+	ctx := context.Background()
+	ctx = callerid.NewContext(ctx, testCallerID, testVTGateCallerID)
+	queries, err := conn.HotQueries(ctx)
+	if err != nil {
+		t.Fatalf("HotQueries failed: %v", err)
+	}
+	if !reflect.DeepEqual(queries, hotQueries) {
+		t.Errorf("Unexpected result from HotQueries: got %v wanted %v", queries, hotQueries)
+	}
+}
+
+func testHotQueriesError(t *testing.T, conn tabletconn.TabletConn, f *FakeQueryService) {
+	f.hasError = true
+	testErrorHelper(t, f, "HotQueries", func(ctx context.Context) error {
+		_, err := conn.HotQueries(ctx)
+		return err
+	})
+	f.hasError = false
+}
+
+func testHotQueriesPanics(t *testing.T, conn tabletconn.TabletConn, f *FakeQueryService) {
+	testPanicHelper(t, f, "HotQueries", func(ctx context.Context) error {
+		_, err := conn.HotQueries(ctx)
+		return err
+	})
+}
+
 // CreateFakeServer returns the fake server for the tests
 func CreateFakeServer(t *testing.T) *FakeQueryService {
 	return &FakeQueryService{
@@ -999,13 +1328,20 @@ func TestSuite(t *testing.T, protocol string, endPoint *topodatapb.EndPoint, fak
 		// positive test cases
 		testBegin,
 		testCommit,
+		testCommitGTID,
 		testRollback,
 		testExecute,
+		testExecuteWaitForGTID,
+		testExecuteRowVersion,
 		testBeginExecute,
 		testStreamExecute,
+		testStreamExecuteResumable,
 		testExecuteBatch,
 		testBeginExecuteBatch,
 		testSplitQuery,
+		testWaitForGTID,
+		testXA,
+		testHotQueries,
 		testStreamHealth,
 
 		// error test cases
@@ -1020,6 +1356,9 @@ func TestSuite(t *testing.T, protocol string, endPoint *topodatapb.EndPoint, fak
 		testBeginExecuteBatchErrorInBegin,
 		testBeginExecuteBatchErrorInExecuteBatch,
 		testSplitQueryError,
+		testWaitForGTIDError,
+		testXAError,
+		testHotQueriesError,
 		testStreamHealthError,
 
 		// panic test cases
@@ -1032,6 +1371,9 @@ func TestSuite(t *testing.T, protocol string, endPoint *topodatapb.EndPoint, fak
 		testExecuteBatchPanics,
 		testBeginExecuteBatchPanics,
 		testSplitQueryPanics,
+		testWaitForGTIDPanics,
+		testXAPanics,
+		testHotQueriesPanics,
 		testStreamHealthPanics,
 	}
 
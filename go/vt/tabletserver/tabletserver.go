@@ -5,9 +5,11 @@
 package tabletserver
 
 import (
+	"encoding/json"
 	"fmt"
 	"math/rand"
 	"net/http"
+	"reflect"
 	"strings"
 	"sync"
 	"time"
@@ -23,8 +25,12 @@ import (
 	"github.com/youtube/vitess/go/vt/dbconfigs"
 	"github.com/youtube/vitess/go/vt/dbconnpool"
 	"github.com/youtube/vitess/go/vt/mysqlctl"
+	"github.com/youtube/vitess/go/vt/mysqlctl/replication"
 	"github.com/youtube/vitess/go/vt/schema"
+	"github.com/youtube/vitess/go/vt/servenv"
+	"github.com/youtube/vitess/go/vt/sessiongtid"
 	"github.com/youtube/vitess/go/vt/sqlparser"
+	"github.com/youtube/vitess/go/vt/tabletserver/planbuilder"
 	"github.com/youtube/vitess/go/vt/tabletserver/queryservice"
 	"github.com/youtube/vitess/go/vt/tabletserver/querytypes"
 	"github.com/youtube/vitess/go/vt/tabletserver/splitquery"
@@ -92,6 +98,11 @@ type TabletServer struct {
 	requests  sync.WaitGroup
 	begins    sync.WaitGroup
 
+	// stateReason and stateTime describe the last state transition.
+	// They're protected by mu.
+	stateReason string
+	stateTime   time.Time
+
 	// The following variables should be initialized only once
 	// before starting the tabletserver. For backward compatibility,
 	// we temporarily allow them to be changed until the migration
@@ -106,6 +117,10 @@ type TabletServer struct {
 	invalidator *RowcacheInvalidator
 	sessionID   int64
 
+	// schemaWatcher updates the schema from the DDLs in the binlogs
+	// if -schema_change_via_binlog is set.
+	schemaWatcher *SchemaChangeWatcher
+
 	// checkMySQLThrottler is used to throttle the number of
 	// requests sent to CheckMySQL.
 	checkMySQLThrottler *sync2.Semaphore
@@ -119,6 +134,13 @@ type TabletServer struct {
 	// history records changes in state for display on the status page.
 	// It has its own internal mutex.
 	history *history.History
+
+	// identityMu protects the tablet identity stamped on the
+	// query log records.
+	identityMu  sync.Mutex
+	keyspace    string
+	shard       string
+	tabletAlias string
 }
 
 // RegisterFunction is a callback type to be called when we
@@ -148,8 +170,15 @@ func NewTabletServer(config Config) *TabletServer {
 		sessionID:           Rand(),
 		history:             history.New(10),
 	}
+	slowQueryThreshold.Set(time.Duration(config.SlowQueryThreshold * 1e9))
+	setQueryLogSampleRate(config.QueryLogSampleRate)
+	StatsLogger.SetSlowThreshold(time.Duration(config.QueryLogSampleExempt * 1e9))
+	if err := SetBindVariableRedactPatterns(*queryLogRedactBindVariables); err != nil {
+		log.Fatalf("%v", err)
+	}
 	tsv.qe = NewQueryEngine(tsv, config)
 	tsv.invalidator = NewRowcacheInvalidator(config.StatsPrefix, tsv, tsv.qe, config.EnablePublishStats)
+	tsv.schemaWatcher = NewSchemaChangeWatcher(config.StatsPrefix, tsv, tsv.qe, config.EnablePublishStats)
 	if config.EnablePublishStats {
 		stats.Publish(config.StatsPrefix+"TabletState", stats.IntFunc(func() int64 {
 			tsv.mu.Lock()
@@ -158,6 +187,14 @@ func NewTabletServer(config Config) *TabletServer {
 			return state
 		}))
 		stats.Publish(config.StatsPrefix+"QueryTimeout", stats.DurationFunc(tsv.QueryTimeout.Get))
+		stats.Publish(config.StatsPrefix+"SlowQueryThreshold", stats.DurationFunc(slowQueryThreshold.Get))
+		stats.Publish(config.StatsPrefix+"QueryLogSampleRate", stats.IntFunc(queryLogSampleRate.Get))
+		stats.Publish(config.StatsPrefix+"QueryLogSampleExempt", stats.DurationFunc(StatsLogger.SlowThreshold))
+		stats.Publish(config.StatsPrefix+"QueryLogSampledOut", stats.IntFunc(StatsLogger.SampledOut))
+		stats.Publish(config.StatsPrefix+"DeadlineExceededCount", stats.IntFunc(deadlineExceededCount.Get))
+		stats.Publish(config.StatsPrefix+"QuerySourceCounts", querySourceCounts)
+		stats.Publish(config.StatsPrefix+"QuerySourceCountsByPlan", querySourceCountsByPlan)
+		stats.Publish(config.StatsPrefix+"SlowQueryGlogSuppressed", stats.IntFunc(slowQueryGlogSuppressed.Get))
 		stats.Publish(config.StatsPrefix+"BeginTimeout", stats.DurationFunc(tsv.BeginTimeout.Get))
 		stats.Publish(config.StatsPrefix+"TabletStateName", stats.StringFunc(tsv.GetState))
 	}
@@ -171,9 +208,14 @@ func (tsv *TabletServer) Register() {
 		f(tsv)
 	}
 	tsv.registerDebugHealthHandler()
+	tsv.registerNotServingHandler()
 	tsv.registerQueryzHandler()
 	tsv.registerSchemazHandler()
 	tsv.registerStreamQueryzHandlers()
+	tsv.registerQueryLogSampleHandler()
+	tsv.registerQueryLogRedactHandler()
+	tsv.registerMemoryBreakdownHandler()
+	tsv.registerTableLimitsHandler()
 }
 
 // RegisterQueryRuleSource registers ruleSource for setting query rules.
@@ -207,22 +249,26 @@ func (tsv *TabletServer) GetState() string {
 	return name
 }
 
-// setState changes the state and logs the event.
+// setState changes the state and logs the event. The reason is
+// reported by /debug/not_serving and in the errors of the queries.
 // It requires the caller to hold a lock on mu.
-func (tsv *TabletServer) setState(state int64) {
-	log.Infof("TabletServer state: %v -> %v", stateName[tsv.state], stateName[state])
+func (tsv *TabletServer) setState(state int64, reason string) {
+	log.Infof("TabletServer state: %v -> %v, reason: %v", stateName[tsv.state], stateName[state], reason)
 	tsv.state = state
+	tsv.stateReason = reason
+	tsv.stateTime = time.Now()
 	tsv.history.Add(&historyRecord{
-		Time:         time.Now(),
+		Time:         tsv.stateTime,
 		ServingState: stateName[state],
 		TabletType:   tsv.target.TabletType.String(),
+		Reason:       reason,
 	})
 }
 
 // transition obtains a lock and changes the state.
-func (tsv *TabletServer) transition(newState int64) {
+func (tsv *TabletServer) transition(newState int64, reason string) {
 	tsv.mu.Lock()
-	tsv.setState(newState)
+	tsv.setState(newState, reason)
 	tsv.mu.Unlock()
 }
 
@@ -243,9 +289,31 @@ func (tsv *TabletServer) InitDBConfig(target querypb.Target, dbconfigs dbconfigs
 	tsv.dbconfigs = dbconfigs
 	tsv.schemaOverrides = schemaOverrides
 	tsv.mysqld = mysqld
+	tsv.identityMu.Lock()
+	tsv.keyspace = target.Keyspace
+	tsv.shard = target.Shard
+	tsv.identityMu.Unlock()
 	return nil
 }
 
+// SetTabletAlias sets the tablet alias stamped on the query log records.
+func (tsv *TabletServer) SetTabletAlias(alias string) {
+	tsv.identityMu.Lock()
+	defer tsv.identityMu.Unlock()
+	tsv.tabletAlias = alias
+}
+
+// newLogStats returns a LogStats stamped with the identity of the tablet.
+func (tsv *TabletServer) newLogStats(methodName string, ctx context.Context) *LogStats {
+	logStats := newLogStats(methodName, ctx)
+	tsv.identityMu.Lock()
+	logStats.Keyspace = tsv.keyspace
+	logStats.Shard = tsv.shard
+	logStats.TabletAlias = tsv.tabletAlias
+	tsv.identityMu.Unlock()
+	return logStats
+}
+
 // StartService is a convenience function for InitDBConfig->SetServingType
 // with serving=true.
 func (tsv *TabletServer) StartService(target querypb.Target, dbconfigs dbconfigs.DBConfigs, schemaOverrides []SchemaOverride, mysqld mysqlctl.MysqlDaemon) (err error) {
@@ -255,7 +323,7 @@ func (tsv *TabletServer) StartService(target querypb.Target, dbconfigs dbconfigs
 	if err != nil {
 		return err
 	}
-	_ /* state changed */, err = tsv.SetServingType(tabletType, true, nil)
+	_ /* state changed */, err = tsv.SetServingType(tabletType, true, nil, "StartService")
 	return err
 }
 
@@ -283,11 +351,12 @@ const (
 // stops internal services as deemed necessary. The tabletType determines the
 // primary serving type, while alsoAllow specifies other tablet types that
 // should also be honored for serving.
+// The reason is recorded with the state transition.
 // Returns true if the state of QueryService or the tablet type changed.
-func (tsv *TabletServer) SetServingType(tabletType topodatapb.TabletType, serving bool, alsoAllow []topodatapb.TabletType) (bool, error) {
+func (tsv *TabletServer) SetServingType(tabletType topodatapb.TabletType, serving bool, alsoAllow []topodatapb.TabletType, reason string) (bool, error) {
 	defer tsv.ExitLameduck()
 
-	action, err := tsv.decideAction(tabletType, serving, alsoAllow)
+	action, err := tsv.decideAction(tabletType, serving, alsoAllow, reason)
 	if err != nil {
 		return false /* state did not change */, err
 	}
@@ -295,17 +364,17 @@ func (tsv *TabletServer) SetServingType(tabletType topodatapb.TabletType, servin
 	case actionNone:
 		return false /* state did not change */, nil
 	case actionFullStart:
-		return true /* state changed */, tsv.fullStart()
+		return true /* state changed */, tsv.fullStart(reason)
 	case actionServeNewType:
-		return true /* state changed */, tsv.serveNewType()
+		return true /* state changed */, tsv.serveNewType(reason)
 	case actionGracefulStop:
-		tsv.gracefulStop()
+		tsv.gracefulStop(reason)
 		return true /* state changed */, nil
 	}
 	panic("unreachable")
 }
 
-func (tsv *TabletServer) decideAction(tabletType topodatapb.TabletType, serving bool, alsoAllow []topodatapb.TabletType) (action int, err error) {
+func (tsv *TabletServer) decideAction(tabletType topodatapb.TabletType, serving bool, alsoAllow []topodatapb.TabletType, reason string) (action int, err error) {
 	tsv.mu.Lock()
 	defer tsv.mu.Unlock()
 
@@ -325,20 +394,20 @@ func (tsv *TabletServer) decideAction(tabletType topodatapb.TabletType, serving
 	switch tsv.state {
 	case StateNotConnected:
 		if serving {
-			tsv.setState(StateTransitioning)
+			tsv.setState(StateTransitioning, reason)
 			return actionFullStart, nil
 		}
 	case StateNotServing:
 		if serving {
-			tsv.setState(StateTransitioning)
+			tsv.setState(StateTransitioning, reason)
 			return actionServeNewType, nil
 		}
 	case StateServing:
 		if !serving {
-			tsv.setState(StateShuttingDown)
+			tsv.setState(StateShuttingDown, reason)
 			return actionGracefulStop, nil
 		}
-		tsv.setState(StateTransitioning)
+		tsv.setState(StateTransitioning, reason)
 		return actionServeNewType, nil
 	case StateTransitioning, StateShuttingDown:
 		return actionNone, NewTabletError(vtrpcpb.ErrorCode_INTERNAL_ERROR, "cannot SetServingType, current state: %s", tsv.state)
@@ -348,12 +417,12 @@ func (tsv *TabletServer) decideAction(tabletType topodatapb.TabletType, serving
 	return actionNone, nil
 }
 
-func (tsv *TabletServer) fullStart() (err error) {
+func (tsv *TabletServer) fullStart(reason string) (err error) {
 	defer func() {
 		if x := recover(); x != nil {
 			log.Errorf("Could not start tabletserver: %v", x)
 			tsv.qe.Close()
-			tsv.transition(StateNotConnected)
+			tsv.transition(StateNotConnected, fmt.Sprintf("could not start tabletserver: %v", x))
 			err = x.(error)
 		}
 	}()
@@ -365,15 +434,15 @@ func (tsv *TabletServer) fullStart() (err error) {
 	c.Close()
 
 	tsv.qe.Open(tsv.dbconfigs, tsv.schemaOverrides)
-	return tsv.serveNewType()
+	return tsv.serveNewType(reason)
 }
 
-func (tsv *TabletServer) serveNewType() (err error) {
+func (tsv *TabletServer) serveNewType(reason string) (err error) {
 	defer func() {
 		if x := recover(); x != nil {
 			log.Errorf("Could not start tabletserver: %v", x)
 			tsv.qe.Close()
-			tsv.transition(StateNotConnected)
+			tsv.transition(StateNotConnected, fmt.Sprintf("could not start tabletserver: %v", x))
 			err = x.(error)
 		}
 	}()
@@ -383,9 +452,17 @@ func (tsv *TabletServer) serveNewType() (err error) {
 	} else {
 		tsv.invalidator.Close()
 	}
+	if tsv.needSchemaWatcher(tsv.target) {
+		tsv.schemaWatcher.Open(tsv.dbconfigs.App.DbName, tsv.mysqld)
+	} else {
+		tsv.schemaWatcher.Close()
+	}
 	tsv.sessionID = Rand()
 	log.Infof("Session id: %d", tsv.sessionID)
-	tsv.transition(StateServing)
+	// The version tokens of the rows given as another type are not
+	// valid anymore.
+	tsv.qe.rowVersions.reset()
+	tsv.transition(StateServing, reason)
 	return nil
 }
 
@@ -397,10 +474,17 @@ func (tsv *TabletServer) needInvalidator(target querypb.Target) bool {
 	return target.TabletType != topodatapb.TabletType_MASTER
 }
 
-func (tsv *TabletServer) gracefulStop() {
+// needSchemaWatcher returns true if the schema change watcher needs to
+// be enabled. The rowcache invalidator already updates the schema from
+// the DDLs when it runs.
+func (tsv *TabletServer) needSchemaWatcher(target querypb.Target) bool {
+	return *schemaChangeViaBinlog && !tsv.needInvalidator(target)
+}
+
+func (tsv *TabletServer) gracefulStop(reason string) {
 	defer close(tsv.setTimeBomb())
 	tsv.waitForShutdown()
-	tsv.transition(StateNotServing)
+	tsv.transition(StateNotServing, reason)
 }
 
 // StopService shuts down the tabletserver to the uninitialized state.
@@ -408,6 +492,11 @@ func (tsv *TabletServer) gracefulStop() {
 // transactions to complete. Once all transactions are resolved, it shuts
 // down the rest of the services and transitions to StateNotConnected.
 func (tsv *TabletServer) StopService() {
+	tsv.stopService("StopService")
+}
+
+// stopService is StopService with the reason of the transitions.
+func (tsv *TabletServer) stopService(reason string) {
 	defer close(tsv.setTimeBomb())
 	defer logError(tsv.qe.queryServiceStats)
 
@@ -416,18 +505,19 @@ func (tsv *TabletServer) StopService() {
 		tsv.mu.Unlock()
 		return
 	}
-	tsv.setState(StateShuttingDown)
+	tsv.setState(StateShuttingDown, reason)
 	tsv.mu.Unlock()
 
 	log.Infof("Executing graceful transition to NotServing")
 	tsv.waitForShutdown()
 
 	defer func() {
-		tsv.transition(StateNotConnected)
+		tsv.transition(StateNotConnected, reason)
 	}()
 	log.Infof("Shutting down query service")
 
 	tsv.invalidator.Close()
+	tsv.schemaWatcher.Close()
 	tsv.qe.Close()
 	tsv.sessionID = Rand()
 }
@@ -490,7 +580,7 @@ func (tsv *TabletServer) CheckMySQL() {
 			return
 		}
 		log.Info("Check MySQL failed. Shutting down query service")
-		tsv.StopService()
+		tsv.stopService("MySQL is unreachable")
 	}()
 }
 
@@ -508,9 +598,11 @@ func (tsv *TabletServer) isMySQLReachable() bool {
 	case StateNotServing:
 		// Prevent transition out of this state by
 		// temporarily switching to StateTransitioning.
-		tsv.setState(StateTransitioning)
+		// The reason of the NotServing state is kept.
+		reason := tsv.stateReason
+		tsv.setState(StateTransitioning, "checking MySQL")
 		defer func() {
-			tsv.transition(StateNotServing)
+			tsv.transition(StateNotServing, reason)
 		}()
 	default:
 		tsv.mu.Unlock()
@@ -532,6 +624,19 @@ func (tsv *TabletServer) ClearQueryPlanCache() {
 	tsv.qe.schemaInfo.ClearQueryPlanCache()
 }
 
+// SetKeyRangeFilter restricts the queries to the rows of the key range
+// of filter. It's a no-op unless -enforce_key_range is set.
+func (tsv *TabletServer) SetKeyRangeFilter(filter *planbuilder.KeyRangeFilter) {
+	if !*enforceKeyRange {
+		filter = nil
+	}
+	if reflect.DeepEqual(filter, tsv.qe.schemaInfo.KeyRangeFilter()) {
+		return
+	}
+	log.Infof("Enforcing key range filter: %v", filter)
+	tsv.qe.schemaInfo.SetKeyRangeFilter(filter)
+}
+
 // QueryService returns the QueryService part of TabletServer.
 func (tsv *TabletServer) QueryService() queryservice.QueryService {
 	return tsv
@@ -560,7 +665,7 @@ func (tsv *TabletServer) GetSessionId(keyspace, shard string) (int64, error) {
 
 // Begin starts a new transaction. This is allowed only if the state is StateServing.
 func (tsv *TabletServer) Begin(ctx context.Context, target *querypb.Target, sessionID int64) (transactionID int64, err error) {
-	logStats := newLogStats("Begin", ctx)
+	logStats := tsv.newLogStats("Begin", ctx)
 	logStats.OriginalSQL = "begin"
 	defer handleError(&err, logStats, tsv.qe.queryServiceStats)
 
@@ -570,18 +675,19 @@ func (tsv *TabletServer) Begin(ctx context.Context, target *querypb.Target, sess
 	ctx, cancel := withTimeout(ctx, tsv.BeginTimeout.Get())
 	defer func(start time.Time) {
 		tsv.qe.queryServiceStats.QueryStats.Record("BEGIN", start)
+		tsv.qe.queryServiceStats.addConnWaitStats("BEGIN", logStats)
 		cancel()
 		tsv.endRequest(true)
 	}(time.Now())
 
-	transactionID = tsv.qe.txPool.Begin(ctx)
+	transactionID = tsv.qe.txPool.begin(ctx, logStats)
 	logStats.TransactionID = transactionID
 	return transactionID, nil
 }
 
 // Commit commits the specified transaction.
 func (tsv *TabletServer) Commit(ctx context.Context, target *querypb.Target, sessionID, transactionID int64) (err error) {
-	logStats := newLogStats("Commit", ctx)
+	logStats := tsv.newLogStats("Commit", ctx)
 	logStats.OriginalSQL = "commit"
 	logStats.TransactionID = transactionID
 	defer handleError(&err, logStats, tsv.qe.queryServiceStats)
@@ -597,12 +703,27 @@ func (tsv *TabletServer) Commit(ctx context.Context, target *querypb.Target, ses
 	}(time.Now())
 
 	tsv.qe.Commit(ctx, logStats, transactionID)
+	if recorder := sessiongtid.RecorderFromContext(ctx); recorder != nil {
+		recorder.GTID = tsv.masterGTID()
+	}
 	return nil
 }
 
+// masterGTID returns the replication position of MySQL after a commit.
+// Without it, the session of the commit only loses its read-your-writes
+// consistency, so the errors are logged and "" is returned.
+func (tsv *TabletServer) masterGTID() string {
+	pos, err := tsv.mysqld.MasterPosition()
+	if err != nil {
+		log.Warningf("Cannot get the replication position after a commit: %v", err)
+		return ""
+	}
+	return replication.EncodePosition(pos)
+}
+
 // Rollback rollsback the specified transaction.
 func (tsv *TabletServer) Rollback(ctx context.Context, target *querypb.Target, sessionID, transactionID int64) (err error) {
-	logStats := newLogStats("Rollback", ctx)
+	logStats := tsv.newLogStats("Rollback", ctx)
 	logStats.OriginalSQL = "rollback"
 	logStats.TransactionID = transactionID
 	defer handleError(&err, logStats, tsv.qe.queryServiceStats)
@@ -621,14 +742,148 @@ func (tsv *TabletServer) Rollback(ctx context.Context, target *querypb.Target, s
 	return nil
 }
 
+// XAStart starts the XA transaction xid. The returned transaction id is
+// used like the one of Begin, to execute the statements of the
+// transaction. The transaction can then be prepared and committed
+// with XAPrepare and XACommit, or committed in one phase by Commit.
+func (tsv *TabletServer) XAStart(ctx context.Context, target *querypb.Target, sessionID int64, xid string) (transactionID int64, err error) {
+	logStats := tsv.newLogStats("XAStart", ctx)
+	logStats.OriginalSQL = xaStatement("xa start", xid)
+	defer handleError(&err, logStats, tsv.qe.queryServiceStats)
+
+	if xid == "" {
+		return 0, NewTabletError(vtrpcpb.ErrorCode_BAD_INPUT, "XA transaction id is empty")
+	}
+	if err = tsv.startRequest(target, sessionID, true, false); err != nil {
+		return 0, err
+	}
+	ctx, cancel := withTimeout(ctx, tsv.BeginTimeout.Get())
+	defer func(start time.Time) {
+		tsv.qe.queryServiceStats.QueryStats.Record("XA_START", start)
+		tsv.qe.queryServiceStats.addConnWaitStats("XA_START", logStats)
+		cancel()
+		tsv.endRequest(true)
+	}(time.Now())
+
+	transactionID = tsv.qe.txPool.XAStart(ctx, logStats, xid)
+	logStats.TransactionID = transactionID
+	return transactionID, nil
+}
+
+// XAPrepare ends and prepares the active XA transaction xid.
+func (tsv *TabletServer) XAPrepare(ctx context.Context, target *querypb.Target, sessionID int64, xid string) (err error) {
+	logStats := tsv.newLogStats("XAPrepare", ctx)
+	logStats.OriginalSQL = xaStatement("xa prepare", xid)
+	logStats.TransactionID = tsv.qe.txPool.ActiveXATransactionID(xid)
+	defer handleError(&err, logStats, tsv.qe.queryServiceStats)
+
+	if err = tsv.startRequest(target, sessionID, false, true); err != nil {
+		return err
+	}
+	ctx, cancel := withTimeout(ctx, tsv.QueryTimeout.Get())
+	defer func(start time.Time) {
+		tsv.qe.queryServiceStats.QueryStats.Record("XA_PREPARE", start)
+		cancel()
+		tsv.endRequest(false)
+	}(time.Now())
+
+	tsv.qe.txPool.XAPrepare(ctx, xid)
+	return nil
+}
+
+// XACommit commits the XA transaction xid. A transaction that wasn't
+// prepared is committed in one phase. The prepared transactions that
+// are not active on the tablet, like the ones returned by XARecover
+// after a restart, are committed on a new connection. The rowcache
+// doesn't know their dirty rows, so it's not invalidated for them.
+func (tsv *TabletServer) XACommit(ctx context.Context, target *querypb.Target, sessionID int64, xid string) (err error) {
+	logStats := tsv.newLogStats("XACommit", ctx)
+	logStats.OriginalSQL = xaStatement("xa commit", xid)
+	transactionID := tsv.qe.txPool.ActiveXATransactionID(xid)
+	logStats.TransactionID = transactionID
+	defer handleError(&err, logStats, tsv.qe.queryServiceStats)
+
+	if err = tsv.startRequest(target, sessionID, false, true); err != nil {
+		return err
+	}
+	ctx, cancel := withTimeout(ctx, tsv.QueryTimeout.Get())
+	defer func(start time.Time) {
+		tsv.qe.queryServiceStats.QueryStats.Record("XA_COMMIT", start)
+		cancel()
+		tsv.endRequest(false)
+	}(time.Now())
+
+	if transactionID == 0 {
+		tsv.qe.txPool.XAConcludeRecovered(ctx, xid, true)
+	} else {
+		tsv.qe.Commit(ctx, logStats, transactionID)
+	}
+	if recorder := sessiongtid.RecorderFromContext(ctx); recorder != nil {
+		recorder.GTID = tsv.masterGTID()
+	}
+	return nil
+}
+
+// XARollback rolls back the XA transaction xid. Like XACommit, it
+// also rolls back the prepared transactions that are not active on
+// the tablet.
+func (tsv *TabletServer) XARollback(ctx context.Context, target *querypb.Target, sessionID int64, xid string) (err error) {
+	logStats := tsv.newLogStats("XARollback", ctx)
+	logStats.OriginalSQL = xaStatement("xa rollback", xid)
+	transactionID := tsv.qe.txPool.ActiveXATransactionID(xid)
+	logStats.TransactionID = transactionID
+	defer handleError(&err, logStats, tsv.qe.queryServiceStats)
+
+	if err = tsv.startRequest(target, sessionID, false, true); err != nil {
+		return err
+	}
+	ctx, cancel := withTimeout(ctx, tsv.QueryTimeout.Get())
+	defer func(start time.Time) {
+		tsv.qe.queryServiceStats.QueryStats.Record("XA_ROLLBACK", start)
+		cancel()
+		tsv.endRequest(false)
+	}(time.Now())
+
+	if transactionID == 0 {
+		tsv.qe.txPool.XAConcludeRecovered(ctx, xid, false)
+	} else {
+		tsv.qe.txPool.Rollback(ctx, transactionID)
+	}
+	return nil
+}
+
+// XARecover returns the xids of the prepared XA transactions of the
+// tablet, for their coordinator to commit or roll them back.
+func (tsv *TabletServer) XARecover(ctx context.Context, target *querypb.Target, sessionID int64) (xids []string, err error) {
+	logStats := tsv.newLogStats("XARecover", ctx)
+	logStats.OriginalSQL = "xa recover"
+	defer handleError(&err, logStats, tsv.qe.queryServiceStats)
+
+	if err = tsv.startRequest(target, sessionID, false, false); err != nil {
+		return nil, err
+	}
+	ctx, cancel := withTimeout(ctx, tsv.QueryTimeout.Get())
+	defer func(start time.Time) {
+		tsv.qe.queryServiceStats.QueryStats.Record("XA_RECOVER", start)
+		cancel()
+		tsv.endRequest(false)
+	}(time.Now())
+
+	return tsv.qe.txPool.XARecover(ctx), nil
+}
+
 // handleExecError handles panics during query execution and sets
 // the supplied error return value.
 func (tsv *TabletServer) handleExecError(sql string, bindVariables map[string]interface{}, err *error, logStats *LogStats) {
+	if logStats != nil {
+		logStats.Finish()
+	}
 	if x := recover(); x != nil {
 		*err = tsv.handleExecErrorNoPanic(sql, bindVariables, x, logStats)
 	}
 	if logStats != nil {
 		logStats.Send()
+		logStats.release()
 	}
 }
 
@@ -646,12 +901,21 @@ func (tsv *TabletServer) handleExecErrorNoPanic(sql string, bindVariables map[st
 		terr = NewTabletError(vtrpcpb.ErrorCode_UNKNOWN_ERROR, "%v: uncaught panic for %v", err, querytypes.QueryAsString(sql, bindVariables))
 		return terr
 	}
+	planType := ""
+	if logStats != nil {
+		planType = logStats.PlanType
+	}
 	var myError error
 	if tsv.config.TerseErrors && terr.SQLError != 0 && len(bindVariables) != 0 {
+		terseSQL := sql
+		if *redactDebugUIQueries {
+			terseSQL = redactSQL(sql, planType)
+		}
 		myError = &TabletError{
 			SQLError:  terr.SQLError,
+			SQLState:  terr.SQLState,
 			ErrorCode: terr.ErrorCode,
-			Message:   fmt.Sprintf("(errno %d) during query: %s", terr.SQLError, sql),
+			Message:   fmt.Sprintf("(errno %d) during query: %s", terr.SQLError, terseSQL),
 		}
 	} else {
 		myError = terr
@@ -686,13 +950,17 @@ func (tsv *TabletServer) handleExecErrorNoPanic(sql string, bindVariables map[st
 			logMethod = log.Infof
 		}
 	}
+	if *redactDebugUIQueries {
+		logMethod("%v: %v", redactErrorQuery(terr.Error(), planType), redactSQL(sql, planType))
+		return myError
+	}
 	logMethod("%v: %v", terr, querytypes.QueryAsString(sql, bindVariables))
 	return myError
 }
 
 // Execute executes the query and returns the result as response.
 func (tsv *TabletServer) Execute(ctx context.Context, target *querypb.Target, sql string, bindVariables map[string]interface{}, sessionID, transactionID int64) (result *sqltypes.Result, err error) {
-	logStats := newLogStats("Execute", ctx)
+	logStats := tsv.newLogStats("Execute", ctx)
 	defer tsv.handleExecError(sql, bindVariables, &err, logStats)
 
 	allowShutdown := (transactionID != 0)
@@ -705,6 +973,11 @@ func (tsv *TabletServer) Execute(ctx context.Context, target *querypb.Target, sq
 		tsv.endRequest(false)
 	}()
 
+	if gtid := sessiongtid.WaitGTIDFromContext(ctx); gtid != "" {
+		if err = tsv.waitForGTID(ctx, target, gtid); err != nil {
+			return nil, tsv.handleExecErrorNoPanic(sql, bindVariables, err, logStats)
+		}
+	}
 	if bindVariables == nil {
 		bindVariables = make(map[string]interface{})
 	}
@@ -720,8 +993,14 @@ func (tsv *TabletServer) Execute(ctx context.Context, target *querypb.Target, sq
 	}
 	result, err = qre.Execute()
 	if err != nil {
+		if transactionID == 0 {
+			err = classifyAutocommitError(err)
+		}
 		return nil, tsv.handleExecErrorNoPanic(sql, bindVariables, err, logStats)
 	}
+	if transactionID == 0 && qre.plan.PlanID.IsSelect() {
+		tsv.qe.hotQueries.Record(sql, bindVariables)
+	}
 	return result, nil
 }
 
@@ -729,7 +1008,7 @@ func (tsv *TabletServer) Execute(ctx context.Context, target *querypb.Target, sq
 // The first QueryResult will have Fields set (and Rows nil).
 // The subsequent QueryResult will have Rows set (and Fields nil).
 func (tsv *TabletServer) StreamExecute(ctx context.Context, target *querypb.Target, sql string, bindVariables map[string]interface{}, sessionID int64, sendReply func(*sqltypes.Result) error) (err error) {
-	logStats := newLogStats("StreamExecute", ctx)
+	logStats := tsv.newLogStats("StreamExecute", ctx)
 	defer tsv.handleExecError(sql, bindVariables, &err, logStats)
 
 	if err = tsv.startRequest(target, sessionID, false, false); err != nil {
@@ -756,6 +1035,40 @@ func (tsv *TabletServer) StreamExecute(ctx context.Context, target *querypb.Targ
 	return nil
 }
 
+// StreamExecuteResumable is like StreamExecute, for a stream that can
+// be resumed after any of its results with rows: each of them is sent
+// with its resume token. If resumeToken is not empty, the stream
+// resumes after the result it was sent with, and starts again with
+// the fields. Only the queries GetResumableStreamPlan accepts can be
+// resumed.
+func (tsv *TabletServer) StreamExecuteResumable(ctx context.Context, target *querypb.Target, sql string, bindVariables map[string]interface{}, sessionID int64, resumeToken []byte, sendReply func(*sqltypes.Result, []byte) error) (err error) {
+	logStats := tsv.newLogStats("StreamExecuteResumable", ctx)
+	defer tsv.handleExecError(sql, bindVariables, &err, logStats)
+
+	if err = tsv.startRequest(target, sessionID, false, false); err != nil {
+		return err
+	}
+	defer tsv.endRequest(false)
+
+	if bindVariables == nil {
+		bindVariables = make(map[string]interface{})
+	}
+	sql = stripTrailing(sql, bindVariables)
+	qre := &QueryExecutor{
+		query:    sql,
+		bindVars: bindVariables,
+		plan:     tsv.qe.schemaInfo.GetResumableStreamPlan(sql),
+		ctx:      ctx,
+		logStats: logStats,
+		qe:       tsv.qe,
+	}
+	err = qre.StreamResumable(resumeToken, sendReply)
+	if err != nil {
+		return tsv.handleExecErrorNoPanic(sql, bindVariables, err, logStats)
+	}
+	return nil
+}
+
 // ExecuteBatch executes a group of queries and returns their results as a list.
 // ExecuteBatch can be called for an existing transaction, or it can be called with
 // the AsTransaction flag which will execute all statements inside an independent
@@ -775,6 +1088,13 @@ func (tsv *TabletServer) ExecuteBatch(ctx context.Context, target *querypb.Targe
 	defer tsv.endRequest(false)
 	defer handleError(&err, nil, tsv.qe.queryServiceStats)
 
+	if gtid := sessiongtid.WaitGTIDFromContext(ctx); gtid != "" {
+		if err = tsv.waitForGTID(ctx, target, gtid); err != nil {
+			return nil, err
+		}
+		// The queries of the batch don't need to wait again.
+		ctx = sessiongtid.NewWaitContext(ctx, "")
+	}
 	if asTransaction {
 		transactionID, err = tsv.Begin(ctx, target, sessionID)
 		if err != nil {
@@ -806,12 +1126,69 @@ func (tsv *TabletServer) ExecuteBatch(ctx context.Context, target *querypb.Targe
 	return results, nil
 }
 
+// WaitForGTID waits until the tablet has replicated up to gtid, a
+// replication position returned by the Commit of the master, or until
+// the query timeout. It returns right away if the target is a master.
+func (tsv *TabletServer) WaitForGTID(ctx context.Context, target *querypb.Target, gtid string) (err error) {
+	defer handleError(&err, nil, tsv.qe.queryServiceStats)
+
+	if err = tsv.startRequest(target, 0, false, false); err != nil {
+		return err
+	}
+	ctx, cancel := withTimeout(ctx, tsv.QueryTimeout.Get())
+	defer func() {
+		cancel()
+		tsv.endRequest(false)
+	}()
+
+	return tsv.waitForGTID(ctx, target, gtid)
+}
+
+// HotQueries returns the most executed SELECT queries of the tablet
+// outside of transactions, the most executed first, each with the bind
+// variables of one of its executions.
+func (tsv *TabletServer) HotQueries(ctx context.Context, target *querypb.Target) (queries []querytypes.BoundQuery, err error) {
+	defer handleError(&err, nil, tsv.qe.queryServiceStats)
+
+	if err = tsv.startRequest(target, 0, false, false); err != nil {
+		return nil, err
+	}
+	defer tsv.endRequest(false)
+
+	return tsv.qe.hotQueries.Top(), nil
+}
+
+// waitForGTID is WaitForGTID, for a request that already started.
+func (tsv *TabletServer) waitForGTID(ctx context.Context, target *querypb.Target, gtid string) error {
+	if target != nil && target.TabletType == topodatapb.TabletType_MASTER {
+		return nil
+	}
+	pos, err := replication.DecodePosition(gtid)
+	if err != nil {
+		return NewTabletError(vtrpcpb.ErrorCode_BAD_INPUT, "invalid GTID %v: %v", gtid, err)
+	}
+	// WaitMasterPos doesn't take a context, it's given the time left
+	// until the deadline, if any.
+	var timeout time.Duration
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = deadline.Sub(time.Now())
+		if timeout <= 0 {
+			return NewTabletError(vtrpcpb.ErrorCode_DEADLINE_EXCEEDED, "deadline exceeded before waiting for GTID %v", gtid)
+		}
+	}
+	defer tsv.qe.queryServiceStats.QueryStats.Record("WAIT_FOR_GTID", time.Now())
+	if err := tsv.mysqld.WaitMasterPos(pos, timeout); err != nil {
+		return NewTabletError(vtrpcpb.ErrorCode_TRANSIENT_ERROR, "cannot wait for GTID %v: %v", gtid, err)
+	}
+	return nil
+}
+
 // SplitQuery splits a query + bind variables into smaller queries that return a
 // subset of rows from the original query.
 // TODO(erez): Remove this method and rename SplitQueryV2 to SplitQuery once we migrate to
 // SplitQuery V2.
 func (tsv *TabletServer) SplitQuery(ctx context.Context, target *querypb.Target, sql string, bindVariables map[string]interface{}, splitColumn string, splitCount int64, sessionID int64) (splits []querytypes.QuerySplit, err error) {
-	logStats := newLogStats("SplitQuery", ctx)
+	logStats := tsv.newLogStats("SplitQuery", ctx)
 	logStats.OriginalSQL = sql
 	logStats.BindVariables = bindVariables
 	defer handleError(&err, logStats, tsv.qe.queryServiceStats)
@@ -839,6 +1216,7 @@ func (tsv *TabletServer) SplitQuery(ctx context.Context, target *querypb.Target,
 		logStats: logStats,
 		qe:       tsv.qe,
 	}
+	defer qre.releaseResults()
 	columnType, err := getColumnType(qre, splitter.splitColumn, splitter.tableName)
 	if err != nil {
 		return nil, err
@@ -872,7 +1250,7 @@ func (tsv *TabletServer) SplitQueryV2(
 	algorithm querypb.SplitQueryRequest_Algorithm,
 	sessionID int64,
 ) (splits []querytypes.QuerySplit, err error) {
-	logStats := newLogStats("SplitQuery", ctx)
+	logStats := tsv.newLogStats("SplitQuery", ctx)
 	logStats.OriginalSQL = sql
 	logStats.BindVariables = bindVariables
 	defer handleError(&err, logStats, tsv.qe.queryServiceStats)
@@ -1030,6 +1408,7 @@ func newSplitQuerySQLExecuter(
 
 func (se *splitQuerySQLExecuter) done() {
 	se.conn.Recycle()
+	se.queryExecutor.releaseResults()
 }
 
 // SQLExecute is part of the SQLExecuter interface.
@@ -1127,6 +1506,13 @@ func (tsv *TabletServer) BroadcastHealth(terTimestamp int64, stats *querypb.Real
 	tsv.mu.Lock()
 	target := tsv.target
 	tsv.mu.Unlock()
+	if missing := tsv.qe.schemaInfo.MissingPrivileges(); len(missing) != 0 && stats != nil && stats.HealthError == "" {
+		// The queries would fail on the missing privileges: report
+		// them to the healthcheck instead.
+		withPrivileges := *stats
+		withPrivileges.HealthError = fmt.Sprintf("missing MySQL privileges: %v", strings.Join(missing, "; "))
+		stats = &withPrivileges
+	}
 	shr := &querypb.StreamHealthResponse{
 		Target:  &target,
 		Serving: tsv.IsServing(),
@@ -1160,7 +1546,7 @@ func (tsv *TabletServer) startRequest(target *querypb.Target, sessionID int64, i
 	if (isBegin || allowShutdown) && tsv.state == StateShuttingDown {
 		goto verifySession
 	}
-	return NewTabletError(vtrpcpb.ErrorCode_QUERY_NOT_SERVED, "operation not allowed in state %s", stateName[tsv.state])
+	return NewTabletError(vtrpcpb.ErrorCode_QUERY_NOT_SERVED, "operation not allowed in state %s, reason: %s", stateName[tsv.state], tsv.stateReason)
 
 verifySession:
 	if target != nil {
@@ -1218,6 +1604,73 @@ func (tsv *TabletServer) registerDebugHealthHandler() {
 	})
 }
 
+// notServingStatus is reported by /debug/not_serving.
+type notServingStatus struct {
+	State      string
+	Lameduck   bool
+	TabletType string
+	AlsoAllow  []string
+	// LastTransition is the last state transition.
+	LastTransition struct {
+		State  string
+		Reason string
+		Time   time.Time
+	}
+	// Health is the last result of the health reporter,
+	// it's nil until the first one is broadcast.
+	Health *querypb.RealtimeStats
+	// QueryRules contains the query rules of each source, including
+	// the blacklisted tables.
+	QueryRules *QueryRuleInfo
+	// MissingPrivileges are the MySQL privileges the app and dba
+	// users miss. The tablet keeps serving, but degraded.
+	MissingPrivileges []string
+}
+
+// getNotServingStatus returns the reasons the tablet may not be serving.
+func (tsv *TabletServer) getNotServingStatus() *notServingStatus {
+	status := &notServingStatus{
+		Lameduck:          tsv.lameduck.Get() != 0,
+		QueryRules:        tsv.qe.schemaInfo.queryRuleSources,
+		MissingPrivileges: tsv.qe.schemaInfo.MissingPrivileges(),
+	}
+	tsv.mu.Lock()
+	status.State = stateName[tsv.state]
+	status.TabletType = tsv.target.TabletType.String()
+	for _, tabletType := range tsv.alsoAllow {
+		status.AlsoAllow = append(status.AlsoAllow, tabletType.String())
+	}
+	status.LastTransition.State = stateName[tsv.state]
+	status.LastTransition.Reason = tsv.stateReason
+	status.LastTransition.Time = tsv.stateTime
+	tsv.mu.Unlock()
+	if status.Lameduck {
+		status.State = "NOT_SERVING"
+	}
+	tsv.streamHealthMutex.Lock()
+	if tsv.lastStreamHealthResponse != nil {
+		status.Health = tsv.lastStreamHealthResponse.RealtimeStats
+	}
+	tsv.streamHealthMutex.Unlock()
+	return status
+}
+
+func (tsv *TabletServer) registerNotServingHandler() {
+	http.HandleFunc("/debug/not_serving", func(w http.ResponseWriter, r *http.Request) {
+		if err := acl.CheckAccessHTTP(r, acl.MONITORING); err != nil {
+			acl.SendError(w, err)
+			return
+		}
+		b, err := json.MarshalIndent(tsv.getNotServingStatus(), "", " ")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Write(b)
+	})
+}
+
 func (tsv *TabletServer) registerQueryzHandler() {
 	http.HandleFunc("/queryz", func(w http.ResponseWriter, r *http.Request) {
 		queryzHandler(tsv.qe.schemaInfo, w, r)
@@ -1228,8 +1681,35 @@ func (tsv *TabletServer) registerStreamQueryzHandlers() {
 	http.HandleFunc("/streamqueryz", func(w http.ResponseWriter, r *http.Request) {
 		streamQueryzHandler(tsv.qe.streamQList, w, r)
 	})
-	http.HandleFunc("/streamqueryz/terminate", func(w http.ResponseWriter, r *http.Request) {
+	http.HandleFunc("/streamqueryz/terminate", servenv.AuditHTTP("StreamQueryzTerminate", func(w http.ResponseWriter, r *http.Request) {
 		streamQueryzTerminateHandler(tsv.qe.streamQList, w, r)
+	}))
+}
+
+func (tsv *TabletServer) registerQueryLogSampleHandler() {
+	setHandler := servenv.AuditHTTP("SetQueryLogSample", func(w http.ResponseWriter, r *http.Request) {
+		queryLogSampleHandler(tsv, w, r)
+	})
+	http.HandleFunc("/debug/querylog_sample", func(w http.ResponseWriter, r *http.Request) {
+		// Only the requests that change the settings are audited.
+		if r.FormValue("rate") == "" && r.FormValue("exempt") == "" && r.FormValue("slow_query_threshold") == "" {
+			queryLogSampleHandler(tsv, w, r)
+			return
+		}
+		setHandler(w, r)
+	})
+}
+
+func (tsv *TabletServer) registerQueryLogRedactHandler() {
+	setHandler := servenv.AuditHTTP("SetQueryLogRedact", queryLogRedactHandler)
+	http.HandleFunc("/debug/querylog_redact", func(w http.ResponseWriter, r *http.Request) {
+		// Only the requests that change the patterns are audited.
+		r.ParseForm()
+		if _, ok := r.Form["patterns"]; !ok {
+			queryLogRedactHandler(w, r)
+			return
+		}
+		setHandler(w, r)
 	})
 }
 
@@ -1279,6 +1759,55 @@ func (tsv *TabletServer) TxTimeout() time.Duration {
 	return tsv.qe.txPool.Timeout()
 }
 
+// SetTxPoolMaxWaiters changes the max number of Begin calls that can wait
+// for a tx pool connection.
+func (tsv *TabletServer) SetTxPoolMaxWaiters(val int) {
+	tsv.qe.txPool.SetMaxWaiters(int64(val))
+}
+
+// TxPoolMaxWaiters returns the max number of Begin calls that can wait
+// for a tx pool connection.
+func (tsv *TabletServer) TxPoolMaxWaiters() int {
+	return int(tsv.qe.txPool.MaxWaiters())
+}
+
+// SetSlowQueryThreshold changes the minimum duration of successful queries
+// that are sent to the query log. /debug/querylog_sample changes it with
+// its slow_query_threshold param.
+func (tsv *TabletServer) SetSlowQueryThreshold(val time.Duration) {
+	slowQueryThreshold.Set(val)
+}
+
+// SlowQueryThreshold returns the minimum duration of successful queries
+// that are sent to the query log.
+func (tsv *TabletServer) SlowQueryThreshold() time.Duration {
+	return slowQueryThreshold.Get()
+}
+
+// SetQueryLogSampleRate changes the query log sample rate: about one
+// in val successful queries is sent to the query log. Values lower
+// than 2 disable sampling.
+func (tsv *TabletServer) SetQueryLogSampleRate(val int) {
+	setQueryLogSampleRate(val)
+}
+
+// QueryLogSampleRate returns the query log sample rate.
+func (tsv *TabletServer) QueryLogSampleRate() int {
+	return int(queryLogSampleRate.Get())
+}
+
+// SetQueryLogSampleExempt changes the duration above which queries are
+// sent to the query log regardless of sampling.
+func (tsv *TabletServer) SetQueryLogSampleExempt(val time.Duration) {
+	StatsLogger.SetSlowThreshold(val)
+}
+
+// QueryLogSampleExempt returns the duration above which queries are
+// sent to the query log regardless of sampling.
+func (tsv *TabletServer) QueryLogSampleExempt() time.Duration {
+	return StatsLogger.SlowThreshold()
+}
+
 // SetQueryCacheCap changes the pool size to the specified value.
 func (tsv *TabletServer) SetQueryCacheCap(val int) {
 	tsv.qe.schemaInfo.SetQueryCacheCap(val)
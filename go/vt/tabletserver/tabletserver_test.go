@@ -5,17 +5,27 @@
 package tabletserver
 
 import (
+	"encoding/json"
+	"errors"
 	"expvar"
+	"fmt"
 	"math/rand"
+	"net/url"
+	"reflect"
 	"strconv"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/youtube/vitess/go/mysql"
+	"github.com/youtube/vitess/go/sqldb"
 	"github.com/youtube/vitess/go/sqltypes"
+	"github.com/youtube/vitess/go/vt/mysqlctl"
+	"github.com/youtube/vitess/go/vt/mysqlctl/replication"
 	querypb "github.com/youtube/vitess/go/vt/proto/query"
 	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
 	vtrpcpb "github.com/youtube/vitess/go/vt/proto/vtrpc"
+	"github.com/youtube/vitess/go/vt/sessiongtid"
 	"github.com/youtube/vitess/go/vt/tabletserver/querytypes"
 	"github.com/youtube/vitess/go/vt/vttest/fakesqldb"
 	"golang.org/x/net/context"
@@ -42,7 +52,7 @@ func TestTabletServerGetState(t *testing.T) {
 	config := testUtils.newQueryServiceConfig()
 	tsv := NewTabletServer(config)
 	for i, state := range states {
-		tsv.setState(state)
+		tsv.setState(state, "test")
 		if stateName := tsv.GetState(); stateName != names[i] {
 			t.Errorf("GetState: %s, want %s", stateName, names[i])
 		}
@@ -95,7 +105,7 @@ func TestTabletServerAllowQueries(t *testing.T) {
 	tsv := NewTabletServer(config)
 	checkTabletServerState(t, tsv, StateNotConnected)
 	dbconfigs := testUtils.newDBConfigs(db)
-	tsv.setState(StateServing)
+	tsv.setState(StateServing, "test")
 	target := querypb.Target{TabletType: topodatapb.TabletType_MASTER}
 	err := tsv.StartService(target, dbconfigs, []SchemaOverride{}, testUtils.newMysqld(&dbconfigs))
 	tsv.StopService()
@@ -103,7 +113,7 @@ func TestTabletServerAllowQueries(t *testing.T) {
 	if err == nil || !strings.Contains(err.Error(), want) {
 		t.Fatalf("TabletServer.StartService: %v, must contain %s", err, want)
 	}
-	tsv.setState(StateShuttingDown)
+	tsv.setState(StateShuttingDown, "test")
 	err = tsv.StartService(target, dbconfigs, []SchemaOverride{}, testUtils.newMysqld(&dbconfigs))
 	if err == nil {
 		t.Fatalf("TabletServer.StartService should fail")
@@ -116,7 +126,7 @@ func TestTabletServerInitDBConfig(t *testing.T) {
 	testUtils := newTestUtils()
 	config := testUtils.newQueryServiceConfig()
 	tsv := NewTabletServer(config)
-	tsv.setState(StateServing)
+	tsv.setState(StateServing, "test")
 	target := querypb.Target{TabletType: topodatapb.TabletType_MASTER}
 	dbconfigs := testUtils.newDBConfigs(db)
 	err := tsv.InitDBConfig(target, dbconfigs, nil, nil)
@@ -124,13 +134,39 @@ func TestTabletServerInitDBConfig(t *testing.T) {
 	if err == nil || !strings.Contains(err.Error(), want) {
 		t.Errorf("InitDBConfig: %v, must contain %s", err, want)
 	}
-	tsv.setState(StateNotConnected)
+	tsv.setState(StateNotConnected, "test")
 	err = tsv.InitDBConfig(target, dbconfigs, nil, nil)
 	if err != nil {
 		t.Error(err)
 	}
 }
 
+func TestTabletServerLogStatsIdentity(t *testing.T) {
+	db := setUpTabletServerTest()
+	testUtils := newTestUtils()
+	config := testUtils.newQueryServiceConfig()
+	tsv := NewTabletServer(config)
+	// The records are stamped with empty values until the identity
+	// is known.
+	logStats := tsv.newLogStats("Execute", context.Background())
+	if logStats.Keyspace != "" || logStats.Shard != "" || logStats.TabletAlias != "" {
+		t.Errorf("identity before InitDBConfig: %v/%v/%v, want empty", logStats.Keyspace, logStats.Shard, logStats.TabletAlias)
+	}
+
+	target := querypb.Target{Keyspace: "ks", Shard: "-80", TabletType: topodatapb.TabletType_MASTER}
+	if err := tsv.InitDBConfig(target, testUtils.newDBConfigs(db), nil, nil); err != nil {
+		t.Fatal(err)
+	}
+	tsv.SetTabletAlias("cell-0000000100")
+	logStats = tsv.newLogStats("Execute", context.Background())
+	if logStats.Keyspace != "ks" || logStats.Shard != "-80" || logStats.TabletAlias != "cell-0000000100" {
+		t.Errorf("identity: %v/%v/%v, want ks/-80/cell-0000000100", logStats.Keyspace, logStats.Shard, logStats.TabletAlias)
+	}
+	if got, want := logStats.Format(url.Values{}), fmt.Sprintf("\t\"ks\"\t\"-80\"\t\"cell-0000000100\"\t0.000000\t0.000000\t\t\t%q\t\"\"\t\n", logStats.QueryID); !strings.HasSuffix(got, want) {
+		t.Errorf("Format: %q, want suffix %q", got, want)
+	}
+}
+
 func TestDecideAction(t *testing.T) {
 	db := setUpTabletServerTest()
 	testUtils := newTestUtils()
@@ -143,8 +179,8 @@ func TestDecideAction(t *testing.T) {
 		t.Error(err)
 	}
 
-	tsv.setState(StateNotConnected)
-	action, err := tsv.decideAction(topodatapb.TabletType_MASTER, false, nil)
+	tsv.setState(StateNotConnected, "test")
+	action, err := tsv.decideAction(topodatapb.TabletType_MASTER, false, nil, "test")
 	if err != nil {
 		t.Error(err)
 	}
@@ -152,8 +188,8 @@ func TestDecideAction(t *testing.T) {
 		t.Errorf("decideAction: %v, want %v", action, actionNone)
 	}
 
-	tsv.setState(StateNotConnected)
-	action, err = tsv.decideAction(topodatapb.TabletType_MASTER, true, nil)
+	tsv.setState(StateNotConnected, "test")
+	action, err = tsv.decideAction(topodatapb.TabletType_MASTER, true, nil, "test")
 	if err != nil {
 		t.Error(err)
 	}
@@ -164,8 +200,8 @@ func TestDecideAction(t *testing.T) {
 		t.Errorf("tsv.state: %v, want %v", tsv.state, StateTransitioning)
 	}
 
-	tsv.setState(StateNotServing)
-	action, err = tsv.decideAction(topodatapb.TabletType_MASTER, false, nil)
+	tsv.setState(StateNotServing, "test")
+	action, err = tsv.decideAction(topodatapb.TabletType_MASTER, false, nil, "test")
 	if err != nil {
 		t.Error(err)
 	}
@@ -173,8 +209,8 @@ func TestDecideAction(t *testing.T) {
 		t.Errorf("decideAction: %v, want %v", action, actionNone)
 	}
 
-	tsv.setState(StateNotServing)
-	action, err = tsv.decideAction(topodatapb.TabletType_MASTER, true, nil)
+	tsv.setState(StateNotServing, "test")
+	action, err = tsv.decideAction(topodatapb.TabletType_MASTER, true, nil, "test")
 	if err != nil {
 		t.Error(err)
 	}
@@ -185,8 +221,8 @@ func TestDecideAction(t *testing.T) {
 		t.Errorf("tsv.state: %v, want %v", tsv.state, StateTransitioning)
 	}
 
-	tsv.setState(StateServing)
-	action, err = tsv.decideAction(topodatapb.TabletType_MASTER, false, nil)
+	tsv.setState(StateServing, "test")
+	action, err = tsv.decideAction(topodatapb.TabletType_MASTER, false, nil, "test")
 	if err != nil {
 		t.Error(err)
 	}
@@ -197,8 +233,8 @@ func TestDecideAction(t *testing.T) {
 		t.Errorf("tsv.state: %v, want %v", tsv.state, StateShuttingDown)
 	}
 
-	tsv.setState(StateServing)
-	action, err = tsv.decideAction(topodatapb.TabletType_REPLICA, true, nil)
+	tsv.setState(StateServing, "test")
+	action, err = tsv.decideAction(topodatapb.TabletType_REPLICA, true, nil, "test")
 	if err != nil {
 		t.Error(err)
 	}
@@ -210,8 +246,8 @@ func TestDecideAction(t *testing.T) {
 	}
 	tsv.target.TabletType = topodatapb.TabletType_MASTER
 
-	tsv.setState(StateServing)
-	action, err = tsv.decideAction(topodatapb.TabletType_MASTER, true, nil)
+	tsv.setState(StateServing, "test")
+	action, err = tsv.decideAction(topodatapb.TabletType_MASTER, true, nil, "test")
 	if err != nil {
 		t.Error(err)
 	}
@@ -222,15 +258,15 @@ func TestDecideAction(t *testing.T) {
 		t.Errorf("tsv.state: %v, want %v", tsv.state, StateServing)
 	}
 
-	tsv.setState(StateTransitioning)
-	action, err = tsv.decideAction(topodatapb.TabletType_MASTER, false, nil)
+	tsv.setState(StateTransitioning, "test")
+	action, err = tsv.decideAction(topodatapb.TabletType_MASTER, false, nil, "test")
 	want := "cannot SetServingType"
 	if err == nil || !strings.Contains(err.Error(), want) {
 		t.Errorf("decideAction: %v, must contain %s", err, want)
 	}
 
-	tsv.setState(StateShuttingDown)
-	action, err = tsv.decideAction(topodatapb.TabletType_MASTER, false, nil)
+	tsv.setState(StateShuttingDown, "test")
+	action, err = tsv.decideAction(topodatapb.TabletType_MASTER, false, nil, "test")
 	want = "cannot SetServingType"
 	if err == nil || !strings.Contains(err.Error(), want) {
 		t.Errorf("decideAction: %v, must contain %s", err, want)
@@ -249,7 +285,7 @@ func TestSetServingType(t *testing.T) {
 		t.Error(err)
 	}
 
-	stateChanged, err := tsv.SetServingType(topodatapb.TabletType_REPLICA, false, nil)
+	stateChanged, err := tsv.SetServingType(topodatapb.TabletType_REPLICA, false, nil, "test")
 	if stateChanged != false {
 		t.Errorf("SetServingType() should NOT have changed the QueryService state, but did")
 	}
@@ -258,7 +294,7 @@ func TestSetServingType(t *testing.T) {
 	}
 	checkTabletServerState(t, tsv, StateNotConnected)
 
-	stateChanged, err = tsv.SetServingType(topodatapb.TabletType_REPLICA, true, nil)
+	stateChanged, err = tsv.SetServingType(topodatapb.TabletType_REPLICA, true, nil, "test")
 	if stateChanged != true {
 		t.Errorf("SetServingType() should have changed the QueryService state, but did not")
 	}
@@ -267,7 +303,7 @@ func TestSetServingType(t *testing.T) {
 	}
 	checkTabletServerState(t, tsv, StateServing)
 
-	stateChanged, err = tsv.SetServingType(topodatapb.TabletType_RDONLY, true, nil)
+	stateChanged, err = tsv.SetServingType(topodatapb.TabletType_RDONLY, true, nil, "test")
 	if stateChanged != true {
 		t.Errorf("SetServingType() should have changed the tablet type, but did not")
 	}
@@ -276,7 +312,7 @@ func TestSetServingType(t *testing.T) {
 	}
 	checkTabletServerState(t, tsv, StateServing)
 
-	stateChanged, err = tsv.SetServingType(topodatapb.TabletType_SPARE, false, nil)
+	stateChanged, err = tsv.SetServingType(topodatapb.TabletType_SPARE, false, nil, "test")
 	if stateChanged != true {
 		t.Errorf("SetServingType() should have changed the QueryService state, but did not")
 	}
@@ -290,7 +326,7 @@ func TestSetServingType(t *testing.T) {
 	if stateName := tsv.GetState(); stateName != "NOT_SERVING" {
 		t.Errorf("GetState: %s, want NOT_SERVING", stateName)
 	}
-	stateChanged, err = tsv.SetServingType(topodatapb.TabletType_REPLICA, true, nil)
+	stateChanged, err = tsv.SetServingType(topodatapb.TabletType_REPLICA, true, nil, "test")
 	if stateChanged != true {
 		t.Errorf("SetServingType() should have changed the QueryService state, but did not")
 	}
@@ -306,6 +342,128 @@ func TestSetServingType(t *testing.T) {
 	checkTabletServerState(t, tsv, StateNotConnected)
 }
 
+func TestTabletServerNotServingReason(t *testing.T) {
+	db := setUpTabletServerTest()
+	testUtils := newTestUtils()
+	config := testUtils.newQueryServiceConfig()
+	tsv := NewTabletServer(config)
+	dbconfigs := testUtils.newDBConfigs(db)
+	target := querypb.Target{TabletType: topodatapb.TabletType_REPLICA}
+	if err := tsv.StartService(target, dbconfigs, []SchemaOverride{}, testUtils.newMysqld(&dbconfigs)); err != nil {
+		t.Fatalf("StartService failed: %v", err)
+	}
+	defer tsv.StopService()
+
+	reason := "query service disabled by tablet control"
+	if _, err := tsv.SetServingType(topodatapb.TabletType_REPLICA, false, nil, reason); err != nil {
+		t.Fatal(err)
+	}
+	tsv.BroadcastHealth(0, &querypb.RealtimeStats{HealthError: "replication is not running"})
+	status := tsv.getNotServingStatus()
+	if status.State != "NOT_SERVING" || status.LastTransition.Reason != reason || status.LastTransition.Time.IsZero() {
+		t.Errorf("getNotServingStatus: %+v, want NOT_SERVING with reason %q", status, reason)
+	}
+	if status.Health == nil || status.Health.HealthError != "replication is not running" {
+		t.Errorf("getNotServingStatus().Health: %+v, want the broadcast health", status.Health)
+	}
+	if _, err := json.Marshal(status); err != nil {
+		t.Errorf("getNotServingStatus cannot be marshaled: %v", err)
+	}
+
+	// The queries get the reason in the error.
+	_, err := tsv.Execute(context.Background(), &target, "select * from test_table limit 1000", nil, tsv.sessionID, 0)
+	if err == nil || !strings.Contains(err.Error(), reason) {
+		t.Errorf("Execute: %v, must contain %q", err, reason)
+	}
+}
+
+func TestTabletServerBroadcastHealthMissingPrivileges(t *testing.T) {
+	db := setUpTabletServerTest()
+	testUtils := newTestUtils()
+	config := testUtils.newQueryServiceConfig()
+	tsv := NewTabletServer(config)
+	dbconfigs := testUtils.newDBConfigs(db)
+	target := querypb.Target{TabletType: topodatapb.TabletType_REPLICA}
+	if err := tsv.StartService(target, dbconfigs, []SchemaOverride{}, testUtils.newMysqld(&dbconfigs)); err != nil {
+		t.Fatalf("StartService failed: %v", err)
+	}
+	defer tsv.StopService()
+
+	tsv.qe.schemaInfo.mu.Lock()
+	tsv.qe.schemaInfo.missingPrivileges = []string{"dba user is missing REPLICATION CLIENT"}
+	tsv.qe.schemaInfo.mu.Unlock()
+	stats := &querypb.RealtimeStats{SecondsBehindMaster: 1}
+	tsv.BroadcastHealth(0, stats)
+	want := "missing MySQL privileges: dba user is missing REPLICATION CLIENT"
+	if got := tsv.lastStreamHealthResponse.RealtimeStats; got.HealthError != want || got.SecondsBehindMaster != 1 {
+		t.Errorf("RealtimeStats: %+v, want HealthError %q", got, want)
+	}
+	if stats.HealthError != "" {
+		t.Errorf("BroadcastHealth changed its input: %+v", stats)
+	}
+
+	// A health error of the tablet is kept.
+	tsv.BroadcastHealth(0, &querypb.RealtimeStats{HealthError: "replication is not running"})
+	if got, want := tsv.lastStreamHealthResponse.RealtimeStats.HealthError, "replication is not running"; got != want {
+		t.Errorf("HealthError: %q, want %q", got, want)
+	}
+}
+
+func TestTabletServerMemoryBreakdown(t *testing.T) {
+	db := setUpTabletServerTest()
+	testUtils := newTestUtils()
+	config := testUtils.newQueryServiceConfig()
+	tsv := NewTabletServer(config)
+	dbconfigs := testUtils.newDBConfigs(db)
+	target := querypb.Target{TabletType: topodatapb.TabletType_MASTER}
+	if err := tsv.StartService(target, dbconfigs, []SchemaOverride{}, testUtils.newMysqld(&dbconfigs)); err != nil {
+		t.Fatalf("StartService failed: %v", err)
+	}
+	defer tsv.StopService()
+
+	db.AddQuery("select * from test_table limit 1000", &sqltypes.Result{
+		RowsAffected: 1,
+		Rows:         [][]sqltypes.Value{{sqltypes.MakeString([]byte("abc"))}},
+	})
+	if _, err := tsv.Execute(context.Background(), &target, "select * from test_table limit 1000", nil, tsv.sessionID, 0); err != nil {
+		t.Fatal(err)
+	}
+	mb := tsv.getMemoryBreakdown()
+	if mb.Runtime.HeapAlloc == 0 {
+		t.Errorf("Runtime.HeapAlloc: 0, want the heap size")
+	}
+	if mb.PlanCache.Entries == 0 || mb.PlanCache.AveragePlanSize == 0 || mb.PlanCache.EstimatedBytes != mb.PlanCache.Entries*mb.PlanCache.AveragePlanSize {
+		t.Errorf("PlanCache: %+v, want the estimated size of the plans", mb.PlanCache)
+	}
+	if len(mb.ConnPools) != 3 || mb.ConnPools[0].Capacity != int64(config.PoolSize) {
+		t.Errorf("ConnPools: %+v, want the 3 pools", mb.ConnPools)
+	}
+	// The results are released when the queries return.
+	if mb.PendingResults.ResultBytes != 0 || mb.PendingResults.Streams != 0 {
+		t.Errorf("PendingResults: %+v, want none", mb.PendingResults)
+	}
+	if _, err := json.Marshal(mb); err != nil {
+		t.Errorf("getMemoryBreakdown cannot be marshaled: %v", err)
+	}
+
+	qre := &QueryExecutor{ctx: context.Background(), logStats: newLogStats("Test", context.Background()), qe: tsv.qe}
+	conn, err := qre.getConn(tsv.qe.connPool)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Recycle()
+	if _, err := qre.execSQL(conn, "select * from test_table limit 1000", true); err != nil {
+		t.Fatal(err)
+	}
+	if got := tsv.getMemoryBreakdown().PendingResults.ResultBytes; got != 3 {
+		t.Errorf("PendingResults.ResultBytes of a query being executed: %v, want 3", got)
+	}
+	qre.releaseResults()
+	if got := tsv.getMemoryBreakdown().PendingResults.ResultBytes; got != 0 {
+		t.Errorf("PendingResults.ResultBytes after releaseResults: %v, want 0", got)
+	}
+}
+
 func TestTabletServerSingleSchemaFailure(t *testing.T) {
 	db := setUpTabletServerTest()
 
@@ -321,6 +479,7 @@ func TestTabletServerSingleSchemaFailure(t *testing.T) {
 				sqltypes.MakeString([]byte("2")),
 				sqltypes.MakeString([]byte("3")),
 				sqltypes.MakeString([]byte("4")),
+				sqltypes.MakeString([]byte("Compact")),
 			},
 			// Return a table that tabletserver can't access (the mock will reject all queries to it).
 			{
@@ -332,6 +491,7 @@ func TestTabletServerSingleSchemaFailure(t *testing.T) {
 				sqltypes.MakeString([]byte("2")),
 				sqltypes.MakeString([]byte("3")),
 				sqltypes.MakeString([]byte("4")),
+				sqltypes.MakeString([]byte("Compact")),
 			},
 		},
 	}
@@ -370,6 +530,7 @@ func TestTabletServerAllSchemaFailure(t *testing.T) {
 				sqltypes.MakeString([]byte("2")),
 				sqltypes.MakeString([]byte("3")),
 				sqltypes.MakeString([]byte("4")),
+				sqltypes.MakeString([]byte("Compact")),
 			},
 			{
 				sqltypes.MakeString([]byte("rejected_table_2")),
@@ -380,6 +541,7 @@ func TestTabletServerAllSchemaFailure(t *testing.T) {
 				sqltypes.MakeString([]byte("2")),
 				sqltypes.MakeString([]byte("3")),
 				sqltypes.MakeString([]byte("4")),
+				sqltypes.MakeString([]byte("Compact")),
 			},
 		},
 	}
@@ -411,7 +573,7 @@ func TestTabletServerCheckMysql(t *testing.T) {
 	if !tsv.isMySQLReachable() {
 		t.Error("isMySQLReachable should return true")
 	}
-	stateChanged, err := tsv.SetServingType(topodatapb.TabletType_SPARE, false, nil)
+	stateChanged, err := tsv.SetServingType(topodatapb.TabletType_SPARE, false, nil, "test")
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -623,7 +785,7 @@ func TestTabletServerTarget(t *testing.T) {
 	if err == nil || !strings.Contains(err.Error(), want) {
 		t.Errorf("err: %v, must contain %s", err, want)
 	}
-	tsv.SetServingType(topodatapb.TabletType_MASTER, true, []topodatapb.TabletType{topodatapb.TabletType_REPLICA})
+	tsv.SetServingType(topodatapb.TabletType_MASTER, true, []topodatapb.TabletType{topodatapb.TabletType_REPLICA}, "test")
 	_, err = tsv.Execute(ctx, &target1, "select * from test_table limit 1000", nil, 0, 0)
 	if err != nil {
 		t.Fatal(err)
@@ -668,6 +830,95 @@ func TestTabletServerCommitTransaciton(t *testing.T) {
 	}
 }
 
+func TestTabletServerCommitGTID(t *testing.T) {
+	db := setUpTabletServerTest()
+	testUtils := newTestUtils()
+	config := testUtils.newQueryServiceConfig()
+	tsv := NewTabletServer(config)
+	dbconfigs := testUtils.newDBConfigs(db)
+	target := querypb.Target{TabletType: topodatapb.TabletType_MASTER}
+	err := tsv.StartService(target, dbconfigs, []SchemaOverride{}, testUtils.newMysqld(&dbconfigs))
+	if err != nil {
+		t.Fatalf("StartService failed: %v", err)
+	}
+	defer tsv.StopService()
+	gtid := "MySQL56/3e11fa47-71ca-11e1-9e33-c80aa9429562:1-23"
+	pos, err := replication.DecodePosition(gtid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmd := mysqlctl.NewFakeMysqlDaemon(db)
+	fmd.CurrentMasterPosition = pos
+	tsv.mysqld = fmd
+
+	ctx, recorder := sessiongtid.NewRecorderContext(context.Background())
+	transactionID, err := tsv.Begin(ctx, nil, tsv.sessionID)
+	if err != nil {
+		t.Fatalf("call TabletServer.Begin failed: %v", err)
+	}
+	if err := tsv.Commit(ctx, nil, tsv.sessionID, transactionID); err != nil {
+		t.Fatalf("call TabletServer.Commit failed: %v", err)
+	}
+	if recorder.GTID != gtid {
+		t.Errorf("GTID of the commit: %v, want %v", recorder.GTID, gtid)
+	}
+}
+
+func TestTabletServerWaitForGTID(t *testing.T) {
+	db := setUpTabletServerTest()
+	testUtils := newTestUtils()
+	executeSQL := "select * from test_table limit 1000"
+	db.AddQuery(executeSQL, &sqltypes.Result{})
+	config := testUtils.newQueryServiceConfig()
+	tsv := NewTabletServer(config)
+	dbconfigs := testUtils.newDBConfigs(db)
+	target := querypb.Target{TabletType: topodatapb.TabletType_REPLICA}
+	err := tsv.StartService(target, dbconfigs, []SchemaOverride{}, testUtils.newMysqld(&dbconfigs))
+	if err != nil {
+		t.Fatalf("StartService failed: %v", err)
+	}
+	defer tsv.StopService()
+	gtid := "MySQL56/3e11fa47-71ca-11e1-9e33-c80aa9429562:1-23"
+	pos, err := replication.DecodePosition(gtid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fmd := mysqlctl.NewFakeMysqlDaemon(db)
+	fmd.WaitMasterPosition = pos
+	tsv.mysqld = fmd
+
+	ctx := context.Background()
+	if err := tsv.WaitForGTID(ctx, &target, gtid); err != nil {
+		t.Errorf("WaitForGTID(%v): %v", gtid, err)
+	}
+	testcases := []struct {
+		gtid string
+		code vtrpcpb.ErrorCode
+	}{
+		{"MySQL56/3e11fa47-71ca-11e1-9e33-c80aa9429562:1-24", vtrpcpb.ErrorCode_TRANSIENT_ERROR},
+		{"not a gtid", vtrpcpb.ErrorCode_BAD_INPUT},
+	}
+	for _, tcase := range testcases {
+		err := tsv.WaitForGTID(ctx, &target, tcase.gtid)
+		tabletErr, ok := err.(*TabletError)
+		if !ok || tabletErr.ErrorCode != tcase.code {
+			t.Errorf("WaitForGTID(%v): %v, want error code %v", tcase.gtid, err, tcase.code)
+		}
+	}
+
+	// Execute waits for the GTID of its context.
+	if _, err := tsv.Execute(sessiongtid.NewWaitContext(ctx, gtid), &target, executeSQL, nil, tsv.sessionID, 0); err != nil {
+		t.Errorf("Execute waiting for %v: %v", gtid, err)
+	}
+	badGTID := "MySQL56/3e11fa47-71ca-11e1-9e33-c80aa9429562:1-24"
+	if _, err := tsv.Execute(sessiongtid.NewWaitContext(ctx, badGTID), &target, executeSQL, nil, tsv.sessionID, 0); err == nil {
+		t.Errorf("Execute waiting for %v: nil, want error", badGTID)
+	}
+	if _, err := tsv.ExecuteBatch(sessiongtid.NewWaitContext(ctx, badGTID), &target, []querytypes.BoundQuery{{Sql: executeSQL}}, tsv.sessionID, false, 0); err == nil {
+		t.Errorf("ExecuteBatch waiting for %v: nil, want error", badGTID)
+	}
+}
+
 func TestTabletServerRollback(t *testing.T) {
 	db := setUpTabletServerTest()
 	testUtils := newTestUtils()
@@ -702,6 +953,64 @@ func TestTabletServerRollback(t *testing.T) {
 	}
 }
 
+func TestTabletServerXA(t *testing.T) {
+	db := setUpTabletServerTest()
+	testUtils := newTestUtils()
+	// sql that will be executed in this test
+	executeSQL := "select * from test_table limit 1000"
+	executeSQLResult := &sqltypes.Result{
+		RowsAffected: 1,
+		Rows: [][]sqltypes.Value{
+			{sqltypes.MakeString([]byte("row01"))},
+		},
+	}
+	db.AddQuery(executeSQL, executeSQLResult)
+	for _, query := range []string{"xa start 'x1'", "xa end 'x1'", "xa prepare 'x1'", "xa commit 'x1'", "xa rollback 'x2'"} {
+		db.AddQuery(query, &sqltypes.Result{})
+	}
+	db.AddQuery("xa recover", &sqltypes.Result{})
+	config := testUtils.newQueryServiceConfig()
+	tsv := NewTabletServer(config)
+	dbconfigs := testUtils.newDBConfigs(db)
+	target := querypb.Target{TabletType: topodatapb.TabletType_MASTER}
+	err := tsv.StartService(target, dbconfigs, []SchemaOverride{}, testUtils.newMysqld(&dbconfigs))
+	if err != nil {
+		t.Fatalf("StartService failed: %v", err)
+	}
+	defer tsv.StopService()
+	ctx := context.Background()
+	if _, err := tsv.XAStart(ctx, nil, tsv.sessionID, ""); err == nil {
+		t.Errorf("XAStart with an empty xid should fail")
+	}
+	transactionID, err := tsv.XAStart(ctx, nil, tsv.sessionID, "x1")
+	if err != nil {
+		t.Fatalf("call TabletServer.XAStart failed: %v", err)
+	}
+	if _, err := tsv.Execute(ctx, nil, executeSQL, nil, tsv.sessionID, transactionID); err != nil {
+		t.Fatalf("failed to execute query: %s", executeSQL)
+	}
+	if err := tsv.XAPrepare(ctx, nil, tsv.sessionID, "x1"); err != nil {
+		t.Fatalf("call TabletServer.XAPrepare failed: %v", err)
+	}
+	if err := tsv.XACommit(ctx, nil, tsv.sessionID, "x1"); err != nil {
+		t.Fatalf("call TabletServer.XACommit failed: %v", err)
+	}
+	if err := tsv.XAPrepare(ctx, nil, tsv.sessionID, "x1"); err == nil {
+		t.Errorf("XAPrepare of a committed transaction should fail")
+	}
+	// x2 is not active: it's rolled back on a new connection.
+	if err := tsv.XARollback(ctx, nil, tsv.sessionID, "x2"); err != nil {
+		t.Fatalf("call TabletServer.XARollback failed: %v", err)
+	}
+	if got := db.GetQueryCalledNum("xa rollback 'x2'"); got != 1 {
+		t.Errorf("xa rollback 'x2' calls: %v, want 1", got)
+	}
+	xids, err := tsv.XARecover(ctx, nil, tsv.sessionID)
+	if err != nil || len(xids) != 0 {
+		t.Errorf("XARecover: %v, %v, want no xids", xids, err)
+	}
+}
+
 func TestTabletServerStreamExecute(t *testing.T) {
 	db := setUpTabletServerTest()
 	testUtils := newTestUtils()
@@ -732,6 +1041,110 @@ func TestTabletServerStreamExecute(t *testing.T) {
 	}
 }
 
+func TestTabletServerStreamExecuteResumable(t *testing.T) {
+	db := setUpTabletServerTest()
+	testUtils := newTestUtils()
+	var rows [][]sqltypes.Value
+	for pk := 1; pk <= 4; pk++ {
+		rows = append(rows, []sqltypes.Value{
+			sqltypes.MakeTrusted(sqltypes.Int32, []byte(strconv.Itoa(pk))),
+			sqltypes.MakeTrusted(sqltypes.Int32, []byte("10")),
+			sqltypes.MakeTrusted(sqltypes.Int32, []byte("20")),
+		})
+	}
+	sql := "select * from test_table"
+	db.AddQuery("select * from test_table order by test_table.pk asc", &sqltypes.Result{
+		RowsAffected: uint64(len(rows)),
+		Rows:         rows,
+	})
+	// The stream resumes after each of the rows.
+	for i := 1; i < len(rows); i++ {
+		db.AddQuery(fmt.Sprintf("select * from test_table where test_table.pk > %d order by test_table.pk asc", i), &sqltypes.Result{
+			RowsAffected: uint64(len(rows) - i),
+			Rows:         rows[i:],
+		})
+	}
+
+	config := testUtils.newQueryServiceConfig()
+	// Each row is sent in its own result.
+	config.StreamBufferSize = 1
+	tsv := NewTabletServer(config)
+	dbconfigs := testUtils.newDBConfigs(db)
+	target := querypb.Target{TabletType: topodatapb.TabletType_MASTER}
+	if err := tsv.StartService(target, dbconfigs, []SchemaOverride{}, testUtils.newMysqld(&dbconfigs)); err != nil {
+		t.Fatalf("StartService failed: %v", err)
+	}
+	defer tsv.StopService()
+	ctx := context.Background()
+
+	// The stream breaks after it sent broken results with rows.
+	errBroken := errors.New("broken stream")
+	for broken := 0; broken <= len(rows); broken++ {
+		var got [][]sqltypes.Value
+		var resumeToken []byte
+		results := 0
+		err := tsv.StreamExecuteResumable(ctx, nil, sql, nil, tsv.sessionID, nil, func(result *sqltypes.Result, token []byte) error {
+			if len(result.Rows) == 0 {
+				return nil
+			}
+			if results == broken {
+				return errBroken
+			}
+			results++
+			got = append(got, copyRows(result.Rows)...)
+			resumeToken = token
+			return nil
+		})
+		if broken < len(rows) && err == nil {
+			t.Errorf("broken after %d results: StreamExecuteResumable: nil, want error", broken)
+		}
+		if broken == len(rows) {
+			if err != nil {
+				t.Errorf("StreamExecuteResumable: %v", err)
+			}
+			if !reflect.DeepEqual(got, rows) {
+				t.Errorf("StreamExecuteResumable rows: %v, want %v", got, rows)
+			}
+			continue
+		}
+		fieldsSent := false
+		err = tsv.StreamExecuteResumable(ctx, nil, sql, nil, tsv.sessionID, resumeToken, func(result *sqltypes.Result, token []byte) error {
+			if result.Fields != nil {
+				fieldsSent = true
+			}
+			got = append(got, copyRows(result.Rows)...)
+			return nil
+		})
+		if err != nil {
+			t.Errorf("broken after %d results: resumed StreamExecuteResumable: %v", broken, err)
+			continue
+		}
+		if !fieldsSent {
+			t.Errorf("broken after %d results: the resumed stream didn't send the fields", broken)
+		}
+		if !reflect.DeepEqual(got, rows) {
+			t.Errorf("broken after %d results: rows: %v, want %v", broken, got, rows)
+		}
+	}
+
+	sendReply := func(*sqltypes.Result, []byte) error { return nil }
+	err := tsv.StreamExecuteResumable(ctx, nil, sql, nil, tsv.sessionID, []byte("bad token"), sendReply)
+	want := "invalid resume token"
+	if err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("StreamExecuteResumable with a bad token: %v, want %v", err, want)
+	}
+	err = tsv.StreamExecuteResumable(ctx, nil, "select * from test_table limit 10", nil, tsv.sessionID, nil, sendReply)
+	want = "cannot be resumed"
+	if err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("StreamExecuteResumable of a select with limit: %v, want %v", err, want)
+	}
+}
+
+// copyRows copies rows, whose slice the stream reuses.
+func copyRows(rows [][]sqltypes.Value) [][]sqltypes.Value {
+	return append([][]sqltypes.Value(nil), rows...)
+}
+
 func TestTabletServerExecuteBatch(t *testing.T) {
 	db := setUpTabletServerTest()
 	testUtils := newTestUtils()
@@ -801,6 +1214,43 @@ func TestTabletServerExecuteBatchFailAsTransaction(t *testing.T) {
 	verifyTabletError(t, err, vtrpcpb.ErrorCode_BAD_INPUT)
 }
 
+func TestTabletServerExecuteDeadlockIsTransient(t *testing.T) {
+	db := setUpTabletServerTest()
+	testUtils := newTestUtils()
+	sql := "select * from test_table limit 1000"
+	deadlock := &sqldb.SQLError{Num: mysql.ErrLockDeadlock, State: "40001", Message: "Deadlock found"}
+	db.AddRejectedQuery(sql, deadlock)
+
+	config := testUtils.newQueryServiceConfig()
+	tsv := NewTabletServer(config)
+	dbconfigs := testUtils.newDBConfigs(db)
+	target := querypb.Target{TabletType: topodatapb.TabletType_MASTER}
+	err := tsv.StartService(target, dbconfigs, []SchemaOverride{}, testUtils.newMysqld(&dbconfigs))
+	if err != nil {
+		t.Fatalf("StartService failed: %v", err)
+	}
+	defer tsv.StopService()
+	ctx := context.Background()
+
+	// Outside of a transaction, the deadlock can be retried.
+	_, err = tsv.Execute(ctx, nil, sql, nil, tsv.sessionID, 0)
+	verifyTabletError(t, err, vtrpcpb.ErrorCode_TRANSIENT_ERROR)
+	if terr := err.(*TabletError); terr.SQLError != mysql.ErrLockDeadlock || terr.SQLState != "40001" {
+		t.Errorf("got errno %d, state %q, want %d, 40001", terr.SQLError, terr.SQLState, mysql.ErrLockDeadlock)
+	}
+
+	// In a transaction, it can't.
+	transactionID, err := tsv.Begin(ctx, nil, tsv.sessionID)
+	if err != nil {
+		t.Fatalf("call TabletServer.Begin failed: %v", err)
+	}
+	_, err = tsv.Execute(ctx, nil, sql, nil, tsv.sessionID, transactionID)
+	verifyTabletError(t, err, vtrpcpb.ErrorCode_UNKNOWN_ERROR)
+	if err := tsv.Rollback(ctx, nil, tsv.sessionID, transactionID); err != nil {
+		t.Fatalf("call TabletServer.Rollback failed: %v", err)
+	}
+}
+
 func TestTabletServerExecuteBatchBeginFail(t *testing.T) {
 	db := setUpTabletServerTest()
 	testUtils := newTestUtils()
@@ -1329,6 +1779,30 @@ func TestTerseErrors3(t *testing.T) {
 	})
 }
 
+func TestTerseErrorsRedacted(t *testing.T) {
+	defer func(redact bool) { *redactDebugUIQueries = redact }(*redactDebugUIQueries)
+	*redactDebugUIQueries = true
+	ctx := context.Background()
+	logStats := newLogStats("TestHandleExecError", ctx)
+	var err error
+	defer func() {
+		want := "error: (errno 10) during query: select * from test_table where name = ?"
+		if err == nil || err.Error() != want {
+			t.Errorf("Error: %v, want '%s'", err, want)
+		}
+	}()
+	testUtils := newTestUtils()
+	config := testUtils.newQueryServiceConfig()
+	tsv := NewTabletServer(config)
+	tsv.config.TerseErrors = true
+	defer tsv.handleExecError("select * from test_table where name = 'secret'", map[string]interface{}{"a": 1}, &err, logStats)
+	panic(&TabletError{
+		ErrorCode: vtrpcpb.ErrorCode_DEADLINE_EXCEEDED,
+		Message:   "msg",
+		SQLError:  10,
+	})
+}
+
 func TestConfigChanges(t *testing.T) {
 	db := setUpTabletServerTest()
 	testUtils := newTestUtils()
@@ -1377,6 +1851,29 @@ func TestConfigChanges(t *testing.T) {
 		t.Errorf("tsv.qe.txPool.Timeout: %v, want %v", val, newDuration)
 	}
 
+	tsv.SetSlowQueryThreshold(newDuration)
+	if val := tsv.SlowQueryThreshold(); val != newDuration {
+		t.Errorf("SlowQueryThreshold: %v, want %v", val, newDuration)
+	}
+	tsv.SetSlowQueryThreshold(0)
+
+	tsv.SetQueryLogSampleRate(newSize)
+	if val := tsv.QueryLogSampleRate(); val != newSize {
+		t.Errorf("QueryLogSampleRate: %d, want %d", val, newSize)
+	}
+	tsv.SetQueryLogSampleRate(0)
+
+	tsv.SetQueryLogSampleExempt(newDuration)
+	if val := tsv.QueryLogSampleExempt(); val != newDuration {
+		t.Errorf("QueryLogSampleExempt: %v, want %v", val, newDuration)
+	}
+	tsv.SetQueryLogSampleExempt(0)
+
+	tsv.SetTxPoolMaxWaiters(newSize)
+	if val := tsv.TxPoolMaxWaiters(); val != newSize {
+		t.Errorf("TxPoolMaxWaiters: %d, want %d", val, newSize)
+	}
+
 	tsv.SetQueryCacheCap(newSize)
 	if val := tsv.QueryCacheCap(); val != newSize {
 		t.Errorf("QueryCacheCap: %d, want %d", val, newSize)
@@ -1492,6 +1989,7 @@ func getSupportedQueries() map[string]*sqltypes.Result {
 					sqltypes.MakeString([]byte("2")),
 					sqltypes.MakeString([]byte("3")),
 					sqltypes.MakeString([]byte("4")),
+					sqltypes.MakeString([]byte("Compact")),
 				},
 			},
 		},
@@ -1563,6 +2061,7 @@ func getSupportedQueries() map[string]*sqltypes.Result {
 					sqltypes.MakeString([]byte("2")),
 					sqltypes.MakeString([]byte("3")),
 					sqltypes.MakeString([]byte("4")),
+					sqltypes.MakeString([]byte("Compact")),
 				},
 			},
 		},
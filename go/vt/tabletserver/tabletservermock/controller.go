@@ -11,6 +11,7 @@ import (
 	querypb "github.com/youtube/vitess/go/vt/proto/query"
 	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
 	"github.com/youtube/vitess/go/vt/tabletserver"
+	"github.com/youtube/vitess/go/vt/tabletserver/planbuilder"
 	"github.com/youtube/vitess/go/vt/tabletserver/queryservice"
 )
 
@@ -58,6 +59,9 @@ type Controller struct {
 
 	// StateChanges has the list of state changes done by SetServingType().
 	StateChanges chan *StateChange
+
+	// KeyRangeFilter stores the last filter set by SetKeyRangeFilter().
+	KeyRangeFilter *planbuilder.KeyRangeFilter
 }
 
 // NewController returns a mock of tabletserver.Controller
@@ -92,7 +96,7 @@ func (tqsc *Controller) InitDBConfig(target querypb.Target, dbConfigs dbconfigs.
 }
 
 // SetServingType is part of the tabletserver.Controller interface
-func (tqsc *Controller) SetServingType(tabletType topodatapb.TabletType, serving bool, alsoAllow []topodatapb.TabletType) (bool, error) {
+func (tqsc *Controller) SetServingType(tabletType topodatapb.TabletType, serving bool, alsoAllow []topodatapb.TabletType, reason string) (bool, error) {
 	stateChanged := false
 	if tqsc.SetServingTypeError == nil {
 		stateChanged = tqsc.QueryServiceEnabled != serving || tqsc.CurrentTarget.TabletType != tabletType
@@ -127,6 +131,11 @@ func (tqsc *Controller) ReloadSchema() {
 func (tqsc *Controller) ClearQueryPlanCache() {
 }
 
+// SetKeyRangeFilter is part of the tabletserver.Controller interface
+func (tqsc *Controller) SetKeyRangeFilter(filter *planbuilder.KeyRangeFilter) {
+	tqsc.KeyRangeFilter = filter
+}
+
 // RegisterQueryRuleSource is part of the tabletserver.Controller interface
 func (tqsc *Controller) RegisterQueryRuleSource(ruleSource string) {
 }
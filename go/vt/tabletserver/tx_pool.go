@@ -5,13 +5,17 @@
 package tabletserver
 
 import (
+	"bytes"
 	"fmt"
 	"net/url"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	log "github.com/golang/glog"
+	"github.com/youtube/vitess/go/cache"
+	"github.com/youtube/vitess/go/clock"
 	"github.com/youtube/vitess/go/pools"
 	"github.com/youtube/vitess/go/sqldb"
 	"github.com/youtube/vitess/go/sqltypes"
@@ -22,6 +26,7 @@ import (
 	"github.com/youtube/vitess/go/vt/callerid"
 	querypb "github.com/youtube/vitess/go/vt/proto/query"
 	vtrpcpb "github.com/youtube/vitess/go/vt/proto/vtrpc"
+	"github.com/youtube/vitess/go/vt/tabletserver/querytypes"
 	"golang.org/x/net/context"
 )
 
@@ -42,23 +47,72 @@ const (
 	TxCommit   = "commit"
 	TxRollback = "rollback"
 	TxKill     = "kill"
+	TxExpire   = "expire"
+	TxUnlock   = "unlock"
 )
 
 const txLogInterval = time.Duration(1 * time.Minute)
 
+// txExpiryInterval is how often the transactions are checked against
+// -max_transaction_duration.
+const txExpiryInterval = time.Second
+
+// expiredTxCacheSize is the number of expired transactions remembered,
+// to tell their clients why they're gone.
+const expiredTxCacheSize = 1000
+
+// txWaitWindow and txWaitSamples bound the waits for a connection
+// that estimatedWait averages: the ones of the last minute, at most 100.
+const (
+	txWaitWindow  = time.Minute
+	txWaitSamples = 100
+)
+
+// waitSample is the time a Begin call that ended at end waited for a
+// connection.
+type waitSample struct {
+	end  time.Time
+	wait time.Duration
+}
+
 // TxPool is the transaction pool for the query service.
 type TxPool struct {
 	pool              *ConnPool
 	activePool        *pools.Numbered
 	lastID            sync2.AtomicInt64
 	timeout           sync2.AtomicDuration
+	waiters           sync2.AtomicInt64
+	maxWaiters        sync2.AtomicInt64
 	ticks             *timer.Timer
 	txStats           *stats.Timings
 	queryServiceStats *QueryServiceStats
 	checker           MySQLChecker
+	clock             clock.Clock
+	// maxDuration is -max_transaction_duration. The transactions
+	// that are older are rolled back by transactionExpirer, which
+	// runs with expiryTicks. 0 disables it.
+	maxDuration time.Duration
+	expiryTicks *timer.Timer
+	// maxLockDuration is -max_lock_tables_duration. The transactions
+	// that have been locked by LOCK TABLES for longer are rolled back
+	// by lockExpirer, which runs with lockExpiryTicks. 0 disables it.
+	maxLockDuration time.Duration
+	lockExpiryTicks *timer.Timer
+	// expired has the expiredTx of the recently expired transactions,
+	// by transaction id.
+	expired *cache.LRUCache
 	// Tracking culprits that cause tx pool full errors.
 	logMu   sync.Mutex
 	lastLog time.Time
+	// xids has the transaction ids of the active XA transactions,
+	// by xid. An xid is mapped to 0 while its XA START runs.
+	xaMu sync.Mutex
+	xids map[string]int64
+	// waits are the samples of estimatedWait, a ring buffer once
+	// full, where nextWait is the oldest.
+	waitMu   sync.Mutex
+	waits    []waitSample
+	nextWait int
 }
 
 // NewTxPool creates a new TxPool. It's not operational until it's Open'd.
@@ -66,6 +120,7 @@ func NewTxPool(
 	name string,
 	txStatsPrefix string,
 	capacity int,
+	maxWaiters int,
 	timeout time.Duration,
 	idleTimeout time.Duration,
 	enablePublishStats bool,
@@ -82,30 +137,60 @@ func NewTxPool(
 		activePool:        pools.NewNumbered(),
 		lastID:            sync2.NewAtomicInt64(time.Now().UnixNano()),
 		timeout:           sync2.NewAtomicDuration(timeout),
+		maxWaiters:        sync2.NewAtomicInt64(int64(maxWaiters)),
 		ticks:             timer.NewTimer(timeout / 10),
 		txStats:           stats.NewTimings(txStatsName),
 		checker:           checker,
 		queryServiceStats: qStats,
+		clock:             clock.Real,
+		maxDuration:       *maxTransactionDuration,
+		expiryTicks:       timer.NewTimer(txExpiryInterval),
+		maxLockDuration:   *maxLockTablesDuration,
+		lockExpiryTicks:   timer.NewTimer(txExpiryInterval),
+		expired:           cache.NewLRUCache(expiredTxCacheSize),
+		xids:              make(map[string]int64),
 	}
 	// Careful: pool also exports name+"xxx" vars,
 	// but we know it doesn't export Timeout.
 	if enablePublishStats {
 		stats.Publish(name+"Timeout", stats.DurationFunc(axp.timeout.Get))
+		stats.Publish(name+"Waiters", stats.IntFunc(axp.waiters.Get))
+		stats.Publish(name+"MaxWaiters", stats.IntFunc(axp.maxWaiters.Get))
 	}
 	return axp
 }
 
+// setClock makes the transaction killer use c to run, and to measure
+// the age of the transactions. It must be called before Open.
+func (axp *TxPool) setClock(c clock.Clock) {
+	axp.clock = c
+	axp.activePool = pools.NewNumberedWithClock(c)
+	axp.ticks = timer.NewTimerWithClock(axp.ticks.Interval(), c)
+	axp.expiryTicks = timer.NewTimerWithClock(txExpiryInterval, c)
+	axp.lockExpiryTicks = timer.NewTimerWithClock(txExpiryInterval, c)
+}
+
 // Open makes the TxPool operational. This also starts the transaction killer
-// that will kill long-running transactions.
+// that will kill long-running transactions, the transaction expirer
+// if -max_transaction_duration is set, and the lock expirer if
+// -max_lock_tables_duration is set.
 func (axp *TxPool) Open(appParams, dbaParams *sqldb.ConnParams) {
 	log.Infof("Starting transaction id: %d", axp.lastID)
 	axp.pool.Open(appParams, dbaParams)
 	axp.ticks.Start(func() { axp.transactionKiller() })
+	if axp.maxDuration > 0 {
+		axp.expiryTicks.Start(func() { axp.transactionExpirer() })
+	}
+	if axp.maxLockDuration > 0 {
+		axp.lockExpiryTicks.Start(func() { axp.lockExpirer() })
+	}
 }
 
 // Close closes the TxPool. A closed pool can be reopened.
 func (axp *TxPool) Close() {
 	axp.ticks.Stop()
+	axp.expiryTicks.Stop()
+	axp.lockExpiryTicks.Stop()
 	for _, v := range axp.activePool.GetOutdated(time.Duration(0), "for closing") {
 		conn := v.(*TxConnection)
 		log.Warningf("killing transaction for shutdown: %s", conn.Format(nil))
@@ -123,6 +208,9 @@ func (axp *TxPool) WaitForEmpty() {
 
 func (axp *TxPool) transactionKiller() {
 	defer logError(axp.queryServiceStats)
+	// Closing the connection of a prepared XA transaction leaves it
+	// prepared in MySQL (since 5.7.7), so it can still be committed or
+	// rolled back after XARecover.
 	for _, v := range axp.activePool.GetOutdated(time.Duration(axp.Timeout()), "for rollback") {
 		conn := v.(*TxConnection)
 		log.Warningf("killing transaction (exceeded timeout: %v): %s", axp.Timeout(), conn.Format(nil))
@@ -132,42 +220,170 @@ func (axp *TxPool) transactionKiller() {
 	}
 }
 
+// expiredTx is what's remembered of a transaction rolled back by
+// transactionExpirer.
+type expiredTx struct {
+	age        time.Duration
+	statements int
+	// unlocked is set if it was rolled back by lockExpirer.
+	unlocked bool
+}
+
+// Size is part of the cache.Value interface.
+func (expiredTx) Size() int {
+	return 1
+}
+
+// transactionExpirer rolls back the transactions that are older than
+// maxDuration. Unlike transactionKiller, it doesn't close the
+// connections, and the transactions are remembered to fail their next
+// statements with a "transaction expired" error.
+func (axp *TxPool) transactionExpirer() {
+	defer logError(axp.queryServiceStats)
+	for _, v := range axp.activePool.GetOutdated(axp.maxDuration, "for expiry") {
+		conn := v.(*TxConnection)
+		exp := expiredTx{
+			age:        axp.clock.Now().Sub(conn.StartTime),
+			statements: len(conn.Queries),
+		}
+		log.Warningf("rolling back transaction %d (exceeded max_transaction_duration: %v) after %v and %d statements: %s", conn.TransactionID, axp.maxDuration, exp.age, exp.statements, conn.Format(nil))
+		axp.queryServiceStats.KillStats.Add("ExpiredTransactions", 1)
+		axp.expired.Set(strconv.FormatInt(conn.TransactionID, 10), exp)
+		if err := conn.rollbackExpired(); err != nil {
+			log.Warningf("rollback of expired transaction %d failed: %v", conn.TransactionID, err)
+			conn.Close()
+		}
+		conn.discard(TxExpire)
+	}
+}
+
+// lockExpirer rolls back the transactions that have been in the
+// locked mode of LOCK TABLES for longer than maxLockDuration. Like
+// transactionExpirer, it keeps the connections, which are unlocked
+// by discard, and it remembers the transactions to fail their next
+// statements with a "tables unlocked" error.
+func (axp *TxPool) lockExpirer() {
+	defer logError(axp.queryServiceStats)
+	// LOCK TABLES is the first statement of its transaction, so the
+	// transactions locked for too long are among the outdated ones.
+	for _, v := range axp.activePool.GetOutdated(axp.maxLockDuration, "for lock expiry") {
+		conn := v.(*TxConnection)
+		if conn.lockedTables == nil || axp.clock.Now().Sub(conn.lockTime) < axp.maxLockDuration {
+			conn.Recycle()
+			continue
+		}
+		exp := expiredTx{
+			age:        axp.clock.Now().Sub(conn.lockTime),
+			statements: len(conn.Queries),
+			unlocked:   true,
+		}
+		log.Warningf("rolling back transaction %d (exceeded max_lock_tables_duration: %v) after %v and %d statements: %s", conn.TransactionID, axp.maxLockDuration, exp.age, exp.statements, conn.Format(nil))
+		axp.queryServiceStats.KillStats.Add("UnlockedTransactions", 1)
+		axp.expired.Set(strconv.FormatInt(conn.TransactionID, 10), exp)
+		if err := conn.rollbackExpired(); err != nil {
+			log.Warningf("rollback of unlocked transaction %d failed: %v", conn.TransactionID, err)
+			conn.Close()
+		}
+		conn.discard(TxUnlock)
+	}
+}
+
 // Begin begins a transaction, and returns the associated transaction id.
 // Subsequent statements can access the connection through the transaction id.
+// If the pool is full, Begin waits for a connection. If there are already
+// MaxWaiters callers waiting, it fails right away.
 func (axp *TxPool) Begin(ctx context.Context) int64 {
+	return axp.begin(ctx, nil)
+}
+
+// begin is Begin. If logStats is not nil, the time spent waiting for a
+// connection is added to it, even if Begin fails.
+func (axp *TxPool) begin(ctx context.Context, logStats *LogStats) int64 {
+	return axp.start(ctx, logStats, "")
+}
+
+// XAStart starts the XA transaction xid, and returns its transaction id.
+// The transaction is used like the ones of Begin: its statements access
+// the connection through the transaction id, and it can be committed in
+// one phase by SafeCommit. It fails if xid is already active.
+func (axp *TxPool) XAStart(ctx context.Context, logStats *LogStats, xid string) int64 {
+	return axp.start(ctx, logStats, xid)
+}
+
+// start starts a transaction for begin, or an XA transaction if xid
+// is not empty.
+func (axp *TxPool) start(ctx context.Context, logStats *LogStats, xid string) (transactionID int64) {
+	beginQuery := "begin"
+	if xid != "" {
+		axp.reserveXid(xid)
+		defer func() {
+			if transactionID == 0 {
+				axp.releaseXid(xid)
+			}
+		}()
+		beginQuery = xaStatement("xa start", xid)
+	}
 	poolCtx := ctx
 	if deadline, ok := ctx.Deadline(); ok {
 		var cancel func()
 		poolCtx, cancel = context.WithDeadline(ctx, deadline.Add(-10*time.Millisecond))
 		defer cancel()
 	}
-	conn, err := axp.pool.Get(poolCtx)
+	start := time.Now()
+	var conn *DBConn
+	var err error
+	if poolCtx.Err() == nil {
+		conn, err = axp.pool.TryGet()
+	}
+	// Only the calls that have to wait for a connection are waiters.
+	var waiters int64
+	if conn == nil && err == nil {
+		if options := querytypes.ExecuteOptionsFromContext(ctx); options != nil && options.TxPoolFailFast {
+			panic(NewTabletError(vtrpcpb.ErrorCode_RESOURCE_EXHAUSTED, "Transaction pool connection limit exceeded: no free connection and tx_pool_fail_fast is set (queue depth: %d, estimated wait: %v)", axp.waiters.Get(), axp.estimatedWait()))
+		}
+		waiters = axp.waiters.Add(1)
+		if maxWaiters := axp.maxWaiters.Get(); maxWaiters > 0 && waiters > maxWaiters {
+			axp.waiters.Add(-1)
+			panic(NewTabletError(vtrpcpb.ErrorCode_RESOURCE_EXHAUSTED, "Transaction pool connection limit exceeded: wait queue is full (queue depth: %d, estimated wait: %v)", waiters-1, axp.estimatedWait()))
+		}
+		conn, err = axp.pool.Get(poolCtx)
+		axp.waiters.Add(-1)
+		axp.recordWait(time.Now().Sub(start))
+	}
+	axp.queryServiceStats.WaitStats.Record("TxPool", start)
+	if logStats != nil {
+		logStats.addTxPoolWait(time.Now().Sub(start))
+	}
 	if err != nil {
 		switch err {
 		case ErrConnPoolClosed:
 			panic(err)
 		case pools.ErrTimeout:
 			axp.LogActive()
-			panic(NewTabletError(vtrpcpb.ErrorCode_RESOURCE_EXHAUSTED, "Transaction pool connection limit exceeded"))
+			panic(NewTabletError(vtrpcpb.ErrorCode_RESOURCE_EXHAUSTED, "Transaction pool connection limit exceeded (queue depth: %d, estimated wait: %v)", waiters-1, axp.estimatedWait()))
 		}
 		panic(NewTabletErrorSQL(vtrpcpb.ErrorCode_INTERNAL_ERROR, err))
 	}
-	if _, err := conn.Exec(ctx, "begin", 1, false); err != nil {
+	if _, err := conn.Exec(ctx, beginQuery, 1, false); err != nil {
 		conn.Recycle()
 		panic(NewTabletErrorSQL(vtrpcpb.ErrorCode_UNKNOWN_ERROR, err))
 	}
-	transactionID := axp.lastID.Add(1)
-	axp.activePool.Register(
-		transactionID,
-		newTxConnection(
-			conn,
-			transactionID,
-			axp,
-			callerid.ImmediateCallerIDFromContext(ctx),
-			callerid.EffectiveCallerIDFromContext(ctx),
-		),
+	id := axp.lastID.Add(1)
+	txc := newTxConnection(
+		conn,
+		id,
+		axp,
+		callerid.ImmediateCallerIDFromContext(ctx),
+		callerid.EffectiveCallerIDFromContext(ctx),
 	)
-	return transactionID
+	txc.xid = xid
+	axp.activePool.Register(id, txc)
+	if xid != "" {
+		axp.xaMu.Lock()
+		axp.xids[xid] = id
+		axp.xaMu.Unlock()
+	}
+	return id
 }
 
 // SafeCommit commits the specified transaction. Unlike other functions, it
@@ -181,7 +397,7 @@ func (axp *TxPool) SafeCommit(ctx context.Context, transactionID int64) (invalid
 	// Assign this upfront to make sure we always return the invalidList.
 	invalidList = conn.dirtyTables
 	axp.txStats.Add("Completed", time.Now().Sub(conn.StartTime))
-	if _, fetchErr := conn.Exec(ctx, "commit", 1, false); fetchErr != nil {
+	if fetchErr := conn.commit(ctx); fetchErr != nil {
 		conn.Close()
 		err = NewTabletErrorSQL(vtrpcpb.ErrorCode_UNKNOWN_ERROR, fetchErr)
 	}
@@ -193,10 +409,119 @@ func (axp *TxPool) Rollback(ctx context.Context, transactionID int64) {
 	conn := axp.Get(transactionID)
 	defer conn.discard(TxRollback)
 	axp.txStats.Add("Aborted", time.Now().Sub(conn.StartTime))
-	if _, err := conn.Exec(ctx, "rollback", 1, false); err != nil {
+	if err := conn.rollback(ctx); err != nil {
+		conn.Close()
+		panic(NewTabletErrorSQL(vtrpcpb.ErrorCode_UNKNOWN_ERROR, err))
+	}
+}
+
+// XAPrepare ends and prepares the active XA transaction xid, the first
+// phase of a two-phase commit. The transaction keeps its connection until
+// it's committed or rolled back, but it can't execute statements anymore.
+func (axp *TxPool) XAPrepare(ctx context.Context, xid string) {
+	conn := axp.Get(axp.xaTransactionID(xid))
+	defer conn.Recycle()
+	if conn.xaPrepared {
+		panic(NewTabletError(vtrpcpb.ErrorCode_BAD_INPUT, "XA transaction %s is already prepared", xid))
+	}
+	if err := conn.execAll(ctx, xaStatement("xa end", xid), xaStatement("xa prepare", xid)); err != nil {
+		// Closing the connection rolls the transaction back.
 		conn.Close()
 		panic(NewTabletErrorSQL(vtrpcpb.ErrorCode_UNKNOWN_ERROR, err))
 	}
+	conn.xaPrepared = true
+}
+
+// XAConcludeRecovered commits, or rolls back, the prepared XA transaction
+// xid that's not active in the pool, like the ones returned by XARecover
+// after a restart of the tablet.
+func (axp *TxPool) XAConcludeRecovered(ctx context.Context, xid string, commit bool) {
+	query := xaStatement("xa rollback", xid)
+	if commit {
+		query = xaStatement("xa commit", xid)
+	}
+	conn := axp.getRecoveryConn(ctx)
+	defer conn.Recycle()
+	if _, err := conn.Exec(ctx, query, 1, false); err != nil {
+		panic(NewTabletErrorSQL(vtrpcpb.ErrorCode_UNKNOWN_ERROR, err))
+	}
+}
+
+// XARecover returns the xids of the prepared XA transactions of MySQL.
+// They include the prepared transactions that are still active in the
+// pool.
+func (axp *TxPool) XARecover(ctx context.Context) []string {
+	conn := axp.getRecoveryConn(ctx)
+	defer conn.Recycle()
+	qr, err := conn.Exec(ctx, "xa recover", 10000, false)
+	if err != nil {
+		panic(NewTabletErrorSQL(vtrpcpb.ErrorCode_UNKNOWN_ERROR, err))
+	}
+	xids := make([]string, 0, len(qr.Rows))
+	for _, row := range qr.Rows {
+		// The columns are formatID, gtrid_length, bqual_length and
+		// data, the xid.
+		if len(row) < 4 {
+			panic(NewTabletError(vtrpcpb.ErrorCode_INTERNAL_ERROR, "unexpected xa recover row: %v", row))
+		}
+		xids = append(xids, row[3].String())
+	}
+	return xids
+}
+
+// getRecoveryConn returns a connection of the pool for the XA statements
+// that don't run in a transaction of the pool.
+func (axp *TxPool) getRecoveryConn(ctx context.Context) *DBConn {
+	conn, err := axp.pool.Get(ctx)
+	if err != nil {
+		if err == ErrConnPoolClosed {
+			panic(err)
+		}
+		panic(NewTabletErrorSQL(vtrpcpb.ErrorCode_INTERNAL_ERROR, err))
+	}
+	return conn
+}
+
+// xaTransactionID returns the transaction id of the active XA
+// transaction xid. It panics if xid is not active.
+func (axp *TxPool) xaTransactionID(xid string) int64 {
+	if id := axp.ActiveXATransactionID(xid); id != 0 {
+		return id
+	}
+	panic(NewTabletError(vtrpcpb.ErrorCode_NOT_IN_TX, "XA transaction %s is not active", xid))
+}
+
+// ActiveXATransactionID returns the transaction id of the active XA
+// transaction xid, or 0 if xid is not active in the pool.
+func (axp *TxPool) ActiveXATransactionID(xid string) int64 {
+	axp.xaMu.Lock()
+	defer axp.xaMu.Unlock()
+	return axp.xids[xid]
+}
+
+// reserveXid reserves xid for an XA START. It panics if xid is active.
+func (axp *TxPool) reserveXid(xid string) {
+	axp.xaMu.Lock()
+	defer axp.xaMu.Unlock()
+	if _, ok := axp.xids[xid]; ok {
+		panic(NewTabletError(vtrpcpb.ErrorCode_BAD_INPUT, "XA transaction %s is already active", xid))
+	}
+	axp.xids[xid] = 0
+}
+
+func (axp *TxPool) releaseXid(xid string) {
+	axp.xaMu.Lock()
+	defer axp.xaMu.Unlock()
+	delete(axp.xids, xid)
+}
+
+// xaStatement returns the XA statement verb of xid, quoted as a
+// string literal, e.g. "xa commit 'xid'".
+func xaStatement(verb, xid string) string {
+	buf := bytes.NewBufferString(verb)
+	buf.WriteByte(' ')
+	sqltypes.MakeString([]byte(xid)).EncodeSQL(buf)
+	return buf.String()
 }
 
 // Get fetches the connection associated to the transactionID.
@@ -204,6 +529,13 @@ func (axp *TxPool) Rollback(ctx context.Context, transactionID int64) {
 func (axp *TxPool) Get(transactionID int64) (conn *TxConnection) {
 	v, err := axp.activePool.Get(transactionID, "for query")
 	if err != nil {
+		if exp, ok := axp.expired.Get(strconv.FormatInt(transactionID, 10)); ok {
+			exp := exp.(expiredTx)
+			if exp.unlocked {
+				panic(NewTabletError(vtrpcpb.ErrorCode_NOT_IN_TX, "Transaction %d: tables unlocked: rolled back after %v and %d statements in the locked mode, it exceeded max_lock_tables_duration (%v)", transactionID, exp.age, exp.statements, axp.maxLockDuration))
+			}
+			panic(NewTabletError(vtrpcpb.ErrorCode_NOT_IN_TX, "Transaction %d: transaction expired: rolled back after %v and %d statements, it exceeded max_transaction_duration (%v)", transactionID, exp.age, exp.statements, axp.maxDuration))
+		}
 		panic(NewTabletError(vtrpcpb.ErrorCode_NOT_IN_TX, "Transaction %d: %v", transactionID, err))
 	}
 	return v.(*TxConnection)
@@ -235,6 +567,54 @@ func (axp *TxPool) SetTimeout(timeout time.Duration) {
 	axp.ticks.SetInterval(timeout / 10)
 }
 
+// MaxWaiters returns the maximum number of Begin calls that can wait
+// for a connection. 0 means there is no limit.
+func (axp *TxPool) MaxWaiters() int64 {
+	return axp.maxWaiters.Get()
+}
+
+// SetMaxWaiters sets the maximum number of Begin calls that can wait
+// for a connection.
+func (axp *TxPool) SetMaxWaiters(maxWaiters int64) {
+	axp.maxWaiters.Set(maxWaiters)
+}
+
+// recordWait adds the time a Begin call waited for a connection to
+// the samples of estimatedWait.
+func (axp *TxPool) recordWait(wait time.Duration) {
+	axp.waitMu.Lock()
+	defer axp.waitMu.Unlock()
+	sample := waitSample{end: axp.clock.Now(), wait: wait}
+	if len(axp.waits) < txWaitSamples {
+		axp.waits = append(axp.waits, sample)
+		return
+	}
+	axp.waits[axp.nextWait] = sample
+	axp.nextWait = (axp.nextWait + 1) % txWaitSamples
+}
+
+// estimatedWait returns the average time the Begin calls that ended
+// in the last txWaitWindow had to wait for a connection, out of the
+// last txWaitSamples of them.
+func (axp *TxPool) estimatedWait() time.Duration {
+	axp.waitMu.Lock()
+	defer axp.waitMu.Unlock()
+	now := axp.clock.Now()
+	var total time.Duration
+	var count int64
+	for _, sample := range axp.waits {
+		if now.Sub(sample.end) > txWaitWindow {
+			continue
+		}
+		total += sample.wait
+		count++
+	}
+	if count == 0 {
+		return 0
+	}
+	return total / time.Duration(count)
+}
+
 // TxConnection is meant for executing transactions. It keeps track
 // of dirty keys for rowcache invalidation. It can return itself to
 // the tx pool correctly. It also does not retry statements if there
@@ -252,6 +632,19 @@ type TxConnection struct {
 	LogToFile         sync2.AtomicInt32
 	ImmediateCallerID *querypb.VTGateCallerID
 	EffectiveCallerID *vtrpcpb.CallerID
+	// lockedTables are the tables locked by LOCK TABLES, at lockTime.
+	// The transaction can only access them until UNLOCK TABLES.
+	// It's nil if the transaction is not in the locked mode.
+	lockedTables map[string]bool
+	lockTime     time.Time
+	// autocommitOff is set once LOCK TABLES has disabled autocommit
+	// on the connection. The locks and autocommit are reset before
+	// the connection goes back to the pool.
+	autocommitOff bool
+	// xid is the id of the XA transaction, "" for the transactions
+	// of Begin. xaPrepared is set once it's prepared by XAPrepare.
+	xid        string
+	xaPrepared bool
 }
 
 func newTxConnection(conn *DBConn, transactionID int64, pool *TxPool, immediate *querypb.VTGateCallerID, effective *vtrpcpb.CallerID) *TxConnection {
@@ -259,7 +652,7 @@ func newTxConnection(conn *DBConn, transactionID int64, pool *TxPool, immediate
 		DBConn:            conn,
 		TransactionID:     transactionID,
 		pool:              pool,
-		StartTime:         time.Now(),
+		StartTime:         pool.clock.Now(),
 		dirtyTables:       make(map[string]DirtyKeys),
 		Queries:           make([]string, 0, 8),
 		ImmediateCallerID: immediate,
@@ -282,6 +675,10 @@ func (txc *TxConnection) DirtyKeys(tableName string) DirtyKeys {
 func (txc *TxConnection) Exec(ctx context.Context, query string, maxrows int, wantfields bool) (*sqltypes.Result, error) {
 	r, err := txc.DBConn.ExecOnce(ctx, query, maxrows, wantfields)
 	if err != nil {
+		if terr, ok := err.(*TabletError); ok {
+			// The statement is over max_allowed_packet.
+			return nil, terr
+		}
 		if IsConnErr(err) {
 			txc.pool.checker.CheckMySQL()
 			return nil, NewTabletErrorSQL(vtrpcpb.ErrorCode_INTERNAL_ERROR, err)
@@ -291,6 +688,101 @@ func (txc *TxConnection) Exec(ctx context.Context, query string, maxrows int, wa
 	return r, nil
 }
 
+// execAll executes the statements of queries in order. It stops at the
+// first error.
+func (txc *TxConnection) execAll(ctx context.Context, queries ...string) error {
+	for _, query := range queries {
+		if _, err := txc.Exec(ctx, query, 1, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// commit commits the transaction. An XA transaction that's not prepared
+// is ended and committed in one phase.
+func (txc *TxConnection) commit(ctx context.Context) error {
+	switch {
+	case txc.xid == "":
+		return txc.execAll(ctx, "commit")
+	case txc.xaPrepared:
+		return txc.execAll(ctx, xaStatement("xa commit", txc.xid))
+	}
+	return txc.execAll(ctx, xaStatement("xa end", txc.xid), xaStatement("xa commit", txc.xid)+" one phase")
+}
+
+// rollback rolls back the transaction.
+func (txc *TxConnection) rollback(ctx context.Context) error {
+	switch {
+	case txc.xid == "":
+		return txc.execAll(ctx, "rollback")
+	case txc.xaPrepared:
+		return txc.execAll(ctx, xaStatement("xa rollback", txc.xid))
+	}
+	return txc.execAll(ctx, xaStatement("xa end", txc.xid), xaStatement("xa rollback", txc.xid))
+}
+
+// rollbackExpired rolls back an expired transaction. A prepared XA
+// transaction is left to its coordinator instead: its connection is
+// closed, which keeps it prepared in MySQL.
+func (txc *TxConnection) rollbackExpired() error {
+	if txc.xaPrepared {
+		txc.Close()
+		return nil
+	}
+	return txc.rollback(context.Background())
+}
+
+// LockTables records that the transaction is in the locked mode,
+// and can only access tables from now on.
+func (txc *TxConnection) LockTables(tables []string) {
+	txc.lockedTables = make(map[string]bool, len(tables))
+	for _, table := range tables {
+		txc.lockedTables[table] = true
+	}
+	txc.lockTime = txc.pool.clock.Now()
+}
+
+// UnlockTables records that the transaction left the locked mode.
+func (txc *TxConnection) UnlockTables() {
+	txc.lockedTables = nil
+}
+
+// IsLocked returns true if the transaction is in the locked mode.
+func (txc *TxConnection) IsLocked() bool {
+	return txc.lockedTables != nil
+}
+
+// CheckLockedTables returns an error if the transaction is in the
+// locked mode and one of tables was not locked.
+func (txc *TxConnection) CheckLockedTables(tables []string) error {
+	if txc.lockedTables == nil {
+		return nil
+	}
+	for _, table := range tables {
+		if !txc.lockedTables[table] {
+			return NewTabletError(vtrpcpb.ErrorCode_BAD_INPUT, "table %s was not locked with LOCK TABLES", table)
+		}
+	}
+	return nil
+}
+
+// resetSession unlocks the tables and enables autocommit again
+// on the connection of a transaction that used LOCK TABLES. If that
+// fails, the connection is closed instead of going back to the pool.
+func (txc *TxConnection) resetSession() {
+	if !txc.autocommitOff || txc.IsClosed() {
+		return
+	}
+	for _, query := range []string{"unlock tables", "set autocommit=1"} {
+		if _, err := txc.Exec(context.Background(), query, 1, false); err != nil {
+			log.Warningf("cannot reset the session of transaction %d after LOCK TABLES: %v", txc.TransactionID, err)
+			txc.Close()
+			return
+		}
+	}
+}
+
 // Recycle returns the connection to the pool. The transaction remains
 // active.
 func (txc *TxConnection) Recycle() {
@@ -308,7 +800,7 @@ func (txc *TxConnection) RecordQuery(query string) {
 
 func (txc *TxConnection) discard(conclusion string) {
 	txc.Conclusion = conclusion
-	txc.EndTime = time.Now()
+	txc.EndTime = txc.pool.clock.Now()
 
 	username := callerid.GetPrincipal(txc.EffectiveCallerID)
 	if username == "" {
@@ -319,6 +811,10 @@ func (txc *TxConnection) discard(conclusion string) {
 	txc.pool.queryServiceStats.UserTransactionTimesNs.Add([]string{username, conclusion}, int64(duration))
 
 	txc.pool.activePool.Unregister(txc.TransactionID)
+	if txc.xid != "" {
+		txc.pool.releaseXid(txc.xid)
+	}
+	txc.resetSession()
 	txc.DBConn.Recycle()
 	// Ensure PoolConnection won't be accessed after Recycle.
 	txc.DBConn = nil
@@ -7,15 +7,20 @@ package tabletserver
 import (
 	"fmt"
 	"math/rand"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	"golang.org/x/net/context"
 
+	"github.com/youtube/vitess/go/clock/fakeclock"
 	"github.com/youtube/vitess/go/sqldb"
 	"github.com/youtube/vitess/go/sqltypes"
+	"github.com/youtube/vitess/go/vt/tabletserver/querytypes"
 	"github.com/youtube/vitess/go/vt/vttest/fakesqldb"
 
+	querypb "github.com/youtube/vitess/go/vt/proto/query"
 	vtrpcpb "github.com/youtube/vitess/go/vt/proto/vtrpc"
 )
 
@@ -83,8 +88,9 @@ func TestTxPoolTransactionKiller(t *testing.T) {
 	db.AddQuery("begin", &sqltypes.Result{})
 
 	txPool := newTxPool(false)
-	// make sure transaction killer will run frequent enough
-	txPool.SetTimeout(time.Duration(10))
+	fc := fakeclock.New(time.Now())
+	txPool.setClock(fc)
+	txPool.SetTimeout(time.Minute)
 	appParams := sqldb.ConnParams{Engine: db.Name}
 	dbaParams := sqldb.ConnParams{Engine: db.Name}
 	txPool.Open(&appParams, &dbaParams)
@@ -95,7 +101,19 @@ func TestTxPoolTransactionKiller(t *testing.T) {
 	txConn := txPool.Get(transactionID)
 	txConn.RecordQuery(sql)
 	txConn.Recycle()
+
+	// The transaction killer runs every 6s, but the transaction
+	// isn't old enough yet.
+	fc.BlockUntil(1)
+	fc.Advance(30 * time.Second)
+	// Wait for the killer to be done, and waiting for the next run.
+	fc.BlockUntil(1)
+	if got := txPool.queryServiceStats.KillStats.Counts()["Transactions"] - killCount; got != 0 {
+		t.Fatalf("transactions killed after 30s: %v, want 0", got)
+	}
+
 	// transaction killer should kill the query
+	fc.Advance(time.Minute)
 	txPool.WaitForEmpty()
 	killCountDiff := txPool.queryServiceStats.KillStats.Counts()["Transactions"] - killCount
 	if killCountDiff != 1 {
@@ -103,6 +121,125 @@ func TestTxPoolTransactionKiller(t *testing.T) {
 	}
 }
 
+func TestTxPoolTransactionExpirer(t *testing.T) {
+	sql := "update test_table set name = 1"
+	db := fakesqldb.Register()
+	db.AddQuery(sql, &sqltypes.Result{})
+	db.AddQuery("begin", &sqltypes.Result{})
+	db.AddQuery("rollback", &sqltypes.Result{})
+
+	txPool := newTxPool(false)
+	fc := fakeclock.New(time.Now())
+	txPool.setClock(fc)
+	txPool.SetTimeout(0)
+	txPool.maxDuration = 10 * time.Second
+	appParams := sqldb.ConnParams{Engine: db.Name}
+	dbaParams := sqldb.ConnParams{Engine: db.Name}
+	txPool.Open(&appParams, &dbaParams)
+	defer txPool.Close()
+	ctx := context.Background()
+	expiredCount := txPool.queryServiceStats.KillStats.Counts()["ExpiredTransactions"]
+	transactionID := txPool.Begin(ctx)
+	txConn := txPool.Get(transactionID)
+	txConn.RecordQuery(sql)
+	txConn.RecordQuery(sql)
+	txConn.Recycle()
+
+	// The expirer runs every second.
+	for i := 0; i < 9; i++ {
+		fc.BlockUntil(1)
+		fc.Advance(time.Second)
+	}
+	fc.BlockUntil(1)
+	if got := txPool.queryServiceStats.KillStats.Counts()["ExpiredTransactions"] - expiredCount; got != 0 {
+		t.Fatalf("transactions expired after 9s: %v, want 0", got)
+	}
+
+	fc.Advance(time.Second)
+	txPool.WaitForEmpty()
+	if got := txPool.queryServiceStats.KillStats.Counts()["ExpiredTransactions"] - expiredCount; got != 1 {
+		t.Fatalf("transactions expired after 10s: %v, want 1", got)
+	}
+	func() {
+		defer func() {
+			err, ok := recover().(*TabletError)
+			if !ok {
+				t.Fatalf("Get of an expired transaction should fail with a TabletError")
+			}
+			want := "transaction expired: rolled back after 10s and 2 statements"
+			if err.ErrorCode != vtrpcpb.ErrorCode_NOT_IN_TX || !strings.Contains(err.Error(), want) {
+				t.Errorf("Get of an expired transaction: %v, want %v containing %q", err, vtrpcpb.ErrorCode_NOT_IN_TX, want)
+			}
+		}()
+		txPool.Get(transactionID)
+	}()
+}
+
+func TestTxPoolLockExpirer(t *testing.T) {
+	sql := "lock tables test_table write"
+	db := fakesqldb.Register()
+	db.AddQuery(sql, &sqltypes.Result{})
+	db.AddQuery("begin", &sqltypes.Result{})
+	db.AddQuery("rollback", &sqltypes.Result{})
+	db.AddQuery("unlock tables", &sqltypes.Result{})
+	db.AddQuery("set autocommit=1", &sqltypes.Result{})
+
+	txPool := newTxPool(false)
+	fc := fakeclock.New(time.Now())
+	txPool.setClock(fc)
+	txPool.SetTimeout(0)
+	txPool.maxLockDuration = 5 * time.Second
+	appParams := sqldb.ConnParams{Engine: db.Name}
+	dbaParams := sqldb.ConnParams{Engine: db.Name}
+	txPool.Open(&appParams, &dbaParams)
+	defer txPool.Close()
+	ctx := context.Background()
+	unlockedCount := txPool.queryServiceStats.KillStats.Counts()["UnlockedTransactions"]
+	// A transaction that isn't locked is left alone.
+	otherID := txPool.Begin(ctx)
+	transactionID := txPool.Begin(ctx)
+	txConn := txPool.Get(transactionID)
+	txConn.RecordQuery(sql)
+	txConn.autocommitOff = true
+	txConn.LockTables([]string{"test_table"})
+	txConn.Recycle()
+
+	for i := 0; i < 4; i++ {
+		fc.BlockUntil(1)
+		fc.Advance(time.Second)
+	}
+	fc.BlockUntil(1)
+	if got := txPool.queryServiceStats.KillStats.Counts()["UnlockedTransactions"] - unlockedCount; got != 0 {
+		t.Fatalf("transactions unlocked after 4s: %v, want 0", got)
+	}
+
+	fc.Advance(time.Second)
+	fc.BlockUntil(1)
+	for txPool.activePool.Size() != 1 {
+		time.Sleep(time.Millisecond)
+	}
+	if got := txPool.queryServiceStats.KillStats.Counts()["UnlockedTransactions"] - unlockedCount; got != 1 {
+		t.Fatalf("transactions unlocked after 5s: %v, want 1", got)
+	}
+	if got := db.GetQueryCalledNum("set autocommit=1"); got != 1 {
+		t.Errorf("set autocommit=1: %v calls, want 1", got)
+	}
+	func() {
+		defer func() {
+			err, ok := recover().(*TabletError)
+			if !ok {
+				t.Fatalf("Get of an unlocked transaction should fail with a TabletError")
+			}
+			want := "tables unlocked: rolled back after 5s and 1 statements in the locked mode"
+			if err.ErrorCode != vtrpcpb.ErrorCode_NOT_IN_TX || !strings.Contains(err.Error(), want) {
+				t.Errorf("Get of an unlocked transaction: %v, want %v containing %q", err, vtrpcpb.ErrorCode_NOT_IN_TX, want)
+			}
+		}()
+		txPool.Get(transactionID)
+	}()
+	txPool.Rollback(ctx, otherID)
+}
+
 func TestTxPoolBeginAfterConnPoolClosed(t *testing.T) {
 	db := fakesqldb.Register()
 	txPool := newTxPool(false)
@@ -141,6 +278,113 @@ func TestTxPoolBeginWithShortDeadline(t *testing.T) {
 	txPool.Begin(ctx)
 }
 
+func TestTxPoolBeginWithFullQueue(t *testing.T) {
+	db := fakesqldb.Register()
+	db.AddQuery("begin", &sqltypes.Result{})
+	db.AddQuery("rollback", &sqltypes.Result{})
+	txPool := newTxPool(false)
+	appParams := sqldb.ConnParams{Engine: db.Name}
+	dbaParams := sqldb.ConnParams{Engine: db.Name}
+	txPool.Open(&appParams, &dbaParams)
+	defer txPool.Close()
+	txPool.pool.SetCapacity(1)
+	txPool.SetMaxWaiters(1)
+
+	ctx := context.Background()
+	transactionID := txPool.Begin(ctx)
+	// The second Begin has to wait for the first transaction.
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		waitCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+		defer cancel()
+		txPool.Rollback(ctx, txPool.Begin(waitCtx))
+	}()
+	for txPool.waiters.Get() != 1 {
+		time.Sleep(1 * time.Millisecond)
+	}
+	// The third Begin should fail right away.
+	func() {
+		defer func() {
+			err, ok := recover().(*TabletError)
+			if !ok || err.ErrorCode != vtrpcpb.ErrorCode_RESOURCE_EXHAUSTED {
+				t.Errorf("Begin: %v, want RESOURCE_EXHAUSTED", err)
+				return
+			}
+			if want := "queue depth: 1"; !strings.Contains(err.Error(), want) {
+				t.Errorf("Begin: %v, must contain %s", err, want)
+			}
+		}()
+		txPool.Begin(ctx)
+	}()
+	txPool.Rollback(ctx, transactionID)
+	<-done
+	if count := txPool.queryServiceStats.WaitStats.Counts()["TxPool"]; count != 2 {
+		t.Errorf("WaitStats[TxPool]: %d, want 2", count)
+	}
+}
+
+func TestTxPoolBeginFailFast(t *testing.T) {
+	db := fakesqldb.Register()
+	db.AddQuery("begin", &sqltypes.Result{})
+	db.AddQuery("rollback", &sqltypes.Result{})
+	txPool := newTxPool(false)
+	appParams := sqldb.ConnParams{Engine: db.Name}
+	dbaParams := sqldb.ConnParams{Engine: db.Name}
+	txPool.Open(&appParams, &dbaParams)
+	defer txPool.Close()
+	txPool.pool.SetCapacity(1)
+
+	ctx := context.Background()
+	failFastCtx := querytypes.NewExecuteOptionsContext(ctx, &querypb.ExecuteOptions{TxPoolFailFast: true})
+	// There's a free connection.
+	transactionID := txPool.Begin(failFastCtx)
+	func() {
+		defer func() {
+			err, ok := recover().(*TabletError)
+			if !ok || err.ErrorCode != vtrpcpb.ErrorCode_RESOURCE_EXHAUSTED {
+				t.Errorf("Begin: %v, want RESOURCE_EXHAUSTED", err)
+				return
+			}
+			if want := "tx_pool_fail_fast"; !strings.Contains(err.Error(), want) {
+				t.Errorf("Begin: %v, must contain %s", err, want)
+			}
+		}()
+		txPool.Begin(failFastCtx)
+	}()
+	if waiters := txPool.waiters.Get(); waiters != 0 {
+		t.Errorf("waiters: %v, want 0", waiters)
+	}
+	txPool.Rollback(ctx, transactionID)
+}
+
+func TestTxPoolEstimatedWait(t *testing.T) {
+	txPool := newTxPool(false)
+	fc := fakeclock.New(time.Now())
+	txPool.setClock(fc)
+	if got := txPool.estimatedWait(); got != 0 {
+		t.Errorf("estimatedWait without waits: %v, want 0", got)
+	}
+	txPool.recordWait(2 * time.Second)
+	txPool.recordWait(4 * time.Second)
+	if got, want := txPool.estimatedWait(), 3*time.Second; got != want {
+		t.Errorf("estimatedWait: %v, want %v", got, want)
+	}
+	// The waits older than the window are forgotten.
+	fc.Advance(2 * txWaitWindow)
+	txPool.recordWait(time.Second)
+	if got, want := txPool.estimatedWait(), time.Second; got != want {
+		t.Errorf("estimatedWait after the window: %v, want %v", got, want)
+	}
+	// So are the oldest samples.
+	for i := 0; i < txWaitSamples; i++ {
+		txPool.recordWait(5 * time.Second)
+	}
+	if got, want := txPool.estimatedWait(), 5*time.Second; got != want {
+		t.Errorf("estimatedWait after %v samples: %v, want %v", txWaitSamples, got, want)
+	}
+}
+
 func TestTxPoolBeginWithPoolConnectionError(t *testing.T) {
 	db := fakesqldb.Register()
 	db.EnableConnFail()
@@ -217,6 +461,107 @@ func TestTxPoolRollbackFail(t *testing.T) {
 	txPool.Rollback(ctx, transactionID)
 }
 
+func TestTxPoolXA(t *testing.T) {
+	db := fakesqldb.Register()
+	for _, query := range []string{
+		"xa start 'x1'", "xa end 'x1'", "xa prepare 'x1'", "xa commit 'x1'",
+		"xa start 'x2'", "xa end 'x2'", "xa commit 'x2' one phase",
+		"xa start 'x3'", "xa rollback 'x3'", "xa rollback 'x4'",
+	} {
+		db.AddQuery(query, &sqltypes.Result{})
+	}
+	db.AddQuery("xa recover", &sqltypes.Result{
+		Rows: [][]sqltypes.Value{{
+			sqltypes.MakeString([]byte("1")),
+			sqltypes.MakeString([]byte("2")),
+			sqltypes.MakeString([]byte("0")),
+			sqltypes.MakeString([]byte("x3")),
+		}},
+	})
+	txPool := newTxPool(false)
+	appParams := sqldb.ConnParams{Engine: db.Name}
+	dbaParams := sqldb.ConnParams{Engine: db.Name}
+	txPool.Open(&appParams, &dbaParams)
+	defer txPool.Close()
+	ctx := context.Background()
+	wantPanic := func(name string, code vtrpcpb.ErrorCode, f func()) {
+		defer func() {
+			err := recover()
+			if err == nil {
+				t.Fatalf("%v didn't fail", name)
+			}
+			verifyTabletError(t, err, code)
+		}()
+		f()
+	}
+
+	// Two-phase commit.
+	transactionID := txPool.XAStart(ctx, nil, "x1")
+	if got := txPool.ActiveXATransactionID("x1"); got != transactionID {
+		t.Errorf("ActiveXATransactionID(x1): %v, want %v", got, transactionID)
+	}
+	wantPanic("XAStart of an active xid", vtrpcpb.ErrorCode_BAD_INPUT, func() { txPool.XAStart(ctx, nil, "x1") })
+	txPool.XAPrepare(ctx, "x1")
+	wantPanic("second XAPrepare", vtrpcpb.ErrorCode_BAD_INPUT, func() { txPool.XAPrepare(ctx, "x1") })
+	if _, err := txPool.SafeCommit(ctx, transactionID); err != nil {
+		t.Fatalf("SafeCommit of x1: %v", err)
+	}
+	if got := txPool.ActiveXATransactionID("x1"); got != 0 {
+		t.Errorf("ActiveXATransactionID(x1) after the commit: %v, want 0", got)
+	}
+	if got := db.GetQueryCalledNum("xa commit 'x1'"); got != 1 {
+		t.Errorf("xa commit 'x1' calls: %v, want 1", got)
+	}
+	wantPanic("XAPrepare of a committed xid", vtrpcpb.ErrorCode_NOT_IN_TX, func() { txPool.XAPrepare(ctx, "x1") })
+
+	// One-phase commit of a transaction that's not prepared.
+	transactionID = txPool.XAStart(ctx, nil, "x2")
+	if _, err := txPool.SafeCommit(ctx, transactionID); err != nil {
+		t.Fatalf("SafeCommit of x2: %v", err)
+	}
+	if got := db.GetQueryCalledNum("xa commit 'x2' one phase"); got != 1 {
+		t.Errorf("xa commit 'x2' one phase calls: %v, want 1", got)
+	}
+
+	// A prepared transaction stays prepared when its connection is
+	// closed, and can be rolled back after XARecover.
+	transactionID = txPool.XAStart(ctx, nil, "x3")
+	db.AddQuery("xa end 'x3'", &sqltypes.Result{})
+	db.AddQuery("xa prepare 'x3'", &sqltypes.Result{})
+	txPool.XAPrepare(ctx, "x3")
+	txConn := txPool.Get(transactionID)
+	txConn.Close()
+	txConn.Recycle()
+	if got := txPool.ActiveXATransactionID("x3"); got != 0 {
+		t.Errorf("ActiveXATransactionID(x3) after the close: %v, want 0", got)
+	}
+	if got, want := txPool.XARecover(ctx), []string{"x3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("XARecover: %v, want %v", got, want)
+	}
+	txPool.XAConcludeRecovered(ctx, "x3", false)
+	if got := db.GetQueryCalledNum("xa rollback 'x3'"); got != 1 {
+		t.Errorf("xa rollback 'x3' calls: %v, want 1", got)
+	}
+
+	// A failed XA START releases the xid.
+	db.AddRejectedQuery("xa start 'x4'", errRejected)
+	wantPanic("rejected XAStart", vtrpcpb.ErrorCode_UNKNOWN_ERROR, func() { txPool.XAStart(ctx, nil, "x4") })
+	db.DeleteRejectedQuery("xa start 'x4'")
+	db.AddQuery("xa start 'x4'", &sqltypes.Result{})
+	db.AddQuery("xa end 'x4'", &sqltypes.Result{})
+	transactionID = txPool.XAStart(ctx, nil, "x4")
+	txPool.Rollback(ctx, transactionID)
+	if got := db.GetQueryCalledNum("xa rollback 'x4'"); got != 1 {
+		t.Errorf("xa rollback 'x4' calls: %v, want 1", got)
+	}
+}
+
+func TestXAStatement(t *testing.T) {
+	if got, want := xaStatement("xa start", "it's"), "xa start 'it\\'s'"; got != want {
+		t.Errorf("xaStatement: %v, want %v", got, want)
+	}
+}
+
 func TestTxPoolGetConnFail(t *testing.T) {
 	db := fakesqldb.Register()
 	txPool := newTxPool(false)
@@ -256,6 +601,7 @@ func newTxPool(enablePublishStats bool) *TxPool {
 	poolName := fmt.Sprintf("TestTransactionPool-%d", randID)
 	txStatsPrefix := fmt.Sprintf("TxStats-%d-", randID)
 	transactionCap := 300
+	maxWaiters := 0
 	transactionTimeout := time.Duration(30 * time.Second)
 	idleTimeout := time.Duration(30 * time.Second)
 	queryServiceStats := NewQueryServiceStats("", enablePublishStats)
@@ -263,6 +609,7 @@ func newTxPool(enablePublishStats bool) *TxPool {
 		poolName,
 		txStatsPrefix,
 		transactionCap,
+		maxWaiters,
 		transactionTimeout,
 		idleTimeout,
 		enablePublishStats,
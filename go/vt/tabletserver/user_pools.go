@@ -0,0 +1,196 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tabletserver
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"sync"
+	"time"
+
+	"github.com/youtube/vitess/go/sqldb"
+)
+
+var userPoolsFile = flag.String("user_pools_file", "", "JSON file of a map of effective caller ids to the sizes of their own connection pools, e.g. {\"batch\": {\"PoolSize\": 4, \"StreamPoolSize\": 2}}. The queries of a user with a pool size only use the connections of its pool, so that it can't exhaust the pool of the other users. The users without an entry, and the sizes that are 0, use the shared pools. The statements of a transaction use the transaction pool.")
+
+// UserPoolSize is the size of the connection pools of a user.
+// 0 means the user shares the pool of the other users.
+type UserPoolSize struct {
+	// PoolSize is the size of the pool of the regular queries.
+	PoolSize int
+	// StreamPoolSize is the size of the pool of the streaming queries.
+	StreamPoolSize int
+}
+
+// loadUserPoolSizes reads the sizes of -user_pools_file.
+func loadUserPoolSizes(poolsFile string) (map[string]UserPoolSize, error) {
+	if poolsFile == "" {
+		return nil, nil
+	}
+	data, err := ioutil.ReadFile(poolsFile)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the user pools file: %v", err)
+	}
+	var sizes map[string]UserPoolSize
+	if err := json.Unmarshal(data, &sizes); err != nil {
+		return nil, fmt.Errorf("cannot parse the user pools file %s: %v", poolsFile, err)
+	}
+	for user, size := range sizes {
+		if size.PoolSize < 0 || size.StreamPoolSize < 0 {
+			return nil, fmt.Errorf("invalid pool sizes of user %v: %+v, they can't be negative", user, size)
+		}
+	}
+	return sizes, nil
+}
+
+// UserConnPools are the connection pools used by the queries of a
+// user.
+type UserConnPools struct {
+	ConnPool       *ConnPool
+	StreamConnPool *ConnPool
+}
+
+// userPools has the connection pools of the users with a pool size.
+// The pools are created on the first query of their user, and they
+// are closed with the QueryEngine.
+type userPools struct {
+	mu    sync.Mutex
+	sizes map[string]UserPoolSize
+	// pools has the pools created since the last open, by user.
+	pools map[string]*UserConnPools
+	// appParams and dbaParams are nil when the pools are closed.
+	appParams *sqldb.ConnParams
+	dbaParams *sqldb.ConnParams
+
+	idleTimeout       time.Duration
+	queryServiceStats *QueryServiceStats
+	checker           MySQLChecker
+}
+
+func newUserPools(sizes map[string]UserPoolSize, idleTimeout time.Duration, queryServiceStats *QueryServiceStats, checker MySQLChecker) *userPools {
+	return &userPools{
+		sizes:             sizes,
+		pools:             make(map[string]*UserConnPools),
+		idleTimeout:       idleTimeout,
+		queryServiceStats: queryServiceStats,
+		checker:           checker,
+	}
+}
+
+// Open allows the creation of the pools.
+func (up *userPools) Open(appParams, dbaParams *sqldb.ConnParams) {
+	up.mu.Lock()
+	defer up.mu.Unlock()
+	up.appParams = appParams
+	up.dbaParams = dbaParams
+}
+
+// Close closes the pools. They are created again after the next Open.
+func (up *userPools) Close() {
+	up.mu.Lock()
+	pools := up.pools
+	up.pools = make(map[string]*UserConnPools)
+	up.appParams = nil
+	up.dbaParams = nil
+	up.mu.Unlock()
+	// The pools wait for their connections to be returned: they are
+	// closed without the lock.
+	for _, p := range pools {
+		if p.ConnPool != nil {
+			p.ConnPool.Close()
+		}
+		if p.StreamConnPool != nil {
+			p.StreamConnPool.Close()
+		}
+	}
+}
+
+// get returns the pools of user, creating them if needed. The pools
+// of a size of 0 are nil, as are the pools of the users without an
+// entry and the pools asked for while closed.
+func (up *userPools) get(user string) *UserConnPools {
+	up.mu.Lock()
+	defer up.mu.Unlock()
+	if p, ok := up.pools[user]; ok {
+		return p
+	}
+	size, ok := up.sizes[user]
+	if !ok || up.appParams == nil {
+		return nil
+	}
+	p := &UserConnPools{
+		ConnPool:       up.newPool(size.PoolSize),
+		StreamConnPool: up.newPool(size.StreamPoolSize),
+	}
+	up.pools[user] = p
+	return p
+}
+
+func (up *userPools) newPool(size int) *ConnPool {
+	if size == 0 {
+		return nil
+	}
+	cp := NewConnPool("", size, up.idleTimeout, false, up.queryServiceStats, up.checker)
+	if *poolHealthProbeInterval > 0 {
+		cp.enableHealthProbe(*poolHealthProbeInterval)
+	}
+	cp.Open(up.appParams, up.dbaParams)
+	return cp
+}
+
+// stats returns f of the pools that were created, by user and pool.
+func (up *userPools) stats(f func(cp *ConnPool) int64) map[string]int64 {
+	up.mu.Lock()
+	defer up.mu.Unlock()
+	values := make(map[string]int64)
+	for user, p := range up.pools {
+		if p.ConnPool != nil {
+			values[user+".ConnPool"] = f(p.ConnPool)
+		}
+		if p.StreamConnPool != nil {
+			values[user+".StreamConnPool"] = f(p.StreamConnPool)
+		}
+	}
+	return values
+}
+
+// Capacity returns the capacity of the pools, by user and pool.
+func (up *userPools) Capacity() map[string]int64 {
+	return up.stats((*ConnPool).Capacity)
+}
+
+// InUse returns the number of connections in use, by user and pool.
+func (up *userPools) InUse() map[string]int64 {
+	return up.stats(func(cp *ConnPool) int64 {
+		return cp.Capacity() - cp.Available()
+	})
+}
+
+// WaitCount returns how many times the queries waited for a
+// connection, by user and pool.
+func (up *userPools) WaitCount() map[string]int64 {
+	return up.stats((*ConnPool).WaitCount)
+}
+
+// GetOrCreatePool returns the connection pools of the queries of
+// user: its own pools if -user_pools_file gives it a size, the shared
+// pools otherwise.
+func (qe *QueryEngine) GetOrCreatePool(user string) UserConnPools {
+	pools := UserConnPools{
+		ConnPool:       qe.connPool,
+		StreamConnPool: qe.streamConnPool,
+	}
+	if p := qe.userPools.get(user); p != nil {
+		if p.ConnPool != nil {
+			pools.ConnPool = p.ConnPool
+		}
+		if p.StreamConnPool != nil {
+			pools.StreamConnPool = p.StreamConnPool
+		}
+	}
+	return pools
+}
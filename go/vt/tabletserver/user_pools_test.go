@@ -0,0 +1,122 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package tabletserver
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/sqltypes"
+	"github.com/youtube/vitess/go/vt/callerid"
+)
+
+func writeUserPoolsFile(t *testing.T, content string) string {
+	f, err := ioutil.TempFile("", "user_pools")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString(content); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+	return f.Name()
+}
+
+func TestLoadUserPoolSizes(t *testing.T) {
+	sizes, err := loadUserPoolSizes("")
+	if err != nil || sizes != nil {
+		t.Errorf("loadUserPoolSizes(\"\"): %v, %v, want nil, nil", sizes, err)
+	}
+
+	name := writeUserPoolsFile(t, `{"batch": {"PoolSize": 4, "StreamPoolSize": 2}}`)
+	defer os.Remove(name)
+	sizes, err = loadUserPoolSizes(name)
+	if err != nil {
+		t.Fatalf("loadUserPoolSizes: %v", err)
+	}
+	if want := map[string]UserPoolSize{"batch": {PoolSize: 4, StreamPoolSize: 2}}; !reflect.DeepEqual(sizes, want) {
+		t.Errorf("loadUserPoolSizes: %v, want %v", sizes, want)
+	}
+
+	negative := writeUserPoolsFile(t, `{"batch": {"PoolSize": -1}}`)
+	defer os.Remove(negative)
+	if _, err := loadUserPoolSizes(negative); err == nil {
+		t.Errorf("loadUserPoolSizes of a negative size: nil, want error")
+	}
+}
+
+func TestQueryExecutorUserPools(t *testing.T) {
+	name := writeUserPoolsFile(t, `{"userb": {"PoolSize": 1}}`)
+	defer os.Remove(name)
+	defer func(v string) { *userPoolsFile = v }(*userPoolsFile)
+	*userPoolsFile = name
+
+	db := setUpQueryExecutorTest()
+	query := "select * from test_table limit 1000"
+	want := &sqltypes.Result{
+		Fields: getTestTableFields(),
+		Rows:   [][]sqltypes.Value{},
+	}
+	db.AddQuery(query, want)
+	db.AddQuery("select * from test_table where 1 != 1", &sqltypes.Result{
+		Fields: getTestTableFields(),
+	})
+	ctx := context.Background()
+	tsv := newTestTabletServer(ctx, enableSchemaOverrides|enableStrict, db)
+	defer tsv.StopService()
+
+	userA := callerid.NewContext(ctx, callerid.NewEffectiveCallerID("usera", "", ""), nil)
+	userB := callerid.NewContext(ctx, callerid.NewEffectiveCallerID("userb", "", ""), nil)
+	if got := tsv.qe.GetOrCreatePool("usera"); got.ConnPool != tsv.qe.connPool || got.StreamConnPool != tsv.qe.streamConnPool {
+		t.Errorf("GetOrCreatePool(usera) isn't the shared pools")
+	}
+	poolB := tsv.qe.GetOrCreatePool("userb")
+	if poolB.ConnPool == tsv.qe.connPool || poolB.StreamConnPool != tsv.qe.streamConnPool {
+		t.Errorf("GetOrCreatePool(userb) = %+v, want its own pool and the shared stream pool", poolB)
+	}
+	if got := tsv.qe.GetOrCreatePool("userb"); got != poolB {
+		t.Errorf("GetOrCreatePool(userb) again: %+v, want %+v", got, poolB)
+	}
+
+	// Exhaust the quota of userb.
+	conn, err := poolB.ConnPool.Get(ctx)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got, want := tsv.qe.userPools.InUse(), map[string]int64{"userb.ConnPool": 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("InUse: %v, want %v", got, want)
+	}
+
+	shortCtx, cancel := context.WithTimeout(userB, 10*time.Millisecond)
+	defer cancel()
+	qre := newTestQueryExecutor(shortCtx, tsv, query, 0)
+	qre.noConsolidation = true
+	if _, err := qre.Execute(); err == nil {
+		t.Errorf("Execute of userb with its pool exhausted: nil, want error")
+	}
+
+	qre = newTestQueryExecutor(userA, tsv, query, 0)
+	got, err := qre.Execute()
+	if err != nil {
+		t.Fatalf("Execute of usera: %v, want nil", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Execute of usera: %v, want %v", got, want)
+	}
+
+	conn.Recycle()
+	qre = newTestQueryExecutor(userB, tsv, query, 0)
+	if _, err := qre.Execute(); err != nil {
+		t.Errorf("Execute of userb after the release: %v, want nil", err)
+	}
+	if got, want := tsv.qe.userPools.Capacity(), map[string]int64{"userb.ConnPool": 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Capacity: %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package topo
+
+import (
+	"fmt"
+
+	"golang.org/x/net/context"
+
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+)
+
+// This file contains the cells alias utility functions. A cells alias
+// is a named group of cells, e.g. the availability zones of a region,
+// that vtgate can treat as one locality with -cell_alias. A cell
+// belongs to at most one alias.
+
+// CreateCellsAlias checks that the cells of cellsAlias are not in
+// another alias, then creates it.
+func (ts Server) CreateCellsAlias(ctx context.Context, alias string, cellsAlias *topodatapb.CellsAlias) error {
+	if err := ts.checkCellsAlias(ctx, alias, cellsAlias); err != nil {
+		return err
+	}
+	return ts.Impl.CreateCellsAlias(ctx, alias, cellsAlias)
+}
+
+// UpdateCellsAlias checks that the cells of cellsAlias are not in
+// another alias, then replaces the cells of alias with them.
+func (ts Server) UpdateCellsAlias(ctx context.Context, alias string, cellsAlias *topodatapb.CellsAlias) error {
+	if err := ts.checkCellsAlias(ctx, alias, cellsAlias); err != nil {
+		return err
+	}
+	return ts.Impl.UpdateCellsAlias(ctx, alias, cellsAlias)
+}
+
+// checkCellsAlias returns an error if cellsAlias has no cells, or if
+// one of them is in an alias other than alias.
+func (ts Server) checkCellsAlias(ctx context.Context, alias string, cellsAlias *topodatapb.CellsAlias) error {
+	if alias == "" {
+		return fmt.Errorf("a cells alias must have a name")
+	}
+	if len(cellsAlias.Cells) == 0 {
+		return fmt.Errorf("cells alias %v must have cells", alias)
+	}
+	aliases, err := ts.GetCellsAliases(ctx)
+	if err != nil {
+		return err
+	}
+	cells := make(map[string]bool, len(cellsAlias.Cells))
+	for _, cell := range cellsAlias.Cells {
+		cells[cell] = true
+	}
+	for _, other := range aliases {
+		if other == alias {
+			continue
+		}
+		otherAlias, err := ts.GetCellsAlias(ctx, other)
+		if err != nil {
+			if err == ErrNoNode {
+				// deleted concurrently
+				continue
+			}
+			return err
+		}
+		for _, cell := range otherAlias.Cells {
+			if cells[cell] {
+				return fmt.Errorf("cell %v is already in the cells alias %v", cell, other)
+			}
+		}
+	}
+	return nil
+}
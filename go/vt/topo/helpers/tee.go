@@ -98,6 +98,68 @@ func (tee *Tee) GetKnownCells(ctx context.Context) ([]string, error) {
 	return tee.readFrom.GetKnownCells(ctx)
 }
 
+//
+// Cells alias management, global.
+//
+
+// CreateCellsAlias is part of the topo.Server interface
+func (tee *Tee) CreateCellsAlias(ctx context.Context, alias string, cellsAlias *topodatapb.CellsAlias) error {
+	if err := tee.primary.CreateCellsAlias(ctx, alias, cellsAlias); err != nil {
+		return err
+	}
+
+	if err := tee.secondary.CreateCellsAlias(ctx, alias, cellsAlias); err != nil {
+		// not critical enough to fail
+		log.Warningf("secondary.CreateCellsAlias(%v) failed: %v", alias, err)
+	}
+	return nil
+}
+
+// UpdateCellsAlias is part of the topo.Server interface
+func (tee *Tee) UpdateCellsAlias(ctx context.Context, alias string, cellsAlias *topodatapb.CellsAlias) error {
+	if err := tee.primary.UpdateCellsAlias(ctx, alias, cellsAlias); err != nil {
+		// failed on primary, not updating secondary
+		return err
+	}
+
+	if err := tee.secondary.UpdateCellsAlias(ctx, alias, cellsAlias); err != nil {
+		// not critical enough to fail
+		if err == topo.ErrNoNode {
+			// the alias doesn't exist on the secondary, let's
+			// just create it
+			if err = tee.secondary.CreateCellsAlias(ctx, alias, cellsAlias); err != nil {
+				log.Warningf("secondary.CreateCellsAlias(%v) failed (after UpdateCellsAlias returned ErrNoNode): %v", alias, err)
+			}
+		} else {
+			log.Warningf("secondary.UpdateCellsAlias(%v) failed: %v", alias, err)
+		}
+	}
+	return nil
+}
+
+// DeleteCellsAlias is part of the topo.Server interface
+func (tee *Tee) DeleteCellsAlias(ctx context.Context, alias string) error {
+	if err := tee.primary.DeleteCellsAlias(ctx, alias); err != nil {
+		return err
+	}
+
+	if err := tee.secondary.DeleteCellsAlias(ctx, alias); err != nil {
+		// not critical enough to fail
+		log.Warningf("secondary.DeleteCellsAlias(%v) failed: %v", alias, err)
+	}
+	return nil
+}
+
+// GetCellsAlias is part of the topo.Server interface
+func (tee *Tee) GetCellsAlias(ctx context.Context, alias string) (*topodatapb.CellsAlias, error) {
+	return tee.readFrom.GetCellsAlias(ctx, alias)
+}
+
+// GetCellsAliases is part of the topo.Server interface
+func (tee *Tee) GetCellsAliases(ctx context.Context) ([]string, error) {
+	return tee.readFrom.GetCellsAliases(ctx)
+}
+
 //
 // Keyspace management, global.
 //
@@ -54,6 +54,12 @@ func TestKeyspace(t *testing.T) {
 	test.CheckKeyspace(ctx, t, ts)
 }
 
+func TestCellsAlias(t *testing.T) {
+	ctx := context.Background()
+	ts := newFakeTeeServer(t)
+	test.CheckCellsAlias(ctx, t, ts)
+}
+
 func TestShard(t *testing.T) {
 	ctx := context.Background()
 	ts := newFakeTeeServer(t)
@@ -70,6 +70,34 @@ type Impl interface {
 	// They shall be sorted.
 	GetKnownCells(ctx context.Context) ([]string, error)
 
+	//
+	// Cells alias management, global.
+	//
+
+	// CreateCellsAlias creates the given cells alias, assuming it
+	// doesn't exist yet. Can return ErrNodeExists if it already exists.
+	//
+	// Do not use directly, but instead use topo.CreateCellsAlias.
+	CreateCellsAlias(ctx context.Context, alias string, cellsAlias *topodatapb.CellsAlias) error
+
+	// UpdateCellsAlias replaces the cells of the given cells alias.
+	// Can return ErrNoNode if the alias doesn't exist yet.
+	//
+	// Do not use directly, but instead use topo.UpdateCellsAlias.
+	UpdateCellsAlias(ctx context.Context, alias string, cellsAlias *topodatapb.CellsAlias) error
+
+	// DeleteCellsAlias deletes the specified cells alias.
+	// Can return ErrNoNode if the alias doesn't exist.
+	DeleteCellsAlias(ctx context.Context, alias string) error
+
+	// GetCellsAlias reads a cells alias and returns it.
+	// Can return ErrNoNode
+	GetCellsAlias(ctx context.Context, alias string) (*topodatapb.CellsAlias, error)
+
+	// GetCellsAliases returns the known cells alias names. They shall
+	// be sorted.
+	GetCellsAliases(ctx context.Context) ([]string, error)
+
 	//
 	// Keyspace management, global.
 	//
@@ -340,6 +340,9 @@ func (si *ShardInfo) UpdateSourceBlacklistedTables(tabletType topodatapb.TabletT
 	if tc.DisableQueryService {
 		return fmt.Errorf("cannot safely alter BlacklistedTables as DisableQueryService is set for shard %v/%v", si.keyspace, si.shardName)
 	}
+	if tc.EnforceKeyRange {
+		return fmt.Errorf("cannot safely alter BlacklistedTables as EnforceKeyRange is set for shard %v/%v", si.keyspace, si.shardName)
+	}
 
 	if remove {
 		si.removeCellsFromTabletControl(tc, tabletType, cells)
@@ -380,6 +383,9 @@ func (si *ShardInfo) UpdateDisableQueryService(tabletType topodatapb.TabletType,
 	if len(tc.BlacklistedTables) > 0 {
 		return fmt.Errorf("cannot safely alter DisableQueryService as BlacklistedTables is set")
 	}
+	if tc.EnforceKeyRange {
+		return fmt.Errorf("cannot safely alter DisableQueryService as EnforceKeyRange is set")
+	}
 	if !tc.DisableQueryService {
 		return fmt.Errorf("cannot safely alter DisableQueryService as DisableQueryService is not set, this record should not be there")
 	}
@@ -392,6 +398,43 @@ func (si *ShardInfo) UpdateDisableQueryService(tabletType topodatapb.TabletType,
 	return nil
 }
 
+// UpdateEnforceKeyRange will make sure the enforceKeyRange is set
+// appropriately in the shard record. Like DisableQueryService and
+// BlacklistedTables, it has to be the only setting of its record:
+// it's used during the overlap of a horizontal resharding, and needs
+// to be removed before the served types are migrated.
+func (si *ShardInfo) UpdateEnforceKeyRange(tabletType topodatapb.TabletType, cells []string, enforceKeyRange bool) error {
+	tc := si.GetTabletControl(tabletType)
+	if tc == nil {
+		// handle the case where the TabletControl object is new
+		if enforceKeyRange {
+			si.TabletControls = append(si.TabletControls, &topodatapb.Shard_TabletControl{
+				TabletType:      tabletType,
+				Cells:           cells,
+				EnforceKeyRange: true,
+			})
+		} else {
+			log.Warningf("Trying to remove TabletControl.EnforceKeyRange for missing type: %v", tabletType)
+		}
+		return nil
+	}
+
+	// we have an existing record, check it only has EnforceKeyRange set
+	if tc.DisableQueryService || len(tc.BlacklistedTables) > 0 {
+		return fmt.Errorf("cannot safely alter EnforceKeyRange as DisableQueryService or BlacklistedTables is set")
+	}
+	if !tc.EnforceKeyRange {
+		return fmt.Errorf("cannot safely alter EnforceKeyRange as EnforceKeyRange is not set, this record should not be there")
+	}
+
+	if enforceKeyRange {
+		tc.Cells = addCells(tc.Cells, cells)
+	} else {
+		si.removeCellsFromTabletControl(tc, tabletType, cells)
+	}
+	return nil
+}
+
 func (si *ShardInfo) removeCellsFromTabletControl(tc *topodatapb.Shard_TabletControl, tabletType topodatapb.TabletType, cells []string) {
 	result, emptyList := removeCells(tc.Cells, cells, si.Cells)
 	if emptyList {
@@ -192,6 +192,45 @@ func TestUpdateDisableQueryService(t *testing.T) {
 	}
 }
 
+func TestUpdateEnforceKeyRange(t *testing.T) {
+	si := NewShardInfo("ks", "sh", &topodatapb.Shard{
+		Cells: []string{"first", "second", "third"},
+	}, 1)
+
+	// add two cells
+	if err := si.UpdateEnforceKeyRange(topodatapb.TabletType_REPLICA, []string{"first"}, true); err != nil {
+		t.Fatalf("one cell add failed: %v", err)
+	}
+	if err := si.UpdateEnforceKeyRange(topodatapb.TabletType_REPLICA, []string{"second"}, true); err != nil || !reflect.DeepEqual(si.TabletControls, []*topodatapb.Shard_TabletControl{
+		{
+			TabletType:      topodatapb.TabletType_REPLICA,
+			Cells:           []string{"first", "second"},
+			EnforceKeyRange: true,
+		},
+	}) {
+		t.Fatalf("second cell add failed: %v %v", err, si)
+	}
+
+	// the other settings of the record cannot be changed
+	if err := si.UpdateDisableQueryService(topodatapb.TabletType_REPLICA, []string{"first"}, true); err == nil || err.Error() != "cannot safely alter DisableQueryService as EnforceKeyRange is set" {
+		t.Fatalf("UpdateDisableQueryService should fail: %v", err)
+	}
+	if err := si.UpdateSourceBlacklistedTables(topodatapb.TabletType_REPLICA, []string{"first"}, false, []string{"t1"}); err == nil || err.Error() != "cannot safely alter BlacklistedTables as EnforceKeyRange is set for shard ks/sh" {
+		t.Fatalf("UpdateSourceBlacklistedTables should fail: %v", err)
+	}
+	if err := si.UpdateDisableQueryService(topodatapb.TabletType_RDONLY, []string{"first"}, true); err != nil {
+		t.Fatalf("UpdateDisableQueryService(RDONLY) failed: %v", err)
+	}
+	if err := si.UpdateEnforceKeyRange(topodatapb.TabletType_RDONLY, []string{"first"}, true); err == nil || err.Error() != "cannot safely alter EnforceKeyRange as DisableQueryService or BlacklistedTables is set" {
+		t.Fatalf("UpdateEnforceKeyRange(RDONLY) should fail: %v", err)
+	}
+
+	// remove the cells, going back
+	if err := si.UpdateEnforceKeyRange(topodatapb.TabletType_REPLICA, []string{"first", "second"}, false); err != nil || len(si.TabletControls) != 1 || si.GetTabletControl(topodatapb.TabletType_REPLICA) != nil {
+		t.Fatalf("going back should have removed the record: %v %v", err, si)
+	}
+}
+
 func TestUpdateServedTypesMap(t *testing.T) {
 	si := NewShardInfo("ks", "sh", &topodatapb.Shard{
 		Cells: []string{"first", "second", "third"},
@@ -0,0 +1,84 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/youtube/vitess/go/vt/topo"
+	"golang.org/x/net/context"
+
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+)
+
+// CheckCellsAlias tests the cells alias part of the API
+func CheckCellsAlias(ctx context.Context, t *testing.T, ts topo.Impl) {
+	aliases, err := ts.GetCellsAliases(ctx)
+	if err != nil {
+		t.Errorf("GetCellsAliases(empty): %v", err)
+	}
+	if len(aliases) != 0 {
+		t.Errorf("len(GetCellsAliases()) != 0: %v", aliases)
+	}
+	if _, err := ts.GetCellsAlias(ctx, "region1"); err != topo.ErrNoNode {
+		t.Errorf("GetCellsAlias(missing) is not ErrNoNode: %v", err)
+	}
+	if err := ts.UpdateCellsAlias(ctx, "region1", &topodatapb.CellsAlias{Cells: []string{"zone1"}}); err != topo.ErrNoNode {
+		t.Errorf("UpdateCellsAlias(missing) is not ErrNoNode: %v", err)
+	}
+
+	region1 := &topodatapb.CellsAlias{Cells: []string{"zone1", "zone2"}}
+	if err := ts.CreateCellsAlias(ctx, "region1", region1); err != nil {
+		t.Fatalf("CreateCellsAlias: %v", err)
+	}
+	if err := ts.CreateCellsAlias(ctx, "region1", region1); err != topo.ErrNodeExists {
+		t.Errorf("CreateCellsAlias(again) is not ErrNodeExists: %v", err)
+	}
+	if err := ts.CreateCellsAlias(ctx, "region2", &topodatapb.CellsAlias{Cells: []string{"zone3"}}); err != nil {
+		t.Fatalf("CreateCellsAlias: %v", err)
+	}
+
+	aliases, err = ts.GetCellsAliases(ctx)
+	if err != nil {
+		t.Errorf("GetCellsAliases: %v", err)
+	}
+	if want := []string{"region1", "region2"}; !reflect.DeepEqual(aliases, want) {
+		t.Errorf("GetCellsAliases: got %v, want %v", aliases, want)
+	}
+	got, err := ts.GetCellsAlias(ctx, "region1")
+	if err != nil {
+		t.Errorf("GetCellsAlias: %v", err)
+	}
+	if !reflect.DeepEqual(got, region1) {
+		t.Errorf("GetCellsAlias: got %v, want %v", got, region1)
+	}
+
+	region1 = &topodatapb.CellsAlias{Cells: []string{"zone1", "zone2", "zone4"}}
+	if err := ts.UpdateCellsAlias(ctx, "region1", region1); err != nil {
+		t.Errorf("UpdateCellsAlias: %v", err)
+	}
+	got, err = ts.GetCellsAlias(ctx, "region1")
+	if err != nil {
+		t.Errorf("GetCellsAlias: %v", err)
+	}
+	if !reflect.DeepEqual(got, region1) {
+		t.Errorf("GetCellsAlias after UpdateCellsAlias: got %v, want %v", got, region1)
+	}
+
+	if err := ts.DeleteCellsAlias(ctx, "region1"); err != nil {
+		t.Errorf("DeleteCellsAlias: %v", err)
+	}
+	if err := ts.DeleteCellsAlias(ctx, "region1"); err != topo.ErrNoNode {
+		t.Errorf("DeleteCellsAlias(again) is not ErrNoNode: %v", err)
+	}
+	aliases, err = ts.GetCellsAliases(ctx)
+	if err != nil {
+		t.Errorf("GetCellsAliases: %v", err)
+	}
+	if want := []string{"region2"}; !reflect.DeepEqual(aliases, want) {
+		t.Errorf("GetCellsAliases after DeleteCellsAlias: got %v, want %v", aliases, want)
+	}
+}
@@ -39,6 +39,31 @@ func (ft FakeTopo) GetKnownCells(ctx context.Context) ([]string, error) {
 	return nil, errNotImplemented
 }
 
+// CreateCellsAlias implements topo.Server.
+func (ft FakeTopo) CreateCellsAlias(ctx context.Context, alias string, cellsAlias *topodatapb.CellsAlias) error {
+	return errNotImplemented
+}
+
+// UpdateCellsAlias implements topo.Server.
+func (ft FakeTopo) UpdateCellsAlias(ctx context.Context, alias string, cellsAlias *topodatapb.CellsAlias) error {
+	return errNotImplemented
+}
+
+// DeleteCellsAlias implements topo.Server.
+func (ft FakeTopo) DeleteCellsAlias(ctx context.Context, alias string) error {
+	return errNotImplemented
+}
+
+// GetCellsAlias implements topo.Server.
+func (ft FakeTopo) GetCellsAlias(ctx context.Context, alias string) (*topodatapb.CellsAlias, error) {
+	return nil, errNotImplemented
+}
+
+// GetCellsAliases implements topo.Server.
+func (ft FakeTopo) GetCellsAliases(ctx context.Context) ([]string, error) {
+	return nil, errNotImplemented
+}
+
 // CreateKeyspace implements topo.Server.
 func (ft FakeTopo) CreateKeyspace(ctx context.Context, keyspace string, value *topodatapb.Keyspace) error {
 	return errNotImplemented
@@ -46,6 +46,7 @@ func findCellsForRebuild(ki *topo.KeyspaceInfo, shardMap map[string]*topo.ShardI
 					ShardingColumnType: ki.ShardingColumnType,
 					ServedFrom:         ki.ComputeCellServedFrom(cell),
 					SplitShardCount:    ki.SplitShardCount,
+					FallbackCells:      ki.FallbackCells,
 				}
 			}
 		}
@@ -110,6 +110,21 @@ func (f *fakeVTGateService) ExecuteBatchKeyspaceIds(ctx context.Context, queries
 	return nil, nil
 }
 
+// Prepare is part of the VTGateService interface
+func (f *fakeVTGateService) Prepare(ctx context.Context, sql string, keyspace string, tabletType topodatapb.TabletType) (int64, int64, []*querypb.Field, error) {
+	return 0, 0, nil, nil
+}
+
+// ExecutePrepared is part of the VTGateService interface
+func (f *fakeVTGateService) ExecutePrepared(ctx context.Context, id int64, bindVariables map[string]interface{}, session *vtgatepb.Session, notInTransaction bool) (*sqltypes.Result, error) {
+	return nil, nil
+}
+
+// ClosePrepared is part of the VTGateService interface
+func (f *fakeVTGateService) ClosePrepared(ctx context.Context, ids []int64) error {
+	return nil
+}
+
 // StreamExecute is part of the VTGateService interface
 func (f *fakeVTGateService) StreamExecute(ctx context.Context, sql string, bindVariables map[string]interface{}, keyspace string, tabletType topodatapb.TabletType, sendReply func(*sqltypes.Result) error) error {
 	execCase, ok := execMap[sql]
@@ -122,8 +137,11 @@ func (f *fakeVTGateService) StreamExecute(ctx context.Context, sql string, bindV
 		Keyspace:      keyspace,
 		TabletType:    tabletType,
 	}
-	if !reflect.DeepEqual(query, execCase.execQuery) {
-		return fmt.Errorf("request mismatch: got %+v, want %+v", query, execCase.execQuery)
+	// The streaming queries have no session.
+	want := *execCase.execQuery
+	want.Session = nil
+	if !reflect.DeepEqual(query, &want) {
+		return fmt.Errorf("request mismatch: got %+v, want %+v", query, &want)
 	}
 	if execCase.result != nil {
 		result := &sqltypes.Result{
@@ -237,6 +255,11 @@ func (f *fakeVTGateService) GetSrvKeyspace(ctx context.Context, keyspace string)
 	return &topodatapb.SrvKeyspace{}, nil
 }
 
+// GetKeyspaceSchema is part of the VTGateService interface
+func (f *fakeVTGateService) GetKeyspaceSchema(ctx context.Context, keyspace string) (*vtgatepb.GetKeyspaceSchemaResponse, error) {
+	return &vtgatepb.GetKeyspaceSchemaResponse{}, nil
+}
+
 // GetSrvShard is part of the VTGateService interface
 func (f *fakeVTGateService) GetSrvShard(ctx context.Context, keyspace, shard string) (*topodatapb.SrvShard, error) {
 	return &topodatapb.SrvShard{}, nil
@@ -267,7 +290,7 @@ var execMap = map[string]struct {
 				"v1": int64(0),
 			},
 			TabletType: topodatapb.TabletType_RDONLY,
-			Session:    nil,
+			Session:    &vtgatepb.Session{},
 		},
 		result:  &result1,
 		session: nil,
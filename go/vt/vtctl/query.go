@@ -55,6 +55,11 @@ func init() {
 		commandVtGateSplitQuery,
 		"-server <vtgate> -keyspace <keyspace> [-split_column <split_column>] -split_count <split_count> [-bind_variables <JSON map>] [-connect_timeout <connect timeout>] <sql>",
 		"Executes the SplitQuery computation for the given SQL query with the provided bound variables against the vtgate server (this is the base query for Map-Reduce workloads, and is provided here for debug / test purposes)."})
+	addCommand(queriesGroupName, command{
+		"VtGateGetKeyspaceSchema",
+		commandVtGateGetKeyspaceSchema,
+		"-server <vtgate> [-connect_timeout <connect timeout>] <keyspace>",
+		"Displays the schema of the keyspace as seen by the vtgate server, read from the master of each shard. The drifts are the differences between the shards."})
 
 	// VtTablet commands
 	addCommand(queriesGroupName, command{
@@ -271,6 +276,28 @@ func commandVtGateSplitQuery(ctx context.Context, wr *wrangler.Wrangler, subFlag
 	return printJSON(wr.Logger(), r)
 }
 
+func commandVtGateGetKeyspaceSchema(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	server := subFlags.String("server", "", "VtGate server to connect to")
+	connectTimeout := subFlags.Duration("connect_timeout", 30*time.Second, "Connection timeout for vtgate client")
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("the <keyspace> argument is required for the VtGateGetKeyspaceSchema command")
+	}
+
+	vtgateConn, err := vtgateconn.Dial(ctx, *server, *connectTimeout)
+	if err != nil {
+		return fmt.Errorf("error connecting to vtgate '%v': %v", *server, err)
+	}
+	defer vtgateConn.Close()
+	r, err := vtgateConn.GetKeyspaceSchema(ctx, subFlags.Arg(0))
+	if err != nil {
+		return fmt.Errorf("GetKeyspaceSchema failed: %v", err)
+	}
+	return printJSON(wr.Logger(), r)
+}
+
 func commandVtTabletExecute(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
 	transactionID := subFlags.Int("transaction_id", 0, "transaction id to use, if inside a transaction.")
 	bindVariables := newBindvars(subFlags)
@@ -102,6 +102,7 @@ import (
 	"github.com/youtube/vitess/go/vt/topo"
 	"github.com/youtube/vitess/go/vt/topo/topoproto"
 	"github.com/youtube/vitess/go/vt/topotools"
+	"github.com/youtube/vitess/go/vt/vtgate/vindexes"
 	"github.com/youtube/vitess/go/vt/wrangler"
 
 	replicationdatapb "github.com/youtube/vitess/go/vt/proto/replicationdata"
@@ -174,6 +175,9 @@ var commands = []commandGroup{
 			{"IgnoreHealthError", commandIgnoreHealthError,
 				"<tablet alias> <ignore regexp>",
 				"Sets the regexp for health check errors to ignore on the specified tablet. The pattern has implicit ^$ anchors. Set to empty string or restart vttablet to stop ignoring anything."},
+			{"WarmQueries", commandWarmQueries,
+				"<tablet alias>",
+				"Starts the query warmer of the specified replica or rdonly tablet, which executes the hot queries of the master of its shard against its MySQL to warm its buffer pool. The progress is shown on the status page of the tablet."},
 			{"Sleep", commandSleep,
 				"<tablet alias> <duration>",
 				"Blocks the action queue on the specified tablet for the specified amount of time. This is typically used for testing."},
@@ -217,8 +221,8 @@ var commands = []commandGroup{
 				"<keyspace/shard> [<served tablet type1>,<served tablet type2>,...]",
 				"Sets a given shard's served tablet types. Does not rebuild any serving graph."},
 			{"SetShardTabletControl", commandSetShardTabletControl,
-				"[--cells=c1,c2,...] [--blacklisted_tables=t1,t2,...] [--remove] [--disable_query_service] <keyspace/shard> <tablet type>",
-				"Sets the TabletControl record for a shard and type. Only use this for an emergency fix or after a finished vertical split. The *MigrateServedFrom* and *MigrateServedType* commands set this field appropriately already. Always specify the blacklisted_tables flag for vertical splits, but never for horizontal splits."},
+				"[--cells=c1,c2,...] [--blacklisted_tables=t1,t2,...] [--remove] [--disable_query_service] [--enforce_key_range] <keyspace/shard> <tablet type>",
+				"Sets the TabletControl record for a shard and type. Only use this for an emergency fix or after a finished vertical split. The *MigrateServedFrom* and *MigrateServedType* commands set this field appropriately already. Always specify the blacklisted_tables flag for vertical splits, but never for horizontal splits. The enforce_key_range flag makes the tablets started with -enforce_key_range restrict their queries to the key range of the shard during the overlap of a horizontal split, and cannot be combined with the other flags. Remove it with --enforce_key_range --remove before migrating the served types."},
 			{"SourceShardDelete", commandSourceShardDelete,
 				"<keyspace/shard> <uid>",
 				"Deletes the SourceShard record with the provided index. This is meant as an emergency cleanup function. It does not call RefreshState for the shard master."},
@@ -245,6 +249,25 @@ var commands = []commandGroup{
 				"Deletes the specified shard(s). In recursive mode, it also deletes all tablets belonging to the shard. Otherwise, there must be no tablets left in the shard."},
 		},
 	},
+	{
+		"Cells Aliases", []command{
+			{"AddCellsAlias", commandAddCellsAlias,
+				"<alias> <cell1>,<cell2>,...",
+				"Creates the cells alias, a named group of cells that the vtgates started with -cell_alias=<alias> treat as local. A cell can only be in one alias."},
+			{"UpdateCellsAlias", commandUpdateCellsAlias,
+				"<alias> <cell1>,<cell2>,...",
+				"Replaces the cells of the cells alias. A cell can only be in one alias. The vtgates read their alias when they start."},
+			{"DeleteCellsAlias", commandDeleteCellsAlias,
+				"<alias>",
+				"Deletes the cells alias."},
+			{"GetCellsAlias", commandGetCellsAlias,
+				"<alias>",
+				"Outputs a JSON structure that contains the cells of the cells alias."},
+			{"GetCellsAliases", commandGetCellsAliases,
+				"",
+				"Outputs a sorted list of all cells aliases."},
+		},
+	},
 	{
 		"Keyspaces", []command{
 			{"CreateKeyspace", commandCreateKeyspace,
@@ -268,6 +291,9 @@ var commands = []commandGroup{
 			{"SetKeyspaceServedFrom", commandSetKeyspaceServedFrom,
 				"[-source=<source keyspace name>] [-remove] [-cells=c1,c2,...] <keyspace name> <tablet type>",
 				"Changes the ServedFromMap manually. This command is intended for emergency fixes. This field is automatically set when you call the *MigrateServedFrom* command. This command does not rebuild the serving graph."},
+			{"SetKeyspaceFallbackCells", commandSetKeyspaceFallbackCells,
+				"<keyspace name> [<cell1>,<cell2>,...]",
+				"Sets the cells, in order, where vtgate sends the replica and rdonly queries of the keyspace when its own cell has no serving tablet. They override the -fallback-cells of vtgate. Without cells, the keyspace uses -fallback-cells again. This command does not rebuild the serving graph."},
 			{"RebuildKeyspaceGraph", commandRebuildKeyspaceGraph,
 				"[-cells=a,b] [-rebuild_srv_shards] <keyspace> ...",
 				"Rebuilds the serving data for the keyspace and, optionally, all shards in the specified keyspace. This command may trigger an update to all connected clients."},
@@ -351,8 +377,8 @@ var commands = []commandGroup{
 				"<keyspace>",
 				"Displays the VTGate routing schema."},
 			{"ApplyVSchema", commandApplyVSchema,
-				"{-vschema=<vschema> || -vschema_file=<vschema file>} <keyspace>",
-				"Applies the VTGate routing schema."},
+				"{-vschema=<vschema> || -vschema_file=<vschema file>} [-dry_run] <keyspace>",
+				"Validates the VTGate routing schema, displays its differences with the current one, and applies it unless -dry_run is set."},
 		},
 	},
 	{
@@ -918,6 +944,24 @@ func commandIgnoreHealthError(ctx context.Context, wr *wrangler.Wrangler, subFla
 	return wr.TabletManagerClient().IgnoreHealthError(ctx, tabletInfo, pattern)
 }
 
+func commandWarmQueries(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("The <tablet alias> argument is required for the WarmQueries command.")
+	}
+	tabletAlias, err := topoproto.ParseTabletAlias(subFlags.Arg(0))
+	if err != nil {
+		return err
+	}
+	tabletInfo, err := wr.TopoServer().GetTablet(ctx, tabletAlias)
+	if err != nil {
+		return err
+	}
+	return wr.TabletManagerClient().WarmQueries(ctx, tabletInfo)
+}
+
 func commandWaitForDrain(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
 	var cells flagutil.StringListValue
 	subFlags.Var(&cells, "cells", "Specifies a comma-separated list of cells to look for tablets")
@@ -1240,12 +1284,16 @@ func commandSetShardTabletControl(ctx context.Context, wr *wrangler.Wrangler, su
 	tablesStr := subFlags.String("tables", "", "Specifies a comma-separated list of tables to replicate (used for vertical split)")
 	remove := subFlags.Bool("remove", false, "Removes cells for vertical splits. This flag requires the *tables* flag to also be set.")
 	disableQueryService := subFlags.Bool("disable_query_service", false, "Disables query service on the provided nodes")
+	enforceKeyRange := subFlags.Bool("enforce_key_range", false, "Restricts the queries of the provided nodes to the key range of the shard. With *remove*, lifts the restriction.")
 	if err := subFlags.Parse(args); err != nil {
 		return err
 	}
 	if subFlags.NArg() != 2 {
 		return fmt.Errorf("The <keyspace/shard> and <tablet type> arguments are both required for the SetShardTabletControl command.")
 	}
+	if *enforceKeyRange && (*disableQueryService || *tablesStr != "") {
+		return fmt.Errorf("The enforce_key_range flag cannot be combined with the disable_query_service and tables flags.")
+	}
 	keyspace, shard, err := topoproto.ParseKeyspaceShard(subFlags.Arg(0))
 	if err != nil {
 		return err
@@ -1263,6 +1311,9 @@ func commandSetShardTabletControl(ctx context.Context, wr *wrangler.Wrangler, su
 		cells = strings.Split(*cellsStr, ",")
 	}
 
+	if *enforceKeyRange {
+		return wr.SetShardEnforceKeyRange(ctx, keyspace, shard, tabletType, cells, !*remove)
+	}
 	return wr.SetShardTabletControl(ctx, keyspace, shard, tabletType, cells, *remove, *disableQueryService, tables)
 }
 
@@ -1575,6 +1626,66 @@ func commandRemoveKeyspaceCell(ctx context.Context, wr *wrangler.Wrangler, subFl
 	return wr.RemoveKeyspaceCell(ctx, subFlags.Arg(0), subFlags.Arg(1), *force, *recursive)
 }
 
+func commandAddCellsAlias(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 2 {
+		return fmt.Errorf("The <alias> and <cells> arguments are required for the AddCellsAlias command.")
+	}
+	return wr.TopoServer().CreateCellsAlias(ctx, subFlags.Arg(0), &topodatapb.CellsAlias{
+		Cells: strings.Split(subFlags.Arg(1), ","),
+	})
+}
+
+func commandUpdateCellsAlias(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 2 {
+		return fmt.Errorf("The <alias> and <cells> arguments are required for the UpdateCellsAlias command.")
+	}
+	return wr.TopoServer().UpdateCellsAlias(ctx, subFlags.Arg(0), &topodatapb.CellsAlias{
+		Cells: strings.Split(subFlags.Arg(1), ","),
+	})
+}
+
+func commandDeleteCellsAlias(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("The <alias> argument is required for the DeleteCellsAlias command.")
+	}
+	return wr.TopoServer().DeleteCellsAlias(ctx, subFlags.Arg(0))
+}
+
+func commandGetCellsAlias(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() != 1 {
+		return fmt.Errorf("The <alias> argument is required for the GetCellsAlias command.")
+	}
+	cellsAlias, err := wr.TopoServer().GetCellsAlias(ctx, subFlags.Arg(0))
+	if err != nil {
+		return err
+	}
+	return printJSON(wr.Logger(), cellsAlias)
+}
+
+func commandGetCellsAliases(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	aliases, err := wr.TopoServer().GetCellsAliases(ctx)
+	if err != nil {
+		return err
+	}
+	wr.Logger().Printf("%v\n", strings.Join(aliases, "\n"))
+	return nil
+}
+
 func commandGetKeyspace(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
 	if err := subFlags.Parse(args); err != nil {
 		return err
@@ -1656,6 +1767,20 @@ func commandSetKeyspaceServedFrom(ctx context.Context, wr *wrangler.Wrangler, su
 	return wr.SetKeyspaceServedFrom(ctx, keyspace, servedType, cells, *source, *remove)
 }
 
+func commandSetKeyspaceFallbackCells(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
+	if err := subFlags.Parse(args); err != nil {
+		return err
+	}
+	if subFlags.NArg() < 1 || subFlags.NArg() > 2 {
+		return fmt.Errorf("The <keyspace name> argument is required for the SetKeyspaceFallbackCells command. The list of cells is optional.")
+	}
+	var cells []string
+	if subFlags.NArg() == 2 && subFlags.Arg(1) != "" {
+		cells = strings.Split(subFlags.Arg(1), ",")
+	}
+	return wr.SetKeyspaceFallbackCells(ctx, subFlags.Arg(0), cells)
+}
+
 func commandRebuildKeyspaceGraph(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
 	cells := subFlags.String("cells", "", "Specifies a comma-separated list of cells to update")
 	rebuildSrvShards := subFlags.Bool("rebuild_srv_shards", false, "Indicates whether all SrvShard objects should also be rebuilt. The default value is <code>false</code>.")
@@ -2074,6 +2199,7 @@ func commandGetVSchema(ctx context.Context, wr *wrangler.Wrangler, subFlags *fla
 func commandApplyVSchema(ctx context.Context, wr *wrangler.Wrangler, subFlags *flag.FlagSet, args []string) error {
 	vschema := subFlags.String("vschema", "", "Identifies the VTGate routing schema")
 	vschemaFile := subFlags.String("vschema_file", "", "Identifies the VTGate routing schema file")
+	dryRun := subFlags.Bool("dry_run", false, "Validates the VTGate routing schema and displays the changes, without applying them")
 	if err := subFlags.Parse(args); err != nil {
 		return err
 	}
@@ -2092,6 +2218,35 @@ func commandApplyVSchema(ctx context.Context, wr *wrangler.Wrangler, subFlags *f
 		s = string(schema)
 	}
 	keyspace := subFlags.Arg(0)
+	if err := vindexes.ValidateVSchema([]byte(s)); err != nil {
+		return fmt.Errorf("invalid vschema for keyspace %v: %v", keyspace, err)
+	}
+	var newFormal vindexes.KeyspaceFormal
+	if err := json.Unmarshal([]byte(s), &newFormal); err != nil {
+		return err
+	}
+
+	current, err := wr.TopoServer().GetVSchema(ctx, keyspace)
+	if err != nil {
+		return err
+	}
+	var currentFormal vindexes.KeyspaceFormal
+	if err := json.Unmarshal([]byte(current), &currentFormal); err != nil {
+		// Show the whole vschema as new if the current one
+		// can't be read.
+		wr.Logger().Warningf("Cannot parse the current vschema of keyspace %v: %v", keyspace, err)
+		currentFormal = vindexes.KeyspaceFormal{}
+	}
+	diffs := vindexes.DiffKeyspaceFormal(&currentFormal, &newFormal)
+	if len(diffs) == 0 {
+		wr.Logger().Printf("No changes to the vschema of keyspace %v\n", keyspace)
+	} else {
+		wr.Logger().Printf("Changes to the vschema of keyspace %v:\n%v\n", keyspace, strings.Join(diffs, "\n"))
+	}
+	if *dryRun {
+		wr.Logger().Printf("Dry run, the vschema was not applied\n")
+		return nil
+	}
 	return wr.TopoServer().SaveVSchema(ctx, keyspace, s)
 }
 
@@ -0,0 +1,178 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"flag"
+	"fmt"
+
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/vt/topo"
+
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+)
+
+var (
+	cellAliasName = flag.String("cell_alias", "", "name of the cells alias of -cell, e.g. the region of its availability zone, created with the AddCellsAlias vtctl command. vtgate reads its cells from the global topology when it starts, and treats them as local: it watches their tablets, sends the replica and rdonly queries to the first of them that has serving endpoints, -cell first, and falls back to them to read the SrvKeyspace of -cell.")
+
+	// localCellAlias is read from the topology by Init, it's nil if
+	// vtgate has no cell alias.
+	localCellAlias *CellAlias
+)
+
+// CellAlias is a group of cells that vtgate treats as one locality.
+type CellAlias struct {
+	Name string
+	// Cells are the cells of the alias, the cell of vtgate first.
+	Cells []string
+}
+
+// getCellAlias reads the cells alias name from ts, and returns it with
+// cell first. It returns nil if name is empty.
+func getCellAlias(ctx context.Context, ts topo.Server, name, cell string) (*CellAlias, error) {
+	if name == "" {
+		return nil, nil
+	}
+	cellsAlias, err := ts.GetCellsAlias(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the cells alias %v: %v", name, err)
+	}
+	return newCellAlias(name, cellsAlias.Cells, cell)
+}
+
+// newCellAlias returns the alias name of cells, with cell first.
+func newCellAlias(name string, cells []string, cell string) (*CellAlias, error) {
+	alias := &CellAlias{
+		Name:  name,
+		Cells: []string{cell},
+	}
+	found := false
+	for _, c := range cells {
+		switch c {
+		case "":
+		case cell:
+			found = true
+		default:
+			alias.Cells = append(alias.Cells, c)
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("the cells %v of the cells alias %v must contain the cell %v", cells, name, cell)
+	}
+	return alias, nil
+}
+
+// otherCells returns the cells of the alias but the one of vtgate. It
+// can be called on a nil alias.
+func (ca *CellAlias) otherCells() []string {
+	if ca == nil {
+		return nil
+	}
+	return ca.Cells[1:]
+}
+
+// GetCellAlias returns the cell alias of vtgate, or nil if it has none.
+func (vtg *VTGate) GetCellAlias() *CellAlias {
+	return localCellAlias
+}
+
+// cellAliasSrvTopoServer reads the serving graph of the cell of vtgate
+// from the other cells of its alias when it's missing there.
+type cellAliasSrvTopoServer struct {
+	topo.SrvTopoServer
+	alias *CellAlias
+}
+
+func newCellAliasSrvTopoServer(serv topo.SrvTopoServer, alias *CellAlias) topo.SrvTopoServer {
+	return &cellAliasSrvTopoServer{
+		SrvTopoServer: serv,
+		alias:         alias,
+	}
+}
+
+// cells returns the cells to read for cell: the alias if it's the cell
+// of vtgate, or only cell.
+func (cas *cellAliasSrvTopoServer) cells(cell string) []string {
+	if cell != cas.alias.Cells[0] {
+		return []string{cell}
+	}
+	return cas.alias.Cells
+}
+
+// GetSrvKeyspaceNames is part of the topo.SrvTopoServer interface. It
+// returns the keyspaces of all the cells of the alias.
+func (cas *cellAliasSrvTopoServer) GetSrvKeyspaceNames(ctx context.Context, cell string) ([]string, error) {
+	var result []string
+	var firstErr error
+	seen := make(map[string]bool)
+	for _, c := range cas.cells(cell) {
+		names, err := cas.SrvTopoServer.GetSrvKeyspaceNames(ctx, c)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		for _, name := range names {
+			if !seen[name] {
+				seen[name] = true
+				result = append(result, name)
+			}
+		}
+	}
+	if len(seen) == 0 && firstErr != nil {
+		return nil, firstErr
+	}
+	return result, nil
+}
+
+// GetSrvKeyspace is part of the topo.SrvTopoServer interface.
+func (cas *cellAliasSrvTopoServer) GetSrvKeyspace(ctx context.Context, cell, keyspace string) (*topodatapb.SrvKeyspace, error) {
+	var firstErr error
+	for _, c := range cas.cells(cell) {
+		srvKeyspace, err := cas.SrvTopoServer.GetSrvKeyspace(ctx, c, keyspace)
+		if err == nil {
+			return srvKeyspace, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return nil, firstErr
+}
+
+// GetSrvShard is part of the topo.SrvTopoServer interface.
+func (cas *cellAliasSrvTopoServer) GetSrvShard(ctx context.Context, cell, keyspace, shard string) (*topodatapb.SrvShard, error) {
+	var firstErr error
+	for _, c := range cas.cells(cell) {
+		srvShard, err := cas.SrvTopoServer.GetSrvShard(ctx, c, keyspace, shard)
+		if err == nil {
+			return srvShard, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return nil, firstErr
+}
+
+// GetEndPoints is part of the topo.SrvTopoServer interface. It returns
+// the endpoints of the first cell of the alias that has any, or the
+// result of cell if none has.
+func (cas *cellAliasSrvTopoServer) GetEndPoints(ctx context.Context, cell, keyspace, shard string, tabletType topodatapb.TabletType) (*topodatapb.EndPoints, int64, error) {
+	cells := cas.cells(cell)
+	endPoints, version, err := cas.SrvTopoServer.GetEndPoints(ctx, cells[0], keyspace, shard, tabletType)
+	if err == nil && len(endPoints.Entries) != 0 {
+		return endPoints, version, nil
+	}
+	for _, c := range cells[1:] {
+		eps, v, e := cas.SrvTopoServer.GetEndPoints(ctx, c, keyspace, shard, tabletType)
+		if e == nil && len(eps.Entries) != 0 {
+			return eps, v, nil
+		}
+	}
+	return endPoints, version, err
+}
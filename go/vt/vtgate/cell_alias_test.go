@@ -0,0 +1,184 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/vt/topo"
+	"github.com/youtube/vitess/go/vt/topo/test/faketopo"
+
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+)
+
+// aliasTopo serves the cells aliases of its map.
+type aliasTopo struct {
+	faketopo.FakeTopo
+	aliases map[string][]string
+}
+
+func (at *aliasTopo) GetCellsAlias(ctx context.Context, alias string) (*topodatapb.CellsAlias, error) {
+	cells, ok := at.aliases[alias]
+	if !ok {
+		return nil, topo.ErrNoNode
+	}
+	return &topodatapb.CellsAlias{Cells: cells}, nil
+}
+
+func TestGetCellAlias(t *testing.T) {
+	ts := topo.Server{Impl: &aliasTopo{aliases: map[string][]string{
+		"region":  {"zone2", "zone1", "zone3", ""},
+		"region2": {"zone2", "zone3"},
+	}}}
+	testcases := []struct {
+		name string
+		want *CellAlias
+		err  string
+	}{{
+		// No alias.
+	}, {
+		name: "region",
+		want: &CellAlias{Name: "region", Cells: []string{"zone1", "zone2", "zone3"}},
+	}, {
+		name: "region2",
+		err:  "the cells [zone2 zone3] of the cells alias region2 must contain the cell zone1",
+	}, {
+		name: "missing",
+		err:  "cannot read the cells alias missing: node doesn't exist",
+	}}
+	for _, tcase := range testcases {
+		got, err := getCellAlias(context.Background(), ts, tcase.name, "zone1")
+		if tcase.err != "" {
+			if err == nil || err.Error() != tcase.err {
+				t.Errorf("getCellAlias(%q): %v, want %v", tcase.name, err, tcase.err)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(got, tcase.want) {
+			t.Errorf("getCellAlias(%q): %+v, %v, want %+v", tcase.name, got, err, tcase.want)
+		}
+	}
+}
+
+// cellTopo serves the SrvKeyspaces and endpoints of the cells in its
+// maps, and an error for the others.
+type cellTopo struct {
+	sandboxTopo
+	keyspaces map[string]string
+	endPoints map[string]int
+}
+
+func (ct *cellTopo) GetSrvKeyspaceNames(ctx context.Context, cell string) ([]string, error) {
+	keyspace, ok := ct.keyspaces[cell]
+	if !ok {
+		return nil, fmt.Errorf("no keyspaces in %v", cell)
+	}
+	return []string{keyspace, "common"}, nil
+}
+
+func (ct *cellTopo) GetSrvKeyspace(ctx context.Context, cell, keyspace string) (*topodatapb.SrvKeyspace, error) {
+	if ct.keyspaces[cell] != keyspace {
+		return nil, fmt.Errorf("no SrvKeyspace %v in cell %v", keyspace, cell)
+	}
+	return &topodatapb.SrvKeyspace{ShardingColumnName: cell}, nil
+}
+
+func (ct *cellTopo) GetEndPoints(ctx context.Context, cell, keyspace, shard string, tabletType topodatapb.TabletType) (*topodatapb.EndPoints, int64, error) {
+	n, ok := ct.endPoints[cell]
+	if !ok {
+		return nil, -1, fmt.Errorf("no endpoints in %v", cell)
+	}
+	endPoints := &topodatapb.EndPoints{}
+	for i := 0; i < n; i++ {
+		endPoints.Entries = append(endPoints.Entries, &topodatapb.EndPoint{Host: cell})
+	}
+	return endPoints, 1, nil
+}
+
+func TestCellAliasSrvTopoServer(t *testing.T) {
+	ct := &cellTopo{
+		keyspaces: map[string]string{"zone1": "ks1", "zone2": "ks2", "zone4": "ks4"},
+		endPoints: map[string]int{"zone1": 0, "zone3": 1, "zone4": 1},
+	}
+	serv := newCellAliasSrvTopoServer(ct, &CellAlias{Name: "region", Cells: []string{"zone1", "zone2", "zone3"}})
+	ctx := context.Background()
+
+	names, err := serv.GetSrvKeyspaceNames(ctx, "zone1")
+	if want := []string{"ks1", "common", "ks2"}; err != nil || !reflect.DeepEqual(names, want) {
+		t.Errorf("GetSrvKeyspaceNames(zone1): %v, %v, want %v", names, err, want)
+	}
+
+	// The SrvKeyspace of the local cell is read from the alias.
+	srvKeyspace, err := serv.GetSrvKeyspace(ctx, "zone1", "ks2")
+	if err != nil || srvKeyspace.ShardingColumnName != "zone2" {
+		t.Errorf("GetSrvKeyspace(zone1, ks2): %v, %v, want the one of zone2", srvKeyspace, err)
+	}
+	want := "no SrvKeyspace ks3 in cell zone1"
+	if _, err := serv.GetSrvKeyspace(ctx, "zone1", "ks3"); err == nil || err.Error() != want {
+		t.Errorf("GetSrvKeyspace(zone1, ks3): %v, want %v", err, want)
+	}
+	// The other cells are read as they are.
+	if _, err := serv.GetSrvKeyspace(ctx, "zone4", "ks2"); err == nil {
+		t.Errorf("GetSrvKeyspace(zone4, ks2): nil, want error")
+	}
+
+	endPoints, _, err := serv.GetEndPoints(ctx, "zone1", "ks1", "0", topodatapb.TabletType_REPLICA)
+	if err != nil || len(endPoints.Entries) != 1 || endPoints.Entries[0].Host != "zone3" {
+		t.Errorf("GetEndPoints(zone1): %v, %v, want the ones of zone3", endPoints, err)
+	}
+	ct.endPoints = map[string]int{"zone1": 0}
+	endPoints, _, err = serv.GetEndPoints(ctx, "zone1", "ks1", "0", topodatapb.TabletType_REPLICA)
+	if err != nil || len(endPoints.Entries) != 0 {
+		t.Errorf("GetEndPoints(zone1) with no endpoints in the alias: %v, %v, want the empty list of zone1", endPoints, err)
+	}
+}
+
+func TestDiscoveryGatewayGetEndPointsCellAlias(t *testing.T) {
+	keyspace := "ks"
+	shard := "0"
+	hc := newFakeHealthCheck()
+	dg := createDiscoveryGateway(hc, topo.Server{}, nil, "local", time.Millisecond, 2, time.Second, time.Second, time.Second, nil, nil).(*discoveryGateway)
+	// The alias is set after the creation, so that its cells are not
+	// watched in the fake topology.
+	dg.cellAlias = &CellAlias{Name: "region", Cells: []string{"local", "zone2", "zone3"}}
+
+	// The local cell is preferred.
+	hc.Reset()
+	hc.addTestEndPoint("zone2", "1.1.1.1", 1001, keyspace, shard, topodatapb.TabletType_REPLICA, true, 10, nil, nil)
+	ep1 := hc.addTestEndPoint("local", "2.2.2.2", 1001, keyspace, shard, topodatapb.TabletType_REPLICA, true, 10, nil, nil)
+	eps := dg.getEndPoints(keyspace, shard, topodatapb.TabletType_REPLICA)
+	if len(eps) != 1 || !topo.EndPointEquality(eps[0], ep1) {
+		t.Errorf("want %+v, got %+v", ep1, eps)
+	}
+
+	// Then the first cell of the alias that has serving endpoints,
+	// but not the cells outside of it.
+	hc.Reset()
+	hc.addTestEndPoint("local", "1.1.1.1", 1001, keyspace, shard, topodatapb.TabletType_REPLICA, false, 10, nil, nil)
+	hc.addTestEndPoint("zone2", "2.2.2.2", 1001, keyspace, shard, topodatapb.TabletType_REPLICA, false, 10, nil, nil)
+	ep1 = hc.addTestEndPoint("zone3", "3.3.3.3", 1001, keyspace, shard, topodatapb.TabletType_REPLICA, true, 10, nil, nil)
+	hc.addTestEndPoint("remote", "4.4.4.4", 1001, keyspace, shard, topodatapb.TabletType_REPLICA, true, 10, nil, nil)
+	eps = dg.getEndPoints(keyspace, shard, topodatapb.TabletType_REPLICA)
+	if len(eps) != 1 || !topo.EndPointEquality(eps[0], ep1) {
+		t.Errorf("want %+v, got %+v", ep1, eps)
+	}
+}
+
+func TestCellsToWatchWithAlias(t *testing.T) {
+	alias := &CellAlias{Name: "region", Cells: []string{"zone1", "zone2", "zone3"}}
+	got := cellsToWatchWithAlias("zone1,remote,", alias)
+	if want := []string{"zone1", "remote", "zone2", "zone3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("cellsToWatchWithAlias: %v, want %v", got, want)
+	}
+	got = cellsToWatchWithAlias("zone1,remote", nil)
+	if want := []string{"zone1", "remote"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("cellsToWatchWithAlias without alias: %v, want %v", got, want)
+	}
+}
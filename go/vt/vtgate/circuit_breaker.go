@@ -0,0 +1,338 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/youtube/vitess/go/acl"
+	"github.com/youtube/vitess/go/vt/discovery"
+	"github.com/youtube/vitess/go/vt/tabletserver/tabletconn"
+
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+	vtrpcpb "github.com/youtube/vitess/go/vt/proto/vtrpc"
+)
+
+var (
+	circuitBreakerErrorThreshold   = flag.Int("circuit-breaker-error-threshold", 0, "number of consecutive errors of a tablet, each within -circuit-breaker-window of the first one, after which discoverygateway stops sending it queries. 0 disables the circuit breakers.")
+	circuitBreakerWindow           = flag.Duration("circuit-breaker-window", 10*time.Second, "time window in which the consecutive errors of a tablet are counted by its circuit breaker")
+	circuitBreakerRecoveryInterval = flag.Duration("circuit-breaker-recovery-interval", 30*time.Second, "time after which a tripped circuit breaker lets one query through to probe the tablet")
+)
+
+// circuitBreakersOnce registers /debug/circuit_breakers for the first
+// gateway that has circuit breakers.
+var circuitBreakersOnce sync.Once
+
+// circuitState is the state of a CircuitBreaker.
+type circuitState int
+
+const (
+	// circuitClosed lets all the queries through.
+	circuitClosed circuitState = iota
+	// circuitOpen stops all the queries.
+	circuitOpen
+	// circuitHalfOpen lets one query through, to probe the tablet.
+	circuitHalfOpen
+)
+
+func (s circuitState) String() string {
+	switch s {
+	case circuitClosed:
+		return "closed"
+	case circuitOpen:
+		return "open"
+	case circuitHalfOpen:
+		return "half-open"
+	}
+	return fmt.Sprintf("unknown(%d)", int(s))
+}
+
+// CircuitBreaker stops the queries to a tablet that keeps failing. It
+// trips (opens) after threshold consecutive errors, the last one
+// within window of the first one. After recoveryInterval, it becomes
+// half-open and lets one query through: if the query succeeds, the
+// breaker closes, otherwise it opens again.
+type CircuitBreaker struct {
+	threshold        int
+	window           time.Duration
+	recoveryInterval time.Duration
+	// now returns the current time. The tests replace it.
+	now func() time.Time
+
+	mu         sync.Mutex
+	state      circuitState
+	errors     int
+	firstError time.Time
+	lastError  error
+	openedAt   time.Time
+	// probeStart is when the probe of the half-open state was let
+	// through. Another probe is allowed if it doesn't finish within
+	// recoveryInterval.
+	probeStart time.Time
+	trips      int64
+}
+
+// NewCircuitBreaker creates a closed CircuitBreaker.
+func NewCircuitBreaker(threshold int, window, recoveryInterval time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		threshold:        threshold,
+		window:           window,
+		recoveryInterval: recoveryInterval,
+		now:              time.Now,
+	}
+}
+
+// Allow returns true if a query can be sent to the tablet. If the
+// breaker is open for longer than the recovery interval, or if the
+// probe of the half-open state got no result, it lets this query
+// through as the probe.
+func (cb *CircuitBreaker) Allow() bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	switch cb.state {
+	case circuitOpen:
+		if cb.now().Sub(cb.openedAt) < cb.recoveryInterval {
+			return false
+		}
+		cb.state = circuitHalfOpen
+		cb.probeStart = cb.now()
+		return true
+	case circuitHalfOpen:
+		if cb.now().Sub(cb.probeStart) < cb.recoveryInterval {
+			return false
+		}
+		cb.probeStart = cb.now()
+		return true
+	}
+	return true
+}
+
+// Tripped returns true if the breaker doesn't let queries through. It
+// doesn't change the state of the breaker, unlike Allow.
+func (cb *CircuitBreaker) Tripped() bool {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	switch cb.state {
+	case circuitOpen:
+		return cb.now().Sub(cb.openedAt) < cb.recoveryInterval
+	case circuitHalfOpen:
+		return cb.now().Sub(cb.probeStart) < cb.recoveryInterval
+	}
+	return false
+}
+
+// RecordSuccess records a query the tablet answered. It closes the
+// breaker.
+func (cb *CircuitBreaker) RecordSuccess() {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	cb.state = circuitClosed
+	cb.errors = 0
+}
+
+// RecordFailure records a query that failed because of the tablet.
+func (cb *CircuitBreaker) RecordFailure(err error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	now := cb.now()
+	cb.lastError = err
+	if cb.state == circuitHalfOpen {
+		// The probe failed.
+		cb.state = circuitOpen
+		cb.openedAt = now
+		cb.trips++
+		return
+	}
+	if cb.state == circuitOpen {
+		return
+	}
+	if cb.errors == 0 || now.Sub(cb.firstError) > cb.window {
+		cb.errors = 0
+		cb.firstError = now
+	}
+	cb.errors++
+	if cb.errors >= cb.threshold {
+		cb.state = circuitOpen
+		cb.openedAt = now
+		cb.trips++
+	}
+}
+
+// CircuitBreakerStatus is the state of the circuit breaker of a
+// tablet, as shown by /debug/circuit_breakers and the gateway status.
+type CircuitBreakerStatus struct {
+	EndPoint          string
+	State             string
+	ConsecutiveErrors int
+	LastError         string `json:",omitempty"`
+	OpenedAt          time.Time
+	Trips             int64
+}
+
+func (cb *CircuitBreaker) status(key string) *CircuitBreakerStatus {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	s := &CircuitBreakerStatus{
+		EndPoint:          key,
+		State:             cb.state.String(),
+		ConsecutiveErrors: cb.errors,
+		OpenedAt:          cb.openedAt,
+		Trips:             cb.trips,
+	}
+	if cb.lastError != nil {
+		s.LastError = cb.lastError.Error()
+	}
+	return s
+}
+
+// circuitBreakers has the circuit breakers of the tablets, by
+// endpoint. They're created on first use.
+type circuitBreakers struct {
+	threshold        int
+	window           time.Duration
+	recoveryInterval time.Duration
+
+	mu       sync.Mutex
+	breakers map[string]*CircuitBreaker
+}
+
+// newCircuitBreakersFromFlags returns the circuitBreakers configured
+// by the command line flags, or nil if they're disabled.
+func newCircuitBreakersFromFlags() *circuitBreakers {
+	if *circuitBreakerErrorThreshold <= 0 {
+		return nil
+	}
+	return newCircuitBreakers(*circuitBreakerErrorThreshold, *circuitBreakerWindow, *circuitBreakerRecoveryInterval)
+}
+
+func newCircuitBreakers(threshold int, window, recoveryInterval time.Duration) *circuitBreakers {
+	return &circuitBreakers{
+		threshold:        threshold,
+		window:           window,
+		recoveryInterval: recoveryInterval,
+		breakers:         make(map[string]*CircuitBreaker),
+	}
+}
+
+// get returns the circuit breaker of endPoint.
+func (cbs *circuitBreakers) get(endPoint *topodatapb.EndPoint) *CircuitBreaker {
+	key := discovery.EndPointToMapKey(endPoint)
+	cbs.mu.Lock()
+	defer cbs.mu.Unlock()
+	cb, ok := cbs.breakers[key]
+	if !ok {
+		cb = NewCircuitBreaker(cbs.threshold, cbs.window, cbs.recoveryInterval)
+		cbs.breakers[key] = cb
+	}
+	return cb
+}
+
+// status returns the status of the circuit breaker of endPoint, or
+// nil if it has none.
+func (cbs *circuitBreakers) status(endPoint *topodatapb.EndPoint) *CircuitBreakerStatus {
+	key := discovery.EndPointToMapKey(endPoint)
+	cbs.mu.Lock()
+	cb, ok := cbs.breakers[key]
+	cbs.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	return cb.status(key)
+}
+
+// remove deletes the circuit breaker of endPoint, once it has left
+// the health check.
+func (cbs *circuitBreakers) remove(endPoint *topodatapb.EndPoint) {
+	cbs.mu.Lock()
+	defer cbs.mu.Unlock()
+	delete(cbs.breakers, discovery.EndPointToMapKey(endPoint))
+}
+
+// tripped returns true if the circuit breaker of endPoint exists and
+// doesn't let queries through.
+func (cbs *circuitBreakers) tripped(endPoint *topodatapb.EndPoint) bool {
+	cbs.mu.Lock()
+	cb, ok := cbs.breakers[discovery.EndPointToMapKey(endPoint)]
+	cbs.mu.Unlock()
+	return ok && cb.Tripped()
+}
+
+// apply returns epsList where the endpoints whose circuit breaker is
+// tripped are not serving. The EndPointStats of the health check are
+// not modified.
+func (cbs *circuitBreakers) apply(epsList []*discovery.EndPointStats) []*discovery.EndPointStats {
+	result := make([]*discovery.EndPointStats, 0, len(epsList))
+	for _, eps := range epsList {
+		if eps.Serving && cbs.tripped(eps.EndPoint) {
+			tripped := *eps
+			tripped.Serving = false
+			tripped.LastError = fmt.Errorf("circuit breaker is open")
+			eps = &tripped
+		}
+		result = append(result, eps)
+	}
+	return result
+}
+
+// statuses returns the status of the circuit breakers, sorted by
+// endpoint.
+func (cbs *circuitBreakers) statuses() []*CircuitBreakerStatus {
+	cbs.mu.Lock()
+	keys := make([]string, 0, len(cbs.breakers))
+	for key := range cbs.breakers {
+		keys = append(keys, key)
+	}
+	breakers := make(map[string]*CircuitBreaker, len(cbs.breakers))
+	for key, cb := range cbs.breakers {
+		breakers[key] = cb
+	}
+	cbs.mu.Unlock()
+
+	sort.Strings(keys)
+	result := make([]*CircuitBreakerStatus, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, breakers[key].status(key))
+	}
+	return result
+}
+
+// ServeHTTP shows the circuit breakers as JSON.
+func (cbs *circuitBreakers) ServeHTTP(response http.ResponseWriter, request *http.Request) {
+	if err := acl.CheckAccessHTTP(request, acl.DEBUGGING); err != nil {
+		acl.SendError(response, err)
+		return
+	}
+	b, err := json.MarshalIndent(cbs.statuses(), "", " ")
+	if err != nil {
+		http.Error(response, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	response.Header().Set("Content-Type", "application/json; charset=utf-8")
+	response.Write(b)
+}
+
+// isTabletFailure returns true if err shows that the tablet failed,
+// rather than the query: the tablet could not be reached, or it
+// returned an internal error or didn't serve the query.
+func isTabletFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	serverError, ok := err.(*tabletconn.ServerError)
+	if !ok {
+		return true
+	}
+	switch serverError.ServerCode {
+	case vtrpcpb.ErrorCode_INTERNAL_ERROR, vtrpcpb.ErrorCode_QUERY_NOT_SERVED:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,187 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/vt/discovery"
+	"github.com/youtube/vitess/go/vt/tabletserver/tabletconn"
+	"github.com/youtube/vitess/go/vt/topo"
+
+	querypb "github.com/youtube/vitess/go/vt/proto/query"
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+	vtrpcpb "github.com/youtube/vitess/go/vt/proto/vtrpc"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Unix(1000, 0)
+	cb := NewCircuitBreaker(3, 10*time.Second, 30*time.Second)
+	cb.now = func() time.Time { return now }
+	checkState := func(desc string, want circuitState, wantTripped bool) {
+		if cb.state != want || cb.Tripped() != wantTripped {
+			t.Errorf("%v: state %v, tripped %v, want %v, %v", desc, cb.state, cb.Tripped(), want, wantTripped)
+		}
+	}
+	tabletErr := fmt.Errorf("tablet error")
+
+	// The errors must be consecutive.
+	cb.RecordFailure(tabletErr)
+	cb.RecordFailure(tabletErr)
+	cb.RecordSuccess()
+	cb.RecordFailure(tabletErr)
+	cb.RecordFailure(tabletErr)
+	checkState("errors followed by a success", circuitClosed, false)
+
+	// And within the window of the first one.
+	now = now.Add(11 * time.Second)
+	cb.RecordFailure(tabletErr)
+	cb.RecordFailure(tabletErr)
+	checkState("errors after the window", circuitClosed, false)
+	cb.RecordFailure(tabletErr)
+	checkState("3 errors in the window", circuitOpen, true)
+	if cb.Allow() {
+		t.Errorf("open breaker: Allow() = true, want false")
+	}
+
+	// After the recovery interval, one probe is let through.
+	now = now.Add(30 * time.Second)
+	checkState("after the recovery interval", circuitOpen, false)
+	if !cb.Allow() {
+		t.Errorf("breaker after the recovery interval: Allow() = false, want true")
+	}
+	checkState("probe in flight", circuitHalfOpen, true)
+	if cb.Allow() {
+		t.Errorf("half-open breaker with a probe in flight: Allow() = true, want false")
+	}
+
+	// The probe fails.
+	cb.RecordFailure(tabletErr)
+	checkState("failed probe", circuitOpen, true)
+
+	// The next probe succeeds.
+	now = now.Add(30 * time.Second)
+	if !cb.Allow() {
+		t.Errorf("breaker after the recovery interval: Allow() = false, want true")
+	}
+	cb.RecordSuccess()
+	checkState("successful probe", circuitClosed, false)
+	if !cb.Allow() {
+		t.Errorf("closed breaker: Allow() = false, want true")
+	}
+	if s := cb.status("key"); s.Trips != 2 || s.LastError != "tablet error" || s.State != "closed" {
+		t.Errorf("status: %+v, want 2 trips and the last error", s)
+	}
+}
+
+func TestIsTabletFailure(t *testing.T) {
+	testcases := []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{tabletconn.OperationalError("connection refused"), true},
+		{&tabletconn.ServerError{Err: "fatal", ServerCode: vtrpcpb.ErrorCode_INTERNAL_ERROR}, true},
+		{&tabletconn.ServerError{Err: "retry", ServerCode: vtrpcpb.ErrorCode_QUERY_NOT_SERVED}, true},
+		{&tabletconn.ServerError{Err: "syntax error", ServerCode: vtrpcpb.ErrorCode_BAD_INPUT}, false},
+		{&tabletconn.ServerError{Err: "deadlock", ServerCode: vtrpcpb.ErrorCode_TRANSIENT_ERROR}, false},
+	}
+	for _, tcase := range testcases {
+		if got := isTabletFailure(tcase.err); got != tcase.want {
+			t.Errorf("isTabletFailure(%v): %v, want %v", tcase.err, got, tcase.want)
+		}
+	}
+}
+
+func TestDiscoveryGatewayCircuitBreaker(t *testing.T) {
+	defer func(v int) { *circuitBreakerErrorThreshold = v }(*circuitBreakerErrorThreshold)
+	*circuitBreakerErrorThreshold = 2
+	keyspace := "ks"
+	shard := "0"
+	tabletType := topodatapb.TabletType_REPLICA
+	hc := newFakeHealthCheck()
+	dg := createDiscoveryGateway(hc, topo.Server{}, nil, "cell", time.Millisecond, 0, time.Second, time.Second, time.Second, nil, nil).(*discoveryGateway)
+
+	bad := &sandboxConn{mustFailConn: 1000}
+	good := &sandboxConn{}
+	badEP := hc.addTestEndPoint("cell", "1.1.1.1", 1001, keyspace, shard, tabletType, true, 10, nil, bad)
+	goodEP := hc.addTestEndPoint("cell", "1.1.1.1", 1002, keyspace, shard, tabletType, true, 10, nil, good)
+
+	// The bad tablet gets queries until its breaker trips.
+	for i := 0; i < 100 && bad.ExecCount.Get() < 2; i++ {
+		dg.Execute(context.Background(), keyspace, shard, tabletType, "query", nil, 0)
+	}
+	if got := bad.ExecCount.Get(); got != 2 {
+		t.Fatalf("queries sent to the bad tablet: %v, want 2", got)
+	}
+	eps := dg.getEndPoints(keyspace, shard, tabletType)
+	if len(eps) != 1 || !topo.EndPointEquality(eps[0], goodEP) {
+		t.Errorf("getEndPoints with a tripped breaker: %+v, want %+v", eps, goodEP)
+	}
+	badKey := discovery.EndPointToMapKey(badEP)
+	found := false
+	for _, s := range dg.breakers.statuses() {
+		if s.EndPoint == badKey {
+			found = true
+			if s.State != "open" || s.ConsecutiveErrors != 2 {
+				t.Errorf("status of %v: %+v, want open after 2 errors", badKey, s)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("no circuit breaker status for %v", badKey)
+	}
+
+	// Then all the queries go to the good tablet.
+	goodCount := good.ExecCount.Get()
+	for i := 0; i < 10; i++ {
+		if _, err := dg.Execute(context.Background(), keyspace, shard, tabletType, "query", nil, 0); err != nil {
+			t.Errorf("Execute with a tripped breaker: %v", err)
+		}
+	}
+	if got := bad.ExecCount.Get(); got != 2 {
+		t.Errorf("queries sent to the bad tablet after the breaker tripped: %v, want 2", got)
+	}
+	if got := good.ExecCount.Get() - goodCount; got != 10 {
+		t.Errorf("queries sent to the good tablet after the breaker tripped: %v, want 10", got)
+	}
+}
+
+func TestDiscoveryGatewayCircuitBreakerStatus(t *testing.T) {
+	defer func(v int) { *circuitBreakerErrorThreshold = v }(*circuitBreakerErrorThreshold)
+	*circuitBreakerErrorThreshold = 1
+	hc := newFakeHealthCheck()
+	dg := createDiscoveryGateway(hc, topo.Server{}, nil, "cell", time.Millisecond, 0, time.Second, time.Second, time.Second, nil, nil).(*discoveryGateway)
+	bad := &sandboxConn{mustFailConn: 1000}
+	badEP := hc.addTestEndPoint("cell", "1.1.1.1", 1001, "ks", "0", topodatapb.TabletType_REPLICA, true, 10, nil, bad)
+	dg.Execute(context.Background(), "ks", "0", topodatapb.TabletType_REPLICA, "query", nil, 0)
+
+	// The gateway status shows the tripped breaker.
+	got := dg.CacheStatus()
+	if len(got) != 1 {
+		t.Fatalf("CacheStatus: %+v, want one endpoint", got)
+	}
+	if got[0].Keyspace != "ks" || got[0].Shard != "0" || got[0].TabletType != topodatapb.TabletType_REPLICA || got[0].Addr != "1.1.1.1:1001" {
+		t.Errorf("CacheStatus: %+v, want ks/0 replica 1.1.1.1:1001", got[0])
+	}
+	if cbs := got[0].CircuitBreaker; cbs == nil || cbs.State != "open" || cbs.Trips != 1 {
+		t.Errorf("CacheStatus.CircuitBreaker: %+v, want open with 1 trip", cbs)
+	}
+
+	// The breaker is removed when the endpoint leaves the health check.
+	hc.RemoveEndPoint(badEP)
+	dg.StatsUpdate(&discovery.EndPointStats{
+		EndPoint: badEP,
+		Target:   &querypb.Target{Keyspace: "ks", Shard: "0", TabletType: topodatapb.TabletType_REPLICA},
+		Up:       false,
+	})
+	if got := dg.breakers.statuses(); len(got) != 0 {
+		t.Errorf("circuit breakers after the endpoint was removed: %+v, want none", got)
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	log "github.com/golang/glog"
+
+	"github.com/youtube/vitess/go/stats"
+)
+
+// configMu protects the values of the reloadable flags, and what is
+// parsed from them, against a config reload. The readers hold it
+// for reading.
+var configMu sync.RWMutex
+
+// reloadableFlags are the flags a config reload can change. Each
+// function parses a new value of its flag, and returns the function
+// that applies it, called with configMu locked.
+var reloadableFlags = map[string]func(value string) (func(vtg *VTGate), error){
+	"query_timeout": func(value string) (func(vtg *VTGate), error) {
+		timeout, err := time.ParseDuration(value)
+		if err != nil {
+			return nil, err
+		}
+		return func(vtg *VTGate) { *queryTimeout = timeout }, nil
+	},
+	"keyspace_timeout_overrides": func(value string) (func(vtg *VTGate), error) {
+		timeouts, err := parseKeyspaceTimeouts(value)
+		if err != nil {
+			return nil, err
+		}
+		return func(vtg *VTGate) {
+			*keyspaceTimeoutOverrides = value
+			keyspaceTimeouts = timeouts
+		}, nil
+	},
+	"query-timeouts": func(value string) (func(vtg *VTGate), error) {
+		timeouts, err := parseQueryTimeouts(value)
+		if err != nil {
+			return nil, err
+		}
+		return func(vtg *VTGate) {
+			*queryTimeoutOverrides = value
+			vtg.QueryTimeouts = timeouts
+		}, nil
+	},
+	"max_dml_shards":          reloadableLimit(maxDMLShards),
+	"max-scatter-parallelism": reloadableLimit(maxScatterParallelism),
+}
+
+// reloadableLimit returns the parse function of a flag that is a
+// limit, where 0 means no limit.
+func reloadableLimit(limit *int) func(value string) (func(vtg *VTGate), error) {
+	return func(value string) (func(vtg *VTGate), error) {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("invalid limit %q", value)
+		}
+		return func(vtg *VTGate) { *limit = n }, nil
+	}
+}
+
+// configReloader applies the values of the reloadable flags of a
+// config to a VTGate. The flags missing from the config go back to
+// the values they had when the configReloader was created.
+type configReloader struct {
+	vtg      *VTGate
+	defaults map[string]string
+	reloads  *stats.Counters
+}
+
+// newConfigReloader creates a configReloader for vtg. If statsName is
+// set, the successful and failed reloads are published.
+func newConfigReloader(vtg *VTGate, statsName string) *configReloader {
+	reloadsName := ""
+	if statsName != "" {
+		reloadsName = statsName + "ConfigReloads"
+	}
+	defaults := make(map[string]string, len(reloadableFlags))
+	for name := range reloadableFlags {
+		defaults[name] = flag.Lookup(name).Value.String()
+	}
+	return &configReloader{
+		vtg:      vtg,
+		defaults: defaults,
+		reloads:  stats.NewCounters(reloadsName),
+	}
+}
+
+// reload applies config, a map of flag name to value, which comes from
+// source. The keys that are not reloadable flags are ignored. If a
+// value is invalid, none of config is applied.
+func (cr *configReloader) reload(source string, config map[string]string) error {
+	var ignored []string
+	for name := range config {
+		if _, ok := reloadableFlags[name]; !ok {
+			ignored = append(ignored, name)
+		}
+	}
+	if len(ignored) != 0 {
+		sort.Strings(ignored)
+		log.Warningf("Config %v: ignoring %v, which are not reloadable flags", source, ignored)
+	}
+
+	var updates []func(vtg *VTGate)
+	for name, parse := range reloadableFlags {
+		value, ok := config[name]
+		if !ok {
+			value = cr.defaults[name]
+		}
+		update, err := parse(value)
+		if err != nil {
+			cr.reloads.Add("Error", 1)
+			return fmt.Errorf("config %v: invalid value %q of %v: %v", source, value, name, err)
+		}
+		updates = append(updates, update)
+	}
+	configMu.Lock()
+	for _, update := range updates {
+		update(cr.vtg)
+	}
+	configMu.Unlock()
+	cr.reloads.Add("Success", 1)
+	log.Infof("Config %v reloaded: %v", source, config)
+	return nil
+}
@@ -0,0 +1,75 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestConfigReloader(t *testing.T) {
+	defer func(v time.Duration) { *queryTimeout = v }(*queryTimeout)
+	defer func(v string) { *keyspaceTimeoutOverrides = v }(*keyspaceTimeoutOverrides)
+	defer func(v map[string]time.Duration) { keyspaceTimeouts = v }(keyspaceTimeouts)
+	defer func(v string) { *queryTimeoutOverrides = v }(*queryTimeoutOverrides)
+	defer func(v int) { *maxDMLShards = v }(*maxDMLShards)
+	defer func(v int) { *maxScatterParallelism = v }(*maxScatterParallelism)
+	*queryTimeout = 10 * time.Second
+	*maxDMLShards = 4
+	*maxScatterParallelism = 0
+
+	vtg := &VTGate{}
+	cr := newConfigReloader(vtg, "")
+	err := cr.reload("test", map[string]string{
+		"query_timeout":              "1s",
+		"keyspace_timeout_overrides": `{"ks": "30s"}`,
+		"query-timeouts":             "SelectScatter=5s",
+		"max_dml_shards":             "8",
+		"max-scatter-parallelism":    "16",
+		"not_reloadable":             "x",
+	})
+	if err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if *queryTimeout != time.Second || *maxDMLShards != 8 || *maxScatterParallelism != 16 {
+		t.Errorf("reload: query_timeout %v, max_dml_shards %v, max-scatter-parallelism %v, want 1s, 8, 16", *queryTimeout, *maxDMLShards, *maxScatterParallelism)
+	}
+	if want := map[string]time.Duration{"ks": 30 * time.Second}; !reflect.DeepEqual(keyspaceTimeouts, want) {
+		t.Errorf("keyspaceTimeouts: %v, want %v", keyspaceTimeouts, want)
+	}
+	if want := map[string]time.Duration{"SelectScatter": 5 * time.Second}; !reflect.DeepEqual(vtg.QueryTimeouts, want) {
+		t.Errorf("QueryTimeouts: %v, want %v", vtg.QueryTimeouts, want)
+	}
+	if timeout, rule := keyspaceTimeout("ks"); timeout != 30*time.Second || rule != "keyspace_timeout_overrides[ks]=30s" {
+		t.Errorf("keyspaceTimeout(ks): %v, %v, want 30s", timeout, rule)
+	}
+
+	// An invalid value leaves the config as it was.
+	err = cr.reload("test", map[string]string{"query_timeout": "2s", "max_dml_shards": "-1"})
+	want := `config test: invalid value "-1" of max_dml_shards: invalid limit "-1"`
+	if err == nil || err.Error() != want {
+		t.Errorf("reload: %v, want %v", err, want)
+	}
+	if *queryTimeout != time.Second || *maxDMLShards != 8 {
+		t.Errorf("reload of an invalid config: query_timeout %v, max_dml_shards %v, want 1s, 8", *queryTimeout, *maxDMLShards)
+	}
+	err = cr.reload("test", map[string]string{"query-timeouts": "Unknown=1s"})
+	if err == nil || !strings.Contains(err.Error(), "invalid value \"Unknown=1s\" of query-timeouts") {
+		t.Errorf("reload: %v, want an invalid value of query-timeouts", err)
+	}
+
+	// The flags missing from the config go back to their initial values.
+	if err := cr.reload("test", map[string]string{"max_dml_shards": "2"}); err != nil {
+		t.Fatalf("reload: %v", err)
+	}
+	if *queryTimeout != 10*time.Second || *maxDMLShards != 2 || *maxScatterParallelism != 0 {
+		t.Errorf("reload: query_timeout %v, max_dml_shards %v, max-scatter-parallelism %v, want 10s, 2, 0", *queryTimeout, *maxDMLShards, *maxScatterParallelism)
+	}
+	if len(keyspaceTimeouts) != 0 || len(vtg.QueryTimeouts) != 0 {
+		t.Errorf("reload: keyspaceTimeouts %v, QueryTimeouts %v, want none", keyspaceTimeouts, vtg.QueryTimeouts)
+	}
+}
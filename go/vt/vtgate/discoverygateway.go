@@ -8,18 +8,21 @@ import (
 	"flag"
 	"fmt"
 	"math/rand"
+	"net/http"
 	"strings"
 	"time"
 
 	log "github.com/golang/glog"
 	"golang.org/x/net/context"
 
+	"github.com/youtube/vitess/go/netutil"
 	"github.com/youtube/vitess/go/sqltypes"
 	"github.com/youtube/vitess/go/stats"
 	"github.com/youtube/vitess/go/vt/discovery"
 	"github.com/youtube/vitess/go/vt/tabletserver/querytypes"
 	"github.com/youtube/vitess/go/vt/tabletserver/tabletconn"
 	"github.com/youtube/vitess/go/vt/topo"
+	"github.com/youtube/vitess/go/vt/topo/topoproto"
 	"github.com/youtube/vitess/go/vt/vterrors"
 	"github.com/youtube/vitess/go/vt/vtgate/masterbuffer"
 
@@ -32,10 +35,18 @@ var (
 	cellsToWatch        = flag.String("cells_to_watch", "", "comma-separated list of cells for watching endpoints")
 	refreshInterval     = flag.Duration("endpoint_refresh_interval", 1*time.Minute, "endpoint refresh interval")
 	topoReadConcurrency = flag.Int("topo_read_concurrency", 32, "concurrent topo reads")
+
+	discoveryMode        = flag.String("discovery_mode", discoveryModeTopo, "how to discover the tablets of the cells_to_watch: topo reads them from the topology server, dns resolves the SRV records _vitess._tcp.{keyspace}.{shard}.{cell} of the dns_discovery_shards, where a key range shard like -80 is written with 0 for its empty bounds, like 0-80")
+	dnsDiscoveryShards   = flag.String("dns_discovery_shards", "", "comma-separated list of keyspace/shard to resolve with -discovery_mode dns")
+	dnsDiscoveryDomain   = flag.String("dns_discovery_domain", "", "domain appended to the SRV record names with -discovery_mode dns")
+	dnsDiscoveryPortName = flag.String("dns_discovery_port_name", "grpc", "name of the port of the SRV records with -discovery_mode dns")
 )
 
 const (
 	gatewayImplementationDiscovery = "discoverygateway"
+
+	discoveryModeTopo = "topo"
+	discoveryModeDNS  = "dns"
 )
 
 func init() {
@@ -48,20 +59,45 @@ func createDiscoveryGateway(hc discovery.HealthCheck, topoServer topo.Server, se
 		topoServer:        topoServer,
 		srvTopoServer:     serv,
 		localCell:         cell,
+		cellAlias:         localCellAlias,
+		fallbackCells:     localFallbackCells,
 		retryCount:        retryCount,
 		tabletTypesToWait: tabletTypesToWait,
 		tabletsWatchers:   make([]*discovery.TopologyWatcher, 0, 1),
+		breakers:          newCircuitBreakersFromFlags(),
+	}
+	if dg.breakers != nil {
+		circuitBreakersOnce.Do(func() {
+			http.Handle("/debug/circuit_breakers", dg.breakers)
+		})
+	}
+	balancer, err := newWeightedBalancerFromFlags()
+	if err != nil {
+		log.Fatalf("createDiscoveryGateway: %v", err)
 	}
+	dg.balancer = balancer
 	dg.hc.SetListener(dg)
-	for _, c := range strings.Split(*cellsToWatch, ",") {
-		if c == "" {
+	var dnsShards []topo.KeyspaceShard
+	if *discoveryMode == discoveryModeDNS {
+		var err error
+		if dnsShards, err = parseDNSDiscoveryShards(*dnsDiscoveryShards); err != nil {
+			log.Fatalf("createDiscoveryGateway: %v", err)
+		}
+	} else if *discoveryMode != discoveryModeTopo {
+		log.Fatalf("createDiscoveryGateway: unknown discovery_mode %v, must be %v or %v", *discoveryMode, discoveryModeTopo, discoveryModeDNS)
+	}
+	// The fallback cells are watched too.
+	watched := *cellsToWatch + "," + strings.Join(dg.fallbackCells, ",")
+	for _, c := range cellsToWatchWithAlias(watched, dg.cellAlias) {
+		if *discoveryMode == discoveryModeDNS {
+			dw := discovery.NewDNSWatcher(dg.hc, c, dnsShards, *dnsDiscoveryDomain, *dnsDiscoveryPortName, *refreshInterval)
+			dg.dnsWatchers = append(dg.dnsWatchers, dw)
 			continue
 		}
 		ctw := discovery.NewCellTabletsWatcher(dg.topoServer, dg.hc, c, *refreshInterval, *topoReadConcurrency)
 		dg.tabletsWatchers = append(dg.tabletsWatchers, ctw)
 	}
-	err := dg.waitForEndPoints()
-	if err != nil {
+	if err := dg.waitForEndPoints(); err != nil {
 		log.Errorf("createDiscoveryGateway: %v", err)
 	}
 	return dg
@@ -75,7 +111,43 @@ type discoveryGateway struct {
 	retryCount        int
 	tabletTypesToWait []topodatapb.TabletType
 
+	// cellAlias is the alias of localCell, or nil.
+	cellAlias *CellAlias
+
+	// fallbackCells are the cells of -fallback-cells, for the
+	// keyspaces that don't have their own.
+	fallbackCells []string
+
 	tabletsWatchers []*discovery.TopologyWatcher
+	dnsWatchers     []*discovery.DNSWatcher
+
+	// breakers are the circuit breakers of the tablets, or nil if
+	// they're disabled.
+	breakers *circuitBreakers
+
+	// balancer picks the endpoints by weight, it's nil if they're
+	// picked at random.
+	balancer *weightedBalancer
+}
+
+// parseDNSDiscoveryShards parses the comma-separated list of
+// keyspace/shard of -dns_discovery_shards.
+func parseDNSDiscoveryShards(value string) ([]topo.KeyspaceShard, error) {
+	var result []topo.KeyspaceShard
+	for _, ks := range strings.Split(value, ",") {
+		if ks == "" {
+			continue
+		}
+		keyspace, shard, err := topoproto.ParseKeyspaceShard(ks)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, topo.KeyspaceShard{Keyspace: keyspace, Shard: shard})
+	}
+	if len(result) == 0 {
+		return nil, fmt.Errorf("dns_discovery_shards must be set with discovery_mode %v", discoveryModeDNS)
+	}
+	return result, nil
 }
 
 func (dg *discoveryGateway) waitForEndPoints() error {
@@ -226,16 +298,53 @@ func (dg *discoveryGateway) Close(ctx context.Context) error {
 	for _, ctw := range dg.tabletsWatchers {
 		ctw.Stop()
 	}
+	for _, dw := range dg.dnsWatchers {
+		dw.Stop()
+	}
 	return nil
 }
 
 // CacheStatus returns a list of GatewayEndPointCacheStatus per endpoint.
+// Only the endpoints of the health check that have a circuit breaker
+// are listed.
 func (dg *discoveryGateway) CacheStatus() GatewayEndPointCacheStatusList {
-	return nil
+	if dg.breakers == nil {
+		return nil
+	}
+	var result GatewayEndPointCacheStatusList
+	for _, epcs := range dg.hc.CacheStatus() {
+		for _, eps := range epcs.EndPointsStats {
+			cbs := dg.breakers.status(eps.EndPoint)
+			if cbs == nil {
+				continue
+			}
+			addr := netutil.JoinHostPort(eps.EndPoint.Host, eps.EndPoint.PortMap["vt"])
+			name := eps.Name
+			if name == "" {
+				name = addr
+			}
+			result = append(result, &GatewayEndPointCacheStatus{
+				Keyspace:       epcs.Target.Keyspace,
+				Shard:          epcs.Target.Shard,
+				TabletType:     epcs.Target.TabletType,
+				Name:           name,
+				Addr:           addr,
+				CircuitBreaker: cbs,
+			})
+		}
+	}
+	return result
 }
 
 // StatsUpdate receives updates about target and realtime stats changes.
-func (dg *discoveryGateway) StatsUpdate(*discovery.EndPointStats) {
+func (dg *discoveryGateway) StatsUpdate(eps *discovery.EndPointStats) {
+	if dg.balancer != nil && eps.Target != nil {
+		dg.balancer.invalidate(targetKey(eps.Target.Keyspace, eps.Target.Shard, eps.Target.TabletType))
+	}
+	if dg.breakers != nil && !eps.Up {
+		// The endpoint left the health check.
+		dg.breakers.remove(eps.EndPoint)
+	}
 }
 
 // withRetry gets available connections and executes the action. If there are retryable errors,
@@ -248,23 +357,50 @@ func (dg *discoveryGateway) withRetry(ctx context.Context, keyspace, shard strin
 	var err error
 	inTransaction := (transactionID != 0)
 	invalidEndPoints := make(map[string]bool)
+	route := geoRouteFromContext(ctx)
+	if tabletType == topodatapb.TabletType_MASTER {
+		route = nil
+	}
 
 	for i := 0; i < dg.retryCount+1; i++ {
 		var endPoint *topodatapb.EndPoint
-		endPoints := dg.getEndPoints(keyspace, shard, tabletType)
+		var endPoints []*topodatapb.EndPoint
+		if route != nil {
+			var cell string
+			endPoints, cell = dg.getEndPointsForGeo(keyspace, shard, tabletType, route.cells)
+			if i == 0 {
+				geoRoutingCounts.Add([]string{route.geo, cell}, 1)
+			}
+		} else {
+			endPoints = dg.getEndPoints(keyspace, shard, tabletType)
+		}
+		if len(endPoints) == 0 && tabletType != topodatapb.TabletType_MASTER {
+			endPoints = dg.getFallbackEndPoints(ctx, keyspace, shard, tabletType)
+		}
 		if len(endPoints) == 0 {
 			// fail fast if there is no endpoint
 			err = vterrors.FromError(vtrpcpb.ErrorCode_INTERNAL_ERROR, fmt.Errorf("no valid endpoint"))
 			break
 		}
-		shuffleEndPoints(endPoints)
+		if dg.balancer != nil {
+			dg.balancer.order(targetKey(keyspace, shard, tabletType), endPoints, func() map[string]float64 {
+				return dg.getEndPointWeights(keyspace, shard, tabletType)
+			}, invalidEndPoints)
+		} else {
+			shuffleEndPoints(endPoints)
+		}
 
-		// skip endpoints we tried before
+		// skip endpoints we tried before, and the ones whose
+		// circuit breaker doesn't let the query through.
 		for _, ep := range endPoints {
-			if _, ok := invalidEndPoints[discovery.EndPointToMapKey(ep)]; !ok {
-				endPoint = ep
-				break
+			if _, ok := invalidEndPoints[discovery.EndPointToMapKey(ep)]; ok {
+				continue
+			}
+			if dg.breakers != nil && !dg.breakers.get(ep).Allow() {
+				continue
 			}
+			endPoint = ep
+			break
 		}
 		if endPoint == nil {
 			if err == nil {
@@ -289,8 +425,12 @@ func (dg *discoveryGateway) withRetry(ctx context.Context, keyspace, shard strin
 		}
 
 		err = action(conn)
+		dg.recordResult(ctx, endPoint, err)
 		if dg.canRetry(ctx, err, transactionID, isStreaming) {
-			invalidEndPoints[discovery.EndPointToMapKey(endPoint)] = true
+			// Transient errors can be retried on the same endpoint.
+			if !isTransientServerError(err) {
+				invalidEndPoints[discovery.EndPointToMapKey(endPoint)] = true
+			}
 			continue
 		}
 		break
@@ -298,8 +438,24 @@ func (dg *discoveryGateway) withRetry(ctx context.Context, keyspace, shard strin
 	return WrapError(err, keyspace, shard, tabletType, endPointLastUsed, inTransaction)
 }
 
+// recordResult updates the circuit breaker of endPoint with the
+// result of a query. The queries whose context is done don't count.
+func (dg *discoveryGateway) recordResult(ctx context.Context, endPoint *topodatapb.EndPoint, err error) {
+	if dg.breakers == nil || ctx.Err() != nil {
+		return
+	}
+	cb := dg.breakers.get(endPoint)
+	if isTabletFailure(err) {
+		cb.RecordFailure(err)
+		return
+	}
+	cb.RecordSuccess()
+}
+
 // canRetry determines whether a query can be retried or not.
 // OperationalErrors like retry/fatal are retryable if query is not in a txn.
+// Transient errors, like a MySQL deadlock of an autocommit statement,
+// are retryable if query is not in a txn.
 // All other errors are non-retryable.
 func (dg *discoveryGateway) canRetry(ctx context.Context, err error, transactionID int64, isStreaming bool) bool {
 	if err == nil {
@@ -328,6 +484,11 @@ func (dg *discoveryGateway) canRetry(ctx context.Context, err error, transaction
 			// INTERNAL_ERROR if not in a transaction.
 			inTransaction := (transactionID != 0)
 			return !inTransaction
+		case vtrpcpb.ErrorCode_TRANSIENT_ERROR:
+			// vttablet only returns TRANSIENT_ERROR for statements
+			// it executed outside of a transaction, but check anyway.
+			inTransaction := (transactionID != 0)
+			return !inTransaction && !isStreaming
 		default:
 			// Not retry for RESOURCE_EXHAUSTED and normal
 			// server errors.
@@ -338,6 +499,13 @@ func (dg *discoveryGateway) canRetry(ctx context.Context, err error, transaction
 	return false
 }
 
+// isTransientServerError returns true if err is a TRANSIENT_ERROR
+// returned by vttablet.
+func isTransientServerError(err error) bool {
+	serverError, ok := err.(*tabletconn.ServerError)
+	return ok && serverError.ServerCode == vtrpcpb.ErrorCode_TRANSIENT_ERROR
+}
+
 func shuffleEndPoints(endPoints []*topodatapb.EndPoint) {
 	index := 0
 	length := len(endPoints)
@@ -350,10 +518,11 @@ func shuffleEndPoints(endPoints []*topodatapb.EndPoint) {
 // getEndPoints gets all available endpoints from HealthCheck,
 // and selects the usable ones based several rules:
 // master - return one from any cells with latest reparent timestamp;
-// replica - return all from local cell.
+// replica - return all from local cell, or from the first other cell
+// of its alias that has any.
 // TODO(liang): select replica by replication lag.
 func (dg *discoveryGateway) getEndPoints(keyspace, shard string, tabletType topodatapb.TabletType) []*topodatapb.EndPoint {
-	epsList := dg.hc.GetEndPointStatsFromTarget(keyspace, shard, tabletType)
+	epsList := dg.getEndPointStats(keyspace, shard, tabletType)
 	// for master, use any cells and return the one with max reparent timestamp.
 	if tabletType == topodatapb.TabletType_MASTER {
 		var maxTimestamp int64
@@ -373,12 +542,92 @@ func (dg *discoveryGateway) getEndPoints(keyspace, shard string, tabletType topo
 		return []*topodatapb.EndPoint{ep}
 	}
 	// for non-master, use only endpoints from local cell and filter by replication lag.
+	return dg.localEndPoints(epsList)
+}
+
+// localEndPoints returns the serving endpoints of epsList in the local
+// cell. If there's none, it returns the ones of the first other cell of
+// the cell alias that has any.
+func (dg *discoveryGateway) localEndPoints(epsList []*discovery.EndPointStats) []*topodatapb.EndPoint {
+	epList := endPointsInCell(epsList, dg.localCell)
+	if len(epList) != 0 {
+		return epList
+	}
+	for _, cell := range dg.cellAlias.otherCells() {
+		if aliasList := endPointsInCell(epsList, cell); len(aliasList) != 0 {
+			return aliasList
+		}
+	}
+	return epList
+}
+
+// cellsToWatchWithAlias returns the cells of -cells_to_watch and those
+// of the cell alias, without duplicates.
+func cellsToWatchWithAlias(cellsToWatch string, alias *CellAlias) []string {
+	var cells []string
+	seen := make(map[string]bool)
+	for _, c := range strings.Split(cellsToWatch, ",") {
+		if c != "" && !seen[c] {
+			seen[c] = true
+			cells = append(cells, c)
+		}
+	}
+	if alias != nil {
+		for _, c := range alias.Cells {
+			if !seen[c] {
+				seen[c] = true
+				cells = append(cells, c)
+			}
+		}
+	}
+	return cells
+}
+
+// getEndPointsForGeo returns the endpoints of the first cell in cells
+// that has any, and that cell. If none has, it returns the local
+// endpoints, as getEndPoints, and geoFallbackCell. It must not be used for
+// master.
+func (dg *discoveryGateway) getEndPointsForGeo(keyspace, shard string, tabletType topodatapb.TabletType, cells []string) ([]*topodatapb.EndPoint, string) {
+	epsList := dg.getEndPointStats(keyspace, shard, tabletType)
+	for _, cell := range cells {
+		if epList := endPointsInCell(epsList, cell); len(epList) != 0 {
+			return epList, cell
+		}
+	}
+	return dg.localEndPoints(epsList), geoFallbackCell
+}
+
+// getEndPointStats returns the EndPointStats of the target from the
+// health check, where the endpoints whose circuit breaker is tripped
+// are not serving.
+func (dg *discoveryGateway) getEndPointStats(keyspace, shard string, tabletType topodatapb.TabletType) []*discovery.EndPointStats {
+	epsList := dg.hc.GetEndPointStatsFromTarget(keyspace, shard, tabletType)
+	if dg.breakers == nil {
+		return epsList
+	}
+	return dg.breakers.apply(epsList)
+}
+
+// getEndPointWeights returns the weights of the endpoints of the
+// target, by key.
+func (dg *discoveryGateway) getEndPointWeights(keyspace, shard string, tabletType topodatapb.TabletType) map[string]float64 {
+	epsList := dg.hc.GetEndPointStatsFromTarget(keyspace, shard, tabletType)
+	weights := make(map[string]float64, len(epsList))
+	for _, eps := range epsList {
+		weights[discovery.EndPointToMapKey(eps.EndPoint)] = endPointWeight(eps)
+	}
+	return weights
+}
+
+// endPointsInCell returns the serving endpoints of epsList that are in
+// cell, filtered by replication lag.
+func endPointsInCell(epsList []*discovery.EndPointStats, cell string) []*topodatapb.EndPoint {
 	list := make([]*discovery.EndPointStats, 0, len(epsList))
 	for _, eps := range epsList {
 		if eps.LastError != nil || !eps.Serving {
 			continue
 		}
-		if dg.localCell != eps.Cell {
+		if cell != eps.Cell {
 			continue
 		}
 		list = append(list, eps)
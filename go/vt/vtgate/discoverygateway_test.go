@@ -2,6 +2,7 @@ package vtgate
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
 	"time"
 
@@ -67,6 +68,19 @@ func TestDiscoveryGatewayRollback(t *testing.T) {
 	})
 }
 
+func TestParseDNSDiscoveryShards(t *testing.T) {
+	got, err := parseDNSDiscoveryShards("ks/-80,ks/80-,")
+	want := []topo.KeyspaceShard{{Keyspace: "ks", Shard: "-80"}, {Keyspace: "ks", Shard: "80-"}}
+	if err != nil || !reflect.DeepEqual(got, want) {
+		t.Errorf("parseDNSDiscoveryShards: %v, %v, want %v", got, err, want)
+	}
+	for _, value := range []string{"", "ks"} {
+		if _, err := parseDNSDiscoveryShards(value); err == nil {
+			t.Errorf("parseDNSDiscoveryShards(%q): nil, want error", value)
+		}
+	}
+}
+
 func TestDiscoveryGatewayGetEndPoints(t *testing.T) {
 	keyspace := "ks"
 	shard := "0"
@@ -92,6 +106,31 @@ func TestDiscoveryGatewayGetEndPoints(t *testing.T) {
 	}
 }
 
+func TestDiscoveryGatewayGetEndPointsForGeo(t *testing.T) {
+	keyspace := "ks"
+	shard := "0"
+	hc := newFakeHealthCheck()
+	dg := createDiscoveryGateway(hc, topo.Server{}, nil, "local", time.Millisecond, 2, time.Second, time.Second, time.Second, nil, nil).(*discoveryGateway)
+
+	// use the first cell of the list that has endpoints
+	hc.Reset()
+	hc.addTestEndPoint("local", "1.1.1.1", 1001, keyspace, shard, topodatapb.TabletType_REPLICA, true, 10, nil, nil)
+	ep1 := hc.addTestEndPoint("remote2", "2.2.2.2", 1001, keyspace, shard, topodatapb.TabletType_REPLICA, true, 10, nil, nil)
+	eps, cell := dg.getEndPointsForGeo(keyspace, shard, topodatapb.TabletType_REPLICA, []string{"remote1", "remote2"})
+	if len(eps) != 1 || !topo.EndPointEquality(eps[0], ep1) || cell != "remote2" {
+		t.Errorf("want %+v in remote2, got %+v in %v", ep1, eps, cell)
+	}
+
+	// fall back to the local cell
+	hc.Reset()
+	ep1 = hc.addTestEndPoint("local", "1.1.1.1", 1001, keyspace, shard, topodatapb.TabletType_REPLICA, true, 10, nil, nil)
+	hc.addTestEndPoint("remote2", "2.2.2.2", 1001, keyspace, shard, topodatapb.TabletType_REPLICA, false, 10, nil, nil)
+	eps, cell = dg.getEndPointsForGeo(keyspace, shard, topodatapb.TabletType_REPLICA, []string{"remote1", "remote2"})
+	if len(eps) != 1 || !topo.EndPointEquality(eps[0], ep1) || cell != geoFallbackCell {
+		t.Errorf("want %+v in %v, got %+v in %v", ep1, geoFallbackCell, eps, cell)
+	}
+}
+
 func testDiscoveryGatewayGeneric(t *testing.T, streaming bool, f func(dg Gateway, keyspace, shard string, tabletType topodatapb.TabletType) error) {
 	keyspace := "ks"
 	shard := "0"
@@ -318,7 +357,18 @@ func (fhc *fakeHealthCheck) GetConnection(endPoint *topodatapb.EndPoint) tabletc
 
 // CacheStatus returns a displayable version of the cache.
 func (fhc *fakeHealthCheck) CacheStatus() discovery.EndPointsCacheStatusList {
-	return nil
+	var res discovery.EndPointsCacheStatusList
+	for _, item := range fhc.items {
+		if item.eps.Target == nil {
+			continue
+		}
+		res = append(res, &discovery.EndPointsCacheStatus{
+			Cell:           item.eps.Cell,
+			Target:         item.eps.Target,
+			EndPointsStats: discovery.EndPointStatsList{item.eps},
+		})
+	}
+	return res
 }
 
 // Close stops the healthcheck.
@@ -33,6 +33,10 @@ type Plan struct {
 	// Instructions contains the instructions needed to
 	// fulfil the query.
 	Instructions Primitive `json:",omitempty"`
+	// LockingRead is true for the selects that lock the rows they
+	// read, like select ... for update or select ... for share.
+	// They are sent to the master.
+	LockingRead bool `json:",omitempty"`
 }
 
 // Size is defined so that Plan can be given to a cache.LRUCache.
@@ -174,6 +174,13 @@ const (
 	// to a single shard: Requires: A Vindex, and
 	// a single Value.
 	UpdateEqual
+	// UpdateIN is for routing an update statement that has
+	// an IN clause using a unique Vindex. The statement is
+	// sent to each shard the IN values map to, with only
+	// the values of the shard. It fails outside of a
+	// transaction if there is more than one shard.
+	// Requires: A Vindex, and a Values list.
+	UpdateIN
 	// DeleteUnsharded is for routing a delete statement
 	// to an unsharded keyspace.
 	DeleteUnsharded
@@ -182,6 +189,12 @@ const (
 	// Value, and a Subquery, which will be used to
 	// determine if lookup rows need to be deleted.
 	DeleteEqual
+	// DeleteIN is for routing a delete statement that has
+	// an IN clause using a unique Vindex, like UpdateIN.
+	// Requires: A Vindex, a Values list, and a Subquery,
+	// which will be used to determine if lookup rows need
+	// to be deleted.
+	DeleteIN
 	// InsertUnsharded is for routing an insert statement
 	// to an unsharded keyspace.
 	InsertUnsharded
@@ -204,8 +217,10 @@ var routeName = [NumCodes]string{
 	"SelectScatter",
 	"UpdateUnsharded",
 	"UpdateEqual",
+	"UpdateIN",
 	"DeleteUnsharded",
 	"DeleteEqual",
+	"DeleteIN",
 	"InsertUnsharded",
 	"InsertSharded",
 }
@@ -305,7 +305,7 @@ func (a *streamExecuteAdapter) Recv() (*sqltypes.Result, error) {
 }
 
 // StreamExecute please see vtgateconn.Impl.StreamExecute
-func (conn *FakeVTGateConn) StreamExecute(ctx context.Context, sql string, bindVars map[string]interface{}, tabletType topodatapb.TabletType) (sqltypes.ResultStream, error) {
+func (conn *FakeVTGateConn) StreamExecute(ctx context.Context, sql string, bindVars map[string]interface{}, tabletType topodatapb.TabletType, session interface{}) (sqltypes.ResultStream, error) {
 	response, ok := conn.execMap[sql]
 	if !ok {
 		return nil, fmt.Errorf("no match for: %s", sql)
@@ -342,17 +342,17 @@ func (conn *FakeVTGateConn) StreamExecute(ctx context.Context, sql string, bindV
 }
 
 // StreamExecuteShards please see vtgateconn.Impl.StreamExecuteShards
-func (conn *FakeVTGateConn) StreamExecuteShards(ctx context.Context, query string, keyspace string, shards []string, bindVars map[string]interface{}, tabletType topodatapb.TabletType) (sqltypes.ResultStream, error) {
+func (conn *FakeVTGateConn) StreamExecuteShards(ctx context.Context, query string, keyspace string, shards []string, bindVars map[string]interface{}, tabletType topodatapb.TabletType, session interface{}) (sqltypes.ResultStream, error) {
 	panic("not implemented")
 }
 
 // StreamExecuteKeyRanges please see vtgateconn.Impl.StreamExecuteKeyRanges
-func (conn *FakeVTGateConn) StreamExecuteKeyRanges(ctx context.Context, query string, keyspace string, keyRanges []*topodatapb.KeyRange, bindVars map[string]interface{}, tabletType topodatapb.TabletType) (sqltypes.ResultStream, error) {
+func (conn *FakeVTGateConn) StreamExecuteKeyRanges(ctx context.Context, query string, keyspace string, keyRanges []*topodatapb.KeyRange, bindVars map[string]interface{}, tabletType topodatapb.TabletType, session interface{}) (sqltypes.ResultStream, error) {
 	panic("not implemented")
 }
 
 // StreamExecuteKeyspaceIds please see vtgateconn.Impl.StreamExecuteKeyspaceIds
-func (conn *FakeVTGateConn) StreamExecuteKeyspaceIds(ctx context.Context, query string, keyspace string, keyspaceIds [][]byte, bindVars map[string]interface{}, tabletType topodatapb.TabletType) (sqltypes.ResultStream, error) {
+func (conn *FakeVTGateConn) StreamExecuteKeyspaceIds(ctx context.Context, query string, keyspace string, keyspaceIds [][]byte, bindVars map[string]interface{}, tabletType topodatapb.TabletType, session interface{}) (sqltypes.ResultStream, error) {
 	panic("not implemented")
 }
 
@@ -364,11 +364,11 @@ func (conn *FakeVTGateConn) Begin(ctx context.Context) (interface{}, error) {
 }
 
 // Commit please see vtgateconn.Impl.Commit
-func (conn *FakeVTGateConn) Commit(ctx context.Context, session interface{}) error {
+func (conn *FakeVTGateConn) Commit(ctx context.Context, session interface{}) (interface{}, error) {
 	if session == nil {
-		return errors.New("commit: not in transaction")
+		return nil, errors.New("commit: not in transaction")
 	}
-	return nil
+	return session, nil
 }
 
 // Rollback please see vtgateconn.Impl.Rollback
@@ -418,11 +418,31 @@ func (conn *FakeVTGateConn) SplitQueryV2(
 	return reply, nil
 }
 
+// Prepare please see vtgateconn.Impl.Prepare
+func (conn *FakeVTGateConn) Prepare(ctx context.Context, query string, keyspace string, tabletType topodatapb.TabletType) (int64, int64, []*querypb.Field, error) {
+	return 0, 0, nil, fmt.Errorf("NYI")
+}
+
+// ExecutePrepared please see vtgateconn.Impl.ExecutePrepared
+func (conn *FakeVTGateConn) ExecutePrepared(ctx context.Context, id int64, bindVars map[string]interface{}, session interface{}) (*sqltypes.Result, interface{}, error) {
+	return nil, nil, fmt.Errorf("NYI")
+}
+
+// ClosePrepared please see vtgateconn.Impl.ClosePrepared
+func (conn *FakeVTGateConn) ClosePrepared(ctx context.Context, ids []int64) error {
+	return fmt.Errorf("NYI")
+}
+
 // GetSrvKeyspace please see vtgateconn.Impl.GetSrvKeyspace
 func (conn *FakeVTGateConn) GetSrvKeyspace(ctx context.Context, keyspace string) (*topodatapb.SrvKeyspace, error) {
 	return nil, fmt.Errorf("NYI")
 }
 
+// GetKeyspaceSchema please see vtgateconn.Impl.GetKeyspaceSchema
+func (conn *FakeVTGateConn) GetKeyspaceSchema(ctx context.Context, keyspace string) (*vtgatepb.GetKeyspaceSchemaResponse, error) {
+	return nil, fmt.Errorf("NYI")
+}
+
 // Close please see vtgateconn.Impl.Close
 func (conn *FakeVTGateConn) Close() {
 }
@@ -0,0 +1,121 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"flag"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/stats"
+	"github.com/youtube/vitess/go/vt/logutil"
+	"github.com/youtube/vitess/go/vt/topo"
+
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+)
+
+var (
+	fallbackCells = flag.String("fallback-cells", "", "comma-separated list of cells, in order, where vtgate sends the replica and rdonly queries of a shard when neither -cell nor the other cells of -cell_alias have a serving tablet of it. The FallbackCells of a keyspace, set with vtctl SetKeyspaceFallbackCells, override it for the keyspace. These cells are watched by the discoverygateway, the ones that only appear in keyspaces must be in -cells_to_watch.")
+
+	// localFallbackCells is parsed from -fallback-cells by Init.
+	localFallbackCells []string
+
+	// cellFallbackCounts counts the endpoint lookups that fell back
+	// to another cell, by keyspace, tablet type and cell.
+	cellFallbackCounts = stats.NewMultiCounters("VtgateCellFallbackCounts", []string{"Keyspace", "TabletType", "Cell"})
+
+	cellFallbackLog = logutil.NewThrottledLogger("CellFallback", 5*time.Second)
+)
+
+// parseFallbackCells parses the comma-separated list of cells of
+// -fallback-cells. The cell of vtgate and the duplicates are skipped.
+func parseFallbackCells(value, cell string) []string {
+	var cells []string
+	seen := map[string]bool{cell: true}
+	for _, c := range strings.Split(value, ",") {
+		if c != "" && !seen[c] {
+			seen[c] = true
+			cells = append(cells, c)
+		}
+	}
+	return cells
+}
+
+// keyspaceFallbackCells returns the cells to fall back to for
+// keyspace: the FallbackCells of its SrvKeyspace in cell, or defaults
+// if it has none or it can't be read.
+func keyspaceFallbackCells(ctx context.Context, serv topo.SrvTopoServer, cell, keyspace string, defaults []string) []string {
+	srvKeyspace, err := serv.GetSrvKeyspace(ctx, cell, keyspace)
+	if err != nil || len(srvKeyspace.FallbackCells) == 0 {
+		return defaults
+	}
+	return parseFallbackCells(strings.Join(srvKeyspace.FallbackCells, ","), cell)
+}
+
+// recordCellFallback counts and logs the queries of a shard that are
+// sent to another cell, so the operators know they pay the cross-cell
+// latency.
+func recordCellFallback(keyspace, shard string, tabletType topodatapb.TabletType, cell, fallbackCell string) {
+	cellFallbackCounts.Add([]string{keyspace, strings.ToLower(tabletType.String()), fallbackCell}, 1)
+	cellFallbackLog.Warningf("%v/%v has no serving %v tablet in cell %v, falling back to cell %v: the queries pay the cross-cell latency", keyspace, shard, strings.ToLower(tabletType.String()), cell, fallbackCell)
+}
+
+// cellFallbackSrvTopoServer returns the endpoints of the fallback cells
+// of a keyspace when the cell of vtgate has none.
+type cellFallbackSrvTopoServer struct {
+	topo.SrvTopoServer
+	cell     string
+	defaults []string
+}
+
+func newCellFallbackSrvTopoServer(serv topo.SrvTopoServer, cell string, defaults []string) topo.SrvTopoServer {
+	return &cellFallbackSrvTopoServer{
+		SrvTopoServer: serv,
+		cell:          cell,
+		defaults:      defaults,
+	}
+}
+
+// GetEndPoints is part of the topo.SrvTopoServer interface. If the
+// cell of vtgate has no replica or rdonly endpoint, it returns the ones
+// of the first fallback cell that has any, or the result of the cell
+// of vtgate if none has.
+func (cfs *cellFallbackSrvTopoServer) GetEndPoints(ctx context.Context, cell, keyspace, shard string, tabletType topodatapb.TabletType) (*topodatapb.EndPoints, int64, error) {
+	endPoints, version, err := cfs.SrvTopoServer.GetEndPoints(ctx, cell, keyspace, shard, tabletType)
+	if (err == nil && len(endPoints.Entries) != 0) || cell != cfs.cell || tabletType == topodatapb.TabletType_MASTER {
+		return endPoints, version, err
+	}
+	for _, c := range keyspaceFallbackCells(ctx, cfs.SrvTopoServer, cell, keyspace, cfs.defaults) {
+		eps, v, e := cfs.SrvTopoServer.GetEndPoints(ctx, c, keyspace, shard, tabletType)
+		if e == nil && len(eps.Entries) != 0 {
+			recordCellFallback(keyspace, shard, tabletType, cell, c)
+			return eps, v, nil
+		}
+	}
+	return endPoints, version, err
+}
+
+// getFallbackEndPoints returns the serving endpoints of the first
+// fallback cell of keyspace that has any. It's used for the replica and
+// rdonly queries when the local cell and its alias have none.
+func (dg *discoveryGateway) getFallbackEndPoints(ctx context.Context, keyspace, shard string, tabletType topodatapb.TabletType) []*topodatapb.EndPoint {
+	cells := dg.fallbackCells
+	if dg.srvTopoServer != nil {
+		cells = keyspaceFallbackCells(ctx, dg.srvTopoServer, dg.localCell, keyspace, dg.fallbackCells)
+	}
+	if len(cells) == 0 {
+		return nil
+	}
+	epsList := dg.getEndPointStats(keyspace, shard, tabletType)
+	for _, cell := range cells {
+		if epList := endPointsInCell(epsList, cell); len(epList) != 0 {
+			recordCellFallback(keyspace, shard, tabletType, dg.localCell, cell)
+			return epList
+		}
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/vt/topo"
+
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+)
+
+func TestParseFallbackCells(t *testing.T) {
+	got := parseFallbackCells("zone2,,zone1,zone3,zone2", "zone1")
+	if want := []string{"zone2", "zone3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("parseFallbackCells: %v, want %v", got, want)
+	}
+	if got := parseFallbackCells("", "zone1"); got != nil {
+		t.Errorf("parseFallbackCells(\"\"): %v, want nil", got)
+	}
+}
+
+// fallbackTopo serves the FallbackCells of the keyspaces in its map,
+// and the endpoints of the cells in its map.
+type fallbackTopo struct {
+	sandboxTopo
+	fallbackCells map[string][]string
+	endPoints     map[string]int
+}
+
+func (ft *fallbackTopo) GetSrvKeyspace(ctx context.Context, cell, keyspace string) (*topodatapb.SrvKeyspace, error) {
+	cells, ok := ft.fallbackCells[keyspace]
+	if !ok {
+		return nil, fmt.Errorf("no SrvKeyspace %v in cell %v", keyspace, cell)
+	}
+	return &topodatapb.SrvKeyspace{FallbackCells: cells}, nil
+}
+
+func (ft *fallbackTopo) GetEndPoints(ctx context.Context, cell, keyspace, shard string, tabletType topodatapb.TabletType) (*topodatapb.EndPoints, int64, error) {
+	endPoints := &topodatapb.EndPoints{}
+	for i := 0; i < ft.endPoints[cell]; i++ {
+		endPoints.Entries = append(endPoints.Entries, &topodatapb.EndPoint{Host: cell})
+	}
+	return endPoints, 1, nil
+}
+
+func TestCellFallbackSrvTopoServer(t *testing.T) {
+	ft := &fallbackTopo{
+		fallbackCells: map[string][]string{"ks1": nil, "ks2": {"zone1", "zone4", "zone3"}},
+		endPoints:     map[string]int{"zone1": 0, "zone2": 0, "zone3": 1, "zone4": 1},
+	}
+	serv := newCellFallbackSrvTopoServer(ft, "zone1", []string{"zone2", "zone3"})
+	ctx := context.Background()
+	count := cellFallbackCounts.Counts()["ks1.replica.zone3"]
+
+	// The default fallback cells are tried in order.
+	endPoints, _, err := serv.GetEndPoints(ctx, "zone1", "ks1", "0", topodatapb.TabletType_REPLICA)
+	if err != nil || len(endPoints.Entries) != 1 || endPoints.Entries[0].Host != "zone3" {
+		t.Errorf("GetEndPoints(zone1, ks1): %v, %v, want the ones of zone3", endPoints, err)
+	}
+	if got := cellFallbackCounts.Counts()["ks1.replica.zone3"] - count; got != 1 {
+		t.Errorf("cellFallbackCounts: %v, want 1", got)
+	}
+	// The keyspace overrides them, and so does a keyspace that
+	// can't be read.
+	endPoints, _, err = serv.GetEndPoints(ctx, "zone1", "ks2", "0", topodatapb.TabletType_RDONLY)
+	if err != nil || len(endPoints.Entries) != 1 || endPoints.Entries[0].Host != "zone4" {
+		t.Errorf("GetEndPoints(zone1, ks2): %v, %v, want the ones of zone4", endPoints, err)
+	}
+	endPoints, _, err = serv.GetEndPoints(ctx, "zone1", "ks3", "0", topodatapb.TabletType_REPLICA)
+	if err != nil || len(endPoints.Entries) != 1 || endPoints.Entries[0].Host != "zone3" {
+		t.Errorf("GetEndPoints(zone1, ks3): %v, %v, want the ones of zone3", endPoints, err)
+	}
+
+	// The masters and the other cells don't fall back.
+	endPoints, _, err = serv.GetEndPoints(ctx, "zone1", "ks1", "0", topodatapb.TabletType_MASTER)
+	if err != nil || len(endPoints.Entries) != 0 {
+		t.Errorf("GetEndPoints(zone1, master): %v, %v, want the empty list of zone1", endPoints, err)
+	}
+	endPoints, _, err = serv.GetEndPoints(ctx, "zone2", "ks1", "0", topodatapb.TabletType_REPLICA)
+	if err != nil || len(endPoints.Entries) != 0 {
+		t.Errorf("GetEndPoints(zone2): %v, %v, want the empty list of zone2", endPoints, err)
+	}
+
+	// The local endpoints are preferred.
+	ft.endPoints["zone1"] = 2
+	endPoints, _, err = serv.GetEndPoints(ctx, "zone1", "ks1", "0", topodatapb.TabletType_REPLICA)
+	if err != nil || len(endPoints.Entries) != 2 || endPoints.Entries[0].Host != "zone1" {
+		t.Errorf("GetEndPoints(zone1) with local endpoints: %v, %v, want the ones of zone1", endPoints, err)
+	}
+}
+
+func TestDiscoveryGatewayFallbackCells(t *testing.T) {
+	keyspace := "ks"
+	shard := "0"
+	hc := newFakeHealthCheck()
+	dg := createDiscoveryGateway(hc, topo.Server{}, nil, "local", time.Millisecond, 2, time.Second, time.Second, time.Second, nil, nil).(*discoveryGateway)
+	// The fallback cells are set after the creation, so that they are
+	// not watched in the fake topology.
+	dg.fallbackCells = []string{"zone2", "zone3"}
+
+	// All the tablets of the local cell are failing: the queries go
+	// to the first fallback cell that has a serving tablet.
+	hc.Reset()
+	local := &sandboxConn{}
+	zone3 := &sandboxConn{}
+	hc.addTestEndPoint("local", "1.1.1.1", 1001, keyspace, shard, topodatapb.TabletType_REPLICA, false, 10, nil, local)
+	hc.addTestEndPoint("local", "1.1.1.2", 1001, keyspace, shard, topodatapb.TabletType_REPLICA, true, 10, fmt.Errorf("health check failed"), local)
+	hc.addTestEndPoint("zone2", "2.2.2.2", 1001, keyspace, shard, topodatapb.TabletType_REPLICA, false, 10, nil, &sandboxConn{})
+	hc.addTestEndPoint("zone3", "3.3.3.3", 1001, keyspace, shard, topodatapb.TabletType_REPLICA, true, 10, nil, zone3)
+	count := cellFallbackCounts.Counts()["ks.replica.zone3"]
+	for i := 0; i < 3; i++ {
+		if _, err := dg.Execute(context.Background(), keyspace, shard, topodatapb.TabletType_REPLICA, "query", nil, 0); err != nil {
+			t.Fatalf("Execute with no local tablet: %v", err)
+		}
+	}
+	if got := zone3.ExecCount.Get(); got != 3 {
+		t.Errorf("ExecCount of zone3: %v, want 3", got)
+	}
+	if got := local.ExecCount.Get(); got != 0 {
+		t.Errorf("ExecCount of the local tablets: %v, want 0", got)
+	}
+	if got := cellFallbackCounts.Counts()["ks.replica.zone3"] - count; got != 3 {
+		t.Errorf("cellFallbackCounts: %v, want 3", got)
+	}
+
+	// The fallback cells of the keyspace override the default ones.
+	zone2 := &sandboxConn{}
+	hc.addTestEndPoint("zone2", "2.2.2.2", 1001, keyspace, shard, topodatapb.TabletType_REPLICA, true, 10, nil, zone2)
+	dg.srvTopoServer = &fallbackTopo{fallbackCells: map[string][]string{keyspace: {"zone3", "zone2"}}}
+	if _, err := dg.Execute(context.Background(), keyspace, shard, topodatapb.TabletType_REPLICA, "query", nil, 0); err != nil {
+		t.Fatalf("Execute with the fallback cells of the keyspace: %v", err)
+	}
+	if got := zone3.ExecCount.Get(); got != 4 {
+		t.Errorf("ExecCount of zone3: %v, want 4", got)
+	}
+	dg.srvTopoServer = nil
+	if _, err := dg.Execute(context.Background(), keyspace, shard, topodatapb.TabletType_REPLICA, "query", nil, 0); err != nil {
+		t.Fatalf("Execute with the default fallback cells: %v", err)
+	}
+	if got := zone2.ExecCount.Get(); got != 1 {
+		t.Errorf("ExecCount of zone2: %v, want 1", got)
+	}
+
+	// The local tablets are preferred as soon as they serve again.
+	hc.addTestEndPoint("local", "1.1.1.1", 1001, keyspace, shard, topodatapb.TabletType_REPLICA, true, 10, nil, local)
+	if _, err := dg.Execute(context.Background(), keyspace, shard, topodatapb.TabletType_REPLICA, "query", nil, 0); err != nil {
+		t.Fatalf("Execute with a local tablet: %v", err)
+	}
+	if got := local.ExecCount.Get(); got != 1 {
+		t.Errorf("ExecCount of the local tablets: %v, want 1", got)
+	}
+
+	// Without fallback cells, the query fails.
+	hc.Reset()
+	hc.addTestEndPoint("local", "1.1.1.1", 1001, keyspace, shard, topodatapb.TabletType_REPLICA, false, 10, nil, local)
+	hc.addTestEndPoint("zone2", "2.2.2.2", 1001, keyspace, shard, topodatapb.TabletType_REPLICA, true, 10, nil, zone2)
+	dg.fallbackCells = nil
+	if _, err := dg.Execute(context.Background(), keyspace, shard, topodatapb.TabletType_REPLICA, "query", nil, 0); err == nil {
+		t.Errorf("Execute without fallback cells: nil, want error")
+	}
+}
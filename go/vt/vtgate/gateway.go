@@ -149,6 +149,10 @@ type GatewayEndPointCacheStatus struct {
 	QueryError uint64
 	QPS        uint64
 	AvgLatency float64 // in milliseconds
+
+	// CircuitBreaker is the state of the circuit breaker of the
+	// endpoint, or nil if it has none.
+	CircuitBreaker *CircuitBreakerStatus
 }
 
 const (
@@ -0,0 +1,86 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/stats"
+)
+
+var (
+	geoCellMapFile = flag.String("geo_cell_map", "", "JSON file that maps the geo routing hints of SELECT queries (/*+ geo=us-west */) to ordered lists of preferred cells, e.g. {\"us-west\": [\"cell1\", \"cell2\"]}. The cells must be watched with -cells_to_watch.")
+
+	// geoCellMap is loaded from geoCellMapFile by Init.
+	geoCellMap map[string][]string
+
+	// geoRoutingCounts counts the SELECT queries with a geo hint by
+	// requested geo and effective cell. The effective cell is
+	// "fallback" if none of the cells of the geo had a serving
+	// endpoint.
+	geoRoutingCounts = stats.NewMultiCounters("VtgateGeoRoutingCounts", []string{"Geo", "Cell"})
+
+	geoHintRE = regexp.MustCompile(`/\*\+\s*geo=([\w-]+)\s*\*/`)
+)
+
+// geoFallbackCell is the effective cell recorded in geoRoutingCounts
+// when the query was routed normally.
+const geoFallbackCell = "fallback"
+
+// loadGeoCellMap reads a geo to cells mapping from a JSON file.
+func loadGeoCellMap(file string) (map[string][]string, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read geo cell map: %v", err)
+	}
+	m := make(map[string][]string)
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("cannot parse geo cell map %v: %v", file, err)
+	}
+	return m, nil
+}
+
+// geoRoute is the routing preference of a query with a geo hint.
+type geoRoute struct {
+	geo   string
+	cells []string
+}
+
+type geoRouteKey struct{}
+
+// withGeoHint returns a context that carries the cell preference
+// list of sql, if it's a SELECT with a known geo hint. Otherwise it
+// returns ctx unchanged.
+func withGeoHint(ctx context.Context, sql string) context.Context {
+	match := geoHintRE.FindStringSubmatchIndex(sql)
+	if match == nil {
+		return ctx
+	}
+	rest := strings.TrimSpace(sql[:match[0]] + sql[match[1]:])
+	if !strings.HasPrefix(strings.ToLower(rest), "select") {
+		return ctx
+	}
+	geo := sql[match[2]:match[3]]
+	cells, ok := geoCellMap[geo]
+	if !ok || len(cells) == 0 {
+		geoRoutingCounts.Add([]string{geo, geoFallbackCell}, 1)
+		return ctx
+	}
+	return context.WithValue(ctx, geoRouteKey{}, &geoRoute{geo: geo, cells: cells})
+}
+
+// geoRouteFromContext returns the geo routing preference stored in
+// ctx by withGeoHint, or nil.
+func geoRouteFromContext(ctx context.Context) *geoRoute {
+	route, _ := ctx.Value(geoRouteKey{}).(*geoRoute)
+	return route
+}
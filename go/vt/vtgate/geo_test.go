@@ -0,0 +1,70 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"golang.org/x/net/context"
+)
+
+func TestLoadGeoCellMap(t *testing.T) {
+	f, err := ioutil.TempFile("", "geo_cell_map")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.WriteString(`{"us-west": ["cell1", "cell2"]}`); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	got, err := loadGeoCellMap(f.Name())
+	if err != nil {
+		t.Fatalf("loadGeoCellMap: %v", err)
+	}
+	want := map[string][]string{"us-west": {"cell1", "cell2"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("loadGeoCellMap: %v, want %v", got, want)
+	}
+
+	if _, err := loadGeoCellMap("/nonexistent"); err == nil {
+		t.Errorf("loadGeoCellMap(/nonexistent): nil, want error")
+	}
+}
+
+func TestWithGeoHint(t *testing.T) {
+	defer func(m map[string][]string) { geoCellMap = m }(geoCellMap)
+	geoCellMap = map[string][]string{"us-west": {"cell1", "cell2"}}
+
+	testcases := []struct {
+		sql  string
+		want *geoRoute
+	}{{
+		sql:  "/*+ geo=us-west */ select * from t",
+		want: &geoRoute{geo: "us-west", cells: []string{"cell1", "cell2"}},
+	}, {
+		sql:  "SELECT /*+geo=us-west*/ * from t",
+		want: &geoRoute{geo: "us-west", cells: []string{"cell1", "cell2"}},
+	}, {
+		sql:  "select * from t",
+		want: nil,
+	}, {
+		sql:  "/*+ geo=us-east */ select * from t",
+		want: nil,
+	}, {
+		sql:  "/*+ geo=us-west */ update t set a = 1",
+		want: nil,
+	}}
+	for _, tc := range testcases {
+		got := geoRouteFromContext(withGeoHint(context.Background(), tc.sql))
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("withGeoHint(%q): %+v, want %+v", tc.sql, got, tc.want)
+		}
+	}
+}
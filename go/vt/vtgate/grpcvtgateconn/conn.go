@@ -31,6 +31,8 @@ var (
 	key  = flag.String("vtgate_grpc_key", "", "the key to use to connect")
 	ca   = flag.String("vtgate_grpc_ca", "", "the server ca to use to validate servers when connecting")
 	name = flag.String("vtgate_grpc_server_name", "", "the server name to use to validate server certificate")
+
+	multiplex = flag.Bool("vtgate_grpc_multiplex", false, "if set, the concurrent Execute calls of a connection share a single RequestMultiplexer stream, instead of one gRPC call each")
 )
 
 func init() {
@@ -40,6 +42,8 @@ func init() {
 type vtgateConn struct {
 	cc *grpc.ClientConn
 	c  vtgateservicepb.VitessClient
+	// mux is set with -vtgate_grpc_multiplex.
+	mux *multiplexer
 }
 
 func dial(ctx context.Context, addr string, timeout time.Duration) (vtgateconn.Impl, error) {
@@ -52,10 +56,14 @@ func dial(ctx context.Context, addr string, timeout time.Duration) (vtgateconn.I
 		return nil, err
 	}
 	c := vtgateservicepb.NewVitessClient(cc)
-	return &vtgateConn{
+	conn := &vtgateConn{
 		cc: cc,
 		c:  c,
-	}, nil
+	}
+	if *multiplex {
+		conn.mux = newMultiplexer(vtgateservicepb.NewRequestMultiplexerClient(cc))
+	}
+	return conn, nil
 }
 
 func (conn *vtgateConn) Execute(ctx context.Context, query string, bindVars map[string]interface{}, tabletType topodatapb.TabletType, session interface{}) (*sqltypes.Result, interface{}, error) {
@@ -73,9 +81,15 @@ func (conn *vtgateConn) Execute(ctx context.Context, query string, bindVars map[
 		Query:      q,
 		TabletType: tabletType,
 	}
-	response, err := conn.c.Execute(ctx, request)
+	var response *vtgatepb.ExecuteResponse
+	if conn.mux != nil {
+		response, err = conn.mux.Execute(ctx, request)
+	} else {
+		response, err = conn.c.Execute(ctx, request)
+		err = vterrors.FromGRPCError(err)
+	}
 	if err != nil {
-		return nil, session, vterrors.FromGRPCError(err)
+		return nil, session, err
 	}
 	if response.Error != nil {
 		return nil, response.Session, vterrors.FromVtRPCError(response.Error)
@@ -255,15 +269,26 @@ func (a *streamExecuteAdapter) Recv() (*sqltypes.Result, error) {
 	return sqltypes.CustomProto3ToResult(a.fields, qr), nil
 }
 
-func (conn *vtgateConn) StreamExecute(ctx context.Context, query string, bindVars map[string]interface{}, tabletType topodatapb.TabletType) (sqltypes.ResultStream, error) {
+// sessionMaxScatterParallelism returns the limit of session, sent with
+// the streaming queries, which have no session.
+func sessionMaxScatterParallelism(session interface{}) int64 {
+	s, _ := session.(*vtgatepb.Session)
+	if s == nil {
+		return 0
+	}
+	return s.MaxScatterParallelism
+}
+
+func (conn *vtgateConn) StreamExecute(ctx context.Context, query string, bindVars map[string]interface{}, tabletType topodatapb.TabletType, session interface{}) (sqltypes.ResultStream, error) {
 	q, err := querytypes.BoundQueryToProto3(query, bindVars)
 	if err != nil {
 		return nil, err
 	}
 	req := &vtgatepb.StreamExecuteRequest{
-		CallerId:   callerid.EffectiveCallerIDFromContext(ctx),
-		Query:      q,
-		TabletType: tabletType,
+		CallerId:              callerid.EffectiveCallerIDFromContext(ctx),
+		Query:                 q,
+		TabletType:            tabletType,
+		MaxScatterParallelism: sessionMaxScatterParallelism(session),
 	}
 	stream, err := conn.c.StreamExecute(ctx, req)
 	if err != nil {
@@ -280,17 +305,18 @@ func (conn *vtgateConn) StreamExecute(ctx context.Context, query string, bindVar
 	}, nil
 }
 
-func (conn *vtgateConn) StreamExecuteShards(ctx context.Context, query string, keyspace string, shards []string, bindVars map[string]interface{}, tabletType topodatapb.TabletType) (sqltypes.ResultStream, error) {
+func (conn *vtgateConn) StreamExecuteShards(ctx context.Context, query string, keyspace string, shards []string, bindVars map[string]interface{}, tabletType topodatapb.TabletType, session interface{}) (sqltypes.ResultStream, error) {
 	q, err := querytypes.BoundQueryToProto3(query, bindVars)
 	if err != nil {
 		return nil, err
 	}
 	req := &vtgatepb.StreamExecuteShardsRequest{
-		CallerId:   callerid.EffectiveCallerIDFromContext(ctx),
-		Query:      q,
-		Keyspace:   keyspace,
-		Shards:     shards,
-		TabletType: tabletType,
+		CallerId:              callerid.EffectiveCallerIDFromContext(ctx),
+		Query:                 q,
+		Keyspace:              keyspace,
+		Shards:                shards,
+		TabletType:            tabletType,
+		MaxScatterParallelism: sessionMaxScatterParallelism(session),
 	}
 	stream, err := conn.c.StreamExecuteShards(ctx, req)
 	if err != nil {
@@ -307,17 +333,18 @@ func (conn *vtgateConn) StreamExecuteShards(ctx context.Context, query string, k
 	}, nil
 }
 
-func (conn *vtgateConn) StreamExecuteKeyRanges(ctx context.Context, query string, keyspace string, keyRanges []*topodatapb.KeyRange, bindVars map[string]interface{}, tabletType topodatapb.TabletType) (sqltypes.ResultStream, error) {
+func (conn *vtgateConn) StreamExecuteKeyRanges(ctx context.Context, query string, keyspace string, keyRanges []*topodatapb.KeyRange, bindVars map[string]interface{}, tabletType topodatapb.TabletType, session interface{}) (sqltypes.ResultStream, error) {
 	q, err := querytypes.BoundQueryToProto3(query, bindVars)
 	if err != nil {
 		return nil, err
 	}
 	req := &vtgatepb.StreamExecuteKeyRangesRequest{
-		CallerId:   callerid.EffectiveCallerIDFromContext(ctx),
-		Query:      q,
-		Keyspace:   keyspace,
-		KeyRanges:  keyRanges,
-		TabletType: tabletType,
+		CallerId:              callerid.EffectiveCallerIDFromContext(ctx),
+		Query:                 q,
+		Keyspace:              keyspace,
+		KeyRanges:             keyRanges,
+		TabletType:            tabletType,
+		MaxScatterParallelism: sessionMaxScatterParallelism(session),
 	}
 	stream, err := conn.c.StreamExecuteKeyRanges(ctx, req)
 	if err != nil {
@@ -334,17 +361,18 @@ func (conn *vtgateConn) StreamExecuteKeyRanges(ctx context.Context, query string
 	}, nil
 }
 
-func (conn *vtgateConn) StreamExecuteKeyspaceIds(ctx context.Context, query string, keyspace string, keyspaceIds [][]byte, bindVars map[string]interface{}, tabletType topodatapb.TabletType) (sqltypes.ResultStream, error) {
+func (conn *vtgateConn) StreamExecuteKeyspaceIds(ctx context.Context, query string, keyspace string, keyspaceIds [][]byte, bindVars map[string]interface{}, tabletType topodatapb.TabletType, session interface{}) (sqltypes.ResultStream, error) {
 	q, err := querytypes.BoundQueryToProto3(query, bindVars)
 	if err != nil {
 		return nil, err
 	}
 	req := &vtgatepb.StreamExecuteKeyspaceIdsRequest{
-		CallerId:    callerid.EffectiveCallerIDFromContext(ctx),
-		Query:       q,
-		Keyspace:    keyspace,
-		KeyspaceIds: keyspaceIds,
-		TabletType:  tabletType,
+		CallerId:              callerid.EffectiveCallerIDFromContext(ctx),
+		Query:                 q,
+		Keyspace:              keyspace,
+		KeyspaceIds:           keyspaceIds,
+		TabletType:            tabletType,
+		MaxScatterParallelism: sessionMaxScatterParallelism(session),
 	}
 	stream, err := conn.c.StreamExecuteKeyspaceIds(ctx, req)
 	if err != nil {
@@ -372,13 +400,16 @@ func (conn *vtgateConn) Begin(ctx context.Context) (interface{}, error) {
 	return response.Session, nil
 }
 
-func (conn *vtgateConn) Commit(ctx context.Context, session interface{}) error {
+func (conn *vtgateConn) Commit(ctx context.Context, session interface{}) (interface{}, error) {
 	request := &vtgatepb.CommitRequest{
 		CallerId: callerid.EffectiveCallerIDFromContext(ctx),
 		Session:  session.(*vtgatepb.Session),
 	}
-	_, err := conn.c.Commit(ctx, request)
-	return vterrors.FromGRPCError(err)
+	response, err := conn.c.Commit(ctx, request)
+	if err != nil {
+		return nil, vterrors.FromGRPCError(err)
+	}
+	return response.Session, nil
 }
 
 func (conn *vtgateConn) Rollback(ctx context.Context, session interface{}) error {
@@ -444,6 +475,57 @@ func (conn *vtgateConn) SplitQueryV2(
 	return response.Splits, nil
 }
 
+func (conn *vtgateConn) Prepare(ctx context.Context, query string, keyspace string, tabletType topodatapb.TabletType) (int64, int64, []*querypb.Field, error) {
+	request := &vtgatepb.PrepareRequest{
+		CallerId:   callerid.EffectiveCallerIDFromContext(ctx),
+		Sql:        query,
+		Keyspace:   keyspace,
+		TabletType: tabletType,
+	}
+	response, err := conn.c.Prepare(ctx, request)
+	if err != nil {
+		return 0, 0, nil, vterrors.FromGRPCError(err)
+	}
+	if response.Error != nil {
+		return 0, 0, nil, vterrors.FromVtRPCError(response.Error)
+	}
+	return response.StatementId, response.ParamCount, response.Fields, nil
+}
+
+func (conn *vtgateConn) ExecutePrepared(ctx context.Context, id int64, bindVars map[string]interface{}, session interface{}) (*sqltypes.Result, interface{}, error) {
+	var s *vtgatepb.Session
+	if session != nil {
+		s = session.(*vtgatepb.Session)
+	}
+	bv, err := querytypes.BindVariablesToProto3(bindVars)
+	if err != nil {
+		return nil, session, err
+	}
+	request := &vtgatepb.ExecutePreparedRequest{
+		CallerId:      callerid.EffectiveCallerIDFromContext(ctx),
+		Session:       s,
+		StatementId:   id,
+		BindVariables: bv,
+	}
+	response, err := conn.c.ExecutePrepared(ctx, request)
+	if err != nil {
+		return nil, session, vterrors.FromGRPCError(err)
+	}
+	if response.Error != nil {
+		return nil, response.Session, vterrors.FromVtRPCError(response.Error)
+	}
+	return sqltypes.Proto3ToResult(response.Result), response.Session, nil
+}
+
+func (conn *vtgateConn) ClosePrepared(ctx context.Context, ids []int64) error {
+	request := &vtgatepb.ClosePreparedRequest{
+		CallerId:     callerid.EffectiveCallerIDFromContext(ctx),
+		StatementIds: ids,
+	}
+	_, err := conn.c.ClosePrepared(ctx, request)
+	return vterrors.FromGRPCError(err)
+}
+
 func (conn *vtgateConn) GetSrvKeyspace(ctx context.Context, keyspace string) (*topodatapb.SrvKeyspace, error) {
 	request := &vtgatepb.GetSrvKeyspaceRequest{
 		Keyspace: keyspace,
@@ -455,6 +537,20 @@ func (conn *vtgateConn) GetSrvKeyspace(ctx context.Context, keyspace string) (*t
 	return response.SrvKeyspace, nil
 }
 
+func (conn *vtgateConn) GetKeyspaceSchema(ctx context.Context, keyspace string) (*vtgatepb.GetKeyspaceSchemaResponse, error) {
+	request := &vtgatepb.GetKeyspaceSchemaRequest{
+		Keyspace: keyspace,
+	}
+	response, err := conn.c.GetKeyspaceSchema(ctx, request)
+	if err != nil {
+		return nil, vterrors.FromGRPCError(err)
+	}
+	return response, nil
+}
+
 func (conn *vtgateConn) Close() {
+	if conn.mux != nil {
+		conn.mux.Close()
+	}
 	conn.cc.Close()
 }
@@ -47,3 +47,38 @@ func TestGRPCVTGateConn(t *testing.T) {
 	// and clean up
 	client.Close()
 }
+
+// TestGRPCVTGateConnMultiplex runs the test suite with the Execute
+// calls sent on a RequestMultiplexer stream.
+func TestGRPCVTGateConnMultiplex(t *testing.T) {
+	*multiplex = true
+	defer func() { *multiplex = false }()
+
+	service := vtgateconntest.CreateFakeServer(t)
+	listener, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatalf("Cannot listen: %v", err)
+	}
+	server := grpc.NewServer()
+	grpcvtgateservice.RegisterForTest(server, service)
+	go server.Serve(listener)
+
+	ctx := context.Background()
+	client, err := dial(ctx, listener.Addr().String(), 30*time.Second)
+	if err != nil {
+		t.Fatalf("dial failed: %v", err)
+	}
+	if client.(*vtgateConn).mux == nil {
+		t.Fatalf("dial with -vtgate_grpc_multiplex: no multiplexer")
+	}
+	vtgateconntest.RegisterTestDialProtocol(client)
+
+	vtgateconntest.TestSuite(t, client, service)
+	vtgateconntest.TestErrorSuite(t, service)
+
+	// After Close, the calls fail.
+	client.Close()
+	if _, _, err := client.Execute(ctx, "select 1", nil, 0, nil); err == nil {
+		t.Errorf("Execute after Close: nil, want an error")
+	}
+}
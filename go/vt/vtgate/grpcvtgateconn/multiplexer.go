@@ -0,0 +1,163 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package grpcvtgateconn
+
+import (
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/youtube/vitess/go/vt/vterrors"
+	"golang.org/x/net/context"
+
+	vtgatepb "github.com/youtube/vitess/go/vt/proto/vtgate"
+	vtgateservicepb "github.com/youtube/vitess/go/vt/proto/vtgateservice"
+	vtrpcpb "github.com/youtube/vitess/go/vt/proto/vtrpc"
+)
+
+// multiplexResult is the response to a request sent on the stream.
+type multiplexResult struct {
+	response *vtgatepb.ExecuteResponse
+	err      error
+}
+
+// multiplexer sends the Execute requests of a connection over a single
+// RequestMultiplexer stream, and routes the responses back to their
+// callers by request ID. The stream is opened by the first request,
+// and opened again by the next one if it breaks.
+type multiplexer struct {
+	c vtgateservicepb.RequestMultiplexerClient
+
+	// mu protects the following fields, and the Send calls.
+	mu      sync.Mutex
+	stream  vtgateservicepb.RequestMultiplexer_MultiplexClient
+	cancel  context.CancelFunc
+	closed  bool
+	nextID  uint64
+	pending map[uint64]chan multiplexResult
+}
+
+func newMultiplexer(c vtgateservicepb.RequestMultiplexerClient) *multiplexer {
+	return &multiplexer{
+		c:       c,
+		pending: make(map[uint64]chan multiplexResult),
+	}
+}
+
+// Execute sends the request on the stream, and waits for its response.
+// The deadline of ctx is sent with the request, since the stream
+// outlives it.
+func (mux *multiplexer) Execute(ctx context.Context, request *vtgatepb.ExecuteRequest) (*vtgatepb.ExecuteResponse, error) {
+	multiplexRequest := &vtgatepb.MultiplexRequest{
+		Execute: request,
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout := deadline.Sub(time.Now())
+		if timeout <= 0 {
+			return nil, contextError(context.DeadlineExceeded)
+		}
+		multiplexRequest.Timeout = int64(timeout)
+	}
+	id, result, err := mux.send(multiplexRequest)
+	if err != nil {
+		return nil, err
+	}
+	select {
+	case r := <-result:
+		return r.response, r.err
+	case <-ctx.Done():
+		mux.mu.Lock()
+		delete(mux.pending, id)
+		mux.mu.Unlock()
+		return nil, contextError(ctx.Err())
+	}
+}
+
+// contextError returns the error of a done context, with the error
+// code of the gRPC calls.
+func contextError(err error) error {
+	if err == context.DeadlineExceeded {
+		return vterrors.FromError(vtrpcpb.ErrorCode_DEADLINE_EXCEEDED, err)
+	}
+	return vterrors.FromError(vtrpcpb.ErrorCode_CANCELLED, err)
+}
+
+// send tags the request with a new ID, and sends it on the stream.
+func (mux *multiplexer) send(request *vtgatepb.MultiplexRequest) (uint64, chan multiplexResult, error) {
+	mux.mu.Lock()
+	defer mux.mu.Unlock()
+	if mux.closed {
+		return 0, nil, fmt.Errorf("connection is closed")
+	}
+	if mux.stream == nil {
+		ctx, cancel := context.WithCancel(context.Background())
+		stream, err := mux.c.Multiplex(ctx)
+		if err != nil {
+			cancel()
+			return 0, nil, vterrors.FromGRPCError(err)
+		}
+		mux.stream = stream
+		mux.cancel = cancel
+		go mux.recv(stream)
+	}
+	mux.nextID++
+	request.RequestId = mux.nextID
+	result := make(chan multiplexResult, 1)
+	mux.pending[request.RequestId] = result
+	if err := mux.stream.Send(request); err != nil {
+		delete(mux.pending, request.RequestId)
+		if err == io.EOF {
+			// The stream is broken, recv gets the actual error.
+			return 0, nil, vterrors.FromError(vtrpcpb.ErrorCode_TRANSIENT_ERROR, fmt.Errorf("the stream to vtgate is broken"))
+		}
+		return 0, nil, vterrors.FromGRPCError(err)
+	}
+	return request.RequestId, result, nil
+}
+
+// recv routes the responses of the stream to their callers. When the
+// stream breaks, the pending requests fail with its error.
+func (mux *multiplexer) recv(stream vtgateservicepb.RequestMultiplexer_MultiplexClient) {
+	for {
+		response, err := stream.Recv()
+		if err != nil {
+			mux.mu.Lock()
+			defer mux.mu.Unlock()
+			if mux.stream == stream {
+				mux.stream = nil
+				mux.cancel()
+			}
+			for id, result := range mux.pending {
+				result <- multiplexResult{err: vterrors.FromGRPCError(err)}
+				delete(mux.pending, id)
+			}
+			return
+		}
+		mux.mu.Lock()
+		result, ok := mux.pending[response.RequestId]
+		delete(mux.pending, response.RequestId)
+		mux.mu.Unlock()
+		if !ok {
+			// The caller gave up.
+			continue
+		}
+		if response.Error != nil {
+			result <- multiplexResult{err: vterrors.FromVtRPCError(response.Error)}
+			continue
+		}
+		result <- multiplexResult{response: response.Execute}
+	}
+}
+
+// Close closes the stream. The pending requests fail.
+func (mux *multiplexer) Close() {
+	mux.mu.Lock()
+	defer mux.mu.Unlock()
+	mux.closed = true
+	if mux.stream != nil {
+		mux.cancel()
+	}
+}
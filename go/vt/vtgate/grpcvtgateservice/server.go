@@ -6,6 +6,10 @@
 package grpcvtgateservice
 
 import (
+	"io"
+	"sync"
+	"time"
+
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/peer"
@@ -215,6 +219,7 @@ func (vtg *VTGate) ExecuteBatchKeyspaceIds(ctx context.Context, request *vtgatep
 func (vtg *VTGate) StreamExecute(request *vtgatepb.StreamExecuteRequest, stream vtgateservicepb.Vitess_StreamExecuteServer) (err error) {
 	defer vtg.server.HandlePanic(&err)
 	ctx := withCallerIDContext(stream.Context(), request.CallerId)
+	ctx = vtgate.NewMaxScatterParallelismContext(ctx, request.MaxScatterParallelism)
 	bv, err := querytypes.Proto3ToBindVariables(request.Query.BindVariables)
 	if err != nil {
 		return vterrors.ToGRPCError(err)
@@ -236,6 +241,7 @@ func (vtg *VTGate) StreamExecute(request *vtgatepb.StreamExecuteRequest, stream
 func (vtg *VTGate) StreamExecuteShards(request *vtgatepb.StreamExecuteShardsRequest, stream vtgateservicepb.Vitess_StreamExecuteShardsServer) (err error) {
 	defer vtg.server.HandlePanic(&err)
 	ctx := withCallerIDContext(stream.Context(), request.CallerId)
+	ctx = vtgate.NewMaxScatterParallelismContext(ctx, request.MaxScatterParallelism)
 	bv, err := querytypes.Proto3ToBindVariables(request.Query.BindVariables)
 	if err != nil {
 		return vterrors.ToGRPCError(err)
@@ -259,6 +265,7 @@ func (vtg *VTGate) StreamExecuteShards(request *vtgatepb.StreamExecuteShardsRequ
 func (vtg *VTGate) StreamExecuteKeyspaceIds(request *vtgatepb.StreamExecuteKeyspaceIdsRequest, stream vtgateservicepb.Vitess_StreamExecuteKeyspaceIdsServer) (err error) {
 	defer vtg.server.HandlePanic(&err)
 	ctx := withCallerIDContext(stream.Context(), request.CallerId)
+	ctx = vtgate.NewMaxScatterParallelismContext(ctx, request.MaxScatterParallelism)
 	bv, err := querytypes.Proto3ToBindVariables(request.Query.BindVariables)
 	if err != nil {
 		return vterrors.ToGRPCError(err)
@@ -282,6 +289,7 @@ func (vtg *VTGate) StreamExecuteKeyspaceIds(request *vtgatepb.StreamExecuteKeysp
 func (vtg *VTGate) StreamExecuteKeyRanges(request *vtgatepb.StreamExecuteKeyRangesRequest, stream vtgateservicepb.Vitess_StreamExecuteKeyRangesServer) (err error) {
 	defer vtg.server.HandlePanic(&err)
 	ctx := withCallerIDContext(stream.Context(), request.CallerId)
+	ctx = vtgate.NewMaxScatterParallelismContext(ctx, request.MaxScatterParallelism)
 	bv, err := querytypes.Proto3ToBindVariables(request.Query.BindVariables)
 	if err != nil {
 		return vterrors.ToGRPCError(err)
@@ -318,7 +326,9 @@ func (vtg *VTGate) Commit(ctx context.Context, request *vtgatepb.CommitRequest)
 	defer vtg.server.HandlePanic(&err)
 	ctx = withCallerIDContext(ctx, request.CallerId)
 	vtgErr := vtg.server.Commit(ctx, request.Session)
-	response = &vtgatepb.CommitResponse{}
+	response = &vtgatepb.CommitResponse{
+		Session: request.Session,
+	}
 	if vtgErr == nil {
 		return response, nil
 	}
@@ -365,6 +375,88 @@ func (vtg *VTGate) SplitQuery(ctx context.Context, request *vtgatepb.SplitQueryR
 	}, nil
 }
 
+// Prepare is the RPC version of vtgateservice.VTGateService method
+func (vtg *VTGate) Prepare(ctx context.Context, request *vtgatepb.PrepareRequest) (response *vtgatepb.PrepareResponse, err error) {
+	defer vtg.server.HandlePanic(&err)
+	ctx = withCallerIDContext(ctx, request.CallerId)
+	id, paramCount, fields, err := vtg.server.Prepare(ctx, request.Sql, request.Keyspace, request.TabletType)
+	return &vtgatepb.PrepareResponse{
+		StatementId: id,
+		ParamCount:  paramCount,
+		Fields:      fields,
+		Error:       vterrors.VtRPCErrorFromVtError(err),
+	}, nil
+}
+
+// ExecutePrepared is the RPC version of vtgateservice.VTGateService method
+func (vtg *VTGate) ExecutePrepared(ctx context.Context, request *vtgatepb.ExecutePreparedRequest) (response *vtgatepb.ExecutePreparedResponse, err error) {
+	defer vtg.server.HandlePanic(&err)
+	ctx = withCallerIDContext(ctx, request.CallerId)
+	bv, err := querytypes.Proto3ToBindVariables(request.BindVariables)
+	if err != nil {
+		return nil, vterrors.ToGRPCError(err)
+	}
+	result, err := vtg.server.ExecutePrepared(ctx, request.StatementId, bv, request.Session, request.NotInTransaction)
+	return &vtgatepb.ExecutePreparedResponse{
+		Result:  sqltypes.ResultToProto3(result),
+		Session: request.Session,
+		Error:   vterrors.VtRPCErrorFromVtError(err),
+	}, nil
+}
+
+// ClosePrepared is the RPC version of vtgateservice.VTGateService method
+func (vtg *VTGate) ClosePrepared(ctx context.Context, request *vtgatepb.ClosePreparedRequest) (response *vtgatepb.ClosePreparedResponse, err error) {
+	defer vtg.server.HandlePanic(&err)
+	ctx = withCallerIDContext(ctx, request.CallerId)
+	vtgErr := vtg.server.ClosePrepared(ctx, request.StatementIds)
+	if vtgErr != nil {
+		return nil, vterrors.ToGRPCError(vtgErr)
+	}
+	return &vtgatepb.ClosePreparedResponse{}, nil
+}
+
+// Multiplex is the RPC version of the RequestMultiplexer service: it
+// executes the requests of the stream concurrently with the Execute
+// method, and sends their responses tagged with their request ID.
+func (vtg *VTGate) Multiplex(stream vtgateservicepb.RequestMultiplexer_MultiplexServer) error {
+	ctx := stream.Context()
+	var sendMu sync.Mutex
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for {
+		request, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			response := &vtgatepb.MultiplexResponse{
+				RequestId: request.RequestId,
+			}
+			requestCtx := ctx
+			if request.Timeout > 0 {
+				var cancel context.CancelFunc
+				requestCtx, cancel = context.WithTimeout(ctx, time.Duration(request.Timeout))
+				defer cancel()
+			}
+			execute, err := vtg.Execute(requestCtx, request.Execute)
+			if err != nil {
+				response.Error = vterrors.VtRPCErrorFromVtError(vterrors.FromGRPCError(err))
+			} else {
+				response.Execute = execute
+			}
+			sendMu.Lock()
+			defer sendMu.Unlock()
+			// If the stream is broken, Recv fails too, and ends the loop.
+			stream.Send(response)
+		}()
+	}
+}
+
 // GetSrvKeyspace is the RPC version of vtgateservice.VTGateService method
 func (vtg *VTGate) GetSrvKeyspace(ctx context.Context, request *vtgatepb.GetSrvKeyspaceRequest) (response *vtgatepb.GetSrvKeyspaceResponse, err error) {
 	defer vtg.server.HandlePanic(&err)
@@ -377,10 +469,21 @@ func (vtg *VTGate) GetSrvKeyspace(ctx context.Context, request *vtgatepb.GetSrvK
 	}, nil
 }
 
+// GetKeyspaceSchema is the RPC version of vtgateservice.VTGateService method
+func (vtg *VTGate) GetKeyspaceSchema(ctx context.Context, request *vtgatepb.GetKeyspaceSchemaRequest) (response *vtgatepb.GetKeyspaceSchemaResponse, err error) {
+	defer vtg.server.HandlePanic(&err)
+	response, vtgErr := vtg.server.GetKeyspaceSchema(ctx, request.Keyspace)
+	if vtgErr != nil {
+		return nil, vterrors.ToGRPCError(vtgErr)
+	}
+	return response, nil
+}
+
 func init() {
 	vtgate.RegisterVTGates = append(vtgate.RegisterVTGates, func(vtGate vtgateservice.VTGateService) {
 		if servenv.GRPCCheckServiceMap("vtgateservice") {
 			vtgateservicepb.RegisterVitessServer(servenv.GRPCServer, &VTGate{vtGate})
+			vtgateservicepb.RegisterRequestMultiplexerServer(servenv.GRPCServer, &VTGate{vtGate})
 		}
 	})
 }
@@ -390,4 +493,5 @@ func init() {
 // function does the registration.
 func RegisterForTest(s *grpc.Server, service vtgateservice.VTGateService) {
 	vtgateservicepb.RegisterVitessServer(s, &VTGate{service})
+	vtgateservicepb.RegisterRequestMultiplexerServer(s, &VTGate{service})
 }
@@ -0,0 +1,267 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"reflect"
+	"strings"
+	"time"
+
+	log "github.com/golang/glog"
+	"golang.org/x/net/context"
+)
+
+var (
+	k8sConfigWatch        = flag.Bool("k8s_config_watch", false, "watch the Kubernetes ConfigMap -k8s_config_map_name, and reload the config of vtgate when it changes. The keys of the ConfigMap are flag names; only query_timeout, keyspace_timeout_overrides, query-timeouts, max_dml_shards and max-scatter-parallelism can be reloaded. The flags missing from the ConfigMap keep their command line values.")
+	k8sConfigMapName      = flag.String("k8s_config_map_name", "", "name of the Kubernetes ConfigMap watched by -k8s_config_watch")
+	k8sConfigMapNamespace = flag.String("k8s_config_map_namespace", "", "namespace of -k8s_config_map_name. Defaults to the namespace of the pod.")
+	k8sAPIServer          = flag.String("k8s_api_server", "", "URL of the Kubernetes API server used by -k8s_config_watch. Defaults to the one of the cluster vtgate runs in.")
+)
+
+const (
+	// k8sServiceAccountDir has the credentials Kubernetes gives to the
+	// pods: token, ca.crt and namespace.
+	k8sServiceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+	// k8sWatchTimeout is how long the API server keeps a watch open.
+	k8sWatchTimeout = 5 * time.Minute
+
+	// k8sRetryDelay is how long the watcher waits after an error.
+	k8sRetryDelay = 10 * time.Second
+)
+
+// k8sConfigMap is the part of a Kubernetes ConfigMap that is watched.
+type k8sConfigMap struct {
+	Metadata struct {
+		ResourceVersion string `json:"resourceVersion"`
+	} `json:"metadata"`
+	Data map[string]string `json:"data"`
+}
+
+// k8sWatchEvent is an event of a watch of the Kubernetes API. Object
+// is a ConfigMap, or a Status for an ERROR event.
+type k8sWatchEvent struct {
+	Type   string          `json:"type"`
+	Object json.RawMessage `json:"object"`
+}
+
+// k8sStatus is the error returned by the Kubernetes API.
+type k8sStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// k8sConfigWatcher watches a Kubernetes ConfigMap, and gives its data
+// to reload every time it changes, or nil if the ConfigMap doesn't
+// exist.
+type k8sConfigWatcher struct {
+	client     *http.Client
+	server     string
+	token      string
+	namespace  string
+	name       string
+	retryDelay time.Duration
+	reload     func(source string, config map[string]string) error
+
+	// loaded and config are the last data given to reload.
+	loaded bool
+	config map[string]string
+}
+
+// newK8sConfigWatcherFromFlags returns the k8sConfigWatcher configured
+// by the command line flags. Without -k8s_api_server, it uses the API
+// server and the credentials Kubernetes gives to the pod.
+func newK8sConfigWatcherFromFlags(reload func(source string, config map[string]string) error) (*k8sConfigWatcher, error) {
+	if *k8sConfigMapName == "" {
+		return nil, errors.New("-k8s_config_watch needs -k8s_config_map_name")
+	}
+	server := *k8sAPIServer
+	if server == "" {
+		host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+		if host == "" || port == "" {
+			return nil, errors.New("-k8s_config_watch needs -k8s_api_server outside of a Kubernetes pod")
+		}
+		server = "https://" + net.JoinHostPort(host, port)
+	}
+	namespace := *k8sConfigMapNamespace
+	if namespace == "" {
+		data, err := ioutil.ReadFile(path.Join(k8sServiceAccountDir, "namespace"))
+		if err != nil {
+			return nil, fmt.Errorf("-k8s_config_watch needs -k8s_config_map_namespace outside of a Kubernetes pod: %v", err)
+		}
+		namespace = strings.TrimSpace(string(data))
+	}
+	var token string
+	if data, err := ioutil.ReadFile(path.Join(k8sServiceAccountDir, "token")); err == nil {
+		token = strings.TrimSpace(string(data))
+	}
+	transport := &http.Transport{Proxy: http.ProxyFromEnvironment}
+	if ca, err := ioutil.ReadFile(path.Join(k8sServiceAccountDir, "ca.crt")); err == nil {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, errors.New("cannot parse the CA certificate of the Kubernetes service account")
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+	return newK8sConfigWatcher(&http.Client{Transport: transport}, server, token, namespace, *k8sConfigMapName, reload), nil
+}
+
+// newK8sConfigWatcher creates a k8sConfigWatcher of the ConfigMap
+// namespace/name, through the API server at server. token is the
+// bearer token of the requests, if set.
+func newK8sConfigWatcher(client *http.Client, server, token, namespace, name string, reload func(source string, config map[string]string) error) *k8sConfigWatcher {
+	return &k8sConfigWatcher{
+		client:     client,
+		server:     strings.TrimSuffix(server, "/"),
+		token:      token,
+		namespace:  namespace,
+		name:       name,
+		retryDelay: k8sRetryDelay,
+		reload:     reload,
+	}
+}
+
+// run watches the ConfigMap until ctx is done. It gets the ConfigMap,
+// then watches its changes from the version it got. It starts over
+// when the watch ends, after retryDelay if there was an error.
+func (w *k8sConfigWatcher) run(ctx context.Context) {
+	for {
+		version, err := w.get(ctx)
+		if err == nil {
+			err = w.watch(ctx, version)
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if err == nil {
+			continue
+		}
+		log.Warningf("Watch of the ConfigMap %v/%v failed, retrying in %v: %v", w.namespace, w.name, w.retryDelay, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(w.retryDelay):
+		}
+	}
+}
+
+// get reads the ConfigMap, gives it to reload, and returns its
+// resource version, or "" if it doesn't exist.
+func (w *k8sConfigWatcher) get(ctx context.Context) (string, error) {
+	resp, err := w.do(ctx, fmt.Sprintf("/api/v1/namespaces/%v/configmaps/%v", url.QueryEscape(w.namespace), url.QueryEscape(w.name)))
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		w.apply(nil)
+		return "", nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", k8sError(resp)
+	}
+	var cm k8sConfigMap
+	if err := json.NewDecoder(resp.Body).Decode(&cm); err != nil {
+		return "", fmt.Errorf("cannot decode the ConfigMap: %v", err)
+	}
+	w.apply(cm.Data)
+	return cm.Metadata.ResourceVersion, nil
+}
+
+// watch follows the changes of the ConfigMap after version, until the
+// API server ends the watch.
+func (w *k8sConfigWatcher) watch(ctx context.Context, version string) error {
+	values := url.Values{}
+	values.Set("watch", "true")
+	values.Set("fieldSelector", "metadata.name="+w.name)
+	values.Set("timeoutSeconds", fmt.Sprintf("%d", int(k8sWatchTimeout/time.Second)))
+	if version != "" {
+		values.Set("resourceVersion", version)
+	}
+	resp, err := w.do(ctx, fmt.Sprintf("/api/v1/namespaces/%v/configmaps?%v", url.QueryEscape(w.namespace), values.Encode()))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return k8sError(resp)
+	}
+	decoder := json.NewDecoder(resp.Body)
+	for {
+		var event k8sWatchEvent
+		if err := decoder.Decode(&event); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			// The API server closes the watch at its timeout.
+			return nil
+		}
+		switch event.Type {
+		case "ADDED", "MODIFIED":
+			var cm k8sConfigMap
+			if err := json.Unmarshal(event.Object, &cm); err != nil {
+				return fmt.Errorf("cannot decode the ConfigMap of a %v event: %v", event.Type, err)
+			}
+			w.apply(cm.Data)
+		case "DELETED":
+			w.apply(nil)
+		case "ERROR":
+			var status k8sStatus
+			json.Unmarshal(event.Object, &status)
+			return fmt.Errorf("watch error %v: %v", status.Code, status.Message)
+		}
+	}
+}
+
+// apply gives config to reload if it changed since the last time.
+// The errors are logged: the previous config stays in place until the
+// ConfigMap is fixed.
+func (w *k8sConfigWatcher) apply(config map[string]string) {
+	if config == nil {
+		config = map[string]string{}
+	}
+	if w.loaded && reflect.DeepEqual(config, w.config) {
+		return
+	}
+	w.loaded = true
+	w.config = config
+	source := fmt.Sprintf("ConfigMap %v/%v", w.namespace, w.name)
+	if err := w.reload(source, config); err != nil {
+		log.Errorf("%v", err)
+	}
+}
+
+func (w *k8sConfigWatcher) do(ctx context.Context, path string) (*http.Response, error) {
+	req, err := http.NewRequest("GET", w.server+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Cancel = ctx.Done()
+	if w.token != "" {
+		req.Header.Set("Authorization", "Bearer "+w.token)
+	}
+	return w.client.Do(req)
+}
+
+// k8sError returns the error of a failed request to the Kubernetes API.
+func k8sError(resp *http.Response) error {
+	var status k8sStatus
+	if err := json.NewDecoder(resp.Body).Decode(&status); err != nil || status.Message == "" {
+		return fmt.Errorf("kubernetes API: %v", resp.Status)
+	}
+	return fmt.Errorf("kubernetes API: %v: %v", resp.Status, status.Message)
+}
@@ -0,0 +1,111 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// fakeK8sAPI serves a ConfigMap, and sends the events of events to its
+// watches.
+type fakeK8sAPI struct {
+	t      *testing.T
+	events chan string
+}
+
+func (f *fakeK8sAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if got, want := r.Header.Get("Authorization"), "Bearer secret"; got != want {
+		f.t.Errorf("Authorization: %q, want %q", got, want)
+	}
+	switch r.URL.Path {
+	case "/api/v1/namespaces/vt/configmaps/vtgate":
+		fmt.Fprint(w, `{"metadata": {"name": "vtgate", "resourceVersion": "10"}, "data": {"max_dml_shards": "8"}}`)
+	case "/api/v1/namespaces/vt/configmaps":
+		query := r.URL.Query()
+		if query.Get("watch") != "true" || query.Get("fieldSelector") != "metadata.name=vtgate" || query.Get("resourceVersion") != "10" {
+			f.t.Errorf("watch query: %v", query)
+		}
+		w.(http.Flusher).Flush()
+		closed := w.(http.CloseNotifier).CloseNotify()
+		for {
+			select {
+			case event := <-f.events:
+				fmt.Fprintln(w, event)
+				w.(http.Flusher).Flush()
+			case <-closed:
+				return
+			case <-time.After(5 * time.Second):
+				return
+			}
+		}
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestK8sConfigWatcher(t *testing.T) {
+	api := &fakeK8sAPI{t: t, events: make(chan string)}
+	server := httptest.NewServer(api)
+	defer server.Close()
+
+	configs := make(chan map[string]string, 10)
+	reload := func(source string, config map[string]string) error {
+		if want := "ConfigMap vt/vtgate"; source != want {
+			t.Errorf("source: %v, want %v", source, want)
+		}
+		configs <- config
+		return nil
+	}
+	w := newK8sConfigWatcher(http.DefaultClient, server.URL, "secret", "vt", "vtgate", reload)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		w.run(ctx)
+		close(done)
+	}()
+
+	next := func() map[string]string {
+		select {
+		case config := <-configs:
+			return config
+		case <-time.After(5 * time.Second):
+			t.Fatalf("no reload")
+			return nil
+		}
+	}
+	if got, want := next(), map[string]string{"max_dml_shards": "8"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("first reload: %v, want %v", got, want)
+	}
+
+	// An event that doesn't change the data doesn't reload.
+	api.events <- `{"type": "MODIFIED", "object": {"metadata": {"resourceVersion": "11"}, "data": {"max_dml_shards": "8"}}}`
+	api.events <- `{"type": "MODIFIED", "object": {"metadata": {"resourceVersion": "12"}, "data": {"max_dml_shards": "2", "query_timeout": "5s"}}}`
+	if got, want := next(), map[string]string{"max_dml_shards": "2", "query_timeout": "5s"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("reload after MODIFIED: %v, want %v", got, want)
+	}
+	api.events <- `{"type": "DELETED", "object": {"metadata": {"resourceVersion": "13"}}}`
+	if got := next(); len(got) != 0 {
+		t.Errorf("reload after DELETED: %v, want an empty config", got)
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Errorf("run didn't return after the cancel")
+	}
+	select {
+	case config := <-configs:
+		t.Errorf("unexpected reload: %v", config)
+	default:
+	}
+}
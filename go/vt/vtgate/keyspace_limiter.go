@@ -0,0 +1,319 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/flagutil"
+	"github.com/youtube/vitess/go/sqltypes"
+	"github.com/youtube/vitess/go/stats"
+	"github.com/youtube/vitess/go/sync2"
+	"github.com/youtube/vitess/go/vt/tabletserver/querytypes"
+	"github.com/youtube/vitess/go/vt/vterrors"
+
+	querypb "github.com/youtube/vitess/go/vt/proto/query"
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+	vtrpcpb "github.com/youtube/vitess/go/vt/proto/vtrpc"
+)
+
+var (
+	keyspaceConcurrency          = flag.Int("gateway_keyspace_concurrency", 0, "maximum number of concurrent requests sent to the tablets of a keyspace. Requests above the limit wait for -gateway_keyspace_queue_timeout, then fail. 0 means no limit.")
+	keyspaceConcurrencyOverrides flagutil.StringMapValue
+	keyspaceQueueTimeout         = flag.Duration("gateway_keyspace_queue_timeout", 100*time.Millisecond, "how long a request waits for its keyspace to be under its concurrency limit before it fails")
+)
+
+func init() {
+	flag.Var(&keyspaceConcurrencyOverrides, "gateway_keyspace_concurrency_overrides", "comma-separated list of keyspace:concurrency pairs that override -gateway_keyspace_concurrency for specific keyspaces")
+}
+
+// keyspaceLimiter limits the number of concurrent requests per
+// keyspace, so that a keyspace with slow tablets cannot use up all
+// of vtgate's capacity.
+type keyspaceLimiter struct {
+	defaultLimit int
+	limits       map[string]int
+	timeout      time.Duration
+	rejections   *stats.Counters
+
+	mu        sync.Mutex
+	keyspaces map[string]*keyspaceSlots
+}
+
+// keyspaceSlots tracks the requests in flight for one keyspace.
+// slots is nil if the keyspace has no limit.
+type keyspaceSlots struct {
+	limit    int
+	slots    chan struct{}
+	inFlight sync2.AtomicInt64
+}
+
+// newKeyspaceLimiterFromFlags returns the keyspaceLimiter configured
+// by the command line flags, or nil if no limit is set.
+func newKeyspaceLimiterFromFlags(statsName string) (*keyspaceLimiter, error) {
+	limits := make(map[string]int, len(keyspaceConcurrencyOverrides))
+	for keyspace, value := range keyspaceConcurrencyOverrides {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 0 {
+			return nil, fmt.Errorf("invalid concurrency %q for keyspace %v in -gateway_keyspace_concurrency_overrides", value, keyspace)
+		}
+		limits[keyspace] = limit
+	}
+	if *keyspaceConcurrency == 0 && len(limits) == 0 {
+		return nil, nil
+	}
+	return newKeyspaceLimiter(statsName, *keyspaceConcurrency, limits, *keyspaceQueueTimeout), nil
+}
+
+// newKeyspaceLimiter creates a keyspaceLimiter. A limit of 0 means no
+// limit. If statsName is set, the per-keyspace requests in flight and
+// rejections are published.
+func newKeyspaceLimiter(statsName string, defaultLimit int, limits map[string]int, timeout time.Duration) *keyspaceLimiter {
+	rejectionsName := ""
+	if statsName != "" {
+		rejectionsName = statsName + "KeyspaceRejections"
+	}
+	kl := &keyspaceLimiter{
+		defaultLimit: defaultLimit,
+		limits:       limits,
+		timeout:      timeout,
+		rejections:   stats.NewCounters(rejectionsName),
+		keyspaces:    make(map[string]*keyspaceSlots),
+	}
+	if statsName != "" {
+		stats.Publish(statsName+"KeyspaceInFlight", stats.CountersFunc(kl.inFlight))
+	}
+	return kl
+}
+
+func (kl *keyspaceLimiter) getSlots(keyspace string) *keyspaceSlots {
+	kl.mu.Lock()
+	defer kl.mu.Unlock()
+	ks, ok := kl.keyspaces[keyspace]
+	if ok {
+		return ks
+	}
+	limit, ok := kl.limits[keyspace]
+	if !ok {
+		limit = kl.defaultLimit
+	}
+	ks = &keyspaceSlots{limit: limit}
+	if limit > 0 {
+		ks.slots = make(chan struct{}, limit)
+	}
+	kl.keyspaces[keyspace] = ks
+	return ks
+}
+
+// acquire waits until keyspace is under its limit. It fails if that
+// takes longer than the queue timeout. Every successful acquire must
+// be followed by a release.
+func (kl *keyspaceLimiter) acquire(ctx context.Context, keyspace string) error {
+	ks := kl.getSlots(keyspace)
+	if ks.slots != nil {
+		select {
+		case ks.slots <- struct{}{}:
+		default:
+			tm := time.NewTimer(kl.timeout)
+			defer tm.Stop()
+			select {
+			case ks.slots <- struct{}{}:
+			case <-tm.C:
+				kl.rejections.Add(keyspace, 1)
+				return vterrors.FromError(vtrpcpb.ErrorCode_RESOURCE_EXHAUSTED, fmt.Errorf("keyspace %v is over its limit of %d concurrent requests", keyspace, ks.limit))
+			case <-ctx.Done():
+				return vterrors.FromError(vtrpcpb.ErrorCode_DEADLINE_EXCEEDED, fmt.Errorf("waiting for a request slot for keyspace %v: %v", keyspace, ctx.Err()))
+			}
+		}
+	}
+	ks.inFlight.Add(1)
+	return nil
+}
+
+// release must be called once the request is done.
+func (kl *keyspaceLimiter) release(keyspace string) {
+	ks := kl.getSlots(keyspace)
+	ks.inFlight.Add(-1)
+	if ks.slots != nil {
+		<-ks.slots
+	}
+}
+
+func (kl *keyspaceLimiter) inFlight() map[string]int64 {
+	kl.mu.Lock()
+	defer kl.mu.Unlock()
+	m := make(map[string]int64, len(kl.keyspaces))
+	for keyspace, ks := range kl.keyspaces {
+		m[keyspace] = ks.inFlight.Get()
+	}
+	return m
+}
+
+// KeyspaceLimiterStatus is the concurrency limit and usage of a
+// keyspace, for display on the status page. A Limit of 0 means no limit.
+type KeyspaceLimiterStatus struct {
+	Keyspace   string
+	Limit      int
+	InFlight   int64
+	Rejections int64
+}
+
+// KeyspaceLimiterStatusList is a slice of KeyspaceLimiterStatus.
+// It's sorted by keyspace.
+type KeyspaceLimiterStatusList []*KeyspaceLimiterStatus
+
+// Len is part of sort.Interface.
+func (ksl KeyspaceLimiterStatusList) Len() int {
+	return len(ksl)
+}
+
+// Less is part of sort.Interface.
+func (ksl KeyspaceLimiterStatusList) Less(i, j int) bool {
+	return ksl[i].Keyspace < ksl[j].Keyspace
+}
+
+// Swap is part of sort.Interface.
+func (ksl KeyspaceLimiterStatusList) Swap(i, j int) {
+	ksl[i], ksl[j] = ksl[j], ksl[i]
+}
+
+// status returns the limit and usage of the keyspaces that got requests.
+func (kl *keyspaceLimiter) status() KeyspaceLimiterStatusList {
+	rejections := kl.rejections.Counts()
+	kl.mu.Lock()
+	defer kl.mu.Unlock()
+	list := make(KeyspaceLimiterStatusList, 0, len(kl.keyspaces))
+	for keyspace, ks := range kl.keyspaces {
+		list = append(list, &KeyspaceLimiterStatus{
+			Keyspace:   keyspace,
+			Limit:      ks.limit,
+			InFlight:   ks.inFlight.Get(),
+			Rejections: rejections[keyspace],
+		})
+	}
+	sort.Sort(list)
+	return list
+}
+
+// limitedGateway is a Gateway that applies the per-keyspace limits of
+// a keyspaceLimiter. Commit and Rollback are not limited, so that
+// open transactions can always be finished.
+type limitedGateway struct {
+	Gateway
+	limiter *keyspaceLimiter
+}
+
+// Execute is part of the Gateway interface.
+func (lg *limitedGateway) Execute(ctx context.Context, keyspace, shard string, tabletType topodatapb.TabletType, query string, bindVars map[string]interface{}, transactionID int64) (*sqltypes.Result, error) {
+	if err := lg.limiter.acquire(ctx, keyspace); err != nil {
+		return nil, err
+	}
+	defer lg.limiter.release(keyspace)
+	return lg.Gateway.Execute(ctx, keyspace, shard, tabletType, query, bindVars, transactionID)
+}
+
+// ExecuteBatch is part of the Gateway interface.
+func (lg *limitedGateway) ExecuteBatch(ctx context.Context, keyspace, shard string, tabletType topodatapb.TabletType, queries []querytypes.BoundQuery, asTransaction bool, transactionID int64) ([]sqltypes.Result, error) {
+	if err := lg.limiter.acquire(ctx, keyspace); err != nil {
+		return nil, err
+	}
+	defer lg.limiter.release(keyspace)
+	return lg.Gateway.ExecuteBatch(ctx, keyspace, shard, tabletType, queries, asTransaction, transactionID)
+}
+
+// StreamExecute is part of the Gateway interface. The request counts
+// against the limit until the end of the stream.
+func (lg *limitedGateway) StreamExecute(ctx context.Context, keyspace, shard string, tabletType topodatapb.TabletType, query string, bindVars map[string]interface{}) (sqltypes.ResultStream, error) {
+	if err := lg.limiter.acquire(ctx, keyspace); err != nil {
+		return nil, err
+	}
+	stream, err := lg.Gateway.StreamExecute(ctx, keyspace, shard, tabletType, query, bindVars)
+	if err != nil {
+		lg.limiter.release(keyspace)
+		return nil, err
+	}
+	return &limitedResultStream{
+		ResultStream: stream,
+		release:      func() { lg.limiter.release(keyspace) },
+	}, nil
+}
+
+// Begin is part of the Gateway interface.
+func (lg *limitedGateway) Begin(ctx context.Context, keyspace string, shard string, tabletType topodatapb.TabletType) (int64, error) {
+	if err := lg.limiter.acquire(ctx, keyspace); err != nil {
+		return 0, err
+	}
+	defer lg.limiter.release(keyspace)
+	return lg.Gateway.Begin(ctx, keyspace, shard, tabletType)
+}
+
+// BeginExecute is part of the Gateway interface.
+func (lg *limitedGateway) BeginExecute(ctx context.Context, keyspace, shard string, tabletType topodatapb.TabletType, query string, bindVars map[string]interface{}) (*sqltypes.Result, int64, error) {
+	if err := lg.limiter.acquire(ctx, keyspace); err != nil {
+		return nil, 0, err
+	}
+	defer lg.limiter.release(keyspace)
+	return lg.Gateway.BeginExecute(ctx, keyspace, shard, tabletType, query, bindVars)
+}
+
+// BeginExecuteBatch is part of the Gateway interface.
+func (lg *limitedGateway) BeginExecuteBatch(ctx context.Context, keyspace, shard string, tabletType topodatapb.TabletType, queries []querytypes.BoundQuery, asTransaction bool) ([]sqltypes.Result, int64, error) {
+	if err := lg.limiter.acquire(ctx, keyspace); err != nil {
+		return nil, 0, err
+	}
+	defer lg.limiter.release(keyspace)
+	return lg.Gateway.BeginExecuteBatch(ctx, keyspace, shard, tabletType, queries, asTransaction)
+}
+
+// SplitQuery is part of the Gateway interface.
+func (lg *limitedGateway) SplitQuery(ctx context.Context, keyspace, shard string, tabletType topodatapb.TabletType, sql string, bindVariables map[string]interface{}, splitColumn string, splitCount int64) ([]querytypes.QuerySplit, error) {
+	if err := lg.limiter.acquire(ctx, keyspace); err != nil {
+		return nil, err
+	}
+	defer lg.limiter.release(keyspace)
+	return lg.Gateway.SplitQuery(ctx, keyspace, shard, tabletType, sql, bindVariables, splitColumn, splitCount)
+}
+
+// SplitQueryV2 is part of the Gateway interface.
+func (lg *limitedGateway) SplitQueryV2(
+	ctx context.Context,
+	keyspace,
+	shard string,
+	tabletType topodatapb.TabletType,
+	sql string,
+	bindVariables map[string]interface{},
+	splitColumns []string,
+	splitCount int64,
+	numRowsPerQueryPart int64,
+	algorithm querypb.SplitQueryRequest_Algorithm) ([]querytypes.QuerySplit, error) {
+	if err := lg.limiter.acquire(ctx, keyspace); err != nil {
+		return nil, err
+	}
+	defer lg.limiter.release(keyspace)
+	return lg.Gateway.SplitQueryV2(ctx, keyspace, shard, tabletType, sql, bindVariables, splitColumns, splitCount, numRowsPerQueryPart, algorithm)
+}
+
+// limitedResultStream releases its keyspace slot when the stream ends.
+type limitedResultStream struct {
+	sqltypes.ResultStream
+	release func()
+	once    sync.Once
+}
+
+// Recv is part of the sqltypes.ResultStream interface.
+func (s *limitedResultStream) Recv() (*sqltypes.Result, error) {
+	qr, err := s.ResultStream.Recv()
+	if err != nil {
+		s.once.Do(s.release)
+	}
+	return qr, err
+}
@@ -0,0 +1,140 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"io"
+	"reflect"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/sqltypes"
+	"github.com/youtube/vitess/go/vt/vterrors"
+
+	vtrpcpb "github.com/youtube/vitess/go/vt/proto/vtrpc"
+)
+
+func TestKeyspaceLimiterIsolation(t *testing.T) {
+	kl := newKeyspaceLimiter("", 1, map[string]int{"big": 2, "nolimit": 0}, time.Millisecond)
+	ctx := context.Background()
+
+	if err := kl.acquire(ctx, "slow"); err != nil {
+		t.Fatalf("acquire(slow): %v", err)
+	}
+	// slow is saturated.
+	err := kl.acquire(ctx, "slow")
+	if code := vterrors.RecoverVtErrorCode(err); code != vtrpcpb.ErrorCode_RESOURCE_EXHAUSTED {
+		t.Errorf("acquire(slow) over limit: %v, want RESOURCE_EXHAUSTED", err)
+	}
+	// The other keyspaces are not affected.
+	for _, keyspace := range []string{"other", "big", "big", "nolimit", "nolimit", "nolimit"} {
+		if err := kl.acquire(ctx, keyspace); err != nil {
+			t.Errorf("acquire(%v): %v", keyspace, err)
+		}
+	}
+	if err := kl.acquire(ctx, "big"); err == nil {
+		t.Errorf("acquire(big) over limit: nil, want error")
+	}
+
+	want := KeyspaceLimiterStatusList{
+		{Keyspace: "big", Limit: 2, InFlight: 2, Rejections: 1},
+		{Keyspace: "nolimit", Limit: 0, InFlight: 3},
+		{Keyspace: "other", Limit: 1, InFlight: 1},
+		{Keyspace: "slow", Limit: 1, InFlight: 1, Rejections: 1},
+	}
+	if got := kl.status(); !reflect.DeepEqual(got, want) {
+		t.Errorf("status: %+v, want %+v", got, want)
+	}
+
+	// A slot that is released while waiting is handed over.
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		kl.release("slow")
+	}()
+	kl.timeout = time.Second
+	if err := kl.acquire(ctx, "slow"); err != nil {
+		t.Errorf("acquire(slow) after release: %v", err)
+	}
+}
+
+func TestKeyspaceLimiterContext(t *testing.T) {
+	kl := newKeyspaceLimiter("", 1, nil, time.Minute)
+	if err := kl.acquire(context.Background(), "ks"); err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	err := kl.acquire(ctx, "ks")
+	if code := vterrors.RecoverVtErrorCode(err); code != vtrpcpb.ErrorCode_DEADLINE_EXCEEDED {
+		t.Errorf("acquire with expired context: %v, want DEADLINE_EXCEEDED", err)
+	}
+}
+
+type fakeResultStream struct {
+	results []*sqltypes.Result
+}
+
+func (s *fakeResultStream) Recv() (*sqltypes.Result, error) {
+	if len(s.results) == 0 {
+		return nil, io.EOF
+	}
+	qr := s.results[0]
+	s.results = s.results[1:]
+	return qr, nil
+}
+
+func TestLimitedResultStream(t *testing.T) {
+	kl := newKeyspaceLimiter("", 1, nil, 0)
+	if err := kl.acquire(context.Background(), "ks"); err != nil {
+		t.Fatalf("acquire: %v", err)
+	}
+	stream := &limitedResultStream{
+		ResultStream: &fakeResultStream{results: []*sqltypes.Result{{}}},
+		release:      func() { kl.release("ks") },
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatalf("Recv: %v", err)
+	}
+	if got := kl.inFlight()["ks"]; got != 1 {
+		t.Errorf("in flight during the stream: %v, want 1", got)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := stream.Recv(); err != io.EOF {
+			t.Errorf("Recv: %v, want EOF", err)
+		}
+	}
+	if got := kl.inFlight()["ks"]; got != 0 {
+		t.Errorf("in flight after the stream: %v, want 0", got)
+	}
+}
+
+func TestNewKeyspaceLimiterFromFlags(t *testing.T) {
+	defer func(v int, overrides map[string]string) {
+		*keyspaceConcurrency = v
+		keyspaceConcurrencyOverrides = overrides
+	}(*keyspaceConcurrency, keyspaceConcurrencyOverrides)
+
+	kl, err := newKeyspaceLimiterFromFlags("")
+	if kl != nil || err != nil {
+		t.Errorf("newKeyspaceLimiterFromFlags without limits: %v, %v, want nil, nil", kl, err)
+	}
+
+	*keyspaceConcurrency = 10
+	keyspaceConcurrencyOverrides = map[string]string{"ks": "3"}
+	kl, err = newKeyspaceLimiterFromFlags("")
+	if err != nil {
+		t.Fatalf("newKeyspaceLimiterFromFlags: %v", err)
+	}
+	if kl.defaultLimit != 10 || kl.limits["ks"] != 3 {
+		t.Errorf("limits: %v, %v, want 10, map[ks:3]", kl.defaultLimit, kl.limits)
+	}
+
+	keyspaceConcurrencyOverrides = map[string]string{"ks": "many"}
+	if _, err := newKeyspaceLimiterFromFlags(""); err == nil {
+		t.Errorf("newKeyspaceLimiterFromFlags with invalid override: nil, want error")
+	}
+}
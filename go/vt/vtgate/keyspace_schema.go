@@ -0,0 +1,314 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+// This file implements GetKeyspaceSchema: it reads the schema of the
+// master of each shard of a keyspace, and reports the differences
+// between the shards.
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/vt/concurrency"
+	"github.com/youtube/vitess/go/vt/tabletmanager/tmclient"
+	"github.com/youtube/vitess/go/vt/topo"
+	"github.com/youtube/vitess/go/vt/topo/topoproto"
+
+	tabletmanagerdatapb "github.com/youtube/vitess/go/vt/proto/tabletmanagerdata"
+	vtgatepb "github.com/youtube/vitess/go/vt/proto/vtgate"
+)
+
+var keyspaceSchemaCacheTTL = flag.Duration("keyspace_schema_cache_ttl", 30*time.Second, "how long GetKeyspaceSchema caches the schema of a keyspace. The DDLs executed through this vtgate clear the cache of their keyspace, the other schema changes are only seen after this long.")
+
+// keyspaceSchemaCache fetches the schemas of the keyspaces for
+// GetKeyspaceSchema, and caches them for ttl.
+type keyspaceSchemaCache struct {
+	ts  topo.Server
+	ttl time.Duration
+	// newTMC is tmclient.NewTabletManagerClient, except in the tests.
+	// It's only called by the first fetch, so that vtgate doesn't need
+	// a tabletmanager client if GetKeyspaceSchema is never called.
+	newTMC func() tmclient.TabletManagerClient
+	now    func() time.Time
+
+	// mu protects the following fields.
+	mu  sync.Mutex
+	tmc tmclient.TabletManagerClient
+	// entries are the cached schemas, by keyspace.
+	entries map[string]*keyspaceSchemaEntry
+	// generations are increased by invalidate, so that a fetch that
+	// started before a DDL doesn't cache its result.
+	generations map[string]int64
+}
+
+type keyspaceSchemaEntry struct {
+	response *vtgatepb.GetKeyspaceSchemaResponse
+	expires  time.Time
+}
+
+func newKeyspaceSchemaCache(ts topo.Server, ttl time.Duration) *keyspaceSchemaCache {
+	return &keyspaceSchemaCache{
+		ts:          ts,
+		ttl:         ttl,
+		newTMC:      tmclient.NewTabletManagerClient,
+		now:         time.Now,
+		entries:     make(map[string]*keyspaceSchemaEntry),
+		generations: make(map[string]int64),
+	}
+}
+
+// Get returns the schema of the keyspace, from the cache if it's
+// recent enough.
+func (c *keyspaceSchemaCache) Get(ctx context.Context, keyspace string) (*vtgatepb.GetKeyspaceSchemaResponse, error) {
+	c.mu.Lock()
+	if entry, ok := c.entries[keyspace]; ok && c.now().Before(entry.expires) {
+		c.mu.Unlock()
+		return entry.response, nil
+	}
+	generation := c.generations[keyspace]
+	if c.tmc == nil {
+		c.tmc = c.newTMC()
+	}
+	tmc := c.tmc
+	c.mu.Unlock()
+
+	response, err := fetchKeyspaceSchema(ctx, c.ts, tmc, keyspace)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.ttl > 0 && c.generations[keyspace] == generation {
+		c.entries[keyspace] = &keyspaceSchemaEntry{
+			response: response,
+			expires:  c.now().Add(c.ttl),
+		}
+	}
+	return response, nil
+}
+
+// invalidate clears the cached schema of the keyspace.
+func (c *keyspaceSchemaCache) invalidate(keyspace string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, keyspace)
+	c.generations[keyspace]++
+}
+
+// invalidateIfDDL clears the cached schema of the keyspace if sql is
+// a DDL.
+func (c *keyspaceSchemaCache) invalidateIfDDL(keyspace, sql string) {
+	if isDDL(sql) {
+		c.invalidate(keyspace)
+	}
+}
+
+// isDDL returns true if sql is a CREATE, ALTER, DROP, RENAME or
+// TRUNCATE statement.
+func isDDL(sql string) bool {
+	sql = strings.TrimSpace(sql)
+	if i := strings.IndexAny(sql, " \t\n"); i >= 0 {
+		sql = sql[:i]
+	}
+	switch strings.ToLower(sql) {
+	case "create", "alter", "drop", "rename", "truncate":
+		return true
+	}
+	return false
+}
+
+// fetchKeyspaceSchema reads the schema of the master of each shard of
+// the keyspace, and compares them to the schema of the first shard.
+func fetchKeyspaceSchema(ctx context.Context, ts topo.Server, tmc tmclient.TabletManagerClient, keyspace string) (*vtgatepb.GetKeyspaceSchemaResponse, error) {
+	shards, err := ts.GetShardNames(ctx, keyspace)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the shards of keyspace %v: %v", keyspace, err)
+	}
+	if len(shards) == 0 {
+		return nil, fmt.Errorf("keyspace %v has no shards", keyspace)
+	}
+	sort.Strings(shards)
+
+	schemas := make([]*tabletmanagerdatapb.SchemaDefinition, len(shards))
+	wg := sync.WaitGroup{}
+	allErrors := new(concurrency.AllErrorRecorder)
+	for i, shard := range shards {
+		wg.Add(1)
+		go func(i int, shard string) {
+			defer wg.Done()
+			sd, err := fetchShardSchema(ctx, ts, tmc, keyspace, shard)
+			if err != nil {
+				allErrors.RecordError(err)
+				return
+			}
+			schemas[i] = sd
+		}(i, shard)
+	}
+	wg.Wait()
+	if allErrors.HasErrors() {
+		return nil, allErrors.Error()
+	}
+
+	response := &vtgatepb.GetKeyspaceSchemaResponse{
+		ReferenceShard: shards[0],
+		Schema:         schemas[0],
+	}
+	for i := 1; i < len(shards); i++ {
+		response.Drifts = append(response.Drifts, diffSchemas(schemas[0], shards[i], schemas[i])...)
+	}
+	return response, nil
+}
+
+// fetchShardSchema returns the schema of the master of the shard.
+func fetchShardSchema(ctx context.Context, ts topo.Server, tmc tmclient.TabletManagerClient, keyspace, shard string) (*tabletmanagerdatapb.SchemaDefinition, error) {
+	si, err := ts.GetShard(ctx, keyspace, shard)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the shard %v: %v", topoproto.KeyspaceShardString(keyspace, shard), err)
+	}
+	if !si.HasMaster() {
+		return nil, fmt.Errorf("the shard %v has no master", topoproto.KeyspaceShardString(keyspace, shard))
+	}
+	ti, err := ts.GetTablet(ctx, si.MasterAlias)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the master tablet %v: %v", topoproto.TabletAliasString(si.MasterAlias), err)
+	}
+	sd, err := tmc.GetSchema(ctx, ti, nil, nil, true)
+	if err != nil {
+		return nil, fmt.Errorf("GetSchema on the master tablet %v of %v failed: %v", topoproto.TabletAliasString(si.MasterAlias), topoproto.KeyspaceShardString(keyspace, shard), err)
+	}
+	return sd, nil
+}
+
+// diffSchemas returns the differences between the schema of shard and
+// the reference schema.
+func diffSchemas(reference *tabletmanagerdatapb.SchemaDefinition, shard string, sd *tabletmanagerdatapb.SchemaDefinition) []*vtgatepb.SchemaDrift {
+	var drifts []*vtgatepb.SchemaDrift
+	if reference.DatabaseSchema != sd.DatabaseSchema {
+		drifts = append(drifts, &vtgatepb.SchemaDrift{
+			Shard:       shard,
+			Description: "the database definitions differ",
+		})
+	}
+
+	tables := make(map[string]*tabletmanagerdatapb.TableDefinition)
+	var names []string
+	for _, td := range sd.TableDefinitions {
+		tables[td.Name] = td
+		names = append(names, td.Name)
+	}
+	referenceTables := make(map[string]*tabletmanagerdatapb.TableDefinition)
+	for _, td := range reference.TableDefinitions {
+		referenceTables[td.Name] = td
+		if _, ok := tables[td.Name]; !ok {
+			names = append(names, td.Name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		td, ok := tables[name]
+		if !ok {
+			drifts = append(drifts, &vtgatepb.SchemaDrift{
+				Shard:       shard,
+				Table:       name,
+				Description: "the table is missing",
+			})
+			continue
+		}
+		referenceTD, ok := referenceTables[name]
+		if !ok {
+			drifts = append(drifts, &vtgatepb.SchemaDrift{
+				Shard:       shard,
+				Table:       name,
+				Description: "the table only exists on this shard",
+			})
+			continue
+		}
+		if drift := diffTables(referenceTD, td); drift != nil {
+			drift.Shard = shard
+			drift.Table = name
+			drifts = append(drifts, drift)
+		}
+	}
+	return drifts
+}
+
+// diffTables returns the difference between the definitions of the
+// table, or nil if they agree.
+func diffTables(reference, td *tabletmanagerdatapb.TableDefinition) *vtgatepb.SchemaDrift {
+	if reference.Type != td.Type {
+		return &vtgatepb.SchemaDrift{
+			Description: fmt.Sprintf("the table is a %v, not a %v", td.Type, reference.Type),
+		}
+	}
+	if reference.Schema == td.Schema {
+		return nil
+	}
+
+	// The columns are named by their definition lines in the CREATE
+	// TABLE statements, e.g. "`id` bigint(20) NOT NULL,".
+	referenceColumns := columnDefinitions(reference.Schema)
+	columns := columnDefinitions(td.Schema)
+	var missing, extra, changed []string
+	for _, name := range reference.Columns {
+		definition, ok := columns[name]
+		switch {
+		case !ok:
+			missing = append(missing, name)
+		case definition != referenceColumns[name]:
+			changed = append(changed, name)
+		}
+	}
+	for _, name := range td.Columns {
+		if _, ok := referenceColumns[name]; !ok {
+			extra = append(extra, name)
+		}
+	}
+	var descriptions []string
+	if len(missing) != 0 {
+		descriptions = append(descriptions, "missing columns: "+strings.Join(missing, ", "))
+	}
+	if len(extra) != 0 {
+		descriptions = append(descriptions, "extra columns: "+strings.Join(extra, ", "))
+	}
+	if len(changed) != 0 {
+		descriptions = append(descriptions, "different columns: "+strings.Join(changed, ", "))
+	}
+	if len(descriptions) == 0 {
+		descriptions = append(descriptions, "the table definitions differ")
+	}
+	differing := append(append(missing, extra...), changed...)
+	sort.Strings(differing)
+	return &vtgatepb.SchemaDrift{
+		Columns:     differing,
+		Description: strings.Join(descriptions, "; "),
+	}
+}
+
+// columnDefinitions returns the definitions of the columns in a
+// CREATE TABLE statement, by column name.
+func columnDefinitions(schema string) map[string]string {
+	definitions := make(map[string]string)
+	for _, line := range strings.Split(schema, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "`") {
+			continue
+		}
+		end := strings.Index(line[1:], "`")
+		if end < 0 {
+			continue
+		}
+		definitions[line[1:end+1]] = strings.TrimSuffix(line, ",")
+	}
+	return definitions
+}
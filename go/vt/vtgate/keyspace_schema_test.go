@@ -0,0 +1,169 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/vt/tabletmanager/tmclient"
+	"github.com/youtube/vitess/go/vt/topo"
+	"github.com/youtube/vitess/go/vt/topo/test/faketopo"
+
+	tabletmanagerdatapb "github.com/youtube/vitess/go/vt/proto/tabletmanagerdata"
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+	vtgatepb "github.com/youtube/vitess/go/vt/proto/vtgate"
+)
+
+// schemaTopo has the shards -80 and 80- in ks, with the masters 1 and 2.
+type schemaTopo struct {
+	faketopo.FakeTopo
+}
+
+func (st schemaTopo) GetShardNames(ctx context.Context, keyspace string) ([]string, error) {
+	return []string{"80-", "-80"}, nil
+}
+
+func (st schemaTopo) GetShard(ctx context.Context, keyspace, shard string) (*topodatapb.Shard, int64, error) {
+	uid := uint32(1)
+	if shard == "80-" {
+		uid = 2
+	}
+	return &topodatapb.Shard{MasterAlias: &topodatapb.TabletAlias{Cell: "cell1", Uid: uid}}, 1, nil
+}
+
+func (st schemaTopo) GetTablet(ctx context.Context, alias *topodatapb.TabletAlias) (*topodatapb.Tablet, int64, error) {
+	return &topodatapb.Tablet{Alias: alias}, 1, nil
+}
+
+// schemaTMC returns the schemas of the masters, by uid. GetSchema is
+// called from a go routine per shard, so mu guards schemas and calls.
+type schemaTMC struct {
+	tmclient.TabletManagerClient
+	mu      sync.Mutex
+	schemas map[uint32]*tabletmanagerdatapb.SchemaDefinition
+	calls   int
+}
+
+func (tmc *schemaTMC) GetSchema(ctx context.Context, tablet *topo.TabletInfo, tables, excludeTables []string, includeViews bool) (*tabletmanagerdatapb.SchemaDefinition, error) {
+	tmc.mu.Lock()
+	defer tmc.mu.Unlock()
+	tmc.calls++
+	return tmc.schemas[tablet.Alias.Uid], nil
+}
+
+func (tmc *schemaTMC) setSchema(uid uint32, sd *tabletmanagerdatapb.SchemaDefinition) {
+	tmc.mu.Lock()
+	defer tmc.mu.Unlock()
+	tmc.schemas[uid] = sd
+}
+
+func (tmc *schemaTMC) numCalls() int {
+	tmc.mu.Lock()
+	defer tmc.mu.Unlock()
+	return tmc.calls
+}
+
+func TestGetKeyspaceSchema(t *testing.T) {
+	reference := &tabletmanagerdatapb.SchemaDefinition{
+		TableDefinitions: []*tabletmanagerdatapb.TableDefinition{{
+			Name:    "a",
+			Schema:  "CREATE TABLE `a` (\n  `id` bigint(20) NOT NULL,\n  `name` varchar(10),\n  PRIMARY KEY (`id`)\n)",
+			Columns: []string{"id", "name"},
+			Type:    "BASE TABLE",
+		}, {
+			Name:    "b",
+			Schema:  "CREATE TABLE `b` (\n  `id` bigint(20) NOT NULL\n)",
+			Columns: []string{"id"},
+			Type:    "BASE TABLE",
+		}},
+	}
+	tmc := &schemaTMC{schemas: map[uint32]*tabletmanagerdatapb.SchemaDefinition{
+		1: reference,
+		2: reference,
+	}}
+	now := time.Now()
+	c := newKeyspaceSchemaCache(topo.Server{Impl: schemaTopo{}}, time.Minute)
+	c.newTMC = func() tmclient.TabletManagerClient { return tmc }
+	c.now = func() time.Time { return now }
+
+	got, err := c.Get(context.Background(), "ks")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	want := &vtgatepb.GetKeyspaceSchemaResponse{
+		ReferenceShard: "-80",
+		Schema:         reference,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Get: %v, want %v", got, want)
+	}
+
+	// The schema of 80- changes: a has a different and an extra
+	// column, and b is missing.
+	tmc.setSchema(2, &tabletmanagerdatapb.SchemaDefinition{
+		TableDefinitions: []*tabletmanagerdatapb.TableDefinition{{
+			Name:    "a",
+			Schema:  "CREATE TABLE `a` (\n  `id` bigint(20) NOT NULL,\n  `name` varchar(20),\n  `extra` int,\n  PRIMARY KEY (`id`)\n)",
+			Columns: []string{"id", "name", "extra"},
+			Type:    "BASE TABLE",
+		}},
+	})
+	if _, err := c.Get(context.Background(), "ks"); err != nil || tmc.numCalls() != 2 {
+		t.Errorf("Get before the TTL: %v, %v calls, want the cached schema", err, tmc.numCalls())
+	}
+
+	// A DDL clears the cache.
+	c.invalidateIfDDL("ks", "alter table a add column extra int")
+	got, err = c.Get(context.Background(), "ks")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	want.Drifts = []*vtgatepb.SchemaDrift{{
+		Shard:       "80-",
+		Table:       "a",
+		Columns:     []string{"extra", "name"},
+		Description: "extra columns: extra; different columns: name",
+	}, {
+		Shard:       "80-",
+		Table:       "b",
+		Description: "the table is missing",
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Get after a DDL: %v, want %v", got, want)
+	}
+
+	// So does the TTL.
+	tmc.setSchema(2, reference)
+	now = now.Add(time.Minute)
+	got, err = c.Get(context.Background(), "ks")
+	if err != nil || len(got.Drifts) != 0 {
+		t.Errorf("Get after the TTL: %v, %v, want no drift", got, err)
+	}
+}
+
+func TestIsDDL(t *testing.T) {
+	testcases := []struct {
+		sql  string
+		want bool
+	}{
+		{"create table a(id int)", true},
+		{" ALTER TABLE a add column b int", true},
+		{"drop\ttable a", true},
+		{"rename table a to b", true},
+		{"truncate a", true},
+		{"select * from create_table", false},
+		{"insert into a values (1)", false},
+	}
+	for _, tc := range testcases {
+		if got := isDDL(tc.sql); got != tc.want {
+			t.Errorf("isDDL(%q): %v, want %v", tc.sql, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/clock"
+)
+
+var (
+	queryTimeout             = flag.Duration("query_timeout", 0, "timeout of the queries vtgate serves, on top of the deadline of the caller. 0 means no timeout.")
+	keyspaceTimeoutOverrides = flag.String("keyspace_timeout_overrides", "", "JSON map of keyspace to query timeout that overrides -query_timeout for the queries sent to these keyspaces, e.g. {\"analytics_ks\": \"300s\", \"oltp_ks\": \"5s\"}")
+
+	// keyspaceTimeouts is parsed from keyspaceTimeoutOverrides by Init.
+	keyspaceTimeouts map[string]time.Duration
+)
+
+// parseKeyspaceTimeouts parses the value of -keyspace_timeout_overrides.
+func parseKeyspaceTimeouts(value string) (map[string]time.Duration, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var overrides map[string]string
+	if err := json.Unmarshal([]byte(value), &overrides); err != nil {
+		return nil, fmt.Errorf("cannot parse keyspace timeout overrides: %v", err)
+	}
+	timeouts := make(map[string]time.Duration, len(overrides))
+	for keyspace, v := range overrides {
+		timeout, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout for keyspace %v: %v", keyspace, err)
+		}
+		timeouts[keyspace] = timeout
+	}
+	return timeouts, nil
+}
+
+// keyspaceTimeout returns the timeout of the queries sent to
+// keyspace, and the rule it comes from. The rule is "" if there's no
+// timeout. The override of /debug/set_query_timeout_override comes
+// first.
+func keyspaceTimeout(keyspace string) (time.Duration, string) {
+	if timeout, rule, ok := timeoutOverride.get(); ok {
+		return timeout, rule
+	}
+	configMu.RLock()
+	defer configMu.RUnlock()
+	if timeout, ok := keyspaceTimeouts[keyspace]; ok {
+		return timeout, fmt.Sprintf("keyspace_timeout_overrides[%v]=%v", keyspace, timeout)
+	}
+	if *queryTimeout != 0 {
+		return *queryTimeout, fmt.Sprintf("query_timeout=%v", *queryTimeout)
+	}
+	return 0, ""
+}
+
+// keyspacesTimeout returns the timeout of a query sent to several
+// keyspaces, and the rule it comes from: the longest timeout of the
+// keyspaces, no timeout being the longest.
+func keyspacesTimeout(keyspaces []string) (time.Duration, string) {
+	if len(keyspaces) == 0 {
+		return keyspaceTimeout("")
+	}
+	var timeout time.Duration
+	var rule string
+	for i, keyspace := range keyspaces {
+		t, r := keyspaceTimeout(keyspace)
+		if t == 0 {
+			return 0, r
+		}
+		if i == 0 || t > timeout {
+			timeout, rule = t, r
+		}
+	}
+	return timeout, rule
+}
+
+// withKeyspaceTimeout returns a context with the timeout of the queries
+// sent to keyspace, and the rule the timeout comes from. The caller
+// must call the returned cancel function when the query is done.
+// The timeout, measured by c, cannot extend the deadline of ctx.
+func withKeyspaceTimeout(ctx context.Context, c clock.Clock, keyspace string) (context.Context, context.CancelFunc, string) {
+	return withKeyspacesTimeout(ctx, c, []string{keyspace})
+}
+
+// withKeyspacesTimeout is like withKeyspaceTimeout, for a query sent
+// to several keyspaces, like a batch. See keyspacesTimeout.
+func withKeyspacesTimeout(ctx context.Context, c clock.Clock, keyspaces []string) (context.Context, context.CancelFunc, string) {
+	timeout, rule := keyspacesTimeout(keyspaces)
+	if timeout == 0 {
+		return ctx, func() {}, rule
+	}
+	ctx, cancel := clock.WithTimeout(ctx, c, timeout)
+	return ctx, cancel, rule
+}
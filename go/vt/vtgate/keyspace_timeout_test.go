@@ -0,0 +1,130 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/clock"
+
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+)
+
+func TestParseKeyspaceTimeouts(t *testing.T) {
+	got, err := parseKeyspaceTimeouts(`{"analytics_ks": "300s", "oltp_ks": "5s"}`)
+	if err != nil {
+		t.Fatalf("parseKeyspaceTimeouts: %v", err)
+	}
+	want := map[string]time.Duration{"analytics_ks": 300 * time.Second, "oltp_ks": 5 * time.Second}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseKeyspaceTimeouts: %v, want %v", got, want)
+	}
+
+	if got, err := parseKeyspaceTimeouts(""); got != nil || err != nil {
+		t.Errorf("parseKeyspaceTimeouts(\"\"): %v, %v, want nil, nil", got, err)
+	}
+	for _, value := range []string{`{"ks": 5}`, `{"ks": "soon"}`, `not json`} {
+		if _, err := parseKeyspaceTimeouts(value); err == nil {
+			t.Errorf("parseKeyspaceTimeouts(%v): nil, want error", value)
+		}
+	}
+}
+
+func TestWithKeyspaceTimeout(t *testing.T) {
+	defer func(timeout time.Duration, timeouts map[string]time.Duration) {
+		*queryTimeout = timeout
+		keyspaceTimeouts = timeouts
+	}(*queryTimeout, keyspaceTimeouts)
+
+	*queryTimeout = 0
+	keyspaceTimeouts = nil
+	ctx, cancel, rule := withKeyspaceTimeout(context.Background(), clock.Real, "ks")
+	cancel()
+	if _, ok := ctx.Deadline(); ok || rule != "" {
+		t.Errorf("no timeout: deadline %v, rule %q, want no deadline and no rule", ok, rule)
+	}
+
+	*queryTimeout = time.Second
+	keyspaceTimeouts = map[string]time.Duration{"analytics_ks": time.Hour}
+	testcases := []struct {
+		keyspace string
+		timeout  time.Duration
+		rule     string
+	}{{
+		keyspace: "analytics_ks",
+		timeout:  time.Hour,
+		rule:     "keyspace_timeout_overrides[analytics_ks]=1h0m0s",
+	}, {
+		keyspace: "oltp_ks",
+		timeout:  time.Second,
+		rule:     "query_timeout=1s",
+	}}
+	for _, tcase := range testcases {
+		start := time.Now()
+		ctx, cancel, rule := withKeyspaceTimeout(context.Background(), clock.Real, tcase.keyspace)
+		deadline, ok := ctx.Deadline()
+		cancel()
+		if !ok || deadline.Sub(start) < tcase.timeout || deadline.Sub(start) > tcase.timeout+time.Minute {
+			t.Errorf("%v: deadline in %v, want %v", tcase.keyspace, deadline.Sub(start), tcase.timeout)
+		}
+		if rule != tcase.rule {
+			t.Errorf("%v: rule %q, want %q", tcase.keyspace, rule, tcase.rule)
+		}
+	}
+}
+
+func TestKeyspacesTimeout(t *testing.T) {
+	defer func(timeout time.Duration, timeouts map[string]time.Duration) {
+		*queryTimeout = timeout
+		keyspaceTimeouts = timeouts
+	}(*queryTimeout, keyspaceTimeouts)
+
+	*queryTimeout = time.Second
+	keyspaceTimeouts = map[string]time.Duration{"analytics_ks": time.Hour, "unlimited_ks": 0}
+	testcases := []struct {
+		keyspaces []string
+		timeout   time.Duration
+		rule      string
+	}{{
+		keyspaces: nil,
+		timeout:   time.Second,
+		rule:      "query_timeout=1s",
+	}, {
+		keyspaces: []string{"oltp_ks", "analytics_ks"},
+		timeout:   time.Hour,
+		rule:      "keyspace_timeout_overrides[analytics_ks]=1h0m0s",
+	}, {
+		keyspaces: []string{"analytics_ks", "unlimited_ks", "oltp_ks"},
+		timeout:   0,
+		rule:      "keyspace_timeout_overrides[unlimited_ks]=0s",
+	}}
+	for _, tcase := range testcases {
+		timeout, rule := keyspacesTimeout(tcase.keyspaces)
+		if timeout != tcase.timeout || rule != tcase.rule {
+			t.Errorf("keyspacesTimeout(%v): %v, %q, want %v, %q", tcase.keyspaces, timeout, rule, tcase.timeout, tcase.rule)
+		}
+	}
+}
+
+func TestVTGateTimeoutOverrideRule(t *testing.T) {
+	defer func(timeout time.Duration) { *queryTimeout = timeout }(*queryTimeout)
+	*queryTimeout = time.Hour
+	sandbox := createSandbox("TestVTGateTimeoutOverrideRule")
+	sandbox.MapTestConn("0", &sandboxConn{})
+
+	ch := QueryLogger.Subscribe("TestVTGateTimeoutOverrideRule")
+	defer QueryLogger.Unsubscribe(ch)
+	if _, err := rpcVTGate.ExecuteShards(context.Background(), "select id from t", nil, "TestVTGateTimeoutOverrideRule", []string{"0"}, topodatapb.TabletType_REPLICA, nil, false); err != nil {
+		t.Fatal(err)
+	}
+	logStats := (<-ch).(*LogStats)
+	if want := "query_timeout=1h0m0s"; logStats.TimeoutOverrideRule != want {
+		t.Errorf("TimeoutOverrideRule: %q, want %q", logStats.TimeoutOverrideRule, want)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"flag"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/youtube/vitess/go/sqltypes"
+	"github.com/youtube/vitess/go/vt/sqlparser"
+	"github.com/youtube/vitess/go/vt/vtgate/engine"
+)
+
+var maxUnboundedQueryRows = flag.Int("max_unbounded_query_rows", 0, "if set, vtgate adds a LIMIT to the non-streaming SELECTs that have none, and truncates their results to this many rows, with a warning. The SELECTs with a /*+ streaming */ hint, the locking reads and the V3 joins are not limited. 0 disables it.")
+
+var streamingHintRE = regexp.MustCompile(`/\*\+\s*streaming\s*\*/`)
+
+// unboundedQueryRows returns the number of rows the result of sql is
+// truncated to if it's a SELECT without LIMIT, or 0 if it's never
+// truncated.
+func unboundedQueryRows(sql string) int {
+	if *maxUnboundedQueryRows <= 0 || streamingHintRE.MatchString(sql) {
+		return 0
+	}
+	return *maxUnboundedQueryRows
+}
+
+// injectLimit returns sql with a LIMIT of maxRows+1 rows, and true, if
+// it's a SELECT without LIMIT. The extra row tells whether the result
+// is truncated. The queries vtgate can't parse, and the locking reads,
+// are returned unchanged.
+func injectLimit(sql string, maxRows int) (string, bool) {
+	stmt, err := sqlparser.Parse(sql)
+	if err != nil {
+		return sql, false
+	}
+	sel, ok := stmt.(*sqlparser.Select)
+	if !ok || sel.Limit != nil || sel.Lock != "" {
+		return sql, false
+	}
+	sel.Limit = &sqlparser.Limit{Rowcount: sqlparser.NumVal(strconv.Itoa(maxRows + 1))}
+	return sqlparser.String(sel), true
+}
+
+// limitRoute returns a copy of route with a LIMIT of maxRows+1 rows,
+// and true, if it's a SELECT without LIMIT.
+func limitRoute(route *engine.Route, maxRows int) (*engine.Route, bool) {
+	switch route.Opcode {
+	case engine.SelectUnsharded, engine.SelectEqual, engine.SelectEqualUnique, engine.SelectIN, engine.SelectScatter:
+	default:
+		return route, false
+	}
+	query, ok := injectLimit(route.Query, maxRows)
+	if !ok {
+		return route, false
+	}
+	limited := *route
+	limited.Query = query
+	return &limited, true
+}
+
+// limitResult returns qr truncated to maxRows rows, with a warning, if
+// it has more.
+func limitResult(qr *sqltypes.Result, maxRows int) *sqltypes.Result {
+	if len(qr.Rows) <= maxRows {
+		return qr
+	}
+	truncated := *qr
+	truncated.Rows = qr.Rows[:maxRows]
+	truncated.RowsAffected = uint64(maxRows)
+	truncated.Warnings = append(append([]string(nil), qr.Warnings...), fmt.Sprintf("the result was truncated to %v rows by -max_unbounded_query_rows, the query needs a LIMIT or a /*+ streaming */ hint", maxRows))
+	return &truncated
+}
@@ -0,0 +1,176 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"reflect"
+	"testing"
+
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/sqltypes"
+	"github.com/youtube/vitess/go/vt/tabletserver/querytypes"
+
+	querypb "github.com/youtube/vitess/go/vt/proto/query"
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+)
+
+func TestInjectLimit(t *testing.T) {
+	testcases := []struct {
+		sql  string
+		want string
+	}{
+		{"select a from t", "select a from t limit 11"},
+		{"select /* comment */ a from t where b = :b order by a", "select /* comment */ a from t where b = :b order by a asc limit 11"},
+		{"select a from t limit 5", ""},
+		{"select a from t limit 5, 20", ""},
+		{"select a from t for update", ""},
+		{"select a from t union select b from u", ""},
+		{"insert into t values (1)", ""},
+		{"not sql", ""},
+	}
+	for _, tc := range testcases {
+		got, ok := injectLimit(tc.sql, 10)
+		if tc.want == "" {
+			if ok || got != tc.sql {
+				t.Errorf("injectLimit(%q): %q, %v, want it unchanged", tc.sql, got, ok)
+			}
+			continue
+		}
+		if !ok || got != tc.want {
+			t.Errorf("injectLimit(%q): %q, %v, want %q", tc.sql, got, ok, tc.want)
+		}
+	}
+}
+
+func TestUnboundedQueryRows(t *testing.T) {
+	defer func(saved int) { *maxUnboundedQueryRows = saved }(*maxUnboundedQueryRows)
+	*maxUnboundedQueryRows = 0
+	if got := unboundedQueryRows("select a from t"); got != 0 {
+		t.Errorf("unboundedQueryRows when disabled: %v, want 0", got)
+	}
+	*maxUnboundedQueryRows = 10
+	if got := unboundedQueryRows("select a from t"); got != 10 {
+		t.Errorf("unboundedQueryRows: %v, want 10", got)
+	}
+	if got := unboundedQueryRows("select /*+ streaming */ a from t"); got != 0 {
+		t.Errorf("unboundedQueryRows with a streaming hint: %v, want 0", got)
+	}
+}
+
+// threeRowResult has the rows 1, 2 and 3.
+var threeRowResult = &sqltypes.Result{
+	Fields:       []*querypb.Field{{Name: "id", Type: sqltypes.Int64}},
+	RowsAffected: 3,
+	Rows: [][]sqltypes.Value{
+		{sqltypes.MakeTrusted(sqltypes.Int64, []byte("1"))},
+		{sqltypes.MakeTrusted(sqltypes.Int64, []byte("2"))},
+		{sqltypes.MakeTrusted(sqltypes.Int64, []byte("3"))},
+	},
+}
+
+func TestLimitResult(t *testing.T) {
+	if got := limitResult(threeRowResult, 3); got != threeRowResult {
+		t.Errorf("limitResult(3 rows, 3): %v, want it unchanged", got)
+	}
+	got := limitResult(threeRowResult, 2)
+	want := &sqltypes.Result{
+		Fields:       threeRowResult.Fields,
+		RowsAffected: 2,
+		Rows:         threeRowResult.Rows[:2],
+		Warnings:     []string{"the result was truncated to 2 rows by -max_unbounded_query_rows, the query needs a LIMIT or a /*+ streaming */ hint"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("limitResult(3 rows, 2): %+v, want %+v", got, want)
+	}
+	if len(threeRowResult.Rows) != 3 || threeRowResult.Warnings != nil {
+		t.Errorf("limitResult changed its input: %+v", threeRowResult)
+	}
+}
+
+func TestRouterUnboundedSelect(t *testing.T) {
+	defer func(saved int) { *maxUnboundedQueryRows = saved }(*maxUnboundedQueryRows)
+	*maxUnboundedQueryRows = 2
+	router, _, _, sbclookup := createRouterEnv()
+
+	logStats, ctx := NewLogStats(context.Background(), "Execute", "", "", "")
+	sbclookup.setResults([]*sqltypes.Result{threeRowResult})
+	qr, err := router.Execute(ctx, "select id from music_user_map", nil, "", topodatapb.TabletType_MASTER, nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantQueries := []querytypes.BoundQuery{{
+		Sql:           "select id from music_user_map limit 3",
+		BindVariables: map[string]interface{}{},
+	}}
+	if !reflect.DeepEqual(sbclookup.Queries, wantQueries) {
+		t.Errorf("sbclookup.Queries: %+v, want %+v", sbclookup.Queries, wantQueries)
+	}
+	if len(qr.Rows) != 2 || len(qr.Warnings) != 1 {
+		t.Errorf("Execute: %+v, want 2 rows and a warning", qr)
+	}
+	if !logStats.LimitInjected {
+		t.Errorf("LimitInjected: false, want true")
+	}
+
+	// The queries with a LIMIT or a streaming hint are sent unchanged.
+	sbclookup.Queries = nil
+	for _, sql := range []string{
+		"select id from music_user_map limit 10",
+		"select /*+ streaming */ id from music_user_map",
+	} {
+		sbclookup.setResults([]*sqltypes.Result{threeRowResult})
+		qr, err := routerExec(router, sql, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(qr.Rows) != 3 || qr.Warnings != nil {
+			t.Errorf("Execute(%q): %+v, want 3 rows", sql, qr)
+		}
+	}
+	wantQueries = []querytypes.BoundQuery{{
+		Sql:           "select id from music_user_map limit 10",
+		BindVariables: map[string]interface{}{},
+	}, {
+		Sql:           "select /*+ streaming */ id from music_user_map",
+		BindVariables: map[string]interface{}{},
+	}}
+	if !reflect.DeepEqual(sbclookup.Queries, wantQueries) {
+		t.Errorf("sbclookup.Queries: %+v, want %+v", sbclookup.Queries, wantQueries)
+	}
+}
+
+func TestVTGateExecuteShardsUnboundedSelect(t *testing.T) {
+	defer func(saved int) { *maxUnboundedQueryRows = saved }(*maxUnboundedQueryRows)
+	*maxUnboundedQueryRows = 2
+	sandbox := createSandbox("TestVTGateExecuteShardsUnboundedSelect")
+	sbc := &sandboxConn{}
+	sandbox.MapTestConn("0", sbc)
+	sbc.setResults([]*sqltypes.Result{threeRowResult})
+
+	ch := QueryLogger.Subscribe("TestVTGateExecuteShardsUnboundedSelect")
+	defer QueryLogger.Unsubscribe(ch)
+	qr, err := rpcVTGate.ExecuteShards(context.Background(),
+		"select id from t",
+		nil,
+		"TestVTGateExecuteShardsUnboundedSelect",
+		[]string{"0"},
+		topodatapb.TabletType_REPLICA,
+		nil,
+		false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := sbc.Queries[0].Sql, "select id from t limit 3"; got != want {
+		t.Errorf("query: %q, want %q", got, want)
+	}
+	if len(qr.Rows) != 2 || len(qr.Warnings) != 1 {
+		t.Errorf("ExecuteShards: %+v, want 2 rows and a warning", qr)
+	}
+	logStats := (<-ch).(*LogStats)
+	if logStats.Method != "ExecuteShards" || !logStats.LimitInjected || logStats.RowsReturned != 2 {
+		t.Errorf("LogStats: %+v, want ExecuteShards with 2 rows and LimitInjected", logStats)
+	}
+}
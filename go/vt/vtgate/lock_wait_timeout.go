@@ -0,0 +1,84 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/sqltypes"
+
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+)
+
+var lockWaitTimeoutHintRE = regexp.MustCompile(`/\*\+\s*lock_wait_timeout=(\d+)\s*\*/`)
+
+// resetLockWaitTimeout sets the innodb_lock_wait_timeout of the
+// session back to its default, the global value.
+const resetLockWaitTimeout = "set innodb_lock_wait_timeout = @@global.innodb_lock_wait_timeout"
+
+type lockWaitTimeoutKey struct{}
+
+// withLockWaitTimeoutHint returns a context that carries the lock wait
+// timeout in seconds of the /*+ lock_wait_timeout=N */ hint of sql, and
+// the timeout. If sql has no hint, it returns ctx unchanged and 0.
+func withLockWaitTimeoutHint(ctx context.Context, sql string) (context.Context, int) {
+	match := lockWaitTimeoutHintRE.FindStringSubmatch(sql)
+	if match == nil {
+		return ctx, 0
+	}
+	timeout, err := strconv.Atoi(match[1])
+	if err != nil || timeout == 0 {
+		return ctx, 0
+	}
+	return context.WithValue(ctx, lockWaitTimeoutKey{}, timeout), timeout
+}
+
+// lockWaitTimeoutFromContext returns the lock wait timeout stored in
+// ctx by withLockWaitTimeoutHint, or 0.
+func lockWaitTimeoutFromContext(ctx context.Context) int {
+	timeout, _ := ctx.Value(lockWaitTimeoutKey{}).(int)
+	return timeout
+}
+
+// executeShard executes query on shard, in the transaction
+// transactionID, or in a new one if shouldBegin is true. It returns
+// the id of the transaction.
+// In a transaction, the innodb_lock_wait_timeout of the lock wait
+// timeout hint of ctx is set before the query, and reset after it.
+// Outside transactions the hint is ignored: the session of the query
+// is not known.
+func (stc *ScatterConn) executeShard(ctx context.Context, keyspace, shard string, tabletType topodatapb.TabletType, query string, bindVars map[string]interface{}, shouldBegin bool, transactionID int64) (*sqltypes.Result, int64, error) {
+	timeout := lockWaitTimeoutFromContext(ctx)
+	if timeout == 0 || (!shouldBegin && transactionID == 0) {
+		if shouldBegin {
+			return stc.gateway.BeginExecute(ctx, keyspace, shard, tabletType, query, bindVars)
+		}
+		qr, err := stc.gateway.Execute(ctx, keyspace, shard, tabletType, query, bindVars, transactionID)
+		return qr, transactionID, err
+	}
+
+	set := fmt.Sprintf("set innodb_lock_wait_timeout = %d", timeout)
+	var err error
+	if shouldBegin {
+		_, transactionID, err = stc.gateway.BeginExecute(ctx, keyspace, shard, tabletType, set, nil)
+	} else {
+		_, err = stc.gateway.Execute(ctx, keyspace, shard, tabletType, set, nil, transactionID)
+	}
+	if err != nil {
+		return nil, transactionID, err
+	}
+	qr, err := stc.gateway.Execute(ctx, keyspace, shard, tabletType, query, bindVars, transactionID)
+	if _, resetErr := stc.gateway.Execute(ctx, keyspace, shard, tabletType, resetLockWaitTimeout, nil, transactionID); resetErr != nil && err == nil {
+		err = resetErr
+	}
+	if err != nil {
+		return nil, transactionID, err
+	}
+	return qr, transactionID, nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"reflect"
+	"testing"
+
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/vt/tabletserver/querytypes"
+
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+	vtgatepb "github.com/youtube/vitess/go/vt/proto/vtgate"
+)
+
+func TestWithLockWaitTimeoutHint(t *testing.T) {
+	testcases := []struct {
+		sql  string
+		want int
+	}{
+		{"/*+ lock_wait_timeout=5 */ update t set a = 1 where id = 1", 5},
+		{"update t set a = 1 where id = 1 /*+lock_wait_timeout=120*/", 120},
+		{"/*+ lock_wait_timeout=0 */ update t set a = 1", 0},
+		{"/*+ lock_wait_timeout=soon */ update t set a = 1", 0},
+		{"/* lock_wait_timeout=5 */ update t set a = 1", 0},
+		{"update t set a = 1", 0},
+	}
+	for _, tcase := range testcases {
+		ctx, timeout := withLockWaitTimeoutHint(context.Background(), tcase.sql)
+		if timeout != tcase.want {
+			t.Errorf("withLockWaitTimeoutHint(%v): %v, want %v", tcase.sql, timeout, tcase.want)
+		}
+		if got := lockWaitTimeoutFromContext(ctx); got != tcase.want {
+			t.Errorf("lockWaitTimeoutFromContext of %v: %v, want %v", tcase.sql, got, tcase.want)
+		}
+	}
+}
+
+func TestVTGateLockWaitTimeout(t *testing.T) {
+	keyspace := "TestVTGateLockWaitTimeout"
+	sandbox := createSandbox(keyspace)
+	sbc := &sandboxConn{}
+	sandbox.MapTestConn("0", sbc)
+	sql := "/*+ lock_wait_timeout=5 */ update t set a = 1 where id = 1"
+	execute := func(session *vtgatepb.Session) {
+		if _, err := rpcVTGate.ExecuteShards(context.Background(), sql, nil, keyspace, []string{"0"}, topodatapb.TabletType_MASTER, session, false); err != nil {
+			t.Fatalf("ExecuteShards: %v", err)
+		}
+	}
+
+	// Outside transactions, the hint is ignored.
+	execute(nil)
+	want := []querytypes.BoundQuery{{Sql: sql, BindVariables: map[string]interface{}{}}}
+	if !reflect.DeepEqual(sbc.Queries, want) {
+		t.Errorf("queries outside a transaction: %+v, want %+v", sbc.Queries, want)
+	}
+
+	// In a transaction, the timeout is set before the query, on the
+	// shard that begins, and the ones already in the transaction.
+	session, err := rpcVTGate.Begin(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		sbc.Queries = nil
+		execute(session)
+		want := []querytypes.BoundQuery{
+			{Sql: "set innodb_lock_wait_timeout = 5", BindVariables: map[string]interface{}{}},
+			{Sql: sql, BindVariables: map[string]interface{}{}},
+			{Sql: resetLockWaitTimeout, BindVariables: map[string]interface{}{}},
+		}
+		if !reflect.DeepEqual(sbc.Queries, want) {
+			t.Errorf("queries in a transaction, %v: %+v, want %+v", i, sbc.Queries, want)
+		}
+	}
+	if got := sbc.BeginCount.Get(); got != 1 {
+		t.Errorf("BeginCount: %v, want 1", got)
+	}
+
+	// The timeout is reset when the query fails.
+	sbc.Queries = nil
+	sbc.onConnUse = func(sbc *sandboxConn) {
+		// Only the query fails, not the sets.
+		sbc.mustFailServer = 0
+		if len(sbc.Queries) == 2 {
+			sbc.mustFailServer = 1
+		}
+	}
+	if _, err := rpcVTGate.ExecuteShards(context.Background(), sql, nil, keyspace, []string{"0"}, topodatapb.TabletType_MASTER, session, false); err == nil {
+		t.Errorf("ExecuteShards of a failing query: nil, want error")
+	}
+	sbc.onConnUse = nil
+	if len(sbc.Queries) != 3 || sbc.Queries[2].Sql != resetLockWaitTimeout {
+		t.Errorf("queries of a failing query: %+v, want the reset of the timeout last", sbc.Queries)
+	}
+}
@@ -0,0 +1,128 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"flag"
+	"fmt"
+	"net/url"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/streamlog"
+	"github.com/youtube/vitess/go/vt/callerid"
+)
+
+var (
+	// QueryLogger receives a *LogStats for each non-streaming query
+	// executed by vtgate, see NewLogStats.
+	QueryLogger = streamlog.New("VTGate", 50)
+
+	queryLogHandler = flag.String("vtgate_query_log_stream_handler", "/debug/vtgate/querylog", "URL handler for streaming the log of the queries executed by vtgate")
+)
+
+// LogStats records the execution of a query by vtgate, for
+// QueryLogger.
+type LogStats struct {
+	Ctx          context.Context
+	Method       string
+	Keyspace     string
+	TabletType   string
+	SQL          string
+	StartTime    time.Time
+	EndTime      time.Time
+	RowsReturned int
+	// LimitInjected is true if vtgate added a LIMIT to the query,
+	// see -max_unbounded_query_rows.
+	LimitInjected bool
+	// TimeoutOverrideRule is the rule the timeout of the query comes
+	// from, like "keyspace_timeout_overrides[ks]=5s", or "" if it has
+	// no timeout.
+	TimeoutOverrideRule string
+	Error               error
+}
+
+type logStatsKey struct{}
+
+// NewLogStats returns the LogStats of a query, and a context that
+// carries it, so that the code executing the query can fill it in.
+func NewLogStats(ctx context.Context, methodName, sql, keyspace, tabletType string) (*LogStats, context.Context) {
+	stats := &LogStats{
+		Method:     methodName,
+		Keyspace:   keyspace,
+		TabletType: tabletType,
+		SQL:        sql,
+		StartTime:  time.Now(),
+	}
+	ctx = context.WithValue(ctx, logStatsKey{}, stats)
+	stats.Ctx = ctx
+	return stats, ctx
+}
+
+// logStatsFromContext returns the LogStats stored in ctx by
+// NewLogStats, or nil.
+func logStatsFromContext(ctx context.Context) *LogStats {
+	stats, _ := ctx.Value(logStatsKey{}).(*LogStats)
+	return stats
+}
+
+// Send sends the LogStats to QueryLogger.
+func (stats *LogStats) Send() {
+	stats.EndTime = time.Now()
+	QueryLogger.Send(stats)
+}
+
+// TotalTime returns how long the query took.
+func (stats *LogStats) TotalTime() time.Duration {
+	return stats.EndTime.Sub(stats.StartTime)
+}
+
+// HasError returns true if the query failed.
+func (stats *LogStats) HasError() bool {
+	return stats.Error != nil
+}
+
+// EffectiveCaller returns the principal of the effective caller of
+// the query, or "".
+func (stats *LogStats) EffectiveCaller() string {
+	if stats.Ctx == nil {
+		return ""
+	}
+	return callerid.GetPrincipal(callerid.EffectiveCallerIDFromContext(stats.Ctx))
+}
+
+// ErrorStr returns the error of the query, or "".
+func (stats *LogStats) ErrorStr() string {
+	if stats.Error == nil {
+		return ""
+	}
+	return stats.Error.Error()
+}
+
+// Format returns a tab separated version of the LogStats.
+func (stats *LogStats) Format(params url.Values) string {
+	return fmt.Sprintf(
+		"%v\t%q\t%v\t%v\t%.6f\t%v\t%v\t%q\t%v\t%v\t%q\t%q\t\n",
+		stats.Method,
+		stats.EffectiveCaller(),
+		stats.StartTime.Format(time.StampMicro),
+		stats.EndTime.Format(time.StampMicro),
+		stats.TotalTime().Seconds(),
+		stats.Keyspace,
+		stats.TabletType,
+		stats.SQL,
+		stats.RowsReturned,
+		stats.LimitInjected,
+		stats.TimeoutOverrideRule,
+		stats.ErrorStr(),
+	)
+}
+
+func initQueryLogger() {
+	QueryLogger.ServeLogs(*queryLogHandler, func(params url.Values, x interface{}) string {
+		return x.(*LogStats).Format(params)
+	})
+}
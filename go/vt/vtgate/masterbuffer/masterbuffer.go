@@ -21,6 +21,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/youtube/vitess/go/clock"
 	"github.com/youtube/vitess/go/stats"
 	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
 	vtrpcpb "github.com/youtube/vitess/go/vt/proto/vtrpc"
@@ -41,8 +42,8 @@ var (
 	bufferedRequests = stats.NewInt("BufferedRequests")
 )
 
-// timeSleep can be mocked out in unit tests
-var timeSleep = time.Sleep
+// bufferClock can be mocked out in unit tests
+var bufferClock = clock.Real
 
 // errBufferFull is the error returned a buffer request is rejected because the buffer is full.
 var errBufferFull = vterrors.FromError(
@@ -78,7 +79,7 @@ func FakeBuffer(keyspace, shard string, tabletType topodatapb.TabletType, inTran
 	bufferMu.Unlock()
 
 	defer bufferedRequestsSuccessful.Add(1)
-	timeSleep(*fakeBufferDelay)
+	bufferClock.Sleep(*fakeBufferDelay)
 	// Don't need to lock for this, as there's no race when decrementing the count
 	bufferedRequests.Add(-1)
 	return nil
@@ -9,37 +9,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/youtube/vitess/go/clock/fakeclock"
+
 	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
 )
 
-// fakeSleepController is used to control a fake sleepFunc
-type fakeSleepController struct {
-	called bool
-	block  bool
-	// block until the done channel if closed, if configured to do so.
-	done chan struct{}
-	// will close this channel when blocked
-	blocked chan struct{}
-}
-
-type sleepFunc func(d time.Duration)
-
-// createFakeSleep creates a function that can be called to fake sleeping.
-// The created fake is managed by the passed in fakeSleepController.
-func createFakeSleep(c *fakeSleepController) sleepFunc {
-	return func(d time.Duration) {
-		c.called = true
-		if !c.block {
-			return
-		}
-		close(c.blocked)
-		select {
-		case <-c.done:
-			return
-		}
-	}
-}
-
 func TestFakeBuffer(t *testing.T) {
 	unbufferedKeyspace := "ukeyspace"
 	unbufferedShard := "80-"
@@ -114,8 +88,8 @@ func TestFakeBuffer(t *testing.T) {
 			wantAttempted:    1,
 		},
 	} {
-		controller := &fakeSleepController{}
-		timeSleep = createFakeSleep(controller)
+		fc := fakeclock.New(time.Now())
+		bufferClock = fc
 		// reset counters
 		bufferedRequestsAttempted.Set(0)
 		bufferedRequestsSuccessful.Set(0)
@@ -129,17 +103,40 @@ func TestFakeBuffer(t *testing.T) {
 			tabletType = topodatapb.TabletType_MASTER
 		}
 
-		gotErr := FakeBuffer(test.keyspace, test.shard, tabletType, test.inTransaction, test.attemptNumber)
+		done := make(chan error)
+		go func() {
+			done <- FakeBuffer(test.keyspace, test.shard, tabletType, test.inTransaction, test.attemptNumber)
+		}()
+		var gotErr error
+		if test.wantCalled {
+			// The buffered request waits for the delay.
+			fc.BlockUntil(1)
+			fc.Advance(*fakeBufferDelay)
+			gotErr = <-done
+		} else {
+			// The other requests must return without waiting for
+			// the clock.
+			waited := make(chan struct{})
+			go func() {
+				fc.BlockUntil(1)
+				close(waited)
+			}()
+			select {
+			case gotErr = <-done:
+			case <-waited:
+				t.Errorf("With %v, FakeBuffer() waited for the clock; want no buffering", test.desc)
+				fc.Advance(*fakeBufferDelay)
+				gotErr = <-done
+			}
+			// Add a waiter to let the go routine return.
+			fc.After(time.Hour)
+			<-waited
+		}
 
 		if gotErr != test.wantErr {
 			t.Errorf("With %v, FakeBuffer() => %v; want: %v", test.desc, gotErr, test.wantErr)
 		}
 
-		if controller.called != test.wantCalled {
-			t.Errorf("With %v, FakeBuffer() => timeSleep.called: %v; want: %v",
-				test.desc, controller.called, test.wantCalled)
-		}
-
 		if bufferedRequestsAttempted.Get() != int64(test.wantAttempted) {
 			t.Errorf("With %v, FakeBuffer() => bufferedRequestsAttempted got: %v; want: %v",
 				test.desc, bufferedRequestsAttempted.Get(), test.wantAttempted)
@@ -173,49 +170,28 @@ func TestParallelFakeBuffer(t *testing.T) {
 	bufferedRequestsAttempted.Set(0)
 	bufferedRequestsSuccessful.Set(0)
 
-	var controllers []*fakeSleepController
+	fc := fakeclock.New(time.Now())
+	bufferClock = fc
 	var wg sync.WaitGroup
 
 	for i := 1; i <= *maxBufferSize+2; i++ {
-		controller := &fakeSleepController{
-			block:   true,
-			done:    make(chan struct{}),
-			blocked: make(chan struct{}),
-		}
-		timeSleep = createFakeSleep(controller)
-		// Only the first maxBufferSize calls to FakeBuffer should actually call fakeSleep
-		wantFakeSleepCalled := (i <= *maxBufferSize)
-
 		wg.Add(1)
-		finished := make(chan struct{})
-		var gotErr error
+		finished := make(chan error, 1)
 		go func() {
 			defer wg.Done()
-			gotErr = FakeBuffer(*bufferKeyspace, *bufferShard, topodatapb.TabletType_MASTER, false, 0)
-			close(finished)
+			finished <- FakeBuffer(*bufferKeyspace, *bufferShard, topodatapb.TabletType_MASTER, false, 0)
 		}()
 
-		// wait until either the gorouotine is blocked (because it's buffering) or until
-		// it's finished (because it shouldn't be buffered).
-		select {
-		case <-controller.blocked:
-		case <-finished:
-		}
-
-		if controller.called {
-			controllers = append(controllers, controller)
+		if i <= *maxBufferSize {
+			// Wait until the request is buffering, with the previous ones.
+			fc.BlockUntil(i)
 		} else {
-			// if we didn't call fakeSleep, the buffer is full and should return an error saying so.
-			if gotErr != errBufferFull {
+			// The buffer is full and should return an error saying so.
+			if gotErr := <-finished; gotErr != errBufferFull {
 				t.Errorf("On iteration %v, FakeBuffer() => %v; want: %v", i, gotErr, errBufferFull)
 			}
 		}
 
-		if controller.called != wantFakeSleepCalled {
-			t.Errorf("On iteration %v, FakeBuffer() => timeSleep.called: %v; want: %v",
-				i, controller.called, wantFakeSleepCalled)
-		}
-
 		if int(bufferedRequestsAttempted.Get()) != i {
 			t.Errorf("On iteration %v, FakeBuffer() => bufferedRequestsAttempted got: %v; want: %v",
 				i, bufferedRequestsAttempted.Get(), i)
@@ -232,10 +208,8 @@ func TestParallelFakeBuffer(t *testing.T) {
 		}
 	}
 
-	// signal to all the buffered calls that they can stop buffering, and wait for them.
-	for _, c := range controllers {
-		close(c.done)
-	}
+	// let all the buffered calls stop buffering, and wait for them.
+	fc.Advance(*fakeBufferDelay)
 	wg.Wait()
 
 	if int(bufferedRequestsSuccessful.Get()) != *maxBufferSize {
@@ -0,0 +1,247 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/sqltypes"
+	"github.com/youtube/vitess/go/stats"
+	"github.com/youtube/vitess/go/vt/callerid"
+	"github.com/youtube/vitess/go/vt/logutil"
+	"github.com/youtube/vitess/go/vt/sqlparser"
+
+	vtgatepb "github.com/youtube/vitess/go/vt/proto/vtgate"
+)
+
+var (
+	mirrorTraffic     = flag.String("mirror_traffic", "", "comma-separated list of source:target:percent rules. The given percentage of the read-only ExecuteShards, ExecuteKeyspaceIds and ExecuteKeyRanges queries sent to the source keyspace outside of a transaction are also sent to the target keyspace in the background, and their results are discarded, e.g. prod_ks:staging_ks:5")
+	mirrorQueueSize   = flag.Int("mirror_queue_size", 1000, "maximum number of mirrored queries waiting to be sent. The queries above it are dropped.")
+	mirrorConcurrency = flag.Int("mirror_concurrency", 10, "number of mirrored queries sent at the same time")
+	mirrorTimeout     = flag.Duration("mirror_timeout", 10*time.Second, "timeout of the mirrored queries")
+	mirrorCompareRate = flag.Float64("mirror_compare_rate", 0, "fraction of the mirrored queries, between 0 and 1, whose results are compared with the results of the source keyspace. The differences are logged.")
+)
+
+// mirrorSubcomponent is the subcomponent of the effective caller id of
+// the mirrored queries, so that the target keyspace can tell them apart.
+const mirrorSubcomponent = "vtgate_mirror"
+
+// mirrorRule sends percent% of the queries of source to target too.
+type mirrorRule struct {
+	source  string
+	target  string
+	percent float64
+}
+
+// parseMirrorRules parses the value of -mirror_traffic, and returns
+// the rules by source keyspace.
+func parseMirrorRules(value string) (map[string]mirrorRule, error) {
+	if value == "" {
+		return nil, nil
+	}
+	rules := make(map[string]mirrorRule)
+	for _, entry := range strings.Split(value, ",") {
+		parts := strings.Split(strings.TrimSpace(entry), ":")
+		if len(parts) != 3 || parts[0] == "" || parts[1] == "" {
+			return nil, fmt.Errorf("invalid mirror rule %q, want source:target:percent", entry)
+		}
+		percent, err := strconv.ParseFloat(parts[2], 64)
+		if err != nil || percent <= 0 || percent > 100 {
+			return nil, fmt.Errorf("invalid percentage %q in mirror rule %q", parts[2], entry)
+		}
+		if parts[0] == parts[1] {
+			return nil, fmt.Errorf("mirror rule %q mirrors a keyspace to itself", entry)
+		}
+		if _, ok := rules[parts[0]]; ok {
+			return nil, fmt.Errorf("keyspace %v has several mirror rules", parts[0])
+		}
+		rules[parts[0]] = mirrorRule{source: parts[0], target: parts[1], percent: percent}
+	}
+	return rules, nil
+}
+
+// mirrorRequest is a query waiting to be sent to a target keyspace.
+type mirrorRequest struct {
+	rule    mirrorRule
+	sql     string
+	ctx     context.Context
+	execute func(ctx context.Context, keyspace string) (*sqltypes.Result, error)
+	// primary is the result of the source keyspace if the results
+	// must be compared, nil otherwise.
+	primary *sqltypes.Result
+}
+
+// trafficMirror duplicates a fraction of the read-only queries to other
+// keyspaces. The queries are queued and sent by a fixed number of
+// goroutines, so they never slow down the original queries: when
+// the queue is full, they're dropped.
+type trafficMirror struct {
+	rules       map[string]mirrorRule
+	compareRate float64
+	timeout     time.Duration
+	queue       chan *mirrorRequest
+	// counts counts the mirrored queries by source, target and
+	// result: Queued or Dropped when they're queued, then Sent, Error,
+	// Match or Mismatch when they're done.
+	counts *stats.MultiCounters
+	logger *logutil.ThrottledLogger
+	// random returns a number in [0, 1). The tests replace it.
+	random func() float64
+	wg     sync.WaitGroup
+}
+
+// newTrafficMirrorFromFlags returns the trafficMirror configured by the
+// command line flags, or nil if nothing is mirrored.
+func newTrafficMirrorFromFlags(statsName string) (*trafficMirror, error) {
+	rules, err := parseMirrorRules(*mirrorTraffic)
+	if err != nil {
+		return nil, err
+	}
+	if len(rules) == 0 {
+		return nil, nil
+	}
+	if *mirrorCompareRate < 0 || *mirrorCompareRate > 1 {
+		return nil, fmt.Errorf("invalid -mirror_compare_rate %v, want a value between 0 and 1", *mirrorCompareRate)
+	}
+	return newTrafficMirror(statsName, rules, *mirrorQueueSize, *mirrorConcurrency, *mirrorCompareRate, *mirrorTimeout), nil
+}
+
+// newTrafficMirror creates a trafficMirror, and starts the goroutines
+// that send the queries. If statsName is set, the counts of mirrored
+// queries are published under it.
+func newTrafficMirror(statsName string, rules map[string]mirrorRule, queueSize, concurrency int, compareRate float64, timeout time.Duration) *trafficMirror {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	tm := &trafficMirror{
+		rules:       rules,
+		compareRate: compareRate,
+		timeout:     timeout,
+		queue:       make(chan *mirrorRequest, queueSize),
+		counts:      stats.NewMultiCounters(statsName, []string{"Source", "Target", "Result"}),
+		logger:      logutil.NewThrottledLogger("Mirror", 5*time.Second),
+		random:      rand.Float64,
+	}
+	for i := 0; i < concurrency; i++ {
+		tm.wg.Add(1)
+		go tm.run()
+	}
+	return tm
+}
+
+// mirror queues a query that succeeded on keyspace, if keyspace is
+// mirrored and the query is picked. execute sends the query to
+// another keyspace. mirror never blocks.
+func (tm *trafficMirror) mirror(ctx context.Context, keyspace, sql string, session *vtgatepb.Session, primary *sqltypes.Result, execute func(ctx context.Context, keyspace string) (*sqltypes.Result, error)) {
+	rule, ok := tm.rules[keyspace]
+	if !ok || tm.random()*100 >= rule.percent {
+		return
+	}
+	if session != nil && session.InTransaction {
+		return
+	}
+	if !isReadOnly(sql) {
+		return
+	}
+
+	req := &mirrorRequest{
+		rule:    rule,
+		sql:     sql,
+		ctx:     mirrorContext(ctx),
+		execute: execute,
+	}
+	if tm.compareRate > 0 && tm.random() < tm.compareRate {
+		req.primary = primary
+	}
+	select {
+	case tm.queue <- req:
+		tm.counts.Add([]string{rule.source, rule.target, "Queued"}, 1)
+	default:
+		tm.counts.Add([]string{rule.source, rule.target, "Dropped"}, 1)
+	}
+}
+
+// mirrorContext returns a context that doesn't end with ctx, with the
+// caller ids of ctx and the mirror subcomponent.
+func mirrorContext(ctx context.Context) context.Context {
+	ef := callerid.EffectiveCallerIDFromContext(ctx)
+	mirrorEf := callerid.NewEffectiveCallerID(callerid.GetPrincipal(ef), callerid.GetComponent(ef), mirrorSubcomponent)
+	return callerid.NewContext(context.Background(), mirrorEf, callerid.ImmediateCallerIDFromContext(ctx))
+}
+
+// isReadOnly returns true if sql is a select that doesn't lock rows.
+func isReadOnly(sql string) bool {
+	stmt, err := sqlparser.Parse(sql)
+	if err != nil {
+		return false
+	}
+	switch stmt := stmt.(type) {
+	case *sqlparser.Select:
+		return stmt.Lock == ""
+	case *sqlparser.Union:
+		return true
+	}
+	return false
+}
+
+// run sends the queued queries until the queue is closed.
+func (tm *trafficMirror) run() {
+	defer tm.wg.Done()
+	for req := range tm.queue {
+		tm.send(req)
+	}
+}
+
+func (tm *trafficMirror) send(req *mirrorRequest) {
+	ctx, cancel := context.WithTimeout(req.ctx, tm.timeout)
+	defer cancel()
+	statsKey := []string{req.rule.source, req.rule.target, ""}
+	qr, err := req.execute(ctx, req.rule.target)
+	switch {
+	case err != nil:
+		statsKey[2] = "Error"
+	case req.primary == nil:
+		statsKey[2] = "Sent"
+	default:
+		diff := diffResults(req.primary, qr)
+		if diff == "" {
+			statsKey[2] = "Match"
+			break
+		}
+		statsKey[2] = "Mismatch"
+		tm.logger.Warningf("mirrored query to %v differs from %v: %v, sql: %v", req.rule.target, req.rule.source, diff, req.sql)
+	}
+	tm.counts.Add(statsKey, 1)
+}
+
+// diffResults describes the first difference between the rows of the
+// source and target results, or returns "" if they have the same rows.
+func diffResults(source, target *sqltypes.Result) string {
+	if len(source.Rows) != len(target.Rows) {
+		return fmt.Sprintf("%d rows, want %d", len(target.Rows), len(source.Rows))
+	}
+	for i := range source.Rows {
+		if !reflect.DeepEqual(source.Rows[i], target.Rows[i]) {
+			return fmt.Sprintf("row %d: %v, want %v", i, target.Rows[i], source.Rows[i])
+		}
+	}
+	return ""
+}
+
+// close waits until the queued queries are sent, and stops the
+// goroutines. mirror must not be called after close.
+func (tm *trafficMirror) close() {
+	close(tm.queue)
+	tm.wg.Wait()
+}
@@ -0,0 +1,141 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/sqltypes"
+	"github.com/youtube/vitess/go/vt/callerid"
+
+	vtgatepb "github.com/youtube/vitess/go/vt/proto/vtgate"
+)
+
+func TestParseMirrorRules(t *testing.T) {
+	got, err := parseMirrorRules("prod_ks:staging_ks:5, other_ks:test_ks:0.5")
+	if err != nil {
+		t.Fatalf("parseMirrorRules: %v", err)
+	}
+	want := map[string]mirrorRule{
+		"prod_ks":  {source: "prod_ks", target: "staging_ks", percent: 5},
+		"other_ks": {source: "other_ks", target: "test_ks", percent: 0.5},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseMirrorRules: %v, want %v", got, want)
+	}
+
+	if got, err := parseMirrorRules(""); got != nil || err != nil {
+		t.Errorf("parseMirrorRules(\"\"): %v, %v, want nil, nil", got, err)
+	}
+	for _, value := range []string{"ks:other", "ks:other:x", "ks:other:0", "ks:other:101", "ks:ks:5", ":other:5", "ks:a:5,ks:b:5"} {
+		if _, err := parseMirrorRules(value); err == nil {
+			t.Errorf("parseMirrorRules(%v): nil, want error", value)
+		}
+	}
+}
+
+func TestIsReadOnly(t *testing.T) {
+	testcases := []struct {
+		sql  string
+		want bool
+	}{
+		{"select * from t", true},
+		{"select * from t union select * from u", true},
+		{"select * from t for update", false},
+		{"insert into t values (1)", false},
+		{"update t set a = 1", false},
+		{"not sql", false},
+	}
+	for _, tcase := range testcases {
+		if got := isReadOnly(tcase.sql); got != tcase.want {
+			t.Errorf("isReadOnly(%s): %v, want %v", tcase.sql, got, tcase.want)
+		}
+	}
+}
+
+func TestTrafficMirror(t *testing.T) {
+	rules := map[string]mirrorRule{"prod_ks": {source: "prod_ks", target: "staging_ks", percent: 50}}
+	tm := newTrafficMirror("", rules, 10, 2, 1, time.Second)
+	var random float64
+	tm.random = func() float64 { return random }
+
+	var mu sync.Mutex
+	var sent []string
+	targetResult := &sqltypes.Result{Rows: [][]sqltypes.Value{{sqltypes.MakeString([]byte("1"))}}}
+	execute := func(ctx context.Context, keyspace string) (*sqltypes.Result, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if got := callerid.GetSubcomponent(callerid.EffectiveCallerIDFromContext(ctx)); got != mirrorSubcomponent {
+			t.Errorf("subcomponent of the mirrored query: %v, want %v", got, mirrorSubcomponent)
+		}
+		if got := callerid.GetPrincipal(callerid.EffectiveCallerIDFromContext(ctx)); got != "user" {
+			t.Errorf("principal of the mirrored query: %v, want user", got)
+		}
+		sent = append(sent, keyspace)
+		return targetResult, nil
+	}
+	ctx := callerid.NewContext(context.Background(), callerid.NewEffectiveCallerID("user", "app", "api"), nil)
+	sameResult := &sqltypes.Result{Rows: [][]sqltypes.Value{{sqltypes.MakeString([]byte("1"))}}}
+	otherResult := &sqltypes.Result{Rows: [][]sqltypes.Value{{sqltypes.MakeString([]byte("2"))}}}
+
+	// Mirrored and compared.
+	tm.mirror(ctx, "prod_ks", "select * from t", nil, sameResult, execute)
+	tm.mirror(ctx, "prod_ks", "select * from t", nil, otherResult, execute)
+	// Not mirrored.
+	tm.mirror(ctx, "other_ks", "select * from t", nil, sameResult, execute)
+	tm.mirror(ctx, "prod_ks", "update t set a = 1", nil, sameResult, execute)
+	tm.mirror(ctx, "prod_ks", "select * from t", &vtgatepb.Session{InTransaction: true}, sameResult, execute)
+	random = 0.6
+	tm.mirror(ctx, "prod_ks", "select * from t", nil, sameResult, execute)
+	tm.close()
+
+	if want := []string{"staging_ks", "staging_ks"}; !reflect.DeepEqual(sent, want) {
+		t.Errorf("mirrored queries: %v, want %v", sent, want)
+	}
+	want := map[string]int64{
+		"prod_ks.staging_ks.Queued":   2,
+		"prod_ks.staging_ks.Match":    1,
+		"prod_ks.staging_ks.Mismatch": 1,
+	}
+	if got := tm.counts.Counts(); !reflect.DeepEqual(got, want) {
+		t.Errorf("counts: %v, want %v", got, want)
+	}
+}
+
+func TestTrafficMirrorDrop(t *testing.T) {
+	rules := map[string]mirrorRule{"prod_ks": {source: "prod_ks", target: "staging_ks", percent: 100}}
+	tm := newTrafficMirror("", rules, 1, 1, 0, time.Second)
+	started := make(chan struct{})
+	unblock := make(chan struct{})
+	execute := func(ctx context.Context, keyspace string) (*sqltypes.Result, error) {
+		started <- struct{}{}
+		<-unblock
+		return &sqltypes.Result{}, nil
+	}
+
+	// The first query blocks the only goroutine, the second one
+	// fills the queue, and the third one is dropped without blocking.
+	tm.mirror(context.Background(), "prod_ks", "select * from t", nil, nil, execute)
+	<-started
+	tm.mirror(context.Background(), "prod_ks", "select * from t", nil, nil, execute)
+	tm.mirror(context.Background(), "prod_ks", "select * from t", nil, nil, execute)
+	close(unblock)
+	<-started
+	tm.close()
+
+	want := map[string]int64{
+		"prod_ks.staging_ks.Queued":  2,
+		"prod_ks.staging_ks.Dropped": 1,
+		"prod_ks.staging_ks.Sent":    2,
+	}
+	if got := tm.counts.Counts(); !reflect.DeepEqual(got, want) {
+		t.Errorf("counts: %v, want %v", got, want)
+	}
+}
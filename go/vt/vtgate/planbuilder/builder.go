@@ -87,6 +87,7 @@ func Build(query string, vschema VSchema) (*engine.Plan, error) {
 	switch statement := statement.(type) {
 	case *sqlparser.Select:
 		plan.Instructions, err = buildSelectPlan(statement, vschema)
+		plan.LockingRead = statement.Lock != ""
 	case *sqlparser.Insert:
 		plan.Instructions, err = buildInsertPlan(statement, vschema)
 	case *sqlparser.Update:
@@ -43,11 +43,12 @@ func buildUpdatePlan(upd *sqlparser.Update, vschema VSchema) (*engine.Route, err
 		return route, nil
 	}
 
-	err = getDMLRouting(upd.Where, route)
+	err = getDMLRouting(upd.Where, route, engine.UpdateEqual, engine.UpdateIN)
 	if err != nil {
 		return nil, err
 	}
-	route.Opcode = engine.UpdateEqual
+	// getDMLRouting may have rewritten the IN clause.
+	route.Query = generateQuery(upd)
 	if isIndexChanging(upd.Exprs, route.Table.ColVindexes) {
 		return nil, errors.New("unsupported: DML cannot change vindex column")
 	}
@@ -94,11 +95,12 @@ func buildDeletePlan(del *sqlparser.Delete, vschema VSchema) (*engine.Route, err
 		return route, nil
 	}
 
-	err = getDMLRouting(del.Where, route)
+	err = getDMLRouting(del.Where, route, engine.DeleteEqual, engine.DeleteIN)
 	if err != nil {
 		return nil, err
 	}
-	route.Opcode = engine.DeleteEqual
+	// getDMLRouting may have rewritten the IN clause.
+	route.Query = generateQuery(del)
 	route.Subquery = generateDeleteSubquery(del, route.Table)
 	return route, nil
 }
@@ -125,8 +127,12 @@ func generateDeleteSubquery(del *sqlparser.Delete, table *vindexes.Table) string
 }
 
 // getDMLRouting updates the route with the necessary routing
-// info. If it cannot find a unique route, then it returns an error.
-func getDMLRouting(where *sqlparser.Where, route *engine.Route) error {
+// info. An equality constraint on a unique vindex gives the
+// equalOpcode route. Otherwise, an IN constraint on a unique vindex
+// gives the inOpcode route, and the IN clause is rewritten to use
+// the list of values of each shard. If it cannot find a route where
+// each row is routed by its vindex, then it returns an error.
+func getDMLRouting(where *sqlparser.Where, route *engine.Route, equalOpcode, inOpcode engine.RouteOpcode) error {
 	if where == nil {
 		return errors.New("unsupported: multi-shard where clause in DML")
 	}
@@ -135,11 +141,24 @@ func getDMLRouting(where *sqlparser.Where, route *engine.Route) error {
 			continue
 		}
 		if values := getMatch(where.Expr, index.Col); values != nil {
+			route.Opcode = equalOpcode
 			route.Vindex = index.Vindex
 			route.Values = values
 			return nil
 		}
 	}
+	for _, index := range route.Table.Ordered {
+		if !vindexes.IsUnique(index.Vindex) {
+			continue
+		}
+		if comparison, values := getINMatch(where.Expr, index.Col); comparison != nil {
+			route.Opcode = inOpcode
+			route.Vindex = index.Vindex
+			route.Values = values
+			comparison.Right = sqlparser.ListArg("::" + engine.ListVarName)
+			return nil
+		}
+	}
 	return errors.New("unsupported: multi-shard where clause in DML")
 }
 
@@ -171,6 +190,51 @@ func getMatch(node sqlparser.BoolExpr, col string) interface{} {
 	return nil
 }
 
+// getINMatch returns the IN constraint on the specified column
+// that can be used to decide on the routes, and its values.
+func getINMatch(node sqlparser.BoolExpr, col string) (*sqlparser.ComparisonExpr, []interface{}) {
+	filters := splitAndExpression(nil, node)
+	for _, filter := range filters {
+		comparison, ok := filter.(*sqlparser.ComparisonExpr)
+		if !ok {
+			continue
+		}
+		if comparison.Operator != sqlparser.InStr {
+			continue
+		}
+		if !nameMatch(comparison.Left, col) {
+			continue
+		}
+		tuple, ok := comparison.Right.(sqlparser.ValTuple)
+		if !ok {
+			continue
+		}
+		values, err := tupleConvert(tuple)
+		if err != nil {
+			continue
+		}
+		return comparison, values
+	}
+	return nil, nil
+}
+
+// tupleConvert converts the values of an IN list to the Values
+// field of the route.
+func tupleConvert(tuple sqlparser.ValTuple) ([]interface{}, error) {
+	values := make([]interface{}, 0, len(tuple))
+	for _, node := range tuple {
+		if !sqlparser.IsValue(node) {
+			return nil, fmt.Errorf("%v is not a value", sqlparser.String(node))
+		}
+		val, err := valConvert(node)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, val)
+	}
+	return values, nil
+}
+
 func nameMatch(node sqlparser.ValExpr, col string) bool {
 	colname, ok := node.(*sqlparser.ColName)
 	return ok && string(colname.Name) == col
@@ -11,18 +11,26 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"hash/fnv"
 	"net/http"
+	"strings"
 	"sync"
+	"time"
 
 	log "github.com/golang/glog"
 	"golang.org/x/net/context"
 
 	"github.com/youtube/vitess/go/acl"
 	"github.com/youtube/vitess/go/cache"
+	"github.com/youtube/vitess/go/stats"
+	"github.com/youtube/vitess/go/sync2"
+	"github.com/youtube/vitess/go/vt/servenv"
 	"github.com/youtube/vitess/go/vt/topo"
 	"github.com/youtube/vitess/go/vt/vtgate/engine"
 	"github.com/youtube/vitess/go/vt/vtgate/planbuilder"
 	"github.com/youtube/vitess/go/vt/vtgate/vindexes"
+
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
 )
 
 // Planner is used to compute the plan. It contains
@@ -33,22 +41,47 @@ type Planner struct {
 	mu      sync.Mutex
 	vschema *vindexes.VSchema
 	plans   *cache.LRUCache
+
+	// hits and misses count the lookups of the plans cache.
+	hits   sync2.AtomicInt64
+	misses sync2.AtomicInt64
+
+	// formalMu protects formal, the per-keyspace sources of
+	// vschema, and version, a hash of formal.
+	formalMu sync.Mutex
+	formal   *vindexes.VSchemaFormal
+	version  string
 }
 
+// vschemaReloadTimeout is how long /debug/reload_vschema waits for
+// the topology.
+const vschemaReloadTimeout = 30 * time.Second
+
 var once sync.Once
 
-// NewPlanner creates a new planner for VTGate.
-// It will watch the vschema in the topology until the ctx is closed.
-func NewPlanner(ctx context.Context, serv topo.SrvTopoServer, cell string, cacheSize int) *Planner {
+// NewPlanner creates a new planner for VTGate, which caches up to
+// cacheSize plans. It will watch the vschema in the topology until
+// the ctx is closed. The stats of the cache are exported with the
+// statsName prefix, if it's not empty.
+func NewPlanner(ctx context.Context, serv topo.SrvTopoServer, cell, statsName string, cacheSize int) *Planner {
 	plr := &Planner{
 		serv:  serv,
 		cell:  cell,
 		plans: cache.NewLRUCache(int64(cacheSize)),
 	}
 	plr.WatchVSchema(ctx)
+	if statsName != "" {
+		stats.Publish(statsName+"PlanCacheLength", stats.IntFunc(plr.plans.Length))
+		stats.Publish(statsName+"PlanCacheCapacity", stats.IntFunc(plr.plans.Capacity))
+		stats.Publish(statsName+"PlanCacheEvictions", stats.IntFunc(plr.plans.Evictions))
+		stats.Publish(statsName+"PlanCacheHits", stats.IntFunc(plr.hits.Get))
+		stats.Publish(statsName+"PlanCacheMisses", stats.IntFunc(plr.misses.Get))
+		stats.Publish(statsName+"PlanCacheHitRate", stats.FloatFunc(plr.hitRate))
+	}
 	once.Do(func() {
 		http.Handle("/debug/query_plans", plr)
 		http.Handle("/debug/vschema", plr)
+		http.Handle("/debug/reload_vschema", plr)
 	})
 	return plr
 }
@@ -68,11 +101,11 @@ func (plr *Planner) WatchVSchema(ctx context.Context) {
 		return
 	}
 
-	// mu protects formal
-	var mu sync.Mutex
-	formal := &vindexes.VSchemaFormal{
+	plr.formalMu.Lock()
+	plr.formal = &vindexes.VSchemaFormal{
 		Keyspaces: make(map[string]vindexes.KeyspaceFormal),
 	}
+	plr.formalMu.Unlock()
 
 	notifications := make(map[string]<-chan string)
 	for _, keyspace := range keyspaces {
@@ -93,20 +126,20 @@ func (plr *Planner) WatchVSchema(ctx context.Context) {
 		if err := json.Unmarshal([]byte(firstValue), &kformal); err != nil {
 			log.Warningf("Error unmarshalling vschema for keyspace %s (will keep watching it): %v", keyspace, err)
 		} else {
-			formal.Keyspaces[keyspace] = kformal
+			plr.formalMu.Lock()
+			plr.formal.Keyspaces[keyspace] = kformal
+			plr.formalMu.Unlock()
 		}
 
 		notifications[keyspace] = n
 	}
 
 	// we got the initial set of values, now build the first version
-	vschema, err := vindexes.BuildVSchema(formal)
+	plr.formalMu.Lock()
+	err = plr.buildVSchema()
+	plr.formalMu.Unlock()
 	if err != nil {
 		log.Warningf("Error creating initial VSchema (will keep watching keyspaces): %v", err)
-	} else {
-		plr.mu.Lock()
-		plr.vschema = vschema
-		plr.mu.Unlock()
 	}
 
 	processKeyspace := func(keyspace, kschema string) error {
@@ -120,19 +153,12 @@ func (plr *Planner) WatchVSchema(ctx context.Context) {
 		}
 
 		// rebuild the new component
-		mu.Lock()
-		defer mu.Unlock()
-		formal.Keyspaces[keyspace] = kformal
-		vschema, err := vindexes.BuildVSchema(formal)
-		if err != nil {
+		plr.formalMu.Lock()
+		defer plr.formalMu.Unlock()
+		plr.formal.Keyspaces[keyspace] = kformal
+		if err := plr.buildVSchema(); err != nil {
 			return fmt.Errorf("Error creating VSchema (will try again when newer parts come in): %v", err)
 		}
-
-		plr.mu.Lock()
-		plr.vschema = vschema
-		plr.mu.Unlock()
-		plr.plans.Clear()
-
 		return nil
 	}
 
@@ -148,6 +174,88 @@ func (plr *Planner) WatchVSchema(ctx context.Context) {
 	}
 }
 
+// buildVSchema builds the VSchema from formal, and replaces the
+// current one if it succeeds. formalMu must be held.
+func (plr *Planner) buildVSchema() error {
+	vschema, err := vindexes.BuildVSchema(plr.formal)
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(plr.formal)
+	if err != nil {
+		return err
+	}
+	h := fnv.New64a()
+	h.Write(b)
+
+	// The plans of the previous VSchema are cleared with it, so
+	// GetPlan doesn't cache a plan of the previous one afterwards.
+	plr.mu.Lock()
+	plr.vschema = vschema
+	plr.plans.Clear()
+	plr.mu.Unlock()
+	plr.version = fmt.Sprintf("%016x", h.Sum64())
+	return nil
+}
+
+// ReloadVSchema reads the VSchema of all the keyspaces from the
+// topology, and rebuilds the VSchema right away. The keyspaces are
+// not watched if they were not there when the Planner was created.
+// It returns the versions of the VSchema before and after the reload.
+func (plr *Planner) ReloadVSchema(ctx context.Context) (oldVersion, newVersion string, err error) {
+	keyspaces, err := plr.serv.GetSrvKeyspaceNames(ctx, plr.cell)
+	if err != nil {
+		return "", "", fmt.Errorf("could not read keyspaces: %v", err)
+	}
+	kformals := make(map[string]vindexes.KeyspaceFormal, len(keyspaces))
+	for _, keyspace := range keyspaces {
+		kformal, err := plr.readKeyspaceVSchema(ctx, keyspace)
+		if err != nil {
+			return "", "", err
+		}
+		kformals[keyspace] = kformal
+	}
+
+	plr.formalMu.Lock()
+	defer plr.formalMu.Unlock()
+	if plr.formal == nil {
+		plr.formal = &vindexes.VSchemaFormal{
+			Keyspaces: make(map[string]vindexes.KeyspaceFormal),
+		}
+	}
+	oldVersion = plr.version
+	previous := plr.formal.Keyspaces
+	plr.formal.Keyspaces = kformals
+	if err := plr.buildVSchema(); err != nil {
+		plr.formal.Keyspaces = previous
+		return oldVersion, oldVersion, fmt.Errorf("could not build VSchema: %v", err)
+	}
+	return oldVersion, plr.version, nil
+}
+
+// readKeyspaceVSchema reads the current VSchema of a keyspace.
+func (plr *Planner) readKeyspaceVSchema(ctx context.Context, keyspace string) (vindexes.KeyspaceFormal, error) {
+	var kformal vindexes.KeyspaceFormal
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	n, err := plr.serv.WatchVSchema(ctx, keyspace)
+	if err != nil {
+		return kformal, fmt.Errorf("could not read vschema for keyspace %s: %v", keyspace, err)
+	}
+	select {
+	case value, ok := <-n:
+		if !ok {
+			return kformal, fmt.Errorf("could not read vschema for keyspace %s: watch closed", keyspace)
+		}
+		if err := json.Unmarshal([]byte(value), &kformal); err != nil {
+			return kformal, fmt.Errorf("could not unmarshal vschema for keyspace %s: %v", keyspace, err)
+		}
+		return kformal, nil
+	case <-ctx.Done():
+		return kformal, fmt.Errorf("could not read vschema for keyspace %s: %v", keyspace, ctx.Err())
+	}
+}
+
 // VSchema returns the VSchema.
 func (plr *Planner) VSchema() *vindexes.VSchema {
 	plr.mu.Lock()
@@ -155,37 +263,69 @@ func (plr *Planner) VSchema() *vindexes.VSchema {
 	return plr.vschema
 }
 
+// VSchemaVersion returns the version of the VSchema, a hash of its
+// sources, or "" if it was never built.
+func (plr *Planner) VSchemaVersion() string {
+	plr.formalMu.Lock()
+	defer plr.formalMu.Unlock()
+	return plr.version
+}
+
+// planCacheKey returns the key of the plan of sql in the cache. The
+// queries are only normalized by trimming their surrounding spaces:
+// the values are expected to be bind variables.
+func planCacheKey(sql, keyspace string, tabletType topodatapb.TabletType) string {
+	return keyspace + "@" + strings.ToLower(tabletType.String()) + ":" + strings.TrimSpace(sql)
+}
+
 // GetPlan computes the plan for the given query. If one is in
 // the cache, it reuses it.
-func (plr *Planner) GetPlan(sql, keyspace string) (*engine.Plan, error) {
-	if plr.VSchema() == nil {
+func (plr *Planner) GetPlan(sql, keyspace string, tabletType topodatapb.TabletType) (*engine.Plan, error) {
+	vschema := plr.VSchema()
+	if vschema == nil {
 		return nil, errors.New("vschema not initialized")
 	}
-	key := sql
-	if keyspace != "" {
-		key = keyspace + ":" + sql
-	}
+	key := planCacheKey(sql, keyspace, tabletType)
 	if result, ok := plr.plans.Get(key); ok {
+		plr.hits.Add(1)
 		return result.(*engine.Plan), nil
 	}
+	plr.misses.Add(1)
 	plan, err := planbuilder.Build(sql, &wrappedVSchema{
-		vschema:  plr.VSchema(),
+		vschema:  vschema,
 		keyspace: keyspace,
 	})
 	if err != nil {
 		return nil, err
 	}
-	plr.plans.Set(sql, plan)
+	plr.mu.Lock()
+	if plr.vschema == vschema {
+		plr.plans.Set(key, plan)
+	}
+	plr.mu.Unlock()
 	return plan, nil
 }
 
-// ServeHTTP shows the current plans in the query cache.
+// hitRate returns the fraction of the lookups of the plans cache that
+// were hits, or 0 if there was none.
+func (plr *Planner) hitRate() float64 {
+	hits, misses := plr.hits.Get(), plr.misses.Get()
+	if hits+misses == 0 {
+		return 0
+	}
+	return float64(hits) / float64(hits+misses)
+}
+
+// ServeHTTP shows the current plans in the query cache or the
+// VSchema. A POST to /debug/reload_vschema reloads the VSchema.
 func (plr *Planner) ServeHTTP(response http.ResponseWriter, request *http.Request) {
 	if err := acl.CheckAccessHTTP(request, acl.DEBUGGING); err != nil {
 		acl.SendError(response, err)
 		return
 	}
-	if request.URL.Path == "/debug/query_plans" {
+	if request.URL.Path == "/debug/reload_vschema" {
+		plr.serveReloadVSchema(response, request)
+	} else if request.URL.Path == "/debug/query_plans" {
 		keys := plr.plans.Keys()
 		response.Header().Set("Content-Type", "text/plain")
 		response.Write([]byte(fmt.Sprintf("Length: %d\n", len(keys))))
@@ -215,6 +355,31 @@ func (plr *Planner) ServeHTTP(response http.ResponseWriter, request *http.Reques
 	}
 }
 
+func (plr *Planner) serveReloadVSchema(response http.ResponseWriter, request *http.Request) {
+	if request.Method != "POST" {
+		http.Error(response, "reload_vschema requires a POST", http.StatusMethodNotAllowed)
+		return
+	}
+	if err := acl.CheckAccessHTTP(request, acl.ADMIN); err != nil {
+		acl.SendError(response, err)
+		return
+	}
+	log.Infof("VSchema reload requested by %v", request.RemoteAddr)
+	ctx, cancel := context.WithTimeout(context.Background(), vschemaReloadTimeout)
+	defer cancel()
+	oldVersion, newVersion, err := plr.ReloadVSchema(ctx)
+	if err != nil {
+		servenv.RecordAdminHTTPAction(request, "ReloadVSchema", err.Error())
+		log.Errorf("VSchema reload failed, keeping version %v: %v", oldVersion, err)
+		http.Error(response, fmt.Sprintf("VSchema reload failed: %v", err), http.StatusInternalServerError)
+		return
+	}
+	servenv.RecordAdminHTTPAction(request, "ReloadVSchema", servenv.AdminResultOK)
+	log.Infof("VSchema reloaded: version %v -> %v", oldVersion, newVersion)
+	response.Header().Set("Content-Type", "text/plain")
+	fmt.Fprintf(response, "VSchema reloaded: version %v -> %v\n", oldVersion, newVersion)
+}
+
 type wrappedVSchema struct {
 	vschema  *vindexes.VSchema
 	keyspace string
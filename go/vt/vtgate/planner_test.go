@@ -0,0 +1,237 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/cache"
+
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+)
+
+// reloadTopo serves the VSchema of the keyspaces in vschemas.
+type reloadTopo struct {
+	sandboxTopo
+	vschemas map[string]string
+	err      error
+}
+
+func (rt *reloadTopo) GetSrvKeyspaceNames(ctx context.Context, cell string) ([]string, error) {
+	if rt.err != nil {
+		return nil, rt.err
+	}
+	var keyspaces []string
+	for keyspace := range rt.vschemas {
+		keyspaces = append(keyspaces, keyspace)
+	}
+	return keyspaces, nil
+}
+
+func (rt *reloadTopo) WatchVSchema(ctx context.Context, keyspace string) (<-chan string, error) {
+	result := make(chan string, 1)
+	result <- rt.vschemas[keyspace]
+	return result, nil
+}
+
+func newReloadPlanner(rt *reloadTopo) *Planner {
+	plr := &Planner{
+		serv:  rt,
+		cell:  "aa",
+		plans: cache.NewLRUCache(10),
+	}
+	plr.WatchVSchema(context.Background())
+	return plr
+}
+
+func TestPlannerReloadVSchema(t *testing.T) {
+	rt := &reloadTopo{
+		vschemas: map[string]string{
+			"ks1": `{"Sharded": false, "Tables": {"t1": {}}}`,
+		},
+	}
+	plr := newReloadPlanner(rt)
+	if _, ok := plr.VSchema().Keyspaces["ks1"]; !ok {
+		t.Fatalf("initial VSchema: %v, want ks1", plr.VSchema().Keyspaces)
+	}
+
+	rt.vschemas["ks2"] = `{"Sharded": false, "Tables": {"t2": {}}}`
+	oldVersion, newVersion, err := plr.ReloadVSchema(context.Background())
+	if err != nil {
+		t.Fatalf("ReloadVSchema: %v", err)
+	}
+	if oldVersion == "" || oldVersion == newVersion {
+		t.Errorf("ReloadVSchema versions: %q -> %q, want different non-empty versions", oldVersion, newVersion)
+	}
+	if _, ok := plr.VSchema().Keyspaces["ks2"]; !ok {
+		t.Errorf("reloaded VSchema: %v, want ks2", plr.VSchema().Keyspaces)
+	}
+
+	// Reloading the same VSchema keeps the version.
+	_, again, err := plr.ReloadVSchema(context.Background())
+	if err != nil {
+		t.Fatalf("ReloadVSchema: %v", err)
+	}
+	if again != newVersion {
+		t.Errorf("ReloadVSchema of the same VSchema: %q, want %q", again, newVersion)
+	}
+
+	// A topology error leaves the VSchema unchanged.
+	rt.err = errors.New("topo down")
+	_, _, err = plr.ReloadVSchema(context.Background())
+	want := "could not read keyspaces: topo down"
+	if err == nil || err.Error() != want {
+		t.Errorf("ReloadVSchema with topo error: %v, want %s", err, want)
+	}
+	if _, ok := plr.VSchema().Keyspaces["ks2"]; !ok {
+		t.Errorf("VSchema after failed reload: %v, want ks2", plr.VSchema().Keyspaces)
+	}
+}
+
+func TestPlannerServeReloadVSchema(t *testing.T) {
+	rt := &reloadTopo{
+		vschemas: map[string]string{
+			"ks1": `{"Sharded": false, "Tables": {"t1": {}}}`,
+		},
+	}
+	plr := newReloadPlanner(rt)
+
+	response := httptest.NewRecorder()
+	request, _ := http.NewRequest("GET", "/debug/reload_vschema", nil)
+	plr.ServeHTTP(response, request)
+	if response.Code != http.StatusMethodNotAllowed {
+		t.Errorf("GET /debug/reload_vschema: %v, want %v", response.Code, http.StatusMethodNotAllowed)
+	}
+
+	response = httptest.NewRecorder()
+	request, _ = http.NewRequest("POST", "/debug/reload_vschema", nil)
+	plr.ServeHTTP(response, request)
+	if response.Code != http.StatusOK || !strings.HasPrefix(response.Body.String(), "VSchema reloaded: version ") {
+		t.Errorf("POST /debug/reload_vschema: %v %q, want 200 and the versions", response.Code, response.Body.String())
+	}
+
+	rt.err = errors.New("topo down")
+	response = httptest.NewRecorder()
+	plr.ServeHTTP(response, request)
+	if response.Code != http.StatusInternalServerError {
+		t.Errorf("POST /debug/reload_vschema with topo error: %v, want %v", response.Code, http.StatusInternalServerError)
+	}
+}
+
+func TestPlannerGetPlanCache(t *testing.T) {
+	rt := &reloadTopo{
+		vschemas: map[string]string{
+			"ks1": `{"Sharded": false, "Tables": {"t1": {}}}`,
+		},
+	}
+	plr := newReloadPlanner(rt)
+	for _, keyspace := range []string{"", "ks1"} {
+		first, err := plr.GetPlan("select * from t1", keyspace, topodatapb.TabletType_MASTER)
+		if err != nil {
+			t.Fatalf("GetPlan(%q): %v", keyspace, err)
+		}
+		second, err := plr.GetPlan(" select * from t1\n", keyspace, topodatapb.TabletType_MASTER)
+		if err != nil {
+			t.Fatalf("GetPlan(%q): %v", keyspace, err)
+		}
+		if first != second {
+			t.Errorf("GetPlan(%q) was not cached: %p, then %p", keyspace, first, second)
+		}
+	}
+	// The plans of the tablet types are cached separately.
+	if _, err := plr.GetPlan("select * from t1", "", topodatapb.TabletType_REPLICA); err != nil {
+		t.Fatalf("GetPlan(REPLICA): %v", err)
+	}
+	if got, want := plr.plans.Length(), int64(3); got != want {
+		t.Errorf("cached plans: %v, want %v", got, want)
+	}
+	if got, want := plr.hits.Get(), int64(2); got != want {
+		t.Errorf("hits: %v, want %v", got, want)
+	}
+	if got, want := plr.misses.Get(), int64(3); got != want {
+		t.Errorf("misses: %v, want %v", got, want)
+	}
+	if got, want := plr.hitRate(), 0.4; got != want {
+		t.Errorf("hitRate: %v, want %v", got, want)
+	}
+
+	// A new VSchema clears the plans.
+	rt.vschemas["ks2"] = `{"Sharded": false, "Tables": {"t2": {}}}`
+	if _, _, err := plr.ReloadVSchema(context.Background()); err != nil {
+		t.Fatalf("ReloadVSchema: %v", err)
+	}
+	if got := plr.plans.Length(); got != 0 {
+		t.Errorf("cached plans after a VSchema change: %v, want 0", got)
+	}
+}
+
+func TestPlannerGetPlanEvictions(t *testing.T) {
+	plr := newReloadPlanner(&reloadTopo{
+		vschemas: map[string]string{
+			"ks1": `{"Sharded": false, "Tables": {"t1": {}}}`,
+		},
+	})
+	plr.plans.SetCapacity(2)
+	for _, sql := range []string{"select a from t1", "select b from t1", "select c from t1"} {
+		if _, err := plr.GetPlan(sql, "", topodatapb.TabletType_MASTER); err != nil {
+			t.Fatalf("GetPlan(%q): %v", sql, err)
+		}
+	}
+	if got, want := plr.plans.Evictions(), int64(1); got != want {
+		t.Errorf("evictions: %v, want %v", got, want)
+	}
+	if _, ok := plr.plans.Peek(planCacheKey("select a from t1", "", topodatapb.TabletType_MASTER)); ok {
+		t.Errorf("the least recently used plan was not evicted")
+	}
+}
+
+// benchmarkPlanCacheQueries is a hot workload of vtgate queries.
+var benchmarkPlanCacheQueries = []string{
+	"select id from user where id = :id",
+	"select id, name from user where id = :id",
+	"select user.id, user_extra.extra from user join user_extra on user.id = user_extra.user_id where user.id = :id",
+	"select id from music where id = :id",
+	"select id from user where name = :name",
+	"update user set a = :a where id = :id",
+	"update music set a = :a where id = :id",
+	"delete from user_extra where user_id = :id",
+	"select id from noauto_table where id = :id",
+	"select id, c from user where id = :id and a = :a and b = :b order by c limit 10",
+}
+
+func benchmarkVTGateExecutePlanCache(b *testing.B, capacity int64) {
+	router, _, _, _ := createRouterEnv()
+	router.planner.plans.SetCapacity(capacity)
+	defer func(router *Router) { rpcVTGate.router = router }(rpcVTGate.router)
+	rpcVTGate.router = router
+	bv := map[string]interface{}{"id": 1, "name": "foo", "a": 2, "b": 3}
+	for _, sql := range benchmarkPlanCacheQueries {
+		if _, err := rpcVTGate.Execute(context.Background(), sql, bv, "", topodatapb.TabletType_MASTER, nil, false); err != nil {
+			b.Fatalf("Execute(%q): %v", sql, err)
+		}
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		sql := benchmarkPlanCacheQueries[i%len(benchmarkPlanCacheQueries)]
+		if _, err := rpcVTGate.Execute(context.Background(), sql, bv, "", topodatapb.TabletType_MASTER, nil, false); err != nil {
+			b.Fatalf("Execute(%q): %v", sql, err)
+		}
+	}
+}
+
+func BenchmarkVTGateExecutePlanCache(b *testing.B) {
+	benchmarkVTGateExecutePlanCache(b, 10000)
+}
+
+// BenchmarkVTGateExecuteNoPlanCache plans all the queries.
+func BenchmarkVTGateExecuteNoPlanCache(b *testing.B) {
+	benchmarkVTGateExecutePlanCache(b, 0)
+}
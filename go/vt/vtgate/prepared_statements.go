@@ -0,0 +1,252 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/cache"
+	"github.com/youtube/vitess/go/sqltypes"
+	"github.com/youtube/vitess/go/sync2"
+	"github.com/youtube/vitess/go/vt/sqlparser"
+	"github.com/youtube/vitess/go/vt/vterrors"
+	"github.com/youtube/vitess/go/vt/vtgate/engine"
+	"github.com/youtube/vitess/go/vt/vtgate/vindexes"
+
+	querypb "github.com/youtube/vitess/go/vt/proto/query"
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+	vtgatepb "github.com/youtube/vitess/go/vt/proto/vtgate"
+	vtrpcpb "github.com/youtube/vitess/go/vt/proto/vtrpc"
+)
+
+var preparedStatementsCacheSize = flag.Int("prepared_statements_cache_size", 10000, "maximum number of statements prepared with Prepare that vtgate keeps. When there are more, the least recently executed ones are closed, and their ExecutePrepared fail.")
+
+// preparedStatement is a query planned by Prepare, that ExecutePrepared
+// executes without parsing and planning it again.
+type preparedStatement struct {
+	sql        string
+	keyspace   string
+	tabletType topodatapb.TabletType
+
+	// mu protects plan and vschema, the vschema plan was built
+	// with. The plan is built again when the vschema changes.
+	mu      sync.Mutex
+	plan    *engine.Plan
+	vschema *vindexes.VSchema
+}
+
+// Size is defined so that preparedStatement can be given to a
+// cache.LRUCache.
+func (ps *preparedStatement) Size() int {
+	return 1
+}
+
+// getPlan returns the plan of the statement, planned again if the
+// vschema of planner changed since it was built.
+func (ps *preparedStatement) getPlan(planner *Planner) (*engine.Plan, error) {
+	vschema := planner.VSchema()
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+	if ps.vschema == vschema {
+		return ps.plan, nil
+	}
+	plan, err := planner.GetPlan(ps.sql, ps.keyspace, ps.tabletType)
+	if err != nil {
+		return nil, err
+	}
+	ps.plan = plan
+	ps.vschema = vschema
+	return plan, nil
+}
+
+// preparedStatements are the statements prepared with Prepare, by id.
+type preparedStatements struct {
+	lastID     sync2.AtomicInt64
+	statements *cache.LRUCache
+}
+
+func newPreparedStatements(size int) *preparedStatements {
+	return &preparedStatements{
+		statements: cache.NewLRUCache(int64(size)),
+	}
+}
+
+// add stores ps, and returns its id.
+func (pss *preparedStatements) add(ps *preparedStatement) int64 {
+	id := pss.lastID.Add(1)
+	pss.statements.Set(strconv.FormatInt(id, 10), ps)
+	return id
+}
+
+// get returns the statement of id, or nil if it was closed.
+func (pss *preparedStatements) get(id int64) *preparedStatement {
+	v, ok := pss.statements.Get(strconv.FormatInt(id, 10))
+	if !ok {
+		return nil
+	}
+	return v.(*preparedStatement)
+}
+
+// close forgets the statement of id.
+func (pss *preparedStatements) close(id int64) {
+	pss.statements.Delete(strconv.FormatInt(id, 10))
+}
+
+// bindVarNames returns the names of the distinct bind variables of
+// statement, in the order of their first use.
+func bindVarNames(statement sqlparser.Statement) []string {
+	var names []string
+	seen := make(map[string]bool)
+	_ = sqlparser.Walk(func(node sqlparser.SQLNode) (bool, error) {
+		var arg []byte
+		switch node := node.(type) {
+		case sqlparser.ValArg:
+			arg = node
+		case sqlparser.ListArg:
+			arg = node
+		default:
+			return true, nil
+		}
+		name := strings.TrimLeft(string(arg), ":")
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+		return true, nil
+	}, statement)
+	return names
+}
+
+// Prepare plans sql, whose unqualified tables are in keyspace. It
+// returns the id of the statement to give to ExecutePrepared, the
+// number of its distinct bind variables, and the fields of its
+// result if it's a select. The statement is kept until it's closed
+// with ClosePrepared, or until -prepared_statements_cache_size other
+// statements were executed since its last execution.
+func (vtg *VTGate) Prepare(ctx context.Context, sql, keyspace string, tabletType topodatapb.TabletType) (id int64, paramCount int64, fields []*querypb.Field, err error) {
+	startTime := time.Now()
+	statsKey := []string{"Prepare", "Any", strings.ToLower(tabletType.String())}
+	defer vtg.timings.Record(statsKey, startTime)
+
+	id, paramCount, fields, err = vtg.prepare(ctx, sql, keyspace, tabletType)
+	if err != nil {
+		query := map[string]interface{}{
+			"Sql":        sql,
+			"Keyspace":   keyspace,
+			"TabletType": strings.ToLower(tabletType.String()),
+		}
+		handleExecuteError(err, statsKey, query, vtg.logExecute)
+		return 0, 0, nil, err
+	}
+	return id, paramCount, fields, nil
+}
+
+func (vtg *VTGate) prepare(ctx context.Context, sql, keyspace string, tabletType topodatapb.TabletType) (int64, int64, []*querypb.Field, error) {
+	statement, err := sqlparser.Parse(sql)
+	if err != nil {
+		return 0, 0, nil, vterrors.FromError(vtrpcpb.ErrorCode_BAD_INPUT, err)
+	}
+	ps := &preparedStatement{
+		sql:        sql,
+		keyspace:   keyspace,
+		tabletType: tabletType,
+	}
+	plan, err := ps.getPlan(vtg.router.planner)
+	if err != nil {
+		return 0, 0, nil, err
+	}
+	names := bindVarNames(statement)
+	var fields []*querypb.Field
+	switch statement.(type) {
+	case *sqlparser.Select, *sqlparser.Union:
+		// The fields don't depend on the values of the bind
+		// variables.
+		bindVars := make(map[string]interface{}, len(names))
+		for _, name := range names {
+			bindVars[name] = nil
+		}
+		if plan.LockingRead {
+			tabletType = topodatapb.TabletType_MASTER
+		}
+		vcursor := newRequestContext(ctx, sql, bindVars, keyspace, tabletType, nil, false, vtg.router)
+		qr, err := plan.Instructions.GetFields(vcursor, make(map[string]interface{}))
+		if err != nil {
+			return 0, 0, nil, fmt.Errorf("cannot get the fields of %v: %v", sql, err)
+		}
+		fields = qr.Fields
+	}
+	return vtg.prepared.add(ps), int64(len(names)), fields, nil
+}
+
+// ExecutePrepared executes the statement of id, prepared with Prepare,
+// with bindVariables. Like Execute, it can change session.
+func (vtg *VTGate) ExecutePrepared(ctx context.Context, id int64, bindVariables map[string]interface{}, session *vtgatepb.Session, notInTransaction bool) (*sqltypes.Result, error) {
+	startTime := time.Now()
+	ps := vtg.prepared.get(id)
+	if ps == nil {
+		statsKey := []string{"ExecutePrepared", "Any", "unknown"}
+		defer vtg.timings.Record(statsKey, startTime)
+		normalErrors.Add(statsKey, 1)
+		return nil, vterrors.FromError(vtrpcpb.ErrorCode_BAD_INPUT, fmt.Errorf("unknown prepared statement %v: it was closed, or evicted from the cache", id))
+	}
+	statsKey := []string{"ExecutePrepared", "Any", strings.ToLower(ps.tabletType.String())}
+	defer vtg.timings.Record(statsKey, startTime)
+
+	x := vtg.inFlight.Add(1)
+	defer vtg.inFlight.Add(-1)
+	if 0 < vtg.maxInFlight && vtg.maxInFlight < x {
+		return nil, errTooManyInFlight
+	}
+	logStats, ctx := NewLogStats(ctx, "ExecutePrepared", ps.sql, ps.keyspace, strings.ToLower(ps.tabletType.String()))
+	defer logStats.Send()
+
+	plan, err := ps.getPlan(vtg.router.planner)
+	if err != nil {
+		logStats.Error = err
+		handleExecuteError(err, statsKey, map[string]interface{}{"Sql": ps.sql, "Keyspace": ps.keyspace}, vtg.logExecute)
+		return nil, err
+	}
+	ctx = withGeoHint(ctx, ps.sql)
+	ctx, lockWaitTimeout := withLockWaitTimeoutHint(ctx, ps.sql)
+	ctx, cancel, timeoutRule := vtg.withPlanTimeout(ctx, vtg.queryTimeouts(), plan, ps.keyspace)
+	defer cancel()
+
+	qr, err := vtg.router.ExecutePlan(ctx, plan, ps.sql, bindVariables, ps.keyspace, ps.tabletType, session, notInTransaction)
+	if err == nil {
+		vtg.rowsReturned.Add(statsKey, int64(len(qr.Rows)))
+		logStats.RowsReturned = len(qr.Rows)
+		return qr, nil
+	}
+	logStats.Error = err
+
+	query := map[string]interface{}{
+		"Sql":              ps.sql,
+		"BindVariables":    bindVariables,
+		"Keyspace":         ps.keyspace,
+		"Timeout":          timeoutRule,
+		"LockWaitTimeout":  lockWaitTimeout,
+		"TabletType":       strings.ToLower(ps.tabletType.String()),
+		"Session":          session,
+		"NotInTransaction": notInTransaction,
+	}
+	handleExecuteError(err, statsKey, query, vtg.logExecute)
+	return nil, err
+}
+
+// ClosePrepared closes the statements of ids, prepared with Prepare.
+// The ids of the statements already closed are ignored.
+func (vtg *VTGate) ClosePrepared(ctx context.Context, ids []int64) error {
+	for _, id := range ids {
+		vtg.prepared.close(id)
+	}
+	return nil
+}
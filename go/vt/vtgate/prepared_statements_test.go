@@ -0,0 +1,174 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/vt/sqlparser"
+	"github.com/youtube/vitess/go/vt/tabletserver/querytypes"
+
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+)
+
+func TestBindVarNames(t *testing.T) {
+	testcases := []struct {
+		sql  string
+		want []string
+	}{{
+		sql:  "select id from user where id = :id and name = :name or id = :id",
+		want: []string{"id", "name"},
+	}, {
+		sql:  "select id from user where id in ::ids",
+		want: []string{"ids"},
+	}, {
+		sql:  "update user set a = :a where id = :id",
+		want: []string{"a", "id"},
+	}, {
+		sql:  "select id from user",
+		want: nil,
+	}}
+	for _, tcase := range testcases {
+		statement, err := sqlparser.Parse(tcase.sql)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := bindVarNames(statement); !reflect.DeepEqual(got, tcase.want) {
+			t.Errorf("bindVarNames(%q): %v, want %v", tcase.sql, got, tcase.want)
+		}
+	}
+}
+
+func TestVTGatePrepared(t *testing.T) {
+	router, sbc1, _, _ := createRouterEnv()
+	defer func(router *Router) { rpcVTGate.router = router }(rpcVTGate.router)
+	rpcVTGate.router = router
+	ctx := context.Background()
+
+	id, paramCount, fields, err := rpcVTGate.Prepare(ctx, "select id from user where id = :id", "", topodatapb.TabletType_MASTER)
+	if err != nil {
+		t.Fatalf("Prepare: %v", err)
+	}
+	if paramCount != 1 {
+		t.Errorf("Prepare param count: %v, want 1", paramCount)
+	}
+	if !reflect.DeepEqual(fields, singleRowResult.Fields) {
+		t.Errorf("Prepare fields: %+v, want %+v", fields, singleRowResult.Fields)
+	}
+	wantFieldQuery := []querytypes.BoundQuery{{
+		Sql:           "select id from user where 1 != 1",
+		BindVariables: map[string]interface{}{"id": nil},
+	}}
+	if !reflect.DeepEqual(sbc1.Queries, wantFieldQuery) {
+		t.Errorf("sbc1.Queries: %+v, want %+v", sbc1.Queries, wantFieldQuery)
+	}
+	sbc1.Queries = nil
+
+	qr, err := rpcVTGate.ExecutePrepared(ctx, id, map[string]interface{}{"id": 1}, nil, false)
+	if err != nil {
+		t.Fatalf("ExecutePrepared: %v", err)
+	}
+	if !reflect.DeepEqual(qr, singleRowResult) {
+		t.Errorf("ExecutePrepared: %+v, want %+v", qr, singleRowResult)
+	}
+	wantQueries := []querytypes.BoundQuery{{
+		Sql:           "select id from user where id = :id",
+		BindVariables: map[string]interface{}{"id": 1},
+	}}
+	if !reflect.DeepEqual(sbc1.Queries, wantQueries) {
+		t.Errorf("sbc1.Queries: %+v, want %+v", sbc1.Queries, wantQueries)
+	}
+
+	// A new vschema makes the statement plan the query again.
+	plan := rpcVTGate.prepared.get(id).plan
+	router.planner.formalMu.Lock()
+	err = router.planner.buildVSchema()
+	router.planner.formalMu.Unlock()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rpcVTGate.ExecutePrepared(ctx, id, map[string]interface{}{"id": 1}, nil, false); err != nil {
+		t.Fatalf("ExecutePrepared after a new vschema: %v", err)
+	}
+	if rpcVTGate.prepared.get(id).plan == plan {
+		t.Errorf("the plan wasn't built again with the new vschema")
+	}
+
+	if err := rpcVTGate.ClosePrepared(ctx, []int64{id}); err != nil {
+		t.Fatalf("ClosePrepared: %v", err)
+	}
+	_, err = rpcVTGate.ExecutePrepared(ctx, id, map[string]interface{}{"id": 1}, nil, false)
+	want := "unknown prepared statement"
+	if err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("ExecutePrepared of a closed statement: %v, want %v", err, want)
+	}
+
+	// The DMLs have no fields.
+	id, paramCount, fields, err = rpcVTGate.Prepare(ctx, "update user set a = :a where id = :id", "", topodatapb.TabletType_MASTER)
+	if err != nil {
+		t.Fatalf("Prepare: %v", err)
+	}
+	if paramCount != 2 || fields != nil {
+		t.Errorf("Prepare of an update: %v, %v, want 2, nil", paramCount, fields)
+	}
+	rpcVTGate.ClosePrepared(ctx, []int64{id})
+
+	if _, _, _, err := rpcVTGate.Prepare(ctx, "select id from nonexistent where id = :id", "", topodatapb.TabletType_MASTER); err == nil {
+		t.Errorf("Prepare of a query that can't be planned: nil, want error")
+	}
+}
+
+func TestPreparedStatementsEviction(t *testing.T) {
+	pss := newPreparedStatements(2)
+	id1 := pss.add(&preparedStatement{sql: "select 1"})
+	id2 := pss.add(&preparedStatement{sql: "select 2"})
+	pss.get(id1)
+	pss.add(&preparedStatement{sql: "select 3"})
+	if pss.get(id1) == nil {
+		t.Errorf("the most recently used statement was evicted")
+	}
+	if pss.get(id2) != nil {
+		t.Errorf("the least recently used statement was not evicted")
+	}
+}
+
+// benchmarkPointLookup is the query of the prepared statement
+// benchmarks.
+const benchmarkPointLookup = "select id from user where id = :id"
+
+func BenchmarkVTGateExecutePointLookup(b *testing.B) {
+	router, _, _, _ := createRouterEnv()
+	defer func(router *Router) { rpcVTGate.router = router }(rpcVTGate.router)
+	rpcVTGate.router = router
+	bv := map[string]interface{}{"id": 1}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := rpcVTGate.Execute(context.Background(), benchmarkPointLookup, bv, "", topodatapb.TabletType_MASTER, nil, false); err != nil {
+			b.Fatalf("Execute: %v", err)
+		}
+	}
+}
+
+func BenchmarkVTGateExecutePreparedPointLookup(b *testing.B) {
+	router, _, _, _ := createRouterEnv()
+	defer func(router *Router) { rpcVTGate.router = router }(rpcVTGate.router)
+	rpcVTGate.router = router
+	id, _, _, err := rpcVTGate.Prepare(context.Background(), benchmarkPointLookup, "", topodatapb.TabletType_MASTER)
+	if err != nil {
+		b.Fatalf("Prepare: %v", err)
+	}
+	defer rpcVTGate.ClosePrepared(context.Background(), []int64{id})
+	bv := map[string]interface{}{"id": 1}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := rpcVTGate.ExecutePrepared(context.Background(), id, bv, nil, false); err != nil {
+			b.Fatalf("ExecutePrepared: %v", err)
+		}
+	}
+}
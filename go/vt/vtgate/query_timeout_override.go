@@ -0,0 +1,139 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	log "github.com/golang/glog"
+
+	"github.com/youtube/vitess/go/acl"
+	"github.com/youtube/vitess/go/vt/servenv"
+)
+
+var debugTimeoutOverrideTTL = flag.Duration("debug_timeout_override_ttl", 5*time.Minute, "how long the query timeout set on /debug/set_query_timeout_override lasts. It then expires, so that a timeout lowered for a load test is not kept by accident.")
+
+// timeoutOverride is the query timeout set on
+// /debug/set_query_timeout_override, for the load tests of the timeout
+// handling.
+var timeoutOverride queryTimeoutOverride
+
+// queryTimeoutOverride is a timeout of all the queries that overrides
+// -query_timeout, -keyspace_timeout_overrides and -query-timeouts
+// until it expires.
+type queryTimeoutOverride struct {
+	mu      sync.Mutex
+	timeout time.Duration
+	expiry  time.Time
+	// timer expires the override. It's nil if there's none.
+	timer *time.Timer
+}
+
+// set overrides the query timeout with timeout for ttl, replacing the
+// previous override.
+func (o *queryTimeoutOverride) set(timeout, ttl time.Duration) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.timer != nil {
+		o.timer.Stop()
+	}
+	o.timeout = timeout
+	o.expiry = time.Now().Add(ttl)
+	var timer *time.Timer
+	timer = time.AfterFunc(ttl, func() {
+		o.mu.Lock()
+		defer o.mu.Unlock()
+		// A later override replaced this one.
+		if o.timer != timer {
+			return
+		}
+		log.Infof("Query timeout override of %v expired, the configured timeouts apply again", o.timeout)
+		o.timer = nil
+	})
+	o.timer = timer
+	log.Warningf("Query timeout overridden with %v for all queries, until %v", timeout, o.expiry.Format(time.RFC3339))
+}
+
+// clear removes the override before it expires.
+func (o *queryTimeoutOverride) clear() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.timer == nil {
+		return
+	}
+	o.timer.Stop()
+	o.timer = nil
+	log.Infof("Query timeout override of %v cleared, the configured timeouts apply again", o.timeout)
+}
+
+// get returns the timeout of the override, and the rule it comes from.
+// ok is false if there's no override.
+func (o *queryTimeoutOverride) get() (timeout time.Duration, rule string, ok bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.timer == nil {
+		return 0, "", false
+	}
+	return o.timeout, fmt.Sprintf("debug_query_timeout=%v", o.timeout), true
+}
+
+// String returns the state of the override, for
+// /debug/set_query_timeout_override.
+func (o *queryTimeoutOverride) String() string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.timer == nil {
+		return "no query timeout override"
+	}
+	return fmt.Sprintf("query timeout override: %v, expires at %v", o.timeout, o.expiry.Format(time.RFC3339))
+}
+
+func init() {
+	setHandler := servenv.AuditHTTP("SetQueryTimeoutOverride", func(w http.ResponseWriter, r *http.Request) {
+		queryTimeoutOverrideHandler(&timeoutOverride, *debugTimeoutOverrideTTL, w, r)
+	})
+	http.HandleFunc("/debug/set_query_timeout_override", func(w http.ResponseWriter, r *http.Request) {
+		// Only the requests that change the override are audited.
+		if r.FormValue("timeout_ms") == "" {
+			queryTimeoutOverrideHandler(&timeoutOverride, *debugTimeoutOverrideTTL, w, r)
+			return
+		}
+		setHandler(w, r)
+	})
+}
+
+// queryTimeoutOverrideHandler shows the query timeout override. If the
+// "timeout_ms" param is set, it overrides the timeout of all the
+// queries with it for ttl first. 0 clears the override.
+func queryTimeoutOverrideHandler(o *queryTimeoutOverride, ttl time.Duration, w http.ResponseWriter, r *http.Request) {
+	value := r.FormValue("timeout_ms")
+	role := acl.DEBUGGING
+	if value != "" {
+		role = acl.ADMIN
+	}
+	if err := acl.CheckAccessHTTP(r, role); err != nil {
+		acl.SendError(w, err)
+		return
+	}
+	if value != "" {
+		ms, err := strconv.Atoi(value)
+		if err != nil || ms < 0 {
+			http.Error(w, fmt.Sprintf("invalid timeout_ms %q", value), http.StatusBadRequest)
+			return
+		}
+		if ms == 0 {
+			o.clear()
+		} else {
+			o.set(time.Duration(ms)*time.Millisecond, ttl)
+		}
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	fmt.Fprintf(w, "%v\n", o)
+}
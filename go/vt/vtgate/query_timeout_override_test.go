@@ -0,0 +1,101 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/clock"
+)
+
+func TestQueryTimeoutOverride(t *testing.T) {
+	defer func(timeout time.Duration, timeouts map[string]time.Duration) {
+		*queryTimeout = timeout
+		keyspaceTimeouts = timeouts
+		timeoutOverride.clear()
+	}(*queryTimeout, keyspaceTimeouts)
+	*queryTimeout = time.Hour
+	keyspaceTimeouts = map[string]time.Duration{"analytics_ks": 2 * time.Hour}
+
+	// The override applies to all the keyspaces.
+	timeoutOverride.set(50*time.Millisecond, time.Hour)
+	for _, keyspace := range []string{"analytics_ks", "oltp_ks"} {
+		start := time.Now()
+		ctx, cancel, rule := withKeyspaceTimeout(context.Background(), clock.Real, keyspace)
+		deadline, ok := ctx.Deadline()
+		cancel()
+		if !ok || deadline.Sub(start) > time.Minute {
+			t.Errorf("%v: deadline in %v, want 50ms", keyspace, deadline.Sub(start))
+		}
+		if want := "debug_query_timeout=50ms"; rule != want {
+			t.Errorf("%v: rule %q, want %q", keyspace, rule, want)
+		}
+	}
+
+	// Once cleared, the configured timeouts apply again.
+	timeoutOverride.clear()
+	if _, rule := keyspaceTimeout("oltp_ks"); rule != "query_timeout=1h0m0s" {
+		t.Errorf("rule after clear: %q, want query_timeout=1h0m0s", rule)
+	}
+
+	// The override expires after its ttl.
+	timeoutOverride.set(50*time.Millisecond, 10*time.Millisecond)
+	for start := time.Now(); ; time.Sleep(time.Millisecond) {
+		if _, _, ok := timeoutOverride.get(); !ok {
+			break
+		}
+		if time.Since(start) > 10*time.Second {
+			t.Fatalf("the override did not expire")
+		}
+	}
+	if _, rule := keyspaceTimeout("analytics_ks"); rule != "keyspace_timeout_overrides[analytics_ks]=2h0m0s" {
+		t.Errorf("rule after expiry: %q, want keyspace_timeout_overrides[analytics_ks]=2h0m0s", rule)
+	}
+
+	// A new override replaces the previous one, and its ttl.
+	timeoutOverride.set(time.Second, 10*time.Millisecond)
+	timeoutOverride.set(2*time.Second, time.Hour)
+	time.Sleep(50 * time.Millisecond)
+	if timeout, _, ok := timeoutOverride.get(); !ok || timeout != 2*time.Second {
+		t.Errorf("get: %v, %v, want 2s, true", timeout, ok)
+	}
+}
+
+func TestQueryTimeoutOverrideHandler(t *testing.T) {
+	o := &queryTimeoutOverride{}
+	defer o.clear()
+
+	req, _ := http.NewRequest("GET", "/debug/set_query_timeout_override?timeout_ms=250", nil)
+	response := httptest.NewRecorder()
+	queryTimeoutOverrideHandler(o, time.Hour, response, req)
+	if body, want := response.Body.String(), "query timeout override: 250ms, expires at "; !strings.HasPrefix(body, want) {
+		t.Errorf("got body %q, want %q...", body, want)
+	}
+	if timeout, _, ok := o.get(); !ok || timeout != 250*time.Millisecond {
+		t.Errorf("get: %v, %v, want 250ms, true", timeout, ok)
+	}
+
+	for _, value := range []string{"soon", "-1"} {
+		req, _ = http.NewRequest("GET", "/debug/set_query_timeout_override?timeout_ms="+value, nil)
+		response = httptest.NewRecorder()
+		queryTimeoutOverrideHandler(o, time.Hour, response, req)
+		if response.Code != http.StatusBadRequest {
+			t.Errorf("timeout_ms=%v: got code %d, want %d", value, response.Code, http.StatusBadRequest)
+		}
+	}
+
+	req, _ = http.NewRequest("GET", "/debug/set_query_timeout_override?timeout_ms=0", nil)
+	response = httptest.NewRecorder()
+	queryTimeoutOverrideHandler(o, time.Hour, response, req)
+	if body, want := response.Body.String(), "no query timeout override\n"; body != want {
+		t.Errorf("got body %q, want %q", body, want)
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/clock"
+	"github.com/youtube/vitess/go/vt/vtgate/engine"
+
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+)
+
+var queryTimeoutOverrides = flag.String("query-timeouts", "", "comma separated list of plan type=timeout that overrides -keyspace_timeout_overrides and -query_timeout for the queries vtgate plans, e.g. SelectScatter=30s,Select=5s. The plan types are the route opcodes, like SelectEqualUnique, Join, or Select, Insert, Update and Delete for all the plans of these statements.")
+
+// planTypes has the plan types that can be given a timeout, besides
+// the route opcodes.
+var planTypes = map[string]bool{
+	"Join":   true,
+	"Select": true,
+	"Insert": true,
+	"Update": true,
+	"Delete": true,
+}
+
+func init() {
+	for code := engine.SelectUnsharded; code < engine.NumCodes; code++ {
+		planTypes[code.String()] = true
+	}
+}
+
+// parseQueryTimeouts parses the value of -query-timeouts.
+func parseQueryTimeouts(value string) (map[string]time.Duration, error) {
+	if value == "" {
+		return nil, nil
+	}
+	timeouts := make(map[string]time.Duration)
+	for _, entry := range strings.Split(value, ",") {
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid query timeout %q, want plan type=timeout", entry)
+		}
+		planType := strings.TrimSpace(parts[0])
+		if !planTypes[planType] {
+			return nil, fmt.Errorf("unknown plan type %q in query timeout %q", planType, entry)
+		}
+		timeout, err := time.ParseDuration(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid timeout for plan type %v: %v", planType, err)
+		}
+		timeouts[planType] = timeout
+	}
+	return timeouts, nil
+}
+
+// planTypesOf returns the types of plan, the most specific first: the
+// opcode of a route, or Join, and the statement.
+func planTypesOf(plan *engine.Plan) []string {
+	switch p := plan.Instructions.(type) {
+	case *engine.Route:
+		name := p.Opcode.String()
+		for _, statement := range []string{"Select", "Insert", "Update", "Delete"} {
+			if strings.HasPrefix(name, statement) {
+				return []string{name, statement}
+			}
+		}
+		return []string{name}
+	case *engine.Join:
+		return []string{"Join", "Select"}
+	}
+	return nil
+}
+
+// planTimeout returns the timeout of the queries of plan, and the rule
+// it comes from. ok is false if no timeout of timeouts applies.
+func planTimeout(timeouts map[string]time.Duration, plan *engine.Plan) (timeout time.Duration, rule string, ok bool) {
+	for _, planType := range planTypesOf(plan) {
+		if timeout, ok := timeouts[planType]; ok {
+			return timeout, fmt.Sprintf("query-timeouts[%v]=%v", planType, timeout), true
+		}
+	}
+	return 0, "", false
+}
+
+// planKeyspaces returns the keyspaces the routes of plan are sent to.
+func planKeyspaces(plan *engine.Plan) []string {
+	var keyspaces []string
+	seen := make(map[string]bool)
+	var visit func(engine.Primitive)
+	visit = func(primitive engine.Primitive) {
+		switch p := primitive.(type) {
+		case *engine.Route:
+			if p.Keyspace != nil && !seen[p.Keyspace.Name] {
+				seen[p.Keyspace.Name] = true
+				keyspaces = append(keyspaces, p.Keyspace.Name)
+			}
+		case *engine.Join:
+			visit(p.Left)
+			visit(p.Right)
+		}
+	}
+	visit(plan.Instructions)
+	return keyspaces
+}
+
+// withQueryTimeout returns a context with the timeout of the plan of
+// sql in QueryTimeouts, or the timeout of keyspace if there's none.
+// If keyspace is empty, the keyspaces of the plan are used. A timeout
+// of 0 in QueryTimeouts means no timeout. See withKeyspaceTimeout.
+// It also returns the plan of sql if it got it, or nil, so that the
+// router doesn't get it again.
+func (vtg *VTGate) withQueryTimeout(ctx context.Context, sql, keyspace string, tabletType topodatapb.TabletType) (context.Context, context.CancelFunc, string, *engine.Plan) {
+	timeouts := vtg.queryTimeouts()
+	if len(timeouts) != 0 || keyspace == "" {
+		// The errors are returned when the router gets the plan.
+		if plan, err := vtg.router.planner.GetPlan(sql, keyspace, tabletType); err == nil {
+			ctx, cancel, rule := vtg.withPlanTimeout(ctx, timeouts, plan, keyspace)
+			return ctx, cancel, rule, plan
+		}
+	}
+	ctx, cancel, rule := withKeyspaceTimeout(ctx, vtg.clock, keyspace)
+	return ctx, cancel, rule, nil
+}
+
+// withPlanTimeout returns a context with the timeout of timeouts for
+// plan, or the timeout of keyspace if none applies, its cancel
+// function, and the rule the timeout comes from. If keyspace is
+// empty, the timeout of the keyspaces of plan applies.
+func (vtg *VTGate) withPlanTimeout(ctx context.Context, timeouts map[string]time.Duration, plan *engine.Plan, keyspace string) (context.Context, context.CancelFunc, string) {
+	if timeout, rule, ok := planTimeout(timeouts, plan); ok {
+		if timeout == 0 {
+			return ctx, func() {}, rule
+		}
+		ctx, cancel := clock.WithTimeout(ctx, vtg.clock, timeout)
+		return ctx, cancel, rule
+	}
+	if keyspace == "" {
+		if keyspaces := planKeyspaces(plan); len(keyspaces) != 0 {
+			return withKeyspacesTimeout(ctx, vtg.clock, keyspaces)
+		}
+	}
+	return withKeyspaceTimeout(ctx, vtg.clock, keyspace)
+}
+
+// queryTimeouts returns the timeouts by plan type that apply, nil if
+// the override of /debug/timeout_override replaces them.
+func (vtg *VTGate) queryTimeouts() map[string]time.Duration {
+	configMu.RLock()
+	timeouts := vtg.QueryTimeouts
+	configMu.RUnlock()
+	if _, _, ok := timeoutOverride.get(); ok {
+		// The override applies to all the queries.
+		return nil
+	}
+	return timeouts
+}
@@ -0,0 +1,168 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/clock/fakeclock"
+	"github.com/youtube/vitess/go/sqltypes"
+	"github.com/youtube/vitess/go/vt/topo"
+
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+)
+
+func TestParseQueryTimeouts(t *testing.T) {
+	got, err := parseQueryTimeouts("SelectScatter=30s, Select=5s,Join=1m")
+	if err != nil {
+		t.Fatalf("parseQueryTimeouts: %v", err)
+	}
+	want := map[string]time.Duration{"SelectScatter": 30 * time.Second, "Select": 5 * time.Second, "Join": time.Minute}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseQueryTimeouts: %v, want %v", got, want)
+	}
+
+	if got, err := parseQueryTimeouts(""); got != nil || err != nil {
+		t.Errorf("parseQueryTimeouts(\"\"): %v, %v, want nil, nil", got, err)
+	}
+	for _, value := range []string{"SelectScatter", "SelectScatter=soon", "Scatter=5s", "Select=5s,"} {
+		if _, err := parseQueryTimeouts(value); err == nil {
+			t.Errorf("parseQueryTimeouts(%v): nil, want error", value)
+		}
+	}
+}
+
+func TestPlanTimeout(t *testing.T) {
+	router, _, _, _ := createRouterEnv()
+	timeouts := map[string]time.Duration{
+		"SelectScatter": 30 * time.Second,
+		"Select":        5 * time.Second,
+	}
+	testcases := []struct {
+		sql  string
+		rule string
+	}{{
+		sql:  "select id from user",
+		rule: "query-timeouts[SelectScatter]=30s",
+	}, {
+		sql:  "select id from user where id = 1",
+		rule: "query-timeouts[Select]=5s",
+	}, {
+		sql:  "select user.id from user join user_extra",
+		rule: "query-timeouts[Select]=5s",
+	}, {
+		sql:  "update user set a = 1 where id = 1",
+		rule: "",
+	}}
+	for _, tcase := range testcases {
+		plan, err := router.planner.GetPlan(tcase.sql, "", topodatapb.TabletType_MASTER)
+		if err != nil {
+			t.Errorf("GetPlan(%v): %v", tcase.sql, err)
+			continue
+		}
+		_, rule, ok := planTimeout(timeouts, plan)
+		if rule != tcase.rule || ok != (tcase.rule != "") {
+			t.Errorf("planTimeout(%v): %q, %v, want %q", tcase.sql, rule, ok, tcase.rule)
+		}
+	}
+}
+
+func TestVTGateQueryTimeouts(t *testing.T) {
+	defer func(timeout time.Duration, timeouts map[string]time.Duration) {
+		*queryTimeout = timeout
+		keyspaceTimeouts = timeouts
+	}(*queryTimeout, keyspaceTimeouts)
+	*queryTimeout = 5 * time.Second
+	keyspaceTimeouts = nil
+
+	// Special setup: Don't use createRouterEnv, the scatter query
+	// needs all the shards. The tablets only answer when the query
+	// times out.
+	s := createSandbox("TestRouter")
+	s.VSchema = routerVSchema
+	getSandbox(KsTestUnsharded).VSchema = unshardedVSchema
+	for _, shard := range []string{"-20", "20-40", "40-60", "60-80", "80-a0", "a0-c0", "c0-e0", "e0-"} {
+		s.MapTestConn(shard, &sandboxConn{mustDelay: time.Hour})
+	}
+	serv := new(sandboxTopo)
+	scatterConn := NewScatterConn(nil, topo.Server{}, serv, "", "aa", 1*time.Second, 10, 2*time.Millisecond, 1*time.Millisecond, 24*time.Hour, nil, "")
+	fc := fakeclock.New(time.Now())
+	vtg := *rpcVTGate
+	vtg.router = NewRouter(context.Background(), serv, "aa", "", scatterConn)
+	vtg.clock = fc
+
+	// checkTimeout checks that sql times out after timeout, and not
+	// before shorter.
+	checkTimeout := func(sql string, shorter, timeout time.Duration) {
+		done := make(chan error, 1)
+		go func() {
+			_, err := vtg.Execute(context.Background(), sql, nil, "", topodatapb.TabletType_MASTER, nil, false)
+			done <- err
+		}()
+		// Wait for the timeout of the query to be set.
+		fc.BlockUntil(1)
+		fc.Advance(shorter)
+		select {
+		case err := <-done:
+			t.Errorf("%v returned after %v: %v, want a timeout after %v", sql, shorter, err, timeout)
+			return
+		default:
+		}
+		fc.Advance(timeout - shorter)
+		if err := <-done; err == nil {
+			t.Errorf("%v: nil, want a timeout error", sql)
+		}
+	}
+
+	// The scatter query has its own timeout, and the point lookup the
+	// global one.
+	vtg.QueryTimeouts = map[string]time.Duration{"SelectScatter": 10 * time.Millisecond}
+	checkTimeout("select id from user", 0, 10*time.Millisecond)
+	checkTimeout("select id from user where id = 1", 10*time.Millisecond, 5*time.Second)
+
+	// The override can be longer than the global timeout.
+	*queryTimeout = 10 * time.Millisecond
+	vtg.QueryTimeouts = map[string]time.Duration{"SelectScatter": 5 * time.Second}
+	checkTimeout("select id from user", 10*time.Millisecond, 5*time.Second)
+	checkTimeout("select id from user where id = 1", 0, 10*time.Millisecond)
+
+	// Without a plan timeout, the timeout of the keyspace of the plan
+	// applies, though the query has no keyspace.
+	vtg.QueryTimeouts = nil
+	keyspaceTimeouts = map[string]time.Duration{"TestRouter": 5 * time.Second}
+	checkTimeout("select id from user where id = 1", 10*time.Millisecond, 5*time.Second)
+}
+
+func TestVTGateQueryTimeoutsGetPlanOnce(t *testing.T) {
+	router, _, _, _ := createRouterEnv()
+	vtg := *rpcVTGate
+	vtg.router = router
+	lookups := func() int64 { return router.planner.hits.Get() + router.planner.misses.Get() }
+
+	// The plan is needed with plan timeouts, and without them to find
+	// the keyspace of the query.
+	sql := "select id from user where id = 1"
+	for _, timeouts := range []map[string]time.Duration{{"SelectEqualUnique": time.Hour}, nil} {
+		vtg.QueryTimeouts = timeouts
+		before := lookups()
+		if _, err := vtg.Execute(context.Background(), sql, nil, "", topodatapb.TabletType_MASTER, nil, false); err != nil {
+			t.Fatalf("Execute with %v: %v", timeouts, err)
+		}
+		if got := lookups() - before; got != 1 {
+			t.Errorf("Execute with %v got the plan %v times, want 1", timeouts, got)
+		}
+		before = lookups()
+		if err := vtg.StreamExecute(context.Background(), sql, nil, "", topodatapb.TabletType_MASTER, func(*sqltypes.Result) error { return nil }); err != nil {
+			t.Fatalf("StreamExecute with %v: %v", timeouts, err)
+		}
+		if got := lookups() - before; got != 1 {
+			t.Errorf("StreamExecute with %v got the plan %v times, want 1", timeouts, got)
+		}
+	}
+}
@@ -16,6 +16,7 @@ import (
 	log "github.com/golang/glog"
 
 	"github.com/youtube/vitess/go/stats"
+	"github.com/youtube/vitess/go/timer"
 	"github.com/youtube/vitess/go/vt/topo"
 	"golang.org/x/net/context"
 
@@ -26,8 +27,50 @@ var (
 	srvTopoCacheTTL    = flag.Duration("srv_topo_cache_ttl", 1*time.Second, "how long to use cached entries for topology")
 	enableRemoteMaster = flag.Bool("enable_remote_master", false, "enable remote master access")
 	srvTopoTimeout     = flag.Duration("srv_topo_timeout", 2*time.Second, "topo server timeout")
+
+	topoCacheTTLs            = flag.String("topo-cache-ttl", "", "comma separated list of entry type=ttl that overrides -srv_topo_cache_ttl for the entries of this type, e.g. EndPoints=1s,SrvKeyspaceNames=1m. The entry types are SrvKeyspaceNames, SrvShard and EndPoints. The SrvKeyspace entries and the VSchema have no ttl, they are watched.")
+	topoCacheRefreshInterval = flag.Duration("topo-cache-refresh-interval", 0, "how often the expired entries of the topology cache are fetched again in the background, so that the queries don't wait for the topo server. 0 disables the background refresh, the entries are then fetched again when they are used.")
 )
 
+// topoCacheIdleTTLs is how many ttls an entry can go unread before the
+// background refresh evicts it from the cache, instead of fetching it
+// again.
+const topoCacheIdleTTLs = 3
+
+// The types of the entries of the topology cache.
+const (
+	srvKeyspaceNamesEntryType = "SrvKeyspaceNames"
+	srvKeyspaceEntryType      = "SrvKeyspace"
+	srvShardEntryType         = "SrvShard"
+	endPointsEntryType        = "EndPoints"
+)
+
+// parseTopoCacheTTLs parses the value of -topo-cache-ttl.
+func parseTopoCacheTTLs(value string) (map[string]time.Duration, error) {
+	if value == "" {
+		return nil, nil
+	}
+	ttls := make(map[string]time.Duration)
+	for _, entry := range strings.Split(value, ",") {
+		parts := strings.SplitN(entry, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid topo cache ttl %q, want entry type=ttl", entry)
+		}
+		entryType := strings.TrimSpace(parts[0])
+		switch entryType {
+		case srvKeyspaceNamesEntryType, srvShardEntryType, endPointsEntryType:
+		default:
+			return nil, fmt.Errorf("unknown entry type %q in topo cache ttl %q, want %v, %v or %v", entryType, entry, srvKeyspaceNamesEntryType, srvShardEntryType, endPointsEntryType)
+		}
+		ttl, err := time.ParseDuration(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid ttl for entry type %v: %v", entryType, err)
+		}
+		ttls[entryType] = ttl
+	}
+	return ttls, nil
+}
+
 const (
 	queryCategory       = "query"
 	cachedCategory      = "cached"
@@ -41,10 +84,15 @@ const (
 // - limit the QPS to the underlying topo.Server
 // - return the last known value of the data if there is an error
 type ResilientSrvTopoServer struct {
-	topoServer         topo.Server
+	topoServer topo.Server
+	// cacheTTL is the ttl of the entry types that are not in cacheTTLs.
 	cacheTTL           time.Duration
+	cacheTTLs          map[string]time.Duration
 	enableRemoteMaster bool
 	counts             *stats.Counters
+	// cacheCounts counts the cache hits, misses and topo server
+	// errors by entry type.
+	cacheCounts *stats.MultiCounters
 
 	// mutex protects the cache map itself, not the individual
 	// values in the cache.
@@ -56,6 +104,10 @@ type ResilientSrvTopoServer struct {
 
 	// GetEndPoints stats.
 	endPointCounters *endPointCounters
+
+	// refreshTimer runs refreshExpired, it's nil if the background
+	// refresh is disabled.
+	refreshTimer *timer.Timer
 }
 
 type endPointCounters struct {
@@ -97,9 +149,12 @@ type srvKeyspaceNamesEntry struct {
 	mutex sync.Mutex
 
 	insertionTime time.Time
-	value         []string
-	lastError     error
-	lastErrorCtx  context.Context
+	// lastRead is when the entry was last returned to a caller,
+	// see topoCacheIdleTTLs.
+	lastRead     time.Time
+	value        []string
+	lastError    error
+	lastErrorCtx context.Context
 }
 
 type srvKeyspaceEntry struct {
@@ -155,9 +210,12 @@ type srvShardEntry struct {
 	mutex sync.Mutex
 
 	insertionTime time.Time
-	value         *topodatapb.SrvShard
-	lastError     error
-	lastErrorCtx  context.Context
+	// lastRead is when the entry was last returned to a caller,
+	// see topoCacheIdleTTLs.
+	lastRead     time.Time
+	value        *topodatapb.SrvShard
+	lastError    error
+	lastErrorCtx context.Context
 }
 
 type endPointsEntry struct {
@@ -172,6 +230,9 @@ type endPointsEntry struct {
 	mutex sync.Mutex
 
 	insertionTime time.Time
+	// lastRead is when the entry was last returned to a caller,
+	// see topoCacheIdleTTLs.
+	lastRead time.Time
 
 	// value is the end points that were returned to the client.
 	value *topodatapb.EndPoints
@@ -220,11 +281,17 @@ func filterUnhealthyServers(endPoints *topodatapb.EndPoints) *topodatapb.EndPoin
 // NewResilientSrvTopoServer creates a new ResilientSrvTopoServer
 // based on the provided topo.Server.
 func NewResilientSrvTopoServer(base topo.Server, counterPrefix string) *ResilientSrvTopoServer {
-	return &ResilientSrvTopoServer{
+	ttls, err := parseTopoCacheTTLs(*topoCacheTTLs)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	server := &ResilientSrvTopoServer{
 		topoServer:         base,
 		cacheTTL:           *srvTopoCacheTTL,
+		cacheTTLs:          ttls,
 		enableRemoteMaster: *enableRemoteMaster,
 		counts:             stats.NewCounters(counterPrefix + "Counts"),
+		cacheCounts:        stats.NewMultiCounters(counterPrefix+"CacheCounts", []string{"EntryType", "Result"}),
 
 		srvKeyspaceNamesCache: make(map[string]*srvKeyspaceNamesEntry),
 		srvKeyspaceCache:      make(map[string]*srvKeyspaceEntry),
@@ -233,6 +300,98 @@ func NewResilientSrvTopoServer(base topo.Server, counterPrefix string) *Resilien
 
 		endPointCounters: newEndPointCounters(counterPrefix),
 	}
+	if *topoCacheRefreshInterval > 0 {
+		server.refreshTimer = timer.NewTimer(*topoCacheRefreshInterval)
+		server.refreshTimer.Start(server.refreshExpired)
+	}
+	return server
+}
+
+// Close stops the background refresh of the cache.
+func (server *ResilientSrvTopoServer) Close() {
+	if server.refreshTimer != nil {
+		server.refreshTimer.Stop()
+	}
+}
+
+// ttl returns how long the entries of entryType are cached.
+func (server *ResilientSrvTopoServer) ttl(entryType string) time.Duration {
+	if ttl, ok := server.cacheTTLs[entryType]; ok {
+		return ttl
+	}
+	return server.cacheTTL
+}
+
+// refreshExpired fetches again the expired entries of the cache, for
+// -topo-cache-refresh-interval. The entries that were not read for
+// topoCacheIdleTTLs ttls are evicted instead.
+func (server *ResilientSrvTopoServer) refreshExpired() {
+	server.mutex.RLock()
+	srvKeyspaceNamesEntries := make(map[string]*srvKeyspaceNamesEntry, len(server.srvKeyspaceNamesCache))
+	for key, entry := range server.srvKeyspaceNamesCache {
+		srvKeyspaceNamesEntries[key] = entry
+	}
+	srvShardEntries := make(map[string]*srvShardEntry, len(server.srvShardCache))
+	for key, entry := range server.srvShardCache {
+		srvShardEntries[key] = entry
+	}
+	endPointsEntries := make(map[string]*endPointsEntry, len(server.endPointsCache))
+	for key, entry := range server.endPointsCache {
+		endPointsEntries[key] = entry
+	}
+	server.mutex.RUnlock()
+
+	var idleSrvKeyspaceNames, idleSrvShards, idleEndPoints []string
+	ttl := server.ttl(srvKeyspaceNamesEntryType)
+	for key, entry := range srvKeyspaceNamesEntries {
+		entry.mutex.Lock()
+		if time.Now().Sub(entry.lastRead) >= topoCacheIdleTTLs*ttl {
+			idleSrvKeyspaceNames = append(idleSrvKeyspaceNames, key)
+		} else if time.Now().Sub(entry.insertionTime) >= ttl {
+			server.fetchSrvKeyspaceNamesLocked(entry)
+		}
+		entry.mutex.Unlock()
+	}
+	ttl = server.ttl(srvShardEntryType)
+	for key, entry := range srvShardEntries {
+		entry.mutex.Lock()
+		if time.Now().Sub(entry.lastRead) >= topoCacheIdleTTLs*ttl {
+			idleSrvShards = append(idleSrvShards, key)
+		} else if time.Now().Sub(entry.insertionTime) >= ttl {
+			server.fetchSrvShardLocked(entry)
+		}
+		entry.mutex.Unlock()
+	}
+	ttl = server.ttl(endPointsEntryType)
+	for key, entry := range endPointsEntries {
+		entry.mutex.Lock()
+		if time.Now().Sub(entry.lastRead) >= topoCacheIdleTTLs*ttl {
+			idleEndPoints = append(idleEndPoints, key)
+		} else if time.Now().Sub(entry.insertionTime) >= ttl {
+			server.fetchEndPointsLocked(entry)
+		}
+		entry.mutex.Unlock()
+	}
+
+	// An entry that was read since it was found idle is a new one,
+	// it's kept.
+	server.mutex.Lock()
+	for _, key := range idleSrvKeyspaceNames {
+		if server.srvKeyspaceNamesCache[key] == srvKeyspaceNamesEntries[key] {
+			delete(server.srvKeyspaceNamesCache, key)
+		}
+	}
+	for _, key := range idleSrvShards {
+		if server.srvShardCache[key] == srvShardEntries[key] {
+			delete(server.srvShardCache, key)
+		}
+	}
+	for _, key := range idleEndPoints {
+		if server.endPointsCache[key] == endPointsEntries[key] {
+			delete(server.endPointsCache, key)
+		}
+	}
+	server.mutex.Unlock()
 }
 
 // GetSrvKeyspaceNames returns all keyspace names for the given cell.
@@ -256,18 +415,28 @@ func (server *ResilientSrvTopoServer) GetSrvKeyspaceNames(ctx context.Context, c
 	// underlying query.
 	entry.mutex.Lock()
 	defer entry.mutex.Unlock()
+	entry.lastRead = time.Now()
 
 	// If the entry is fresh enough, return it
-	if time.Now().Sub(entry.insertionTime) < server.cacheTTL {
+	if time.Now().Sub(entry.insertionTime) < server.ttl(srvKeyspaceNamesEntryType) {
+		server.cacheCounts.Add([]string{srvKeyspaceNamesEntryType, "Hit"}, 1)
 		return entry.value, entry.lastError
 	}
+	server.cacheCounts.Add([]string{srvKeyspaceNamesEntryType, "Miss"}, 1)
+	return server.fetchSrvKeyspaceNamesLocked(entry)
+}
 
-	// not in cache or too old, get the real value
+// fetchSrvKeyspaceNamesLocked gets the value of entry from the topo
+// server, and caches it. If that fails, it returns the cached value if
+// there is one. entry.mutex must be held when calling this function.
+func (server *ResilientSrvTopoServer) fetchSrvKeyspaceNamesLocked(entry *srvKeyspaceNamesEntry) ([]string, error) {
+	cell := entry.cell
 	newCtx, cancel := context.WithTimeout(context.Background(), *srvTopoTimeout)
 	defer cancel()
 
 	result, err := server.topoServer.GetSrvKeyspaceNames(newCtx, cell)
 	if err != nil {
+		server.cacheCounts.Add([]string{srvKeyspaceNamesEntryType, "Error"}, 1)
 		if entry.insertionTime.IsZero() {
 			server.counts.Add(errorCategory, 1)
 			log.Errorf("GetSrvKeyspaceNames(%v, %v) failed: %v (no cached value, caching and returning error)", newCtx, cell, err)
@@ -324,6 +493,7 @@ func (server *ResilientSrvTopoServer) GetSrvKeyspace(ctx context.Context, cell,
 	if entry.watchRunning {
 		v, e := entry.value, entry.lastError
 		entry.mutex.RUnlock()
+		server.cacheCounts.Add([]string{srvKeyspaceEntryType, "Hit"}, 1)
 		return v, e
 	}
 	entry.mutex.RUnlock()
@@ -336,8 +506,10 @@ func (server *ResilientSrvTopoServer) GetSrvKeyspace(ctx context.Context, cell,
 
 	// If the watch is already running, return the value
 	if entry.watchRunning {
+		server.cacheCounts.Add([]string{srvKeyspaceEntryType, "Hit"}, 1)
 		return entry.value, entry.lastError
 	}
+	server.cacheCounts.Add([]string{srvKeyspaceEntryType, "Miss"}, 1)
 
 	// Watch is not running, let's try to start it.
 	// We use a background context, as the watch should last
@@ -346,6 +518,7 @@ func (server *ResilientSrvTopoServer) GetSrvKeyspace(ctx context.Context, cell,
 	newCtx := context.Background()
 	notifications, err := server.topoServer.WatchSrvKeyspace(newCtx, cell, keyspace)
 	if err != nil {
+		server.cacheCounts.Add([]string{srvKeyspaceEntryType, "Error"}, 1)
 		// lastError and lastErrorCtx will be visible from the UI
 		// until the next try
 		entry.value = nil
@@ -356,6 +529,7 @@ func (server *ResilientSrvTopoServer) GetSrvKeyspace(ctx context.Context, cell,
 	}
 	sk, ok := <-notifications
 	if !ok {
+		server.cacheCounts.Add([]string{srvKeyspaceEntryType, "Error"}, 1)
 		// lastError and lastErrorCtx will be visible from the UI
 		// until the next try
 		entry.value = nil
@@ -410,18 +584,28 @@ func (server *ResilientSrvTopoServer) GetSrvShard(ctx context.Context, cell, key
 	// underlying query.
 	entry.mutex.Lock()
 	defer entry.mutex.Unlock()
+	entry.lastRead = time.Now()
 
 	// If the entry is fresh enough, return it
-	if time.Now().Sub(entry.insertionTime) < server.cacheTTL {
+	if time.Now().Sub(entry.insertionTime) < server.ttl(srvShardEntryType) {
+		server.cacheCounts.Add([]string{srvShardEntryType, "Hit"}, 1)
 		return entry.value, entry.lastError
 	}
+	server.cacheCounts.Add([]string{srvShardEntryType, "Miss"}, 1)
+	return server.fetchSrvShardLocked(entry)
+}
 
-	// not in cache or too old, get the real value
+// fetchSrvShardLocked gets the value of entry from the topo server,
+// and caches it. If that fails, it returns the cached value if there
+// is one. entry.mutex must be held when calling this function.
+func (server *ResilientSrvTopoServer) fetchSrvShardLocked(entry *srvShardEntry) (*topodatapb.SrvShard, error) {
+	cell, keyspace, shard := entry.cell, entry.keyspace, entry.shard
 	newCtx, cancel := context.WithTimeout(context.Background(), *srvTopoTimeout)
 	defer cancel()
 
 	result, err := server.topoServer.GetSrvShard(newCtx, cell, keyspace, shard)
 	if err != nil {
+		server.cacheCounts.Add([]string{srvShardEntryType, "Error"}, 1)
 		if entry.insertionTime.IsZero() {
 			server.counts.Add(errorCategory, 1)
 			log.Errorf("GetSrvShard(%v, %v, %v, %v) failed: %v (no cached value, caching and returning error)", newCtx, cell, keyspace, shard, err)
@@ -468,6 +652,7 @@ func (server *ResilientSrvTopoServer) GetEndPoints(ctx context.Context, cell, ke
 	// underlying query.
 	entry.mutex.Lock()
 	defer entry.mutex.Unlock()
+	entry.lastRead = time.Now()
 
 	// Whether the query was serviced with remote endpoints.
 	remote := false
@@ -494,13 +679,24 @@ func (server *ResilientSrvTopoServer) GetEndPoints(ctx context.Context, cell, ke
 	}()
 
 	// If the entry is fresh enough, return it
-	if time.Now().Sub(entry.insertionTime) < server.cacheTTL {
+	if time.Now().Sub(entry.insertionTime) < server.ttl(endPointsEntryType) {
+		server.cacheCounts.Add([]string{endPointsEntryType, "Hit"}, 1)
 		server.endPointCounters.cacheHits.Add(key, 1)
 		remote = entry.remote
 		return entry.value, -1, entry.lastError
 	}
+	server.cacheCounts.Add([]string{endPointsEntryType, "Miss"}, 1)
+	result, remote, err = server.fetchEndPointsLocked(entry)
+	return result, -1, err
+}
 
-	// not in cache or too old, get the real value
+// fetchEndPointsLocked gets the value of entry from the topo server,
+// and caches it. If that fails, it returns the cached value if there
+// is one. remote is true if it looked up the endpoints in the master
+// cell. entry.mutex must be held when calling this function.
+func (server *ResilientSrvTopoServer) fetchEndPointsLocked(entry *endPointsEntry) (result *topodatapb.EndPoints, remote bool, err error) {
+	cell, keyspace, shard, tabletType := entry.cell, entry.keyspace, entry.shard, entry.tabletType
+	key := []string{cell, keyspace, shard, strings.ToLower(tabletType.String())}
 	newCtx, cancel := context.WithTimeout(context.Background(), *srvTopoTimeout)
 	defer cancel()
 
@@ -524,6 +720,7 @@ func (server *ResilientSrvTopoServer) GetEndPoints(ctx context.Context, cell, ke
 		}
 	}
 	if err != nil {
+		server.cacheCounts.Add([]string{endPointsEntryType, "Error"}, 1)
 		server.endPointCounters.lookupErrors.Add(key, 1)
 		if entry.insertionTime.IsZero() {
 			server.counts.Add(errorCategory, 1)
@@ -532,7 +729,7 @@ func (server *ResilientSrvTopoServer) GetEndPoints(ctx context.Context, cell, ke
 			server.counts.Add(cachedCategory, 1)
 			server.endPointCounters.staleCacheFallbacks.Add(key, 1)
 			log.Warningf("GetEndPoints(%v, %v, %v, %v, %v) failed: %v (returning cached value: %v %v)", newCtx, cell, keyspace, shard, tabletType, err, entry.value, entry.lastError)
-			return entry.value, -1, entry.lastError
+			return entry.value, remote, entry.lastError
 		}
 	}
 
@@ -543,7 +740,7 @@ func (server *ResilientSrvTopoServer) GetEndPoints(ctx context.Context, cell, ke
 	entry.lastError = err
 	entry.lastErrorCtx = newCtx
 	entry.remote = remote
-	return entry.value, -1, err
+	return entry.value, remote, err
 }
 
 // The next few structures and methods are used to get a displayable
@@ -488,3 +488,169 @@ func TestSrvKeyspaceCachedErrors(t *testing.T) {
 		t.Fatalf("GetSrvKeyspace was not called again: %v times", ft.callCount)
 	}
 }
+
+func TestParseTopoCacheTTLs(t *testing.T) {
+	ttls, err := parseTopoCacheTTLs("EndPoints=1s, SrvKeyspaceNames=1m,SrvShard=0s")
+	if err != nil {
+		t.Fatalf("parseTopoCacheTTLs: %v", err)
+	}
+	want := map[string]time.Duration{
+		endPointsEntryType:        time.Second,
+		srvKeyspaceNamesEntryType: time.Minute,
+		srvShardEntryType:         0,
+	}
+	if !reflect.DeepEqual(ttls, want) {
+		t.Errorf("parseTopoCacheTTLs: %v, want %v", ttls, want)
+	}
+	if ttls, err := parseTopoCacheTTLs(""); err != nil || ttls != nil {
+		t.Errorf("parseTopoCacheTTLs(\"\"): %v, %v, want nil, nil", ttls, err)
+	}
+	for _, value := range []string{"EndPoints", "SrvKeyspace=1s", "EndPoints=soon"} {
+		if _, err := parseTopoCacheTTLs(value); err == nil {
+			t.Errorf("parseTopoCacheTTLs(%q): nil, want error", value)
+		}
+	}
+}
+
+// fakeTopoCallCounts counts the calls of each type to the topo server.
+type fakeTopoCallCounts struct {
+	fakeTopo
+	srvKeyspaceNamesCalls int
+	endPointsCalls        int
+}
+
+func (ft *fakeTopoCallCounts) GetSrvKeyspaceNames(ctx context.Context, cell string) ([]string, error) {
+	ft.srvKeyspaceNamesCalls++
+	return ft.fakeTopo.GetSrvKeyspaceNames(ctx, cell)
+}
+
+func (ft *fakeTopoCallCounts) GetEndPoints(ctx context.Context, cell, keyspace, shard string, tabletType topodatapb.TabletType) (*topodatapb.EndPoints, int64, error) {
+	ft.endPointsCalls++
+	return &topodatapb.EndPoints{Entries: []*topodatapb.EndPoint{{Uid: 1}}}, -1, nil
+}
+
+// TestCacheTTLByEntryType tests that the entries are not fetched
+// again before the ttl of their type expires.
+func TestCacheTTLByEntryType(t *testing.T) {
+	ft := &fakeTopoCallCounts{fakeTopo: fakeTopo{keyspace: "test_ks"}}
+	rsts := NewResilientSrvTopoServer(topo.Server{Impl: ft}, "TestCacheTTLByEntryType")
+	rsts.cacheTTL = 0
+	rsts.cacheTTLs = map[string]time.Duration{
+		srvShardEntryType:  time.Hour,
+		endPointsEntryType: time.Hour,
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := rsts.GetSrvShard(context.Background(), "", "test_ks", "shard_0"); err != nil {
+			t.Fatalf("GetSrvShard got unexpected error: %v", err)
+		}
+		if _, _, err := rsts.GetEndPoints(context.Background(), "", "test_ks", "shard_0", topodatapb.TabletType_REPLICA); err != nil {
+			t.Fatalf("GetEndPoints got unexpected error: %v", err)
+		}
+		if _, err := rsts.GetSrvKeyspaceNames(context.Background(), ""); err != nil {
+			t.Fatalf("GetSrvKeyspaceNames got unexpected error: %v", err)
+		}
+	}
+	if ft.callCount != 1 {
+		t.Errorf("GetSrvShard was called %v times, want 1", ft.callCount)
+	}
+	if ft.endPointsCalls != 1 {
+		t.Errorf("GetEndPoints was called %v times, want 1", ft.endPointsCalls)
+	}
+	// The SrvKeyspaceNames use -srv_topo_cache_ttl, which is 0 here.
+	if ft.srvKeyspaceNamesCalls != 3 {
+		t.Errorf("GetSrvKeyspaceNames was called %v times, want 3", ft.srvKeyspaceNamesCalls)
+	}
+
+	// Once the ttl expires, the entries are fetched again.
+	rsts.cacheTTLs[srvShardEntryType] = 0
+	if _, err := rsts.GetSrvShard(context.Background(), "", "test_ks", "shard_0"); err != nil {
+		t.Fatalf("GetSrvShard got unexpected error: %v", err)
+	}
+	if ft.callCount != 2 {
+		t.Errorf("GetSrvShard was called %v times after the ttl expired, want 2", ft.callCount)
+	}
+
+	// The errors of the topo server are counted too.
+	if _, err := rsts.GetSrvShard(context.Background(), "", "unknown_ks", "shard_0"); err == nil {
+		t.Errorf("GetSrvShard of an unknown keyspace didn't return an error")
+	}
+	want := map[string]int64{
+		"SrvShard.Hit":          2,
+		"SrvShard.Miss":         3,
+		"SrvShard.Error":        1,
+		"EndPoints.Hit":         2,
+		"EndPoints.Miss":        1,
+		"SrvKeyspaceNames.Miss": 3,
+	}
+	if got := rsts.cacheCounts.Counts(); !reflect.DeepEqual(got, want) {
+		t.Errorf("cacheCounts: %v, want %v", got, want)
+	}
+}
+
+// TestRefreshExpired tests that the background refresh only fetches
+// the expired entries again.
+func TestRefreshExpired(t *testing.T) {
+	ft := &fakeTopoCallCounts{fakeTopo: fakeTopo{keyspace: "test_ks"}}
+	rsts := NewResilientSrvTopoServer(topo.Server{Impl: ft}, "TestRefreshExpired")
+	rsts.cacheTTL = time.Hour
+	if _, err := rsts.GetSrvShard(context.Background(), "", "test_ks", "shard_0"); err != nil {
+		t.Fatalf("GetSrvShard got unexpected error: %v", err)
+	}
+	if _, _, err := rsts.GetEndPoints(context.Background(), "", "test_ks", "shard_0", topodatapb.TabletType_REPLICA); err != nil {
+		t.Fatalf("GetEndPoints got unexpected error: %v", err)
+	}
+
+	rsts.refreshExpired()
+	if ft.callCount != 1 || ft.endPointsCalls != 1 {
+		t.Errorf("refreshExpired fetched fresh entries: GetSrvShard called %v times, GetEndPoints %v times, want 1 and 1", ft.callCount, ft.endPointsCalls)
+	}
+
+	endPoints := rsts.endPointsCache[".test_ks.shard_0.replica"]
+	endPoints.insertionTime = time.Now().Add(-2 * time.Hour)
+	rsts.refreshExpired()
+	if ft.callCount != 1 || ft.endPointsCalls != 2 {
+		t.Errorf("refreshExpired: GetSrvShard called %v times, GetEndPoints %v times, want 1 and 2", ft.callCount, ft.endPointsCalls)
+	}
+
+	// The refreshed entry is fresh again for the queries.
+	if _, _, err := rsts.GetEndPoints(context.Background(), "", "test_ks", "shard_0", topodatapb.TabletType_REPLICA); err != nil {
+		t.Fatalf("GetEndPoints got unexpected error: %v", err)
+	}
+	if ft.endPointsCalls != 2 {
+		t.Errorf("GetEndPoints was called %v times, want 2", ft.endPointsCalls)
+	}
+
+	// The entries that are not read are evicted, not refreshed.
+	endPoints.insertionTime = time.Now().Add(-2 * time.Hour)
+	endPoints.lastRead = time.Now().Add(-topoCacheIdleTTLs * time.Hour)
+	rsts.refreshExpired()
+	if ft.endPointsCalls != 2 {
+		t.Errorf("refreshExpired fetched an idle entry: GetEndPoints called %v times, want 2", ft.endPointsCalls)
+	}
+	if _, ok := rsts.endPointsCache[".test_ks.shard_0.replica"]; ok {
+		t.Errorf("refreshExpired kept the idle EndPoints entry")
+	}
+	if _, ok := rsts.srvShardCache[".test_ks.shard_0"]; !ok {
+		t.Errorf("refreshExpired evicted the SrvShard entry that was read")
+	}
+}
+
+func TestRefreshTimer(t *testing.T) {
+	defer func(saved time.Duration) { *topoCacheRefreshInterval = saved }(*topoCacheRefreshInterval)
+	*topoCacheRefreshInterval = time.Millisecond
+	ft := &fakeTopoCallCounts{fakeTopo: fakeTopo{keyspace: "test_ks"}}
+	rsts := NewResilientSrvTopoServer(topo.Server{Impl: ft}, "TestRefreshTimer")
+	rsts.Close()
+	// Close stopped the refresh, so the expired entry is not fetched
+	// again.
+	rsts.cacheTTL = time.Hour
+	if _, err := rsts.GetSrvShard(context.Background(), "", "test_ks", "shard_0"); err != nil {
+		t.Fatalf("GetSrvShard got unexpected error: %v", err)
+	}
+	rsts.srvShardCache[".test_ks.shard_0"].insertionTime = time.Now().Add(-2 * time.Hour)
+	time.Sleep(10 * time.Millisecond)
+	if ft.callCount != 1 {
+		t.Errorf("GetSrvShard was called %v times after Close, want 1", ft.callCount)
+	}
+}
@@ -376,6 +376,12 @@ func (res *Resolver) GetGatewayCacheStatus() GatewayEndPointCacheStatusList {
 	return res.scatterConn.GetGatewayCacheStatus()
 }
 
+// GetKeyspaceLimiterStatus returns a displayable version of the
+// per-keyspace concurrency limits.
+func (res *Resolver) GetKeyspaceLimiterStatus() KeyspaceLimiterStatusList {
+	return res.scatterConn.GetKeyspaceLimiterStatus()
+}
+
 // StrsEquals compares contents of two string slices.
 func StrsEquals(a, b []string) bool {
 	if len(a) != len(b) {
@@ -0,0 +1,116 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/cache"
+	"github.com/youtube/vitess/go/stats"
+	"github.com/youtube/vitess/go/vt/topo"
+
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+)
+
+var (
+	routeCacheEntries = flag.Int("vtgate_route_cache_entries", 1000, "number of endpoints of keyspace/shard/tablet type combinations that vtgate keeps in its route cache, in front of the topology cache. The least recently routed combinations are evicted first. 0 disables the route cache.")
+	routeCacheTTL     = flag.Duration("vtgate_route_cache_ttl", 0, "how long the route cache uses its entries. 0 uses -srv_topo_cache_ttl.")
+
+	// routeCacheCounts counts the route cache lookups, by keyspace
+	// and result (Hit or Miss).
+	routeCacheCounts = stats.NewMultiCounters("VtgateRouteCacheCounts", []string{"Keyspace", "Result"})
+)
+
+func init() {
+	stats.NewMultiCountersFunc("VtgateRouteCacheHitRate", []string{"Keyspace"}, routeCacheHitRates)
+}
+
+// routeCacheHitRates returns the percentage of the route cache
+// lookups that were hits, by keyspace.
+func routeCacheHitRates() map[string]int64 {
+	hits := make(map[string]int64)
+	totals := make(map[string]int64)
+	for name, count := range routeCacheCounts.Counts() {
+		i := strings.LastIndex(name, ".")
+		if i < 0 {
+			continue
+		}
+		keyspace := name[:i]
+		totals[keyspace] += count
+		if name[i+1:] == "Hit" {
+			hits[keyspace] += count
+		}
+	}
+	rates := make(map[string]int64, len(totals))
+	for keyspace, total := range totals {
+		if total != 0 {
+			rates[keyspace] = hits[keyspace] * 100 / total
+		}
+	}
+	return rates
+}
+
+// routeCacheEntry is the value of the route cache.
+type routeCacheEntry struct {
+	endPoints *topodatapb.EndPoints
+	version   int64
+	insertion time.Time
+}
+
+// Size is part of the cache.Value interface: the capacity of the
+// route cache is a number of entries.
+func (e *routeCacheEntry) Size() int {
+	return 1
+}
+
+// routeCacheSrvTopoServer keeps the endpoints of the most recently
+// routed keyspace/shard/tablet type combinations in an LRU cache. The
+// topology cache under it keeps every entry it was asked for, and
+// takes its lock on every lookup: the route cache bounds the memory
+// that the hot combinations need to be served without it. With a
+// -vtgate_route_cache_ttl longer than -srv_topo_cache_ttl, the hot
+// combinations are also fetched less often from the topo server.
+type routeCacheSrvTopoServer struct {
+	topo.SrvTopoServer
+	ttl   time.Duration
+	cache *cache.LRUCache
+}
+
+func newRouteCacheSrvTopoServer(serv topo.SrvTopoServer, entries int, ttl time.Duration) topo.SrvTopoServer {
+	return &routeCacheSrvTopoServer{
+		SrvTopoServer: serv,
+		ttl:           ttl,
+		cache:         cache.NewLRUCache(int64(entries)),
+	}
+}
+
+// GetEndPoints is part of the topo.SrvTopoServer interface. The
+// errors aren't cached, the next lookup tries again.
+func (server *routeCacheSrvTopoServer) GetEndPoints(ctx context.Context, cell, keyspace, shard string, tabletType topodatapb.TabletType) (*topodatapb.EndPoints, int64, error) {
+	key := fmt.Sprintf("%v.%v.%v.%v", cell, keyspace, shard, tabletType)
+	if v, ok := server.cache.Get(key); ok {
+		entry := v.(*routeCacheEntry)
+		if time.Since(entry.insertion) < server.ttl {
+			routeCacheCounts.Add([]string{keyspace, "Hit"}, 1)
+			return entry.endPoints, entry.version, nil
+		}
+	}
+	routeCacheCounts.Add([]string{keyspace, "Miss"}, 1)
+	endPoints, version, err := server.SrvTopoServer.GetEndPoints(ctx, cell, keyspace, shard, tabletType)
+	if err != nil {
+		return endPoints, version, err
+	}
+	server.cache.Set(key, &routeCacheEntry{
+		endPoints: endPoints,
+		version:   version,
+		insertion: time.Now(),
+	})
+	return endPoints, version, nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+)
+
+// routeTopo counts the endpoint lookups, and fails the ones of the
+// keyspaces in its map.
+type routeTopo struct {
+	sandboxTopo
+	lookups int
+	fail    map[string]bool
+}
+
+func (rt *routeTopo) GetEndPoints(ctx context.Context, cell, keyspace, shard string, tabletType topodatapb.TabletType) (*topodatapb.EndPoints, int64, error) {
+	rt.lookups++
+	if rt.fail[keyspace] {
+		return nil, -1, fmt.Errorf("no endpoints for %v/%v", keyspace, shard)
+	}
+	return &topodatapb.EndPoints{Entries: []*topodatapb.EndPoint{{Host: shard}}}, int64(rt.lookups), nil
+}
+
+func TestRouteCacheSrvTopoServer(t *testing.T) {
+	rt := &routeTopo{fail: map[string]bool{"route_bad": true}}
+	serv := newRouteCacheSrvTopoServer(rt, 2, time.Hour)
+	ctx := context.Background()
+	hits := routeCacheCounts.Counts()["route_ks.Hit"]
+	misses := routeCacheCounts.Counts()["route_ks.Miss"]
+
+	for i := 0; i < 3; i++ {
+		endPoints, version, err := serv.GetEndPoints(ctx, "aa", "route_ks", "-80", topodatapb.TabletType_REPLICA)
+		if err != nil || len(endPoints.Entries) != 1 || endPoints.Entries[0].Host != "-80" || version != 1 {
+			t.Errorf("GetEndPoints(route_ks/-80): %v, %v, %v, want the cached ones of version 1", endPoints, version, err)
+		}
+	}
+	if rt.lookups != 1 {
+		t.Errorf("lookups: %v, want 1", rt.lookups)
+	}
+	if got := routeCacheCounts.Counts()["route_ks.Hit"] - hits; got != 2 {
+		t.Errorf("hits: %v, want 2", got)
+	}
+	if got := routeCacheCounts.Counts()["route_ks.Miss"] - misses; got != 1 {
+		t.Errorf("misses: %v, want 1", got)
+	}
+	if got := routeCacheHitRates()["route_ks"]; got <= 0 || got > 100 {
+		t.Errorf("hit rate: %v, want a percentage", got)
+	}
+
+	// The errors aren't cached.
+	for i := 0; i < 2; i++ {
+		if _, _, err := serv.GetEndPoints(ctx, "aa", "route_bad", "0", topodatapb.TabletType_REPLICA); err == nil {
+			t.Errorf("GetEndPoints(route_bad): nil, want an error")
+		}
+	}
+	if rt.lookups != 3 {
+		t.Errorf("lookups: %v, want 3", rt.lookups)
+	}
+
+	// The least recently routed combination is evicted.
+	serv.GetEndPoints(ctx, "aa", "route_ks", "80-", topodatapb.TabletType_REPLICA)
+	serv.GetEndPoints(ctx, "aa", "route_ks", "-80", topodatapb.TabletType_RDONLY)
+	serv.GetEndPoints(ctx, "aa", "route_ks", "-80", topodatapb.TabletType_REPLICA)
+	if rt.lookups != 6 {
+		t.Errorf("lookups after an eviction: %v, want 6", rt.lookups)
+	}
+
+	// The expired entries are fetched again.
+	serv = newRouteCacheSrvTopoServer(rt, 2, 0)
+	serv.GetEndPoints(ctx, "aa", "route_ks", "-80", topodatapb.TabletType_REPLICA)
+	serv.GetEndPoints(ctx, "aa", "route_ks", "-80", topodatapb.TabletType_REPLICA)
+	if rt.lookups != 8 {
+		t.Errorf("lookups with a 0 ttl: %v, want 8", rt.lookups)
+	}
+}
@@ -6,7 +6,9 @@ package vtgate
 
 import (
 	"encoding/hex"
+	"flag"
 	"fmt"
+	"sort"
 
 	"github.com/youtube/vitess/go/sqltypes"
 	"github.com/youtube/vitess/go/vt/sqlannotation"
@@ -23,6 +25,10 @@ const (
 	ksidName = "keyspace_id"
 )
 
+var planCacheSize = flag.Int("plan-cache-size", 10000, "number of query plans vtgate caches. The plans are cleared when the VSchema changes.")
+
+var maxDMLShards = flag.Int("max_dml_shards", 0, "maximum number of shards an UPDATE or DELETE with an IN list of sharding keys can be sent to. The statements that would be sent to more shards fail. 0 means no limit.")
+
 // Router is the layer to route queries to the correct shards
 // based on the values in the query.
 type Router struct {
@@ -53,34 +59,106 @@ func NewRouter(ctx context.Context, serv topo.SrvTopoServer, cell, statsName str
 	return &Router{
 		serv:        serv,
 		cell:        cell,
-		planner:     NewPlanner(ctx, serv, cell, 5000),
+		planner:     NewPlanner(ctx, serv, cell, statsName, *planCacheSize),
 		scatterConn: scatterConn,
 	}
 }
 
-// Execute routes a non-streaming query.
+// Execute routes a non-streaming query. The selects that lock rows
+// are sent to the master, whatever tabletType is. SHOW VITESS_KEYSPACES
+// and SET VITESS_MAX_SCATTER_PARALLELISM are answered by vtgate.
 func (rtr *Router) Execute(ctx context.Context, sql string, bindVars map[string]interface{}, keyspace string, tabletType topodatapb.TabletType, session *vtgatepb.Session, notInTransaction bool) (*sqltypes.Result, error) {
+	return rtr.executeWithPlan(ctx, nil, sql, bindVars, keyspace, tabletType, session, notInTransaction)
+}
+
+// executeWithPlan is Execute, for a caller that may already have the
+// plan of sql. If plan is nil, it gets the plan from the planner.
+func (rtr *Router) executeWithPlan(ctx context.Context, plan *engine.Plan, sql string, bindVars map[string]interface{}, keyspace string, tabletType topodatapb.TabletType, session *vtgatepb.Session, notInTransaction bool) (*sqltypes.Result, error) {
+	if isShowVitessKeyspaces(sql) {
+		return rtr.showKeyspaces(ctx)
+	}
+	if n, ok, err := parseSetMaxScatterParallelism(sql); ok {
+		if err != nil {
+			return nil, err
+		}
+		return setSessionMaxScatterParallelism(session, n)
+	}
+	if plan == nil {
+		var err error
+		plan, err = rtr.planner.GetPlan(sql, keyspace, tabletType)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return rtr.ExecutePlan(ctx, plan, sql, bindVars, keyspace, tabletType, session, notInTransaction)
+}
+
+// ExecutePlan executes plan, the plan of sql. It lets the prepared
+// statements skip the parsing and the planning of Execute.
+func (rtr *Router) ExecutePlan(ctx context.Context, plan *engine.Plan, sql string, bindVars map[string]interface{}, keyspace string, tabletType topodatapb.TabletType, session *vtgatepb.Session, notInTransaction bool) (*sqltypes.Result, error) {
 	if bindVars == nil {
 		bindVars = make(map[string]interface{})
 	}
+	if plan.LockingRead {
+		tabletType = topodatapb.TabletType_MASTER
+	}
 	vcursor := newRequestContext(ctx, sql, bindVars, keyspace, tabletType, session, notInTransaction, rtr)
-	plan, err := rtr.planner.GetPlan(sql, keyspace)
-	if err != nil {
-		return nil, err
+	// Only the selects of a single route are limited: the rows of
+	// the left side of a join don't bound the rows of the join.
+	maxRows := unboundedQueryRows(sql)
+	if route, ok := plan.Instructions.(*engine.Route); ok && maxRows > 0 && !plan.LockingRead {
+		if limited, ok := limitRoute(route, maxRows); ok {
+			if logStats := logStatsFromContext(ctx); logStats != nil {
+				logStats.LimitInjected = true
+			}
+			qr, err := limited.Execute(vcursor, make(map[string]interface{}), true)
+			if err != nil {
+				return nil, err
+			}
+			return limitResult(qr, maxRows), nil
+		}
 	}
 	return plan.Instructions.Execute(vcursor, make(map[string]interface{}), true)
 }
 
 // StreamExecute executes a streaming query.
 func (rtr *Router) StreamExecute(ctx context.Context, sql string, bindVars map[string]interface{}, keyspace string, tabletType topodatapb.TabletType, sendReply func(*sqltypes.Result) error) error {
+	return rtr.streamExecuteWithPlan(ctx, nil, sql, bindVars, keyspace, tabletType, sendReply)
+}
+
+// streamExecuteWithPlan is StreamExecute, for a caller that may already
+// have the plan of sql. If plan is nil, it gets the plan from the
+// planner.
+func (rtr *Router) streamExecuteWithPlan(ctx context.Context, plan *engine.Plan, sql string, bindVars map[string]interface{}, keyspace string, tabletType topodatapb.TabletType, sendReply func(*sqltypes.Result) error) error {
+	if isShowVitessKeyspaces(sql) {
+		qr, err := rtr.showKeyspaces(ctx)
+		if err != nil {
+			return err
+		}
+		return sendReply(qr)
+	}
+	if n, ok, err := parseSetMaxScatterParallelism(sql); ok {
+		if err != nil {
+			return err
+		}
+		// The streaming queries have no session to store it in.
+		_, err = setSessionMaxScatterParallelism(nil, n)
+		return err
+	}
 	if bindVars == nil {
 		bindVars = make(map[string]interface{})
 	}
-	vcursor := newRequestContext(ctx, sql, bindVars, keyspace, tabletType, nil, false, rtr)
-	plan, err := rtr.planner.GetPlan(sql, keyspace)
-	if err != nil {
-		return err
+	if plan == nil {
+		var err error
+		plan, err = rtr.planner.GetPlan(sql, keyspace, tabletType)
+		if err != nil {
+			return err
+		}
 	}
+	if plan.LockingRead {
+		tabletType = topodatapb.TabletType_MASTER
+	}
+	vcursor := newRequestContext(ctx, sql, bindVars, keyspace, tabletType, nil, false, rtr)
 	return plan.Instructions.StreamExecute(vcursor, make(map[string]interface{}), true, sendReply)
 }
 
@@ -95,8 +173,12 @@ func (rtr *Router) ExecuteRoute(vcursor *requestContext, route *engine.Route, jo
 	switch route.Opcode {
 	case engine.UpdateEqual:
 		return rtr.execUpdateEqual(vcursor, route)
+	case engine.UpdateIN:
+		return rtr.execUpdateIN(vcursor, route)
 	case engine.DeleteEqual:
 		return rtr.execDeleteEqual(vcursor, route)
+	case engine.DeleteIN:
+		return rtr.execDeleteIN(vcursor, route)
 	case engine.InsertSharded:
 		return rtr.execInsertSharded(vcursor, route)
 	}
@@ -304,6 +386,139 @@ func (rtr *Router) execDeleteEqual(vcursor *requestContext, route *engine.Route)
 		vcursor.notInTransaction)
 }
 
+func (rtr *Router) execUpdateIN(vcursor *requestContext, route *engine.Route) (*sqltypes.Result, error) {
+	ks, routing, err := rtr.resolveDMLShards(vcursor, route)
+	if err != nil {
+		return nil, fmt.Errorf("execUpdateIN: %v", err)
+	}
+	return rtr.execDMLShards(vcursor, route, ks, routing)
+}
+
+func (rtr *Router) execDeleteIN(vcursor *requestContext, route *engine.Route) (*sqltypes.Result, error) {
+	ks, routing, err := rtr.resolveDMLShards(vcursor, route)
+	if err != nil {
+		return nil, fmt.Errorf("execDeleteIN: %v", err)
+	}
+	if route.Subquery != "" {
+		// The lookup rows are deleted by keyspace id, so the
+		// rows of each key are fetched separately.
+		for _, ds := range routing {
+			for i, key := range ds.keys {
+				vcursor.bindVars[engine.ListVarName] = []interface{}{key}
+				if err := rtr.deleteVindexEntries(vcursor, route, ks, ds.shard, ds.ksids[i]); err != nil {
+					return nil, fmt.Errorf("execDeleteIN: %v", err)
+				}
+			}
+		}
+	}
+	return rtr.execDMLShards(vcursor, route, ks, routing)
+}
+
+// dmlShard is the part of an UpdateIN or DeleteIN route that is
+// sent to a shard: the keys of the IN list that map to the shard,
+// and their keyspace ids.
+type dmlShard struct {
+	shard string
+	keys  []interface{}
+	ksids [][]byte
+}
+
+// resolveDMLShards maps the keys of an UpdateIN or DeleteIN route to
+// their shards, in the order of the shard names. The keys that don't
+// map to a keyspace id are dropped.
+func (rtr *Router) resolveDMLShards(vcursor *requestContext, route *engine.Route) (newKeyspace string, routing []*dmlShard, err error) {
+	keys, err := rtr.resolveKeys(route.Values.([]interface{}), vcursor.bindVars)
+	if err != nil {
+		return "", nil, err
+	}
+	newKeyspace, _, allShards, err := getKeyspaceShards(vcursor.ctx, rtr.serv, rtr.cell, route.Keyspace.Name, vcursor.tabletType)
+	if err != nil {
+		return "", nil, err
+	}
+	mapper := route.Vindex.(vindexes.Unique)
+	ksids, err := mapper.Map(vcursor, keys)
+	if err != nil {
+		return "", nil, err
+	}
+	byShard := make(map[string]*dmlShard)
+	for i, ksid := range ksids {
+		if len(ksid) == 0 {
+			continue
+		}
+		shard, err := getShardForKeyspaceID(allShards, ksid)
+		if err != nil {
+			return "", nil, err
+		}
+		ds, ok := byShard[shard]
+		if !ok {
+			ds = &dmlShard{shard: shard}
+			byShard[shard] = ds
+			routing = append(routing, ds)
+		}
+		ds.keys = append(ds.keys, keys[i])
+		ds.ksids = append(ds.ksids, ksid)
+	}
+	configMu.RLock()
+	limit := *maxDMLShards
+	configMu.RUnlock()
+	if limit > 0 && len(routing) > limit {
+		return "", nil, fmt.Errorf("statement would be sent to %d shards, more than -max_dml_shards (%d)", len(routing), limit)
+	}
+	// Outside a transaction, each shard would commit its part on its
+	// own, and a failure would leave the others changed.
+	if len(routing) > 1 && (vcursor.notInTransaction || !NewSafeSession(vcursor.session).InTransaction()) {
+		return "", nil, fmt.Errorf("statement would be sent to %d shards outside of a transaction, it must be executed in one", len(routing))
+	}
+	sort.Sort(dmlShardsByName(routing))
+	return newKeyspace, routing, nil
+}
+
+// dmlShardsByName sorts dmlShard lists by shard name.
+type dmlShardsByName []*dmlShard
+
+// Len is part of sort.Interface.
+func (s dmlShardsByName) Len() int {
+	return len(s)
+}
+
+// Less is part of sort.Interface.
+func (s dmlShardsByName) Less(i, j int) bool {
+	return s[i].shard < s[j].shard
+}
+
+// Swap is part of sort.Interface.
+func (s dmlShardsByName) Swap(i, j int) {
+	s[i], s[j] = s[j], s[i]
+}
+
+// execDMLShards sends the query of an UpdateIN or DeleteIN route to
+// each of the shards of routing, with the keys of the shard, and
+// returns the sum of the rows affected.
+func (rtr *Router) execDMLShards(vcursor *requestContext, route *engine.Route, ks string, routing []*dmlShard) (*sqltypes.Result, error) {
+	qr := &sqltypes.Result{}
+	for _, ds := range routing {
+		bindVars := copyBindVars(vcursor.bindVars)
+		bindVars[engine.ListVarName] = ds.keys
+		if len(ds.ksids) == 1 {
+			bindVars[ksidName] = string(ds.ksids[0])
+		}
+		result, err := rtr.scatterConn.Execute(
+			vcursor.ctx,
+			sqlannotation.AddIfDML(route.Query, ds.ksids),
+			bindVars,
+			ks,
+			[]string{ds.shard},
+			vcursor.tabletType,
+			NewSafeSession(vcursor.session),
+			vcursor.notInTransaction)
+		if err != nil {
+			return nil, err
+		}
+		appendResult(qr, result)
+	}
+	return qr, nil
+}
+
 func (rtr *Router) execInsertSharded(vcursor *requestContext, route *engine.Route) (*sqltypes.Result, error) {
 	insertid, err := rtr.handleGenerate(vcursor, route.Generate)
 	if err != nil {
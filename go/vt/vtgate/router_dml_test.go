@@ -239,6 +239,158 @@ func TestDeleteEqualFail(t *testing.T) {
 	s.ShardSpec = DefaultShardSpec
 }
 
+func TestUpdateIN(t *testing.T) {
+	router, sbc1, sbc2, _ := createRouterEnv()
+
+	qr, err := routerExecInTx(router, "update user set a=2 where id in (1, 3)", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if qr.RowsAffected != 2 {
+		t.Errorf("RowsAffected: %v, want 2", qr.RowsAffected)
+	}
+	wantQueries := []querytypes.BoundQuery{{
+		Sql: "update user set a = 2 where id in ::__vals /* vtgate:: keyspace_id:166b40b44aba4bd6 */",
+		BindVariables: map[string]interface{}{
+			"__vals":      []interface{}{int64(1)},
+			"keyspace_id": "\x16k@\xb4J\xbaK\xd6",
+		},
+	}}
+	if !reflect.DeepEqual(sbc1.Queries, wantQueries) {
+		t.Errorf("sbc1.Queries: %+v, want %+v\n", sbc1.Queries, wantQueries)
+	}
+	wantQueries = []querytypes.BoundQuery{{
+		Sql: "update user set a = 2 where id in ::__vals /* vtgate:: keyspace_id:4eb190c9a2fa169c */",
+		BindVariables: map[string]interface{}{
+			"__vals":      []interface{}{int64(3)},
+			"keyspace_id": "N\xb1\x90ɢ\xfa\x16\x9c",
+		},
+	}}
+	if !reflect.DeepEqual(sbc2.Queries, wantQueries) {
+		t.Errorf("sbc2.Queries: %+v, want %+v\n", sbc2.Queries, wantQueries)
+	}
+
+	// Two keys of the same shard are sent together, and the
+	// statement isn't friendly to filtered replication.
+	sbc1.Queries = nil
+	sbc2.Queries = nil
+	_, err = routerExec(router, "update user set a=2 where id in (1, :id)", map[string]interface{}{
+		"id": 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantQueries = []querytypes.BoundQuery{{
+		Sql: "update user set a = 2 where id in ::__vals/* vtgate:: filtered_replication_unfriendly */",
+		BindVariables: map[string]interface{}{
+			"__vals": []interface{}{int64(1), 1},
+			"id":     1,
+		},
+	}}
+	if !reflect.DeepEqual(sbc1.Queries, wantQueries) {
+		t.Errorf("sbc1.Queries: %+v, want %+v\n", sbc1.Queries, wantQueries)
+	}
+	if sbc2.Queries != nil {
+		t.Errorf("sbc2.Queries: %+v, want nil\n", sbc2.Queries)
+	}
+}
+
+func TestUpdateINFail(t *testing.T) {
+	router, sbc1, sbc2, _ := createRouterEnv()
+
+	_, err := routerExec(router, "update user set a=2 where id in (1, :aa)", nil)
+	want := "execUpdateIN: could not find bind var :aa"
+	if err == nil || err.Error() != want {
+		t.Errorf("routerExec: %v, want %v", err, want)
+	}
+
+	defer func(v int) { *maxDMLShards = v }(*maxDMLShards)
+	*maxDMLShards = 1
+	_, err = routerExec(router, "update user set a=2 where id in (1, 3)", nil)
+	want = "execUpdateIN: statement would be sent to 2 shards, more than -max_dml_shards (1)"
+	if err == nil || err.Error() != want {
+		t.Errorf("routerExec: %v, want %v", err, want)
+	}
+	if sbc1.Queries != nil || sbc2.Queries != nil {
+		t.Errorf("queries sent: %+v, %+v, want none", sbc1.Queries, sbc2.Queries)
+	}
+	*maxDMLShards = 0
+
+	// The statements sent to several shards need a transaction.
+	_, err = routerExec(router, "update user set a=2 where id in (1, 3)", nil)
+	want = "execUpdateIN: statement would be sent to 2 shards outside of a transaction, it must be executed in one"
+	if err == nil || err.Error() != want {
+		t.Errorf("routerExec: %v, want %v", err, want)
+	}
+	_, err = routerExec(router, "delete from user where id in (1, 3)", nil)
+	want = "execDeleteIN: statement would be sent to 2 shards outside of a transaction, it must be executed in one"
+	if err == nil || err.Error() != want {
+		t.Errorf("routerExec: %v, want %v", err, want)
+	}
+	if sbc1.Queries != nil || sbc2.Queries != nil {
+		t.Errorf("queries sent: %+v, %+v, want none", sbc1.Queries, sbc2.Queries)
+	}
+}
+
+func TestDeleteIN(t *testing.T) {
+	router, sbc1, sbc2, sbclookup := createRouterEnv()
+
+	sbc1.setResults([]*sqltypes.Result{{
+		Fields: []*querypb.Field{
+			{Name: "name", Type: sqltypes.VarChar},
+		},
+		RowsAffected: 1,
+		Rows: [][]sqltypes.Value{{
+			sqltypes.MakeTrusted(sqltypes.VarChar, []byte("myname")),
+		}},
+	}})
+	sbc2.setResults([]*sqltypes.Result{{}})
+	_, err := routerExecInTx(router, "delete from user where id in (1, 3)", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantQueries := []querytypes.BoundQuery{{
+		Sql: "select name from user where id in ::__vals for update",
+		BindVariables: map[string]interface{}{
+			"__vals": []interface{}{int64(1)},
+		},
+	}, {
+		Sql: "delete from user where id in ::__vals /* vtgate:: keyspace_id:166b40b44aba4bd6 */",
+		BindVariables: map[string]interface{}{
+			"__vals":      []interface{}{int64(1)},
+			"keyspace_id": "\x16k@\xb4J\xbaK\xd6",
+		},
+	}}
+	if !reflect.DeepEqual(sbc1.Queries, wantQueries) {
+		t.Errorf("sbc1.Queries:\n%+v, want\n%+v\n", sbc1.Queries, wantQueries)
+	}
+	wantQueries = []querytypes.BoundQuery{{
+		Sql: "select name from user where id in ::__vals for update",
+		BindVariables: map[string]interface{}{
+			"__vals": []interface{}{int64(3)},
+		},
+	}, {
+		Sql: "delete from user where id in ::__vals /* vtgate:: keyspace_id:4eb190c9a2fa169c */",
+		BindVariables: map[string]interface{}{
+			"__vals":      []interface{}{int64(3)},
+			"keyspace_id": "N\xb1\x90ɢ\xfa\x16\x9c",
+		},
+	}}
+	if !reflect.DeepEqual(sbc2.Queries, wantQueries) {
+		t.Errorf("sbc2.Queries:\n%+v, want\n%+v\n", sbc2.Queries, wantQueries)
+	}
+	wantQueries = []querytypes.BoundQuery{{
+		Sql: "delete from name_user_map where name = :name and user_id = :user_id",
+		BindVariables: map[string]interface{}{
+			"user_id": int64(1),
+			"name":    "myname",
+		},
+	}}
+	if !reflect.DeepEqual(sbclookup.Queries, wantQueries) {
+		t.Errorf("sbclookup.Queries:\n%+v, want\n%+v\n", sbclookup.Queries, wantQueries)
+	}
+}
+
 func TestInsertSharded(t *testing.T) {
 	router, sbc1, sbc2, sbclookup := createRouterEnv()
 
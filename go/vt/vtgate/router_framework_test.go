@@ -12,6 +12,7 @@ import (
 	"golang.org/x/net/context"
 
 	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+	vtgatepb "github.com/youtube/vitess/go/vt/proto/vtgate"
 )
 
 var routerVSchema = `
@@ -189,6 +190,17 @@ func routerExec(router *Router, sql string, bv map[string]interface{}) (*sqltype
 		false)
 }
 
+// routerExecInTx is routerExec in a transaction.
+func routerExecInTx(router *Router, sql string, bv map[string]interface{}) (*sqltypes.Result, error) {
+	return router.Execute(context.Background(),
+		sql,
+		bv,
+		"",
+		topodatapb.TabletType_MASTER,
+		&vtgatepb.Session{InTransaction: true},
+		false)
+}
+
 func routerStream(router *Router, sql string) (qr *sqltypes.Result, err error) {
 	results := make(chan *sqltypes.Result, 10)
 	err = router.StreamExecute(context.Background(), sql, nil, "", topodatapb.TabletType_MASTER, func(qr *sqltypes.Result) error {
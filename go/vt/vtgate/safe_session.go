@@ -7,6 +7,9 @@ package vtgate
 import (
 	"sync"
 
+	"github.com/youtube/vitess/go/vt/key"
+	"github.com/youtube/vitess/go/vt/topo"
+
 	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
 	vtgatepb "github.com/youtube/vitess/go/vt/proto/vtgate"
 )
@@ -64,3 +67,62 @@ func (session *SafeSession) Reset() {
 	session.Session.InTransaction = false
 	session.ShardSessions = nil
 }
+
+// ShardGTID returns the GTID of the last commit of the session on
+// keyspace/shard, or "" if there's none.
+func (session *SafeSession) ShardGTID(keyspace, shard string) string {
+	if session == nil || session.Session == nil {
+		return ""
+	}
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	for _, shardGtid := range session.ShardGtids {
+		if keyspace == shardGtid.Keyspace && shard == shardGtid.Shard {
+			return shardGtid.Gtid
+		}
+	}
+	return ""
+}
+
+// SetShardGTID stores gtid as the GTID of the last commit of the
+// session on keyspace/shard.
+func (session *SafeSession) SetShardGTID(keyspace, shard, gtid string) {
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	for _, shardGtid := range session.ShardGtids {
+		if keyspace == shardGtid.Keyspace && shard == shardGtid.Shard {
+			shardGtid.Gtid = gtid
+			return
+		}
+	}
+	session.ShardGtids = append(session.ShardGtids, &vtgatepb.Session_ShardGtid{
+		Keyspace: keyspace,
+		Shard:    shard,
+		Gtid:     gtid,
+	})
+}
+
+// HasOverlappingShardGTID returns true if the session has the GTID of
+// a shard of keyspace other than shard, whose key range overlaps the
+// one of shard, like a source shard of shard after a reshard.
+func (session *SafeSession) HasOverlappingShardGTID(keyspace, shard string) bool {
+	if session == nil || session.Session == nil || !topo.IsShardUsingRangeBasedSharding(shard) {
+		return false
+	}
+	_, keyRange, err := topo.ValidateShardName(shard)
+	if err != nil {
+		return false
+	}
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	for _, shardGtid := range session.ShardGtids {
+		if keyspace != shardGtid.Keyspace || shard == shardGtid.Shard || !topo.IsShardUsingRangeBasedSharding(shardGtid.Shard) {
+			continue
+		}
+		_, gtidKeyRange, err := topo.ValidateShardName(shardGtid.Shard)
+		if err == nil && key.KeyRangesIntersect(keyRange, gtidKeyRange) {
+			return true
+		}
+	}
+	return false
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/youtube/vitess/go/sqltypes"
 	"github.com/youtube/vitess/go/sync2"
 	"github.com/youtube/vitess/go/vt/key"
+	"github.com/youtube/vitess/go/vt/sessiongtid"
 	"github.com/youtube/vitess/go/vt/tabletserver/querytypes"
 	"github.com/youtube/vitess/go/vt/tabletserver/tabletconn"
 	"golang.org/x/net/context"
@@ -292,7 +293,7 @@ func (sct *sandboxTopo) WatchVSchema(ctx context.Context, keyspace string) (noti
 }
 
 func (sct *sandboxTopo) GetSrvShard(ctx context.Context, cell, keyspace, shard string) (*topodatapb.SrvShard, error) {
-	return nil, fmt.Errorf("Unsupported")
+	return &topodatapb.SrvShard{Name: shard, MasterCell: "aa"}, nil
 }
 
 func (sct *sandboxTopo) GetEndPoints(ctx context.Context, cell, keyspace, shard string, tabletType topodatapb.TabletType) (*topodatapb.EndPoints, int64, error) {
@@ -338,13 +339,17 @@ func sandboxDialer(ctx context.Context, endPoint *topodatapb.EndPoint, keyspace,
 
 // sandboxConn satisfies the TabletConn interface
 type sandboxConn struct {
-	endPoint       *topodatapb.EndPoint
-	mustFailRetry  int
-	mustFailFatal  int
-	mustFailServer int
-	mustFailConn   int
-	mustFailTxPool int
-	mustFailNotTx  int
+	endPoint          *topodatapb.EndPoint
+	mustFailRetry     int
+	mustFailFatal     int
+	mustFailServer    int
+	mustFailConn      int
+	mustFailTxPool    int
+	mustFailNotTx     int
+	mustFailTransient int
+
+	// mustDelay delays the executions, until the end of their context.
+	mustDelay time.Duration
 
 	// A callback to tweak the behavior on each conn call
 	onConnUse func(*sandboxConn)
@@ -365,6 +370,13 @@ type sandboxConn struct {
 	// Each batch request is inlined as a slice of Queries.
 	BatchQueries [][]querytypes.BoundQuery
 
+	// WaitGTIDs stores the GTID each execution waited for,
+	// "" if it didn't wait.
+	WaitGTIDs []string
+
+	// commitGTID is the GTID returned by the commits.
+	commitGTID string
+
 	// results specifies the results to be returned.
 	// They're consumed as results are returned. If there are
 	// no results left, singleRowResult is returned.
@@ -417,6 +429,13 @@ func (sbc *sandboxConn) getError() error {
 			ServerCode: vtrpcpb.ErrorCode_NOT_IN_TX,
 		}
 	}
+	if sbc.mustFailTransient > 0 {
+		sbc.mustFailTransient--
+		return &tabletconn.ServerError{
+			Err:        "error: deadlock (errno 1213)",
+			ServerCode: vtrpcpb.ErrorCode_TRANSIENT_ERROR,
+		}
+	}
 	return nil
 }
 
@@ -434,6 +453,14 @@ func (sbc *sandboxConn) Execute(ctx context.Context, query string, bindVars map[
 		Sql:           query,
 		BindVariables: bv,
 	})
+	sbc.WaitGTIDs = append(sbc.WaitGTIDs, sessiongtid.WaitGTIDFromContext(ctx))
+	if sbc.mustDelay != 0 {
+		select {
+		case <-time.After(sbc.mustDelay):
+		case <-ctx.Done():
+			return nil, tabletconn.Cancelled
+		}
+	}
 	if err := sbc.getError(); err != nil {
 		return nil, err
 	}
@@ -449,6 +476,7 @@ func (sbc *sandboxConn) ExecuteBatch(ctx context.Context, queries []querytypes.B
 		return nil, err
 	}
 	sbc.BatchQueries = append(sbc.BatchQueries, queries)
+	sbc.WaitGTIDs = append(sbc.WaitGTIDs, sessiongtid.WaitGTIDFromContext(ctx))
 	result := make([]sqltypes.Result, 0, len(queries))
 	for range queries {
 		result = append(result, *(sbc.getNextResult()))
@@ -487,6 +515,10 @@ func (sbc *sandboxConn) StreamExecute(ctx context.Context, query string, bindVar
 	return &streamExecuteAdapter{result: r}, nil
 }
 
+func (sbc *sandboxConn) StreamExecuteResumable(ctx context.Context, query string, bindVars map[string]interface{}, resumeToken []byte) (tabletconn.ResumableResultStream, error) {
+	return nil, fmt.Errorf("not implemented in this test")
+}
+
 func (sbc *sandboxConn) Begin(ctx context.Context) (int64, error) {
 	sbc.BeginCount.Add(1)
 	err := sbc.getError()
@@ -498,9 +530,43 @@ func (sbc *sandboxConn) Begin(ctx context.Context) (int64, error) {
 
 func (sbc *sandboxConn) Commit(ctx context.Context, transactionID int64) error {
 	sbc.CommitCount.Add(1)
+	if err := sbc.getError(); err != nil {
+		return err
+	}
+	if recorder := sessiongtid.RecorderFromContext(ctx); recorder != nil {
+		recorder.GTID = sbc.commitGTID
+	}
+	return nil
+}
+
+func (sbc *sandboxConn) WaitForGTID(ctx context.Context, gtid string) error {
 	return sbc.getError()
 }
 
+func (sbc *sandboxConn) XAStart(ctx context.Context, xid string) (int64, error) {
+	return 0, sbc.getError()
+}
+
+func (sbc *sandboxConn) XAPrepare(ctx context.Context, xid string) error {
+	return sbc.getError()
+}
+
+func (sbc *sandboxConn) XACommit(ctx context.Context, xid string) error {
+	return sbc.getError()
+}
+
+func (sbc *sandboxConn) XARollback(ctx context.Context, xid string) error {
+	return sbc.getError()
+}
+
+func (sbc *sandboxConn) XARecover(ctx context.Context) ([]string, error) {
+	return nil, sbc.getError()
+}
+
+func (sbc *sandboxConn) HotQueries(ctx context.Context) ([]querytypes.BoundQuery, error) {
+	return nil, sbc.getError()
+}
+
 func (sbc *sandboxConn) Rollback(ctx context.Context, transactionID int64) error {
 	sbc.RollbackCount.Add(1)
 	return sbc.getError()
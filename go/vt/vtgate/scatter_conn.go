@@ -12,6 +12,7 @@ import (
 	"sync"
 	"time"
 
+	log "github.com/golang/glog"
 	"golang.org/x/net/context"
 
 	"github.com/youtube/vitess/go/sqltypes"
@@ -35,6 +36,8 @@ type ScatterConn struct {
 	tabletCallErrorCount *stats.MultiCounters
 	gateway              Gateway
 	testGateway          Gateway // test health checking module
+	limiter              *keyspaceLimiter
+	scatterWaiting       *stats.Int
 }
 
 // shardActionFunc defines the contract for a shard action
@@ -52,24 +55,37 @@ type shardActionFunc func(shard string) error
 // multiGoTransaction is capable of executing multiple
 // shardActionTransactionFunc actions in parallel and consolidating
 // the results and errors for the caller.
-type shardActionTransactionFunc func(shard string, shouldBegin bool, transactionID int64) (int64, error)
+// The tablet type may differ from the one of the query, see
+// withSessionGTID.
+type shardActionTransactionFunc func(ctx context.Context, shard string, tabletType topodatapb.TabletType, shouldBegin bool, transactionID int64) (int64, error)
 
 // NewScatterConn creates a new ScatterConn. All input parameters are passed through
 // for creating the appropriate connections.
 func NewScatterConn(hc discovery.HealthCheck, topoServer topo.Server, serv topo.SrvTopoServer, statsName, cell string, retryDelay time.Duration, retryCount int, connTimeoutTotal, connTimeoutPerConn, connLife time.Duration, tabletTypesToWait []topodatapb.TabletType, testGateway string) *ScatterConn {
 	tabletCallErrorCountStatsName := ""
 	tabletConnectStatsName := ""
+	scatterWaitingStatsName := ""
 	if statsName != "" {
 		tabletCallErrorCountStatsName = statsName + "ErrorCount"
 		tabletConnectStatsName = statsName + "TabletConnect"
+		scatterWaitingStatsName = statsName + "ScatterWaitingShardCalls"
 	}
 	connTimings := stats.NewMultiTimings(tabletConnectStatsName, []string{"Keyspace", "ShardName", "DbType"})
 	gateway := GetGatewayCreator()(hc, topoServer, serv, cell, retryDelay, retryCount, connTimeoutTotal, connTimeoutPerConn, connLife, connTimings, tabletTypesToWait)
+	limiter, err := newKeyspaceLimiterFromFlags(statsName)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	if limiter != nil {
+		gateway = &limitedGateway{Gateway: gateway, limiter: limiter}
+	}
 
 	sc := &ScatterConn{
 		timings:              stats.NewMultiTimings(statsName, []string{"Operation", "Keyspace", "ShardName", "DbType"}),
 		tabletCallErrorCount: stats.NewMultiCounters(tabletCallErrorCountStatsName, []string{"Operation", "Keyspace", "ShardName", "DbType"}),
 		gateway:              gateway,
+		limiter:              limiter,
+		scatterWaiting:       stats.NewInt(scatterWaitingStatsName),
 	}
 
 	// this is to test health checking module when using existing gateway
@@ -154,20 +170,10 @@ func (stc *ScatterConn) Execute(
 		tabletType,
 		session,
 		notInTransaction,
-		func(shard string, shouldBegin bool, transactionID int64) (int64, error) {
-			var innerqr *sqltypes.Result
-			if shouldBegin {
-				var err error
-				innerqr, transactionID, err = stc.gateway.BeginExecute(ctx, keyspace, shard, tabletType, query, bindVars)
-				if err != nil {
-					return transactionID, err
-				}
-			} else {
-				var err error
-				innerqr, err = stc.gateway.Execute(ctx, keyspace, shard, tabletType, query, bindVars, transactionID)
-				if err != nil {
-					return transactionID, err
-				}
+		func(ctx context.Context, shard string, tabletType topodatapb.TabletType, shouldBegin bool, transactionID int64) (int64, error) {
+			innerqr, transactionID, err := stc.executeShard(ctx, keyspace, shard, tabletType, query, bindVars, shouldBegin, transactionID)
+			if err != nil {
+				return transactionID, err
 			}
 
 			mu.Lock()
@@ -208,20 +214,10 @@ func (stc *ScatterConn) ExecuteMulti(
 		tabletType,
 		session,
 		notInTransaction,
-		func(shard string, shouldBegin bool, transactionID int64) (int64, error) {
-			var innerqr *sqltypes.Result
-			if shouldBegin {
-				var err error
-				innerqr, transactionID, err = stc.gateway.BeginExecute(ctx, keyspace, shard, tabletType, query, shardVars[shard])
-				if err != nil {
-					return transactionID, err
-				}
-			} else {
-				var err error
-				innerqr, err = stc.gateway.Execute(ctx, keyspace, shard, tabletType, query, shardVars[shard], transactionID)
-				if err != nil {
-					return transactionID, err
-				}
+		func(ctx context.Context, shard string, tabletType topodatapb.TabletType, shouldBegin bool, transactionID int64) (int64, error) {
+			innerqr, transactionID, err := stc.executeShard(ctx, keyspace, shard, tabletType, query, shardVars[shard], shouldBegin, transactionID)
+			if err != nil {
+				return transactionID, err
 			}
 
 			mu.Lock()
@@ -261,23 +257,10 @@ func (stc *ScatterConn) ExecuteEntityIds(
 		tabletType,
 		session,
 		notInTransaction,
-		func(shard string, shouldBegin bool, transactionID int64) (int64, error) {
-			sql := sqls[shard]
-			bindVar := bindVars[shard]
-			var innerqr *sqltypes.Result
-
-			if shouldBegin {
-				var err error
-				innerqr, transactionID, err = stc.gateway.BeginExecute(ctx, keyspace, shard, tabletType, sql, bindVar)
-				if err != nil {
-					return transactionID, err
-				}
-			} else {
-				var err error
-				innerqr, err = stc.gateway.Execute(ctx, keyspace, shard, tabletType, sql, bindVar, transactionID)
-				if err != nil {
-					return transactionID, err
-				}
+		func(ctx context.Context, shard string, tabletType topodatapb.TabletType, shouldBegin bool, transactionID int64) (int64, error) {
+			innerqr, transactionID, err := stc.executeShard(ctx, keyspace, shard, tabletType, sqls[shard], bindVars[shard], shouldBegin, transactionID)
+			if err != nil {
+				return transactionID, err
 			}
 
 			mu.Lock()
@@ -321,11 +304,17 @@ func (stc *ScatterConn) ExecuteBatch(
 	results := make([]sqltypes.Result, batchRequest.Length)
 	var resMutex sync.Mutex
 
+	slots := newScatterSlots(session.MaxScatterParallelism(), len(batchRequest.Requests), stc.scatterWaiting)
 	var wg sync.WaitGroup
 	for _, req := range batchRequest.Requests {
 		wg.Add(1)
 		go func(req *shardBatchRequest) {
 			defer wg.Done()
+			if err := slots.acquire(ctx, req.Keyspace, req.Shard); err != nil {
+				allErrors.RecordError(err)
+				return
+			}
+			defer slots.release()
 
 			var err error
 			startTime, statsKey := stc.startAction("ExecuteBatch", req.Keyspace, req.Shard, tabletType)
@@ -349,7 +338,8 @@ func (stc *ScatterConn) ExecuteBatch(
 					return
 				}
 			} else {
-				innerqrs, err = stc.gateway.ExecuteBatch(ctx, req.Keyspace, req.Shard, tabletType, req.Queries, asTransaction, transactionID)
+				shardCtx, shardTabletType := withSessionGTID(ctx, session, req.Keyspace, req.Shard, tabletType, transactionID != 0)
+				innerqrs, err = stc.gateway.ExecuteBatch(shardCtx, req.Keyspace, req.Shard, shardTabletType, req.Queries, asTransaction, transactionID)
 				if err != nil {
 					return
 				}
@@ -494,9 +484,12 @@ func (stc *ScatterConn) Commit(ctx context.Context, session *SafeSession) (err e
 			stc.gateway.Rollback(ctx, shardSession.Target.Keyspace, shardSession.Target.Shard, shardSession.Target.TabletType, shardSession.TransactionId)
 			continue
 		}
-		if err = stc.gateway.Commit(ctx, shardSession.Target.Keyspace, shardSession.Target.Shard, shardSession.Target.TabletType, shardSession.TransactionId); err != nil {
+		commitCtx, recordGTID := withCommitGTID(ctx, session, shardSession.Target.Keyspace, shardSession.Target.Shard)
+		if err = stc.gateway.Commit(commitCtx, shardSession.Target.Keyspace, shardSession.Target.Shard, shardSession.Target.TabletType, shardSession.TransactionId); err != nil {
 			committing = false
+			continue
 		}
+		recordGTID()
 	}
 	session.Reset()
 	return err
@@ -759,6 +752,15 @@ func (stc *ScatterConn) GetGatewayCacheStatus() GatewayEndPointCacheStatusList {
 	return stc.gateway.CacheStatus()
 }
 
+// GetKeyspaceLimiterStatus returns a displayable version of the
+// per-keyspace concurrency limits, or nil if there are none.
+func (stc *ScatterConn) GetKeyspaceLimiterStatus() KeyspaceLimiterStatusList {
+	if stc.limiter == nil {
+		return nil
+	}
+	return stc.limiter.status()
+}
+
 // ScatterConnError is the ScatterConn specific error.
 // It implements vterrors.VtError.
 type ScatterConnError struct {
@@ -799,7 +801,8 @@ func (stc *ScatterConn) aggregateErrors(errors []error) error {
 }
 
 // multiGo performs the requested 'action' on the specified
-// shards in parallel. This does not handle any transaction state.
+// shards in parallel, on at most -max-scatter-parallelism shards at a
+// time. This does not handle any transaction state.
 // The action function must match the shardActionFunc signature.
 func (stc *ScatterConn) multiGo(
 	ctx context.Context,
@@ -830,11 +833,17 @@ func (stc *ScatterConn) multiGo(
 		}
 	}
 
+	slots := newScatterSlots(MaxScatterParallelismFromContext(ctx), len(shardMap), stc.scatterWaiting)
 	var wg sync.WaitGroup
 	for shard := range shardMap {
 		wg.Add(1)
 		go func(shard string) {
 			defer wg.Done()
+			if err := slots.acquire(ctx, keyspace, shard); err != nil {
+				allErrors.RecordError(err)
+				return
+			}
+			defer slots.release()
 			oneShard(shard)
 		}(shard)
 	}
@@ -843,7 +852,8 @@ func (stc *ScatterConn) multiGo(
 }
 
 // multiGoTransaction performs the requested 'action' on the specified
-// shards in parallel. For each shard, if the requested
+// shards in parallel, on at most the max scatter parallelism of the
+// session at a time. For each shard, if the requested
 // session is in a transaction, it opens a new transactions on the connection,
 // and updates the Session with the transaction id. If the session already
 // contains a transaction id for the shard, it reuses it.
@@ -870,7 +880,8 @@ func (stc *ScatterConn) multiGoTransaction(
 		defer stc.endAction(startTime, allErrors, statsKey, &err)
 
 		shouldBegin, transactionID := transactionInfo(keyspace, shard, tabletType, session, notInTransaction)
-		transactionID, err = action(shard, shouldBegin, transactionID)
+		shardCtx, shardTabletType := withSessionGTID(ctx, session, keyspace, shard, tabletType, shouldBegin || transactionID != 0)
+		transactionID, err = action(shardCtx, shard, shardTabletType, shouldBegin, transactionID)
 		if shouldBegin && transactionID != 0 {
 			session.Append(&vtgatepb.Session_ShardSession{
 				Target: &querypb.Target{
@@ -891,11 +902,17 @@ func (stc *ScatterConn) multiGoTransaction(
 		}
 	}
 
+	slots := newScatterSlots(session.MaxScatterParallelism(), len(shardMap), stc.scatterWaiting)
 	var wg sync.WaitGroup
 	for shard := range shardMap {
 		wg.Add(1)
 		go func(shard string) {
 			defer wg.Done()
+			if err := slots.acquire(ctx, keyspace, shard); err != nil {
+				allErrors.RecordError(err)
+				return
+			}
+			defer slots.release()
 			oneShard(shard)
 		}(shard)
 	}
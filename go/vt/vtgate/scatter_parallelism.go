@@ -0,0 +1,147 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/sqltypes"
+	"github.com/youtube/vitess/go/stats"
+	"github.com/youtube/vitess/go/vt/vterrors"
+
+	vtgatepb "github.com/youtube/vitess/go/vt/proto/vtgate"
+	vtrpcpb "github.com/youtube/vitess/go/vt/proto/vtrpc"
+)
+
+var maxScatterParallelism = flag.Int("max-scatter-parallelism", 0, "maximum number of shards a scatter query sends its calls to at the same time. The calls to the other shards wait for a free slot, until the deadline of the query. 0 means no limit. SET VITESS_MAX_SCATTER_PARALLELISM = N overrides it for the queries of a session.")
+
+// setMaxScatterParallelism is the variable of the virtual statement
+// SET VITESS_MAX_SCATTER_PARALLELISM = N. It's answered by vtgate,
+// which stores N in the session.
+const setMaxScatterParallelism = "vitess_max_scatter_parallelism"
+
+// parseSetMaxScatterParallelism returns the N of sql if it's
+// SET VITESS_MAX_SCATTER_PARALLELISM = N, in any case, with an optional
+// trailing semicolon. ok is false for the other statements.
+func parseSetMaxScatterParallelism(sql string) (n int64, ok bool, err error) {
+	sql = strings.TrimSuffix(strings.TrimSpace(sql), ";")
+	fields := strings.Fields(sql)
+	if len(fields) < 2 || !strings.EqualFold(fields[0], "set") {
+		return 0, false, nil
+	}
+	parts := strings.SplitN(strings.Join(fields[1:], ""), "=", 2)
+	if !strings.EqualFold(parts[0], setMaxScatterParallelism) {
+		return 0, false, nil
+	}
+	if len(parts) != 2 {
+		return 0, true, vterrors.FromError(vtrpcpb.ErrorCode_BAD_INPUT, fmt.Errorf("missing value in %q", sql))
+	}
+	n, err = strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || n < 0 {
+		return 0, true, vterrors.FromError(vtrpcpb.ErrorCode_BAD_INPUT, fmt.Errorf("invalid %v %q: it must be a number of shards, or 0 for the default", setMaxScatterParallelism, parts[1]))
+	}
+	return n, true, nil
+}
+
+// setSessionMaxScatterParallelism stores n in session, or fails if the
+// request has no session to store it in.
+func setSessionMaxScatterParallelism(session *vtgatepb.Session, n int64) (*sqltypes.Result, error) {
+	if session == nil {
+		return nil, vterrors.FromError(vtrpcpb.ErrorCode_BAD_INPUT, fmt.Errorf("SET %v needs a session", strings.ToUpper(setMaxScatterParallelism)))
+	}
+	session.MaxScatterParallelism = n
+	return &sqltypes.Result{}, nil
+}
+
+// MaxScatterParallelism returns the limit of the session set by
+// SET VITESS_MAX_SCATTER_PARALLELISM, or 0 if it has none.
+func (session *SafeSession) MaxScatterParallelism() int {
+	if session == nil || session.Session == nil {
+		return 0
+	}
+	session.mu.Lock()
+	defer session.mu.Unlock()
+	return int(session.Session.MaxScatterParallelism)
+}
+
+type maxScatterParallelismKey struct{}
+
+// NewMaxScatterParallelismContext returns a context whose queries use
+// n as the limit of the session, like SET VITESS_MAX_SCATTER_PARALLELISM
+// = N. The RPC layers copy it from the streaming requests, which have
+// no session.
+func NewMaxScatterParallelismContext(ctx context.Context, n int64) context.Context {
+	if n == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, maxScatterParallelismKey{}, int(n))
+}
+
+// MaxScatterParallelismFromContext returns the limit stored by
+// NewMaxScatterParallelismContext, or 0 if there is none.
+func MaxScatterParallelismFromContext(ctx context.Context) int {
+	n, _ := ctx.Value(maxScatterParallelismKey{}).(int)
+	return n
+}
+
+// scatterSlots limits the number of shard calls of one scatter query
+// in flight. A nil scatterSlots has no limit.
+type scatterSlots struct {
+	slots   chan struct{}
+	waiting *stats.Int
+}
+
+// newScatterSlots returns the scatterSlots of a query sent to shards
+// shards, with limit, the one of its session, if it's not 0, or the one
+// of -max-scatter-parallelism. It returns nil if the query is under the
+// limit. waiting counts the calls waiting for a slot.
+func newScatterSlots(limit, shards int, waiting *stats.Int) *scatterSlots {
+	if limit == 0 {
+		configMu.RLock()
+		limit = *maxScatterParallelism
+		configMu.RUnlock()
+	}
+	if limit <= 0 || shards <= limit {
+		return nil
+	}
+	return &scatterSlots{
+		slots:   make(chan struct{}, limit),
+		waiting: waiting,
+	}
+}
+
+// acquire waits for a free slot, until ctx is done. Every successful
+// acquire must be followed by a release.
+func (ss *scatterSlots) acquire(ctx context.Context, keyspace, shard string) error {
+	if ss == nil {
+		return nil
+	}
+	select {
+	case ss.slots <- struct{}{}:
+		return nil
+	default:
+	}
+	ss.waiting.Add(1)
+	defer ss.waiting.Add(-1)
+	select {
+	case ss.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return vterrors.FromError(vtrpcpb.ErrorCode_DEADLINE_EXCEEDED, fmt.Errorf("shard %v/%v: waiting for a slot of the %d concurrent shard calls of the query: %v", keyspace, shard, cap(ss.slots), ctx.Err()))
+	}
+}
+
+// release frees the slot of a shard call.
+func (ss *scatterSlots) release() {
+	if ss == nil {
+		return
+	}
+	<-ss.slots
+}
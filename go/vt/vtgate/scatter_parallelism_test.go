@@ -0,0 +1,172 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/sqltypes"
+	"github.com/youtube/vitess/go/vt/topo"
+
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+	vtgatepb "github.com/youtube/vitess/go/vt/proto/vtgate"
+)
+
+func TestParseSetMaxScatterParallelism(t *testing.T) {
+	testcases := []struct {
+		sql string
+		n   int64
+		ok  bool
+		err string
+	}{
+		{sql: "set vitess_max_scatter_parallelism = 10", n: 10, ok: true},
+		{sql: "  SET\tVITESS_MAX_SCATTER_PARALLELISM=0 ;", n: 0, ok: true},
+		{sql: "set vitess_max_scatter_parallelism", ok: true, err: `missing value in "set vitess_max_scatter_parallelism"`},
+		{sql: "set vitess_max_scatter_parallelism = -1", ok: true, err: `invalid vitess_max_scatter_parallelism "-1": it must be a number of shards, or 0 for the default`},
+		{sql: "set vitess_max_scatter_parallelism = many", ok: true, err: `invalid vitess_max_scatter_parallelism "many": it must be a number of shards, or 0 for the default`},
+		{sql: "set autocommit = 1"},
+		{sql: "select vitess_max_scatter_parallelism from t"},
+		{sql: "set"},
+	}
+	for _, tcase := range testcases {
+		n, ok, err := parseSetMaxScatterParallelism(tcase.sql)
+		if tcase.err != "" {
+			if !ok || err == nil || err.Error() != tcase.err {
+				t.Errorf("parseSetMaxScatterParallelism(%q): %v, %v, want %v", tcase.sql, ok, err, tcase.err)
+			}
+			continue
+		}
+		if n != tcase.n || ok != tcase.ok || err != nil {
+			t.Errorf("parseSetMaxScatterParallelism(%q): %v, %v, %v, want %v, %v", tcase.sql, n, ok, err, tcase.n, tcase.ok)
+		}
+	}
+}
+
+func TestRouterSetMaxScatterParallelism(t *testing.T) {
+	router, _, _, _ := createRouterEnv()
+	session := &vtgatepb.Session{InTransaction: true}
+	if _, err := router.Execute(context.Background(), "set vitess_max_scatter_parallelism = 16", nil, "", topodatapb.TabletType_MASTER, session, false); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if session.MaxScatterParallelism != 16 {
+		t.Errorf("MaxScatterParallelism: %v, want 16", session.MaxScatterParallelism)
+	}
+
+	want := "SET VITESS_MAX_SCATTER_PARALLELISM needs a session"
+	if _, err := routerExec(router, "set vitess_max_scatter_parallelism = 16", nil); err == nil || err.Error() != want {
+		t.Errorf("Execute without session: %v, want %v", err, want)
+	}
+}
+
+// scatterTracker records the maximum number of concurrent shard calls,
+// and of shard calls waiting for a slot.
+type scatterTracker struct {
+	stc   *ScatterConn
+	delay time.Duration
+
+	mu                    sync.Mutex
+	inFlight, maxInFlight int
+	maxWaiting            int64
+}
+
+func (st *scatterTracker) onConnUse(*sandboxConn) {
+	st.mu.Lock()
+	st.inFlight++
+	if st.inFlight > st.maxInFlight {
+		st.maxInFlight = st.inFlight
+	}
+	st.mu.Unlock()
+	time.Sleep(st.delay)
+	st.mu.Lock()
+	if waiting := st.stc.scatterWaiting.Get(); waiting > st.maxWaiting {
+		st.maxWaiting = waiting
+	}
+	st.inFlight--
+	st.mu.Unlock()
+}
+
+func (st *scatterTracker) reset() {
+	st.mu.Lock()
+	defer st.mu.Unlock()
+	st.maxInFlight = 0
+	st.maxWaiting = 0
+}
+
+func TestScatterConnMaxScatterParallelism(t *testing.T) {
+	defer func(v int) { *maxScatterParallelism = v }(*maxScatterParallelism)
+	*maxScatterParallelism = 2
+
+	keyspace := "TestScatterConnMaxScatterParallelism"
+	s := createSandbox(keyspace)
+	st := &scatterTracker{delay: 20 * time.Millisecond}
+	shards := []string{"0", "1", "2", "3", "4", "5"}
+	for _, shard := range shards {
+		s.MapTestConn(shard, &sandboxConn{onConnUse: st.onConnUse})
+	}
+	st.stc = NewScatterConn(nil, topo.Server{}, new(sandboxTopo), "", "aa", retryDelay, retryCount, connTimeoutTotal, connTimeoutPerConn, connLife, nil, "")
+
+	testcases := []struct {
+		session *SafeSession
+		want    int
+	}{
+		{nil, 2},
+		// The session overrides the flag.
+		{NewSafeSession(&vtgatepb.Session{MaxScatterParallelism: 3}), 3},
+		{NewSafeSession(&vtgatepb.Session{}), 2},
+	}
+	for _, tcase := range testcases {
+		st.reset()
+		qr, err := st.stc.Execute(context.Background(), "query", nil, keyspace, shards, topodatapb.TabletType_REPLICA, tcase.session, false)
+		if err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+		if len(qr.Rows) != len(shards) {
+			t.Errorf("Execute: %v rows, want %v", len(qr.Rows), len(shards))
+		}
+		if st.maxInFlight > tcase.want {
+			t.Errorf("Execute with session %v: %v concurrent shard calls, want at most %v", tcase.session, st.maxInFlight, tcase.want)
+		}
+		if st.maxWaiting == 0 {
+			t.Errorf("Execute with session %v: no shard call waited", tcase.session)
+		}
+	}
+	if got := st.stc.scatterWaiting.Get(); got != 0 {
+		t.Errorf("scatterWaiting: %v, want 0", got)
+	}
+
+	// The streaming queries use the flag, or the limit of their
+	// context.
+	for _, want := range []int{2, 3} {
+		st.reset()
+		ctx := context.Background()
+		if want != 2 {
+			ctx = NewMaxScatterParallelismContext(ctx, int64(want))
+		}
+		err := st.stc.StreamExecute(ctx, "query", nil, keyspace, shards, topodatapb.TabletType_REPLICA, func(*sqltypes.Result) error { return nil })
+		if err != nil {
+			t.Fatalf("StreamExecute: %v", err)
+		}
+		if st.maxInFlight > want {
+			t.Errorf("StreamExecute: %v concurrent shard calls, want at most %v", st.maxInFlight, want)
+		}
+		if st.maxWaiting == 0 {
+			t.Errorf("StreamExecute with a limit of %v: no shard call waited", want)
+		}
+	}
+
+	// The calls waiting for a slot fail at the deadline of the query.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := st.stc.Execute(ctx, "query", nil, keyspace, shards, topodatapb.TabletType_REPLICA, nil, false)
+	want := "waiting for a slot of the 2 concurrent shard calls of the query: context deadline exceeded"
+	if err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("Execute with a deadline: %v, want %v", err, want)
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"flag"
+
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/vt/sessiongtid"
+
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+)
+
+var sessionGTIDConsistency = flag.Bool("session-gtid-consistency", false, "let the sessions read their own writes on the replicas: the commits store the GTID of each master in the session, and the later reads of the session outside transactions wait until the tablet has replicated up to the GTID of its shard")
+
+// withSessionGTID returns a context whose queries to keyspace/shard
+// wait for the GTID of the last commit of session on the shard, with
+// -session-gtid-consistency, and the tablet type to send them to. The
+// masters and the transactions don't wait. The GTIDs of a shard mean
+// nothing on the other shards, so the reads of a shard the session
+// didn't commit to, but whose key range overlaps a shard it committed
+// to, like the new shards after a reshard, go to the master instead.
+// Once the session commits to the new shard, its GTID covers the
+// writes replicated from the source shards before the reshard.
+func withSessionGTID(ctx context.Context, session *SafeSession, keyspace, shard string, tabletType topodatapb.TabletType, inTransaction bool) (context.Context, topodatapb.TabletType) {
+	if !*sessionGTIDConsistency || inTransaction || tabletType == topodatapb.TabletType_MASTER {
+		return ctx, tabletType
+	}
+	gtid := session.ShardGTID(keyspace, shard)
+	if gtid == "" {
+		if session.HasOverlappingShardGTID(keyspace, shard) {
+			return ctx, topodatapb.TabletType_MASTER
+		}
+		return ctx, tabletType
+	}
+	return sessiongtid.NewWaitContext(ctx, gtid), tabletType
+}
+
+// withCommitGTID returns a context whose commit records the GTID of
+// the master, with -session-gtid-consistency, and a function that
+// stores it in session for keyspace/shard.
+func withCommitGTID(ctx context.Context, session *SafeSession, keyspace, shard string) (context.Context, func()) {
+	if !*sessionGTIDConsistency {
+		return ctx, func() {}
+	}
+	ctx, recorder := sessiongtid.NewRecorderContext(ctx)
+	return ctx, func() {
+		if recorder.GTID != "" {
+			session.SetShardGTID(keyspace, shard, recorder.GTID)
+		}
+	}
+}
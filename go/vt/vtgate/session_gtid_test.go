@@ -0,0 +1,172 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"reflect"
+	"testing"
+
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/vt/sessiongtid"
+
+	querypb "github.com/youtube/vitess/go/vt/proto/query"
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+	vtgatepb "github.com/youtube/vitess/go/vt/proto/vtgate"
+)
+
+func TestSafeSessionShardGTID(t *testing.T) {
+	var nilSession *SafeSession
+	if got := nilSession.ShardGTID("ks", "0"); got != "" {
+		t.Errorf("ShardGTID of a nil session: %q, want \"\"", got)
+	}
+	if got := NewSafeSession(nil).ShardGTID("ks", "0"); got != "" {
+		t.Errorf("ShardGTID of an empty session: %q, want \"\"", got)
+	}
+
+	session := NewSafeSession(&vtgatepb.Session{})
+	session.SetShardGTID("ks", "0", "MySQL56/0b1ae4f4-4e2c-11e6-beb8-9e71128cae77:1-5")
+	session.SetShardGTID("ks", "1", "MySQL56/1c2bf5a5-4e2c-11e6-beb8-9e71128cae77:1-2")
+	session.SetShardGTID("ks", "0", "MySQL56/0b1ae4f4-4e2c-11e6-beb8-9e71128cae77:1-6")
+	want := []*vtgatepb.Session_ShardGtid{
+		{Keyspace: "ks", Shard: "0", Gtid: "MySQL56/0b1ae4f4-4e2c-11e6-beb8-9e71128cae77:1-6"},
+		{Keyspace: "ks", Shard: "1", Gtid: "MySQL56/1c2bf5a5-4e2c-11e6-beb8-9e71128cae77:1-2"},
+	}
+	if !reflect.DeepEqual(session.ShardGtids, want) {
+		t.Errorf("ShardGtids: %v, want %v", session.ShardGtids, want)
+	}
+	if got, want := session.ShardGTID("ks", "1"), "MySQL56/1c2bf5a5-4e2c-11e6-beb8-9e71128cae77:1-2"; got != want {
+		t.Errorf("ShardGTID(ks, 1): %q, want %q", got, want)
+	}
+	if got := session.ShardGTID("ks", "2"); got != "" {
+		t.Errorf("ShardGTID(ks, 2): %q, want \"\"", got)
+	}
+
+	session.Reset()
+	if !reflect.DeepEqual(session.ShardGtids, want) {
+		t.Errorf("ShardGtids after Reset: %v, want %v", session.ShardGtids, want)
+	}
+}
+
+func TestWithSessionGTID(t *testing.T) {
+	defer func(enabled bool) {
+		*sessionGTIDConsistency = enabled
+	}(*sessionGTIDConsistency)
+
+	session := NewSafeSession(&vtgatepb.Session{})
+	session.SetShardGTID("ks", "0", "MySQL56/0b1ae4f4-4e2c-11e6-beb8-9e71128cae77:1-5")
+	testcases := []struct {
+		enabled       bool
+		shard         string
+		tabletType    topodatapb.TabletType
+		inTransaction bool
+		want          string
+	}{
+		{true, "0", topodatapb.TabletType_REPLICA, false, "MySQL56/0b1ae4f4-4e2c-11e6-beb8-9e71128cae77:1-5"},
+		{true, "0", topodatapb.TabletType_RDONLY, false, "MySQL56/0b1ae4f4-4e2c-11e6-beb8-9e71128cae77:1-5"},
+		{true, "0", topodatapb.TabletType_MASTER, false, ""},
+		{true, "0", topodatapb.TabletType_REPLICA, true, ""},
+		{true, "1", topodatapb.TabletType_REPLICA, false, ""},
+		{false, "0", topodatapb.TabletType_REPLICA, false, ""},
+	}
+	for _, tcase := range testcases {
+		*sessionGTIDConsistency = tcase.enabled
+		ctx, tabletType := withSessionGTID(context.Background(), session, "ks", tcase.shard, tcase.tabletType, tcase.inTransaction)
+		if got := sessiongtid.WaitGTIDFromContext(ctx); got != tcase.want {
+			t.Errorf("withSessionGTID(%+v): %q, want %q", tcase, got, tcase.want)
+		}
+		if tabletType != tcase.tabletType {
+			t.Errorf("withSessionGTID(%+v) tablet type: %v, want %v", tcase, tabletType, tcase.tabletType)
+		}
+	}
+}
+
+func TestWithSessionGTIDReshard(t *testing.T) {
+	defer func(enabled bool) {
+		*sessionGTIDConsistency = enabled
+	}(*sessionGTIDConsistency)
+	*sessionGTIDConsistency = true
+
+	// The session committed to -80 before it was split into -40 and
+	// 40-80, and to 40-80 after.
+	session := NewSafeSession(&vtgatepb.Session{})
+	session.SetShardGTID("ks", "-80", "MySQL56/0b1ae4f4-4e2c-11e6-beb8-9e71128cae77:1-5")
+	session.SetShardGTID("ks", "40-80", "MySQL56/1c2bf5a5-4e2c-11e6-beb8-9e71128cae77:1-2")
+	session.SetShardGTID("other", "80-", "MySQL56/2d3cf6b6-4e2c-11e6-beb8-9e71128cae77:1-3")
+	testcases := []struct {
+		keyspace       string
+		shard          string
+		want           string
+		wantTabletType topodatapb.TabletType
+	}{
+		{"ks", "-80", "MySQL56/0b1ae4f4-4e2c-11e6-beb8-9e71128cae77:1-5", topodatapb.TabletType_REPLICA},
+		{"ks", "-40", "", topodatapb.TabletType_MASTER},
+		{"ks", "40-80", "MySQL56/1c2bf5a5-4e2c-11e6-beb8-9e71128cae77:1-2", topodatapb.TabletType_REPLICA},
+		{"ks", "80-", "", topodatapb.TabletType_REPLICA},
+		{"ks", "0", "", topodatapb.TabletType_REPLICA},
+		{"other", "-80", "", topodatapb.TabletType_REPLICA},
+	}
+	for _, tcase := range testcases {
+		ctx, tabletType := withSessionGTID(context.Background(), session, tcase.keyspace, tcase.shard, topodatapb.TabletType_REPLICA, false)
+		if got := sessiongtid.WaitGTIDFromContext(ctx); got != tcase.want {
+			t.Errorf("withSessionGTID(%v/%v): %q, want %q", tcase.keyspace, tcase.shard, got, tcase.want)
+		}
+		if tabletType != tcase.wantTabletType {
+			t.Errorf("withSessionGTID(%v/%v) tablet type: %v, want %v", tcase.keyspace, tcase.shard, tabletType, tcase.wantTabletType)
+		}
+	}
+}
+
+func TestVTGateSessionGTIDConsistency(t *testing.T) {
+	defer func(enabled bool) {
+		*sessionGTIDConsistency = enabled
+	}(*sessionGTIDConsistency)
+	*sessionGTIDConsistency = true
+
+	keyspace := "TestVTGateSessionGTIDConsistency"
+	sandbox := createSandbox(keyspace)
+	sbc := &sandboxConn{commitGTID: "MySQL56/0b1ae4f4-4e2c-11e6-beb8-9e71128cae77:1-7"}
+	sandbox.MapTestConn("0", sbc)
+
+	session, err := rpcVTGate.Begin(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rpcVTGate.ExecuteShards(context.Background(), "update t set a = 1 where id = 1", nil, keyspace, []string{"0"}, topodatapb.TabletType_MASTER, session, false); err != nil {
+		t.Fatalf("ExecuteShards: %v", err)
+	}
+	if err := rpcVTGate.Commit(context.Background(), session); err != nil {
+		t.Fatalf("Commit: %v", err)
+	}
+	want := []*vtgatepb.Session_ShardGtid{{Keyspace: keyspace, Shard: "0", Gtid: "MySQL56/0b1ae4f4-4e2c-11e6-beb8-9e71128cae77:1-7"}}
+	if !reflect.DeepEqual(session.ShardGtids, want) {
+		t.Errorf("ShardGtids after Commit: %v, want %v", session.ShardGtids, want)
+	}
+
+	// The reads of the session on the replicas wait for the GTID,
+	// the ones on the master and without the session don't.
+	sbc.WaitGTIDs = nil
+	if _, err := rpcVTGate.ExecuteShards(context.Background(), "select a from t where id = 1", nil, keyspace, []string{"0"}, topodatapb.TabletType_REPLICA, session, false); err != nil {
+		t.Fatalf("ExecuteShards: %v", err)
+	}
+	if _, err := rpcVTGate.ExecuteShards(context.Background(), "select a from t where id = 1", nil, keyspace, []string{"0"}, topodatapb.TabletType_MASTER, session, false); err != nil {
+		t.Fatalf("ExecuteShards: %v", err)
+	}
+	if _, err := rpcVTGate.ExecuteShards(context.Background(), "select a from t where id = 1", nil, keyspace, []string{"0"}, topodatapb.TabletType_REPLICA, nil, false); err != nil {
+		t.Fatalf("ExecuteShards: %v", err)
+	}
+	queries := []*vtgatepb.BoundShardQuery{{
+		Query:    &querypb.BoundQuery{Sql: "select a from t where id = 1"},
+		Keyspace: keyspace,
+		Shards:   []string{"0"},
+	}}
+	if _, err := rpcVTGate.ExecuteBatchShards(context.Background(), queries, topodatapb.TabletType_REPLICA, false, session); err != nil {
+		t.Fatalf("ExecuteBatchShards: %v", err)
+	}
+	wantGTIDs := []string{"MySQL56/0b1ae4f4-4e2c-11e6-beb8-9e71128cae77:1-7", "", "", "MySQL56/0b1ae4f4-4e2c-11e6-beb8-9e71128cae77:1-7"}
+	if !reflect.DeepEqual(sbc.WaitGTIDs, wantGTIDs) {
+		t.Errorf("WaitGTIDs: %q, want %q", sbc.WaitGTIDs, wantGTIDs)
+	}
+}
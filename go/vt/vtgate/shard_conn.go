@@ -413,6 +413,7 @@ func (sdc *ShardConn) getConnTimeoutPerConn(endPointCount int) time.Duration {
 
 // canRetry determines whether a query can be retried or not.
 // OperationalErrors like retry/fatal cause a reconnect and retry if query is not in a txn.
+// Transient errors cause a retry on the same connection if query is not in a txn.
 // TxPoolFull causes a retry and all other errors are non-retry.
 func (sdc *ShardConn) canRetry(ctx context.Context, err error, transactionID int64, conn tabletconn.TabletConn, isStreaming bool) bool {
 	if err == nil {
@@ -442,6 +443,11 @@ func (sdc *ShardConn) canRetry(ctx context.Context, err error, transactionID int
 			inTransaction := (transactionID != 0)
 			sdc.markDown(conn, err.Error())
 			return !inTransaction
+		case vtrpcpb.ErrorCode_TRANSIENT_ERROR:
+			// vttablet only returns TRANSIENT_ERROR for statements
+			// it executed outside of a transaction, but check anyway.
+			inTransaction := (transactionID != 0)
+			return !inTransaction && !isStreaming
 		default:
 			// Not retry for RESOURCE_EXHAUSTED and normal
 			// server errors.
@@ -329,6 +329,38 @@ func TestShardConnStreamingRetry(t *testing.T) {
 	}
 }
 
+func TestShardConnTransientRetry(t *testing.T) {
+	s := createSandbox("TestShardConnTransientRetry")
+	sbc := &sandboxConn{mustFailTransient: 1}
+	s.MapTestConn("0", sbc)
+	sdc := NewShardConn(context.Background(), new(sandboxTopo), "aa", "TestShardConnTransientRetry", "0", topodatapb.TabletType_MASTER, 10*time.Millisecond, 3, connTimeoutTotal, connTimeoutPerConn, connLife, connectTimings)
+	_, err := sdc.Execute(context.Background(), "query", nil, 0)
+	if err != nil {
+		t.Errorf("want nil, got %v", err)
+	}
+	// The connection should have been reused.
+	if s.DialCounter != 1 {
+		t.Errorf("want 1, got %v", s.DialCounter)
+	}
+	if execCount := sbc.ExecCount.Get(); execCount != 2 {
+		t.Errorf("want 2, got %v", execCount)
+	}
+
+	// Should not retry if we're in transaction.
+	s.Reset()
+	sbc = &sandboxConn{mustFailTransient: 1}
+	s.MapTestConn("0", sbc)
+	sdc = NewShardConn(context.Background(), new(sandboxTopo), "aa", "TestShardConnTransientRetry", "0", topodatapb.TabletType_MASTER, 10*time.Millisecond, 3, connTimeoutTotal, connTimeoutPerConn, connLife, connectTimings)
+	_, err = sdc.Execute(context.Background(), "query", nil, 1)
+	want := "shard, host: TestShardConnTransientRetry.0.master, host:\"0\" port_map:<key:\"vt\" value:1 > , error: deadlock (errno 1213)"
+	if err == nil || err.Error() != want {
+		t.Errorf("want %v, got %v", want, err)
+	}
+	if execCount := sbc.ExecCount.Get(); execCount != 1 {
+		t.Errorf("want 1, got %v", execCount)
+	}
+}
+
 func TestShardConnTimeout(t *testing.T) {
 	s := createSandbox("TestShardConnTimeout")
 	// case 1: one endpoint, per conn timeout becomes total timeout
@@ -0,0 +1,110 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/sqltypes"
+
+	querypb "github.com/youtube/vitess/go/vt/proto/query"
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+)
+
+// showVitessKeyspaces is the virtual statement that lists the
+// keyspaces vtgate serves. It's answered by vtgate, it's not sent to
+// the tablets.
+const showVitessKeyspaces = "show vitess_keyspaces"
+
+var showVitessKeyspacesFields = []*querypb.Field{
+	{Name: "Keyspace", Type: sqltypes.VarChar},
+	{Name: "ShardingType", Type: sqltypes.VarChar},
+	{Name: "ShardCount", Type: sqltypes.Int64},
+	{Name: "PrimaryCell", Type: sqltypes.VarChar},
+	{Name: "VSchemaVersion", Type: sqltypes.VarChar},
+}
+
+// isShowVitessKeyspaces returns true if sql is SHOW VITESS_KEYSPACES,
+// in any case, with an optional trailing semicolon.
+func isShowVitessKeyspaces(sql string) bool {
+	sql = strings.TrimSuffix(strings.TrimSpace(sql), ";")
+	return strings.EqualFold(strings.Join(strings.Fields(sql), " "), showVitessKeyspaces)
+}
+
+// showKeyspaces returns a row per keyspace of the serving graph of the
+// cell of the Router: its name, whether the VSchema shards it, the
+// number of shards serving its masters, the cells of these masters,
+// and the version of the VSchema. A keyspace missing from the VSchema
+// is sharded if its SrvKeyspace has a sharding column, and has no
+// VSchema version.
+func (rtr *Router) showKeyspaces(ctx context.Context) (*sqltypes.Result, error) {
+	keyspaces, err := rtr.serv.GetSrvKeyspaceNames(ctx, rtr.cell)
+	if err != nil {
+		return nil, fmt.Errorf("cannot list the keyspaces: %v", err)
+	}
+	sort.Strings(keyspaces)
+	vschema := rtr.planner.VSchema()
+	version := rtr.planner.VSchemaVersion()
+
+	qr := &sqltypes.Result{Fields: showVitessKeyspacesFields}
+	for _, keyspace := range keyspaces {
+		srvKeyspace, err := rtr.serv.GetSrvKeyspace(ctx, rtr.cell, keyspace)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read keyspace %v: %v", keyspace, err)
+		}
+		var shards []*topodatapb.ShardReference
+		for _, partition := range srvKeyspace.Partitions {
+			if partition.ServedType == topodatapb.TabletType_MASTER {
+				shards = partition.ShardReferences
+			}
+		}
+		cells := make(map[string]bool)
+		for _, shard := range shards {
+			srvShard, err := rtr.serv.GetSrvShard(ctx, rtr.cell, keyspace, shard.Name)
+			if err != nil {
+				return nil, fmt.Errorf("cannot read shard %v/%v: %v", keyspace, shard.Name, err)
+			}
+			if srvShard.MasterCell != "" {
+				cells[srvShard.MasterCell] = true
+			}
+		}
+		masterCells := make([]string, 0, len(cells))
+		for cell := range cells {
+			masterCells = append(masterCells, cell)
+		}
+		sort.Strings(masterCells)
+
+		sharded := srvKeyspace.ShardingColumnName != ""
+		keyspaceVersion := sqltypes.NULL
+		if vschema != nil {
+			if ks, ok := vschema.Keyspaces[keyspace]; ok {
+				sharded = ks.Keyspace.Sharded
+				keyspaceVersion = sqltypes.MakeString([]byte(version))
+			}
+		}
+		shardingType := "unsharded"
+		if sharded {
+			shardingType = "sharded"
+		}
+		primaryCell := sqltypes.NULL
+		if len(masterCells) != 0 {
+			primaryCell = sqltypes.MakeString([]byte(strings.Join(masterCells, ",")))
+		}
+		qr.Rows = append(qr.Rows, []sqltypes.Value{
+			sqltypes.MakeString([]byte(keyspace)),
+			sqltypes.MakeString([]byte(shardingType)),
+			sqltypes.MakeTrusted(sqltypes.Int64, strconv.AppendInt(nil, int64(len(shards)), 10)),
+			primaryCell,
+			keyspaceVersion,
+		})
+	}
+	qr.RowsAffected = uint64(len(qr.Rows))
+	return qr, nil
+}
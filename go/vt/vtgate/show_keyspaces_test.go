@@ -0,0 +1,108 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/sqltypes"
+	"github.com/youtube/vitess/go/vt/topo"
+)
+
+func TestIsShowVitessKeyspaces(t *testing.T) {
+	testcases := []struct {
+		sql  string
+		want bool
+	}{
+		{"show vitess_keyspaces", true},
+		{"SHOW VITESS_KEYSPACES", true},
+		{"  Show\tvitess_keyspaces ;", true},
+		{"show vitess_keyspaces like 'a'", false},
+		{"show keyspaces", false},
+		{"select * from vitess_keyspaces", false},
+	}
+	for _, tcase := range testcases {
+		if got := isShowVitessKeyspaces(tcase.sql); got != tcase.want {
+			t.Errorf("isShowVitessKeyspaces(%q): %v, want %v", tcase.sql, got, tcase.want)
+		}
+	}
+}
+
+func TestRouterShowVitessKeyspaces(t *testing.T) {
+	// Only the keyspaces of this test are in the serving graph.
+	sandboxMu.Lock()
+	saved := ksToSandbox
+	ksToSandbox = make(map[string]*sandbox)
+	sandboxMu.Unlock()
+	defer func() {
+		sandboxMu.Lock()
+		ksToSandbox = saved
+		sandboxMu.Unlock()
+	}()
+
+	createSandbox("TestRouter").VSchema = routerVSchema
+	createSandbox(KsTestUnsharded).VSchema = unshardedVSchema
+	// A keyspace missing from the VSchema.
+	createSandbox("TestNoVSchema").VSchema = ""
+	serv := new(sandboxTopo)
+	scatterConn := NewScatterConn(nil, topo.Server{}, serv, "", "aa", 1*time.Second, 10, 20*time.Millisecond, 10*time.Millisecond, 24*time.Hour, nil, "")
+	router := NewRouter(context.Background(), serv, "aa", "", scatterConn)
+	version := router.planner.VSchemaVersion()
+	if version == "" {
+		t.Fatalf("VSchemaVersion: \"\", want a version")
+	}
+
+	want := &sqltypes.Result{
+		Fields: showVitessKeyspacesFields,
+		Rows: [][]sqltypes.Value{
+			{
+				sqltypes.MakeString([]byte("TestNoVSchema")),
+				sqltypes.MakeString([]byte("sharded")),
+				sqltypes.MakeTrusted(sqltypes.Int64, []byte("8")),
+				sqltypes.MakeString([]byte("aa")),
+				sqltypes.NULL,
+			},
+			{
+				sqltypes.MakeString([]byte("TestRouter")),
+				sqltypes.MakeString([]byte("sharded")),
+				sqltypes.MakeTrusted(sqltypes.Int64, []byte("8")),
+				sqltypes.MakeString([]byte("aa")),
+				sqltypes.MakeString([]byte(version)),
+			},
+			{
+				sqltypes.MakeString([]byte(KsTestUnsharded)),
+				sqltypes.MakeString([]byte("unsharded")),
+				sqltypes.MakeTrusted(sqltypes.Int64, []byte("1")),
+				sqltypes.MakeString([]byte("aa")),
+				sqltypes.MakeString([]byte(version)),
+			},
+		},
+		RowsAffected: 3,
+	}
+	qr, err := routerExec(router, "show vitess_keyspaces", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(qr, want) {
+		t.Errorf("show vitess_keyspaces:\n%+v, want\n%+v", qr, want)
+	}
+
+	qr, err = routerStream(router, "SHOW VITESS_KEYSPACES")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(qr, want) {
+		t.Errorf("streamed show vitess_keyspaces:\n%+v, want\n%+v", qr, want)
+	}
+
+	getSandbox("TestRouter").SrvKeyspaceMustFail = 1
+	if _, err := routerExec(router, "show vitess_keyspaces", nil); err == nil {
+		t.Errorf("show vitess_keyspaces with a topo error: nil, want error")
+	}
+}
@@ -94,13 +94,22 @@ func VSchemaFormalForKeyspace(input []byte, name string) (*VSchemaFormal, error)
 }
 
 // ValidateVSchema ensures that the the JSON representation
-// of the keyspace vschema are valid.
+// of the keyspace vschema are valid. The errors give the path
+// of the fields at fault, like Tables.t1.ColVindexes[0].Name.
 // External references (like sequence) are not validated.
 func ValidateVSchema(input []byte) error {
+	// The syntax errors are reported by VSchemaFormalForKeyspace.
+	if errs, err := checkKeyspaceJSON(input); err == nil && len(errs) != 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
 	formal, err := VSchemaFormalForKeyspace(input, "ks")
 	if err != nil {
 		return err
 	}
+	ks := formal.Keyspaces["ks"]
+	if errs := checkKeyspaceFormal(&ks); len(errs) != 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
 	// We go through the motion of building the vschema,
 	// but just for this keyspace
 	vschema := &VSchema{
@@ -0,0 +1,69 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vindexes
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// DiffKeyspaceFormal returns the differences between two keyspace
+// vschemas, one line per changed field, vindex or table. The added
+// entries start with "+", the removed ones with "-", and the changed
+// ones with "~". It returns nil if left and right are the same.
+func DiffKeyspaceFormal(left, right *KeyspaceFormal) (result []string) {
+	if left.Sharded != right.Sharded {
+		result = append(result, fmt.Sprintf("~ Sharded: %v -> %v", left.Sharded, right.Sharded))
+	}
+
+	names := make(map[string]bool)
+	for name := range left.Vindexes {
+		names[name] = true
+	}
+	for name := range right.Vindexes {
+		names[name] = true
+	}
+	for _, name := range sortedNames(names) {
+		l, inLeft := left.Vindexes[name]
+		r, inRight := right.Vindexes[name]
+		result = appendDiff(result, joinPath("Vindexes", name), l, inLeft, r, inRight)
+	}
+
+	names = make(map[string]bool)
+	for name := range left.Tables {
+		names[name] = true
+	}
+	for name := range right.Tables {
+		names[name] = true
+	}
+	for _, name := range sortedNames(names) {
+		l, inLeft := left.Tables[name]
+		r, inRight := right.Tables[name]
+		result = appendDiff(result, joinPath("Tables", name), l, inLeft, r, inRight)
+	}
+	return result
+}
+
+func appendDiff(result []string, path string, left interface{}, inLeft bool, right interface{}, inRight bool) []string {
+	switch {
+	case !inLeft:
+		return append(result, fmt.Sprintf("+ %s: %s", path, jsonValue(right)))
+	case !inRight:
+		return append(result, fmt.Sprintf("- %s: %s", path, jsonValue(left)))
+	case !reflect.DeepEqual(left, right):
+		return append(result, fmt.Sprintf("~ %s: %s -> %s", path, jsonValue(left), jsonValue(right)))
+	}
+	return result
+}
+
+func sortedNames(names map[string]bool) []string {
+	result := make([]string, 0, len(names))
+	for name := range names {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
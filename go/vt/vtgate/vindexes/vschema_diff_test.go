@@ -0,0 +1,48 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vindexes
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDiffKeyspaceFormal(t *testing.T) {
+	left := &KeyspaceFormal{
+		Vindexes: map[string]VindexFormal{
+			"hash":   {Type: "hash"},
+			"lookup": {Type: "lookup_hash", Owner: "t1"},
+		},
+		Tables: map[string]TableFormal{
+			"t1": {ColVindexes: []ColVindexFormal{{Col: "c1", Name: "hash"}}},
+			"t2": {},
+		},
+	}
+	right := &KeyspaceFormal{
+		Sharded: true,
+		Vindexes: map[string]VindexFormal{
+			"hash":    {Type: "hash"},
+			"numeric": {Type: "numeric"},
+		},
+		Tables: map[string]TableFormal{
+			"t1": {ColVindexes: []ColVindexFormal{{Col: "c2", Name: "hash"}}},
+			"t2": {},
+		},
+	}
+	got := DiffKeyspaceFormal(left, right)
+	want := []string{
+		"~ Sharded: false -> true",
+		`- Vindexes.lookup: {"Type":"lookup_hash","Params":null,"Owner":"t1"}`,
+		`+ Vindexes.numeric: {"Type":"numeric","Params":null,"Owner":""}`,
+		`~ Tables.t1: {"Type":"","ColVindexes":[{"Col":"c1","Name":"hash"}],"Autoinc":null} -> {"Type":"","ColVindexes":[{"Col":"c2","Name":"hash"}],"Autoinc":null}`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("DiffKeyspaceFormal:\n%v\nwant:\n%v", got, want)
+	}
+
+	if got := DiffKeyspaceFormal(left, left); got != nil {
+		t.Errorf("DiffKeyspaceFormal(left, left): %v, want nil", got)
+	}
+}
@@ -796,12 +796,51 @@ func TestValidate(t *testing.T) {
 	}
 `
 	err = ValidateVSchema([]byte(bad2))
-	want = "vindexType absent not found"
+	want = "Vindexes.hash.Type: vindexType absent not found"
 	if err == nil || !strings.HasPrefix(err.Error(), want) {
 		t.Errorf("Validate: %v, must start with %s", err, want)
 	}
 }
 
+func TestValidatePaths(t *testing.T) {
+	testcases := []struct {
+		input string
+		err   string
+	}{{
+		input: `{"Sharded": "yes"}`,
+		err:   `Sharded: "yes" is not a valid bool`,
+	}, {
+		input: `{"Tables": {"t1": {"ColVindexes": [{"Col": "c1", "Nmae": "hash"}]}}}`,
+		err:   "Tables.t1.ColVindexes[0].Nmae: unknown field",
+	}, {
+		input: `{"Tables": {"t1": {"ColVindexes": {"Col": "c1"}}}}`,
+		err:   `Tables.t1.ColVindexes: {"Col":"c1"} is not a valid list`,
+	}, {
+		input: `{
+			"Sharded": true,
+			"Vindexes": {"hash": {"Type": "hash"}, "lkp": {"Type": "lookup_hash", "Owner": "t2"}},
+			"Tables": {"t1": {"ColVindexes": [{"Col": "c1", "Name": "hash"}, {"Name": "lookup"}]}}
+		}`,
+		err: "Vindexes.lkp.Owner: table t2 not found; Tables.t1.ColVindexes[1].Col: missing column; Tables.t1.ColVindexes[1].Name: vindex lookup not found",
+	}, {
+		input: `{"Tables": {"seq": {"Type": "Sequense"}, "t1": {"Autoinc": {"Col": "id"}}}}`,
+		err:   "Tables.seq.Type: table type Sequense not supported, must be empty or Sequence; Tables.t1.Autoinc.Sequence: missing sequence",
+	}, {
+		// Field names are matched without regard to case.
+		input: `{"sharded": true, "vindexes": {"hash": {"type": "hash"}}, "tables": {"t1": {"colVindexes": [{"col": "c1", "name": "hash"}]}}}`,
+	}}
+	for _, tcase := range testcases {
+		err := ValidateVSchema([]byte(tcase.input))
+		got := ""
+		if err != nil {
+			got = err.Error()
+		}
+		if got != tcase.err {
+			t.Errorf("ValidateVSchema(%s): %q, want %q", tcase.input, got, tcase.err)
+		}
+	}
+}
+
 func TestFindSingleKeyspace(t *testing.T) {
 	input := VSchemaFormal{
 		Keyspaces: map[string]KeyspaceFormal{
@@ -0,0 +1,185 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vindexes
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// checkKeyspaceJSON checks the JSON representation of a KeyspaceFormal
+// before it's unmarshaled, so that the unknown fields, which
+// json.Unmarshal ignores, and the values of the wrong type are
+// reported with their path, like Tables.t1.ColVindexes[0].Col.
+func checkKeyspaceJSON(input []byte) ([]string, error) {
+	var value interface{}
+	if err := json.Unmarshal(input, &value); err != nil {
+		return nil, err
+	}
+	var errs []string
+	checkJSONValue("", value, reflect.TypeOf(KeyspaceFormal{}), &errs)
+	return errs, nil
+}
+
+func checkJSONValue(path string, value interface{}, typ reflect.Type, errs *[]string) {
+	if value == nil {
+		// null is accepted for any field, like json.Unmarshal does.
+		return
+	}
+	wrongType := func() {
+		*errs = append(*errs, fmt.Sprintf("%s: %s is not a valid %v", jsonPath(path), jsonValue(value), typeName(typ)))
+	}
+	switch typ.Kind() {
+	case reflect.Ptr:
+		checkJSONValue(path, value, typ.Elem(), errs)
+	case reflect.Struct:
+		fields, ok := value.(map[string]interface{})
+		if !ok {
+			wrongType()
+			return
+		}
+		for _, name := range sortedKeys(fields) {
+			// json.Unmarshal matches the field names without
+			// regard to case.
+			field, ok := typ.FieldByNameFunc(func(n string) bool { return strings.EqualFold(n, name) })
+			if !ok {
+				*errs = append(*errs, fmt.Sprintf("%s: unknown field", joinPath(path, name)))
+				continue
+			}
+			checkJSONValue(joinPath(path, field.Name), fields[name], field.Type, errs)
+		}
+	case reflect.Map:
+		entries, ok := value.(map[string]interface{})
+		if !ok {
+			wrongType()
+			return
+		}
+		for _, name := range sortedKeys(entries) {
+			checkJSONValue(joinPath(path, name), entries[name], typ.Elem(), errs)
+		}
+	case reflect.Slice:
+		items, ok := value.([]interface{})
+		if !ok {
+			wrongType()
+			return
+		}
+		for i, item := range items {
+			checkJSONValue(fmt.Sprintf("%s[%d]", path, i), item, typ.Elem(), errs)
+		}
+	case reflect.String:
+		if _, ok := value.(string); !ok {
+			wrongType()
+		}
+	case reflect.Bool:
+		if _, ok := value.(bool); !ok {
+			wrongType()
+		}
+	}
+}
+
+// checkKeyspaceFormal checks the references between the vindexes
+// and the tables of ks, and that the vindex types are registered.
+// The errors are reported with the path of the field at fault.
+func checkKeyspaceFormal(ks *KeyspaceFormal) []string {
+	var errs []string
+	vindexNames := make([]string, 0, len(ks.Vindexes))
+	for vname := range ks.Vindexes {
+		vindexNames = append(vindexNames, vname)
+	}
+	sort.Strings(vindexNames)
+	for _, vname := range vindexNames {
+		vindexInfo := ks.Vindexes[vname]
+		path := joinPath("Vindexes", vname)
+		switch _, ok := registry[vindexInfo.Type]; {
+		case vindexInfo.Type == "":
+			errs = append(errs, fmt.Sprintf("%s.Type: missing vindexType", path))
+		case !ok:
+			errs = append(errs, fmt.Sprintf("%s.Type: vindexType %s not found", path, vindexInfo.Type))
+		default:
+			if _, err := CreateVindex(vindexInfo.Type, vname, vindexInfo.Params); err != nil {
+				errs = append(errs, fmt.Sprintf("%s.Params: %v", path, err))
+			}
+		}
+		if vindexInfo.Owner != "" {
+			if _, ok := ks.Tables[vindexInfo.Owner]; !ok {
+				errs = append(errs, fmt.Sprintf("%s.Owner: table %s not found", path, vindexInfo.Owner))
+			}
+		}
+	}
+
+	tableNames := make([]string, 0, len(ks.Tables))
+	for tname := range ks.Tables {
+		tableNames = append(tableNames, tname)
+	}
+	sort.Strings(tableNames)
+	for _, tname := range tableNames {
+		table := ks.Tables[tname]
+		path := joinPath("Tables", tname)
+		if table.Type != "" && table.Type != "Sequence" {
+			errs = append(errs, fmt.Sprintf("%s.Type: table type %s not supported, must be empty or Sequence", path, table.Type))
+		}
+		for i, ind := range table.ColVindexes {
+			if ind.Col == "" {
+				errs = append(errs, fmt.Sprintf("%s.ColVindexes[%d].Col: missing column", path, i))
+			}
+			if _, ok := ks.Vindexes[ind.Name]; !ok {
+				errs = append(errs, fmt.Sprintf("%s.ColVindexes[%d].Name: vindex %s not found", path, i, ind.Name))
+			}
+		}
+		if table.Autoinc != nil {
+			if table.Autoinc.Col == "" {
+				errs = append(errs, fmt.Sprintf("%s.Autoinc.Col: missing column", path))
+			}
+			if table.Autoinc.Sequence == "" {
+				errs = append(errs, fmt.Sprintf("%s.Autoinc.Sequence: missing sequence", path))
+			}
+		}
+	}
+	return errs
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func jsonPath(path string) string {
+	if path == "" {
+		return "vschema"
+	}
+	return path
+}
+
+func jsonValue(value interface{}) string {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(b)
+}
+
+func typeName(typ reflect.Type) string {
+	switch typ.Kind() {
+	case reflect.Struct, reflect.Map:
+		return "object"
+	case reflect.Slice:
+		return "list"
+	}
+	return typ.Kind().String()
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -16,6 +16,7 @@ import (
 	log "github.com/golang/glog"
 	"golang.org/x/net/context"
 
+	"github.com/youtube/vitess/go/clock"
 	"github.com/youtube/vitess/go/sqltypes"
 	"github.com/youtube/vitess/go/stats"
 	"github.com/youtube/vitess/go/sync2"
@@ -74,6 +75,22 @@ type VTGate struct {
 	maxInFlight int64
 	inFlight    sync2.AtomicInt64
 
+	// mirror is nil if no traffic is mirrored.
+	mirror *trafficMirror
+
+	// prepared are the statements prepared with Prepare.
+	prepared *preparedStatements
+
+	// keyspaceSchemas caches the schemas returned by GetKeyspaceSchema.
+	keyspaceSchemas *keyspaceSchemaCache
+
+	// QueryTimeouts are the timeouts of the queries vtgate plans, by
+	// plan type. They override the timeout of the keyspace.
+	QueryTimeouts map[string]time.Duration
+
+	// clock measures the timeouts of the queries.
+	clock clock.Clock
+
 	// the throttled loggers for all errors, one per API entry
 	logExecute                  *logutil.ThrottledLogger
 	logExecuteShards            *logutil.ThrottledLogger
@@ -99,6 +116,53 @@ func Init(ctx context.Context, hc discovery.HealthCheck, topoServer topo.Server,
 	if rpcVTGate != nil {
 		log.Fatalf("VTGate already initialized")
 	}
+	if *geoCellMapFile != "" {
+		m, err := loadGeoCellMap(*geoCellMapFile)
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+		geoCellMap = m
+	}
+	if *routeCacheEntries > 0 {
+		ttl := *routeCacheTTL
+		if ttl == 0 {
+			ttl = *srvTopoCacheTTL
+		}
+		serv = newRouteCacheSrvTopoServer(serv, *routeCacheEntries, ttl)
+	}
+	alias, err := getCellAlias(ctx, topoServer, *cellAliasName, cell)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	if alias != nil {
+		log.Infof("Cell alias %v: %v", alias.Name, alias.Cells)
+		localCellAlias = alias
+		serv = newCellAliasSrvTopoServer(serv, alias)
+	}
+	localFallbackCells = parseFallbackCells(*fallbackCells, cell)
+	if len(localFallbackCells) != 0 {
+		log.Infof("Fallback cells: %v", localFallbackCells)
+	}
+	serv = newCellFallbackSrvTopoServer(serv, cell, localFallbackCells)
+	timeouts, err := parseKeyspaceTimeouts(*keyspaceTimeoutOverrides)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	if len(timeouts) != 0 {
+		log.Infof("Keyspace query timeouts: %v", timeouts)
+	}
+	keyspaceTimeouts = timeouts
+	queryTimeouts, err := parseQueryTimeouts(*queryTimeoutOverrides)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	if len(queryTimeouts) != 0 {
+		log.Infof("Query timeouts by plan type: %v", queryTimeouts)
+	}
+	mirror, err := newTrafficMirrorFromFlags("VtgateMirrorQueries")
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
 	rpcVTGate = &VTGate{
 		resolver:     NewResolver(hc, topoServer, serv, "VttabletCall", cell, retryDelay, retryCount, connTimeoutTotal, connTimeoutPerConn, connLife, tabletTypesToWait, testGateway),
 		timings:      stats.NewMultiTimings("VtgateApi", []string{"Operation", "Keyspace", "DbType"}),
@@ -106,6 +170,13 @@ func Init(ctx context.Context, hc discovery.HealthCheck, topoServer topo.Server,
 
 		maxInFlight: int64(maxInFlight),
 		inFlight:    sync2.NewAtomicInt64(0),
+		mirror:      mirror,
+		prepared:    newPreparedStatements(*preparedStatementsCacheSize),
+
+		keyspaceSchemas: newKeyspaceSchemaCache(topoServer, *keyspaceSchemaCacheTTL),
+
+		QueryTimeouts: queryTimeouts,
+		clock:         clock.Real,
 
 		logExecute:                  logutil.NewThrottledLogger("Execute", 5*time.Second),
 		logExecuteShards:            logutil.NewThrottledLogger("ExecuteShards", 5*time.Second),
@@ -133,6 +204,19 @@ func Init(ctx context.Context, hc discovery.HealthCheck, topoServer topo.Server,
 	errorsByKeyspace = stats.NewRates("ErrorsByKeyspace", stats.CounterForDimension(normalErrors, "Keyspace"), 15, 1*time.Minute)
 	errorsByDbType = stats.NewRates("ErrorsByDbType", stats.CounterForDimension(normalErrors, "DbType"), 15, 1*time.Minute)
 
+	if *k8sConfigWatch {
+		reloader := newConfigReloader(rpcVTGate, "Vtgate")
+		watcher, err := newK8sConfigWatcherFromFlags(reloader.reload)
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+		watchCtx, cancel := context.WithCancel(ctx)
+		servenv.OnRun(func() { go watcher.run(watchCtx) })
+		servenv.OnTerm(cancel)
+	}
+
+	initQueryLogger()
+
 	servenv.OnRun(func() {
 		for _, f := range RegisterVTGates {
 			f(rpcVTGate)
@@ -168,17 +252,29 @@ func (vtg *VTGate) Execute(ctx context.Context, sql string, bindVariables map[st
 	if 0 < vtg.maxInFlight && vtg.maxInFlight < x {
 		return nil, errTooManyInFlight
 	}
+	logStats, ctx := NewLogStats(ctx, "Execute", sql, keyspace, strings.ToLower(tabletType.String()))
+	defer logStats.Send()
+
+	ctx = withGeoHint(ctx, sql)
+	ctx, lockWaitTimeout := withLockWaitTimeoutHint(ctx, sql)
+	ctx, cancel, timeoutRule, plan := vtg.withQueryTimeout(ctx, sql, keyspace, tabletType)
+	defer cancel()
+	logStats.TimeoutOverrideRule = timeoutRule
 
-	qr, err := vtg.router.Execute(ctx, sql, bindVariables, keyspace, tabletType, session, notInTransaction)
+	qr, err := vtg.router.executeWithPlan(ctx, plan, sql, bindVariables, keyspace, tabletType, session, notInTransaction)
 	if err == nil {
 		vtg.rowsReturned.Add(statsKey, int64(len(qr.Rows)))
+		logStats.RowsReturned = len(qr.Rows)
 		return qr, nil
 	}
+	logStats.Error = err
 
 	query := map[string]interface{}{
 		"Sql":              sql,
 		"BindVariables":    bindVariables,
 		"Keyspace":         keyspace,
+		"Timeout":          timeoutRule,
+		"LockWaitTimeout":  lockWaitTimeout,
 		"TabletType":       strings.ToLower(tabletType.String()),
 		"Session":          session,
 		"NotInTransaction": notInTransaction,
@@ -198,7 +294,21 @@ func (vtg *VTGate) ExecuteShards(ctx context.Context, sql string, bindVariables
 	if 0 < vtg.maxInFlight && vtg.maxInFlight < x {
 		return nil, errTooManyInFlight
 	}
-
+	logStats, ctx := NewLogStats(ctx, "ExecuteShards", sql, keyspace, strings.ToLower(tabletType.String()))
+	defer logStats.Send()
+	// A failed DDL may have changed some of the shards.
+	defer vtg.keyspaceSchemas.invalidateIfDDL(keyspace, sql)
+
+	ctx = withGeoHint(ctx, sql)
+	ctx, lockWaitTimeout := withLockWaitTimeoutHint(ctx, sql)
+	ctx, cancel, timeoutRule := withKeyspaceTimeout(ctx, vtg.clock, keyspace)
+	defer cancel()
+	logStats.TimeoutOverrideRule = timeoutRule
+
+	maxRows := unboundedQueryRows(sql)
+	if maxRows > 0 {
+		sql, logStats.LimitInjected = injectLimit(sql, maxRows)
+	}
 	sql = sqlannotation.AddFilteredReplicationUnfriendlyIfDML(sql)
 
 	qr, err := vtg.resolver.Execute(
@@ -214,14 +324,29 @@ func (vtg *VTGate) ExecuteShards(ctx context.Context, sql string, bindVariables
 		notInTransaction,
 	)
 	if err == nil {
+		if vtg.mirror != nil {
+			vtg.mirror.mirror(ctx, keyspace, sql, session, qr, func(ctx context.Context, keyspace string) (*sqltypes.Result, error) {
+				return vtg.resolver.Execute(ctx, sql, bindVariables, keyspace, tabletType, nil, func(keyspace string) (string, []string, error) {
+					return keyspace, shards, nil
+				}, true)
+			})
+		}
+		// The mirror gets the untruncated result, like its own.
+		if logStats.LimitInjected {
+			qr = limitResult(qr, maxRows)
+		}
 		vtg.rowsReturned.Add(statsKey, int64(len(qr.Rows)))
+		logStats.RowsReturned = len(qr.Rows)
 		return qr, nil
 	}
+	logStats.Error = err
 
 	query := map[string]interface{}{
 		"Sql":              sql,
 		"BindVariables":    bindVariables,
 		"Keyspace":         keyspace,
+		"Timeout":          timeoutRule,
+		"LockWaitTimeout":  lockWaitTimeout,
 		"Shards":           shards,
 		"TabletType":       strings.ToLower(tabletType.String()),
 		"Session":          session,
@@ -242,19 +367,46 @@ func (vtg *VTGate) ExecuteKeyspaceIds(ctx context.Context, sql string, bindVaria
 	if 0 < vtg.maxInFlight && vtg.maxInFlight < x {
 		return nil, errTooManyInFlight
 	}
-
+	logStats, ctx := NewLogStats(ctx, "ExecuteKeyspaceIds", sql, keyspace, strings.ToLower(tabletType.String()))
+	defer logStats.Send()
+	// A failed DDL may have changed some of the shards.
+	defer vtg.keyspaceSchemas.invalidateIfDDL(keyspace, sql)
+
+	ctx = withGeoHint(ctx, sql)
+	ctx, lockWaitTimeout := withLockWaitTimeoutHint(ctx, sql)
+	ctx, cancel, timeoutRule := withKeyspaceTimeout(ctx, vtg.clock, keyspace)
+	defer cancel()
+	logStats.TimeoutOverrideRule = timeoutRule
+
+	maxRows := unboundedQueryRows(sql)
+	if maxRows > 0 {
+		sql, logStats.LimitInjected = injectLimit(sql, maxRows)
+	}
 	sql = sqlannotation.AddIfDML(sql, keyspaceIds)
 
 	qr, err := vtg.resolver.ExecuteKeyspaceIds(ctx, sql, bindVariables, keyspace, keyspaceIds, tabletType, session, notInTransaction)
 	if err == nil {
+		if vtg.mirror != nil {
+			vtg.mirror.mirror(ctx, keyspace, sql, session, qr, func(ctx context.Context, keyspace string) (*sqltypes.Result, error) {
+				return vtg.resolver.ExecuteKeyspaceIds(ctx, sql, bindVariables, keyspace, keyspaceIds, tabletType, nil, true)
+			})
+		}
+		// The mirror gets the untruncated result, like its own.
+		if logStats.LimitInjected {
+			qr = limitResult(qr, maxRows)
+		}
 		vtg.rowsReturned.Add(statsKey, int64(len(qr.Rows)))
+		logStats.RowsReturned = len(qr.Rows)
 		return qr, nil
 	}
+	logStats.Error = err
 
 	query := map[string]interface{}{
 		"Sql":              sql,
 		"BindVariables":    bindVariables,
 		"Keyspace":         keyspace,
+		"Timeout":          timeoutRule,
+		"LockWaitTimeout":  lockWaitTimeout,
 		"KeyspaceIds":      keyspaceIds,
 		"TabletType":       strings.ToLower(tabletType.String()),
 		"Session":          session,
@@ -275,19 +427,46 @@ func (vtg *VTGate) ExecuteKeyRanges(ctx context.Context, sql string, bindVariabl
 	if 0 < vtg.maxInFlight && vtg.maxInFlight < x {
 		return nil, errTooManyInFlight
 	}
-
+	logStats, ctx := NewLogStats(ctx, "ExecuteKeyRanges", sql, keyspace, strings.ToLower(tabletType.String()))
+	defer logStats.Send()
+	// A failed DDL may have changed some of the shards.
+	defer vtg.keyspaceSchemas.invalidateIfDDL(keyspace, sql)
+
+	ctx = withGeoHint(ctx, sql)
+	ctx, lockWaitTimeout := withLockWaitTimeoutHint(ctx, sql)
+	ctx, cancel, timeoutRule := withKeyspaceTimeout(ctx, vtg.clock, keyspace)
+	defer cancel()
+	logStats.TimeoutOverrideRule = timeoutRule
+
+	maxRows := unboundedQueryRows(sql)
+	if maxRows > 0 {
+		sql, logStats.LimitInjected = injectLimit(sql, maxRows)
+	}
 	sql = sqlannotation.AddFilteredReplicationUnfriendlyIfDML(sql)
 
 	qr, err := vtg.resolver.ExecuteKeyRanges(ctx, sql, bindVariables, keyspace, keyRanges, tabletType, session, notInTransaction)
 	if err == nil {
+		if vtg.mirror != nil {
+			vtg.mirror.mirror(ctx, keyspace, sql, session, qr, func(ctx context.Context, keyspace string) (*sqltypes.Result, error) {
+				return vtg.resolver.ExecuteKeyRanges(ctx, sql, bindVariables, keyspace, keyRanges, tabletType, nil, true)
+			})
+		}
+		// The mirror gets the untruncated result, like its own.
+		if logStats.LimitInjected {
+			qr = limitResult(qr, maxRows)
+		}
 		vtg.rowsReturned.Add(statsKey, int64(len(qr.Rows)))
+		logStats.RowsReturned = len(qr.Rows)
 		return qr, nil
 	}
+	logStats.Error = err
 
 	query := map[string]interface{}{
 		"Sql":              sql,
 		"BindVariables":    bindVariables,
 		"Keyspace":         keyspace,
+		"Timeout":          timeoutRule,
+		"LockWaitTimeout":  lockWaitTimeout,
 		"KeyRanges":        keyRanges,
 		"TabletType":       strings.ToLower(tabletType.String()),
 		"Session":          session,
@@ -308,19 +487,38 @@ func (vtg *VTGate) ExecuteEntityIds(ctx context.Context, sql string, bindVariabl
 	if 0 < vtg.maxInFlight && vtg.maxInFlight < x {
 		return nil, errTooManyInFlight
 	}
-
+	logStats, ctx := NewLogStats(ctx, "ExecuteEntityIds", sql, keyspace, strings.ToLower(tabletType.String()))
+	defer logStats.Send()
+
+	ctx = withGeoHint(ctx, sql)
+	ctx, lockWaitTimeout := withLockWaitTimeoutHint(ctx, sql)
+	ctx, cancel, timeoutRule := withKeyspaceTimeout(ctx, vtg.clock, keyspace)
+	defer cancel()
+	logStats.TimeoutOverrideRule = timeoutRule
+
+	maxRows := unboundedQueryRows(sql)
+	if maxRows > 0 {
+		sql, logStats.LimitInjected = injectLimit(sql, maxRows)
+	}
 	sql = sqlannotation.AddFilteredReplicationUnfriendlyIfDML(sql)
 
 	qr, err := vtg.resolver.ExecuteEntityIds(ctx, sql, bindVariables, keyspace, entityColumnName, entityKeyspaceIDs, tabletType, session, notInTransaction)
 	if err == nil {
+		if logStats.LimitInjected {
+			qr = limitResult(qr, maxRows)
+		}
 		vtg.rowsReturned.Add(statsKey, int64(len(qr.Rows)))
+		logStats.RowsReturned = len(qr.Rows)
 		return qr, nil
 	}
+	logStats.Error = err
 
 	query := map[string]interface{}{
 		"Sql":               sql,
 		"BindVariables":     bindVariables,
 		"Keyspace":          keyspace,
+		"Timeout":           timeoutRule,
+		"LockWaitTimeout":   lockWaitTimeout,
 		"EntityColumnName":  entityColumnName,
 		"EntityKeyspaceIDs": entityKeyspaceIDs,
 		"TabletType":        strings.ToLower(tabletType.String()),
@@ -342,6 +540,20 @@ func (vtg *VTGate) ExecuteBatchShards(ctx context.Context, queries []*vtgatepb.B
 	if 0 < vtg.maxInFlight && vtg.maxInFlight < x {
 		return nil, errTooManyInFlight
 	}
+	defer func() {
+		for _, q := range queries {
+			if q.Query != nil {
+				vtg.keyspaceSchemas.invalidateIfDDL(q.Keyspace, q.Query.Sql)
+			}
+		}
+	}()
+
+	keyspaces := make([]string, 0, len(queries))
+	for _, q := range queries {
+		keyspaces = append(keyspaces, q.Keyspace)
+	}
+	ctx, cancel, timeoutRule := withKeyspacesTimeout(ctx, vtg.clock, keyspaces)
+	defer cancel()
 
 	annotateBoundShardQueriesAsUnfriendly(queries)
 
@@ -364,6 +576,7 @@ func (vtg *VTGate) ExecuteBatchShards(ctx context.Context, queries []*vtgatepb.B
 
 	query := map[string]interface{}{
 		"Queries":       queries,
+		"Timeout":       timeoutRule,
 		"TabletType":    strings.ToLower(tabletType.String()),
 		"AsTransaction": asTransaction,
 		"Session":       session,
@@ -383,6 +596,20 @@ func (vtg *VTGate) ExecuteBatchKeyspaceIds(ctx context.Context, queries []*vtgat
 	if 0 < vtg.maxInFlight && vtg.maxInFlight < x {
 		return nil, errTooManyInFlight
 	}
+	defer func() {
+		for _, q := range queries {
+			if q.Query != nil {
+				vtg.keyspaceSchemas.invalidateIfDDL(q.Keyspace, q.Query.Sql)
+			}
+		}
+	}()
+
+	keyspaces := make([]string, 0, len(queries))
+	for _, q := range queries {
+		keyspaces = append(keyspaces, q.Keyspace)
+	}
+	ctx, cancel, timeoutRule := withKeyspacesTimeout(ctx, vtg.clock, keyspaces)
+	defer cancel()
 
 	annotateBoundKeyspaceIDQueries(queries)
 
@@ -403,6 +630,7 @@ func (vtg *VTGate) ExecuteBatchKeyspaceIds(ctx context.Context, queries []*vtgat
 
 	query := map[string]interface{}{
 		"Queries":       queries,
+		"Timeout":       timeoutRule,
 		"TabletType":    strings.ToLower(tabletType.String()),
 		"AsTransaction": asTransaction,
 		"Session":       session,
@@ -423,9 +651,14 @@ func (vtg *VTGate) StreamExecute(ctx context.Context, sql string, bindVariables
 		return errTooManyInFlight
 	}
 
+	ctx = withGeoHint(ctx, sql)
+	ctx, cancel, timeoutRule, plan := vtg.withQueryTimeout(ctx, sql, keyspace, tabletType)
+	defer cancel()
+
 	var rowCount int64
-	err := vtg.router.StreamExecute(
+	err := vtg.router.streamExecuteWithPlan(
 		ctx,
+		plan,
 		sql,
 		bindVariables,
 		keyspace,
@@ -442,6 +675,7 @@ func (vtg *VTGate) StreamExecute(ctx context.Context, sql string, bindVariables
 			"Sql":           sql,
 			"BindVariables": bindVariables,
 			"Keyspace":      keyspace,
+			"Timeout":       timeoutRule,
 			"TabletType":    strings.ToLower(tabletType.String()),
 		}
 		logError(err, query, vtg.logStreamExecute)
@@ -466,6 +700,10 @@ func (vtg *VTGate) StreamExecuteKeyspaceIds(ctx context.Context, sql string, bin
 		return errTooManyInFlight
 	}
 
+	ctx = withGeoHint(ctx, sql)
+	ctx, cancel, timeoutRule := withKeyspaceTimeout(ctx, vtg.clock, keyspace)
+	defer cancel()
+
 	var rowCount int64
 	err := vtg.resolver.StreamExecuteKeyspaceIds(
 		ctx,
@@ -486,6 +724,7 @@ func (vtg *VTGate) StreamExecuteKeyspaceIds(ctx context.Context, sql string, bin
 			"Sql":           sql,
 			"BindVariables": bindVariables,
 			"Keyspace":      keyspace,
+			"Timeout":       timeoutRule,
 			"KeyspaceIds":   keyspaceIds,
 			"TabletType":    strings.ToLower(tabletType.String()),
 		}
@@ -511,6 +750,10 @@ func (vtg *VTGate) StreamExecuteKeyRanges(ctx context.Context, sql string, bindV
 		return errTooManyInFlight
 	}
 
+	ctx = withGeoHint(ctx, sql)
+	ctx, cancel, timeoutRule := withKeyspaceTimeout(ctx, vtg.clock, keyspace)
+	defer cancel()
+
 	var rowCount int64
 	err := vtg.resolver.StreamExecuteKeyRanges(
 		ctx,
@@ -531,6 +774,7 @@ func (vtg *VTGate) StreamExecuteKeyRanges(ctx context.Context, sql string, bindV
 			"Sql":           sql,
 			"BindVariables": bindVariables,
 			"Keyspace":      keyspace,
+			"Timeout":       timeoutRule,
 			"KeyRanges":     keyRanges,
 			"TabletType":    strings.ToLower(tabletType.String()),
 		}
@@ -551,6 +795,10 @@ func (vtg *VTGate) StreamExecuteShards(ctx context.Context, sql string, bindVari
 		return errTooManyInFlight
 	}
 
+	ctx = withGeoHint(ctx, sql)
+	ctx, cancel, timeoutRule := withKeyspaceTimeout(ctx, vtg.clock, keyspace)
+	defer cancel()
+
 	var rowCount int64
 	err := vtg.resolver.StreamExecute(
 		ctx,
@@ -573,6 +821,7 @@ func (vtg *VTGate) StreamExecuteShards(ctx context.Context, sql string, bindVari
 			"Sql":           sql,
 			"BindVariables": bindVariables,
 			"Keyspace":      keyspace,
+			"Timeout":       timeoutRule,
 			"Shards":        shards,
 			"TabletType":    strings.ToLower(tabletType.String()),
 		}
@@ -764,6 +1013,11 @@ func (vtg *VTGate) GetSrvKeyspace(ctx context.Context, keyspace string) (*topoda
 	return vtg.resolver.toposerv.GetSrvKeyspace(ctx, vtg.resolver.cell, keyspace)
 }
 
+// GetKeyspaceSchema is part of the vtgate service API.
+func (vtg *VTGate) GetKeyspaceSchema(ctx context.Context, keyspace string) (*vtgatepb.GetKeyspaceSchemaResponse, error) {
+	return vtg.keyspaceSchemas.Get(ctx, keyspace)
+}
+
 // GetSrvShard is part of the vtgate service API.
 func (vtg *VTGate) GetSrvShard(ctx context.Context, keyspace, shard string) (*topodatapb.SrvShard, error) {
 	return vtg.resolver.toposerv.GetSrvShard(ctx, vtg.resolver.cell, keyspace, shard)
@@ -774,6 +1028,12 @@ func (vtg *VTGate) GetGatewayCacheStatus() GatewayEndPointCacheStatusList {
 	return vtg.resolver.GetGatewayCacheStatus()
 }
 
+// GetKeyspaceLimiterStatus returns a displayable version of the
+// per-keyspace concurrency limits.
+func (vtg *VTGate) GetKeyspaceLimiterStatus() KeyspaceLimiterStatusList {
+	return vtg.resolver.GetKeyspaceLimiterStatus()
+}
+
 // Any errors that are caused by VTGate dependencies (e.g, VtTablet) should be logged
 // as errors in those components, but logged to Info in VTGate itself.
 func logError(err error, query map[string]interface{}, logger *logutil.ThrottledLogger) {
@@ -38,6 +38,8 @@ func init() {
 	}
 }
 `
+	// The tests change the endpoints of the sandbox, they must not be cached.
+	*routeCacheEntries = 0
 	Init(context.Background(), nil, topo.Server{}, new(sandboxTopo), "aa", 1*time.Second, 10, 2*time.Millisecond, 1*time.Millisecond, 24*time.Hour, nil, 0, "")
 }
 
@@ -7,6 +7,7 @@ package vtgateconn
 import (
 	"flag"
 	"fmt"
+	"sync"
 	"time"
 
 	log "github.com/golang/glog"
@@ -33,36 +34,113 @@ var (
 // It can be used concurrently across goroutines.
 type VTGateConn struct {
 	impl Impl
+
+	// mu protects prepared, the ids of the statements prepared with
+	// Prepare and not closed yet, shardGtids, the GTIDs returned
+	// by the last commits of the transactions of the connection, and
+	// maxScatterParallelism, set by SET VITESS_MAX_SCATTER_PARALLELISM.
+	mu                    sync.Mutex
+	prepared              map[int64]bool
+	shardGtids            []*vtgatepb.Session_ShardGtid
+	maxScatterParallelism int64
+}
+
+// session returns the session of the queries outside transactions.
+// It carries the GTIDs of the last commits of the connection, so that
+// with -session-gtid-consistency on vtgate, the reads on the replicas
+// see them, and the settings of the connection. It's nil if there are
+// none.
+func (conn *VTGateConn) session() interface{} {
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+	if len(conn.shardGtids) == 0 && conn.maxScatterParallelism == 0 {
+		return nil
+	}
+	return &vtgatepb.Session{
+		ShardGtids:            append([]*vtgatepb.Session_ShardGtid(nil), conn.shardGtids...),
+		MaxScatterParallelism: conn.maxScatterParallelism,
+	}
+}
+
+// recordSettings stores the settings of session, returned by a V3
+// query, for the later queries and transactions of the connection.
+func (conn *VTGateConn) recordSettings(session interface{}) {
+	s, _ := session.(*vtgatepb.Session)
+	if s == nil {
+		return
+	}
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+	conn.maxScatterParallelism = s.MaxScatterParallelism
+}
+
+// recordGTIDs stores the GTIDs of session, returned by a commit, for
+// the later queries outside transactions. They replace the ones of the
+// same shards. When transactions commit concurrently on a shard, the
+// last one to return wins.
+func (conn *VTGateConn) recordGTIDs(session interface{}) {
+	s, _ := session.(*vtgatepb.Session)
+	if s == nil || len(s.ShardGtids) == 0 {
+		return
+	}
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+	shardGtids := make([]*vtgatepb.Session_ShardGtid, 0, len(conn.shardGtids)+len(s.ShardGtids))
+	for _, shardGtid := range conn.shardGtids {
+		if !hasShardGtid(s.ShardGtids, shardGtid.Keyspace, shardGtid.Shard) {
+			shardGtids = append(shardGtids, shardGtid)
+		}
+	}
+	conn.shardGtids = append(shardGtids, s.ShardGtids...)
+}
+
+func hasShardGtid(shardGtids []*vtgatepb.Session_ShardGtid, keyspace, shard string) bool {
+	for _, shardGtid := range shardGtids {
+		if shardGtid.Keyspace == keyspace && shardGtid.Shard == shard {
+			return true
+		}
+	}
+	return false
 }
 
 // Execute executes a non-streaming query on vtgate.
-// This is using v3 API.
+// This is using v3 API. The settings of the returned session, like
+// SET VITESS_MAX_SCATTER_PARALLELISM, apply to the later queries and
+// transactions of the connection.
 func (conn *VTGateConn) Execute(ctx context.Context, query string, bindVars map[string]interface{}, tabletType topodatapb.TabletType) (*sqltypes.Result, error) {
-	res, _, err := conn.impl.Execute(ctx, query, bindVars, tabletType, nil)
+	session := conn.session()
+	if session == nil {
+		// A SET needs a session to be stored in.
+		session = &vtgatepb.Session{}
+	}
+	res, session, err := conn.impl.Execute(ctx, query, bindVars, tabletType, session)
+	if err == nil {
+		conn.recordSettings(session)
+	}
 	return res, err
 }
 
 // ExecuteShards executes a non-streaming query for multiple shards on vtgate.
 func (conn *VTGateConn) ExecuteShards(ctx context.Context, query string, keyspace string, shards []string, bindVars map[string]interface{}, tabletType topodatapb.TabletType) (*sqltypes.Result, error) {
-	res, _, err := conn.impl.ExecuteShards(ctx, query, keyspace, shards, bindVars, tabletType, nil)
+	res, _, err := conn.impl.ExecuteShards(ctx, query, keyspace, shards, bindVars, tabletType, conn.session())
 	return res, err
 }
 
 // ExecuteKeyspaceIds executes a non-streaming query for multiple keyspace_ids.
 func (conn *VTGateConn) ExecuteKeyspaceIds(ctx context.Context, query string, keyspace string, keyspaceIds [][]byte, bindVars map[string]interface{}, tabletType topodatapb.TabletType) (*sqltypes.Result, error) {
-	res, _, err := conn.impl.ExecuteKeyspaceIds(ctx, query, keyspace, keyspaceIds, bindVars, tabletType, nil)
+	res, _, err := conn.impl.ExecuteKeyspaceIds(ctx, query, keyspace, keyspaceIds, bindVars, tabletType, conn.session())
 	return res, err
 }
 
 // ExecuteKeyRanges executes a non-streaming query on a key range.
 func (conn *VTGateConn) ExecuteKeyRanges(ctx context.Context, query string, keyspace string, keyRanges []*topodatapb.KeyRange, bindVars map[string]interface{}, tabletType topodatapb.TabletType) (*sqltypes.Result, error) {
-	res, _, err := conn.impl.ExecuteKeyRanges(ctx, query, keyspace, keyRanges, bindVars, tabletType, nil)
+	res, _, err := conn.impl.ExecuteKeyRanges(ctx, query, keyspace, keyRanges, bindVars, tabletType, conn.session())
 	return res, err
 }
 
 // ExecuteEntityIds executes a non-streaming query for multiple entities.
 func (conn *VTGateConn) ExecuteEntityIds(ctx context.Context, query string, keyspace string, entityColumnName string, entityKeyspaceIDs []*vtgatepb.ExecuteEntityIdsRequest_EntityId, bindVars map[string]interface{}, tabletType topodatapb.TabletType) (*sqltypes.Result, error) {
-	res, _, err := conn.impl.ExecuteEntityIds(ctx, query, keyspace, entityColumnName, entityKeyspaceIDs, bindVars, tabletType, nil)
+	res, _, err := conn.impl.ExecuteEntityIds(ctx, query, keyspace, entityColumnName, entityKeyspaceIDs, bindVars, tabletType, conn.session())
 	return res, err
 }
 
@@ -70,7 +148,7 @@ func (conn *VTGateConn) ExecuteEntityIds(ctx context.Context, query string, keys
 // If "asTransaction" is true, vtgate will automatically create a transaction
 // (per shard) that encloses all the batch queries.
 func (conn *VTGateConn) ExecuteBatchShards(ctx context.Context, queries []*vtgatepb.BoundShardQuery, tabletType topodatapb.TabletType, asTransaction bool) ([]sqltypes.Result, error) {
-	res, _, err := conn.impl.ExecuteBatchShards(ctx, queries, tabletType, asTransaction, nil)
+	res, _, err := conn.impl.ExecuteBatchShards(ctx, queries, tabletType, asTransaction, conn.session())
 	return res, err
 }
 
@@ -78,7 +156,7 @@ func (conn *VTGateConn) ExecuteBatchShards(ctx context.Context, queries []*vtgat
 // If "asTransaction" is true, vtgate will automatically create a transaction
 // (per shard) that encloses all the batch queries.
 func (conn *VTGateConn) ExecuteBatchKeyspaceIds(ctx context.Context, queries []*vtgatepb.BoundKeyspaceIdQuery, tabletType topodatapb.TabletType, asTransaction bool) ([]sqltypes.Result, error) {
-	res, _, err := conn.impl.ExecuteBatchKeyspaceIds(ctx, queries, tabletType, asTransaction, nil)
+	res, _, err := conn.impl.ExecuteBatchKeyspaceIds(ctx, queries, tabletType, asTransaction, conn.session())
 	return res, err
 }
 
@@ -86,7 +164,7 @@ func (conn *VTGateConn) ExecuteBatchKeyspaceIds(ctx context.Context, queries []*
 // ResultStream and an error. First check the error. Then you can
 // pull values from the ResultStream until io.EOF, or another error.
 func (conn *VTGateConn) StreamExecute(ctx context.Context, query string, bindVars map[string]interface{}, tabletType topodatapb.TabletType) (sqltypes.ResultStream, error) {
-	return conn.impl.StreamExecute(ctx, query, bindVars, tabletType)
+	return conn.impl.StreamExecute(ctx, query, bindVars, tabletType, conn.session())
 }
 
 // StreamExecuteShards executes a streaming query on vtgate, on a set
@@ -94,7 +172,7 @@ func (conn *VTGateConn) StreamExecute(ctx context.Context, query string, bindVar
 // error. Then you can pull values from the ResultStream until io.EOF,
 // or another error.
 func (conn *VTGateConn) StreamExecuteShards(ctx context.Context, query string, keyspace string, shards []string, bindVars map[string]interface{}, tabletType topodatapb.TabletType) (sqltypes.ResultStream, error) {
-	return conn.impl.StreamExecuteShards(ctx, query, keyspace, shards, bindVars, tabletType)
+	return conn.impl.StreamExecuteShards(ctx, query, keyspace, shards, bindVars, tabletType, conn.session())
 }
 
 // StreamExecuteKeyRanges executes a streaming query on vtgate, on a
@@ -102,7 +180,7 @@ func (conn *VTGateConn) StreamExecuteShards(ctx context.Context, query string, k
 // error. Then you can pull values from the ResultStream until io.EOF,
 // or another error.
 func (conn *VTGateConn) StreamExecuteKeyRanges(ctx context.Context, query string, keyspace string, keyRanges []*topodatapb.KeyRange, bindVars map[string]interface{}, tabletType topodatapb.TabletType) (sqltypes.ResultStream, error) {
-	return conn.impl.StreamExecuteKeyRanges(ctx, query, keyspace, keyRanges, bindVars, tabletType)
+	return conn.impl.StreamExecuteKeyRanges(ctx, query, keyspace, keyRanges, bindVars, tabletType, conn.session())
 }
 
 // StreamExecuteKeyspaceIds executes a streaming query on vtgate, for
@@ -110,7 +188,7 @@ func (conn *VTGateConn) StreamExecuteKeyRanges(ctx context.Context, query string
 // error. Then you can pull values from the ResultStream until io.EOF,
 // or another error.
 func (conn *VTGateConn) StreamExecuteKeyspaceIds(ctx context.Context, query string, keyspace string, keyspaceIds [][]byte, bindVars map[string]interface{}, tabletType topodatapb.TabletType) (sqltypes.ResultStream, error) {
-	return conn.impl.StreamExecuteKeyspaceIds(ctx, query, keyspace, keyspaceIds, bindVars, tabletType)
+	return conn.impl.StreamExecuteKeyspaceIds(ctx, query, keyspace, keyspaceIds, bindVars, tabletType, conn.session())
 }
 
 // Begin starts a transaction and returns a VTGateTX.
@@ -119,15 +197,56 @@ func (conn *VTGateConn) Begin(ctx context.Context) (*VTGateTx, error) {
 	if err != nil {
 		return nil, err
 	}
+	if s, ok := session.(*vtgatepb.Session); ok && s != nil {
+		conn.mu.Lock()
+		s.MaxScatterParallelism = conn.maxScatterParallelism
+		conn.mu.Unlock()
+	}
 
 	return &VTGateTx{
+		conn:    conn,
 		impl:    conn.impl,
 		session: session,
 	}, nil
 }
 
-// Close must be called for releasing resources.
+// Prepare plans a query on vtgate, whose unqualified tables are in
+// keyspace. The returned statement executes it with new bind variables
+// without planning it again. This is using v3 API.
+func (conn *VTGateConn) Prepare(ctx context.Context, query string, keyspace string, tabletType topodatapb.TabletType) (*VTGateStatement, error) {
+	id, paramCount, fields, err := conn.impl.Prepare(ctx, query, keyspace, tabletType)
+	if err != nil {
+		return nil, err
+	}
+	conn.mu.Lock()
+	if conn.prepared == nil {
+		conn.prepared = make(map[int64]bool)
+	}
+	conn.prepared[id] = true
+	conn.mu.Unlock()
+	return &VTGateStatement{
+		conn:       conn,
+		id:         id,
+		ParamCount: paramCount,
+		Fields:     fields,
+	}, nil
+}
+
+// Close must be called for releasing resources. It closes the
+// statements prepared with Prepare.
 func (conn *VTGateConn) Close() {
+	conn.mu.Lock()
+	ids := make([]int64, 0, len(conn.prepared))
+	for id := range conn.prepared {
+		ids = append(ids, id)
+	}
+	conn.prepared = nil
+	conn.mu.Unlock()
+	if len(ids) != 0 {
+		if err := conn.impl.ClosePrepared(context.Background(), ids); err != nil {
+			log.Warningf("cannot close the prepared statements %v: %v", ids, err)
+		}
+	}
 	conn.impl.Close()
 	conn.impl = nil
 }
@@ -162,9 +281,44 @@ func (conn *VTGateConn) GetSrvKeyspace(ctx context.Context, keyspace string) (*t
 	return conn.impl.GetSrvKeyspace(ctx, keyspace)
 }
 
+// GetKeyspaceSchema returns the schema of a keyspace, read from the
+// master of each of its shards, and the differences between them.
+func (conn *VTGateConn) GetKeyspaceSchema(ctx context.Context, keyspace string) (*vtgatepb.GetKeyspaceSchemaResponse, error) {
+	return conn.impl.GetKeyspaceSchema(ctx, keyspace)
+}
+
+// VTGateStatement is a query prepared on vtgate with Prepare.
+// It can be used concurrently across goroutines.
+type VTGateStatement struct {
+	conn *VTGateConn
+	id   int64
+
+	// ParamCount is the number of distinct bind variables of the
+	// query.
+	ParamCount int64
+	// Fields are the fields of the result of the query if it's a
+	// select, without their values.
+	Fields []*querypb.Field
+}
+
+// Execute executes the statement with bindVars.
+func (stmt *VTGateStatement) Execute(ctx context.Context, bindVars map[string]interface{}) (*sqltypes.Result, error) {
+	res, _, err := stmt.conn.impl.ExecutePrepared(ctx, stmt.id, bindVars, stmt.conn.session())
+	return res, err
+}
+
+// Close closes the statement on vtgate. It can't be executed after.
+func (stmt *VTGateStatement) Close(ctx context.Context) error {
+	stmt.conn.mu.Lock()
+	delete(stmt.conn.prepared, stmt.id)
+	stmt.conn.mu.Unlock()
+	return stmt.conn.impl.ClosePrepared(ctx, []int64{stmt.id})
+}
+
 // VTGateTx defines an ongoing transaction.
 // It should not be concurrently used across goroutines.
 type VTGateTx struct {
+	conn    *VTGateConn
 	impl    Impl
 	session interface{}
 }
@@ -176,6 +330,20 @@ func (tx *VTGateTx) Execute(ctx context.Context, query string, bindVars map[stri
 	}
 	res, session, err := tx.impl.Execute(ctx, query, bindVars, tabletType, tx.session)
 	tx.session = session
+	if err == nil {
+		tx.conn.recordSettings(session)
+	}
+	return res, err
+}
+
+// ExecutePrepared executes a prepared statement on vtgate within the
+// current transaction.
+func (tx *VTGateTx) ExecutePrepared(ctx context.Context, stmt *VTGateStatement, bindVars map[string]interface{}) (*sqltypes.Result, error) {
+	if tx.session == nil {
+		return nil, fmt.Errorf("executePrepared: not in transaction")
+	}
+	res, session, err := tx.impl.ExecutePrepared(ctx, stmt.id, bindVars, tx.session)
+	tx.session = session
 	return res, err
 }
 
@@ -239,14 +407,19 @@ func (tx *VTGateTx) ExecuteBatchKeyspaceIds(ctx context.Context, queries []*vtga
 	return res, err
 }
 
-// Commit commits the current transaction.
+// Commit commits the current transaction. The GTIDs it returns are
+// sent with the later queries of the connection outside transactions.
 func (tx *VTGateTx) Commit(ctx context.Context) error {
 	if tx.session == nil {
 		return fmt.Errorf("commit: not in transaction")
 	}
-	err := tx.impl.Commit(ctx, tx.session)
+	session, err := tx.impl.Commit(ctx, tx.session)
 	tx.session = nil
-	return err
+	if err != nil {
+		return err
+	}
+	tx.conn.recordGTIDs(session)
+	return nil
 }
 
 // Rollback rolls back the current transaction.
@@ -287,22 +460,36 @@ type Impl interface {
 	// ExecuteBatchKeyspaceIds executes a set of non-streaming queries for multiple keyspace ids.
 	ExecuteBatchKeyspaceIds(ctx context.Context, queries []*vtgatepb.BoundKeyspaceIdQuery, tabletType topodatapb.TabletType, asTransaction bool, session interface{}) ([]sqltypes.Result, interface{}, error)
 
+	// Prepare plans a query on vtgate, and returns the id of the
+	// statement, its number of bind variables and its fields.
+	Prepare(ctx context.Context, query string, keyspace string, tabletType topodatapb.TabletType) (int64, int64, []*querypb.Field, error)
+
+	// ExecutePrepared executes a statement prepared with Prepare.
+	ExecutePrepared(ctx context.Context, id int64, bindVars map[string]interface{}, session interface{}) (*sqltypes.Result, interface{}, error)
+
+	// ClosePrepared closes statements prepared with Prepare.
+	ClosePrepared(ctx context.Context, ids []int64) error
+
 	// StreamExecute executes a streaming query on vtgate.
-	StreamExecute(ctx context.Context, query string, bindVars map[string]interface{}, tabletType topodatapb.TabletType) (sqltypes.ResultStream, error)
+	// The session of the streaming queries only carries the settings
+	// of the connection. It may be nil.
+	StreamExecute(ctx context.Context, query string, bindVars map[string]interface{}, tabletType topodatapb.TabletType, session interface{}) (sqltypes.ResultStream, error)
 
 	// StreamExecuteShards executes a streaming query on vtgate, on a set of shards.
-	StreamExecuteShards(ctx context.Context, query string, keyspace string, shards []string, bindVars map[string]interface{}, tabletType topodatapb.TabletType) (sqltypes.ResultStream, error)
+	StreamExecuteShards(ctx context.Context, query string, keyspace string, shards []string, bindVars map[string]interface{}, tabletType topodatapb.TabletType, session interface{}) (sqltypes.ResultStream, error)
 
 	// StreamExecuteKeyRanges executes a streaming query on vtgate, on a set of keyranges.
-	StreamExecuteKeyRanges(ctx context.Context, query string, keyspace string, keyRanges []*topodatapb.KeyRange, bindVars map[string]interface{}, tabletType topodatapb.TabletType) (sqltypes.ResultStream, error)
+	StreamExecuteKeyRanges(ctx context.Context, query string, keyspace string, keyRanges []*topodatapb.KeyRange, bindVars map[string]interface{}, tabletType topodatapb.TabletType, session interface{}) (sqltypes.ResultStream, error)
 
 	// StreamExecuteKeyspaceIds executes a streaming query on vtgate, for the given keyspaceIds.
-	StreamExecuteKeyspaceIds(ctx context.Context, query string, keyspace string, keyspaceIds [][]byte, bindVars map[string]interface{}, tabletType topodatapb.TabletType) (sqltypes.ResultStream, error)
+	StreamExecuteKeyspaceIds(ctx context.Context, query string, keyspace string, keyspaceIds [][]byte, bindVars map[string]interface{}, tabletType topodatapb.TabletType, session interface{}) (sqltypes.ResultStream, error)
 
 	// Begin starts a transaction and returns a VTGateTX.
 	Begin(ctx context.Context) (interface{}, error)
-	// Commit commits the current transaction.
-	Commit(ctx context.Context, session interface{}) error
+	// Commit commits the current transaction, and returns the
+	// session after it, with the GTIDs of the commit if vtgate
+	// records them.
+	Commit(ctx context.Context, session interface{}) (interface{}, error)
 	// Rollback rolls back the current transaction.
 	Rollback(ctx context.Context, session interface{}) error
 
@@ -327,6 +514,10 @@ type Impl interface {
 	// GetSrvKeyspace returns a topo.SrvKeyspace.
 	GetSrvKeyspace(ctx context.Context, keyspace string) (*topodatapb.SrvKeyspace, error)
 
+	// GetKeyspaceSchema returns the schema of a keyspace, and the
+	// differences between its shards.
+	GetKeyspaceSchema(ctx context.Context, keyspace string) (*vtgatepb.GetKeyspaceSchemaResponse, error)
+
 	// Close must be called for releasing resources.
 	Close()
 }
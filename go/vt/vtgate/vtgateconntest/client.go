@@ -12,6 +12,7 @@ import (
 	"io"
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -19,11 +20,13 @@ import (
 	"github.com/youtube/vitess/go/tb"
 	"github.com/youtube/vitess/go/vt/callerid"
 	"github.com/youtube/vitess/go/vt/vterrors"
+	"github.com/youtube/vitess/go/vt/vtgate"
 	"github.com/youtube/vitess/go/vt/vtgate/vtgateconn"
 	"github.com/youtube/vitess/go/vt/vtgate/vtgateservice"
 	"golang.org/x/net/context"
 
 	querypb "github.com/youtube/vitess/go/vt/proto/query"
+	tabletmanagerdatapb "github.com/youtube/vitess/go/vt/proto/tabletmanagerdata"
 	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
 	vtgatepb "github.com/youtube/vitess/go/vt/proto/vtgate"
 	vtrpcpb "github.com/youtube/vitess/go/vt/proto/vtrpc"
@@ -38,6 +41,12 @@ type fakeVTGateService struct {
 	// we can test subsequent calls in the transaction (e.g., Commit, Rollback).
 	forceBeginSuccess bool
 	errorWait         chan struct{}
+	// If set, calls to Commit return them in the session, like
+	// vtgate with -session-gtid-consistency.
+	commitShardGtids []*vtgatepb.Session_ShardGtid
+	// The limit of SET VITESS_MAX_SCATTER_PARALLELISM of the last
+	// call to StreamExecute.
+	streamMaxScatterParallelism int
 }
 
 const expectedErrMatch string = "test vtgate error"
@@ -367,6 +376,63 @@ func (f *fakeVTGateService) ExecuteBatchKeyspaceIds(ctx context.Context, queries
 	return nil, nil
 }
 
+// preparedID is the id of the statement the fake prepares.
+const preparedID int64 = 12
+
+// Prepare is part of the VTGateService interface
+func (f *fakeVTGateService) Prepare(ctx context.Context, sql string, keyspace string, tabletType topodatapb.TabletType) (int64, int64, []*querypb.Field, error) {
+	if f.hasError {
+		return 0, 0, nil, errTestVtGateError
+	}
+	if f.panics {
+		panic(fmt.Errorf("test forced panic"))
+	}
+	f.checkCallerID(ctx, "Prepare")
+	execCase, ok := execMap[sql]
+	if !ok {
+		return 0, 0, nil, fmt.Errorf("no match for: %s", sql)
+	}
+	if keyspace != "ks" || tabletType != execCase.execQuery.TabletType {
+		f.t.Errorf("Prepare: %v, %v, want ks, %v", keyspace, tabletType, execCase.execQuery.TabletType)
+	}
+	return preparedID, int64(len(execCase.execQuery.BindVariables)), execCase.result.Fields, nil
+}
+
+// ExecutePrepared is part of the VTGateService interface
+func (f *fakeVTGateService) ExecutePrepared(ctx context.Context, id int64, bindVariables map[string]interface{}, session *vtgatepb.Session, notInTransaction bool) (*sqltypes.Result, error) {
+	if f.hasError {
+		return nil, errTestVtGateError
+	}
+	if f.panics {
+		panic(fmt.Errorf("test forced panic"))
+	}
+	f.checkCallerID(ctx, "ExecutePrepared")
+	if id != preparedID {
+		return nil, fmt.Errorf("no match for statement: %v", id)
+	}
+	execCase := execMap["request1"]
+	if !reflect.DeepEqual(bindVariables, execCase.execQuery.BindVariables) {
+		f.t.Errorf("ExecutePrepared: %v, want %v", bindVariables, execCase.execQuery.BindVariables)
+		return nil, nil
+	}
+	return execCase.result, nil
+}
+
+// ClosePrepared is part of the VTGateService interface
+func (f *fakeVTGateService) ClosePrepared(ctx context.Context, ids []int64) error {
+	if f.hasError {
+		return errTestVtGateError
+	}
+	if f.panics {
+		panic(fmt.Errorf("test forced panic"))
+	}
+	f.checkCallerID(ctx, "ClosePrepared")
+	if want := []int64{preparedID}; !reflect.DeepEqual(ids, want) {
+		return fmt.Errorf("ClosePrepared: %v, want %v", ids, want)
+	}
+	return nil
+}
+
 // StreamExecute is part of the VTGateService interface
 func (f *fakeVTGateService) StreamExecute(ctx context.Context, sql string, bindVariables map[string]interface{}, keyspace string, tabletType topodatapb.TabletType, sendReply func(*sqltypes.Result) error) error {
 	if f.panics {
@@ -377,14 +443,18 @@ func (f *fakeVTGateService) StreamExecute(ctx context.Context, sql string, bindV
 		return fmt.Errorf("no match for: %s", sql)
 	}
 	f.checkCallerID(ctx, "StreamExecute")
+	f.streamMaxScatterParallelism = vtgate.MaxScatterParallelismFromContext(ctx)
 	query := &queryExecute{
 		SQL:           sql,
 		BindVariables: bindVariables,
 		Keyspace:      keyspace,
 		TabletType:    tabletType,
 	}
-	if !reflect.DeepEqual(query, execCase.execQuery) {
-		f.t.Errorf("StreamExecute: %+v, want %+v", query, execCase.execQuery)
+	// The streaming queries have no session.
+	want := *execCase.execQuery
+	want.Session = nil
+	if !reflect.DeepEqual(query, &want) {
+		f.t.Errorf("StreamExecute: %+v, want %+v", query, &want)
 		return nil
 	}
 	if execCase.result != nil {
@@ -580,6 +650,9 @@ func (f *fakeVTGateService) Commit(ctx context.Context, inSession *vtgatepb.Sess
 	if !reflect.DeepEqual(inSession, session2) {
 		return errors.New("commit: session mismatch")
 	}
+	if f.commitShardGtids != nil {
+		*inSession = vtgatepb.Session{ShardGtids: f.commitShardGtids}
+	}
 	return nil
 }
 
@@ -688,6 +761,20 @@ func (f *fakeVTGateService) GetSrvKeyspace(ctx context.Context, keyspace string)
 	return getSrvKeyspaceResult, nil
 }
 
+// GetKeyspaceSchema is part of the VTGateService interface
+func (f *fakeVTGateService) GetKeyspaceSchema(ctx context.Context, keyspace string) (*vtgatepb.GetKeyspaceSchemaResponse, error) {
+	if f.hasError {
+		return nil, errTestVtGateError
+	}
+	if f.panics {
+		panic(fmt.Errorf("test forced panic"))
+	}
+	if keyspace != getSrvKeyspaceKeyspace {
+		f.t.Errorf("GetKeyspaceSchema has wrong input: got %v wanted %v", keyspace, getSrvKeyspaceKeyspace)
+	}
+	return getKeyspaceSchemaResult, nil
+}
+
 // GetSrvShard is part of the VTGateService interface
 func (f *fakeVTGateService) GetSrvShard(ctx context.Context, keyspace, shard string) (*topodatapb.SrvShard, error) {
 	panic(fmt.Errorf("GetSrvShard is not tested here"))
@@ -731,21 +818,26 @@ func TestSuite(t *testing.T, impl vtgateconn.Impl, fakeServer vtgateservice.VTGa
 	fs := fakeServer.(*fakeVTGateService)
 
 	testExecute(t, conn)
+	testExecuteConcurrent(t, conn)
 	testExecuteShards(t, conn)
 	testExecuteKeyspaceIds(t, conn)
 	testExecuteKeyRanges(t, conn)
 	testExecuteEntityIds(t, conn)
 	testExecuteBatchShards(t, conn)
 	testExecuteBatchKeyspaceIds(t, conn)
+	testPrepare(t, conn)
 	testStreamExecute(t, conn)
 	testStreamExecuteShards(t, conn)
 	testStreamExecuteKeyRanges(t, conn)
 	testStreamExecuteKeyspaceIds(t, conn)
 	testTxPass(t, conn)
 	testTxFail(t, conn)
+	testTxSessionGTIDs(t, fs)
+	testSessionSettings(t, fs)
 	testSplitQuery(t, conn)
 	testSplitQueryV2(t, conn)
 	testGetSrvKeyspace(t, conn)
+	testGetKeyspaceSchema(t, conn)
 
 	// force a panic at every call, then test that works
 	fs.panics = true
@@ -759,6 +851,7 @@ func TestSuite(t *testing.T, impl vtgateconn.Impl, fakeServer vtgateservice.VTGa
 	testExecuteEntityIdsPanic(t, conn)
 	testExecuteBatchShardsPanic(t, conn)
 	testExecuteBatchKeyspaceIdsPanic(t, conn)
+	testPreparePanic(t, conn)
 	testStreamExecutePanic(t, conn)
 	testStreamExecuteShardsPanic(t, conn)
 	testStreamExecuteKeyRangesPanic(t, conn)
@@ -766,6 +859,7 @@ func TestSuite(t *testing.T, impl vtgateconn.Impl, fakeServer vtgateservice.VTGa
 	testSplitQueryPanic(t, conn)
 	testSplitQueryV2Panic(t, conn)
 	testGetSrvKeyspacePanic(t, conn)
+	testGetKeyspaceSchemaPanic(t, conn)
 	fs.panics = false
 }
 
@@ -790,6 +884,7 @@ func TestErrorSuite(t *testing.T, fakeServer vtgateservice.VTGateService) {
 	testExecuteEntityIdsError(t, conn, fs)
 	testExecuteBatchShardsError(t, conn, fs)
 	testExecuteBatchKeyspaceIdsError(t, conn, fs)
+	testPrepareError(t, conn)
 	testStreamExecuteError(t, conn, fs)
 	testStreamExecuteShardsError(t, conn, fs)
 	testStreamExecuteKeyRangesError(t, conn, fs)
@@ -797,6 +892,7 @@ func TestErrorSuite(t *testing.T, fakeServer vtgateservice.VTGateService) {
 	testSplitQueryError(t, conn)
 	testSplitQueryV2Error(t, conn)
 	testGetSrvKeyspaceError(t, conn)
+	testGetKeyspaceSchemaError(t, conn)
 	fs.hasError = false
 }
 
@@ -855,6 +951,27 @@ func testExecute(t *testing.T, conn *vtgateconn.VTGateConn) {
 	}
 }
 
+func testExecuteConcurrent(t *testing.T, conn *vtgateconn.VTGateConn) {
+	ctx := newContext()
+	execCase := execMap["request1"]
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			qr, err := conn.Execute(ctx, execCase.execQuery.SQL, execCase.execQuery.BindVariables, execCase.execQuery.TabletType)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if !reflect.DeepEqual(qr, execCase.result) {
+				t.Errorf("Unexpected result from concurrent Execute: got\n%#v want\n%#v", qr, execCase.result)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
 func testExecuteError(t *testing.T, conn *vtgateconn.VTGateConn, fake *fakeVTGateService) {
 	ctx := newContext()
 	execCase := execMap["errorRequst"]
@@ -870,6 +987,49 @@ func testExecutePanic(t *testing.T, conn *vtgateconn.VTGateConn) {
 	expectPanic(t, err)
 }
 
+func testPrepare(t *testing.T, conn *vtgateconn.VTGateConn) {
+	ctx := newContext()
+	execCase := execMap["request1"]
+	stmt, err := conn.Prepare(ctx, execCase.execQuery.SQL, "ks", execCase.execQuery.TabletType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stmt.ParamCount != 1 || !reflect.DeepEqual(stmt.Fields, execCase.result.Fields) {
+		t.Errorf("Prepare: %v, %+v, want 1, %+v", stmt.ParamCount, stmt.Fields, execCase.result.Fields)
+	}
+	qr, err := stmt.Execute(ctx, execCase.execQuery.BindVariables)
+	if err != nil {
+		t.Error(err)
+	}
+	if !reflect.DeepEqual(qr, execCase.result) {
+		t.Errorf("Unexpected result from ExecutePrepared: got\n%#v want\n%#v", qr, execCase.result)
+	}
+	if err := stmt.Close(ctx); err != nil {
+		t.Errorf("Close: %v", err)
+	}
+
+	_, err = conn.Prepare(ctx, "none", "ks", topodatapb.TabletType_RDONLY)
+	want := "no match for: none"
+	if err == nil || !strings.Contains(err.Error(), want) {
+		t.Errorf("none request: %v, want %v", err, want)
+	}
+}
+
+func testPrepareError(t *testing.T, conn *vtgateconn.VTGateConn) {
+	ctx := newContext()
+	execCase := execMap["errorRequst"]
+
+	_, err := conn.Prepare(ctx, execCase.execQuery.SQL, "ks", execCase.execQuery.TabletType)
+	verifyError(t, err, "Prepare")
+}
+
+func testPreparePanic(t *testing.T, conn *vtgateconn.VTGateConn) {
+	ctx := newContext()
+	execCase := execMap["request1"]
+	_, err := conn.Prepare(ctx, execCase.execQuery.SQL, "ks", execCase.execQuery.TabletType)
+	expectPanic(t, err)
+}
+
 func testExecuteShards(t *testing.T, conn *vtgateconn.VTGateConn) {
 	ctx := newContext()
 	execCase := execMap["request1"]
@@ -1495,6 +1655,102 @@ func testTxPass(t *testing.T, conn *vtgateconn.VTGateConn) {
 	}
 }
 
+// testTxSessionGTIDs checks the GTIDs returned by a commit are sent
+// with the later queries of the connection outside transactions.
+func testTxSessionGTIDs(t *testing.T, fake *fakeVTGateService) {
+	ctx := newContext()
+	// A new connection, so that the GTIDs don't change the sessions
+	// of the other tests. It shares the impl, and is not closed.
+	conn, err := vtgateconn.DialProtocol(ctx, "test", "", 0)
+	if err != nil {
+		t.Fatalf("Got err: %v from vtgateconn.DialProtocol", err)
+	}
+
+	// The queries before the commit have no session.
+	execCase := execMap["request1"]
+	if _, err := conn.Execute(ctx, execCase.execQuery.SQL, execCase.execQuery.BindVariables, execCase.execQuery.TabletType); err != nil {
+		t.Error(err)
+	}
+
+	fake.commitShardGtids = gtidSession.ShardGtids
+	defer func() { fake.commitShardGtids = nil }()
+	txCase := execMap["txRequest"]
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tx.Execute(ctx, txCase.execQuery.SQL, txCase.execQuery.BindVariables, txCase.execQuery.TabletType); err != nil {
+		t.Error(err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	execCase = execMap["gtidRequest"]
+	if _, err := conn.Execute(ctx, execCase.execQuery.SQL, execCase.execQuery.BindVariables, execCase.execQuery.TabletType); err != nil {
+		t.Error(err)
+	}
+	if _, err := conn.ExecuteShards(ctx, execCase.shardQuery.SQL, execCase.shardQuery.Keyspace, execCase.shardQuery.Shards, execCase.shardQuery.BindVariables, execCase.shardQuery.TabletType); err != nil {
+		t.Error(err)
+	}
+
+	// The transactions start without them.
+	tx, err = conn.Begin(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tx.Execute(ctx, txCase.execQuery.SQL, txCase.execQuery.BindVariables, txCase.execQuery.TabletType); err != nil {
+		t.Error(err)
+	}
+	if err := tx.Rollback(ctx); err != nil {
+		t.Error(err)
+	}
+}
+
+// testSessionSettings checks the settings of the session returned by
+// Execute are sent with the later queries and transactions of the
+// connection.
+func testSessionSettings(t *testing.T, fake *fakeVTGateService) {
+	ctx := newContext()
+	conn, err := vtgateconn.DialProtocol(ctx, "test", "", 0)
+	if err != nil {
+		t.Fatalf("Got err: %v from vtgateconn.DialProtocol", err)
+	}
+
+	execCase := execMap["setRequest"]
+	if _, err := conn.Execute(ctx, execCase.execQuery.SQL, execCase.execQuery.BindVariables, execCase.execQuery.TabletType); err != nil {
+		t.Error(err)
+	}
+	execCase = execMap["settingsRequest"]
+	if _, err := conn.Execute(ctx, execCase.execQuery.SQL, execCase.execQuery.BindVariables, execCase.execQuery.TabletType); err != nil {
+		t.Error(err)
+	}
+	stream, err := conn.StreamExecute(ctx, execCase.execQuery.SQL, execCase.execQuery.BindVariables, execCase.execQuery.TabletType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for {
+		if _, err := stream.Recv(); err != nil {
+			if err != io.EOF {
+				t.Error(err)
+			}
+			break
+		}
+	}
+	if fake.streamMaxScatterParallelism != 3 {
+		t.Errorf("StreamExecute max scatter parallelism: %v, want 3", fake.streamMaxScatterParallelism)
+	}
+
+	execCase = execMap["txSettingsRequest"]
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tx.Execute(ctx, execCase.execQuery.SQL, execCase.execQuery.BindVariables, execCase.execQuery.TabletType); err != nil {
+		t.Error(err)
+	}
+}
+
 func testBeginError(t *testing.T, conn *vtgateconn.VTGateConn) {
 	ctx := newContext()
 	_, err := conn.Begin(ctx)
@@ -1748,6 +2004,29 @@ func testGetSrvKeyspacePanic(t *testing.T, conn *vtgateconn.VTGateConn) {
 	expectPanic(t, err)
 }
 
+func testGetKeyspaceSchema(t *testing.T, conn *vtgateconn.VTGateConn) {
+	ctx := newContext()
+	schema, err := conn.GetKeyspaceSchema(ctx, getSrvKeyspaceKeyspace)
+	if err != nil {
+		t.Fatalf("GetKeyspaceSchema failed: %v", err)
+	}
+	if !reflect.DeepEqual(schema, getKeyspaceSchemaResult) {
+		t.Errorf("GetKeyspaceSchema returned wrong result: got %+v wanted %+v", schema, getKeyspaceSchemaResult)
+	}
+}
+
+func testGetKeyspaceSchemaError(t *testing.T, conn *vtgateconn.VTGateConn) {
+	ctx := newContext()
+	_, err := conn.GetKeyspaceSchema(ctx, getSrvKeyspaceKeyspace)
+	verifyErrorString(t, err, "GetKeyspaceSchema")
+}
+
+func testGetKeyspaceSchemaPanic(t *testing.T, conn *vtgateconn.VTGateConn) {
+	ctx := newContext()
+	_, err := conn.GetKeyspaceSchema(ctx, getSrvKeyspaceKeyspace)
+	expectPanic(t, err)
+}
+
 var testCallerID = &vtrpcpb.CallerID{
 	Principal:    "test_principal",
 	Component:    "test_component",
@@ -1773,7 +2052,7 @@ var execMap = map[string]struct {
 				"bind1": int64(0),
 			},
 			TabletType: topodatapb.TabletType_RDONLY,
-			Session:    nil,
+			Session:    &vtgatepb.Session{},
 		},
 		shardQuery: &queryExecuteShards{
 			SQL: "request1",
@@ -1881,7 +2160,7 @@ var execMap = map[string]struct {
 				"bind1": int64(0),
 			},
 			TabletType: topodatapb.TabletType_RDONLY,
-			Session:    nil,
+			Session:    &vtgatepb.Session{},
 		},
 		shardQuery: &queryExecuteShards{
 			SQL: "errorRequst",
@@ -2088,6 +2367,71 @@ var execMap = map[string]struct {
 		result:     nil,
 		outSession: session2,
 	},
+	"setRequest": {
+		execQuery: &queryExecute{
+			SQL: "setRequest",
+			BindVariables: map[string]interface{}{
+				"bind1": int64(0),
+			},
+			TabletType: topodatapb.TabletType_REPLICA,
+			Session:    &vtgatepb.Session{},
+		},
+		result: &sqltypes.Result{},
+		outSession: &vtgatepb.Session{
+			MaxScatterParallelism: 3,
+		},
+	},
+	"settingsRequest": {
+		execQuery: &queryExecute{
+			SQL: "settingsRequest",
+			BindVariables: map[string]interface{}{
+				"bind1": int64(0),
+			},
+			TabletType: topodatapb.TabletType_REPLICA,
+			Session: &vtgatepb.Session{
+				MaxScatterParallelism: 3,
+			},
+		},
+		result:     &result1,
+		outSession: nil,
+	},
+	"txSettingsRequest": {
+		execQuery: &queryExecute{
+			SQL: "txSettingsRequest",
+			BindVariables: map[string]interface{}{
+				"bind1": int64(0),
+			},
+			TabletType: topodatapb.TabletType_MASTER,
+			Session: &vtgatepb.Session{
+				InTransaction:         true,
+				MaxScatterParallelism: 3,
+			},
+		},
+		result:     &sqltypes.Result{},
+		outSession: nil,
+	},
+	"gtidRequest": {
+		execQuery: &queryExecute{
+			SQL: "gtidRequest",
+			BindVariables: map[string]interface{}{
+				"bind1": int64(0),
+			},
+			TabletType: topodatapb.TabletType_REPLICA,
+			Session:    gtidSession,
+		},
+		shardQuery: &queryExecuteShards{
+			SQL: "gtidRequest",
+			BindVariables: map[string]interface{}{
+				"bind1": int64(0),
+			},
+			Keyspace:   "ks",
+			Shards:     []string{"1"},
+			TabletType: topodatapb.TabletType_REPLICA,
+			Session:    gtidSession,
+		},
+		result:     &result1,
+		outSession: nil,
+	},
 }
 
 var result1 = sqltypes.Result{
@@ -2139,6 +2483,18 @@ var session2 = &vtgatepb.Session{
 	},
 }
 
+// gtidSession is the session of the queries outside transactions after
+// a commit that returned GTIDs.
+var gtidSession = &vtgatepb.Session{
+	ShardGtids: []*vtgatepb.Session_ShardGtid{
+		{
+			Keyspace: "ks",
+			Shard:    "1",
+			Gtid:     "MySQL56/0b1ae4f4-4e2c-11e6-beb8-9e71128cae77:1-7",
+		},
+	},
+}
+
 var splitQueryRequest = &querySplitQuery{
 	Keyspace: "ks",
 	SQL:      "in for SplitQuery",
@@ -2212,3 +2568,27 @@ var getSrvKeyspaceResult = &topodatapb.SrvKeyspace{
 	},
 	SplitShardCount: 128,
 }
+
+var getKeyspaceSchemaResult = &vtgatepb.GetKeyspaceSchemaResponse{
+	ReferenceShard: "-80",
+	Schema: &tabletmanagerdatapb.SchemaDefinition{
+		DatabaseSchema: "CREATE DATABASE {{.DatabaseName}}",
+		TableDefinitions: []*tabletmanagerdatapb.TableDefinition{
+			{
+				Name:    "t1",
+				Schema:  "CREATE TABLE t1 (id bigint)",
+				Columns: []string{"id"},
+				Type:    "BASE TABLE",
+			},
+		},
+		Version: "version",
+	},
+	Drifts: []*vtgatepb.SchemaDrift{
+		{
+			Shard:       "80-",
+			Table:       "t1",
+			Columns:     []string{"id"},
+			Description: "the columns differ",
+		},
+	},
+}
@@ -28,6 +28,15 @@ type VTGateService interface {
 	ExecuteBatchShards(ctx context.Context, queries []*vtgatepb.BoundShardQuery, tabletType topodatapb.TabletType, asTransaction bool, session *vtgatepb.Session) ([]sqltypes.Result, error)
 	ExecuteBatchKeyspaceIds(ctx context.Context, queries []*vtgatepb.BoundKeyspaceIdQuery, tabletType topodatapb.TabletType, asTransaction bool, session *vtgatepb.Session) ([]sqltypes.Result, error)
 
+	// Prepared statements.
+	// Prepare plans sql, and returns the id of the statement, the
+	// number of its bind variables and the fields of its result.
+	// ExecutePrepared executes the statement of id, and can change
+	// the provided session. ClosePrepared closes statements.
+	Prepare(ctx context.Context, sql string, keyspace string, tabletType topodatapb.TabletType) (id int64, paramCount int64, fields []*querypb.Field, err error)
+	ExecutePrepared(ctx context.Context, id int64, bindVariables map[string]interface{}, session *vtgatepb.Session, notInTransaction bool) (*sqltypes.Result, error)
+	ClosePrepared(ctx context.Context, ids []int64) error
+
 	// Streaming queries
 	StreamExecute(ctx context.Context, sql string, bindVariables map[string]interface{}, keyspace string, tabletType topodatapb.TabletType, sendReply func(*sqltypes.Result) error) error
 	StreamExecuteShards(ctx context.Context, sql string, bindVariables map[string]interface{}, keyspace string, shards []string, tabletType topodatapb.TabletType, sendReply func(*sqltypes.Result) error) error
@@ -57,6 +66,10 @@ type VTGateService interface {
 	// Topology support
 	GetSrvKeyspace(ctx context.Context, keyspace string) (*topodatapb.SrvKeyspace, error)
 
+	// GetKeyspaceSchema returns the schema of a keyspace, and the
+	// differences between its shards.
+	GetKeyspaceSchema(ctx context.Context, keyspace string) (*vtgatepb.GetKeyspaceSchemaResponse, error)
+
 	// GetSrvShard is not part of the public API, but might be used
 	// by some implementations.
 	GetSrvShard(ctx context.Context, keyspace, shard string) (*topodatapb.SrvShard, error)
@@ -110,6 +110,40 @@ func (_mr *_MockVTGateServiceRecorder) ExecuteBatchKeyspaceIds(arg0, arg1, arg2,
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "ExecuteBatchKeyspaceIds", arg0, arg1, arg2, arg3, arg4)
 }
 
+func (_m *MockVTGateService) Prepare(ctx context.Context, sql string, keyspace string, tabletType topodata.TabletType) (int64, int64, []*query.Field, error) {
+	ret := _m.ctrl.Call(_m, "Prepare", ctx, sql, keyspace, tabletType)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(int64)
+	ret2, _ := ret[2].([]*query.Field)
+	ret3, _ := ret[3].(error)
+	return ret0, ret1, ret2, ret3
+}
+
+func (_mr *_MockVTGateServiceRecorder) Prepare(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "Prepare", arg0, arg1, arg2, arg3)
+}
+
+func (_m *MockVTGateService) ExecutePrepared(ctx context.Context, id int64, bindVariables map[string]interface{}, session *vtgate.Session, notInTransaction bool) (*sqltypes.Result, error) {
+	ret := _m.ctrl.Call(_m, "ExecutePrepared", ctx, id, bindVariables, session, notInTransaction)
+	ret0, _ := ret[0].(*sqltypes.Result)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVTGateServiceRecorder) ExecutePrepared(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "ExecutePrepared", arg0, arg1, arg2, arg3, arg4)
+}
+
+func (_m *MockVTGateService) ClosePrepared(ctx context.Context, ids []int64) error {
+	ret := _m.ctrl.Call(_m, "ClosePrepared", ctx, ids)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (_mr *_MockVTGateServiceRecorder) ClosePrepared(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "ClosePrepared", arg0, arg1)
+}
+
 func (_m *MockVTGateService) StreamExecute(ctx context.Context, sql string, bindVariables map[string]interface{}, keyspace string, tabletType topodata.TabletType, sendReply func(*sqltypes.Result) error) error {
 	ret := _m.ctrl.Call(_m, "StreamExecute", ctx, sql, bindVariables, keyspace, tabletType, sendReply)
 	ret0, _ := ret[0].(error)
@@ -214,6 +248,17 @@ func (_mr *_MockVTGateServiceRecorder) GetSrvKeyspace(arg0, arg1 interface{}) *g
 	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetSrvKeyspace", arg0, arg1)
 }
 
+func (_m *MockVTGateService) GetKeyspaceSchema(ctx context.Context, keyspace string) (*vtgate.GetKeyspaceSchemaResponse, error) {
+	ret := _m.ctrl.Call(_m, "GetKeyspaceSchema", ctx, keyspace)
+	ret0, _ := ret[0].(*vtgate.GetKeyspaceSchemaResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (_mr *_MockVTGateServiceRecorder) GetKeyspaceSchema(arg0, arg1 interface{}) *gomock.Call {
+	return _mr.mock.ctrl.RecordCall(_mr.mock, "GetKeyspaceSchema", arg0, arg1)
+}
+
 func (_m *MockVTGateService) GetSrvShard(ctx context.Context, keyspace string, shard string) (*topodata.SrvShard, error) {
 	ret := _m.ctrl.Call(_m, "GetSrvShard", ctx, keyspace, shard)
 	ret0, _ := ret[0].(*topodata.SrvShard)
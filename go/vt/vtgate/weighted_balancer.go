@@ -0,0 +1,243 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/youtube/vitess/go/vt/discovery"
+
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+)
+
+var gatewayBalanceStrategy = flag.String("gateway-balance-strategy", balanceStrategyRandom, "how the discoverygateway chooses among the endpoints of a shard: random picks any with the same probability, weighted picks them proportionally to the CPU idle fraction of the host of their tablet")
+
+const (
+	balanceStrategyRandom   = "random"
+	balanceStrategyWeighted = "weighted"
+
+	// minEndPointWeight is the weight of the endpoints with a CPU
+	// usage of 100% or more, so that they still get some queries.
+	minEndPointWeight = 0.01
+)
+
+// newWeightedBalancerFromFlags returns the balancer of
+// -gateway-balance-strategy, or nil if the endpoints are picked at
+// random.
+func newWeightedBalancerFromFlags() (*weightedBalancer, error) {
+	switch *gatewayBalanceStrategy {
+	case balanceStrategyRandom:
+		return nil, nil
+	case balanceStrategyWeighted:
+		return newWeightedBalancer(rand.New(rand.NewSource(time.Now().UnixNano()))), nil
+	}
+	return nil, fmt.Errorf("unknown gateway-balance-strategy %v, must be %v or %v", *gatewayBalanceStrategy, balanceStrategyRandom, balanceStrategyWeighted)
+}
+
+// endPointWeight returns the weight of eps: the fraction of the CPU of
+// its host that is idle, as reported by its tablet.
+func endPointWeight(eps *discovery.EndPointStats) float64 {
+	weight := 1.0
+	if eps.Stats != nil {
+		weight -= eps.Stats.CpuUsage
+	}
+	if weight < minEndPointWeight {
+		return minEndPointWeight
+	}
+	return weight
+}
+
+// targetKey returns the key of a target for weightedBalancer.
+func targetKey(keyspace, shard string, tabletType topodatapb.TabletType) string {
+	return fmt.Sprintf("%v/%v/%v", keyspace, shard, tabletType)
+}
+
+// weightedBalancer orders the endpoints of a shard so that the first
+// one is picked proportionally to the weights. The alias table of a
+// target is cached until its next healthcheck update.
+type weightedBalancer struct {
+	mu   sync.Mutex
+	rand *rand.Rand
+	// tables are the alias tables by target key.
+	tables map[string]*weightedTable
+	// updates counts the calls to invalidate, so that a table built
+	// from the weights read before an update isn't cached.
+	updates int64
+}
+
+func newWeightedBalancer(r *rand.Rand) *weightedBalancer {
+	return &weightedBalancer{
+		rand:   r,
+		tables: make(map[string]*weightedTable),
+	}
+}
+
+// weightedTable is the alias table of a set of endpoints.
+type weightedTable struct {
+	// keys are the endpoint keys of the indexes of table.
+	keys  []string
+	index map[string]bool
+	table *aliasTable
+}
+
+// newWeightedTable returns the table of the endpoints with keys. The
+// endpoints missing from weights have a weight of 1.
+func newWeightedTable(keys []string, weights map[string]float64) *weightedTable {
+	wt := &weightedTable{
+		keys:  keys,
+		index: make(map[string]bool, len(keys)),
+	}
+	w := make([]float64, len(keys))
+	for i, key := range keys {
+		wt.index[key] = true
+		w[i] = 1
+		if weight, ok := weights[key]; ok {
+			w[i] = weight
+		}
+	}
+	wt.table = newAliasTable(w)
+	return wt
+}
+
+// matches returns true if wt is the table of the endpoints with keys.
+func (wt *weightedTable) matches(keys []string) bool {
+	if wt == nil || len(wt.keys) != len(keys) {
+		return false
+	}
+	for _, key := range keys {
+		if !wt.index[key] {
+			return false
+		}
+	}
+	return true
+}
+
+// invalidate drops the cached table of the target, whose weights
+// changed.
+func (wb *weightedBalancer) invalidate(target string) {
+	wb.mu.Lock()
+	defer wb.mu.Unlock()
+	delete(wb.tables, target)
+	wb.updates++
+}
+
+// order shuffles endPoints, and moves the one picked by weight first
+// among the ones not in skip. weights returns the weights of the
+// endpoints of target by key, it's only called when the cached table
+// doesn't have the same endpoints.
+func (wb *weightedBalancer) order(target string, endPoints []*topodatapb.EndPoint, weights func() map[string]float64, skip map[string]bool) {
+	wb.mu.Lock()
+	defer wb.mu.Unlock()
+	for i := len(endPoints) - 1; i > 0; i-- {
+		j := wb.rand.Intn(i + 1)
+		endPoints[i], endPoints[j] = endPoints[j], endPoints[i]
+	}
+
+	var candidates []int
+	var keys []string
+	for i, ep := range endPoints {
+		key := discovery.EndPointToMapKey(ep)
+		if skip[key] {
+			continue
+		}
+		candidates = append(candidates, i)
+		keys = append(keys, key)
+	}
+	if len(candidates) == 0 {
+		return
+	}
+	wt := wb.tables[target]
+	if !wt.matches(keys) {
+		// Don't hold the lock while reading the weights.
+		updates := wb.updates
+		wb.mu.Unlock()
+		w := weights()
+		wb.mu.Lock()
+		wt = newWeightedTable(keys, w)
+		// The tables of the retries, which skip some endpoints,
+		// are not cached.
+		if len(skip) == 0 && updates == wb.updates {
+			wb.tables[target] = wt
+		}
+	}
+	key := wt.keys[wt.table.pick(wb.rand)]
+	for i, k := range keys {
+		if k == key {
+			picked := candidates[i]
+			endPoints[0], endPoints[picked] = endPoints[picked], endPoints[0]
+			return
+		}
+	}
+}
+
+// aliasTable picks indexes proportionally to their weight in constant
+// time, with the alias method of Vose.
+type aliasTable struct {
+	prob  []float64
+	alias []int
+}
+
+// newAliasTable returns the table of weights, that can't be empty.
+// If all the weights are 0, the indexes have the same probability.
+func newAliasTable(weights []float64) *aliasTable {
+	n := len(weights)
+	t := &aliasTable{
+		prob:  make([]float64, n),
+		alias: make([]int, n),
+	}
+	var sum float64
+	for _, w := range weights {
+		sum += w
+	}
+	scaled := make([]float64, n)
+	var small, large []int
+	for i, w := range weights {
+		if sum > 0 {
+			scaled[i] = w * float64(n) / sum
+		} else {
+			scaled[i] = 1
+		}
+		if scaled[i] < 1 {
+			small = append(small, i)
+		} else {
+			large = append(large, i)
+		}
+	}
+	for len(small) > 0 && len(large) > 0 {
+		s := small[len(small)-1]
+		small = small[:len(small)-1]
+		l := large[len(large)-1]
+		large = large[:len(large)-1]
+		t.prob[s] = scaled[s]
+		t.alias[s] = l
+		scaled[l] = scaled[l] + scaled[s] - 1
+		if scaled[l] < 1 {
+			small = append(small, l)
+		} else {
+			large = append(large, l)
+		}
+	}
+	// The rest are 1, up to the rounding errors.
+	for _, i := range large {
+		t.prob[i] = 1
+	}
+	for _, i := range small {
+		t.prob[i] = 1
+	}
+	return t
+}
+
+// pick returns an index of the table.
+func (t *aliasTable) pick(r *rand.Rand) int {
+	i := r.Intn(len(t.prob))
+	if r.Float64() < t.prob[i] {
+		return i
+	}
+	return t.alias[i]
+}
@@ -0,0 +1,172 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package vtgate
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"github.com/youtube/vitess/go/vt/discovery"
+	"github.com/youtube/vitess/go/vt/topo"
+
+	querypb "github.com/youtube/vitess/go/vt/proto/query"
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+)
+
+func TestAliasTable(t *testing.T) {
+	testcases := [][]float64{
+		{1},
+		{1, 1},
+		{1, 2, 3, 4},
+		{0.9, 0.05, 0.05},
+		{0, 5, 0, 1},
+		{0, 0, 0},
+	}
+	const samples = 100000
+	for _, weights := range testcases {
+		r := rand.New(rand.NewSource(1))
+		table := newAliasTable(weights)
+		counts := make([]int, len(weights))
+		for i := 0; i < samples; i++ {
+			counts[table.pick(r)]++
+		}
+		var sum float64
+		for _, w := range weights {
+			sum += w
+		}
+		for i, w := range weights {
+			want := 1 / float64(len(weights))
+			if sum > 0 {
+				want = w / sum
+			}
+			got := float64(counts[i]) / samples
+			if math.Abs(got-want) > 0.05*want || (want == 0 && counts[i] != 0) {
+				t.Errorf("weights %v: index %v picked %.4f of the times, want %.4f", weights, i, got, want)
+			}
+		}
+	}
+}
+
+func TestEndPointWeight(t *testing.T) {
+	testcases := []struct {
+		stats *querypb.RealtimeStats
+		want  float64
+	}{
+		{nil, 1},
+		{&querypb.RealtimeStats{CpuUsage: 0.25}, 0.75},
+		{&querypb.RealtimeStats{CpuUsage: 1.5}, minEndPointWeight},
+	}
+	for _, tcase := range testcases {
+		eps := &discovery.EndPointStats{
+			EndPoint: &topodatapb.EndPoint{},
+			Stats:    tcase.stats,
+		}
+		if got := endPointWeight(eps); got != tcase.want {
+			t.Errorf("endPointWeight(%v): %v, want %v", tcase.stats, got, tcase.want)
+		}
+	}
+}
+
+func TestWeightedBalancerOrder(t *testing.T) {
+	wb := newWeightedBalancer(rand.New(rand.NewSource(1)))
+	a := topo.NewEndPoint(1, "a")
+	b := topo.NewEndPoint(2, "b")
+	c := topo.NewEndPoint(3, "c")
+	weights := func() map[string]float64 {
+		return map[string]float64{
+			discovery.EndPointToMapKey(a): 100,
+			discovery.EndPointToMapKey(b): 0,
+		}
+	}
+	skip := map[string]bool{discovery.EndPointToMapKey(a): true}
+	for i := 0; i < 100; i++ {
+		endPoints := []*topodatapb.EndPoint{a, b, c}
+		// a is skipped, and b has no weight: c, which has the
+		// default weight, must be first.
+		wb.order("ks/0/REPLICA", endPoints, weights, skip)
+		if endPoints[0] != c {
+			t.Fatalf("order: %v first, want %v", endPoints[0], c)
+		}
+		if len(endPoints) != 3 {
+			t.Fatalf("order: %v, want 3 endpoints", endPoints)
+		}
+	}
+
+	// All skipped: just shuffled.
+	endPoints := []*topodatapb.EndPoint{a}
+	wb.order("ks/0/REPLICA", endPoints, weights, skip)
+	if endPoints[0] != a {
+		t.Errorf("order of skipped endpoints: %v, want %v", endPoints, a)
+	}
+}
+
+func TestWeightedBalancerCache(t *testing.T) {
+	wb := newWeightedBalancer(rand.New(rand.NewSource(1)))
+	a := topo.NewEndPoint(1, "a")
+	b := topo.NewEndPoint(2, "b")
+	reads := 0
+	weights := func() map[string]float64 {
+		reads++
+		return nil
+	}
+	order := func(endPoints []*topodatapb.EndPoint, skip map[string]bool) {
+		wb.order("ks/0/REPLICA", endPoints, weights, skip)
+	}
+	order([]*topodatapb.EndPoint{a, b}, nil)
+	order([]*topodatapb.EndPoint{b, a}, nil)
+	if reads != 1 {
+		t.Errorf("weights read %v times for the same endpoints, want 1", reads)
+	}
+
+	// Other endpoints, or a retry, read the weights again, but the
+	// table of a retry isn't cached.
+	order([]*topodatapb.EndPoint{a}, nil)
+	order([]*topodatapb.EndPoint{a, b}, map[string]bool{discovery.EndPointToMapKey(a): true})
+	order([]*topodatapb.EndPoint{a}, nil)
+	if reads != 3 {
+		t.Errorf("weights read %v times, want 3", reads)
+	}
+
+	// A healthcheck update drops the table.
+	wb.invalidate("ks/0/REPLICA")
+	order([]*topodatapb.EndPoint{a}, nil)
+	if reads != 4 {
+		t.Errorf("weights read %v times after an update, want 4", reads)
+	}
+}
+
+func TestDiscoveryGatewayWeighted(t *testing.T) {
+	keyspace := "ks"
+	shard := "0"
+	tabletType := topodatapb.TabletType_REPLICA
+	hc := newFakeHealthCheck()
+	dg := createDiscoveryGateway(hc, topo.Server{}, nil, "cell", time.Millisecond, 0, time.Second, time.Second, time.Second, nil, nil).(*discoveryGateway)
+	dg.balancer = newWeightedBalancer(rand.New(rand.NewSource(1)))
+
+	busy := &sandboxConn{}
+	idle := &sandboxConn{}
+	busyEP := hc.addTestEndPoint("cell", "1.1.1.1", 1001, keyspace, shard, tabletType, true, 10, nil, busy)
+	idleEP := hc.addTestEndPoint("cell", "1.1.1.1", 1002, keyspace, shard, tabletType, true, 10, nil, idle)
+	hc.items[discovery.EndPointToMapKey(busyEP)].eps.Stats = &querypb.RealtimeStats{CpuUsage: 0.8}
+	hc.items[discovery.EndPointToMapKey(idleEP)].eps.Stats = &querypb.RealtimeStats{CpuUsage: 0.2}
+
+	const queries = 10000
+	for i := 0; i < queries; i++ {
+		if _, err := dg.Execute(context.Background(), keyspace, shard, tabletType, "query", nil, 0); err != nil {
+			t.Fatalf("Execute: %v", err)
+		}
+	}
+	// The weights are 0.2 and 0.8.
+	if got, want := float64(idle.ExecCount.Get())/queries, 0.8; math.Abs(got-want) > 0.05*want {
+		t.Errorf("queries sent to the idle tablet: %.4f, want %.4f", got, want)
+	}
+	if got := busy.ExecCount.Get() + idle.ExecCount.Get(); got != queries {
+		t.Errorf("queries sent: %v, want %v", got, queries)
+	}
+}
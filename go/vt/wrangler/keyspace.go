@@ -818,6 +818,30 @@ func (wr *Wrangler) setKeyspaceServedFrom(ctx context.Context, keyspace string,
 	return wr.ts.UpdateKeyspace(ctx, ki)
 }
 
+// SetKeyspaceFallbackCells sets the cells vtgate falls back to for the
+// non-master queries of keyspace, in order, when its cell has no
+// serving tablet. An empty list removes them. The serving graph must be
+// rebuilt for vtgate to see the change.
+func (wr *Wrangler) SetKeyspaceFallbackCells(ctx context.Context, keyspace string, cells []string) error {
+	actionNode := actionnode.SetKeyspaceFallbackCells()
+	lockPath, err := wr.lockKeyspace(ctx, keyspace, actionNode)
+	if err != nil {
+		return err
+	}
+
+	err = wr.setKeyspaceFallbackCells(ctx, keyspace, cells)
+	return wr.unlockKeyspace(ctx, keyspace, actionNode, lockPath, err)
+}
+
+func (wr *Wrangler) setKeyspaceFallbackCells(ctx context.Context, keyspace string, cells []string) error {
+	ki, err := wr.ts.GetKeyspace(ctx, keyspace)
+	if err != nil {
+		return err
+	}
+	ki.FallbackCells = cells
+	return wr.ts.UpdateKeyspace(ctx, ki)
+}
+
 // RefreshTablesByShard calls RefreshState on all the tables of a
 // given type in a shard. It would work for the master, but the
 // discovery wouldn't be very efficient.
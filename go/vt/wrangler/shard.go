@@ -144,6 +144,31 @@ func (wr *Wrangler) setShardTabletControl(ctx context.Context, keyspace, shard s
 	return wr.ts.UpdateShard(ctx, shardInfo)
 }
 
+// SetShardEnforceKeyRange changes the EnforceKeyRange flag of the
+// TabletControl record of a shard and type, for the provided cells.
+// The tablets then restrict their queries to the key range of the shard.
+func (wr *Wrangler) SetShardEnforceKeyRange(ctx context.Context, keyspace, shard string, tabletType topodatapb.TabletType, cells []string, enforceKeyRange bool) error {
+	actionNode := actionnode.UpdateShard()
+	lockPath, err := wr.lockShard(ctx, keyspace, shard, actionNode)
+	if err != nil {
+		return err
+	}
+
+	err = wr.setShardEnforceKeyRange(ctx, keyspace, shard, tabletType, cells, enforceKeyRange)
+	return wr.unlockShard(ctx, keyspace, shard, actionNode, lockPath, err)
+}
+
+func (wr *Wrangler) setShardEnforceKeyRange(ctx context.Context, keyspace, shard string, tabletType topodatapb.TabletType, cells []string, enforceKeyRange bool) error {
+	shardInfo, err := wr.ts.GetShard(ctx, keyspace, shard)
+	if err != nil {
+		return err
+	}
+	if err := shardInfo.UpdateEnforceKeyRange(tabletType, cells, enforceKeyRange); err != nil {
+		return fmt.Errorf("UpdateEnforceKeyRange(%v/%v) failed: %v", shardInfo.Keyspace(), shardInfo.ShardName(), err)
+	}
+	return wr.ts.UpdateShard(ctx, shardInfo)
+}
+
 // DeleteShard will do all the necessary changes in the topology server
 // to entirely remove a shard.
 func (wr *Wrangler) DeleteShard(ctx context.Context, keyspace, shard string, recursive bool) error {
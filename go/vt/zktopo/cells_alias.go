@@ -0,0 +1,102 @@
+// Copyright 2016, Google Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package zktopo
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+
+	"github.com/youtube/vitess/go/vt/topo"
+	"github.com/youtube/vitess/go/zk"
+	"golang.org/x/net/context"
+	"launchpad.net/gozk/zookeeper"
+
+	topodatapb "github.com/youtube/vitess/go/vt/proto/topodata"
+)
+
+/*
+This file contains the cells alias management code for zktopo.Server
+*/
+
+const (
+	// GlobalCellsAliasesPath is the path used to store the cells
+	// aliases in ZK. Exported for tests.
+	GlobalCellsAliasesPath = "/zk/global/vt/cells_aliases"
+)
+
+// CreateCellsAlias is part of the topo.Server interface
+func (zkts *Server) CreateCellsAlias(ctx context.Context, alias string, cellsAlias *topodatapb.CellsAlias) error {
+	data, err := json.MarshalIndent(cellsAlias, "", "  ")
+	if err != nil {
+		return err
+	}
+	aliasPath := path.Join(GlobalCellsAliasesPath, alias)
+	if _, err := zk.CreateRecursive(zkts.zconn, aliasPath, string(data), 0, zookeeper.WorldACL(zookeeper.PERM_ALL)); err != nil {
+		if zookeeper.IsError(err, zookeeper.ZNODEEXISTS) {
+			return topo.ErrNodeExists
+		}
+		return fmt.Errorf("error creating cells alias: %v %v", aliasPath, err)
+	}
+	return nil
+}
+
+// UpdateCellsAlias is part of the topo.Server interface
+func (zkts *Server) UpdateCellsAlias(ctx context.Context, alias string, cellsAlias *topodatapb.CellsAlias) error {
+	data, err := json.MarshalIndent(cellsAlias, "", "  ")
+	if err != nil {
+		return err
+	}
+	if _, err := zkts.zconn.Set(path.Join(GlobalCellsAliasesPath, alias), string(data), -1); err != nil {
+		if zookeeper.IsError(err, zookeeper.ZNONODE) {
+			err = topo.ErrNoNode
+		}
+		return err
+	}
+	return nil
+}
+
+// DeleteCellsAlias is part of the topo.Server interface
+func (zkts *Server) DeleteCellsAlias(ctx context.Context, alias string) error {
+	if err := zkts.zconn.Delete(path.Join(GlobalCellsAliasesPath, alias), -1); err != nil {
+		if zookeeper.IsError(err, zookeeper.ZNONODE) {
+			err = topo.ErrNoNode
+		}
+		return err
+	}
+	return nil
+}
+
+// GetCellsAlias is part of the topo.Server interface
+func (zkts *Server) GetCellsAlias(ctx context.Context, alias string) (*topodatapb.CellsAlias, error) {
+	data, _, err := zkts.zconn.Get(path.Join(GlobalCellsAliasesPath, alias))
+	if err != nil {
+		if zookeeper.IsError(err, zookeeper.ZNONODE) {
+			err = topo.ErrNoNode
+		}
+		return nil, err
+	}
+
+	cellsAlias := &topodatapb.CellsAlias{}
+	if err := json.Unmarshal([]byte(data), cellsAlias); err != nil {
+		return nil, fmt.Errorf("bad cells alias data %v", err)
+	}
+	return cellsAlias, nil
+}
+
+// GetCellsAliases is part of the topo.Server interface
+func (zkts *Server) GetCellsAliases(ctx context.Context) ([]string, error) {
+	children, _, err := zkts.zconn.Children(GlobalCellsAliasesPath)
+	if err != nil {
+		if zookeeper.IsError(err, zookeeper.ZNONODE) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	sort.Strings(children)
+	return children, nil
+}
@@ -54,6 +54,7 @@ conversions = {
     # query_pb2.ENUM: no conversion
     # query_pb2.SET: no conversion
     # query_pb2.TUPLE: no conversion
+    # query_pb2.JSON: no conversion
 }
 
 
@@ -22,7 +22,7 @@ DESCRIPTOR = _descriptor.FileDescriptor(
   name='query.proto',
   package='query',
   syntax='proto3',
-  serialized_pb=_b('\n\x0bquery.proto\x12\x05query\x1a\x0etopodata.proto\x1a\x0bvtrpc.proto\"T\n\x06Target\x12\x10\n\x08keyspace\x18\x01 \x01(\t\x12\r\n\x05shard\x18\x02 \x01(\t\x12)\n\x0btablet_type\x18\x03 \x01(\x0e\x32\x14.topodata.TabletType\"\"\n\x0eVTGateCallerID\x12\x10\n\x08username\x18\x01 \x01(\t\"1\n\x05Value\x12\x19\n\x04type\x18\x01 \x01(\x0e\x32\x0b.query.Type\x12\r\n\x05value\x18\x02 \x01(\x0c\"V\n\x0c\x42indVariable\x12\x19\n\x04type\x18\x01 \x01(\x0e\x32\x0b.query.Type\x12\r\n\x05value\x18\x02 \x01(\x0c\x12\x1c\n\x06values\x18\x03 \x03(\x0b\x32\x0c.query.Value\"\xa2\x01\n\nBoundQuery\x12\x0b\n\x03sql\x18\x01 \x01(\t\x12<\n\x0e\x62ind_variables\x18\x02 \x03(\x0b\x32$.query.BoundQuery.BindVariablesEntry\x1aI\n\x12\x42indVariablesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\"\n\x05value\x18\x02 \x01(\x0b\x32\x13.query.BindVariable:\x02\x38\x01\"0\n\x05\x46ield\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x19\n\x04type\x18\x02 \x01(\x0e\x32\x0b.query.Type\"&\n\x03Row\x12\x0f\n\x07lengths\x18\x01 \x03(\x12\x12\x0e\n\x06values\x18\x02 \x01(\x0c\"o\n\x0bQueryResult\x12\x1c\n\x06\x66ields\x18\x01 \x03(\x0b\x32\x0c.query.Field\x12\x15\n\rrows_affected\x18\x02 \x01(\x04\x12\x11\n\tinsert_id\x18\x03 \x01(\x04\x12\x18\n\x04rows\x18\x04 \x03(\x0b\x32\n.query.Row\"\x98\x01\n\x13GetSessionIdRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x10\n\x08keyspace\x18\x03 \x01(\t\x12\r\n\x05shard\x18\x04 \x01(\t\"*\n\x14GetSessionIdResponse\x12\x12\n\nsession_id\x18\x01 \x01(\x03\"\xdf\x01\n\x0e\x45xecuteRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x1d\n\x06target\x18\x03 \x01(\x0b\x32\r.query.Target\x12 \n\x05query\x18\x04 \x01(\x0b\x32\x11.query.BoundQuery\x12\x16\n\x0etransaction_id\x18\x05 \x01(\x03\x12\x12\n\nsession_id\x18\x06 \x01(\x03\"5\n\x0f\x45xecuteResponse\x12\"\n\x06result\x18\x01 \x01(\x0b\x32\x12.query.QueryResult\"\xfe\x01\n\x13\x45xecuteBatchRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x1d\n\x06target\x18\x03 \x01(\x0b\x32\r.query.Target\x12\"\n\x07queries\x18\x04 \x03(\x0b\x32\x11.query.BoundQuery\x12\x16\n\x0e\x61s_transaction\x18\x05 \x01(\x08\x12\x16\n\x0etransaction_id\x18\x06 \x01(\x03\x12\x12\n\nsession_id\x18\x07 \x01(\x03\";\n\x14\x45xecuteBatchResponse\x12#\n\x07results\x18\x01 \x03(\x0b\x32\x12.query.QueryResult\"\xcd\x01\n\x14StreamExecuteRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x1d\n\x06target\x18\x03 \x01(\x0b\x32\r.query.Target\x12 \n\x05query\x18\x04 \x01(\x0b\x32\x11.query.BoundQuery\x12\x12\n\nsession_id\x18\x05 \x01(\x03\";\n\x15StreamExecuteResponse\x12\"\n\x06result\x18\x01 \x01(\x0b\x32\x12.query.QueryResult\"\xa3\x01\n\x0c\x42\x65ginRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x1d\n\x06target\x18\x03 \x01(\x0b\x32\r.query.Target\x12\x12\n\nsession_id\x18\x04 \x01(\x03\"\'\n\rBeginResponse\x12\x16\n\x0etransaction_id\x18\x01 \x01(\x03\"\xbc\x01\n\rCommitRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x1d\n\x06target\x18\x03 \x01(\x0b\x32\r.query.Target\x12\x16\n\x0etransaction_id\x18\x04 \x01(\x03\x12\x12\n\nsession_id\x18\x05 \x01(\x03\"\x10\n\x0e\x43ommitResponse\"\xbe\x01\n\x0fRollbackRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x1d\n\x06target\x18\x03 \x01(\x0b\x32\r.query.Target\x12\x16\n\x0etransaction_id\x18\x04 \x01(\x03\x12\x12\n\nsession_id\x18\x05 \x01(\x03\"\x12\n\x10RollbackResponse\"\xb8\x01\n\x13\x42\x65ginExecuteRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x1d\n\x06target\x18\x03 \x01(\x0b\x32\r.query.Target\x12 \n\x05query\x18\x04 \x01(\x0b\x32\x11.query.BoundQuery\"r\n\x14\x42\x65ginExecuteResponse\x12\x1e\n\x05\x65rror\x18\x01 \x01(\x0b\x32\x0f.vtrpc.RPCError\x12\"\n\x06result\x18\x02 \x01(\x0b\x32\x12.query.QueryResult\x12\x16\n\x0etransaction_id\x18\x03 \x01(\x03\"\xd7\x01\n\x18\x42\x65ginExecuteBatchRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x1d\n\x06target\x18\x03 \x01(\x0b\x32\r.query.Target\x12\"\n\x07queries\x18\x04 \x03(\x0b\x32\x11.query.BoundQuery\x12\x16\n\x0e\x61s_transaction\x18\x05 \x01(\x08\"x\n\x19\x42\x65ginExecuteBatchResponse\x12\x1e\n\x05\x65rror\x18\x01 \x01(\x0b\x32\x0f.vtrpc.RPCError\x12#\n\x07results\x18\x02 \x03(\x0b\x32\x12.query.QueryResult\x12\x16\n\x0etransaction_id\x18\x03 \x01(\x03\"\x97\x03\n\x11SplitQueryRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x1d\n\x06target\x18\x03 \x01(\x0b\x32\r.query.Target\x12 \n\x05query\x18\x04 \x01(\x0b\x32\x11.query.BoundQuery\x12\x14\n\x0csplit_column\x18\x05 \x03(\t\x12\x13\n\x0bsplit_count\x18\x06 \x01(\x03\x12\x1f\n\x17num_rows_per_query_part\x18\x08 \x01(\x03\x12\x12\n\nsession_id\x18\x07 \x01(\x03\x12\x35\n\talgorithm\x18\t \x01(\x0e\x32\".query.SplitQueryRequest.Algorithm\x12\x1a\n\x12use_split_query_v2\x18\n \x01(\x08\",\n\tAlgorithm\x12\x10\n\x0c\x45QUAL_SPLITS\x10\x00\x12\r\n\tFULL_SCAN\x10\x01\"A\n\nQuerySplit\x12 \n\x05query\x18\x01 \x01(\x0b\x32\x11.query.BoundQuery\x12\x11\n\trow_count\x18\x02 \x01(\x03\"8\n\x12SplitQueryResponse\x12\"\n\x07queries\x18\x01 \x03(\x0b\x32\x11.query.QuerySplit\"\x15\n\x13StreamHealthRequest\"\xb6\x01\n\rRealtimeStats\x12\x14\n\x0chealth_error\x18\x01 \x01(\t\x12\x1d\n\x15seconds_behind_master\x18\x02 \x01(\r\x12\x1c\n\x14\x62inlog_players_count\x18\x03 \x01(\x05\x12\x32\n*seconds_behind_master_filtered_replication\x18\x04 \x01(\x03\x12\x11\n\tcpu_usage\x18\x05 \x01(\x01\x12\x0b\n\x03qps\x18\x06 \x01(\x01\"\xa4\x01\n\x14StreamHealthResponse\x12\x1d\n\x06target\x18\x01 \x01(\x0b\x32\r.query.Target\x12\x0f\n\x07serving\x18\x02 \x01(\x08\x12.\n&tablet_externally_reparented_timestamp\x18\x03 \x01(\x03\x12,\n\x0erealtime_stats\x18\x04 \x01(\x0b\x32\x14.query.RealtimeStats*k\n\x04\x46lag\x12\x08\n\x04NONE\x10\x00\x12\x0f\n\nISINTEGRAL\x10\x80\x02\x12\x0f\n\nISUNSIGNED\x10\x80\x04\x12\x0c\n\x07ISFLOAT\x10\x80\x08\x12\r\n\x08ISQUOTED\x10\x80\x10\x12\x0b\n\x06ISTEXT\x10\x80 \x12\r\n\x08ISBINARY\x10\x80@*\xef\x02\n\x04Type\x12\r\n\tNULL_TYPE\x10\x00\x12\t\n\x04INT8\x10\x81\x02\x12\n\n\x05UINT8\x10\x82\x06\x12\n\n\x05INT16\x10\x83\x02\x12\x0b\n\x06UINT16\x10\x84\x06\x12\n\n\x05INT24\x10\x85\x02\x12\x0b\n\x06UINT24\x10\x86\x06\x12\n\n\x05INT32\x10\x87\x02\x12\x0b\n\x06UINT32\x10\x88\x06\x12\n\n\x05INT64\x10\x89\x02\x12\x0b\n\x06UINT64\x10\x8a\x06\x12\x0c\n\x07\x46LOAT32\x10\x8b\x08\x12\x0c\n\x07\x46LOAT64\x10\x8c\x08\x12\x0e\n\tTIMESTAMP\x10\x8d\x10\x12\t\n\x04\x44\x41TE\x10\x8e\x10\x12\t\n\x04TIME\x10\x8f\x10\x12\r\n\x08\x44\x41TETIME\x10\x90\x10\x12\t\n\x04YEAR\x10\x91\x06\x12\x0b\n\x07\x44\x45\x43IMAL\x10\x12\x12\t\n\x04TEXT\x10\x93\x30\x12\t\n\x04\x42LOB\x10\x94P\x12\x0c\n\x07VARCHAR\x10\x95\x30\x12\x0e\n\tVARBINARY\x10\x96P\x12\t\n\x04\x43HAR\x10\x97\x30\x12\x0b\n\x06\x42INARY\x10\x98P\x12\x08\n\x03\x42IT\x10\x99\x10\x12\t\n\x04\x45NUM\x10\x9a\x10\x12\x08\n\x03SET\x10\x9b\x10\x12\t\n\x05TUPLE\x10\x1c\x42\x1a\n\x18\x63om.youtube.vitess.protob\x06proto3')
+  serialized_pb=_b('\n\x0bquery.proto\x12\x05query\x1a\x0etopodata.proto\x1a\x0bvtrpc.proto\"T\n\x06Target\x12\x10\n\x08keyspace\x18\x01 \x01(\t\x12\r\n\x05shard\x18\x02 \x01(\t\x12)\n\x0btablet_type\x18\x03 \x01(\x0e\x32\x14.topodata.TabletType\"\"\n\x0eVTGateCallerID\x12\x10\n\x08username\x18\x01 \x01(\t\"1\n\x05Value\x12\x19\n\x04type\x18\x01 \x01(\x0e\x32\x0b.query.Type\x12\r\n\x05value\x18\x02 \x01(\x0c\"V\n\x0c\x42indVariable\x12\x19\n\x04type\x18\x01 \x01(\x0e\x32\x0b.query.Type\x12\r\n\x05value\x18\x02 \x01(\x0c\x12\x1c\n\x06values\x18\x03 \x03(\x0b\x32\x0c.query.Value\"\xa2\x01\n\nBoundQuery\x12\x0b\n\x03sql\x18\x01 \x01(\t\x12<\n\x0e\x62ind_variables\x18\x02 \x03(\x0b\x32$.query.BoundQuery.BindVariablesEntry\x1aI\n\x12\x42indVariablesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\"\n\x05value\x18\x02 \x01(\x0b\x32\x13.query.BindVariable:\x02\x38\x01\"0\n\x05\x46ield\x12\x0c\n\x04name\x18\x01 \x01(\t\x12\x19\n\x04type\x18\x02 \x01(\x0e\x32\x0b.query.Type\"&\n\x03Row\x12\x0f\n\x07lengths\x18\x01 \x03(\x12\x12\x0e\n\x06values\x18\x02 \x01(\x0c\"\x81\x01\n\x0bQueryResult\x12\x1c\n\x06\x66ields\x18\x01 \x03(\x0b\x32\x0c.query.Field\x12\x15\n\rrows_affected\x18\x02 \x01(\x04\x12\x11\n\tinsert_id\x18\x03 \x01(\x04\x12\x18\n\x04rows\x18\x04 \x03(\x0b\x32\n.query.Row\x12\x10\n\x08warnings\x18\x05 \x03(\t\"\x98\x01\n\x13GetSessionIdRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x10\n\x08keyspace\x18\x03 \x01(\t\x12\r\n\x05shard\x18\x04 \x01(\t\"*\n\x14GetSessionIdResponse\x12\x12\n\nsession_id\x18\x01 \x01(\x03\"\xaf\x02\n\x0e\x45xecuteRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x1d\n\x06target\x18\x03 \x01(\x0b\x32\r.query.Target\x12 \n\x05query\x18\x04 \x01(\x0b\x32\x11.query.BoundQuery\x12\x16\n\x0etransaction_id\x18\x05 \x01(\x03\x12\x12\n\nsession_id\x18\x06 \x01(\x03\x12\x15\n\rwait_for_gtid\x18\x07 \x01(\t\x12\x1b\n\x13include_row_version\x18\x08 \x01(\x08\x12\x1a\n\x12\x65xpect_row_version\x18\t \x01(\t\"J\n\x0f\x45xecuteResponse\x12\"\n\x06result\x18\x01 \x01(\x0b\x32\x12.query.QueryResult\x12\x13\n\x0brow_version\x18\x02 \x01(\t\"\x95\x02\n\x13\x45xecuteBatchRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x1d\n\x06target\x18\x03 \x01(\x0b\x32\r.query.Target\x12\"\n\x07queries\x18\x04 \x03(\x0b\x32\x11.query.BoundQuery\x12\x16\n\x0e\x61s_transaction\x18\x05 \x01(\x08\x12\x16\n\x0etransaction_id\x18\x06 \x01(\x03\x12\x12\n\nsession_id\x18\x07 \x01(\x03\x12\x15\n\rwait_for_gtid\x18\x08 \x01(\t\";\n\x14\x45xecuteBatchResponse\x12#\n\x07results\x18\x01 \x03(\x0b\x32\x12.query.QueryResult\"\xf6\x01\n\x14StreamExecuteRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x1d\n\x06target\x18\x03 \x01(\x0b\x32\r.query.Target\x12 \n\x05query\x18\x04 \x01(\x0b\x32\x11.query.BoundQuery\x12\x12\n\nsession_id\x18\x05 \x01(\x03\x12\x11\n\tresumable\x18\x06 \x01(\x08\x12\x14\n\x0cresume_token\x18\x07 \x01(\x0c\"Q\n\x15StreamExecuteResponse\x12\"\n\x06result\x18\x01 \x01(\x0b\x32\x12.query.QueryResult\x12\x14\n\x0cresume_token\x18\x02 \x01(\x0c\"\xcb\x01\n\x0c\x42\x65ginRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x1d\n\x06target\x18\x03 \x01(\x0b\x32\r.query.Target\x12\x12\n\nsession_id\x18\x04 \x01(\x03\x12&\n\x07options\x18\x05 \x01(\x0b\x32\x15.query.ExecuteOptions\"\'\n\rBeginResponse\x12\x16\n\x0etransaction_id\x18\x01 \x01(\x03\"\xd1\x01\n\rCommitRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x1d\n\x06target\x18\x03 \x01(\x0b\x32\r.query.Target\x12\x16\n\x0etransaction_id\x18\x04 \x01(\x03\x12\x12\n\nsession_id\x18\x05 \x01(\x03\x12\x13\n\x0breturn_gtid\x18\x06 \x01(\x08\"\x1e\n\x0e\x43ommitResponse\x12\x0c\n\x04gtid\x18\x01 \x01(\t\"\xbe\x01\n\x0fRollbackRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x1d\n\x06target\x18\x03 \x01(\x0b\x32\r.query.Target\x12\x16\n\x0etransaction_id\x18\x04 \x01(\x03\x12\x12\n\nsession_id\x18\x05 \x01(\x03\"\x12\n\x10RollbackResponse\"\xe0\x01\n\x13\x42\x65ginExecuteRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x1d\n\x06target\x18\x03 \x01(\x0b\x32\r.query.Target\x12 \n\x05query\x18\x04 \x01(\x0b\x32\x11.query.BoundQuery\x12&\n\x07options\x18\x05 \x01(\x0b\x32\x15.query.ExecuteOptions\"r\n\x14\x42\x65ginExecuteResponse\x12\x1e\n\x05\x65rror\x18\x01 \x01(\x0b\x32\x0f.vtrpc.RPCError\x12\"\n\x06result\x18\x02 \x01(\x0b\x32\x12.query.QueryResult\x12\x16\n\x0etransaction_id\x18\x03 \x01(\x03\"\xff\x01\n\x18\x42\x65ginExecuteBatchRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x1d\n\x06target\x18\x03 \x01(\x0b\x32\r.query.Target\x12\"\n\x07queries\x18\x04 \x03(\x0b\x32\x11.query.BoundQuery\x12\x16\n\x0e\x61s_transaction\x18\x05 \x01(\x08\x12&\n\x07options\x18\x06 \x01(\x0b\x32\x15.query.ExecuteOptions\"x\n\x19\x42\x65ginExecuteBatchResponse\x12\x1e\n\x05\x65rror\x18\x01 \x01(\x0b\x32\x0f.vtrpc.RPCError\x12#\n\x07results\x18\x02 \x03(\x0b\x32\x12.query.QueryResult\x12\x16\n\x0etransaction_id\x18\x03 \x01(\x03\"\x97\x03\n\x11SplitQueryRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x1d\n\x06target\x18\x03 \x01(\x0b\x32\r.query.Target\x12 \n\x05query\x18\x04 \x01(\x0b\x32\x11.query.BoundQuery\x12\x14\n\x0csplit_column\x18\x05 \x03(\t\x12\x13\n\x0bsplit_count\x18\x06 \x01(\x03\x12\x1f\n\x17num_rows_per_query_part\x18\x08 \x01(\x03\x12\x12\n\nsession_id\x18\x07 \x01(\x03\x12\x35\n\talgorithm\x18\t \x01(\x0e\x32\".query.SplitQueryRequest.Algorithm\x12\x1a\n\x12use_split_query_v2\x18\n \x01(\x08\",\n\tAlgorithm\x12\x10\n\x0c\x45QUAL_SPLITS\x10\x00\x12\r\n\tFULL_SCAN\x10\x01\"A\n\nQuerySplit\x12 \n\x05query\x18\x01 \x01(\x0b\x32\x11.query.BoundQuery\x12\x11\n\trow_count\x18\x02 \x01(\x03\"8\n\x12SplitQueryResponse\x12\"\n\x07queries\x18\x01 \x03(\x0b\x32\x11.query.QuerySplit\"\x15\n\x13StreamHealthRequest\"\xb6\x01\n\rRealtimeStats\x12\x14\n\x0chealth_error\x18\x01 \x01(\t\x12\x1d\n\x15seconds_behind_master\x18\x02 \x01(\r\x12\x1c\n\x14\x62inlog_players_count\x18\x03 \x01(\x05\x12\x32\n*seconds_behind_master_filtered_replication\x18\x04 \x01(\x03\x12\x11\n\tcpu_usage\x18\x05 \x01(\x01\x12\x0b\n\x03qps\x18\x06 \x01(\x01\"\xa4\x01\n\x14StreamHealthResponse\x12\x1d\n\x06target\x18\x01 \x01(\x0b\x32\r.query.Target\x12\x0f\n\x07serving\x18\x02 \x01(\x08\x12.\n&tablet_externally_reparented_timestamp\x18\x03 \x01(\x03\x12,\n\x0erealtime_stats\x18\x04 \x01(\x0b\x32\x14.query.RealtimeStats\"\xa3\x01\n\x12WaitForGTIDRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x1d\n\x06target\x18\x03 \x01(\x0b\x32\r.query.Target\x12\x0c\n\x04gtid\x18\x04 \x01(\t\"\x15\n\x13WaitForGTIDResponse\"\xb2\x01\n\x0eXAStartRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x1d\n\x06target\x18\x03 \x01(\x0b\x32\r.query.Target\x12\x12\n\nsession_id\x18\x04 \x01(\x03\x12\x0b\n\x03xid\x18\x05 \x01(\t\")\n\x0fXAStartResponse\x12\x16\n\x0etransaction_id\x18\x01 \x01(\x03\"\xb4\x01\n\x10XAPrepareRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x1d\n\x06target\x18\x03 \x01(\x0b\x32\r.query.Target\x12\x12\n\nsession_id\x18\x04 \x01(\x03\x12\x0b\n\x03xid\x18\x05 \x01(\t\"\x13\n\x11XAPrepareResponse\"\xb3\x01\n\x0fXACommitRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x1d\n\x06target\x18\x03 \x01(\x0b\x32\r.query.Target\x12\x12\n\nsession_id\x18\x04 \x01(\x03\x12\x0b\n\x03xid\x18\x05 \x01(\t\"\x12\n\x10XACommitResponse\"\xb5\x01\n\x11XARollbackRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x1d\n\x06target\x18\x03 \x01(\x0b\x32\r.query.Target\x12\x12\n\nsession_id\x18\x04 \x01(\x03\x12\x0b\n\x03xid\x18\x05 \x01(\t\"\x14\n\x12XARollbackResponse\"\xa7\x01\n\x10XARecoverRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x1d\n\x06target\x18\x03 \x01(\x0b\x32\r.query.Target\x12\x12\n\nsession_id\x18\x04 \x01(\x03\"!\n\x11XARecoverResponse\x12\x0c\n\x04xids\x18\x01 \x03(\t\"\x94\x01\n\x11HotQueriesRequest\x12,\n\x13\x65\x66\x66\x65\x63tive_caller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x32\n\x13immediate_caller_id\x18\x02 \x01(\x0b\x32\x15.query.VTGateCallerID\x12\x1d\n\x06target\x18\x03 \x01(\x0b\x32\r.query.Target\"8\n\x12HotQueriesResponse\x12\"\n\x07queries\x18\x01 \x03(\x0b\x32\x11.query.BoundQuery\"+\n\x0e\x45xecuteOptions\x12\x19\n\x11tx_pool_fail_fast\x18\x01 \x01(\x08*k\n\x04\x46lag\x12\x08\n\x04NONE\x10\x00\x12\x0f\n\nISINTEGRAL\x10\x80\x02\x12\x0f\n\nISUNSIGNED\x10\x80\x04\x12\x0c\n\x07ISFLOAT\x10\x80\x08\x12\r\n\x08ISQUOTED\x10\x80\x10\x12\x0b\n\x06ISTEXT\x10\x80 \x12\r\n\x08ISBINARY\x10\x80@*\xfa\x02\n\x04Type\x12\r\n\tNULL_TYPE\x10\x00\x12\t\n\x04INT8\x10\x81\x02\x12\n\n\x05UINT8\x10\x82\x06\x12\n\n\x05INT16\x10\x83\x02\x12\x0b\n\x06UINT16\x10\x84\x06\x12\n\n\x05INT24\x10\x85\x02\x12\x0b\n\x06UINT24\x10\x86\x06\x12\n\n\x05INT32\x10\x87\x02\x12\x0b\n\x06UINT32\x10\x88\x06\x12\n\n\x05INT64\x10\x89\x02\x12\x0b\n\x06UINT64\x10\x8a\x06\x12\x0c\n\x07\x46LOAT32\x10\x8b\x08\x12\x0c\n\x07\x46LOAT64\x10\x8c\x08\x12\x0e\n\tTIMESTAMP\x10\x8d\x10\x12\t\n\x04\x44\x41TE\x10\x8e\x10\x12\t\n\x04TIME\x10\x8f\x10\x12\r\n\x08\x44\x41TETIME\x10\x90\x10\x12\t\n\x04YEAR\x10\x91\x06\x12\x0b\n\x07\x44\x45\x43IMAL\x10\x12\x12\t\n\x04TEXT\x10\x93\x30\x12\t\n\x04\x42LOB\x10\x94P\x12\x0c\n\x07VARCHAR\x10\x95\x30\x12\x0e\n\tVARBINARY\x10\x96P\x12\t\n\x04\x43HAR\x10\x97\x30\x12\x0b\n\x06\x42INARY\x10\x98P\x12\x08\n\x03\x42IT\x10\x99\x10\x12\t\n\x04\x45NUM\x10\x9a\x10\x12\x08\n\x03SET\x10\x9b\x10\x12\t\n\x05TUPLE\x10\x1c\x12\t\n\x04JSON\x10\x9d\x10\x42\x1a\n\x18\x63om.youtube.vitess.protob\x06proto3')
   ,
   dependencies=[topodata__pb2.DESCRIPTOR,vtrpc__pb2.DESCRIPTOR,])
 _sym_db.RegisterFileDescriptor(DESCRIPTOR)
@@ -64,8 +64,8 @@ _FLAG = _descriptor.EnumDescriptor(
   ],
   containing_type=None,
   options=None,
-  serialized_start=3929,
-  serialized_end=4036,
+  serialized_start=5774,
+  serialized_end=5881,
 )
 _sym_db.RegisterEnumDescriptor(_FLAG)
 
@@ -192,11 +192,15 @@ _TYPE = _descriptor.EnumDescriptor(
       name='TUPLE', index=28, number=28,
       options=None,
       type=None),
+    _descriptor.EnumValueDescriptor(
+      name='JSON', index=29, number=2077,
+      options=None,
+      type=None),
   ],
   containing_type=None,
   options=None,
-  serialized_start=4039,
-  serialized_end=4406,
+  serialized_start=5884,
+  serialized_end=6262,
 )
 _sym_db.RegisterEnumDescriptor(_TYPE)
 
@@ -237,6 +241,7 @@ BIT = 2073
 ENUM = 2074
 SET = 2075
 TUPLE = 28
+JSON = 2077
 
 
 _SPLITQUERYREQUEST_ALGORITHM = _descriptor.EnumDescriptor(
@@ -256,8 +261,8 @@ _SPLITQUERYREQUEST_ALGORITHM = _descriptor.EnumDescriptor(
   ],
   containing_type=None,
   options=None,
-  serialized_start=3383,
-  serialized_end=3427,
+  serialized_start=3744,
+  serialized_end=3788,
 )
 _sym_db.RegisterEnumDescriptor(_SPLITQUERYREQUEST_ALGORITHM)
 
@@ -607,6 +612,13 @@ _QUERYRESULT = _descriptor.Descriptor(
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
+    _descriptor.FieldDescriptor(
+      name='warnings', full_name='query.QueryResult.warnings', index=4,
+      number=5, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
   ],
   extensions=[
   ],
@@ -619,8 +631,8 @@ _QUERYRESULT = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=567,
-  serialized_end=678,
+  serialized_start=568,
+  serialized_end=697,
 )
 
 
@@ -671,8 +683,8 @@ _GETSESSIONIDREQUEST = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=681,
-  serialized_end=833,
+  serialized_start=700,
+  serialized_end=852,
 )
 
 
@@ -702,8 +714,8 @@ _GETSESSIONIDRESPONSE = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=835,
-  serialized_end=877,
+  serialized_start=854,
+  serialized_end=896,
 )
 
 
@@ -756,6 +768,27 @@ _EXECUTEREQUEST = _descriptor.Descriptor(
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
+    _descriptor.FieldDescriptor(
+      name='wait_for_gtid', full_name='query.ExecuteRequest.wait_for_gtid', index=6,
+      number=7, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='include_row_version', full_name='query.ExecuteRequest.include_row_version', index=7,
+      number=8, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='expect_row_version', full_name='query.ExecuteRequest.expect_row_version', index=8,
+      number=9, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
   ],
   extensions=[
   ],
@@ -768,8 +801,8 @@ _EXECUTEREQUEST = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=880,
-  serialized_end=1103,
+  serialized_start=899,
+  serialized_end=1202,
 )
 
 
@@ -787,6 +820,13 @@ _EXECUTERESPONSE = _descriptor.Descriptor(
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
+    _descriptor.FieldDescriptor(
+      name='row_version', full_name='query.ExecuteResponse.row_version', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
   ],
   extensions=[
   ],
@@ -799,8 +839,8 @@ _EXECUTERESPONSE = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1105,
-  serialized_end=1158,
+  serialized_start=1204,
+  serialized_end=1278,
 )
 
 
@@ -860,6 +900,13 @@ _EXECUTEBATCHREQUEST = _descriptor.Descriptor(
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
+    _descriptor.FieldDescriptor(
+      name='wait_for_gtid', full_name='query.ExecuteBatchRequest.wait_for_gtid', index=7,
+      number=8, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
   ],
   extensions=[
   ],
@@ -872,8 +919,8 @@ _EXECUTEBATCHREQUEST = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1161,
-  serialized_end=1415,
+  serialized_start=1281,
+  serialized_end=1558,
 )
 
 
@@ -903,8 +950,8 @@ _EXECUTEBATCHRESPONSE = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1417,
-  serialized_end=1476,
+  serialized_start=1560,
+  serialized_end=1619,
 )
 
 
@@ -950,6 +997,20 @@ _STREAMEXECUTEREQUEST = _descriptor.Descriptor(
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
+    _descriptor.FieldDescriptor(
+      name='resumable', full_name='query.StreamExecuteRequest.resumable', index=5,
+      number=6, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='resume_token', full_name='query.StreamExecuteRequest.resume_token', index=6,
+      number=7, type=12, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b(""),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
   ],
   extensions=[
   ],
@@ -962,8 +1023,8 @@ _STREAMEXECUTEREQUEST = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1479,
-  serialized_end=1684,
+  serialized_start=1622,
+  serialized_end=1868,
 )
 
 
@@ -981,6 +1042,13 @@ _STREAMEXECUTERESPONSE = _descriptor.Descriptor(
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
+    _descriptor.FieldDescriptor(
+      name='resume_token', full_name='query.StreamExecuteResponse.resume_token', index=1,
+      number=2, type=12, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b(""),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
   ],
   extensions=[
   ],
@@ -993,8 +1061,8 @@ _STREAMEXECUTERESPONSE = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1686,
-  serialized_end=1745,
+  serialized_start=1870,
+  serialized_end=1951,
 )
 
 
@@ -1033,6 +1101,13 @@ _BEGINREQUEST = _descriptor.Descriptor(
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
+    _descriptor.FieldDescriptor(
+      name='options', full_name='query.BeginRequest.options', index=4,
+      number=5, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
   ],
   extensions=[
   ],
@@ -1045,8 +1120,8 @@ _BEGINREQUEST = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1748,
-  serialized_end=1911,
+  serialized_start=1954,
+  serialized_end=2157,
 )
 
 
@@ -1076,8 +1151,8 @@ _BEGINRESPONSE = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1913,
-  serialized_end=1952,
+  serialized_start=2159,
+  serialized_end=2198,
 )
 
 
@@ -1123,6 +1198,13 @@ _COMMITREQUEST = _descriptor.Descriptor(
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
+    _descriptor.FieldDescriptor(
+      name='return_gtid', full_name='query.CommitRequest.return_gtid', index=5,
+      number=6, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
   ],
   extensions=[
   ],
@@ -1135,8 +1217,8 @@ _COMMITREQUEST = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1955,
-  serialized_end=2143,
+  serialized_start=2201,
+  serialized_end=2410,
 )
 
 
@@ -1147,6 +1229,13 @@ _COMMITRESPONSE = _descriptor.Descriptor(
   file=DESCRIPTOR,
   containing_type=None,
   fields=[
+    _descriptor.FieldDescriptor(
+      name='gtid', full_name='query.CommitResponse.gtid', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
   ],
   extensions=[
   ],
@@ -1159,8 +1248,8 @@ _COMMITRESPONSE = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=2145,
-  serialized_end=2161,
+  serialized_start=2412,
+  serialized_end=2442,
 )
 
 
@@ -1218,8 +1307,8 @@ _ROLLBACKREQUEST = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=2164,
-  serialized_end=2354,
+  serialized_start=2445,
+  serialized_end=2635,
 )
 
 
@@ -1242,8 +1331,8 @@ _ROLLBACKRESPONSE = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=2356,
-  serialized_end=2374,
+  serialized_start=2637,
+  serialized_end=2655,
 )
 
 
@@ -1282,6 +1371,13 @@ _BEGINEXECUTEREQUEST = _descriptor.Descriptor(
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
+    _descriptor.FieldDescriptor(
+      name='options', full_name='query.BeginExecuteRequest.options', index=4,
+      number=5, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
   ],
   extensions=[
   ],
@@ -1294,8 +1390,8 @@ _BEGINEXECUTEREQUEST = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=2377,
-  serialized_end=2561,
+  serialized_start=2658,
+  serialized_end=2882,
 )
 
 
@@ -1339,8 +1435,8 @@ _BEGINEXECUTERESPONSE = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=2563,
-  serialized_end=2677,
+  serialized_start=2884,
+  serialized_end=2998,
 )
 
 
@@ -1386,6 +1482,13 @@ _BEGINEXECUTEBATCHREQUEST = _descriptor.Descriptor(
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
+    _descriptor.FieldDescriptor(
+      name='options', full_name='query.BeginExecuteBatchRequest.options', index=5,
+      number=6, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
   ],
   extensions=[
   ],
@@ -1398,8 +1501,8 @@ _BEGINEXECUTEBATCHREQUEST = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=2680,
-  serialized_end=2895,
+  serialized_start=3001,
+  serialized_end=3256,
 )
 
 
@@ -1443,8 +1546,8 @@ _BEGINEXECUTEBATCHRESPONSE = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=2897,
-  serialized_end=3017,
+  serialized_start=3258,
+  serialized_end=3378,
 )
 
 
@@ -1538,8 +1641,8 @@ _SPLITQUERYREQUEST = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=3020,
-  serialized_end=3427,
+  serialized_start=3381,
+  serialized_end=3788,
 )
 
 
@@ -1576,8 +1679,8 @@ _QUERYSPLIT = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=3429,
-  serialized_end=3494,
+  serialized_start=3790,
+  serialized_end=3855,
 )
 
 
@@ -1607,8 +1710,8 @@ _SPLITQUERYRESPONSE = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=3496,
-  serialized_end=3552,
+  serialized_start=3857,
+  serialized_end=3913,
 )
 
 
@@ -1631,8 +1734,8 @@ _STREAMHEALTHREQUEST = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=3554,
-  serialized_end=3575,
+  serialized_start=3915,
+  serialized_end=3936,
 )
 
 
@@ -1697,8 +1800,8 @@ _REALTIMESTATS = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=3578,
-  serialized_end=3760,
+  serialized_start=3939,
+  serialized_end=4121,
 )
 
 
@@ -1749,136 +1852,780 @@ _STREAMHEALTHRESPONSE = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=3763,
-  serialized_end=3927,
+  serialized_start=4124,
+  serialized_end=4288,
 )
 
-_TARGET.fields_by_name['tablet_type'].enum_type = topodata__pb2._TABLETTYPE
-_VALUE.fields_by_name['type'].enum_type = _TYPE
-_BINDVARIABLE.fields_by_name['type'].enum_type = _TYPE
-_BINDVARIABLE.fields_by_name['values'].message_type = _VALUE
-_BOUNDQUERY_BINDVARIABLESENTRY.fields_by_name['value'].message_type = _BINDVARIABLE
-_BOUNDQUERY_BINDVARIABLESENTRY.containing_type = _BOUNDQUERY
-_BOUNDQUERY.fields_by_name['bind_variables'].message_type = _BOUNDQUERY_BINDVARIABLESENTRY
-_FIELD.fields_by_name['type'].enum_type = _TYPE
-_QUERYRESULT.fields_by_name['fields'].message_type = _FIELD
-_QUERYRESULT.fields_by_name['rows'].message_type = _ROW
-_GETSESSIONIDREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
-_GETSESSIONIDREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
-_EXECUTEREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
-_EXECUTEREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
-_EXECUTEREQUEST.fields_by_name['target'].message_type = _TARGET
-_EXECUTEREQUEST.fields_by_name['query'].message_type = _BOUNDQUERY
-_EXECUTERESPONSE.fields_by_name['result'].message_type = _QUERYRESULT
-_EXECUTEBATCHREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
-_EXECUTEBATCHREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
-_EXECUTEBATCHREQUEST.fields_by_name['target'].message_type = _TARGET
-_EXECUTEBATCHREQUEST.fields_by_name['queries'].message_type = _BOUNDQUERY
-_EXECUTEBATCHRESPONSE.fields_by_name['results'].message_type = _QUERYRESULT
-_STREAMEXECUTEREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
-_STREAMEXECUTEREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
-_STREAMEXECUTEREQUEST.fields_by_name['target'].message_type = _TARGET
-_STREAMEXECUTEREQUEST.fields_by_name['query'].message_type = _BOUNDQUERY
-_STREAMEXECUTERESPONSE.fields_by_name['result'].message_type = _QUERYRESULT
-_BEGINREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
-_BEGINREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
-_BEGINREQUEST.fields_by_name['target'].message_type = _TARGET
-_COMMITREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
-_COMMITREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
-_COMMITREQUEST.fields_by_name['target'].message_type = _TARGET
-_ROLLBACKREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
-_ROLLBACKREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
-_ROLLBACKREQUEST.fields_by_name['target'].message_type = _TARGET
-_BEGINEXECUTEREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
-_BEGINEXECUTEREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
-_BEGINEXECUTEREQUEST.fields_by_name['target'].message_type = _TARGET
-_BEGINEXECUTEREQUEST.fields_by_name['query'].message_type = _BOUNDQUERY
-_BEGINEXECUTERESPONSE.fields_by_name['error'].message_type = vtrpc__pb2._RPCERROR
-_BEGINEXECUTERESPONSE.fields_by_name['result'].message_type = _QUERYRESULT
-_BEGINEXECUTEBATCHREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
-_BEGINEXECUTEBATCHREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
-_BEGINEXECUTEBATCHREQUEST.fields_by_name['target'].message_type = _TARGET
-_BEGINEXECUTEBATCHREQUEST.fields_by_name['queries'].message_type = _BOUNDQUERY
-_BEGINEXECUTEBATCHRESPONSE.fields_by_name['error'].message_type = vtrpc__pb2._RPCERROR
-_BEGINEXECUTEBATCHRESPONSE.fields_by_name['results'].message_type = _QUERYRESULT
-_SPLITQUERYREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
-_SPLITQUERYREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
-_SPLITQUERYREQUEST.fields_by_name['target'].message_type = _TARGET
-_SPLITQUERYREQUEST.fields_by_name['query'].message_type = _BOUNDQUERY
-_SPLITQUERYREQUEST.fields_by_name['algorithm'].enum_type = _SPLITQUERYREQUEST_ALGORITHM
-_SPLITQUERYREQUEST_ALGORITHM.containing_type = _SPLITQUERYREQUEST
-_QUERYSPLIT.fields_by_name['query'].message_type = _BOUNDQUERY
-_SPLITQUERYRESPONSE.fields_by_name['queries'].message_type = _QUERYSPLIT
-_STREAMHEALTHRESPONSE.fields_by_name['target'].message_type = _TARGET
-_STREAMHEALTHRESPONSE.fields_by_name['realtime_stats'].message_type = _REALTIMESTATS
-DESCRIPTOR.message_types_by_name['Target'] = _TARGET
-DESCRIPTOR.message_types_by_name['VTGateCallerID'] = _VTGATECALLERID
-DESCRIPTOR.message_types_by_name['Value'] = _VALUE
-DESCRIPTOR.message_types_by_name['BindVariable'] = _BINDVARIABLE
-DESCRIPTOR.message_types_by_name['BoundQuery'] = _BOUNDQUERY
-DESCRIPTOR.message_types_by_name['Field'] = _FIELD
-DESCRIPTOR.message_types_by_name['Row'] = _ROW
-DESCRIPTOR.message_types_by_name['QueryResult'] = _QUERYRESULT
-DESCRIPTOR.message_types_by_name['GetSessionIdRequest'] = _GETSESSIONIDREQUEST
-DESCRIPTOR.message_types_by_name['GetSessionIdResponse'] = _GETSESSIONIDRESPONSE
-DESCRIPTOR.message_types_by_name['ExecuteRequest'] = _EXECUTEREQUEST
-DESCRIPTOR.message_types_by_name['ExecuteResponse'] = _EXECUTERESPONSE
-DESCRIPTOR.message_types_by_name['ExecuteBatchRequest'] = _EXECUTEBATCHREQUEST
-DESCRIPTOR.message_types_by_name['ExecuteBatchResponse'] = _EXECUTEBATCHRESPONSE
-DESCRIPTOR.message_types_by_name['StreamExecuteRequest'] = _STREAMEXECUTEREQUEST
-DESCRIPTOR.message_types_by_name['StreamExecuteResponse'] = _STREAMEXECUTERESPONSE
-DESCRIPTOR.message_types_by_name['BeginRequest'] = _BEGINREQUEST
-DESCRIPTOR.message_types_by_name['BeginResponse'] = _BEGINRESPONSE
-DESCRIPTOR.message_types_by_name['CommitRequest'] = _COMMITREQUEST
-DESCRIPTOR.message_types_by_name['CommitResponse'] = _COMMITRESPONSE
-DESCRIPTOR.message_types_by_name['RollbackRequest'] = _ROLLBACKREQUEST
-DESCRIPTOR.message_types_by_name['RollbackResponse'] = _ROLLBACKRESPONSE
-DESCRIPTOR.message_types_by_name['BeginExecuteRequest'] = _BEGINEXECUTEREQUEST
-DESCRIPTOR.message_types_by_name['BeginExecuteResponse'] = _BEGINEXECUTERESPONSE
-DESCRIPTOR.message_types_by_name['BeginExecuteBatchRequest'] = _BEGINEXECUTEBATCHREQUEST
-DESCRIPTOR.message_types_by_name['BeginExecuteBatchResponse'] = _BEGINEXECUTEBATCHRESPONSE
-DESCRIPTOR.message_types_by_name['SplitQueryRequest'] = _SPLITQUERYREQUEST
-DESCRIPTOR.message_types_by_name['QuerySplit'] = _QUERYSPLIT
-DESCRIPTOR.message_types_by_name['SplitQueryResponse'] = _SPLITQUERYRESPONSE
-DESCRIPTOR.message_types_by_name['StreamHealthRequest'] = _STREAMHEALTHREQUEST
-DESCRIPTOR.message_types_by_name['RealtimeStats'] = _REALTIMESTATS
-DESCRIPTOR.message_types_by_name['StreamHealthResponse'] = _STREAMHEALTHRESPONSE
-DESCRIPTOR.enum_types_by_name['Flag'] = _FLAG
-DESCRIPTOR.enum_types_by_name['Type'] = _TYPE
-
-Target = _reflection.GeneratedProtocolMessageType('Target', (_message.Message,), dict(
-  DESCRIPTOR = _TARGET,
-  __module__ = 'query_pb2'
-  # @@protoc_insertion_point(class_scope:query.Target)
-  ))
-_sym_db.RegisterMessage(Target)
 
-VTGateCallerID = _reflection.GeneratedProtocolMessageType('VTGateCallerID', (_message.Message,), dict(
-  DESCRIPTOR = _VTGATECALLERID,
-  __module__ = 'query_pb2'
-  # @@protoc_insertion_point(class_scope:query.VTGateCallerID)
-  ))
-_sym_db.RegisterMessage(VTGateCallerID)
+_WAITFORGTIDREQUEST = _descriptor.Descriptor(
+  name='WaitForGTIDRequest',
+  full_name='query.WaitForGTIDRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='effective_caller_id', full_name='query.WaitForGTIDRequest.effective_caller_id', index=0,
+      number=1, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='immediate_caller_id', full_name='query.WaitForGTIDRequest.immediate_caller_id', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='target', full_name='query.WaitForGTIDRequest.target', index=2,
+      number=3, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='gtid', full_name='query.WaitForGTIDRequest.gtid', index=3,
+      number=4, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4291,
+  serialized_end=4454,
+)
 
-Value = _reflection.GeneratedProtocolMessageType('Value', (_message.Message,), dict(
-  DESCRIPTOR = _VALUE,
-  __module__ = 'query_pb2'
-  # @@protoc_insertion_point(class_scope:query.Value)
-  ))
-_sym_db.RegisterMessage(Value)
 
-BindVariable = _reflection.GeneratedProtocolMessageType('BindVariable', (_message.Message,), dict(
-  DESCRIPTOR = _BINDVARIABLE,
-  __module__ = 'query_pb2'
-  # @@protoc_insertion_point(class_scope:query.BindVariable)
-  ))
-_sym_db.RegisterMessage(BindVariable)
+_WAITFORGTIDRESPONSE = _descriptor.Descriptor(
+  name='WaitForGTIDResponse',
+  full_name='query.WaitForGTIDResponse',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4456,
+  serialized_end=4477,
+)
 
-BoundQuery = _reflection.GeneratedProtocolMessageType('BoundQuery', (_message.Message,), dict(
 
-  BindVariablesEntry = _reflection.GeneratedProtocolMessageType('BindVariablesEntry', (_message.Message,), dict(
-    DESCRIPTOR = _BOUNDQUERY_BINDVARIABLESENTRY,
-    __module__ = 'query_pb2'
+_XASTARTREQUEST = _descriptor.Descriptor(
+  name='XAStartRequest',
+  full_name='query.XAStartRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='effective_caller_id', full_name='query.XAStartRequest.effective_caller_id', index=0,
+      number=1, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='immediate_caller_id', full_name='query.XAStartRequest.immediate_caller_id', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='target', full_name='query.XAStartRequest.target', index=2,
+      number=3, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='session_id', full_name='query.XAStartRequest.session_id', index=3,
+      number=4, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='xid', full_name='query.XAStartRequest.xid', index=4,
+      number=5, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4480,
+  serialized_end=4658,
+)
+
+
+_XASTARTRESPONSE = _descriptor.Descriptor(
+  name='XAStartResponse',
+  full_name='query.XAStartResponse',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='transaction_id', full_name='query.XAStartResponse.transaction_id', index=0,
+      number=1, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4660,
+  serialized_end=4701,
+)
+
+
+_XAPREPAREREQUEST = _descriptor.Descriptor(
+  name='XAPrepareRequest',
+  full_name='query.XAPrepareRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='effective_caller_id', full_name='query.XAPrepareRequest.effective_caller_id', index=0,
+      number=1, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='immediate_caller_id', full_name='query.XAPrepareRequest.immediate_caller_id', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='target', full_name='query.XAPrepareRequest.target', index=2,
+      number=3, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='session_id', full_name='query.XAPrepareRequest.session_id', index=3,
+      number=4, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='xid', full_name='query.XAPrepareRequest.xid', index=4,
+      number=5, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4704,
+  serialized_end=4884,
+)
+
+
+_XAPREPARERESPONSE = _descriptor.Descriptor(
+  name='XAPrepareResponse',
+  full_name='query.XAPrepareResponse',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4886,
+  serialized_end=4905,
+)
+
+
+_XACOMMITREQUEST = _descriptor.Descriptor(
+  name='XACommitRequest',
+  full_name='query.XACommitRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='effective_caller_id', full_name='query.XACommitRequest.effective_caller_id', index=0,
+      number=1, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='immediate_caller_id', full_name='query.XACommitRequest.immediate_caller_id', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='target', full_name='query.XACommitRequest.target', index=2,
+      number=3, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='session_id', full_name='query.XACommitRequest.session_id', index=3,
+      number=4, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='xid', full_name='query.XACommitRequest.xid', index=4,
+      number=5, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=4908,
+  serialized_end=5087,
+)
+
+
+_XACOMMITRESPONSE = _descriptor.Descriptor(
+  name='XACommitResponse',
+  full_name='query.XACommitResponse',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=5089,
+  serialized_end=5107,
+)
+
+
+_XAROLLBACKREQUEST = _descriptor.Descriptor(
+  name='XARollbackRequest',
+  full_name='query.XARollbackRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='effective_caller_id', full_name='query.XARollbackRequest.effective_caller_id', index=0,
+      number=1, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='immediate_caller_id', full_name='query.XARollbackRequest.immediate_caller_id', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='target', full_name='query.XARollbackRequest.target', index=2,
+      number=3, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='session_id', full_name='query.XARollbackRequest.session_id', index=3,
+      number=4, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='xid', full_name='query.XARollbackRequest.xid', index=4,
+      number=5, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=5110,
+  serialized_end=5291,
+)
+
+
+_XAROLLBACKRESPONSE = _descriptor.Descriptor(
+  name='XARollbackResponse',
+  full_name='query.XARollbackResponse',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=5293,
+  serialized_end=5313,
+)
+
+
+_XARECOVERREQUEST = _descriptor.Descriptor(
+  name='XARecoverRequest',
+  full_name='query.XARecoverRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='effective_caller_id', full_name='query.XARecoverRequest.effective_caller_id', index=0,
+      number=1, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='immediate_caller_id', full_name='query.XARecoverRequest.immediate_caller_id', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='target', full_name='query.XARecoverRequest.target', index=2,
+      number=3, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='session_id', full_name='query.XARecoverRequest.session_id', index=3,
+      number=4, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=5316,
+  serialized_end=5483,
+)
+
+
+_XARECOVERRESPONSE = _descriptor.Descriptor(
+  name='XARecoverResponse',
+  full_name='query.XARecoverResponse',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='xids', full_name='query.XARecoverResponse.xids', index=0,
+      number=1, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=5485,
+  serialized_end=5518,
+)
+
+
+_HOTQUERIESREQUEST = _descriptor.Descriptor(
+  name='HotQueriesRequest',
+  full_name='query.HotQueriesRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='effective_caller_id', full_name='query.HotQueriesRequest.effective_caller_id', index=0,
+      number=1, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='immediate_caller_id', full_name='query.HotQueriesRequest.immediate_caller_id', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='target', full_name='query.HotQueriesRequest.target', index=2,
+      number=3, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=5521,
+  serialized_end=5669,
+)
+
+
+_HOTQUERIESRESPONSE = _descriptor.Descriptor(
+  name='HotQueriesResponse',
+  full_name='query.HotQueriesResponse',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='queries', full_name='query.HotQueriesResponse.queries', index=0,
+      number=1, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=5671,
+  serialized_end=5727,
+)
+
+
+_EXECUTEOPTIONS = _descriptor.Descriptor(
+  name='ExecuteOptions',
+  full_name='query.ExecuteOptions',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='tx_pool_fail_fast', full_name='query.ExecuteOptions.tx_pool_fail_fast', index=0,
+      number=1, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=5729,
+  serialized_end=5772,
+)
+_TARGET.fields_by_name['tablet_type'].enum_type = topodata__pb2._TABLETTYPE
+_VALUE.fields_by_name['type'].enum_type = _TYPE
+_BINDVARIABLE.fields_by_name['type'].enum_type = _TYPE
+_BINDVARIABLE.fields_by_name['values'].message_type = _VALUE
+_BOUNDQUERY_BINDVARIABLESENTRY.fields_by_name['value'].message_type = _BINDVARIABLE
+_BOUNDQUERY_BINDVARIABLESENTRY.containing_type = _BOUNDQUERY
+_BOUNDQUERY.fields_by_name['bind_variables'].message_type = _BOUNDQUERY_BINDVARIABLESENTRY
+_FIELD.fields_by_name['type'].enum_type = _TYPE
+_QUERYRESULT.fields_by_name['fields'].message_type = _FIELD
+_QUERYRESULT.fields_by_name['rows'].message_type = _ROW
+_GETSESSIONIDREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
+_GETSESSIONIDREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
+_EXECUTEREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
+_EXECUTEREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
+_EXECUTEREQUEST.fields_by_name['target'].message_type = _TARGET
+_EXECUTEREQUEST.fields_by_name['query'].message_type = _BOUNDQUERY
+_EXECUTERESPONSE.fields_by_name['result'].message_type = _QUERYRESULT
+_EXECUTEBATCHREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
+_EXECUTEBATCHREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
+_EXECUTEBATCHREQUEST.fields_by_name['target'].message_type = _TARGET
+_EXECUTEBATCHREQUEST.fields_by_name['queries'].message_type = _BOUNDQUERY
+_EXECUTEBATCHRESPONSE.fields_by_name['results'].message_type = _QUERYRESULT
+_STREAMEXECUTEREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
+_STREAMEXECUTEREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
+_STREAMEXECUTEREQUEST.fields_by_name['target'].message_type = _TARGET
+_STREAMEXECUTEREQUEST.fields_by_name['query'].message_type = _BOUNDQUERY
+_STREAMEXECUTERESPONSE.fields_by_name['result'].message_type = _QUERYRESULT
+_BEGINREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
+_BEGINREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
+_BEGINREQUEST.fields_by_name['target'].message_type = _TARGET
+_BEGINREQUEST.fields_by_name['options'].message_type = _EXECUTEOPTIONS
+_COMMITREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
+_COMMITREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
+_COMMITREQUEST.fields_by_name['target'].message_type = _TARGET
+_ROLLBACKREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
+_ROLLBACKREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
+_ROLLBACKREQUEST.fields_by_name['target'].message_type = _TARGET
+_BEGINEXECUTEREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
+_BEGINEXECUTEREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
+_BEGINEXECUTEREQUEST.fields_by_name['target'].message_type = _TARGET
+_BEGINEXECUTEREQUEST.fields_by_name['query'].message_type = _BOUNDQUERY
+_BEGINEXECUTEREQUEST.fields_by_name['options'].message_type = _EXECUTEOPTIONS
+_BEGINEXECUTERESPONSE.fields_by_name['error'].message_type = vtrpc__pb2._RPCERROR
+_BEGINEXECUTERESPONSE.fields_by_name['result'].message_type = _QUERYRESULT
+_BEGINEXECUTEBATCHREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
+_BEGINEXECUTEBATCHREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
+_BEGINEXECUTEBATCHREQUEST.fields_by_name['target'].message_type = _TARGET
+_BEGINEXECUTEBATCHREQUEST.fields_by_name['queries'].message_type = _BOUNDQUERY
+_BEGINEXECUTEBATCHREQUEST.fields_by_name['options'].message_type = _EXECUTEOPTIONS
+_BEGINEXECUTEBATCHRESPONSE.fields_by_name['error'].message_type = vtrpc__pb2._RPCERROR
+_BEGINEXECUTEBATCHRESPONSE.fields_by_name['results'].message_type = _QUERYRESULT
+_SPLITQUERYREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
+_SPLITQUERYREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
+_SPLITQUERYREQUEST.fields_by_name['target'].message_type = _TARGET
+_SPLITQUERYREQUEST.fields_by_name['query'].message_type = _BOUNDQUERY
+_SPLITQUERYREQUEST.fields_by_name['algorithm'].enum_type = _SPLITQUERYREQUEST_ALGORITHM
+_SPLITQUERYREQUEST_ALGORITHM.containing_type = _SPLITQUERYREQUEST
+_QUERYSPLIT.fields_by_name['query'].message_type = _BOUNDQUERY
+_SPLITQUERYRESPONSE.fields_by_name['queries'].message_type = _QUERYSPLIT
+_STREAMHEALTHRESPONSE.fields_by_name['target'].message_type = _TARGET
+_STREAMHEALTHRESPONSE.fields_by_name['realtime_stats'].message_type = _REALTIMESTATS
+_WAITFORGTIDREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
+_WAITFORGTIDREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
+_WAITFORGTIDREQUEST.fields_by_name['target'].message_type = _TARGET
+_XASTARTREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
+_XASTARTREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
+_XASTARTREQUEST.fields_by_name['target'].message_type = _TARGET
+_XAPREPAREREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
+_XAPREPAREREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
+_XAPREPAREREQUEST.fields_by_name['target'].message_type = _TARGET
+_XACOMMITREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
+_XACOMMITREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
+_XACOMMITREQUEST.fields_by_name['target'].message_type = _TARGET
+_XAROLLBACKREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
+_XAROLLBACKREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
+_XAROLLBACKREQUEST.fields_by_name['target'].message_type = _TARGET
+_XARECOVERREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
+_XARECOVERREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
+_XARECOVERREQUEST.fields_by_name['target'].message_type = _TARGET
+_HOTQUERIESREQUEST.fields_by_name['effective_caller_id'].message_type = vtrpc__pb2._CALLERID
+_HOTQUERIESREQUEST.fields_by_name['immediate_caller_id'].message_type = _VTGATECALLERID
+_HOTQUERIESREQUEST.fields_by_name['target'].message_type = _TARGET
+_HOTQUERIESRESPONSE.fields_by_name['queries'].message_type = _BOUNDQUERY
+DESCRIPTOR.message_types_by_name['Target'] = _TARGET
+DESCRIPTOR.message_types_by_name['VTGateCallerID'] = _VTGATECALLERID
+DESCRIPTOR.message_types_by_name['Value'] = _VALUE
+DESCRIPTOR.message_types_by_name['BindVariable'] = _BINDVARIABLE
+DESCRIPTOR.message_types_by_name['BoundQuery'] = _BOUNDQUERY
+DESCRIPTOR.message_types_by_name['Field'] = _FIELD
+DESCRIPTOR.message_types_by_name['Row'] = _ROW
+DESCRIPTOR.message_types_by_name['QueryResult'] = _QUERYRESULT
+DESCRIPTOR.message_types_by_name['GetSessionIdRequest'] = _GETSESSIONIDREQUEST
+DESCRIPTOR.message_types_by_name['GetSessionIdResponse'] = _GETSESSIONIDRESPONSE
+DESCRIPTOR.message_types_by_name['ExecuteRequest'] = _EXECUTEREQUEST
+DESCRIPTOR.message_types_by_name['ExecuteResponse'] = _EXECUTERESPONSE
+DESCRIPTOR.message_types_by_name['ExecuteBatchRequest'] = _EXECUTEBATCHREQUEST
+DESCRIPTOR.message_types_by_name['ExecuteBatchResponse'] = _EXECUTEBATCHRESPONSE
+DESCRIPTOR.message_types_by_name['StreamExecuteRequest'] = _STREAMEXECUTEREQUEST
+DESCRIPTOR.message_types_by_name['StreamExecuteResponse'] = _STREAMEXECUTERESPONSE
+DESCRIPTOR.message_types_by_name['BeginRequest'] = _BEGINREQUEST
+DESCRIPTOR.message_types_by_name['BeginResponse'] = _BEGINRESPONSE
+DESCRIPTOR.message_types_by_name['CommitRequest'] = _COMMITREQUEST
+DESCRIPTOR.message_types_by_name['CommitResponse'] = _COMMITRESPONSE
+DESCRIPTOR.message_types_by_name['RollbackRequest'] = _ROLLBACKREQUEST
+DESCRIPTOR.message_types_by_name['RollbackResponse'] = _ROLLBACKRESPONSE
+DESCRIPTOR.message_types_by_name['BeginExecuteRequest'] = _BEGINEXECUTEREQUEST
+DESCRIPTOR.message_types_by_name['BeginExecuteResponse'] = _BEGINEXECUTERESPONSE
+DESCRIPTOR.message_types_by_name['BeginExecuteBatchRequest'] = _BEGINEXECUTEBATCHREQUEST
+DESCRIPTOR.message_types_by_name['BeginExecuteBatchResponse'] = _BEGINEXECUTEBATCHRESPONSE
+DESCRIPTOR.message_types_by_name['SplitQueryRequest'] = _SPLITQUERYREQUEST
+DESCRIPTOR.message_types_by_name['QuerySplit'] = _QUERYSPLIT
+DESCRIPTOR.message_types_by_name['SplitQueryResponse'] = _SPLITQUERYRESPONSE
+DESCRIPTOR.message_types_by_name['StreamHealthRequest'] = _STREAMHEALTHREQUEST
+DESCRIPTOR.message_types_by_name['RealtimeStats'] = _REALTIMESTATS
+DESCRIPTOR.message_types_by_name['StreamHealthResponse'] = _STREAMHEALTHRESPONSE
+DESCRIPTOR.message_types_by_name['WaitForGTIDRequest'] = _WAITFORGTIDREQUEST
+DESCRIPTOR.message_types_by_name['WaitForGTIDResponse'] = _WAITFORGTIDRESPONSE
+DESCRIPTOR.message_types_by_name['XAStartRequest'] = _XASTARTREQUEST
+DESCRIPTOR.message_types_by_name['XAStartResponse'] = _XASTARTRESPONSE
+DESCRIPTOR.message_types_by_name['XAPrepareRequest'] = _XAPREPAREREQUEST
+DESCRIPTOR.message_types_by_name['XAPrepareResponse'] = _XAPREPARERESPONSE
+DESCRIPTOR.message_types_by_name['XACommitRequest'] = _XACOMMITREQUEST
+DESCRIPTOR.message_types_by_name['XACommitResponse'] = _XACOMMITRESPONSE
+DESCRIPTOR.message_types_by_name['XARollbackRequest'] = _XAROLLBACKREQUEST
+DESCRIPTOR.message_types_by_name['XARollbackResponse'] = _XAROLLBACKRESPONSE
+DESCRIPTOR.message_types_by_name['XARecoverRequest'] = _XARECOVERREQUEST
+DESCRIPTOR.message_types_by_name['XARecoverResponse'] = _XARECOVERRESPONSE
+DESCRIPTOR.message_types_by_name['HotQueriesRequest'] = _HOTQUERIESREQUEST
+DESCRIPTOR.message_types_by_name['HotQueriesResponse'] = _HOTQUERIESRESPONSE
+DESCRIPTOR.message_types_by_name['ExecuteOptions'] = _EXECUTEOPTIONS
+DESCRIPTOR.enum_types_by_name['Flag'] = _FLAG
+DESCRIPTOR.enum_types_by_name['Type'] = _TYPE
+
+Target = _reflection.GeneratedProtocolMessageType('Target', (_message.Message,), dict(
+  DESCRIPTOR = _TARGET,
+  __module__ = 'query_pb2'
+  # @@protoc_insertion_point(class_scope:query.Target)
+  ))
+_sym_db.RegisterMessage(Target)
+
+VTGateCallerID = _reflection.GeneratedProtocolMessageType('VTGateCallerID', (_message.Message,), dict(
+  DESCRIPTOR = _VTGATECALLERID,
+  __module__ = 'query_pb2'
+  # @@protoc_insertion_point(class_scope:query.VTGateCallerID)
+  ))
+_sym_db.RegisterMessage(VTGateCallerID)
+
+Value = _reflection.GeneratedProtocolMessageType('Value', (_message.Message,), dict(
+  DESCRIPTOR = _VALUE,
+  __module__ = 'query_pb2'
+  # @@protoc_insertion_point(class_scope:query.Value)
+  ))
+_sym_db.RegisterMessage(Value)
+
+BindVariable = _reflection.GeneratedProtocolMessageType('BindVariable', (_message.Message,), dict(
+  DESCRIPTOR = _BINDVARIABLE,
+  __module__ = 'query_pb2'
+  # @@protoc_insertion_point(class_scope:query.BindVariable)
+  ))
+_sym_db.RegisterMessage(BindVariable)
+
+BoundQuery = _reflection.GeneratedProtocolMessageType('BoundQuery', (_message.Message,), dict(
+
+  BindVariablesEntry = _reflection.GeneratedProtocolMessageType('BindVariablesEntry', (_message.Message,), dict(
+    DESCRIPTOR = _BOUNDQUERY_BINDVARIABLESENTRY,
+    __module__ = 'query_pb2'
     # @@protoc_insertion_point(class_scope:query.BoundQuery.BindVariablesEntry)
     ))
   ,
@@ -2078,6 +2825,111 @@ StreamHealthResponse = _reflection.GeneratedProtocolMessageType('StreamHealthRes
   ))
 _sym_db.RegisterMessage(StreamHealthResponse)
 
+WaitForGTIDRequest = _reflection.GeneratedProtocolMessageType('WaitForGTIDRequest', (_message.Message,), dict(
+  DESCRIPTOR = _WAITFORGTIDREQUEST,
+  __module__ = 'query_pb2'
+  # @@protoc_insertion_point(class_scope:query.WaitForGTIDRequest)
+  ))
+_sym_db.RegisterMessage(WaitForGTIDRequest)
+
+WaitForGTIDResponse = _reflection.GeneratedProtocolMessageType('WaitForGTIDResponse', (_message.Message,), dict(
+  DESCRIPTOR = _WAITFORGTIDRESPONSE,
+  __module__ = 'query_pb2'
+  # @@protoc_insertion_point(class_scope:query.WaitForGTIDResponse)
+  ))
+_sym_db.RegisterMessage(WaitForGTIDResponse)
+
+XAStartRequest = _reflection.GeneratedProtocolMessageType('XAStartRequest', (_message.Message,), dict(
+  DESCRIPTOR = _XASTARTREQUEST,
+  __module__ = 'query_pb2'
+  # @@protoc_insertion_point(class_scope:query.XAStartRequest)
+  ))
+_sym_db.RegisterMessage(XAStartRequest)
+
+XAStartResponse = _reflection.GeneratedProtocolMessageType('XAStartResponse', (_message.Message,), dict(
+  DESCRIPTOR = _XASTARTRESPONSE,
+  __module__ = 'query_pb2'
+  # @@protoc_insertion_point(class_scope:query.XAStartResponse)
+  ))
+_sym_db.RegisterMessage(XAStartResponse)
+
+XAPrepareRequest = _reflection.GeneratedProtocolMessageType('XAPrepareRequest', (_message.Message,), dict(
+  DESCRIPTOR = _XAPREPAREREQUEST,
+  __module__ = 'query_pb2'
+  # @@protoc_insertion_point(class_scope:query.XAPrepareRequest)
+  ))
+_sym_db.RegisterMessage(XAPrepareRequest)
+
+XAPrepareResponse = _reflection.GeneratedProtocolMessageType('XAPrepareResponse', (_message.Message,), dict(
+  DESCRIPTOR = _XAPREPARERESPONSE,
+  __module__ = 'query_pb2'
+  # @@protoc_insertion_point(class_scope:query.XAPrepareResponse)
+  ))
+_sym_db.RegisterMessage(XAPrepareResponse)
+
+XACommitRequest = _reflection.GeneratedProtocolMessageType('XACommitRequest', (_message.Message,), dict(
+  DESCRIPTOR = _XACOMMITREQUEST,
+  __module__ = 'query_pb2'
+  # @@protoc_insertion_point(class_scope:query.XACommitRequest)
+  ))
+_sym_db.RegisterMessage(XACommitRequest)
+
+XACommitResponse = _reflection.GeneratedProtocolMessageType('XACommitResponse', (_message.Message,), dict(
+  DESCRIPTOR = _XACOMMITRESPONSE,
+  __module__ = 'query_pb2'
+  # @@protoc_insertion_point(class_scope:query.XACommitResponse)
+  ))
+_sym_db.RegisterMessage(XACommitResponse)
+
+XARollbackRequest = _reflection.GeneratedProtocolMessageType('XARollbackRequest', (_message.Message,), dict(
+  DESCRIPTOR = _XAROLLBACKREQUEST,
+  __module__ = 'query_pb2'
+  # @@protoc_insertion_point(class_scope:query.XARollbackRequest)
+  ))
+_sym_db.RegisterMessage(XARollbackRequest)
+
+XARollbackResponse = _reflection.GeneratedProtocolMessageType('XARollbackResponse', (_message.Message,), dict(
+  DESCRIPTOR = _XAROLLBACKRESPONSE,
+  __module__ = 'query_pb2'
+  # @@protoc_insertion_point(class_scope:query.XARollbackResponse)
+  ))
+_sym_db.RegisterMessage(XARollbackResponse)
+
+XARecoverRequest = _reflection.GeneratedProtocolMessageType('XARecoverRequest', (_message.Message,), dict(
+  DESCRIPTOR = _XARECOVERREQUEST,
+  __module__ = 'query_pb2'
+  # @@protoc_insertion_point(class_scope:query.XARecoverRequest)
+  ))
+_sym_db.RegisterMessage(XARecoverRequest)
+
+XARecoverResponse = _reflection.GeneratedProtocolMessageType('XARecoverResponse', (_message.Message,), dict(
+  DESCRIPTOR = _XARECOVERRESPONSE,
+  __module__ = 'query_pb2'
+  # @@protoc_insertion_point(class_scope:query.XARecoverResponse)
+  ))
+_sym_db.RegisterMessage(XARecoverResponse)
+
+HotQueriesRequest = _reflection.GeneratedProtocolMessageType('HotQueriesRequest', (_message.Message,), dict(
+  DESCRIPTOR = _HOTQUERIESREQUEST,
+  __module__ = 'query_pb2'
+  # @@protoc_insertion_point(class_scope:query.HotQueriesRequest)
+  ))
+_sym_db.RegisterMessage(HotQueriesRequest)
+
+HotQueriesResponse = _reflection.GeneratedProtocolMessageType('HotQueriesResponse', (_message.Message,), dict(
+  DESCRIPTOR = _HOTQUERIESRESPONSE,
+  __module__ = 'query_pb2'
+  # @@protoc_insertion_point(class_scope:query.HotQueriesResponse)
+  ))
+_sym_db.RegisterMessage(HotQueriesResponse)
+
+ExecuteOptions = _reflection.GeneratedProtocolMessageType('ExecuteOptions', (_message.Message,), dict(
+  DESCRIPTOR = _EXECUTEOPTIONS,
+  __module__ = 'query_pb2'
+  # @@protoc_insertion_point(class_scope:query.ExecuteOptions)
+  ))
+_sym_db.RegisterMessage(ExecuteOptions)
+
 
 DESCRIPTOR.has_options = True
 DESCRIPTOR._options = _descriptor._ParseOptions(descriptor_pb2.FileOptions(), _b('\n\030com.youtube.vitess.proto'))
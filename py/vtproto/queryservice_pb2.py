@@ -20,7 +20,7 @@ DESCRIPTOR = _descriptor.FileDescriptor(
   name='queryservice.proto',
   package='queryservice',
   syntax='proto3',
-  serialized_pb=_b('\n\x12queryservice.proto\x12\x0cqueryservice\x1a\x0bquery.proto2\x8e\x06\n\x05Query\x12I\n\x0cGetSessionId\x12\x1a.query.GetSessionIdRequest\x1a\x1b.query.GetSessionIdResponse\"\x00\x12:\n\x07\x45xecute\x12\x15.query.ExecuteRequest\x1a\x16.query.ExecuteResponse\"\x00\x12I\n\x0c\x45xecuteBatch\x12\x1a.query.ExecuteBatchRequest\x1a\x1b.query.ExecuteBatchResponse\"\x00\x12N\n\rStreamExecute\x12\x1b.query.StreamExecuteRequest\x1a\x1c.query.StreamExecuteResponse\"\x00\x30\x01\x12\x34\n\x05\x42\x65gin\x12\x13.query.BeginRequest\x1a\x14.query.BeginResponse\"\x00\x12\x37\n\x06\x43ommit\x12\x14.query.CommitRequest\x1a\x15.query.CommitResponse\"\x00\x12=\n\x08Rollback\x12\x16.query.RollbackRequest\x1a\x17.query.RollbackResponse\"\x00\x12I\n\x0c\x42\x65ginExecute\x12\x1a.query.BeginExecuteRequest\x1a\x1b.query.BeginExecuteResponse\"\x00\x12X\n\x11\x42\x65ginExecuteBatch\x12\x1f.query.BeginExecuteBatchRequest\x1a .query.BeginExecuteBatchResponse\"\x00\x12\x43\n\nSplitQuery\x12\x18.query.SplitQueryRequest\x1a\x19.query.SplitQueryResponse\"\x00\x12K\n\x0cStreamHealth\x12\x1a.query.StreamHealthRequest\x1a\x1b.query.StreamHealthResponse\"\x00\x30\x01\x62\x06proto3')
+  serialized_pb=_b('\n\x12queryservice.proto\x12\x0cqueryservice\x1a\x0bquery.proto2\xdf\t\n\x05Query\x12I\n\x0cGetSessionId\x12\x1a.query.GetSessionIdRequest\x1a\x1b.query.GetSessionIdResponse\"\x00\x12:\n\x07\x45xecute\x12\x15.query.ExecuteRequest\x1a\x16.query.ExecuteResponse\"\x00\x12I\n\x0c\x45xecuteBatch\x12\x1a.query.ExecuteBatchRequest\x1a\x1b.query.ExecuteBatchResponse\"\x00\x12N\n\rStreamExecute\x12\x1b.query.StreamExecuteRequest\x1a\x1c.query.StreamExecuteResponse\"\x00\x30\x01\x12\x34\n\x05\x42\x65gin\x12\x13.query.BeginRequest\x1a\x14.query.BeginResponse\"\x00\x12\x37\n\x06\x43ommit\x12\x14.query.CommitRequest\x1a\x15.query.CommitResponse\"\x00\x12=\n\x08Rollback\x12\x16.query.RollbackRequest\x1a\x17.query.RollbackResponse\"\x00\x12I\n\x0c\x42\x65ginExecute\x12\x1a.query.BeginExecuteRequest\x1a\x1b.query.BeginExecuteResponse\"\x00\x12X\n\x11\x42\x65ginExecuteBatch\x12\x1f.query.BeginExecuteBatchRequest\x1a .query.BeginExecuteBatchResponse\"\x00\x12\x43\n\nSplitQuery\x12\x18.query.SplitQueryRequest\x1a\x19.query.SplitQueryResponse\"\x00\x12K\n\x0cStreamHealth\x12\x1a.query.StreamHealthRequest\x1a\x1b.query.StreamHealthResponse\"\x00\x30\x01\x12\x46\n\x0bWaitForGTID\x12\x19.query.WaitForGTIDRequest\x1a\x1a.query.WaitForGTIDResponse\"\x00\x12:\n\x07XAStart\x12\x15.query.XAStartRequest\x1a\x16.query.XAStartResponse\"\x00\x12@\n\tXAPrepare\x12\x17.query.XAPrepareRequest\x1a\x18.query.XAPrepareResponse\"\x00\x12=\n\x08XACommit\x12\x16.query.XACommitRequest\x1a\x17.query.XACommitResponse\"\x00\x12\x43\n\nXARollback\x12\x18.query.XARollbackRequest\x1a\x19.query.XARollbackResponse\"\x00\x12@\n\tXARecover\x12\x17.query.XARecoverRequest\x1a\x18.query.XARecoverResponse\"\x00\x12\x43\n\nHotQueries\x12\x18.query.HotQueriesRequest\x1a\x19.query.HotQueriesResponse\"\x00\x62\x06proto3')
   ,
   dependencies=[query__pb2.DESCRIPTOR,])
 _sym_db.RegisterFileDescriptor(DESCRIPTOR)
@@ -70,6 +70,27 @@ class BetaQueryServicer(object):
   @abc.abstractmethod
   def StreamHealth(self, request, context):
     raise NotImplementedError()
+  @abc.abstractmethod
+  def WaitForGTID(self, request, context):
+    raise NotImplementedError()
+  @abc.abstractmethod
+  def XAStart(self, request, context):
+    raise NotImplementedError()
+  @abc.abstractmethod
+  def XAPrepare(self, request, context):
+    raise NotImplementedError()
+  @abc.abstractmethod
+  def XACommit(self, request, context):
+    raise NotImplementedError()
+  @abc.abstractmethod
+  def XARollback(self, request, context):
+    raise NotImplementedError()
+  @abc.abstractmethod
+  def XARecover(self, request, context):
+    raise NotImplementedError()
+  @abc.abstractmethod
+  def HotQueries(self, request, context):
+    raise NotImplementedError()
 
 class BetaQueryStub(object):
   """The interface to which stubs will conform."""
@@ -116,6 +137,34 @@ class BetaQueryStub(object):
   @abc.abstractmethod
   def StreamHealth(self, request, timeout):
     raise NotImplementedError()
+  @abc.abstractmethod
+  def WaitForGTID(self, request, timeout):
+    raise NotImplementedError()
+  WaitForGTID.future = None
+  @abc.abstractmethod
+  def XAStart(self, request, timeout):
+    raise NotImplementedError()
+  XAStart.future = None
+  @abc.abstractmethod
+  def XAPrepare(self, request, timeout):
+    raise NotImplementedError()
+  XAPrepare.future = None
+  @abc.abstractmethod
+  def XACommit(self, request, timeout):
+    raise NotImplementedError()
+  XACommit.future = None
+  @abc.abstractmethod
+  def XARollback(self, request, timeout):
+    raise NotImplementedError()
+  XARollback.future = None
+  @abc.abstractmethod
+  def XARecover(self, request, timeout):
+    raise NotImplementedError()
+  XARecover.future = None
+  @abc.abstractmethod
+  def HotQueries(self, request, timeout):
+    raise NotImplementedError()
+  HotQueries.future = None
 
 def beta_create_Query_server(servicer, pool=None, pool_size=None, default_timeout=None, maximum_timeout=None):
   import query_pb2
@@ -140,6 +189,20 @@ def beta_create_Query_server(servicer, pool=None, pool_size=None, default_timeou
   import query_pb2
   import query_pb2
   import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
   request_deserializers = {
     ('queryservice.Query', 'Begin'): query_pb2.BeginRequest.FromString,
     ('queryservice.Query', 'BeginExecute'): query_pb2.BeginExecuteRequest.FromString,
@@ -148,10 +211,17 @@ def beta_create_Query_server(servicer, pool=None, pool_size=None, default_timeou
     ('queryservice.Query', 'Execute'): query_pb2.ExecuteRequest.FromString,
     ('queryservice.Query', 'ExecuteBatch'): query_pb2.ExecuteBatchRequest.FromString,
     ('queryservice.Query', 'GetSessionId'): query_pb2.GetSessionIdRequest.FromString,
+    ('queryservice.Query', 'HotQueries'): query_pb2.HotQueriesRequest.FromString,
     ('queryservice.Query', 'Rollback'): query_pb2.RollbackRequest.FromString,
     ('queryservice.Query', 'SplitQuery'): query_pb2.SplitQueryRequest.FromString,
     ('queryservice.Query', 'StreamExecute'): query_pb2.StreamExecuteRequest.FromString,
     ('queryservice.Query', 'StreamHealth'): query_pb2.StreamHealthRequest.FromString,
+    ('queryservice.Query', 'WaitForGTID'): query_pb2.WaitForGTIDRequest.FromString,
+    ('queryservice.Query', 'XACommit'): query_pb2.XACommitRequest.FromString,
+    ('queryservice.Query', 'XAPrepare'): query_pb2.XAPrepareRequest.FromString,
+    ('queryservice.Query', 'XARecover'): query_pb2.XARecoverRequest.FromString,
+    ('queryservice.Query', 'XARollback'): query_pb2.XARollbackRequest.FromString,
+    ('queryservice.Query', 'XAStart'): query_pb2.XAStartRequest.FromString,
   }
   response_serializers = {
     ('queryservice.Query', 'Begin'): query_pb2.BeginResponse.SerializeToString,
@@ -161,10 +231,17 @@ def beta_create_Query_server(servicer, pool=None, pool_size=None, default_timeou
     ('queryservice.Query', 'Execute'): query_pb2.ExecuteResponse.SerializeToString,
     ('queryservice.Query', 'ExecuteBatch'): query_pb2.ExecuteBatchResponse.SerializeToString,
     ('queryservice.Query', 'GetSessionId'): query_pb2.GetSessionIdResponse.SerializeToString,
+    ('queryservice.Query', 'HotQueries'): query_pb2.HotQueriesResponse.SerializeToString,
     ('queryservice.Query', 'Rollback'): query_pb2.RollbackResponse.SerializeToString,
     ('queryservice.Query', 'SplitQuery'): query_pb2.SplitQueryResponse.SerializeToString,
     ('queryservice.Query', 'StreamExecute'): query_pb2.StreamExecuteResponse.SerializeToString,
     ('queryservice.Query', 'StreamHealth'): query_pb2.StreamHealthResponse.SerializeToString,
+    ('queryservice.Query', 'WaitForGTID'): query_pb2.WaitForGTIDResponse.SerializeToString,
+    ('queryservice.Query', 'XACommit'): query_pb2.XACommitResponse.SerializeToString,
+    ('queryservice.Query', 'XAPrepare'): query_pb2.XAPrepareResponse.SerializeToString,
+    ('queryservice.Query', 'XARecover'): query_pb2.XARecoverResponse.SerializeToString,
+    ('queryservice.Query', 'XARollback'): query_pb2.XARollbackResponse.SerializeToString,
+    ('queryservice.Query', 'XAStart'): query_pb2.XAStartResponse.SerializeToString,
   }
   method_implementations = {
     ('queryservice.Query', 'Begin'): face_utilities.unary_unary_inline(servicer.Begin),
@@ -174,10 +251,17 @@ def beta_create_Query_server(servicer, pool=None, pool_size=None, default_timeou
     ('queryservice.Query', 'Execute'): face_utilities.unary_unary_inline(servicer.Execute),
     ('queryservice.Query', 'ExecuteBatch'): face_utilities.unary_unary_inline(servicer.ExecuteBatch),
     ('queryservice.Query', 'GetSessionId'): face_utilities.unary_unary_inline(servicer.GetSessionId),
+    ('queryservice.Query', 'HotQueries'): face_utilities.unary_unary_inline(servicer.HotQueries),
     ('queryservice.Query', 'Rollback'): face_utilities.unary_unary_inline(servicer.Rollback),
     ('queryservice.Query', 'SplitQuery'): face_utilities.unary_unary_inline(servicer.SplitQuery),
     ('queryservice.Query', 'StreamExecute'): face_utilities.unary_stream_inline(servicer.StreamExecute),
     ('queryservice.Query', 'StreamHealth'): face_utilities.unary_stream_inline(servicer.StreamHealth),
+    ('queryservice.Query', 'WaitForGTID'): face_utilities.unary_unary_inline(servicer.WaitForGTID),
+    ('queryservice.Query', 'XACommit'): face_utilities.unary_unary_inline(servicer.XACommit),
+    ('queryservice.Query', 'XAPrepare'): face_utilities.unary_unary_inline(servicer.XAPrepare),
+    ('queryservice.Query', 'XARecover'): face_utilities.unary_unary_inline(servicer.XARecover),
+    ('queryservice.Query', 'XARollback'): face_utilities.unary_unary_inline(servicer.XARollback),
+    ('queryservice.Query', 'XAStart'): face_utilities.unary_unary_inline(servicer.XAStart),
   }
   server_options = beta_implementations.server_options(request_deserializers=request_deserializers, response_serializers=response_serializers, thread_pool=pool, thread_pool_size=pool_size, default_timeout=default_timeout, maximum_timeout=maximum_timeout)
   return beta_implementations.server(method_implementations, options=server_options)
@@ -205,6 +289,20 @@ def beta_create_Query_stub(channel, host=None, metadata_transformer=None, pool=N
   import query_pb2
   import query_pb2
   import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
+  import query_pb2
   request_serializers = {
     ('queryservice.Query', 'Begin'): query_pb2.BeginRequest.SerializeToString,
     ('queryservice.Query', 'BeginExecute'): query_pb2.BeginExecuteRequest.SerializeToString,
@@ -213,10 +311,17 @@ def beta_create_Query_stub(channel, host=None, metadata_transformer=None, pool=N
     ('queryservice.Query', 'Execute'): query_pb2.ExecuteRequest.SerializeToString,
     ('queryservice.Query', 'ExecuteBatch'): query_pb2.ExecuteBatchRequest.SerializeToString,
     ('queryservice.Query', 'GetSessionId'): query_pb2.GetSessionIdRequest.SerializeToString,
+    ('queryservice.Query', 'HotQueries'): query_pb2.HotQueriesRequest.SerializeToString,
     ('queryservice.Query', 'Rollback'): query_pb2.RollbackRequest.SerializeToString,
     ('queryservice.Query', 'SplitQuery'): query_pb2.SplitQueryRequest.SerializeToString,
     ('queryservice.Query', 'StreamExecute'): query_pb2.StreamExecuteRequest.SerializeToString,
     ('queryservice.Query', 'StreamHealth'): query_pb2.StreamHealthRequest.SerializeToString,
+    ('queryservice.Query', 'WaitForGTID'): query_pb2.WaitForGTIDRequest.SerializeToString,
+    ('queryservice.Query', 'XACommit'): query_pb2.XACommitRequest.SerializeToString,
+    ('queryservice.Query', 'XAPrepare'): query_pb2.XAPrepareRequest.SerializeToString,
+    ('queryservice.Query', 'XARecover'): query_pb2.XARecoverRequest.SerializeToString,
+    ('queryservice.Query', 'XARollback'): query_pb2.XARollbackRequest.SerializeToString,
+    ('queryservice.Query', 'XAStart'): query_pb2.XAStartRequest.SerializeToString,
   }
   response_deserializers = {
     ('queryservice.Query', 'Begin'): query_pb2.BeginResponse.FromString,
@@ -226,10 +331,17 @@ def beta_create_Query_stub(channel, host=None, metadata_transformer=None, pool=N
     ('queryservice.Query', 'Execute'): query_pb2.ExecuteResponse.FromString,
     ('queryservice.Query', 'ExecuteBatch'): query_pb2.ExecuteBatchResponse.FromString,
     ('queryservice.Query', 'GetSessionId'): query_pb2.GetSessionIdResponse.FromString,
+    ('queryservice.Query', 'HotQueries'): query_pb2.HotQueriesResponse.FromString,
     ('queryservice.Query', 'Rollback'): query_pb2.RollbackResponse.FromString,
     ('queryservice.Query', 'SplitQuery'): query_pb2.SplitQueryResponse.FromString,
     ('queryservice.Query', 'StreamExecute'): query_pb2.StreamExecuteResponse.FromString,
     ('queryservice.Query', 'StreamHealth'): query_pb2.StreamHealthResponse.FromString,
+    ('queryservice.Query', 'WaitForGTID'): query_pb2.WaitForGTIDResponse.FromString,
+    ('queryservice.Query', 'XACommit'): query_pb2.XACommitResponse.FromString,
+    ('queryservice.Query', 'XAPrepare'): query_pb2.XAPrepareResponse.FromString,
+    ('queryservice.Query', 'XARecover'): query_pb2.XARecoverResponse.FromString,
+    ('queryservice.Query', 'XARollback'): query_pb2.XARollbackResponse.FromString,
+    ('queryservice.Query', 'XAStart'): query_pb2.XAStartResponse.FromString,
   }
   cardinalities = {
     'Begin': cardinality.Cardinality.UNARY_UNARY,
@@ -239,10 +351,17 @@ def beta_create_Query_stub(channel, host=None, metadata_transformer=None, pool=N
     'Execute': cardinality.Cardinality.UNARY_UNARY,
     'ExecuteBatch': cardinality.Cardinality.UNARY_UNARY,
     'GetSessionId': cardinality.Cardinality.UNARY_UNARY,
+    'HotQueries': cardinality.Cardinality.UNARY_UNARY,
     'Rollback': cardinality.Cardinality.UNARY_UNARY,
     'SplitQuery': cardinality.Cardinality.UNARY_UNARY,
     'StreamExecute': cardinality.Cardinality.UNARY_STREAM,
     'StreamHealth': cardinality.Cardinality.UNARY_STREAM,
+    'WaitForGTID': cardinality.Cardinality.UNARY_UNARY,
+    'XACommit': cardinality.Cardinality.UNARY_UNARY,
+    'XAPrepare': cardinality.Cardinality.UNARY_UNARY,
+    'XARecover': cardinality.Cardinality.UNARY_UNARY,
+    'XARollback': cardinality.Cardinality.UNARY_UNARY,
+    'XAStart': cardinality.Cardinality.UNARY_UNARY,
   }
   stub_options = beta_implementations.stub_options(host=host, metadata_transformer=metadata_transformer, request_serializers=request_serializers, response_deserializers=response_deserializers, thread_pool=pool, thread_pool_size=pool_size)
   return beta_implementations.dynamic_stub(channel, 'queryservice.Query', cardinalities, options=stub_options)
@@ -20,7 +20,7 @@ DESCRIPTOR = _descriptor.FileDescriptor(
   name='tabletmanagerservice.proto',
   package='tabletmanagerservice',
   syntax='proto3',
-  serialized_pb=_b('\n\x1atabletmanagerservice.proto\x12\x14tabletmanagerservice\x1a\x17tabletmanagerdata.proto2\xa7 \n\rTabletManager\x12I\n\x04Ping\x12\x1e.tabletmanagerdata.PingRequest\x1a\x1f.tabletmanagerdata.PingResponse\"\x00\x12L\n\x05Sleep\x12\x1f.tabletmanagerdata.SleepRequest\x1a .tabletmanagerdata.SleepResponse\"\x00\x12^\n\x0b\x45xecuteHook\x12%.tabletmanagerdata.ExecuteHookRequest\x1a&.tabletmanagerdata.ExecuteHookResponse\"\x00\x12X\n\tGetSchema\x12#.tabletmanagerdata.GetSchemaRequest\x1a$.tabletmanagerdata.GetSchemaResponse\"\x00\x12g\n\x0eGetPermissions\x12(.tabletmanagerdata.GetPermissionsRequest\x1a).tabletmanagerdata.GetPermissionsResponse\"\x00\x12^\n\x0bSetReadOnly\x12%.tabletmanagerdata.SetReadOnlyRequest\x1a&.tabletmanagerdata.SetReadOnlyResponse\"\x00\x12\x61\n\x0cSetReadWrite\x12&.tabletmanagerdata.SetReadWriteRequest\x1a\'.tabletmanagerdata.SetReadWriteResponse\"\x00\x12[\n\nChangeType\x12$.tabletmanagerdata.ChangeTypeRequest\x1a%.tabletmanagerdata.ChangeTypeResponse\"\x00\x12\x61\n\x0cRefreshState\x12&.tabletmanagerdata.RefreshStateRequest\x1a\'.tabletmanagerdata.RefreshStateResponse\"\x00\x12g\n\x0eRunHealthCheck\x12(.tabletmanagerdata.RunHealthCheckRequest\x1a).tabletmanagerdata.RunHealthCheckResponse\"\x00\x12p\n\x11IgnoreHealthError\x12+.tabletmanagerdata.IgnoreHealthErrorRequest\x1a,.tabletmanagerdata.IgnoreHealthErrorResponse\"\x00\x12\x61\n\x0cReloadSchema\x12&.tabletmanagerdata.ReloadSchemaRequest\x1a\'.tabletmanagerdata.ReloadSchemaResponse\"\x00\x12j\n\x0fPreflightSchema\x12).tabletmanagerdata.PreflightSchemaRequest\x1a*.tabletmanagerdata.PreflightSchemaResponse\"\x00\x12^\n\x0b\x41pplySchema\x12%.tabletmanagerdata.ApplySchemaRequest\x1a&.tabletmanagerdata.ApplySchemaResponse\"\x00\x12p\n\x11\x45xecuteFetchAsDba\x12+.tabletmanagerdata.ExecuteFetchAsDbaRequest\x1a,.tabletmanagerdata.ExecuteFetchAsDbaResponse\"\x00\x12p\n\x11\x45xecuteFetchAsApp\x12+.tabletmanagerdata.ExecuteFetchAsAppRequest\x1a,.tabletmanagerdata.ExecuteFetchAsAppResponse\"\x00\x12^\n\x0bSlaveStatus\x12%.tabletmanagerdata.SlaveStatusRequest\x1a&.tabletmanagerdata.SlaveStatusResponse\"\x00\x12g\n\x0eMasterPosition\x12(.tabletmanagerdata.MasterPositionRequest\x1a).tabletmanagerdata.MasterPositionResponse\"\x00\x12X\n\tStopSlave\x12#.tabletmanagerdata.StopSlaveRequest\x1a$.tabletmanagerdata.StopSlaveResponse\"\x00\x12m\n\x10StopSlaveMinimum\x12*.tabletmanagerdata.StopSlaveMinimumRequest\x1a+.tabletmanagerdata.StopSlaveMinimumResponse\"\x00\x12[\n\nStartSlave\x12$.tabletmanagerdata.StartSlaveRequest\x1a%.tabletmanagerdata.StartSlaveResponse\"\x00\x12\x8b\x01\n\x1aTabletExternallyReparented\x12\x34.tabletmanagerdata.TabletExternallyReparentedRequest\x1a\x35.tabletmanagerdata.TabletExternallyReparentedResponse\"\x00\x12\x82\x01\n\x17TabletExternallyElected\x12\x31.tabletmanagerdata.TabletExternallyElectedRequest\x1a\x32.tabletmanagerdata.TabletExternallyElectedResponse\"\x00\x12X\n\tGetSlaves\x12#.tabletmanagerdata.GetSlavesRequest\x1a$.tabletmanagerdata.GetSlavesResponse\"\x00\x12j\n\x0fWaitBlpPosition\x12).tabletmanagerdata.WaitBlpPositionRequest\x1a*.tabletmanagerdata.WaitBlpPositionResponse\"\x00\x12R\n\x07StopBlp\x12!.tabletmanagerdata.StopBlpRequest\x1a\".tabletmanagerdata.StopBlpResponse\"\x00\x12U\n\x08StartBlp\x12\".tabletmanagerdata.StartBlpRequest\x1a#.tabletmanagerdata.StartBlpResponse\"\x00\x12^\n\x0bRunBlpUntil\x12%.tabletmanagerdata.RunBlpUntilRequest\x1a&.tabletmanagerdata.RunBlpUntilResponse\"\x00\x12m\n\x10ResetReplication\x12*.tabletmanagerdata.ResetReplicationRequest\x1a+.tabletmanagerdata.ResetReplicationResponse\"\x00\x12[\n\nInitMaster\x12$.tabletmanagerdata.InitMasterRequest\x1a%.tabletmanagerdata.InitMasterResponse\"\x00\x12\x82\x01\n\x17PopulateReparentJournal\x12\x31.tabletmanagerdata.PopulateReparentJournalRequest\x1a\x32.tabletmanagerdata.PopulateReparentJournalResponse\"\x00\x12X\n\tInitSlave\x12#.tabletmanagerdata.InitSlaveRequest\x1a$.tabletmanagerdata.InitSlaveResponse\"\x00\x12\x61\n\x0c\x44\x65moteMaster\x12&.tabletmanagerdata.DemoteMasterRequest\x1a\'.tabletmanagerdata.DemoteMasterResponse\"\x00\x12\x85\x01\n\x18PromoteSlaveWhenCaughtUp\x12\x32.tabletmanagerdata.PromoteSlaveWhenCaughtUpRequest\x1a\x33.tabletmanagerdata.PromoteSlaveWhenCaughtUpResponse\"\x00\x12m\n\x10SlaveWasPromoted\x12*.tabletmanagerdata.SlaveWasPromotedRequest\x1a+.tabletmanagerdata.SlaveWasPromotedResponse\"\x00\x12X\n\tSetMaster\x12#.tabletmanagerdata.SetMasterRequest\x1a$.tabletmanagerdata.SetMasterResponse\"\x00\x12p\n\x11SlaveWasRestarted\x12+.tabletmanagerdata.SlaveWasRestartedRequest\x1a,.tabletmanagerdata.SlaveWasRestartedResponse\"\x00\x12\x8e\x01\n\x1bStopReplicationAndGetStatus\x12\x35.tabletmanagerdata.StopReplicationAndGetStatusRequest\x1a\x36.tabletmanagerdata.StopReplicationAndGetStatusResponse\"\x00\x12\x61\n\x0cPromoteSlave\x12&.tabletmanagerdata.PromoteSlaveRequest\x1a\'.tabletmanagerdata.PromoteSlaveResponse\"\x00\x12Q\n\x06\x42\x61\x63kup\x12 .tabletmanagerdata.BackupRequest\x1a!.tabletmanagerdata.BackupResponse\"\x00\x30\x01\x62\x06proto3')
+  serialized_pb=_b('\n\x1atabletmanagerservice.proto\x12\x14tabletmanagerservice\x1a\x17tabletmanagerdata.proto2\x87!\n\rTabletManager\x12I\n\x04Ping\x12\x1e.tabletmanagerdata.PingRequest\x1a\x1f.tabletmanagerdata.PingResponse\"\x00\x12L\n\x05Sleep\x12\x1f.tabletmanagerdata.SleepRequest\x1a .tabletmanagerdata.SleepResponse\"\x00\x12^\n\x0b\x45xecuteHook\x12%.tabletmanagerdata.ExecuteHookRequest\x1a&.tabletmanagerdata.ExecuteHookResponse\"\x00\x12X\n\tGetSchema\x12#.tabletmanagerdata.GetSchemaRequest\x1a$.tabletmanagerdata.GetSchemaResponse\"\x00\x12g\n\x0eGetPermissions\x12(.tabletmanagerdata.GetPermissionsRequest\x1a).tabletmanagerdata.GetPermissionsResponse\"\x00\x12^\n\x0bSetReadOnly\x12%.tabletmanagerdata.SetReadOnlyRequest\x1a&.tabletmanagerdata.SetReadOnlyResponse\"\x00\x12\x61\n\x0cSetReadWrite\x12&.tabletmanagerdata.SetReadWriteRequest\x1a\'.tabletmanagerdata.SetReadWriteResponse\"\x00\x12[\n\nChangeType\x12$.tabletmanagerdata.ChangeTypeRequest\x1a%.tabletmanagerdata.ChangeTypeResponse\"\x00\x12\x61\n\x0cRefreshState\x12&.tabletmanagerdata.RefreshStateRequest\x1a\'.tabletmanagerdata.RefreshStateResponse\"\x00\x12g\n\x0eRunHealthCheck\x12(.tabletmanagerdata.RunHealthCheckRequest\x1a).tabletmanagerdata.RunHealthCheckResponse\"\x00\x12p\n\x11IgnoreHealthError\x12+.tabletmanagerdata.IgnoreHealthErrorRequest\x1a,.tabletmanagerdata.IgnoreHealthErrorResponse\"\x00\x12\x61\n\x0cReloadSchema\x12&.tabletmanagerdata.ReloadSchemaRequest\x1a\'.tabletmanagerdata.ReloadSchemaResponse\"\x00\x12j\n\x0fPreflightSchema\x12).tabletmanagerdata.PreflightSchemaRequest\x1a*.tabletmanagerdata.PreflightSchemaResponse\"\x00\x12^\n\x0b\x41pplySchema\x12%.tabletmanagerdata.ApplySchemaRequest\x1a&.tabletmanagerdata.ApplySchemaResponse\"\x00\x12p\n\x11\x45xecuteFetchAsDba\x12+.tabletmanagerdata.ExecuteFetchAsDbaRequest\x1a,.tabletmanagerdata.ExecuteFetchAsDbaResponse\"\x00\x12p\n\x11\x45xecuteFetchAsApp\x12+.tabletmanagerdata.ExecuteFetchAsAppRequest\x1a,.tabletmanagerdata.ExecuteFetchAsAppResponse\"\x00\x12^\n\x0bSlaveStatus\x12%.tabletmanagerdata.SlaveStatusRequest\x1a&.tabletmanagerdata.SlaveStatusResponse\"\x00\x12g\n\x0eMasterPosition\x12(.tabletmanagerdata.MasterPositionRequest\x1a).tabletmanagerdata.MasterPositionResponse\"\x00\x12X\n\tStopSlave\x12#.tabletmanagerdata.StopSlaveRequest\x1a$.tabletmanagerdata.StopSlaveResponse\"\x00\x12m\n\x10StopSlaveMinimum\x12*.tabletmanagerdata.StopSlaveMinimumRequest\x1a+.tabletmanagerdata.StopSlaveMinimumResponse\"\x00\x12[\n\nStartSlave\x12$.tabletmanagerdata.StartSlaveRequest\x1a%.tabletmanagerdata.StartSlaveResponse\"\x00\x12\x8b\x01\n\x1aTabletExternallyReparented\x12\x34.tabletmanagerdata.TabletExternallyReparentedRequest\x1a\x35.tabletmanagerdata.TabletExternallyReparentedResponse\"\x00\x12\x82\x01\n\x17TabletExternallyElected\x12\x31.tabletmanagerdata.TabletExternallyElectedRequest\x1a\x32.tabletmanagerdata.TabletExternallyElectedResponse\"\x00\x12X\n\tGetSlaves\x12#.tabletmanagerdata.GetSlavesRequest\x1a$.tabletmanagerdata.GetSlavesResponse\"\x00\x12j\n\x0fWaitBlpPosition\x12).tabletmanagerdata.WaitBlpPositionRequest\x1a*.tabletmanagerdata.WaitBlpPositionResponse\"\x00\x12R\n\x07StopBlp\x12!.tabletmanagerdata.StopBlpRequest\x1a\".tabletmanagerdata.StopBlpResponse\"\x00\x12U\n\x08StartBlp\x12\".tabletmanagerdata.StartBlpRequest\x1a#.tabletmanagerdata.StartBlpResponse\"\x00\x12^\n\x0bRunBlpUntil\x12%.tabletmanagerdata.RunBlpUntilRequest\x1a&.tabletmanagerdata.RunBlpUntilResponse\"\x00\x12m\n\x10ResetReplication\x12*.tabletmanagerdata.ResetReplicationRequest\x1a+.tabletmanagerdata.ResetReplicationResponse\"\x00\x12[\n\nInitMaster\x12$.tabletmanagerdata.InitMasterRequest\x1a%.tabletmanagerdata.InitMasterResponse\"\x00\x12\x82\x01\n\x17PopulateReparentJournal\x12\x31.tabletmanagerdata.PopulateReparentJournalRequest\x1a\x32.tabletmanagerdata.PopulateReparentJournalResponse\"\x00\x12X\n\tInitSlave\x12#.tabletmanagerdata.InitSlaveRequest\x1a$.tabletmanagerdata.InitSlaveResponse\"\x00\x12\x61\n\x0c\x44\x65moteMaster\x12&.tabletmanagerdata.DemoteMasterRequest\x1a\'.tabletmanagerdata.DemoteMasterResponse\"\x00\x12\x85\x01\n\x18PromoteSlaveWhenCaughtUp\x12\x32.tabletmanagerdata.PromoteSlaveWhenCaughtUpRequest\x1a\x33.tabletmanagerdata.PromoteSlaveWhenCaughtUpResponse\"\x00\x12m\n\x10SlaveWasPromoted\x12*.tabletmanagerdata.SlaveWasPromotedRequest\x1a+.tabletmanagerdata.SlaveWasPromotedResponse\"\x00\x12X\n\tSetMaster\x12#.tabletmanagerdata.SetMasterRequest\x1a$.tabletmanagerdata.SetMasterResponse\"\x00\x12p\n\x11SlaveWasRestarted\x12+.tabletmanagerdata.SlaveWasRestartedRequest\x1a,.tabletmanagerdata.SlaveWasRestartedResponse\"\x00\x12\x8e\x01\n\x1bStopReplicationAndGetStatus\x12\x35.tabletmanagerdata.StopReplicationAndGetStatusRequest\x1a\x36.tabletmanagerdata.StopReplicationAndGetStatusResponse\"\x00\x12\x61\n\x0cPromoteSlave\x12&.tabletmanagerdata.PromoteSlaveRequest\x1a\'.tabletmanagerdata.PromoteSlaveResponse\"\x00\x12Q\n\x06\x42\x61\x63kup\x12 .tabletmanagerdata.BackupRequest\x1a!.tabletmanagerdata.BackupResponse\"\x00\x30\x01\x12^\n\x0bWarmQueries\x12%.tabletmanagerdata.WarmQueriesRequest\x1a&.tabletmanagerdata.WarmQueriesResponse\"\x00\x62\x06proto3')
   ,
   dependencies=[tabletmanagerdata__pb2.DESCRIPTOR,])
 _sym_db.RegisterFileDescriptor(DESCRIPTOR)
@@ -157,6 +157,9 @@ class BetaTabletManagerServicer(object):
   @abc.abstractmethod
   def Backup(self, request, context):
     raise NotImplementedError()
+  @abc.abstractmethod
+  def WarmQueries(self, request, context):
+    raise NotImplementedError()
 
 class BetaTabletManagerStub(object):
   """The interface to which stubs will conform."""
@@ -320,6 +323,10 @@ class BetaTabletManagerStub(object):
   @abc.abstractmethod
   def Backup(self, request, timeout):
     raise NotImplementedError()
+  @abc.abstractmethod
+  def WarmQueries(self, request, timeout):
+    raise NotImplementedError()
+  WarmQueries.future = None
 
 def beta_create_TabletManager_server(servicer, pool=None, pool_size=None, default_timeout=None, maximum_timeout=None):
   import tabletmanagerdata_pb2
@@ -402,6 +409,8 @@ def beta_create_TabletManager_server(servicer, pool=None, pool_size=None, defaul
   import tabletmanagerdata_pb2
   import tabletmanagerdata_pb2
   import tabletmanagerdata_pb2
+  import tabletmanagerdata_pb2
+  import tabletmanagerdata_pb2
   request_deserializers = {
     ('tabletmanagerservice.TabletManager', 'ApplySchema'): tabletmanagerdata_pb2.ApplySchemaRequest.FromString,
     ('tabletmanagerservice.TabletManager', 'Backup'): tabletmanagerdata_pb2.BackupRequest.FromString,
@@ -443,6 +452,7 @@ def beta_create_TabletManager_server(servicer, pool=None, pool_size=None, defaul
     ('tabletmanagerservice.TabletManager', 'TabletExternallyElected'): tabletmanagerdata_pb2.TabletExternallyElectedRequest.FromString,
     ('tabletmanagerservice.TabletManager', 'TabletExternallyReparented'): tabletmanagerdata_pb2.TabletExternallyReparentedRequest.FromString,
     ('tabletmanagerservice.TabletManager', 'WaitBlpPosition'): tabletmanagerdata_pb2.WaitBlpPositionRequest.FromString,
+    ('tabletmanagerservice.TabletManager', 'WarmQueries'): tabletmanagerdata_pb2.WarmQueriesRequest.FromString,
   }
   response_serializers = {
     ('tabletmanagerservice.TabletManager', 'ApplySchema'): tabletmanagerdata_pb2.ApplySchemaResponse.SerializeToString,
@@ -485,6 +495,7 @@ def beta_create_TabletManager_server(servicer, pool=None, pool_size=None, defaul
     ('tabletmanagerservice.TabletManager', 'TabletExternallyElected'): tabletmanagerdata_pb2.TabletExternallyElectedResponse.SerializeToString,
     ('tabletmanagerservice.TabletManager', 'TabletExternallyReparented'): tabletmanagerdata_pb2.TabletExternallyReparentedResponse.SerializeToString,
     ('tabletmanagerservice.TabletManager', 'WaitBlpPosition'): tabletmanagerdata_pb2.WaitBlpPositionResponse.SerializeToString,
+    ('tabletmanagerservice.TabletManager', 'WarmQueries'): tabletmanagerdata_pb2.WarmQueriesResponse.SerializeToString,
   }
   method_implementations = {
     ('tabletmanagerservice.TabletManager', 'ApplySchema'): face_utilities.unary_unary_inline(servicer.ApplySchema),
@@ -527,6 +538,7 @@ def beta_create_TabletManager_server(servicer, pool=None, pool_size=None, defaul
     ('tabletmanagerservice.TabletManager', 'TabletExternallyElected'): face_utilities.unary_unary_inline(servicer.TabletExternallyElected),
     ('tabletmanagerservice.TabletManager', 'TabletExternallyReparented'): face_utilities.unary_unary_inline(servicer.TabletExternallyReparented),
     ('tabletmanagerservice.TabletManager', 'WaitBlpPosition'): face_utilities.unary_unary_inline(servicer.WaitBlpPosition),
+    ('tabletmanagerservice.TabletManager', 'WarmQueries'): face_utilities.unary_unary_inline(servicer.WarmQueries),
   }
   server_options = beta_implementations.server_options(request_deserializers=request_deserializers, response_serializers=response_serializers, thread_pool=pool, thread_pool_size=pool_size, default_timeout=default_timeout, maximum_timeout=maximum_timeout)
   return beta_implementations.server(method_implementations, options=server_options)
@@ -612,6 +624,8 @@ def beta_create_TabletManager_stub(channel, host=None, metadata_transformer=None
   import tabletmanagerdata_pb2
   import tabletmanagerdata_pb2
   import tabletmanagerdata_pb2
+  import tabletmanagerdata_pb2
+  import tabletmanagerdata_pb2
   request_serializers = {
     ('tabletmanagerservice.TabletManager', 'ApplySchema'): tabletmanagerdata_pb2.ApplySchemaRequest.SerializeToString,
     ('tabletmanagerservice.TabletManager', 'Backup'): tabletmanagerdata_pb2.BackupRequest.SerializeToString,
@@ -653,6 +667,7 @@ def beta_create_TabletManager_stub(channel, host=None, metadata_transformer=None
     ('tabletmanagerservice.TabletManager', 'TabletExternallyElected'): tabletmanagerdata_pb2.TabletExternallyElectedRequest.SerializeToString,
     ('tabletmanagerservice.TabletManager', 'TabletExternallyReparented'): tabletmanagerdata_pb2.TabletExternallyReparentedRequest.SerializeToString,
     ('tabletmanagerservice.TabletManager', 'WaitBlpPosition'): tabletmanagerdata_pb2.WaitBlpPositionRequest.SerializeToString,
+    ('tabletmanagerservice.TabletManager', 'WarmQueries'): tabletmanagerdata_pb2.WarmQueriesRequest.SerializeToString,
   }
   response_deserializers = {
     ('tabletmanagerservice.TabletManager', 'ApplySchema'): tabletmanagerdata_pb2.ApplySchemaResponse.FromString,
@@ -695,6 +710,7 @@ def beta_create_TabletManager_stub(channel, host=None, metadata_transformer=None
     ('tabletmanagerservice.TabletManager', 'TabletExternallyElected'): tabletmanagerdata_pb2.TabletExternallyElectedResponse.FromString,
     ('tabletmanagerservice.TabletManager', 'TabletExternallyReparented'): tabletmanagerdata_pb2.TabletExternallyReparentedResponse.FromString,
     ('tabletmanagerservice.TabletManager', 'WaitBlpPosition'): tabletmanagerdata_pb2.WaitBlpPositionResponse.FromString,
+    ('tabletmanagerservice.TabletManager', 'WarmQueries'): tabletmanagerdata_pb2.WarmQueriesResponse.FromString,
   }
   cardinalities = {
     'ApplySchema': cardinality.Cardinality.UNARY_UNARY,
@@ -737,6 +753,7 @@ def beta_create_TabletManager_stub(channel, host=None, metadata_transformer=None
     'TabletExternallyElected': cardinality.Cardinality.UNARY_UNARY,
     'TabletExternallyReparented': cardinality.Cardinality.UNARY_UNARY,
     'WaitBlpPosition': cardinality.Cardinality.UNARY_UNARY,
+    'WarmQueries': cardinality.Cardinality.UNARY_UNARY,
   }
   stub_options = beta_implementations.stub_options(host=host, metadata_transformer=metadata_transformer, request_serializers=request_serializers, response_deserializers=response_deserializers, thread_pool=pool, thread_pool_size=pool_size)
   return beta_implementations.dynamic_stub(channel, 'tabletmanagerservice.TabletManager', cardinalities, options=stub_options)
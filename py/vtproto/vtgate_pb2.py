@@ -14,6 +14,7 @@ _sym_db = _symbol_database.Default()
 
 
 import query_pb2 as query__pb2
+import tabletmanagerdata_pb2 as tabletmanagerdata__pb2
 import topodata_pb2 as topodata__pb2
 import vtrpc_pb2 as vtrpc__pb2
 
@@ -22,9 +23,9 @@ DESCRIPTOR = _descriptor.FileDescriptor(
   name='vtgate.proto',
   package='vtgate',
   syntax='proto3',
-  serialized_pb=_b('\n\x0cvtgate.proto\x12\x06vtgate\x1a\x0bquery.proto\x1a\x0etopodata.proto\x1a\x0bvtrpc.proto\"\x9e\x01\n\x07Session\x12\x16\n\x0ein_transaction\x18\x01 \x01(\x08\x12\x34\n\x0eshard_sessions\x18\x02 \x03(\x0b\x32\x1c.vtgate.Session.ShardSession\x1a\x45\n\x0cShardSession\x12\x1d\n\x06target\x18\x01 \x01(\x0b\x32\r.query.Target\x12\x16\n\x0etransaction_id\x18\x02 \x01(\x03\"\xd1\x01\n\x0e\x45xecuteRequest\x12\"\n\tcaller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12 \n\x07session\x18\x02 \x01(\x0b\x32\x0f.vtgate.Session\x12 \n\x05query\x18\x03 \x01(\x0b\x32\x11.query.BoundQuery\x12)\n\x0btablet_type\x18\x04 \x01(\x0e\x32\x14.topodata.TabletType\x12\x1a\n\x12not_in_transaction\x18\x05 \x01(\x08\x12\x10\n\x08keyspace\x18\x06 \x01(\t\"w\n\x0f\x45xecuteResponse\x12\x1e\n\x05\x65rror\x18\x01 \x01(\x0b\x32\x0f.vtrpc.RPCError\x12 \n\x07session\x18\x02 \x01(\x0b\x32\x0f.vtgate.Session\x12\"\n\x06result\x18\x03 \x01(\x0b\x32\x12.query.QueryResult\"\xe7\x01\n\x14\x45xecuteShardsRequest\x12\"\n\tcaller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12 \n\x07session\x18\x02 \x01(\x0b\x32\x0f.vtgate.Session\x12 \n\x05query\x18\x03 \x01(\x0b\x32\x11.query.BoundQuery\x12\x10\n\x08keyspace\x18\x04 \x01(\t\x12\x0e\n\x06shards\x18\x05 \x03(\t\x12)\n\x0btablet_type\x18\x06 \x01(\x0e\x32\x14.topodata.TabletType\x12\x1a\n\x12not_in_transaction\x18\x07 \x01(\x08\"}\n\x15\x45xecuteShardsResponse\x12\x1e\n\x05\x65rror\x18\x01 \x01(\x0b\x32\x0f.vtrpc.RPCError\x12 \n\x07session\x18\x02 \x01(\x0b\x32\x0f.vtgate.Session\x12\"\n\x06result\x18\x03 \x01(\x0b\x32\x12.query.QueryResult\"\xf2\x01\n\x19\x45xecuteKeyspaceIdsRequest\x12\"\n\tcaller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12 \n\x07session\x18\x02 \x01(\x0b\x32\x0f.vtgate.Session\x12 \n\x05query\x18\x03 \x01(\x0b\x32\x11.query.BoundQuery\x12\x10\n\x08keyspace\x18\x04 \x01(\t\x12\x14\n\x0ckeyspace_ids\x18\x05 \x03(\x0c\x12)\n\x0btablet_type\x18\x06 \x01(\x0e\x32\x14.topodata.TabletType\x12\x1a\n\x12not_in_transaction\x18\x07 \x01(\x08\"\x82\x01\n\x1a\x45xecuteKeyspaceIdsResponse\x12\x1e\n\x05\x65rror\x18\x01 \x01(\x0b\x32\x0f.vtrpc.RPCError\x12 \n\x07session\x18\x02 \x01(\x0b\x32\x0f.vtgate.Session\x12\"\n\x06result\x18\x03 \x01(\x0b\x32\x12.query.QueryResult\"\x82\x02\n\x17\x45xecuteKeyRangesRequest\x12\"\n\tcaller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12 \n\x07session\x18\x02 \x01(\x0b\x32\x0f.vtgate.Session\x12 \n\x05query\x18\x03 \x01(\x0b\x32\x11.query.BoundQuery\x12\x10\n\x08keyspace\x18\x04 \x01(\t\x12&\n\nkey_ranges\x18\x05 \x03(\x0b\x32\x12.topodata.KeyRange\x12)\n\x0btablet_type\x18\x06 \x01(\x0e\x32\x14.topodata.TabletType\x12\x1a\n\x12not_in_transaction\x18\x07 \x01(\x08\"\x80\x01\n\x18\x45xecuteKeyRangesResponse\x12\x1e\n\x05\x65rror\x18\x01 \x01(\x0b\x32\x0f.vtrpc.RPCError\x12 \n\x07session\x18\x02 \x01(\x0b\x32\x0f.vtgate.Session\x12\"\n\x06result\x18\x03 \x01(\x0b\x32\x12.query.QueryResult\"\x88\x03\n\x17\x45xecuteEntityIdsRequest\x12\"\n\tcaller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12 \n\x07session\x18\x02 \x01(\x0b\x32\x0f.vtgate.Session\x12 \n\x05query\x18\x03 \x01(\x0b\x32\x11.query.BoundQuery\x12\x10\n\x08keyspace\x18\x04 \x01(\t\x12\x1a\n\x12\x65ntity_column_name\x18\x05 \x01(\t\x12\x45\n\x13\x65ntity_keyspace_ids\x18\x06 \x03(\x0b\x32(.vtgate.ExecuteEntityIdsRequest.EntityId\x12)\n\x0btablet_type\x18\x07 \x01(\x0e\x32\x14.topodata.TabletType\x12\x1a\n\x12not_in_transaction\x18\x08 \x01(\x08\x1aI\n\x08\x45ntityId\x12\x19\n\x04type\x18\x01 \x01(\x0e\x32\x0b.query.Type\x12\r\n\x05value\x18\x02 \x01(\x0c\x12\x13\n\x0bkeyspace_id\x18\x03 \x01(\x0c\"\x80\x01\n\x18\x45xecuteEntityIdsResponse\x12\x1e\n\x05\x65rror\x18\x01 \x01(\x0b\x32\x0f.vtrpc.RPCError\x12 \n\x07session\x18\x02 \x01(\x0b\x32\x0f.vtgate.Session\x12\"\n\x06result\x18\x03 \x01(\x0b\x32\x12.query.QueryResult\"U\n\x0f\x42oundShardQuery\x12 \n\x05query\x18\x01 \x01(\x0b\x32\x11.query.BoundQuery\x12\x10\n\x08keyspace\x18\x02 \x01(\t\x12\x0e\n\x06shards\x18\x03 \x03(\t\"\xce\x01\n\x19\x45xecuteBatchShardsRequest\x12\"\n\tcaller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12 \n\x07session\x18\x02 \x01(\x0b\x32\x0f.vtgate.Session\x12(\n\x07queries\x18\x03 \x03(\x0b\x32\x17.vtgate.BoundShardQuery\x12)\n\x0btablet_type\x18\x04 \x01(\x0e\x32\x14.topodata.TabletType\x12\x16\n\x0e\x61s_transaction\x18\x05 \x01(\x08\"\x83\x01\n\x1a\x45xecuteBatchShardsResponse\x12\x1e\n\x05\x65rror\x18\x01 \x01(\x0b\x32\x0f.vtrpc.RPCError\x12 \n\x07session\x18\x02 \x01(\x0b\x32\x0f.vtgate.Session\x12#\n\x07results\x18\x03 \x03(\x0b\x32\x12.query.QueryResult\"`\n\x14\x42oundKeyspaceIdQuery\x12 \n\x05query\x18\x01 \x01(\x0b\x32\x11.query.BoundQuery\x12\x10\n\x08keyspace\x18\x02 \x01(\t\x12\x14\n\x0ckeyspace_ids\x18\x03 \x03(\x0c\"\xd8\x01\n\x1e\x45xecuteBatchKeyspaceIdsRequest\x12\"\n\tcaller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12 \n\x07session\x18\x02 \x01(\x0b\x32\x0f.vtgate.Session\x12-\n\x07queries\x18\x03 \x03(\x0b\x32\x1c.vtgate.BoundKeyspaceIdQuery\x12)\n\x0btablet_type\x18\x04 \x01(\x0e\x32\x14.topodata.TabletType\x12\x16\n\x0e\x61s_transaction\x18\x05 \x01(\x08\"\x88\x01\n\x1f\x45xecuteBatchKeyspaceIdsResponse\x12\x1e\n\x05\x65rror\x18\x01 \x01(\x0b\x32\x0f.vtrpc.RPCError\x12 \n\x07session\x18\x02 \x01(\x0b\x32\x0f.vtgate.Session\x12#\n\x07results\x18\x03 \x03(\x0b\x32\x12.query.QueryResult\"\x99\x01\n\x14StreamExecuteRequest\x12\"\n\tcaller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12 \n\x05query\x18\x02 \x01(\x0b\x32\x11.query.BoundQuery\x12)\n\x0btablet_type\x18\x03 \x01(\x0e\x32\x14.topodata.TabletType\x12\x10\n\x08keyspace\x18\x04 \x01(\t\";\n\x15StreamExecuteResponse\x12\"\n\x06result\x18\x01 \x01(\x0b\x32\x12.query.QueryResult\"\xaf\x01\n\x1aStreamExecuteShardsRequest\x12\"\n\tcaller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12 \n\x05query\x18\x02 \x01(\x0b\x32\x11.query.BoundQuery\x12\x10\n\x08keyspace\x18\x03 \x01(\t\x12\x0e\n\x06shards\x18\x04 \x03(\t\x12)\n\x0btablet_type\x18\x05 \x01(\x0e\x32\x14.topodata.TabletType\"A\n\x1bStreamExecuteShardsResponse\x12\"\n\x06result\x18\x01 \x01(\x0b\x32\x12.query.QueryResult\"\xba\x01\n\x1fStreamExecuteKeyspaceIdsRequest\x12\"\n\tcaller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12 \n\x05query\x18\x02 \x01(\x0b\x32\x11.query.BoundQuery\x12\x10\n\x08keyspace\x18\x03 \x01(\t\x12\x14\n\x0ckeyspace_ids\x18\x04 \x03(\x0c\x12)\n\x0btablet_type\x18\x05 \x01(\x0e\x32\x14.topodata.TabletType\"F\n StreamExecuteKeyspaceIdsResponse\x12\"\n\x06result\x18\x01 \x01(\x0b\x32\x12.query.QueryResult\"\xca\x01\n\x1dStreamExecuteKeyRangesRequest\x12\"\n\tcaller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12 \n\x05query\x18\x02 \x01(\x0b\x32\x11.query.BoundQuery\x12\x10\n\x08keyspace\x18\x03 \x01(\t\x12&\n\nkey_ranges\x18\x04 \x03(\x0b\x32\x12.topodata.KeyRange\x12)\n\x0btablet_type\x18\x05 \x01(\x0e\x32\x14.topodata.TabletType\"D\n\x1eStreamExecuteKeyRangesResponse\x12\"\n\x06result\x18\x01 \x01(\x0b\x32\x12.query.QueryResult\"2\n\x0c\x42\x65ginRequest\x12\"\n\tcaller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\"1\n\rBeginResponse\x12 \n\x07session\x18\x01 \x01(\x0b\x32\x0f.vtgate.Session\"U\n\rCommitRequest\x12\"\n\tcaller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12 \n\x07session\x18\x02 \x01(\x0b\x32\x0f.vtgate.Session\"\x10\n\x0e\x43ommitResponse\"W\n\x0fRollbackRequest\x12\"\n\tcaller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12 \n\x07session\x18\x02 \x01(\x0b\x32\x0f.vtgate.Session\"\x12\n\x10RollbackResponse\"\x8a\x02\n\x11SplitQueryRequest\x12\"\n\tcaller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x10\n\x08keyspace\x18\x02 \x01(\t\x12 \n\x05query\x18\x03 \x01(\x0b\x32\x11.query.BoundQuery\x12\x14\n\x0csplit_column\x18\x04 \x03(\t\x12\x13\n\x0bsplit_count\x18\x05 \x01(\x03\x12\x1f\n\x17num_rows_per_query_part\x18\x06 \x01(\x03\x12\x35\n\talgorithm\x18\x07 \x01(\x0e\x32\".query.SplitQueryRequest.Algorithm\x12\x1a\n\x12use_split_query_v2\x18\x08 \x01(\x08\"\xf2\x02\n\x12SplitQueryResponse\x12/\n\x06splits\x18\x01 \x03(\x0b\x32\x1f.vtgate.SplitQueryResponse.Part\x1aH\n\x0cKeyRangePart\x12\x10\n\x08keyspace\x18\x01 \x01(\t\x12&\n\nkey_ranges\x18\x02 \x03(\x0b\x32\x12.topodata.KeyRange\x1a-\n\tShardPart\x12\x10\n\x08keyspace\x18\x01 \x01(\t\x12\x0e\n\x06shards\x18\x02 \x03(\t\x1a\xb1\x01\n\x04Part\x12 \n\x05query\x18\x01 \x01(\x0b\x32\x11.query.BoundQuery\x12?\n\x0ekey_range_part\x18\x02 \x01(\x0b\x32\'.vtgate.SplitQueryResponse.KeyRangePart\x12\x38\n\nshard_part\x18\x03 \x01(\x0b\x32$.vtgate.SplitQueryResponse.ShardPart\x12\x0c\n\x04size\x18\x04 \x01(\x03\")\n\x15GetSrvKeyspaceRequest\x12\x10\n\x08keyspace\x18\x01 \x01(\t\"E\n\x16GetSrvKeyspaceResponse\x12+\n\x0csrv_keyspace\x18\x01 \x01(\x0b\x32\x15.topodata.SrvKeyspaceB\x1a\n\x18\x63om.youtube.vitess.protob\x06proto3')
+  serialized_pb=_b('\n\x0cvtgate.proto\x12\x06vtgate\x1a\x0bquery.proto\x1a\x17tabletmanagerdata.proto\x1a\x0etopodata.proto\x1a\x0bvtrpc.proto\"\xab\x02\n\x07Session\x12\x16\n\x0ein_transaction\x18\x01 \x01(\x08\x12\x34\n\x0eshard_sessions\x18\x02 \x03(\x0b\x32\x1c.vtgate.Session.ShardSession\x12.\n\x0bshard_gtids\x18\x03 \x03(\x0b\x32\x19.vtgate.Session.ShardGtid\x12\x1f\n\x17max_scatter_parallelism\x18\x04 \x01(\x03\x1a\x45\n\x0cShardSession\x12\x1d\n\x06target\x18\x01 \x01(\x0b\x32\r.query.Target\x12\x16\n\x0etransaction_id\x18\x02 \x01(\x03\x1a:\n\tShardGtid\x12\x10\n\x08keyspace\x18\x01 \x01(\t\x12\r\n\x05shard\x18\x02 \x01(\t\x12\x0c\n\x04gtid\x18\x03 \x01(\t\"\xd1\x01\n\x0e\x45xecuteRequest\x12\"\n\tcaller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12 \n\x07session\x18\x02 \x01(\x0b\x32\x0f.vtgate.Session\x12 \n\x05query\x18\x03 \x01(\x0b\x32\x11.query.BoundQuery\x12)\n\x0btablet_type\x18\x04 \x01(\x0e\x32\x14.topodata.TabletType\x12\x1a\n\x12not_in_transaction\x18\x05 \x01(\x08\x12\x10\n\x08keyspace\x18\x06 \x01(\t\"w\n\x0f\x45xecuteResponse\x12\x1e\n\x05\x65rror\x18\x01 \x01(\x0b\x32\x0f.vtrpc.RPCError\x12 \n\x07session\x18\x02 \x01(\x0b\x32\x0f.vtgate.Session\x12\"\n\x06result\x18\x03 \x01(\x0b\x32\x12.query.QueryResult\"\xe7\x01\n\x14\x45xecuteShardsRequest\x12\"\n\tcaller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12 \n\x07session\x18\x02 \x01(\x0b\x32\x0f.vtgate.Session\x12 \n\x05query\x18\x03 \x01(\x0b\x32\x11.query.BoundQuery\x12\x10\n\x08keyspace\x18\x04 \x01(\t\x12\x0e\n\x06shards\x18\x05 \x03(\t\x12)\n\x0btablet_type\x18\x06 \x01(\x0e\x32\x14.topodata.TabletType\x12\x1a\n\x12not_in_transaction\x18\x07 \x01(\x08\"}\n\x15\x45xecuteShardsResponse\x12\x1e\n\x05\x65rror\x18\x01 \x01(\x0b\x32\x0f.vtrpc.RPCError\x12 \n\x07session\x18\x02 \x01(\x0b\x32\x0f.vtgate.Session\x12\"\n\x06result\x18\x03 \x01(\x0b\x32\x12.query.QueryResult\"\xf2\x01\n\x19\x45xecuteKeyspaceIdsRequest\x12\"\n\tcaller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12 \n\x07session\x18\x02 \x01(\x0b\x32\x0f.vtgate.Session\x12 \n\x05query\x18\x03 \x01(\x0b\x32\x11.query.BoundQuery\x12\x10\n\x08keyspace\x18\x04 \x01(\t\x12\x14\n\x0ckeyspace_ids\x18\x05 \x03(\x0c\x12)\n\x0btablet_type\x18\x06 \x01(\x0e\x32\x14.topodata.TabletType\x12\x1a\n\x12not_in_transaction\x18\x07 \x01(\x08\"\x82\x01\n\x1a\x45xecuteKeyspaceIdsResponse\x12\x1e\n\x05\x65rror\x18\x01 \x01(\x0b\x32\x0f.vtrpc.RPCError\x12 \n\x07session\x18\x02 \x01(\x0b\x32\x0f.vtgate.Session\x12\"\n\x06result\x18\x03 \x01(\x0b\x32\x12.query.QueryResult\"\x82\x02\n\x17\x45xecuteKeyRangesRequest\x12\"\n\tcaller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12 \n\x07session\x18\x02 \x01(\x0b\x32\x0f.vtgate.Session\x12 \n\x05query\x18\x03 \x01(\x0b\x32\x11.query.BoundQuery\x12\x10\n\x08keyspace\x18\x04 \x01(\t\x12&\n\nkey_ranges\x18\x05 \x03(\x0b\x32\x12.topodata.KeyRange\x12)\n\x0btablet_type\x18\x06 \x01(\x0e\x32\x14.topodata.TabletType\x12\x1a\n\x12not_in_transaction\x18\x07 \x01(\x08\"\x80\x01\n\x18\x45xecuteKeyRangesResponse\x12\x1e\n\x05\x65rror\x18\x01 \x01(\x0b\x32\x0f.vtrpc.RPCError\x12 \n\x07session\x18\x02 \x01(\x0b\x32\x0f.vtgate.Session\x12\"\n\x06result\x18\x03 \x01(\x0b\x32\x12.query.QueryResult\"\x88\x03\n\x17\x45xecuteEntityIdsRequest\x12\"\n\tcaller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12 \n\x07session\x18\x02 \x01(\x0b\x32\x0f.vtgate.Session\x12 \n\x05query\x18\x03 \x01(\x0b\x32\x11.query.BoundQuery\x12\x10\n\x08keyspace\x18\x04 \x01(\t\x12\x1a\n\x12\x65ntity_column_name\x18\x05 \x01(\t\x12\x45\n\x13\x65ntity_keyspace_ids\x18\x06 \x03(\x0b\x32(.vtgate.ExecuteEntityIdsRequest.EntityId\x12)\n\x0btablet_type\x18\x07 \x01(\x0e\x32\x14.topodata.TabletType\x12\x1a\n\x12not_in_transaction\x18\x08 \x01(\x08\x1aI\n\x08\x45ntityId\x12\x19\n\x04type\x18\x01 \x01(\x0e\x32\x0b.query.Type\x12\r\n\x05value\x18\x02 \x01(\x0c\x12\x13\n\x0bkeyspace_id\x18\x03 \x01(\x0c\"\x80\x01\n\x18\x45xecuteEntityIdsResponse\x12\x1e\n\x05\x65rror\x18\x01 \x01(\x0b\x32\x0f.vtrpc.RPCError\x12 \n\x07session\x18\x02 \x01(\x0b\x32\x0f.vtgate.Session\x12\"\n\x06result\x18\x03 \x01(\x0b\x32\x12.query.QueryResult\"U\n\x0f\x42oundShardQuery\x12 \n\x05query\x18\x01 \x01(\x0b\x32\x11.query.BoundQuery\x12\x10\n\x08keyspace\x18\x02 \x01(\t\x12\x0e\n\x06shards\x18\x03 \x03(\t\"\xce\x01\n\x19\x45xecuteBatchShardsRequest\x12\"\n\tcaller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12 \n\x07session\x18\x02 \x01(\x0b\x32\x0f.vtgate.Session\x12(\n\x07queries\x18\x03 \x03(\x0b\x32\x17.vtgate.BoundShardQuery\x12)\n\x0btablet_type\x18\x04 \x01(\x0e\x32\x14.topodata.TabletType\x12\x16\n\x0e\x61s_transaction\x18\x05 \x01(\x08\"\x83\x01\n\x1a\x45xecuteBatchShardsResponse\x12\x1e\n\x05\x65rror\x18\x01 \x01(\x0b\x32\x0f.vtrpc.RPCError\x12 \n\x07session\x18\x02 \x01(\x0b\x32\x0f.vtgate.Session\x12#\n\x07results\x18\x03 \x03(\x0b\x32\x12.query.QueryResult\"`\n\x14\x42oundKeyspaceIdQuery\x12 \n\x05query\x18\x01 \x01(\x0b\x32\x11.query.BoundQuery\x12\x10\n\x08keyspace\x18\x02 \x01(\t\x12\x14\n\x0ckeyspace_ids\x18\x03 \x03(\x0c\"\xd8\x01\n\x1e\x45xecuteBatchKeyspaceIdsRequest\x12\"\n\tcaller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12 \n\x07session\x18\x02 \x01(\x0b\x32\x0f.vtgate.Session\x12-\n\x07queries\x18\x03 \x03(\x0b\x32\x1c.vtgate.BoundKeyspaceIdQuery\x12)\n\x0btablet_type\x18\x04 \x01(\x0e\x32\x14.topodata.TabletType\x12\x16\n\x0e\x61s_transaction\x18\x05 \x01(\x08\"\x88\x01\n\x1f\x45xecuteBatchKeyspaceIdsResponse\x12\x1e\n\x05\x65rror\x18\x01 \x01(\x0b\x32\x0f.vtrpc.RPCError\x12 \n\x07session\x18\x02 \x01(\x0b\x32\x0f.vtgate.Session\x12#\n\x07results\x18\x03 \x03(\x0b\x32\x12.query.QueryResult\"\xba\x01\n\x14StreamExecuteRequest\x12\"\n\tcaller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12 \n\x05query\x18\x02 \x01(\x0b\x32\x11.query.BoundQuery\x12)\n\x0btablet_type\x18\x03 \x01(\x0e\x32\x14.topodata.TabletType\x12\x10\n\x08keyspace\x18\x04 \x01(\t\x12\x1f\n\x17max_scatter_parallelism\x18\x05 \x01(\x03\";\n\x15StreamExecuteResponse\x12\"\n\x06result\x18\x01 \x01(\x0b\x32\x12.query.QueryResult\"\xd0\x01\n\x1aStreamExecuteShardsRequest\x12\"\n\tcaller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12 \n\x05query\x18\x02 \x01(\x0b\x32\x11.query.BoundQuery\x12\x10\n\x08keyspace\x18\x03 \x01(\t\x12\x0e\n\x06shards\x18\x04 \x03(\t\x12)\n\x0btablet_type\x18\x05 \x01(\x0e\x32\x14.topodata.TabletType\x12\x1f\n\x17max_scatter_parallelism\x18\x06 \x01(\x03\"A\n\x1bStreamExecuteShardsResponse\x12\"\n\x06result\x18\x01 \x01(\x0b\x32\x12.query.QueryResult\"\xdb\x01\n\x1fStreamExecuteKeyspaceIdsRequest\x12\"\n\tcaller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12 \n\x05query\x18\x02 \x01(\x0b\x32\x11.query.BoundQuery\x12\x10\n\x08keyspace\x18\x03 \x01(\t\x12\x14\n\x0ckeyspace_ids\x18\x04 \x03(\x0c\x12)\n\x0btablet_type\x18\x05 \x01(\x0e\x32\x14.topodata.TabletType\x12\x1f\n\x17max_scatter_parallelism\x18\x06 \x01(\x03\"F\n StreamExecuteKeyspaceIdsResponse\x12\"\n\x06result\x18\x01 \x01(\x0b\x32\x12.query.QueryResult\"\xeb\x01\n\x1dStreamExecuteKeyRangesRequest\x12\"\n\tcaller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12 \n\x05query\x18\x02 \x01(\x0b\x32\x11.query.BoundQuery\x12\x10\n\x08keyspace\x18\x03 \x01(\t\x12&\n\nkey_ranges\x18\x04 \x03(\x0b\x32\x12.topodata.KeyRange\x12)\n\x0btablet_type\x18\x05 \x01(\x0e\x32\x14.topodata.TabletType\x12\x1f\n\x17max_scatter_parallelism\x18\x06 \x01(\x03\"D\n\x1eStreamExecuteKeyRangesResponse\x12\"\n\x06result\x18\x01 \x01(\x0b\x32\x12.query.QueryResult\"2\n\x0c\x42\x65ginRequest\x12\"\n\tcaller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\"1\n\rBeginResponse\x12 \n\x07session\x18\x01 \x01(\x0b\x32\x0f.vtgate.Session\"U\n\rCommitRequest\x12\"\n\tcaller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12 \n\x07session\x18\x02 \x01(\x0b\x32\x0f.vtgate.Session\"2\n\x0e\x43ommitResponse\x12 \n\x07session\x18\x01 \x01(\x0b\x32\x0f.vtgate.Session\"W\n\x0fRollbackRequest\x12\"\n\tcaller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12 \n\x07session\x18\x02 \x01(\x0b\x32\x0f.vtgate.Session\"\x12\n\x10RollbackResponse\"\x8a\x02\n\x11SplitQueryRequest\x12\"\n\tcaller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x10\n\x08keyspace\x18\x02 \x01(\t\x12 \n\x05query\x18\x03 \x01(\x0b\x32\x11.query.BoundQuery\x12\x14\n\x0csplit_column\x18\x04 \x03(\t\x12\x13\n\x0bsplit_count\x18\x05 \x01(\x03\x12\x1f\n\x17num_rows_per_query_part\x18\x06 \x01(\x03\x12\x35\n\talgorithm\x18\x07 \x01(\x0e\x32\".query.SplitQueryRequest.Algorithm\x12\x1a\n\x12use_split_query_v2\x18\x08 \x01(\x08\"\xf2\x02\n\x12SplitQueryResponse\x12/\n\x06splits\x18\x01 \x03(\x0b\x32\x1f.vtgate.SplitQueryResponse.Part\x1aH\n\x0cKeyRangePart\x12\x10\n\x08keyspace\x18\x01 \x01(\t\x12&\n\nkey_ranges\x18\x02 \x03(\x0b\x32\x12.topodata.KeyRange\x1a-\n\tShardPart\x12\x10\n\x08keyspace\x18\x01 \x01(\t\x12\x0e\n\x06shards\x18\x02 \x03(\t\x1a\xb1\x01\n\x04Part\x12 \n\x05query\x18\x01 \x01(\x0b\x32\x11.query.BoundQuery\x12?\n\x0ekey_range_part\x18\x02 \x01(\x0b\x32\'.vtgate.SplitQueryResponse.KeyRangePart\x12\x38\n\nshard_part\x18\x03 \x01(\x0b\x32$.vtgate.SplitQueryResponse.ShardPart\x12\x0c\n\x04size\x18\x04 \x01(\x03\")\n\x15GetSrvKeyspaceRequest\x12\x10\n\x08keyspace\x18\x01 \x01(\t\"E\n\x16GetSrvKeyspaceResponse\x12+\n\x0csrv_keyspace\x18\x01 \x01(\x0b\x32\x15.topodata.SrvKeyspace\"~\n\x0ePrepareRequest\x12\"\n\tcaller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x0b\n\x03sql\x18\x02 \x01(\t\x12\x10\n\x08keyspace\x18\x03 \x01(\t\x12)\n\x0btablet_type\x18\x04 \x01(\x0e\x32\x14.topodata.TabletType\"z\n\x0fPrepareResponse\x12\x1e\n\x05\x65rror\x18\x01 \x01(\x0b\x32\x0f.vtrpc.RPCError\x12\x14\n\x0cstatement_id\x18\x02 \x01(\x03\x12\x13\n\x0bparam_count\x18\x03 \x01(\x03\x12\x1c\n\x06\x66ields\x18\x04 \x03(\x0b\x32\x0c.query.Field\"\xa6\x02\n\x16\x45xecutePreparedRequest\x12\"\n\tcaller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12 \n\x07session\x18\x02 \x01(\x0b\x32\x0f.vtgate.Session\x12\x14\n\x0cstatement_id\x18\x03 \x01(\x03\x12I\n\x0e\x62ind_variables\x18\x04 \x03(\x0b\x32\x31.vtgate.ExecutePreparedRequest.BindVariablesEntry\x12\x1a\n\x12not_in_transaction\x18\x05 \x01(\x08\x1aI\n\x12\x42indVariablesEntry\x12\x0b\n\x03key\x18\x01 \x01(\t\x12\"\n\x05value\x18\x02 \x01(\x0b\x32\x13.query.BindVariable:\x02\x38\x01\"\x7f\n\x17\x45xecutePreparedResponse\x12\x1e\n\x05\x65rror\x18\x01 \x01(\x0b\x32\x0f.vtrpc.RPCError\x12 \n\x07session\x18\x02 \x01(\x0b\x32\x0f.vtgate.Session\x12\"\n\x06result\x18\x03 \x01(\x0b\x32\x12.query.QueryResult\"Q\n\x14\x43losePreparedRequest\x12\"\n\tcaller_id\x18\x01 \x01(\x0b\x32\x0f.vtrpc.CallerID\x12\x15\n\rstatement_ids\x18\x02 \x03(\x03\"\x17\n\x15\x43losePreparedResponse\"`\n\x10MultiplexRequest\x12\x12\n\nrequest_id\x18\x01 \x01(\x04\x12\x0f\n\x07timeout\x18\x02 \x01(\x03\x12\'\n\x07\x65xecute\x18\x03 \x01(\x0b\x32\x16.vtgate.ExecuteRequest\"q\n\x11MultiplexResponse\x12\x12\n\nrequest_id\x18\x01 \x01(\x04\x12\x1e\n\x05\x65rror\x18\x02 \x01(\x0b\x32\x0f.vtrpc.RPCError\x12(\n\x07\x65xecute\x18\x03 \x01(\x0b\x32\x17.vtgate.ExecuteResponse\",\n\x18GetKeyspaceSchemaRequest\x12\x10\n\x08keyspace\x18\x01 \x01(\t\"Q\n\x0bSchemaDrift\x12\r\n\x05shard\x18\x01 \x01(\t\x12\r\n\x05table\x18\x02 \x01(\t\x12\x0f\n\x07\x63olumns\x18\x03 \x03(\t\x12\x13\n\x0b\x64\x65scription\x18\x04 \x01(\t\"\x8e\x01\n\x19GetKeyspaceSchemaResponse\x12\x17\n\x0freference_shard\x18\x01 \x01(\t\x12\x33\n\x06schema\x18\x02 \x01(\x0b\x32#.tabletmanagerdata.SchemaDefinition\x12#\n\x06\x64rifts\x18\x03 \x03(\x0b\x32\x13.vtgate.SchemaDriftB\x1a\n\x18\x63om.youtube.vitess.protob\x06proto3')
   ,
-  dependencies=[query__pb2.DESCRIPTOR,topodata__pb2.DESCRIPTOR,vtrpc__pb2.DESCRIPTOR,])
+  dependencies=[query__pb2.DESCRIPTOR,tabletmanagerdata__pb2.DESCRIPTOR,topodata__pb2.DESCRIPTOR,vtrpc__pb2.DESCRIPTOR,])
 _sym_db.RegisterFileDescriptor(DESCRIPTOR)
 
 
@@ -63,8 +64,52 @@ _SESSION_SHARDSESSION = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=156,
-  serialized_end=225,
+  serialized_start=262,
+  serialized_end=331,
+)
+
+_SESSION_SHARDGTID = _descriptor.Descriptor(
+  name='ShardGtid',
+  full_name='vtgate.Session.ShardGtid',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='keyspace', full_name='vtgate.Session.ShardGtid.keyspace', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='shard', full_name='vtgate.Session.ShardGtid.shard', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='gtid', full_name='vtgate.Session.ShardGtid.gtid', index=2,
+      number=3, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=333,
+  serialized_end=391,
 )
 
 _SESSION = _descriptor.Descriptor(
@@ -88,10 +133,24 @@ _SESSION = _descriptor.Descriptor(
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
+    _descriptor.FieldDescriptor(
+      name='shard_gtids', full_name='vtgate.Session.shard_gtids', index=2,
+      number=3, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='max_scatter_parallelism', full_name='vtgate.Session.max_scatter_parallelism', index=3,
+      number=4, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
   ],
   extensions=[
   ],
-  nested_types=[_SESSION_SHARDSESSION, ],
+  nested_types=[_SESSION_SHARDSESSION, _SESSION_SHARDGTID, ],
   enum_types=[
   ],
   options=None,
@@ -100,8 +159,8 @@ _SESSION = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=67,
-  serialized_end=225,
+  serialized_start=92,
+  serialized_end=391,
 )
 
 
@@ -166,8 +225,8 @@ _EXECUTEREQUEST = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=228,
-  serialized_end=437,
+  serialized_start=394,
+  serialized_end=603,
 )
 
 
@@ -211,8 +270,8 @@ _EXECUTERESPONSE = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=439,
-  serialized_end=558,
+  serialized_start=605,
+  serialized_end=724,
 )
 
 
@@ -284,8 +343,8 @@ _EXECUTESHARDSREQUEST = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=561,
-  serialized_end=792,
+  serialized_start=727,
+  serialized_end=958,
 )
 
 
@@ -329,8 +388,8 @@ _EXECUTESHARDSRESPONSE = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=794,
-  serialized_end=919,
+  serialized_start=960,
+  serialized_end=1085,
 )
 
 
@@ -402,8 +461,8 @@ _EXECUTEKEYSPACEIDSREQUEST = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=922,
-  serialized_end=1164,
+  serialized_start=1088,
+  serialized_end=1330,
 )
 
 
@@ -447,8 +506,8 @@ _EXECUTEKEYSPACEIDSRESPONSE = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1167,
-  serialized_end=1297,
+  serialized_start=1333,
+  serialized_end=1463,
 )
 
 
@@ -520,8 +579,8 @@ _EXECUTEKEYRANGESREQUEST = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1300,
-  serialized_end=1558,
+  serialized_start=1466,
+  serialized_end=1724,
 )
 
 
@@ -565,8 +624,8 @@ _EXECUTEKEYRANGESRESPONSE = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1561,
-  serialized_end=1689,
+  serialized_start=1727,
+  serialized_end=1855,
 )
 
 
@@ -610,8 +669,8 @@ _EXECUTEENTITYIDSREQUEST_ENTITYID = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=2011,
-  serialized_end=2084,
+  serialized_start=2177,
+  serialized_end=2250,
 )
 
 _EXECUTEENTITYIDSREQUEST = _descriptor.Descriptor(
@@ -689,8 +748,8 @@ _EXECUTEENTITYIDSREQUEST = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=1692,
-  serialized_end=2084,
+  serialized_start=1858,
+  serialized_end=2250,
 )
 
 
@@ -734,8 +793,8 @@ _EXECUTEENTITYIDSRESPONSE = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=2087,
-  serialized_end=2215,
+  serialized_start=2253,
+  serialized_end=2381,
 )
 
 
@@ -779,8 +838,8 @@ _BOUNDSHARDQUERY = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=2217,
-  serialized_end=2302,
+  serialized_start=2383,
+  serialized_end=2468,
 )
 
 
@@ -838,8 +897,8 @@ _EXECUTEBATCHSHARDSREQUEST = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=2305,
-  serialized_end=2511,
+  serialized_start=2471,
+  serialized_end=2677,
 )
 
 
@@ -883,8 +942,8 @@ _EXECUTEBATCHSHARDSRESPONSE = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=2514,
-  serialized_end=2645,
+  serialized_start=2680,
+  serialized_end=2811,
 )
 
 
@@ -928,8 +987,8 @@ _BOUNDKEYSPACEIDQUERY = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=2647,
-  serialized_end=2743,
+  serialized_start=2813,
+  serialized_end=2909,
 )
 
 
@@ -987,8 +1046,8 @@ _EXECUTEBATCHKEYSPACEIDSREQUEST = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=2746,
-  serialized_end=2962,
+  serialized_start=2912,
+  serialized_end=3128,
 )
 
 
@@ -1032,8 +1091,8 @@ _EXECUTEBATCHKEYSPACEIDSRESPONSE = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=2965,
-  serialized_end=3101,
+  serialized_start=3131,
+  serialized_end=3267,
 )
 
 
@@ -1072,6 +1131,13 @@ _STREAMEXECUTEREQUEST = _descriptor.Descriptor(
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
+    _descriptor.FieldDescriptor(
+      name='max_scatter_parallelism', full_name='vtgate.StreamExecuteRequest.max_scatter_parallelism', index=4,
+      number=5, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
   ],
   extensions=[
   ],
@@ -1084,8 +1150,8 @@ _STREAMEXECUTEREQUEST = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=3104,
-  serialized_end=3257,
+  serialized_start=3270,
+  serialized_end=3456,
 )
 
 
@@ -1115,8 +1181,8 @@ _STREAMEXECUTERESPONSE = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=3259,
-  serialized_end=3318,
+  serialized_start=3458,
+  serialized_end=3517,
 )
 
 
@@ -1162,6 +1228,13 @@ _STREAMEXECUTESHARDSREQUEST = _descriptor.Descriptor(
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
+    _descriptor.FieldDescriptor(
+      name='max_scatter_parallelism', full_name='vtgate.StreamExecuteShardsRequest.max_scatter_parallelism', index=5,
+      number=6, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
   ],
   extensions=[
   ],
@@ -1174,8 +1247,8 @@ _STREAMEXECUTESHARDSREQUEST = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=3321,
-  serialized_end=3496,
+  serialized_start=3520,
+  serialized_end=3728,
 )
 
 
@@ -1205,8 +1278,8 @@ _STREAMEXECUTESHARDSRESPONSE = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=3498,
-  serialized_end=3563,
+  serialized_start=3730,
+  serialized_end=3795,
 )
 
 
@@ -1252,6 +1325,13 @@ _STREAMEXECUTEKEYSPACEIDSREQUEST = _descriptor.Descriptor(
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
+    _descriptor.FieldDescriptor(
+      name='max_scatter_parallelism', full_name='vtgate.StreamExecuteKeyspaceIdsRequest.max_scatter_parallelism', index=5,
+      number=6, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
   ],
   extensions=[
   ],
@@ -1264,8 +1344,8 @@ _STREAMEXECUTEKEYSPACEIDSREQUEST = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=3566,
-  serialized_end=3752,
+  serialized_start=3798,
+  serialized_end=4017,
 )
 
 
@@ -1295,8 +1375,8 @@ _STREAMEXECUTEKEYSPACEIDSRESPONSE = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=3754,
-  serialized_end=3824,
+  serialized_start=4019,
+  serialized_end=4089,
 )
 
 
@@ -1342,6 +1422,13 @@ _STREAMEXECUTEKEYRANGESREQUEST = _descriptor.Descriptor(
       message_type=None, enum_type=None, containing_type=None,
       is_extension=False, extension_scope=None,
       options=None),
+    _descriptor.FieldDescriptor(
+      name='max_scatter_parallelism', full_name='vtgate.StreamExecuteKeyRangesRequest.max_scatter_parallelism', index=5,
+      number=6, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
   ],
   extensions=[
   ],
@@ -1354,8 +1441,8 @@ _STREAMEXECUTEKEYRANGESREQUEST = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=3827,
-  serialized_end=4029,
+  serialized_start=4092,
+  serialized_end=4327,
 )
 
 
@@ -1385,8 +1472,8 @@ _STREAMEXECUTEKEYRANGESRESPONSE = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=4031,
-  serialized_end=4099,
+  serialized_start=4329,
+  serialized_end=4397,
 )
 
 
@@ -1416,8 +1503,8 @@ _BEGINREQUEST = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=4101,
-  serialized_end=4151,
+  serialized_start=4399,
+  serialized_end=4449,
 )
 
 
@@ -1447,8 +1534,8 @@ _BEGINRESPONSE = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=4153,
-  serialized_end=4202,
+  serialized_start=4451,
+  serialized_end=4500,
 )
 
 
@@ -1485,8 +1572,8 @@ _COMMITREQUEST = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=4204,
-  serialized_end=4289,
+  serialized_start=4502,
+  serialized_end=4587,
 )
 
 
@@ -1497,6 +1584,13 @@ _COMMITRESPONSE = _descriptor.Descriptor(
   file=DESCRIPTOR,
   containing_type=None,
   fields=[
+    _descriptor.FieldDescriptor(
+      name='session', full_name='vtgate.CommitResponse.session', index=0,
+      number=1, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
   ],
   extensions=[
   ],
@@ -1509,8 +1603,8 @@ _COMMITRESPONSE = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=4291,
-  serialized_end=4307,
+  serialized_start=4589,
+  serialized_end=4639,
 )
 
 
@@ -1547,8 +1641,8 @@ _ROLLBACKREQUEST = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=4309,
-  serialized_end=4396,
+  serialized_start=4641,
+  serialized_end=4728,
 )
 
 
@@ -1571,8 +1665,8 @@ _ROLLBACKRESPONSE = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=4398,
-  serialized_end=4416,
+  serialized_start=4730,
+  serialized_end=4748,
 )
 
 
@@ -1651,8 +1745,8 @@ _SPLITQUERYREQUEST = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=4419,
-  serialized_end=4685,
+  serialized_start=4751,
+  serialized_end=5017,
 )
 
 
@@ -1689,8 +1783,8 @@ _SPLITQUERYRESPONSE_KEYRANGEPART = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=4759,
-  serialized_end=4831,
+  serialized_start=5091,
+  serialized_end=5163,
 )
 
 _SPLITQUERYRESPONSE_SHARDPART = _descriptor.Descriptor(
@@ -1726,8 +1820,8 @@ _SPLITQUERYRESPONSE_SHARDPART = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=4833,
-  serialized_end=4878,
+  serialized_start=5165,
+  serialized_end=5210,
 )
 
 _SPLITQUERYRESPONSE_PART = _descriptor.Descriptor(
@@ -1777,8 +1871,8 @@ _SPLITQUERYRESPONSE_PART = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=4881,
-  serialized_end=5058,
+  serialized_start=5213,
+  serialized_end=5390,
 )
 
 _SPLITQUERYRESPONSE = _descriptor.Descriptor(
@@ -1807,8 +1901,8 @@ _SPLITQUERYRESPONSE = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=4688,
-  serialized_end=5058,
+  serialized_start=5020,
+  serialized_end=5390,
 )
 
 
@@ -1838,8 +1932,8 @@ _GETSRVKEYSPACEREQUEST = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=5060,
-  serialized_end=5101,
+  serialized_start=5392,
+  serialized_end=5433,
 )
 
 
@@ -1869,153 +1963,718 @@ _GETSRVKEYSPACERESPONSE = _descriptor.Descriptor(
   extension_ranges=[],
   oneofs=[
   ],
-  serialized_start=5103,
-  serialized_end=5172,
+  serialized_start=5435,
+  serialized_end=5504,
 )
 
-_SESSION_SHARDSESSION.fields_by_name['target'].message_type = query__pb2._TARGET
-_SESSION_SHARDSESSION.containing_type = _SESSION
-_SESSION.fields_by_name['shard_sessions'].message_type = _SESSION_SHARDSESSION
-_EXECUTEREQUEST.fields_by_name['caller_id'].message_type = vtrpc__pb2._CALLERID
-_EXECUTEREQUEST.fields_by_name['session'].message_type = _SESSION
-_EXECUTEREQUEST.fields_by_name['query'].message_type = query__pb2._BOUNDQUERY
-_EXECUTEREQUEST.fields_by_name['tablet_type'].enum_type = topodata__pb2._TABLETTYPE
-_EXECUTERESPONSE.fields_by_name['error'].message_type = vtrpc__pb2._RPCERROR
-_EXECUTERESPONSE.fields_by_name['session'].message_type = _SESSION
-_EXECUTERESPONSE.fields_by_name['result'].message_type = query__pb2._QUERYRESULT
-_EXECUTESHARDSREQUEST.fields_by_name['caller_id'].message_type = vtrpc__pb2._CALLERID
-_EXECUTESHARDSREQUEST.fields_by_name['session'].message_type = _SESSION
-_EXECUTESHARDSREQUEST.fields_by_name['query'].message_type = query__pb2._BOUNDQUERY
-_EXECUTESHARDSREQUEST.fields_by_name['tablet_type'].enum_type = topodata__pb2._TABLETTYPE
-_EXECUTESHARDSRESPONSE.fields_by_name['error'].message_type = vtrpc__pb2._RPCERROR
-_EXECUTESHARDSRESPONSE.fields_by_name['session'].message_type = _SESSION
-_EXECUTESHARDSRESPONSE.fields_by_name['result'].message_type = query__pb2._QUERYRESULT
-_EXECUTEKEYSPACEIDSREQUEST.fields_by_name['caller_id'].message_type = vtrpc__pb2._CALLERID
-_EXECUTEKEYSPACEIDSREQUEST.fields_by_name['session'].message_type = _SESSION
-_EXECUTEKEYSPACEIDSREQUEST.fields_by_name['query'].message_type = query__pb2._BOUNDQUERY
-_EXECUTEKEYSPACEIDSREQUEST.fields_by_name['tablet_type'].enum_type = topodata__pb2._TABLETTYPE
-_EXECUTEKEYSPACEIDSRESPONSE.fields_by_name['error'].message_type = vtrpc__pb2._RPCERROR
-_EXECUTEKEYSPACEIDSRESPONSE.fields_by_name['session'].message_type = _SESSION
-_EXECUTEKEYSPACEIDSRESPONSE.fields_by_name['result'].message_type = query__pb2._QUERYRESULT
-_EXECUTEKEYRANGESREQUEST.fields_by_name['caller_id'].message_type = vtrpc__pb2._CALLERID
-_EXECUTEKEYRANGESREQUEST.fields_by_name['session'].message_type = _SESSION
-_EXECUTEKEYRANGESREQUEST.fields_by_name['query'].message_type = query__pb2._BOUNDQUERY
-_EXECUTEKEYRANGESREQUEST.fields_by_name['key_ranges'].message_type = topodata__pb2._KEYRANGE
-_EXECUTEKEYRANGESREQUEST.fields_by_name['tablet_type'].enum_type = topodata__pb2._TABLETTYPE
-_EXECUTEKEYRANGESRESPONSE.fields_by_name['error'].message_type = vtrpc__pb2._RPCERROR
-_EXECUTEKEYRANGESRESPONSE.fields_by_name['session'].message_type = _SESSION
-_EXECUTEKEYRANGESRESPONSE.fields_by_name['result'].message_type = query__pb2._QUERYRESULT
-_EXECUTEENTITYIDSREQUEST_ENTITYID.fields_by_name['type'].enum_type = query__pb2._TYPE
-_EXECUTEENTITYIDSREQUEST_ENTITYID.containing_type = _EXECUTEENTITYIDSREQUEST
-_EXECUTEENTITYIDSREQUEST.fields_by_name['caller_id'].message_type = vtrpc__pb2._CALLERID
-_EXECUTEENTITYIDSREQUEST.fields_by_name['session'].message_type = _SESSION
-_EXECUTEENTITYIDSREQUEST.fields_by_name['query'].message_type = query__pb2._BOUNDQUERY
-_EXECUTEENTITYIDSREQUEST.fields_by_name['entity_keyspace_ids'].message_type = _EXECUTEENTITYIDSREQUEST_ENTITYID
-_EXECUTEENTITYIDSREQUEST.fields_by_name['tablet_type'].enum_type = topodata__pb2._TABLETTYPE
-_EXECUTEENTITYIDSRESPONSE.fields_by_name['error'].message_type = vtrpc__pb2._RPCERROR
-_EXECUTEENTITYIDSRESPONSE.fields_by_name['session'].message_type = _SESSION
-_EXECUTEENTITYIDSRESPONSE.fields_by_name['result'].message_type = query__pb2._QUERYRESULT
-_BOUNDSHARDQUERY.fields_by_name['query'].message_type = query__pb2._BOUNDQUERY
-_EXECUTEBATCHSHARDSREQUEST.fields_by_name['caller_id'].message_type = vtrpc__pb2._CALLERID
-_EXECUTEBATCHSHARDSREQUEST.fields_by_name['session'].message_type = _SESSION
-_EXECUTEBATCHSHARDSREQUEST.fields_by_name['queries'].message_type = _BOUNDSHARDQUERY
-_EXECUTEBATCHSHARDSREQUEST.fields_by_name['tablet_type'].enum_type = topodata__pb2._TABLETTYPE
-_EXECUTEBATCHSHARDSRESPONSE.fields_by_name['error'].message_type = vtrpc__pb2._RPCERROR
-_EXECUTEBATCHSHARDSRESPONSE.fields_by_name['session'].message_type = _SESSION
-_EXECUTEBATCHSHARDSRESPONSE.fields_by_name['results'].message_type = query__pb2._QUERYRESULT
-_BOUNDKEYSPACEIDQUERY.fields_by_name['query'].message_type = query__pb2._BOUNDQUERY
-_EXECUTEBATCHKEYSPACEIDSREQUEST.fields_by_name['caller_id'].message_type = vtrpc__pb2._CALLERID
-_EXECUTEBATCHKEYSPACEIDSREQUEST.fields_by_name['session'].message_type = _SESSION
-_EXECUTEBATCHKEYSPACEIDSREQUEST.fields_by_name['queries'].message_type = _BOUNDKEYSPACEIDQUERY
-_EXECUTEBATCHKEYSPACEIDSREQUEST.fields_by_name['tablet_type'].enum_type = topodata__pb2._TABLETTYPE
-_EXECUTEBATCHKEYSPACEIDSRESPONSE.fields_by_name['error'].message_type = vtrpc__pb2._RPCERROR
-_EXECUTEBATCHKEYSPACEIDSRESPONSE.fields_by_name['session'].message_type = _SESSION
-_EXECUTEBATCHKEYSPACEIDSRESPONSE.fields_by_name['results'].message_type = query__pb2._QUERYRESULT
-_STREAMEXECUTEREQUEST.fields_by_name['caller_id'].message_type = vtrpc__pb2._CALLERID
-_STREAMEXECUTEREQUEST.fields_by_name['query'].message_type = query__pb2._BOUNDQUERY
-_STREAMEXECUTEREQUEST.fields_by_name['tablet_type'].enum_type = topodata__pb2._TABLETTYPE
-_STREAMEXECUTERESPONSE.fields_by_name['result'].message_type = query__pb2._QUERYRESULT
-_STREAMEXECUTESHARDSREQUEST.fields_by_name['caller_id'].message_type = vtrpc__pb2._CALLERID
-_STREAMEXECUTESHARDSREQUEST.fields_by_name['query'].message_type = query__pb2._BOUNDQUERY
-_STREAMEXECUTESHARDSREQUEST.fields_by_name['tablet_type'].enum_type = topodata__pb2._TABLETTYPE
-_STREAMEXECUTESHARDSRESPONSE.fields_by_name['result'].message_type = query__pb2._QUERYRESULT
-_STREAMEXECUTEKEYSPACEIDSREQUEST.fields_by_name['caller_id'].message_type = vtrpc__pb2._CALLERID
-_STREAMEXECUTEKEYSPACEIDSREQUEST.fields_by_name['query'].message_type = query__pb2._BOUNDQUERY
-_STREAMEXECUTEKEYSPACEIDSREQUEST.fields_by_name['tablet_type'].enum_type = topodata__pb2._TABLETTYPE
-_STREAMEXECUTEKEYSPACEIDSRESPONSE.fields_by_name['result'].message_type = query__pb2._QUERYRESULT
-_STREAMEXECUTEKEYRANGESREQUEST.fields_by_name['caller_id'].message_type = vtrpc__pb2._CALLERID
-_STREAMEXECUTEKEYRANGESREQUEST.fields_by_name['query'].message_type = query__pb2._BOUNDQUERY
-_STREAMEXECUTEKEYRANGESREQUEST.fields_by_name['key_ranges'].message_type = topodata__pb2._KEYRANGE
-_STREAMEXECUTEKEYRANGESREQUEST.fields_by_name['tablet_type'].enum_type = topodata__pb2._TABLETTYPE
-_STREAMEXECUTEKEYRANGESRESPONSE.fields_by_name['result'].message_type = query__pb2._QUERYRESULT
-_BEGINREQUEST.fields_by_name['caller_id'].message_type = vtrpc__pb2._CALLERID
-_BEGINRESPONSE.fields_by_name['session'].message_type = _SESSION
-_COMMITREQUEST.fields_by_name['caller_id'].message_type = vtrpc__pb2._CALLERID
-_COMMITREQUEST.fields_by_name['session'].message_type = _SESSION
-_ROLLBACKREQUEST.fields_by_name['caller_id'].message_type = vtrpc__pb2._CALLERID
-_ROLLBACKREQUEST.fields_by_name['session'].message_type = _SESSION
-_SPLITQUERYREQUEST.fields_by_name['caller_id'].message_type = vtrpc__pb2._CALLERID
-_SPLITQUERYREQUEST.fields_by_name['query'].message_type = query__pb2._BOUNDQUERY
-_SPLITQUERYREQUEST.fields_by_name['algorithm'].enum_type = query__pb2._SPLITQUERYREQUEST_ALGORITHM
-_SPLITQUERYRESPONSE_KEYRANGEPART.fields_by_name['key_ranges'].message_type = topodata__pb2._KEYRANGE
-_SPLITQUERYRESPONSE_KEYRANGEPART.containing_type = _SPLITQUERYRESPONSE
-_SPLITQUERYRESPONSE_SHARDPART.containing_type = _SPLITQUERYRESPONSE
-_SPLITQUERYRESPONSE_PART.fields_by_name['query'].message_type = query__pb2._BOUNDQUERY
-_SPLITQUERYRESPONSE_PART.fields_by_name['key_range_part'].message_type = _SPLITQUERYRESPONSE_KEYRANGEPART
-_SPLITQUERYRESPONSE_PART.fields_by_name['shard_part'].message_type = _SPLITQUERYRESPONSE_SHARDPART
-_SPLITQUERYRESPONSE_PART.containing_type = _SPLITQUERYRESPONSE
-_SPLITQUERYRESPONSE.fields_by_name['splits'].message_type = _SPLITQUERYRESPONSE_PART
-_GETSRVKEYSPACERESPONSE.fields_by_name['srv_keyspace'].message_type = topodata__pb2._SRVKEYSPACE
-DESCRIPTOR.message_types_by_name['Session'] = _SESSION
-DESCRIPTOR.message_types_by_name['ExecuteRequest'] = _EXECUTEREQUEST
-DESCRIPTOR.message_types_by_name['ExecuteResponse'] = _EXECUTERESPONSE
-DESCRIPTOR.message_types_by_name['ExecuteShardsRequest'] = _EXECUTESHARDSREQUEST
-DESCRIPTOR.message_types_by_name['ExecuteShardsResponse'] = _EXECUTESHARDSRESPONSE
-DESCRIPTOR.message_types_by_name['ExecuteKeyspaceIdsRequest'] = _EXECUTEKEYSPACEIDSREQUEST
-DESCRIPTOR.message_types_by_name['ExecuteKeyspaceIdsResponse'] = _EXECUTEKEYSPACEIDSRESPONSE
-DESCRIPTOR.message_types_by_name['ExecuteKeyRangesRequest'] = _EXECUTEKEYRANGESREQUEST
-DESCRIPTOR.message_types_by_name['ExecuteKeyRangesResponse'] = _EXECUTEKEYRANGESRESPONSE
-DESCRIPTOR.message_types_by_name['ExecuteEntityIdsRequest'] = _EXECUTEENTITYIDSREQUEST
-DESCRIPTOR.message_types_by_name['ExecuteEntityIdsResponse'] = _EXECUTEENTITYIDSRESPONSE
-DESCRIPTOR.message_types_by_name['BoundShardQuery'] = _BOUNDSHARDQUERY
-DESCRIPTOR.message_types_by_name['ExecuteBatchShardsRequest'] = _EXECUTEBATCHSHARDSREQUEST
-DESCRIPTOR.message_types_by_name['ExecuteBatchShardsResponse'] = _EXECUTEBATCHSHARDSRESPONSE
-DESCRIPTOR.message_types_by_name['BoundKeyspaceIdQuery'] = _BOUNDKEYSPACEIDQUERY
-DESCRIPTOR.message_types_by_name['ExecuteBatchKeyspaceIdsRequest'] = _EXECUTEBATCHKEYSPACEIDSREQUEST
-DESCRIPTOR.message_types_by_name['ExecuteBatchKeyspaceIdsResponse'] = _EXECUTEBATCHKEYSPACEIDSRESPONSE
-DESCRIPTOR.message_types_by_name['StreamExecuteRequest'] = _STREAMEXECUTEREQUEST
-DESCRIPTOR.message_types_by_name['StreamExecuteResponse'] = _STREAMEXECUTERESPONSE
-DESCRIPTOR.message_types_by_name['StreamExecuteShardsRequest'] = _STREAMEXECUTESHARDSREQUEST
-DESCRIPTOR.message_types_by_name['StreamExecuteShardsResponse'] = _STREAMEXECUTESHARDSRESPONSE
-DESCRIPTOR.message_types_by_name['StreamExecuteKeyspaceIdsRequest'] = _STREAMEXECUTEKEYSPACEIDSREQUEST
-DESCRIPTOR.message_types_by_name['StreamExecuteKeyspaceIdsResponse'] = _STREAMEXECUTEKEYSPACEIDSRESPONSE
-DESCRIPTOR.message_types_by_name['StreamExecuteKeyRangesRequest'] = _STREAMEXECUTEKEYRANGESREQUEST
-DESCRIPTOR.message_types_by_name['StreamExecuteKeyRangesResponse'] = _STREAMEXECUTEKEYRANGESRESPONSE
-DESCRIPTOR.message_types_by_name['BeginRequest'] = _BEGINREQUEST
-DESCRIPTOR.message_types_by_name['BeginResponse'] = _BEGINRESPONSE
-DESCRIPTOR.message_types_by_name['CommitRequest'] = _COMMITREQUEST
-DESCRIPTOR.message_types_by_name['CommitResponse'] = _COMMITRESPONSE
-DESCRIPTOR.message_types_by_name['RollbackRequest'] = _ROLLBACKREQUEST
-DESCRIPTOR.message_types_by_name['RollbackResponse'] = _ROLLBACKRESPONSE
-DESCRIPTOR.message_types_by_name['SplitQueryRequest'] = _SPLITQUERYREQUEST
-DESCRIPTOR.message_types_by_name['SplitQueryResponse'] = _SPLITQUERYRESPONSE
-DESCRIPTOR.message_types_by_name['GetSrvKeyspaceRequest'] = _GETSRVKEYSPACEREQUEST
-DESCRIPTOR.message_types_by_name['GetSrvKeyspaceResponse'] = _GETSRVKEYSPACERESPONSE
 
-Session = _reflection.GeneratedProtocolMessageType('Session', (_message.Message,), dict(
+_PREPAREREQUEST = _descriptor.Descriptor(
+  name='PrepareRequest',
+  full_name='vtgate.PrepareRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='caller_id', full_name='vtgate.PrepareRequest.caller_id', index=0,
+      number=1, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='sql', full_name='vtgate.PrepareRequest.sql', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='keyspace', full_name='vtgate.PrepareRequest.keyspace', index=2,
+      number=3, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='tablet_type', full_name='vtgate.PrepareRequest.tablet_type', index=3,
+      number=4, type=14, cpp_type=8, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=5506,
+  serialized_end=5632,
+)
 
-  ShardSession = _reflection.GeneratedProtocolMessageType('ShardSession', (_message.Message,), dict(
-    DESCRIPTOR = _SESSION_SHARDSESSION,
-    __module__ = 'vtgate_pb2'
-    # @@protoc_insertion_point(class_scope:vtgate.Session.ShardSession)
-    ))
-  ,
-  DESCRIPTOR = _SESSION,
-  __module__ = 'vtgate_pb2'
+
+_PREPARERESPONSE = _descriptor.Descriptor(
+  name='PrepareResponse',
+  full_name='vtgate.PrepareResponse',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='error', full_name='vtgate.PrepareResponse.error', index=0,
+      number=1, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='statement_id', full_name='vtgate.PrepareResponse.statement_id', index=1,
+      number=2, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='param_count', full_name='vtgate.PrepareResponse.param_count', index=2,
+      number=3, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='fields', full_name='vtgate.PrepareResponse.fields', index=3,
+      number=4, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=5634,
+  serialized_end=5756,
+)
+
+
+_EXECUTEPREPAREDREQUEST_BINDVARIABLESENTRY = _descriptor.Descriptor(
+  name='BindVariablesEntry',
+  full_name='vtgate.ExecutePreparedRequest.BindVariablesEntry',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='key', full_name='vtgate.ExecutePreparedRequest.BindVariablesEntry.key', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='value', full_name='vtgate.ExecutePreparedRequest.BindVariablesEntry.value', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=_descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001')),
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=5980,
+  serialized_end=6053,
+)
+
+_EXECUTEPREPAREDREQUEST = _descriptor.Descriptor(
+  name='ExecutePreparedRequest',
+  full_name='vtgate.ExecutePreparedRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='caller_id', full_name='vtgate.ExecutePreparedRequest.caller_id', index=0,
+      number=1, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='session', full_name='vtgate.ExecutePreparedRequest.session', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='statement_id', full_name='vtgate.ExecutePreparedRequest.statement_id', index=2,
+      number=3, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='bind_variables', full_name='vtgate.ExecutePreparedRequest.bind_variables', index=3,
+      number=4, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='not_in_transaction', full_name='vtgate.ExecutePreparedRequest.not_in_transaction', index=4,
+      number=5, type=8, cpp_type=7, label=1,
+      has_default_value=False, default_value=False,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[_EXECUTEPREPAREDREQUEST_BINDVARIABLESENTRY, ],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=5759,
+  serialized_end=6053,
+)
+
+
+_EXECUTEPREPAREDRESPONSE = _descriptor.Descriptor(
+  name='ExecutePreparedResponse',
+  full_name='vtgate.ExecutePreparedResponse',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='error', full_name='vtgate.ExecutePreparedResponse.error', index=0,
+      number=1, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='session', full_name='vtgate.ExecutePreparedResponse.session', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='result', full_name='vtgate.ExecutePreparedResponse.result', index=2,
+      number=3, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=6055,
+  serialized_end=6182,
+)
+
+
+_CLOSEPREPAREDREQUEST = _descriptor.Descriptor(
+  name='ClosePreparedRequest',
+  full_name='vtgate.ClosePreparedRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='caller_id', full_name='vtgate.ClosePreparedRequest.caller_id', index=0,
+      number=1, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='statement_ids', full_name='vtgate.ClosePreparedRequest.statement_ids', index=1,
+      number=2, type=3, cpp_type=2, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=6184,
+  serialized_end=6265,
+)
+
+
+_CLOSEPREPAREDRESPONSE = _descriptor.Descriptor(
+  name='ClosePreparedResponse',
+  full_name='vtgate.ClosePreparedResponse',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=6267,
+  serialized_end=6290,
+)
+
+
+_MULTIPLEXREQUEST = _descriptor.Descriptor(
+  name='MultiplexRequest',
+  full_name='vtgate.MultiplexRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='request_id', full_name='vtgate.MultiplexRequest.request_id', index=0,
+      number=1, type=4, cpp_type=4, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='timeout', full_name='vtgate.MultiplexRequest.timeout', index=1,
+      number=2, type=3, cpp_type=2, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='execute', full_name='vtgate.MultiplexRequest.execute', index=2,
+      number=3, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=6292,
+  serialized_end=6388,
+)
+
+
+_MULTIPLEXRESPONSE = _descriptor.Descriptor(
+  name='MultiplexResponse',
+  full_name='vtgate.MultiplexResponse',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='request_id', full_name='vtgate.MultiplexResponse.request_id', index=0,
+      number=1, type=4, cpp_type=4, label=1,
+      has_default_value=False, default_value=0,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='error', full_name='vtgate.MultiplexResponse.error', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='execute', full_name='vtgate.MultiplexResponse.execute', index=2,
+      number=3, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=6390,
+  serialized_end=6503,
+)
+
+
+_GETKEYSPACESCHEMAREQUEST = _descriptor.Descriptor(
+  name='GetKeyspaceSchemaRequest',
+  full_name='vtgate.GetKeyspaceSchemaRequest',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='keyspace', full_name='vtgate.GetKeyspaceSchemaRequest.keyspace', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=6505,
+  serialized_end=6549,
+)
+
+
+_SCHEMADRIFT = _descriptor.Descriptor(
+  name='SchemaDrift',
+  full_name='vtgate.SchemaDrift',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='shard', full_name='vtgate.SchemaDrift.shard', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='table', full_name='vtgate.SchemaDrift.table', index=1,
+      number=2, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='columns', full_name='vtgate.SchemaDrift.columns', index=2,
+      number=3, type=9, cpp_type=9, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='description', full_name='vtgate.SchemaDrift.description', index=3,
+      number=4, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=6551,
+  serialized_end=6632,
+)
+
+
+_GETKEYSPACESCHEMARESPONSE = _descriptor.Descriptor(
+  name='GetKeyspaceSchemaResponse',
+  full_name='vtgate.GetKeyspaceSchemaResponse',
+  filename=None,
+  file=DESCRIPTOR,
+  containing_type=None,
+  fields=[
+    _descriptor.FieldDescriptor(
+      name='reference_shard', full_name='vtgate.GetKeyspaceSchemaResponse.reference_shard', index=0,
+      number=1, type=9, cpp_type=9, label=1,
+      has_default_value=False, default_value=_b("").decode('utf-8'),
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='schema', full_name='vtgate.GetKeyspaceSchemaResponse.schema', index=1,
+      number=2, type=11, cpp_type=10, label=1,
+      has_default_value=False, default_value=None,
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+    _descriptor.FieldDescriptor(
+      name='drifts', full_name='vtgate.GetKeyspaceSchemaResponse.drifts', index=2,
+      number=3, type=11, cpp_type=10, label=3,
+      has_default_value=False, default_value=[],
+      message_type=None, enum_type=None, containing_type=None,
+      is_extension=False, extension_scope=None,
+      options=None),
+  ],
+  extensions=[
+  ],
+  nested_types=[],
+  enum_types=[
+  ],
+  options=None,
+  is_extendable=False,
+  syntax='proto3',
+  extension_ranges=[],
+  oneofs=[
+  ],
+  serialized_start=6635,
+  serialized_end=6777,
+)
+
+_SESSION_SHARDSESSION.fields_by_name['target'].message_type = query__pb2._TARGET
+_SESSION_SHARDSESSION.containing_type = _SESSION
+_SESSION_SHARDGTID.containing_type = _SESSION
+_SESSION.fields_by_name['shard_sessions'].message_type = _SESSION_SHARDSESSION
+_SESSION.fields_by_name['shard_gtids'].message_type = _SESSION_SHARDGTID
+_EXECUTEREQUEST.fields_by_name['caller_id'].message_type = vtrpc__pb2._CALLERID
+_EXECUTEREQUEST.fields_by_name['session'].message_type = _SESSION
+_EXECUTEREQUEST.fields_by_name['query'].message_type = query__pb2._BOUNDQUERY
+_EXECUTEREQUEST.fields_by_name['tablet_type'].enum_type = topodata__pb2._TABLETTYPE
+_EXECUTERESPONSE.fields_by_name['error'].message_type = vtrpc__pb2._RPCERROR
+_EXECUTERESPONSE.fields_by_name['session'].message_type = _SESSION
+_EXECUTERESPONSE.fields_by_name['result'].message_type = query__pb2._QUERYRESULT
+_EXECUTESHARDSREQUEST.fields_by_name['caller_id'].message_type = vtrpc__pb2._CALLERID
+_EXECUTESHARDSREQUEST.fields_by_name['session'].message_type = _SESSION
+_EXECUTESHARDSREQUEST.fields_by_name['query'].message_type = query__pb2._BOUNDQUERY
+_EXECUTESHARDSREQUEST.fields_by_name['tablet_type'].enum_type = topodata__pb2._TABLETTYPE
+_EXECUTESHARDSRESPONSE.fields_by_name['error'].message_type = vtrpc__pb2._RPCERROR
+_EXECUTESHARDSRESPONSE.fields_by_name['session'].message_type = _SESSION
+_EXECUTESHARDSRESPONSE.fields_by_name['result'].message_type = query__pb2._QUERYRESULT
+_EXECUTEKEYSPACEIDSREQUEST.fields_by_name['caller_id'].message_type = vtrpc__pb2._CALLERID
+_EXECUTEKEYSPACEIDSREQUEST.fields_by_name['session'].message_type = _SESSION
+_EXECUTEKEYSPACEIDSREQUEST.fields_by_name['query'].message_type = query__pb2._BOUNDQUERY
+_EXECUTEKEYSPACEIDSREQUEST.fields_by_name['tablet_type'].enum_type = topodata__pb2._TABLETTYPE
+_EXECUTEKEYSPACEIDSRESPONSE.fields_by_name['error'].message_type = vtrpc__pb2._RPCERROR
+_EXECUTEKEYSPACEIDSRESPONSE.fields_by_name['session'].message_type = _SESSION
+_EXECUTEKEYSPACEIDSRESPONSE.fields_by_name['result'].message_type = query__pb2._QUERYRESULT
+_EXECUTEKEYRANGESREQUEST.fields_by_name['caller_id'].message_type = vtrpc__pb2._CALLERID
+_EXECUTEKEYRANGESREQUEST.fields_by_name['session'].message_type = _SESSION
+_EXECUTEKEYRANGESREQUEST.fields_by_name['query'].message_type = query__pb2._BOUNDQUERY
+_EXECUTEKEYRANGESREQUEST.fields_by_name['key_ranges'].message_type = topodata__pb2._KEYRANGE
+_EXECUTEKEYRANGESREQUEST.fields_by_name['tablet_type'].enum_type = topodata__pb2._TABLETTYPE
+_EXECUTEKEYRANGESRESPONSE.fields_by_name['error'].message_type = vtrpc__pb2._RPCERROR
+_EXECUTEKEYRANGESRESPONSE.fields_by_name['session'].message_type = _SESSION
+_EXECUTEKEYRANGESRESPONSE.fields_by_name['result'].message_type = query__pb2._QUERYRESULT
+_EXECUTEENTITYIDSREQUEST_ENTITYID.fields_by_name['type'].enum_type = query__pb2._TYPE
+_EXECUTEENTITYIDSREQUEST_ENTITYID.containing_type = _EXECUTEENTITYIDSREQUEST
+_EXECUTEENTITYIDSREQUEST.fields_by_name['caller_id'].message_type = vtrpc__pb2._CALLERID
+_EXECUTEENTITYIDSREQUEST.fields_by_name['session'].message_type = _SESSION
+_EXECUTEENTITYIDSREQUEST.fields_by_name['query'].message_type = query__pb2._BOUNDQUERY
+_EXECUTEENTITYIDSREQUEST.fields_by_name['entity_keyspace_ids'].message_type = _EXECUTEENTITYIDSREQUEST_ENTITYID
+_EXECUTEENTITYIDSREQUEST.fields_by_name['tablet_type'].enum_type = topodata__pb2._TABLETTYPE
+_EXECUTEENTITYIDSRESPONSE.fields_by_name['error'].message_type = vtrpc__pb2._RPCERROR
+_EXECUTEENTITYIDSRESPONSE.fields_by_name['session'].message_type = _SESSION
+_EXECUTEENTITYIDSRESPONSE.fields_by_name['result'].message_type = query__pb2._QUERYRESULT
+_BOUNDSHARDQUERY.fields_by_name['query'].message_type = query__pb2._BOUNDQUERY
+_EXECUTEBATCHSHARDSREQUEST.fields_by_name['caller_id'].message_type = vtrpc__pb2._CALLERID
+_EXECUTEBATCHSHARDSREQUEST.fields_by_name['session'].message_type = _SESSION
+_EXECUTEBATCHSHARDSREQUEST.fields_by_name['queries'].message_type = _BOUNDSHARDQUERY
+_EXECUTEBATCHSHARDSREQUEST.fields_by_name['tablet_type'].enum_type = topodata__pb2._TABLETTYPE
+_EXECUTEBATCHSHARDSRESPONSE.fields_by_name['error'].message_type = vtrpc__pb2._RPCERROR
+_EXECUTEBATCHSHARDSRESPONSE.fields_by_name['session'].message_type = _SESSION
+_EXECUTEBATCHSHARDSRESPONSE.fields_by_name['results'].message_type = query__pb2._QUERYRESULT
+_BOUNDKEYSPACEIDQUERY.fields_by_name['query'].message_type = query__pb2._BOUNDQUERY
+_EXECUTEBATCHKEYSPACEIDSREQUEST.fields_by_name['caller_id'].message_type = vtrpc__pb2._CALLERID
+_EXECUTEBATCHKEYSPACEIDSREQUEST.fields_by_name['session'].message_type = _SESSION
+_EXECUTEBATCHKEYSPACEIDSREQUEST.fields_by_name['queries'].message_type = _BOUNDKEYSPACEIDQUERY
+_EXECUTEBATCHKEYSPACEIDSREQUEST.fields_by_name['tablet_type'].enum_type = topodata__pb2._TABLETTYPE
+_EXECUTEBATCHKEYSPACEIDSRESPONSE.fields_by_name['error'].message_type = vtrpc__pb2._RPCERROR
+_EXECUTEBATCHKEYSPACEIDSRESPONSE.fields_by_name['session'].message_type = _SESSION
+_EXECUTEBATCHKEYSPACEIDSRESPONSE.fields_by_name['results'].message_type = query__pb2._QUERYRESULT
+_STREAMEXECUTEREQUEST.fields_by_name['caller_id'].message_type = vtrpc__pb2._CALLERID
+_STREAMEXECUTEREQUEST.fields_by_name['query'].message_type = query__pb2._BOUNDQUERY
+_STREAMEXECUTEREQUEST.fields_by_name['tablet_type'].enum_type = topodata__pb2._TABLETTYPE
+_STREAMEXECUTERESPONSE.fields_by_name['result'].message_type = query__pb2._QUERYRESULT
+_STREAMEXECUTESHARDSREQUEST.fields_by_name['caller_id'].message_type = vtrpc__pb2._CALLERID
+_STREAMEXECUTESHARDSREQUEST.fields_by_name['query'].message_type = query__pb2._BOUNDQUERY
+_STREAMEXECUTESHARDSREQUEST.fields_by_name['tablet_type'].enum_type = topodata__pb2._TABLETTYPE
+_STREAMEXECUTESHARDSRESPONSE.fields_by_name['result'].message_type = query__pb2._QUERYRESULT
+_STREAMEXECUTEKEYSPACEIDSREQUEST.fields_by_name['caller_id'].message_type = vtrpc__pb2._CALLERID
+_STREAMEXECUTEKEYSPACEIDSREQUEST.fields_by_name['query'].message_type = query__pb2._BOUNDQUERY
+_STREAMEXECUTEKEYSPACEIDSREQUEST.fields_by_name['tablet_type'].enum_type = topodata__pb2._TABLETTYPE
+_STREAMEXECUTEKEYSPACEIDSRESPONSE.fields_by_name['result'].message_type = query__pb2._QUERYRESULT
+_STREAMEXECUTEKEYRANGESREQUEST.fields_by_name['caller_id'].message_type = vtrpc__pb2._CALLERID
+_STREAMEXECUTEKEYRANGESREQUEST.fields_by_name['query'].message_type = query__pb2._BOUNDQUERY
+_STREAMEXECUTEKEYRANGESREQUEST.fields_by_name['key_ranges'].message_type = topodata__pb2._KEYRANGE
+_STREAMEXECUTEKEYRANGESREQUEST.fields_by_name['tablet_type'].enum_type = topodata__pb2._TABLETTYPE
+_STREAMEXECUTEKEYRANGESRESPONSE.fields_by_name['result'].message_type = query__pb2._QUERYRESULT
+_BEGINREQUEST.fields_by_name['caller_id'].message_type = vtrpc__pb2._CALLERID
+_BEGINRESPONSE.fields_by_name['session'].message_type = _SESSION
+_COMMITREQUEST.fields_by_name['caller_id'].message_type = vtrpc__pb2._CALLERID
+_COMMITREQUEST.fields_by_name['session'].message_type = _SESSION
+_COMMITRESPONSE.fields_by_name['session'].message_type = _SESSION
+_ROLLBACKREQUEST.fields_by_name['caller_id'].message_type = vtrpc__pb2._CALLERID
+_ROLLBACKREQUEST.fields_by_name['session'].message_type = _SESSION
+_SPLITQUERYREQUEST.fields_by_name['caller_id'].message_type = vtrpc__pb2._CALLERID
+_SPLITQUERYREQUEST.fields_by_name['query'].message_type = query__pb2._BOUNDQUERY
+_SPLITQUERYREQUEST.fields_by_name['algorithm'].enum_type = query__pb2._SPLITQUERYREQUEST_ALGORITHM
+_SPLITQUERYRESPONSE_KEYRANGEPART.fields_by_name['key_ranges'].message_type = topodata__pb2._KEYRANGE
+_SPLITQUERYRESPONSE_KEYRANGEPART.containing_type = _SPLITQUERYRESPONSE
+_SPLITQUERYRESPONSE_SHARDPART.containing_type = _SPLITQUERYRESPONSE
+_SPLITQUERYRESPONSE_PART.fields_by_name['query'].message_type = query__pb2._BOUNDQUERY
+_SPLITQUERYRESPONSE_PART.fields_by_name['key_range_part'].message_type = _SPLITQUERYRESPONSE_KEYRANGEPART
+_SPLITQUERYRESPONSE_PART.fields_by_name['shard_part'].message_type = _SPLITQUERYRESPONSE_SHARDPART
+_SPLITQUERYRESPONSE_PART.containing_type = _SPLITQUERYRESPONSE
+_SPLITQUERYRESPONSE.fields_by_name['splits'].message_type = _SPLITQUERYRESPONSE_PART
+_GETSRVKEYSPACERESPONSE.fields_by_name['srv_keyspace'].message_type = topodata__pb2._SRVKEYSPACE
+_PREPAREREQUEST.fields_by_name['caller_id'].message_type = vtrpc__pb2._CALLERID
+_PREPAREREQUEST.fields_by_name['tablet_type'].enum_type = topodata__pb2._TABLETTYPE
+_PREPARERESPONSE.fields_by_name['error'].message_type = vtrpc__pb2._RPCERROR
+_PREPARERESPONSE.fields_by_name['fields'].message_type = query__pb2._FIELD
+_EXECUTEPREPAREDREQUEST_BINDVARIABLESENTRY.fields_by_name['value'].message_type = query__pb2._BINDVARIABLE
+_EXECUTEPREPAREDREQUEST_BINDVARIABLESENTRY.containing_type = _EXECUTEPREPAREDREQUEST
+_EXECUTEPREPAREDREQUEST.fields_by_name['caller_id'].message_type = vtrpc__pb2._CALLERID
+_EXECUTEPREPAREDREQUEST.fields_by_name['session'].message_type = _SESSION
+_EXECUTEPREPAREDREQUEST.fields_by_name['bind_variables'].message_type = _EXECUTEPREPAREDREQUEST_BINDVARIABLESENTRY
+_EXECUTEPREPAREDRESPONSE.fields_by_name['error'].message_type = vtrpc__pb2._RPCERROR
+_EXECUTEPREPAREDRESPONSE.fields_by_name['session'].message_type = _SESSION
+_EXECUTEPREPAREDRESPONSE.fields_by_name['result'].message_type = query__pb2._QUERYRESULT
+_CLOSEPREPAREDREQUEST.fields_by_name['caller_id'].message_type = vtrpc__pb2._CALLERID
+_MULTIPLEXREQUEST.fields_by_name['execute'].message_type = _EXECUTEREQUEST
+_MULTIPLEXRESPONSE.fields_by_name['error'].message_type = vtrpc__pb2._RPCERROR
+_MULTIPLEXRESPONSE.fields_by_name['execute'].message_type = _EXECUTERESPONSE
+_GETKEYSPACESCHEMARESPONSE.fields_by_name['schema'].message_type = tabletmanagerdata__pb2._SCHEMADEFINITION
+_GETKEYSPACESCHEMARESPONSE.fields_by_name['drifts'].message_type = _SCHEMADRIFT
+DESCRIPTOR.message_types_by_name['Session'] = _SESSION
+DESCRIPTOR.message_types_by_name['ExecuteRequest'] = _EXECUTEREQUEST
+DESCRIPTOR.message_types_by_name['ExecuteResponse'] = _EXECUTERESPONSE
+DESCRIPTOR.message_types_by_name['ExecuteShardsRequest'] = _EXECUTESHARDSREQUEST
+DESCRIPTOR.message_types_by_name['ExecuteShardsResponse'] = _EXECUTESHARDSRESPONSE
+DESCRIPTOR.message_types_by_name['ExecuteKeyspaceIdsRequest'] = _EXECUTEKEYSPACEIDSREQUEST
+DESCRIPTOR.message_types_by_name['ExecuteKeyspaceIdsResponse'] = _EXECUTEKEYSPACEIDSRESPONSE
+DESCRIPTOR.message_types_by_name['ExecuteKeyRangesRequest'] = _EXECUTEKEYRANGESREQUEST
+DESCRIPTOR.message_types_by_name['ExecuteKeyRangesResponse'] = _EXECUTEKEYRANGESRESPONSE
+DESCRIPTOR.message_types_by_name['ExecuteEntityIdsRequest'] = _EXECUTEENTITYIDSREQUEST
+DESCRIPTOR.message_types_by_name['ExecuteEntityIdsResponse'] = _EXECUTEENTITYIDSRESPONSE
+DESCRIPTOR.message_types_by_name['BoundShardQuery'] = _BOUNDSHARDQUERY
+DESCRIPTOR.message_types_by_name['ExecuteBatchShardsRequest'] = _EXECUTEBATCHSHARDSREQUEST
+DESCRIPTOR.message_types_by_name['ExecuteBatchShardsResponse'] = _EXECUTEBATCHSHARDSRESPONSE
+DESCRIPTOR.message_types_by_name['BoundKeyspaceIdQuery'] = _BOUNDKEYSPACEIDQUERY
+DESCRIPTOR.message_types_by_name['ExecuteBatchKeyspaceIdsRequest'] = _EXECUTEBATCHKEYSPACEIDSREQUEST
+DESCRIPTOR.message_types_by_name['ExecuteBatchKeyspaceIdsResponse'] = _EXECUTEBATCHKEYSPACEIDSRESPONSE
+DESCRIPTOR.message_types_by_name['StreamExecuteRequest'] = _STREAMEXECUTEREQUEST
+DESCRIPTOR.message_types_by_name['StreamExecuteResponse'] = _STREAMEXECUTERESPONSE
+DESCRIPTOR.message_types_by_name['StreamExecuteShardsRequest'] = _STREAMEXECUTESHARDSREQUEST
+DESCRIPTOR.message_types_by_name['StreamExecuteShardsResponse'] = _STREAMEXECUTESHARDSRESPONSE
+DESCRIPTOR.message_types_by_name['StreamExecuteKeyspaceIdsRequest'] = _STREAMEXECUTEKEYSPACEIDSREQUEST
+DESCRIPTOR.message_types_by_name['StreamExecuteKeyspaceIdsResponse'] = _STREAMEXECUTEKEYSPACEIDSRESPONSE
+DESCRIPTOR.message_types_by_name['StreamExecuteKeyRangesRequest'] = _STREAMEXECUTEKEYRANGESREQUEST
+DESCRIPTOR.message_types_by_name['StreamExecuteKeyRangesResponse'] = _STREAMEXECUTEKEYRANGESRESPONSE
+DESCRIPTOR.message_types_by_name['BeginRequest'] = _BEGINREQUEST
+DESCRIPTOR.message_types_by_name['BeginResponse'] = _BEGINRESPONSE
+DESCRIPTOR.message_types_by_name['CommitRequest'] = _COMMITREQUEST
+DESCRIPTOR.message_types_by_name['CommitResponse'] = _COMMITRESPONSE
+DESCRIPTOR.message_types_by_name['RollbackRequest'] = _ROLLBACKREQUEST
+DESCRIPTOR.message_types_by_name['RollbackResponse'] = _ROLLBACKRESPONSE
+DESCRIPTOR.message_types_by_name['SplitQueryRequest'] = _SPLITQUERYREQUEST
+DESCRIPTOR.message_types_by_name['SplitQueryResponse'] = _SPLITQUERYRESPONSE
+DESCRIPTOR.message_types_by_name['GetSrvKeyspaceRequest'] = _GETSRVKEYSPACEREQUEST
+DESCRIPTOR.message_types_by_name['GetSrvKeyspaceResponse'] = _GETSRVKEYSPACERESPONSE
+DESCRIPTOR.message_types_by_name['PrepareRequest'] = _PREPAREREQUEST
+DESCRIPTOR.message_types_by_name['PrepareResponse'] = _PREPARERESPONSE
+DESCRIPTOR.message_types_by_name['ExecutePreparedRequest'] = _EXECUTEPREPAREDREQUEST
+DESCRIPTOR.message_types_by_name['ExecutePreparedResponse'] = _EXECUTEPREPAREDRESPONSE
+DESCRIPTOR.message_types_by_name['ClosePreparedRequest'] = _CLOSEPREPAREDREQUEST
+DESCRIPTOR.message_types_by_name['ClosePreparedResponse'] = _CLOSEPREPAREDRESPONSE
+DESCRIPTOR.message_types_by_name['MultiplexRequest'] = _MULTIPLEXREQUEST
+DESCRIPTOR.message_types_by_name['MultiplexResponse'] = _MULTIPLEXRESPONSE
+DESCRIPTOR.message_types_by_name['GetKeyspaceSchemaRequest'] = _GETKEYSPACESCHEMAREQUEST
+DESCRIPTOR.message_types_by_name['SchemaDrift'] = _SCHEMADRIFT
+DESCRIPTOR.message_types_by_name['GetKeyspaceSchemaResponse'] = _GETKEYSPACESCHEMARESPONSE
+
+Session = _reflection.GeneratedProtocolMessageType('Session', (_message.Message,), dict(
+
+  ShardSession = _reflection.GeneratedProtocolMessageType('ShardSession', (_message.Message,), dict(
+    DESCRIPTOR = _SESSION_SHARDSESSION,
+    __module__ = 'vtgate_pb2'
+    # @@protoc_insertion_point(class_scope:vtgate.Session.ShardSession)
+    ))
+  ,
+
+  ShardGtid = _reflection.GeneratedProtocolMessageType('ShardGtid', (_message.Message,), dict(
+    DESCRIPTOR = _SESSION_SHARDGTID,
+    __module__ = 'vtgate_pb2'
+    # @@protoc_insertion_point(class_scope:vtgate.Session.ShardGtid)
+    ))
+  ,
+  DESCRIPTOR = _SESSION,
+  __module__ = 'vtgate_pb2'
   # @@protoc_insertion_point(class_scope:vtgate.Session)
   ))
 _sym_db.RegisterMessage(Session)
 _sym_db.RegisterMessage(Session.ShardSession)
+_sym_db.RegisterMessage(Session.ShardGtid)
 
 ExecuteRequest = _reflection.GeneratedProtocolMessageType('ExecuteRequest', (_message.Message,), dict(
   DESCRIPTOR = _EXECUTEREQUEST,
@@ -2287,9 +2946,96 @@ GetSrvKeyspaceResponse = _reflection.GeneratedProtocolMessageType('GetSrvKeyspac
   ))
 _sym_db.RegisterMessage(GetSrvKeyspaceResponse)
 
+PrepareRequest = _reflection.GeneratedProtocolMessageType('PrepareRequest', (_message.Message,), dict(
+  DESCRIPTOR = _PREPAREREQUEST,
+  __module__ = 'vtgate_pb2'
+  # @@protoc_insertion_point(class_scope:vtgate.PrepareRequest)
+  ))
+_sym_db.RegisterMessage(PrepareRequest)
+
+PrepareResponse = _reflection.GeneratedProtocolMessageType('PrepareResponse', (_message.Message,), dict(
+  DESCRIPTOR = _PREPARERESPONSE,
+  __module__ = 'vtgate_pb2'
+  # @@protoc_insertion_point(class_scope:vtgate.PrepareResponse)
+  ))
+_sym_db.RegisterMessage(PrepareResponse)
+
+ExecutePreparedRequest = _reflection.GeneratedProtocolMessageType('ExecutePreparedRequest', (_message.Message,), dict(
+
+  BindVariablesEntry = _reflection.GeneratedProtocolMessageType('BindVariablesEntry', (_message.Message,), dict(
+    DESCRIPTOR = _EXECUTEPREPAREDREQUEST_BINDVARIABLESENTRY,
+    __module__ = 'vtgate_pb2'
+    # @@protoc_insertion_point(class_scope:vtgate.ExecutePreparedRequest.BindVariablesEntry)
+    ))
+  ,
+  DESCRIPTOR = _EXECUTEPREPAREDREQUEST,
+  __module__ = 'vtgate_pb2'
+  # @@protoc_insertion_point(class_scope:vtgate.ExecutePreparedRequest)
+  ))
+_sym_db.RegisterMessage(ExecutePreparedRequest)
+_sym_db.RegisterMessage(ExecutePreparedRequest.BindVariablesEntry)
+
+ExecutePreparedResponse = _reflection.GeneratedProtocolMessageType('ExecutePreparedResponse', (_message.Message,), dict(
+  DESCRIPTOR = _EXECUTEPREPAREDRESPONSE,
+  __module__ = 'vtgate_pb2'
+  # @@protoc_insertion_point(class_scope:vtgate.ExecutePreparedResponse)
+  ))
+_sym_db.RegisterMessage(ExecutePreparedResponse)
+
+ClosePreparedRequest = _reflection.GeneratedProtocolMessageType('ClosePreparedRequest', (_message.Message,), dict(
+  DESCRIPTOR = _CLOSEPREPAREDREQUEST,
+  __module__ = 'vtgate_pb2'
+  # @@protoc_insertion_point(class_scope:vtgate.ClosePreparedRequest)
+  ))
+_sym_db.RegisterMessage(ClosePreparedRequest)
+
+ClosePreparedResponse = _reflection.GeneratedProtocolMessageType('ClosePreparedResponse', (_message.Message,), dict(
+  DESCRIPTOR = _CLOSEPREPAREDRESPONSE,
+  __module__ = 'vtgate_pb2'
+  # @@protoc_insertion_point(class_scope:vtgate.ClosePreparedResponse)
+  ))
+_sym_db.RegisterMessage(ClosePreparedResponse)
+
+MultiplexRequest = _reflection.GeneratedProtocolMessageType('MultiplexRequest', (_message.Message,), dict(
+  DESCRIPTOR = _MULTIPLEXREQUEST,
+  __module__ = 'vtgate_pb2'
+  # @@protoc_insertion_point(class_scope:vtgate.MultiplexRequest)
+  ))
+_sym_db.RegisterMessage(MultiplexRequest)
+
+MultiplexResponse = _reflection.GeneratedProtocolMessageType('MultiplexResponse', (_message.Message,), dict(
+  DESCRIPTOR = _MULTIPLEXRESPONSE,
+  __module__ = 'vtgate_pb2'
+  # @@protoc_insertion_point(class_scope:vtgate.MultiplexResponse)
+  ))
+_sym_db.RegisterMessage(MultiplexResponse)
+
+GetKeyspaceSchemaRequest = _reflection.GeneratedProtocolMessageType('GetKeyspaceSchemaRequest', (_message.Message,), dict(
+  DESCRIPTOR = _GETKEYSPACESCHEMAREQUEST,
+  __module__ = 'vtgate_pb2'
+  # @@protoc_insertion_point(class_scope:vtgate.GetKeyspaceSchemaRequest)
+  ))
+_sym_db.RegisterMessage(GetKeyspaceSchemaRequest)
+
+SchemaDrift = _reflection.GeneratedProtocolMessageType('SchemaDrift', (_message.Message,), dict(
+  DESCRIPTOR = _SCHEMADRIFT,
+  __module__ = 'vtgate_pb2'
+  # @@protoc_insertion_point(class_scope:vtgate.SchemaDrift)
+  ))
+_sym_db.RegisterMessage(SchemaDrift)
+
+GetKeyspaceSchemaResponse = _reflection.GeneratedProtocolMessageType('GetKeyspaceSchemaResponse', (_message.Message,), dict(
+  DESCRIPTOR = _GETKEYSPACESCHEMARESPONSE,
+  __module__ = 'vtgate_pb2'
+  # @@protoc_insertion_point(class_scope:vtgate.GetKeyspaceSchemaResponse)
+  ))
+_sym_db.RegisterMessage(GetKeyspaceSchemaResponse)
+
 
 DESCRIPTOR.has_options = True
 DESCRIPTOR._options = _descriptor._ParseOptions(descriptor_pb2.FileOptions(), _b('\n\030com.youtube.vitess.proto'))
+_EXECUTEPREPAREDREQUEST_BINDVARIABLESENTRY.has_options = True
+_EXECUTEPREPAREDREQUEST_BINDVARIABLESENTRY._options = _descriptor._ParseOptions(descriptor_pb2.MessageOptions(), _b('8\001'))
 import abc
 from grpc.beta import implementations as beta_implementations
 from grpc.framework.common import cardinality
@@ -20,7 +20,7 @@ DESCRIPTOR = _descriptor.FileDescriptor(
   name='vtgateservice.proto',
   package='vtgateservice',
   syntax='proto3',
-  serialized_pb=_b('\n\x13vtgateservice.proto\x12\rvtgateservice\x1a\x0cvtgate.proto2\xd8\n\n\x06Vitess\x12<\n\x07\x45xecute\x12\x16.vtgate.ExecuteRequest\x1a\x17.vtgate.ExecuteResponse\"\x00\x12N\n\rExecuteShards\x12\x1c.vtgate.ExecuteShardsRequest\x1a\x1d.vtgate.ExecuteShardsResponse\"\x00\x12]\n\x12\x45xecuteKeyspaceIds\x12!.vtgate.ExecuteKeyspaceIdsRequest\x1a\".vtgate.ExecuteKeyspaceIdsResponse\"\x00\x12W\n\x10\x45xecuteKeyRanges\x12\x1f.vtgate.ExecuteKeyRangesRequest\x1a .vtgate.ExecuteKeyRangesResponse\"\x00\x12W\n\x10\x45xecuteEntityIds\x12\x1f.vtgate.ExecuteEntityIdsRequest\x1a .vtgate.ExecuteEntityIdsResponse\"\x00\x12]\n\x12\x45xecuteBatchShards\x12!.vtgate.ExecuteBatchShardsRequest\x1a\".vtgate.ExecuteBatchShardsResponse\"\x00\x12l\n\x17\x45xecuteBatchKeyspaceIds\x12&.vtgate.ExecuteBatchKeyspaceIdsRequest\x1a\'.vtgate.ExecuteBatchKeyspaceIdsResponse\"\x00\x12P\n\rStreamExecute\x12\x1c.vtgate.StreamExecuteRequest\x1a\x1d.vtgate.StreamExecuteResponse\"\x00\x30\x01\x12\x62\n\x13StreamExecuteShards\x12\".vtgate.StreamExecuteShardsRequest\x1a#.vtgate.StreamExecuteShardsResponse\"\x00\x30\x01\x12q\n\x18StreamExecuteKeyspaceIds\x12\'.vtgate.StreamExecuteKeyspaceIdsRequest\x1a(.vtgate.StreamExecuteKeyspaceIdsResponse\"\x00\x30\x01\x12k\n\x16StreamExecuteKeyRanges\x12%.vtgate.StreamExecuteKeyRangesRequest\x1a&.vtgate.StreamExecuteKeyRangesResponse\"\x00\x30\x01\x12\x36\n\x05\x42\x65gin\x12\x14.vtgate.BeginRequest\x1a\x15.vtgate.BeginResponse\"\x00\x12\x39\n\x06\x43ommit\x12\x15.vtgate.CommitRequest\x1a\x16.vtgate.CommitResponse\"\x00\x12?\n\x08Rollback\x12\x17.vtgate.RollbackRequest\x1a\x18.vtgate.RollbackResponse\"\x00\x12\x45\n\nSplitQuery\x12\x19.vtgate.SplitQueryRequest\x1a\x1a.vtgate.SplitQueryResponse\"\x00\x12Q\n\x0eGetSrvKeyspace\x12\x1d.vtgate.GetSrvKeyspaceRequest\x1a\x1e.vtgate.GetSrvKeyspaceResponse\"\x00\x42\x1f\n\x1d\x63om.youtube.vitess.proto.grpcb\x06proto3')
+  serialized_pb=_b('\n\x13vtgateservice.proto\x12\rvtgateservice\x1a\x0cvtgate.proto2\x98\r\n\x06Vitess\x12<\n\x07\x45xecute\x12\x16.vtgate.ExecuteRequest\x1a\x17.vtgate.ExecuteResponse\"\x00\x12N\n\rExecuteShards\x12\x1c.vtgate.ExecuteShardsRequest\x1a\x1d.vtgate.ExecuteShardsResponse\"\x00\x12]\n\x12\x45xecuteKeyspaceIds\x12!.vtgate.ExecuteKeyspaceIdsRequest\x1a\".vtgate.ExecuteKeyspaceIdsResponse\"\x00\x12W\n\x10\x45xecuteKeyRanges\x12\x1f.vtgate.ExecuteKeyRangesRequest\x1a .vtgate.ExecuteKeyRangesResponse\"\x00\x12W\n\x10\x45xecuteEntityIds\x12\x1f.vtgate.ExecuteEntityIdsRequest\x1a .vtgate.ExecuteEntityIdsResponse\"\x00\x12]\n\x12\x45xecuteBatchShards\x12!.vtgate.ExecuteBatchShardsRequest\x1a\".vtgate.ExecuteBatchShardsResponse\"\x00\x12l\n\x17\x45xecuteBatchKeyspaceIds\x12&.vtgate.ExecuteBatchKeyspaceIdsRequest\x1a\'.vtgate.ExecuteBatchKeyspaceIdsResponse\"\x00\x12P\n\rStreamExecute\x12\x1c.vtgate.StreamExecuteRequest\x1a\x1d.vtgate.StreamExecuteResponse\"\x00\x30\x01\x12\x62\n\x13StreamExecuteShards\x12\".vtgate.StreamExecuteShardsRequest\x1a#.vtgate.StreamExecuteShardsResponse\"\x00\x30\x01\x12q\n\x18StreamExecuteKeyspaceIds\x12\'.vtgate.StreamExecuteKeyspaceIdsRequest\x1a(.vtgate.StreamExecuteKeyspaceIdsResponse\"\x00\x30\x01\x12k\n\x16StreamExecuteKeyRanges\x12%.vtgate.StreamExecuteKeyRangesRequest\x1a&.vtgate.StreamExecuteKeyRangesResponse\"\x00\x30\x01\x12\x36\n\x05\x42\x65gin\x12\x14.vtgate.BeginRequest\x1a\x15.vtgate.BeginResponse\"\x00\x12\x39\n\x06\x43ommit\x12\x15.vtgate.CommitRequest\x1a\x16.vtgate.CommitResponse\"\x00\x12?\n\x08Rollback\x12\x17.vtgate.RollbackRequest\x1a\x18.vtgate.RollbackResponse\"\x00\x12\x45\n\nSplitQuery\x12\x19.vtgate.SplitQueryRequest\x1a\x1a.vtgate.SplitQueryResponse\"\x00\x12Q\n\x0eGetSrvKeyspace\x12\x1d.vtgate.GetSrvKeyspaceRequest\x1a\x1e.vtgate.GetSrvKeyspaceResponse\"\x00\x12<\n\x07Prepare\x12\x16.vtgate.PrepareRequest\x1a\x17.vtgate.PrepareResponse\"\x00\x12T\n\x0f\x45xecutePrepared\x12\x1e.vtgate.ExecutePreparedRequest\x1a\x1f.vtgate.ExecutePreparedResponse\"\x00\x12N\n\rClosePrepared\x12\x1c.vtgate.ClosePreparedRequest\x1a\x1d.vtgate.ClosePreparedResponse\"\x00\x12Z\n\x11GetKeyspaceSchema\x12 .vtgate.GetKeyspaceSchemaRequest\x1a!.vtgate.GetKeyspaceSchemaResponse\"\x00\x32\\\n\x12RequestMultiplexer\x12\x46\n\tMultiplex\x12\x18.vtgate.MultiplexRequest\x1a\x19.vtgate.MultiplexResponse\"\x00(\x01\x30\x01\x42\x1f\n\x1d\x63om.youtube.vitess.proto.grpcb\x06proto3')
   ,
   dependencies=[vtgate__pb2.DESCRIPTOR,])
 _sym_db.RegisterFileDescriptor(DESCRIPTOR)
@@ -87,6 +87,18 @@ class BetaVitessServicer(object):
   @abc.abstractmethod
   def GetSrvKeyspace(self, request, context):
     raise NotImplementedError()
+  @abc.abstractmethod
+  def Prepare(self, request, context):
+    raise NotImplementedError()
+  @abc.abstractmethod
+  def ExecutePrepared(self, request, context):
+    raise NotImplementedError()
+  @abc.abstractmethod
+  def ClosePrepared(self, request, context):
+    raise NotImplementedError()
+  @abc.abstractmethod
+  def GetKeyspaceSchema(self, request, context):
+    raise NotImplementedError()
 
 class BetaVitessStub(object):
   """The interface to which stubs will conform."""
@@ -151,6 +163,22 @@ class BetaVitessStub(object):
   def GetSrvKeyspace(self, request, timeout):
     raise NotImplementedError()
   GetSrvKeyspace.future = None
+  @abc.abstractmethod
+  def Prepare(self, request, timeout):
+    raise NotImplementedError()
+  Prepare.future = None
+  @abc.abstractmethod
+  def ExecutePrepared(self, request, timeout):
+    raise NotImplementedError()
+  ExecutePrepared.future = None
+  @abc.abstractmethod
+  def ClosePrepared(self, request, timeout):
+    raise NotImplementedError()
+  ClosePrepared.future = None
+  @abc.abstractmethod
+  def GetKeyspaceSchema(self, request, timeout):
+    raise NotImplementedError()
+  GetKeyspaceSchema.future = None
 
 def beta_create_Vitess_server(servicer, pool=None, pool_size=None, default_timeout=None, maximum_timeout=None):
   import vtgate_pb2
@@ -185,8 +213,17 @@ def beta_create_Vitess_server(servicer, pool=None, pool_size=None, default_timeo
   import vtgate_pb2
   import vtgate_pb2
   import vtgate_pb2
+  import vtgate_pb2
+  import vtgate_pb2
+  import vtgate_pb2
+  import vtgate_pb2
+  import vtgate_pb2
+  import vtgate_pb2
+  import vtgate_pb2
+  import vtgate_pb2
   request_deserializers = {
     ('vtgateservice.Vitess', 'Begin'): vtgate_pb2.BeginRequest.FromString,
+    ('vtgateservice.Vitess', 'ClosePrepared'): vtgate_pb2.ClosePreparedRequest.FromString,
     ('vtgateservice.Vitess', 'Commit'): vtgate_pb2.CommitRequest.FromString,
     ('vtgateservice.Vitess', 'Execute'): vtgate_pb2.ExecuteRequest.FromString,
     ('vtgateservice.Vitess', 'ExecuteBatchKeyspaceIds'): vtgate_pb2.ExecuteBatchKeyspaceIdsRequest.FromString,
@@ -194,8 +231,11 @@ def beta_create_Vitess_server(servicer, pool=None, pool_size=None, default_timeo
     ('vtgateservice.Vitess', 'ExecuteEntityIds'): vtgate_pb2.ExecuteEntityIdsRequest.FromString,
     ('vtgateservice.Vitess', 'ExecuteKeyRanges'): vtgate_pb2.ExecuteKeyRangesRequest.FromString,
     ('vtgateservice.Vitess', 'ExecuteKeyspaceIds'): vtgate_pb2.ExecuteKeyspaceIdsRequest.FromString,
+    ('vtgateservice.Vitess', 'ExecutePrepared'): vtgate_pb2.ExecutePreparedRequest.FromString,
     ('vtgateservice.Vitess', 'ExecuteShards'): vtgate_pb2.ExecuteShardsRequest.FromString,
+    ('vtgateservice.Vitess', 'GetKeyspaceSchema'): vtgate_pb2.GetKeyspaceSchemaRequest.FromString,
     ('vtgateservice.Vitess', 'GetSrvKeyspace'): vtgate_pb2.GetSrvKeyspaceRequest.FromString,
+    ('vtgateservice.Vitess', 'Prepare'): vtgate_pb2.PrepareRequest.FromString,
     ('vtgateservice.Vitess', 'Rollback'): vtgate_pb2.RollbackRequest.FromString,
     ('vtgateservice.Vitess', 'SplitQuery'): vtgate_pb2.SplitQueryRequest.FromString,
     ('vtgateservice.Vitess', 'StreamExecute'): vtgate_pb2.StreamExecuteRequest.FromString,
@@ -205,6 +245,7 @@ def beta_create_Vitess_server(servicer, pool=None, pool_size=None, default_timeo
   }
   response_serializers = {
     ('vtgateservice.Vitess', 'Begin'): vtgate_pb2.BeginResponse.SerializeToString,
+    ('vtgateservice.Vitess', 'ClosePrepared'): vtgate_pb2.ClosePreparedResponse.SerializeToString,
     ('vtgateservice.Vitess', 'Commit'): vtgate_pb2.CommitResponse.SerializeToString,
     ('vtgateservice.Vitess', 'Execute'): vtgate_pb2.ExecuteResponse.SerializeToString,
     ('vtgateservice.Vitess', 'ExecuteBatchKeyspaceIds'): vtgate_pb2.ExecuteBatchKeyspaceIdsResponse.SerializeToString,
@@ -212,8 +253,11 @@ def beta_create_Vitess_server(servicer, pool=None, pool_size=None, default_timeo
     ('vtgateservice.Vitess', 'ExecuteEntityIds'): vtgate_pb2.ExecuteEntityIdsResponse.SerializeToString,
     ('vtgateservice.Vitess', 'ExecuteKeyRanges'): vtgate_pb2.ExecuteKeyRangesResponse.SerializeToString,
     ('vtgateservice.Vitess', 'ExecuteKeyspaceIds'): vtgate_pb2.ExecuteKeyspaceIdsResponse.SerializeToString,
+    ('vtgateservice.Vitess', 'ExecutePrepared'): vtgate_pb2.ExecutePreparedResponse.SerializeToString,
     ('vtgateservice.Vitess', 'ExecuteShards'): vtgate_pb2.ExecuteShardsResponse.SerializeToString,
+    ('vtgateservice.Vitess', 'GetKeyspaceSchema'): vtgate_pb2.GetKeyspaceSchemaResponse.SerializeToString,
     ('vtgateservice.Vitess', 'GetSrvKeyspace'): vtgate_pb2.GetSrvKeyspaceResponse.SerializeToString,
+    ('vtgateservice.Vitess', 'Prepare'): vtgate_pb2.PrepareResponse.SerializeToString,
     ('vtgateservice.Vitess', 'Rollback'): vtgate_pb2.RollbackResponse.SerializeToString,
     ('vtgateservice.Vitess', 'SplitQuery'): vtgate_pb2.SplitQueryResponse.SerializeToString,
     ('vtgateservice.Vitess', 'StreamExecute'): vtgate_pb2.StreamExecuteResponse.SerializeToString,
@@ -223,6 +267,7 @@ def beta_create_Vitess_server(servicer, pool=None, pool_size=None, default_timeo
   }
   method_implementations = {
     ('vtgateservice.Vitess', 'Begin'): face_utilities.unary_unary_inline(servicer.Begin),
+    ('vtgateservice.Vitess', 'ClosePrepared'): face_utilities.unary_unary_inline(servicer.ClosePrepared),
     ('vtgateservice.Vitess', 'Commit'): face_utilities.unary_unary_inline(servicer.Commit),
     ('vtgateservice.Vitess', 'Execute'): face_utilities.unary_unary_inline(servicer.Execute),
     ('vtgateservice.Vitess', 'ExecuteBatchKeyspaceIds'): face_utilities.unary_unary_inline(servicer.ExecuteBatchKeyspaceIds),
@@ -230,8 +275,11 @@ def beta_create_Vitess_server(servicer, pool=None, pool_size=None, default_timeo
     ('vtgateservice.Vitess', 'ExecuteEntityIds'): face_utilities.unary_unary_inline(servicer.ExecuteEntityIds),
     ('vtgateservice.Vitess', 'ExecuteKeyRanges'): face_utilities.unary_unary_inline(servicer.ExecuteKeyRanges),
     ('vtgateservice.Vitess', 'ExecuteKeyspaceIds'): face_utilities.unary_unary_inline(servicer.ExecuteKeyspaceIds),
+    ('vtgateservice.Vitess', 'ExecutePrepared'): face_utilities.unary_unary_inline(servicer.ExecutePrepared),
     ('vtgateservice.Vitess', 'ExecuteShards'): face_utilities.unary_unary_inline(servicer.ExecuteShards),
+    ('vtgateservice.Vitess', 'GetKeyspaceSchema'): face_utilities.unary_unary_inline(servicer.GetKeyspaceSchema),
     ('vtgateservice.Vitess', 'GetSrvKeyspace'): face_utilities.unary_unary_inline(servicer.GetSrvKeyspace),
+    ('vtgateservice.Vitess', 'Prepare'): face_utilities.unary_unary_inline(servicer.Prepare),
     ('vtgateservice.Vitess', 'Rollback'): face_utilities.unary_unary_inline(servicer.Rollback),
     ('vtgateservice.Vitess', 'SplitQuery'): face_utilities.unary_unary_inline(servicer.SplitQuery),
     ('vtgateservice.Vitess', 'StreamExecute'): face_utilities.unary_stream_inline(servicer.StreamExecute),
@@ -275,8 +323,17 @@ def beta_create_Vitess_stub(channel, host=None, metadata_transformer=None, pool=
   import vtgate_pb2
   import vtgate_pb2
   import vtgate_pb2
+  import vtgate_pb2
+  import vtgate_pb2
+  import vtgate_pb2
+  import vtgate_pb2
+  import vtgate_pb2
+  import vtgate_pb2
+  import vtgate_pb2
+  import vtgate_pb2
   request_serializers = {
     ('vtgateservice.Vitess', 'Begin'): vtgate_pb2.BeginRequest.SerializeToString,
+    ('vtgateservice.Vitess', 'ClosePrepared'): vtgate_pb2.ClosePreparedRequest.SerializeToString,
     ('vtgateservice.Vitess', 'Commit'): vtgate_pb2.CommitRequest.SerializeToString,
     ('vtgateservice.Vitess', 'Execute'): vtgate_pb2.ExecuteRequest.SerializeToString,
     ('vtgateservice.Vitess', 'ExecuteBatchKeyspaceIds'): vtgate_pb2.ExecuteBatchKeyspaceIdsRequest.SerializeToString,
@@ -284,8 +341,11 @@ def beta_create_Vitess_stub(channel, host=None, metadata_transformer=None, pool=
     ('vtgateservice.Vitess', 'ExecuteEntityIds'): vtgate_pb2.ExecuteEntityIdsRequest.SerializeToString,
     ('vtgateservice.Vitess', 'ExecuteKeyRanges'): vtgate_pb2.ExecuteKeyRangesRequest.SerializeToString,
     ('vtgateservice.Vitess', 'ExecuteKeyspaceIds'): vtgate_pb2.ExecuteKeyspaceIdsRequest.SerializeToString,
+    ('vtgateservice.Vitess', 'ExecutePrepared'): vtgate_pb2.ExecutePreparedRequest.SerializeToString,
     ('vtgateservice.Vitess', 'ExecuteShards'): vtgate_pb2.ExecuteShardsRequest.SerializeToString,
+    ('vtgateservice.Vitess', 'GetKeyspaceSchema'): vtgate_pb2.GetKeyspaceSchemaRequest.SerializeToString,
     ('vtgateservice.Vitess', 'GetSrvKeyspace'): vtgate_pb2.GetSrvKeyspaceRequest.SerializeToString,
+    ('vtgateservice.Vitess', 'Prepare'): vtgate_pb2.PrepareRequest.SerializeToString,
     ('vtgateservice.Vitess', 'Rollback'): vtgate_pb2.RollbackRequest.SerializeToString,
     ('vtgateservice.Vitess', 'SplitQuery'): vtgate_pb2.SplitQueryRequest.SerializeToString,
     ('vtgateservice.Vitess', 'StreamExecute'): vtgate_pb2.StreamExecuteRequest.SerializeToString,
@@ -295,6 +355,7 @@ def beta_create_Vitess_stub(channel, host=None, metadata_transformer=None, pool=
   }
   response_deserializers = {
     ('vtgateservice.Vitess', 'Begin'): vtgate_pb2.BeginResponse.FromString,
+    ('vtgateservice.Vitess', 'ClosePrepared'): vtgate_pb2.ClosePreparedResponse.FromString,
     ('vtgateservice.Vitess', 'Commit'): vtgate_pb2.CommitResponse.FromString,
     ('vtgateservice.Vitess', 'Execute'): vtgate_pb2.ExecuteResponse.FromString,
     ('vtgateservice.Vitess', 'ExecuteBatchKeyspaceIds'): vtgate_pb2.ExecuteBatchKeyspaceIdsResponse.FromString,
@@ -302,8 +363,11 @@ def beta_create_Vitess_stub(channel, host=None, metadata_transformer=None, pool=
     ('vtgateservice.Vitess', 'ExecuteEntityIds'): vtgate_pb2.ExecuteEntityIdsResponse.FromString,
     ('vtgateservice.Vitess', 'ExecuteKeyRanges'): vtgate_pb2.ExecuteKeyRangesResponse.FromString,
     ('vtgateservice.Vitess', 'ExecuteKeyspaceIds'): vtgate_pb2.ExecuteKeyspaceIdsResponse.FromString,
+    ('vtgateservice.Vitess', 'ExecutePrepared'): vtgate_pb2.ExecutePreparedResponse.FromString,
     ('vtgateservice.Vitess', 'ExecuteShards'): vtgate_pb2.ExecuteShardsResponse.FromString,
+    ('vtgateservice.Vitess', 'GetKeyspaceSchema'): vtgate_pb2.GetKeyspaceSchemaResponse.FromString,
     ('vtgateservice.Vitess', 'GetSrvKeyspace'): vtgate_pb2.GetSrvKeyspaceResponse.FromString,
+    ('vtgateservice.Vitess', 'Prepare'): vtgate_pb2.PrepareResponse.FromString,
     ('vtgateservice.Vitess', 'Rollback'): vtgate_pb2.RollbackResponse.FromString,
     ('vtgateservice.Vitess', 'SplitQuery'): vtgate_pb2.SplitQueryResponse.FromString,
     ('vtgateservice.Vitess', 'StreamExecute'): vtgate_pb2.StreamExecuteResponse.FromString,
@@ -313,6 +377,7 @@ def beta_create_Vitess_stub(channel, host=None, metadata_transformer=None, pool=
   }
   cardinalities = {
     'Begin': cardinality.Cardinality.UNARY_UNARY,
+    'ClosePrepared': cardinality.Cardinality.UNARY_UNARY,
     'Commit': cardinality.Cardinality.UNARY_UNARY,
     'Execute': cardinality.Cardinality.UNARY_UNARY,
     'ExecuteBatchKeyspaceIds': cardinality.Cardinality.UNARY_UNARY,
@@ -320,8 +385,11 @@ def beta_create_Vitess_stub(channel, host=None, metadata_transformer=None, pool=
     'ExecuteEntityIds': cardinality.Cardinality.UNARY_UNARY,
     'ExecuteKeyRanges': cardinality.Cardinality.UNARY_UNARY,
     'ExecuteKeyspaceIds': cardinality.Cardinality.UNARY_UNARY,
+    'ExecutePrepared': cardinality.Cardinality.UNARY_UNARY,
     'ExecuteShards': cardinality.Cardinality.UNARY_UNARY,
+    'GetKeyspaceSchema': cardinality.Cardinality.UNARY_UNARY,
     'GetSrvKeyspace': cardinality.Cardinality.UNARY_UNARY,
+    'Prepare': cardinality.Cardinality.UNARY_UNARY,
     'Rollback': cardinality.Cardinality.UNARY_UNARY,
     'SplitQuery': cardinality.Cardinality.UNARY_UNARY,
     'StreamExecute': cardinality.Cardinality.UNARY_STREAM,
@@ -331,4 +399,48 @@ def beta_create_Vitess_stub(channel, host=None, metadata_transformer=None, pool=
   }
   stub_options = beta_implementations.stub_options(host=host, metadata_transformer=metadata_transformer, request_serializers=request_serializers, response_deserializers=response_deserializers, thread_pool=pool, thread_pool_size=pool_size)
   return beta_implementations.dynamic_stub(channel, 'vtgateservice.Vitess', cardinalities, options=stub_options)
+
+class BetaRequestMultiplexerServicer(object):
+  """<fill me in later!>"""
+  __metaclass__ = abc.ABCMeta
+  @abc.abstractmethod
+  def Multiplex(self, request_iterator, context):
+    raise NotImplementedError()
+
+class BetaRequestMultiplexerStub(object):
+  """The interface to which stubs will conform."""
+  __metaclass__ = abc.ABCMeta
+  @abc.abstractmethod
+  def Multiplex(self, request_iterator, timeout):
+    raise NotImplementedError()
+
+def beta_create_RequestMultiplexer_server(servicer, pool=None, pool_size=None, default_timeout=None, maximum_timeout=None):
+  import vtgate_pb2
+  import vtgate_pb2
+  request_deserializers = {
+    ('vtgateservice.RequestMultiplexer', 'Multiplex'): vtgate_pb2.MultiplexRequest.FromString,
+  }
+  response_serializers = {
+    ('vtgateservice.RequestMultiplexer', 'Multiplex'): vtgate_pb2.MultiplexResponse.SerializeToString,
+  }
+  method_implementations = {
+    ('vtgateservice.RequestMultiplexer', 'Multiplex'): face_utilities.stream_stream_inline(servicer.Multiplex),
+  }
+  server_options = beta_implementations.server_options(request_deserializers=request_deserializers, response_serializers=response_serializers, thread_pool=pool, thread_pool_size=pool_size, default_timeout=default_timeout, maximum_timeout=maximum_timeout)
+  return beta_implementations.server(method_implementations, options=server_options)
+
+def beta_create_RequestMultiplexer_stub(channel, host=None, metadata_transformer=None, pool=None, pool_size=None):
+  import vtgate_pb2
+  import vtgate_pb2
+  request_serializers = {
+    ('vtgateservice.RequestMultiplexer', 'Multiplex'): vtgate_pb2.MultiplexRequest.SerializeToString,
+  }
+  response_deserializers = {
+    ('vtgateservice.RequestMultiplexer', 'Multiplex'): vtgate_pb2.MultiplexResponse.FromString,
+  }
+  cardinalities = {
+    'Multiplex': cardinality.Cardinality.STREAM_STREAM,
+  }
+  stub_options = beta_implementations.stub_options(host=host, metadata_transformer=metadata_transformer, request_serializers=request_serializers, response_deserializers=response_deserializers, thread_pool=pool, thread_pool_size=pool_size)
+  return beta_implementations.dynamic_stub(channel, 'vtgateservice.RequestMultiplexer', cardinalities, options=stub_options)
 # @@protoc_insertion_point(module_scope)